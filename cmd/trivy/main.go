@@ -19,6 +19,9 @@ func main() {
 	if err := run(); err != nil {
 		var exitError *types.ExitError
 		if errors.As(err, &exitError) {
+			if exitError.Err != nil {
+				log.Error("Fatal error", log.Err(exitError.Err))
+			}
 			os.Exit(exitError.Code)
 		}
 
@@ -17,6 +17,7 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
 
 	"github.com/aquasecurity/trivy/pkg/report"
 )
@@ -584,6 +585,35 @@ func TestClientServerWithRedis(t *testing.T) {
 	})
 }
 
+func TestClientServerWithPostgres(t *testing.T) {
+	// Set up a PostgreSQL container
+	ctx := context.Background()
+	postgresC, addr := setupPostgres(t, ctx)
+	t.Cleanup(func() { _ = testcontainers.TerminateContainer(postgresC) })
+
+	// Set up Trivy server
+	addr, cacheDir := setup(t, setupOptions{cacheBackend: addr})
+	t.Cleanup(func() { os.RemoveAll(cacheDir) })
+
+	// Test parameters
+	testArgs := csArgs{
+		Input: "testdata/fixtures/images/alpine-39.tar.gz",
+	}
+	golden := "testdata/alpine-39.json.golden"
+
+	// The second scan uses the cached analysis results
+	for _, name := range []string{"alpine 3.9", "alpine 3.9 cached"} {
+		t.Run(name, func(t *testing.T) {
+			osArgs := setupClient(t, testArgs, addr, cacheDir)
+
+			// Run Trivy client
+			runTest(t, osArgs, golden, "", types.FormatJSON, runOptions{
+				override: overrideUID,
+			})
+		})
+	}
+}
+
 type setupOptions struct {
 	token        string
 	tokenHeader  string
@@ -733,3 +763,40 @@ func setupRedis(t *testing.T, ctx context.Context) (testcontainers.Container, st
 	addr := fmt.Sprintf("redis://%s:%s", ip, p.Port())
 	return redis, addr
 }
+
+func setupPostgres(t *testing.T, ctx context.Context) (testcontainers.Container, string) {
+	t.Setenv("TESTCONTAINERS_RYUK_DISABLED", "true")
+	t.Helper()
+	imageName := "postgres:16-alpine"
+	port := "5432/tcp"
+	req := testcontainers.ContainerRequest{
+		Name:         "postgres",
+		Image:        imageName,
+		ExposedPorts: []string{port},
+		Env: map[string]string{
+			"POSTGRES_USER":     "trivy",
+			"POSTGRES_PASSWORD": "trivy",
+			"POSTGRES_DB":       "trivy",
+		},
+		// PostgreSQL restarts once after the initialization
+		WaitingFor: wait.ForLog("database system is ready to accept connections").WithOccurrence(2),
+		HostConfigModifier: func(hostConfig *dockercontainer.HostConfig) {
+			hostConfig.AutoRemove = true
+		},
+	}
+
+	postgres, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err)
+
+	ip, err := postgres.Host(ctx)
+	require.NoError(t, err)
+
+	p, err := postgres.MappedPort(ctx, nat.Port(port))
+	require.NoError(t, err)
+
+	addr := fmt.Sprintf("postgres://trivy:trivy@%s:%s/trivy?sslmode=disable", ip, p.Port())
+	return postgres, addr
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/commands"
 	"github.com/aquasecurity/trivy/pkg/flag"
 	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/result"
 )
 
 const (
@@ -51,6 +52,14 @@ func generateConfigDocs(filename string) error {
 	remoteFlags := flag.NewClientFlags()
 	remoteFlags.Listen = flag.ServerListenFlag.Clone()
 
+	// NewReportFlagGroup doesn't initialize `EarlyResults` field
+	reportFlags := flag.NewReportFlagGroup()
+	reportFlags.EarlyResults = flag.EarlyResultsFlag.Clone()
+
+	// NewScanFlagGroup doesn't initialize `Resume` field
+	scanFlags := flag.NewScanFlagGroup()
+	scanFlags.Resume = flag.ResumeFlag.Clone()
+
 	// These flags don't work from config file.
 	// Clear configName to skip them later.
 	globalFlags := flag.NewGlobalFlagGroup()
@@ -60,22 +69,27 @@ func generateConfigDocs(filename string) error {
 
 	var allFlagGroups = []flag.FlagGroup{
 		globalFlags,
+		flag.NewBrowseFlagGroup(),
 		flag.NewCacheFlagGroup(),
 		flag.NewCleanFlagGroup(),
 		remoteFlags,
 		flag.NewDBFlagGroup(),
+		flag.NewDBMirrorFlagGroup(),
 		flag.NewImageFlagGroup(),
+		flag.NewJavaDBFlagGroup(),
 		flag.NewK8sFlagGroup(),
 		flag.NewLicenseFlagGroup(),
 		flag.NewMisconfFlagGroup(),
 		flag.NewModuleFlagGroup(),
+		flag.NewMonitorFlagGroup(),
 		flag.NewPackageFlagGroup(),
 		flag.NewRegistryFlagGroup(),
 		flag.NewRegoFlagGroup(),
-		flag.NewReportFlagGroup(),
+		reportFlags,
 		flag.NewRepoFlagGroup(),
-		flag.NewScanFlagGroup(),
+		scanFlags,
 		flag.NewSecretFlagGroup(),
+		flag.NewVerifyReportFlagGroup(),
 		flag.NewVulnerabilityFlagGroup(),
 	}
 
@@ -155,6 +169,9 @@ func writeFlagValue(val any, ind string, w *os.File) {
 				fmt.Fprintf(w, "  %s - %s\n", ind, vvv)
 			}
 		}
+	case result.CheckOverrides:
+		// Overrides are empty by default
+		w.WriteString("\n")
 	case string:
 		fmt.Fprintf(w, " %q\n", v)
 	default:
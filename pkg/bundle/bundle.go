@@ -0,0 +1,206 @@
+package bundle
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/aquasecurity/trivy/pkg/db"
+	"github.com/aquasecurity/trivy/pkg/javadb"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/archive"
+)
+
+// checksumFileName has SHA-256 digests of all the other files in the format of "sha256sum",
+// so that bundles can also be verified with "sha256sum -c".
+const checksumFileName = "SHA256SUMS"
+
+// component is a database stored in the bundle with the same path as in the cache directory
+type component struct {
+	name string
+	dir  string // Relative path in the cache directory, using slashes
+}
+
+var components = []component{
+	{
+		name: "vulnerability DB",
+		dir:  "db",
+	},
+	{
+		name: "Java DB",
+		dir:  "java-db",
+	},
+	{
+		name: "checks bundle",
+		dir:  "policy",
+	},
+	{
+		name: "VEX repositories",
+		dir:  "vex/repositories",
+	},
+}
+
+// Export writes the databases in the cache directory to a gzipped tarball,
+// which can be installed into the cache directory of air-gapped environments with Import.
+// The vulnerability DB is required, and the other databases are included if they exist in the cache directory.
+func Export(ctx context.Context, cacheDir, archivePath string) error {
+	if err := verifyDBs(ctx, cacheDir); err != nil {
+		return xerrors.Errorf("unable to verify the databases: %w", err)
+	}
+
+	files := make(map[string]string) // Name in the archive => path
+	for _, c := range components {
+		dir := filepath.Join(cacheDir, filepath.FromSlash(c.dir))
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			log.InfoContext(ctx, "Skipping the database not found in the cache", log.String("db", c.name))
+			continue
+		} else if err != nil {
+			return xerrors.Errorf("stat error: %w", err)
+		}
+
+		err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if !d.Type().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(cacheDir, filePath)
+			if err != nil {
+				return err
+			}
+			files[filepath.ToSlash(rel)] = filePath
+			return nil
+		})
+		if err != nil {
+			return xerrors.Errorf("unable to walk %s: %w", dir, err)
+		}
+		log.InfoContext(ctx, "Adding the database to the bundle", log.String("db", c.name))
+	}
+
+	tmpDir, err := os.MkdirTemp("", "trivy-bundle-*")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	checksums, err := archive.Checksums(files)
+	if err != nil {
+		return xerrors.Errorf("checksum error: %w", err)
+	}
+	checksumPath := filepath.Join(tmpDir, checksumFileName)
+	if err = os.WriteFile(checksumPath, checksums, 0o644); err != nil {
+		return xerrors.Errorf("unable to write checksums: %w", err)
+	}
+	files[checksumFileName] = checksumPath
+
+	if err = archive.Write(archivePath, files); err != nil {
+		return xerrors.Errorf("archive error: %w", err)
+	}
+	log.InfoContext(ctx, "Bundle exported", log.FilePath(archivePath))
+	return nil
+}
+
+// Import installs the databases in the bundle written by Export into the cache directory.
+// The bundle is verified entirely before any database in the cache directory is replaced.
+func Import(ctx context.Context, cacheDir, archivePath string) error {
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return xerrors.Errorf("failed to create the cache dir: %w", err)
+	}
+
+	// Extract into the cache directory so that the databases can be moved atomically
+	tmpDir, err := os.MkdirTemp(cacheDir, "bundle-import-*")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Only the files written by Export are extracted
+	err = archive.Extract(archivePath, tmpDir, func(name string) bool {
+		return name == checksumFileName || validName(name)
+	})
+	if err != nil {
+		return xerrors.Errorf("unable to extract %s: %w", archivePath, err)
+	}
+	if err = verifyChecksums(tmpDir); err != nil {
+		return xerrors.Errorf("checksum error: %w", err)
+	}
+	if err = verifyDBs(ctx, tmpDir); err != nil {
+		return xerrors.Errorf("unable to verify the bundle: %w", err)
+	}
+
+	for _, c := range components {
+		src := filepath.Join(tmpDir, filepath.FromSlash(c.dir))
+		if _, err = os.Stat(src); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		dst := filepath.Join(cacheDir, filepath.FromSlash(c.dir))
+		if err = os.RemoveAll(dst); err != nil {
+			return xerrors.Errorf("unable to remove the old %s: %w", c.name, err)
+		}
+		if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+			return xerrors.Errorf("failed to mkdir: %w", err)
+		}
+		if err = os.Rename(src, dst); err != nil {
+			return xerrors.Errorf("unable to install the %s: %w", c.name, err)
+		}
+		log.InfoContext(ctx, "Database imported", log.String("db", c.name))
+	}
+	return nil
+}
+
+// verifyDBs makes sure that the databases are supported by this version of Trivy
+func verifyDBs(ctx context.Context, dir string) error {
+	meta, err := metadata.NewClient(db.Dir(dir)).Get()
+	if err != nil {
+		return xerrors.Errorf("vulnerability DB metadata error: %w", err)
+	} else if meta.Version != db.SchemaVersion {
+		return xerrors.Errorf("vulnerability DB schema version mismatch (expected: %d, actual: %d)", db.SchemaVersion, meta.Version)
+	}
+	log.DebugContext(ctx, "Vulnerability DB", log.String("updated_at", meta.UpdatedAt.String()))
+
+	if _, err = os.Stat(filepath.Join(dir, "java-db")); err == nil {
+		if _, err = javadb.Verify(ctx, dir); err != nil {
+			return xerrors.Errorf("Java DB error: %w", err)
+		}
+	}
+	return nil
+}
+
+// verifyChecksums verifies the digests of all the extracted files
+func verifyChecksums(dir string) error {
+	verified, err := archive.VerifyChecksums(dir, checksumFileName, validName)
+	if err != nil {
+		return err
+	}
+
+	// Files without digests must not be installed
+	return filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !slices.Contains(verified, name) && name != checksumFileName {
+			return xerrors.Errorf("digest of %s not found", name)
+		}
+		return nil
+	})
+}
+
+// validName reports whether the file belongs to one of the databases
+func validName(name string) bool {
+	return lo.SomeBy(components, func(c component) bool {
+		return strings.HasPrefix(name, c.dir+"/")
+	})
+}
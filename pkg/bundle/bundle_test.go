@@ -0,0 +1,216 @@
+package bundle_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	jdb "github.com/aquasecurity/trivy-java-db/pkg/db"
+	jtypes "github.com/aquasecurity/trivy-java-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/bundle"
+	"github.com/aquasecurity/trivy/pkg/db"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	srcCacheDir := t.TempDir()
+	initDBs(t, srcCacheDir)
+
+	archivePath := filepath.Join(t.TempDir(), "trivy-bundle.tar.gz")
+	require.NoError(t, bundle.Export(ctx, srcCacheDir, archivePath))
+
+	// The old databases are replaced
+	dstCacheDir := t.TempDir()
+	writeFile(t, filepath.Join(dstCacheDir, "db", "old.db"), "old")
+	writeFile(t, filepath.Join(dstCacheDir, "fanal", "fanal.db"), "scan cache")
+	require.NoError(t, bundle.Import(ctx, dstCacheDir, archivePath))
+
+	for _, name := range []string{
+		"db/trivy.db",
+		"db/metadata.json",
+		"java-db/trivy-java.db",
+		"java-db/metadata.json",
+		"policy/metadata.json",
+		"policy/content/policies/test.rego",
+		"vex/repositories/default/vex-repository.json",
+	} {
+		want, err := os.ReadFile(filepath.Join(srcCacheDir, name))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(dstCacheDir, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+	assert.NoFileExists(t, filepath.Join(dstCacheDir, "db", "old.db"))
+	// The other caches are kept
+	assert.FileExists(t, filepath.Join(dstCacheDir, "fanal", "fanal.db"))
+}
+
+func TestExport(t *testing.T) {
+	err := bundle.Export(context.Background(), t.TempDir(), filepath.Join(t.TempDir(), "trivy-bundle.tar.gz"))
+	require.ErrorContains(t, err, "vulnerability DB metadata error")
+}
+
+func TestImport(t *testing.T) {
+	metadataJSON := fmt.Sprintf(`{"Version":%d}`, db.SchemaVersion)
+	tests := []struct {
+		name    string
+		files   map[string]string
+		sums    map[string]string
+		wantErr string
+	}{
+		{
+			name: "tampered file",
+			files: map[string]string{
+				"db/metadata.json": metadataJSON,
+				"db/trivy.db":      "db",
+			},
+			sums: map[string]string{
+				"db/trivy.db": "0000000000000000000000000000000000000000000000000000000000000000",
+			},
+			wantErr: "digest mismatch for db/trivy.db",
+		},
+		{
+			name: "no checksum",
+			files: map[string]string{
+				"db/metadata.json": metadataJSON,
+			},
+			sums: map[string]string{
+				"db/metadata.json": "",
+			},
+			wantErr: "digest of db/metadata.json not found",
+		},
+		{
+			name: "path traversal",
+			files: map[string]string{
+				"db/../../evil": "evil",
+			},
+			wantErr: "unexpected file in the archive: db/../../evil",
+		},
+		{
+			name: "unknown file",
+			files: map[string]string{
+				"fanal/fanal.db": "cache",
+			},
+			wantErr: "unexpected file in the archive: fanal/fanal.db",
+		},
+		{
+			name: "schema version mismatch",
+			files: map[string]string{
+				"db/metadata.json": `{"Version":1}`,
+				"db/trivy.db":      "db",
+			},
+			wantErr: "vulnerability DB schema version mismatch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archivePath := writeArchive(t, tt.files, tt.sums)
+
+			cacheDir := t.TempDir()
+			writeFile(t, filepath.Join(cacheDir, "db", "trivy.db"), "old")
+			err := bundle.Import(context.Background(), cacheDir, archivePath)
+			require.ErrorContains(t, err, tt.wantErr)
+
+			// The cached DB is kept as is
+			got, err := os.ReadFile(filepath.Join(cacheDir, "db", "trivy.db"))
+			require.NoError(t, err)
+			assert.Equal(t, "old", string(got))
+		})
+	}
+}
+
+func initDBs(t *testing.T, cacheDir string) {
+	dbDir := db.Dir(cacheDir)
+	writeFile(t, db.Path(dbDir), "trivy-db")
+	require.NoError(t, metadata.NewClient(dbDir).Update(metadata.Metadata{
+		Version:   db.SchemaVersion,
+		UpdatedAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	javaDBDir := filepath.Join(cacheDir, "java-db")
+	dbc, err := jdb.New(javaDBDir)
+	require.NoError(t, err)
+	require.NoError(t, dbc.Init())
+	require.NoError(t, dbc.InsertIndexes([]jtypes.Index{
+		{
+			GroupID:     "org.apache.commons",
+			ArtifactID:  "commons-lang3",
+			Version:     "3.14.0",
+			SHA1:        []byte("1ed471194b02f2c6cb73"),
+			ArchiveType: jtypes.JarType,
+		},
+	}))
+	require.NoError(t, dbc.Close())
+	metac := jdb.NewMetadata(javaDBDir)
+	require.NoError(t, metac.Update(jdb.Metadata{
+		Version:   jdb.SchemaVersion,
+		UpdatedAt: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}))
+
+	writeFile(t, filepath.Join(cacheDir, "policy", "metadata.json"), `{"Digest":"sha256:0123"}`)
+	writeFile(t, filepath.Join(cacheDir, "policy", "content", "policies", "test.rego"), "package test")
+	writeFile(t, filepath.Join(cacheDir, "vex", "repositories", "default", "vex-repository.json"), "{}")
+}
+
+// writeArchive writes a bundle with SHA256SUMS of the files.
+// The digests can be overridden with sums, and are removed if empty.
+func writeArchive(t *testing.T, files, sums map[string]string) string {
+	digests := make(map[string]string)
+	for name, content := range files {
+		h := sha256.Sum256([]byte(content))
+		digests[name] = hex.EncodeToString(h[:])
+	}
+	for name, sum := range sums {
+		if sum == "" {
+			delete(digests, name)
+			continue
+		}
+		digests[name] = sum
+	}
+	var checksums string
+	for name, sum := range digests {
+		checksums += fmt.Sprintf("%s  %s\n", sum, name)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "trivy-bundle.tar.gz")
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		addFile(t, tw, name, content)
+	}
+	addFile(t, tw, "SHA256SUMS", checksums)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return archivePath
+}
+
+func addFile(t *testing.T, tw *tar.Writer, name, content string) {
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0o644,
+		Size: int64(len(content)),
+	}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+}
+
+func writeFile(t *testing.T, filePath, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o700))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+}
@@ -11,6 +11,10 @@ const (
 	artifactBucket = "artifact"
 	// blobBucket stores os, package and library information per blob ID such as layer ID
 	blobBucket = "blob"
+	// accessBucket stores the last access time per artifact and blob for eviction
+	accessBucket = "access"
+	// statsBucket stores the numbers of cache hits and misses
+	statsBucket = "stats"
 )
 
 type Cache interface {
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -10,30 +11,41 @@ import (
 )
 
 const (
-	TypeUnknown Type = "unknown"
-	TypeFS      Type = "fs"
-	TypeRedis   Type = "redis"
-	TypeMemory  Type = "memory"
+	TypeUnknown  Type = "unknown"
+	TypeFS       Type = "fs"
+	TypeRedis    Type = "redis"
+	TypeS3       Type = "s3"
+	TypePostgres Type = "postgres"
+	TypeMemory   Type = "memory"
 )
 
 type Type string
 
 type Options struct {
-	Backend     string
-	CacheDir    string
-	RedisCACert string
-	RedisCert   string
-	RedisKey    string
-	RedisTLS    bool
-	TTL         time.Duration
+	Backend       string
+	CacheDir      string
+	RedisCACert   string
+	RedisCert     string
+	RedisKey      string
+	RedisTLS      bool
+	S3SSE         string
+	S3SSEKMSKeyID string
+	S3Concurrency int
+	TTL           time.Duration
+	MaxSize       int64
 }
 
 func NewType(backend string) Type {
-	// "redis://" or "fs" are allowed for now
+	// "redis://", "redis+sentinel://", "redis+cluster://", "s3://", "postgres://" or "fs" are allowed for now
 	// An empty value is also allowed for testability
 	switch {
-	case strings.HasPrefix(backend, "redis://"):
+	case strings.HasPrefix(backend, "redis://"), strings.HasPrefix(backend, redisSentinelScheme+"://"),
+		strings.HasPrefix(backend, redisClusterScheme+"://"):
 		return TypeRedis
+	case strings.HasPrefix(backend, "s3://"):
+		return TypeS3
+	case strings.HasPrefix(backend, "postgres://"), strings.HasPrefix(backend, "postgresql://"):
+		return TypePostgres
 	case backend == "fs", backend == "":
 		return TypeFS
 	case backend == "memory":
@@ -57,12 +69,40 @@ func New(opts Options) (Cache, func(), error) {
 			return nil, cleanup, xerrors.Errorf("unable to initialize redis cache: %w", err)
 		}
 		cache = redisCache
+	case TypeS3:
+		s3Cache, err := NewS3Cache(context.TODO(), S3Options{
+			Backend:     opts.Backend,
+			SSE:         opts.S3SSE,
+			SSEKMSKeyID: opts.S3SSEKMSKeyID,
+			Concurrency: opts.S3Concurrency,
+		})
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("unable to initialize S3 cache: %w", err)
+		}
+		cache = s3Cache
+	case TypePostgres:
+		postgresCache, err := NewPostgresCache(opts.Backend, opts.TTL)
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("unable to initialize PostgreSQL cache: %w", err)
+		}
+		cache = postgresCache
 	case TypeFS:
 		// standalone mode
 		fsCache, err := NewFSCache(opts.CacheDir)
 		if err != nil {
 			return nil, cleanup, xerrors.Errorf("unable to initialize fs cache: %w", err)
 		}
+		if opts.TTL > 0 || opts.MaxSize > 0 {
+			result, err := fsCache.Prune(context.TODO(), PruneOptions{
+				TTL:     opts.TTL,
+				MaxSize: opts.MaxSize,
+			})
+			if err != nil {
+				_ = fsCache.Close()
+				return nil, cleanup, xerrors.Errorf("unable to prune fs cache: %w", err)
+			}
+			log.Debug("Pruned the scan cache", log.Int("removed", result.Removed), log.Int64("size", result.Size))
+		}
 		cache = fsCache
 	case TypeMemory:
 		cache = NewMemoryCache()
@@ -65,6 +65,21 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: "failed to get TLS config",
 		},
+		{
+			name: "invalid S3 server-side encryption",
+			opts: cache.Options{
+				Backend: "s3://bucket/prefix",
+				S3SSE:   "unknown",
+			},
+			wantErr: "unknown S3 server-side encryption",
+		},
+		{
+			name: "invalid PostgreSQL pool size",
+			opts: cache.Options{
+				Backend: "postgres://localhost/trivy?pool_max_conns=foo",
+			},
+			wantErr: "invalid pool_max_conns",
+		},
 	}
 
 	for _, tt := range tests {
@@ -95,6 +110,26 @@ func TestNewType(t *testing.T) {
 			backend:  "redis://localhost:6379",
 			wantType: cache.TypeRedis,
 		},
+		{
+			name:     "redis sentinel backend",
+			backend:  "redis+sentinel://localhost:26379?master_name=mymaster",
+			wantType: cache.TypeRedis,
+		},
+		{
+			name:     "redis cluster backend",
+			backend:  "redis+cluster://localhost:6379,localhost:6380",
+			wantType: cache.TypeRedis,
+		},
+		{
+			name:     "s3 backend",
+			backend:  "s3://bucket/prefix",
+			wantType: cache.TypeS3,
+		},
+		{
+			name:     "postgres backend",
+			backend:  "postgres://localhost:5432/trivy",
+			wantType: cache.TypePostgres,
+		},
 		{
 			name:     "fs backend",
 			backend:  "fs",
@@ -1,17 +1,27 @@
 package cache
 
 import (
+	"context"
+	"encoding/binary"
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/samber/lo"
 	bolt "go.etcd.io/bbolt"
 	"golang.org/x/xerrors"
 
+	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 )
 
+// accessTimeResolution is the precision of the access times.
+// Entries used again within it are not written, as cache hits would otherwise write to the database on every scan.
+const accessTimeResolution = time.Hour
+
 var _ Cache = &FSCache{}
 
 type FSCache struct {
@@ -34,6 +44,8 @@ func NewFSCache(cacheDir string) (FSCache, error) {
 		for _, bucket := range []string{
 			artifactBucket,
 			blobBucket,
+			accessBucket,
+			statsBucket,
 		} {
 			if _, err := tx.CreateBucketIfNotExists([]byte(bucket)); err != nil {
 				return xerrors.Errorf("unable to create %s bucket: %w", bucket, err)
@@ -85,13 +97,14 @@ func (fs FSCache) PutBlob(blobID string, blobInfo types.BlobInfo) error {
 	if err != nil {
 		return xerrors.Errorf("unable to marshal blob JSON (%s): %w", blobID, err)
 	}
+	key := accessKey(blobBucket, blobID)
 	err = fs.db.Update(func(tx *bolt.Tx) error {
 		blobBucket := tx.Bucket([]byte(blobBucket))
 		err = blobBucket.Put([]byte(blobID), b)
 		if err != nil {
 			return xerrors.Errorf("unable to store blob information in cache (%s): %w", blobID, err)
 		}
-		return nil
+		return touch(tx, clock.Now(context.TODO()), key)
 	})
 	if err != nil {
 		return xerrors.Errorf("DB update error: %w", err)
@@ -123,10 +136,14 @@ func (fs FSCache) DeleteBlobs(blobIDs []string) error {
 	var errs error
 	err := fs.db.Update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket([]byte(blobBucket))
+		access := tx.Bucket([]byte(accessBucket))
 		for _, blobID := range blobIDs {
 			if err := bucket.Delete([]byte(blobID)); err != nil {
 				errs = multierror.Append(errs, err)
 			}
+			if err := access.Delete(accessKey(blobBucket, blobID)); err != nil {
+				errs = multierror.Append(errs, err)
+			}
 		}
 		return nil
 	})
@@ -143,13 +160,14 @@ func (fs FSCache) PutArtifact(artifactID string, artifactInfo types.ArtifactInfo
 		return xerrors.Errorf("unable to marshal artifact JSON (%s): %w", artifactID, err)
 	}
 
+	key := accessKey(artifactBucket, artifactID)
 	err = fs.db.Update(func(tx *bolt.Tx) error {
 		artifactBucket := tx.Bucket([]byte(artifactBucket))
 		err = artifactBucket.Put([]byte(artifactID), b)
 		if err != nil {
 			return xerrors.Errorf("unable to store artifact information in cache (%s): %w", artifactID, err)
 		}
-		return nil
+		return touch(tx, clock.Now(context.TODO()), key)
 	})
 	if err != nil {
 		return xerrors.Errorf("DB update error: %w", err)
@@ -157,14 +175,19 @@ func (fs FSCache) PutArtifact(artifactID string, artifactInfo types.ArtifactInfo
 	return nil
 }
 
-// MissingBlobs returns missing blob IDs such as layer IDs
+// MissingBlobs returns missing blob IDs such as layer IDs.
+// The cached artifact and blobs are marked as used so that they are evicted last,
+// and the cache hits and misses are counted for the statistics.
+// The access times are only updated when they are older than accessTimeResolution,
+// and the updates of concurrent calls are batched into a single transaction.
 func (fs FSCache) MissingBlobs(artifactID string, blobIDs []string) (bool, []string, error) {
 	var missingArtifact bool
 	var missingBlobIDs []string
+	var hits [][]byte
 	err := fs.db.View(func(tx *bolt.Tx) error {
-		blobBucket := tx.Bucket([]byte(blobBucket))
+		bucket := tx.Bucket([]byte(blobBucket))
 		for _, blobID := range blobIDs {
-			blobInfo, err := fs.getBlob(blobBucket, blobID)
+			blobInfo, err := fs.getBlob(bucket, blobID)
 			if err != nil {
 				// error means cache missed blob info
 				missingBlobIDs = append(missingBlobIDs, blobID)
@@ -172,7 +195,9 @@ func (fs FSCache) MissingBlobs(artifactID string, blobIDs []string) (bool, []str
 			}
 			if blobInfo.SchemaVersion != types.BlobJSONSchemaVersion {
 				missingBlobIDs = append(missingBlobIDs, blobID)
+				continue
 			}
+			hits = append(hits, accessKey(blobBucket, blobID))
 		}
 		return nil
 	})
@@ -184,14 +209,165 @@ func (fs FSCache) MissingBlobs(artifactID string, blobIDs []string) (bool, []str
 	artifactInfo, err := fs.GetArtifact(artifactID)
 	if err != nil {
 		// error means cache missed artifact info
-		return true, missingBlobIDs, nil
-	}
-	if artifactInfo.SchemaVersion != types.ArtifactJSONSchemaVersion {
 		missingArtifact = true
+	} else if artifactInfo.SchemaVersion != types.ArtifactJSONSchemaVersion {
+		missingArtifact = true
+	} else {
+		hits = append(hits, accessKey(artifactBucket, artifactID))
+	}
+
+	now := clock.Now(context.TODO())
+	misses := len(missingBlobIDs) + lo.Ternary(missingArtifact, 1, 0)
+	if err = fs.db.Batch(func(tx *bolt.Tx) error {
+		if err := countStats(tx, len(hits), misses); err != nil {
+			return err
+		}
+		return touch(tx, now, staleAccessKeys(tx, now, hits)...)
+	}); err != nil {
+		return false, nil, xerrors.Errorf("DB update error: %w", err)
 	}
 	return missingArtifact, missingBlobIDs, nil
 }
 
+// PruneOptions holds the limits of the scan cache
+type PruneOptions struct {
+	// TTL is the duration after which unused entries are removed
+	TTL time.Duration
+	// MaxSize is the maximum total size of the entries in bytes
+	MaxSize int64
+}
+
+// PruneResult holds the entries removed by Prune
+type PruneResult struct {
+	Removed      int
+	RemovedBytes int64
+	Remaining    int
+	Size         int64
+}
+
+type cacheEntry struct {
+	bucket     string
+	key        []byte
+	size       int64
+	accessedAt time.Time
+}
+
+// Prune removes the entries which haven't been used for the TTL,
+// then the least recently used entries until the cache fits in the max size.
+// Entries cached before access times were recorded are considered used now.
+// The database file doesn't shrink, but the space of the removed entries is reused.
+func (fs FSCache) Prune(ctx context.Context, opts PruneOptions) (PruneResult, error) {
+	now := clock.Now(ctx)
+	var result PruneResult
+	err := fs.db.Update(func(tx *bolt.Tx) error {
+		access := tx.Bucket([]byte(accessBucket))
+
+		var entries []cacheEntry
+		var untracked [][]byte
+		tracked := make(map[string]struct{})
+		for _, name := range []string{artifactBucket, blobBucket} {
+			err := tx.Bucket([]byte(name)).ForEach(func(k, v []byte) error {
+				key := accessKey(name, string(k))
+				e := cacheEntry{
+					bucket:     name,
+					key:        slices.Clone(k),
+					size:       int64(len(k) + len(v)),
+					accessedAt: now,
+				}
+				if b := access.Get(key); len(b) == 8 {
+					e.accessedAt = time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+				} else {
+					untracked = append(untracked, key)
+				}
+				tracked[string(key)] = struct{}{}
+				entries = append(entries, e)
+				return nil
+			})
+			if err != nil {
+				return xerrors.Errorf("unable to list %s entries: %w", name, err)
+			}
+		}
+		if err := touch(tx, now, untracked...); err != nil {
+			return err
+		}
+
+		// Remove the access times of the entries deleted in other ways
+		var orphans [][]byte
+		if err := access.ForEach(func(k, _ []byte) error {
+			if _, ok := tracked[string(k)]; !ok {
+				orphans = append(orphans, slices.Clone(k))
+			}
+			return nil
+		}); err != nil {
+			return xerrors.Errorf("unable to list access times: %w", err)
+		}
+		for _, k := range orphans {
+			if err := access.Delete(k); err != nil {
+				return xerrors.Errorf("unable to delete the access time of %s: %w", k, err)
+			}
+		}
+
+		// Least recently used first
+		slices.SortFunc(entries, func(a, b cacheEntry) int {
+			return a.accessedAt.Compare(b.accessedAt)
+		})
+		for _, e := range entries {
+			result.Size += e.size
+		}
+
+		for i, e := range entries {
+			expired := opts.TTL > 0 && now.Sub(e.accessedAt) > opts.TTL
+			oversized := opts.MaxSize > 0 && result.Size > opts.MaxSize
+			if !expired && !oversized {
+				result.Remaining = len(entries) - i
+				break
+			}
+			if err := tx.Bucket([]byte(e.bucket)).Delete(e.key); err != nil {
+				return xerrors.Errorf("unable to delete %s: %w", e.key, err)
+			}
+			if err := access.Delete(accessKey(e.bucket, string(e.key))); err != nil {
+				return xerrors.Errorf("unable to delete the access time of %s: %w", e.key, err)
+			}
+			result.Removed++
+			result.RemovedBytes += e.size
+			result.Size -= e.size
+		}
+		return nil
+	})
+	if err != nil {
+		return PruneResult{}, xerrors.Errorf("DB update error: %w", err)
+	}
+	return result, nil
+}
+
+func accessKey(bucket, id string) []byte {
+	return []byte(bucket + "::" + id)
+}
+
+// staleAccessKeys returns the keys whose access times are older than accessTimeResolution
+func staleAccessKeys(tx *bolt.Tx, now time.Time, keys [][]byte) [][]byte {
+	access := tx.Bucket([]byte(accessBucket))
+	return lo.Filter(keys, func(key []byte, _ int) bool {
+		b := access.Get(key)
+		if len(b) != 8 {
+			return true
+		}
+		return now.Sub(time.Unix(0, int64(binary.BigEndian.Uint64(b)))) >= accessTimeResolution
+	})
+}
+
+// touch records the access time of the entries
+func touch(tx *bolt.Tx, t time.Time, keys ...[]byte) error {
+	access := tx.Bucket([]byte(accessBucket))
+	b := binary.BigEndian.AppendUint64(nil, uint64(t.UnixNano()))
+	for _, key := range keys {
+		if err := access.Put(key, b); err != nil {
+			return xerrors.Errorf("unable to store the access time of %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
 // Close closes the database
 func (fs FSCache) Close() error {
 	if err := fs.db.Close(); err != nil {
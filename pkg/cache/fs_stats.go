@@ -0,0 +1,239 @@
+package cache
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// Types of the cache entries
+const (
+	// EntryTypeImageConfig is the analysis result of image configs
+	EntryTypeImageConfig = "image_config"
+	// EntryTypeImageLayer is the analysis result of image layers
+	EntryTypeImageLayer = "image_layer"
+	// EntryTypeFilesystem is the analysis result of filesystem, repository, rootfs, VM and SBOM scans
+	EntryTypeFilesystem = "filesystem"
+)
+
+var (
+	statsHitsKey   = []byte("hits")
+	statsMissesKey = []byte("misses")
+)
+
+// Stats holds the statistics of the scan cache
+type Stats struct {
+	// Hits and Misses are the numbers of cached and missing artifacts and blobs looked up by scans
+	Hits   uint64
+	Misses uint64
+
+	// Types holds the number of entries and their size per type
+	Types map[string]TypeStats
+}
+
+// TypeStats holds the number and the size of the entries of a type
+type TypeStats struct {
+	Count int
+	Size  int64
+}
+
+// HitRatio returns the ratio of the cache hits to the lookups
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Entry describes a cached artifact or blob
+type Entry struct {
+	Key           string
+	Type          string
+	Size          int64
+	AccessedAt    time.Time
+	SchemaVersion int
+
+	// Layer information
+	Digest    string
+	DiffID    string
+	CreatedBy string
+
+	// Summary of the analysis result
+	OS                types.OS
+	Packages          int
+	Applications      int
+	Misconfigurations int
+	Secrets           int
+	Licenses          int
+}
+
+// Stats returns the numbers of cache hits and misses, and the number and the size of the entries per type
+func (fs FSCache) Stats() (Stats, error) {
+	stats := Stats{Types: make(map[string]TypeStats)}
+	err := fs.db.View(func(tx *bolt.Tx) error {
+		stats.Hits, stats.Misses = getStats(tx)
+		return fs.forEachEntry(tx, func(e Entry) error {
+			ts := stats.Types[e.Type]
+			ts.Count++
+			ts.Size += e.Size
+			stats.Types[e.Type] = ts
+			return nil
+		})
+	})
+	if err != nil {
+		return Stats{}, xerrors.Errorf("DB error: %w", err)
+	}
+	return stats, nil
+}
+
+// Inspect returns the entries whose cache key, layer digest or layer diff ID is the digest
+func (fs FSCache) Inspect(digest string) ([]Entry, error) {
+	var entries []Entry
+	err := fs.db.View(func(tx *bolt.Tx) error {
+		return fs.forEachEntry(tx, func(e Entry) error {
+			if e.Key == digest || e.Digest == digest || e.DiffID == digest {
+				entries = append(entries, e)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("DB error: %w", err)
+	}
+	return entries, nil
+}
+
+// DeleteEntries removes the entries so that they are analyzed again on the next scan
+func (fs FSCache) DeleteEntries(entries []Entry) error {
+	var errs error
+	err := fs.db.Update(func(tx *bolt.Tx) error {
+		access := tx.Bucket([]byte(accessBucket))
+		for _, e := range entries {
+			bucket := entryBucket(e.Type)
+			if err := tx.Bucket([]byte(bucket)).Delete([]byte(e.Key)); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+			if err := access.Delete(accessKey(bucket, e.Key)); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return xerrors.Errorf("DB delete error: %w", err)
+	}
+	return errs
+}
+
+func (fs FSCache) forEachEntry(tx *bolt.Tx, fn func(Entry) error) error {
+	access := tx.Bucket([]byte(accessBucket))
+	for _, name := range []string{artifactBucket, blobBucket} {
+		err := tx.Bucket([]byte(name)).ForEach(func(k, v []byte) error {
+			e := Entry{
+				Key:  string(k),
+				Type: EntryTypeImageConfig,
+				Size: int64(len(k) + len(v)),
+			}
+			if name == artifactBucket {
+				summarizeArtifact(&e, v)
+			} else {
+				summarizeBlob(&e, v)
+			}
+			if b := access.Get(accessKey(name, string(k))); len(b) == 8 {
+				e.AccessedAt = time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+			}
+			return fn(e)
+		})
+		if err != nil {
+			return xerrors.Errorf("unable to list %s entries: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// summarizeArtifact fills the entry with the artifact information.
+// Entries which cannot be decoded, e.g. with an old schema, are reported without summary.
+func summarizeArtifact(e *Entry, b []byte) {
+	var info types.ArtifactInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		return
+	}
+	e.SchemaVersion = info.SchemaVersion
+	e.Packages = len(info.HistoryPackages)
+	e.Applications = len(info.Applications)
+	if info.Misconfiguration != nil {
+		e.Misconfigurations = 1
+	}
+	if info.Secret != nil {
+		e.Secrets = 1
+	}
+}
+
+// summarizeBlob fills the entry with the blob information, and its type depending on whether it is a layer
+func summarizeBlob(e *Entry, b []byte) {
+	e.Type = EntryTypeFilesystem
+	var info types.BlobInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		return
+	}
+	if info.DiffID != "" {
+		e.Type = EntryTypeImageLayer
+	}
+	e.SchemaVersion = info.SchemaVersion
+	e.Digest = info.Digest
+	e.DiffID = info.DiffID
+	e.CreatedBy = info.CreatedBy
+	e.OS = info.OS
+	for _, pkgInfo := range info.PackageInfos {
+		e.Packages += len(pkgInfo.Packages)
+	}
+	e.Applications = len(info.Applications)
+	e.Misconfigurations = len(info.Misconfigurations)
+	e.Secrets = len(info.Secrets)
+	e.Licenses = len(info.Licenses)
+}
+
+func entryBucket(entryType string) string {
+	if entryType == EntryTypeImageConfig {
+		return artifactBucket
+	}
+	return blobBucket
+}
+
+// countStats adds the numbers of cache hits and misses
+func countStats(tx *bolt.Tx, hits, misses int) error {
+	bucket := tx.Bucket([]byte(statsBucket))
+	for key, n := range map[string]int{
+		string(statsHitsKey):   hits,
+		string(statsMissesKey): misses,
+	} {
+		if n == 0 {
+			continue
+		}
+		var count uint64
+		if b := bucket.Get([]byte(key)); len(b) == 8 {
+			count = binary.BigEndian.Uint64(b)
+		}
+		if err := bucket.Put([]byte(key), binary.BigEndian.AppendUint64(nil, count+uint64(n))); err != nil {
+			return xerrors.Errorf("unable to store the cache %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func getStats(tx *bolt.Tx) (hits, misses uint64) {
+	bucket := tx.Bucket([]byte(statsBucket))
+	if b := bucket.Get(statsHitsKey); len(b) == 8 {
+		hits = binary.BigEndian.Uint64(b)
+	}
+	if b := bucket.Get(statsMissesKey); len(b) == 8 {
+		misses = binary.BigEndian.Uint64(b)
+	}
+	return hits, misses
+}
@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestFSCache_Stats(t *testing.T) {
+	const (
+		artifactID = "sha256:8652b9f0cb4c0599575e5a003f5906876e10c1ceb2ab9fe1786712dac14a50cf"
+		layerKey   = "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7"
+		fsKey      = "sha256:dffd9992ca398466a663c87c92cfea2a2db0ae0cf33fcb99da60eec52addbfc5"
+		diffID     = "sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203"
+		missingKey = "sha256:dab15cac9ebd43beceeeda3ce95c574d6714ed3d3969071caead678c065813ec"
+	)
+
+	cache, err := NewFSCache(t.TempDir())
+	require.NoError(t, err)
+	defer cache.Close()
+
+	require.NoError(t, cache.PutArtifact(artifactID, types.ArtifactInfo{
+		SchemaVersion: types.ArtifactJSONSchemaVersion,
+		OS:            "linux",
+	}))
+	require.NoError(t, cache.PutBlob(layerKey, types.BlobInfo{
+		SchemaVersion: types.BlobJSONSchemaVersion,
+		Digest:        "sha256:9d48c3bd43c520dc2784e868a780e976b207cbf493eaff8c6596eb871cbd9609",
+		DiffID:        diffID,
+		OS: types.OS{
+			Family: "alpine",
+			Name:   "3.10.2",
+		},
+		PackageInfos: []types.PackageInfo{
+			{
+				FilePath: "lib/apk/db/installed",
+				Packages: types.Packages{
+					{Name: "musl", Version: "1.1.22-r3"},
+					{Name: "busybox", Version: "1.30.1-r2"},
+				},
+			},
+		},
+	}))
+	require.NoError(t, cache.PutBlob(fsKey, types.BlobInfo{
+		SchemaVersion: types.BlobJSONSchemaVersion,
+		Secrets:       []types.Secret{{FilePath: "config.yaml"}},
+	}))
+
+	// 2 hits and 1 miss
+	_, _, err = cache.MissingBlobs(artifactID, []string{layerKey, missingKey})
+	require.NoError(t, err)
+
+	t.Run("stats", func(t *testing.T) {
+		stats, err := cache.Stats()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), stats.Hits)
+		assert.Equal(t, uint64(1), stats.Misses)
+		assert.InDelta(t, 2.0/3.0, stats.HitRatio(), 0.001)
+		for _, typ := range []string{EntryTypeImageConfig, EntryTypeImageLayer, EntryTypeFilesystem} {
+			assert.Equal(t, 1, stats.Types[typ].Count, typ)
+			assert.Positive(t, stats.Types[typ].Size, typ)
+		}
+	})
+
+	t.Run("inspect by diff ID", func(t *testing.T) {
+		entries, err := cache.Inspect(diffID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		got := entries[0]
+		assert.Equal(t, layerKey, got.Key)
+		assert.Equal(t, EntryTypeImageLayer, got.Type)
+		assert.Equal(t, types.OS{Family: "alpine", Name: "3.10.2"}, got.OS)
+		assert.Equal(t, 2, got.Packages)
+		assert.False(t, got.AccessedAt.IsZero())
+	})
+
+	t.Run("inspect and delete by key", func(t *testing.T) {
+		entries, err := cache.Inspect(fsKey)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, EntryTypeFilesystem, entries[0].Type)
+		assert.Equal(t, 1, entries[0].Secrets)
+
+		require.NoError(t, cache.DeleteEntries(entries))
+		entries, err = cache.Inspect(fsKey)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
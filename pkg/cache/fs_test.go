@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"os"
@@ -13,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	bolt "go.etcd.io/bbolt"
 
+	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 )
 
@@ -496,3 +499,153 @@ func TestFSCache_MissingBlobs(t *testing.T) {
 		})
 	}
 }
+
+func TestFSCache_MissingBlobs_AccessTime(t *testing.T) {
+	const blobID = "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7"
+
+	cache, err := NewFSCache(t.TempDir())
+	require.NoError(t, err)
+	defer cache.Close()
+	require.NoError(t, cache.PutBlob(blobID, types.BlobInfo{SchemaVersion: types.BlobJSONSchemaVersion}))
+
+	key := accessKey(blobBucket, blobID)
+	setAccessTime := func(accessedAt time.Time) {
+		require.NoError(t, cache.db.Update(func(tx *bolt.Tx) error {
+			return touch(tx, accessedAt, key)
+		}))
+	}
+	getAccessTime := func() time.Time {
+		var accessedAt time.Time
+		require.NoError(t, cache.db.View(func(tx *bolt.Tx) error {
+			b := tx.Bucket([]byte(accessBucket)).Get(key)
+			accessedAt = time.Unix(0, int64(binary.BigEndian.Uint64(b)))
+			return nil
+		}))
+		return accessedAt
+	}
+
+	// Recent access times are not updated
+	recent := time.Now().Add(-time.Minute).Truncate(time.Second)
+	setAccessTime(recent)
+	_, _, err = cache.MissingBlobs("", []string{blobID})
+	require.NoError(t, err)
+	assert.True(t, getAccessTime().Equal(recent))
+
+	// Stale access times are updated
+	stale := time.Now().Add(-accessTimeResolution).Truncate(time.Second)
+	setAccessTime(stale)
+	_, _, err = cache.MissingBlobs("", []string{blobID})
+	require.NoError(t, err)
+	assert.True(t, getAccessTime().After(stale))
+}
+
+func TestFSCache_Prune(t *testing.T) {
+	const (
+		artifactID = "sha256:8652b9f0cb4c0599575e5a003f5906876e10c1ceb2ab9fe1786712dac14a50cf"
+		blobA      = "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7"
+		blobB      = "sha256:dffd9992ca398466a663c87c92cfea2a2db0ae0cf33fcb99da60eec52addbfc5"
+		blobC      = "sha256:dab15cac9ebd43beceeeda3ce95c574d6714ed3d3969071caead678c065813ec"
+	)
+
+	setup := func(t *testing.T) FSCache {
+		cache, err := NewFSCache(t.TempDir())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = cache.Close() })
+
+		for _, blobID := range []string{blobA, blobB, blobC} {
+			require.NoError(t, cache.PutBlob(blobID, types.BlobInfo{
+				SchemaVersion: types.BlobJSONSchemaVersion,
+				Digest:        blobID,
+			}))
+		}
+		require.NoError(t, cache.PutArtifact(artifactID, types.ArtifactInfo{
+			SchemaVersion: types.ArtifactJSONSchemaVersion,
+		}))
+
+		// Access times within accessTimeResolution are not updated, so the entries are cached earlier
+		require.NoError(t, cache.db.Update(func(tx *bolt.Tx) error {
+			return tx.Bucket([]byte(accessBucket)).ForEach(func(k, _ []byte) error {
+				return touch(tx, time.Now().Add(-accessTimeResolution), k)
+			})
+		}))
+
+		// Use blob A and the artifact so that blob B and C are the least recently used
+		_, _, err = cache.MissingBlobs(artifactID, []string{blobA})
+		require.NoError(t, err)
+		return cache
+	}
+
+	blobIDs := func(t *testing.T, cache FSCache) []string {
+		var ids []string
+		err := cache.db.View(func(tx *bolt.Tx) error {
+			return tx.Bucket([]byte(blobBucket)).ForEach(func(k, _ []byte) error {
+				ids = append(ids, string(k))
+				return nil
+			})
+		})
+		require.NoError(t, err)
+		return ids
+	}
+
+	t.Run("TTL", func(t *testing.T) {
+		cache := setup(t)
+
+		result, err := cache.Prune(context.Background(), PruneOptions{TTL: 2 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Removed)
+		assert.Equal(t, 4, result.Remaining)
+
+		ctx := clock.With(context.Background(), time.Now().Add(90*time.Minute))
+		result, err = cache.Prune(ctx, PruneOptions{TTL: 2 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Removed)
+		assert.Equal(t, []string{blobA}, blobIDs(t, cache))
+
+		ctx = clock.With(context.Background(), time.Now().Add(3*time.Hour))
+		result, err = cache.Prune(ctx, PruneOptions{TTL: 2 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Removed)
+		assert.Zero(t, result.Size)
+		assert.Empty(t, blobIDs(t, cache))
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		cache := setup(t)
+
+		result, err := cache.Prune(context.Background(), PruneOptions{})
+		require.NoError(t, err)
+		blobSize := result.Size / 4 // the blobs and the artifact have similar sizes
+
+		result, err = cache.Prune(context.Background(), PruneOptions{MaxSize: 2 * blobSize})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Removed)
+		assert.Equal(t, 2, result.Remaining)
+		assert.LessOrEqual(t, result.Size, 2*blobSize)
+		assert.Equal(t, []string{blobA}, blobIDs(t, cache))
+
+		_, err = cache.GetArtifact(artifactID)
+		require.NoError(t, err)
+	})
+
+	t.Run("untracked entries", func(t *testing.T) {
+		cache := setup(t)
+
+		// Entries cached by older versions don't have access times
+		require.NoError(t, cache.db.Update(func(tx *bolt.Tx) error {
+			return tx.DeleteBucket([]byte(accessBucket))
+		}))
+		require.NoError(t, cache.db.Update(func(tx *bolt.Tx) error {
+			_, err := tx.CreateBucket([]byte(accessBucket))
+			return err
+		}))
+
+		result, err := cache.Prune(context.Background(), PruneOptions{TTL: time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Removed)
+
+		ctx := clock.With(context.Background(), time.Now().Add(2*time.Hour))
+		result, err = cache.Prune(ctx, PruneOptions{TTL: time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Removed)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/samber/lo"
 	"golang.org/x/mod/sumdb/dirhash"
 	"golang.org/x/xerrors"
 
@@ -25,13 +26,19 @@ func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[str
 
 	// Write ID, analyzer/handler versions, skipped files/dirs and file patterns
 	keyBase := struct {
-		ID                string
-		AnalyzerVersions  analyzer.Versions
-		HookVersions      map[string]int
-		SkipFiles         []string
-		SkipDirs          []string
-		FilePatterns      []string                `json:",omitempty"`
-		DetectionPriority types.DetectionPriority `json:",omitempty"`
+		ID                   string
+		AnalyzerVersions     analyzer.Versions
+		HookVersions         map[string]int
+		SkipFiles            []string
+		SkipDirs             []string
+		FilePatterns         []string                `json:",omitempty"`
+		DetectionPriority    types.DetectionPriority `json:",omitempty"`
+		ValidateSecrets      bool                    `json:",omitempty"`
+		SecretFingerprint    bool                    `json:",omitempty"`
+		SecretRedaction      string                  `json:",omitempty"`
+		SecretArchiveDepth   int                     `json:",omitempty"`
+		SecretArchiveMaxSize int64                   `json:",omitempty"`
+		GoBinarySources      []string                `json:",omitempty"`
 	}{
 		id,
 		analyzerVersions,
@@ -40,6 +47,13 @@ func CalcKey(id string, analyzerVersions analyzer.Versions, hookVersions map[str
 		artifactOpt.WalkerOption.SkipDirs,
 		artifactOpt.FilePatterns,
 		artifactOpt.DetectionPriority,
+		artifactOpt.SecretScannerOption.Validate,
+		artifactOpt.SecretScannerOption.Fingerprint,
+		artifactOpt.SecretScannerOption.Redaction,
+		artifactOpt.SecretScannerOption.ArchiveDepth,
+		// The max size doesn't matter unless archives are scanned
+		lo.Ternary(artifactOpt.SecretScannerOption.ArchiveDepth > 0, artifactOpt.SecretScannerOption.ArchiveMaxSize, 0),
+		artifactOpt.LicenseScannerOption.GoBinarySources,
 	}
 
 	if err := json.NewEncoder(h).Encode(keyBase); err != nil {
@@ -0,0 +1,294 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var _ Cache = (*PostgresCache)(nil)
+
+const (
+	postgresArtifactTable = "fanal_artifacts"
+	postgresBlobTable     = "fanal_blobs"
+
+	// postgresPoolSizeParam is the URL parameter of the maximum number of connections, following pgx
+	postgresPoolSizeParam       = "pool_max_conns"
+	defaultPostgresPoolSize     = 10
+	defaultPostgresConnIdleTime = 5 * time.Minute
+
+	// postgresMigrationLockID is the key of the advisory lock taken while migrating,
+	// so that Trivy servers sharing the database don't migrate it concurrently.
+	postgresMigrationLockID = 0x7472697679 // "trivy"
+)
+
+// postgresMigrations are the statements creating the schema of the cache, applied in order on startup.
+// Applied migrations are recorded in fanal_schema_migrations. Never modify them, append new ones instead.
+var postgresMigrations = []string{
+	// 1: artifacts and blobs with an optional expiration
+	`CREATE TABLE fanal_artifacts (
+		id TEXT PRIMARY KEY,
+		info JSONB NOT NULL,
+		expires_at TIMESTAMPTZ
+	);
+	CREATE TABLE fanal_blobs (
+		id TEXT PRIMARY KEY,
+		info JSONB NOT NULL,
+		expires_at TIMESTAMPTZ
+	);
+	CREATE INDEX fanal_artifacts_expires_at ON fanal_artifacts (expires_at) WHERE expires_at IS NOT NULL;
+	CREATE INDEX fanal_blobs_expires_at ON fanal_blobs (expires_at) WHERE expires_at IS NOT NULL;`,
+}
+
+// PostgresOptions holds the options for PostgreSQL cache
+type PostgresOptions struct {
+	// DSN is the connection string passed to the driver
+	DSN string
+	// PoolSize is the maximum number of open connections
+	PoolSize int
+}
+
+// NewPostgresOptions parses the "postgres://" URL, extracting the pool size from the "pool_max_conns" parameter
+func NewPostgresOptions(backend string) (PostgresOptions, error) {
+	u, err := url.Parse(backend)
+	if err != nil {
+		return PostgresOptions{}, xerrors.Errorf("failed to parse PostgreSQL URL: %w", err)
+	}
+
+	poolSize := defaultPostgresPoolSize
+	query := u.Query()
+	if v := query.Get(postgresPoolSizeParam); v != "" {
+		if poolSize, err = strconv.Atoi(v); err != nil || poolSize <= 0 {
+			return PostgresOptions{}, xerrors.Errorf("invalid %s %q, must be a positive integer", postgresPoolSizeParam, v)
+		}
+		query.Del(postgresPoolSizeParam)
+		u.RawQuery = query.Encode()
+	}
+
+	return PostgresOptions{
+		DSN:      u.String(),
+		PoolSize: poolSize,
+	}, nil
+}
+
+// BackendMasked returns the PostgreSQL connection string masking the password
+func (o *PostgresOptions) BackendMasked() string {
+	u, err := url.Parse(o.DSN)
+	if err != nil {
+		return ""
+	}
+	return u.Redacted()
+}
+
+type PostgresCache struct {
+	db         *sql.DB
+	expiration time.Duration
+}
+
+// NewPostgresCache connects to the database and migrates its schema.
+// Entries expire after the TTL if it is specified.
+func NewPostgresCache(backend string, ttl time.Duration) (PostgresCache, error) {
+	opts, err := NewPostgresOptions(backend)
+	if err != nil {
+		return PostgresCache{}, xerrors.Errorf("failed to create PostgreSQL options: %w", err)
+	}
+
+	log.Info("PostgreSQL scan cache", log.String("url", opts.BackendMasked()))
+	db, err := sql.Open("postgres", opts.DSN)
+	if err != nil {
+		return PostgresCache{}, xerrors.Errorf("failed to open PostgreSQL: %w", err)
+	}
+	db.SetMaxOpenConns(opts.PoolSize)
+	db.SetMaxIdleConns(opts.PoolSize)
+	db.SetConnMaxIdleTime(defaultPostgresConnIdleTime)
+
+	c := PostgresCache{
+		db:         db,
+		expiration: ttl,
+	}
+	if err = c.migrate(context.TODO()); err != nil {
+		_ = db.Close()
+		return PostgresCache{}, xerrors.Errorf("failed to migrate the PostgreSQL schema: %w", err)
+	}
+	return c, nil
+}
+
+// migrate applies the pending migrations in a transaction holding an advisory lock
+func (c PostgresCache) migrate(ctx context.Context) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return xerrors.Errorf("unable to begin a transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, postgresMigrationLockID); err != nil {
+		return xerrors.Errorf("unable to lock: %w", err)
+	}
+	if _, err = tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS fanal_schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return xerrors.Errorf("unable to create the migration table: %w", err)
+	}
+
+	var version int
+	if err = tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM fanal_schema_migrations`).Scan(&version); err != nil {
+		return xerrors.Errorf("unable to get the schema version: %w", err)
+	}
+	if version > len(postgresMigrations) {
+		return xerrors.Errorf("the schema version %d is newer than this version of Trivy supports (%d)", version, len(postgresMigrations))
+	}
+
+	for i := version; i < len(postgresMigrations); i++ {
+		log.Debug("Migrating the PostgreSQL scan cache", log.Int("version", i+1))
+		if _, err = tx.ExecContext(ctx, postgresMigrations[i]); err != nil {
+			return xerrors.Errorf("migration %d error: %w", i+1, err)
+		}
+		if _, err = tx.ExecContext(ctx, `INSERT INTO fanal_schema_migrations (version) VALUES ($1)`, i+1); err != nil {
+			return xerrors.Errorf("unable to record the migration %d: %w", i+1, err)
+		}
+	}
+
+	// Remove the expired entries left behind
+	for _, table := range []string{postgresArtifactTable, postgresBlobTable} {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE expires_at <= now()`, table)); err != nil {
+			return xerrors.Errorf("unable to delete the expired entries: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return xerrors.Errorf("unable to commit: %w", err)
+	}
+	return nil
+}
+
+func (c PostgresCache) PutArtifact(artifactID string, artifactInfo types.ArtifactInfo) error {
+	if err := c.put(postgresArtifactTable, artifactID, artifactInfo); err != nil {
+		return xerrors.Errorf("unable to store artifact information in PostgreSQL cache (%s): %w", artifactID, err)
+	}
+	return nil
+}
+
+func (c PostgresCache) PutBlob(blobID string, blobInfo types.BlobInfo) error {
+	if err := c.put(postgresBlobTable, blobID, blobInfo); err != nil {
+		return xerrors.Errorf("unable to store blob information in PostgreSQL cache (%s): %w", blobID, err)
+	}
+	return nil
+}
+
+func (c PostgresCache) put(table, id string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	var expiresAt *time.Time
+	if c.expiration > 0 {
+		t := time.Now().Add(c.expiration)
+		expiresAt = &t
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (id, info, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET info = EXCLUDED.info, expires_at = EXCLUDED.expires_at`, table)
+	if _, err = c.db.ExecContext(context.TODO(), query, id, b, expiresAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c PostgresCache) GetArtifact(artifactID string) (types.ArtifactInfo, error) {
+	var info types.ArtifactInfo
+	if err := c.get(postgresArtifactTable, artifactID, &info); errors.Is(err, sql.ErrNoRows) {
+		return types.ArtifactInfo{}, xerrors.Errorf("artifact (%s) is missing in PostgreSQL cache", artifactID)
+	} else if err != nil {
+		return types.ArtifactInfo{}, xerrors.Errorf("failed to get artifact (%s) from the PostgreSQL cache: %w", artifactID, err)
+	}
+	return info, nil
+}
+
+func (c PostgresCache) GetBlob(blobID string) (types.BlobInfo, error) {
+	var blobInfo types.BlobInfo
+	if err := c.get(postgresBlobTable, blobID, &blobInfo); errors.Is(err, sql.ErrNoRows) {
+		return types.BlobInfo{}, xerrors.Errorf("blob (%s) is missing in PostgreSQL cache", blobID)
+	} else if err != nil {
+		return types.BlobInfo{}, xerrors.Errorf("failed to get blob (%s) from the PostgreSQL cache: %w", blobID, err)
+	}
+	return blobInfo, nil
+}
+
+func (c PostgresCache) get(table, id string, v any) error {
+	var b []byte
+	query := fmt.Sprintf(`SELECT info FROM %s WHERE id = $1 AND (expires_at IS NULL OR expires_at > now())`, table)
+	if err := c.db.QueryRowContext(context.TODO(), query, id).Scan(&b); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return xerrors.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return nil
+}
+
+// MissingBlobs looks up the schema versions of all the blobs in a single query
+func (c PostgresCache) MissingBlobs(artifactID string, blobIDs []string) (bool, []string, error) {
+	versions := make(map[string]int)
+	rows, err := c.db.QueryContext(context.TODO(), `SELECT id, COALESCE((info->>'SchemaVersion')::INTEGER, 0) FROM fanal_blobs
+		WHERE id = ANY($1) AND (expires_at IS NULL OR expires_at > now())`, pq.Array(blobIDs))
+	if err != nil {
+		return false, nil, xerrors.Errorf("failed to get blobs from the PostgreSQL cache: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var version int
+		if err = rows.Scan(&id, &version); err != nil {
+			return false, nil, xerrors.Errorf("failed to scan a row: %w", err)
+		}
+		versions[id] = version
+	}
+	if err = rows.Err(); err != nil {
+		return false, nil, xerrors.Errorf("failed to get blobs from the PostgreSQL cache: %w", err)
+	}
+
+	var missingBlobIDs []string
+	for _, blobID := range blobIDs {
+		// a missing entry means cache missed blob info
+		if version, ok := versions[blobID]; !ok || version != types.BlobJSONSchemaVersion {
+			missingBlobIDs = append(missingBlobIDs, blobID)
+		}
+	}
+
+	// get artifact info
+	artifactInfo, err := c.GetArtifact(artifactID)
+	// error means cache missed artifact info
+	if err != nil {
+		return true, missingBlobIDs, nil
+	}
+	return artifactInfo.SchemaVersion != types.ArtifactJSONSchemaVersion, missingBlobIDs, nil
+}
+
+func (c PostgresCache) DeleteBlobs(blobIDs []string) error {
+	if _, err := c.db.ExecContext(context.TODO(), `DELETE FROM fanal_blobs WHERE id = ANY($1)`, pq.Array(blobIDs)); err != nil {
+		return xerrors.Errorf("unable to delete blobs: %w", err)
+	}
+	return nil
+}
+
+func (c PostgresCache) Close() error {
+	return c.db.Close()
+}
+
+// Clear deletes all the entries, keeping the schema
+func (c PostgresCache) Clear() error {
+	if _, err := c.db.ExecContext(context.TODO(), `TRUNCATE fanal_artifacts, fanal_blobs`); err != nil {
+		return xerrors.Errorf("failed to truncate the tables: %w", err)
+	}
+	return nil
+}
@@ -3,30 +3,46 @@ package cache
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"net/http"
+	"sync/atomic"
 
+	"github.com/samber/lo"
 	"github.com/twitchtv/twirp"
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/rpc"
 	"github.com/aquasecurity/trivy/pkg/rpc/client"
+	"github.com/aquasecurity/trivy/pkg/uuid"
 	rpcCache "github.com/aquasecurity/trivy/rpc/cache"
 )
 
 var _ ArtifactCache = (*RemoteCache)(nil)
 
+// defaultBlobChunkSize is the maximum size of blob info sent in a single request.
+// Larger blob info, e.g. of images with a huge number of files, is split into chunks
+// so that requests don't exceed the message size limits of the server or proxies in between.
+const defaultBlobChunkSize = 4 << 20 // 4MiB
+
 type RemoteOptions struct {
 	ServerAddr    string
 	CustomHeaders http.Header
 	Insecure      bool
 	PathPrefix    string
+	BlobChunkSize int // defaultBlobChunkSize is used if zero
 }
 
 // RemoteCache implements remote cache
 type RemoteCache struct {
-	ctx    context.Context // for custom header
-	client rpcCache.Cache
+	ctx       context.Context // for custom header
+	client    rpcCache.Cache
+	chunkSize int
+
+	// noChunks is set once the server turns out not to support chunked uploads, e.g. older versions
+	noChunks *atomic.Bool
 }
 
 // NewRemoteCache is the factory method for RemoteCache
@@ -48,8 +64,10 @@ func NewRemoteCache(opts RemoteOptions) *RemoteCache {
 	}
 	c := rpcCache.NewCacheProtobufClient(opts.ServerAddr, httpClient, twirpOpts...)
 	return &RemoteCache{
-		ctx:    ctx,
-		client: c,
+		ctx:       ctx,
+		client:    c,
+		chunkSize: lo.Ternary(opts.BlobChunkSize > 0, opts.BlobChunkSize, defaultBlobChunkSize),
+		noChunks:  new(atomic.Bool),
 	}
 }
 
@@ -68,9 +86,17 @@ func (c RemoteCache) PutArtifact(imageID string, artifactInfo types.ArtifactInfo
 
 // PutBlob sends blobInfo to remote client
 func (c RemoteCache) PutBlob(diffID string, blobInfo types.BlobInfo) error {
+	req := rpc.ConvertToRPCPutBlobRequest(diffID, blobInfo)
+	if proto.Size(req) > c.chunkSize && !c.noChunks.Load() {
+		return c.putBlobChunks(req)
+	}
+	return c.putBlob(req)
+}
+
+func (c RemoteCache) putBlob(req *rpcCache.PutBlobRequest) error {
 	err := rpc.Retry(func() error {
 		var err error
-		_, err = c.client.PutBlob(c.ctx, rpc.ConvertToRPCPutBlobRequest(diffID, blobInfo))
+		_, err = c.client.PutBlob(c.ctx, req)
 		return err
 	})
 	if err != nil {
@@ -79,6 +105,42 @@ func (c RemoteCache) PutBlob(diffID string, blobInfo types.BlobInfo) error {
 	return nil
 }
 
+// putBlobChunks sends large blob info in chunks. The server stores it once all the chunks are received.
+// It falls back to a single request if the server doesn't support chunked uploads.
+func (c RemoteCache) putBlobChunks(req *rpcCache.PutBlobRequest) error {
+	b, err := proto.Marshal(req)
+	if err != nil {
+		return xerrors.Errorf("unable to marshal blob info: %w", err)
+	}
+
+	chunks := lo.Chunk(b, c.chunkSize)
+	uploadID := uuid.New().String()
+	log.Debug("Sending blob info in chunks", log.String("diff_id", req.DiffId), log.Int("chunks", len(chunks)))
+	for i, chunk := range chunks {
+		err = rpc.Retry(func() error {
+			_, err := c.client.PutBlobChunk(c.ctx, &rpcCache.PutBlobChunkRequest{
+				DiffId:   req.DiffId,
+				UploadId: uploadID,
+				Index:    int32(i),
+				Total:    int32(len(chunks)),
+				Data:     chunk,
+			})
+			return err
+		})
+		var twerr twirp.Error
+		if i == 0 && errors.As(err, &twerr) && twerr.Code() == twirp.BadRoute {
+			log.Debug("The server doesn't support chunked uploads, sending blob info in a single request",
+				log.String("diff_id", req.DiffId))
+			c.noChunks.Store(true)
+			return c.putBlob(req)
+		}
+		if err != nil {
+			return xerrors.Errorf("unable to store cache on the server: %w", err)
+		}
+	}
+	return nil
+}
+
 // MissingBlobs fetches missing blobs from RemoteCache
 func (c RemoteCache) MissingBlobs(imageID string, layerIDs []string) (bool, []string, error) {
 	var layers *rpcCache.MissingBlobsResponse
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/twitchtv/twirp"
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/aquasecurity/trivy/pkg/cache"
@@ -21,7 +22,9 @@ import (
 )
 
 type mockCacheServer struct {
-	cache cache.Cache
+	cache  cache.Cache
+	blobs  []*rpcCache.PutBlobRequest
+	chunks []*rpcCache.PutBlobChunkRequest
 }
 
 func (s *mockCacheServer) PutArtifact(_ context.Context, in *rpcCache.PutArtifactRequest) (*emptypb.Empty, error) {
@@ -35,6 +38,7 @@ func (s *mockCacheServer) PutBlob(_ context.Context, in *rpcCache.PutBlobRequest
 	if strings.Contains(in.DiffId, "invalid") {
 		return &emptypb.Empty{}, xerrors.New("invalid layer ID")
 	}
+	s.blobs = append(s.blobs, in)
 	return &emptypb.Empty{}, nil
 }
 
@@ -61,6 +65,14 @@ func (s *mockCacheServer) DeleteBlobs(_ context.Context, in *rpcCache.DeleteBlob
 	return &emptypb.Empty{}, nil
 }
 
+func (s *mockCacheServer) PutBlobChunk(_ context.Context, in *rpcCache.PutBlobChunkRequest) (*emptypb.Empty, error) {
+	if strings.Contains(in.DiffId, "invalid") {
+		return &emptypb.Empty{}, xerrors.New("invalid layer ID")
+	}
+	s.chunks = append(s.chunks, in)
+	return &emptypb.Empty{}, nil
+}
+
 func withToken(base http.Handler, token, tokenHeader string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if token != "" && token != r.Header.Get(tokenHeader) {
@@ -227,6 +239,91 @@ func TestRemoteCache_PutBlob(t *testing.T) {
 	}
 }
 
+func TestRemoteCache_PutBlobChunks(t *testing.T) {
+	server := new(mockCacheServer)
+	mux := http.NewServeMux()
+	layerHandler := rpcCache.NewCacheServer(server, nil)
+	mux.Handle(rpcCache.CachePathPrefix, layerHandler)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	diffID := "sha256:dffd9992ca398466a663c87c92cfea2a2db0ae0cf33fcb99da60eec52addbfc5"
+	blobInfo := types.BlobInfo{
+		SchemaVersion: 2,
+		DiffID:        diffID,
+		OpaqueDirs:    []string{"etc/", "usr/lib/", "usr/share/doc/"},
+	}
+
+	c := cache.NewRemoteCache(cache.RemoteOptions{
+		ServerAddr:    ts.URL,
+		BlobChunkSize: 32,
+	})
+	err := c.PutBlob(diffID, blobInfo)
+	require.NoError(t, err)
+
+	// The request is larger than the chunk size
+	require.Greater(t, len(server.chunks), 1)
+
+	var data []byte
+	for i, chunk := range server.chunks {
+		assert.Equal(t, diffID, chunk.DiffId)
+		assert.Equal(t, server.chunks[0].UploadId, chunk.UploadId)
+		assert.Equal(t, int32(i), chunk.Index)
+		assert.Equal(t, int32(len(server.chunks)), chunk.Total)
+		assert.LessOrEqual(t, len(chunk.Data), 32)
+		data = append(data, chunk.Data...)
+	}
+
+	var got rpcCache.PutBlobRequest
+	require.NoError(t, proto.Unmarshal(data, &got))
+	assert.Equal(t, diffID, got.DiffId)
+	assert.Equal(t, blobInfo.OpaqueDirs, got.BlobInfo.OpaqueDirs)
+}
+
+// legacyCacheServer emulates servers that don't support chunked uploads
+type legacyCacheServer struct {
+	*mockCacheServer
+}
+
+func (s legacyCacheServer) PutBlobChunk(context.Context, *rpcCache.PutBlobChunkRequest) (*emptypb.Empty, error) {
+	return nil, twirp.NewError(twirp.BadRoute, "no handler for path")
+}
+
+func TestRemoteCache_PutBlobChunks_Unsupported(t *testing.T) {
+	server := legacyCacheServer{new(mockCacheServer)}
+	mux := http.NewServeMux()
+	layerHandler := rpcCache.NewCacheServer(server, nil)
+	mux.Handle(rpcCache.CachePathPrefix, layerHandler)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	c := cache.NewRemoteCache(cache.RemoteOptions{
+		ServerAddr:    ts.URL,
+		BlobChunkSize: 32,
+	})
+
+	diffIDs := []string{
+		"sha256:dffd9992ca398466a663c87c92cfea2a2db0ae0cf33fcb99da60eec52addbfc5",
+		"sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7",
+	}
+	for _, diffID := range diffIDs {
+		err := c.PutBlob(diffID, types.BlobInfo{
+			SchemaVersion: 2,
+			DiffID:        diffID,
+			OpaqueDirs:    []string{"etc/", "usr/lib/", "usr/share/doc/"},
+		})
+		require.NoError(t, err)
+	}
+
+	// Blob info is sent in a single request instead
+	require.Len(t, server.blobs, len(diffIDs))
+	for i, blob := range server.blobs {
+		assert.Equal(t, diffIDs[i], blob.DiffId)
+		assert.Equal(t, []string{"etc/", "usr/lib/", "usr/share/doc/"}, blob.BlobInfo.OpaqueDirs)
+	}
+	assert.Empty(t, server.chunks)
+}
+
 func TestRemoteCache_MissingBlobs(t *testing.T) {
 	mux := http.NewServeMux()
 	layerHandler := rpcCache.NewCacheServer(new(mockCacheServer), nil)
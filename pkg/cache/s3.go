@@ -0,0 +1,274 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/hashicorp/go-multierror"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var (
+	_ Cache = (*S3Cache)(nil)
+
+	errS3NotFound = xerrors.New("not found")
+)
+
+const (
+	s3Prefix             = "fanal"
+	defaultS3Concurrency = 10
+
+	// s3DeleteBatchSize is the maximum number of keys that can be deleted in a single request
+	s3DeleteBatchSize = 1000
+)
+
+// s3API is the subset of the S3 client used by the cache
+type s3API interface {
+	s3.ListObjectsV2APIClient
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	DeleteObjects(ctx context.Context, params *s3.DeleteObjectsInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error)
+}
+
+// S3Options holds the options for S3 cache
+type S3Options struct {
+	// Backend is the S3 URL, e.g. s3://bucket/prefix
+	Backend string
+	// SSE is the server-side encryption algorithm, e.g. AES256 and aws:kms
+	SSE string
+	// SSEKMSKeyID is the KMS key ID used with the aws:kms algorithm
+	SSEKMSKeyID string
+	// Concurrency is the maximum number of concurrent requests
+	Concurrency int
+}
+
+type S3Cache struct {
+	client      s3API
+	bucket      string
+	prefix      string
+	sse         s3types.ServerSideEncryption
+	sseKMSKeyID string
+	concurrency int
+}
+
+// NewS3Cache returns a cache storing analysis results as JSON objects in an S3 bucket.
+// The credentials and the region are loaded from the default AWS configuration,
+// so that S3-compatible storages can be used via AWS_ENDPOINT_URL_S3.
+func NewS3Cache(ctx context.Context, opts S3Options) (S3Cache, error) {
+	log.Info("S3 scan cache", log.String("url", opts.Backend))
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return S3Cache{}, xerrors.Errorf("aws config load error: %w", err)
+	}
+	return newS3Cache(s3.NewFromConfig(cfg), opts)
+}
+
+func newS3Cache(client s3API, opts S3Options) (S3Cache, error) {
+	u, err := url.Parse(opts.Backend)
+	if err != nil {
+		return S3Cache{}, xerrors.Errorf("failed to parse S3 URL: %w", err)
+	} else if u.Scheme != "s3" || u.Host == "" {
+		return S3Cache{}, xerrors.Errorf("invalid S3 URL %q, must be s3://bucket[/prefix]", opts.Backend)
+	}
+
+	sse := s3types.ServerSideEncryption(opts.SSE)
+	if sse != "" && !slices.Contains(sse.Values(), sse) {
+		return S3Cache{}, xerrors.Errorf("unknown S3 server-side encryption %q, must be one of %q", opts.SSE, sse.Values())
+	} else if opts.SSEKMSKeyID != "" && !strings.HasPrefix(opts.SSE, "aws:kms") {
+		return S3Cache{}, xerrors.New("the S3 KMS key ID requires the aws:kms or aws:kms:dsse server-side encryption")
+	}
+
+	return S3Cache{
+		client:      client,
+		bucket:      u.Host,
+		prefix:      lo.Ternary(strings.Trim(u.Path, "/") != "", strings.Trim(u.Path, "/"), s3Prefix),
+		sse:         sse,
+		sseKMSKeyID: opts.SSEKMSKeyID,
+		concurrency: lo.Ternary(opts.Concurrency > 0, opts.Concurrency, defaultS3Concurrency),
+	}, nil
+}
+
+func (c S3Cache) key(bucket, id string) string {
+	return path.Join(c.prefix, bucket, id)
+}
+
+func (c S3Cache) PutArtifact(artifactID string, artifactInfo types.ArtifactInfo) error {
+	if err := c.put(c.key(artifactBucket, artifactID), artifactInfo); err != nil {
+		return xerrors.Errorf("unable to store artifact information in S3 cache (%s): %w", artifactID, err)
+	}
+	return nil
+}
+
+func (c S3Cache) PutBlob(blobID string, blobInfo types.BlobInfo) error {
+	if err := c.put(c.key(blobBucket, blobID), blobInfo); err != nil {
+		return xerrors.Errorf("unable to store blob information in S3 cache (%s): %w", blobID, err)
+	}
+	return nil
+}
+
+func (c S3Cache) put(key string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal JSON: %w", err)
+	}
+	input := &s3.PutObjectInput{
+		Bucket:               aws.String(c.bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(b),
+		ContentType:          aws.String("application/json"),
+		ServerSideEncryption: c.sse,
+	}
+	if c.sseKMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(c.sseKMSKeyID)
+	}
+	if _, err = c.client.PutObject(context.TODO(), input); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c S3Cache) GetArtifact(artifactID string) (types.ArtifactInfo, error) {
+	var info types.ArtifactInfo
+	if err := c.get(c.key(artifactBucket, artifactID), &info); errors.Is(err, errS3NotFound) {
+		return types.ArtifactInfo{}, xerrors.Errorf("artifact (%s) is missing in S3 cache", artifactID)
+	} else if err != nil {
+		return types.ArtifactInfo{}, xerrors.Errorf("failed to get artifact (%s) from the S3 cache: %w", artifactID, err)
+	}
+	return info, nil
+}
+
+func (c S3Cache) GetBlob(blobID string) (types.BlobInfo, error) {
+	var blobInfo types.BlobInfo
+	if err := c.get(c.key(blobBucket, blobID), &blobInfo); errors.Is(err, errS3NotFound) {
+		return types.BlobInfo{}, xerrors.Errorf("blob (%s) is missing in S3 cache", blobID)
+	} else if err != nil {
+		return types.BlobInfo{}, xerrors.Errorf("failed to get blob (%s) from the S3 cache: %w", blobID, err)
+	}
+	return blobInfo, nil
+}
+
+func (c S3Cache) get(key string, v any) error {
+	out, err := c.client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	var noSuchKey *s3types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return errS3NotFound
+	} else if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return xerrors.Errorf("failed to read the object: %w", err)
+	}
+	if err = json.Unmarshal(b, v); err != nil {
+		return xerrors.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return nil
+}
+
+// MissingBlobs fetches the blobs concurrently as there is no way to get several objects in a single request
+func (c S3Cache) MissingBlobs(artifactID string, blobIDs []string) (bool, []string, error) {
+	missing := make([]bool, len(blobIDs))
+	var g errgroup.Group
+	g.SetLimit(c.concurrency)
+	for i, blobID := range blobIDs {
+		g.Go(func() error {
+			blobInfo, err := c.GetBlob(blobID)
+			// error means cache missed blob info
+			missing[i] = err != nil || blobInfo.SchemaVersion != types.BlobJSONSchemaVersion
+			return nil
+		})
+	}
+
+	var missingArtifact bool
+	g.Go(func() error {
+		artifactInfo, err := c.GetArtifact(artifactID)
+		// error means cache missed artifact info
+		missingArtifact = err != nil || artifactInfo.SchemaVersion != types.ArtifactJSONSchemaVersion
+		return nil
+	})
+	_ = g.Wait()
+
+	var missingBlobIDs []string
+	for i, blobID := range blobIDs {
+		if missing[i] {
+			missingBlobIDs = append(missingBlobIDs, blobID)
+		}
+	}
+	return missingArtifact, missingBlobIDs, nil
+}
+
+func (c S3Cache) DeleteBlobs(blobIDs []string) error {
+	keys := lo.Map(blobIDs, func(blobID string, _ int) string {
+		return c.key(blobBucket, blobID)
+	})
+	return c.delete(keys)
+}
+
+func (c S3Cache) delete(keys []string) error {
+	var errs error
+	for _, chunk := range lo.Chunk(keys, s3DeleteBatchSize) {
+		out, err := c.client.DeleteObjects(context.TODO(), &s3.DeleteObjectsInput{
+			Bucket: aws.String(c.bucket),
+			Delete: &s3types.Delete{
+				Objects: lo.Map(chunk, func(key string, _ int) s3types.ObjectIdentifier {
+					return s3types.ObjectIdentifier{Key: aws.String(key)}
+				}),
+				Quiet: aws.Bool(true),
+			},
+		})
+		if err != nil {
+			errs = multierror.Append(errs, xerrors.Errorf("unable to delete objects: %w", err))
+			continue
+		}
+		for _, e := range out.Errors {
+			errs = multierror.Append(errs, xerrors.Errorf("unable to delete %s: %s", aws.ToString(e.Key), aws.ToString(e.Message)))
+		}
+	}
+	return errs
+}
+
+func (c S3Cache) Close() error {
+	return nil
+}
+
+// Clear deletes all the objects under the prefix
+func (c S3Cache) Clear() error {
+	paginator := s3.NewListObjectsV2Paginator(c.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(c.prefix + "/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return xerrors.Errorf("failed to list objects: %w", err)
+		}
+		keys := lo.Map(page.Contents, func(obj s3types.Object, _ int) string {
+			return aws.ToString(obj.Key)
+		})
+		if err = c.delete(keys); err != nil {
+			return xerrors.Errorf("failed to delete objects: %w", err)
+		}
+	}
+	return nil
+}
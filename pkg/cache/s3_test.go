@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// fakeS3 is an in-memory S3 bucket
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	inputs  []*s3.PutObjectInput
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: make(map[string][]byte)}
+}
+
+func (f *fakeS3) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.objects[aws.ToString(params.Key)]
+	if !ok {
+		return nil, &s3types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(b))}, nil
+}
+
+func (f *fakeS3) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	b, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[aws.ToString(params.Key)] = b
+	f.inputs = append(f.inputs, params)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeS3) DeleteObjects(_ context.Context, params *s3.DeleteObjectsInput, _ ...func(*s3.Options)) (*s3.DeleteObjectsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, obj := range params.Delete.Objects {
+		delete(f.objects, aws.ToString(obj.Key))
+	}
+	return &s3.DeleteObjectsOutput{}, nil
+}
+
+func (f *fakeS3) ListObjectsV2(_ context.Context, params *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var contents []s3types.Object
+	for key := range f.objects {
+		if strings.HasPrefix(key, aws.ToString(params.Prefix)) {
+			contents = append(contents, s3types.Object{Key: aws.String(key)})
+		}
+	}
+	return &s3.ListObjectsV2Output{Contents: contents}, nil
+}
+
+func TestNewS3Cache(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       S3Options
+		wantBucket string
+		wantPrefix string
+		wantErr    string
+	}{
+		{
+			name:       "default prefix",
+			opts:       S3Options{Backend: "s3://bucket"},
+			wantBucket: "bucket",
+			wantPrefix: "fanal",
+		},
+		{
+			name:       "custom prefix",
+			opts:       S3Options{Backend: "s3://bucket/ci/trivy/"},
+			wantBucket: "bucket",
+			wantPrefix: "ci/trivy",
+		},
+		{
+			name:    "no bucket",
+			opts:    S3Options{Backend: "s3:///prefix"},
+			wantErr: "invalid S3 URL",
+		},
+		{
+			name: "KMS key without aws:kms",
+			opts: S3Options{
+				Backend:     "s3://bucket",
+				SSE:         "AES256",
+				SSEKMSKeyID: "alias/trivy",
+			},
+			wantErr: "the S3 KMS key ID requires",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := newS3Cache(newFakeS3(), tt.opts)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBucket, c.bucket)
+			assert.Equal(t, tt.wantPrefix, c.prefix)
+		})
+	}
+}
+
+func TestS3Cache(t *testing.T) {
+	client := newFakeS3()
+	c, err := newS3Cache(client, S3Options{
+		Backend:     "s3://bucket/prefix",
+		SSE:         "aws:kms",
+		SSEKMSKeyID: "alias/trivy",
+		Concurrency: 2,
+	})
+	require.NoError(t, err)
+
+	artifactID := "sha256:8652b9f0cb4c0599575e5a003f5906876e10c1ceb2ab9fe1786712dac14a50cf"
+	blobIDs := []string{
+		"sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7",
+		"sha256:dffd9992ca398466a663c87c92cfea2a2db0ae0cf33fcb99da60eec52addbfc5",
+		"sha256:4d2a3b4a9b2b6a4c3b8a8c8d3b6c4a8f9e1e2d3c4b5a6978877665544332211",
+	}
+
+	missingArtifact, missingBlobIDs, err := c.MissingBlobs(artifactID, blobIDs)
+	require.NoError(t, err)
+	assert.True(t, missingArtifact)
+	assert.Equal(t, blobIDs, missingBlobIDs)
+
+	artifactInfo := types.ArtifactInfo{
+		SchemaVersion: types.ArtifactJSONSchemaVersion,
+		Architecture:  "amd64",
+		OS:            "linux",
+	}
+	require.NoError(t, c.PutArtifact(artifactID, artifactInfo))
+	blobInfo := types.BlobInfo{
+		SchemaVersion: types.BlobJSONSchemaVersion,
+		Digest:        blobIDs[0],
+	}
+	require.NoError(t, c.PutBlob(blobIDs[0], blobInfo))
+	require.NoError(t, c.PutBlob(blobIDs[2], types.BlobInfo{SchemaVersion: 1})) // old schema
+
+	assert.ElementsMatch(t, []string{
+		"prefix/artifact/" + artifactID,
+		"prefix/blob/" + blobIDs[0],
+		"prefix/blob/" + blobIDs[2],
+	}, lo.Keys(client.objects))
+	for _, input := range client.inputs {
+		assert.Equal(t, s3types.ServerSideEncryptionAwsKms, input.ServerSideEncryption)
+		assert.Equal(t, "alias/trivy", aws.ToString(input.SSEKMSKeyId))
+	}
+
+	gotArtifact, err := c.GetArtifact(artifactID)
+	require.NoError(t, err)
+	assert.Equal(t, artifactInfo, gotArtifact)
+
+	gotBlob, err := c.GetBlob(blobIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, blobInfo, gotBlob)
+
+	_, err = c.GetBlob(blobIDs[1])
+	require.ErrorContains(t, err, "is missing in S3 cache")
+
+	missingArtifact, missingBlobIDs, err = c.MissingBlobs(artifactID, blobIDs)
+	require.NoError(t, err)
+	assert.False(t, missingArtifact)
+	assert.Equal(t, blobIDs[1:], missingBlobIDs)
+
+	require.NoError(t, c.DeleteBlobs(blobIDs[:1]))
+	_, err = c.GetBlob(blobIDs[0])
+	require.ErrorContains(t, err, "is missing in S3 cache")
+
+	client.objects["other/blob/"+blobIDs[0]] = []byte("{}")
+	require.NoError(t, c.Clear())
+	assert.Equal(t, []string{"other/blob/" + blobIDs[0]}, lo.Keys(client.objects))
+}
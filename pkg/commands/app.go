@@ -16,9 +16,17 @@ import (
 	"github.com/aquasecurity/trivy/pkg/cache"
 	"github.com/aquasecurity/trivy/pkg/commands/artifact"
 	"github.com/aquasecurity/trivy/pkg/commands/auth"
+	"github.com/aquasecurity/trivy/pkg/commands/browse"
+	bundlecmd "github.com/aquasecurity/trivy/pkg/commands/bundle"
+	"github.com/aquasecurity/trivy/pkg/commands/check"
 	"github.com/aquasecurity/trivy/pkg/commands/clean"
 	"github.com/aquasecurity/trivy/pkg/commands/convert"
+	dbcmd "github.com/aquasecurity/trivy/pkg/commands/db"
+	javadbcmd "github.com/aquasecurity/trivy/pkg/commands/javadb"
+	"github.com/aquasecurity/trivy/pkg/commands/monitor"
+	"github.com/aquasecurity/trivy/pkg/commands/secret"
 	"github.com/aquasecurity/trivy/pkg/commands/server"
+	"github.com/aquasecurity/trivy/pkg/commands/verify"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/flag"
 	k8scommands "github.com/aquasecurity/trivy/pkg/k8s/commands"
@@ -89,19 +97,30 @@ func NewApp() *cobra.Command {
 		NewFilesystemCommand(globalFlags),
 		NewRootfsCommand(globalFlags),
 		NewRepositoryCommand(globalFlags),
+		NewPackageCommand(globalFlags),
+		NewArtifactCommand(globalFlags),
 		NewClientCommand(globalFlags),
 		NewServerCommand(globalFlags),
 		NewConfigCommand(globalFlags),
 		NewConvertCommand(globalFlags),
+		NewBrowseCommand(globalFlags),
+		NewVerifyReportCommand(globalFlags),
 		NewPluginCommand(globalFlags),
 		NewModuleCommand(globalFlags),
 		NewKubernetesCommand(globalFlags),
 		NewSBOMCommand(globalFlags),
+		NewMonitorCommand(globalFlags),
 		NewVersionCommand(globalFlags),
 		NewVMCommand(globalFlags),
 		NewCleanCommand(globalFlags),
+		NewBundleCommand(globalFlags),
+		NewCacheCommand(globalFlags),
+		NewDBCommand(globalFlags),
+		NewJavaDBCommand(globalFlags),
 		NewRegistryCommand(globalFlags),
 		NewVEXCommand(globalFlags),
+		NewSecretCommand(globalFlags),
+		NewCheckCommand(globalFlags),
 	)
 
 	if plugins := loadPluginCommands(); len(plugins) > 0 {
@@ -155,20 +174,54 @@ func loadPluginCommands() []*cobra.Command {
 	return commands
 }
 
-func initConfig(configFile string, pathChanged bool) error {
+func initConfig(ctx context.Context, configFile string, pathChanged bool) error {
 	// Read from config
 	viper.SetConfigFile(configFile)
 	viper.SetConfigType("yaml")
+	configFound := true
 	if err := viper.ReadInConfig(); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			if !pathChanged {
-				log.Debugf("Default config file %q not found, using built in values", log.FilePath(configFile))
-				return nil
-			}
+		if !errors.Is(err, os.ErrNotExist) || pathChanged {
+			return xerrors.Errorf("config file %q loading error: %s", configFile, err)
 		}
-		return xerrors.Errorf("config file %q loading error: %s", configFile, err)
+		log.Debugf("Default config file %q not found, using built in values", log.FilePath(configFile))
+		configFound = false
+	} else {
+		log.Info("Loaded", log.FilePath(configFile))
+	}
+
+	// The base config can be specified in the config file as well as via the flag and the environment variable.
+	baseConfig := viper.GetString(flag.BaseConfigFlag.ConfigName)
+	if baseConfig == "" {
+		return nil
 	}
-	log.Info("Loaded", log.FilePath(configFile))
+	return initBaseConfig(ctx, baseConfig, configFound)
+}
+
+// initBaseConfig reads the remote base config and merges the config file into it
+// so that local settings take precedence over the centrally managed defaults.
+func initBaseConfig(ctx context.Context, baseConfig string, configFound bool) error {
+	// Flags are already bound, so the cache dir and the insecure option are available before parsing.
+	filePath, err := fetchBaseConfig(ctx, baseConfig, viper.GetString(flag.CacheDirFlag.ConfigName),
+		viper.GetBool(flag.InsecureFlag.ConfigName))
+	if err != nil {
+		return xerrors.Errorf("base config error: %w", err)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return xerrors.Errorf("unable to open the base config: %w", err)
+	}
+	defer f.Close()
+
+	if err = viper.ReadConfig(f); err != nil {
+		return xerrors.Errorf("base config %q loading error: %s", baseConfig, err)
+	}
+	if configFound {
+		if err = viper.MergeInConfig(); err != nil {
+			return xerrors.Errorf("config file %q loading error: %s", viper.ConfigFileUsed(), err)
+		}
+	}
+	log.Info("Loaded the base config", log.String("url", baseConfig))
 	return nil
 }
 
@@ -207,7 +260,7 @@ func NewRootCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 
 			// Configure environment variables and config file
 			// It cannot be called in init() because it must be called after viper.BindPFlags.
-			if err := initConfig(configPath, cmd.Flags().Changed(flag.ConfigFileFlag.ConfigName)); err != nil {
+			if err := initConfig(cmd.Context(), configPath, cmd.Flags().Changed(flag.ConfigFileFlag.ConfigName)); err != nil {
 				return err
 			}
 
@@ -254,6 +307,7 @@ func NewImageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	compliance := flag.ComplianceFlag.Clone()
 	compliance.Values = []string{types.ComplianceDockerCIS160}
 	reportFlagGroup.Compliance = compliance // override usage as the accepted values differ for each subcommand.
+	reportFlagGroup.EarlyResults = flag.EarlyResultsFlag.Clone()
 
 	imageFlags := &flag.Flags{
 		GlobalFlagGroup:        globalFlags,
@@ -355,6 +409,7 @@ func NewFilesystemCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	fsFlags.CacheFlagGroup.CacheBackend.Default = string(cache.TypeMemory)                           // Use memory cache by default
 	fsFlags.ReportFlagGroup.ReportFormat.Usage = "specify a compliance report format for the output" // @TODO: support --report summary for non compliance reports
 	fsFlags.ReportFlagGroup.ExitOnEOL = nil                                                          // disable '--exit-on-eol'
+	fsFlags.ScanFlagGroup.Resume = flag.ResumeFlag.Clone()                                           // enable '--resume'
 
 	cmd := &cobra.Command{
 		Use:     "filesystem [flags] PATH",
@@ -413,6 +468,8 @@ func NewRootfsCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	rootfsFlags.ReportFlagGroup.ReportFormat = nil                             // TODO: support --report summary
 	rootfsFlags.ReportFlagGroup.Compliance = nil                               // disable '--compliance'
 	rootfsFlags.ReportFlagGroup.ReportFormat = nil                             // disable '--report'
+	rootfsFlags.ReportFlagGroup.EarlyResults = flag.EarlyResultsFlag.Clone()   // enable '--early-results'
+	rootfsFlags.ScanFlagGroup.Resume = flag.ResumeFlag.Clone()                 // enable '--resume'
 	rootfsFlags.PackageFlagGroup.IncludeDevDeps = nil                          // disable '--include-dev-deps'
 	rootfsFlags.CacheFlagGroup.CacheBackend.Default = string(cache.TypeMemory) // Use memory cache by default
 
@@ -515,6 +572,132 @@ func NewRepositoryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewPackageCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	packageFlags := &flag.Flags{
+		GlobalFlagGroup:        globalFlags,
+		CacheFlagGroup:         flag.NewCacheFlagGroup(),
+		DBFlagGroup:            flag.NewDBFlagGroup(),
+		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
+		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
+		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		PackageFlagGroup:       flag.NewPackageFlagGroup(),
+		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
+		RegoFlagGroup:          flag.NewRegoFlagGroup(),
+		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
+		ReportFlagGroup:        flag.NewReportFlagGroup(),
+		ScanFlagGroup:          flag.NewScanFlagGroup(),
+		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
+	}
+	packageFlags.ReportFlagGroup.ReportFormat = nil    // TODO: support --report summary
+	packageFlags.ReportFlagGroup.Compliance = nil      // disable '--compliance'
+	packageFlags.ReportFlagGroup.ExitOnEOL = nil       // disable '--exit-on-eol'
+	packageFlags.PackageFlagGroup.IncludeDevDeps = nil // disable '--include-dev-deps'
+
+	packageFlags.ScanFlagGroup.DistroFlag = nil // `package` subcommand doesn't support scanning OS packages, so we can disable `--distro`
+
+	packageFlags.CacheFlagGroup.CacheBackend.Default = string(cache.TypeMemory) // Use memory cache by default
+
+	cmd := &cobra.Command{
+		Use:     "package [flags] ECOSYSTEM:NAME@VERSION",
+		Aliases: []string{"pkg"},
+		GroupID: groupScanning,
+		Short:   "[EXPERIMENTAL] Scan a package in the registry",
+		Long: `Download a package from the registry and scan it.
+Supported ecosystems: npm, pypi, gem and crate.`,
+		Example: `  # Scan an npm package
+  $ trivy package npm:lodash@4.17.21
+
+  # Scan an npm scoped package
+  $ trivy package npm:@babel/core@7.24.0
+
+  # Scan a PyPI package
+  $ trivy package pypi:requests@2.31.0`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := packageFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return validateArgs(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := packageFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			options, err := packageFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return artifact.Run(cmd.Context(), options, artifact.TargetPackage)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	packageFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, packageFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func NewArtifactCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	artifactFlags := &flag.Flags{
+		GlobalFlagGroup:        globalFlags,
+		CacheFlagGroup:         flag.NewCacheFlagGroup(),
+		DBFlagGroup:            flag.NewDBFlagGroup(),
+		LicenseFlagGroup:       flag.NewLicenseFlagGroup(),
+		MisconfFlagGroup:       flag.NewMisconfFlagGroup(),
+		ModuleFlagGroup:        flag.NewModuleFlagGroup(),
+		PackageFlagGroup:       flag.NewPackageFlagGroup(),
+		RegistryFlagGroup:      flag.NewRegistryFlagGroup(),
+		RegoFlagGroup:          flag.NewRegoFlagGroup(),
+		RemoteFlagGroup:        flag.NewClientFlags(), // for client/server mode
+		ReportFlagGroup:        flag.NewReportFlagGroup(),
+		ScanFlagGroup:          flag.NewScanFlagGroup(),
+		SecretFlagGroup:        flag.NewSecretFlagGroup(),
+		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
+	}
+	artifactFlags.ReportFlagGroup.ReportFormat = nil // TODO: support --report summary
+	artifactFlags.ReportFlagGroup.Compliance = nil   // disable '--compliance'
+	artifactFlags.ReportFlagGroup.ExitOnEOL = nil    // disable '--exit-on-eol'
+
+	artifactFlags.CacheFlagGroup.CacheBackend.Default = string(cache.TypeMemory) // Use memory cache by default
+
+	cmd := &cobra.Command{
+		Use:     "artifact [flags] TYPE TARGET",
+		GroupID: groupScanning,
+		Short:   "[EXPERIMENTAL] Scan an artifact of a type registered by a module",
+		Long: `Scan an artifact of a type registered by a WASM module, such as a proprietary appliance bundle.
+The module unpacks the artifact and Trivy scans the contents as a filesystem.`,
+		Example: `  # Scan an appliance bundle with a module registering the "appliance" type
+  $ trivy artifact appliance ./firmware.bundle`,
+		Args: cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := artifactFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := artifactFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			// The first argument is the artifact type
+			options, err := artifactFlags.ToOptions(args[1:])
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return artifact.Run(cmd.Context(), options, artifact.TargetKind(args[0]))
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	artifactFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, artifactFlags.Usages(cmd)))
+
+	return cmd
+}
+
 func NewConvertCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	convertFlags := &flag.Flags{
 		GlobalFlagGroup: globalFlags,
@@ -558,6 +741,91 @@ func NewConvertCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewBrowseCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	browseFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+		BrowseFlagGroup: flag.NewBrowseFlagGroup(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "browse [flags] RESULT_JSON",
+		GroupID: groupUtility,
+		Short:   "[EXPERIMENTAL] Browse Trivy JSON report interactively",
+		Long: `Browse Trivy JSON report in an interactive terminal UI.
+Findings can be filtered by severity, target and text, and the selected findings can be exported as a JSON report.`,
+		Example: `  # Browse a report
+  $ trivy image --format json --output result.json alpine:3.15
+  $ trivy browse result.json
+
+  # Export selected findings to another file
+  $ trivy browse --export-path selected.json result.json
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := browseFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := browseFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return browse.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	browseFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, browseFlags.Usages(cmd)))
+
+	return cmd
+}
+
+func NewVerifyReportCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	verifyFlags := &flag.Flags{
+		GlobalFlagGroup:       globalFlags,
+		VerifyReportFlagGroup: flag.NewVerifyReportFlagGroup(),
+	}
+
+	cmd := &cobra.Command{
+		Use:     "verify-report [flags] REPORT",
+		GroupID: groupUtility,
+		Short:   "[EXPERIMENTAL] Verify the signature of a report",
+		Long: `Verify the signature of a report signed with '--signing-key'.
+The signature is also compatible with 'cosign verify-blob'.`,
+		Example: `  # Sign a report
+  $ trivy image --format json --output result.json --signing-key cosign.key alpine:3.20
+
+  # Verify the report with the signature in "result.json.sig"
+  $ trivy verify-report --key cosign.pub result.json
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := verifyFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := verifyFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return verify.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	verifyFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, verifyFlags.Usages(cmd)))
+
+	return cmd
+}
+
 // NewClientCommand returns the 'client' subcommand that is deprecated
 func NewClientCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	remoteFlags := flag.NewClientFlags()
@@ -629,6 +897,7 @@ func NewServerCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	serverFlags.DBFlagGroup.DownloadJavaDBOnly = nil // disable '--download-java-db-only'
 	serverFlags.DBFlagGroup.SkipJavaDBUpdate = nil   // disable '--skip-java-db-update'
 	serverFlags.DBFlagGroup.JavaDBRepositories = nil // disable '--java-db-repository'
+	serverFlags.DBFlagGroup.JavaDBURL = nil          // disable '--java-db-url'
 
 	cmd := &cobra.Command{
 		Use:     "server [flags]",
@@ -664,15 +933,23 @@ func NewServerCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 
 func NewConfigCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	scanFlags := &flag.ScanFlagGroup{
-		// Enable only '--skip-dirs' and '--skip-files' and disable other flags
+		// Enable only '--skip-dirs', '--skip-files' and '--offline-scan' and disable other flags
 		SkipDirs:     flag.SkipDirsFlag.Clone(),
 		SkipFiles:    flag.SkipFilesFlag.Clone(),
 		FilePatterns: flag.FilePatternsFlag.Clone(),
+		OfflineScan:  flag.OfflineScanFlag.Clone(),
 	}
 
 	configFlags := &flag.Flags{
-		GlobalFlagGroup:   globalFlags,
-		CacheFlagGroup:    flag.NewCacheFlagGroup(),
+		GlobalFlagGroup: globalFlags,
+		CacheFlagGroup:  flag.NewCacheFlagGroup(),
+		DBFlagGroup: &flag.DBFlagGroup{
+			// Only the flags to verify the signature of the checks bundle
+			VerifySignature:   flag.VerifyDBSignatureFlag.Clone(),
+			SignatureKey:      flag.DBSignatureKeyFlag.Clone(),
+			SignatureIdentity: flag.DBSignatureIdentityFlag.Clone(),
+			SignatureIssuer:   flag.DBSignatureOIDCIssuerFlag.Clone(),
+		},
 		MisconfFlagGroup:  flag.NewMisconfFlagGroup(),
 		ModuleFlagGroup:   flag.NewModuleFlagGroup(),
 		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
@@ -979,6 +1256,10 @@ func NewKubernetesCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	}
 	reportFlagGroup.Compliance = compliance // override usage as the accepted values differ for each subcommand.
 	reportFlagGroup.ExitOnEOL = nil         // disable '--exit-on-eol'
+	reportFlagGroup.GraphFormat = nil       // disable '--graph-format'
+	reportFlagGroup.CSVColumns = nil        // disable '--csv-columns'
+	reportFlagGroup.GitHubSubmit = nil      // disable '--github-submit'
+	reportFlagGroup.SigningKey = nil        // disable '--signing-key'
 
 	formatFlag := flag.FormatFlag.Clone()
 	formatFlag.Values = xstrings.ToStringSlice([]types.Format{
@@ -1078,6 +1359,7 @@ func NewVMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	vmFlags.PackageFlagGroup.IncludeDevDeps = nil          // disable '--include-dev-deps'
 	vmFlags.MisconfFlagGroup.CloudformationParamVars = nil // disable '--cf-params'
 	vmFlags.MisconfFlagGroup.TerraformTFVars = nil         // disable '--tf-vars'
+	vmFlags.ReportFlagGroup.EarlyResults = flag.EarlyResultsFlag.Clone()
 
 	cmd := &cobra.Command{
 		Use:     "vm [flags] VM_IMAGE",
@@ -1195,6 +1477,74 @@ func NewSBOMCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewMonitorCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	reportFlagGroup := flag.NewReportFlagGroup()
+	reportFlagGroup.DependencyTree = nil // disable '--dependency-tree'
+	reportFlagGroup.ListAllPkgs = nil    // disable '--list-all-pkgs'
+	reportFlagGroup.ReportFormat = nil   // disable '--report'
+	reportFlagGroup.Compliance = nil     // disable '--compliance'
+	reportFlagGroup.ExitOnEOL = nil      // disable '--exit-on-eol'
+	reportFlagGroup.GraphFormat = nil    // disable '--graph-format'
+	reportFlagGroup.CSVColumns = nil     // disable '--csv-columns'
+	reportFlagGroup.GitHubSubmit = nil   // disable '--github-submit'
+	reportFlagGroup.SigningKey = nil     // disable '--signing-key'
+
+	scanFlagGroup := flag.NewScanFlagGroup()
+	scanFlagGroup.Scanners = nil // only vulnerabilities are monitored
+	scanFlagGroup.Parallel = nil // disable '--parallel'
+
+	monitorFlags := &flag.Flags{
+		GlobalFlagGroup:        globalFlags,
+		CacheFlagGroup:         flag.NewCacheFlagGroup(),
+		DBFlagGroup:            flag.NewDBFlagGroup(),
+		MonitorFlagGroup:       flag.NewMonitorFlagGroup(),
+		PackageFlagGroup:       flag.NewPackageFlagGroup(),
+		RegistryFlagGroup:      flag.NewRegistryFlagGroup(), // for DBs in private registries
+		ReportFlagGroup:        reportFlagGroup,
+		ScanFlagGroup:          scanFlagGroup,
+		VulnerabilityFlagGroup: flag.NewVulnerabilityFlagGroup(),
+	}
+
+	monitorFlags.CacheFlagGroup.CacheBackend.Default = string(cache.TypeMemory) // Use memory cache by default
+	monitorFlags.PackageFlagGroup.IncludeDevDeps = nil                          // disable '--include-dev-deps'
+
+	cmd := &cobra.Command{
+		Use:     "monitor [flags] SBOM_DIR",
+		Short:   "[EXPERIMENTAL] Re-evaluate stored SBOMs and report newly applicable vulnerabilities",
+		GroupID: groupScanning,
+		Example: `  # Evaluate SBOMs once and report vulnerabilities found since the last evaluation
+  $ trivy monitor /path/to/sboms
+
+  # Re-evaluate SBOMs every 24 hours
+  $ trivy monitor --interval 24h /path/to/sboms
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := monitorFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return validateArgs(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options, err := monitorFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			options.Scanners = types.Scanners{
+				types.VulnerabilityScanner,
+				types.SBOMScanner,
+			}
+			return monitor.Run(cmd.Context(), options)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+	monitorFlags.AddFlags(cmd)
+	cmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, monitorFlags.Usages(cmd)))
+
+	return cmd
+}
+
 func NewCleanCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	cleanFlags := &flag.Flags{
 		GlobalFlagGroup: globalFlags,
@@ -1237,6 +1587,453 @@ func NewCleanCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewBundleCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "bundle subcommand",
+		GroupID:       groupManagement,
+		Short:         "Export and import the databases for air-gapped environments",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Example: `  # Download the databases and export them on a machine with internet access
+  $ trivy bundle export trivy-bundle.tar.gz
+
+  # Import the databases in an air-gapped environment
+  $ trivy bundle import trivy-bundle.tar.gz
+`,
+	}
+
+	exportFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+		DBFlagGroup:     flag.NewDBFlagGroup(),
+		MisconfFlagGroup: &flag.MisconfFlagGroup{
+			ChecksBundleRepository: flag.ChecksBundleRepositoryFlag.Clone(), // Only '--checks-bundle-repository'
+		},
+		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
+		RegoFlagGroup: &flag.RegoFlagGroup{
+			SkipCheckUpdate: flag.SkipCheckUpdateFlag.Clone(), // Only '--skip-check-update'
+		},
+		VulnerabilityFlagGroup: &flag.VulnerabilityFlagGroup{
+			SkipVEXRepoUpdate: flag.SkipVEXRepoUpdateFlag.Clone(), // Only '--skip-vex-repo-update'
+		},
+	}
+	exportFlags.DBFlagGroup.Reset = nil              // disable '--reset'
+	exportFlags.DBFlagGroup.DownloadDBOnly = nil     // disable '--download-db-only'
+	exportFlags.DBFlagGroup.DownloadJavaDBOnly = nil // disable '--download-java-db-only'
+	exportFlags.DBFlagGroup.Light = nil              // disable '--light'
+	exportCmd := &cobra.Command{
+		Use:   "export [flags] ARCHIVE_PATH",
+		Short: "Download the databases and export them to an archive",
+		Long: `Download the vulnerability DB, the Java DB, the checks bundle and the VEX repositories into the cache directory,
+and export them to a gzipped tarball to be imported with 'trivy bundle import'.
+The archive contains SHA-256 digests of the files, which are verified when importing.
+Use '--skip-*-update' flags to export the databases in the cache directory as they are.`,
+		Example: `  # Export the latest databases
+  $ trivy bundle export trivy-bundle.tar.gz
+
+  # Export the databases from a mirror
+  $ trivy bundle export --db-repository registry.example.com/trivy-db:2 \
+      --java-db-repository registry.example.com/trivy-java-db:1 \
+      --checks-bundle-repository registry.example.com/trivy-checks:1 trivy-bundle.tar.gz
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := exportFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := exportFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return bundlecmd.Export(cmd.Context(), opts, args[0])
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	exportCmd.SetFlagErrorFunc(flagErrorFunc)
+	exportFlags.AddFlags(exportCmd)
+	exportCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, exportFlags.Usages(exportCmd)))
+
+	importFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+	}
+	importCmd := &cobra.Command{
+		Use:   "import [flags] ARCHIVE_PATH",
+		Short: "Import the databases from an archive",
+		Long: `Import the databases exported by 'trivy bundle export' into the cache directory.
+The digests and the schema versions are verified before any database in the cache directory is replaced.
+Use '--skip-db-update', '--skip-java-db-update', '--skip-check-update' and '--skip-vex-repo-update' when scanning,
+so that Trivy doesn't try to download the databases.`,
+		Example: `  # Import the databases
+  $ trivy bundle import trivy-bundle.tar.gz
+
+  # Scan with the imported databases
+  $ trivy image --skip-db-update --skip-java-db-update --skip-check-update alpine:3.20
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := importFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return bundlecmd.Import(cmd.Context(), opts, args[0])
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	importCmd.SetFlagErrorFunc(flagErrorFunc)
+
+	cmd.AddCommand(exportCmd, importCmd)
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+
+	return cmd
+}
+
+func NewCacheCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "cache subcommand",
+		GroupID:       groupUtility,
+		Short:         "Manage the scan cache",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	cacheFlagGroup := flag.NewCacheFlagGroup()
+	cacheFlagGroup.ClearCache = nil
+	cacheFlagGroup.CacheBackend = nil // only the fs backend can be pruned
+	cacheFlagGroup.RedisTLS = nil
+	cacheFlagGroup.RedisCACert = nil
+	cacheFlagGroup.RedisCert = nil
+	cacheFlagGroup.RedisKey = nil
+	cacheFlagGroup.S3SSE = nil
+	cacheFlagGroup.S3SSEKMSKeyID = nil
+	cacheFlagGroup.S3Concurrency = nil
+
+	pruneFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+		CacheFlagGroup:  cacheFlagGroup,
+	}
+	pruneCmd := &cobra.Command{
+		Use:   "prune [flags]",
+		Short: "Remove stale entries from the scan cache",
+		Long: `Remove the entries of the filesystem scan cache which haven't been used for the TTL,
+then the least recently used entries until the cache fits in the max size.
+
+The same limits are applied when scanning with the fs cache backend if '--cache-ttl' or '--cache-max-size' is specified.`,
+		Example: `  # Remove entries unused for a week
+  $ trivy cache prune --cache-ttl 168h
+
+  # Keep the scan cache under 10GB
+  $ trivy cache prune --cache-max-size 10GB
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := pruneFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := pruneFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return clean.Prune(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	pruneCmd.SetFlagErrorFunc(flagErrorFunc)
+	pruneFlags.AddFlags(pruneCmd)
+	pruneCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, pruneFlags.Usages(pruneCmd)))
+
+	statsFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+	}
+	statsCmd := &cobra.Command{
+		Use:   "stats [flags]",
+		Short: "Show statistics of the scan cache",
+		Long: `Show the hit ratio of the filesystem scan cache, and the number and the size of its entries per type.
+
+Hits and misses are counted when scans look up the analysis results of artifacts and layers,
+so a low hit ratio means that layers are analyzed again unexpectedly.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := statsFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := statsFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return clean.Stats(cmd.Context(), opts, cmd.OutOrStdout())
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	statsCmd.SetFlagErrorFunc(flagErrorFunc)
+	statsFlags.AddFlags(statsCmd)
+	statsCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, statsFlags.Usages(statsCmd)))
+
+	inspectFlags := &flag.Flags{
+		GlobalFlagGroup:       globalFlags,
+		CacheInspectFlagGroup: flag.NewCacheInspectFlagGroup(),
+	}
+	inspectCmd := &cobra.Command{
+		Use:   "inspect [flags] DIGEST",
+		Short: "Inspect the scan cache entries of a layer",
+		Long: `Show the scan cache entries matching the cache key, the layer digest or the layer diff ID.
+
+The entries can be deleted with '--delete' so that they are analyzed again on the next scan.`,
+		Example: `  # Show the cached analysis result of a layer
+  $ trivy cache inspect sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7
+
+  # Delete it
+  $ trivy cache inspect --delete sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := inspectFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := inspectFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return clean.Inspect(cmd.Context(), opts, cmd.OutOrStdout())
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	inspectCmd.SetFlagErrorFunc(flagErrorFunc)
+	inspectFlags.AddFlags(inspectCmd)
+	inspectCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, inspectFlags.Usages(inspectCmd)))
+
+	cmd.AddCommand(pruneCmd, statsCmd, inspectCmd)
+	return cmd
+}
+
+func NewDBCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "db subcommand",
+		GroupID:       groupManagement,
+		Short:         "Manage the databases",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	serveFlags := &flag.Flags{
+		GlobalFlagGroup:   globalFlags,
+		DBFlagGroup:       flag.NewDBFlagGroup(),
+		DBMirrorFlagGroup: flag.NewDBMirrorFlagGroup(),
+		MisconfFlagGroup: &flag.MisconfFlagGroup{
+			ChecksBundleRepository: flag.ChecksBundleRepositoryFlag.Clone(), // Only '--checks-bundle-repository'
+		},
+		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
+	}
+	// Only the repositories are available
+	serveFlags.DBFlagGroup.Reset = nil              // disable '--reset'
+	serveFlags.DBFlagGroup.DownloadDBOnly = nil     // disable '--download-db-only'
+	serveFlags.DBFlagGroup.SkipDBUpdate = nil       // disable '--skip-db-update'
+	serveFlags.DBFlagGroup.DownloadJavaDBOnly = nil // disable '--download-java-db-only'
+	serveFlags.DBFlagGroup.SkipJavaDBUpdate = nil   // disable '--skip-java-db-update'
+	serveFlags.DBFlagGroup.NoProgress = nil         // disable '--no-progress'
+	serveFlags.DBFlagGroup.Light = nil              // disable '--light'
+	serveFlags.DBFlagGroup.DBURL = nil              // disable '--db-url'
+	serveFlags.DBFlagGroup.DBDelta = nil            // disable '--db-delta'
+	serveFlags.DBFlagGroup.JavaDBURL = nil          // disable '--java-db-url'
+	serveFlags.DBFlagGroup.VerifySignature = nil    // disable '--verify-db-signature'
+	serveFlags.DBFlagGroup.SignatureKey = nil       // disable '--db-signature-key'
+	serveFlags.DBFlagGroup.SignatureIdentity = nil  // disable '--db-signature-identity'
+	serveFlags.DBFlagGroup.SignatureIssuer = nil    // disable '--db-signature-oidc-issuer'
+	serveCmd := &cobra.Command{
+		Use:   "serve [flags]",
+		Short: "Serve a mirror of the databases and the checks bundle",
+		Long: `Serve the vulnerability DB, the Java DB and the checks bundle as a read-only OCI registry from a local directory,
+so that air-gapped sites can run a mirror without deploying a registry.
+The artifacts are synced with the upstream repositories periodically, and served as
+"aquasecurity/trivy-db", "aquasecurity/trivy-java-db" and "aquasecurity/trivy-checks" with the upstream tags.`,
+		Example: `  # Serve the mirror
+  $ trivy db serve --listen 0.0.0.0:4955 --tls-cert cert.pem --tls-key key.pem
+
+  # Scan with the mirror
+  $ trivy image --db-repository mirror.example.com:4955/aquasecurity/trivy-db:2 \
+      --java-db-repository mirror.example.com:4955/aquasecurity/trivy-java-db:1 \
+      --checks-bundle-repository mirror.example.com:4955/aquasecurity/trivy-checks:1 alpine:3.20
+
+  # Serve the mirror directory copied into an air-gapped environment without syncing
+  $ trivy db serve --mirror-dir /path/to/mirror --sync-interval 0
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := serveFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := serveFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return dbcmd.Serve(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	serveCmd.SetFlagErrorFunc(flagErrorFunc)
+	serveFlags.AddFlags(serveCmd)
+	serveCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, serveFlags.Usages(serveCmd)))
+
+	cmd.AddCommand(serveCmd)
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+
+	return cmd
+}
+
+func NewJavaDBCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "java-db subcommand",
+		GroupID:       groupManagement,
+		Short:         "Manage the Java database",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		Example: `  # Download the Java DB on a machine with internet access
+  $ trivy java-db download
+
+  # Export the Java DB
+  $ trivy java-db export java-db.tar.gz
+
+  # Import the Java DB in an air-gapped environment
+  $ trivy java-db import java-db.tar.gz
+`,
+	}
+
+	downloadFlags := &flag.Flags{
+		GlobalFlagGroup:   globalFlags,
+		DBFlagGroup:       flag.NewDBFlagGroup(),
+		RegistryFlagGroup: flag.NewRegistryFlagGroup(),
+	}
+	// Only the flags for the Java DB are available
+	downloadFlags.DBFlagGroup.Reset = nil              // disable '--reset'
+	downloadFlags.DBFlagGroup.DownloadDBOnly = nil     // disable '--download-db-only'
+	downloadFlags.DBFlagGroup.SkipDBUpdate = nil       // disable '--skip-db-update'
+	downloadFlags.DBFlagGroup.DownloadJavaDBOnly = nil // disable '--download-java-db-only'
+	downloadFlags.DBFlagGroup.SkipJavaDBUpdate = nil   // disable '--skip-java-db-update'
+	downloadFlags.DBFlagGroup.Light = nil              // disable '--light'
+	downloadFlags.DBFlagGroup.DBRepositories = nil     // disable '--db-repository'
+	downloadFlags.DBFlagGroup.DBURL = nil              // disable '--db-url'
+	downloadFlags.DBFlagGroup.DBDelta = nil            // disable '--db-delta'
+	downloadCmd := &cobra.Command{
+		Use:   "download [flags]",
+		Short: "Download the Java DB into the cache directory",
+		Long: `Download the Java DB into the cache directory in the same way as '--download-java-db-only', and verify it.
+The downloaded Java DB can be exported with 'trivy java-db export'.`,
+		Example: `  # Download the Java DB from a mirror
+  $ trivy java-db download --java-db-repository registry.example.com/trivy-java-db:1
+`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := downloadFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := downloadFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return javadbcmd.Download(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	downloadCmd.SetFlagErrorFunc(flagErrorFunc)
+	downloadFlags.AddFlags(downloadCmd)
+	downloadCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, downloadFlags.Usages(downloadCmd)))
+
+	exportFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+		JavaDBFlagGroup: flag.NewJavaDBFlagGroup(),
+	}
+	exportCmd := &cobra.Command{
+		Use:   "export [flags] ARCHIVE_PATH",
+		Short: "Export the cached Java DB to an archive",
+		Long: `Export the Java DB in the cache directory to a gzipped tarball.
+The archive contains SHA-256 digests of the files, which are verified by 'trivy java-db import'.
+With '--group-ids', only the indexes of the artifacts with the group IDs are exported to reduce the size.`,
+		Example: `  # Export the whole Java DB
+  $ trivy java-db export java-db.tar.gz
+
+  # Export only the indexes of artifacts under "org.apache" and "com.example"
+  $ trivy java-db export --group-ids org.apache,com.example java-db.tar.gz
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := exportFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := exportFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return javadbcmd.Export(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	exportCmd.SetFlagErrorFunc(flagErrorFunc)
+	exportFlags.AddFlags(exportCmd)
+	exportCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, exportFlags.Usages(exportCmd)))
+
+	importFlags := &flag.Flags{
+		GlobalFlagGroup: globalFlags,
+		JavaDBFlagGroup: flag.NewJavaDBFlagGroup(),
+	}
+	importFlags.JavaDBFlagGroup.GroupIDs = nil // disable '--group-ids'
+	importCmd := &cobra.Command{
+		Use:   "import [flags] ARCHIVE_PATH",
+		Short: "Import the Java DB from an archive",
+		Long: `Import the Java DB exported by 'trivy java-db export' into the cache directory.
+The digests and the schema version are verified before the cached Java DB is replaced.
+Use '--skip-java-db-update' when scanning, so that Trivy doesn't try to download the Java DB.`,
+		Example: `  # Import the Java DB
+  $ trivy java-db import java-db.tar.gz
+
+  # Scan with the imported Java DB
+  $ trivy image --skip-java-db-update alpine:3.20
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := importFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return javadbcmd.Import(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	importCmd.SetFlagErrorFunc(flagErrorFunc)
+
+	cmd.AddCommand(downloadCmd, exportCmd, importCmd)
+	cmd.SetFlagErrorFunc(flagErrorFunc)
+
+	return cmd
+}
+
 func NewRegistryCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:           "registry [flags]",
@@ -1379,6 +2176,119 @@ func NewVEXCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	return cmd
 }
 
+func NewSecretCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "secret subcommand",
+		GroupID:       groupUtility,
+		Short:         "[EXPERIMENTAL] Secret scanning utilities",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	testFlags := &flag.Flags{
+		GlobalFlagGroup:         globalFlags,
+		SecretRuleTestFlagGroup: flag.NewSecretRuleTestFlagGroup(),
+	}
+	testCmd := &cobra.Command{
+		Use:   "test [flags] RULES_FILE",
+		Short: "Test custom secret rules against sample files",
+		Long: `Test custom secret rules against positive and negative sample files.
+
+Samples are stored in a directory per rule ID under the directory specified with '--cases'.
+Files under "positive" must be detected by the rule, and files under "negative" must not be detected.
+The command exits with a non-zero status if any rule misses a positive sample or matches a negative sample.`,
+		Example: `  # Test rules with samples
+  $ trivy secret test trivy-secret.yaml --cases cases/
+
+  # Directory layout of samples
+  cases/
+  └── my-rule-id/
+      ├── positive/
+      │   └── config.yaml
+      └── negative/
+          └── README.txt
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := testFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := testFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return secret.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	testCmd.SetFlagErrorFunc(flagErrorFunc)
+	testFlags.AddFlags(testCmd)
+	testCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, testFlags.Usages(testCmd)))
+
+	cmd.AddCommand(testCmd)
+	return cmd
+}
+
+func NewCheckCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "check subcommand",
+		GroupID:       groupUtility,
+		Short:         "[EXPERIMENTAL] Custom check utilities",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+
+	testFlags := &flag.Flags{
+		GlobalFlagGroup:    globalFlags,
+		CheckTestFlagGroup: flag.NewCheckTestFlagGroup(),
+	}
+	testCmd := &cobra.Command{
+		Use:   "test [flags] PATH...",
+		Short: "Run the unit tests of custom Rego checks",
+		Long: `Run the unit tests of custom Rego checks with the same engine as misconfiguration scanning.
+
+Tests are rules prefixed with "test_" in "*_test.rego" files found in the specified files and directories.
+Checks are compiled with Trivy's built-in functions, such as "result.new", the embedded libraries, such as "data.lib.kubernetes",
+and the input schemas declared in their metadata.
+The command exits with a non-zero status if any test fails.`,
+		Example: `  # Run the tests of checks in a directory
+  $ trivy check test ./checks
+
+  # Run the tests with data documents
+  $ trivy check test ./checks --data ./data
+
+  # Run only the matching tests
+  $ trivy check test ./checks --run "test_deny_.*"
+`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := testFlags.Bind(cmd); err != nil {
+				return xerrors.Errorf("flag bind error: %w", err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := testFlags.ToOptions(args)
+			if err != nil {
+				return xerrors.Errorf("flag error: %w", err)
+			}
+			return check.Run(cmd.Context(), opts)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	testCmd.SetFlagErrorFunc(flagErrorFunc)
+	testFlags.AddFlags(testCmd)
+	testCmd.SetUsageTemplate(fmt.Sprintf(usageTemplate, testFlags.Usages(testCmd)))
+
+	cmd.AddCommand(testCmd)
+	return cmd
+}
+
 func NewVersionCommand(globalFlags *flag.GlobalFlagGroup) *cobra.Command {
 	var versionFormat string
 	cmd := &cobra.Command{
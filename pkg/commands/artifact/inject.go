@@ -46,6 +46,21 @@ func initializeRepositoryScanner(ctx context.Context, url string, cacheOptions c
 	return scanner.Scanner{}, nil, nil
 }
 
+// initializePackageScanner is for package scanning in standalone mode
+// e.g. npm:lodash@4.17.21
+func initializePackageScanner(ctx context.Context, target string, cacheOptions cache.Options, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.StandalonePackageSet)
+	return scanner.Scanner{}, nil, nil
+}
+
+// initializeCustomScanner is for scanning artifact types registered by embedding applications or modules
+// in standalone mode
+func initializeCustomScanner(ctx context.Context, artifactType artifact.Type, target string, cacheOptions cache.Options,
+	artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.StandaloneCustomSet)
+	return scanner.Scanner{}, nil, nil
+}
+
 func initializeSBOMScanner(ctx context.Context, filePath string, cacheOptions cache.Options, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
 	wire.Build(scanner.StandaloneSBOMSet)
 	return scanner.Scanner{}, nil, nil
@@ -93,6 +108,21 @@ func initializeRemoteRepositoryScanner(ctx context.Context, url string, remoteCa
 	return scanner.Scanner{}, nil, nil
 }
 
+// initializeRemotePackageScanner is for package scanning in client/server mode
+func initializeRemotePackageScanner(ctx context.Context, target string, remoteCacheOptions cache.RemoteOptions,
+	remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
+	wire.Build(scanner.RemotePackageSet)
+	return scanner.Scanner{}, nil, nil
+}
+
+// initializeRemoteCustomScanner is for scanning registered artifact types in client/server mode
+func initializeRemoteCustomScanner(ctx context.Context, artifactType artifact.Type, target string,
+	remoteCacheOptions cache.RemoteOptions, remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (
+	scanner.Scanner, func(), error) {
+	wire.Build(scanner.RemoteCustomSet)
+	return scanner.Scanner{}, nil, nil
+}
+
 // initializeRemoteSBOMScanner is for sbom scanning in client/server mode
 func initializeRemoteSBOMScanner(ctx context.Context, path string, remoteCacheOptions cache.RemoteOptions,
 	remoteScanOptions client.ScannerOption, artifactOption artifact.Option) (scanner.Scanner, func(), error) {
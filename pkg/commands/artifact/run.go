@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"slices"
 
 	"github.com/hashicorp/go-multierror"
@@ -17,6 +18,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/db"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/custom"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/fanal/walker"
 	"github.com/aquasecurity/trivy/pkg/flag"
@@ -43,6 +45,7 @@ const (
 	TargetRepository     TargetKind = "repo"
 	TargetSBOM           TargetKind = "sbom"
 	TargetVM             TargetKind = "vm"
+	TargetPackage        TargetKind = "package"
 )
 
 var (
@@ -80,6 +83,10 @@ type Runner interface {
 	ScanSBOM(ctx context.Context, opts flag.Options) (types.Report, error)
 	// ScanVM scans VM
 	ScanVM(ctx context.Context, opts flag.Options) (types.Report, error)
+	// ScanPackage scans a package in the registry
+	ScanPackage(ctx context.Context, opts flag.Options) (types.Report, error)
+	// ScanCustom scans an artifact of the type registered by embedding applications or modules
+	ScanCustom(ctx context.Context, opts flag.Options, artifactType artifact.Type) (types.Report, error)
 	// Filter filter a report
 	Filter(ctx context.Context, opts flag.Options, report types.Report) (types.Report, error)
 	// Report a writes a report
@@ -252,6 +259,41 @@ func (r *runner) ScanVM(ctx context.Context, opts flag.Options) (types.Report, e
 	return r.scanArtifact(ctx, opts, s)
 }
 
+func (r *runner) ScanPackage(ctx context.Context, opts flag.Options) (types.Report, error) {
+	// Do not scan OS packages
+	opts.PkgTypes = []string{types.PkgTypeLibrary}
+
+	// The package is laid out as installed, so lock files in the package are not scanned, as with rootfs.
+	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeOSes...)
+	opts.DisabledAnalyzers = append(opts.DisabledAnalyzers, analyzer.TypeLockfiles...)
+
+	var s InitializeScanner
+	if opts.ServerAddr == "" {
+		// Scan the package in standalone mode
+		s = packageStandaloneScanner
+	} else {
+		// Scan the package in client/server mode
+		s = packageRemoteScanner
+	}
+	return r.scanArtifact(ctx, opts, s)
+}
+
+func (r *runner) ScanCustom(ctx context.Context, opts flag.Options, artifactType artifact.Type) (types.Report, error) {
+	if !custom.Registered(artifactType) {
+		return types.Report{}, xerrors.Errorf("unknown artifact type: %s", artifactType)
+	}
+
+	var s InitializeScanner
+	if opts.ServerAddr == "" {
+		// Scan the artifact in standalone mode
+		s = customStandaloneScanner(artifactType)
+	} else {
+		// Scan the artifact in client/server mode
+		s = customRemoteScanner(artifactType)
+	}
+	return r.scanArtifact(ctx, opts, s)
+}
+
 func (r *runner) scanArtifact(ctx context.Context, opts flag.Options, initializeScanner InitializeScanner) (types.Report, error) {
 	if r.initializeScanner != nil {
 		initializeScanner = r.initializeScanner
@@ -292,7 +334,7 @@ func (r *runner) initDB(ctx context.Context, opts flag.Options) error {
 
 	// download the database file
 	noProgress := opts.Quiet || opts.NoProgress
-	if err := operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepositories, noProgress, opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
+	if err := operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepositories, opts.DBURL, opts.DBDelta, noProgress, opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
 		return err
 	}
 
@@ -322,7 +364,7 @@ func (r *runner) initJavaDB(opts flag.Options) error {
 
 	// Update the Java DB
 	noProgress := opts.Quiet || opts.NoProgress
-	javadb.Init(opts.CacheDir, opts.JavaDBRepositories, opts.SkipJavaDBUpdate, noProgress, opts.RegistryOpts())
+	javadb.Init(opts.CacheDir, opts.JavaDBRepositories, opts.JavaDBURL, opts.SkipJavaDBUpdate, noProgress, opts.RegistryOpts())
 	if opts.DownloadJavaDBOnly {
 		if err := javadb.Update(); err != nil {
 			return xerrors.Errorf("Java DB error: %w", err)
@@ -343,6 +385,7 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 			// e.g. https://aquasecurity.github.io/trivy/latest/docs/configuration/
 			log.WarnContext(ctx, fmt.Sprintf("Provide a higher timeout value, see %s", doc.URL("/docs/configuration/", "")))
 		}
+		err = operation.ExitOnError(opts, err)
 	}()
 
 	if opts.ServerAddr != "" && opts.Scanners.AnyEnabled(types.MisconfigScanner, types.SecretScanner) {
@@ -390,14 +433,41 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 		TargetRepository:     r.ScanRepository,
 		TargetSBOM:           r.ScanSBOM,
 		TargetVM:             r.ScanVM,
+		TargetPackage:        r.ScanPackage,
 	}
 
 	scanFunction, exists := scans[targetKind]
-	if !exists {
+	if !exists && custom.Registered(artifact.Type(targetKind)) {
+		// Artifact types registered by embedding applications or modules
+		scanFunction = func(ctx context.Context, opts flag.Options) (types.Report, error) {
+			return r.ScanCustom(ctx, opts, artifact.Type(targetKind))
+		}
+	} else if !exists {
 		return xerrors.Errorf("unknown target kind: %s", targetKind)
 	}
 
+	var early *earlyResults
+	if opts.EarlyResults {
+		// Keep the output open so that the early results and the full results are written to the same output.
+		output, cleanup, oerr := opts.OutputWriter(ctx)
+		if oerr != nil {
+			return xerrors.Errorf("failed to create a file: %w", oerr)
+		}
+		defer func() {
+			if cerr := cleanup(); cerr != nil {
+				err = multierror.Append(err, cerr)
+			}
+		}()
+		opts.SetOutputWriter(output)
+
+		early = startEarlyResults(ctx, r, opts, scanFunction)
+	}
+
 	report, err := scanFunction(ctx, opts)
+	if early != nil {
+		// The early results are not written once the full results are available
+		early.stop()
+	}
 	if err != nil {
 		return xerrors.Errorf("%s scan error: %w", targetKind, err)
 	}
@@ -411,7 +481,80 @@ func Run(ctx context.Context, opts flag.Options, targetKind TargetKind) (err err
 		return xerrors.Errorf("report error: %w", err)
 	}
 
-	return operation.Exit(opts, report.Results.Failed(), report.Metadata)
+	return operation.Exit(opts, report.Results.Failed(), false, report.Metadata)
+}
+
+// earlyResults reports vulnerabilities in OS packages while the full scan is running
+type earlyResults struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startEarlyResults starts scanning only OS packages concurrently with the full scan.
+// OS analyzers are cheap while language and license analyzers can take minutes for large images,
+// so interactive users see vulnerabilities in OS packages first.
+func startEarlyResults(ctx context.Context, r Runner, opts flag.Options,
+	scan func(context.Context, flag.Options) (types.Report, error)) *earlyResults {
+	ctx, cancel := context.WithCancel(ctx)
+	e := &earlyResults{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(e.done)
+		// The early results are optional, so the full scan continues even if they fail
+		if err := reportEarlyResults(ctx, r, opts, scan); err != nil && ctx.Err() == nil {
+			log.WarnContext(ctx, "Unable to report early results", log.Err(err))
+		}
+	}()
+	return e
+}
+
+// stop cancels the early scan if it is still running and waits until it returns,
+// so that the early results are never written after the full results.
+func (e *earlyResults) stop() {
+	e.cancel()
+	<-e.done
+}
+
+// reportEarlyResults scans only OS packages and writes the results unless ctx is canceled in the meantime.
+func reportEarlyResults(ctx context.Context, r Runner, opts flag.Options,
+	scan func(context.Context, flag.Options) (types.Report, error)) error {
+	if !opts.Scanners.Enabled(types.VulnerabilityScanner) || !slices.Contains(opts.PkgTypes, types.PkgTypeOS) {
+		log.InfoContext(ctx, `"--early-results" is ignored since vulnerability scanning of OS packages is disabled`)
+		return nil
+	}
+
+	opts.Scanners = types.Scanners{types.VulnerabilityScanner}
+	opts.ImageConfigScanners = nil
+	opts.PkgTypes = []string{types.PkgTypeOS}
+	opts.SBOMSources = nil
+
+	// The local cache can't be opened twice in the same process, and the results of OS analyzers alone
+	// are not worth caching anyway.
+	opts.CacheBackend = string(cache.TypeMemory)
+
+	report, err := scan(ctx, opts)
+	if err != nil {
+		return xerrors.Errorf("scan error: %w", err)
+	}
+	report.Partial = true
+
+	report, err = r.Filter(ctx, opts, report)
+	if err != nil {
+		return xerrors.Errorf("filter error: %w", err)
+	}
+
+	// The full results are already available
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	log.InfoContext(ctx, "Writing early results of OS packages. The full results follow once all the analyzers complete.")
+	if err = r.Report(ctx, opts, report); err != nil {
+		return xerrors.Errorf("report error: %w", err)
+	}
+	return nil
 }
 
 func disabledAnalyzers(opts flag.Options) []analyzer.Type {
@@ -427,6 +570,7 @@ func disabledAnalyzers(opts flag.Options) []analyzer.Type {
 	// Do not analyze programming language packages when not running in 'library'
 	if !slices.Contains(opts.PkgTypes, types.PkgTypeLibrary) {
 		analyzers = append(analyzers, analyzer.TypeLanguages...)
+		analyzers = append(analyzers, analyzer.TypeBuildpacksLabel)
 	}
 
 	// Do not perform secret scanning when it is not specified.
@@ -465,7 +609,7 @@ func disabledAnalyzers(opts flag.Options) []analyzer.Type {
 	// Do not perform misconfiguration scanning on container image config
 	// when it is not specified.
 	if !opts.ImageConfigScanners.Enabled(types.MisconfigScanner) {
-		analyzers = append(analyzers, analyzer.TypeHistoryDockerfile)
+		analyzers = append(analyzers, analyzer.TypeHistoryDockerfile, analyzer.TypeImageLabels)
 	}
 
 	// Skip executable file analysis if Rekor isn't a specified SBOM source.
@@ -590,18 +734,27 @@ func (r *runner) initScannerConfig(ctx context.Context, opts flag.Options) (Scan
 				MaxImageSize: opts.MaxImageSize,
 			},
 
+			// For filesystem scanning
+			Resume: opts.Resume,
+
 			// For misconfiguration scanning
 			MisconfScannerOption: configScannerOptions,
 
 			// For secret scanning
 			SecretScannerOption: analyzer.SecretScannerOption{
-				ConfigPath: opts.SecretConfigPath,
+				ConfigPath:     opts.SecretConfigPath,
+				Validate:       opts.ValidateSecrets,
+				Fingerprint:    opts.SecretBaseline != "",
+				Redaction:      opts.SecretRedaction(opts.Format),
+				ArchiveDepth:   opts.ArchiveDepth,
+				ArchiveMaxSize: opts.ArchiveMaxSize,
 			},
 
 			// For license scanning
 			LicenseScannerOption: analyzer.LicenseScannerOption{
 				Full:                      opts.LicenseFull,
 				ClassifierConfidenceLevel: opts.LicenseConfidenceLevel,
+				GoBinarySources:           opts.LicenseGoBinarySources,
 			},
 
 			// For file walking
@@ -628,9 +781,26 @@ func (r *runner) scan(ctx context.Context, opts flag.Options, initializeScanner
 	if err != nil {
 		return types.Report{}, xerrors.Errorf("scan failed: %w", err)
 	}
+
+	if profiler := scannerConfig.ArtifactOption.MisconfScannerOption.CheckProfiler; profiler != nil {
+		if err = writeCheckProfile(ctx, profiler); err != nil {
+			return types.Report{}, xerrors.Errorf("unable to write the check profile: %w", err)
+		}
+	}
 	return report, nil
 }
 
+// writeCheckProfile writes the evaluation time of checks to stderr, so that it doesn't mix with the report
+func writeCheckProfile(ctx context.Context, profiler *rego.Profiler) error {
+	ctx = log.WithContextPrefix(ctx, log.PrefixMisconfiguration)
+	if len(profiler.Profiles()) == 0 {
+		log.InfoContext(ctx, "No checks were evaluated. Cached results are not evaluated again, clear the cache with 'trivy clean --scan-cache' to profile them")
+		return nil
+	}
+	log.InfoContext(ctx, "Check evaluation profile")
+	return profiler.Write(os.Stderr)
+}
+
 func initMisconfScannerOption(ctx context.Context, opts flag.Options) (misconf.ScannerOption, error) {
 	ctx = log.WithContextPrefix(ctx, log.PrefixMisconfiguration)
 	log.InfoContext(ctx, "Misconfiguration scanning is enabled")
@@ -638,7 +808,7 @@ func initMisconfScannerOption(ctx context.Context, opts flag.Options) (misconf.S
 	var downloadedPolicyPaths []string
 	var disableEmbedded bool
 
-	downloadedPolicyPaths, err := operation.InitBuiltinChecks(ctx, opts.CacheDir, opts.Quiet, opts.SkipCheckUpdate, opts.MisconfOptions.ChecksBundleRepository, opts.RegistryOpts())
+	downloadedPolicyPaths, err := operation.InitBuiltinChecks(ctx, opts.CacheDir, opts.Quiet, opts.SkipCheckUpdate, opts.MisconfOptions.ChecksBundleRepositories, opts.RegistryOpts())
 	if err != nil {
 		if !opts.SkipCheckUpdate {
 			log.ErrorContext(ctx, "Falling back to embedded checks", log.Err(err))
@@ -653,27 +823,68 @@ func initMisconfScannerOption(ctx context.Context, opts flag.Options) (misconf.S
 		return misconf.ScannerOption{}, xerrors.Errorf("load schemas error: %w", err)
 	}
 
+	namespaces := append(opts.CheckNamespaces, rego.BuiltinNamespaces()...)
+	policyPaths := append(opts.CheckPaths, downloadedPolicyPaths...)
+	dataPaths, err := misconf.ResolveDataPaths(ctx, opts.DataPaths, opts.CacheDir, opts.OfflineScan, opts.RegistryOpts())
+	if err != nil {
+		return misconf.ScannerOption{}, xerrors.Errorf("data paths error: %w", err)
+	}
+	if opts.RepoChecksKey != "" {
+		repoChecksDir, err := misconf.LoadRepoChecks(ctx, opts.Target, opts.RepoChecksKey)
+		if err != nil {
+			return misconf.ScannerOption{}, xerrors.Errorf("load repository checks error: %w", err)
+		} else if repoChecksDir != "" {
+			// Custom checks must be in the "user" namespace
+			namespaces = append(namespaces, "user")
+			policyPaths = append(policyPaths, repoChecksDir)
+			dataPaths = append(dataPaths, repoChecksDir)
+		}
+	}
+
+	var checkProfiler *rego.Profiler
+	if opts.ProfileChecks {
+		checkProfiler = rego.NewProfiler()
+	}
+
 	return misconf.ScannerOption{
-		Trace:                    opts.Trace,
-		Namespaces:               append(opts.CheckNamespaces, rego.BuiltinNamespaces()...),
-		PolicyPaths:              append(opts.CheckPaths, downloadedPolicyPaths...),
-		DataPaths:                opts.DataPaths,
-		HelmValues:               opts.HelmValues,
-		HelmValueFiles:           opts.HelmValueFiles,
-		HelmFileValues:           opts.HelmFileValues,
-		HelmStringValues:         opts.HelmStringValues,
-		HelmAPIVersions:          opts.HelmAPIVersions,
-		HelmKubeVersion:          opts.HelmKubeVersion,
-		TerraformTFVars:          opts.TerraformTFVars,
-		CloudFormationParamVars:  opts.CloudFormationParamVars,
-		K8sVersion:               opts.K8sVersion,
-		DisableEmbeddedPolicies:  disableEmbedded,
-		DisableEmbeddedLibraries: disableEmbedded,
-		IncludeDeprecatedChecks:  opts.IncludeDeprecatedChecks,
-		TfExcludeDownloaded:      opts.TfExcludeDownloaded,
-		FilePatterns:             opts.FilePatterns,
-		ConfigFileSchemas:        configSchemas,
-		SkipFiles:                opts.SkipFiles,
-		SkipDirs:                 opts.SkipDirs,
+		Trace:                     opts.Trace,
+		CheckProfiler:             checkProfiler,
+		Namespaces:                namespaces,
+		PolicyPaths:               policyPaths,
+		DataPaths:                 dataPaths,
+		CheckBundleKey:            opts.CheckBundleKey,
+		HelmValues:                opts.HelmValues,
+		HelmValueFiles:            opts.HelmValueFiles,
+		HelmFileValues:            opts.HelmFileValues,
+		HelmStringValues:          opts.HelmStringValues,
+		HelmAPIVersions:           opts.HelmAPIVersions,
+		HelmKubeVersion:           opts.HelmKubeVersion,
+		HelmDependencyUpdate:      opts.HelmDependencyUpdate,
+		TerraformTFVars:           opts.TerraformTFVars,
+		CloudFormationParamVars:   opts.CloudFormationParamVars,
+		CloudFormationS3Templates: opts.CloudFormationS3Templates,
+		K8sVersion:                opts.K8sVersion,
+		K8sSchemaDir:              opts.K8sSchemaDir,
+		K8sSchemaVersion:          opts.K8sSchemaVersion,
+		K8sUpgradeVersion:         opts.K8sUpgradeVersion,
+		K8sCRDSchemas:             opts.K8sCRDSchemas,
+		K8sResolveGitOps:          opts.K8sResolveGitOps,
+		JsonnetImportPaths:        opts.JsonnetImportPaths,
+		JsonnetExtVars:            opts.JsonnetExtVars,
+		JsonnetExtCode:            opts.JsonnetExtCode,
+		MaxFileSize:               opts.MaxFileSize,
+		ParseTimeout:              opts.ParseTimeout,
+		DisableEmbeddedPolicies:   disableEmbedded,
+		DisableEmbeddedLibraries:  disableEmbedded,
+		IncludeDeprecatedChecks:   opts.IncludeDeprecatedChecks,
+		TfExcludeDownloaded:       opts.TfExcludeDownloaded,
+		TfPlanChangesOnly:         opts.TfPlanChangesOnly,
+		TfModuleCacheDir:          filepath.Join(opts.CacheDir, "terraform", "modules"),
+		TfModuleCacheTTL:          opts.TfModuleCacheTTL,
+		Offline:                   opts.OfflineScan,
+		FilePatterns:              opts.FilePatterns,
+		ConfigFileSchemas:         configSchemas,
+		SkipFiles:                 opts.SkipFiles,
+		SkipDirs:                  opts.SkipDirs,
 	}, nil
 }
@@ -0,0 +1,157 @@
+package artifact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type fakeRunner struct {
+	Runner
+	reported []types.Report
+}
+
+func (r *fakeRunner) Filter(_ context.Context, _ flag.Options, report types.Report) (types.Report, error) {
+	return report, nil
+}
+
+func (r *fakeRunner) Report(_ context.Context, _ flag.Options, report types.Report) error {
+	r.reported = append(r.reported, report)
+	return nil
+}
+
+func TestReportEarlyResults(t *testing.T) {
+	tests := []struct {
+		name         string
+		scanners     types.Scanners
+		pkgTypes     []string
+		canceled     bool
+		wantScanned  bool
+		wantReported []types.Report
+	}{
+		{
+			name: "OS packages",
+			scanners: types.Scanners{
+				types.VulnerabilityScanner,
+				types.SecretScanner,
+			},
+			pkgTypes: []string{
+				types.PkgTypeOS,
+				types.PkgTypeLibrary,
+			},
+			wantScanned: true,
+			wantReported: []types.Report{
+				{
+					ArtifactName: "alpine:3.14",
+					Partial:      true,
+				},
+			},
+		},
+		{
+			name:        "full results already available",
+			scanners:    types.Scanners{types.VulnerabilityScanner},
+			pkgTypes:    []string{types.PkgTypeOS},
+			canceled:    true,
+			wantScanned: true,
+		},
+		{
+			name:     "vulnerability scanning disabled",
+			scanners: types.Scanners{types.SecretScanner},
+			pkgTypes: []string{types.PkgTypeOS},
+		},
+		{
+			name:     "OS packages disabled",
+			scanners: types.Scanners{types.VulnerabilityScanner},
+			pkgTypes: []string{types.PkgTypeLibrary},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := flag.Options{
+				ImageOptions: flag.ImageOptions{
+					ImageConfigScanners: types.Scanners{types.MisconfigScanner},
+				},
+				PackageOptions: flag.PackageOptions{
+					PkgTypes: tt.pkgTypes,
+				},
+				ScanOptions: flag.ScanOptions{
+					Scanners:    tt.scanners,
+					SBOMSources: []string{"oci"},
+				},
+			}
+
+			var scanned bool
+			scan := func(_ context.Context, opts flag.Options) (types.Report, error) {
+				scanned = true
+
+				// Only vulnerabilities in OS packages are scanned for the early results
+				assert.Equal(t, types.Scanners{types.VulnerabilityScanner}, opts.Scanners)
+				assert.Equal(t, []string{types.PkgTypeOS}, opts.PkgTypes)
+				assert.Empty(t, opts.ImageConfigScanners)
+				assert.Empty(t, opts.SBOMSources)
+				assert.Equal(t, "memory", opts.CacheBackend)
+				return types.Report{ArtifactName: "alpine:3.14"}, nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.canceled {
+				cancel()
+			}
+			defer cancel()
+
+			r := &fakeRunner{}
+			require.NoError(t, reportEarlyResults(ctx, r, opts, scan))
+			assert.Equal(t, tt.wantScanned, scanned)
+			assert.Equal(t, tt.wantReported, r.reported)
+		})
+	}
+}
+
+func TestStartEarlyResults(t *testing.T) {
+	opts := flag.Options{
+		PackageOptions: flag.PackageOptions{
+			PkgTypes: []string{types.PkgTypeOS},
+		},
+		ScanOptions: flag.ScanOptions{
+			Scanners: types.Scanners{types.VulnerabilityScanner},
+		},
+	}
+
+	t.Run("early scan completes first", func(t *testing.T) {
+		scan := func(context.Context, flag.Options) (types.Report, error) {
+			return types.Report{ArtifactName: "alpine:3.14"}, nil
+		}
+
+		r := &fakeRunner{}
+		early := startEarlyResults(context.Background(), r, opts, scan)
+		<-early.done // The full scan is still running
+		early.stop()
+
+		assert.Equal(t, []types.Report{
+			{
+				ArtifactName: "alpine:3.14",
+				Partial:      true,
+			},
+		}, r.reported)
+	})
+
+	t.Run("full scan completes first", func(t *testing.T) {
+		// The early scan runs until it is stopped
+		scan := func(ctx context.Context, _ flag.Options) (types.Report, error) {
+			<-ctx.Done()
+			return types.Report{ArtifactName: "alpine:3.14"}, nil
+		}
+
+		r := &fakeRunner{}
+		early := startEarlyResults(context.Background(), r, opts, scan)
+		early.stop()
+
+		// The early results are not written after the full results are available
+		assert.Empty(t, r.reported)
+	})
+}
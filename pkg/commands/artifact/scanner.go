@@ -5,6 +5,7 @@ import (
 
 	"golang.org/x/xerrors"
 
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	"github.com/aquasecurity/trivy/pkg/scanner"
 )
 
@@ -88,6 +89,48 @@ func repositoryRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.S
 	return s, cleanup, nil
 }
 
+// packageStandaloneScanner initializes a package scanner in standalone mode
+func packageStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializePackageScanner(ctx, conf.Target, conf.CacheOptions, conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a package scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+// packageRemoteScanner initializes a package scanner in client/server mode
+func packageRemoteScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+	s, cleanup, err := initializeRemotePackageScanner(ctx, conf.Target, conf.RemoteCacheOptions, conf.ServerOption,
+		conf.ArtifactOption)
+	if err != nil {
+		return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a remote package scanner: %w", err)
+	}
+	return s, cleanup, nil
+}
+
+// customStandaloneScanner returns a scanner initializer of the registered artifact type in standalone mode
+func customStandaloneScanner(artifactType artifact.Type) InitializeScanner {
+	return func(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+		s, cleanup, err := initializeCustomScanner(ctx, artifactType, conf.Target, conf.CacheOptions, conf.ArtifactOption)
+		if err != nil {
+			return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a %s scanner: %w", artifactType, err)
+		}
+		return s, cleanup, nil
+	}
+}
+
+// customRemoteScanner returns a scanner initializer of the registered artifact type in client/server mode
+func customRemoteScanner(artifactType artifact.Type) InitializeScanner {
+	return func(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
+		s, cleanup, err := initializeRemoteCustomScanner(ctx, artifactType, conf.Target, conf.RemoteCacheOptions,
+			conf.ServerOption, conf.ArtifactOption)
+		if err != nil {
+			return scanner.Scanner{}, func() {}, xerrors.Errorf("unable to initialize a remote %s scanner: %w", artifactType, err)
+		}
+		return s, cleanup, nil
+	}
+}
+
 // sbomStandaloneScanner initializes a SBOM scanner in standalone mode
 func sbomStandaloneScanner(ctx context.Context, conf ScannerConfig) (scanner.Scanner, func(), error) {
 	s, cleanup, err := initializeSBOMScanner(ctx, conf.Target, conf.CacheOptions, conf.ArtifactOption)
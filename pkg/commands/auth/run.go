@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/x509"
 	"net/http"
 	"os"
 
@@ -30,11 +31,16 @@ func Login(ctx context.Context, registry string, opts flag.Options) error {
 	}
 	serverAddress := reg.Name()
 
+	tr, err := httpTransport(opts)
+	if err != nil {
+		return xerrors.Errorf("failed to create http transport: %w", err)
+	}
+
 	// Validate the credential
 	_, err = transport.NewWithContext(ctx, reg, &authn.Basic{
 		Username: opts.Credentials[0].Username,
 		Password: opts.Credentials[0].Password,
-	}, httpTransport(opts), []string{reg.Scope(transport.PullScope)})
+	}, tr, []string{reg.Scope(transport.PullScope)})
 	if err != nil {
 		return xerrors.Errorf("failed to authenticate: %w", err)
 	}
@@ -100,10 +106,20 @@ func parseRegistry(registry string, opts flag.Options) (name.Registry, error) {
 	return reg, nil
 }
 
-func httpTransport(opts flag.Options) *http.Transport {
+func httpTransport(opts flag.Options) (*http.Transport, error) {
 	tr := remote.DefaultTransport.(*http.Transport).Clone()
 	if opts.Insecure {
 		tr.TLSClientConfig.InsecureSkipVerify = true
 	}
-	return tr
+	if len(opts.RegistryCACert) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.RegistryCACert) {
+			return nil, xerrors.New("no valid CA certificate found")
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+	return tr, nil
 }
@@ -0,0 +1,31 @@
+package browse
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/report/tui"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func Run(_ context.Context, opts flag.Options) error {
+	f, err := os.Open(opts.BrowseReportPath)
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	var r types.Report
+	if err = json.NewDecoder(f).Decode(&r); err != nil {
+		return xerrors.Errorf("json decode error: %w", err)
+	}
+
+	if err = tui.Run(r, opts.ExportPath, os.Stdin, os.Stdout); err != nil {
+		return xerrors.Errorf("browse error: %w", err)
+	}
+	return nil
+}
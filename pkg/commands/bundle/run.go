@@ -0,0 +1,64 @@
+package bundle
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/bundle"
+	"github.com/aquasecurity/trivy/pkg/commands/operation"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/javadb"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/vex/repo"
+)
+
+// Export downloads the databases into the cache directory and writes them to a single archive
+// to be imported in air-gapped environments.
+func Export(ctx context.Context, opts flag.Options, archivePath string) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	noProgress := opts.Quiet || opts.NoProgress
+	if err := operation.DownloadDB(ctx, opts.AppVersion, opts.CacheDir, opts.DBRepositories, opts.DBURL, opts.DBDelta, noProgress,
+		opts.SkipDBUpdate, opts.RegistryOpts()); err != nil {
+		return xerrors.Errorf("DB error: %w", err)
+	}
+
+	javadb.Init(opts.CacheDir, opts.JavaDBRepositories, opts.JavaDBURL, opts.SkipJavaDBUpdate, noProgress, opts.RegistryOpts())
+	if err := javadb.Update(); err != nil {
+		return xerrors.Errorf("Java DB error: %w", err)
+	}
+
+	if _, err := operation.InitBuiltinChecks(ctx, opts.CacheDir, opts.Quiet, opts.SkipCheckUpdate,
+		opts.ChecksBundleRepositories, opts.RegistryOpts()); err != nil {
+		return xerrors.Errorf("checks bundle error: %w", err)
+	}
+
+	if opts.SkipVEXRepoUpdate {
+		log.InfoContext(ctx, "Skipping VEX repository update")
+	} else {
+		err := repo.NewManager(opts.CacheDir).DownloadRepositories(log.WithContextPrefix(ctx, "vex"), nil, repo.Options{
+			Insecure: opts.Insecure,
+		})
+		if err != nil {
+			return xerrors.Errorf("failed to download VEX repositories: %w", err)
+		}
+	}
+
+	if err := bundle.Export(ctx, opts.CacheDir, archivePath); err != nil {
+		return xerrors.Errorf("bundle export error: %w", err)
+	}
+	return nil
+}
+
+// Import verifies the archive written by Export and installs the databases into the cache directory.
+func Import(ctx context.Context, opts flag.Options, archivePath string) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	if err := bundle.Import(ctx, opts.CacheDir, archivePath); err != nil {
+		return xerrors.Errorf("bundle import error: %w", err)
+	}
+	return nil
+}
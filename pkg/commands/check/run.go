@@ -0,0 +1,69 @@
+package check
+
+import (
+	"context"
+
+	"github.com/open-policy-agent/opa/tester"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/misconf"
+)
+
+// Run runs the unit tests of custom checks and reports the results.
+func Run(ctx context.Context, opts flag.Options) error {
+	results, err := Test(ctx, opts.CheckTestPaths, opts.CheckTestDataPaths, rego.TestOptions{
+		Run:     opts.CheckTestRun,
+		Timeout: opts.CheckTestTimeout,
+	})
+	if err != nil {
+		return xerrors.Errorf("check test error: %w", err)
+	} else if len(results) == 0 {
+		return xerrors.New("no tests found, tests must be defined in '*_test.rego' files with rules prefixed with 'test_'")
+	}
+
+	w, cleanup, err := opts.OutputWriter(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to create output writer: %w", err)
+	}
+	defer func() { _ = cleanup() }()
+
+	ch := make(chan *tester.Result, len(results))
+	for _, result := range results {
+		ch <- result
+	}
+	close(ch)
+
+	reporter := tester.PrettyReporter{
+		Output:  w,
+		Verbose: true,
+	}
+	if err = reporter.Report(ch); err != nil {
+		return xerrors.Errorf("failed to write the results: %w", err)
+	}
+
+	if lo.SomeBy(results, func(r *tester.Result) bool { return !r.Pass() && !r.Skip }) {
+		return xerrors.New("some check tests failed")
+	}
+	return nil
+}
+
+// Test runs the tests found in the check files and directories with the data in dataPaths
+func Test(ctx context.Context, checkPaths, dataPaths []string, opts rego.TestOptions) ([]*tester.Result, error) {
+	checkFS, checkPaths, err := misconf.CreatePolicyFS(checkPaths)
+	if err != nil {
+		return nil, xerrors.Errorf("check file system error: %w", err)
+	}
+	opts.DataFS, opts.DataPaths, err = misconf.CreateDataFS(dataPaths)
+	if err != nil {
+		return nil, xerrors.Errorf("data file system error: %w", err)
+	}
+
+	results, err := rego.RunTests(ctx, checkFS, checkPaths, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to run tests: %w", err)
+	}
+	return results, nil
+}
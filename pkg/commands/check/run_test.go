@@ -0,0 +1,72 @@
+package check_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/commands/check"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+func TestTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		dataPaths []string
+		opts      rego.TestOptions
+		want      map[string]bool
+		wantErr   string
+	}{
+		{
+			name:      "happy path",
+			dataPaths: []string{"testdata/data"},
+			want: map[string]bool{
+				"test_from":          true,
+				"test_allowed_image": true,
+				"test_no_stages":     false,
+			},
+		},
+		{
+			name:      "filtered",
+			dataPaths: []string{"testdata/data"},
+			opts: rego.TestOptions{
+				Run: "image",
+			},
+			want: map[string]bool{
+				"test_allowed_image": true,
+			},
+		},
+		{
+			name: "missing data",
+			want: map[string]bool{
+				"test_from":          false,
+				"test_allowed_image": true,
+				"test_no_stages":     false,
+			},
+		},
+		{
+			name:      "data not found",
+			dataPaths: []string{"testdata/missing"},
+			wantErr:   "data file system error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := check.Test(context.Background(), []string{"testdata/checks"}, tt.dataPaths, tt.opts)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got := make(map[string]bool)
+			for _, r := range results {
+				assert.Equal(t, "data.user.docker.from", r.Package)
+				got[r.Name] = r.Pass()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
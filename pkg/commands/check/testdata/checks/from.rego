@@ -0,0 +1,18 @@
+# METADATA
+# title: Images from allowed registries
+# schemas:
+# - input: schema["dockerfile"]
+# custom:
+#   id: USER-0001
+#   input:
+#     selector:
+#     - type: dockerfile
+package user.docker.from
+
+import data.lib.docker
+
+deny[res] {
+	from := docker.from[_]
+	not startswith(from.Value[0], data.registry)
+	res := result.new(sprintf("Image %q is not from %s", [from.Value[0], data.registry]), from)
+}
@@ -0,0 +1,16 @@
+package user.docker.from
+
+test_from {
+	r := deny with input as {"Stages": [{"Name": "alpine", "Commands": [{"Cmd": "from", "Value": ["alpine"]}]}]}
+	count(r) == 1
+}
+
+test_allowed_image {
+	r := deny with input as {"Stages": [{"Name": "registry.example.com/alpine", "Commands": [{"Cmd": "from", "Value": ["registry.example.com/alpine"]}]}]}
+	count(r) == 0
+}
+
+test_no_stages {
+	r := deny with input as {"Stages": []}
+	count(r) == 1
+}
@@ -0,0 +1,38 @@
+package clean
+
+import (
+	"context"
+
+	"github.com/docker/go-units"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Prune removes the stale entries from the filesystem scan cache
+func Prune(ctx context.Context, opts flag.Options) error {
+	if opts.CacheTTL <= 0 && opts.CacheMaxSize <= 0 {
+		return xerrors.New("no prune option is specified, use --cache-ttl and/or --cache-max-size")
+	}
+
+	c, err := cache.NewFSCache(opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("failed to open the scan cache: %w", err)
+	}
+	defer c.Close()
+
+	log.InfoContext(ctx, "Pruning scan cache...")
+	result, err := c.Prune(ctx, cache.PruneOptions{
+		TTL:     opts.CacheTTL,
+		MaxSize: opts.CacheMaxSize,
+	})
+	if err != nil {
+		return xerrors.Errorf("prune scan cache: %w", err)
+	}
+	log.InfoContext(ctx, "Pruned scan cache",
+		log.Int("removed", result.Removed), log.String("freed", units.HumanSize(float64(result.RemovedBytes))),
+		log.Int("remaining", result.Remaining), log.String("size", units.HumanSize(float64(result.Size))))
+	return nil
+}
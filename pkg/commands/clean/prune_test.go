@@ -0,0 +1,68 @@
+package clean_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/commands/clean"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+)
+
+func TestPrune(t *testing.T) {
+	const blobID = "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7"
+
+	tests := []struct {
+		name        string
+		cacheOpts   flag.CacheOptions
+		wantErr     string
+		wantMissing bool
+	}{
+		{
+			name:        "expired",
+			cacheOpts:   flag.CacheOptions{CacheTTL: time.Hour},
+			wantMissing: true,
+		},
+		{
+			name:      "not expired",
+			cacheOpts: flag.CacheOptions{CacheTTL: 24 * time.Hour},
+		},
+		{
+			name:    "no option",
+			wantErr: "no prune option is specified",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			c, err := cache.NewFSCache(cacheDir)
+			require.NoError(t, err)
+			require.NoError(t, c.PutBlob(blobID, types.BlobInfo{SchemaVersion: types.BlobJSONSchemaVersion}))
+			require.NoError(t, c.Close())
+
+			ctx := clock.With(context.Background(), time.Now().Add(2*time.Hour))
+			err = clean.Prune(ctx, flag.Options{
+				GlobalOptions: flag.GlobalOptions{CacheDir: cacheDir},
+				CacheOptions:  tt.cacheOpts,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			c, err = cache.NewFSCache(cacheDir)
+			require.NoError(t, err)
+			defer c.Close()
+			_, missing, err := c.MissingBlobs("", []string{blobID})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMissing, len(missing) == 1)
+		})
+	}
+}
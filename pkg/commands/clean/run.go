@@ -2,6 +2,7 @@ package clean
 
 import (
 	"context"
+	"os"
 
 	"golang.org/x/xerrors"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/javadb"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/policy"
+	"github.com/aquasecurity/trivy/pkg/remote"
 	"github.com/aquasecurity/trivy/pkg/vex/repo"
 )
 
@@ -74,6 +76,11 @@ func cleanScanCache(ctx context.Context, opts flag.Options) error {
 	if err = c.Clear(); err != nil {
 		return xerrors.Errorf("clear scan cache: %w", err)
 	}
+
+	// Remove partially downloaded blobs as well
+	if err = os.RemoveAll(remote.BlobDir(opts.CacheDir)); err != nil {
+		return xerrors.Errorf("failed to remove partially downloaded blobs: %w", err)
+	}
 	return nil
 }
 
@@ -96,7 +103,7 @@ func cleanJavaDB(ctx context.Context, opts flag.Options) error {
 
 func cleanCheckBundle(opts flag.Options) error {
 	log.Info("Removing check bundle...")
-	c, err := policy.NewClient(opts.CacheDir, true, opts.MisconfOptions.ChecksBundleRepository)
+	c, err := policy.NewClient(opts.CacheDir, true, opts.MisconfOptions.ChecksBundleRepositories)
 	if err != nil {
 		return xerrors.Errorf("failed to instantiate check client: %w", err)
 	}
@@ -44,6 +44,7 @@ func TestRun(t *testing.T) {
 			wantErr: false,
 			checkFunc: func(t *testing.T, dir string) {
 				assert.NoDirExists(t, filepath.Join(dir, "fanal"))
+				assert.NoDirExists(t, filepath.Join(dir, "blobs"))
 				assert.DirExists(t, filepath.Join(dir, "db"))
 				assert.DirExists(t, filepath.Join(dir, "java-db"))
 				assert.DirExists(t, filepath.Join(dir, "policy"))
@@ -59,6 +60,7 @@ func TestRun(t *testing.T) {
 			checkFunc: func(t *testing.T, dir string) {
 				assert.NoDirExists(t, filepath.Join(dir, "db"))
 				assert.DirExists(t, filepath.Join(dir, "fanal"))
+				assert.DirExists(t, filepath.Join(dir, "blobs"))
 				assert.DirExists(t, filepath.Join(dir, "java-db"))
 				assert.DirExists(t, filepath.Join(dir, "policy"))
 				assert.DirExists(t, filepath.Join(dir, "vex"))
@@ -151,6 +153,7 @@ func createTestFiles(t *testing.T, dir string) {
 		"java-db",
 		"policy",
 		"vex",
+		"blobs",
 	}
 	for _, subdir := range subdirs {
 		err := os.MkdirAll(filepath.Join(dir, subdir), 0755)
@@ -0,0 +1,105 @@
+package clean
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Stats writes the hit ratio of the filesystem scan cache, and the number and the size of its entries per type
+func Stats(_ context.Context, opts flag.Options, w io.Writer) error {
+	c, err := cache.NewFSCache(opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("failed to open the scan cache: %w", err)
+	}
+	defer c.Close()
+
+	stats, err := c.Stats()
+	if err != nil {
+		return xerrors.Errorf("scan cache stats error: %w", err)
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Hits: %d\nMisses: %d\nHit ratio: %.1f%%\n\n", stats.Hits, stats.Misses, stats.HitRatio()*100))
+
+	tw := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tENTRIES\tSIZE")
+	var total cache.TypeStats
+	for _, typ := range []string{cache.EntryTypeImageConfig, cache.EntryTypeImageLayer, cache.EntryTypeFilesystem} {
+		ts := stats.Types[typ]
+		total.Count += ts.Count
+		total.Size += ts.Size
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", typ, ts.Count, units.HumanSize(float64(ts.Size)))
+	}
+	fmt.Fprintf(tw, "total\t%d\t%s\n", total.Count, units.HumanSize(float64(total.Size)))
+	if err = tw.Flush(); err != nil {
+		return xerrors.Errorf("failed to write output: %w", err)
+	}
+
+	if _, err = io.WriteString(w, output.String()); err != nil {
+		return xerrors.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// Inspect writes the entries of the filesystem scan cache matching the cache key, layer digest or diff ID,
+// and deletes them if requested so that they are analyzed again on the next scan.
+func Inspect(ctx context.Context, opts flag.Options, w io.Writer) error {
+	c, err := cache.NewFSCache(opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("failed to open the scan cache: %w", err)
+	}
+	defer c.Close()
+
+	entries, err := c.Inspect(opts.CacheInspectDigest)
+	if err != nil {
+		return xerrors.Errorf("scan cache inspect error: %w", err)
+	}
+
+	var output strings.Builder
+	if len(entries) == 0 {
+		output.WriteString(fmt.Sprintf("No entries found for %s.\n", opts.CacheInspectDigest))
+	}
+	for _, e := range entries {
+		output.WriteString(fmt.Sprintf("- Key: %s\n  Type: %s\n  Size: %s\n  Schema version: %d\n",
+			e.Key, e.Type, units.HumanSize(float64(e.Size)), e.SchemaVersion))
+		if !e.AccessedAt.IsZero() {
+			output.WriteString(fmt.Sprintf("  Last access: %s\n", e.AccessedAt.UTC().Format(time.RFC3339)))
+		}
+		for _, field := range [][2]string{
+			{"Digest", e.Digest},
+			{"DiffID", e.DiffID},
+			{"Created by", e.CreatedBy},
+			{"OS", strings.TrimSpace(string(e.OS.Family) + " " + e.OS.Name)},
+		} {
+			if field[1] != "" {
+				output.WriteString(fmt.Sprintf("  %s: %s\n", field[0], field[1]))
+			}
+		}
+		output.WriteString(fmt.Sprintf("  Packages: %d\n  Applications: %d\n  Misconfigurations: %d\n  Secrets: %d\n  Licenses: %d\n\n",
+			e.Packages, e.Applications, e.Misconfigurations, e.Secrets, e.Licenses))
+	}
+	if _, err = io.WriteString(w, output.String()); err != nil {
+		return xerrors.Errorf("failed to write output: %w", err)
+	}
+
+	if opts.CacheInspectDelete && len(entries) > 0 {
+		if err = c.DeleteEntries(entries); err != nil {
+			return xerrors.Errorf("failed to delete the entries: %w", err)
+		}
+		keys := lo.Map(entries, func(e cache.Entry, _ int) string { return e.Key })
+		log.InfoContext(ctx, "Deleted the scan cache entries", log.String("keys", strings.Join(keys, ", ")))
+	}
+	return nil
+}
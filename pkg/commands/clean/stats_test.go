@@ -0,0 +1,66 @@
+package clean_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/commands/clean"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+)
+
+func TestStatsAndInspect(t *testing.T) {
+	const (
+		layerKey = "sha256:24df0d4e20c0f42d3703bf1f1db2bdd77346c7956f74f423603d651e8e5ae8a7"
+		diffID   = "sha256:beee9f30bc1f711043e78d4a2be0668955d4b761d587d6f60c2c8dc081efb203"
+	)
+
+	cacheDir := t.TempDir()
+	c, err := cache.NewFSCache(cacheDir)
+	require.NoError(t, err)
+	require.NoError(t, c.PutBlob(layerKey, types.BlobInfo{
+		SchemaVersion: types.BlobJSONSchemaVersion,
+		DiffID:        diffID,
+		OS:            types.OS{Family: "alpine", Name: "3.10.2"},
+	}))
+	_, _, err = c.MissingBlobs("sha256:missing", []string{layerKey})
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+
+	opts := flag.Options{
+		GlobalOptions: flag.GlobalOptions{CacheDir: cacheDir},
+	}
+
+	t.Run("stats", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, clean.Stats(context.Background(), opts, &out))
+		assert.Contains(t, out.String(), "Hits: 1\nMisses: 1\nHit ratio: 50.0%\n")
+		assert.Regexp(t, `image_layer\s+1\s+`, out.String())
+	})
+
+	t.Run("inspect", func(t *testing.T) {
+		opts := opts
+		opts.CacheInspectDigest = diffID
+
+		var out bytes.Buffer
+		require.NoError(t, clean.Inspect(context.Background(), opts, &out))
+		assert.Contains(t, out.String(), "- Key: "+layerKey+"\n  Type: image_layer\n")
+		assert.Contains(t, out.String(), "  OS: alpine 3.10.2\n")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		opts := opts
+		opts.CacheInspectDigest = layerKey
+		opts.CacheInspectDelete = true
+		require.NoError(t, clean.Inspect(context.Background(), opts, &bytes.Buffer{}))
+
+		var out bytes.Buffer
+		require.NoError(t, clean.Inspect(context.Background(), opts, &out))
+		assert.Equal(t, "No entries found for "+layerKey+".\n", out.String())
+	})
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	getter "github.com/hashicorp/go-getter"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/downloader"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/oci"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+const (
+	baseConfigDir            = "base-config"
+	baseConfigFile           = "trivy.yaml"
+	baseConfigMetadataFile   = "metadata.json"
+	baseConfigUpdateInterval = time.Hour
+)
+
+type baseConfigMetadata struct {
+	URL       string
+	ETag      string `json:",omitempty"`
+	UpdatedAt time.Time
+}
+
+// fetchBaseConfig downloads the base config into the cache dir and returns the path.
+// The cached config is used for an hour without checking for updates.
+// It is also used when the download fails so that scans keep working while the server is unavailable.
+func fetchBaseConfig(ctx context.Context, src, cacheDir string, insecure bool) (string, error) {
+	hash := sha256.Sum256([]byte(src))
+	dir := filepath.Join(cacheDir, baseConfigDir, hex.EncodeToString(hash[:]))
+	filePath := filepath.Join(dir, baseConfigFile)
+	logger := log.WithPrefix("config").With(log.String("url", src))
+
+	var m baseConfigMetadata
+	if fsutils.FileExists(filePath) {
+		var err error
+		if m, err = readBaseConfigMetadata(dir); err != nil {
+			logger.Debug("Failed to read the base config metadata", log.Err(err))
+		} else if clock.Now(ctx).Before(m.UpdatedAt.Add(baseConfigUpdateInterval)) {
+			logger.Debug("Using the cached base config", log.Time("updated_at", m.UpdatedAt))
+			return filePath, nil
+		}
+	}
+
+	logger.Debug("Downloading the base config...", log.String("etag", m.ETag))
+	etag, err := downloadBaseConfig(ctx, src, dir, m.ETag, insecure)
+	switch {
+	case errors.Is(err, downloader.ErrSkipDownload):
+		logger.Debug("No updates in the base config")
+		etag = m.ETag // Keep the old ETag
+	case err != nil:
+		if !fsutils.FileExists(filePath) {
+			return "", xerrors.Errorf("failed to download the base config: %w", err)
+		}
+		logger.Warn("Failed to download the base config, using the cached one", log.Err(err))
+		return filePath, nil
+	}
+
+	if err = writeBaseConfigMetadata(dir, baseConfigMetadata{
+		URL:       src,
+		ETag:      etag,
+		UpdatedAt: clock.Now(ctx),
+	}); err != nil {
+		return "", xerrors.Errorf("failed to write the base config metadata: %w", err)
+	}
+	return filePath, nil
+}
+
+// downloadBaseConfig downloads the base config into a temporary file first
+// so that the cached config remains when the download fails.
+func downloadBaseConfig(ctx context.Context, src, dir, etag string, insecure bool) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", xerrors.Errorf("failed to mkdir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(dir, "download-")
+	if err != nil {
+		return "", xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tmpPath := filepath.Join(tmpDir, baseConfigFile)
+
+	var newETag string
+	switch {
+	case strings.HasPrefix(src, "oci://"):
+		art := oci.NewArtifact(strings.TrimPrefix(src, "oci://"), ftypes.RegistryOptions{Insecure: insecure})
+		// The layer is not an archive, so it is copied to the destination path as is.
+		if err = art.Download(ctx, tmpPath, oci.DownloadOption{
+			Filename: baseConfigFile,
+			Quiet:    true,
+		}); err != nil {
+			return "", xerrors.Errorf("OCI download error: %w", err)
+		}
+	case strings.HasPrefix(src, "https://"):
+		newETag, err = downloader.Download(ctx, src, tmpPath, tmpDir, downloader.Options{
+			Insecure:   insecure,
+			ETag:       etag,
+			ClientMode: getter.ClientModeFile,
+		})
+		if err != nil {
+			return "", xerrors.Errorf("download error: %w", err)
+		}
+	default:
+		return "", xerrors.Errorf("unsupported base config URL %q: https:// or oci:// is required", src)
+	}
+
+	if !fsutils.FileExists(tmpPath) {
+		return "", xerrors.New("the base config must be a single file")
+	}
+	if err = os.Rename(tmpPath, filepath.Join(dir, baseConfigFile)); err != nil {
+		return "", xerrors.Errorf("failed to rename the base config: %w", err)
+	}
+	return newETag, nil
+}
+
+func readBaseConfigMetadata(dir string) (baseConfigMetadata, error) {
+	b, err := os.ReadFile(filepath.Join(dir, baseConfigMetadataFile))
+	if err != nil {
+		return baseConfigMetadata{}, xerrors.Errorf("unable to read the metadata: %w", err)
+	}
+	var m baseConfigMetadata
+	if err = json.Unmarshal(b, &m); err != nil {
+		return baseConfigMetadata{}, xerrors.Errorf("unable to decode the metadata: %w", err)
+	}
+	return m, nil
+}
+
+func writeBaseConfigMetadata(dir string, m baseConfigMetadata) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return xerrors.Errorf("unable to encode the metadata: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, baseConfigMetadataFile), b, 0600); err != nil {
+		return xerrors.Errorf("unable to write the metadata: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+)
+
+const baseConfig = `format: json
+severity:
+  - HIGH
+  - CRITICAL
+`
+
+func newBaseConfigServer(t *testing.T, requests *int) *httptest.Server {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			return
+		}
+		*requests++
+		if r.Header.Get("If-None-Match") == `"etag"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+		_, _ = w.Write([]byte(baseConfig))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func Test_fetchBaseConfig(t *testing.T) {
+	var requests int
+	ts := newBaseConfigServer(t, &requests)
+	cacheDir := t.TempDir()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Download
+	filePath, err := fetchBaseConfig(clock.With(context.Background(), now), ts.URL, cacheDir, true)
+	require.NoError(t, err)
+	got, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(got))
+	assert.Equal(t, 1, requests)
+
+	// Use the cache within the update interval
+	_, err = fetchBaseConfig(clock.With(context.Background(), now.Add(30*time.Minute)), ts.URL, cacheDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	// Not modified
+	filePath, err = fetchBaseConfig(clock.With(context.Background(), now.Add(2*time.Hour)), ts.URL, cacheDir, true)
+	require.NoError(t, err)
+	got, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(got))
+	assert.Equal(t, 2, requests)
+
+	// Fall back to the cache
+	ts.Close()
+	filePath, err = fetchBaseConfig(clock.With(context.Background(), now.Add(4*time.Hour)), ts.URL, cacheDir, true)
+	require.NoError(t, err)
+	got, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, baseConfig, string(got))
+
+	// No cache
+	_, err = fetchBaseConfig(context.Background(), ts.URL, t.TempDir(), true)
+	require.ErrorContains(t, err, "failed to download the base config")
+
+	// Unsupported scheme
+	_, err = fetchBaseConfig(context.Background(), "ftp://example.com/trivy.yaml", t.TempDir(), true)
+	require.ErrorContains(t, err, "unsupported base config URL")
+}
+
+func Test_initConfig_baseConfig(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(viper.Reset)
+
+	var requests int
+	ts := newBaseConfigServer(t, &requests)
+
+	// The local config overrides the severities of the base config
+	configFile := filepath.Join(t.TempDir(), "trivy.yaml")
+	err := os.WriteFile(configFile, []byte("base-config: "+ts.URL+"\nseverity:\n  - CRITICAL\n"), 0600)
+	require.NoError(t, err)
+
+	viper.Set("cache.dir", t.TempDir())
+	viper.Set("insecure", true)
+
+	err = initConfig(context.Background(), configFile, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "json", viper.GetString("format"))
+	assert.Equal(t, []string{"CRITICAL"}, viper.GetStringSlice("severity"))
+}
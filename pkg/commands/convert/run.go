@@ -47,7 +47,7 @@ func Run(ctx context.Context, opts flag.Options) (err error) {
 		return xerrors.Errorf("unable to write results: %w", err)
 	}
 
-	return operation.Exit(opts, r.Results.Failed(), r.Metadata)
+	return operation.Exit(opts, r.Results.Failed(), false, r.Metadata)
 }
 
 // compat converts the JSON report to the latest format
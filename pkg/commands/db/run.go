@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/mirror"
+)
+
+// Serve serves the vulnerability DB, the Java DB and the checks bundle as a read-only OCI registry,
+// syncing them with the upstream repositories periodically.
+func Serve(ctx context.Context, opts flag.Options) error {
+	ctx = log.WithContextPrefix(ctx, "mirror")
+
+	dir := lo.Ternary(opts.DBMirrorDir != "", opts.DBMirrorDir, filepath.Join(opts.CacheDir, "mirror"))
+	m, err := mirror.New(dir)
+	if err != nil {
+		return xerrors.Errorf("mirror error: %w", err)
+	}
+
+	if opts.DBMirrorSyncInterval > 0 {
+		sources, err := mirrorSources(opts)
+		if err != nil {
+			return xerrors.Errorf("invalid repository: %w", err)
+		}
+		go func() {
+			for {
+				if err := m.Sync(ctx, sources, opts.RegistryOpts()); err != nil {
+					log.ErrorContext(ctx, "Sync error", log.Err(err))
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(opts.DBMirrorSyncInterval):
+				}
+			}
+		}()
+	}
+
+	server := &http.Server{
+		Addr:              opts.DBMirrorListen,
+		Handler:           m.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	log.InfoContext(ctx, "Serving the mirror", log.String("addr", opts.DBMirrorListen), log.String("dir", dir))
+	if opts.DBMirrorTLSCert != "" {
+		err = server.ListenAndServeTLS(opts.DBMirrorTLSCert, opts.DBMirrorTLSKey)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return xerrors.Errorf("server error: %w", err)
+	}
+	return nil
+}
+
+func mirrorSources(opts flag.Options) ([]mirror.Source, error) {
+	toString := func(ref name.Reference, _ int) string { return ref.String() }
+	sources := []mirror.Source{
+		{Repository: mirror.DBRepository},
+		{Repository: mirror.JavaDBRepository},
+		{Repository: mirror.ChecksRepository},
+	}
+	for i, repos := range [][]string{
+		lo.Map(opts.DBRepositories, toString),
+		lo.Map(opts.JavaDBRepositories, toString),
+		opts.ChecksBundleRepositories,
+	} {
+		for _, repo := range repos {
+			ref, err := parseReference(repo, opts.Insecure)
+			if err != nil {
+				return nil, xerrors.Errorf("invalid repository %q: %w", repo, err)
+			}
+			sources[i].Upstreams = append(sources[i].Upstreams, ref)
+		}
+	}
+	return sources, nil
+}
+
+// parseReference parses the repository in the same way as OCI artifacts are downloaded
+func parseReference(repo string, insecure bool) (name.Reference, error) {
+	var nameOpts []name.Option
+	if insecure {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	return name.ParseReference(repo, nameOpts...)
+}
@@ -0,0 +1,56 @@
+package javadb
+
+import (
+	"context"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/javadb"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Download downloads the Java DB into the cache directory and verifies it.
+func Download(ctx context.Context, opts flag.Options) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	noProgress := opts.Quiet || opts.NoProgress
+	javadb.Init(opts.CacheDir, opts.JavaDBRepositories, opts.JavaDBURL, false, noProgress, opts.RegistryOpts())
+	if err := javadb.Update(); err != nil {
+		return xerrors.Errorf("Java DB error: %w", err)
+	}
+
+	meta, err := javadb.Verify(ctx, opts.CacheDir)
+	if err != nil {
+		return xerrors.Errorf("unable to verify Java DB: %w", err)
+	}
+	log.InfoContext(log.WithContextPrefix(ctx, log.PrefixJavaDB), "Java DB is verified",
+		log.String("updated_at", meta.UpdatedAt.String()))
+	return nil
+}
+
+// Export writes the cached Java DB to an archive to be imported in air-gapped environments.
+func Export(ctx context.Context, opts flag.Options) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	err := javadb.Export(ctx, opts.CacheDir, opts.JavaDBArchivePath, javadb.ExportOption{
+		GroupIDs: opts.JavaDBGroupIDs,
+	})
+	if err != nil {
+		return xerrors.Errorf("Java DB export error: %w", err)
+	}
+	return nil
+}
+
+// Import verifies the archive written by Export and installs the Java DB into the cache directory.
+func Import(ctx context.Context, opts flag.Options) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	if err := javadb.Import(ctx, opts.CacheDir, opts.JavaDBArchivePath); err != nil {
+		return xerrors.Errorf("Java DB import error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package monitor
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/commands/artifact"
+	"github.com/aquasecurity/trivy/pkg/commands/operation"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Run re-evaluates the SBOMs stored in the target directory against the latest vulnerability database
+// and reports only vulnerabilities that were not reported in the previous evaluation.
+// If the interval is specified, the evaluation is repeated until the context is canceled.
+func Run(ctx context.Context, opts flag.Options) (err error) {
+	ctx = log.WithContextPrefix(ctx, "monitor")
+	defer func() {
+		err = operation.ExitOnError(opts, err)
+	}()
+
+	dir, err := filepath.Abs(opts.Target)
+	if err != nil {
+		return xerrors.Errorf("unable to get the absolute path: %w", err)
+	}
+	if opts.MonitorStateFile == "" {
+		opts.MonitorStateFile = defaultStateFile(opts.CacheDir, dir)
+	}
+
+	for {
+		report, partial, err := evaluate(ctx, opts, dir)
+		if err != nil {
+			return xerrors.Errorf("evaluation error: %w", err)
+		}
+
+		if opts.MonitorInterval == 0 {
+			return operation.Exit(opts, report.Results.Failed(), partial, report.Metadata)
+		}
+
+		log.InfoContext(ctx, "Waiting for the next evaluation...", log.Duration("interval", opts.MonitorInterval))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.MonitorInterval):
+		}
+	}
+}
+
+// evaluate returns the report of new findings. The boolean is true if some SBOMs failed to be scanned.
+func evaluate(ctx context.Context, opts flag.Options, dir string) (types.Report, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	// The runner is created for each evaluation so that the vulnerability database is updated.
+	r, err := artifact.NewRunner(ctx, opts)
+	if err != nil {
+		if errors.Is(err, artifact.SkipScan) {
+			return types.Report{}, false, nil
+		}
+		return types.Report{}, false, xerrors.Errorf("init error: %w", err)
+	}
+	defer r.Close(ctx)
+
+	return evaluateSBOMs(ctx, r, opts, dir)
+}
+
+// evaluateSBOMs scans the SBOMs in the directory and saves the findings as the state for the next evaluation.
+func evaluateSBOMs(ctx context.Context, r artifact.Runner, opts flag.Options, dir string) (types.Report, bool, error) {
+	state, err := loadState(opts.MonitorStateFile)
+	if err != nil {
+		return types.Report{}, false, err
+	}
+
+	sboms, err := findSBOMs(dir)
+	if err != nil {
+		return types.Report{}, false, xerrors.Errorf("unable to find SBOMs: %w", err)
+	}
+	log.InfoContext(ctx, "Evaluating SBOMs...", log.FilePath(dir), log.Int("num", len(sboms)))
+
+	report := types.Report{
+		SchemaVersion: 2,
+		CreatedAt:     clock.Now(ctx),
+		ArtifactName:  dir,
+	}
+	findings := make(map[string][]string, len(sboms))
+	var partial bool
+	for _, sbom := range sboms {
+		rel, err := filepath.Rel(dir, sbom)
+		if err != nil {
+			return types.Report{}, false, xerrors.Errorf("unable to get the relative path: %w", err)
+		}
+
+		scanOpts := opts
+		scanOpts.Target = sbom
+		rep, err := r.ScanSBOM(ctx, scanOpts)
+		if err != nil {
+			// Skip files that cannot be decoded as SBOM rather than aborting the whole evaluation
+			log.WarnContext(ctx, "Unable to scan SBOM", log.FilePath(rel), log.Err(err))
+			partial = true
+			// Keep the previous findings so that they are not reported again once the file can be scanned
+			if prev, ok := state.Findings[rel]; ok {
+				findings[rel] = prev
+			}
+			continue
+		}
+		if rep, err = r.Filter(ctx, scanOpts, rep); err != nil {
+			return types.Report{}, false, xerrors.Errorf("filter error: %w", err)
+		}
+
+		results, keys := newFindings(rep.Results, state.Findings[rel])
+		for _, result := range results {
+			result.Target = fmt.Sprintf("%s (%s)", rel, result.Target)
+			report.Results = append(report.Results, result)
+		}
+		findings[rel] = keys
+	}
+
+	if err = r.Report(ctx, opts, report); err != nil {
+		return types.Report{}, false, xerrors.Errorf("report error: %w", err)
+	}
+
+	// SBOMs removed from the directory are dropped from the state.
+	state.Findings = findings
+	state.EvaluatedAt = report.CreatedAt
+	if err = saveState(opts.MonitorStateFile, state); err != nil {
+		return types.Report{}, false, xerrors.Errorf("unable to save the state: %w", err)
+	}
+
+	return report, partial, nil
+}
+
+// findSBOMs returns regular files in the directory. The format is detected when each file is scanned.
+func findSBOMs(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func defaultStateFile(cacheDir, dir string) string {
+	return filepath.Join(cacheDir, "monitor", fmt.Sprintf("%x.json", sha256.Sum256([]byte(dir))))
+}
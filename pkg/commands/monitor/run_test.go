@@ -0,0 +1,87 @@
+package monitor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/commands/artifact"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type fakeRunner struct {
+	artifact.Runner
+	reports map[string]types.Report // keyed by the file name
+}
+
+func (r fakeRunner) ScanSBOM(_ context.Context, opts flag.Options) (types.Report, error) {
+	rep, ok := r.reports[filepath.Base(opts.Target)]
+	if !ok {
+		return types.Report{}, xerrors.New("unknown SBOM format")
+	}
+	return rep, nil
+}
+
+func (r fakeRunner) Filter(_ context.Context, _ flag.Options, report types.Report) (types.Report, error) {
+	return report, nil
+}
+
+func (r fakeRunner) Report(context.Context, flag.Options, types.Report) error {
+	return nil
+}
+
+func TestEvaluateSBOMs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.cdx.json", "broken.cdx.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600))
+	}
+
+	vuln := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0001",
+		PkgName:          "musl",
+		InstalledVersion: "1.2.3",
+	}
+	r := fakeRunner{
+		reports: map[string]types.Report{
+			"app.cdx.json": {
+				Results: types.Results{
+					{
+						Target:          "app",
+						Vulnerabilities: []types.DetectedVulnerability{vuln},
+					},
+				},
+			},
+		},
+	}
+
+	// The findings of the SBOM which fails to be scanned must be kept
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	prev := []string{"broken|CVE-2024-0002|zlib|1.3.1|"}
+	require.NoError(t, saveState(stateFile, State{
+		Findings: map[string][]string{
+			"broken.cdx.json":  prev,
+			"removed.cdx.json": {"removed|CVE-2024-0003|openssl|3.3.0|"},
+		},
+	}))
+
+	opts := flag.Options{}
+	opts.MonitorStateFile = stateFile
+	report, partial, err := evaluateSBOMs(context.Background(), r, opts, dir)
+	require.NoError(t, err)
+	assert.True(t, partial)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "app.cdx.json (app)", report.Results[0].Target)
+
+	state, err := loadState(stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"app.cdx.json":    {findingKey("app", vuln)},
+		"broken.cdx.json": prev,
+	}, state.Findings)
+}
@@ -0,0 +1,94 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/set"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// State holds findings reported by the previous evaluation, keyed by the SBOM path relative to the monitored directory.
+type State struct {
+	EvaluatedAt time.Time           `json:",omitempty"`
+	Findings    map[string][]string `json:",omitempty"`
+}
+
+func loadState(path string) (State, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return State{Findings: make(map[string][]string)}, nil
+	} else if err != nil {
+		return State{}, xerrors.Errorf("unable to open the state file: %w", err)
+	}
+	defer f.Close()
+
+	var state State
+	if err = json.NewDecoder(f).Decode(&state); err != nil {
+		return State{}, xerrors.Errorf("unable to decode the state file: %w", err)
+	}
+	if state.Findings == nil {
+		state.Findings = make(map[string][]string)
+	}
+	return state, nil
+}
+
+func saveState(path string, state State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return xerrors.Errorf("unable to create a directory: %w", err)
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return xerrors.Errorf("unable to encode the state: %w", err)
+	}
+	if err = os.WriteFile(path, b, 0o600); err != nil {
+		return xerrors.Errorf("unable to write the state file: %w", err)
+	}
+	return nil
+}
+
+// findingKey returns a key identifying the vulnerability regardless of its severity or fixed version
+// so that updated advisories are not reported again.
+func findingKey(target string, vuln types.DetectedVulnerability) string {
+	return strings.Join([]string{
+		target,
+		vuln.VulnerabilityID,
+		vuln.PkgName,
+		vuln.InstalledVersion,
+		vuln.PkgPath,
+	}, "|")
+}
+
+// newFindings removes vulnerabilities that were already reported in the previous evaluation from the results
+// and returns the keys of all vulnerabilities currently detected.
+func newFindings(results types.Results, reported []string) (types.Results, []string) {
+	var keys []string
+	var filtered types.Results
+	seen := set.New(reported...)
+	for _, result := range results {
+		var vulns []types.DetectedVulnerability
+		for _, vuln := range result.Vulnerabilities {
+			key := findingKey(result.Target, vuln)
+			keys = append(keys, key)
+			if seen.Contains(key) {
+				continue
+			}
+			vulns = append(vulns, vuln)
+		}
+		if len(vulns) == 0 {
+			continue
+		}
+		result.Vulnerabilities = vulns
+		result.Packages = nil // Only new findings are reported
+		filtered = append(filtered, result)
+	}
+	slices.Sort(keys)
+	return filtered, slices.Compact(keys)
+}
@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestNewFindings(t *testing.T) {
+	vuln1 := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0001",
+		PkgName:          "musl",
+		InstalledVersion: "1.2.3",
+	}
+	vuln2 := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0002",
+		PkgName:          "musl",
+		InstalledVersion: "1.2.3",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: "HIGH",
+		},
+	}
+	results := types.Results{
+		{
+			Target: "alpine:3.20 (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Packages: []ftypes.Package{
+				{
+					Name:    "musl",
+					Version: "1.2.3",
+				},
+			},
+			Vulnerabilities: []types.DetectedVulnerability{
+				vuln1,
+				vuln2,
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		reported    []string
+		wantResults types.Results
+		wantKeys    []string
+	}{
+		{
+			name:     "first evaluation",
+			reported: nil,
+			wantResults: types.Results{
+				{
+					Target: "alpine:3.20 (alpine 3.20.0)",
+					Class:  types.ClassOSPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln1,
+						vuln2,
+					},
+				},
+			},
+			wantKeys: []string{
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0001|musl|1.2.3|",
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0002|musl|1.2.3|",
+			},
+		},
+		{
+			name: "newly applicable vulnerability",
+			reported: []string{
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0001|musl|1.2.3|",
+			},
+			wantResults: types.Results{
+				{
+					Target: "alpine:3.20 (alpine 3.20.0)",
+					Class:  types.ClassOSPkg,
+					Vulnerabilities: []types.DetectedVulnerability{
+						vuln2,
+					},
+				},
+			},
+			wantKeys: []string{
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0001|musl|1.2.3|",
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0002|musl|1.2.3|",
+			},
+		},
+		{
+			name: "no new vulnerabilities",
+			reported: []string{
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0001|musl|1.2.3|",
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0002|musl|1.2.3|",
+				"alpine:3.20 (alpine 3.20.0)|CVE-2023-9999|busybox|1.36.1|",
+			},
+			wantResults: nil,
+			wantKeys: []string{
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0001|musl|1.2.3|",
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0002|musl|1.2.3|",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotResults, gotKeys := newFindings(results, tt.reported)
+			assert.Equal(t, tt.wantResults, gotResults)
+			assert.Equal(t, tt.wantKeys, gotKeys)
+		})
+	}
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "monitor", "state.json")
+
+	// The state file doesn't exist in the first evaluation
+	got, err := loadState(path)
+	require.NoError(t, err)
+	assert.Empty(t, got.Findings)
+
+	want := State{
+		EvaluatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Findings: map[string][]string{
+			"alpine.cdx.json": {
+				"alpine:3.20 (alpine 3.20.0)|CVE-2024-0001|musl|1.2.3|",
+			},
+		},
+	}
+	require.NoError(t, saveState(path, want))
+
+	got, err = loadState(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -2,6 +2,7 @@ package operation
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/google/go-containerregistry/pkg/name"
@@ -21,14 +22,15 @@ import (
 var mu sync.Mutex
 
 // DownloadDB downloads the DB
-func DownloadDB(ctx context.Context, appVersion, cacheDir string, dbRepositories []name.Reference, quiet, skipUpdate bool,
-	opt ftypes.RegistryOptions) error {
+func DownloadDB(ctx context.Context, appVersion, cacheDir string, dbRepositories []name.Reference, dbURL string,
+	dbDelta, quiet, skipUpdate bool, opt ftypes.RegistryOptions) error {
 	mu.Lock()
 	defer mu.Unlock()
 
 	ctx = log.WithContextPrefix(ctx, log.PrefixVulnerabilityDB)
 	dbDir := db.Dir(cacheDir)
-	client := db.NewClient(dbDir, quiet, db.WithDBRepository(dbRepositories))
+	client := db.NewClient(dbDir, quiet, db.WithDBRepository(dbRepositories), db.WithURL(dbURL),
+		db.WithDelta(dbDelta))
 	needsUpdate, err := client.NeedsUpdate(ctx, appVersion, skipUpdate)
 	if err != nil {
 		return xerrors.Errorf("database error: %w", err)
@@ -78,11 +80,11 @@ func DownloadVEXRepositories(ctx context.Context, opts flag.Options) error {
 }
 
 // InitBuiltinChecks downloads the built-in policies and loads them
-func InitBuiltinChecks(ctx context.Context, cacheDir string, quiet, skipUpdate bool, checkBundleRepository string, registryOpts ftypes.RegistryOptions) ([]string, error) {
+func InitBuiltinChecks(ctx context.Context, cacheDir string, quiet, skipUpdate bool, checkBundleRepositories []string, registryOpts ftypes.RegistryOptions) ([]string, error) {
 	mu.Lock()
 	defer mu.Unlock()
 
-	client, err := policy.NewClient(cacheDir, quiet, checkBundleRepository)
+	client, err := policy.NewClient(cacheDir, quiet, checkBundleRepositories)
 	if err != nil {
 		return nil, xerrors.Errorf("check client error: %w", err)
 	}
@@ -115,15 +117,46 @@ func InitBuiltinChecks(ctx context.Context, cacheDir string, quiet, skipUpdate b
 	return policyPaths, nil
 }
 
-func Exit(opts flag.Options, failedResults bool, m types.Metadata) error {
+// Exit returns the error with the exit code for the outcome of the scan.
+// partial is true if some targets failed to be scanned.
+func Exit(opts flag.Options, failedResults, partial bool, m types.Metadata) error {
 	if opts.ExitOnEOL != 0 && m.OS != nil && m.OS.Eosl {
 		log.Error("Detected EOL OS", log.String("family", string(m.OS.Family)),
 			log.String("version", m.OS.Name))
 		return &types.ExitError{Code: opts.ExitOnEOL}
 	}
 
+	// Findings take precedence over failed targets as they are security failures anyway
+	status := types.ExitStatusClean
+	if failedResults {
+		status = types.ExitStatusFindings
+	} else if _, ok := opts.ExitCodeMap[types.ExitStatusPartial]; ok && partial {
+		// Partial scans are regarded as clean unless the exit code is specified
+		status = types.ExitStatusPartial
+	}
+	if code, ok := opts.ExitCodeMap[status]; ok {
+		if code == 0 {
+			return nil
+		}
+		return &types.ExitError{Code: code}
+	}
+
 	if opts.ExitCode != 0 && failedResults {
 		return &types.ExitError{Code: opts.ExitCode}
 	}
 	return nil
 }
+
+// ExitOnError returns the error with the exit code for scan errors if it is specified with "--exit-code-map".
+// Errors already having an exit code are returned as is.
+func ExitOnError(opts flag.Options, err error) error {
+	code, ok := opts.ExitCodeMap[types.ExitStatusError]
+	var exitError *types.ExitError
+	if err == nil || !ok || errors.As(err, &exitError) {
+		return err
+	}
+	return &types.ExitError{
+		Code: code,
+		Err:  err,
+	}
+}
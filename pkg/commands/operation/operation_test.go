@@ -0,0 +1,106 @@
+package operation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/commands/operation"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestExit(t *testing.T) {
+	exitCodeMap := map[types.ExitStatus]int{
+		types.ExitStatusClean:    0,
+		types.ExitStatusFindings: 1,
+		types.ExitStatusPartial:  3,
+	}
+	tests := []struct {
+		name          string
+		opts          flag.Options
+		failedResults bool
+		partial       bool
+		want          error
+	}{
+		{
+			name:          "exit code",
+			opts:          flag.Options{ReportOptions: flag.ReportOptions{ExitCode: 5}},
+			failedResults: true,
+			want:          &types.ExitError{Code: 5},
+		},
+		{
+			name: "no exit code",
+		},
+		{
+			name:          "findings",
+			opts:          flag.Options{ReportOptions: flag.ReportOptions{ExitCodeMap: exitCodeMap}},
+			failedResults: true,
+			partial:       true,
+			want:          &types.ExitError{Code: 1},
+		},
+		{
+			name:    "partial",
+			opts:    flag.Options{ReportOptions: flag.ReportOptions{ExitCodeMap: exitCodeMap}},
+			partial: true,
+			want:    &types.ExitError{Code: 3},
+		},
+		{
+			name: "clean",
+			opts: flag.Options{ReportOptions: flag.ReportOptions{ExitCodeMap: exitCodeMap}},
+		},
+		{
+			name: "partial falls back to clean",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCodeMap: map[types.ExitStatus]int{
+						types.ExitStatusClean: 10,
+					},
+				},
+			},
+			partial: true,
+			want:    &types.ExitError{Code: 10},
+		},
+		{
+			name: "findings fall back to --exit-code",
+			opts: flag.Options{
+				ReportOptions: flag.ReportOptions{
+					ExitCode: 5,
+					ExitCodeMap: map[types.ExitStatus]int{
+						types.ExitStatusPartial: 3,
+					},
+				},
+			},
+			failedResults: true,
+			want:          &types.ExitError{Code: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := operation.Exit(tt.opts, tt.failedResults, tt.partial, types.Metadata{})
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
+
+func TestExitOnError(t *testing.T) {
+	scanErr := errors.New("network error")
+	opts := flag.Options{
+		ReportOptions: flag.ReportOptions{
+			ExitCodeMap: map[types.ExitStatus]int{
+				types.ExitStatusError: 2,
+			},
+		},
+	}
+
+	assert.NoError(t, operation.ExitOnError(opts, nil))
+	assert.Equal(t, &types.ExitError{Code: 2, Err: scanErr}, operation.ExitOnError(opts, scanErr))
+
+	// The exit code for findings is kept
+	exitErr := &types.ExitError{Code: 1}
+	assert.Equal(t, exitErr, operation.ExitOnError(opts, exitErr))
+
+	// Not configured
+	assert.Equal(t, scanErr, operation.ExitOnError(flag.Options{}, scanErr))
+}
@@ -0,0 +1,261 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/secret"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	positiveDir = "positive"
+	negativeDir = "negative"
+)
+
+// Report represents the result of testing custom secret rules against sample files.
+type Report struct {
+	Rules []RuleResult
+}
+
+// RuleResult represents the result of testing a custom secret rule.
+type RuleResult struct {
+	RuleID string
+
+	// Positives is the number of samples that must be detected by the rule
+	Positives int
+	// Negatives is the number of samples that must not be detected by the rule
+	Negatives int
+
+	// Missed holds positive samples that the rule didn't detect
+	Missed []string
+	// FalseMatches holds secrets that the rule detected in negative samples
+	FalseMatches []FalseMatch
+}
+
+type FalseMatch struct {
+	FilePath string
+	Line     int
+	Match    string
+}
+
+func (r RuleResult) Tested() bool {
+	return r.Positives > 0
+}
+
+func (r RuleResult) Failed() bool {
+	return len(r.Missed) > 0 || len(r.FalseMatches) > 0
+}
+
+// Covered returns the number of rules having at least one positive sample.
+func (r Report) Covered() int {
+	var covered int
+	for _, rule := range r.Rules {
+		if rule.Tested() {
+			covered++
+		}
+	}
+	return covered
+}
+
+func (r Report) Failed() bool {
+	for _, rule := range r.Rules {
+		if rule.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Run tests custom secret rules against positive and negative samples and reports the results.
+func Run(ctx context.Context, opts flag.Options) error {
+	if opts.SecretCasesDir == "" {
+		return xerrors.New("'--cases' must be specified")
+	}
+
+	report, err := Test(opts.SecretRulesPath, opts.SecretCasesDir)
+	if err != nil {
+		return xerrors.Errorf("secret rule test error: %w", err)
+	}
+
+	w, cleanup, err := opts.OutputWriter(ctx)
+	if err != nil {
+		return xerrors.Errorf("failed to create output writer: %w", err)
+	}
+	defer func() { _ = cleanup() }()
+
+	if err = Write(w, report); err != nil {
+		return xerrors.Errorf("failed to write the results: %w", err)
+	}
+
+	if report.Failed() {
+		return xerrors.New("some secret rules failed the tests")
+	}
+	return nil
+}
+
+// Test runs custom rules defined in rulesPath against samples in casesDir.
+// Samples are stored per rule as follows:
+//
+//	cases/
+//	└── <rule-id>/
+//	    ├── positive/  # files that must be detected by the rule
+//	    └── negative/  # files that must not be detected by the rule
+//
+// Samples are scanned with paths relative to "positive" or "negative" so that rules with "path" can be tested.
+func Test(rulesPath, casesDir string) (Report, error) {
+	// secret.ParseConfig falls back to built-in rules when the file doesn't exist.
+	if _, err := os.Stat(rulesPath); err != nil {
+		return Report{}, xerrors.Errorf("rules file error: %w", err)
+	}
+	if _, err := os.Stat(casesDir); err != nil {
+		return Report{}, xerrors.Errorf("cases directory error: %w", err)
+	}
+
+	config, err := secret.ParseConfig(rulesPath)
+	if err != nil {
+		return Report{}, xerrors.Errorf("unable to parse %s: %w", rulesPath, err)
+	} else if config == nil || len(config.CustomRules) == 0 {
+		return Report{}, xerrors.Errorf("no custom rules found in %s", rulesPath)
+	}
+	scanner := secret.NewScanner(config)
+
+	ruleIDs := make(map[string]struct{})
+	var report Report
+	for _, rule := range config.CustomRules {
+		ruleIDs[rule.ID] = struct{}{}
+		result := RuleResult{RuleID: rule.ID}
+
+		ruleDir := filepath.Join(casesDir, rule.ID)
+		err = walkSamples(filepath.Join(ruleDir, positiveDir), func(filePath, displayPath string, content []byte) {
+			result.Positives++
+			if len(findings(scanner, rule.ID, filePath, content)) == 0 {
+				result.Missed = append(result.Missed, displayPath)
+			}
+		})
+		if err != nil {
+			return Report{}, xerrors.Errorf("positive samples error (%s): %w", rule.ID, err)
+		}
+
+		err = walkSamples(filepath.Join(ruleDir, negativeDir), func(filePath, displayPath string, content []byte) {
+			result.Negatives++
+			for _, f := range findings(scanner, rule.ID, filePath, content) {
+				result.FalseMatches = append(result.FalseMatches, FalseMatch{
+					FilePath: displayPath,
+					Line:     f.StartLine,
+					Match:    f.Match,
+				})
+			}
+		})
+		if err != nil {
+			return Report{}, xerrors.Errorf("negative samples error (%s): %w", rule.ID, err)
+		}
+		report.Rules = append(report.Rules, result)
+	}
+
+	// Warn about samples for rules that don't exist, e.g. typos in directory names
+	entries, err := os.ReadDir(casesDir)
+	if err != nil {
+		return Report{}, xerrors.Errorf("unable to read %s: %w", casesDir, err)
+	}
+	for _, entry := range entries {
+		if _, ok := ruleIDs[entry.Name()]; entry.IsDir() && !ok {
+			log.Warn("Samples found for an unknown rule", log.String("rule_id", entry.Name()))
+		}
+	}
+
+	return report, nil
+}
+
+// walkSamples calls fn for each sample file under dir.
+// The file path passed to the scanner is relative to dir, while displayPath contains the "positive" or "negative" prefix.
+func walkSamples(dir string, fn func(filePath, displayPath string, content []byte)) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return xerrors.Errorf("read error: %w", err)
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return xerrors.Errorf("relative path error: %w", err)
+		}
+		rel = filepath.ToSlash(rel)
+		fn(rel, filepath.Base(dir)+"/"+rel, content)
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		// Rules may have only positive or negative samples
+		return nil
+	}
+	return err
+}
+
+// findings returns secrets detected by the given rule.
+func findings(scanner secret.Scanner, ruleID, filePath string, content []byte) []types.SecretFinding {
+	result := scanner.Scan(secret.ScanArgs{
+		FilePath: filePath,
+		Content:  content,
+	})
+	return lo.Filter(result.Findings, func(f types.SecretFinding, _ int) bool {
+		return f.RuleID == ruleID
+	})
+}
+
+// Write writes the report in a human-readable format.
+func Write(w io.Writer, report Report) error {
+	var output strings.Builder
+	for _, rule := range report.Rules {
+		status := "PASS"
+		switch {
+		case rule.Failed():
+			status = "FAIL"
+		case !rule.Tested():
+			status = "UNTESTED"
+		}
+		output.WriteString(fmt.Sprintf("%-8s %s (positive: %d/%d, negative: %d/%d)\n", status, rule.RuleID,
+			rule.Positives-len(rule.Missed), rule.Positives, rule.Negatives-falseMatchedFiles(rule), rule.Negatives))
+
+		for _, missed := range rule.Missed {
+			output.WriteString(fmt.Sprintf("  - missed: %s\n", missed))
+		}
+		for _, m := range rule.FalseMatches {
+			output.WriteString(fmt.Sprintf("  - false match: %s:%d: %s\n", m.FilePath, m.Line, m.Match))
+		}
+	}
+
+	covered := report.Covered()
+	total := len(report.Rules)
+	output.WriteString(fmt.Sprintf("\nCoverage: %d/%d rules (%.1f%%)\n", covered, total, float64(covered)*100/float64(total)))
+
+	if _, err := io.WriteString(w, output.String()); err != nil {
+		return xerrors.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// falseMatchedFiles returns the number of negative samples with false matches.
+func falseMatchedFiles(rule RuleResult) int {
+	files := make(map[string]struct{})
+	for _, m := range rule.FalseMatches {
+		files[m.FilePath] = struct{}{}
+	}
+	return len(files)
+}
@@ -0,0 +1,119 @@
+package secret_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/commands/secret"
+)
+
+func TestTest(t *testing.T) {
+	tests := []struct {
+		name      string
+		rulesPath string
+		casesDir  string
+		want      secret.Report
+		wantErr   string
+	}{
+		{
+			name:      "happy path",
+			rulesPath: "testdata/rules.yaml",
+			casesDir:  "testdata/cases",
+			want: secret.Report{
+				Rules: []secret.RuleResult{
+					{
+						RuleID:    "internal-token",
+						Positives: 1,
+						Negatives: 1,
+					},
+					{
+						RuleID:    "db-password",
+						Positives: 2,
+						Negatives: 2,
+						Missed: []string{
+							"positive/empty.env",
+						},
+						FalseMatches: []secret.FalseMatch{
+							{
+								FilePath: "negative/template.env",
+								Line:     2,
+								Match:    "db_password=**************",
+							},
+						},
+					},
+					{
+						RuleID: "untested-rule",
+					},
+				},
+			},
+		},
+		{
+			name:      "rules file not found",
+			rulesPath: "testdata/missing.yaml",
+			casesDir:  "testdata/cases",
+			wantErr:   "rules file error",
+		},
+		{
+			name:      "cases directory not found",
+			rulesPath: "testdata/rules.yaml",
+			casesDir:  "testdata/missing",
+			wantErr:   "cases directory error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := secret.Test(tt.rulesPath, tt.casesDir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	report := secret.Report{
+		Rules: []secret.RuleResult{
+			{
+				RuleID:    "internal-token",
+				Positives: 1,
+				Negatives: 1,
+			},
+			{
+				RuleID:    "db-password",
+				Positives: 2,
+				Negatives: 2,
+				Missed: []string{
+					"positive/empty.env",
+				},
+				FalseMatches: []secret.FalseMatch{
+					{
+						FilePath: "negative/template.env",
+						Line:     2,
+						Match:    "db_password=**************",
+					},
+				},
+			},
+			{
+				RuleID: "untested-rule",
+			},
+		},
+	}
+
+	want := `PASS     internal-token (positive: 1/1, negative: 1/1)
+FAIL     db-password (positive: 1/2, negative: 1/2)
+  - missed: positive/empty.env
+  - false match: negative/template.env:2: db_password=**************
+UNTESTED untested-rule (positive: 0/0, negative: 0/0)
+
+Coverage: 2/3 rules (66.7%)
+`
+	var buf bytes.Buffer
+	require.NoError(t, secret.Write(&buf, report))
+	assert.Equal(t, want, buf.String())
+}
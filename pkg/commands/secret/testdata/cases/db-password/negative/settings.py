@@ -0,0 +1 @@
+db_password=s3cr3t
@@ -0,0 +1,37 @@
+package verify
+
+import (
+	"context"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/signing"
+)
+
+// Run verifies the signature of the report signed with "--signing-key".
+func Run(_ context.Context, opts flag.Options) error {
+	if opts.VerifyKey == "" {
+		return xerrors.New(`"--key" must be specified`)
+	}
+
+	sig, err := os.ReadFile(opts.ReportSignature)
+	if err != nil {
+		return xerrors.Errorf("unable to read the signature: %w", err)
+	}
+
+	f, err := os.Open(opts.ReportPath)
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	if err = signing.Verify(opts.VerifyKey, f, sig); err != nil {
+		return xerrors.Errorf("verification failed: %w", err)
+	}
+
+	log.Info("Verified OK", log.FilePath(opts.ReportPath))
+	return nil
+}
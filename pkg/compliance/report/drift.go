@@ -0,0 +1,191 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/table"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	PreviousStatusColumn = "Previous"
+	CurrentStatusColumn  = "Current"
+)
+
+// Snapshot is the status of the controls saved after a compliance scan.
+// The next scan compares the status of the controls with the snapshot to detect drift.
+type Snapshot struct {
+	ID        string
+	Target    string
+	CreatedAt time.Time
+	Controls  []ControlCheckSummary
+}
+
+// DriftReport represents the controls whose status changed since the previous scan
+type DriftReport struct {
+	ID           string
+	Title        string
+	Target       string
+	PreviousScan *time.Time     `json:",omitempty"` // nil if there is no previous scan
+	Controls     []ControlDrift `json:",omitempty"`
+}
+
+type ControlDrift struct {
+	ID             string
+	Name           string
+	Severity       string
+	PreviousStatus string
+	Status         string
+	TotalFail      int
+}
+
+// NewSnapshot returns the status of the controls in the compliance report
+func NewSnapshot(report *ComplianceReport, target string, createdAt time.Time) Snapshot {
+	return Snapshot{
+		ID:        report.ID,
+		Target:    target,
+		CreatedAt: createdAt,
+		Controls:  BuildSummary(report).SummaryControls,
+	}
+}
+
+// LoadSnapshot reads the snapshot saved by the previous scan. It returns nil if the snapshot doesn't exist.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, xerrors.Errorf("failed to open the compliance snapshot: %w", err)
+	}
+	defer f.Close()
+
+	var snapshot Snapshot
+	if err = json.NewDecoder(f).Decode(&snapshot); err != nil {
+		return nil, xerrors.Errorf("failed to decode the compliance snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// SaveSnapshot saves the snapshot for the next scan
+func SaveSnapshot(path string, snapshot Snapshot) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return xerrors.Errorf("failed to create a directory for the compliance snapshot: %w", err)
+	}
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal the compliance snapshot: %w", err)
+	}
+	if err = os.WriteFile(path, b, 0o600); err != nil {
+		return xerrors.Errorf("failed to write the compliance snapshot: %w", err)
+	}
+	return nil
+}
+
+// BuildDriftReport compares the status of the controls with the previous snapshot.
+// Manual controls and controls that didn't exist in the previous scan are not reported.
+func BuildDriftReport(previous *Snapshot, report *ComplianceReport, target string) *DriftReport {
+	drift := &DriftReport{
+		ID:     report.ID,
+		Title:  report.Title,
+		Target: target,
+	}
+	if previous == nil {
+		return drift
+	}
+	drift.PreviousScan = &previous.CreatedAt
+
+	previousStatus := make(map[string]string)
+	for _, control := range previous.Controls {
+		previousStatus[control.ID] = control.status()
+	}
+
+	for _, control := range BuildSummary(report).SummaryControls {
+		status, prev := control.status(), previousStatus[control.ID]
+		if status == "" || prev == "" || status == prev {
+			continue
+		}
+		drift.Controls = append(drift.Controls, ControlDrift{
+			ID:             control.ID,
+			Name:           control.Name,
+			Severity:       control.Severity,
+			PreviousStatus: prev,
+			Status:         status,
+			TotalFail:      *control.TotalFail,
+		})
+	}
+	return drift
+}
+
+// Regressed returns true if any control changed from PASS to FAIL
+func (r DriftReport) Regressed() bool {
+	for _, control := range r.Controls {
+		if control.PreviousStatus == StatusPass && control.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteDrift writes the drift report in the given format
+func WriteDrift(report *DriftReport, option Option) error {
+	switch option.Format {
+	case types.FormatJSON:
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return xerrors.Errorf("failed to marshal json: %w", err)
+		}
+		if _, err = fmt.Fprintln(option.Output, string(output)); err != nil {
+			return xerrors.Errorf("failed to write json: %w", err)
+		}
+		return nil
+	case types.FormatTable:
+		return writeDriftTable(option.Output, report)
+	default:
+		return xerrors.Errorf(`unknown format %q. Use "json" or "table"`, option.Format)
+	}
+}
+
+func writeDriftTable(output io.Writer, report *DriftReport) error {
+	if _, err := fmt.Fprintf(output, "\nDrift Report for compliance: %s\n", report.Title); err != nil {
+		return xerrors.Errorf("failed to write drift report: %w", err)
+	}
+
+	var msg string
+	switch {
+	case report.PreviousScan == nil:
+		msg = "No previous scan to compare with. The next scan will report the drift from this one."
+	case len(report.Controls) == 0:
+		msg = fmt.Sprintf("No drift since the previous scan at %s.", report.PreviousScan.Format(time.RFC3339))
+	default:
+		msg = fmt.Sprintf("Compared with the previous scan at %s.", report.PreviousScan.Format(time.RFC3339))
+	}
+	if _, err := fmt.Fprintln(output, msg); err != nil {
+		return xerrors.Errorf("failed to write drift report: %w", err)
+	}
+	if len(report.Controls) == 0 {
+		return nil
+	}
+
+	t := table.New(output)
+	t.SetRowLines(false)
+	t.SetHeaders(ControlIDColumn, SeverityColumn, ControlNameColumn, PreviousStatusColumn, CurrentStatusColumn,
+		IssuesColumn)
+	t.SetAlignment(table.AlignLeft, table.AlignCenter, table.AlignLeft, table.AlignCenter, table.AlignCenter,
+		table.AlignCenter)
+	t.SetAutoMergeHeaders(true)
+	for _, control := range report.Controls {
+		t.AddRow(control.ID, control.Severity, control.Name, control.PreviousStatus, control.Status,
+			strconv.Itoa(control.TotalFail))
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,192 @@
+package report_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/compliance/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+var (
+	previousScan = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	previousSnapshot = &report.Snapshot{
+		ID:        "1234",
+		Target:    "test-cluster",
+		CreatedAt: previousScan,
+		Controls: []report.ControlCheckSummary{
+			{
+				ID:        "1.0",
+				Name:      "Non-root containers",
+				Severity:  "MEDIUM",
+				TotalFail: lo.ToPtr(0),
+			},
+			{
+				ID:        "1.1",
+				Name:      "Immutable container file systems",
+				Severity:  "LOW",
+				TotalFail: lo.ToPtr(2),
+			},
+			{
+				ID:        "1.2",
+				Name:      "Preventing privileged containers",
+				Severity:  "HIGH",
+				TotalFail: lo.ToPtr(1),
+			},
+			{
+				ID:       "1.3",
+				Name:     "Manual control",
+				Severity: "LOW",
+			},
+		},
+	}
+
+	currentReport = &report.ComplianceReport{
+		ID:    "1234",
+		Title: "NSA",
+		Results: []*report.ControlCheckResult{
+			{
+				ID:       "1.0",
+				Name:     "Non-root containers",
+				Severity: "MEDIUM",
+				Results: types.Results{
+					{
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								AVDID:  "AVD-KSV012",
+								Status: types.MisconfStatusFailure,
+							},
+						},
+					},
+				},
+			},
+			{
+				ID:       "1.1",
+				Name:     "Immutable container file systems",
+				Severity: "LOW",
+			},
+			{
+				ID:       "1.2",
+				Name:     "Preventing privileged containers",
+				Severity: "HIGH",
+				Results: types.Results{
+					{
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								AVDID:  "AVD-KSV017",
+								Status: types.MisconfStatusFailure,
+							},
+						},
+					},
+				},
+			},
+			{
+				ID:       "1.3",
+				Name:     "Manual control",
+				Severity: "LOW",
+			},
+			{
+				ID:       "1.4",
+				Name:     "New control",
+				Severity: "LOW",
+			},
+		},
+	}
+)
+
+func TestBuildDriftReport(t *testing.T) {
+	tests := []struct {
+		name          string
+		previous      *report.Snapshot
+		want          *report.DriftReport
+		wantRegressed bool
+	}{
+		{
+			name:     "drift",
+			previous: previousSnapshot,
+			want: &report.DriftReport{
+				ID:           "1234",
+				Title:        "NSA",
+				Target:       "test-cluster",
+				PreviousScan: &previousScan,
+				Controls: []report.ControlDrift{
+					{
+						ID:             "1.0",
+						Name:           "Non-root containers",
+						Severity:       "MEDIUM",
+						PreviousStatus: "PASS",
+						Status:         "FAIL",
+						TotalFail:      1,
+					},
+					{
+						ID:             "1.1",
+						Name:           "Immutable container file systems",
+						Severity:       "LOW",
+						PreviousStatus: "FAIL",
+						Status:         "PASS",
+						TotalFail:      0,
+					},
+				},
+			},
+			wantRegressed: true,
+		},
+		{
+			name: "no previous scan",
+			want: &report.DriftReport{
+				ID:     "1234",
+				Title:  "NSA",
+				Target: "test-cluster",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := report.BuildDriftReport(tt.previous, currentReport, "test-cluster")
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRegressed, got.Regressed())
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "compliance", "1234.json")
+
+	got, err := report.LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, report.SaveSnapshot(path, *previousSnapshot))
+	got, err = report.LoadSnapshot(path)
+	require.NoError(t, err)
+	assert.Equal(t, previousSnapshot, got)
+}
+
+func TestWriteDrift(t *testing.T) {
+	drift := report.BuildDriftReport(previousSnapshot, currentReport, "test-cluster")
+
+	buf := new(bytes.Buffer)
+	err := report.WriteDrift(drift, report.Option{
+		Format: types.FormatTable,
+		Output: buf,
+	})
+	require.NoError(t, err)
+
+	want := `
+Drift Report for compliance: NSA
+Compared with the previous scan at 2024-01-01T00:00:00Z.
+┌─────┬──────────┬──────────────────────────────────┬──────────┬─────────┬────────┐
+│ ID  │ Severity │           Control Name           │ Previous │ Current │ Issues │
+├─────┼──────────┼──────────────────────────────────┼──────────┼─────────┼────────┤
+│ 1.0 │  MEDIUM  │ Non-root containers              │   PASS   │  FAIL   │   1    │
+│ 1.1 │   LOW    │ Immutable container file systems │   FAIL   │  PASS   │   0    │
+└─────┴──────────┴──────────────────────────────────┴──────────┴─────────┴────────┘
+`
+	assert.Equal(t, want, buf.String())
+}
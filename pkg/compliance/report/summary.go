@@ -12,6 +12,11 @@ import (
 	"github.com/aquasecurity/table"
 )
 
+const (
+	StatusPass = "PASS"
+	StatusFail = "FAIL"
+)
+
 func BuildSummary(cr *ComplianceReport) *SummaryReport {
 	var ccma []ControlCheckSummary
 	for _, control := range cr.Results {
@@ -32,6 +37,18 @@ func BuildSummary(cr *ComplianceReport) *SummaryReport {
 	}
 }
 
+// status returns the status of the control. It is empty for manual controls.
+func (s ControlCheckSummary) status() string {
+	switch {
+	case s.TotalFail == nil:
+		return ""
+	case *s.TotalFail == 0:
+		return StatusPass
+	default:
+		return StatusFail
+	}
+}
+
 type SummaryWriter struct {
 	Output io.Writer
 }
@@ -81,11 +98,7 @@ func (s SummaryWriter) generateSummary(summaryControls ControlCheckSummary) []st
 	numOfIssues := "-"
 	status := "-"
 	if summaryControls.TotalFail != nil {
-		if *summaryControls.TotalFail == 0 {
-			status = "PASS"
-		} else {
-			status = "FAIL"
-		}
+		status = summaryControls.status()
 		numOfIssues = strconv.Itoa(*summaryControls.TotalFail)
 	}
 	return []string{
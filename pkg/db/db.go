@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -41,6 +42,8 @@ var (
 type options struct {
 	artifact       *oci.Artifact
 	dbRepositories []name.Reference
+	url            string
+	delta          bool
 }
 
 // Option is a functional option
@@ -53,6 +56,14 @@ func WithOCIArtifact(art *oci.Artifact) Option {
 	}
 }
 
+// WithDelta enables downloading the delta from the local DB.
+// It is opt-in because deltas are not published in the default repositories.
+func WithDelta(enabled bool) Option {
+	return func(opts *options) {
+		opts.delta = enabled
+	}
+}
+
 // WithDBRepository takes a dbRepository
 func WithDBRepository(dbRepository []name.Reference) Option {
 	return func(opts *options) {
@@ -60,6 +71,14 @@ func WithDBRepository(dbRepository []name.Reference) Option {
 	}
 }
 
+// WithURL takes the URL of a gzipped tarball of the DB,
+// which is downloaded over HTTP(S) instead of the OCI artifacts
+func WithURL(url string) Option {
+	return func(opts *options) {
+		opts.url = url
+	}
+}
+
 // Client implements DB operations
 type Client struct {
 	*options
@@ -152,8 +171,30 @@ func (c *Client) isNewDB(ctx context.Context, meta metadata.Metadata) bool {
 	return false
 }
 
-// Download downloads the DB file
+// Download downloads the DB file.
+// If delta updates are enabled, only the changes since the local DB are downloaded when the delta is available,
+// otherwise it falls back to downloading the whole DB.
 func (c *Client) Download(ctx context.Context, dst string, opt types.RegistryOptions) error {
+	if c.url != "" {
+		if err := c.downloadURL(ctx, opt, dst); err != nil {
+			return xerrors.Errorf("HTTP download error: %w", err)
+		}
+		if err := c.updateDownloadedAt(ctx, dst); err != nil {
+			return xerrors.Errorf("failed to update downloaded_at: %w", err)
+		}
+		return nil
+	}
+
+	if err := c.downloadDelta(ctx, opt, dst); err == nil {
+		log.InfoContext(ctx, "Vulnerability DB updated with the delta")
+		if err = c.updateDownloadedAt(ctx, dst); err != nil {
+			return xerrors.Errorf("failed to update downloaded_at: %w", err)
+		}
+		return nil
+	} else if !errors.Is(err, errNoDelta) {
+		log.DebugContext(ctx, "Falling back to downloading the whole DB", log.Err(err))
+	}
+
 	// Remove the metadata file under the cache directory before downloading DB
 	if err := c.metadata.Delete(); err != nil {
 		log.DebugContext(ctx, "No metadata file")
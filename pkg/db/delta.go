@@ -0,0 +1,227 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	bolt "go.etcd.io/bbolt"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy-db/pkg/db"
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/oci"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+const (
+	deltaMediaType = "application/vnd.aquasec.trivy.db.delta.layer.v1.tar+gzip"
+	deltaFileName  = "delta.jsonl"
+
+	// deltaTagTimeFormat is the format of the version of the local DB in delta tags, e.g. "2-delta-20241017060000"
+	deltaTagTimeFormat = "20060102150405"
+
+	deltaOpPut    = "put"
+	deltaOpDelete = "delete"
+)
+
+var errNoDelta = xerrors.New("no delta available")
+
+// deltaOperation is a change of the DB between two versions, stored as a line of delta.jsonl.
+// "put" stores the value under the key in the nested buckets, creating them if needed.
+// "delete" removes the key, or the last bucket if the key is empty.
+type deltaOperation struct {
+	Op     string          `json:"op"`
+	Bucket []string        `json:"bucket"`
+	Key    string          `json:"key,omitempty"`
+	Value  json.RawMessage `json:"value,omitempty"`
+}
+
+// deltaArtifacts returns the artifacts of the delta from the local DB version to the latest one.
+// Deltas are published only for recent versions, so the artifacts don't exist when the local DB is too old.
+func (c *Client) deltaArtifacts(ctx context.Context, base time.Time, opt types.RegistryOptions) oci.Artifacts {
+	if !c.delta || c.artifact != nil {
+		return nil
+	}
+
+	var arts oci.Artifacts
+	for _, repo := range c.dbRepositories {
+		// Deltas can't be derived from digests
+		tag, ok := repo.(name.Tag)
+		if !ok {
+			continue
+		}
+		// Deltas are not published in the default repositories, so looking them up is a wasted round-trip.
+		if tag.Context() == defaultGHCRRepository.Context() || tag.Context() == defaultGCRRepository.Context() {
+			log.DebugContext(ctx, "Delta updates are not available for the default repository", log.String("repo", tag.String()))
+			continue
+		}
+		deltaTag := tag.Tag(fmt.Sprintf("%s-delta-%s", tag.TagStr(), base.UTC().Format(deltaTagTimeFormat)))
+		arts = append(arts, oci.NewArtifact(deltaTag.String(), opt))
+	}
+	return arts
+}
+
+// downloadDelta downloads only the changes since the local DB and applies them to the DB in dst.
+// The local DB is copied to dst first if dst is not the DB directory.
+func (c *Client) downloadDelta(ctx context.Context, opt types.RegistryOptions, dst string) error {
+	meta, err := c.metadata.Get()
+	if err != nil || meta.Version != db.SchemaVersion {
+		return errNoDelta
+	} else if _, err = os.Stat(db.Path(c.dbDir)); err != nil {
+		return errNoDelta
+	}
+
+	tmpDir, err := os.MkdirTemp("", "trivy-db-delta-*")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = c.downloadDeltaArtifact(ctx, opt, meta.UpdatedAt, tmpDir); err != nil {
+		return err
+	}
+
+	newMeta, err := metadata.NewClient(tmpDir).Get()
+	if err != nil {
+		return xerrors.Errorf("unable to get the delta metadata: %w", err)
+	} else if newMeta.Version != db.SchemaVersion {
+		return xerrors.Errorf("unexpected schema version of the delta: %d", newMeta.Version)
+	} else if !newMeta.UpdatedAt.After(meta.UpdatedAt) {
+		return xerrors.Errorf("the delta is not newer than the local DB (%s)", meta.UpdatedAt)
+	}
+
+	if filepath.Clean(dst) != filepath.Clean(c.dbDir) {
+		if err = os.MkdirAll(dst, 0o700); err != nil {
+			return xerrors.Errorf("failed to create %s: %w", dst, err)
+		}
+		if _, err = fsutils.CopyFile(db.Path(c.dbDir), db.Path(dst)); err != nil {
+			return xerrors.Errorf("failed to copy the local DB: %w", err)
+		}
+	}
+
+	if err = applyDelta(db.Path(dst), filepath.Join(tmpDir, deltaFileName)); err != nil {
+		return xerrors.Errorf("failed to apply the delta: %w", err)
+	}
+
+	if _, err = fsutils.CopyFile(metadata.Path(tmpDir), metadata.Path(dst)); err != nil {
+		return xerrors.Errorf("failed to copy the metadata file: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) downloadDeltaArtifact(ctx context.Context, opt types.RegistryOptions, base time.Time, dst string) error {
+	arts := c.deltaArtifacts(ctx, base, opt)
+	if len(arts) == 0 {
+		return errNoDelta
+	}
+
+	downloadOpt := oci.DownloadOption{
+		MediaType: deltaMediaType,
+		Quiet:     c.quiet,
+	}
+	var err error
+	for _, art := range arts {
+		log.DebugContext(ctx, "Downloading the DB delta...", log.String("repo", art.Repository()))
+		if err = art.Download(ctx, dst, downloadOpt); err == nil {
+			return nil
+		}
+		log.DebugContext(ctx, "The DB delta is not available", log.String("repo", art.Repository()), log.Err(err))
+	}
+	return xerrors.Errorf("failed to download the DB delta: %w", err)
+}
+
+// applyDelta applies all the operations in a single transaction so that the DB is left untouched on failure
+func applyDelta(dbPath, deltaPath string) error {
+	f, err := os.Open(deltaPath)
+	if err != nil {
+		return xerrors.Errorf("unable to open the delta: %w", err)
+	}
+	defer f.Close()
+
+	boltDB, err := bolt.Open(dbPath, 0o644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return xerrors.Errorf("unable to open the DB: %w", err)
+	}
+	defer boltDB.Close()
+
+	return boltDB.Update(func(tx *bolt.Tx) error {
+		decoder := json.NewDecoder(f)
+		for i := 1; ; i++ {
+			var op deltaOperation
+			if err := decoder.Decode(&op); errors.Is(err, io.EOF) {
+				return nil
+			} else if err != nil {
+				return xerrors.Errorf("invalid operation at line %d: %w", i, err)
+			}
+			if err := applyDeltaOperation(tx, op); err != nil {
+				return xerrors.Errorf("operation at line %d error: %w", i, err)
+			}
+		}
+	})
+}
+
+func applyDeltaOperation(tx *bolt.Tx, op deltaOperation) error {
+	if len(op.Bucket) == 0 {
+		return xerrors.New("no bucket")
+	}
+
+	switch op.Op {
+	case deltaOpPut:
+		if op.Key == "" {
+			return xerrors.New("no key to put")
+		}
+		bucket, err := tx.CreateBucketIfNotExists([]byte(op.Bucket[0]))
+		if err != nil {
+			return xerrors.Errorf("unable to create the bucket %q: %w", op.Bucket[0], err)
+		}
+		for _, name := range op.Bucket[1:] {
+			if bucket, err = bucket.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return xerrors.Errorf("unable to create the bucket %q: %w", name, err)
+			}
+		}
+		return bucket.Put([]byte(op.Key), op.Value)
+	case deltaOpDelete:
+		parents, last := op.Bucket, ""
+		if op.Key == "" {
+			parents, last = op.Bucket[:len(op.Bucket)-1], op.Bucket[len(op.Bucket)-1]
+		}
+
+		// Deleting what doesn't exist is a no-op so that deltas can be applied again after interruptions
+		var bucket *bolt.Bucket
+		for i, name := range parents {
+			if i == 0 {
+				bucket = tx.Bucket([]byte(name))
+			} else {
+				bucket = bucket.Bucket([]byte(name))
+			}
+			if bucket == nil {
+				return nil
+			}
+		}
+
+		var err error
+		switch {
+		case op.Key != "":
+			err = bucket.Delete([]byte(op.Key))
+		case bucket == nil:
+			err = tx.DeleteBucket([]byte(last))
+		default:
+			err = bucket.DeleteBucket([]byte(last))
+		}
+		if err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		return nil
+	default:
+		return xerrors.Errorf("unknown operation %q", op.Op)
+	}
+}
@@ -0,0 +1,222 @@
+package db_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/aquasecurity/trivy/internal/dbtest"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/db"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestClient_Download_Delta(t *testing.T) {
+	baseUpdatedAt := time.Date(2019, 9, 30, 0, 0, 0, 0, time.UTC)
+	deltaMeta := metadata.Metadata{
+		Version:    db.SchemaVersion,
+		NextUpdate: time.Date(2019, 10, 1, 6, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2019, 9, 30, 18, 0, 0, 0, time.UTC),
+	}
+	delta := `{"op":"put","bucket":["alpine 3.20","openssl"],"key":"CVE-2024-0003","value":{"FixedVersion":"3.0.3"}}
+{"op":"delete","bucket":["alpine 3.20","openssl"],"key":"CVE-2024-0001"}
+{"op":"delete","bucket":["debian 12"]}
+{"op":"delete","bucket":["unknown","zlib"],"key":"CVE-2024-0004"}
+`
+
+	// The whole DB in testdata/db.tar.gz
+	wholeDBMeta := metadata.Metadata{
+		Version:      1,
+		NextUpdate:   time.Date(3000, 1, 1, 18, 5, 43, 198355188, time.UTC),
+		UpdatedAt:    time.Date(3000, 1, 1, 12, 5, 43, 198355588, time.UTC),
+		DownloadedAt: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		disabled  bool
+		delta     string
+		deltaMeta metadata.Metadata
+		sameDir   bool
+		want      map[string]map[string][]string
+		wantMeta  metadata.Metadata
+	}{
+		{
+			name:      "happy path",
+			delta:     delta,
+			deltaMeta: deltaMeta,
+			sameDir:   true,
+			want: map[string]map[string][]string{
+				"alpine 3.20": {
+					"openssl": {"CVE-2024-0002", "CVE-2024-0003"},
+				},
+			},
+			wantMeta: metadata.Metadata{
+				Version:      db.SchemaVersion,
+				NextUpdate:   deltaMeta.NextUpdate,
+				UpdatedAt:    deltaMeta.UpdatedAt,
+				DownloadedAt: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "delta disabled",
+			disabled:  true,
+			delta:     delta,
+			deltaMeta: deltaMeta,
+			sameDir:   true,
+			wantMeta:  wholeDBMeta,
+		},
+		{
+			name:      "another destination",
+			delta:     delta,
+			deltaMeta: deltaMeta,
+			want: map[string]map[string][]string{
+				"alpine 3.20": {
+					"openssl": {"CVE-2024-0002", "CVE-2024-0003"},
+				},
+			},
+			wantMeta: metadata.Metadata{
+				Version:      db.SchemaVersion,
+				NextUpdate:   deltaMeta.NextUpdate,
+				UpdatedAt:    deltaMeta.UpdatedAt,
+				DownloadedAt: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "invalid operation",
+			delta:     `{"op":"unknown","bucket":["alpine 3.20"]}`,
+			deltaMeta: deltaMeta,
+			sameDir:   true,
+			wantMeta:  wholeDBMeta,
+		},
+		{
+			name:  "delta not newer than the local DB",
+			delta: delta,
+			deltaMeta: metadata.Metadata{
+				Version:   db.SchemaVersion,
+				UpdatedAt: baseUpdatedAt,
+			},
+			sameDir:  true,
+			wantMeta: wholeDBMeta,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := clock.With(context.Background(), time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+
+			// Local DB
+			dbDir := db.Dir(t.TempDir())
+			writeDeltaTestDB(t, dbDir)
+			require.NoError(t, metadata.NewClient(dbDir).Update(metadata.Metadata{
+				Version:   db.SchemaVersion,
+				UpdatedAt: baseUpdatedAt,
+			}))
+
+			server := httptest.NewServer(registry.New())
+			defer server.Close()
+			repo, err := name.NewTag(strings.TrimPrefix(server.URL, "http://") + "/trivy-db:2")
+			require.NoError(t, err)
+
+			// The whole DB and the delta from the local DB
+			pushDB(t, repo, "testdata/db.tar.gz", "application/vnd.aquasec.trivy.db.layer.v1.tar+gzip")
+			deltaDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(deltaDir, "delta.jsonl"), []byte(tt.delta), 0o644))
+			require.NoError(t, metadata.NewClient(deltaDir).Update(tt.deltaMeta))
+			pushDB(t, repo.Tag("2-delta-20190930000000"), dbtest.ArchiveDir(t, deltaDir),
+				"application/vnd.aquasec.trivy.db.delta.layer.v1.tar+gzip")
+
+			dst := dbDir
+			if !tt.sameDir {
+				dst = t.TempDir()
+			}
+
+			client := db.NewClient(dbDir, true, db.WithDBRepository([]name.Reference{repo}), db.WithDelta(!tt.disabled))
+			require.NoError(t, client.Download(ctx, dst, ftypes.RegistryOptions{}))
+
+			gotMeta, err := metadata.NewClient(dst).Get()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMeta, gotMeta)
+
+			if tt.want != nil {
+				assert.Equal(t, tt.want, readDeltaTestDB(t, dst))
+			}
+		})
+	}
+}
+
+func writeDeltaTestDB(t *testing.T, dbDir string) {
+	require.NoError(t, os.MkdirAll(dbDir, 0o700))
+	boltDB, err := bolt.Open(db.Path(dbDir), 0o644, nil)
+	require.NoError(t, err)
+	defer boltDB.Close()
+
+	err = boltDB.Update(func(tx *bolt.Tx) error {
+		for source, pkgs := range map[string]map[string][]string{
+			"alpine 3.20": {"openssl": {"CVE-2024-0001", "CVE-2024-0002"}},
+			"debian 12":   {"zlib": {"CVE-2024-0004"}},
+		} {
+			b, err := tx.CreateBucket([]byte(source))
+			require.NoError(t, err)
+			for pkg, vulns := range pkgs {
+				pb, err := b.CreateBucket([]byte(pkg))
+				require.NoError(t, err)
+				for _, vuln := range vulns {
+					require.NoError(t, pb.Put([]byte(vuln), []byte(`{}`)))
+				}
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func readDeltaTestDB(t *testing.T, dbDir string) map[string]map[string][]string {
+	boltDB, err := bolt.Open(db.Path(dbDir), 0o644, &bolt.Options{ReadOnly: true})
+	require.NoError(t, err)
+	defer boltDB.Close()
+
+	got := make(map[string]map[string][]string)
+	err = boltDB.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(source []byte, b *bolt.Bucket) error {
+			got[string(source)] = make(map[string][]string)
+			return b.ForEachBucket(func(pkg []byte) error {
+				return b.Bucket(pkg).ForEach(func(vuln, _ []byte) error {
+					got[string(source)][string(pkg)] = append(got[string(source)][string(pkg)], string(vuln))
+					return nil
+				})
+			})
+		})
+	})
+	require.NoError(t, err)
+	return got
+}
+
+func pushDB(t *testing.T, ref name.Reference, dbPath string, mediaType types.MediaType) {
+	layer, err := tarball.LayerFromFile(dbPath, tarball.WithMediaType(mediaType))
+	require.NoError(t, err)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: layer,
+		Annotations: map[string]string{
+			"org.opencontainers.image.title": "db.tar.gz",
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy-db/pkg/db"
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/aquasecurity/trivy/pkg/downloader"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+func (c *Client) downloadURL(ctx context.Context, opt types.RegistryOptions, dst string) error {
+	log.InfoContext(ctx, "Downloading vulnerability DB...", log.String("url", c.url))
+
+	// The ETag is sent only if the local DB was downloaded from the same URL and is still valid
+	var etag string
+	if meta := downloader.ReadArchiveMetadata(c.dbDir); meta.URL == c.url {
+		if m, err := c.metadata.Get(); err == nil && m.Version == db.SchemaVersion {
+			etag = meta.ETag
+		}
+	}
+
+	newETag, err := downloader.DownloadArchive(ctx, c.url, dst, downloader.Options{
+		Insecure: opt.Insecure,
+		ETag:     etag,
+	}, verifyMetadata)
+	switch {
+	case errors.Is(err, downloader.ErrSkipDownload):
+		log.InfoContext(ctx, "Vulnerability DB is not modified on the server")
+		newETag = etag
+		if dst != c.dbDir {
+			if err = copyDB(c.dbDir, dst); err != nil {
+				return xerrors.Errorf("unable to copy the local DB: %w", err)
+			}
+		}
+	case err != nil:
+		return xerrors.Errorf("failed to download vulnerability DB: %w", err)
+	}
+
+	if err = downloader.WriteArchiveMetadata(dst, downloader.ArchiveMetadata{
+		URL:  c.url,
+		ETag: newETag,
+	}); err != nil {
+		return xerrors.Errorf("unable to write the download metadata: %w", err)
+	}
+	return nil
+}
+
+// verifyMetadata makes sure that the downloaded tarball contains the DB supported by this version of Trivy
+func verifyMetadata(dir string) error {
+	meta, err := metadata.NewClient(dir).Get()
+	if err != nil {
+		return xerrors.Errorf("metadata error: %w", err)
+	} else if meta.Version != db.SchemaVersion {
+		return xerrors.Errorf("schema version mismatch (expected: %d, actual: %d)", db.SchemaVersion, meta.Version)
+	}
+	if !fsutils.FileExists(db.Path(dir)) {
+		return xerrors.New("trivy.db not found")
+	}
+	return nil
+}
+
+func copyDB(src, dst string) error {
+	if err := os.MkdirAll(dst, 0o700); err != nil {
+		return xerrors.Errorf("failed to mkdir: %w", err)
+	}
+	for _, path := range []func(string) string{db.Path, metadata.Path} {
+		if _, err := fsutils.CopyFile(path(src), path(dst)); err != nil {
+			return xerrors.Errorf("copy error: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package db_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy-db/pkg/metadata"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/db"
+	"github.com/aquasecurity/trivy/pkg/downloader"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestClient_Download_URL(t *testing.T) {
+	archive := dbArchive(t, db.SchemaVersion, "new db")
+	const etag = `"v1"`
+
+	var downloads int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trivy-db":
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			if r.Method == http.MethodGet {
+				downloads++
+			}
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write(archive)
+		case "/old-schema":
+			_, _ = w.Write(dbArchive(t, 1, "old schema"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := clock.With(context.Background(), time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	t.Run("happy path", func(t *testing.T) {
+		dbDir := db.Dir(t.TempDir())
+		client := db.NewClient(dbDir, true, db.WithURL(ts.URL+"/trivy-db"))
+		downloads = 0
+
+		require.NoError(t, client.Download(ctx, dbDir, ftypes.RegistryOptions{}))
+		assertDB(t, dbDir, "new db", time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, downloader.ArchiveMetadata{
+			URL:  ts.URL + "/trivy-db",
+			ETag: etag,
+		}, downloader.ReadArchiveMetadata(dbDir))
+
+		// Not modified on the server
+		ctx := clock.With(ctx, time.Date(2019, 10, 2, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, client.Download(ctx, dbDir, ftypes.RegistryOptions{}))
+		assertDB(t, dbDir, "new db", time.Date(2019, 10, 2, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, 1, downloads)
+
+		// The local DB is copied when downloading into another directory
+		dst := t.TempDir()
+		require.NoError(t, client.Download(ctx, dst, ftypes.RegistryOptions{}))
+		assertDB(t, dst, "new db", time.Date(2019, 10, 2, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, 1, downloads)
+	})
+
+	t.Run("schema version mismatch", func(t *testing.T) {
+		dbDir := db.Dir(t.TempDir())
+		require.NoError(t, os.MkdirAll(dbDir, 0o700))
+		require.NoError(t, os.WriteFile(db.Path(dbDir), []byte("local db"), 0o644))
+
+		client := db.NewClient(dbDir, true, db.WithURL(ts.URL+"/old-schema"))
+		err := client.Download(ctx, dbDir, ftypes.RegistryOptions{})
+		require.ErrorContains(t, err, "schema version mismatch")
+
+		// The local DB is kept
+		got, err := os.ReadFile(db.Path(dbDir))
+		require.NoError(t, err)
+		assert.Equal(t, "local db", string(got))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		dbDir := db.Dir(t.TempDir())
+		client := db.NewClient(dbDir, true, db.WithURL(ts.URL+"/missing"))
+		err := client.Download(ctx, dbDir, ftypes.RegistryOptions{})
+		require.ErrorContains(t, err, "HTTP download error")
+	})
+}
+
+func assertDB(t *testing.T, dbDir, want string, downloadedAt time.Time) {
+	got, err := os.ReadFile(db.Path(dbDir))
+	require.NoError(t, err)
+	assert.Equal(t, want, string(got))
+
+	meta, err := metadata.NewClient(dbDir).Get()
+	require.NoError(t, err)
+	assert.Equal(t, downloadedAt, meta.DownloadedAt)
+}
+
+// dbArchive returns a gzipped tarball of trivy.db and metadata.json
+func dbArchive(t *testing.T, schemaVersion int, content string) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, body := range map[string]string{
+		filepath.Base(db.Path("")):       content,
+		filepath.Base(metadata.Path("")): fmt.Sprintf(`{"Version":%d}`, schemaVersion),
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0o644,
+			Size: int64(len(body)),
+		}))
+		_, err := tw.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
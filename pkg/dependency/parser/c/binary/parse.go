@@ -0,0 +1,152 @@
+// Detects C libraries statically linked into ELF binaries by the version strings embedded by the libraries
+package binary
+
+import (
+	"bytes"
+	"debug/elf"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+var ErrUnrecognizedExe = xerrors.New("unrecognized executable format")
+
+const (
+	chunkSize = 1 << 20
+	// Version strings split across chunks are found thanks to the overlap
+	overlapSize = 256
+)
+
+// signature represents the version string of a library.
+type signature struct {
+	// The package name in Conan Center, so that the vulnerabilities can be detected with Conan advisories
+	name string
+	// The first submatch is the version
+	pattern *regexp.Regexp
+	// Some version strings are too generic, so another string specific to the library must exist in the binary as well.
+	marker []byte
+}
+
+// pcreMarker is an error message in both PCRE and PCRE2
+var pcreMarker = []byte("lookbehind assertion is not fixed length")
+
+var signatures = []signature{
+	{
+		// e.g. "OpenSSL 3.0.13 30 Jan 2024", "OpenSSL 1.1.1w  11 Sep 2023"
+		// BoringSSL and LibreSSL don't match as they have no release date.
+		name:    "openssl",
+		pattern: regexp.MustCompile(`OpenSSL (\d+\.\d+\.\d+[a-z]{0,2}) +\d{1,2} [A-Z][a-z]{2} \d{4}`),
+	},
+	{
+		// e.g. "deflate 1.3.1 Copyright 1995-2024 Jean-loup Gailly and Mark Adler"
+		name:    "zlib",
+		pattern: regexp.MustCompile(`(?:deflate|inflate) (\d+\.\d+(?:\.\d+){0,2}) Copyright 1995-\d{4}`),
+	},
+	{
+		// e.g. "libcurl/8.5.0", "CLIENT libcurl 8.5.0"
+		name:    "libcurl",
+		pattern: regexp.MustCompile(`(?:libcurl/|CLIENT libcurl )(\d+\.\d+\.\d+)`),
+	},
+	{
+		// e.g. "8.45 2021-06-15"
+		name:    "pcre",
+		pattern: regexp.MustCompile(`(?:^|[^\d.])(\d\.\d{2}) \d{4}-\d{2}-\d{2}`),
+		marker:  pcreMarker,
+	},
+	{
+		// e.g. "10.42 2022-12-11"
+		name:    "pcre2",
+		pattern: regexp.MustCompile(`(?:^|[^\d.])(1\d\.\d{2}) \d{4}-\d{2}-\d{2}`),
+		marker:  pcreMarker,
+	},
+}
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse scans ELF binaries for the version strings of statically linked C libraries, such as OpenSSL and zlib.
+// It works with stripped binaries as the version strings are kept in the read-only data.
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, nil, ErrUnrecognizedExe
+	}
+
+	// String literals are stored in .rodata
+	var sr io.Reader
+	if sec := f.Section(".rodata"); sec != nil && sec.Type != elf.SHT_NOBITS {
+		sr = sec.Open()
+	} else {
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, xerrors.Errorf("seek error: %w", err)
+		}
+		sr = r
+	}
+
+	versions, err := scan(sr)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("scan error: %w", err)
+	}
+
+	var pkgs []ftypes.Package
+	for name, ver := range versions {
+		pkgs = append(pkgs, ftypes.Package{
+			ID:      dependency.ID(ftypes.CBinary, name, ver),
+			Name:    name,
+			Version: ver,
+		})
+	}
+	sort.Sort(ftypes.Packages(pkgs))
+
+	return pkgs, nil, nil
+}
+
+// scan returns the versions of the libraries found in the data.
+func scan(r io.Reader) (map[string]string, error) {
+	found := make(map[string]string)
+	markers := make(map[string]bool)
+
+	buf := make([]byte, overlapSize+chunkSize)
+	var prev int
+	for {
+		n, err := io.ReadFull(r, buf[prev:])
+		data := buf[:prev+n]
+		for _, sig := range signatures {
+			if sig.marker != nil && !markers[string(sig.marker)] && bytes.Contains(data, sig.marker) {
+				markers[string(sig.marker)] = true
+			}
+			if _, ok := found[sig.name]; ok {
+				continue
+			}
+			if m := sig.pattern.FindSubmatch(data); m != nil {
+				found[sig.name] = string(m[1])
+			}
+		}
+
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Keep the tail for the next chunk
+		prev = copy(buf, data[len(data)-overlapSize:])
+	}
+
+	for _, sig := range signatures {
+		if sig.marker != nil && !markers[string(sig.marker)] {
+			delete(found, sig.name)
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,84 @@
+package binary
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      []ftypes.Package
+		wantErr   error
+	}{
+		{
+			name:      "ELF",
+			inputFile: "testdata/static.elf",
+			want: []ftypes.Package{
+				{
+					ID:      "libcurl/8.5.0",
+					Name:    "libcurl",
+					Version: "8.5.0",
+				},
+				{
+					ID:      "openssl/3.0.13",
+					Name:    "openssl",
+					Version: "3.0.13",
+				},
+				{
+					ID:      "pcre2/10.42",
+					Name:    "pcre2",
+					Version: "10.42",
+				},
+				{
+					ID:      "zlib/1.2.13",
+					Name:    "zlib",
+					Version: "1.2.13",
+				},
+			},
+		},
+		{
+			name:      "generic version strings",
+			inputFile: "testdata/generic.elf",
+			want:      nil,
+		},
+		{
+			name:      "sad path",
+			inputFile: "testdata/dummy",
+			wantErr:   ErrUnrecognizedExe,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, _, err := NewParser().Parse(f)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_scan(t *testing.T) {
+	// The version string is split across chunks
+	data := make([]byte, chunkSize+overlapSize)
+	copy(data[chunkSize-10:], "OpenSSL 3.0.13 30 Jan 2024")
+
+	got, err := scan(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"openssl": "3.0.13"}, got)
+}
@@ -14,6 +14,8 @@ import (
 	xio "github.com/aquasecurity/trivy/pkg/x/io"
 )
 
+const pipManager = "pip"
+
 type environment struct {
 	Entries []Entry `yaml:"dependencies"`
 	Prefix  string  `yaml:"prefix"`
@@ -26,11 +28,15 @@ type Entry struct {
 type Dependency struct {
 	Value string
 	Line  int
+	// The package manager other than conda, e.g. pip
+	Manager string
 }
 
 type Packages struct {
 	Packages ftypes.Packages
-	Prefix   string
+	// Packages installed with pip
+	PipPackages ftypes.Packages
+	Prefix      string
 }
 
 type Parser struct {
@@ -51,27 +57,43 @@ func (p *Parser) Parse(r xio.ReadSeekerAt) (Packages, error) {
 		return Packages{}, xerrors.Errorf("unable to decode conda environment.yml file: %w", err)
 	}
 
-	var pkgs ftypes.Packages
+	var pkgs, pipPkgs ftypes.Packages
 	for _, entry := range env.Entries {
 		for _, dep := range entry.Dependencies {
-			pkg := p.toPackage(dep)
-			// Skip empty pkgs
-			if pkg.Name == "" {
-				continue
+			switch dep.Manager {
+			case "":
+				pkg := p.toPackage(dep, p.parseDependency)
+				// Skip empty pkgs
+				if pkg.Name == "" {
+					continue
+				}
+				pkgs = append(pkgs, pkg)
+			case pipManager:
+				pkg := p.toPackage(dep, parsePipDependency)
+				if pkg.Name == "" {
+					continue
+				}
+				pipPkgs = append(pipPkgs, pkg)
+			default:
+				p.logger.Debug("Unsupported package manager", log.String("manager", dep.Manager), log.Int("line", dep.Line))
 			}
-			pkgs = append(pkgs, pkg)
 		}
 	}
 
 	sort.Sort(pkgs)
+	sort.Sort(pipPkgs)
 	return Packages{
-		Packages: pkgs,
-		Prefix:   env.Prefix,
+		Packages:    pkgs,
+		PipPackages: pipPkgs,
+		Prefix:      env.Prefix,
 	}, nil
 }
 
-func (p *Parser) toPackage(dep Dependency) ftypes.Package {
-	name, ver := p.parseDependency(dep.Value)
+func (p *Parser) toPackage(dep Dependency, parse func(string) (string, string)) ftypes.Package {
+	name, ver := parse(dep.Value)
+	if name == "" {
+		return ftypes.Package{}
+	}
 	if ver == "" {
 		p.once.Do(func() {
 			p.logger.Warn("Unable to detect the dependency versions from `environment.yml` as those versions are not pinned. Use `conda env export` to pin versions.")
@@ -111,6 +133,31 @@ func (*Parser) parseDependency(line string) (string, string) {
 	return name, parts[1]
 }
 
+// parsePipDependency parses the requirement specifier in the "pip" section and returns the name and the pinned version.
+// Options (e.g. "-r requirements.txt") and URLs are skipped.
+// e.g.
+//   - django==5.0.6
+//   - requests[security]==2.31.0
+//
+// cf. https://pip.pypa.io/en/stable/reference/requirement-specifiers/
+func parsePipDependency(line string) (string, string) {
+	if strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+		return "", ""
+	}
+	// Remove environment markers
+	line, _, _ = strings.Cut(line, ";")
+	line = strings.ReplaceAll(line, " ", "")
+
+	name, ver, found := strings.Cut(line, "==")
+	if i := strings.IndexAny(name, "[<>=!~"); i >= 0 {
+		name = name[:i]
+	}
+	if !found {
+		return name, ""
+	}
+	return name, ver
+}
+
 func (e *Entry) UnmarshalYAML(node *yaml.Node) error {
 	var dependencies []Dependency
 	// cf. https://github.com/go-yaml/yaml/blob/f6f7691b1fdeb513f56608cd2c32c51f8194bf51/resolve.go#L70-L81
@@ -129,6 +176,7 @@ func (e *Entry) UnmarshalYAML(node *yaml.Node) error {
 			if node.Content[1].Tag != "!!seq" { // Conda supports only map[string][]string format.
 				return xerrors.Errorf("unsupported dependency type %q on line %d", node.Content[1].Tag, node.Content[1].Line)
 			}
+			manager := node.Content[0].Value
 
 			for _, depContent := range node.Content[1].Content {
 				if depContent.Tag != "!!str" {
@@ -136,8 +184,9 @@ func (e *Entry) UnmarshalYAML(node *yaml.Node) error {
 				}
 
 				dependencies = append(dependencies, Dependency{
-					Value: depContent.Value,
-					Line:  depContent.Line,
+					Value:   depContent.Value,
+					Line:    depContent.Line,
+					Manager: manager,
 				})
 			}
 		}
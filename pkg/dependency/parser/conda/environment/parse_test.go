@@ -32,16 +32,6 @@ func TestParse(t *testing.T) {
 							},
 						},
 					},
-					{
-						Name:    "asgiref",
-						Version: "3.8.1",
-						Locations: ftypes.Locations{
-							{
-								StartLine: 21,
-								EndLine:   21,
-							},
-						},
-					},
 					{
 						Name:    "blas",
 						Version: "1.0",
@@ -72,16 +62,6 @@ func TestParse(t *testing.T) {
 							},
 						},
 					},
-					{
-						Name:    "django",
-						Version: "5.0.6",
-						Locations: ftypes.Locations{
-							{
-								StartLine: 22,
-								EndLine:   22,
-							},
-						},
-					},
 					{
 						Name: "ld_impl_linux-aarch64",
 						Locations: ftypes.Locations{
@@ -186,6 +166,38 @@ func TestParse(t *testing.T) {
 						},
 					},
 				},
+				PipPackages: []ftypes.Package{
+					{
+						Name:    "asgiref",
+						Version: "3.8.1",
+						Locations: ftypes.Locations{
+							{
+								StartLine: 21,
+								EndLine:   21,
+							},
+						},
+					},
+					{
+						Name:    "django",
+						Version: "5.0.6",
+						Locations: ftypes.Locations{
+							{
+								StartLine: 22,
+								EndLine:   22,
+							},
+						},
+					},
+					{
+						Name:    "requests",
+						Version: "2.31.0",
+						Locations: ftypes.Locations{
+							{
+								StartLine: 23,
+								EndLine:   23,
+							},
+						},
+					},
+				},
 				Prefix: "/opt/conda/envs/test-env",
 			},
 		},
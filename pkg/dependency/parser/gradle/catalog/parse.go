@@ -0,0 +1,180 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+var (
+	// e.g. [libraries]
+	sectionRegexp = regexp.MustCompile(`^\s*\[\s*([^\]\s]+)\s*\]`)
+	// e.g. groovy-core = { module = "org.codehaus.groovy:groovy", version.ref = "groovy" }
+	aliasRegexp = regexp.MustCompile(`^\s*"?([A-Za-z0-9_-]+)"?\s*[=.]`)
+)
+
+// versionCatalog represents the TOML file of Gradle version catalogs.
+// cf. https://docs.gradle.org/current/userguide/platforms.html#sub::toml-dependencies-format
+type versionCatalog struct {
+	Versions  map[string]any `toml:"versions"`
+	Libraries map[string]any `toml:"libraries"`
+}
+
+type Parser struct {
+	logger *log.Logger
+}
+
+func NewParser() *Parser {
+	return &Parser{
+		logger: log.WithPrefix("gradle"),
+	}
+}
+
+// Parse parses Gradle version catalogs.
+// e.g. gradle/libs.versions.toml
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	var catalog versionCatalog
+	if _, err = toml.Decode(string(b), &catalog); err != nil {
+		return nil, nil, xerrors.Errorf("toml decode error: %w", err)
+	}
+
+	lines := libraryLines(b)
+
+	var pkgs []ftypes.Package
+	for alias, lib := range catalog.Libraries {
+		groupID, artifactID, version := p.parseLibrary(lib, catalog.Versions)
+		if groupID == "" || artifactID == "" {
+			p.logger.Debug("Unable to parse the library", log.String("alias", alias))
+			continue
+		}
+		// The version can be omitted when it is managed by a platform (BOM),
+		// or can be a range such as "[1.0, 2.0[" that is resolved at build time.
+		if !isConcreteVersion(version) {
+			p.logger.Debug("Skipping the library without a concrete version",
+				log.String("alias", alias), log.String("version", version))
+			continue
+		}
+		pkgs = append(pkgs, newPackage(groupID, artifactID, version, lines[alias]))
+	}
+
+	pkgs = utils.UniquePackages(pkgs)
+	sort.Sort(ftypes.Packages(pkgs))
+	return pkgs, nil, nil
+}
+
+// parseLibrary parses the library declaration in the following formats.
+//   - "org.codehaus.groovy:groovy:3.0.5"
+//   - { module = "org.codehaus.groovy:groovy", version = "3.0.5" }
+//   - { group = "org.codehaus.groovy", name = "groovy", version.ref = "groovy" }
+func (p *Parser) parseLibrary(lib any, versions map[string]any) (groupID, artifactID, version string) {
+	switch v := lib.(type) {
+	case string:
+		ss := strings.Split(v, ":")
+		if len(ss) != 3 {
+			return "", "", ""
+		}
+		return ss[0], ss[1], ss[2]
+	case map[string]any:
+		if module, ok := v["module"].(string); ok {
+			groupID, artifactID, _ = strings.Cut(module, ":")
+		} else {
+			groupID, _ = v["group"].(string)
+			artifactID, _ = v["name"].(string)
+		}
+		return groupID, artifactID, resolveVersion(v["version"], versions)
+	}
+	return "", "", ""
+}
+
+// resolveVersion returns the version to be used from the version declaration.
+//   - "3.0.5"
+//   - { ref = "groovy" }
+//   - { strictly = "[3.8, 4.0[", prefer = "3.9" }
+//
+// cf. https://docs.gradle.org/current/userguide/rich_versions.html
+func resolveVersion(version any, versions map[string]any) string {
+	switch v := version.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if ref, ok := v["ref"].(string); ok {
+			// References can't refer to other references, so we don't pass versions here
+			return resolveVersion(versions[ref], nil)
+		}
+		for _, key := range []string{"prefer", "strictly", "require"} {
+			if s, ok := v[key].(string); ok && isConcreteVersion(s) {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// isConcreteVersion returns false for empty, dynamic versions and version ranges.
+// e.g. "1.+", "latest.release", "[1.0, 2.0["
+func isConcreteVersion(version string) bool {
+	return version != "" && !strings.HasPrefix(version, "latest.") && !strings.ContainsAny(version, "[]()+,")
+}
+
+// libraryLines returns the line numbers of library aliases
+func libraryLines(b []byte) map[string]int {
+	lines := make(map[string]int)
+	var section string
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if m := sectionRegexp.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			// e.g. [libraries.groovy-core]
+			if alias, ok := strings.CutPrefix(section, "libraries."); ok {
+				lines[strings.Trim(alias, `"`)] = lineNum
+			}
+			continue
+		}
+		if section != "libraries" {
+			continue
+		}
+		if m := aliasRegexp.FindStringSubmatch(line); m != nil {
+			if _, ok := lines[m[1]]; !ok {
+				lines[m[1]] = lineNum
+			}
+		}
+	}
+	return lines
+}
+
+func newPackage(groupID, artifactID, version string, lineNum int) ftypes.Package {
+	name := groupID + ":" + artifactID
+	pkg := ftypes.Package{
+		ID:           dependency.ID(ftypes.Gradle, name, version),
+		Name:         name,
+		Version:      version,
+		Relationship: ftypes.RelationshipDirect,
+	}
+	if lineNum > 0 {
+		pkg.Locations = []ftypes.Location{
+			{
+				StartLine: lineNum,
+				EndLine:   lineNum,
+			},
+		}
+	}
+	return pkg
+}
@@ -0,0 +1,110 @@
+package catalog_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/gradle/catalog"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      []ftypes.Package
+	}{
+		{
+			name:      "happy path",
+			inputFile: "testdata/libs.versions.toml",
+			want: []ftypes.Package{
+				{
+					ID:           "com.fasterxml.jackson.core:jackson-databind:2.12.3",
+					Name:         "com.fasterxml.jackson.core:jackson-databind",
+					Version:      "2.12.3",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 10,
+							EndLine:   10,
+						},
+					},
+				},
+				{
+					ID:           "com.google.guava:guava:32.1.2-jre",
+					Name:         "com.google.guava:guava",
+					Version:      "32.1.2-jre",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 11,
+							EndLine:   11,
+						},
+					},
+				},
+				{
+					ID:           "org.apache.commons:commons-lang3:3.9",
+					Name:         "org.apache.commons:commons-lang3",
+					Version:      "3.9",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 12,
+							EndLine:   12,
+						},
+					},
+				},
+				{
+					ID:           "org.codehaus.groovy:groovy:3.0.5",
+					Name:         "org.codehaus.groovy:groovy",
+					Version:      "3.0.5",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 8,
+							EndLine:   8,
+						},
+					},
+				},
+				{
+					ID:           "org.codehaus.groovy:groovy-json:3.0.5",
+					Name:         "org.codehaus.groovy:groovy-json",
+					Version:      "3.0.5",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 9,
+							EndLine:   9,
+						},
+					},
+				},
+				{
+					ID:           "org.slf4j:slf4j-api:2.0.9",
+					Name:         "org.slf4j:slf4j-api",
+					Version:      "2.0.9",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 17,
+							EndLine:   17,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			pkgs, _, err := catalog.NewParser().Parse(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pkgs)
+		})
+	}
+}
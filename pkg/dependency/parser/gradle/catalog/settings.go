@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"bufio"
+	"regexp"
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+const quoted = `["']([^"']+)["']`
+
+var (
+	// e.g. version("groovy", "3.0.5")
+	versionDeclRegexp = regexp.MustCompile(`(?:^|[^.\w])version\(\s*` + quoted + `\s*,\s*` + quoted + `\s*\)`)
+	// e.g. library("commons-lang3", "org.apache.commons:commons-lang3:3.12.0")
+	libraryCoordRegexp = regexp.MustCompile(`\blibrary\(\s*` + quoted + `\s*,\s*["']([^"':]+):([^"':]+):([^"':]+)["']\s*\)`)
+	// e.g. library("groovy-core", "org.codehaus.groovy", "groovy").versionRef("groovy")
+	libraryRegexp = regexp.MustCompile(`\blibrary\(\s*` + quoted + `\s*,\s*` + quoted + `\s*,\s*` + quoted + `\s*\)\s*\.version(Ref)?\(\s*` + quoted + `\s*\)`)
+)
+
+type SettingsParser struct {
+	logger *log.Logger
+}
+
+func NewSettingsParser() *SettingsParser {
+	return &SettingsParser{
+		logger: log.WithPrefix("gradle"),
+	}
+}
+
+// Parse parses version catalogs declared in Gradle settings files (settings.gradle and settings.gradle.kts).
+// Only simple declarations are supported since the settings file is a Groovy or Kotlin script.
+// e.g.
+//
+//	dependencyResolutionManagement {
+//	    versionCatalogs {
+//	        create("libs") {
+//	            version("groovy", "3.0.5")
+//	            library("groovy-core", "org.codehaus.groovy", "groovy").versionRef("groovy")
+//	            library("commons-lang3", "org.apache.commons:commons-lang3:3.12.0")
+//	        }
+//	    }
+//	}
+//
+// cf. https://docs.gradle.org/current/userguide/platforms.html#sub:central-declaration-of-dependencies
+func (p *SettingsParser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, xerrors.Errorf("scan error: %w", err)
+	}
+
+	// Versions can be referred to before they are declared
+	versions := make(map[string]string)
+	for _, line := range lines {
+		if m := versionDeclRegexp.FindStringSubmatch(line); m != nil {
+			versions[m[1]] = m[2]
+		}
+	}
+
+	var pkgs []ftypes.Package
+	for i, line := range lines {
+		var groupID, artifactID, version string
+		if m := libraryCoordRegexp.FindStringSubmatch(line); m != nil {
+			groupID, artifactID, version = m[2], m[3], m[4]
+		} else if m = libraryRegexp.FindStringSubmatch(line); m != nil {
+			groupID, artifactID, version = m[2], m[3], m[5]
+			if m[4] != "" { // versionRef
+				version = versions[m[5]]
+			}
+		} else {
+			continue
+		}
+
+		if !isConcreteVersion(version) {
+			p.logger.Debug("Skipping the library without a concrete version",
+				log.String("module", groupID+":"+artifactID), log.String("version", version))
+			continue
+		}
+		pkgs = append(pkgs, newPackage(groupID, artifactID, version, i+1))
+	}
+
+	pkgs = utils.UniquePackages(pkgs)
+	sort.Sort(ftypes.Packages(pkgs))
+	return pkgs, nil, nil
+}
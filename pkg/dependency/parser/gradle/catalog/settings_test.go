@@ -0,0 +1,97 @@
+package catalog_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/gradle/catalog"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestSettingsParser_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      []ftypes.Package
+	}{
+		{
+			name:      "kotlin",
+			inputFile: "testdata/settings.gradle.kts",
+			want: []ftypes.Package{
+				{
+					ID:           "com.google.guava:guava:32.1.2-jre",
+					Name:         "com.google.guava:guava",
+					Version:      "32.1.2-jre",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 9,
+							EndLine:   9,
+						},
+					},
+				},
+				{
+					ID:           "org.apache.commons:commons-lang3:3.12.0",
+					Name:         "org.apache.commons:commons-lang3",
+					Version:      "3.12.0",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 8,
+							EndLine:   8,
+						},
+					},
+				},
+				{
+					ID:           "org.codehaus.groovy:groovy:3.0.5",
+					Name:         "org.codehaus.groovy:groovy",
+					Version:      "3.0.5",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 7,
+							EndLine:   7,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "groovy",
+			inputFile: "testdata/settings.gradle",
+			want: []ftypes.Package{
+				{
+					ID:           "org.codehaus.groovy:groovy:3.0.5",
+					Name:         "org.codehaus.groovy:groovy",
+					Version:      "3.0.5",
+					Relationship: ftypes.RelationshipDirect,
+					Locations: []ftypes.Location{
+						{
+							StartLine: 6,
+							EndLine:   6,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no catalogs",
+			inputFile: "testdata/empty.settings.gradle.kts",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			pkgs, _, err := catalog.NewSettingsParser().Parse(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pkgs)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+rootProject.name = "example"
+
+dependencyResolutionManagement {
+    versionCatalogs {
+        create("libs") {
+            version("groovy", "3.0.5")
+            library("groovy-core", "org.codehaus.groovy", "groovy").versionRef("groovy")
+            library("commons-lang3", "org.apache.commons:commons-lang3:3.12.0")
+            library("guava", "com.google.guava", "guava").version("32.1.2-jre")
+            library("junit", "junit", "junit").version("4.+")
+            library("spring-boot-starter", "org.springframework.boot", "spring-boot-starter").withoutVersion()
+        }
+    }
+}
+
+include("app")
@@ -187,6 +187,17 @@ func (p *Parser) traverseZip(filePath string, size int64, r xio.ReadSeekerAt, fi
 				continue
 			}
 			pkgs = append(pkgs, innerPkgs...)
+		case path.Ext(fileInJar.Name) == ".class":
+			fp, ok := shadedFingerprint(fileInJar.Name)
+			if !ok {
+				continue
+			}
+			props, err := p.parseShadedClass(fileInJar, fp, filePath)
+			if err != nil {
+				p.logger.Debug("Unable to identify the shaded library", log.String("class", fileInJar.Name), log.Err(err))
+				continue
+			}
+			pkgs = append(pkgs, props.Package())
 		}
 	}
 	return pkgs, m, foundPomProps, nil
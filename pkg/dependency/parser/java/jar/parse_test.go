@@ -218,6 +218,26 @@ var (
 			FilePath: "testdata/io.quarkus.gizmo.gizmo-1.1.jar",
 		},
 	}
+
+	// manually created
+	// Jackson and OkHttp are relocated under com/example/libs without pom.properties
+	wantShadedJar = []ftypes.Package{
+		{
+			Name:     "com.example:shaded",
+			Version:  "1.0.0",
+			FilePath: "testdata/shaded-1.0.0.jar",
+		},
+		{
+			Name:     "com.fasterxml.jackson.core:jackson-databind",
+			Version:  "2.9.10.6",
+			FilePath: "testdata/shaded-1.0.0.jar",
+		},
+		{
+			Name:     "com.squareup.okhttp3:okhttp",
+			Version:  "4.9.0",
+			FilePath: "testdata/shaded-1.0.0.jar",
+		},
+	}
 )
 
 type apiResponse struct {
@@ -286,6 +306,17 @@ func TestParse(t *testing.T) {
 			file: "testdata/io.quarkus.gizmo.gizmo-1.1.jar",
 			want: wantDuplicatesJar,
 		},
+		{
+			name: "shaded libraries",
+			file: "testdata/shaded-1.0.0.jar",
+			want: wantShadedJar,
+		},
+		{
+			name:    "shaded libraries in offline mode",
+			file:    "testdata/shaded-1.0.0.jar",
+			offline: true,
+			want:    wantShadedJar,
+		},
 	}
 
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,203 @@
+package jar
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Constant pool tags of class files
+// cf. https://docs.oracle.com/javase/specs/jvms/se21/html/jvms-4.html#jvms-4.4
+const (
+	constantUtf8               = 1
+	constantInteger            = 3
+	constantFloat              = 4
+	constantLong               = 5
+	constantDouble             = 6
+	constantClass              = 7
+	constantString             = 8
+	constantFieldref           = 9
+	constantMethodref          = 10
+	constantInterfaceMethodref = 11
+	constantNameAndType        = 12
+	constantMethodHandle       = 15
+	constantMethodType         = 16
+	constantDynamic            = 17
+	constantInvokeDynamic      = 18
+	constantModule             = 19
+	constantPackage            = 20
+
+	classMagic = 0xCAFEBABE
+)
+
+var versionConstantRegexp = regexp.MustCompile(`^\d+(\.\d+)+([.-][0-9A-Za-z.-]+)?$`)
+
+// fingerprint identifies a library by the class holding its version as a string constant.
+// The class is still found when the library is relocated into a fat JAR by the shade plugin,
+// even if pom.properties of the library is removed.
+type fingerprint struct {
+	versionClass *regexp.Regexp
+	groupID      func(constants []string) string
+	artifactID   func(constants []string) string
+}
+
+var fingerprints = []fingerprint{
+	{
+		// e.g. com/fasterxml/jackson/databind/cfg/PackageVersion.class
+		// It calls VersionUtil.parseVersion("2.13.0", "com.fasterxml.jackson.core", "jackson-databind").
+		versionClass: regexp.MustCompile(`com/fasterxml/jackson/(?:[a-z0-9]+/)*PackageVersion\.class$`),
+		groupID:      constantWithPrefix("com.fasterxml.jackson."),
+		artifactID:   constantWithPrefix("jackson-"),
+	},
+	{
+		// OkHttp.VERSION, available since OkHttp 4
+		versionClass: regexp.MustCompile(`okhttp3/OkHttp\.class$`),
+		groupID:      fixedConstant("com.squareup.okhttp3"),
+		artifactID:   fixedConstant("okhttp"),
+	},
+}
+
+func constantWithPrefix(prefix string) func([]string) string {
+	return func(constants []string) string {
+		for _, c := range constants {
+			if strings.HasPrefix(c, prefix) {
+				return c
+			}
+		}
+		return ""
+	}
+}
+
+func fixedConstant(s string) func([]string) string {
+	return func([]string) string {
+		return s
+	}
+}
+
+// shadedFingerprint returns the fingerprint matching the class file relocated under another package,
+// e.g. "org/example/shaded/com/fasterxml/jackson/databind/cfg/PackageVersion.class".
+// Classes not relocated belong to the JAR itself and are identified with pom.properties and MANIFEST.MF.
+func shadedFingerprint(name string) (fingerprint, bool) {
+	for _, fp := range fingerprints {
+		loc := fp.versionClass.FindStringIndex(name)
+		if loc != nil && loc[0] > 0 && name[loc[0]-1] == '/' {
+			return fp, true
+		}
+	}
+	return fingerprint{}, false
+}
+
+// parseShadedClass returns the properties of the library relocated into the JAR.
+func (p *Parser) parseShadedClass(f *zip.File, fp fingerprint, filePath string) (Properties, error) {
+	file, err := f.Open()
+	if err != nil {
+		return Properties{}, xerrors.Errorf("unable to open %s: %w", f.Name, err)
+	}
+	defer file.Close()
+
+	constants, err := parseStringConstants(file)
+	if err != nil {
+		return Properties{}, xerrors.Errorf("unable to parse %s: %w", f.Name, err)
+	}
+
+	props := Properties{
+		GroupID:    fp.groupID(constants),
+		ArtifactID: fp.artifactID(constants),
+		FilePath:   filePath,
+	}
+	for _, c := range constants {
+		if versionConstantRegexp.MatchString(c) {
+			props.Version = c
+			break
+		}
+	}
+	if !props.Valid() {
+		return Properties{}, ArtifactNotFoundErr
+	}
+
+	// The relocated library must be published in the Maven repositories
+	if !p.offline {
+		if ok, _ := p.client.Exists(props.GroupID, props.ArtifactID); !ok {
+			return Properties{}, ArtifactNotFoundErr
+		}
+	}
+
+	p.logger.Debug("Shaded library detected", log.String("class", f.Name), log.String("artifact", props.String()))
+	return props, nil
+}
+
+// parseStringConstants returns the string constants in the constant pool of the class file.
+func parseStringConstants(r io.Reader) ([]string, error) {
+	var header struct {
+		Magic        uint32
+		MinorVersion uint16
+		MajorVersion uint16
+		PoolCount    uint16
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, xerrors.Errorf("header error: %w", err)
+	} else if header.Magic != classMagic {
+		return nil, xerrors.New("not a class file")
+	}
+
+	utf8s := make(map[uint16]string)
+	var stringIndexes []uint16
+	for i := uint16(1); i < header.PoolCount; i++ {
+		var tag uint8
+		if err := binary.Read(r, binary.BigEndian, &tag); err != nil {
+			return nil, xerrors.Errorf("constant pool error: %w", err)
+		}
+
+		var size int64
+		switch tag {
+		case constantUtf8:
+			var length uint16
+			if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+				return nil, xerrors.Errorf("constant pool error: %w", err)
+			}
+			b := make([]byte, length)
+			if _, err := io.ReadFull(r, b); err != nil {
+				return nil, xerrors.Errorf("constant pool error: %w", err)
+			}
+			utf8s[i] = string(b)
+			continue
+		case constantString:
+			var index uint16
+			if err := binary.Read(r, binary.BigEndian, &index); err != nil {
+				return nil, xerrors.Errorf("constant pool error: %w", err)
+			}
+			stringIndexes = append(stringIndexes, index)
+			continue
+		case constantClass, constantMethodType, constantModule, constantPackage:
+			size = 2
+		case constantMethodHandle:
+			size = 3
+		case constantInteger, constantFloat, constantFieldref, constantMethodref, constantInterfaceMethodref,
+			constantNameAndType, constantDynamic, constantInvokeDynamic:
+			size = 4
+		case constantLong, constantDouble:
+			// 8-byte constants take up two entries
+			size = 8
+			i++
+		default:
+			return nil, xerrors.Errorf("unknown constant pool tag: %d", tag)
+		}
+		if _, err := io.CopyN(io.Discard, r, size); err != nil {
+			return nil, xerrors.Errorf("constant pool error: %w", err)
+		}
+	}
+
+	var constants []string
+	for _, index := range stringIndexes {
+		if s, ok := utf8s[index]; ok {
+			constants = append(constants, s)
+		}
+	}
+	return constants, nil
+}
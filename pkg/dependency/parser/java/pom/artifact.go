@@ -31,6 +31,8 @@ type artifact struct {
 	Version    version
 	Licenses   []string
 
+	ExternalReferences []ftypes.ExternalRef
+
 	Exclusions set.Set[string]
 
 	Module       bool
@@ -216,10 +216,11 @@ func (p *Parser) parseRoot(root artifact, uniqModules set.Set[string]) ([]ftypes
 		if !art.IsEmpty() {
 			// Override the version
 			uniqArtifacts[art.Name()] = artifact{
-				Version:      art.Version,
-				Licenses:     result.artifact.Licenses,
-				Relationship: art.Relationship,
-				Locations:    art.Locations,
+				Version:            art.Version,
+				Licenses:           result.artifact.Licenses,
+				ExternalReferences: result.artifact.ExternalReferences,
+				Relationship:       art.Relationship,
+				Locations:          art.Locations,
 			}
 
 			// save only dependency names
@@ -234,12 +235,13 @@ func (p *Parser) parseRoot(root artifact, uniqModules set.Set[string]) ([]ftypes
 	// Convert to []ftypes.Package and []ftypes.Dependency
 	for name, art := range uniqArtifacts {
 		pkg := ftypes.Package{
-			ID:           packageID(name, art.Version.String()),
-			Name:         name,
-			Version:      art.Version.String(),
-			Licenses:     art.Licenses,
-			Relationship: art.Relationship,
-			Locations:    art.Locations,
+			ID:                 packageID(name, art.Version.String()),
+			Name:               name,
+			Version:            art.Version.String(),
+			Licenses:           art.Licenses,
+			ExternalReferences: art.ExternalReferences,
+			Relationship:       art.Relationship,
+			Locations:          art.Locations,
 		}
 		pkgs = append(pkgs, pkg)
 
@@ -533,6 +535,12 @@ func (p *Parser) parseParent(currentPath string, parent pomParent) (*pom, error)
 	parentPOM, err := p.retrieveParent(currentPath, parent.RelativePath, target)
 	if err != nil {
 		logger.Debug("Parent POM not found", log.Err(err))
+		// Versions inherited from the parent can't be determined, which results in empty versions.
+		// Remote repositories are the last resort, so let users know why they are empty.
+		if p.offline {
+			logger.Warn("Parent POM was not found locally and remote repositories are not searched in offline mode. " +
+				"Versions inherited from the parent may be empty")
+		}
 		return &pom{content: &pomXML{}}, nil
 	}
 
@@ -326,6 +326,33 @@ func TestPom_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "external references",
+			inputFile: filepath.Join("testdata", "external-references", "pom.xml"),
+			local:     true,
+			want: []ftypes.Package{
+				{
+					ID:           "com.example:external-references:1.0.0",
+					Name:         "com.example:external-references",
+					Version:      "1.0.0",
+					Relationship: ftypes.RelationshipRoot,
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://example.com/external-references",
+						},
+						{
+							Type: ftypes.RefVCS,
+							URL:  "https://github.com/example/external-references",
+						},
+						{
+							Type: ftypes.RefIssueTracker,
+							URL:  "https://github.com/example/external-references/issues",
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "inherit parent properties",
 			inputFile: filepath.Join("testdata", "parent-properties", "child", "pom.xml"),
@@ -60,6 +60,8 @@ func (p *pom) projectProperties() map[string]string {
 	// https://maven.apache.org/pom.html#inheritance
 	props["groupId"] = p.content.GroupId
 	props["version"] = p.content.Version
+	// "artifactId" is never inherited, but the parent properties merged into this POM may contain it.
+	props["artifactId"] = p.content.ArtifactId
 
 	// https://maven.apache.org/pom.html#properties
 	projectProperties := make(map[string]string)
@@ -114,7 +116,40 @@ func (p *pom) listProperties(val reflect.Value) map[string]string {
 }
 
 func (p *pom) artifact() artifact {
-	return newArtifact(p.content.GroupId, p.content.ArtifactId, p.content.Version, p.licenses(), p.content.Properties)
+	art := newArtifact(p.content.GroupId, p.content.ArtifactId, p.content.Version, p.licenses(), p.content.Properties)
+	art.ExternalReferences = p.externalReferences()
+	return art
+}
+
+// externalReferences returns the project URLs, such as the source repository and the issue tracker.
+// The URLs are not inherited from the parent since Maven adjusts some of them for child projects.
+func (p *pom) externalReferences() []ftypes.ExternalRef {
+	props := p.properties()
+	var refs []ftypes.ExternalRef
+	for _, ref := range []ftypes.ExternalRef{
+		{
+			Type: ftypes.RefWebsite,
+			URL:  p.content.URL,
+		},
+		{
+			Type: ftypes.RefVCS,
+			URL:  p.content.Scm.URL,
+		},
+		{
+			Type: ftypes.RefIssueTracker,
+			URL:  p.content.IssueManagement.URL,
+		},
+		{
+			Type: ftypes.RefDistribution,
+			URL:  p.content.DistributionManagement.DownloadURL,
+		},
+	} {
+		ref.URL = evaluateVariable(ref.URL, props, nil)
+		if ref.URL != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
 }
 
 func (p *pom) licenses() []string {
@@ -167,7 +202,17 @@ type pomXML struct {
 	ArtifactId string      `xml:"artifactId"`
 	Version    string      `xml:"version"`
 	Licenses   pomLicenses `xml:"licenses"`
-	Modules    struct {
+	URL        string      `xml:"url"`
+	Scm        struct {
+		URL string `xml:"url"`
+	} `xml:"scm"`
+	IssueManagement struct {
+		URL string `xml:"url"`
+	} `xml:"issueManagement"`
+	DistributionManagement struct {
+		DownloadURL string `xml:"downloadUrl"`
+	} `xml:"distributionManagement"`
+	Modules struct {
 		Text   string   `xml:",chardata"`
 		Module []string `xml:"module"`
 	} `xml:"modules"`
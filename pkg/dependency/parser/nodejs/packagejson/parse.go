@@ -3,7 +3,9 @@ package packagejson
 import (
 	"encoding/json"
 	"io"
+	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/samber/lo"
 	"golang.org/x/xerrors"
@@ -18,6 +20,9 @@ type packageJSON struct {
 	Name                 string            `json:"name"`
 	Version              string            `json:"version"`
 	License              any               `json:"license"`
+	Homepage             string            `json:"homepage"`
+	Repository           any               `json:"repository"`
+	Bugs                 any               `json:"bugs"`
 	Dependencies         map[string]string `json:"dependencies"`
 	OptionalDependencies map[string]string `json:"optionalDependencies"`
 	DevDependencies      map[string]string `json:"devDependencies"`
@@ -57,10 +62,11 @@ func (p *Parser) Parse(r io.Reader) (Package, error) {
 
 	return Package{
 		Package: ftypes.Package{
-			ID:       id,
-			Name:     pkgJSON.Name,
-			Version:  pkgJSON.Version,
-			Licenses: parseLicense(pkgJSON.License),
+			ID:                 id,
+			Name:               pkgJSON.Name,
+			Version:            pkgJSON.Version,
+			Licenses:           parseLicense(pkgJSON.License),
+			ExternalReferences: parseExternalReferences(pkgJSON),
 		},
 		Dependencies:         pkgJSON.Dependencies,
 		OptionalDependencies: pkgJSON.OptionalDependencies,
@@ -86,6 +92,45 @@ func parseLicense(val any) []string {
 	return nil
 }
 
+// parseExternalReferences returns the URLs of the source repository, the issue tracker and the homepage
+// cf. https://docs.npmjs.com/cli/v10/configuring-npm/package-json#repository
+func parseExternalReferences(pkgJSON packageJSON) []ftypes.ExternalRef {
+	var refs []ftypes.ExternalRef
+	for _, ref := range []ftypes.ExternalRef{
+		{
+			Type: ftypes.RefVCS,
+			URL:  strings.TrimPrefix(parseURL(pkgJSON.Repository), "git+"),
+		},
+		{
+			Type: ftypes.RefIssueTracker,
+			URL:  parseURL(pkgJSON.Bugs),
+		},
+		{
+			Type: ftypes.RefWebsite,
+			URL:  pkgJSON.Homepage,
+		},
+	} {
+		// Skip shortcuts such as "github:user/repo"
+		if u, err := url.Parse(ref.URL); err == nil && u.Host != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// parseURL returns the URL of the field, which can be either a string or an object with the "url" field
+func parseURL(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if u, ok := v["url"].(string); ok {
+			return u
+		}
+	}
+	return ""
+}
+
 // parseWorkspaces returns slice of workspaces
 func parseWorkspaces(val any) []string {
 	// Workspaces support 2 types - https://github.com/SchemaStore/schemastore/blob/d9516961f8a5b0e65a457808070147b5a866f60b/src/schemas/json/package.json#L777
@@ -32,6 +32,20 @@ func TestParse(t *testing.T) {
 					Name:     "bootstrap",
 					Version:  "5.0.2",
 					Licenses: []string{"MIT"},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefVCS,
+							URL:  "https://github.com/twbs/bootstrap.git",
+						},
+						{
+							Type: ftypes.RefIssueTracker,
+							URL:  "https://github.com/twbs/bootstrap/issues",
+						},
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://getbootstrap.com/",
+						},
+					},
 				},
 				Dependencies: map[string]string{
 					"js-tokens": "^4.0.0",
@@ -58,6 +72,20 @@ func TestParse(t *testing.T) {
 					Name:     "angular",
 					Version:  "4.1.2",
 					Licenses: []string{"ISC"},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefVCS,
+							URL:  "https://github.com/twbs/bootstrap.git",
+						},
+						{
+							Type: ftypes.RefIssueTracker,
+							URL:  "https://github.com/twbs/bootstrap/issues",
+						},
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://getbootstrap.com/",
+						},
+					},
 				},
 				Dependencies: make(map[string]string),
 				DevDependencies: map[string]string{
@@ -40,6 +40,11 @@ type LockFile struct {
 	// V9
 	Importers map[string]Importer `yaml:"importers,omitempty"`
 	Snapshots map[string]Snapshot `yaml:"snapshots,omitempty"`
+
+	// Catalogs hold the versions shared across workspace packages (pnpm v9.5+).
+	// e.g. catalogs.default.react => {specifier: ^18.2.0, version: 18.3.1}
+	// cf. https://pnpm.io/catalogs
+	Catalogs map[string]map[string]ImporterDepVersion `yaml:"catalogs,omitempty"`
 }
 
 type Importer struct {
@@ -48,7 +53,8 @@ type Importer struct {
 }
 
 type ImporterDepVersion struct {
-	Version string `yaml:"version,omitempty"`
+	Specifier string `yaml:"specifier,omitempty"`
+	Version   string `yaml:"version,omitempty"`
 }
 
 type Snapshot struct {
@@ -164,15 +170,17 @@ func (p *Parser) parseV9(lockFile LockFile) ([]ftypes.Package, []ftypes.Dependen
 
 	}
 
-	// Parse `Importers` to find all direct dependencies
-	devDeps := make(map[string]string)
-	deps := make(map[string]string)
+	// Parse `Importers` to find all direct dependencies.
+	// Workspace packages may depend on different versions of the same package (e.g. using named catalogs),
+	// so direct dependencies are stored by ID rather than by name.
+	devDeps := set.New[string]()
+	deps := set.New[string]()
 	for _, importer := range lockFile.Importers {
 		for n, v := range importer.DevDependencies {
-			devDeps[n] = v.Version
+			devDeps.Append(packageID(n, p.trimPeerDeps(p.resolveImporterVersion(n, v, lockFile.Catalogs), lockVer)))
 		}
 		for n, v := range importer.Dependencies {
-			deps[n] = v.Version
+			deps.Append(packageID(n, p.trimPeerDeps(p.resolveImporterVersion(n, v, lockFile.Catalogs), lockVer)))
 		}
 	}
 
@@ -188,10 +196,10 @@ func (p *Parser) parseV9(lockFile LockFile) ([]ftypes.Package, []ftypes.Dependen
 		// We will update `Dev` field later.
 		dev := true
 		relationship := ftypes.RelationshipIndirect
-		if v, ok := devDeps[name]; ok && p.trimPeerDeps(v, lockVer) == ver {
+		if devDeps.Contains(packageID(name, ver)) {
 			relationship = ftypes.RelationshipDirect
 		}
-		if v, ok := deps[name]; ok && p.trimPeerDeps(v, lockVer) == ver {
+		if deps.Contains(packageID(name, ver)) {
 			relationship = ftypes.RelationshipDirect
 			dev = false // mark root direct deps to update `dev` field of their child deps.
 		}
@@ -227,6 +235,26 @@ func (p *Parser) parseV9(lockFile LockFile) ([]ftypes.Package, []ftypes.Dependen
 	return lo.Values(resolvedPkgs), lo.Values(resolvedDeps)
 }
 
+// resolveImporterVersion returns the resolved version of the importer dependency.
+// Dependencies using the catalog protocol fall back to the catalog entry if the version is not recorded.
+// e.g.
+//   - "catalog:" => catalogs.default.<name>.version
+//   - "catalog:react17" => catalogs.react17.<name>.version
+func (p *Parser) resolveImporterVersion(name string, dep ImporterDepVersion, catalogs map[string]map[string]ImporterDepVersion) string {
+	catalog, ok := strings.CutPrefix(dep.Specifier, "catalog:")
+	if !ok || dep.Version != "" {
+		return dep.Version
+	}
+	if catalog == "" {
+		catalog = "default"
+	}
+	if entry, found := catalogs[catalog][name]; found {
+		return entry.Version
+	}
+	p.logger.Debug("Unable to find the catalog entry", log.String("name", name), log.String("catalog", catalog))
+	return ""
+}
+
 // markRootPkgs sets `Dev` to false for non dev dependency.
 func (p *Parser) markRootPkgs(id string, pkgs map[string]ftypes.Package, deps map[string]ftypes.Dependency, visited set.Set[string]) {
 	if visited.Contains(id) {
@@ -65,6 +65,12 @@ func TestParse(t *testing.T) {
 			want:     pnpmV9CyclicImport,
 			wantDeps: pnpmV9CyclicImportDeps,
 		},
+		{
+			name:     "v9 with catalogs",
+			file:     "testdata/pnpm-lock_v9_catalogs.yaml",
+			want:     pnpmV9Catalogs,
+			wantDeps: pnpmV9CatalogsDeps,
+		},
 	}
 
 	for _, tt := range tests {
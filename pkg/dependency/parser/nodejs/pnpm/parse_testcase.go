@@ -977,4 +977,68 @@ var (
 			},
 		},
 	}
+
+	// docker run --name node --rm -it node:22-alpine sh
+	// corepack enable && mkdir -p /app/packages/legacy && cd /app
+	// cat <<EOF > pnpm-workspace.yaml
+	// packages:
+	//   - 'packages/*'
+	// catalog:
+	//   ms: ^2.1.2
+	// catalogs:
+	//   legacy:
+	//     ms: ^2.0.0
+	//     debug: ^2.6.0
+	// EOF
+	// pnpm add debug@^4.3.1 ms@catalog: && pnpm pkg set pnpm.overrides.debug@^4=4.3.4
+	// cd packages/legacy && pnpm init && pnpm add ms@catalog:legacy && pnpm add -D debug@catalog:legacy
+	// pnpm-lock.yaml is manually edited to remove the version of the catalog dev dependency.
+	pnpmV9Catalogs = []ftypes.Package{
+		{
+			ID:           "debug@2.6.9",
+			Name:         "debug",
+			Version:      "2.6.9",
+			Relationship: ftypes.RelationshipDirect,
+			Dev:          true,
+		},
+		{
+			ID:           "debug@4.3.4",
+			Name:         "debug",
+			Version:      "4.3.4",
+			Relationship: ftypes.RelationshipDirect,
+		},
+		{
+			ID:           "ms@2.0.0",
+			Name:         "ms",
+			Version:      "2.0.0",
+			Relationship: ftypes.RelationshipDirect,
+		},
+		{
+			ID:           "ms@2.1.2",
+			Name:         "ms",
+			Version:      "2.1.2",
+			Relationship: ftypes.RelationshipIndirect,
+		},
+		{
+			ID:           "ms@2.1.3",
+			Name:         "ms",
+			Version:      "2.1.3",
+			Relationship: ftypes.RelationshipDirect,
+		},
+	}
+
+	pnpmV9CatalogsDeps = []ftypes.Dependency{
+		{
+			ID: "debug@2.6.9",
+			DependsOn: []string{
+				"ms@2.0.0",
+			},
+		},
+		{
+			ID: "debug@4.3.4",
+			DependsOn: []string{
+				"ms@2.1.2",
+			},
+		},
+	}
 )
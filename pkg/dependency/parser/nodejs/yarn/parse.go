@@ -14,6 +14,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/dependency"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/set"
 	xio "github.com/aquasecurity/trivy/pkg/x/io"
 )
 
@@ -28,10 +29,11 @@ type LockFile struct {
 }
 
 type Library struct {
-	Patterns []string
-	Name     string
-	Version  string
-	Location ftypes.Location
+	Patterns   []string
+	Name       string
+	Version    string
+	Conditions string // e.g. "os=darwin & cpu=arm64"
+	Location   ftypes.Location
 }
 type Dependency struct {
 	Pattern string
@@ -113,8 +115,8 @@ func getDependency(target string) (name, version string, err error) {
 
 func validProtocol(protocol string) bool {
 	switch protocol {
-	// only scan npm packages
-	case "npm", "":
+	// only scan npm packages and patched npm packages
+	case "npm", "patch", "":
 		return true
 	}
 	return false
@@ -122,7 +124,7 @@ func validProtocol(protocol string) bool {
 
 func ignoreProtocol(protocol string) bool {
 	switch protocol {
-	case "workspace", "patch", "file", "link", "portal", "github", "git", "git+ssh", "git+http", "git+https", "git+file":
+	case "workspace", "file", "link", "portal", "github", "git", "git+ssh", "git+http", "git+https", "git+file":
 		return true
 	}
 	return false
@@ -131,9 +133,14 @@ func ignoreProtocol(protocol string) bool {
 func parseResults(patternIDs map[string]string, dependsOn map[string][]string) (deps ftypes.Dependencies) {
 	// find dependencies by patterns
 	for pkgID, depPatterns := range dependsOn {
-		depIDs := lo.Map(depPatterns, func(pattern string, index int) string {
-			return patternIDs[pattern]
+		// Dependencies that are not in the result, such as skipped conditional packages, are dropped
+		depIDs := lo.FilterMap(depPatterns, func(pattern string, _ int) (string, bool) {
+			depID, ok := patternIDs[pattern]
+			return depID, ok
 		})
+		if len(depIDs) == 0 {
+			continue
+		}
 		deps = append(deps, ftypes.Dependency{
 			ID:        pkgID,
 			DependsOn: depIDs,
@@ -144,12 +151,29 @@ func parseResults(patternIDs map[string]string, dependsOn map[string][]string) (
 
 type Parser struct {
 	logger *log.Logger
+
+	// installed holds IDs of the installed packages if the install state is available.
+	installed set.Set[string]
+}
+
+type Option func(*Parser)
+
+// WithInstalledPackages skips conditional packages that are not installed,
+// such as platform-specific optional dependencies.
+func WithInstalledPackages(installed set.Set[string]) Option {
+	return func(p *Parser) {
+		p.installed = installed
+	}
 }
 
-func NewParser() *Parser {
-	return &Parser{
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
 		logger: log.WithPrefix("yarn"),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 func (p *Parser) scanBlocks(data []byte, atEOF bool) (advance int, token []byte, err error) {
@@ -204,6 +228,9 @@ func (p *Parser) parseBlock(block []byte, lineNum int) (lib Library, deps []stri
 				skipBlock = true
 			}
 			continue
+		case strings.HasPrefix(line, "conditions:"):
+			lib.Conditions = strings.TrimSpace(strings.TrimPrefix(line, "conditions:"))
+			continue
 		case strings.HasPrefix(line, "dependencies:"):
 			// start dependencies block
 			deps = parseDependencies(scanner)
@@ -254,7 +281,7 @@ func parseDependencies(scanner *LineScanner) (deps []string) {
 		if dep, err := parseDependency(line); err != nil {
 			// finished dependencies block
 			return deps
-		} else {
+		} else if dep != "" { // skip dependencies with unsupported protocols
 			deps = append(deps, dep)
 		}
 	}
@@ -298,14 +325,24 @@ func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependenc
 		}
 
 		pkgID := packageID(lib.Name, lib.Version)
-		pkgs = append(pkgs, ftypes.Package{
-			ID:        pkgID,
-			Name:      lib.Name,
-			Version:   lib.Version,
-			Locations: []ftypes.Location{lib.Location},
-		})
+		if lib.Conditions != "" && p.installed != nil && !p.installed.Contains(pkgID) {
+			p.logger.Debug("Skipping the conditional package that is not installed",
+				log.String("id", pkgID), log.String("conditions", lib.Conditions))
+			continue
+		}
+
+		// Patched packages (e.g. "resolve@patch:resolve@npm%3A^1.20.0#~builtin<compat/resolve>")
+		// have the same ID as the original package.
+		if _, ok := pkgIDPatterns[pkgID]; !ok {
+			pkgs = append(pkgs, ftypes.Package{
+				ID:        pkgID,
+				Name:      lib.Name,
+				Version:   lib.Version,
+				Locations: []ftypes.Location{lib.Location},
+			})
+		}
 
-		pkgIDPatterns[pkgID] = lib.Patterns
+		pkgIDPatterns[pkgID] = append(pkgIDPatterns[pkgID], lib.Patterns...)
 		for _, pattern := range lib.Patterns {
 			// e.g.
 			//   combined-stream@^1.0.6 => combined-stream@1.0.8
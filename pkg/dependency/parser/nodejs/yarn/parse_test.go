@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/set"
 )
 
 func TestParsePattern(t *testing.T) {
@@ -252,10 +253,11 @@ func TestGetDependency(t *testing.T) {
 
 func TestParse(t *testing.T) {
 	tests := []struct {
-		name     string
-		file     string // Test input file
-		want     []ftypes.Package
-		wantDeps []ftypes.Dependency
+		name      string
+		file      string // Test input file
+		installed set.Set[string]
+		want      []ftypes.Package
+		wantDeps  []ftypes.Dependency
 	}{
 		{
 			name:     "happy",
@@ -285,6 +287,24 @@ func TestParse(t *testing.T) {
 			want:     yarnV2DepsWithProtocol,
 			wantDeps: yarnV2DepsWithProtocolDeps,
 		},
+		{
+			name:     "yarn v4 with workspace, patch protocols and conditional dependencies",
+			file:     "testdata/yarn_v4_berry.lock",
+			want:     yarnV4Berry,
+			wantDeps: yarnV4BerryDeps,
+		},
+		{
+			name: "yarn v4 with install state",
+			file: "testdata/yarn_v4_berry.lock",
+			installed: set.New(
+				"@esbuild/linux-x64@0.20.2",
+				"esbuild@0.20.2",
+				"ms@2.1.3",
+				"resolve@1.22.8",
+			),
+			want:     yarnV4BerryInstalled,
+			wantDeps: yarnV4BerryInstalledDeps,
+		},
 		{
 			name: "yarn with git dependency",
 			file: "testdata/yarn_with_git.lock",
@@ -301,7 +321,11 @@ func TestParse(t *testing.T) {
 			f, err := os.Open(tt.file)
 			require.NoError(t, err)
 
-			got, deps, _, err := NewParser().Parse(f)
+			var opts []Option
+			if tt.installed != nil {
+				opts = append(opts, WithInstalledPackages(tt.installed))
+			}
+			got, deps, _, err := NewParser(opts...).Parse(f)
 			require.NoError(t, err)
 
 			assert.Equal(t, tt.want, got)
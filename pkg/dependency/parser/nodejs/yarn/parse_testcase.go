@@ -75,6 +75,7 @@ var (
 		{ID: "color-convert@2.0.1", Name: "color-convert", Version: "2.0.1", Locations: []ftypes.Location{{StartLine: 72, EndLine: 79}}},
 		{ID: "color-name@1.1.3", Name: "color-name", Version: "1.1.3", Locations: []ftypes.Location{{StartLine: 81, EndLine: 86}}},
 		{ID: "color-name@1.1.4", Name: "color-name", Version: "1.1.4", Locations: []ftypes.Location{{StartLine: 88, EndLine: 93}}},
+		{ID: "fsevents@2.1.3", Name: "fsevents", Version: "2.1.3", Locations: []ftypes.Location{{StartLine: 95, EndLine: 102}}},
 		{ID: "ipaddr.js@1.9.1", Name: "ipaddr.js", Version: "1.9.1", Locations: []ftypes.Location{{StartLine: 104, EndLine: 109}}},
 		{ID: "js-tokens@4.0.0", Name: "js-tokens", Version: "4.0.0", Locations: []ftypes.Location{{StartLine: 111, EndLine: 116}}},
 		{ID: "loose-envify@1.4.0", Name: "loose-envify", Version: "1.4.0", Locations: []ftypes.Location{{StartLine: 118, EndLine: 127}}},
@@ -109,6 +110,12 @@ var (
 				"color-name@1.1.4",
 			},
 		},
+		{
+			ID: "fsevents@2.1.3",
+			DependsOn: []string{
+				"node-gyp@7.1.0",
+			},
+		},
 		{
 			ID: "loose-envify@1.4.0",
 			DependsOn: []string{
@@ -157,4 +164,45 @@ var (
 			DependsOn: []string{"ms@2.1.2"},
 		},
 	}
+
+	yarnV4Berry = []ftypes.Package{
+		{ID: "@esbuild/darwin-arm64@0.20.2", Name: "@esbuild/darwin-arm64", Version: "0.20.2", Locations: []ftypes.Location{{StartLine: 8, EndLine: 13}}},
+		{ID: "@esbuild/linux-x64@0.20.2", Name: "@esbuild/linux-x64", Version: "0.20.2", Locations: []ftypes.Location{{StartLine: 15, EndLine: 20}}},
+		{ID: "esbuild@0.20.2", Name: "esbuild", Version: "0.20.2", Locations: []ftypes.Location{{StartLine: 32, EndLine: 47}}},
+		{ID: "ms@2.1.3", Name: "ms", Version: "2.1.3", Locations: []ftypes.Location{{StartLine: 58, EndLine: 63}}},
+		{ID: "resolve@1.22.8", Name: "resolve", Version: "1.22.8", Locations: []ftypes.Location{{StartLine: 65, EndLine: 74}}},
+	}
+
+	yarnV4BerryDeps = []ftypes.Dependency{
+		{
+			ID: "esbuild@0.20.2",
+			DependsOn: []string{
+				"@esbuild/darwin-arm64@0.20.2",
+				"@esbuild/linux-x64@0.20.2",
+			},
+		},
+		{
+			ID:        "resolve@1.22.8",
+			DependsOn: []string{"ms@2.1.3"},
+		},
+	}
+
+	// only the packages for linux/x64 are installed
+	yarnV4BerryInstalled = []ftypes.Package{
+		{ID: "@esbuild/linux-x64@0.20.2", Name: "@esbuild/linux-x64", Version: "0.20.2", Locations: []ftypes.Location{{StartLine: 15, EndLine: 20}}},
+		{ID: "esbuild@0.20.2", Name: "esbuild", Version: "0.20.2", Locations: []ftypes.Location{{StartLine: 32, EndLine: 47}}},
+		{ID: "ms@2.1.3", Name: "ms", Version: "2.1.3", Locations: []ftypes.Location{{StartLine: 58, EndLine: 63}}},
+		{ID: "resolve@1.22.8", Name: "resolve", Version: "1.22.8", Locations: []ftypes.Location{{StartLine: 65, EndLine: 74}}},
+	}
+
+	yarnV4BerryInstalledDeps = []ftypes.Dependency{
+		{
+			ID:        "esbuild@0.20.2",
+			DependsOn: []string{"@esbuild/linux-x64@0.20.2"},
+		},
+		{
+			ID:        "resolve@1.22.8",
+			DependsOn: []string{"ms@2.1.3"},
+		},
+	}
 )
@@ -0,0 +1,142 @@
+package yarn
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/set"
+)
+
+// pnpState represents the runtime state embedded in .pnp.cjs or stored in .pnp.data.json.
+// cf. https://yarnpkg.com/advanced/pnp-spec#packageregistrydata
+type pnpState struct {
+	// e.g. [["debug", [["npm:4.3.4", {...}]]]]
+	PackageRegistryData []pnpPackage `json:"packageRegistryData"`
+}
+
+type pnpPackage struct {
+	Name       string
+	References []string
+}
+
+func (p *pnpPackage) UnmarshalJSON(data []byte) error {
+	var entry [2]json.RawMessage
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return err
+	}
+	// The name is null for the top-level workspace
+	var name *string
+	if err := json.Unmarshal(entry[0], &name); err != nil {
+		return err
+	}
+	var stores [][2]json.RawMessage
+	if err := json.Unmarshal(entry[1], &stores); err != nil {
+		return err
+	}
+	for _, store := range stores {
+		var reference *string
+		if err := json.Unmarshal(store[0], &reference); err != nil {
+			return err
+		}
+		if reference != nil {
+			p.References = append(p.References, *reference)
+		}
+	}
+	p.Name = lo.FromPtr(name)
+	return nil
+}
+
+// ParseInstallState parses the Plug'n'Play install state (.pnp.cjs or .pnp.data.json)
+// and returns IDs of the installed npm packages.
+func ParseInstallState(r io.Reader) (set.Set[string], error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	// .pnp.data.json holds the state as is, while .pnp.cjs embeds it as a string literal.
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		if b, err = extractRuntimeState(b); err != nil {
+			return nil, err
+		}
+	}
+
+	var state pnpState
+	if err = json.Unmarshal(b, &state); err != nil {
+		return nil, xerrors.Errorf("unable to decode the PnP runtime state: %w", err)
+	}
+
+	installed := set.New[string]()
+	for _, pkg := range state.PackageRegistryData {
+		for _, ref := range pkg.References {
+			// Virtual packages hold the original reference after "#"
+			// e.g. "virtual:4c0e3d...#npm:18.2.0"
+			if _, after, found := strings.Cut(ref, "#"); found && strings.HasPrefix(ref, "virtual:") {
+				ref = after
+			}
+			// Patched packages hold the original descriptor before "#"
+			// e.g. "patch:resolve@npm%3A1.22.8#optional!builtin<compat/resolve>::version=1.22.8&hash=c3c19d"
+			if patched, ok := strings.CutPrefix(ref, "patch:"); ok && patched != "" {
+				patched, _, _ = strings.Cut(patched, "#")
+				if unescaped, err := url.PathUnescape(patched); err == nil {
+					patched = unescaped
+				}
+				// Skip the first character for scoped packages, e.g. "@types/node@npm:20.0.0"
+				if _, descriptor, found := strings.Cut(patched[1:], "@"); found {
+					ref = descriptor
+				}
+			}
+			version, ok := strings.CutPrefix(ref, "npm:")
+			if !ok {
+				continue
+			}
+			// Aliased packages hold the original name
+			// e.g. "npm:string-width@4.2.3"
+			if i := strings.LastIndex(version, "@"); i > 0 {
+				version = version[i+1:]
+			}
+			installed.Append(packageID(pkg.Name, version))
+		}
+	}
+	return installed, nil
+}
+
+// extractRuntimeState extracts the JSON string literal embedded in .pnp.cjs.
+// e.g.
+//
+//	const RAW_RUNTIME_STATE =
+//	'{\
+//	  "__info": [\
+//	...
+//	}';
+func extractRuntimeState(b []byte) ([]byte, error) {
+	start := bytes.Index(b, []byte("'{"))
+	if start < 0 {
+		return nil, xerrors.New("PnP runtime state not found")
+	}
+
+	var buf bytes.Buffer
+	for i := start + 1; i < len(b); i++ {
+		switch b[i] {
+		case '\'':
+			return buf.Bytes(), nil
+		case '\\':
+			i++
+			if i < len(b) && b[i] == '\r' {
+				i++
+			}
+			if i < len(b) && b[i] != '\n' { // Skip line continuations
+				buf.WriteByte(b[i])
+			}
+		default:
+			buf.WriteByte(b[i])
+		}
+	}
+	return nil, xerrors.New("unterminated PnP runtime state")
+}
@@ -0,0 +1,63 @@
+package yarn
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstallState(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   func(t *testing.T) string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: ".pnp.cjs",
+			input: func(t *testing.T) string {
+				b, err := os.ReadFile("testdata/pnp.cjs")
+				require.NoError(t, err)
+				return string(b)
+			},
+			want: []string{
+				"@esbuild/linux-x64@0.20.2",
+				"esbuild@0.20.2",
+				"ms@2.1.3",
+				"resolve@1.22.8",
+				"string-width-cjs@4.2.3",
+			},
+		},
+		{
+			name: ".pnp.data.json",
+			input: func(_ *testing.T) string {
+				return `{"packageRegistryData": [[null, [[null, {}]]], ["ms", [["npm:2.1.3", {}], ["virtual:4c0e3d#npm:2.1.2", {}]]]]}`
+			},
+			want: []string{
+				"ms@2.1.2",
+				"ms@2.1.3",
+			},
+		},
+		{
+			name: "runtime state not found",
+			input: func(_ *testing.T) string {
+				return `module.exports = {};`
+			},
+			wantErr: "PnP runtime state not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInstallState(strings.NewReader(tt.input(t)))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, got.Items())
+		})
+	}
+}
@@ -55,6 +55,7 @@ func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependenc
 
 			depId := packageID(packageName, packageContent.Resolved)
 
+			// "CentralTransitive" packages are transitive dependencies pinned by Central Package Management
 			pkg := ftypes.Package{
 				ID:           depId,
 				Name:         packageName,
@@ -2,6 +2,9 @@ package packagesprops
 
 import (
 	"encoding/xml"
+	"io"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"golang.org/x/xerrors"
@@ -20,15 +23,50 @@ type Pkg struct {
 
 // https://github.com/dotnet/roslyn-tools/blob/b4c5220f5dfc4278847b6d38eff91cc1188f8066/src/RoslynInsertionTool/RoslynInsertionTool/CoreXT.cs#L150
 type itemGroup struct {
-	PackageReferenceEntry []Pkg `xml:"PackageReference"`
-	PackageVersionEntry   []Pkg `xml:"PackageVersion"`
+	PackageReferenceEntry       []Pkg `xml:"PackageReference"`
+	PackageVersionEntry         []Pkg `xml:"PackageVersion"`
+	GlobalPackageReferenceEntry []Pkg `xml:"GlobalPackageReference"`
+}
+
+type propertyGroup struct {
+	Properties []property `xml:",any"`
+}
+
+type property struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
 }
 
 type project struct {
-	XMLName    xml.Name    `xml:"Project"`
-	ItemGroups []itemGroup `xml:"ItemGroup"`
+	XMLName        xml.Name        `xml:"Project"`
+	PropertyGroups []propertyGroup `xml:"PropertyGroup"`
+	ItemGroups     []itemGroup     `xml:"ItemGroup"`
+}
+
+// Props represents the packages and the settings of Central Package Management in Directory.Packages.props.
+// cf. https://learn.microsoft.com/en-us/nuget/consume-packages/central-package-management
+type Props struct {
+	// Packages defined with PackageVersion or PackageReference
+	Packages []ftypes.Package
+	// Packages referenced by every project with GlobalPackageReference
+	GlobalPackages []ftypes.Package
+	// Properties defined in PropertyGroup
+	Properties map[string]string
+}
+
+// CentralPackageManagement returns true if "ManagePackageVersionsCentrally" is enabled.
+func (p Props) CentralPackageManagement() bool {
+	return isTrue(p.Properties["ManagePackageVersionsCentrally"])
+}
+
+// TransitivePinning returns true if "CentralPackageTransitivePinningEnabled" is enabled.
+func (p Props) TransitivePinning() bool {
+	return isTrue(p.Properties["CentralPackageTransitivePinningEnabled"])
 }
 
+// e.g. $(NewtonsoftJsonVersion)
+var variableRegexp = regexp.MustCompile(`\$\(([^)]+)\)`)
+
 type Parser struct{}
 
 func NewParser() *Parser {
@@ -36,14 +74,19 @@ func NewParser() *Parser {
 }
 
 func (p Pkg) Package() ftypes.Package {
+	return p.resolve(nil)
+}
+
+// resolve returns the package, replacing variables with the properties.
+func (p Pkg) resolve(props map[string]string) ftypes.Package {
 	// Update attribute is considered legacy, so preferring Include
 	name := p.UpdatePackageName
 	if p.IncludePackageName != "" {
 		name = p.IncludePackageName
 	}
 
-	name = strings.TrimSpace(name)
-	version := strings.TrimSpace(p.Version)
+	name = ResolveProperties(strings.TrimSpace(name), props)
+	version := NormalizeVersion(ResolveProperties(strings.TrimSpace(p.Version), props))
 	return ftypes.Package{
 		ID:      dependency.ID(ftypes.NuGet, name, version),
 		Name:    name,
@@ -67,20 +110,76 @@ func isVariable(s string) bool {
 	return strings.HasPrefix(s, "$(") && strings.HasSuffix(s, ")")
 }
 
-func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+func isTrue(s string) bool {
+	b, _ := strconv.ParseBool(strings.TrimSpace(s))
+	return b
+}
+
+// ResolveProperties replaces MSBuild properties such as "$(Version)" with the values.
+// Undefined properties are left as is.
+func ResolveProperties(s string, props map[string]string) string {
+	if len(props) == 0 {
+		return s
+	}
+	return variableRegexp.ReplaceAllStringFunc(s, func(v string) string {
+		if value, ok := props[strings.TrimSpace(v[2:len(v)-1])]; ok {
+			return strings.TrimSpace(value)
+		}
+		return v
+	})
+}
+
+// NormalizeVersion converts exact versions such as "[1.2.3]" into "1.2.3".
+// Version ranges, such as "[1.0,2.0)", are returned as empty, as the resolved version is unknown.
+// cf. https://learn.microsoft.com/en-us/nuget/concepts/package-versioning#version-ranges
+func NormalizeVersion(v string) string {
+	if !strings.ContainsAny(v, "[](),") {
+		return v
+	}
+	if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") && !strings.Contains(v, ",") {
+		return strings.TrimSpace(v[1 : len(v)-1])
+	}
+	return ""
+}
+
+// ParseProps parses the packages and the properties of *.packages.props files.
+func ParseProps(r io.Reader) (Props, error) {
 	var configData project
 	if err := xml.NewDecoder(r).Decode(&configData); err != nil {
-		return nil, nil, xerrors.Errorf("failed to decode '*.packages.props' file: %w", err)
+		return Props{}, xerrors.Errorf("failed to decode '*.packages.props' file: %w", err)
+	}
+
+	props := Props{
+		Properties: make(map[string]string),
+	}
+	for _, group := range configData.PropertyGroups {
+		for _, prop := range group.Properties {
+			props.Properties[prop.XMLName.Local] = strings.TrimSpace(prop.Value)
+		}
 	}
 
-	var pkgs []ftypes.Package
 	for _, item := range configData.ItemGroups {
 		for _, pkg := range append(item.PackageReferenceEntry, item.PackageVersionEntry...) {
-			pkg := pkg.Package()
+			pkg := pkg.resolve(props.Properties)
+			if !shouldSkipPkg(pkg) {
+				props.Packages = append(props.Packages, pkg)
+			}
+		}
+		for _, pkg := range item.GlobalPackageReferenceEntry {
+			pkg := pkg.resolve(props.Properties)
 			if !shouldSkipPkg(pkg) {
-				pkgs = append(pkgs, pkg)
+				pkg.Relationship = ftypes.RelationshipDirect
+				props.GlobalPackages = append(props.GlobalPackages, pkg)
 			}
 		}
 	}
-	return utils.UniquePackages(pkgs), nil, nil
+	return props, nil
+}
+
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	props, err := ParseProps(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return utils.UniquePackages(append(props.Packages, props.GlobalPackages...)), nil, nil
 }
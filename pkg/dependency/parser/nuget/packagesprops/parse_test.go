@@ -52,6 +52,16 @@ func TestParse(t *testing.T) {
 				{Name: "PackageFour", Version: "2.4.1", ID: "PackageFour@2.4.1"},
 			},
 		},
+		{
+			name:      "CentralPackageManagement",
+			inputFile: "testdata/central_package_management.props",
+			want: []ftypes.Package{
+				{Name: "StyleCop.Analyzers", Version: "1.1.118", ID: "StyleCop.Analyzers@1.1.118", Relationship: ftypes.RelationshipDirect},
+				{Name: "Newtonsoft.Json", Version: "13.0.3", ID: "Newtonsoft.Json@13.0.3"},
+				{Name: "Serilog", Version: "3.1.1", ID: "Serilog@3.1.1"},
+				{Name: "System.Text.Json", Version: "8.0.4", ID: "System.Text.Json@8.0.4"},
+			},
+		},
 		{
 			name:      "NoItemGroupInXMLStructure",
 			inputFile: "testdata/no_item_group.props",
@@ -80,3 +90,36 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProps(t *testing.T) {
+	f, err := os.Open("testdata/central_package_management.props")
+	require.NoError(t, err)
+	defer f.Close()
+
+	got, err := config.ParseProps(f)
+	require.NoError(t, err)
+
+	assert.True(t, got.CentralPackageManagement())
+	assert.True(t, got.TransitivePinning())
+	assert.Equal(t, []ftypes.Package{
+		{Name: "StyleCop.Analyzers", Version: "1.1.118", ID: "StyleCop.Analyzers@1.1.118", Relationship: ftypes.RelationshipDirect},
+	}, got.GlobalPackages)
+	assert.Equal(t, "3.1.1", got.Properties["SerilogVersion"])
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.2.3", want: "1.2.3"},
+		{version: "[1.2.3]", want: "1.2.3"},
+		{version: "[1.2.3, )", want: ""},
+		{version: "(1.0,2.0]", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, config.NormalizeVersion(tt.version))
+		})
+	}
+}
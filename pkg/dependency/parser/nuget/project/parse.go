@@ -0,0 +1,87 @@
+package project
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+)
+
+// Reference represents PackageReference in project files.
+type Reference struct {
+	Name string
+	// Version is not allowed with Central Package Management, but it's kept to report it as is.
+	Version string
+	// VersionOverride overrides the central version.
+	// cf. https://learn.microsoft.com/en-us/nuget/consume-packages/central-package-management#overriding-package-versions
+	VersionOverride string
+}
+
+// Project represents MSBuild project files such as *.csproj.
+type Project struct {
+	References []Reference
+	// Properties defined in PropertyGroup
+	Properties map[string]string
+}
+
+type packageReference struct {
+	Include             string `xml:"Include,attr"`
+	Version             string `xml:"Version,attr"`
+	VersionElement      string `xml:"Version"`
+	VersionOverride     string `xml:"VersionOverride,attr"`
+	VersionOverrideElem string `xml:"VersionOverride"`
+}
+
+type itemGroup struct {
+	PackageReferences []packageReference `xml:"PackageReference"`
+}
+
+type propertyGroup struct {
+	Properties []property `xml:",any"`
+}
+
+type property struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type project struct {
+	XMLName        xml.Name        `xml:"Project"`
+	PropertyGroups []propertyGroup `xml:"PropertyGroup"`
+	ItemGroups     []itemGroup     `xml:"ItemGroup"`
+}
+
+// Parse parses PackageReference items and properties in MSBuild project files (*.csproj, *.fsproj and *.vbproj).
+func Parse(r io.Reader) (Project, error) {
+	var p project
+	if err := xml.NewDecoder(r).Decode(&p); err != nil {
+		return Project{}, xerrors.Errorf("failed to decode the project file: %w", err)
+	}
+
+	proj := Project{
+		Properties: make(map[string]string),
+	}
+	for _, group := range p.PropertyGroups {
+		for _, prop := range group.Properties {
+			proj.Properties[prop.XMLName.Local] = strings.TrimSpace(prop.Value)
+		}
+	}
+
+	for _, group := range p.ItemGroups {
+		for _, ref := range group.PackageReferences {
+			// References with "Update" modify the references defined elsewhere, e.g. in Directory.Build.props
+			name := strings.TrimSpace(ref.Include)
+			if name == "" {
+				continue
+			}
+			proj.References = append(proj.References, Reference{
+				Name:            name,
+				Version:         strings.TrimSpace(lo.CoalesceOrEmpty(ref.Version, ref.VersionElement)),
+				VersionOverride: strings.TrimSpace(lo.CoalesceOrEmpty(ref.VersionOverride, ref.VersionOverrideElem)),
+			})
+		}
+	}
+	return proj, nil
+}
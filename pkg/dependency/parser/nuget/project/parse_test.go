@@ -0,0 +1,57 @@
+package project_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/nuget/project"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      project.Project
+		wantErr   string
+	}{
+		{
+			name:      "happy path",
+			inputFile: "testdata/App.csproj",
+			want: project.Project{
+				References: []project.Reference{
+					{Name: "Newtonsoft.Json"},
+					{Name: "Serilog", VersionOverride: "$(SerilogVersion)"},
+					{Name: "Polly", Version: "8.2.0"},
+				},
+				Properties: map[string]string{
+					"TargetFramework": "net8.0",
+					"SerilogVersion":  "3.0.0",
+				},
+			},
+		},
+		{
+			name:      "sad path",
+			inputFile: "testdata/malformed.csproj",
+			wantErr:   "failed to decode the project file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := project.Parse(f)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package modelcard
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	version "github.com/aquasecurity/go-pep440-version"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+const frontMatterDelimiter = "---"
+
+// e.g. "pip install transformers==4.38.0", "!pip install -q 'accelerate==0.27.2'"
+var pipInstallRegexp = regexp.MustCompile(`\bpip3?\s+install\s+(.+)`)
+
+// metadata represents the YAML front matter of Hugging Face model cards.
+// cf. https://huggingface.co/docs/hub/model-cards#model-card-metadata
+type metadata struct {
+	LibraryName string `yaml:"library_name"`
+	PipelineTag string `yaml:"pipeline_tag"`
+	BaseModel   any    `yaml:"base_model"`
+	ModelIndex  any    `yaml:"model-index"`
+}
+
+func (m metadata) isModelCard() bool {
+	return m.LibraryName != "" || m.PipelineTag != "" || m.BaseModel != nil || m.ModelIndex != nil
+}
+
+// Parser parses Python packages installed with pip in the usage of model cards (README.md).
+type Parser struct {
+	logger *log.Logger
+}
+
+func NewParser() *Parser {
+	return &Parser{
+		logger: log.WithPrefix("model-card"),
+	}
+}
+
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	if !isModelCard(b) {
+		return nil, nil, nil
+	}
+
+	var pkgs []ftypes.Package
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	var lineNumber int
+	for scanner.Scan() {
+		lineNumber++
+		matches := pipInstallRegexp.FindStringSubmatch(scanner.Text())
+		if matches == nil {
+			continue
+		}
+
+		for _, arg := range strings.Fields(matches[1]) {
+			// Ignore options such as "-U" and "--upgrade"
+			if strings.HasPrefix(arg, "-") {
+				continue
+			}
+			name, ver, ok := splitRequirement(arg)
+			if !ok {
+				// Packages without versions are not reported as the installed versions are unknown.
+				continue
+			}
+			if _, err = version.Parse(ver); err != nil {
+				p.logger.Debug("Invalid package version in model card", log.String("requirement", arg))
+				continue
+			}
+			pkgs = append(pkgs, ftypes.Package{
+				Name:    name,
+				Version: ver,
+				Locations: []ftypes.Location{
+					{
+						StartLine: lineNumber,
+						EndLine:   lineNumber,
+					},
+				},
+			})
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, nil, xerrors.Errorf("scan error: %w", err)
+	}
+	return pkgs, nil, nil
+}
+
+// isModelCard checks if the front matter contains Hugging Face model card metadata.
+func isModelCard(b []byte) bool {
+	rest, ok := bytes.CutPrefix(b, []byte(frontMatterDelimiter+"\n"))
+	if !ok {
+		return false
+	}
+	frontMatter, _, ok := bytes.Cut(rest, []byte("\n"+frontMatterDelimiter))
+	if !ok {
+		return false
+	}
+
+	var m metadata
+	if err := yaml.Unmarshal(frontMatter, &m); err != nil {
+		return false
+	}
+	return m.isModelCard()
+}
+
+// splitRequirement splits "name[extras]==version" into the name and the version.
+func splitRequirement(arg string) (string, string, bool) {
+	// Remove quotes and a trailing backtick or semicolon
+	arg = strings.Trim(arg, "'\"`;")
+	name, ver, ok := strings.Cut(arg, "==")
+	if !ok || name == "" || ver == "" {
+		return "", "", false
+	}
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	return name, ver, true
+}
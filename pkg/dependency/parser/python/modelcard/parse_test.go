@@ -0,0 +1,72 @@
+package modelcard
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     []ftypes.Package
+	}{
+		{
+			name:     "model card",
+			filePath: "testdata/README.md",
+			want: []ftypes.Package{
+				{
+					Name:    "transformers",
+					Version: "4.38.0",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 14,
+							EndLine:   14,
+						},
+					},
+				},
+				{
+					Name:    "accelerate",
+					Version: "0.27.2",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 14,
+							EndLine:   14,
+						},
+					},
+				},
+				{
+					Name:    "bitsandbytes",
+					Version: "0.42.0",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 20,
+							EndLine:   20,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "not model card",
+			filePath: "testdata/not-model-card.md",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.filePath)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, _, err := NewParser().Parse(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
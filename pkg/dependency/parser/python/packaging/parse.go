@@ -5,8 +5,10 @@ import (
 	"errors"
 	"io"
 	"net/textproto"
+	"slices"
 	"strings"
 	"sync"
+	"unicode"
 
 	"golang.org/x/xerrors"
 
@@ -91,9 +93,63 @@ func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependenc
 
 	return []ftypes.Package{
 		{
-			Name:     name,
-			Version:  version,
-			Licenses: licensing.SplitLicenses(license),
+			Name:               name,
+			Version:            version,
+			Licenses:           licensing.SplitLicenses(license),
+			ExternalReferences: externalReferences(h),
 		},
 	}, nil, nil
 }
+
+// projectURLTypes maps the normalized labels of "Project-URL" to the reference types.
+// cf. https://packaging.python.org/en/latest/specifications/well-known-project-urls/
+var projectURLTypes = map[string]ftypes.RefType{
+	"homepage":     ftypes.RefWebsite,
+	"source":       ftypes.RefVCS,
+	"sourcecode":   ftypes.RefVCS,
+	"repository":   ftypes.RefVCS,
+	"code":         ftypes.RefVCS,
+	"issues":       ftypes.RefIssueTracker,
+	"issuetracker": ftypes.RefIssueTracker,
+	"bugtracker":   ftypes.RefIssueTracker,
+	"bugreports":   ftypes.RefIssueTracker,
+	"bugs":         ftypes.RefIssueTracker,
+	"tracker":      ftypes.RefIssueTracker,
+	"download":     ftypes.RefDistribution,
+}
+
+// externalReferences returns the project URLs defined in "Home-page", "Download-URL" and "Project-URL".
+// "Project-URL" entries with unknown labels, e.g. "Documentation", are skipped.
+func externalReferences(h textproto.MIMEHeader) []ftypes.ExternalRef {
+	var refs []ftypes.ExternalRef
+	add := func(refType ftypes.RefType, u string) {
+		ref := ftypes.ExternalRef{
+			Type: refType,
+			URL:  strings.TrimSpace(u),
+		}
+		if ref.URL != "" && ref.URL != "UNKNOWN" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+
+	add(ftypes.RefWebsite, h.Get("Home-page"))
+	add(ftypes.RefDistribution, h.Get("Download-URL"))
+
+	// e.g. Project-URL: Bug Tracker, https://github.com/networkx/networkx/issues
+	for _, projectURL := range h.Values("Project-URL") {
+		label, u, ok := strings.Cut(projectURL, ",")
+		if !ok {
+			continue
+		}
+		label = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, label)
+		if refType, ok := projectURLTypes[label]; ok {
+			add(refType, u)
+		}
+	}
+	return refs
+}
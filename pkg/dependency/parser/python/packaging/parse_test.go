@@ -40,6 +40,12 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"UNKNOWN",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://github.com/pypa/setuptools",
+						},
+					},
 				},
 			},
 		},
@@ -53,6 +59,12 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"UNKNOWN",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "http://www.tablix.org/~avian/blog/archives/2009/01/unicode_transliteration_in_python/",
+						},
+					},
 				},
 			},
 		},
@@ -72,6 +84,16 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"Python license",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://bitbucket.org/pypa/distlib",
+						},
+						{
+							Type: ftypes.RefDistribution,
+							URL:  "https://bitbucket.org/pypa/distlib/downloads/distlib-0.3.1.zip",
+						},
+					},
 				},
 			},
 		},
@@ -107,6 +129,16 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"Python Software Foundation License",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://bitbucket.org/pypa/distlib",
+						},
+						{
+							Type: ftypes.RefDistribution,
+							URL:  "https://bitbucket.org/pypa/distlib/downloads/distlib-0.3.1.zip",
+						},
+					},
 				},
 			},
 		},
@@ -122,6 +154,20 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"Eclipse Public License v2.0",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "http://asyncssh.timeheart.net",
+						},
+						{
+							Type: ftypes.RefVCS,
+							URL:  "https://github.com/ronf/asyncssh",
+						},
+						{
+							Type: ftypes.RefIssueTracker,
+							URL:  "https://github.com/ronf/asyncssh/issues",
+						},
+					},
 				},
 			},
 		},
@@ -139,6 +185,20 @@ func TestParse(t *testing.T) {
 						"GNU Lesser General Public License v2 or later (LGPLv2+)",
 						"Mozilla Public License 1.1 (MPL 1.1)",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefVCS,
+							URL:  "https://github.com/Kozea/Pyphen",
+						},
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://www.courtbouillon.org/pyphen",
+						},
+						{
+							Type: ftypes.RefIssueTracker,
+							URL:  "https://github.com/Kozea/Pyphen/issues",
+						},
+					},
 				},
 			},
 		},
@@ -157,6 +217,12 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"MIT",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://github.com/pytest-dev/iniconfig",
+						},
+					},
 				},
 			},
 		},
@@ -170,6 +236,12 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"MIT License",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://github.com/jaraco/zipp",
+						},
+					},
 				},
 			},
 		},
@@ -183,6 +255,20 @@ func TestParse(t *testing.T) {
 					Licenses: []string{
 						"file://LICENSE.txt",
 					},
+					ExternalReferences: []ftypes.ExternalRef{
+						{
+							Type: ftypes.RefWebsite,
+							URL:  "https://networkx.org/",
+						},
+						{
+							Type: ftypes.RefIssueTracker,
+							URL:  "https://github.com/networkx/networkx/issues",
+						},
+						{
+							Type: ftypes.RefVCS,
+							URL:  "https://github.com/networkx/networkx",
+						},
+					},
 				},
 			},
 		},
@@ -0,0 +1,83 @@
+package gem
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+const metadataFile = "metadata.gz"
+
+// specification represents the YAML-serialized Gem::Specification.
+// e.g.
+//
+//	--- !ruby/object:Gem::Specification
+//	name: rake
+//	version: !ruby/object:Gem::Version
+//	  version: 13.2.1
+//	licenses:
+//	- MIT
+type specification struct {
+	Name    string `yaml:"name"`
+	Version struct {
+		Version string `yaml:"version"`
+	} `yaml:"version"`
+	Licenses []string `yaml:"licenses"`
+}
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse parses the metadata of gem archives.
+// e.g. vendor/cache/rake-13.2.1.gem
+// For details see https://guides.rubygems.org/specification-reference/
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, xerrors.Errorf("%s not found", metadataFile)
+		} else if err != nil {
+			return nil, nil, xerrors.Errorf("tar read error: %w", err)
+		}
+
+		if hdr.Name != metadataFile {
+			continue
+		}
+
+		gr, err := gzip.NewReader(tr)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("gzip reader error: %w", err)
+		}
+		defer gr.Close()
+
+		var spec specification
+		if err = yaml.NewDecoder(gr).Decode(&spec); err != nil {
+			return nil, nil, xerrors.Errorf("yaml decode error: %w", err)
+		}
+
+		if spec.Name == "" || spec.Version.Version == "" {
+			return nil, nil, xerrors.New("name or version is empty")
+		}
+
+		return []ftypes.Package{
+			{
+				Name:     spec.Name,
+				Version:  spec.Version.Version,
+				Licenses: licensing.SplitLicenses(strings.Join(spec.Licenses, ", ")),
+			},
+		}, nil, nil
+	}
+}
@@ -0,0 +1,55 @@
+package gem_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/ruby/gem"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ftypes.Package
+		wantErr string
+	}{
+		{
+			name:  "rake",
+			input: "testdata/rake-13.2.1.gem",
+			want: []ftypes.Package{
+				{
+					Name:     "rake",
+					Version:  "13.2.1",
+					Licenses: []string{"MIT"},
+				},
+			},
+		},
+		{
+			name:    "no metadata",
+			input:   "testdata/no-metadata-0.1.0.gem",
+			wantErr: "metadata.gz not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.input)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, _, err := gem.NewParser().Parse(f)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package gemfile
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+var (
+	// e.g. gem "rails", "~> 7.1"
+	//      gem("rails")
+	gemRegexp = regexp.MustCompile(`^gem[\s(]`)
+
+	// e.g. group :development, :test do
+	groupRegexp = regexp.MustCompile(`^group[\s(]`)
+
+	// e.g. gemspec
+	//      gemspec path: "engines/admin"
+	gemspecRegexp = regexp.MustCompile(`^gemspec\b`)
+
+	// e.g. spec.add_dependency "rack", ">= 2.2"
+	//      s.add_development_dependency(%q<rspec>.freeze, ["~> 3.0"])
+	dependencyRegexp = regexp.MustCompile(`^\w+\.add_(runtime_|development_)?dependency\b`)
+
+	// Lines opening blocks closed by "end", e.g. `platforms :jruby do` and `if ENV["CI"]`
+	blockRegexp   = regexp.MustCompile(`\bdo(\s*\|[^|]*\|)?$`)
+	keywordRegexp = regexp.MustCompile(`^(if|unless|case|begin|while|until|def|class|module)\b`)
+	endRegexp     = regexp.MustCompile(`^end\b`)
+
+	// Capture string literals
+	// e.g. "rails", 'rails' and %q<rails>
+	stringRegexp = regexp.MustCompile(`"([^"]*)"|'([^']*)'|%q<([^>]*)>`)
+
+	// Capture options
+	// e.g. group: :test, groups: [:development, :test] and :require => false
+	optionRegexp = regexp.MustCompile(`(?:(\w+):|:(\w+)\s*=>)\s*(\[[^\]]*\]|"[^"]*"|'[^']*'|:\w+|[^,\s)]+)`)
+
+	// Capture symbols
+	// e.g. :development
+	symbolRegexp = regexp.MustCompile(`:(\w+)`)
+
+	// Statement modifiers, e.g. gem "pry" if ENV["DEBUG"]
+	modifierRegexp = regexp.MustCompile(`\s+(if|unless)\s.*$`)
+
+	// e.g. "7.1.3" or "= 7.1.3"
+	exactVersionRegexp = regexp.MustCompile(`^(?:=\s*)?(\d[0-9A-Za-z.\-]*)$`)
+
+	// Dependencies only in these groups are not installed with "bundle install --without development test"
+	devGroups = []string{
+		"development",
+		"test",
+	}
+)
+
+// Gemfile represents the dependencies declared in Gemfile.
+type Gemfile struct {
+	Packages []ftypes.Package
+	// GemspecDir is the directory of *.gemspec loaded with the "gemspec" directive, relative to Gemfile.
+	// It is empty if Gemfile doesn't have the directive.
+	GemspecDir string
+}
+
+// Parse parses Gemfile, which declares dependencies with version constraints.
+// The versions are not resolved without Gemfile.lock, so packages are reported with the constraints.
+// cf. https://bundler.io/guides/gemfile.html
+func Parse(r io.Reader) (Gemfile, error) {
+	var gemfile Gemfile
+	// Groups of nested blocks. Blocks other than "group" have no groups.
+	var blocks [][]string
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		switch {
+		case line == "":
+		case endRegexp.MatchString(line):
+			if len(blocks) > 0 {
+				blocks = blocks[:len(blocks)-1]
+			}
+		case groupRegexp.MatchString(line):
+			blocks = append(blocks, symbols(line))
+		case gemRegexp.MatchString(line):
+			name, reqs, options := parseArgs(strings.TrimPrefix(line, "gem"))
+			if name == "" {
+				continue
+			}
+			groups := lo.Flatten(blocks)
+			for key, value := range options {
+				if key == "group" || key == "groups" {
+					groups = append(groups, symbols(value)...)
+				}
+			}
+			gemfile.Packages = append(gemfile.Packages, newPackage(name, reqs, isDev(groups), lineNum))
+		case gemspecRegexp.MatchString(line):
+			_, _, options := parseArgs(strings.TrimPrefix(line, "gemspec"))
+			gemfile.GemspecDir = lo.CoalesceOrEmpty(unquote(options["path"]), ".")
+		case blockRegexp.MatchString(line), keywordRegexp.MatchString(line):
+			blocks = append(blocks, nil)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Gemfile{}, xerrors.Errorf("failed to parse Gemfile: %w", err)
+	}
+
+	gemfile.Packages = utils.UniquePackages(gemfile.Packages)
+	return gemfile, nil
+}
+
+// ParseGemspec parses the dependencies declared in *.gemspec of a gem project.
+// cf. https://guides.rubygems.org/specification-reference/#add_runtime_dependency
+func ParseGemspec(r io.Reader) ([]ftypes.Package, error) {
+	var pkgs []ftypes.Package
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		m := dependencyRegexp.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name, reqs, _ := parseArgs(strings.TrimPrefix(line, m[0]))
+		if name == "" {
+			continue
+		}
+		pkgs = append(pkgs, newPackage(name, reqs, m[1] == "development_", lineNum))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("failed to parse gemspec: %w", err)
+	}
+	return utils.UniquePackages(pkgs), nil
+}
+
+// parseArgs returns the gem name, the version requirements and the options
+// e.g. `"rails", "~> 7.1", require: false` => "rails", ["~> 7.1"], {"require": "false"}
+func parseArgs(args string) (string, []string, map[string]string) {
+	args = modifierRegexp.ReplaceAllString(args, "")
+
+	options := make(map[string]string)
+	for _, m := range optionRegexp.FindAllStringSubmatch(args, -1) {
+		options[lo.CoalesceOrEmpty(m[1], m[2])] = m[3]
+	}
+	args = optionRegexp.ReplaceAllString(args, "")
+
+	var values []string
+	for _, m := range stringRegexp.FindAllStringSubmatch(args, -1) {
+		values = append(values, strings.TrimSpace(m[1]+m[2]+m[3]))
+	}
+	if len(values) == 0 {
+		return "", nil, options
+	}
+	return values[0], values[1:], options
+}
+
+func newPackage(name string, reqs []string, dev bool, lineNum int) ftypes.Package {
+	pkg := ftypes.Package{
+		Name:         name,
+		Relationship: ftypes.RelationshipDirect,
+		Dev:          dev,
+		Locations: []ftypes.Location{
+			{
+				StartLine: lineNum,
+				EndLine:   lineNum,
+			},
+		},
+	}
+
+	// A single exact requirement is the version to be installed
+	if len(reqs) == 1 {
+		if m := exactVersionRegexp.FindStringSubmatch(reqs[0]); m != nil {
+			pkg.ID = dependency.ID(ftypes.Bundler, name, m[1])
+			pkg.Version = m[1]
+			return pkg
+		}
+	}
+
+	pkg.ID = dependency.ID(ftypes.Bundler, name, "")
+	pkg.Unresolved = true
+	pkg.VersionConstraint = strings.Join(reqs, ", ")
+	return pkg
+}
+
+// isDev returns true if the dependency belongs only to development and test groups.
+func isDev(groups []string) bool {
+	if len(groups) == 0 {
+		return false
+	}
+	return lo.EveryBy(groups, func(group string) bool {
+		return slices.Contains(devGroups, group)
+	})
+}
+
+func symbols(s string) []string {
+	return lo.Map(symbolRegexp.FindAllStringSubmatch(s, -1), func(m []string, _ int) string {
+		return m[1]
+	})
+}
+
+func unquote(s string) string {
+	if m := stringRegexp.FindStringSubmatch(s); m != nil {
+		return m[1] + m[2] + m[3]
+	}
+	return ""
+}
+
+// stripComment removes the comment outside string literals
+func stripComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
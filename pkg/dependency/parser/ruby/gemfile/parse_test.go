@@ -0,0 +1,78 @@
+package gemfile_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/ruby/gemfile"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParse(t *testing.T) {
+	f, err := os.Open("testdata/Gemfile")
+	require.NoError(t, err)
+	defer f.Close()
+
+	got, err := gemfile.Parse(f)
+	require.NoError(t, err)
+
+	want := gemfile.Gemfile{
+		Packages: []ftypes.Package{
+			newPackage("bootsnap", "", "", false, 9),
+			newPackage("capybara", "", "", true, 25),
+			newPackage("debug", "", ">= 1.0", false, 14),
+			newPackage("pg", "1.5.4", "", false, 10),
+			newPackage("pry", "", "", false, 27),
+			newPackage("puma", "", ">= 5.0, < 7", false, 8),
+			newPackage("rails", "", "~> 7.1.3", false, 7),
+			newPackage("redis", "5.0.8", "", false, 11),
+			newPackage("rspec-rails", "", "~> 6.1", true, 18),
+			newPackage("rubocop", "", "", false, 26),
+			newPackage("web-console", "", "", true, 22),
+		},
+		GemspecDir: "engine",
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestParseGemspec(t *testing.T) {
+	f, err := os.Open("testdata/library.gemspec")
+	require.NoError(t, err)
+	defer f.Close()
+
+	got, err := gemfile.ParseGemspec(f)
+	require.NoError(t, err)
+
+	want := []ftypes.Package{
+		newPackage("concurrent-ruby", "", "~> 1.2", false, 9),
+		newPackage("rack", "", ">= 2.2, < 4", false, 8),
+		newPackage("rake", "13.1.0", "", true, 11),
+		newPackage("zeitwerk", "", "", false, 10),
+	}
+	assert.Equal(t, want, got)
+}
+
+func newPackage(name, version, constraint string, dev bool, line int) ftypes.Package {
+	id := name
+	if version != "" {
+		id += "@" + version
+	}
+	return ftypes.Package{
+		ID:                id,
+		Name:              name,
+		Version:           version,
+		Relationship:      ftypes.RelationshipDirect,
+		Dev:               dev,
+		Unresolved:        version == "",
+		VersionConstraint: constraint,
+		Locations: []ftypes.Location{
+			{
+				StartLine: line,
+				EndLine:   line,
+			},
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package crate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+type cargoToml struct {
+	Package struct {
+		Name        string `toml:"name"`
+		Version     string `toml:"version"`
+		License     string `toml:"license"`
+		LicenseFile string `toml:"license-file"`
+	} `toml:"package"`
+}
+
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse parses the manifest of crate archives.
+// e.g. ~/.cargo/registry/cache/index.crates.io-6f17d22bba15001f/serde-1.0.210.crate
+// For details see https://doc.rust-lang.org/cargo/reference/registry-web-api.html#publish
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("gzip reader error: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, xerrors.New("Cargo.toml not found")
+		} else if err != nil {
+			return nil, nil, xerrors.Errorf("tar read error: %w", err)
+		}
+
+		// Files are stored in the "<name>-<version>" directory
+		if _, filePath, _ := strings.Cut(hdr.Name, "/"); filePath != ftypes.CargoToml {
+			continue
+		}
+
+		var manifest cargoToml
+		if _, err = toml.NewDecoder(tr).Decode(&manifest); err != nil {
+			return nil, nil, xerrors.Errorf("toml decode error: %w", err)
+		}
+		return toPackages(manifest)
+	}
+}
+
+func toPackages(manifest cargoToml) ([]ftypes.Package, []ftypes.Dependency, error) {
+	pkg := manifest.Package
+	if pkg.Name == "" || pkg.Version == "" {
+		return nil, nil, xerrors.New("name or version is empty")
+	}
+
+	// "license-file" is used for non-standard licenses instead of "license"
+	// cf. https://doc.rust-lang.org/cargo/reference/manifest.html#the-license-and-license-file-fields
+	// "/" is the deprecated separator for "OR", e.g. "MIT/Apache-2.0"
+	license := strings.ReplaceAll(pkg.License, "/", " OR ")
+	if license == "" && pkg.LicenseFile != "" {
+		license = licensing.LicenseFilePrefix + pkg.LicenseFile
+	}
+
+	return []ftypes.Package{
+		{
+			Name:     pkg.Name,
+			Version:  pkg.Version,
+			Licenses: licensing.SplitLicenses(license),
+		},
+	}, nil, nil
+}
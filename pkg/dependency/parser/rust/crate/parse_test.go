@@ -0,0 +1,57 @@
+package crate_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/rust/crate"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ftypes.Package
+		wantErr string
+	}{
+		{
+			name:  "cfg-if",
+			input: "testdata/cfg-if-1.0.0.crate",
+			want: []ftypes.Package{
+				{
+					Name:    "cfg-if",
+					Version: "1.0.0",
+					Licenses: []string{
+						"MIT OR Apache-2.0",
+					},
+				},
+			},
+		},
+		{
+			name:    "no manifest",
+			input:   "testdata/no-manifest-0.1.0.crate",
+			wantErr: "Cargo.toml not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.input)
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, _, err := crate.NewParser().Parse(f)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
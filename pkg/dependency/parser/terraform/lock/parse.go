@@ -0,0 +1,91 @@
+package lock
+
+import (
+	"io"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+const providerBlock = "provider"
+
+// Parser is a parser for .terraform.lock.hcl, the dependency lock file of Terraform.
+// cf. https://developer.hashicorp.com/terraform/language/files/dependency-lock
+type Parser struct {
+	logger *log.Logger
+}
+
+func NewParser() *Parser {
+	return &Parser{
+		logger: log.WithPrefix("terraform-lock"),
+	}
+}
+
+func (p *Parser) Parse(r xio.ReadSeekerAt) ([]ftypes.Package, []ftypes.Dependency, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	file, diags := hclsyntax.ParseConfig(b, ftypes.TerraformLock, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, xerrors.Errorf("hcl parse error: %w", diags)
+	}
+
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, nil, xerrors.New("unexpected body type")
+	}
+
+	var pkgs []ftypes.Package
+	for _, block := range body.Blocks {
+		// The lock file only has "provider" blocks for now.
+		// It includes providers required by child modules, so the relationship is unknown.
+		if block.Type != providerBlock || len(block.Labels) != 1 {
+			continue
+		}
+		// e.g. registry.terraform.io/hashicorp/aws
+		source := block.Labels[0]
+
+		version := p.stringAttr(block, "version")
+		if version == "" {
+			p.logger.Debug("Skipping the provider without version", log.String("provider", source))
+			continue
+		}
+
+		pkgs = append(pkgs, ftypes.Package{
+			ID:      dependency.ID(ftypes.TerraformProvider, source, version),
+			Name:    source,
+			Version: version,
+			Locations: []ftypes.Location{
+				{
+					StartLine: block.Range().Start.Line,
+					EndLine:   block.Range().End.Line,
+				},
+			},
+		})
+	}
+
+	return utils.UniquePackages(pkgs), nil, nil
+}
+
+func (p *Parser) stringAttr(block *hclsyntax.Block, name string) string {
+	attr, ok := block.Body.Attributes[name]
+	if !ok {
+		return ""
+	}
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.Type().Equals(cty.String) {
+		return ""
+	}
+	return strings.TrimSpace(val.AsString())
+}
@@ -0,0 +1,85 @@
+package lock
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      []ftypes.Package
+	}{
+		{
+			name:      "happy path",
+			inputFile: "testdata/happy.terraform.lock.hcl",
+			want: []ftypes.Package{
+				{
+					ID:      "registry.opentofu.org/integrations/github@5.42.0",
+					Name:    "registry.opentofu.org/integrations/github",
+					Version: "5.42.0",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 20,
+							EndLine:   23,
+						},
+					},
+				},
+				{
+					ID:      "registry.terraform.io/hashicorp/aws@4.67.0",
+					Name:    "registry.terraform.io/hashicorp/aws",
+					Version: "4.67.0",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 4,
+							EndLine:   11,
+						},
+					},
+				},
+				{
+					ID:      "registry.terraform.io/hashicorp/random@3.5.1",
+					Name:    "registry.terraform.io/hashicorp/random",
+					Version: "3.5.1",
+					Locations: []ftypes.Location{
+						{
+							StartLine: 13,
+							EndLine:   18,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "empty",
+			inputFile: "testdata/empty.terraform.lock.hcl",
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewParser()
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			pkgs, deps, err := parser.Parse(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, pkgs)
+			assert.Empty(t, deps)
+		})
+	}
+}
+
+func TestParser_Parse_InvalidHCL(t *testing.T) {
+	parser := NewParser()
+	_, _, err := parser.Parse(strings.NewReader(`provider "registry.terraform.io/hashicorp/aws" {`))
+	assert.ErrorContains(t, err, "hcl parse error")
+}
@@ -2,6 +2,7 @@ package library
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/samber/lo"
@@ -21,10 +22,17 @@ import (
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
+// publicProviderRegistries are the hosts of the public Terraform provider registries
+var publicProviderRegistries = []string{
+	"registry.terraform.io",
+	"registry.opentofu.org",
+}
+
 // NewDriver returns a driver according to the library type
 func NewDriver(libType ftypes.LangType) (Driver, bool) {
 	var ecosystem dbTypes.Ecosystem
 	var comparer compare.Comparer
+	var advisoryName func(string) string
 
 	switch libType {
 	case ftypes.Bundler, ftypes.GemSpec:
@@ -57,7 +65,7 @@ func NewDriver(libType ftypes.LangType) (Driver, bool) {
 	case ftypes.Hex:
 		ecosystem = vulnerability.Erlang
 		comparer = compare.GenericComparer{}
-	case ftypes.Conan:
+	case ftypes.Conan, ftypes.CBinary:
 		ecosystem = vulnerability.Conan
 		// Only semver can be used for version ranges
 		// https://docs.conan.io/en/latest/versioning/version_ranges.html
@@ -84,15 +92,21 @@ func NewDriver(libType ftypes.LangType) (Driver, bool) {
 	case ftypes.Julia:
 		log.Warn("Julia is supported for SBOM, not for vulnerability scanning")
 		return Driver{}, false
+	case ftypes.TerraformProvider:
+		// Terraform providers are Go modules, and their advisories are published for the modules.
+		ecosystem = vulnerability.Go
+		comparer = compare.GenericComparer{}
+		advisoryName = providerModule
 	default:
 		log.Warn("The library type is not supported for vulnerability scanning",
 			log.String("type", string(libType)))
 		return Driver{}, false
 	}
 	return Driver{
-		ecosystem: ecosystem,
-		comparer:  comparer,
-		dbc:       db.Config{},
+		ecosystem:    ecosystem,
+		comparer:     comparer,
+		advisoryName: advisoryName,
+		dbc:          db.Config{},
 	}, true
 }
 
@@ -101,6 +115,9 @@ type Driver struct {
 	ecosystem dbTypes.Ecosystem
 	comparer  compare.Comparer
 	dbc       db.Config
+
+	// advisoryName converts the package name to the name in advisories if they differ
+	advisoryName func(string) string
 }
 
 // Type returns the driver ecosystem
@@ -115,7 +132,11 @@ func (d *Driver) Type() string {
 func (d *Driver) DetectVulnerabilities(pkgID, pkgName, pkgVer string) ([]types.DetectedVulnerability, error) {
 	// e.g. "pip::", "npm::"
 	prefix := fmt.Sprintf("%s::", d.ecosystem)
-	advisories, err := d.dbc.GetAdvisories(prefix, vulnerability.NormalizePkgName(d.ecosystem, pkgName))
+	name := pkgName
+	if d.advisoryName != nil {
+		name = d.advisoryName(pkgName)
+	}
+	advisories, err := d.dbc.GetAdvisories(prefix, vulnerability.NormalizePkgName(d.ecosystem, name))
 	if err != nil {
 		return nil, xerrors.Errorf("failed to get %s advisories: %w", d.ecosystem, err)
 	}
@@ -141,6 +162,17 @@ func (d *Driver) DetectVulnerabilities(pkgID, pkgName, pkgVer string) ([]types.D
 	return vulns, nil
 }
 
+// providerModule returns the Go module of the Terraform provider in the public registries.
+// Providers published there must be hosted on GitHub with the "terraform-provider-<TYPE>" repository name.
+// e.g. "registry.terraform.io/hashicorp/aws" => "github.com/hashicorp/terraform-provider-aws"
+func providerModule(source string) string {
+	ss := strings.Split(source, "/")
+	if len(ss) != 3 || !slices.Contains(publicProviderRegistries, ss[0]) {
+		return source
+	}
+	return fmt.Sprintf("github.com/%s/terraform-provider-%s", ss[1], ss[2])
+}
+
 func createFixedVersions(advisory dbTypes.Advisory) string {
 	if len(advisory.PatchedVersions) != 0 {
 		return joinFixedVersions(advisory.PatchedVersions)
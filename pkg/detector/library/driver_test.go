@@ -53,6 +53,44 @@ func TestDriver_Detect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "terraform provider",
+			fixtures: []string{
+				"testdata/fixtures/go.yaml",
+				"testdata/fixtures/data-source.yaml",
+			},
+			libType: ftypes.TerraformProvider,
+			args: args{
+				pkgName: "registry.terraform.io/hashicorp/aws",
+				pkgVer:  "1.13.0",
+			},
+			want: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2018-9057",
+					PkgName:          "registry.terraform.io/hashicorp/aws",
+					InstalledVersion: "1.13.0",
+					FixedVersion:     "v1.14.0",
+					DataSource: &dbTypes.DataSource{
+						ID:   vulnerability.GLAD,
+						Name: "GitLab Advisory Database Community",
+						URL:  "https://gitlab.com/gitlab-org/advisories-community",
+					},
+				},
+			},
+		},
+		{
+			name: "terraform provider in a private registry",
+			fixtures: []string{
+				"testdata/fixtures/go.yaml",
+				"testdata/fixtures/data-source.yaml",
+			},
+			libType: ftypes.TerraformProvider,
+			args: args{
+				pkgName: "registry.example.com/hashicorp/aws",
+				pkgVer:  "1.13.0",
+			},
+			want: nil,
+		},
 		{
 			name: "case-sensitive go package",
 			fixtures: []string{
@@ -4,11 +4,13 @@ import (
 	"cmp"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"maps"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -20,6 +22,9 @@ import (
 
 var ErrSkipDownload = errors.New("skip download")
 
+// archiveMetadataFile holds the URL and the ETag of the tarball downloaded with DownloadArchive
+const archiveMetadataFile = "download.json"
+
 type Options struct {
 	Insecure   bool
 	Auth       Auth
@@ -102,6 +107,85 @@ func Download(ctx context.Context, src, dst, pwd string, opts Options) (string,
 	return transport.newETag, nil
 }
 
+// DownloadArchive downloads the gzipped tarball from the URL and extracts it into the destination.
+// The tarball is extracted into a temp dir next to the destination first,
+// and the destination is replaced only if verify succeeds for the extracted files.
+// ErrSkipDownload is returned without touching the destination if the tarball is not modified since opts.ETag.
+func DownloadArchive(ctx context.Context, src, dst string, opts Options, verify func(dir string) error) (string, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", xerrors.Errorf("url parse error: %w", err)
+	}
+	// go-getter detects the archive format from the extension, which URLs may not have
+	q := u.Query()
+	if q.Get("archive") == "" {
+		q.Set("archive", "tar.gz")
+		u.RawQuery = q.Encode()
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return "", xerrors.Errorf("failed to mkdir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dst), "download-*")
+	if err != nil {
+		return "", xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	extracted := filepath.Join(tmpDir, "content")
+	etag, err := Download(ctx, u.String(), extracted, tmpDir, opts)
+	if err != nil {
+		return "", err
+	}
+	if err = verify(extracted); err != nil {
+		return "", xerrors.Errorf("verification error: %w", err)
+	}
+
+	if err = os.RemoveAll(dst); err != nil {
+		return "", xerrors.Errorf("unable to remove %s: %w", dst, err)
+	}
+	if err = os.Rename(extracted, dst); err != nil {
+		return "", xerrors.Errorf("unable to rename %s: %w", extracted, err)
+	}
+	return etag, nil
+}
+
+// ArchiveMetadata is stored next to the files downloaded with DownloadArchive
+// so that they are downloaded again only if they are modified on the server.
+type ArchiveMetadata struct {
+	URL  string `json:",omitempty"`
+	ETag string `json:",omitempty"`
+}
+
+// ArchiveMetadataPath returns the path to the metadata of the files downloaded into the directory
+func ArchiveMetadataPath(dir string) string {
+	return filepath.Join(dir, archiveMetadataFile)
+}
+
+// ReadArchiveMetadata returns the metadata of the files downloaded into the directory,
+// or the empty metadata if it doesn't exist.
+func ReadArchiveMetadata(dir string) ArchiveMetadata {
+	var meta ArchiveMetadata
+	b, err := os.ReadFile(ArchiveMetadataPath(dir))
+	if err != nil {
+		return meta
+	}
+	_ = json.Unmarshal(b, &meta)
+	return meta
+}
+
+// WriteArchiveMetadata writes the metadata of the files downloaded into the directory
+func WriteArchiveMetadata(dir string, meta ArchiveMetadata) error {
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return xerrors.Errorf("json marshal error: %w", err)
+	}
+	if err = os.WriteFile(ArchiveMetadataPath(dir), b, 0o644); err != nil {
+		return xerrors.Errorf("write error: %w", err)
+	}
+	return nil
+}
+
 type CustomTransport struct {
 	auth       Auth
 	cachedETag string
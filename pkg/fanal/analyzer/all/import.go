@@ -5,11 +5,16 @@ import (
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/all"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/executable"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/imgconf/apk"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/imgconf/buildpacks"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/imgconf/dockerfile"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/imgconf/labels"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/imgconf/secret"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/installscript"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/c/binary"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/c/conan"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/conda/environment"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/conda/meta"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/conda/pack"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/dart/pub"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/dotnet/deps"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/dotnet/nuget"
@@ -33,12 +38,18 @@ import (
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/python/poetry"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/python/uv"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/ruby/bundler"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/ruby/gem"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/ruby/gemfile"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/ruby/gemspec"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/rust/binary"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/rust/cargo"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/rust/crate"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/swift/cocoapods"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/swift/swift"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/terraform/lock"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/licensing"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/ml/huggingface"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/ml/pickle"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/os/alpine"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/os/amazonlinux"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/os/debian"
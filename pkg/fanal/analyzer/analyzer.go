@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"io/fs"
@@ -52,12 +53,29 @@ type AnalyzerOptions struct {
 
 type SecretScannerOption struct {
 	ConfigPath string
+
+	// Verify whether the detected secrets are active
+	Validate bool
+
+	// Set fingerprints to findings for comparison with a baseline
+	Fingerprint bool
+
+	// How matched secrets appear in findings, e.g. "partial". Secrets are fully redacted if empty.
+	Redaction string
+
+	// Depth of nested archives to scan. Archives are not scanned if 0.
+	ArchiveDepth int
+	// Maximum size of an archive and the total size of files extracted from it
+	ArchiveMaxSize int64
 }
 
 type LicenseScannerOption struct {
 	// Use license classifier to get better results though the classification is expensive.
 	Full                      bool
 	ClassifierConfidenceLevel float64
+
+	// Sources to resolve licenses of modules embedded in Go binaries, e.g. "cache" and "proxy"
+	GoBinarySources []string
 }
 
 ////////////////
@@ -126,6 +144,9 @@ type AnalyzerGroup struct {
 	postAnalyzers     []PostAnalyzer
 	filePatterns      map[Type][]*regexp.Regexp
 	detectionPriority types.DetectionPriority
+
+	// heavyLimit limits the number of heavy analyzers running at the same time across layers
+	heavyLimit *semaphore.Weighted
 }
 
 ///////////////////////////
@@ -318,6 +339,19 @@ func belongToGroup(groupName Group, analyzerType Type, disabledAnalyzers []Type,
 
 const separator = ":"
 
+// priority returns the scheduling order of the analyzer.
+// OS analyzers are cheap and detect most of the vulnerabilities, so they are run first.
+func priority(t Type) int {
+	switch {
+	case slices.Contains(TypeOSes, t):
+		return 0
+	case slices.Contains(TypeHeavy, t):
+		return 2
+	default:
+		return 1
+	}
+}
+
 func NewAnalyzerGroup(opts AnalyzerOptions) (AnalyzerGroup, error) {
 	groupName := opts.Group
 	if groupName == "" {
@@ -328,6 +362,8 @@ func NewAnalyzerGroup(opts AnalyzerOptions) (AnalyzerGroup, error) {
 		logger:            log.WithPrefix("analyzer"),
 		filePatterns:      make(map[Type][]*regexp.Regexp),
 		detectionPriority: opts.DetectionPriority,
+		// Heavy analyzers can take up to half of the parallelism so that they don't hold up cheap analyzers.
+		heavyLimit: semaphore.NewWeighted(int64(max(opts.Parallel/2, 1))),
 	}
 	for _, p := range opts.FilePatterns {
 		// e.g. "dockerfile:my_dockerfile_*"
@@ -362,6 +398,14 @@ func NewAnalyzerGroup(opts AnalyzerOptions) (AnalyzerGroup, error) {
 		group.analyzers = append(group.analyzers, a)
 	}
 
+	// Schedule OS analyzers first and heavy analyzers last for each file
+	slices.SortFunc(group.analyzers, func(a, b analyzer) int {
+		return cmp.Or(
+			cmp.Compare(priority(a.Type()), priority(b.Type())),
+			cmp.Compare(a.Type(), b.Type()),
+		)
+	})
+
 	for analyzerType, init := range postAnalyzers {
 		a, err := init(opts)
 		if err != nil {
@@ -418,20 +462,37 @@ func (ag AnalyzerGroup) AnalyzeFile(ctx context.Context, wg *sync.WaitGroup, lim
 		if !ag.filePatternMatch(a.Type(), cleanPath) && !a.Required(cleanPath, info) {
 			continue
 		}
+
+		heavy := slices.Contains(TypeHeavy, a.Type())
+		if heavy {
+			if err := ag.heavyLimit.Acquire(ctx, 1); err != nil {
+				return xerrors.Errorf("semaphore acquire: %w", err)
+			}
+		}
+		release := func() {
+			if heavy {
+				ag.heavyLimit.Release(1)
+			}
+		}
+
 		rc, err := opener()
 		if errors.Is(err, fs.ErrPermission) {
+			release()
 			ag.logger.Debug("Permission error", log.FilePath(filePath))
 			break
 		} else if err != nil {
+			release()
 			return xerrors.Errorf("unable to open %s: %w", filePath, err)
 		}
 
 		if err = limit.Acquire(ctx, 1); err != nil {
+			release()
 			return xerrors.Errorf("semaphore acquire: %w", err)
 		}
 		wg.Add(1)
 
 		go func(a analyzer, rc xio.ReadSeekCloserAt) {
+			defer release()
 			defer limit.Release(1)
 			defer wg.Done()
 			defer rc.Close()
@@ -625,7 +625,7 @@ func TestAnalyzerGroup_PostAnalyze(t *testing.T) {
 				// init java-trivy-db with skip update
 				repo, err := name.NewTag(javadb.DefaultGHCRRepository)
 				require.NoError(t, err)
-				javadb.Init("./language/java/jar/testdata", []name.Reference{repo}, true, false, types.RegistryOptions{Insecure: false})
+				javadb.Init("./language/java/jar/testdata", []name.Reference{repo}, "", true, false, types.RegistryOptions{Insecure: false})
 			}
 
 			ctx := context.Background()
@@ -656,7 +656,7 @@ func TestAnalyzerGroup_AnalyzerVersions(t *testing.T) {
 					"ubuntu-esm": 1,
 				},
 				PostAnalyzers: map[string]int{
-					"jar":    1,
+					"jar":    2,
 					"poetry": 1,
 				},
 			},
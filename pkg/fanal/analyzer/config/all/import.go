@@ -1,12 +1,20 @@
 package all
 
 import (
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/ansible"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/azurearm"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/azurepipelines"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/bicep"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/cloudformation"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/cue"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/dockerfile"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/githubactions"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/gitlabci"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/helm"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/json"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/jsonnet"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/k8s"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/pulumi"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/terraform"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/terraformplan/json"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/config/terraformplan/snapshot"
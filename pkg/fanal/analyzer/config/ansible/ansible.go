@@ -0,0 +1,41 @@
+package ansible
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/ansible/parser"
+)
+
+const (
+	version      = 1
+	analyzerType = analyzer.TypeAnsible
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newAnsibleConfigAnalyzer)
+}
+
+// ansibleConfigAnalyzer is an analyzer for detecting misconfigurations in Ansible playbooks, roles and inventories.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type ansibleConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newAnsibleConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeAnsible, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ansibleConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is YAML or an inventory.
+// Playbooks are recognized by their content when scanning.
+func (*ansibleConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	ext := filepath.Ext(filePath)
+	return ext == ".yml" || ext == ".yaml" || parser.IsVarsFile(filePath) || parser.IsInventoryFile(filePath)
+}
@@ -0,0 +1,56 @@
+package ansible
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+)
+
+func Test_ansibleConfigAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "playbook",
+			filePath: "site.yml",
+			want:     true,
+		},
+		{
+			name:     "role",
+			filePath: "roles/nginx/tasks/main.yaml",
+			want:     true,
+		},
+		{
+			name:     "inventory",
+			filePath: "inventories/production/hosts",
+			want:     true,
+		},
+		{
+			name:     "group variables",
+			filePath: "group_vars/all",
+			want:     true,
+		},
+		{
+			name:     "json",
+			filePath: "test.json",
+			want:     false,
+		},
+		{
+			name:     "hosts file",
+			filePath: "etc/hosts",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newAnsibleConfigAnalyzer(analyzer.AnalyzerOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package azurepipelines
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeAzurePipelines
+	version      = 1
+)
+
+// builtinChecks detect insecure patterns in pipelines, such as unpinned tasks and privileged containers.
+//
+//go:embed checks/*.rego
+var builtinChecks embed.FS
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newAzurePipelinesConfigAnalyzer)
+}
+
+// azurePipelinesConfigAnalyzer is an analyzer for detecting misconfigurations in Azure Pipelines.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type azurePipelinesConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newAzurePipelinesConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	checks, err := fs.Sub(builtinChecks, "checks")
+	if err != nil {
+		return nil, xerrors.Errorf("builtin checks error: %w", err)
+	}
+	opts.MisconfScannerOption.BuiltinChecks = checks
+
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeAzurePipelines, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &azurePipelinesConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is YAML.
+// Pipelines and templates are recognized by their content when scanning.
+func (*azurePipelinesConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	ext := filepath.Ext(filePath)
+	return ext == ".yml" || ext == ".yaml"
+}
@@ -0,0 +1,49 @@
+# METADATA
+# title: System.AccessToken should not be exposed to scripts
+# description: The System.AccessToken grants the permissions of the build service to the Azure DevOps organization. Macros expanding the token into scripts write it to the script files and the command lines of the agent, and checkouts persisting their credentials leave the token in the Git configuration for all the later steps.
+# scope: package
+# related_resources:
+# - https://learn.microsoft.com/en-us/azure/devops/pipelines/build/variables#systemaccesstoken
+# - https://learn.microsoft.com/en-us/azure/devops/pipelines/yaml-schema/steps-checkout
+# custom:
+#   id: AZP002
+#   avd_id: AVD-AZP-0002
+#   severity: HIGH
+#   short_code: no-access-token-exposure
+#   recommended_action: Pass the token to the steps which need it with environment variables, e.g. "SYSTEM_ACCESSTOKEN", and do not persist the credentials of checkouts.
+#   input:
+#     selector:
+#     - type: azure-pipelines
+package builtin.azure.pipelines.AZP002
+
+import rego.v1
+
+jobs contains job if {
+	some stage in input.stages
+	some job in stage.jobs
+}
+
+token_pattern := `(?i)\$\(\s*System\.AccessToken\s*\)`
+
+# scripts are the inline scripts of script steps and the string inputs of tasks, e.g. the inline scripts of Bash@3
+scripts(step) := array.concat([step.script], [v | some v in step.inputs; is_string(v)])
+
+step_name(step) := step.displayName if {
+	step.displayName != ""
+} else := step.type
+
+deny contains res if {
+	some job in jobs
+	some step in job.steps
+	some script in scripts(step)
+	regex.match(token_pattern, script)
+	res := result.new(sprintf("Step %q of job %q expands System.AccessToken into a script", [step_name(step), job.job]), step)
+}
+
+deny contains res if {
+	some job in jobs
+	some step in job.steps
+	step.type == "checkout"
+	step.persistCredentials == true
+	res := result.new(sprintf("Checkout of %q in job %q persists System.AccessToken for the later steps", [step.checkout, job.job]), step)
+}
@@ -0,0 +1,41 @@
+# METADATA
+# title: Container jobs should not run privileged containers
+# description: Containers with the privileged flag, added capabilities, the namespaces of the host, disabled security profiles or the Docker socket of the host have full access to the agent. Code built by the job can then escape its container, tamper with the agent and steal the secrets of the other jobs of the agent.
+# scope: package
+# related_resources:
+# - https://learn.microsoft.com/en-us/azure/devops/pipelines/process/container-phases
+# custom:
+#   id: AZP003
+#   avd_id: AVD-AZP-0003
+#   severity: HIGH
+#   short_code: no-privileged-containers
+#   recommended_action: Remove the privileged options of the containers, and build images without a Docker daemon or on dedicated agents.
+#   input:
+#     selector:
+#     - type: azure-pipelines
+package builtin.azure.pipelines.AZP003
+
+import rego.v1
+
+jobs contains job if {
+	some stage in input.stages
+	some job in stage.jobs
+}
+
+privileged_patterns := [
+	`--privileged`,
+	`--cap-add`,
+	`--(pid|ipc|uts|userns|net|network)[= ]host`,
+	`(seccomp|apparmor)[=:]unconfined`,
+	`/var/run/docker\.sock`,
+]
+
+containers(job) := array.concat([c | c := job.container; c != null], job.services)
+
+deny contains res if {
+	some job in jobs
+	some container in containers(job)
+	some pattern in privileged_patterns
+	regex.match(pattern, container.options)
+	res := result.new(sprintf("Container %q of job %q runs with privileged options %q", [container.image, job.job, container.options]), container)
+}
@@ -0,0 +1,42 @@
+# METADATA
+# title: Pull requests should not be built on self-hosted agents
+# description: Pull requests run the code of their authors on the agents of the pipeline. Self-hosted agents are reused by the following jobs, so code of untrusted pull requests can persist on the agents, tamper with the builds of protected branches and steal their secrets. Microsoft-hosted agents are discarded after each job.
+# scope: package
+# related_resources:
+# - https://learn.microsoft.com/en-us/azure/devops/pipelines/security/misc#dont-provide-secrets-to-fork-builds
+# - https://learn.microsoft.com/en-us/azure/devops/pipelines/agents/agents#self-hosted-agents
+# custom:
+#   id: AZP004
+#   avd_id: AVD-AZP-0004
+#   severity: HIGH
+#   short_code: no-self-hosted-pull-requests
+#   recommended_action: Build pull requests on Microsoft-hosted agents, or on self-hosted agents dedicated to pull requests and reset after each job.
+#   input:
+#     selector:
+#     - type: azure-pipelines
+package builtin.azure.pipelines.AZP004
+
+import rego.v1
+
+jobs contains job if {
+	some stage in input.stages
+	some job in stage.jobs
+}
+
+pull_request_triggered if {
+	input.pr != null
+	input.pr != "none"
+}
+
+self_hosted(pool) if {
+	pool.name != ""
+	pool.vmImage == ""
+	pool.name != "Azure Pipelines"
+}
+
+deny contains res if {
+	pull_request_triggered
+	some job in jobs
+	self_hosted(job.pool)
+	res := result.new(sprintf("Job %q builds pull requests on the self-hosted pool %q", [job.job, job.pool.name]), job.pool)
+}
@@ -0,0 +1,45 @@
+# METADATA
+# title: Tasks should be pinned to versions
+# description: Tasks referenced by their major version are updated when their publishers release new minor versions. Tasks of Marketplace extensions, or of extensions whose publishers are compromised, can then run arbitrary code with the secrets and the service connections of the pipeline. Built-in tasks, whose names have no publisher prefix, are only required to set their major version.
+# scope: package
+# related_resources:
+# - https://learn.microsoft.com/en-us/azure/devops/pipelines/process/tasks#task-versions
+# custom:
+#   id: AZP001
+#   avd_id: AVD-AZP-0001
+#   severity: MEDIUM
+#   short_code: pin-tasks
+#   recommended_action: Pin the tasks of Marketplace extensions to full versions, e.g. "publisher.extension.task@1.2.3", and set the major version of built-in tasks.
+#   input:
+#     selector:
+#     - type: azure-pipelines
+package builtin.azure.pipelines.AZP001
+
+import rego.v1
+
+jobs contains job if {
+	some stage in input.stages
+	some job in stage.jobs
+}
+
+unpinned(step) if {
+	step.type == "task"
+	step.version == ""
+}
+
+unpinned(step) if {
+	step.type == "task"
+	contains(step.task, ".")
+	not regex.match(`^\d+\.\d+\.\d+$`, step.version)
+}
+
+task_reference(step) := step.task if {
+	step.version == ""
+} else := concat("@", [step.task, step.version])
+
+deny contains res if {
+	some job in jobs
+	some step in job.steps
+	unpinned(step)
+	res := result.new(sprintf("Task %q used in job %q is not pinned to a version", [task_reference(step), job.job]), step)
+}
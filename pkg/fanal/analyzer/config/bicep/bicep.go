@@ -0,0 +1,37 @@
+package bicep
+
+import (
+	"os"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeBicep
+	version      = 1
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newBicepConfigAnalyzer)
+}
+
+// bicepConfigAnalyzer is an analyzer for detecting misconfigurations in Bicep files and Bicep parameters files.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type bicepConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newBicepConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeBicep, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &bicepConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is a Bicep file.
+func (*bicepConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return detection.IsBicepFile(filePath)
+}
@@ -1,9 +1,12 @@
 package cloudformation
 
 import (
+	"os"
+
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
 	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	cfparser "github.com/aquasecurity/trivy/pkg/iac/scanners/cloudformation/parser"
 )
 
 const (
@@ -28,3 +31,9 @@ func newCloudFormationConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.Po
 	}
 	return &cloudFormationConfigAnalyzer{Analyzer: a}, nil
 }
+
+// Required overrides config.Analyzer.Required() and also checks if the given file is the configuration of a module project,
+// as modules are resolved when scanning.
+func (a *cloudFormationConfigAnalyzer) Required(filePath string, fi os.FileInfo) bool {
+	return a.Analyzer.Required(filePath, fi) || cfparser.IsModuleConfig(filePath)
+}
@@ -0,0 +1,36 @@
+package cue
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeCUE
+	version      = 1
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newCUEConfigAnalyzer)
+}
+
+// cueConfigAnalyzer analyzes CUE files
+type cueConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newCUEConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeCUE, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &cueConfigAnalyzer{Analyzer: a}, nil
+}
+
+func (*cueConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filepath.Ext(filePath) == ".cue"
+}
@@ -0,0 +1,36 @@
+# METADATA
+# title: The permissions of the GITHUB_TOKEN should be restricted
+# description: Without the permissions key, the GITHUB_TOKEN gets the default permissions of the repository, which can include write access to all the scopes. A compromised step can use such a token to push code, create releases or approve pull requests.
+# scope: package
+# related_resources:
+# - https://docs.github.com/en/actions/security-for-github-actions/security-guides/automatic-token-authentication#modifying-the-permissions-for-the-github_token
+# custom:
+#   id: GHA003
+#   avd_id: AVD-GHA-0003
+#   severity: MEDIUM
+#   short_code: restrict-token-permissions
+#   recommended_action: Set the permissions key at the workflow or job level and grant only the scopes needed by the jobs.
+#   input:
+#     selector:
+#     - type: github-actions
+package builtin.github.actions.GHA003
+
+import rego.v1
+
+deny contains res if {
+	input.permissions.all == "write-all"
+	res := result.new("Workflow grants write permissions to all the scopes of the GITHUB_TOKEN", input.permissions)
+}
+
+deny contains res if {
+	some job in input.jobs
+	job.permissions.all == "write-all"
+	res := result.new(sprintf("Job %q grants write permissions to all the scopes of the GITHUB_TOKEN", [job.id]), job.permissions)
+}
+
+deny contains res if {
+	input.permissions == null
+	some job in input.jobs
+	job.permissions == null
+	res := result.new(sprintf("Job %q does not restrict the permissions of the GITHUB_TOKEN", [job.id]), job)
+}
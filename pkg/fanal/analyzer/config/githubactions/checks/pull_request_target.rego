@@ -0,0 +1,46 @@
+# METADATA
+# title: Workflows triggered by pull_request_target should not check out the code of pull requests
+# description: Workflows triggered by pull_request_target run in the context of the base repository with access to its secrets and a GITHUB_TOKEN with write permissions. Checking out and building the code of a pull request from a fork lets anyone run arbitrary code with these privileges.
+# scope: package
+# related_resources:
+# - https://securitylab.github.com/resources/github-actions-preventing-pwn-requests/
+# custom:
+#   id: GHA002
+#   avd_id: AVD-GHA-0002
+#   severity: CRITICAL
+#   short_code: no-pull-request-target-checkout
+#   recommended_action: Use the pull_request event to build the code of pull requests, and pass the results to privileged workflows triggered by workflow_run as artifacts.
+#   input:
+#     selector:
+#     - type: github-actions
+package builtin.github.actions.GHA002
+
+import rego.v1
+
+# untrusted_refs are the expressions referring to the code of pull requests
+untrusted_refs := [
+	"github.event.pull_request.head.sha",
+	"github.event.pull_request.head.ref",
+	"github.event.pull_request.merge_commit_sha",
+	"github.event.pull_request.head.repo.full_name",
+	"github.head_ref",
+	"refs/pull/",
+]
+
+checkout(step) if startswith(lower(step.uses), "actions/checkout@")
+
+untrusted(value) if {
+	is_string(value)
+	some ref in untrusted_refs
+	contains(value, ref)
+}
+
+deny contains res if {
+	"pull_request_target" in object.keys(input.on)
+	some job in input.jobs
+	some step in job.steps
+	checkout(step)
+	some param in ["ref", "repository"]
+	untrusted(step["with"][param])
+	res := result.new(sprintf("Job %q checks out the code of the pull request in a workflow triggered by pull_request_target", [job.id]), step)
+}
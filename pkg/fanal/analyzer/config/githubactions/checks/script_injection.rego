@@ -0,0 +1,50 @@
+# METADATA
+# title: Untrusted inputs should not be interpolated into scripts
+# description: Expressions are expanded before scripts are run, so inputs controlled by other users, such as the titles of issues or the branch names of pull requests, can inject commands into inline scripts and run them with the secrets of the workflow.
+# scope: package
+# related_resources:
+# - https://docs.github.com/en/actions/security-for-github-actions/security-guides/security-hardening-for-github-actions#understanding-the-risk-of-script-injections
+# custom:
+#   id: GHA004
+#   avd_id: AVD-GHA-0004
+#   severity: HIGH
+#   short_code: no-script-injection
+#   recommended_action: Pass the untrusted inputs to scripts via environment variables set in the env key, and quote the variables in the scripts.
+#   input:
+#     selector:
+#     - type: github-actions
+package builtin.github.actions.GHA004
+
+import rego.v1
+
+# untrusted_inputs are the contexts which can be set by users without write access to the repository
+untrusted_inputs := [
+	`github\.event\.issue\.(title|body)`,
+	`github\.event\.pull_request\.(title|body)`,
+	`github\.event\.pull_request\.head\.(ref|label|repo\.default_branch)`,
+	`github\.event\.(comment|review|review_comment)\.body`,
+	`github\.event\.discussion\.(title|body)`,
+	`github\.event\.pages\.[^}]*\.page_name`,
+	`github\.event\.(commits\.[^}]*|head_commit)\.(message|author\.(email|name))`,
+	`github\.event\.workflow_run\.(head_branch|head_commit\.(message|author\.(email|name)))`,
+	`github\.head_ref`,
+]
+
+injected_inputs(script) := {expr |
+	is_string(script)
+	some expr in regex.find_n(`\$\{\{[^}]*\}\}`, script, -1)
+	some pattern in untrusted_inputs
+	regex.match(pattern, expr)
+}
+
+scripts(step) := [step.run] if step.run != ""
+
+scripts(step) := [step["with"].script] if startswith(lower(step.uses), "actions/github-script@")
+
+deny contains res if {
+	some job in input.jobs
+	some step in job.steps
+	some script in scripts(step)
+	some expr in injected_inputs(script)
+	res := result.new(sprintf("Untrusted input %s is interpolated into a script in job %q", [expr, job.id]), step)
+}
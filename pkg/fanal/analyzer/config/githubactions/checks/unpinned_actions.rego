@@ -0,0 +1,59 @@
+# METADATA
+# title: Third-party actions should be pinned to a full-length commit SHA
+# description: Tags and branches of actions can be moved by their owners, or by attackers who compromised the repositories of the actions, to run arbitrary code with the secrets of the workflow. Pinning a full-length commit SHA is the only way to use an action as an immutable release. Actions owned by GitHub and local actions are not checked.
+# scope: package
+# related_resources:
+# - https://docs.github.com/en/actions/security-for-github-actions/security-guides/security-hardening-for-github-actions#using-third-party-actions
+# custom:
+#   id: GHA001
+#   avd_id: AVD-GHA-0001
+#   severity: MEDIUM
+#   short_code: pin-third-party-actions
+#   recommended_action: Pin the actions and the reusable workflows to full-length commit SHAs, and the Docker images to digests.
+#   input:
+#     selector:
+#     - type: github-actions
+package builtin.github.actions.GHA001
+
+import rego.v1
+
+first_party_owners := {"actions", "github"}
+
+third_party(uses) if {
+	uses != ""
+	not startswith(uses, "./")
+	not startswith(uses, "docker://")
+	owner := split(uses, "/")[0]
+	not lower(owner) in first_party_owners
+}
+
+unpinned(uses) if {
+	third_party(uses)
+	parts := split(uses, "@")
+	count(parts) != 2
+}
+
+unpinned(uses) if {
+	third_party(uses)
+	parts := split(uses, "@")
+	count(parts) == 2
+	not regex.match(`^[0-9a-f]{40}$`, parts[1])
+}
+
+unpinned(uses) if {
+	startswith(uses, "docker://")
+	not contains(uses, "@sha256:")
+}
+
+deny contains res if {
+	some job in input.jobs
+	some step in job.steps
+	unpinned(step.uses)
+	res := result.new(sprintf("Action %q used in job %q is not pinned to an immutable reference", [step.uses, job.id]), step)
+}
+
+deny contains res if {
+	some job in input.jobs
+	unpinned(job.uses)
+	res := result.new(sprintf("Reusable workflow %q called by job %q is not pinned to a commit SHA", [job.uses, job.id]), job)
+}
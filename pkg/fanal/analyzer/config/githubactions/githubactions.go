@@ -0,0 +1,55 @@
+package githubactions
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeGitHubActions
+	version      = 1
+)
+
+// builtinChecks detect insecure patterns in workflows, such as unpinned actions and script injection.
+//
+//go:embed checks/*.rego
+var builtinChecks embed.FS
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newGitHubActionsConfigAnalyzer)
+}
+
+// githubActionsConfigAnalyzer is an analyzer for detecting misconfigurations in GitHub Actions workflows.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type githubActionsConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newGitHubActionsConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	checks, err := fs.Sub(builtinChecks, "checks")
+	if err != nil {
+		return nil, xerrors.Errorf("builtin checks error: %w", err)
+	}
+	opts.MisconfScannerOption.BuiltinChecks = checks
+
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeGitHubActions, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &githubActionsConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is YAML.
+// Workflows are recognized by their content when scanning.
+func (*githubActionsConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	ext := filepath.Ext(filePath)
+	return ext == ".yml" || ext == ".yaml"
+}
@@ -0,0 +1,149 @@
+package githubactions
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+)
+
+func TestConfigAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "yml",
+			filePath: ".github/workflows/ci.yml",
+			want:     true,
+		},
+		{
+			name:     "yaml",
+			filePath: ".github/workflows/release.yaml",
+			want:     true,
+		},
+		{
+			name:     "json",
+			filePath: "package.json",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := githubActionsConfigAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
+
+func TestConfigAnalyzer_PostAnalyze(t *testing.T) {
+	a, err := newGitHubActionsConfigAnalyzer(analyzer.AnalyzerOptions{})
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{
+		".github/workflows/triage.yml": &fstest.MapFile{Data: []byte(`name: Triage
+on:
+  pull_request_target:
+    types: [opened]
+permissions: write-all
+jobs:
+  label:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - uses: some-org/labeler@v1
+      - uses: some-org/other@8f4b7f84864484a7bf31766abe9204da3cbe65b3
+      - name: Greet
+        run: echo "${{ github.event.pull_request.title }}"
+`)},
+		".github/workflows/ci.yml": &fstest.MapFile{Data: []byte(`on: [push]
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make test
+  lint:
+    runs-on: ubuntu-latest
+    permissions:
+      contents: read
+    steps:
+      - uses: actions/github-script@v7
+        with:
+          script: console.log("${{ github.sha }}")
+      # trivy:ignore:GHA001
+      - uses: some-org/lint@v2
+`)},
+		"docker-compose.yml": &fstest.MapFile{Data: []byte(`services:
+  app:
+    image: app
+`)},
+	}
+	got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{FS: fsys})
+	require.NoError(t, err)
+
+	type failure struct {
+		FilePath  string
+		ID        string
+		Message   string
+		StartLine int
+		EndLine   int
+	}
+	var failures []failure
+	for _, m := range got.Misconfigurations {
+		for _, f := range m.Failures {
+			failures = append(failures, failure{
+				FilePath:  m.FilePath,
+				ID:        f.ID,
+				Message:   f.Message,
+				StartLine: f.CauseMetadata.StartLine,
+				EndLine:   f.CauseMetadata.EndLine,
+			})
+		}
+	}
+	want := []failure{
+		{
+			FilePath:  ".github/workflows/triage.yml",
+			ID:        "GHA001",
+			Message:   `Action "some-org/labeler@v1" used in job "label" is not pinned to an immutable reference`,
+			StartLine: 13,
+			EndLine:   13,
+		},
+		{
+			FilePath:  ".github/workflows/triage.yml",
+			ID:        "GHA002",
+			Message:   `Job "label" checks out the code of the pull request in a workflow triggered by pull_request_target`,
+			StartLine: 10,
+			EndLine:   12,
+		},
+		{
+			FilePath:  ".github/workflows/triage.yml",
+			ID:        "GHA003",
+			Message:   "Workflow grants write permissions to all the scopes of the GITHUB_TOKEN",
+			StartLine: 5,
+			EndLine:   5,
+		},
+		{
+			FilePath:  ".github/workflows/triage.yml",
+			ID:        "GHA004",
+			Message:   `Untrusted input ${{ github.event.pull_request.title }} is interpolated into a script in job "label"`,
+			StartLine: 15,
+			EndLine:   16,
+		},
+		{
+			FilePath:  ".github/workflows/ci.yml",
+			ID:        "GHA003",
+			Message:   `Job "build" does not restrict the permissions of the GITHUB_TOKEN`,
+			StartLine: 3,
+			EndLine:   7,
+		},
+	}
+	assert.ElementsMatch(t, want, failures)
+}
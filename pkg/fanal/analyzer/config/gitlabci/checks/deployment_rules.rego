@@ -0,0 +1,40 @@
+# METADATA
+# title: Deployment jobs should have rules
+# description: Jobs without rules run in the pipelines of all the branches. Deployment jobs without rules deploy the code of any branch, including branches which are not reviewed, and expose the secrets of the environments to them. Jobs deploying to an environment or in the deploy stage are deployment jobs.
+# scope: package
+# related_resources:
+# - https://docs.gitlab.com/ci/jobs/job_rules/
+# - https://docs.gitlab.com/ci/environments/protected_environments/
+# custom:
+#   id: GLC004
+#   avd_id: AVD-GLC-0004
+#   severity: MEDIUM
+#   short_code: restrict-deployment-jobs
+#   recommended_action: Add rules to the deployment jobs to run them only for protected branches or tags, or make them manual.
+#   input:
+#     selector:
+#     - type: gitlab-ci
+package builtin.gitlab.ci.GLC004
+
+import rego.v1
+
+deployment_stages := {"deploy", "release", "production"}
+
+deployment(job) if job.environment != ""
+
+deployment(job) if job.stage in deployment_stages
+
+restricted(job) if count(job.rules) > 0
+
+restricted(job) if job.only != null
+
+restricted(job) if job.except != null
+
+restricted(job) if job.when == "manual"
+
+deny contains res if {
+	some job in input.jobs
+	deployment(job)
+	not restricted(job)
+	res := result.new(sprintf("Deployment job %q has no rules and runs in the pipelines of all the branches", [job.name]), job)
+}
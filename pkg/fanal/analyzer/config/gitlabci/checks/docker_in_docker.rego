@@ -0,0 +1,33 @@
+# METADATA
+# title: Jobs should not use Docker-in-Docker services
+# description: The docker:dind service only runs on runners executing jobs in privileged containers. Privileged containers have full access to the host of the runner, so a compromised job can escape its container, tamper with the other jobs of the runner and steal their secrets.
+# scope: package
+# related_resources:
+# - https://docs.gitlab.com/ci/docker/using_docker_build/#use-docker-in-docker
+# - https://docs.gitlab.com/runner/security/#usage-of-docker-executor
+# custom:
+#   id: GLC001
+#   avd_id: AVD-GLC-0001
+#   severity: HIGH
+#   short_code: no-docker-in-docker
+#   recommended_action: Build images without a Docker daemon, e.g. with Kaniko or Buildah, or run the jobs on runners dedicated to trusted projects.
+#   input:
+#     selector:
+#     - type: gitlab-ci
+package builtin.gitlab.ci.GLC001
+
+import rego.v1
+
+docker_in_docker(name) if {
+	parts := split(name, "/")
+	image := parts[count(parts) - 1]
+	startswith(image, "docker:")
+	contains(image, "dind")
+}
+
+deny contains res if {
+	some job in input.jobs
+	some service in job.services
+	docker_in_docker(service.name)
+	res := result.new(sprintf("Job %q uses the Docker-in-Docker service %q, which requires a privileged runner", [job.name, service.name]), service)
+}
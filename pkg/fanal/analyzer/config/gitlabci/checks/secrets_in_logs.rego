@@ -0,0 +1,42 @@
+# METADATA
+# title: Scripts should not print secrets in job logs
+# description: Job logs are readable by all the members of the project, and by everyone in public projects. Secrets printed by scripts, or variables dumped by env and printenv, are exposed in the logs unless they are masked, and masking does not apply to transformed values.
+# scope: package
+# related_resources:
+# - https://docs.gitlab.com/ci/variables/#cicd-variable-security
+# custom:
+#   id: GLC002
+#   avd_id: AVD-GLC-0002
+#   severity: HIGH
+#   short_code: no-secrets-in-logs
+#   recommended_action: Pass secrets to commands with pipes, files or environment variables instead of printing them, and mask the secret variables.
+#   input:
+#     selector:
+#     - type: gitlab-ci
+package builtin.gitlab.ci.GLC002
+
+import rego.v1
+
+secret_variable := `\$\{?[A-Za-z0-9_]*(?i:token|secret|password|passwd|api_?key|private_?key|credential)[A-Za-z0-9_]*\}?`
+
+# echo and printf commands printing a secret, unless their output is piped or redirected
+print_pattern := concat("", [`(^|[;&]\s*)(echo|printf)\s[^;&|>]*`, secret_variable, `[^;&|>]*($|;|&&|\|\|)`])
+
+# commands printing all the variables
+dump_pattern := `(^|[;&]\s*)(env|printenv|export -p)\s*($|;|&&|\|\|)`
+
+lines(job) := array.concat(array.concat(job.before_script, job.script), job.after_script)
+
+deny contains res if {
+	some job in input.jobs
+	some line in lines(job)
+	regex.match(print_pattern, line)
+	res := result.new(sprintf("Job %q prints a secret in its logs with %q", [job.name, line]), job)
+}
+
+deny contains res if {
+	some job in input.jobs
+	some line in lines(job)
+	regex.match(dump_pattern, line)
+	res := result.new(sprintf("Job %q prints all the variables in its logs with %q", [job.name, line]), job)
+}
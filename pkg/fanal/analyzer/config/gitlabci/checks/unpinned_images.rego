@@ -0,0 +1,46 @@
+# METADATA
+# title: Images should be pinned to a version or a digest
+# description: Images without a tag, or with the latest tag, change whenever new versions are pushed. Jobs using them are not reproducible and run any image pushed by the publishers of the images, or by attackers who compromised them. Images set by variables are not checked.
+# scope: package
+# related_resources:
+# - https://docs.gitlab.com/ci/yaml/#image
+# custom:
+#   id: GLC003
+#   avd_id: AVD-GLC-0003
+#   severity: MEDIUM
+#   short_code: pin-images
+#   recommended_action: Pin the images of the jobs and the services to versions, or to digests to make them immutable.
+#   input:
+#     selector:
+#     - type: gitlab-ci
+package builtin.gitlab.ci.GLC003
+
+import rego.v1
+
+image_tag(name) := tag if {
+	parts := split(name, "/")
+	image := parts[count(parts) - 1]
+	i := indexof(image, ":")
+	i >= 0
+	tag := substring(image, i + 1, -1)
+} else := ""
+
+unpinned(name) if {
+	name != ""
+	not contains(name, "$")
+	not contains(name, "@sha256:")
+	image_tag(name) in {"", "latest"}
+}
+
+deny contains res if {
+	some job in input.jobs
+	unpinned(job.image.name)
+	res := result.new(sprintf("Image %q of job %q is not pinned to a version", [job.image.name, job.name]), job.image)
+}
+
+deny contains res if {
+	some job in input.jobs
+	some service in job.services
+	unpinned(service.name)
+	res := result.new(sprintf("Service %q of job %q is not pinned to a version", [service.name, job.name]), service)
+}
@@ -0,0 +1,55 @@
+package gitlabci
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeGitLabCI
+	version      = 1
+)
+
+// builtinChecks detect insecure patterns in pipelines, such as Docker-in-Docker services and secrets printed in logs.
+//
+//go:embed checks/*.rego
+var builtinChecks embed.FS
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newGitLabCIConfigAnalyzer)
+}
+
+// gitlabCIConfigAnalyzer is an analyzer for detecting misconfigurations in GitLab CI pipelines.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type gitlabCIConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newGitLabCIConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	checks, err := fs.Sub(builtinChecks, "checks")
+	if err != nil {
+		return nil, xerrors.Errorf("builtin checks error: %w", err)
+	}
+	opts.MisconfScannerOption.BuiltinChecks = checks
+
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeGitLabCI, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &gitlabCIConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is YAML.
+// Files included by pipelines are recognized by their content when scanning.
+func (*gitlabCIConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	ext := filepath.Ext(filePath)
+	return ext == ".yml" || ext == ".yaml"
+}
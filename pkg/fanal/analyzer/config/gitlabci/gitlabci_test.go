@@ -0,0 +1,149 @@
+package gitlabci
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+)
+
+func TestConfigAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "pipeline",
+			filePath: ".gitlab-ci.yml",
+			want:     true,
+		},
+		{
+			name:     "included file",
+			filePath: "ci/deploy.yaml",
+			want:     true,
+		},
+		{
+			name:     "json",
+			filePath: "package.json",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := gitlabCIConfigAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
+
+func TestConfigAnalyzer_PostAnalyze(t *testing.T) {
+	a, err := newGitLabCIConfigAnalyzer(analyzer.AnalyzerOptions{})
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{
+		".gitlab-ci.yml": &fstest.MapFile{Data: []byte(`include:
+  - local: ci/deploy.yml
+default:
+  image: golang:1.23
+build:
+  services:
+    - docker:24-dind
+  before_script:
+    - echo "$REGISTRY_PASSWORD" | docker login -u ci --password-stdin
+  script:
+    - echo "Using $CI_JOB_TOKEN"
+    - docker build .
+test:
+  image: golang
+  script:
+    - printenv
+    - go test ./...
+# trivy:ignore:GLC003
+lint:
+  image: golangci/golangci-lint:latest
+  script:
+    - golangci-lint run
+`)},
+		"ci/deploy.yml": &fstest.MapFile{Data: []byte(`deploy:
+  stage: deploy
+  environment: production
+  script:
+    - ./deploy.sh
+release:
+  stage: deploy
+  rules:
+    - if: $CI_COMMIT_TAG
+  script:
+    - ./release.sh
+`)},
+		"docker-compose.yml": &fstest.MapFile{Data: []byte(`services:
+  app:
+    image: app
+`)},
+	}
+	got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{FS: fsys})
+	require.NoError(t, err)
+
+	type failure struct {
+		FilePath  string
+		ID        string
+		Message   string
+		StartLine int
+		EndLine   int
+	}
+	var failures []failure
+	for _, m := range got.Misconfigurations {
+		for _, f := range m.Failures {
+			failures = append(failures, failure{
+				FilePath:  m.FilePath,
+				ID:        f.ID,
+				Message:   f.Message,
+				StartLine: f.CauseMetadata.StartLine,
+				EndLine:   f.CauseMetadata.EndLine,
+			})
+		}
+	}
+	want := []failure{
+		{
+			FilePath:  ".gitlab-ci.yml",
+			ID:        "GLC001",
+			Message:   `Job "build" uses the Docker-in-Docker service "docker:24-dind", which requires a privileged runner`,
+			StartLine: 5,
+			EndLine:   12,
+		},
+		{
+			FilePath:  ".gitlab-ci.yml",
+			ID:        "GLC002",
+			Message:   `Job "build" prints a secret in its logs with "echo \"Using $CI_JOB_TOKEN\""`,
+			StartLine: 5,
+			EndLine:   12,
+		},
+		{
+			FilePath:  ".gitlab-ci.yml",
+			ID:        "GLC002",
+			Message:   `Job "test" prints all the variables in its logs with "printenv"`,
+			StartLine: 13,
+			EndLine:   17,
+		},
+		{
+			FilePath:  ".gitlab-ci.yml",
+			ID:        "GLC003",
+			Message:   `Image "golang" of job "test" is not pinned to a version`,
+			StartLine: 13,
+			EndLine:   17,
+		},
+		{
+			FilePath:  "ci/deploy.yml",
+			ID:        "GLC004",
+			Message:   `Deployment job "deploy" has no rules and runs in the pipelines of all the branches`,
+			StartLine: 1,
+			EndLine:   5,
+		},
+	}
+	assert.ElementsMatch(t, want, failures)
+}
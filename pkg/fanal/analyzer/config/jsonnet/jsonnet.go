@@ -0,0 +1,41 @@
+package jsonnet
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	analyzerType = analyzer.TypeJsonnet
+	version      = 1
+)
+
+// JSON files may be imported by Jsonnet files
+var acceptedExts = []string{".jsonnet", ".libsonnet", ".json"}
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newJsonnetConfigAnalyzer)
+}
+
+// jsonnetConfigAnalyzer analyzes the Kubernetes objects produced by Jsonnet files
+type jsonnetConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newJsonnetConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeJsonnet, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonnetConfigAnalyzer{Analyzer: a}, nil
+}
+
+func (*jsonnetConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return slices.Contains(acceptedExts, strings.ToLower(filepath.Ext(filePath)))
+}
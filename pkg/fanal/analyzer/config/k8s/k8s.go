@@ -1,9 +1,14 @@
 package k8s
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
+
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
 	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	k8sscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/kubernetes"
 )
 
 const (
@@ -11,6 +16,10 @@ const (
 	version      = 1
 )
 
+// renderedExts are the extensions of files used for rendering other than manifests, i.e. the sources
+// of ConfigMap and Secret generators of Kustomize and the templates of charts referred to by GitOps objects
+var renderedExts = []string{".env", ".properties", ".tpl"}
+
 func init() {
 	analyzer.RegisterPostAnalyzer(analyzerType, newKubernetesConfigAnalyzer)
 }
@@ -28,3 +37,10 @@ func newKubernetesConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAn
 	}
 	return &kubernetesConfigAnalyzer{Analyzer: a}, nil
 }
+
+// Required overrides config.Analyzer.Required() and also checks if the given file is a Kustomization
+// or another file used for rendering, as Kustomizations and GitOps sources are rendered when scanning.
+func (a *kubernetesConfigAnalyzer) Required(filePath string, fi os.FileInfo) bool {
+	return a.Analyzer.Required(filePath, fi) || k8sscanner.IsKustomization(filePath) ||
+		slices.Contains(renderedExts, filepath.Ext(filePath))
+}
@@ -0,0 +1,46 @@
+package pulumi
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+)
+
+const (
+	version      = 1
+	analyzerType = analyzer.TypePulumi
+)
+
+var requiredExts = []string{
+	".yaml",
+	".yml",
+	".json",
+}
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzerType, newPulumiConfigAnalyzer)
+}
+
+// pulumiConfigAnalyzer is an analyzer for detecting misconfigurations in Pulumi YAML programs
+// and the output of `pulumi preview --json`.
+// It embeds config.Analyzer so it can implement analyzer.PostAnalyzer.
+type pulumiConfigAnalyzer struct {
+	*config.Analyzer
+}
+
+func newPulumiConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypePulumi, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumiConfigAnalyzer{Analyzer: a}, nil
+}
+
+// Required overrides config.Analyzer.Required() and checks if the given file is YAML or JSON.
+func (*pulumiConfigAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return slices.Contains(requiredExts, filepath.Ext(filePath))
+}
@@ -0,0 +1,34 @@
+# METADATA
+# title: Terraform providers should not be pinned to versions with known vulnerabilities
+# description: A provider pinned to a vulnerable version keeps being installed even after a fixed version is released. The vulnerable versions are configured in "terraform_providers.vulnerable" of the check data, keyed by the provider source.
+# scope: package
+# custom:
+#   id: TFP001
+#   avd_id: AVD-TFP-0001
+#   severity: HIGH
+#   short_code: no-vulnerable-provider-versions
+#   recommended_action: Upgrade the provider and update the version constraint in the required_providers block.
+#   input:
+#     selector:
+#     - type: cloud
+package builtin.terraform.providers.TFP001
+
+import rego.v1
+
+default vulnerable := {}
+
+# e.g. {"hashicorp/aws": ["5.0.0"]}
+vulnerable := data.terraform_providers.vulnerable
+
+# pinned_version returns the version of exact constraints, e.g. "5.0.0" for "= 5.0.0"
+pinned_version(constraint) := matches[0][1] if {
+	matches := regex.find_all_string_submatch_n(`^\s*=?\s*v?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?)\s*$`, constraint, 1)
+	count(matches) == 1
+}
+
+deny contains res if {
+	some provider in input.terraform.required_providers
+	version := pinned_version(provider.version)
+	version in vulnerable[provider.source]
+	res := result.new(sprintf("Provider %q is pinned to version %s with known vulnerabilities", [provider.source, version]), provider)
+}
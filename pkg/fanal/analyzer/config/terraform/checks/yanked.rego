@@ -0,0 +1,34 @@
+# METADATA
+# title: Terraform providers should not be pinned to yanked versions
+# description: Yanked provider releases were withdrawn by their publishers, e.g. because of serious bugs, and may no longer be installable. The yanked versions are configured in "terraform_providers.yanked" of the check data, keyed by the provider source.
+# scope: package
+# custom:
+#   id: TFP002
+#   avd_id: AVD-TFP-0002
+#   severity: MEDIUM
+#   short_code: no-yanked-provider-versions
+#   recommended_action: Pin the provider to a version which is not yanked in the required_providers block.
+#   input:
+#     selector:
+#     - type: cloud
+package builtin.terraform.providers.TFP002
+
+import rego.v1
+
+default yanked := {}
+
+# e.g. {"hashicorp/aws": ["5.0.0"]}
+yanked := data.terraform_providers.yanked
+
+# pinned_version returns the version of exact constraints, e.g. "5.0.0" for "= 5.0.0"
+pinned_version(constraint) := matches[0][1] if {
+	matches := regex.find_all_string_submatch_n(`^\s*=?\s*v?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?)\s*$`, constraint, 1)
+	count(matches) == 1
+}
+
+deny contains res if {
+	some provider in input.terraform.required_providers
+	version := pinned_version(provider.version)
+	version in yanked[provider.source]
+	res := result.new(sprintf("Provider %q is pinned to the yanked version %s", [provider.source, version]), provider)
+}
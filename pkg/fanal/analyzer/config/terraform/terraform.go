@@ -1,8 +1,12 @@
 package terraform
 
 import (
+	"embed"
+	"io/fs"
 	"os"
 
+	"golang.org/x/xerrors"
+
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/config"
 	"github.com/aquasecurity/trivy/pkg/iac/detection"
@@ -13,6 +17,11 @@ const (
 	version      = 1
 )
 
+// builtinChecks detect providers pinned to versions with known vulnerabilities or yanked releases.
+//
+//go:embed checks/*.rego
+var builtinChecks embed.FS
+
 func init() {
 	analyzer.RegisterPostAnalyzer(analyzerType, newTerraformConfigAnalyzer)
 }
@@ -24,6 +33,12 @@ type terraformConfigAnalyzer struct {
 }
 
 func newTerraformConfigAnalyzer(opts analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	checks, err := fs.Sub(builtinChecks, "checks")
+	if err != nil {
+		return nil, xerrors.Errorf("builtin checks error: %w", err)
+	}
+	opts.MisconfScannerOption.BuiltinChecks = checks
+
 	a, err := config.NewAnalyzer(analyzerType, version, detection.FileTypeTerraform, opts)
 	if err != nil {
 		return nil, err
@@ -1,9 +1,15 @@
 package terraform
 
 import (
+	"context"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/misconf"
 )
 
 func TestConfigAnalyzer_Required(t *testing.T) {
@@ -37,6 +43,16 @@ func TestConfigAnalyzer_Required(t *testing.T) {
 			filePath: "/path/to/main.hcl",
 			want:     false,
 		},
+		{
+			name:     "terragrunt",
+			filePath: "/path/to/terragrunt.hcl",
+			want:     true,
+		},
+		{
+			name:     "terragrunt root",
+			filePath: "/path/to/root.hcl",
+			want:     true,
+		},
 		{
 			name:     "yaml",
 			filePath: "deployment.yaml",
@@ -56,3 +72,66 @@ func TestConfigAnalyzer_Required(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigAnalyzer_PostAnalyze(t *testing.T) {
+	a, err := newTerraformConfigAnalyzer(analyzer.AnalyzerOptions{
+		MisconfScannerOption: misconf.ScannerOption{
+			DataPaths: []string{"testdata"},
+		},
+	})
+	require.NoError(t, err)
+
+	fsys := fstest.MapFS{
+		"main.tf": &fstest.MapFile{Data: []byte(`terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "= 5.0.0"
+    }
+    google = {
+      source  = "hashicorp/google"
+      version = "4.80.0"
+    }
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+  }
+}
+`)},
+	}
+	got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{FS: fsys})
+	require.NoError(t, err)
+	type failure struct {
+		ID        string
+		Message   string
+		StartLine int
+		EndLine   int
+	}
+	var failures []failure
+	for _, m := range got.Misconfigurations {
+		for _, f := range m.Failures {
+			failures = append(failures, failure{
+				ID:        f.ID,
+				Message:   f.Message,
+				StartLine: f.CauseMetadata.StartLine,
+				EndLine:   f.CauseMetadata.EndLine,
+			})
+		}
+	}
+	want := []failure{
+		{
+			ID:        "TFP001",
+			Message:   `Provider "hashicorp/aws" is pinned to version 5.0.0 with known vulnerabilities`,
+			StartLine: 3,
+			EndLine:   6,
+		},
+		{
+			ID:        "TFP002",
+			Message:   `Provider "hashicorp/google" is pinned to the yanked version 4.80.0`,
+			StartLine: 7,
+			EndLine:   10,
+		},
+	}
+	assert.ElementsMatch(t, want, failures)
+}
@@ -3,6 +3,7 @@ package analyzer
 import (
 	"context"
 	"slices"
+	"sort"
 
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"golang.org/x/xerrors"
@@ -51,6 +52,7 @@ type ConfigAnalysisResult struct {
 	Misconfiguration *types.Misconfiguration
 	Secret           *types.Secret
 	HistoryPackages  types.Packages
+	Applications     []types.Application
 }
 
 func (r *ConfigAnalysisResult) Merge(newResult *ConfigAnalysisResult) {
@@ -58,7 +60,7 @@ func (r *ConfigAnalysisResult) Merge(newResult *ConfigAnalysisResult) {
 		return
 	}
 	if newResult.Misconfiguration != nil {
-		r.Misconfiguration = newResult.Misconfiguration
+		r.mergeMisconfiguration(newResult.Misconfiguration)
 	}
 	if newResult.Secret != nil {
 		r.Secret = newResult.Secret
@@ -66,6 +68,26 @@ func (r *ConfigAnalysisResult) Merge(newResult *ConfigAnalysisResult) {
 	if newResult.HistoryPackages != nil {
 		r.HistoryPackages = newResult.HistoryPackages
 	}
+	if newResult.Applications != nil {
+		r.Applications = append(r.Applications, newResult.Applications...)
+	}
+}
+
+// mergeMisconfiguration merges the findings of image config analyzers, such as the history and labels,
+// as the image config is reported as a single result.
+func (r *ConfigAnalysisResult) mergeMisconfiguration(misconf *types.Misconfiguration) {
+	if r.Misconfiguration == nil {
+		r.Misconfiguration = misconf
+		return
+	}
+	merged := *r.Misconfiguration
+	merged.Successes = append(slices.Clone(merged.Successes), misconf.Successes...)
+	merged.Warnings = append(slices.Clone(merged.Warnings), misconf.Warnings...)
+	merged.Failures = append(slices.Clone(merged.Failures), misconf.Failures...)
+	sort.Sort(merged.Successes)
+	sort.Sort(merged.Warnings)
+	sort.Sort(merged.Failures)
+	r.Misconfiguration = &merged
 }
 
 type ConfigAnalyzerGroup struct {
@@ -45,20 +45,24 @@ const (
 	// Ruby
 	TypeBundler Type = "bundler"
 	TypeGemSpec Type = "gemspec"
+	TypeGem     Type = "gem"
+	TypeGemfile Type = "gemfile"
 
 	// Rust
 	TypeRustBinary Type = "rustbinary"
 	TypeCargo      Type = "cargo"
+	TypeRustCrate  Type = "rust-crate"
 
 	// PHP
 	TypeComposer       Type = "composer"
 	TypeComposerVendor Type = "composer-vendor"
 
 	// Java
-	TypeJar        Type = "jar"
-	TypePom        Type = "pom"
-	TypeGradleLock Type = "gradle-lockfile"
-	TypeSbtLock    Type = "sbt-lockfile"
+	TypeJar           Type = "jar"
+	TypePom           Type = "pom"
+	TypeGradleLock    Type = "gradle-lockfile"
+	TypeGradleCatalog Type = "gradle-version-catalog"
+	TypeSbtLock       Type = "sbt-lockfile"
 
 	// Node.js
 	TypeNpmPkgLock Type = "npm"
@@ -72,16 +76,18 @@ const (
 	TypePackagesProps Type = "packages-props"
 
 	// Conda
-	TypeCondaPkg Type = "conda-pkg"
-	TypeCondaEnv Type = "conda-environment"
+	TypeCondaPkg  Type = "conda-pkg"
+	TypeCondaEnv  Type = "conda-environment"
+	TypeCondaPack Type = "conda-pack"
 
 	// Python
-	TypePythonPkg    Type = "python-pkg"
-	TypePythonPkgEgg Type = "python-egg"
-	TypePip          Type = "pip"
-	TypePipenv       Type = "pipenv"
-	TypePoetry       Type = "poetry"
-	TypeUv           Type = "uv"
+	TypePythonPkg      Type = "python-pkg"
+	TypePythonPkgEgg   Type = "python-egg"
+	TypePythonPkgWheel Type = "python-wheel"
+	TypePip            Type = "pip"
+	TypePipenv         Type = "pipenv"
+	TypePoetry         Type = "poetry"
+	TypeUv             Type = "uv"
 
 	// Go
 	TypeGoBinary Type = "gobinary"
@@ -89,6 +95,7 @@ const (
 
 	// C/C++
 	TypeConanLock Type = "conan-lock"
+	TypeCBinary   Type = "cbinary"
 
 	// Elixir
 	TypeMixLock Type = "mix-lock"
@@ -103,6 +110,9 @@ const (
 	// Julia
 	TypeJulia Type = "julia"
 
+	// Terraform
+	TypeTerraformLock Type = "terraform-lock"
+
 	// ============
 	// Non-packaged
 	// ============
@@ -115,21 +125,43 @@ const (
 	TypeApkCommand        Type = "apk-command"
 	TypeHistoryDockerfile Type = "history-dockerfile"
 	TypeImageConfigSecret Type = "image-config-secret"
+	TypeBuildpacksLabel   Type = "buildpacks-label"
+	TypeImageLabels       Type = "image-labels"
 
 	// =================
 	// Structured Config
 	// =================
+	TypeAnsible               Type = Type(detection.FileTypeAnsible)
 	TypeAzureARM              Type = Type(detection.FileTypeAzureARM)
+	TypeAzurePipelines        Type = Type(detection.FileTypeAzurePipelines)
+	TypeBicep                 Type = Type(detection.FileTypeBicep)
 	TypeCloudFormation        Type = Type(detection.FileTypeCloudFormation)
+	TypeCUE                   Type = Type(detection.FileTypeCUE)
 	TypeDockerfile            Type = Type(detection.FileTypeDockerfile)
+	TypeGitHubActions         Type = Type(detection.FileTypeGitHubActions)
+	TypeGitLabCI              Type = Type(detection.FileTypeGitLabCI)
 	TypeHelm                  Type = Type(detection.FileTypeHelm)
+	TypeJsonnet               Type = Type(detection.FileTypeJsonnet)
 	TypeKubernetes            Type = Type(detection.FileTypeKubernetes)
+	TypePulumi                Type = Type(detection.FileTypePulumi)
 	TypeTerraform             Type = Type(detection.FileTypeTerraform)
 	TypeTerraformPlanJSON     Type = Type(detection.FileTypeTerraformPlanJSON)
 	TypeTerraformPlanSnapshot Type = Type(detection.FileTypeTerraformPlanSnapshot)
 	TypeYAML                  Type = Type(detection.FileTypeYAML)
 	TypeJSON                  Type = Type(detection.FileTypeJSON)
 
+	// ===========
+	// ML artifact
+	// ===========
+	TypePickle            Type = "pickle"
+	TypeHuggingFaceConfig Type = "huggingface-config"
+	TypeModelCard         Type = "model-card"
+
+	// ============
+	// Supply chain
+	// ============
+	TypeInstallScript Type = "install-script"
+
 	// ========
 	// License
 	// ========
@@ -175,12 +207,14 @@ var (
 	// TypeLanguages has all language analyzers
 	TypeLanguages = []Type{
 		TypeBundler,
+		TypeGemfile,
 		TypeGemSpec,
 		TypeCargo,
 		TypeComposer,
 		TypeJar,
 		TypePom,
 		TypeGradleLock,
+		TypeGradleCatalog,
 		TypeSbtLock,
 		TypeNpmPkgLock,
 		TypeNodePkg,
@@ -191,8 +225,10 @@ var (
 		TypePackagesProps,
 		TypeCondaPkg,
 		TypeCondaEnv,
+		TypeCondaPack,
 		TypePythonPkg,
 		TypePip,
+		TypeModelCard,
 		TypePipenv,
 		TypePoetry,
 		TypeUv,
@@ -200,16 +236,19 @@ var (
 		TypeGoMod,
 		TypeRustBinary,
 		TypeConanLock,
+		TypeCBinary,
 		TypeCocoaPods,
 		TypeSwift,
 		TypePubSpecLock,
 		TypeMixLock,
 		TypeJulia,
+		TypeTerraformLock,
 	}
 
 	// TypeLockfiles has all lock file analyzers
 	TypeLockfiles = []Type{
 		TypeBundler,
+		TypeGemfile,
 		TypeNpmPkgLock,
 		TypeYarn,
 		TypePnpm,
@@ -221,6 +260,7 @@ var (
 		TypePom,
 		TypeConanLock,
 		TypeGradleLock,
+		TypeGradleCatalog,
 		TypeSbtLock,
 		TypeCocoaPods,
 		TypeSwift,
@@ -228,6 +268,7 @@ var (
 		TypeMixLock,
 		TypeCondaEnv,
 		TypeComposer,
+		TypeTerraformLock,
 	}
 
 	// TypeIndividualPkgs has all analyzers for individual packages
@@ -239,20 +280,42 @@ var (
 		TypeGoBinary,
 		TypeJar,
 		TypeRustBinary,
+		TypeCBinary,
 		TypeComposerVendor,
 	}
 
+	// TypeHeavy has expensive analyzers, such as analyzers unpacking nested archives and classifying license texts.
+	// They are rate-limited and scheduled after the other analyzers.
+	TypeHeavy = []Type{
+		TypeJar,
+		TypeRpmArchive,
+		TypeCondaPack,
+		TypePickle,
+		TypeLicenseFile,
+	}
+
 	// TypeConfigFiles has all config file analyzers
 	TypeConfigFiles = []Type{
+		TypeAnsible,
 		TypeAzureARM,
+		TypeAzurePipelines,
+		TypeBicep,
 		TypeCloudFormation,
+		TypeCUE,
 		TypeDockerfile,
+		TypeGitHubActions,
+		TypeGitLabCI,
 		TypeHelm,
+		TypeJsonnet,
 		TypeKubernetes,
+		TypePulumi,
 		TypeTerraform,
 		TypeTerraformPlanJSON,
 		TypeTerraformPlanSnapshot,
 		TypeYAML,
 		TypeJSON,
+		TypePickle,
+		TypeHuggingFaceConfig,
+		TypeInstallScript,
 	}
 )
@@ -0,0 +1,138 @@
+package buildpacks
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/purl"
+	rtypes "github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	analyzerVersion = 1
+
+	// The label is set by the lifecycle of Cloud Native Buildpacks
+	// ref: https://github.com/buildpacks/spec/blob/main/platform.md#iobuildpacksbuildmetadata-json
+	buildMetadataLabel = "io.buildpacks.build.metadata"
+)
+
+func init() {
+	analyzer.RegisterConfigAnalyzer(analyzer.TypeBuildpacksLabel, newBuildpacksLabelAnalyzer)
+}
+
+type buildMetadata struct {
+	BOM []bomEntry `json:"bom"`
+}
+
+// bomEntry represents an entry of the legacy BOM written by buildpacks.
+// Newer buildpacks write SBOM files into the image layers instead, which are detected by the SBOM analyzer.
+type bomEntry struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Metadata struct {
+		Version  string    `json:"version"`
+		PURL     string    `json:"purl"`
+		Licenses []license `json:"licenses"`
+	} `json:"metadata"`
+}
+
+type license struct {
+	Type string `json:"type"`
+}
+
+// buildpacksLabelAnalyzer detects packages listed in the BOM label of Cloud Native Buildpacks images.
+type buildpacksLabelAnalyzer struct {
+	logger *log.Logger
+}
+
+func newBuildpacksLabelAnalyzer(_ analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnalyzer, error) {
+	return &buildpacksLabelAnalyzer{
+		logger: log.WithPrefix("buildpacks"),
+	}, nil
+}
+
+func (a *buildpacksLabelAnalyzer) Analyze(_ context.Context, input analyzer.ConfigAnalysisInput) (*analyzer.ConfigAnalysisResult, error) {
+	if input.Config == nil {
+		return nil, nil
+	}
+	label, ok := input.Config.Config.Labels[buildMetadataLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	var metadata buildMetadata
+	if err := json.Unmarshal([]byte(label), &metadata); err != nil {
+		return nil, xerrors.Errorf("unable to parse the %q label: %w", buildMetadataLabel, err)
+	}
+
+	pkgs := make(map[types.LangType]types.Packages)
+	for _, entry := range metadata.BOM {
+		if entry.Metadata.PURL == "" {
+			continue
+		}
+		p, err := purl.FromString(entry.Metadata.PURL)
+		if err != nil {
+			a.logger.Debug("Unable to parse PURL", log.String("purl", entry.Metadata.PURL), log.Err(err))
+			continue
+		}
+		// Runtimes installed by buildpacks usually have generic PURLs, e.g. pkg:generic/node@20.11.1
+		if p.Class() != rtypes.ClassLangPkg {
+			a.logger.Debug("Skipping the BOM entry with the unsupported PURL type",
+				log.String("name", entry.Name), log.String("purl", entry.Metadata.PURL))
+			continue
+		}
+
+		pkg := p.Package()
+		if pkg.Version == "" {
+			pkg.Version = lo.CoalesceOrEmpty(entry.Metadata.Version, entry.Version)
+		}
+		for _, l := range entry.Metadata.Licenses {
+			if l.Type != "" {
+				pkg.Licenses = append(pkg.Licenses, l.Type)
+			}
+		}
+		pkgs[p.LangType()] = append(pkgs[p.LangType()], *pkg)
+	}
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+
+	var apps []types.Application
+	for appType, appPkgs := range pkgs {
+		slices.SortFunc(appPkgs, func(a, b types.Package) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		apps = append(apps, types.Application{
+			Type:     appType,
+			FilePath: buildMetadataLabel,
+			Packages: appPkgs,
+		})
+	}
+	slices.SortFunc(apps, func(a, b types.Application) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+
+	return &analyzer.ConfigAnalysisResult{
+		Applications: apps,
+	}, nil
+}
+
+func (a *buildpacksLabelAnalyzer) Required(_ types.OS) bool {
+	return true
+}
+
+func (a *buildpacksLabelAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeBuildpacksLabel
+}
+
+func (a *buildpacksLabelAnalyzer) Version() int {
+	return analyzerVersion
+}
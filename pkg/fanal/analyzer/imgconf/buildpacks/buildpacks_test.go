@@ -0,0 +1,118 @@
+package buildpacks
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_buildpacksLabelAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		want    *analyzer.ConfigAnalysisResult
+		wantErr string
+	}{
+		{
+			name:  "happy path",
+			label: "testdata/build-metadata.json",
+			want: &analyzer.ConfigAnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Jar,
+						FilePath: "io.buildpacks.build.metadata",
+						Packages: types.Packages{
+							{
+								ID:      "org.springframework:spring-core:5.3.18",
+								Name:    "org.springframework:spring-core",
+								Version: "5.3.18",
+								Identifier: types.PkgIdentifier{
+									PURL: &packageurl.PackageURL{
+										Type:      packageurl.TypeMaven,
+										Namespace: "org.springframework",
+										Name:      "spring-core",
+										Version:   "5.3.18",
+									},
+								},
+							},
+						},
+					},
+					{
+						Type:     types.NodePkg,
+						FilePath: "io.buildpacks.build.metadata",
+						Packages: types.Packages{
+							{
+								ID:      "@babel/core@7.23.9",
+								Name:    "@babel/core",
+								Version: "7.23.9",
+								Identifier: types.PkgIdentifier{
+									PURL: &packageurl.PackageURL{
+										Type:      packageurl.TypeNPM,
+										Namespace: "@babel",
+										Name:      "core",
+										Version:   "7.23.9",
+									},
+								},
+							},
+							{
+								ID:       "express@4.17.1",
+								Name:     "express",
+								Version:  "4.17.1",
+								Licenses: []string{"MIT"},
+								Identifier: types.PkgIdentifier{
+									PURL: &packageurl.PackageURL{
+										Type:    packageurl.TypeNPM,
+										Name:    "express",
+										Version: "4.17.1",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no label",
+			want: nil,
+		},
+		{
+			name:    "invalid label",
+			label:   "testdata/invalid.json",
+			wantErr: "unable to parse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &v1.ConfigFile{}
+			if tt.label != "" {
+				b, err := os.ReadFile(tt.label)
+				require.NoError(t, err)
+				config.Config.Labels = map[string]string{
+					buildMetadataLabel: string(b),
+				}
+			}
+
+			a, err := newBuildpacksLabelAnalyzer(analyzer.ConfigAnalyzerOptions{})
+			require.NoError(t, err)
+
+			got, err := a.Analyze(context.Background(), analyzer.ConfigAnalysisInput{
+				Config: config,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+# METADATA
+# title: Image labels should not have disallowed values
+# description: Organizations may disallow some label values, such as internal vendor names or unapproved licenses. The disallowed values are configured in "image_labels.disallowed" of the check data.
+# scope: package
+# custom:
+#   id: IMG003
+#   avd_id: AVD-IMG-0003
+#   severity: MEDIUM
+#   short_code: no-disallowed-label-values
+#   recommended_action: Change the label value with the LABEL instruction.
+#   input:
+#     selector:
+#     - type: json
+package builtin.image.labels.IMG003
+
+import rego.v1
+
+default disallowed := {}
+
+# e.g. {"org.opencontainers.image.licenses": ["AGPL-3.0-only"]}
+disallowed := data.image_labels.disallowed
+
+deny contains res if {
+	some key, value in input.labels
+	value in disallowed[key]
+	res := result.new(sprintf("Label %q has the disallowed value %q", [key, value]), {})
+}
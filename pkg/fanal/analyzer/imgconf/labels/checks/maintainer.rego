@@ -0,0 +1,29 @@
+# METADATA
+# title: Image should have a maintainer label
+# description: The "maintainer" or "org.opencontainers.image.authors" label tells users who to contact about the image.
+# scope: package
+# custom:
+#   id: IMG001
+#   avd_id: AVD-IMG-0001
+#   severity: LOW
+#   short_code: add-maintainer-label
+#   recommended_action: Add a "org.opencontainers.image.authors" label with the LABEL instruction.
+#   input:
+#     selector:
+#     - type: json
+package builtin.image.labels.IMG001
+
+import rego.v1
+
+maintainer_labels := {"maintainer", "org.opencontainers.image.authors"}
+
+has_maintainer if {
+	some key, value in input.labels
+	key in maintainer_labels
+	value != ""
+}
+
+deny contains res if {
+	not has_maintainer
+	res := result.new("Image has no maintainer label", {})
+}
@@ -0,0 +1,29 @@
+# METADATA
+# title: Image should have a source label
+# description: The "org.opencontainers.image.source" label links the image to the source code it is built from.
+# scope: package
+# custom:
+#   id: IMG002
+#   avd_id: AVD-IMG-0002
+#   severity: LOW
+#   short_code: add-source-label
+#   recommended_action: Add a "org.opencontainers.image.source" label with the URL of the source repository.
+#   input:
+#     selector:
+#     - type: json
+package builtin.image.labels.IMG002
+
+import rego.v1
+
+source_labels := {"org.opencontainers.image.source", "org.label-schema.vcs-url"}
+
+has_source if {
+	some key, value in input.labels
+	key in source_labels
+	value != ""
+}
+
+deny contains res if {
+	not has_source
+	res := result.new("Image has no source label", {})
+}
@@ -0,0 +1,108 @@
+package labels
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	"github.com/aquasecurity/trivy/pkg/mapfs"
+	"github.com/aquasecurity/trivy/pkg/misconf"
+)
+
+const analyzerVersion = 1
+
+// labelsFile is the virtual file passed to the checks
+const labelsFile = "labels.json"
+
+// builtinChecks detect missing maintainer and source labels, and disallowed label values.
+//
+//go:embed checks/*.rego
+var builtinChecks embed.FS
+
+func init() {
+	analyzer.RegisterConfigAnalyzer(analyzer.TypeImageLabels, newLabelsAnalyzer)
+}
+
+// labelsAnalyzer evaluates the checks against the labels of the image config.
+// The input of the checks is a JSON document such as {"labels": {"maintainer": "..."}},
+// so that custom checks with the "json" selector can enforce image metadata standards as well.
+type labelsAnalyzer struct {
+	scanner *misconf.Scanner
+}
+
+func newLabelsAnalyzer(opts analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnalyzer, error) {
+	checks, err := fs.Sub(builtinChecks, "checks")
+	if err != nil {
+		return nil, xerrors.Errorf("builtin checks error: %w", err)
+	}
+	scannerOpt := opts.MisconfScannerOption
+	scannerOpt.BuiltinChecks = checks
+	// The labels document is virtual, so schemas and file patterns for JSON files don't apply.
+	scannerOpt.FilePatterns = nil
+	scannerOpt.ConfigFileSchemas = nil
+
+	s, err := misconf.NewScanner(detection.FileTypeJSON, scannerOpt)
+	if err != nil {
+		return nil, xerrors.Errorf("misconfiguration scanner error: %w", err)
+	}
+	return &labelsAnalyzer{
+		scanner: s,
+	}, nil
+}
+
+func (a *labelsAnalyzer) Analyze(ctx context.Context, input analyzer.ConfigAnalysisInput) (*analyzer.
+	ConfigAnalysisResult, error) {
+	if input.Config == nil {
+		return nil, nil
+	}
+
+	labels := input.Config.Config.Labels
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	b, err := json.Marshal(map[string]any{
+		"labels": labels,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("json marshal error: %w", err)
+	}
+
+	fsys := mapfs.New()
+	if err = fsys.WriteVirtualFile(labelsFile, b, 0600); err != nil {
+		return nil, xerrors.Errorf("mapfs write error: %w", err)
+	}
+
+	misconfs, err := a.scanner.Scan(ctx, fsys)
+	if err != nil {
+		return nil, xerrors.Errorf("labels scan error: %w", err)
+	}
+	// The result should be a single element as it passes one document.
+	if len(misconfs) != 1 {
+		return nil, nil
+	}
+
+	// Findings on the image config are reported together as a single result of the image
+	misconf := misconfs[0]
+	misconf.FileType = types.Dockerfile
+	return &analyzer.ConfigAnalysisResult{
+		Misconfiguration: &misconf,
+	}, nil
+}
+
+func (a *labelsAnalyzer) Required(_ types.OS) bool {
+	return true
+}
+
+func (a *labelsAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeImageLabels
+}
+
+func (a *labelsAnalyzer) Version() int {
+	return analyzerVersion
+}
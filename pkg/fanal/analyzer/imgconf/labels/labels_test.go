@@ -0,0 +1,158 @@
+package labels
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/misconf"
+)
+
+func Test_labelsAnalyzer_Analyze(t *testing.T) {
+	var (
+		missingMaintainer = types.MisconfResult{
+			Namespace: "builtin.image.labels.IMG001",
+			Query:     "data.builtin.image.labels.IMG001.deny",
+			Message:   "Image has no maintainer label",
+			PolicyMetadata: types.PolicyMetadata{
+				ID:                 "IMG001",
+				AVDID:              "AVD-IMG-0001",
+				Type:               "JSON Security Check",
+				Title:              "Image should have a maintainer label",
+				Description:        `The "maintainer" or "org.opencontainers.image.authors" label tells users who to contact about the image.`,
+				Severity:           "LOW",
+				RecommendedActions: `Add a "org.opencontainers.image.authors" label with the LABEL instruction.`,
+			},
+			CauseMetadata: types.CauseMetadata{
+				Provider: "Json",
+				Service:  "general",
+			},
+		}
+		missingSource = types.MisconfResult{
+			Namespace: "builtin.image.labels.IMG002",
+			Query:     "data.builtin.image.labels.IMG002.deny",
+			Message:   "Image has no source label",
+			PolicyMetadata: types.PolicyMetadata{
+				ID:                 "IMG002",
+				AVDID:              "AVD-IMG-0002",
+				Type:               "JSON Security Check",
+				Title:              "Image should have a source label",
+				Description:        `The "org.opencontainers.image.source" label links the image to the source code it is built from.`,
+				Severity:           "LOW",
+				RecommendedActions: `Add a "org.opencontainers.image.source" label with the URL of the source repository.`,
+			},
+			CauseMetadata: types.CauseMetadata{
+				Provider: "Json",
+				Service:  "general",
+			},
+		}
+	)
+
+	tests := []struct {
+		name  string
+		input analyzer.ConfigAnalysisInput
+		want  *analyzer.ConfigAnalysisResult
+	}{
+		{
+			name: "no labels",
+			input: analyzer.ConfigAnalysisInput{
+				Config: &v1.ConfigFile{},
+			},
+			want: &analyzer.ConfigAnalysisResult{
+				Misconfiguration: &types.Misconfiguration{
+					FileType: types.Dockerfile,
+					FilePath: "labels.json",
+					Failures: types.MisconfResults{
+						missingMaintainer,
+						missingSource,
+					},
+				},
+			},
+		},
+		{
+			name: "compliant labels",
+			input: analyzer.ConfigAnalysisInput{
+				Config: &v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{
+							"maintainer":                      "Example <admin@example.com>",
+							"org.opencontainers.image.source": "https://github.com/example/app",
+							"org.opencontainers.image.vendor": "Example",
+						},
+					},
+				},
+			},
+			want: &analyzer.ConfigAnalysisResult{
+				Misconfiguration: &types.Misconfiguration{
+					FileType: types.Dockerfile,
+					FilePath: "labels.json",
+				},
+			},
+		},
+		{
+			name: "disallowed label value",
+			input: analyzer.ConfigAnalysisInput{
+				Config: &v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{
+							"org.opencontainers.image.authors": "Example <admin@example.com>",
+							"org.label-schema.vcs-url":         "https://github.com/example/app",
+							"org.opencontainers.image.vendor":  "ACME Internal",
+						},
+					},
+				},
+			},
+			want: &analyzer.ConfigAnalysisResult{
+				Misconfiguration: &types.Misconfiguration{
+					FileType: types.Dockerfile,
+					FilePath: "labels.json",
+					Failures: types.MisconfResults{
+						{
+							Namespace: "builtin.image.labels.IMG003",
+							Query:     "data.builtin.image.labels.IMG003.deny",
+							Message:   `Label "org.opencontainers.image.vendor" has the disallowed value "ACME Internal"`,
+							PolicyMetadata: types.PolicyMetadata{
+								ID:                 "IMG003",
+								AVDID:              "AVD-IMG-0003",
+								Type:               "JSON Security Check",
+								Title:              "Image labels should not have disallowed values",
+								Description:        `Organizations may disallow some label values, such as internal vendor names or unapproved licenses. The disallowed values are configured in "image_labels.disallowed" of the check data.`,
+								Severity:           "MEDIUM",
+								RecommendedActions: "Change the label value with the LABEL instruction.",
+							},
+							CauseMetadata: types.CauseMetadata{
+								Provider: "Json",
+								Service:  "general",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "no config",
+			input: analyzer.ConfigAnalysisInput{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newLabelsAnalyzer(analyzer.ConfigAnalyzerOptions{
+				MisconfScannerOption: misconf.ScannerOption{
+					DataPaths: []string{"testdata"},
+				},
+			})
+			require.NoError(t, err)
+			got, err := a.Analyze(context.Background(), tt.input)
+			require.NoError(t, err)
+			if got != nil && got.Misconfiguration != nil {
+				got.Misconfiguration.Successes = nil // Not compare successes in this test
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
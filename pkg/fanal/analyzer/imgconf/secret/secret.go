@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
@@ -12,7 +13,56 @@ import (
 	"github.com/aquasecurity/trivy/pkg/log"
 )
 
-const analyzerVersion = 1
+const analyzerVersion = 2
+
+// sensitiveName matches the names of variables and labels that usually hold credentials
+const sensitiveName = `(?i:password|passwd|secret|token|api[_.-]?key|access[_.-]?key|private[_.-]?key|credentials?)`
+
+// imageConfigRules detect credentials set by ENV, ARG and LABEL instructions.
+// Unlike files, such values rarely have a well-known format, so the names are used instead.
+var imageConfigRules = []secret.Rule{
+	{
+		// e.g. "DB_PASSWORD=..." in "Env" and "ENV DB_PASSWORD=..." or "|1 DB_PASSWORD=..." (ARG) in "created_by"
+		ID:              "image-config-sensitive-variable",
+		Category:        secret.CategoryDocker,
+		Title:           "Credential set by ENV or ARG instruction",
+		Severity:        "HIGH",
+		Regex:           secret.MustCompile(`\b[A-Za-z0-9_]*` + sensitiveName + `[A-Za-z0-9_]*=(?P<secret>[^\s"'$\\][^\s"'\\]{7,})`),
+		SecretGroupName: "secret",
+		Keywords:        []string{"pass", "secret", "token", "key", "credential"},
+		AllowRules:      sensitiveValueAllowRules,
+	},
+	{
+		// e.g. "com.example.api-token": "..." in "Labels"
+		ID:              "image-config-sensitive-label",
+		Category:        secret.CategoryDocker,
+		Title:           "Credential set by LABEL instruction",
+		Severity:        "HIGH",
+		Regex:           secret.MustCompile(`"[A-Za-z0-9_.-]*` + sensitiveName + `[A-Za-z0-9_.-]*":\s*"(?P<secret>[^"$\\][^"\\]{7,})"`),
+		SecretGroupName: "secret",
+		Keywords:        []string{"pass", "secret", "token", "key", "credential"},
+		AllowRules:      sensitiveValueAllowRules,
+	},
+}
+
+// sensitiveValueAllowRules ignores values that are obviously not credentials
+var sensitiveValueAllowRules = secret.AllowRules{
+	{
+		ID:          "url",
+		Description: "URLs such as token endpoints",
+		Regex:       secret.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://`),
+	},
+	{
+		ID:          "path",
+		Description: "paths to files holding credentials",
+		Regex:       secret.MustCompile(`^(/|\./|~/)`),
+	},
+	{
+		ID:          "boolean",
+		Description: "flags such as PASSWORD_REQUIRED=false",
+		Regex:       secret.MustCompile(`^(?i:true|false|enabled|disabled)$`),
+	},
+}
 
 func init() {
 	analyzer.RegisterConfigAnalyzer(analyzer.TypeImageConfigSecret, newSecretAnalyzer)
@@ -28,8 +78,24 @@ func newSecretAnalyzer(opts analyzer.ConfigAnalyzerOptions) (analyzer.ConfigAnal
 	c, err := secret.ParseConfig(configPath)
 	if err != nil {
 		return nil, xerrors.Errorf("secret config error: %w", err)
+	} else if c == nil {
+		// The built-in rules are used with the empty config as well
+		c = &secret.Config{}
 	}
-	scanner := secret.NewScanner(c)
+	// The rules are added as custom rules so that they can be disabled with "disable-rules"
+	c.CustomRules = append(c.CustomRules, imageConfigRules...)
+
+	var scannerOpts []secret.ScannerOption
+	if opts.SecretScannerOption.Validate {
+		scannerOpts = append(scannerOpts, secret.WithValidation(nil))
+	}
+	if opts.SecretScannerOption.Fingerprint {
+		scannerOpts = append(scannerOpts, secret.WithFingerprint())
+	}
+	if r := opts.SecretScannerOption.Redaction; r != "" {
+		scannerOpts = append(scannerOpts, secret.WithRedaction(secret.Redaction(r)))
+	}
+	scanner := secret.NewScanner(c, scannerOpts...)
 
 	return &secretAnalyzer{
 		scanner: scanner,
@@ -51,6 +117,7 @@ func (a *secretAnalyzer) Analyze(_ context.Context, input analyzer.ConfigAnalysi
 		Content:  b,
 	})
 
+	result.Findings = dedupFindings(result.Findings)
 	if len(result.Findings) == 0 {
 		log.Debug("No secrets found in container image config")
 		return nil, nil
@@ -72,3 +139,21 @@ func (a *secretAnalyzer) Type() analyzer.Type {
 func (a *secretAnalyzer) Version() int {
 	return analyzerVersion
 }
+
+// dedupFindings removes the findings of the name-based rules on the lines where a specific rule already matched,
+// e.g. "GITHUB_TOKEN=ghp_..." is reported only as a GitHub token.
+func dedupFindings(findings []types.SecretFinding) []types.SecretFinding {
+	specific := make(map[int]bool)
+	for _, f := range findings {
+		if !isImageConfigRule(f.RuleID) {
+			specific[f.StartLine] = true
+		}
+	}
+	return lo.Filter(findings, func(f types.SecretFinding, _ int) bool {
+		return !isImageConfigRule(f.RuleID) || !specific[f.StartLine]
+	})
+}
+
+func isImageConfigRule(id string) bool {
+	return lo.ContainsBy(imageConfigRules, func(r secret.Rule) bool { return r.ID == id })
+}
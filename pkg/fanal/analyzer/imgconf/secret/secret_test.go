@@ -73,6 +73,140 @@ func Test_secretAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "sensitive ENV, ARG and LABEL",
+			config: &v1.ConfigFile{
+				Config: v1.Config{
+					Env: []string{
+						"DB_PASSWORD=s3cr3t-passw0rd",
+						"TOKEN_URL=https://example.com/token",
+					},
+					Labels: map[string]string{
+						"org.acme.api-key": "0123456789abcdef",
+					},
+				},
+				History: []v1.History{
+					{
+						CreatedBy: "RUN |1 NPM_TOKEN=npm-dummy-value /bin/sh -c npm ci # buildkit",
+					},
+				},
+			},
+			want: &analyzer.ConfigAnalysisResult{
+				Secret: &types.Secret{
+					FilePath: "config.json",
+					Findings: []types.SecretFinding{
+						{
+							RuleID:    "image-config-sensitive-label",
+							Category:  "Docker",
+							Severity:  "HIGH",
+							Title:     "Credential set by LABEL instruction",
+							StartLine: 21,
+							EndLine:   21,
+							Code: types.Code{
+								Lines: []types.Line{
+									{
+										Number:      19,
+										Content:     "  ],",
+										Highlighted: "  ],",
+									},
+									{
+										Number:      20,
+										Content:     "  \"Labels\": {",
+										Highlighted: "  \"Labels\": {",
+									},
+									{
+										Number:      21,
+										Content:     "  \"org.acme.api-key\": \"****************\"",
+										IsCause:     true,
+										Highlighted: "  \"org.acme.api-key\": \"****************\"",
+										FirstCause:  true,
+										LastCause:   true,
+									},
+									{
+										Number:      22,
+										Content:     "  }",
+										Highlighted: "  }",
+									},
+								},
+							},
+							Match: "  \"org.acme.api-key\": \"****************\"",
+						},
+						{
+							RuleID:    "image-config-sensitive-variable",
+							Category:  "Docker",
+							Severity:  "HIGH",
+							Title:     "Credential set by ENV or ARG instruction",
+							StartLine: 17,
+							EndLine:   17,
+							Code: types.Code{
+								Lines: []types.Line{
+									{
+										Number:      15,
+										Content:     "  \"config\": {",
+										Highlighted: "  \"config\": {",
+									},
+									{
+										Number:      16,
+										Content:     "  \"Env\": [",
+										Highlighted: "  \"Env\": [",
+									},
+									{
+										Number:      17,
+										Content:     "  \"DB_PASSWORD=***************\",",
+										IsCause:     true,
+										Highlighted: "  \"DB_PASSWORD=***************\",",
+										FirstCause:  true,
+										LastCause:   true,
+									},
+									{
+										Number:      18,
+										Content:     "  \"TOKEN_URL=https://example.com/token\"",
+										Highlighted: "  \"TOKEN_URL=https://example.com/token\"",
+									},
+								},
+							},
+							Match: "  \"DB_PASSWORD=***************\",",
+						},
+						{
+							RuleID:    "image-config-sensitive-variable",
+							Category:  "Docker",
+							Severity:  "HIGH",
+							Title:     "Credential set by ENV or ARG instruction",
+							StartLine: 7,
+							EndLine:   7,
+							Code: types.Code{
+								Lines: []types.Line{
+									{
+										Number:      5,
+										Content:     "  {",
+										Highlighted: "  {",
+									},
+									{
+										Number:      6,
+										Content:     "  \"created\": \"0001-01-01T00:00:00Z\",",
+										Highlighted: "  \"created\": \"0001-01-01T00:00:00Z\",",
+									},
+									{
+										Number:      7,
+										Content:     "  \"created_by\": \"RUN |1 NPM_TOKEN=*************** /bin/sh -c npm ci # buildkit\"",
+										IsCause:     true,
+										Highlighted: "  \"created_by\": \"RUN |1 NPM_TOKEN=*************** /bin/sh -c npm ci # buildkit\"",
+										FirstCause:  true,
+										LastCause:   true,
+									},
+									{
+										Number:      8,
+										Content:     "  }",
+										Highlighted: "  }",
+									},
+								},
+							},
+							Match: "  \"created_by\": \"RUN |1 NPM_TOKEN=*************** /bin/sh -c npm ci # buildkit\"",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "no secret",
 			config: &v1.ConfigFile{
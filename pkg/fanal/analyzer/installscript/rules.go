@@ -0,0 +1,130 @@
+package installscript
+
+import (
+	"regexp"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+type check struct {
+	types.PolicyMetadata
+	// Patterns matched against npm lifecycle scripts, which are run by the shell
+	shellPatterns []*regexp.Regexp
+	// Patterns matched against setup.py
+	pythonPatterns []*regexp.Regexp
+	// e.g. "downloads and executes code"
+	behavior string
+}
+
+var (
+	remoteExecCheck = check{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "SC001",
+			Type:               "Install Script Security Check",
+			Title:              "Remote code executed at install time",
+			Description:        "The install script downloads code and executes it, e.g. 'curl ... | sh'. The code can be changed at any time without publishing a new version of the package.",
+			Severity:           "CRITICAL",
+			RecommendedActions: "Do not install the package, or install it with scripts disabled, e.g. 'npm install --ignore-scripts', after reviewing the downloaded code.",
+			References: []string{
+				"https://docs.npmjs.com/cli/using-npm/scripts#best-practices",
+			},
+		},
+		shellPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(curl|wget)\b[^|;&]*\|\s*(sudo\s+)?(ba|z|k|da)?sh\b`),
+			regexp.MustCompile(`(?i)\b(curl|wget)\b[^|;&]*\|\s*(python[0-9.]*|node|perl|ruby)\b`),
+			regexp.MustCompile(`(?i)\b(ba|z)?sh\s+<\(\s*(curl|wget)\b`),
+			regexp.MustCompile(`(?i)\b(iwr|irm|invoke-webrequest|invoke-restmethod)\b[^|;]*\|\s*(iex|invoke-expression)\b`),
+		},
+		pythonPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\b(curl|wget)\b[^|;&]*\|\s*(sudo\s+)?(ba|z|k|da)?sh\b`),
+			regexp.MustCompile(`\b(exec|eval)\s*\(.*\b(urlopen|requests\.get|urlretrieve)\s*\(`),
+		},
+		behavior: "downloads and executes code",
+	}
+
+	networkCheck = check{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "SC002",
+			Type:               "Install Script Security Check",
+			Title:              "Network access at install time",
+			Description:        "The install script accesses the network. Malicious packages use install scripts to download payloads or to send data from the machine to the attacker.",
+			Severity:           "HIGH",
+			RecommendedActions: "Review the install script, or install the package with scripts disabled, e.g. 'npm install --ignore-scripts'.",
+			References: []string{
+				"https://docs.npmjs.com/cli/using-npm/scripts#best-practices",
+			},
+		},
+		shellPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`(?i)\bhttps?://[^\s'"]+`),
+			regexp.MustCompile(`/dev/(tcp|udp)/\S+`),
+			regexp.MustCompile(`\brequire\(\s*['"](node:)?(https?|net|dgram|dns)['"]\s*\)`),
+			regexp.MustCompile(`(?i)\b(curl|wget|nc|ncat|netcat)\s`),
+		},
+		// URLs in setup.py are usually metadata, such as the project URL
+		pythonPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`^\s*(import|from)\s+(urllib[0-9]?|requests|http\.client|httplib|socket|ftplib|smtplib)\b.*`),
+			regexp.MustCompile(`\b(urlopen|urlretrieve|requests\.(get|post|put)|socket\.socket|create_connection)\s*\(`),
+			regexp.MustCompile(`['"](curl|wget)\s`),
+		},
+		behavior: "accesses the network",
+	}
+
+	envHarvestCheck = check{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "SC003",
+			Type:               "Install Script Security Check",
+			Title:              "Credentials read at install time",
+			Description:        "The install script reads all the environment variables or credential files, such as '~/.npmrc' and '~/.aws/credentials'. Malicious packages use install scripts to steal tokens and keys.",
+			Severity:           "HIGH",
+			RecommendedActions: "Do not install the package, and rotate the credentials available on machines where the package was installed.",
+			References: []string{
+				"https://docs.npmjs.com/cli/using-npm/scripts#best-practices",
+			},
+		},
+		shellPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bprintenv\b`),
+			regexp.MustCompile(`(^|[;&|]\s*)(env|set)\s*(>|\||$)`),
+			regexp.MustCompile(`(JSON\.stringify|Object\.(keys|values|entries))\(\s*process\.env\s*\)`),
+			regexp.MustCompile(`\$\{?(NPM_TOKEN|NODE_AUTH_TOKEN|GITHUB_TOKEN|GH_TOKEN|AWS_SECRET_ACCESS_KEY|AWS_ACCESS_KEY_ID)\b`),
+			credentialFilePattern,
+		},
+		pythonPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bos\.environ\.(copy|items|keys|values)\(\)`),
+			regexp.MustCompile(`\b(dict|str|json\.dumps)\(\s*os\.environ\s*\)`),
+			credentialFilePattern,
+		},
+		behavior: "reads credentials",
+	}
+
+	obfuscationCheck = check{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "SC004",
+			Type:               "Install Script Security Check",
+			Title:              "Obfuscated code executed at install time",
+			Description:        "The install script decodes code and executes it. Malicious packages obfuscate install scripts to hide the payload from reviewers.",
+			Severity:           "MEDIUM",
+			RecommendedActions: "Decode and review the executed code, or install the package with scripts disabled.",
+			References: []string{
+				"https://docs.npmjs.com/cli/using-npm/scripts#best-practices",
+			},
+		},
+		shellPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bbase64\s+(-d|--decode)\b[^|;&]*\|\s*(ba)?sh\b`),
+			regexp.MustCompile(`\beval\(\s*(atob|Buffer\.from)\(`),
+		},
+		pythonPatterns: []*regexp.Regexp{
+			regexp.MustCompile(`\b(exec|eval)\s*\(.*\b(b64decode|decompress|fromhex|marshal\.loads|codecs\.decode)\s*\(`),
+		},
+		behavior: "executes obfuscated code",
+	}
+
+	// Checks are evaluated in this order
+	checks = []check{
+		remoteExecCheck,
+		networkCheck,
+		envHarvestCheck,
+		obfuscationCheck,
+	}
+
+	credentialFilePattern = regexp.MustCompile(`(\.npmrc|\.pypirc|\.netrc|\.git-credentials|\.ssh/id_\w+|\.aws/credentials|\.docker/config\.json|\.kube/config)\b`)
+)
@@ -0,0 +1,10 @@
+from setuptools import setup
+
+version = {}
+exec(open("benign/version.py").read(), version)
+
+setup(
+    name="benign",
+    version=version["__version__"],
+    url="https://github.com/example/benign",
+)
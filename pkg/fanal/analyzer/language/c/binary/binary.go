@@ -0,0 +1,46 @@
+package binary
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/c/binary"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/utils"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&cBinaryLibraryAnalyzer{})
+}
+
+const version = 1
+
+// cBinaryLibraryAnalyzer detects C libraries statically linked into ELF binaries, such as OpenSSL and zlib.
+type cBinaryLibraryAnalyzer struct{}
+
+func (a cBinaryLibraryAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	res, err := language.Analyze(types.CBinary, input.FilePath, input.Content, binary.NewParser())
+	if errors.Is(err, binary.ErrUnrecognizedExe) {
+		return nil, nil
+	} else if err != nil {
+		return nil, xerrors.Errorf("c binary parse error: %w", err)
+	}
+	return res, nil
+}
+
+func (a cBinaryLibraryAnalyzer) Required(_ string, fileInfo os.FileInfo) bool {
+	return utils.IsExecutable(fileInfo)
+}
+
+func (a cBinaryLibraryAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeCBinary
+}
+
+func (a cBinaryLibraryAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,105 @@
+package binary
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_cBinaryLibraryAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "happy path",
+			inputFile: "testdata/static.elf",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.CBinary,
+						FilePath: "testdata/static.elf",
+						Packages: types.Packages{
+							{
+								ID:      "libcurl/8.5.0",
+								Name:    "libcurl",
+								Version: "8.5.0",
+							},
+							{
+								ID:      "openssl/3.0.13",
+								Name:    "openssl",
+								Version: "3.0.13",
+							},
+							{
+								ID:      "pcre2/10.42",
+								Name:    "pcre2",
+								Version: "10.42",
+							},
+							{
+								ID:      "zlib/1.2.13",
+								Name:    "zlib",
+								Version: "1.2.13",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "not ELF",
+			inputFile: "testdata/executable_bash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := cBinaryLibraryAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				FilePath: tt.inputFile,
+				Content:  f,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_cBinaryLibraryAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "executable",
+			filePath: "testdata/static.elf",
+			want:     true,
+		},
+		{
+			name:     "source file",
+			filePath: "binary.go",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fi, err := os.Lstat(tt.filePath)
+			require.NoError(t, err)
+
+			a := cBinaryLibraryAnalyzer{}
+			got := a.Required(tt.filePath, fi)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -24,16 +24,19 @@ func init() {
 	analyzer.RegisterAnalyzer(&environmentAnalyzer{})
 }
 
-const version = 2
+const version = 3
 
-type parser struct{}
+type parser struct {
+	// Packages installed with pip in the "pip" section
+	pipPkgs []types.Package
+}
 
-func (*parser) Parse(r xio.ReadSeekerAt) ([]types.Package, []types.Dependency, error) {
-	p := environment.NewParser()
-	pkgs, err := p.Parse(r)
+func (p *parser) Parse(r xio.ReadSeekerAt) ([]types.Package, []types.Dependency, error) {
+	pkgs, err := environment.NewParser().Parse(r)
 	if err != nil {
 		return nil, nil, err
 	}
+	p.pipPkgs = pkgs.PipPackages
 
 	once := sync.Once{}
 	for i, pkg := range pkgs.Packages {
@@ -59,11 +62,24 @@ func (*parser) Parse(r xio.ReadSeekerAt) ([]types.Package, []types.Dependency, e
 type environmentAnalyzer struct{}
 
 func (a environmentAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
-	res, err := language.Analyze(types.CondaEnv, input.FilePath, input.Content, &parser{})
+	p := &parser{}
+	res, err := language.Analyze(types.CondaEnv, input.FilePath, input.Content, p)
 	if err != nil {
 		return nil, xerrors.Errorf("unable to parse environment.yaml: %w", err)
 	}
 
+	// Python packages in the "pip" section are installed by pip, not conda.
+	if len(p.pipPkgs) > 0 {
+		if res == nil {
+			res = &analyzer.AnalysisResult{}
+		}
+		res.Applications = append(res.Applications, types.Application{
+			Type:     types.Pip,
+			FilePath: input.FilePath,
+			Packages: p.pipPkgs,
+		})
+	}
+
 	if res == nil {
 		return nil, nil
 	}
@@ -72,6 +72,66 @@ func Test_environmentAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "happy path with pip packages",
+			inputFile: "testdata/environment-with-pip.yaml",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.CondaEnv,
+						FilePath: "testdata/environment-with-pip.yaml",
+						Packages: types.Packages{
+							{
+								Name:    "pip",
+								Version: "24.0",
+								Locations: []types.Location{
+									{
+										StartLine: 6,
+										EndLine:   6,
+									},
+								},
+							},
+							{
+								Name:    "python",
+								Version: "3.11.9",
+								Locations: []types.Location{
+									{
+										StartLine: 5,
+										EndLine:   5,
+									},
+								},
+							},
+						},
+					},
+					{
+						Type:     types.Pip,
+						FilePath: "testdata/environment-with-pip.yaml",
+						Packages: types.Packages{
+							{
+								Name:    "torch",
+								Version: "2.3.1",
+								Locations: []types.Location{
+									{
+										StartLine: 8,
+										EndLine:   8,
+									},
+								},
+							},
+							{
+								Name:    "transformers",
+								Version: "4.41.2",
+								Locations: []types.Location{
+									{
+										StartLine: 9,
+										EndLine:   9,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "happy path with licenses",
 			inputFile: "testdata/environment-with-licenses.yaml",
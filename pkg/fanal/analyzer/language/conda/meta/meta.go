@@ -16,9 +16,10 @@ func init() {
 	analyzer.RegisterAnalyzer(&metaAnalyzer{})
 }
 
-const version = 1
+const version = 2
 
-var fileRegex = regexp.MustCompile(`.*/envs/.+/conda-meta/.+-.+-.+\.json`)
+// conda-meta of the base environment (e.g. /opt/conda/conda-meta) and named environments (e.g. /opt/conda/envs/<env>/conda-meta)
+var fileRegex = regexp.MustCompile(`(^|/)conda-meta/[^/]+-[^/]+-[^/]+\.json$`)
 
 type metaAnalyzer struct{}
 
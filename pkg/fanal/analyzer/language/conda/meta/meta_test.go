@@ -85,6 +85,16 @@ func Test_packagingAnalyzer_Required(t *testing.T) {
 			filePath: "/home/<user>/miniconda3/envs/<env>/conda-meta/pip-22.2.2-py38h06a4308_0.json",
 			want:     true,
 		},
+		{
+			name:     "base environment",
+			filePath: "opt/conda/conda-meta/numpy-1.26.4-py311h24aa872_0.json",
+			want:     true,
+		},
+		{
+			name:     "not in conda-meta",
+			filePath: "opt/conda/pkgs/numpy-1.26.4-py311h24aa872_0/info/repodata_record.json",
+			want:     false,
+		},
 		{
 			name:     "invalid",
 			filePath: "/home/<user>/miniconda3/envs/<env>/conda-meta/invalid.json",
@@ -0,0 +1,136 @@
+package pack
+
+import (
+	"archive/tar"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/conda/meta"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&packAnalyzer{})
+}
+
+const version = 1
+
+// conda-pack stores the environment in the archive root.
+// e.g. ./conda-meta/numpy-1.26.4-py311h64a7726_0.json
+var metaRegex = regexp.MustCompile(`^(\./)?conda-meta/[^/]+-[^/]+-[^/]+\.json$`)
+
+// packAnalyzer analyzes conda environments archived by conda-pack.
+// cf. https://conda.github.io/conda-pack/
+type packAnalyzer struct {
+	logger *log.Logger
+}
+
+func (a *packAnalyzer) Init(_ analyzer.AnalyzerOptions) error {
+	a.logger = log.WithPrefix("conda-pack")
+	return nil
+}
+
+func (a *packAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	r, err := decompress(input.FilePath, input.Content)
+	if err != nil {
+		a.logger.Debug("Unable to decompress the archive", log.FilePath(input.FilePath), log.Err(err))
+		return nil, nil
+	}
+	defer r.Close()
+
+	var pkgs []types.Package
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			// Not a tar archive
+			a.logger.Debug("Unable to read the archive", log.FilePath(input.FilePath), log.Err(err))
+			return nil, nil
+		}
+		if hdr.Typeflag != tar.TypeReg || !metaRegex.MatchString(hdr.Name) {
+			continue
+		}
+
+		pkg, err := parseMeta(tr)
+		if err != nil {
+			a.logger.Debug("Unable to parse the conda package metadata", log.FilePath(input.FilePath),
+				log.String("entry", hdr.Name), log.Err(err))
+			continue
+		}
+		pkg.FilePath = path.Join(input.FilePath, path.Clean(hdr.Name))
+		pkgs = append(pkgs, pkg)
+	}
+
+	if len(pkgs) == 0 {
+		return nil, nil
+	}
+	sort.Sort(types.Packages(pkgs))
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     types.CondaPkg,
+				FilePath: input.FilePath,
+				Packages: pkgs,
+			},
+		},
+	}, nil
+}
+
+func (a *packAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return isGzip(filePath) || isBzip2(filePath)
+}
+
+func (a *packAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeCondaPack
+}
+
+func (a *packAnalyzer) Version() int {
+	return version
+}
+
+func isGzip(filePath string) bool {
+	return strings.HasSuffix(filePath, ".tar.gz") || strings.HasSuffix(filePath, ".tgz")
+}
+
+func isBzip2(filePath string) bool {
+	return strings.HasSuffix(filePath, ".tar.bz2")
+}
+
+func decompress(filePath string, r xio.ReadSeekerAt) (io.ReadCloser, error) {
+	if isBzip2(filePath) {
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	}
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, xerrors.Errorf("gzip reader error: %w", err)
+	}
+	return gr, nil
+}
+
+func parseMeta(r io.Reader) (types.Package, error) {
+	rr, err := xio.NewReadSeekerAt(r)
+	if err != nil {
+		return types.Package{}, xerrors.Errorf("reader error: %w", err)
+	}
+	pkgs, _, err := meta.NewParser().Parse(rr)
+	if err != nil {
+		return types.Package{}, err
+	}
+	return pkgs[0], nil
+}
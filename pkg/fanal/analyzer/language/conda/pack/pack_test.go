@@ -0,0 +1,103 @@
+package pack
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+func Test_packAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "conda-pack archive",
+			inputFile: "testdata/env.tar.gz",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.CondaPkg,
+						FilePath: "testdata/env.tar.gz",
+						Packages: types.Packages{
+							{
+								Name:     "openssl",
+								Version:  "3.0.13",
+								Licenses: []string{"Apache-2.0"},
+								FilePath: "testdata/env.tar.gz/conda-meta/openssl-3.0.13-h7f8727e_0.json",
+							},
+							{
+								Name:     "pip",
+								Version:  "22.2.2",
+								Licenses: []string{"MIT"},
+								FilePath: "testdata/env.tar.gz/conda-meta/pip-22.2.2-py38h06a4308_0.json",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "other archive",
+			inputFile: "testdata/other.tar.gz",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := &packAnalyzer{
+				logger: log.WithPrefix("conda-pack"),
+			}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				Content:  f,
+				FilePath: tt.inputFile,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_packAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "tar.gz",
+			filePath: "envs/ml-env.tar.gz",
+			want:     true,
+		},
+		{
+			name:     "tar.bz2",
+			filePath: "envs/ml-env.tar.bz2",
+			want:     true,
+		},
+		{
+			name:     "zip",
+			filePath: "envs/ml-env.zip",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := packAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
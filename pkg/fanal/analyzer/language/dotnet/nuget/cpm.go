@@ -0,0 +1,196 @@
+package nuget
+
+import (
+	"io"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/nuget/packagesprops"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/nuget/project"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const directoryPackagesProps = "directory.packages.props"
+
+var projectFileExts = []string{
+	".csproj",
+	".fsproj",
+	".vbproj",
+}
+
+func isDirectoryPackagesProps(filePath string) bool {
+	// NuGet works correctly with lowercase filenames
+	return strings.EqualFold(filepath.Base(filePath), directoryPackagesProps)
+}
+
+func isProjectFile(filePath string) bool {
+	return slices.Contains(projectFileExts, strings.ToLower(filepath.Ext(filePath)))
+}
+
+// centralPackageManagement resolves versions of packages referenced by project files
+// with the nearest Directory.Packages.props.
+// cf. https://learn.microsoft.com/en-us/nuget/consume-packages/central-package-management
+type centralPackageManagement struct {
+	// Directory.Packages.props files with Central Package Management enabled, keyed by directory
+	props     map[string]packagesprops.Props
+	propsPath map[string]string
+	projects  map[string]project.Project
+	// Directories containing packages.lock.json
+	lockDirs map[string]struct{}
+}
+
+func newCentralPackageManagement() *centralPackageManagement {
+	return &centralPackageManagement{
+		props:     make(map[string]packagesprops.Props),
+		propsPath: make(map[string]string),
+		projects:  make(map[string]project.Project),
+		lockDirs:  make(map[string]struct{}),
+	}
+}
+
+func (c *centralPackageManagement) addProps(filePath string, r io.Reader) error {
+	props, err := packagesprops.ParseProps(r)
+	if err != nil {
+		return xerrors.Errorf("%s parse error: %w", filePath, err)
+	}
+	// Directory.Packages.props without Central Package Management is handled by the packages.props analyzer
+	if !props.CentralPackageManagement() {
+		return nil
+	}
+	dir := path.Dir(filePath)
+	c.props[dir] = props
+	c.propsPath[dir] = filePath
+	return nil
+}
+
+func (c *centralPackageManagement) addProject(filePath string, r io.Reader) error {
+	proj, err := project.Parse(r)
+	if err != nil {
+		return xerrors.Errorf("%s parse error: %w", filePath, err)
+	}
+	c.projects[filePath] = proj
+	return nil
+}
+
+func (c *centralPackageManagement) addLockFile(filePath string) {
+	c.lockDirs[path.Dir(filePath)] = struct{}{}
+}
+
+// nearestProps returns the directory of the nearest Directory.Packages.props.
+// MSBuild only imports the first file found walking up from the project directory.
+func (c *centralPackageManagement) nearestProps(dir string) (string, bool) {
+	for {
+		if _, ok := c.props[dir]; ok {
+			return dir, true
+		}
+		if dir == "." || dir == "/" {
+			return "", false
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+func (c *centralPackageManagement) applications(logger *log.Logger) []types.Application {
+	var apps []types.Application
+	usedProps := make(map[string]struct{})
+	for _, projectPath := range lo.Keys(c.projects) {
+		dir := path.Dir(projectPath)
+		propsDir, ok := c.nearestProps(dir)
+		if !ok {
+			continue
+		}
+		usedProps[propsDir] = struct{}{}
+
+		// packages.lock.json contains the resolved versions, including transitive ones
+		if _, ok = c.lockDirs[dir]; ok {
+			continue
+		}
+
+		proj := c.projects[projectPath]
+		if v, ok := proj.Properties["ManagePackageVersionsCentrally"]; ok && strings.EqualFold(v, "false") {
+			logger.Debug("Central Package Management is disabled in the project", log.FilePath(projectPath))
+			continue
+		}
+
+		pkgs := c.resolve(projectPath, proj, c.props[propsDir], logger)
+		if len(pkgs) == 0 {
+			continue
+		}
+		apps = append(apps, types.Application{
+			Type:     types.NuGet,
+			FilePath: projectPath,
+			Packages: pkgs,
+		})
+	}
+
+	// Report the central versions of Directory.Packages.props not imported by any projects,
+	// e.g. when only Directory.Packages.props is scanned.
+	for dir, props := range c.props {
+		if _, ok := usedProps[dir]; ok {
+			continue
+		}
+		pkgs := utils.UniquePackages(append(props.Packages, props.GlobalPackages...))
+		if len(pkgs) == 0 {
+			continue
+		}
+		apps = append(apps, types.Application{
+			Type:     types.PackagesProps,
+			FilePath: c.propsPath[dir],
+			Packages: pkgs,
+		})
+	}
+
+	slices.SortFunc(apps, func(a, b types.Application) int {
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+	return apps
+}
+
+// resolve returns the packages referenced by the project.
+// The version is taken from VersionOverride, the central version and the Version attribute in that order.
+func (c *centralPackageManagement) resolve(projectPath string, proj project.Project, props packagesprops.Props, logger *log.Logger) types.Packages {
+	// Project properties take precedence over the properties in Directory.Packages.props
+	properties := lo.Assign(props.Properties, proj.Properties)
+
+	// Package IDs are case-insensitive
+	centralPkgs := lo.SliceToMap(props.Packages, func(pkg types.Package) (string, types.Package) {
+		return strings.ToLower(pkg.Name), pkg
+	})
+
+	var pkgs types.Packages
+	for _, ref := range proj.References {
+		name := packagesprops.ResolveProperties(ref.Name, properties)
+		central, ok := centralPkgs[strings.ToLower(name)]
+		if ok {
+			// Prefer the name in Directory.Packages.props
+			name = central.Name
+		}
+		version := lo.CoalesceOrEmpty(
+			packagesprops.NormalizeVersion(packagesprops.ResolveProperties(ref.VersionOverride, properties)),
+			central.Version,
+			packagesprops.NormalizeVersion(packagesprops.ResolveProperties(ref.Version, properties)),
+		)
+		if version == "" || strings.Contains(version, "$(") {
+			logger.Debug("Unable to resolve the package version", log.FilePath(projectPath), log.String("package", name))
+			continue
+		}
+		pkgs = append(pkgs, types.Package{
+			ID:           dependency.ID(types.NuGet, name, version),
+			Name:         name,
+			Version:      version,
+			Relationship: types.RelationshipDirect,
+		})
+	}
+
+	// GlobalPackageReference applies to every project
+	pkgs = append(pkgs, props.GlobalPackages...)
+	return utils.UniquePackages(pkgs)
+}
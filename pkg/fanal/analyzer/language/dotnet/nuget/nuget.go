@@ -26,7 +26,7 @@ func init() {
 }
 
 const (
-	version    = 3
+	version    = 4
 	lockFile   = types.NuGetPkgsLock
 	configFile = types.NuGetPkgsConfig
 )
@@ -59,13 +59,23 @@ func (a *nugetLibraryAnalyzer) PostAnalyze(_ context.Context, input analyzer.Pos
 		a.logger.Debug("The nuget packages directory couldn't be found. License search disabled")
 	}
 
-	// We saved only config and lock files in the FS,
+	// We saved only config, lock, project and Directory.Packages.props files in the FS,
 	// so we need to parse all saved files
 	required := func(path string, d fs.DirEntry) bool {
 		return true
 	}
 
+	cpm := newCentralPackageManagement()
 	err := fsutils.WalkDir(input.FS, ".", required, func(path string, d fs.DirEntry, r io.Reader) error {
+		switch {
+		case isDirectoryPackagesProps(path):
+			return cpm.addProps(path, r)
+		case isProjectFile(path):
+			return cpm.addProject(path, r)
+		case filepath.Base(path) == lockFile:
+			cpm.addLockFile(path)
+		}
+
 		// Set the default parser
 		parser := a.lockParser
 
@@ -84,25 +94,30 @@ func (a *nugetLibraryAnalyzer) PostAnalyze(_ context.Context, input analyzer.Pos
 			return nil
 		}
 
+		apps = append(apps, *app)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("NuGet walk error: %w", err)
+	}
+
+	// Projects using Central Package Management
+	apps = append(apps, cpm.applications(a.logger)...)
+
+	for _, app := range apps {
 		for i, lib := range app.Packages {
 			license, ok := foundLicenses[lib.ID]
 			if !ok {
 				license, err = a.licenseParser.findLicense(lib.Name, lib.Version)
 				if err != nil && !errors.Is(err, fs.ErrNotExist) {
-					return xerrors.Errorf("license find error: %w", err)
+					return nil, xerrors.Errorf("license find error: %w", err)
 				}
 				foundLicenses[lib.ID] = license
 			}
 
 			app.Packages[i].Licenses = license
 		}
-
 		sort.Sort(app.Packages)
-		apps = append(apps, *app)
-		return nil
-	})
-	if err != nil {
-		return nil, xerrors.Errorf("NuGet walk error: %w", err)
 	}
 
 	return &analyzer.AnalysisResult{
@@ -112,7 +127,7 @@ func (a *nugetLibraryAnalyzer) PostAnalyze(_ context.Context, input analyzer.Pos
 
 func (a *nugetLibraryAnalyzer) Required(filePath string, _ os.FileInfo) bool {
 	fileName := filepath.Base(filePath)
-	return slices.Contains(requiredFiles, fileName)
+	return slices.Contains(requiredFiles, fileName) || isDirectoryPackagesProps(filePath) || isProjectFile(filePath)
 }
 
 func (a *nugetLibraryAnalyzer) Type() analyzer.Type {
@@ -172,6 +172,114 @@ func Test_nugetLibraryAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path Central Package Management.",
+			dir:  "testdata/cpm",
+			env: map[string]string{
+				"HOME": "testdata/repository",
+			},
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.NuGet,
+						FilePath: "src/Locked/packages.lock.json",
+						Packages: types.Packages{
+							{
+								ID:           "NuGet.Frameworks@5.7.0",
+								Name:         "NuGet.Frameworks",
+								Version:      "5.7.0",
+								Relationship: types.RelationshipDirect,
+								Locations: []types.Location{
+									{
+										StartLine: 5,
+										EndLine:   13,
+									},
+								},
+								DependsOn: []string{"Newtonsoft.Json@12.0.3"},
+							},
+							{
+								ID:           "Newtonsoft.Json@12.0.3",
+								Name:         "Newtonsoft.Json",
+								Version:      "12.0.3",
+								Indirect:     true,
+								Relationship: types.RelationshipIndirect,
+								Locations: []types.Location{
+									{
+										StartLine: 14,
+										EndLine:   19,
+									},
+								},
+								Licenses: []string{"MIT"},
+							},
+						},
+					},
+					{
+						Type:     types.NuGet,
+						FilePath: "src/App/App.csproj",
+						Packages: types.Packages{
+							{
+								ID:           "Newtonsoft.Json@12.0.3",
+								Name:         "Newtonsoft.Json",
+								Version:      "12.0.3",
+								Relationship: types.RelationshipDirect,
+								Licenses:     []string{"MIT"},
+							},
+							{
+								ID:           "Serilog@3.0.0",
+								Name:         "Serilog",
+								Version:      "3.0.0",
+								Relationship: types.RelationshipDirect,
+							},
+							{
+								ID:           "StyleCop.Analyzers@1.1.118",
+								Name:         "StyleCop.Analyzers",
+								Version:      "1.1.118",
+								Relationship: types.RelationshipDirect,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "happy path Directory.Packages.props without projects.",
+			dir:  "testdata/cpm-props-only",
+			env: map[string]string{
+				"HOME": "testdata/repository",
+			},
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.PackagesProps,
+						FilePath: "Directory.Packages.props",
+						Packages: types.Packages{
+							{
+								ID:           "StyleCop.Analyzers@1.1.118",
+								Name:         "StyleCop.Analyzers",
+								Version:      "1.1.118",
+								Relationship: types.RelationshipDirect,
+							},
+							{
+								ID:       "Newtonsoft.Json@12.0.3",
+								Name:     "Newtonsoft.Json",
+								Version:  "12.0.3",
+								Licenses: []string{"MIT"},
+							},
+							{
+								ID:      "NuGet.Frameworks@5.7.0",
+								Name:    "NuGet.Frameworks",
+								Version: "5.7.0",
+							},
+							{
+								ID:      "Serilog@3.1.1",
+								Name:    "Serilog",
+								Version: "3.1.1",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "happy path lock file without dependencies.",
 			dir:  "testdata/lock-without-deps",
@@ -223,6 +331,16 @@ func Test_nugetLibraryAnalyzer_Required(t *testing.T) {
 			filePath: "test/packages.lock.json",
 			want:     true,
 		},
+		{
+			name:     "project",
+			filePath: "test/App.csproj",
+			want:     true,
+		},
+		{
+			name:     "directory packages props",
+			filePath: "test/directory.packages.props",
+			want:     true,
+		},
 		{
 			name:     "zip",
 			filePath: "test.zip",
@@ -2,7 +2,9 @@ package packagesprops
 
 import (
 	"context"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"golang.org/x/xerrors"
@@ -18,13 +20,29 @@ func init() {
 }
 
 const (
-	version             = 1
-	packagesPropsSuffix = "packages.props" // https://github.com/dotnet/roslyn-tools/blob/b4c5220f5dfc4278847b6d38eff91cc1188f8066/src/RoslynInsertionTool/RoslynInsertionTool/CoreXT.cs#L39-L40
+	version                = 2
+	packagesPropsSuffix    = "packages.props" // https://github.com/dotnet/roslyn-tools/blob/b4c5220f5dfc4278847b6d38eff91cc1188f8066/src/RoslynInsertionTool/RoslynInsertionTool/CoreXT.cs#L39-L40
+	directoryPackagesProps = "directory.packages.props"
 )
 
 type packagesPropsAnalyzer struct{}
 
 func (a packagesPropsAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	// Directory.Packages.props with Central Package Management enabled is handled by the NuGet analyzer,
+	// which resolves the versions of packages referenced by projects.
+	if strings.EqualFold(filepath.Base(input.FilePath), directoryPackagesProps) {
+		p, err := props.ParseProps(input.Content)
+		if err != nil {
+			return nil, xerrors.Errorf("*Packages.props parse error: %w", err)
+		}
+		if p.CentralPackageManagement() {
+			return nil, nil
+		}
+		if _, err = input.Content.Seek(0, io.SeekStart); err != nil {
+			return nil, xerrors.Errorf("seek error: %w", err)
+		}
+	}
+
 	parser := props.NewParser()
 	res, err := language.Analyze(types.PackagesProps, input.FilePath, input.Content, parser)
 	if err != nil {
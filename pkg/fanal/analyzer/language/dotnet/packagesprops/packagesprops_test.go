@@ -67,6 +67,11 @@ func Test_packagesPropsAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "central package management",
+			inputFile: "testdata/cpm/Directory.Packages.props",
+			want:      nil,
+		},
 		{
 			name:      "sad path",
 			inputFile: "testdata/invalid.txt",
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"os"
 
+	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/dependency/parser/golang/binary"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/golang/license"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/fanal/utils"
 )
@@ -20,9 +22,23 @@ func init() {
 
 const version = 1
 
-type gobinaryLibraryAnalyzer struct{}
+type gobinaryLibraryAnalyzer struct {
+	// licenseResolver resolves licenses of the embedded modules. It is nil unless sources are configured.
+	licenseResolver *license.Resolver
+}
 
-func (a gobinaryLibraryAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+func (a *gobinaryLibraryAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	sources := opt.LicenseScannerOption.GoBinarySources
+	if len(sources) == 0 {
+		return nil
+	}
+	a.licenseResolver = license.NewResolver(lo.Map(sources, func(s string, _ int) license.Source {
+		return license.Source(s)
+	}), opt.LicenseScannerOption.ClassifierConfidenceLevel)
+	return nil
+}
+
+func (a *gobinaryLibraryAnalyzer) Analyze(ctx context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
 	p := binary.NewParser()
 	res, err := language.Analyze(types.GoBinary, input.FilePath, input.Content, p)
 	if errors.Is(err, binary.ErrUnrecognizedExe) || errors.Is(err, binary.ErrNonGoBinary) {
@@ -31,17 +47,33 @@ func (a gobinaryLibraryAnalyzer) Analyze(_ context.Context, input analyzer.Analy
 		return nil, xerrors.Errorf("go binary (filepath: %s) parse error: %w", input.FilePath, err)
 	}
 
+	if a.licenseResolver != nil && res != nil {
+		a.fillLicenses(ctx, res.Applications, input.Options.Offline)
+	}
+
 	return res, nil
 }
 
-func (a gobinaryLibraryAnalyzer) Required(_ string, fileInfo os.FileInfo) bool {
+// fillLicenses fills licenses of the embedded modules, as Go binaries don't have license information
+func (a *gobinaryLibraryAnalyzer) fillLicenses(ctx context.Context, apps []types.Application, offline bool) {
+	for i := range apps {
+		for j, pkg := range apps[i].Packages {
+			if pkg.Name == "stdlib" || len(pkg.Licenses) > 0 {
+				continue
+			}
+			apps[i].Packages[j].Licenses = a.licenseResolver.Resolve(ctx, pkg.Name, pkg.Version, offline)
+		}
+	}
+}
+
+func (a *gobinaryLibraryAnalyzer) Required(_ string, fileInfo os.FileInfo) bool {
 	return utils.IsExecutable(fileInfo)
 }
 
-func (a gobinaryLibraryAnalyzer) Type() analyzer.Type {
+func (a *gobinaryLibraryAnalyzer) Type() analyzer.Type {
 	return analyzer.TypeGoBinary
 }
 
-func (a gobinaryLibraryAnalyzer) Version() int {
+func (a *gobinaryLibraryAnalyzer) Version() int {
 	return version
 }
@@ -16,9 +16,10 @@ import (
 
 func Test_gobinaryLibraryAnalyzer_Analyze(t *testing.T) {
 	tests := []struct {
-		name      string
-		inputFile string
-		want      *analyzer.AnalysisResult
+		name           string
+		inputFile      string
+		licenseSources []string
+		want           *analyzer.AnalysisResult
 	}{
 		{
 			name:      "happy path",
@@ -67,6 +68,55 @@ func Test_gobinaryLibraryAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "with licenses from module cache",
+			inputFile:      "testdata/executable_gobinary",
+			licenseSources: []string{"cache"},
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.GoBinary,
+						FilePath: "testdata/executable_gobinary",
+						Packages: types.Packages{
+							{
+								ID:           "github.com/aquasecurity/test",
+								Name:         "github.com/aquasecurity/test",
+								Version:      "",
+								Relationship: types.RelationshipRoot,
+								DependsOn: []string{
+									"github.com/aquasecurity/go-pep440-version@v0.0.0-20210121094942-22b2f8951d46",
+									"github.com/aquasecurity/go-version@v0.0.0-20210121072130-637058cfe492",
+									"golang.org/x/xerrors@v0.0.0-20200804184101-5ec99f83aff1",
+									"stdlib@v1.15.2",
+								},
+							},
+							{
+								ID:           "stdlib@v1.15.2",
+								Name:         "stdlib",
+								Version:      "v1.15.2",
+								Relationship: types.RelationshipDirect,
+							},
+							{
+								ID:      "github.com/aquasecurity/go-pep440-version@v0.0.0-20210121094942-22b2f8951d46",
+								Name:    "github.com/aquasecurity/go-pep440-version",
+								Version: "v0.0.0-20210121094942-22b2f8951d46",
+							},
+							{
+								ID:      "github.com/aquasecurity/go-version@v0.0.0-20210121072130-637058cfe492",
+								Name:    "github.com/aquasecurity/go-version",
+								Version: "v0.0.0-20210121072130-637058cfe492",
+							},
+							{
+								ID:       "golang.org/x/xerrors@v0.0.0-20200804184101-5ec99f83aff1",
+								Name:     "golang.org/x/xerrors",
+								Version:  "v0.0.0-20200804184101-5ec99f83aff1",
+								Licenses: []string{"BSD-3-Clause"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "not go binary",
 			inputFile: "testdata/executable_bash",
@@ -82,7 +132,16 @@ func Test_gobinaryLibraryAnalyzer_Analyze(t *testing.T) {
 			require.NoError(t, err)
 			defer f.Close()
 
-			a := gobinaryLibraryAnalyzer{}
+			t.Setenv("GOMODCACHE", "testdata/modcache")
+			a := &gobinaryLibraryAnalyzer{}
+			err = a.Init(analyzer.AnalyzerOptions{
+				LicenseScannerOption: analyzer.LicenseScannerOption{
+					ClassifierConfidenceLevel: 0.9,
+					GoBinarySources:           tt.licenseSources,
+				},
+			})
+			require.NoError(t, err)
+
 			ctx := context.Background()
 			got, err := a.Analyze(ctx, analyzer.AnalysisInput{
 				FilePath: tt.inputFile,
@@ -119,7 +178,7 @@ func Test_gobinaryLibraryAnalyzer_Required(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			a := gobinaryLibraryAnalyzer{}
+			a := &gobinaryLibraryAnalyzer{}
 			fileInfo, err := os.Lstat(tt.filePath)
 			require.NoError(t, err)
 			got := a.Required(tt.filePath, fileInfo)
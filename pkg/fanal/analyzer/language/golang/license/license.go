@@ -0,0 +1,333 @@
+package license
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Source is where licenses of Go modules are resolved from
+type Source string
+
+const (
+	// SourceCache is the local module cache, such as $GOPATH/pkg/mod
+	SourceCache Source = "cache"
+	// SourceProxy is the module proxy configured by GOPROXY, such as https://proxy.golang.org
+	SourceProxy Source = "proxy"
+)
+
+var Sources = []Source{
+	SourceCache,
+	SourceProxy,
+}
+
+const (
+	defaultProxy = "https://proxy.golang.org"
+
+	// maxZipSize is the maximum size of module zip files downloaded from the proxy
+	maxZipSize = 100 << 20
+
+	// proxyTimeout is the timeout to download a module zip file from the proxy
+	proxyTimeout = 2 * time.Minute
+)
+
+var licenseRegexp = regexp.MustCompile(`^(?i)((UN)?LICEN(S|C)E|COPYING|README|NOTICE).*$`)
+
+// Resolver resolves licenses of Go modules that are not available in the scanned files,
+// such as dependencies embedded in Go binaries.
+type Resolver struct {
+	logger                    *log.Logger
+	sources                   []Source
+	modCacheDir               string
+	proxyURL                  string // empty if the proxy is disabled
+	noProxy                   string // patterns of modules not fetched from the proxy
+	client                    *http.Client
+	classifierConfidenceLevel float64
+
+	mu       sync.Mutex
+	licenses map[string][]string // module@version => licenses
+
+	// group deduplicates concurrent lookups of the same module, e.g. in multiple Go binaries
+	group singleflight.Group
+}
+
+type Option func(*Resolver)
+
+// WithProxyURL overrides the module proxy configured by GOPROXY
+func WithProxyURL(url string) Option {
+	return func(r *Resolver) {
+		r.proxyURL = url
+	}
+}
+
+// WithModCacheDir overrides the module cache directory
+func WithModCacheDir(dir string) Option {
+	return func(r *Resolver) {
+		r.modCacheDir = dir
+	}
+}
+
+func NewResolver(sources []Source, classifierConfidenceLevel float64, opts ...Option) *Resolver {
+	r := &Resolver{
+		logger:                    log.WithPrefix("gobinary"),
+		sources:                   sources,
+		modCacheDir:               ModCacheDir(),
+		proxyURL:                  proxyURL(os.Getenv("GOPROXY")),
+		noProxy:                   noProxyPatterns(),
+		client:                    &http.Client{Timeout: proxyTimeout},
+		classifierConfidenceLevel: classifierConfidenceLevel,
+		licenses:                  make(map[string][]string),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Resolve returns the licenses of the module, trying the sources in order.
+// The proxy is not used if offline is true.
+// It returns nil if the licenses are not found.
+func (r *Resolver) Resolve(ctx context.Context, modPath, version string, offline bool) []string {
+	// Modules replaced with local directories or the main module built from source don't have a version
+	if module.Check(modPath, version) != nil {
+		return nil
+	}
+
+	id := modPath + "@" + version
+	r.mu.Lock()
+	licenses, ok := r.licenses[id]
+	r.mu.Unlock()
+	if ok {
+		return licenses
+	}
+
+	// The lock is not held while the module is being downloaded, so that other modules are resolved in parallel
+	v, _, _ := r.group.Do(id, func() (any, error) {
+		licenses := r.resolve(ctx, modPath, version, offline)
+
+		// Cache missing licenses as well not to download the module again
+		r.mu.Lock()
+		r.licenses[id] = licenses
+		r.mu.Unlock()
+		return licenses, nil
+	})
+	return v.([]string)
+}
+
+func (r *Resolver) resolve(ctx context.Context, modPath, version string, offline bool) []string {
+	var licenses []string
+	for _, source := range r.sources {
+		var err error
+		switch source {
+		case SourceCache:
+			licenses, err = r.fromCache(modPath, version)
+		case SourceProxy:
+			if offline {
+				continue
+			}
+			licenses, err = r.fromProxy(ctx, modPath, version)
+		}
+		if err != nil {
+			r.logger.Debug("Unable to resolve licenses", log.String("module", modPath+"@"+version),
+				log.String("source", string(source)), log.Err(err))
+		}
+		if len(licenses) > 0 {
+			break
+		}
+	}
+	return licenses
+}
+
+func (r *Resolver) fromCache(modPath, version string) ([]string, error) {
+	escapedPath, escapedVersion, err := escape(modPath, version)
+	if err != nil {
+		return nil, err
+	}
+	// e.g. $GOPATH/pkg/mod/github.com/!burnt!sushi/toml@v1.3.2
+	modDir := filepath.Join(r.modCacheDir, escapedPath+"@"+escapedVersion)
+	return FindLicense(modDir, r.classifierConfidenceLevel)
+}
+
+func (r *Resolver) fromProxy(ctx context.Context, modPath, version string) ([]string, error) {
+	if r.proxyURL == "" || module.MatchPrefixPatterns(r.noProxy, modPath) {
+		return nil, nil
+	}
+	escapedPath, escapedVersion, err := escape(modPath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// e.g. https://proxy.golang.org/github.com/!burnt!sushi/toml/@v/v1.3.2.zip
+	url := fmt.Sprintf("%s/%s/@v/%s.zip", strings.TrimSuffix(r.proxyURL, "/"), escapedPath, escapedVersion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to create a request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, xerrors.Errorf("unable to download %s: %s", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxZipSize+1))
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read %s: %w", url, err)
+	} else if len(b) > maxZipSize {
+		return nil, xerrors.Errorf("%s exceeds the maximum size (%d bytes)", url, maxZipSize)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, xerrors.Errorf("zip error: %w", err)
+	}
+	return r.findLicenseInZip(zr, modPath+"@"+version+"/")
+}
+
+// findLicenseInZip classifies license files in the module root of the zip file.
+// Files in the zip are prefixed with "<module>@<version>/".
+func (r *Resolver) findLicenseInZip(zr *zip.Reader, prefix string) ([]string, error) {
+	files := slices.Clone(zr.File)
+	slices.SortFunc(files, func(a, b *zip.File) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, f := range files {
+		name, ok := strings.CutPrefix(f.Name, prefix)
+		if !ok || strings.Contains(name, "/") || !licenseRegexp.MatchString(name) {
+			continue
+		}
+		license, err := classify(f.Name, f.Open, r.classifierConfidenceLevel)
+		if err != nil {
+			return nil, err
+		} else if license != nil && len(license.Findings) > 0 {
+			return license.Findings.Names(), nil
+		}
+	}
+	return nil, nil
+}
+
+// FindLicense classifies license files in the module directory and returns the licenses of the first one found
+func FindLicense(dir string, classifierConfidenceLevel float64) ([]string, error) {
+	var license *types.LicenseFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if !d.Type().IsRegular() {
+			return nil
+		}
+		if !licenseRegexp.MatchString(filepath.Base(path)) {
+			return nil
+		}
+		// e.g. $GOPATH/pkg/mod/github.com/aquasecurity/go-dep-parser@v0.0.0-20220406074731-71021a481237/LICENSE
+		l, err := classify(path, func() (io.ReadCloser, error) {
+			return os.Open(path)
+		}, classifierConfidenceLevel)
+		if err != nil {
+			return err
+		}
+		// License found
+		if l != nil && len(l.Findings) > 0 {
+			license = l
+			return io.EOF
+		}
+		return nil
+	})
+
+	switch {
+	// The module path may not exist
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil && !errors.Is(err, io.EOF):
+		return nil, fmt.Errorf("finding a known open source license: %w", err)
+	case license == nil || len(license.Findings) == 0:
+		return nil, nil
+	}
+
+	return license.Findings.Names(), nil
+}
+
+func classify(path string, open func() (io.ReadCloser, error), classifierConfidenceLevel float64) (*types.LicenseFile, error) {
+	f, err := open()
+	if err != nil {
+		return nil, xerrors.Errorf("file (%s) open error: %w", path, err)
+	}
+	defer f.Close()
+
+	l, err := licensing.Classify(path, f, classifierConfidenceLevel)
+	if err != nil {
+		return nil, xerrors.Errorf("license classify error: %w", err)
+	}
+	return l, nil
+}
+
+// ModCacheDir returns the module cache directory, i.e. $GOMODCACHE or $GOPATH/pkg/mod
+func ModCacheDir() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		gopath = build.Default.GOPATH
+	}
+	// GOPATH may have several paths, and the module cache is in the first one
+	gopath, _, _ = strings.Cut(gopath, string(os.PathListSeparator))
+	return filepath.Join(gopath, "pkg", "mod")
+}
+
+// proxyURL returns the first proxy in GOPROXY, e.g. "https://proxy.example.com,direct".
+// It returns an empty string if the proxy is disabled by "direct" or "off".
+func proxyURL(goproxy string) string {
+	if goproxy == "" {
+		return defaultProxy
+	}
+	proxy, _, _ := strings.Cut(goproxy, ",")
+	proxy, _, _ = strings.Cut(proxy, "|")
+	switch proxy = strings.TrimSpace(proxy); proxy {
+	case "direct", "off":
+		return ""
+	}
+	return proxy
+}
+
+// noProxyPatterns returns the patterns of private modules that must not be fetched from the proxy
+func noProxyPatterns() string {
+	if patterns := os.Getenv("GONOPROXY"); patterns != "" {
+		return patterns
+	}
+	return os.Getenv("GOPRIVATE")
+}
+
+func escape(modPath, version string) (string, string, error) {
+	escapedPath, err := module.EscapePath(modPath)
+	if err != nil {
+		return "", "", xerrors.Errorf("module path escape error: %w", err)
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", "", xerrors.Errorf("module version escape error: %w", err)
+	}
+	return escapedPath, escapedVersion, nil
+}
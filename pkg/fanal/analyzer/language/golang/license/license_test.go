@@ -0,0 +1,158 @@
+package license_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/golang/license"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	mit, err := os.ReadFile("testdata/modcache/github.com/!burnt!sushi/toml@v1.3.2/COPYING")
+	require.NoError(t, err)
+
+	// Module zip served by the proxy
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range map[string][]byte{
+		"github.com/aquasecurity/go-version@v0.1.0/go.mod":             []byte("module github.com/aquasecurity/go-version\n"),
+		"github.com/aquasecurity/go-version@v0.1.0/LICENSE":            mit,
+		"github.com/aquasecurity/go-version@v0.1.0/vendor/foo/LICENSE": []byte("Apache License 2.0"),
+	} {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.URL.Path != "/github.com/aquasecurity/go-version/@v/v0.1.0.zip" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name         string
+		sources      []license.Source
+		modPath      string
+		version      string
+		offline      bool
+		want         []string
+		wantRequests []string
+	}{
+		{
+			name:    "module cache",
+			sources: []license.Source{license.SourceCache},
+			modPath: "github.com/BurntSushi/toml",
+			version: "v1.3.2",
+			want:    []string{"MIT"},
+		},
+		{
+			name:         "module cache first",
+			sources:      []license.Source{license.SourceCache, license.SourceProxy},
+			modPath:      "github.com/BurntSushi/toml",
+			version:      "v1.3.2",
+			want:         []string{"MIT"},
+			wantRequests: nil,
+		},
+		{
+			name:         "proxy",
+			sources:      []license.Source{license.SourceCache, license.SourceProxy},
+			modPath:      "github.com/aquasecurity/go-version",
+			version:      "v0.1.0",
+			want:         []string{"MIT"},
+			wantRequests: []string{"/github.com/aquasecurity/go-version/@v/v0.1.0.zip"},
+		},
+		{
+			name:         "not found",
+			sources:      []license.Source{license.SourceCache, license.SourceProxy},
+			modPath:      "github.com/aquasecurity/unknown",
+			version:      "v1.0.0",
+			wantRequests: []string{"/github.com/aquasecurity/unknown/@v/v1.0.0.zip"},
+		},
+		{
+			name:    "offline",
+			sources: []license.Source{license.SourceCache, license.SourceProxy},
+			modPath: "github.com/aquasecurity/go-version",
+			version: "v0.1.0",
+			offline: true,
+		},
+		{
+			name:    "proxy not enabled",
+			sources: []license.Source{license.SourceCache},
+			modPath: "github.com/aquasecurity/go-version",
+			version: "v0.1.0",
+		},
+		{
+			name:    "no version",
+			sources: []license.Source{license.SourceCache, license.SourceProxy},
+			modPath: "github.com/aquasecurity/go-version",
+			version: "(devel)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			r := license.NewResolver(tt.sources, 0.9,
+				license.WithModCacheDir("testdata/modcache"), license.WithProxyURL(ts.URL))
+
+			got := r.Resolve(context.Background(), tt.modPath, tt.version, tt.offline)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequests, requests)
+
+			// The result is cached
+			got = r.Resolve(context.Background(), tt.modPath, tt.version, tt.offline)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRequests, requests)
+		})
+	}
+}
+
+func TestResolver_Resolve_Concurrent(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		http.NotFound(w, r)
+	}))
+	defer ts.Close()
+
+	r := license.NewResolver([]license.Source{license.SourceCache, license.SourceProxy}, 0.9,
+		license.WithModCacheDir("testdata/modcache"), license.WithProxyURL(ts.URL))
+
+	// Another module is resolved while the first module is being downloaded
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, r.Resolve(context.Background(), "github.com/aquasecurity/unknown", "v1.0.0", false))
+		}()
+	}
+	assert.Eventually(t, func() bool { return requests.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"MIT"}, r.Resolve(context.Background(), "github.com/BurntSushi/toml", "v1.3.2", false))
+
+	close(release)
+	wg.Wait()
+
+	// The concurrent lookups of the same module share a single download
+	assert.Equal(t, int32(1), requests.Load())
+}
@@ -9,8 +9,8 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"regexp"
 	"slices"
+	"sort"
 	"unicode"
 
 	"github.com/samber/lo"
@@ -20,8 +20,8 @@ import (
 	"github.com/aquasecurity/trivy/pkg/dependency/parser/golang/sum"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/golang/license"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
-	"github.com/aquasecurity/trivy/pkg/licensing"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
 	xio "github.com/aquasecurity/trivy/pkg/x/io"
@@ -31,14 +31,15 @@ func init() {
 	analyzer.RegisterPostAnalyzer(analyzer.TypeGoMod, newGoModAnalyzer)
 }
 
-const version = 2
+const version = 3
 
 var (
 	requiredFiles = []string{
 		types.GoMod,
 		types.GoSum,
+		types.GoWork,
+		types.GoWorkSum,
 	}
-	licenseRegexp = regexp.MustCompile(`^(?i)((UN)?LICEN(S|C)E|COPYING|README|NOTICE).*$`)
 )
 
 type gomodAnalyzer struct {
@@ -67,11 +68,17 @@ func newGoModAnalyzer(opt analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, erro
 func (a *gomodAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
 	var apps []types.Application
 
+	// Modules in workspaces are analyzed together
+	workspaces, err := a.parseWorkspaces(input.FS)
+	if err != nil {
+		return nil, xerrors.Errorf("go.work error: %w", err)
+	}
+
 	required := func(path string, d fs.DirEntry) bool {
 		return filepath.Base(path) == types.GoMod
 	}
 
-	err := fsutils.WalkDir(input.FS, ".", required, func(path string, d fs.DirEntry, _ io.Reader) error {
+	err = fsutils.WalkDir(input.FS, ".", required, func(path string, d fs.DirEntry, _ io.Reader) error {
 		// Parse go.mod
 		gomod, err := parse(input.FS, path, a.modParser)
 		if err != nil {
@@ -90,6 +97,15 @@ func (a *gomodAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalys
 			mergeGoSum(gomod, gosum)
 		}
 
+		if ws, ok := workspaces[path]; ok {
+			if lessThanGo117(gomod) {
+				// go.work.sum holds checksums that are not in go.sum of the workspace modules
+				mergeGoSum(gomod, ws.sum)
+			}
+			ws.members = append(ws.members, gomod)
+			return nil
+		}
+
 		apps = append(apps, *gomod)
 		return nil
 	})
@@ -97,12 +113,32 @@ func (a *gomodAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalys
 		return nil, xerrors.Errorf("walk error: %w", err)
 	}
 
+	// Analyze each workspace as a single application
+	indirectOwners := make(map[string]map[string][]string)
+	uniqWorkspaces := lo.Uniq(lo.Values(workspaces))
+	sort.Slice(uniqWorkspaces, func(i, j int) bool {
+		return uniqWorkspaces[i].filePath < uniqWorkspaces[j].filePath
+	})
+	for _, ws := range uniqWorkspaces {
+		if len(ws.members) == 0 {
+			continue
+		}
+		app, owners := ws.merge()
+		indirectOwners[app.FilePath] = owners
+		apps = append(apps, app)
+	}
+
 	if err = a.fillAdditionalData(apps); err != nil {
 		a.logger.Warn("Unable to collect additional info", log.Err(err))
 	}
 
 	// Add orphan indirect dependencies under the main module
 	a.addOrphanIndirectDepsUnderRoot(apps)
+	for _, app := range apps {
+		if owners, ok := indirectOwners[app.FilePath]; ok {
+			addOrphanIndirectDepsUnderModules(app, owners)
+		}
+	}
 
 	return &analyzer.AnalysisResult{
 		Applications: apps,
@@ -154,7 +190,7 @@ func (a *gomodAnalyzer) fillAdditionalData(apps []types.Application) error {
 			modDir := filepath.Join(modPath, fmt.Sprintf("%s@%s", normalizeModName(lib.Name), lib.Version))
 
 			// Collect licenses
-			if licenseNames, err := findLicense(modDir, a.licenseClassifierConfidenceLevel); err != nil {
+			if licenseNames, err := license.FindLicense(modDir, a.licenseClassifierConfidenceLevel); err != nil {
 				return xerrors.Errorf("license error: %w", err)
 			} else {
 				// Cache the detected licenses
@@ -301,49 +337,6 @@ func mergeGoSum(gomod, gosum *types.Application) {
 	gomod.Packages = lo.Values(uniq)
 }
 
-func findLicense(dir string, classifierConfidenceLevel float64) ([]string, error) {
-	var license *types.LicenseFile
-	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		} else if !d.Type().IsRegular() {
-			return nil
-		}
-		if !licenseRegexp.MatchString(filepath.Base(path)) {
-			return nil
-		}
-		// e.g. $GOPATH/pkg/mod/github.com/aquasecurity/go-dep-parser@v0.0.0-20220406074731-71021a481237/LICENSE
-		f, err := os.Open(path)
-		if err != nil {
-			return xerrors.Errorf("file (%s) open error: %w", path, err)
-		}
-		defer f.Close()
-
-		l, err := licensing.Classify(path, f, classifierConfidenceLevel)
-		if err != nil {
-			return xerrors.Errorf("license classify error: %w", err)
-		}
-		// License found
-		if l != nil && len(l.Findings) > 0 {
-			license = l
-			return io.EOF
-		}
-		return nil
-	})
-
-	switch {
-	// The module path may not exist
-	case errors.Is(err, os.ErrNotExist):
-		return nil, nil
-	case err != nil && !errors.Is(err, io.EOF):
-		return nil, fmt.Errorf("finding a known open source license: %w", err)
-	case license == nil || len(license.Findings) == 0:
-		return nil, nil
-	}
-
-	return license.Findings.Names(), nil
-}
-
 // normalizeModName escapes upper characters
 // e.g. 'github.com/BurntSushi/toml' => 'github.com/!burnt!sushi'
 func normalizeModName(name string) string {
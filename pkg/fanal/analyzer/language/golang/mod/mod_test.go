@@ -318,6 +318,143 @@ func Test_gomodAnalyzer_Analyze(t *testing.T) {
 	}
 }
 
+func Test_gomodAnalyzer_Workspace(t *testing.T) {
+	t.Setenv("GOPATH", "testdata")
+
+	a, err := newGoModAnalyzer(analyzer.AnalyzerOptions{})
+	require.NoError(t, err)
+
+	// Since go.mod and go.work files bother Go tools, they are stored with other names.
+	mfs := mapfs.New()
+	for path, file := range map[string]string{
+		"go.work":   "testdata/workspace/work",
+		"a/go.mod":  "testdata/workspace/a/mod",
+		"b/go.mod":  "testdata/workspace/b/mod",
+		"c/go.mod":  "testdata/workspace/c/mod",
+		"go.work.x": "testdata/workspace/work", // Not go.work
+	} {
+		require.NoError(t, mfs.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, mfs.WriteFile(path, file))
+	}
+
+	got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{
+		FS: mfs,
+	})
+	require.NoError(t, err)
+
+	want := &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     types.GoModule,
+				FilePath: "c/go.mod",
+				Packages: types.Packages{
+					{
+						ID:           "example.com/c",
+						Name:         "example.com/c",
+						Relationship: types.RelationshipRoot,
+						DependsOn: []string{
+							"github.com/BurntSushi/toml@v0.3.1",
+						},
+					},
+					{
+						ID:           "github.com/BurntSushi/toml@v0.3.1",
+						Name:         "github.com/BurntSushi/toml",
+						Version:      "v0.3.1",
+						Relationship: types.RelationshipDirect,
+						Licenses:     []string{"MIT"},
+						ExternalReferences: []types.ExternalRef{
+							{
+								Type: types.RefVCS,
+								URL:  "https://github.com/BurntSushi/toml",
+							},
+						},
+					},
+				},
+			},
+			{
+				Type:     types.GoModule,
+				FilePath: "go.work",
+				Packages: types.Packages{
+					{
+						ID:           "example.com/a",
+						Name:         "example.com/a",
+						Relationship: types.RelationshipWorkspace,
+						DependsOn: []string{
+							"example.com/b",
+							"github.com/aquasecurity/go-dep-parser@v0.0.0-20230219131432-590b1dfb6edd",
+						},
+					},
+					{
+						ID:           "example.com/b",
+						Name:         "example.com/b",
+						Relationship: types.RelationshipWorkspace,
+						DependsOn: []string{
+							"github.com/aquasecurity/go-dep-parser@v0.0.0-20230219131432-590b1dfb6edd",
+							"github.com/orphan/indirect@v1.0.0", // No parent found, so it's added here.
+							"github.com/replaced/new@v1.1.0",
+						},
+					},
+					{
+						// The highest version is selected
+						ID:           "github.com/aquasecurity/go-dep-parser@v0.0.0-20230219131432-590b1dfb6edd",
+						Name:         "github.com/aquasecurity/go-dep-parser",
+						Version:      "v0.0.0-20230219131432-590b1dfb6edd",
+						Relationship: types.RelationshipDirect,
+						ExternalReferences: []types.ExternalRef{
+							{
+								Type: types.RefVCS,
+								URL:  "https://github.com/aquasecurity/go-dep-parser",
+							},
+						},
+						DependsOn: []string{
+							"golang.org/x/xerrors@v0.0.0-20200804184101-5ec99f83aff1",
+						},
+					},
+					{
+						ID:           "github.com/orphan/indirect@v1.0.0",
+						Name:         "github.com/orphan/indirect",
+						Version:      "v1.0.0",
+						Relationship: types.RelationshipIndirect,
+						Indirect:     true,
+						ExternalReferences: []types.ExternalRef{
+							{
+								Type: types.RefVCS,
+								URL:  "https://github.com/orphan/indirect",
+							},
+						},
+					},
+					{
+						// Replaced in go.work
+						ID:           "github.com/replaced/new@v1.1.0",
+						Name:         "github.com/replaced/new",
+						Version:      "v1.1.0",
+						Relationship: types.RelationshipDirect,
+						ExternalReferences: []types.ExternalRef{
+							{
+								Type: types.RefVCS,
+								URL:  "https://github.com/replaced/new",
+							},
+						},
+					},
+					{
+						ID:           "golang.org/x/xerrors@v0.0.0-20200804184101-5ec99f83aff1",
+						Name:         "golang.org/x/xerrors",
+						Version:      "v0.0.0-20200804184101-5ec99f83aff1",
+						Relationship: types.RelationshipIndirect,
+						Indirect:     true,
+					},
+				},
+			},
+		},
+	}
+	require.Len(t, got.Applications, len(want.Applications))
+	for i := range got.Applications {
+		sort.Sort(got.Applications[i].Packages)
+		sort.Sort(want.Applications[i].Packages)
+	}
+	assert.Equal(t, want, got)
+}
+
 func Test_gomodAnalyzer_Required(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -334,6 +471,16 @@ func Test_gomodAnalyzer_Required(t *testing.T) {
 			filePath: "test/foo/go.sum",
 			want:     true,
 		},
+		{
+			name:     "go.work",
+			filePath: "test/go.work",
+			want:     true,
+		},
+		{
+			name:     "go.work.sum",
+			filePath: "test/go.work.sum",
+			want:     true,
+		},
 		{
 			name:     "sad",
 			filePath: "a/b/c/d/test.sum",
@@ -0,0 +1,223 @@
+package mod
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/samber/lo"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/golang/mod"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+// workspace represents a Go workspace defined by go.work.
+// cf. https://go.dev/ref/mod#workspaces
+type workspace struct {
+	filePath string
+	work     *modfile.WorkFile
+
+	// go.work.sum
+	sum *types.Application
+
+	// Modules listed in "use" directives
+	members []*types.Application
+}
+
+// parseWorkspaces finds go.work files and returns workspaces keyed by go.mod paths of their modules.
+func (a *gomodAnalyzer) parseWorkspaces(fsys fs.FS) (map[string]*workspace, error) {
+	required := func(path string, _ fs.DirEntry) bool {
+		return filepath.Base(path) == types.GoWork
+	}
+
+	workspaces := make(map[string]*workspace)
+	err := fsutils.WalkDir(fsys, ".", required, func(path string, _ fs.DirEntry, r io.Reader) error {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return xerrors.Errorf("read error: %w", err)
+		}
+		work, err := modfile.ParseWork(path, b, nil)
+		if err != nil {
+			a.logger.Debug("Unable to parse go.work", log.FilePath(path), log.Err(err))
+			return nil
+		}
+
+		ws := &workspace{
+			filePath: path,
+			work:     work,
+		}
+
+		// e.g. /app/go.work => /app/go.work.sum
+		sumPath := filepath.Join(filepath.Dir(path), types.GoWorkSum)
+		if ws.sum, err = parse(fsys, sumPath, a.sumParser); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return xerrors.Errorf("parse error: %w", err)
+		}
+
+		for _, use := range work.Use {
+			// e.g. /app/go.work + ./foo => /app/foo/go.mod
+			modPath := filepath.Join(filepath.Dir(path), filepath.FromSlash(use.Path), types.GoMod)
+			if !fs.ValidPath(filepath.ToSlash(modPath)) {
+				a.logger.Debug("Module outside the scan target is skipped", log.FilePath(path),
+					log.String("use", use.Path))
+				continue
+			}
+			// Go uses the closest go.work in the parent directories
+			if other, ok := workspaces[modPath]; ok && len(filepath.Dir(other.filePath)) > len(filepath.Dir(path)) {
+				continue
+			}
+			workspaces[modPath] = ws
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk error: %w", err)
+	}
+	return workspaces, nil
+}
+
+// merge builds a single application from the modules in the workspace.
+// The workspace modules are analyzed as a unit, like "go build" in workspace mode, so that:
+//   - the highest version required by any module is selected for each dependency (minimal version selection)
+//   - dependencies shared by several modules are reported once
+//   - each module depends on the selected versions of its direct dependencies, so that it's clear which module requires them
+//
+// It also returns the workspace modules that require each indirect dependency.
+func (ws *workspace) merge() (types.Application, map[string][]string) {
+	// Workspace modules
+	modules := make(map[string]types.Package)
+	for _, member := range ws.members {
+		if root, ok := lo.Find(member.Packages, func(pkg types.Package) bool {
+			return pkg.Relationship == types.RelationshipRoot
+		}); ok {
+			root.Relationship = types.RelationshipWorkspace
+			modules[root.Name] = root
+		}
+	}
+
+	// Select the highest version of each dependency
+	selected := make(map[string]types.Package)
+	indirectOwners := make(map[string][]string)
+	for _, member := range ws.members {
+		root, _ := lo.Find(member.Packages, func(pkg types.Package) bool {
+			return pkg.Relationship == types.RelationshipRoot
+		})
+		for _, pkg := range member.Packages {
+			if pkg.Relationship == types.RelationshipRoot {
+				continue
+			} else if _, ok := modules[pkg.Name]; ok {
+				// Workspace modules take precedence over the required versions
+				continue
+			}
+			if pkg.Relationship == types.RelationshipIndirect && root.ID != "" {
+				indirectOwners[pkg.Name] = append(indirectOwners[pkg.Name], root.ID)
+			}
+
+			s, ok := selected[pkg.Name]
+			if !ok || semver.Compare(pkg.Version, s.Version) > 0 {
+				// Keep the relationship as direct if any module requires it directly
+				if ok && s.Relationship == types.RelationshipDirect {
+					pkg.Relationship = types.RelationshipDirect
+					pkg.Indirect = false
+				}
+				selected[pkg.Name] = pkg
+			} else if pkg.Relationship == types.RelationshipDirect {
+				s.Relationship = types.RelationshipDirect
+				s.Indirect = false
+				selected[pkg.Name] = s
+			}
+		}
+	}
+
+	// "replace" directives in go.work override the ones in go.mod
+	replaced := make(map[string]string)
+	for _, rep := range ws.work.Replace {
+		old, ok := selected[rep.Old.Path]
+		if !ok || (rep.Old.Version != "" && old.Version != rep.Old.Version) {
+			continue
+		}
+		delete(selected, rep.Old.Path)
+		// Directive without version is a local path.
+		if rep.New.Version == "" {
+			continue
+		}
+		replaced[rep.Old.Path] = rep.New.Path
+		old.ID = dependency.ID(types.GoModule, rep.New.Path, rep.New.Version)
+		old.Name = rep.New.Path
+		old.Version = rep.New.Version
+		old.ExternalReferences = mod.NewParser(false, false).GetExternalRefs(rep.New.Path)
+		selected[rep.New.Path] = old
+	}
+
+	// Resolve dependencies of workspace modules to the selected versions
+	for _, member := range ws.members {
+		names := lo.SliceToMap(member.Packages, func(pkg types.Package) (string, string) {
+			return pkg.ID, pkg.Name
+		})
+		for _, pkg := range member.Packages {
+			module, ok := modules[pkg.Name]
+			if !ok || pkg.Relationship != types.RelationshipRoot {
+				continue
+			}
+			module.DependsOn = lo.FilterMap(pkg.DependsOn, func(id string, _ int) (string, bool) {
+				name := names[id]
+				if newName, ok := replaced[name]; ok {
+					name = newName
+				}
+				if m, ok := modules[name]; ok {
+					return m.ID, true
+				} else if s, ok := selected[name]; ok {
+					return s.ID, true
+				}
+				return "", false
+			})
+			slices.Sort(module.DependsOn)
+			module.DependsOn = slices.Compact(module.DependsOn)
+			modules[pkg.Name] = module
+		}
+	}
+
+	pkgs := append(lo.Values(modules), lo.Values(selected)...)
+	sort.Sort(types.Packages(pkgs))
+
+	return types.Application{
+		Type:     types.GoModule,
+		FilePath: ws.filePath,
+		Packages: pkgs,
+	}, indirectOwners
+}
+
+// addOrphanIndirectDepsUnderModules adds indirect dependencies with no parents under the workspace modules requiring them.
+// See addOrphanIndirectDepsUnderRoot for the details.
+func addOrphanIndirectDepsUnderModules(app types.Application, indirectOwners map[string][]string) {
+	modules := make(map[string]int)
+	for i, pkg := range app.Packages {
+		if pkg.Relationship == types.RelationshipWorkspace {
+			modules[pkg.ID] = i
+		}
+	}
+
+	parents := app.Packages.ParentDeps()
+	for _, pkg := range app.Packages {
+		if pkg.Relationship != types.RelationshipIndirect || len(parents[pkg.ID]) != 0 {
+			continue
+		}
+		for _, owner := range indirectOwners[pkg.Name] {
+			if i, ok := modules[owner]; ok && !slices.Contains(app.Packages[i].DependsOn, pkg.ID) {
+				app.Packages[i].DependsOn = append(app.Packages[i].DependsOn, pkg.ID)
+			}
+		}
+	}
+
+	for _, i := range modules {
+		slices.Sort(app.Packages[i].DependsOn)
+	}
+}
@@ -0,0 +1,155 @@
+package gradle
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/gradle/catalog"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzer.TypeGradleCatalog, newGradleCatalogAnalyzer)
+}
+
+const (
+	catalogAnalyzerVersion = 1
+	catalogFileNameSuffix  = ".versions.toml"
+)
+
+var settingsFiles = []string{
+	"settings.gradle",
+	"settings.gradle.kts",
+}
+
+// gradleCatalogAnalyzer analyzes Gradle version catalogs ('*.versions.toml' and 'settings.gradle(.kts)').
+// Projects with '*gradle.lockfile' are skipped since lock files have the resolved versions.
+type gradleCatalogAnalyzer struct {
+	logger         *log.Logger
+	catalogParser  language.Parser
+	settingsParser language.Parser
+}
+
+func newGradleCatalogAnalyzer(_ analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	return &gradleCatalogAnalyzer{
+		logger:         log.WithPrefix("gradle"),
+		catalogParser:  catalog.NewParser(),
+		settingsParser: catalog.NewSettingsParser(),
+	}, nil
+}
+
+func (a gradleCatalogAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
+	// Find directories with lock files
+	var lockDirs []string
+	isLockFile := func(path string, _ fs.DirEntry) bool {
+		return strings.HasSuffix(path, fileNameSuffix)
+	}
+	err := fsutils.WalkDir(input.FS, ".", isLockFile, func(filePath string, _ fs.DirEntry, _ io.Reader) error {
+		lockDirs = append(lockDirs, path.Dir(filePath))
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk error: %w", err)
+	}
+
+	poms, err := parsePoms(a.logger)
+	if err != nil {
+		a.logger.Warn("Unable to get licenses", log.Err(err))
+	}
+
+	required := func(path string, _ fs.DirEntry) bool {
+		return isCatalogFile(path)
+	}
+
+	var apps []types.Application
+	err = fsutils.WalkDir(input.FS, ".", required, func(filePath string, _ fs.DirEntry, r io.Reader) error {
+		root := projectRoot(filePath)
+		if hasLockFile(root, lockDirs) {
+			a.logger.Debug("Skipping the version catalog since the project has lock files", log.FilePath(filePath))
+			return nil
+		}
+
+		parser := a.catalogParser
+		if isSettingsFile(filePath) {
+			parser = a.settingsParser
+		}
+
+		app, err := language.Parse(types.Gradle, filePath, r, parser)
+		if err != nil {
+			return xerrors.Errorf("%s parse error: %w", filePath, err)
+		} else if app == nil {
+			return nil
+		}
+
+		// Fill licenses from pom files
+		for i, pkg := range app.Packages {
+			if pom, ok := poms[pkg.ID]; ok && len(pom.Licenses.License) > 0 {
+				app.Packages[i].Licenses = lo.Map(pom.Licenses.License, func(license License, _ int) string {
+					return license.Name
+				})
+			}
+		}
+
+		apps = append(apps, *app)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk error: %w", err)
+	}
+
+	return &analyzer.AnalysisResult{
+		Applications: apps,
+	}, nil
+}
+
+func (a gradleCatalogAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	// Lock files are also required to skip projects with lock files
+	return isCatalogFile(filePath) || strings.HasSuffix(filePath, fileNameSuffix)
+}
+
+func (a gradleCatalogAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeGradleCatalog
+}
+
+func (a gradleCatalogAnalyzer) Version() int {
+	return catalogAnalyzerVersion
+}
+
+func isCatalogFile(filePath string) bool {
+	return strings.HasSuffix(filePath, catalogFileNameSuffix) || isSettingsFile(filePath)
+}
+
+func isSettingsFile(filePath string) bool {
+	return lo.Contains(settingsFiles, filepath.Base(filePath))
+}
+
+// projectRoot returns the root directory of the Gradle project.
+// e.g.
+//   - "gradle/libs.versions.toml" => "."
+//   - "app/settings.gradle.kts" => "app"
+func projectRoot(filePath string) string {
+	dir := path.Dir(filePath)
+	if !isSettingsFile(filePath) && path.Base(dir) == "gradle" {
+		return path.Dir(dir)
+	}
+	return dir
+}
+
+// hasLockFile returns true if the project root or its subprojects have lock files
+func hasLockFile(root string, lockDirs []string) bool {
+	return lo.SomeBy(lockDirs, func(dir string) bool {
+		return root == "." || dir == root || strings.HasPrefix(dir, root+"/")
+	})
+}
@@ -0,0 +1,150 @@
+package gradle
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_gradleCatalogAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		dir      string
+		cacheDir string
+		want     *analyzer.AnalysisResult
+	}{
+		{
+			name:     "libs.versions.toml",
+			dir:      "testdata/catalogs/happy",
+			cacheDir: "testdata/cache",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Gradle,
+						FilePath: "gradle/libs.versions.toml",
+						Packages: types.Packages{
+							{
+								ID:           "junit:junit:4.13",
+								Name:         "junit:junit",
+								Version:      "4.13",
+								Relationship: types.RelationshipDirect,
+								Locations: []types.Location{
+									{
+										StartLine: 5,
+										EndLine:   5,
+									},
+								},
+								Licenses: []string{
+									"Eclipse Public License 1.0",
+								},
+							},
+							{
+								ID:           "org.hamcrest:hamcrest-core:1.3",
+								Name:         "org.hamcrest:hamcrest-core",
+								Version:      "1.3",
+								Relationship: types.RelationshipDirect,
+								Locations: []types.Location{
+									{
+										StartLine: 6,
+										EndLine:   6,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "settings.gradle",
+			dir:  "testdata/catalogs/settings",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Gradle,
+						FilePath: "settings.gradle",
+						Packages: types.Packages{
+							{
+								ID:           "org.codehaus.groovy:groovy:3.0.5",
+								Name:         "org.codehaus.groovy:groovy",
+								Version:      "3.0.5",
+								Relationship: types.RelationshipDirect,
+								Locations: []types.Location{
+									{
+										StartLine: 6,
+										EndLine:   6,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project with lock files",
+			dir:  "testdata/catalogs/with-lockfile",
+			want: &analyzer.AnalysisResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.cacheDir != "" {
+				t.Setenv("GRADLE_USER_HOME", tt.cacheDir)
+			}
+
+			a, err := newGradleCatalogAnalyzer(analyzer.AnalyzerOptions{})
+			require.NoError(t, err)
+
+			got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{
+				FS: os.DirFS(tt.dir),
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_gradleCatalogAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "default catalog",
+			filePath: "gradle/libs.versions.toml",
+			want:     true,
+		},
+		{
+			name:     "settings.gradle.kts",
+			filePath: "settings.gradle.kts",
+			want:     true,
+		},
+		{
+			name:     "lock file",
+			filePath: "app/gradle.lockfile",
+			want:     true,
+		},
+		{
+			name:     "build.gradle",
+			filePath: "app/build.gradle",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := gradleCatalogAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -43,7 +43,7 @@ func newGradleLockAnalyzer(_ analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, e
 }
 
 func (a gradleLockAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
-	poms, err := a.parsePoms()
+	poms, err := parsePoms(a.logger)
 	if err != nil {
 		a.logger.Warn("Unable to get licenses and dependencies", log.Err(err))
 	}
@@ -65,8 +65,9 @@ func (props *Properties) UnmarshalXML(d *xml.Decoder, _ xml.StartElement) error
 	return nil
 }
 
-func (a gradleLockAnalyzer) parsePoms() (map[string]pomXML, error) {
-	cacheDir := a.detectCacheDir()
+// parsePoms parses pom files in the Gradle cache to get licenses and dependencies
+func parsePoms(logger *log.Logger) (map[string]pomXML, error) {
+	cacheDir := detectCacheDir(logger)
 	// Cache dir is not found
 	if cacheDir == "" {
 		return nil, nil
@@ -80,7 +81,7 @@ func (a gradleLockAnalyzer) parsePoms() (map[string]pomXML, error) {
 	err := fsutils.WalkDir(os.DirFS(cacheDir), ".", required, func(path string, _ fs.DirEntry, r io.Reader) error {
 		pom, err := parsePom(r, path)
 		if err != nil {
-			a.logger.Debug("Unable to parse pom", log.FilePath(path), log.Err(err))
+			logger.Debug("Unable to parse pom", log.FilePath(path), log.Err(err))
 			return nil
 		}
 
@@ -146,7 +147,7 @@ func (pom *pomXML) resolveDependencyVersions() error {
 	return nil
 }
 
-func (a gradleLockAnalyzer) detectCacheDir() string {
+func detectCacheDir(logger *log.Logger) string {
 	// https://docs.gradle.org/current/userguide/directory_layout.html
 	dir := os.Getenv("GRADLE_USER_HOME")
 	if dir == "" {
@@ -159,7 +160,7 @@ func (a gradleLockAnalyzer) detectCacheDir() string {
 	dir = filepath.Join(dir, "caches")
 
 	if !fsutils.DirExists(dir) {
-		a.logger.Debug("Unable to get licenses and dependencies. Gradle cache dir doesn't exist.")
+		logger.Debug("Unable to get licenses and dependencies. Gradle cache dir doesn't exist.")
 		return ""
 	}
 	return dir
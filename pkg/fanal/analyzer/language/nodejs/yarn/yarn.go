@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"io/fs"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -27,6 +28,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/nodejs/license"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/set"
 	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
 	xio "github.com/aquasecurity/trivy/pkg/x/io"
 )
@@ -35,7 +37,7 @@ func init() {
 	analyzer.RegisterPostAnalyzer(analyzer.TypeYarn, newYarnAnalyzer)
 }
 
-const version = 2
+const version = 3
 
 // Taken from Yarn
 // cf. https://github.com/yarnpkg/yarn/blob/328fd596de935acc6c3e134741748fcc62ec3739/src/resolvers/exotics/registry-resolver.js#L12
@@ -57,12 +59,24 @@ func newYarnAnalyzer(opt analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error
 	}, nil
 }
 
+// Yarn Plug'n'Play install state files
+// cf. https://yarnpkg.com/features/pnp
+var pnpFiles = []string{
+	".pnp.data.json",
+	".pnp.cjs",
+}
+
 type parserWithPatterns struct {
-	patterns map[string][]string
+	patterns  map[string][]string
+	installed set.Set[string]
 }
 
 func (p *parserWithPatterns) Parse(r xio.ReadSeekerAt) ([]types.Package, []types.Dependency, error) {
-	pkgs, deps, patterns, err := yarn.NewParser().Parse(r)
+	var opts []yarn.Option
+	if p.installed != nil {
+		opts = append(opts, yarn.WithInstalledPackages(p.installed))
+	}
+	pkgs, deps, patterns, err := yarn.NewParser(opts...).Parse(r)
 	p.patterns = patterns
 	return pkgs, deps, err
 }
@@ -75,7 +89,9 @@ func (a yarnAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysis
 	}
 
 	err := fsutils.WalkDir(input.FS, ".", required, func(filePath string, d fs.DirEntry, r io.Reader) error {
-		parser := &parserWithPatterns{}
+		parser := &parserWithPatterns{
+			installed: a.parseInstallState(input.FS, path.Dir(filePath)),
+		}
 		// Parse yarn.lock
 		app, err := language.Parse(types.Yarn, filePath, r, parser)
 		if err != nil {
@@ -129,6 +145,7 @@ func (a yarnAnalyzer) Required(filePath string, _ os.FileInfo) bool {
 
 	if fileName == types.YarnLock ||
 		fileName == types.NpmPkg ||
+		slices.Contains(pnpFiles, fileName) ||
 		strings.HasPrefix(strings.ToLower(fileName), "license") {
 		return true
 	}
@@ -150,6 +167,26 @@ func splitPath(filePath string) (dirs []string, fileName string) {
 	return dirs, fileName
 }
 
+// parseInstallState returns IDs of the packages installed by Plug'n'Play.
+// It returns nil if the install state is not available, e.g. when the node_modules linker is used.
+func (a yarnAnalyzer) parseInstallState(fsys fs.FS, dir string) set.Set[string] {
+	for _, fileName := range pnpFiles {
+		filePath := path.Join(dir, fileName)
+		f, err := fsys.Open(filePath)
+		if err != nil {
+			continue
+		}
+		installed, err := yarn.ParseInstallState(f)
+		_ = f.Close()
+		if err != nil {
+			a.logger.Debug("Unable to parse the PnP install state", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		return installed
+	}
+	return nil
+}
+
 func (a yarnAnalyzer) Type() analyzer.Type {
 	return analyzer.TypeYarn
 }
@@ -211,6 +248,26 @@ func (a yarnAnalyzer) walkDependencies(pkgs []types.Package, pkgIDs map[string]t
 			continue
 		}
 
+		// Handle patched packages
+		// cf. https://yarnpkg.com/protocol/patch
+		// e.g. "patch:lodash@npm%3A4.17.21#./.yarn/patches/lodash-npm-4.17.21-6382451519.patch"
+		if c, ok := strings.CutPrefix(constraint, "patch:"); ok {
+			c, _, _ = strings.Cut(c, "#")
+			if unescaped, err := url.PathUnescape(c); err == nil {
+				c = unescaped
+			}
+			// Take the range of the original descriptor, e.g. "lodash@npm:4.17.21" => "npm:4.17.21"
+			if i := strings.LastIndex(c, "@"); i > 0 {
+				c = c[i+1:]
+			}
+			constraint = strings.TrimPrefix(c, "npm:")
+		}
+
+		// Workspaces and local packages are not included in the lock file as npm packages
+		if ignoredConstraint(constraint) {
+			continue
+		}
+
 		// Handle aliases
 		// cf. https://classic.yarnpkg.com/lang/en/docs/cli/add/#toc-yarn-add-alias
 		if m := fragmentRegexp.FindStringSubmatch(constraint); len(m) == 5 {
@@ -246,6 +303,15 @@ func (a yarnAnalyzer) walkDependencies(pkgs []types.Package, pkgIDs map[string]t
 	return directPkgs, nil
 }
 
+func ignoredConstraint(constraint string) bool {
+	for _, protocol := range []string{"workspace:", "portal:", "link:", "file:", "exec:"} {
+		if strings.HasPrefix(constraint, protocol) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a yarnAnalyzer) walkIndirectDependencies(pkg types.Package, pkgIDs, deps map[string]types.Package) {
 	for _, pkgID := range pkg.DependsOn {
 		if _, ok := deps[pkgID]; ok {
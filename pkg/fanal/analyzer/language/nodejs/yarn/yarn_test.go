@@ -694,6 +694,73 @@ func Test_yarnLibraryAnalyzer_Analyze(t *testing.T) {
 		// mkdir test && cd "$_"
 		// yarn set version 1.22.19
 		// yarn add @vue/compiler-sfc@2.7.14
+		{
+			name: "yarn berry with PnP, workspace and patch protocols",
+			dir:  "testdata/yarn-berry",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Yarn,
+						FilePath: "yarn.lock",
+						Packages: types.Packages{
+							{
+								ID:           "esbuild@0.20.2",
+								Name:         "esbuild",
+								Version:      "0.20.2",
+								Relationship: types.RelationshipDirect,
+								DependsOn: []string{
+									"@esbuild/linux-x64@0.20.2",
+								},
+								Locations: []types.Location{
+									{
+										StartLine: 32,
+										EndLine:   47,
+									},
+								},
+							},
+							{
+								ID:           "lodash@4.17.21",
+								Name:         "lodash",
+								Version:      "4.17.21",
+								Dev:          true,
+								Relationship: types.RelationshipDirect,
+								Locations: []types.Location{
+									{
+										StartLine: 57,
+										EndLine:   62,
+									},
+								},
+							},
+							{
+								ID:           "ms@2.1.3",
+								Name:         "ms",
+								Version:      "2.1.3",
+								Relationship: types.RelationshipDirect,
+								Locations: []types.Location{
+									{
+										StartLine: 71,
+										EndLine:   76,
+									},
+								},
+							},
+							{
+								ID:           "@esbuild/linux-x64@0.20.2",
+								Name:         "@esbuild/linux-x64",
+								Version:      "0.20.2",
+								Indirect:     true,
+								Relationship: types.RelationshipIndirect,
+								Locations: []types.Location{
+									{
+										StartLine: 15,
+										EndLine:   20,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "parse licenses (yarn classic)",
 			dir:  "testdata/yarn-classic-licenses",
@@ -878,6 +945,11 @@ func Test_yarnLibraryAnalyzer_Required(t *testing.T) {
 			filePath: "node_modules/@vue/compiler-sfc/LICENSE",
 			want:     true,
 		},
+		{
+			name:     "PnP install state",
+			filePath: ".pnp.cjs",
+			want:     true,
+		},
 		{
 			name:     "txt license file",
 			filePath: "node_modules/@vue/compiler-sfc/LICENSE.txt",
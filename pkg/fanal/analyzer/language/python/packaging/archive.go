@@ -0,0 +1,144 @@
+package packaging
+
+import (
+	"archive/zip"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/python/packaging"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+// analyzeArchive analyzes the package metadata and the license files in zip archives (.egg and .whl)
+func analyzeArchive(input analyzer.AnalysisInput, isMetadata func(filePath string) bool,
+	licenseClassifierConfidenceLevel float64, logger *log.Logger) (*analyzer.AnalysisResult, error) {
+	metadataInZip, err := findFileInZip(input.Content, input.Info.Size(), isMetadata)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to open %q archive: %w", path.Ext(input.FilePath), err)
+	}
+
+	// Archive may not contain required files, then we will get nil. Skip this archives
+	if metadataInZip == nil {
+		return nil, nil
+	}
+
+	rsa, err := xio.NewReadSeekerAt(metadataInZip)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to convert metadata reader: %w", err)
+	}
+
+	app, err := language.ParsePackage(types.PythonPkg, input.FilePath, rsa, packaging.NewParser(), input.Options.FileChecksum)
+	if err != nil {
+		return nil, xerrors.Errorf("parse error: %w", err)
+	} else if app == nil {
+		return nil, nil
+	}
+
+	opener := func(licPath string) (io.ReadCloser, error) {
+		required := func(filePath string) bool {
+			return isMetadataDir(path.Dir(filePath)) && path.Base(filePath) == licPath
+		}
+
+		f, err := findFileInZip(input.Content, input.Info.Size(), required)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to find license file in %q file: %w", path.Ext(input.FilePath), err)
+		} else if f == nil { // zip doesn't contain license file
+			return nil, nil
+		}
+
+		return f, nil
+	}
+
+	if err = fillAdditionalData(opener, app, licenseClassifierConfidenceLevel); err != nil {
+		logger.Warn("Unable to collect additional info", log.Err(err))
+	}
+
+	// Registry metadata may be missing in air-gapped mirrors,
+	// and old packages may not declare the license in the metadata.
+	// Fall back to the license files bundled in the archive.
+	for i, pkg := range app.Packages {
+		if len(pkg.Licenses) > 0 {
+			continue
+		}
+		licenses, err := classifyLicenseFilesInZip(input.Content, input.Info.Size(), licenseClassifierConfidenceLevel)
+		if err != nil {
+			logger.Warn("Unable to classify license files", log.FilePath(input.FilePath), log.Err(err))
+			continue
+		}
+		app.Packages[i].Licenses = licenses
+	}
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{*app},
+	}, nil
+}
+
+func findFileInZip(r xio.ReadSeekerAt, zipSize int64, required func(filePath string) bool) (io.ReadCloser, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, xerrors.Errorf("file seek error: %w", err)
+	}
+
+	zr, err := zip.NewReader(r, zipSize)
+	if err != nil {
+		return nil, xerrors.Errorf("zip reader error: %w", err)
+	}
+
+	found, ok := lo.Find(zr.File, func(f *zip.File) bool {
+		return required(f.Name)
+	})
+	if !ok {
+		return nil, nil
+	}
+
+	f, err := found.Open()
+	if err != nil {
+		return nil, xerrors.Errorf("unable to open file in zip: %w", err)
+	}
+
+	return f, nil
+}
+
+// classifyLicenseFilesInZip classifies license files (e.g. LICENSE, COPYING) stored in the metadata directory
+func classifyLicenseFilesInZip(r xio.ReadSeekerAt, zipSize int64, licenseClassifierConfidenceLevel float64) ([]string, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, xerrors.Errorf("file seek error: %w", err)
+	}
+
+	zr, err := zip.NewReader(r, zipSize)
+	if err != nil {
+		return nil, xerrors.Errorf("zip reader error: %w", err)
+	}
+
+	var licenses []string
+	for _, f := range zr.File {
+		if !isMetadataDir(path.Dir(f.Name)) || !licensing.IsLicenseFile(path.Base(f.Name)) {
+			continue
+		}
+		opener := func(string) (io.ReadCloser, error) {
+			return f.Open()
+		}
+		foundLicenses, err := classifyLicenses(opener, f.Name, licenseClassifierConfidenceLevel)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to classify %q: %w", f.Name, err)
+		}
+		licenses = append(licenses, foundLicenses...)
+	}
+	return lo.Uniq(licenses), nil
+}
+
+// isMetadataDir returns true if the directory holds the package metadata and license files.
+// e.g. "EGG-INFO", "requests-2.32.3.dist-info" and "requests-2.32.3.dist-info/licenses"
+// cf. https://peps.python.org/pep-0639/#add-license-files-directory
+func isMetadataDir(dir string) bool {
+	dir = strings.TrimSuffix(dir, "/licenses")
+	return dir == "EGG-INFO" || (strings.HasSuffix(dir, ".dist-info") && !strings.Contains(dir, "/"))
+}
@@ -35,6 +35,12 @@ func Test_eggAnalyzer_Analyze(t *testing.T) {
 								Licenses: []string{
 									"LGPL-2.1-only",
 								},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "https://fedorahosted.org/kitchen",
+									},
+								},
 								FilePath: "testdata/egg-zip/kitchen-1.2.6-py2.7.egg",
 							},
 						},
@@ -58,6 +64,12 @@ func Test_eggAnalyzer_Analyze(t *testing.T) {
 								Licenses: []string{
 									"LGPL-2.1-only",
 								},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "https://fedorahosted.org/kitchen",
+									},
+								},
 								FilePath: "testdata/egg-zip/kitchen-1.2.6-py2.7.egg",
 								Digest:   "sha1:4e13b6e379966771e896ee43cf8e240bf6083dca",
 							},
@@ -81,6 +93,12 @@ func Test_eggAnalyzer_Analyze(t *testing.T) {
 								Licenses: []string{
 									"MIT",
 								},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "http://example.com",
+									},
+								},
 								FilePath: "testdata/egg-zip-with-license-file/sample_package.egg",
 							},
 						},
@@ -34,6 +34,16 @@ func Test_packagingAnalyzer_Analyze(t *testing.T) {
 								Name:     "distlib",
 								Version:  "0.3.1",
 								Licenses: []string{"Python-2.0"},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "https://bitbucket.org/pypa/distlib",
+									},
+									{
+										Type: types.RefDistribution,
+										URL:  "https://bitbucket.org/pypa/distlib/downloads/distlib-0.3.1.zip",
+									},
+								},
 								FilePath: "distlib-0.3.1.egg-info/PKG-INFO",
 								Digest:   "sha1:d9d89d8ed3b2b683767c96814c9c5d3e57ef2e1b",
 							},
@@ -55,6 +65,12 @@ func Test_packagingAnalyzer_Analyze(t *testing.T) {
 								Name:     "setuptools",
 								Version:  "51.3.3",
 								Licenses: []string{"MIT"},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "https://github.com/pypa/setuptools",
+									},
+								},
 								FilePath: "setuptools-51.3.3.egg-info/PKG-INFO",
 							},
 						},
@@ -75,6 +91,12 @@ func Test_packagingAnalyzer_Analyze(t *testing.T) {
 								Name:     "setuptools",
 								Version:  "51.3.3",
 								Licenses: []string{"MIT"},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "https://github.com/pypa/setuptools",
+									},
+								},
 								FilePath: "setuptools-51.3.3.dist-info/METADATA",
 							},
 						},
@@ -95,6 +117,16 @@ func Test_packagingAnalyzer_Analyze(t *testing.T) {
 								Name:     "distlib",
 								Version:  "0.3.1",
 								Licenses: []string{"Python-2.0"},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefWebsite,
+										URL:  "https://bitbucket.org/pypa/distlib",
+									},
+									{
+										Type: types.RefDistribution,
+										URL:  "https://bitbucket.org/pypa/distlib/downloads/distlib-0.3.1.zip",
+									},
+								},
 								FilePath: "distlib-0.3.1.dist-info/METADATA",
 							},
 						},
@@ -120,6 +152,16 @@ func Test_packagingAnalyzer_Analyze(t *testing.T) {
 									"LicenseRef-MIT-Lucent",
 									"Python-2.0",
 								},
+								ExternalReferences: []types.ExternalRef{
+									{
+										Type: types.RefIssueTracker,
+										URL:  "https://github.com/python/typing_extensions/issues",
+									},
+									{
+										Type: types.RefVCS,
+										URL:  "https://github.com/python/typing_extensions",
+									},
+								},
 								FilePath: "typing_extensions-4.4.0.dist-info/METADATA",
 							},
 						},
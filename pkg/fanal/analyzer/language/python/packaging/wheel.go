@@ -0,0 +1,59 @@
+package packaging
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&wheelAnalyzer{})
+}
+
+const (
+	wheelAnalyzerVersion = 1
+	wheelExt             = ".whl"
+)
+
+// wheelAnalyzer analyzes wheel archives stored on disk, such as a local package index or a pip cache.
+// cf. https://packaging.python.org/en/latest/specifications/binary-distribution-format/
+type wheelAnalyzer struct {
+	logger                           *log.Logger
+	licenseClassifierConfidenceLevel float64
+}
+
+func (a *wheelAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	a.logger = log.WithPrefix("python")
+	a.licenseClassifierConfidenceLevel = opt.LicenseScannerOption.ClassifierConfidenceLevel
+	return nil
+}
+
+// Analyze analyzes wheel archive files
+func (a *wheelAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	// .whl file is zip format and METADATA needs to be extracted from the zip file.
+	return analyzeArchive(input, isWheelMetadata, a.licenseClassifierConfidenceLevel, a.logger)
+}
+
+// isWheelMetadata returns true for the METADATA file of the top-level .dist-info directory
+// e.g. requests-2.32.3.dist-info/METADATA
+func isWheelMetadata(filePath string) bool {
+	dir, file := path.Split(filePath)
+	return file == "METADATA" && strings.Count(dir, "/") == 1 && strings.HasSuffix(dir, ".dist-info/")
+}
+
+func (a *wheelAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filepath.Ext(filePath) == wheelExt
+}
+
+func (a *wheelAnalyzer) Type() analyzer.Type {
+	return analyzer.TypePythonPkgWheel
+}
+
+func (a *wheelAnalyzer) Version() int {
+	return wheelAnalyzerVersion
+}
@@ -0,0 +1,116 @@
+package packaging
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_wheelAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "license expression",
+			inputFile: "testdata/wheel/sample_pkg-1.0.0-py3-none-any.whl",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.PythonPkg,
+						FilePath: "testdata/wheel/sample_pkg-1.0.0-py3-none-any.whl",
+						Packages: types.Packages{
+							{
+								Name:    "sample-pkg",
+								Version: "1.0.0",
+								Licenses: []string{
+									"Apache-2.0",
+								},
+								FilePath: "testdata/wheel/sample_pkg-1.0.0-py3-none-any.whl",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "license file without license metadata",
+			inputFile: "testdata/wheel/no_license_metadata-0.2.0-py3-none-any.whl",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.PythonPkg,
+						FilePath: "testdata/wheel/no_license_metadata-0.2.0-py3-none-any.whl",
+						Packages: types.Packages{
+							{
+								Name:    "no-license-metadata",
+								Version: "0.2.0",
+								Licenses: []string{
+									"MIT",
+								},
+								FilePath: "testdata/wheel/no_license_metadata-0.2.0-py3-none-any.whl",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "wheel doesn't contain required files",
+			inputFile: "testdata/no-req-files/no-required-files.egg",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+			fileInfo, err := os.Lstat(tt.inputFile)
+			require.NoError(t, err)
+
+			a := &wheelAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				Content:  f,
+				FilePath: tt.inputFile,
+				Info:     fileInfo,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_wheelAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "wheel",
+			filePath: "wheelhouse/requests-2.32.3-py3-none-any.whl",
+			want:     true,
+		},
+		{
+			name:     "dist-info METADATA",
+			filePath: "python3.12/site-packages/requests-2.32.3.dist-info/METADATA",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := wheelAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package gem
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/ruby/gem"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&gemAnalyzer{})
+}
+
+const (
+	version  = 1
+	gemExt   = ".gem"
+	dataFile = "data.tar.gz"
+)
+
+// gemAnalyzer analyzes gem archives stored on disk, such as vendor/cache or a local gem server.
+type gemAnalyzer struct {
+	logger                           *log.Logger
+	licenseClassifierConfidenceLevel float64
+}
+
+func (a *gemAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	a.logger = log.WithPrefix("gem")
+	a.licenseClassifierConfidenceLevel = opt.LicenseScannerOption.ClassifierConfidenceLevel
+	return nil
+}
+
+func (a *gemAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	app, err := language.ParsePackage(types.GemSpec, input.FilePath, input.Content, gem.NewParser(), input.Options.FileChecksum)
+	if err != nil {
+		return nil, xerrors.Errorf("parse error: %w", err)
+	} else if app == nil {
+		return nil, nil
+	}
+
+	for i, pkg := range app.Packages {
+		// Fall back to the license files bundled in the gem when the specification doesn't declare the license
+		if len(pkg.Licenses) > 0 {
+			continue
+		}
+		licenses, err := a.classifyLicenseFiles(input.Content)
+		if err != nil {
+			a.logger.Warn("Unable to classify license files", log.FilePath(input.FilePath), log.Err(err))
+		}
+		app.Packages[i].Licenses = licenses
+	}
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{*app},
+	}, nil
+}
+
+// classifyLicenseFiles classifies license files (e.g. LICENSE.txt) in the root of data.tar.gz
+func (a *gemAnalyzer) classifyLicenseFiles(r xio.ReadSeekerAt) ([]string, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, xerrors.Errorf("file seek error: %w", err)
+	}
+
+	data, err := findFileInTar(r, dataFile)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to find %s: %w", dataFile, err)
+	}
+
+	gr, err := gzip.NewReader(data)
+	if err != nil {
+		return nil, xerrors.Errorf("gzip reader error: %w", err)
+	}
+	defer gr.Close()
+
+	var licenses []string
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, xerrors.Errorf("tar read error: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || path.Dir(hdr.Name) != "." || !licensing.IsLicenseFile(hdr.Name) {
+			continue
+		}
+
+		l, err := licensing.Classify(hdr.Name, tr, a.licenseClassifierConfidenceLevel)
+		if err != nil {
+			return nil, xerrors.Errorf("license classify error: %w", err)
+		} else if l == nil {
+			continue
+		}
+		licenses = append(licenses, l.Findings.Names()...)
+	}
+	return lo.Uniq(licenses), nil
+}
+
+func findFileInTar(r io.Reader, fileName string) (io.Reader, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, xerrors.Errorf("%s not found", fileName)
+		} else if err != nil {
+			return nil, xerrors.Errorf("tar read error: %w", err)
+		}
+		if hdr.Name == fileName {
+			return tr, nil
+		}
+	}
+}
+
+func (a *gemAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filepath.Ext(filePath) == gemExt
+}
+
+func (a *gemAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeGem
+}
+
+func (a *gemAnalyzer) Version() int {
+	return version
+}
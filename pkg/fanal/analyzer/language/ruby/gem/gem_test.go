@@ -0,0 +1,111 @@
+package gem
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+func Test_gemAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "licenses in specification",
+			inputFile: "testdata/rake-13.2.1.gem",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.GemSpec,
+						FilePath: "testdata/rake-13.2.1.gem",
+						Packages: types.Packages{
+							{
+								Name:    "rake",
+								Version: "13.2.1",
+								Licenses: []string{
+									"MIT",
+								},
+								FilePath: "testdata/rake-13.2.1.gem",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no licenses in specification",
+			inputFile: "testdata/no-license-0.1.0.gem",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.GemSpec,
+						FilePath: "testdata/no-license-0.1.0.gem",
+						Packages: types.Packages{
+							{
+								Name:    "no-license",
+								Version: "0.1.0",
+								Licenses: []string{
+									"MIT",
+								},
+								FilePath: "testdata/no-license-0.1.0.gem",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := &gemAnalyzer{
+				logger: log.WithPrefix("gem"),
+			}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				Content:  f,
+				FilePath: tt.inputFile,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_gemAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "gem",
+			filePath: "vendor/cache/rake-13.2.1.gem",
+			want:     true,
+		},
+		{
+			name:     "gemspec",
+			filePath: "vendor/bundle/ruby/3.3.0/specifications/rake-13.2.1.gemspec",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := gemAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
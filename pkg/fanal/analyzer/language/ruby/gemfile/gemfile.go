@@ -0,0 +1,160 @@
+package gemfile
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/ruby/gemfile"
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/utils"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+func init() {
+	analyzer.RegisterPostAnalyzer(analyzer.TypeGemfile, newGemfileAnalyzer)
+}
+
+const (
+	version = 1
+
+	gemspecExt = ".gemspec"
+)
+
+// Installed gems are detected by the gemspec analyzer
+var installedGemRegex = regexp.MustCompile(`(^|/)(specifications|vendor/bundle)/`)
+
+// gemfileAnalyzer detects dependencies declared in Gemfile and *.gemspec when Gemfile.lock doesn't exist,
+// e.g. gem libraries not committing Gemfile.lock.
+// The versions are not resolved, so packages are reported with the version constraints.
+type gemfileAnalyzer struct {
+	logger *log.Logger
+}
+
+func newGemfileAnalyzer(_ analyzer.AnalyzerOptions) (analyzer.PostAnalyzer, error) {
+	return &gemfileAnalyzer{
+		logger: log.WithPrefix("gemfile"),
+	}, nil
+}
+
+func (a gemfileAnalyzer) PostAnalyze(_ context.Context, input analyzer.PostAnalysisInput) (*analyzer.AnalysisResult, error) {
+	gemfiles := make(map[string]gemfile.Gemfile)
+	// Dependencies of *.gemspec, keyed by directory and file path
+	gemspecs := make(map[string]map[string][]types.Package)
+	// Directories containing Gemfile.lock, which is analyzed by the bundler analyzer
+	lockDirs := make(map[string]struct{})
+
+	required := func(path string, d fs.DirEntry) bool {
+		return true
+	}
+
+	err := fsutils.WalkDir(input.FS, ".", required, func(filePath string, d fs.DirEntry, r io.Reader) error {
+		dir := path.Dir(filePath)
+		switch {
+		case path.Base(filePath) == types.GemfileLock:
+			lockDirs[dir] = struct{}{}
+		case path.Base(filePath) == types.Gemfile:
+			g, err := gemfile.Parse(r)
+			if err != nil {
+				return xerrors.Errorf("unable to parse %s: %w", filePath, err)
+			}
+			gemfiles[filePath] = g
+		case path.Ext(filePath) == gemspecExt:
+			pkgs, err := gemfile.ParseGemspec(r)
+			if err != nil {
+				return xerrors.Errorf("unable to parse %s: %w", filePath, err)
+			}
+			if gemspecs[dir] == nil {
+				gemspecs[dir] = make(map[string][]types.Package)
+			}
+			gemspecs[dir][filePath] = pkgs
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("gemfile walk error: %w", err)
+	}
+
+	var apps []types.Application
+	// Directories of *.gemspec loaded by Gemfile
+	loadedDirs := make(map[string]struct{})
+	for filePath, g := range gemfiles {
+		dir := path.Dir(filePath)
+		if g.GemspecDir != "" {
+			gemspecDir := path.Join(dir, g.GemspecDir)
+			loadedDirs[gemspecDir] = struct{}{}
+			for _, pkgs := range gemspecs[gemspecDir] {
+				g.Packages = append(g.Packages, pkgs...)
+			}
+		}
+		if _, ok := lockDirs[dir]; ok {
+			continue
+		}
+		apps = a.appendApp(apps, filePath, utils.UniquePackages(g.Packages))
+	}
+
+	// *.gemspec of gem projects without Gemfile
+	for dir, files := range gemspecs {
+		if _, ok := loadedDirs[dir]; ok {
+			continue
+		} else if _, ok = lockDirs[dir]; ok {
+			continue
+		}
+		for filePath, pkgs := range files {
+			apps = a.appendApp(apps, filePath, pkgs)
+		}
+	}
+
+	slices.SortFunc(apps, func(a, b types.Application) int {
+		return strings.Compare(a.FilePath, b.FilePath)
+	})
+
+	return &analyzer.AnalysisResult{
+		Applications: apps,
+	}, nil
+}
+
+func (a gemfileAnalyzer) appendApp(apps []types.Application, filePath string, pkgs []types.Package) []types.Application {
+	if len(pkgs) == 0 {
+		return apps
+	}
+	if lo.ContainsBy(pkgs, func(pkg types.Package) bool { return pkg.Unresolved }) {
+		a.logger.Info("Gemfile.lock is not found. Versions of the dependencies are not resolved and only the version constraints are reported",
+			log.FilePath(filePath))
+	}
+	return append(apps, types.Application{
+		Type:     types.Bundler,
+		FilePath: filePath,
+		Packages: pkgs,
+	})
+}
+
+func (a gemfileAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	fileName := filepath.Base(filePath)
+	switch {
+	case fileName == types.Gemfile, fileName == types.GemfileLock:
+		return true
+	case filepath.Ext(fileName) == gemspecExt:
+		return !installedGemRegex.MatchString(filepath.ToSlash(filePath))
+	}
+	return false
+}
+
+func (a gemfileAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeGemfile
+}
+
+func (a gemfileAnalyzer) Version() int {
+	return version
+}
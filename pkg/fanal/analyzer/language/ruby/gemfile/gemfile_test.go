@@ -0,0 +1,166 @@
+package gemfile
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_gemfileAnalyzer_PostAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+		want *analyzer.AnalysisResult
+	}{
+		{
+			name: "Gemfile with gemspec",
+			dir:  "testdata/library",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Bundler,
+						FilePath: "Gemfile",
+						Packages: types.Packages{
+							{
+								ID:                "rack",
+								Name:              "rack",
+								Relationship:      types.RelationshipDirect,
+								Unresolved:        true,
+								VersionConstraint: ">= 2.2",
+								Locations: []types.Location{
+									{
+										StartLine: 5,
+										EndLine:   5,
+									},
+								},
+							},
+							{
+								ID:           "rake@13.1.0",
+								Name:         "rake",
+								Version:      "13.1.0",
+								Relationship: types.RelationshipDirect,
+								Dev:          true,
+								Locations: []types.Location{
+									{
+										StartLine: 6,
+										EndLine:   6,
+									},
+								},
+							},
+							{
+								ID:                "rspec",
+								Name:              "rspec",
+								Relationship:      types.RelationshipDirect,
+								Dev:               true,
+								Unresolved:        true,
+								VersionConstraint: "~> 3.13",
+								Locations: []types.Location{
+									{
+										StartLine: 6,
+										EndLine:   6,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "gemspec without Gemfile",
+			dir:  "testdata/gemspec-only",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Bundler,
+						FilePath: "plugin.gemspec",
+						Packages: types.Packages{
+							{
+								ID:                "faraday",
+								Name:              "faraday",
+								Relationship:      types.RelationshipDirect,
+								Unresolved:        true,
+								VersionConstraint: "~> 2.9",
+								Locations: []types.Location{
+									{
+										StartLine: 4,
+										EndLine:   4,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Gemfile.lock exists",
+			dir:  "testdata/locked",
+			want: &analyzer.AnalysisResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newGemfileAnalyzer(analyzer.AnalyzerOptions{})
+			require.NoError(t, err)
+
+			got, err := a.PostAnalyze(context.Background(), analyzer.PostAnalysisInput{
+				FS: os.DirFS(tt.dir),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_gemfileAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "Gemfile",
+			filePath: "app/Gemfile",
+			want:     true,
+		},
+		{
+			name:     "Gemfile.lock",
+			filePath: "app/Gemfile.lock",
+			want:     true,
+		},
+		{
+			name:     "gemspec",
+			filePath: "lib/library.gemspec",
+			want:     true,
+		},
+		{
+			name:     "installed gemspec",
+			filePath: "usr/local/bundle/specifications/rack-3.0.9.gemspec",
+			want:     false,
+		},
+		{
+			name:     "vendored gemspec",
+			filePath: "vendor/bundle/ruby/3.3.0/gems/rack-3.0.9/rack.gemspec",
+			want:     false,
+		},
+		{
+			name:     "gems.rb",
+			filePath: "gems.rb",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := gemfileAnalyzer{}
+			assert.Equal(t, tt.want, a.Required(tt.filePath, nil))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package crate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/rust/crate"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&crateAnalyzer{})
+}
+
+const (
+	version  = 1
+	crateExt = ".crate"
+)
+
+// crateAnalyzer analyzes crate archives stored on disk, such as the Cargo registry cache or a local mirror.
+type crateAnalyzer struct {
+	logger                           *log.Logger
+	licenseClassifierConfidenceLevel float64
+}
+
+func (a *crateAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	a.logger = log.WithPrefix("cargo")
+	a.licenseClassifierConfidenceLevel = opt.LicenseScannerOption.ClassifierConfidenceLevel
+	return nil
+}
+
+func (a *crateAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	app, err := language.ParsePackage(types.Cargo, input.FilePath, input.Content, crate.NewParser(), input.Options.FileChecksum)
+	if err != nil {
+		return nil, xerrors.Errorf("parse error: %w", err)
+	} else if app == nil {
+		return nil, nil
+	}
+
+	for i, pkg := range app.Packages {
+		// The license is declared in the manifest
+		if len(pkg.Licenses) > 0 && !strings.HasPrefix(pkg.Licenses[0], licensing.LicenseFilePrefix) {
+			continue
+		}
+
+		var licenseFile string
+		if len(pkg.Licenses) > 0 {
+			licenseFile = path.Clean(strings.TrimPrefix(pkg.Licenses[0], licensing.LicenseFilePrefix))
+		}
+
+		licenses, err := a.classifyLicenseFiles(input.Content, licenseFile)
+		if err != nil {
+			a.logger.Warn("Unable to classify license files", log.FilePath(input.FilePath), log.Err(err))
+		}
+		app.Packages[i].Licenses = licenses
+	}
+
+	return &analyzer.AnalysisResult{
+		Applications: []types.Application{*app},
+	}, nil
+}
+
+// classifyLicenseFiles classifies the file specified in "license-file" and license files (e.g. LICENSE-MIT) in the crate root
+func (a *crateAnalyzer) classifyLicenseFiles(r xio.ReadSeekerAt, licenseFile string) ([]string, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, xerrors.Errorf("file seek error: %w", err)
+	}
+
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, xerrors.Errorf("gzip reader error: %w", err)
+	}
+	defer gr.Close()
+
+	var licenses []string
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, xerrors.Errorf("tar read error: %w", err)
+		}
+
+		// Files are stored in the "<name>-<version>" directory
+		_, filePath, _ := strings.Cut(hdr.Name, "/")
+		if hdr.Typeflag != tar.TypeReg || (filePath != licenseFile && (path.Dir(filePath) != "." || !licensing.IsLicenseFile(filePath))) {
+			continue
+		}
+
+		l, err := licensing.Classify(hdr.Name, tr, a.licenseClassifierConfidenceLevel)
+		if err != nil {
+			return nil, xerrors.Errorf("license classify error: %w", err)
+		} else if l == nil {
+			continue
+		}
+		licenses = append(licenses, l.Findings.Names()...)
+	}
+	return lo.Uniq(licenses), nil
+}
+
+func (a *crateAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filepath.Ext(filePath) == crateExt
+}
+
+func (a *crateAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeRustCrate
+}
+
+func (a *crateAnalyzer) Version() int {
+	return version
+}
@@ -0,0 +1,133 @@
+package crate
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+func Test_crateAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputFile string
+		want      *analyzer.AnalysisResult
+	}{
+		{
+			name:      "license in manifest",
+			inputFile: "testdata/cfg-if-1.0.0.crate",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Cargo,
+						FilePath: "testdata/cfg-if-1.0.0.crate",
+						Packages: types.Packages{
+							{
+								Name:    "cfg-if",
+								Version: "1.0.0",
+								Licenses: []string{
+									"MIT OR Apache-2.0",
+								},
+								FilePath: "testdata/cfg-if-1.0.0.crate",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "license-file in manifest",
+			inputFile: "testdata/license-file-0.1.0.crate",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Cargo,
+						FilePath: "testdata/license-file-0.1.0.crate",
+						Packages: types.Packages{
+							{
+								Name:    "license-file",
+								Version: "0.1.0",
+								Licenses: []string{
+									"MIT",
+								},
+								FilePath: "testdata/license-file-0.1.0.crate",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "no license in manifest",
+			inputFile: "testdata/no-license-0.1.0.crate",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.Cargo,
+						FilePath: "testdata/no-license-0.1.0.crate",
+						Packages: types.Packages{
+							{
+								Name:    "no-license",
+								Version: "0.1.0",
+								Licenses: []string{
+									"MIT",
+								},
+								FilePath: "testdata/no-license-0.1.0.crate",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := &crateAnalyzer{
+				logger: log.WithPrefix("cargo"),
+			}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				Content:  f,
+				FilePath: tt.inputFile,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_crateAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "crate",
+			filePath: ".cargo/registry/cache/index.crates.io-6f17d22bba15001f/serde-1.0.210.crate",
+			want:     true,
+		},
+		{
+			name:     "Cargo.toml",
+			filePath: ".cargo/registry/src/index.crates.io-6f17d22bba15001f/serde-1.0.210/Cargo.toml",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := crateAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package lock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/terraform/lock"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&terraformLockAnalyzer{})
+}
+
+const version = 1
+
+// terraformLockAnalyzer analyzes '.terraform.lock.hcl'
+type terraformLockAnalyzer struct{}
+
+func (a terraformLockAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	p := lock.NewParser()
+	res, err := language.Analyze(types.TerraformProvider, input.FilePath, input.Content, p)
+	if err != nil {
+		return nil, xerrors.Errorf("%s parse error: %w", input.FilePath, err)
+	}
+	return res, nil
+}
+
+func (a terraformLockAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filepath.Base(filePath) == types.TerraformLock
+}
+
+func (a terraformLockAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeTerraformLock
+}
+
+func (a terraformLockAnalyzer) Version() int {
+	return version
+}
@@ -18,7 +18,7 @@ import (
 	xio "github.com/aquasecurity/trivy/pkg/x/io"
 )
 
-const version = 1
+const version = 3
 
 var (
 	skipDirs = []string{
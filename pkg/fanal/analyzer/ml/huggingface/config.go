@@ -0,0 +1,125 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&configAnalyzer{})
+}
+
+const (
+	configVersion  = 1
+	configFileName = "config.json"
+)
+
+// modelConfig represents config.json in Hugging Face model repositories.
+// cf. https://huggingface.co/docs/transformers/main_classes/configuration
+type modelConfig struct {
+	ModelType           string   `json:"model_type"`
+	Architectures       []string `json:"architectures"`
+	TransformersVersion string   `json:"transformers_version"`
+
+	// e.g. {"AutoModelForCausalLM": "modeling_foo.FooForCausalLM"}
+	// or {"AutoTokenizer": ["tokenization_foo.FooTokenizer", null]}
+	AutoMap map[string]any `json:"auto_map"`
+}
+
+func (c modelConfig) isModelConfig() bool {
+	return c.ModelType != "" || len(c.Architectures) > 0 || c.TransformersVersion != ""
+}
+
+// configAnalyzer detects Hugging Face models that require custom code to be loaded.
+type configAnalyzer struct{}
+
+func (a configAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	var cfg modelConfig
+	if err := json.NewDecoder(input.Content).Decode(&cfg); err != nil {
+		// Other tools use config.json as well
+		return nil, nil
+	} else if !cfg.isModelConfig() {
+		return nil, nil
+	}
+
+	misconf := types.Misconfiguration{
+		FileType: types.HuggingFace,
+		FilePath: input.FilePath,
+	}
+
+	autoClasses := lo.Keys(cfg.AutoMap)
+	slices.Sort(autoClasses)
+	for _, autoClass := range autoClasses {
+		classRef := classReference(cfg.AutoMap[autoClass])
+		if classRef == "" {
+			continue
+		}
+		msg := fmt.Sprintf("'%s' is mapped to '%s', which requires 'trust_remote_code=True' to load the model", autoClass, classRef)
+		misconf.Failures = append(misconf.Failures, newRemoteCodeResult(autoClass, msg))
+	}
+	if len(misconf.Failures) == 0 {
+		misconf.Successes = append(misconf.Successes, newRemoteCodeResult("", ""))
+	}
+
+	return &analyzer.AnalysisResult{
+		Misconfigurations: []types.Misconfiguration{misconf},
+	}, nil
+}
+
+func (a configAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return filepath.Base(filePath) == configFileName
+}
+
+func (a configAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeHuggingFaceConfig
+}
+
+func (a configAnalyzer) Version() int {
+	return configVersion
+}
+
+// classReference returns the class referenced in "auto_map".
+func classReference(v any) string {
+	switch ref := v.(type) {
+	case string:
+		return ref
+	case []any:
+		// Slow and fast tokenizers
+		return strings.Join(lo.FilterMap(ref, func(r any, _ int) (string, bool) {
+			s, ok := r.(string)
+			return s, ok && s != ""
+		}), ", ")
+	}
+	return ""
+}
+
+func newRemoteCodeResult(resource, msg string) types.MisconfResult {
+	return types.MisconfResult{
+		Namespace: "ml.huggingface",
+		Message:   msg,
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "ML002",
+			Type:               "Hugging Face Security Check",
+			Title:              "Model requires remote code execution",
+			Description:        "The model config maps classes to Python code in the model repository. Loading the model with 'trust_remote_code=True' runs that code on the local machine.",
+			Severity:           "HIGH",
+			RecommendedActions: "Review the custom code in the model repository and pin the model revision, or use a model supported by the library natively.",
+			References: []string{
+				"https://huggingface.co/docs/transformers/custom_models",
+			},
+		},
+		CauseMetadata: types.CauseMetadata{
+			Resource: resource,
+		},
+	}
+}
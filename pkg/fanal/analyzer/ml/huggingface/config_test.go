@@ -0,0 +1,100 @@
+package huggingface
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_configAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputFile     string
+		wantFailures  []string
+		wantSuccesses int
+		wantNil       bool
+	}{
+		{
+			name:      "remote code",
+			inputFile: "testdata/remote-code/config.json",
+			wantFailures: []string{
+				"'AutoConfig' is mapped to 'configuration_foo.FooConfig', which requires 'trust_remote_code=True' to load the model",
+				"'AutoModelForCausalLM' is mapped to 'modeling_foo.FooForCausalLM', which requires 'trust_remote_code=True' to load the model",
+				"'AutoTokenizer' is mapped to 'tokenization_foo.FooTokenizer', which requires 'trust_remote_code=True' to load the model",
+			},
+		},
+		{
+			name:          "native model",
+			inputFile:     "testdata/native/config.json",
+			wantFailures:  []string{},
+			wantSuccesses: 1,
+		},
+		{
+			name:      "not model config",
+			inputFile: "testdata/other/config.json",
+			wantNil:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			a := configAnalyzer{}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				Content:  f,
+				FilePath: tt.inputFile,
+			})
+			require.NoError(t, err)
+
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			require.Len(t, got.Misconfigurations, 1)
+
+			misconf := got.Misconfigurations[0]
+			assert.Equal(t, types.HuggingFace, misconf.FileType)
+			assert.Equal(t, tt.inputFile, misconf.FilePath)
+			assert.Len(t, misconf.Successes, tt.wantSuccesses)
+			assert.Equal(t, tt.wantFailures, lo.Map(misconf.Failures, func(res types.MisconfResult, _ int) string {
+				return res.Message
+			}))
+		})
+	}
+}
+
+func Test_configAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "config.json",
+			filePath: "models/foo/config.json",
+			want:     true,
+		},
+		{
+			name:     "generation_config.json",
+			filePath: "models/foo/generation_config.json",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := configAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
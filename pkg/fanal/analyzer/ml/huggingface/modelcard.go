@@ -0,0 +1,42 @@
+package huggingface
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/dependency/parser/python/modelcard"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&modelCardAnalyzer{})
+}
+
+const (
+	modelCardVersion  = 1
+	modelCardFileName = "README.md"
+)
+
+// modelCardAnalyzer analyzes Python packages required by Hugging Face models.
+// Model cards often describe the packages to be installed, e.g. "pip install transformers==4.38.0".
+type modelCardAnalyzer struct{}
+
+func (a modelCardAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	return language.Analyze(types.Pip, input.FilePath, input.Content, modelcard.NewParser())
+}
+
+func (a modelCardAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return strings.EqualFold(filepath.Base(filePath), modelCardFileName)
+}
+
+func (a modelCardAnalyzer) Type() analyzer.Type {
+	return analyzer.TypeModelCard
+}
+
+func (a modelCardAnalyzer) Version() int {
+	return modelCardVersion
+}
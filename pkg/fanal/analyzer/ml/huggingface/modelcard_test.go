@@ -0,0 +1,84 @@
+package huggingface
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_modelCardAnalyzer_Analyze(t *testing.T) {
+	f, err := os.Open("testdata/remote-code/README.md")
+	require.NoError(t, err)
+	defer f.Close()
+
+	a := modelCardAnalyzer{}
+	got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+		Content:  f,
+		FilePath: "models/foo/README.md",
+	})
+	require.NoError(t, err)
+
+	want := &analyzer.AnalysisResult{
+		Applications: []types.Application{
+			{
+				Type:     types.Pip,
+				FilePath: "models/foo/README.md",
+				Packages: types.Packages{
+					{
+						Name:      "transformers",
+						Version:   "4.38.0",
+						Locations: []types.Location{{StartLine: 14, EndLine: 14}},
+					},
+					{
+						Name:      "accelerate",
+						Version:   "0.27.2",
+						Locations: []types.Location{{StartLine: 14, EndLine: 14}},
+					},
+					{
+						Name:      "bitsandbytes",
+						Version:   "0.42.0",
+						Locations: []types.Location{{StartLine: 20, EndLine: 20}},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
+
+func Test_modelCardAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "README.md",
+			filePath: "models/foo/README.md",
+			want:     true,
+		},
+		{
+			name:     "lower case",
+			filePath: "models/foo/readme.md",
+			want:     true,
+		},
+		{
+			name:     "other markdown",
+			filePath: "models/foo/USAGE.md",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := modelCardAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package pickle
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+func init() {
+	analyzer.RegisterAnalyzer(&pickleAnalyzer{})
+}
+
+const version = 2
+
+var (
+	// Extensions of pickle files and PyTorch checkpoints
+	// e.g. pytorch_model.bin in Hugging Face model repositories
+	requiredExts = []string{
+		".pkl",
+		".pickle",
+		".pt",
+		".pth",
+		".bin",
+		".ckpt",
+		".joblib",
+	}
+
+	// Extensions of files that are always pickles.
+	// The other files, such as ".bin", are analyzed only if they look like pickles or PyTorch checkpoints.
+	pickleExts = []string{
+		".pkl",
+		".pickle",
+		".joblib",
+	}
+
+	zipMagic   = []byte("PK\x03\x04")
+	gzipMagic  = []byte("\x1f\x8b")
+	bzip2Magic = []byte("BZh")
+)
+
+// unsafeGlobals are callables that allow arbitrary code execution, file access, network access, etc.
+// "*" means all callables in the module.
+// cf. https://github.com/mmaitre314/picklescan/blob/main/src/picklescan/scanner.py
+var unsafeGlobals = map[string][]string{
+	"__builtin__":    {"eval", "exec", "execfile", "compile", "open", "getattr", "apply", "__import__", "breakpoint"},
+	"builtins":       {"eval", "exec", "execfile", "compile", "open", "getattr", "apply", "__import__", "breakpoint"},
+	"_codecs":        {"open"},
+	"_io":            {"open", "FileIO"},
+	"io":             {"open", "FileIO"},
+	"os":             {"*"},
+	"posix":          {"*"},
+	"nt":             {"*"},
+	"subprocess":     {"*"},
+	"sys":            {"*"},
+	"shutil":         {"*"},
+	"socket":         {"*"},
+	"ssl":            {"*"},
+	"runpy":          {"*"},
+	"pty":            {"*"},
+	"commands":       {"*"},
+	"webbrowser":     {"*"},
+	"ctypes":         {"*"},
+	"importlib":      {"*"},
+	"pickle":         {"*"},
+	"_pickle":        {"*"},
+	"marshal":        {"*"},
+	"code":           {"*"},
+	"codeop":         {"*"},
+	"pdb":            {"*"},
+	"bdb":            {"*"},
+	"timeit":         {"*"},
+	"asyncio":        {"*"},
+	"httplib":        {"*"},
+	"http.client":    {"*"},
+	"urllib":         {"*"},
+	"urllib.request": {"*"},
+	"requests":       {"*"},
+	"aiohttp":        {"*"},
+	"operator":       {"attrgetter", "methodcaller"},
+	"functools":      {"partial"},
+}
+
+// safeGlobals are callables commonly used to rebuild models and arrays, which don't have side effects.
+// Imports not listed here nor in unsafeGlobals are reported as unknown, as custom classes might run any code.
+var safeGlobals = map[string][]string{
+	"__builtin__":            {"set", "frozenset", "slice", "range", "complex", "bytearray", "bytes", "object"},
+	"builtins":               {"set", "frozenset", "slice", "range", "complex", "bytearray", "bytes", "object"},
+	"_codecs":                {"encode"},
+	"copy_reg":               {"_reconstructor"},
+	"copyreg":                {"_reconstructor"},
+	"collections":            {"OrderedDict", "defaultdict", "Counter", "deque"},
+	"datetime":               {"date", "datetime", "time", "timedelta", "timezone"},
+	"numpy":                  {"dtype", "ndarray", "float16", "float32", "float64", "int8", "int16", "int32", "int64", "uint8", "bool_"},
+	"numpy.core.multiarray":  {"_reconstruct", "scalar"},
+	"numpy._core.multiarray": {"_reconstruct", "scalar"},
+	"numpy.random._pickle":   {"__randomstate_ctor", "__generator_ctor", "__bit_generator_ctor"},
+	"joblib.numpy_pickle":    {"NumpyArrayWrapper"},
+	"torch": {"Size", "device", "dtype", "float16", "float32", "float64", "bfloat16", "int8", "int16", "int32", "int64", "uint8", "bool",
+		"BFloat16Storage", "BoolStorage", "ByteStorage", "CharStorage", "DoubleStorage", "FloatStorage", "HalfStorage",
+		"IntStorage", "LongStorage", "ShortStorage", "ComplexFloatStorage", "ComplexDoubleStorage", "UntypedStorage"},
+	"torch._utils": {"_rebuild_tensor", "_rebuild_tensor_v2", "_rebuild_tensor_v3", "_rebuild_parameter",
+		"_rebuild_parameter_with_state", "_rebuild_qtensor", "_rebuild_sparse_tensor", "_rebuild_device_tensor_from_numpy"},
+	"torch._tensor": {"_rebuild_from_type_v2"},
+	// scikit-learn estimators are instantiated without arguments and restored with __setstate__
+	"sklearn": {"*"},
+}
+
+// joblibArrayWrapper wraps NumPy arrays in joblib dumps.
+// The array is written as raw bytes after the wrapper, so the rest of the pickle can't be parsed without loading it.
+var joblibArrayWrapper = Global{
+	Module: "joblib.numpy_pickle",
+	Name:   "NumpyArrayWrapper",
+}
+
+type pickleAnalyzer struct {
+	logger *log.Logger
+}
+
+func (a *pickleAnalyzer) Init(_ analyzer.AnalyzerOptions) error {
+	a.logger = log.WithPrefix("pickle")
+	return nil
+}
+
+func (a *pickleAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
+	header := make([]byte, len(zipMagic))
+	n, err := io.ReadFull(input.Content, header)
+	if err != nil && n == 0 {
+		return nil, nil
+	}
+	if _, err = input.Content.Seek(0, io.SeekStart); err != nil {
+		return nil, xerrors.Errorf("seek error: %w", err)
+	}
+
+	var results []scanResult
+	switch {
+	case bytes.HasPrefix(header[:n], zipMagic):
+		// PyTorch saves models as zip archives containing "data.pkl" since v1.6
+		results, err = scanZip(input.Content, input.Info.Size())
+	case header[0] == opProto || slices.Contains(pickleExts, filepath.Ext(input.FilePath)):
+		var globals []Global
+		globals, err = scanCompressed(header[:n], input.Content)
+		results = []scanResult{{globals: globals}}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		a.logger.Debug("Unable to parse the pickle file", log.FilePath(input.FilePath), log.Err(err))
+		return nil, nil
+	} else if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &analyzer.AnalysisResult{
+		Misconfigurations: []types.Misconfiguration{toMisconfiguration(input.FilePath, results)},
+	}, nil
+}
+
+func (a *pickleAnalyzer) Required(filePath string, _ os.FileInfo) bool {
+	return slices.Contains(requiredExts, strings.ToLower(filepath.Ext(filePath)))
+}
+
+func (a *pickleAnalyzer) Type() analyzer.Type {
+	return analyzer.TypePickle
+}
+
+func (a *pickleAnalyzer) Version() int {
+	return version
+}
+
+type scanResult struct {
+	// The pickle path in the archive
+	resource string
+	globals  []Global
+}
+
+// scanZip scans pickles in the zip archive, e.g. "archive/data.pkl".
+func scanZip(r xio.ReadSeekerAt, size int64) ([]scanResult, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, xerrors.Errorf("zip reader error: %w", err)
+	}
+
+	var results []scanResult
+	for _, f := range zr.File {
+		if !slices.Contains(pickleExts, filepath.Ext(f.Name)) {
+			continue
+		}
+		globals, err := scanZipFile(f)
+		if err != nil {
+			return nil, xerrors.Errorf("%s: %w", f.Name, err)
+		}
+		results = append(results, scanResult{
+			resource: f.Name,
+			globals:  globals,
+		})
+	}
+	return results, nil
+}
+
+// scanCompressed scans the pickle compressed by joblib, e.g. joblib.dump(model, "model.joblib", compress=3).
+// LZMA and LZ4 are not supported.
+func scanCompressed(header []byte, r io.Reader) ([]Global, error) {
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, xerrors.Errorf("gzip reader error: %w", err)
+		}
+		defer gr.Close()
+		return scanGlobals(gr)
+	case bytes.HasPrefix(header, bzip2Magic):
+		return scanGlobals(bzip2.NewReader(r))
+	case isZlib(header):
+		zr, err := zlib.NewReader(r)
+		if err != nil {
+			return nil, xerrors.Errorf("zlib reader error: %w", err)
+		}
+		defer zr.Close()
+		return scanGlobals(zr)
+	}
+	return scanGlobals(r)
+}
+
+// isZlib checks the zlib header. 'x' is also a valid opcode (BINUNICODE), but pickles never start with it.
+func isZlib(header []byte) bool {
+	return len(header) >= 2 && header[0] == 0x78 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0
+}
+
+func scanZipFile(f *zip.File) ([]Global, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, xerrors.Errorf("open error: %w", err)
+	}
+	defer rc.Close()
+	return scanGlobals(rc)
+}
+
+func isUnsafe(g Global) bool {
+	return contains(unsafeGlobals, g)
+}
+
+func isSafe(g Global) bool {
+	return contains(safeGlobals, g)
+}
+
+func contains(globals map[string][]string, g Global) bool {
+	names, ok := globals[g.Module]
+	if !ok {
+		// e.g. "os.path" => "os"
+		module, _, _ := strings.Cut(g.Module, ".")
+		if names, ok = globals[module]; !ok || !slices.Contains(names, "*") {
+			return false
+		}
+	}
+	return slices.Contains(names, "*") || slices.Contains(names, g.Name)
+}
+
+// check represents the metadata of findings in pickle files.
+type check struct {
+	types.PolicyMetadata
+}
+
+var (
+	unsafeImportCheck = check{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "ML001",
+			Type:               "Pickle Security Check",
+			Title:              "Unsafe deserialization in pickle file",
+			Description:        "Loading a pickle file calls the functions imported by it. A pickle importing functions such as 'os.system' or 'builtins.exec' runs arbitrary code when the model is loaded.",
+			Severity:           "CRITICAL",
+			RecommendedActions: "Load pickle files only from trusted sources, or use a format that doesn't execute code, such as safetensors.",
+			References: []string{
+				"https://huggingface.co/docs/hub/security-pickle",
+				"https://docs.python.org/3/library/pickle.html",
+			},
+		},
+	}
+	unknownImportCheck = check{
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "ML003",
+			Type:               "Pickle Security Check",
+			Title:              "Unknown import in pickle file",
+			Description:        "The pickle file imports a callable that is not known to be safe, such as a custom class. The callable is executed when the file is loaded and might have side effects.",
+			Severity:           "MEDIUM",
+			RecommendedActions: "Review the imported callables, or use a format that doesn't execute code, such as safetensors.",
+			References: []string{
+				"https://huggingface.co/docs/hub/security-pickle",
+				"https://docs.python.org/3/library/pickle.html#restricting-globals",
+			},
+		},
+	}
+)
+
+func toMisconfiguration(filePath string, results []scanResult) types.Misconfiguration {
+	misconf := types.Misconfiguration{
+		FileType: types.Pickle,
+		FilePath: filePath,
+	}
+
+	for _, res := range results {
+		var unsafe, unknown []Global
+		for _, g := range lo.Uniq(res.globals) {
+			switch {
+			case isUnsafe(g):
+				unsafe = append(unsafe, g)
+			case !isSafe(g):
+				unknown = append(unknown, g)
+			}
+		}
+
+		for _, c := range []struct {
+			check   check
+			globals []Global
+			format  string
+		}{
+			{
+				check:   unsafeImportCheck,
+				globals: unsafe,
+				format:  "Pickle imports '%s', which can execute arbitrary code when the file is loaded",
+			},
+			{
+				check:   unknownImportCheck,
+				globals: unknown,
+				format:  "Pickle imports '%s', which is not in the allowlist of safe imports",
+			},
+		} {
+			if len(c.globals) == 0 {
+				misconf.Successes = append(misconf.Successes, c.check.newResult(res.resource, ""))
+				continue
+			}
+			for _, g := range c.globals {
+				misconf.Failures = append(misconf.Failures, c.check.newResult(res.resource, fmt.Sprintf(c.format, g)))
+			}
+		}
+	}
+	return misconf
+}
+
+func (c check) newResult(resource, msg string) types.MisconfResult {
+	return types.MisconfResult{
+		Namespace:      "ml.pickle",
+		Message:        msg,
+		PolicyMetadata: c.PolicyMetadata,
+		CauseMetadata: types.CauseMetadata{
+			Resource: resource,
+		},
+	}
+}
@@ -0,0 +1,205 @@
+package pickle
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+func Test_pickleAnalyzer_Analyze(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputFile     string
+		wantResources []string
+		wantFailures  []string
+		wantSuccesses int
+		wantNil       bool
+	}{
+		{
+			name:          "safe pickle",
+			inputFile:     "testdata/safe.pkl",
+			wantResources: []string{},
+			wantFailures:  []string{},
+			wantSuccesses: 2,
+		},
+		{
+			name:      "STACK_GLOBAL",
+			inputFile: "testdata/malicious.pkl",
+			wantResources: []string{
+				"",
+				"",
+			},
+			wantFailures: []string{
+				"Pickle imports 'posix.system', which can execute arbitrary code when the file is loaded",
+				"Pickle imports 'builtins.eval', which can execute arbitrary code when the file is loaded",
+			},
+			wantSuccesses: 1,
+		},
+		{
+			name:      "protocol 0",
+			inputFile: "testdata/protocol0.pickle",
+			wantResources: []string{
+				"",
+			},
+			wantFailures: []string{
+				"Pickle imports 'posix.system', which can execute arbitrary code when the file is loaded",
+			},
+			wantSuccesses: 1,
+		},
+		{
+			name:      "PyTorch zip format",
+			inputFile: "testdata/pytorch_model.bin",
+			wantResources: []string{
+				"archive/data.pkl",
+			},
+			wantFailures: []string{
+				"Pickle imports 'posix.system', which can execute arbitrary code when the file is loaded",
+			},
+			wantSuccesses: 1,
+		},
+		{
+			name:      "unknown import",
+			inputFile: "testdata/unknown.pkl",
+			wantResources: []string{
+				"",
+			},
+			wantFailures: []string{
+				"Pickle imports '__main__.Net', which is not in the allowlist of safe imports",
+			},
+			wantSuccesses: 1,
+		},
+		{
+			name:          "compressed joblib",
+			inputFile:     "testdata/model.joblib",
+			wantResources: []string{},
+			wantFailures:  []string{},
+			wantSuccesses: 2,
+		},
+		{
+			name:      "not pickle",
+			inputFile: "testdata/not-pickle.bin",
+			wantNil:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.inputFile)
+			require.NoError(t, err)
+			defer f.Close()
+
+			info, err := f.Stat()
+			require.NoError(t, err)
+
+			a := &pickleAnalyzer{
+				logger: log.WithPrefix("pickle"),
+			}
+			got, err := a.Analyze(context.Background(), analyzer.AnalysisInput{
+				Content:  f,
+				FilePath: tt.inputFile,
+				Info:     info,
+			})
+			require.NoError(t, err)
+
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			require.Len(t, got.Misconfigurations, 1)
+
+			misconf := got.Misconfigurations[0]
+			assert.Equal(t, types.Pickle, misconf.FileType)
+			assert.Equal(t, tt.inputFile, misconf.FilePath)
+			assert.Len(t, misconf.Successes, tt.wantSuccesses)
+			assert.Equal(t, tt.wantFailures, lo.Map(misconf.Failures, func(res types.MisconfResult, _ int) string {
+				return res.Message
+			}))
+			assert.Equal(t, tt.wantResources, lo.Map(misconf.Failures, func(res types.MisconfResult, _ int) string {
+				return res.Resource
+			}))
+		})
+	}
+}
+
+func Test_isUnsafe(t *testing.T) {
+	tests := []struct {
+		name   string
+		global Global
+		want   bool
+	}{
+		{
+			name:   "os",
+			global: Global{Module: "os", Name: "system"},
+			want:   true,
+		},
+		{
+			name:   "submodule",
+			global: Global{Module: "os.path", Name: "join"},
+			want:   true,
+		},
+		{
+			name:   "unsafe builtin",
+			global: Global{Module: "builtins", Name: "exec"},
+			want:   true,
+		},
+		{
+			name:   "safe builtin",
+			global: Global{Module: "builtins", Name: "set"},
+			want:   false,
+		},
+		{
+			name:   "PyTorch",
+			global: Global{Module: "torch._utils", Name: "_rebuild_tensor_v2"},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isUnsafe(tt.global))
+		})
+	}
+}
+
+func Test_pickleAnalyzer_Required(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		want     bool
+	}{
+		{
+			name:     "pickle",
+			filePath: "models/model.pkl",
+			want:     true,
+		},
+		{
+			name:     "PyTorch",
+			filePath: "bert-base-uncased/pytorch_model.bin",
+			want:     true,
+		},
+		{
+			name:     "joblib",
+			filePath: "models/classifier.joblib",
+			want:     true,
+		},
+		{
+			name:     "safetensors",
+			filePath: "bert-base-uncased/model.safetensors",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := pickleAnalyzer{}
+			got := a.Required(tt.filePath, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package pickle
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// Pickle opcodes
+// cf. https://github.com/python/cpython/blob/main/Lib/pickletools.py
+const (
+	opMark           = '('
+	opStop           = '.'
+	opPop            = '0'
+	opPopMark        = '1'
+	opDup            = '2'
+	opFloat          = 'F'
+	opInt            = 'I'
+	opBinInt         = 'J'
+	opBinInt1        = 'K'
+	opLong           = 'L'
+	opBinInt2        = 'M'
+	opNone           = 'N'
+	opPersID         = 'P'
+	opBinPersID      = 'Q'
+	opReduce         = 'R'
+	opString         = 'S'
+	opBinString      = 'T'
+	opShortBinString = 'U'
+	opUnicode        = 'V'
+	opBinUnicode     = 'X'
+	opAppend         = 'a'
+	opBuild          = 'b'
+	opGlobal         = 'c'
+	opDict           = 'd'
+	opEmptyDict      = '}'
+	opAppends        = 'e'
+	opGet            = 'g'
+	opBinGet         = 'h'
+	opInst           = 'i'
+	opLongBinGet     = 'j'
+	opList           = 'l'
+	opEmptyList      = ']'
+	opObj            = 'o'
+	opPut            = 'p'
+	opBinPut         = 'q'
+	opLongBinPut     = 'r'
+	opSetItem        = 's'
+	opTuple          = 't'
+	opEmptyTuple     = ')'
+	opSetItems       = 'u'
+	opBinFloat       = 'G'
+
+	// Protocol 2
+	opProto    = '\x80'
+	opNewObj   = '\x81'
+	opExt1     = '\x82'
+	opExt2     = '\x83'
+	opExt4     = '\x84'
+	opTuple1   = '\x85'
+	opTuple2   = '\x86'
+	opTuple3   = '\x87'
+	opNewTrue  = '\x88'
+	opNewFalse = '\x89'
+	opLong1    = '\x8a'
+	opLong4    = '\x8b'
+
+	// Protocol 3
+	opBinBytes      = 'B'
+	opShortBinBytes = 'C'
+
+	// Protocol 4
+	opShortBinUnicode = '\x8c'
+	opBinUnicode8     = '\x8d'
+	opBinBytes8       = '\x8e'
+	opEmptySet        = '\x8f'
+	opAddItems        = '\x90'
+	opFrozenSet       = '\x91'
+	opNewObjEx        = '\x92'
+	opStackGlobal     = '\x93'
+	opMemoize         = '\x94'
+	opFrame           = '\x95'
+
+	// Protocol 5
+	opByteArray8     = '\x96'
+	opNextBuffer     = '\x97'
+	opReadOnlyBuffer = '\x98'
+)
+
+// maxStringSize is the maximum size of strings kept to resolve imports.
+// Larger strings, such as embedded data, are skipped.
+const maxStringSize = 1024
+
+// Global represents a callable imported by a pickle, e.g. "os.system".
+// Such callables are invoked on deserialization with REDUCE, etc.
+type Global struct {
+	Module string
+	Name   string
+}
+
+func (g Global) String() string {
+	return g.Module + "." + g.Name
+}
+
+// scanGlobals returns the globals imported by the pickle stream.
+// It doesn't execute the pickle, it only walks through the opcodes.
+// A file may contain several pickles in a row, like PyTorch legacy format,
+// so it continues until EOF or any data that is not a pickle.
+func scanGlobals(r io.Reader) ([]Global, error) {
+	br := bufio.NewReader(r)
+	var globals []Global
+	for i := 0; ; i++ {
+		g, err := scanPickle(br)
+		globals = append(globals, g...)
+		if errors.Is(err, io.EOF) {
+			return globals, nil
+		} else if err != nil {
+			// Only the first pickle must be valid.
+			// The rest might be raw data, e.g. tensor storages in PyTorch legacy format.
+			// Joblib writes NumPy arrays as raw data in the middle of the pickle, so the rest can't be scanned.
+			if i == 0 && !slices.Contains(globals, joblibArrayWrapper) {
+				return globals, err
+			}
+			return globals, nil
+		}
+	}
+}
+
+// scanPickle reads a single pickle until STOP.
+// It returns io.EOF if there is no data.
+func scanPickle(br *bufio.Reader) ([]Global, error) {
+	var (
+		globals []Global
+		// Strings recently pushed onto the stack, used to resolve STACK_GLOBAL
+		strs []string
+		// Values in the memo, only strings are kept
+		memo = make(map[int]*string)
+		// The value pushed by the last opcode if it's a string
+		last    string
+		lastStr bool
+	)
+
+	push := func(s string) {
+		strs = append(strs, s)
+		last, lastStr = s, true
+	}
+
+	for n := 0; ; n++ {
+		op, err := br.ReadByte()
+		if err != nil {
+			if n != 0 && errors.Is(err, io.EOF) {
+				return globals, io.ErrUnexpectedEOF
+			}
+			return globals, err
+		}
+		prev, prevStr := last, lastStr
+		last, lastStr = "", false
+
+		switch op {
+		case opStop:
+			return globals, nil
+		case opGlobal, opInst:
+			module, err := readLine(br)
+			if err != nil {
+				return globals, err
+			}
+			name, err := readLine(br)
+			if err != nil {
+				return globals, err
+			}
+			globals = append(globals, Global{
+				Module: module,
+				Name:   name,
+			})
+		case opStackGlobal:
+			if len(strs) < 2 {
+				return globals, xerrors.New("STACK_GLOBAL without module and name")
+			}
+			globals = append(globals, Global{
+				Module: strs[len(strs)-2],
+				Name:   strs[len(strs)-1],
+			})
+			strs = strs[:len(strs)-2]
+		case opShortBinUnicode, opShortBinString, opShortBinBytes:
+			s, err := readString(br, 1)
+			if err != nil {
+				return globals, err
+			}
+			if op == opShortBinUnicode {
+				push(s)
+			}
+		case opBinUnicode, opBinString, opBinBytes:
+			s, err := readString(br, 4)
+			if err != nil {
+				return globals, err
+			}
+			if op == opBinUnicode {
+				push(s)
+			}
+		case opBinUnicode8, opBinBytes8, opByteArray8:
+			s, err := readString(br, 8)
+			if err != nil {
+				return globals, err
+			}
+			if op == opBinUnicode8 {
+				push(s)
+			}
+		case opUnicode:
+			s, err := readLine(br)
+			if err != nil {
+				return globals, err
+			}
+			push(s)
+		case opString:
+			s, err := readLine(br)
+			if err != nil {
+				return globals, err
+			}
+			// e.g. 'os'
+			if unquoted, err := strconv.Unquote(s); err == nil {
+				push(unquoted)
+			} else if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+				push(s[1 : len(s)-1])
+			}
+		case opMemoize, opPut, opBinPut, opLongBinPut:
+			idx := len(memo)
+			if op != opMemoize {
+				if idx, err = readMemoIndex(br, op, opPut, opBinPut); err != nil {
+					return globals, err
+				}
+			}
+			if prevStr {
+				memo[idx] = &prev
+			} else {
+				memo[idx] = nil
+			}
+			// The stack is not changed
+			last, lastStr = prev, prevStr
+		case opGet, opBinGet, opLongBinGet:
+			idx, err := readMemoIndex(br, op, opGet, opBinGet)
+			if err != nil {
+				return globals, err
+			}
+			if s := memo[idx]; s != nil {
+				push(*s)
+			}
+		case opFloat, opInt, opLong, opPersID:
+			if _, err = readLine(br); err != nil {
+				return globals, err
+			}
+		case opBinInt1, opProto, opExt1:
+			_, err = br.Discard(1)
+		case opBinInt2, opExt2:
+			_, err = br.Discard(2)
+		case opBinInt, opExt4:
+			_, err = br.Discard(4)
+		case opBinFloat, opFrame:
+			_, err = br.Discard(8)
+		case opLong1:
+			_, err = readString(br, 1)
+		case opLong4:
+			_, err = readString(br, 4)
+		case opMark, opPop, opPopMark, opDup, opNone, opBinPersID, opReduce, opAppend, opBuild, opDict,
+			opEmptyDict, opAppends, opList, opEmptyList, opObj, opSetItem, opTuple, opEmptyTuple, opSetItems,
+			opNewObj, opTuple1, opTuple2, opTuple3, opNewTrue, opNewFalse, opEmptySet, opAddItems, opFrozenSet,
+			opNewObjEx, opNextBuffer, opReadOnlyBuffer:
+			// No argument
+		default:
+			return globals, xerrors.Errorf("unknown opcode 0x%02x", op)
+		}
+		if err != nil {
+			return globals, err
+		}
+	}
+}
+
+func readLine(br *bufio.Reader) (string, error) {
+	line, err := br.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", xerrors.New("too long line")
+	} else if err != nil {
+		return "", unexpectedEOF(err)
+	}
+	return string(bytes.TrimRight(line, "\r\n")), nil
+}
+
+// readString reads a length-prefixed string.
+// The string is discarded and an empty string is returned if it's too large.
+func readString(br *bufio.Reader, lenSize int) (string, error) {
+	b := make([]byte, lenSize)
+	if _, err := io.ReadFull(br, b); err != nil {
+		return "", unexpectedEOF(err)
+	}
+
+	var size uint64
+	switch lenSize {
+	case 1:
+		size = uint64(b[0])
+	case 4:
+		size = uint64(binary.LittleEndian.Uint32(b))
+	case 8:
+		size = binary.LittleEndian.Uint64(b)
+	}
+
+	if size > maxStringSize {
+		if _, err := io.CopyN(io.Discard, br, int64(size)); err != nil {
+			return "", unexpectedEOF(err)
+		}
+		return "", nil
+	}
+
+	s := make([]byte, size)
+	if _, err := io.ReadFull(br, s); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	return string(s), nil
+}
+
+func readMemoIndex(br *bufio.Reader, op, textOp, shortOp byte) (int, error) {
+	switch op {
+	case textOp:
+		line, err := readLine(br)
+		if err != nil {
+			return 0, err
+		}
+		idx, err := strconv.Atoi(line)
+		if err != nil {
+			return 0, xerrors.Errorf("invalid memo index %q: %w", line, err)
+		}
+		return idx, nil
+	case shortOp:
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		return int(b), nil
+	default:
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(br, b); err != nil {
+			return 0, unexpectedEOF(err)
+		}
+		return int(binary.LittleEndian.Uint32(b)), nil
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -15,7 +15,7 @@ func init() {
 	analyzer.RegisterAnalyzer(&osReleaseAnalyzer{})
 }
 
-const version = 1
+const version = 2
 
 var requiredFiles = []string{
 	"etc/os-release",
@@ -25,7 +25,7 @@ var requiredFiles = []string{
 type osReleaseAnalyzer struct{}
 
 func (a osReleaseAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
-	var id, versionID string
+	var id, versionID, cpeName string
 	scanner := bufio.NewScanner(input.Content)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -41,48 +41,92 @@ func (a osReleaseAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInp
 			id = strings.Trim(value, `"'`)
 		case "VERSION_ID":
 			versionID = strings.Trim(value, `"'`)
+		case "CPE_NAME":
+			cpeName = strings.Trim(value, `"'`)
 		default:
 			continue
 		}
+	}
 
-		var family types.OSType
-		switch id {
-		case "alpine":
-			family = types.Alpine
-		case "opensuse-tumbleweed":
-			family = types.OpenSUSETumbleweed
-		case "opensuse-leap", "opensuse": // opensuse for leap:42, opensuse-leap for leap:15
-			family = types.OpenSUSELeap
-		case "sles":
-			family = types.SLES
-		// There are various rebrands of SLE Micro, there is also one brief (and reverted rebrand)
-		// for SLE Micro 6.0. which was called "SL Micro 6.0" until very short before release
-		// and there is a "SLE Micro for Rancher" rebrand, which is used by SUSEs K8S based offerings.
-		case "sle-micro", "sl-micro", "sle-micro-rancher":
-			family = types.SLEMicro
-		case "photon":
-			family = types.Photon
-		case "wolfi":
-			family = types.Wolfi
-		case "chainguard":
-			family = types.Chainguard
-		case "azurelinux":
-			family = types.Azure
-		case "mariner":
-			family = types.CBLMariner
-		}
+	family := osFamily(id)
+	if family == "" {
+		// Variants such as SLES for SAP and openSUSE Leap Micro have their own IDs,
+		// but they share the same advisories with the base product identified by CPE.
+		family = osFamilyFromCPE(cpeName)
+	}
 
-		if family != "" && versionID != "" {
-			return &analyzer.AnalysisResult{
-				OS: types.OS{
-					Family: family,
-					Name:   versionID,
-				},
-			}, nil
-		}
+	if family == "" || versionID == "" {
+		return nil, nil
+	}
+
+	return &analyzer.AnalysisResult{
+		OS: types.OS{
+			Family: family,
+			Name:   versionID,
+		},
+	}, nil
+}
+
+func osFamily(id string) types.OSType {
+	switch id {
+	case "alpine":
+		return types.Alpine
+	case "opensuse-tumbleweed":
+		return types.OpenSUSETumbleweed
+	case "opensuse-leap", "opensuse": // opensuse for leap:42, opensuse-leap for leap:15
+		return types.OpenSUSELeap
+	case "sles":
+		return types.SLES
+	// There are various rebrands of SLE Micro, there is also one brief (and reverted rebrand)
+	// for SLE Micro 6.0. which was called "SL Micro 6.0" until very short before release
+	// and there is a "SLE Micro for Rancher" rebrand, which is used by SUSEs K8S based offerings.
+	// SLE Micro 5.0 was released as "SUSE MicroOS".
+	case "sle-micro", "sl-micro", "sle-micro-rancher", "suse-microos":
+		return types.SLEMicro
+	case "photon":
+		return types.Photon
+	case "wolfi":
+		return types.Wolfi
+	case "chainguard":
+		return types.Chainguard
+	case "azurelinux":
+		return types.Azure
+	case "mariner":
+		return types.CBLMariner
+	}
+	return ""
+}
+
+// osFamilyFromCPE detects SUSE products from CPE_NAME.
+// e.g. cpe:/o:suse:sles_sap:15:sp5, cpe:/o:opensuse:leap-micro:5.5
+func osFamilyFromCPE(cpeName string) types.OSType {
+	parts := strings.Split(cpeName, ":")
+	if len(parts) < 4 || parts[0] != "cpe" || parts[1] != "/o" {
+		return ""
 	}
+	vendor, product := parts[2], parts[3]
 
-	return nil, nil
+	switch vendor {
+	case "suse":
+		switch {
+		case strings.HasPrefix(product, "sle-micro"), strings.HasPrefix(product, "sl-micro"), product == "suse-microos":
+			return types.SLEMicro
+		// SLES, SLES for SAP, SLE HPC, SLED and BCI images are covered by SUSE Linux Enterprise advisories.
+		case strings.HasPrefix(product, "sles"), strings.HasPrefix(product, "sle_"), product == "sled", strings.HasPrefix(product, "sle-bci"):
+			return types.SLES
+		}
+	case "opensuse":
+		switch product {
+		case "leap":
+			return types.OpenSUSELeap
+		case "tumbleweed":
+			return types.OpenSUSETumbleweed
+		case "leap-micro":
+			// openSUSE Leap Micro is built from SLE Micro sources
+			return types.SLEMicro
+		}
+	}
+	return ""
 }
 
 func (a osReleaseAnalyzer) Required(filePath string, _ os.FileInfo) bool {
@@ -100,6 +100,46 @@ func Test_osReleaseAnalyzer_Analyze(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "SUSE Linux Enterprise Server for SAP Applications",
+			inputFile: "testdata/sles-sap",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family: types.SLES,
+					Name:   "15.5",
+				},
+			},
+		},
+		{
+			name:      "SUSE Linux Enterprise High Performance Computing",
+			inputFile: "testdata/sle-hpc",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family: types.SLES,
+					Name:   "15.4",
+				},
+			},
+		},
+		{
+			name:      "SUSE MicroOS 5.0",
+			inputFile: "testdata/slemicro-5.0",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family: types.SLEMicro,
+					Name:   "5.0",
+				},
+			},
+		},
+		{
+			name:      "openSUSE Leap Micro",
+			inputFile: "testdata/opensuse-leap-micro",
+			want: &analyzer.AnalysisResult{
+				OS: types.OS{
+					Family: types.SLEMicro,
+					Name:   "5.5",
+				},
+			},
+		},
 		{
 			name:      "Photon OS",
 			inputFile: "testdata/photon",
@@ -43,6 +43,14 @@ func Test_rpmPkgAnalyzer_Analyze(t *testing.T) {
 			},
 			want: 1,
 		},
+		{
+			name: "ndb",
+			input: analyzer.AnalysisInput{
+				FilePath: "testdata/ndb",
+				Content:  lo.Must(os.Open("testdata/ndb")),
+			},
+			want: 1,
+		},
 		{
 			name: "broken",
 			input: analyzer.AnalysisInput{
@@ -20,7 +20,10 @@ func init() {
 	analyzer.RegisterAnalyzer(&sbomAnalyzer{})
 }
 
-const version = 1
+const version = 2
+
+// e.g. layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.cdx.json
+const buildpacksSBOMDir = "layers/sbom/"
 
 var requiredSuffixes = []string{
 	".spdx",
@@ -52,6 +55,13 @@ func (a sbomAnalyzer) Analyze(ctx context.Context, input analyzer.AnalysisInput)
 		handleBitnamiImages(path.Dir(input.FilePath), bom)
 	}
 
+	// Cloud Native Buildpacks images
+	// SBOM files are located under the /layers/sbom/<launch|build>/<buildpack-id>/<layer> directory
+	// ref: https://github.com/buildpacks/spec/blob/main/buildpack.md#bill-of-materials
+	if isBuildpacksSBOM(input.FilePath) {
+		handleBuildpacksImages(input.FilePath, bom)
+	}
+
 	// FilePath for apps with aggregatingTypes is empty.
 	// Set the SBOM file path as Application.FilePath to correctly overwrite applications when merging layers.
 	for i, app := range bom.Applications {
@@ -102,3 +112,18 @@ func handleBitnamiImages(componentPath string, bom types.SBOM) {
 		}
 	}
 }
+
+// isBuildpacksSBOM returns true if the file is SBOM generated by Cloud Native Buildpacks.
+func isBuildpacksSBOM(filePath string) bool {
+	return strings.HasPrefix(filePath, buildpacksSBOMDir) && strings.HasPrefix(path.Base(filePath), "sbom.")
+}
+
+func handleBuildpacksImages(filePath string, bom types.SBOM) {
+	for i, app := range bom.Applications {
+		// Buildpacks SBOM doesn't contain the path to the lock file or the binary.
+		// Set the SBOM file path so that applications can be distinguished by the layer of the buildpack.
+		if app.FilePath == "" {
+			bom.Applications[i].FilePath = filePath
+		}
+	}
+}
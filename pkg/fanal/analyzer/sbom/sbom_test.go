@@ -238,6 +238,50 @@ func Test_sbomAnalyzer_Analyze(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name:     "valid buildpacks cdx file",
+			file:     "testdata/buildpacks.cdx.json",
+			filePath: "layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.cdx.json",
+			want: &analyzer.AnalysisResult{
+				Applications: []types.Application{
+					{
+						Type:     types.GoBinary,
+						FilePath: "layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.cdx.json",
+						Packages: types.Packages{
+							{
+								ID:      "github.com/gorilla/mux@v1.8.0",
+								Name:    "github.com/gorilla/mux",
+								Version: "v1.8.0",
+								Identifier: types.PkgIdentifier{
+									PURL: &packageurl.PackageURL{
+										Type:      packageurl.TypeGolang,
+										Namespace: "github.com/gorilla",
+										Name:      "mux",
+										Version:   "v1.8.0",
+									},
+									BOMRef: "pkg:golang/github.com/gorilla/mux@v1.8.0?package-id=9c2d34b4f7a0b0a1",
+								},
+							},
+							{
+								ID:      "golang.org/x/text@v0.3.7",
+								Name:    "golang.org/x/text",
+								Version: "v0.3.7",
+								Identifier: types.PkgIdentifier{
+									PURL: &packageurl.PackageURL{
+										Type:      packageurl.TypeGolang,
+										Namespace: "golang.org/x",
+										Name:      "text",
+										Version:   "v0.3.7",
+									},
+									BOMRef: "pkg:golang/golang.org/x/text@v0.3.7?package-id=5e1b1f2ac4d2e0f3",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: require.NoError,
+		},
 		{
 			name:     "invalid spdx file",
 			file:     "testdata/invalid_spdx.json",
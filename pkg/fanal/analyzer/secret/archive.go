@@ -0,0 +1,167 @@
+package secret
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
+)
+
+const defaultArchiveMaxSize = 100 << 20 // 100MB
+
+var (
+	archiveExts = []string{
+		".zip",
+		".jar",
+		".war",
+		".ear",
+		".tar",
+		".tgz",
+		".tar.gz",
+	}
+
+	errArchiveSizeExceeded = errors.New("archive size limit exceeded")
+)
+
+func isArchive(filePath string) bool {
+	name := strings.ToLower(filePath)
+	return slices.ContainsFunc(archiveExts, func(ext string) bool {
+		return strings.HasSuffix(name, ext)
+	})
+}
+
+func (a *SecretAnalyzer) scanArchives() bool {
+	return a.archiveDepth > 0
+}
+
+// archiveScanner scans files in an archive and archives nested in it.
+// The total size of extracted files is limited to prevent decompression bombs.
+type archiveScanner struct {
+	*SecretAnalyzer
+	remaining int64
+	secrets   []types.Secret
+}
+
+// scanArchive returns secrets in the archive.
+// Files in the archive are reported with the archive path as a prefix, e.g. "app.jar/BOOT-INF/classes/application.yml".
+func (a *SecretAnalyzer) scanArchive(filePath string, r xio.ReadSeekerAt, size int64) ([]types.Secret, error) {
+	s := &archiveScanner{
+		SecretAnalyzer: a,
+		remaining:      a.archiveMaxSize,
+	}
+	if err := s.scan(filePath, r, size, 1); errors.Is(err, errArchiveSizeExceeded) {
+		// Return the secrets found so far
+		log.WithPrefix("secret").Warn("The archive is too large to scan entirely. It is recommended to increase `--secret-archive-max-size` to scan the rest.",
+			log.FilePath(filePath), log.Int64("max_size", a.archiveMaxSize))
+	} else if err != nil {
+		return nil, err
+	}
+	return s.secrets, nil
+}
+
+func (s *archiveScanner) scan(filePath string, r xio.ReadSeekerAt, size int64, depth int) error {
+	name := strings.ToLower(filePath)
+	switch {
+	case strings.HasSuffix(name, ".tar"):
+		return s.scanTar(filePath, r, depth)
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return xerrors.Errorf("gzip error: %w", err)
+		}
+		defer gr.Close()
+		return s.scanTar(filePath, gr, depth)
+	default:
+		return s.scanZip(filePath, r, size, depth)
+	}
+}
+
+func (s *archiveScanner) scanZip(filePath string, r io.ReaderAt, size int64, depth int) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return xerrors.Errorf("zip error: %w", err)
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return xerrors.Errorf("unable to open %s: %w", f.Name, err)
+		}
+		err = s.scanEntry(filePath, f.Name, rc, int64(f.UncompressedSize64), depth)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *archiveScanner) scanTar(filePath string, r io.Reader, depth int) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return xerrors.Errorf("tar error: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err = s.scanEntry(filePath, hdr.Name, tr, hdr.Size, depth); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *archiveScanner) scanEntry(archivePath, name string, r io.Reader, size int64, depth int) error {
+	// Keep the path under the archive even if the name contains "../"
+	filePath := path.Join(archivePath, path.Clean("/"+name))
+	nested := isArchive(name)
+	if !s.required(filePath, size) || (nested && depth >= s.archiveDepth) {
+		return nil
+	}
+
+	// The size in the header is not trustworthy
+	if size > s.remaining {
+		return errArchiveSizeExceeded
+	}
+	content, err := io.ReadAll(io.LimitReader(r, s.remaining+1))
+	if err != nil {
+		return xerrors.Errorf("read error %s: %w", filePath, err)
+	} else if int64(len(content)) > s.remaining {
+		return errArchiveSizeExceeded
+	}
+	s.remaining -= int64(len(content))
+
+	if nested {
+		err = s.scan(filePath, bytes.NewReader(content), int64(len(content)), depth+1)
+		if err != nil && !errors.Is(err, errArchiveSizeExceeded) {
+			// Broken nested archives should not stop scanning the others
+			log.WithPrefix("secret").Debug("Unable to scan the nested archive", log.FilePath(filePath), log.Err(err))
+			return nil
+		}
+		return err
+	}
+
+	secret, err := s.scanFile(filePath, bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	} else if len(secret.Findings) > 0 {
+		s.secrets = append(s.secrets, secret)
+	}
+	return nil
+}
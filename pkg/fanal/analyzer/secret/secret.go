@@ -2,6 +2,7 @@ package secret
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/fanal/utils"
 	"github.com/aquasecurity/trivy/pkg/log"
+	xio "github.com/aquasecurity/trivy/pkg/x/io"
 )
 
 // To make sure SecretAnalyzer implements analyzer.Initializer
@@ -74,6 +76,10 @@ func allowedBinary(filename string) bool {
 type SecretAnalyzer struct {
 	scanner    secret.Scanner
 	configPath string
+
+	// For scanning inside archives
+	archiveDepth   int
+	archiveMaxSize int64
 }
 
 func NewSecretAnalyzer(s secret.Scanner, configPath string) *SecretAnalyzer {
@@ -85,6 +91,9 @@ func NewSecretAnalyzer(s secret.Scanner, configPath string) *SecretAnalyzer {
 
 // Init initializes and sets a secret scanner
 func (a *SecretAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
+	a.archiveDepth = opt.SecretScannerOption.ArchiveDepth
+	a.archiveMaxSize = cmp.Or(opt.SecretScannerOption.ArchiveMaxSize, defaultArchiveMaxSize)
+
 	if opt.SecretScannerOption.ConfigPath == a.configPath && !lo.IsEmpty(a.scanner) {
 		// This check is for tools importing Trivy and customize analyzers
 		// Never reach here in Trivy OSS
@@ -95,63 +104,102 @@ func (a *SecretAnalyzer) Init(opt analyzer.AnalyzerOptions) error {
 	if err != nil {
 		return xerrors.Errorf("secret config error: %w", err)
 	}
-	a.scanner = secret.NewScanner(c)
+	var opts []secret.ScannerOption
+	if opt.SecretScannerOption.Validate {
+		opts = append(opts, secret.WithValidation(nil))
+	}
+	if opt.SecretScannerOption.Fingerprint {
+		opts = append(opts, secret.WithFingerprint())
+	}
+	if r := opt.SecretScannerOption.Redaction; r != "" {
+		opts = append(opts, secret.WithRedaction(secret.Redaction(r)))
+	}
+	a.scanner = secret.NewScanner(c, opts...)
 	a.configPath = configPath
 	return nil
 }
 
 func (a *SecretAnalyzer) Analyze(_ context.Context, input analyzer.AnalysisInput) (*analyzer.AnalysisResult, error) {
-	// Do not scan binaries
-	binary, err := utils.IsBinary(input.Content, input.Info.Size())
-	if err != nil || (binary && !allowedBinary(input.FilePath)) {
+	filePath := input.FilePath
+	// Files extracted from the image have an empty input.Dir.
+	// Also, paths to these files do not have "/" prefix.
+	// We need to add a "/" prefix to properly filter paths from the config file.
+	if input.Dir == "" { // add leading `/` for files extracted from image
+		filePath = fmt.Sprintf("/%s", filePath)
+	}
+
+	var secrets []types.Secret
+	if a.scanArchives() && isArchive(input.FilePath) {
+		var err error
+		if secrets, err = a.scanArchive(filePath, input.Content, input.Info.Size()); err != nil {
+			// Broken archives should not stop the scan
+			log.WithPrefix("secret").Debug("Unable to scan the archive", log.FilePath(input.FilePath), log.Err(err))
+			return nil, nil
+		}
+	} else {
+		result, err := a.scanFile(filePath, input.Content, input.Info.Size())
+		if err != nil {
+			return nil, err
+		} else if len(result.Findings) > 0 {
+			secrets = append(secrets, result)
+		}
+	}
+
+	if len(secrets) == 0 {
 		return nil, nil
 	}
 
-	if size := input.Info.Size(); size > 10485760 { // 10MB
-		log.WithPrefix("secret").Warn("The size of the scanned file is too large. It is recommended to use `--skip-files` for this file to avoid high memory consumption.", log.FilePath(input.FilePath), log.Int64("size (MB)", size/1048576))
+	return &analyzer.AnalysisResult{
+		Secrets: secrets,
+	}, nil
+}
+
+// scanFile scans the file content. Binaries are skipped except for allowed ones.
+func (a *SecretAnalyzer) scanFile(filePath string, r xio.ReadSeekerAt, size int64) (types.Secret, error) {
+	// Do not scan binaries
+	binary, err := utils.IsBinary(r, size)
+	if err != nil || (binary && !allowedBinary(filePath)) {
+		return types.Secret{}, nil
+	}
+
+	if size > 10485760 { // 10MB
+		log.WithPrefix("secret").Warn("The size of the scanned file is too large. It is recommended to use `--skip-files` for this file to avoid high memory consumption.", log.FilePath(filePath), log.Int64("size (MB)", size/1048576))
 	}
 
 	var content []byte
 
 	if !binary {
-		content, err = io.ReadAll(input.Content)
+		content, err = io.ReadAll(r)
 		if err != nil {
-			return nil, xerrors.Errorf("read error %s: %w", input.FilePath, err)
+			return types.Secret{}, xerrors.Errorf("read error %s: %w", filePath, err)
 		}
 		content = bytes.ReplaceAll(content, []byte("\r"), []byte(""))
 	} else {
-		content, err = utils.ExtractPrintableBytes(input.Content)
+		content, err = utils.ExtractPrintableBytes(r)
 		if err != nil {
-			return nil, xerrors.Errorf("binary read error %s: %w", input.FilePath, err)
+			return types.Secret{}, xerrors.Errorf("binary read error %s: %w", filePath, err)
 		}
 	}
 
-	filePath := input.FilePath
-	// Files extracted from the image have an empty input.Dir.
-	// Also, paths to these files do not have "/" prefix.
-	// We need to add a "/" prefix to properly filter paths from the config file.
-	if input.Dir == "" { // add leading `/` for files extracted from image
-		filePath = fmt.Sprintf("/%s", filePath)
-	}
-
-	result := a.scanner.Scan(secret.ScanArgs{
+	return a.scanner.Scan(secret.ScanArgs{
 		FilePath: filePath,
 		Content:  content,
 		Binary:   binary,
-	})
+	}), nil
+}
 
-	if len(result.Findings) == 0 {
-		return nil, nil
+func (a *SecretAnalyzer) Required(filePath string, fi os.FileInfo) bool {
+	// Skip the config file for secret scanning
+	if filepath.Base(a.configPath) == filePath {
+		return false
 	}
-
-	return &analyzer.AnalysisResult{
-		Secrets: []types.Secret{result},
-	}, nil
+	return a.required(filePath, fi.Size())
 }
 
-func (a *SecretAnalyzer) Required(filePath string, fi os.FileInfo) bool {
+// required is shared with files in archives
+func (a *SecretAnalyzer) required(filePath string, size int64) bool {
 	// Skip small files
-	if fi.Size() < 10 {
+	if size < 10 {
 		return false
 	}
 
@@ -171,9 +219,8 @@ func (a *SecretAnalyzer) Required(filePath string, fi os.FileInfo) bool {
 		return false
 	}
 
-	// Skip the config file for secret scanning
-	if filepath.Base(a.configPath) == filePath {
-		return false
+	if a.scanArchives() && isArchive(fileName) {
+		return size <= a.archiveMaxSize && !a.scanner.AllowPath(filePath)
 	}
 
 	// Check if the file extension should be skipped
@@ -106,11 +106,12 @@ func TestSecretAnalyzer(t *testing.T) {
 	}
 
 	tests := []struct {
-		name       string
-		configPath string
-		filePath   string
-		dir        string
-		want       *analyzer.AnalysisResult
+		name         string
+		configPath   string
+		filePath     string
+		dir          string
+		archiveDepth int
+		want         *analyzer.AnalysisResult
 	}{
 		{
 			name:       "return results",
@@ -178,13 +179,67 @@ func TestSecretAnalyzer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "archive",
+			configPath:   "testdata/config.yaml",
+			filePath:     "testdata/secret.zip",
+			dir:          ".",
+			archiveDepth: 1,
+			want: &analyzer.AnalysisResult{
+				Secrets: []types.Secret{
+					{
+						FilePath: "testdata/secret.zip/config/secret.txt",
+						Findings: []types.SecretFinding{
+							wantFinding1,
+							wantFinding2,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "nested archive",
+			configPath:   "testdata/config.yaml",
+			filePath:     "testdata/secret.tar.gz",
+			dir:          ".",
+			archiveDepth: 2,
+			want: &analyzer.AnalysisResult{
+				Secrets: []types.Secret{
+					{
+						FilePath: "testdata/secret.tar.gz/inner.jar/secret.txt",
+						Findings: []types.SecretFinding{
+							wantFinding1,
+							wantFinding2,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "nested archive beyond the depth",
+			configPath:   "testdata/config.yaml",
+			filePath:     "testdata/secret.tar.gz",
+			dir:          ".",
+			archiveDepth: 1,
+			want:         nil,
+		},
+		{
+			name:       "archive scanning disabled",
+			configPath: "testdata/config.yaml",
+			filePath:   "testdata/secret.zip",
+			dir:        ".",
+			want:       nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := secret.SecretAnalyzer{}
 			err := a.Init(analyzer.AnalyzerOptions{
-				SecretScannerOption: analyzer.SecretScannerOption{ConfigPath: tt.configPath},
+				SecretScannerOption: analyzer.SecretScannerOption{
+					ConfigPath:   tt.configPath,
+					ArchiveDepth: tt.archiveDepth,
+				},
 			})
 			require.NoError(t, err)
 			content, err := os.Open(tt.filePath)
@@ -207,9 +262,10 @@ func TestSecretAnalyzer(t *testing.T) {
 
 func TestSecretRequire(t *testing.T) {
 	tests := []struct {
-		name     string
-		filePath string
-		want     bool
+		name         string
+		filePath     string
+		archiveDepth int
+		want         bool
 	}{
 		{
 			name:     "pass regular file",
@@ -236,6 +292,17 @@ func TestSecretRequire(t *testing.T) {
 			filePath: "testdata/secret.doc",
 			want:     false,
 		},
+		{
+			name:     "skip archive",
+			filePath: "testdata/secret.zip",
+			want:     false,
+		},
+		{
+			name:         "pass archive",
+			filePath:     "testdata/secret.tar.gz",
+			archiveDepth: 1,
+			want:         true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -243,7 +310,8 @@ func TestSecretRequire(t *testing.T) {
 			a := secret.SecretAnalyzer{}
 			err := a.Init(analyzer.AnalyzerOptions{
 				SecretScannerOption: analyzer.SecretScannerOption{
-					ConfigPath: "testdata/skip-tests-config.yaml",
+					ConfigPath:   "testdata/skip-tests-config.yaml",
+					ArchiveDepth: tt.archiveDepth,
 				},
 			})
 			require.NoError(t, err)
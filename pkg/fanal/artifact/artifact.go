@@ -38,6 +38,9 @@ type Option struct {
 	// For image scanning
 	ImageOption types.ImageOptions
 
+	// For filesystem scanning
+	Resume bool // Save the progress periodically and resume an interrupted scan
+
 	MisconfScannerOption misconf.ScannerOption
 	SecretScannerOption  analyzer.SecretScannerOption
 	LicenseScannerOption analyzer.LicenseScannerOption
@@ -92,6 +95,7 @@ const (
 	TypeSPDX           Type = "spdx"
 	TypeAWSAccount     Type = "aws_account"
 	TypeVM             Type = "vm"
+	TypePackage        Type = "package"
 )
 
 // Reference represents a reference of container image, local filesystem and repository
@@ -0,0 +1,178 @@
+package custom
+
+import (
+	"context"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/wire"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/local"
+	"github.com/aquasecurity/trivy/pkg/fanal/walker"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var (
+	ArtifactSet = wire.NewSet(
+		walker.NewFS,
+		wire.Bind(new(Walker), new(*walker.FS)),
+		NewArtifact,
+	)
+
+	_ Walker = (*walker.FS)(nil)
+)
+
+// builtinTypes cannot be overridden by custom artifacts
+var builtinTypes = []artifact.Type{
+	artifact.TypeContainerImage,
+	artifact.TypeFilesystem,
+	artifact.TypeRepository,
+	artifact.TypeCycloneDX,
+	artifact.TypeSPDX,
+	artifact.TypeAWSAccount,
+	artifact.TypeVM,
+	artifact.TypePackage,
+}
+
+var (
+	mu           sync.RWMutex
+	constructors = make(map[artifact.Type]Constructor)
+)
+
+type Walker interface {
+	Walk(root string, opt walker.Option, fn walker.WalkFunc) error
+}
+
+// Constructor initializes an artifact of the registered type.
+// Trivy passes the cache and the filesystem walker, so the artifact can delegate the analysis
+// to the filesystem artifact, e.g. after unpacking the target. See NewExtractor.
+type Constructor func(ctx context.Context, target string, c cache.ArtifactCache, w Walker, opt artifact.Option) (
+	artifact.Artifact, func(), error)
+
+// Extractor unpacks the target into the directory
+type Extractor func(ctx context.Context, target, dir string) error
+
+// RegisterArtifact registers a new artifact type so that Trivy can scan targets of the type,
+// e.g. proprietary appliance bundles. It is useful when Trivy is imported as a library.
+func RegisterArtifact(t artifact.Type, c Constructor) error {
+	if t == "" || slices.Contains(builtinTypes, t) {
+		return xerrors.Errorf("invalid artifact type: %q", t)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := constructors[t]; ok {
+		return xerrors.Errorf("artifact type %q is already registered", t)
+	}
+	constructors[t] = c
+	return nil
+}
+
+// DeregisterArtifact is mainly for testing
+func DeregisterArtifact(t artifact.Type) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(constructors, t)
+}
+
+// Registered returns true if the artifact type is registered
+func Registered(t artifact.Type) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := constructors[t]
+	return ok
+}
+
+// Types returns the registered artifact types
+func Types() []artifact.Type {
+	mu.RLock()
+	defer mu.RUnlock()
+	types := make([]artifact.Type, 0, len(constructors))
+	for t := range constructors {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// NewArtifact initializes an artifact of the registered type
+func NewArtifact(ctx context.Context, t artifact.Type, target string, c cache.ArtifactCache, w Walker,
+	opt artifact.Option) (artifact.Artifact, func(), error) {
+	mu.RLock()
+	newArtifact, ok := constructors[t]
+	mu.RUnlock()
+	if !ok {
+		return nil, func() {}, xerrors.Errorf("unknown artifact type: %s", t)
+	}
+
+	art, cleanup, err := newArtifact(ctx, target, c, w, opt)
+	if cleanup == nil {
+		cleanup = func() {}
+	}
+	if err != nil {
+		// The caller doesn't clean up on error
+		cleanup()
+		return nil, func() {}, xerrors.Errorf("%s artifact error: %w", t, err)
+	}
+	return art, cleanup, nil
+}
+
+// NewExtractor returns a constructor of artifacts that are unpacked into a temp dir
+// and scanned as a filesystem. Only the extraction is required for new artifact types.
+func NewExtractor(t artifact.Type, extract Extractor) Constructor {
+	return func(ctx context.Context, target string, c cache.ArtifactCache, w Walker, opt artifact.Option) (
+		artifact.Artifact, func(), error) {
+		cleanup := func() {}
+		tmpDir, err := os.MkdirTemp("", "trivy-artifact")
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("failed to create a temp dir: %w", err)
+		}
+		cleanup = func() { _ = os.RemoveAll(tmpDir) }
+
+		log.DebugContext(ctx, "Extracting the artifact...", log.String("type", string(t)), log.String("target", target))
+		if err = extract(ctx, target, tmpDir); err != nil {
+			return nil, cleanup, xerrors.Errorf("extract error: %w", err)
+		}
+
+		art, err := local.NewArtifact(tmpDir, c, w, opt)
+		if err != nil {
+			return nil, cleanup, xerrors.Errorf("fs artifact: %w", err)
+		}
+
+		return Artifact{
+			name:         target,
+			artifactType: t,
+			local:        art,
+		}, cleanup, nil
+	}
+}
+
+// Artifact represents a custom artifact extracted into a local directory
+type Artifact struct {
+	name         string
+	artifactType artifact.Type
+	local        artifact.Artifact
+}
+
+func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
+	ref, err := a.local.Inspect(ctx)
+	if err != nil {
+		return artifact.Reference{}, xerrors.Errorf("%s error: %w", a.artifactType, err)
+	}
+
+	ref.Name = a.name
+	ref.Type = a.artifactType
+
+	return ref, nil
+}
+
+func (a Artifact) Clean(reference artifact.Reference) error {
+	return a.local.Clean(reference)
+}
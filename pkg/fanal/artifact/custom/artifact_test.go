@@ -0,0 +1,127 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/walker"
+
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/nodejs/pkg"
+)
+
+const typeAppliance artifact.Type = "appliance"
+
+func TestRegisterArtifact(t *testing.T) {
+	extractor := NewExtractor(typeAppliance, func(context.Context, string, string) error { return nil })
+
+	require.NoError(t, RegisterArtifact(typeAppliance, extractor))
+	defer DeregisterArtifact(typeAppliance)
+
+	assert.True(t, Registered(typeAppliance))
+	assert.Equal(t, []artifact.Type{typeAppliance}, Types())
+
+	err := RegisterArtifact(typeAppliance, extractor)
+	require.ErrorContains(t, err, `artifact type "appliance" is already registered`)
+
+	err = RegisterArtifact(artifact.TypeFilesystem, extractor)
+	require.ErrorContains(t, err, `invalid artifact type: "filesystem"`)
+}
+
+func TestArtifact_Inspect(t *testing.T) {
+	tests := []struct {
+		name         string
+		artifactType artifact.Type
+		extract      Extractor
+		wantApps     []types.Application
+		wantErr      string
+	}{
+		{
+			name:         "happy path",
+			artifactType: typeAppliance,
+			extract: func(_ context.Context, _, dir string) error {
+				pkgDir := filepath.Join(dir, "node_modules", "demo")
+				if err := os.MkdirAll(pkgDir, 0o700); err != nil {
+					return err
+				}
+				return os.WriteFile(filepath.Join(pkgDir, "package.json"),
+					[]byte(`{"name": "demo", "version": "1.0.0", "license": "MIT"}`), 0o600)
+			},
+			wantApps: []types.Application{
+				{
+					Type:     types.NodePkg,
+					FilePath: "node_modules/demo/package.json",
+					Packages: types.Packages{
+						{
+							ID:       "demo@1.0.0",
+							Name:     "demo",
+							Version:  "1.0.0",
+							Licenses: []string{"MIT"},
+							FilePath: "node_modules/demo/package.json",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "extract error",
+			artifactType: typeAppliance,
+			extract: func(context.Context, string, string) error {
+				return errors.New("broken bundle")
+			},
+			wantErr: "broken bundle",
+		},
+		{
+			name:         "unknown type",
+			artifactType: "unknown",
+			wantErr:      "unknown artifact type: unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.extract != nil {
+				require.NoError(t, RegisterArtifact(tt.artifactType, NewExtractor(tt.artifactType, tt.extract)))
+				defer DeregisterArtifact(tt.artifactType)
+			}
+
+			c := cache.NewMemoryCache()
+			art, cleanup, err := NewArtifact(context.Background(), tt.artifactType, "bundle.img", c, walker.NewFS(),
+				artifact.Option{})
+			defer cleanup()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			ref, err := art.Inspect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "bundle.img", ref.Name)
+			assert.Equal(t, tt.artifactType, ref.Type)
+
+			require.Len(t, ref.BlobIDs, 1)
+			blob, err := c.GetBlob(ref.BlobIDs[0])
+			require.NoError(t, err)
+
+			// Digests and locations depend on the analyzers
+			apps := lo.Map(blob.Applications, func(app types.Application, _ int) types.Application {
+				for i := range app.Packages {
+					app.Packages[i].Digest = ""
+					app.Packages[i].Locations = nil
+				}
+				return app
+			})
+			assert.Equal(t, tt.wantApps, apps)
+		})
+	}
+}
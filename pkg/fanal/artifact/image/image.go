@@ -514,6 +514,7 @@ func (a Artifact) inspectConfig(ctx context.Context, imageID string, osFound typ
 		Misconfiguration: result.Misconfiguration,
 		Secret:           result.Secret,
 		HistoryPackages:  result.HistoryPackages,
+		Applications:     result.Applications,
 	}
 
 	if err := a.cache.PutArtifact(imageID, info); err != nil {
@@ -354,17 +354,17 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingArtifact: true,
-					MissingBlobIDs:  []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					MissingBlobIDs:  []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4",
+						BlobID: "sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -431,7 +431,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				Name:    "../../test/testdata/alpine-311.tar.gz",
 				Type:    artifact.TypeContainerImage,
 				ID:      "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-				BlobIDs: []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+				BlobIDs: []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				ImageMetadata: artifact.ImageMetadata{
 					ID: "sha256:a187dde48cd289ac374ad8539930628314bc581a481cdb41409c9289419ddb72",
 					DiffIDs: []string{
@@ -490,25 +490,25 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
+						"sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
+						"sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
+						"sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
+						"sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
+						"sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
+						"sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 					},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
+						BlobID: "sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -597,7 +597,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
+						BlobID: "sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -693,7 +693,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
+						BlobID: "sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -901,7 +901,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						BlobID: "sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1764,10 +1764,10 @@ func TestArtifact_Inspect(t *testing.T) {
 				Type: artifact.TypeContainerImage,
 				ID:   "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 				BlobIDs: []string{
-					"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-					"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-					"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-					"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+					"sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
+					"sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
+					"sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
+					"sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 				},
 				ImageMetadata: artifact.ImageMetadata{
 					ID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4",
@@ -1861,25 +1861,25 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
-						"sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
-						"sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
-						"sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+						"sha256:709bbbcc546ff6cb232e2d20ea1996c78ca258d5cbf41dede96aa449f32c13ab",
+						"sha256:3f0fa3d12a5146fad550c5853f9835fde395a86107f197dd4bc9af4653aab976",
+						"sha256:8dccb8d33761ca03316ae499cdc26cdfd066073ca5fe453ba810ba20fdfc2214",
+						"sha256:2512b7bd7edab621e4b5f7d862ffb5a8bb1a69ca29dd93a629c0f7268f12c251",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
-						"sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
-						"sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
-						"sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+						"sha256:709bbbcc546ff6cb232e2d20ea1996c78ca258d5cbf41dede96aa449f32c13ab",
+						"sha256:3f0fa3d12a5146fad550c5853f9835fde395a86107f197dd4bc9af4653aab976",
+						"sha256:8dccb8d33761ca03316ae499cdc26cdfd066073ca5fe453ba810ba20fdfc2214",
+						"sha256:2512b7bd7edab621e4b5f7d862ffb5a8bb1a69ca29dd93a629c0f7268f12c251",
 					},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
+						BlobID: "sha256:709bbbcc546ff6cb232e2d20ea1996c78ca258d5cbf41dede96aa449f32c13ab",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1890,7 +1890,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
+						BlobID: "sha256:3f0fa3d12a5146fad550c5853f9835fde395a86107f197dd4bc9af4653aab976",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1901,7 +1901,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
+						BlobID: "sha256:8dccb8d33761ca03316ae499cdc26cdfd066073ca5fe453ba810ba20fdfc2214",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1913,7 +1913,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				},
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+						BlobID: "sha256:2512b7bd7edab621e4b5f7d862ffb5a8bb1a69ca29dd93a629c0f7268f12c251",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -1929,10 +1929,10 @@ func TestArtifact_Inspect(t *testing.T) {
 				Type: artifact.TypeContainerImage,
 				ID:   "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 				BlobIDs: []string{
-					"sha256:139bc12e936e0c46090b9380c4a29456d3ad8d8abd50c7bdc6160018cd887462",
-					"sha256:c491838e70ff0fcfdd0605af1ba84e86d6958c0846b16c52a84e06bb344e8e8d",
-					"sha256:25e775ef81049a93eafd865447b0b79da9e9956ab74bc02b5916eaea21c87c7c",
-					"sha256:a8a4798a22b65739cda9ca99ddb2cd86125c1dd86df6fc3971f937a0ff5b9ec3",
+					"sha256:709bbbcc546ff6cb232e2d20ea1996c78ca258d5cbf41dede96aa449f32c13ab",
+					"sha256:3f0fa3d12a5146fad550c5853f9835fde395a86107f197dd4bc9af4653aab976",
+					"sha256:8dccb8d33761ca03316ae499cdc26cdfd066073ca5fe453ba810ba20fdfc2214",
+					"sha256:2512b7bd7edab621e4b5f7d862ffb5a8bb1a69ca29dd93a629c0f7268f12c251",
 				},
 				ImageMetadata: artifact.ImageMetadata{
 					ID: "sha256:58701fd185bda36cab0557bb6438661831267aa4a9e0b54211c4d5317a48aff4",
@@ -2015,7 +2015,7 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					Err: xerrors.New("MissingBlobs failed"),
@@ -2029,16 +2029,16 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
-					MissingBlobIDs: []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					MissingBlobIDs: []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4",
+						BlobID: "sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
@@ -2098,18 +2098,18 @@ func TestArtifact_Inspect(t *testing.T) {
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:33f9415ed2cd5a9cef5d5144333619745b9ec0f851f0684dd45fa79c6b26a650",
 					BlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
+						"sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
+						"sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
+						"sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 					},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingBlobIDs: []string{
-						"sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
-						"sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
-						"sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
-						"sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						"sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
+						"sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
+						"sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
+						"sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 					},
 				},
 			},
@@ -2117,7 +2117,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:4a26915356c961f038d5a7b7f73f24cd1eec53dcf6fdeecd39b310ddc066faec",
+						BlobID:           "sha256:4e2b24611c9a2d7934a5be293588fb7e4ee576fcafb3993cfa6071188e890efe",
 						BlobInfoAnything: true,
 					},
 
@@ -2128,7 +2128,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:e23e1d428a2a4ca9607cde5c556f744c7e9f3a1d3bfe835707c0fea107caf453",
+						BlobID:           "sha256:0975afdf05049cad4440e5c17f34ca6c2d7d1d1e8f583ab4ff621bbb6f0014d6",
 						BlobInfoAnything: true,
 					},
 
@@ -2139,7 +2139,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:b8ae022ed4f8b8bf827c04a825c2e6998217581d44c0b28b59a4e66ca65bbaa5",
+						BlobID:           "sha256:c8fc076367ce886404c7e18872c9b6bcb1d56e2023b94c241cd047a47121577f",
 						BlobInfoAnything: true,
 					},
 
@@ -2150,7 +2150,7 @@ func TestArtifact_Inspect(t *testing.T) {
 				{
 
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID:           "sha256:8c51dcc708602d983f3f0507f0d26de609819c4391db92497639417e54378d11",
+						BlobID:           "sha256:256aa18f44696bbc37bbd606f35b77bb8444d7024a6d503bc04d3fd7b3f5fb53",
 						BlobInfoAnything: true,
 					},
 
@@ -2167,17 +2167,17 @@ func TestArtifact_Inspect(t *testing.T) {
 			missingBlobsExpectation: cache.ArtifactCacheMissingBlobsExpectation{
 				Args: cache.ArtifactCacheMissingBlobsArgs{
 					ArtifactID: "sha256:c232b7d8ac8aa08aa767313d0b53084c4380d1c01a213a5971bdb039e6538313",
-					BlobIDs:    []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					BlobIDs:    []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 				Returns: cache.ArtifactCacheMissingBlobsReturns{
 					MissingArtifact: true,
-					MissingBlobIDs:  []string{"sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4"},
+					MissingBlobIDs:  []string{"sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696"},
 				},
 			},
 			putBlobExpectations: []cache.ArtifactCachePutBlobExpectation{
 				{
 					Args: cache.ArtifactCachePutBlobArgs{
-						BlobID: "sha256:24a7af33784fabfedf01999d9e0dc456e8e1c1943f7d4421f7c05164026788a4",
+						BlobID: "sha256:d73f1e963056a77b3d3ded7412de12d479fdc3ecad5bc4336b1cf1cd78044696",
 						BlobInfo: types.BlobInfo{
 							SchemaVersion: types.BlobJSONSchemaVersion,
 							Digest:        "",
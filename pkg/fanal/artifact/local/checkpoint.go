@@ -0,0 +1,139 @@
+package local
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// checkpointInterval is how often the partial result is saved
+var checkpointInterval = 5 * time.Minute
+
+// checkpoint periodically saves the partial analysis result of the filesystem in the cache,
+// so that an interrupted scan can be resumed from the last analyzed file instead of starting over.
+// The walker visits files in lexical order, so files up to the last analyzed one are skipped on resume.
+type checkpoint struct {
+	logger    *log.Logger
+	cache     cache.Cache
+	key       string
+	resumeAt  string // the last analyzed file of the previous scan
+	lastSaved time.Time
+}
+
+func (a Artifact) newCheckpoint() (*checkpoint, error) {
+	c, ok := a.cache.(cache.Cache)
+	if !ok {
+		// e.g. the remote cache in client/server mode
+		log.Warn("'--resume' is not supported with the cache backend and is ignored")
+		return nil, nil
+	}
+
+	key, err := a.checkpointKey()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to calculate a checkpoint key: %w", err)
+	}
+	return &checkpoint{
+		logger:    log.WithPrefix("checkpoint"),
+		cache:     c,
+		key:       key,
+		lastSaved: time.Now(),
+	}, nil
+}
+
+// checkpointKey returns the key of the partial result, which must be the same across scans of the same directory
+// with the same options so that the next scan can find it.
+func (a Artifact) checkpointKey() (string, error) {
+	root, err := filepath.Abs(a.rootPath)
+	if err != nil {
+		return "", xerrors.Errorf("absolute path error: %w", err)
+	}
+	return cache.CalcKey("checkpoint:"+filepath.ToSlash(root), a.analyzer.AnalyzerVersions(),
+		a.handlerManager.Versions(), a.artifactOption)
+}
+
+// load merges the partial result of the previous scan into the result, if any
+func (c *checkpoint) load(result *analyzer.AnalysisResult) {
+	blob, err := c.cache.GetBlob(c.key)
+	if err != nil || blob.Checkpoint == "" {
+		c.logger.Debug("No checkpoint to resume from", log.String("key", c.key))
+		return
+	}
+	c.logger.Info("Resuming the scan from the checkpoint", log.FilePath(blob.Checkpoint))
+
+	c.resumeAt = blob.Checkpoint
+	result.Merge(&analyzer.AnalysisResult{
+		OS:                blob.OS,
+		Repository:        blob.Repository,
+		PackageInfos:      blob.PackageInfos,
+		Applications:      blob.Applications,
+		Misconfigurations: blob.Misconfigurations,
+		Secrets:           blob.Secrets,
+		Licenses:          blob.Licenses,
+		BuildInfo:         blob.BuildInfo,
+		CustomResources:   blob.CustomResources,
+	})
+}
+
+// analyzed returns true if the file was analyzed by the previous scan
+func (c *checkpoint) analyzed(filePath string) bool {
+	return c.resumeAt != "" && !walkedBefore(c.resumeAt, filePath)
+}
+
+// due returns true if the partial result should be saved
+func (c *checkpoint) due() bool {
+	return time.Since(c.lastSaved) >= checkpointInterval
+}
+
+// save stores the partial result analyzed up to the file.
+// All analyses of the files walked so far must have been completed.
+func (c *checkpoint) save(result *analyzer.AnalysisResult, lastPath string) error {
+	if lastPath == "" {
+		return nil
+	}
+	blob := types.BlobInfo{
+		SchemaVersion:     types.BlobJSONSchemaVersion,
+		OS:                result.OS,
+		Repository:        result.Repository,
+		PackageInfos:      result.PackageInfos,
+		Applications:      result.Applications,
+		Misconfigurations: result.Misconfigurations,
+		Secrets:           result.Secrets,
+		Licenses:          result.Licenses,
+		BuildInfo:         result.BuildInfo,
+		CustomResources:   result.CustomResources,
+		Checkpoint:        lastPath,
+	}
+	if err := c.cache.PutBlob(c.key, blob); err != nil {
+		return xerrors.Errorf("failed to store the checkpoint: %w", err)
+	}
+	c.logger.Debug("Checkpoint saved", log.FilePath(lastPath))
+	c.lastSaved = time.Now()
+	return nil
+}
+
+// delete removes the partial result after the scan is completed
+func (c *checkpoint) delete() error {
+	if err := c.cache.DeleteBlobs([]string{c.key}); err != nil {
+		return xerrors.Errorf("failed to delete the checkpoint: %w", err)
+	}
+	return nil
+}
+
+// walkedBefore returns true if the walker visits the path "a" before "b".
+// The walker visits directory entries in lexical order, so paths are compared by each element.
+func walkedBefore(a, b string) bool {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != bs[i] {
+			return as[i] < bs[i]
+		}
+	}
+	return len(as) < len(bs)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/fanal/handler"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/fanal/walker"
+	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/semaphore"
 	"github.com/aquasecurity/trivy/pkg/uuid"
 )
@@ -84,6 +85,17 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 	}
 	defer composite.Cleanup()
 
+	var cp *checkpoint
+	if a.artifactOption.Resume {
+		if cp, err = a.newCheckpoint(); err != nil {
+			return artifact.Reference{}, xerrors.Errorf("checkpoint error: %w", err)
+		} else if cp != nil {
+			cp.load(result)
+		}
+	}
+
+	// lastPath is the last file passed to the analyzers
+	var lastPath string
 	err = a.walker.Walk(a.rootPath, a.artifactOption.WalkerOption, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
 		dir := a.rootPath
 
@@ -93,8 +105,20 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 			dir, filePath = path.Split(a.rootPath)
 		}
 
-		if err := a.analyzer.AnalyzeFile(ctx, &wg, limit, result, dir, filePath, info, opener, nil, opts); err != nil {
-			return xerrors.Errorf("analyze file (%s): %w", filePath, err)
+		if cp != nil && cp.due() {
+			// Wait for the analyses of the walked files so that the checkpoint doesn't miss their results
+			wg.Wait()
+			if err := cp.save(result, lastPath); err != nil {
+				return err
+			}
+		}
+
+		// Files analyzed by the interrupted scan are skipped, but they may still be required for post analysis.
+		if cp == nil || !cp.analyzed(filePath) {
+			if err := a.analyzer.AnalyzeFile(ctx, &wg, limit, result, dir, filePath, info, opener, nil, opts); err != nil {
+				return xerrors.Errorf("analyze file (%s): %w", filePath, err)
+			}
+			lastPath = filePath
 		}
 
 		// Skip post analysis if the file is not required
@@ -111,6 +135,13 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 		return nil
 	})
 	if err != nil {
+		// Save the progress so that the next scan can resume from the last analyzed file
+		if cp != nil {
+			wg.Wait()
+			if cpErr := cp.save(result, lastPath); cpErr != nil {
+				log.Warn("Unable to save the checkpoint", log.Err(cpErr))
+			}
+		}
 		return artifact.Reference{}, xerrors.Errorf("walk filesystem: %w", err)
 	}
 
@@ -150,6 +181,13 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 		return artifact.Reference{}, xerrors.Errorf("failed to store blob (%s) in cache: %w", cacheKey, err)
 	}
 
+	// The scan is completed, and the partial result is no longer needed
+	if cp != nil {
+		if err = cp.delete(); err != nil {
+			return artifact.Reference{}, xerrors.Errorf("checkpoint error: %w", err)
+		}
+	}
+
 	// get hostname
 	var hostName string
 	b, err := os.ReadFile(filepath.Join(a.rootPath, "etc", "hostname"))
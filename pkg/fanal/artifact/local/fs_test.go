@@ -276,6 +276,95 @@ func TestArtifact_Inspect(t *testing.T) {
 	}
 }
 
+// interruptedWalker fails when it reaches the file, as if the scan was interrupted
+type interruptedWalker struct {
+	*walker.FS
+	failAt string
+}
+
+func (w interruptedWalker) Walk(root string, opt walker.Option, fn walker.WalkFunc) error {
+	return w.FS.Walk(root, opt, func(filePath string, info os.FileInfo, opener analyzer.Opener) error {
+		if filePath == w.failAt {
+			return errors.New("interrupted")
+		}
+		return fn(filePath, info, opener)
+	})
+}
+
+func TestArtifact_InspectResume(t *testing.T) {
+	// Save a checkpoint for every file
+	defaultInterval := checkpointInterval
+	checkpointInterval = 0
+	t.Cleanup(func() { checkpointInterval = defaultInterval })
+
+	c := cache.NewMemoryCache()
+	opt := artifact.Option{Resume: true}
+
+	// The first scan is interrupted
+	a, err := NewArtifact("testdata/alpine", c, interruptedWalker{
+		FS:     walker.NewFS(),
+		failAt: "lib/apk/db/installed",
+	}, opt)
+	require.NoError(t, err)
+	_, err = a.Inspect(context.Background())
+	require.ErrorContains(t, err, "interrupted")
+
+	key, err := a.(Artifact).checkpointKey()
+	require.NoError(t, err)
+	got, err := c.GetBlob(key)
+	require.NoError(t, err)
+	assert.Equal(t, types.BlobInfo{
+		SchemaVersion: types.BlobJSONSchemaVersion,
+		OS: types.OS{
+			Family: "alpine",
+			Name:   "3.11.6",
+		},
+		Checkpoint: "etc/hostname",
+	}, got)
+
+	// Tamper with the checkpoint to make sure the analyzed files are not analyzed again
+	got.OS.Name = "3.10.0"
+	require.NoError(t, c.PutBlob(key, got))
+
+	// The second scan resumes from the checkpoint
+	a, err = NewArtifact("testdata/alpine", c, walker.NewFS(), opt)
+	require.NoError(t, err)
+	ref, err := a.Inspect(context.Background())
+	require.NoError(t, err)
+
+	got, err = c.GetBlob(ref.BlobIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, types.OS{
+		Family: "alpine",
+		Name:   "3.10.0",
+	}, got.OS)
+	require.Len(t, got.PackageInfos, 1)
+	assert.Equal(t, "lib/apk/db/installed", got.PackageInfos[0].FilePath)
+	assert.Empty(t, got.Checkpoint)
+
+	// The checkpoint is deleted after the scan is completed
+	_, err = c.GetBlob(key)
+	require.Error(t, err)
+}
+
+func Test_walkedBefore(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "a/b", b: "a.txt", want: true},
+		{a: "a.txt", b: "a/b", want: false},
+		{a: "a/b/c", b: "a/c", want: true},
+		{a: "b", b: "a/b", want: false},
+		{a: "a/b", b: "a/b", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, walkedBefore(tt.a, tt.b))
+		})
+	}
+}
+
 var terraformPolicyMetadata = types.PolicyMetadata{
 	ID:                 "TEST001",
 	AVDID:              "AVD-TEST-0001",
@@ -287,6 +376,44 @@ var terraformPolicyMetadata = types.PolicyMetadata{
 	References:         []string{"https://trivy.dev/"},
 }
 
+// terraformProviderSuccesses are the results of the built-in checks for required providers
+var terraformProviderSuccesses = types.MisconfResults{
+	{
+		Namespace: "builtin.terraform.providers.TFP001",
+		Query:     "data.builtin.terraform.providers.TFP001.deny",
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "TFP001",
+			AVDID:              "AVD-TFP-0001",
+			Type:               "Terraform Security Check",
+			Title:              "Terraform providers should not be pinned to versions with known vulnerabilities",
+			Description:        `A provider pinned to a vulnerable version keeps being installed even after a fixed version is released. The vulnerable versions are configured in "terraform_providers.vulnerable" of the check data, keyed by the provider source.`,
+			Severity:           "HIGH",
+			RecommendedActions: "Upgrade the provider and update the version constraint in the required_providers block.",
+		},
+		CauseMetadata: types.CauseMetadata{
+			Provider: "Cloud",
+			Service:  "general",
+		},
+	},
+	{
+		Namespace: "builtin.terraform.providers.TFP002",
+		Query:     "data.builtin.terraform.providers.TFP002.deny",
+		PolicyMetadata: types.PolicyMetadata{
+			ID:                 "TFP002",
+			AVDID:              "AVD-TFP-0002",
+			Type:               "Terraform Security Check",
+			Title:              "Terraform providers should not be pinned to yanked versions",
+			Description:        `Yanked provider releases were withdrawn by their publishers, e.g. because of serious bugs, and may no longer be installable. The yanked versions are configured in "terraform_providers.yanked" of the check data, keyed by the provider source.`,
+			Severity:           "MEDIUM",
+			RecommendedActions: "Pin the provider to a version which is not yanked in the required_providers block.",
+		},
+		CauseMetadata: types.CauseMetadata{
+			Provider: "Cloud",
+			Service:  "general",
+		},
+	},
+}
+
 func TestTerraformMisconfigurationScan(t *testing.T) {
 	type fields struct {
 		dir string
@@ -309,6 +436,11 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: 2,
 						Misconfigurations: []types.Misconfiguration{
+							{
+								FileType:  "terraform",
+								FilePath:  ".",
+								Successes: terraformProviderSuccesses,
+							},
 							{
 								FileType: "terraform",
 								FilePath: "main.tf",
@@ -353,6 +485,11 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: 2,
 						Misconfigurations: []types.Misconfiguration{
+							{
+								FileType:  "terraform",
+								FilePath:  ".",
+								Successes: terraformProviderSuccesses,
+							},
 							{
 								FileType: "terraform",
 								FilePath: "main.tf",
@@ -455,7 +592,7 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 							{
 								FileType: "terraform",
 								FilePath: ".",
-								Successes: types.MisconfResults{
+								Successes: append(types.MisconfResults{
 									{
 										Namespace:      "user.something",
 										Query:          "data.user.something.deny",
@@ -465,7 +602,7 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 											Service:  "general",
 										},
 									},
-								},
+								}, terraformProviderSuccesses...),
 							},
 						},
 					},
@@ -492,6 +629,11 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: 2,
 						Misconfigurations: []types.Misconfiguration{
+							{
+								FileType:  "terraform",
+								FilePath:  ".",
+								Successes: terraformProviderSuccesses,
+							},
 							{
 								FileType: "terraform",
 								FilePath: "main.tf",
@@ -557,7 +699,7 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 							{
 								FileType: types.Terraform,
 								FilePath: ".",
-								Successes: types.MisconfResults{
+								Successes: append(types.MisconfResults{
 									{
 										Namespace:      "user.something",
 										Query:          "data.user.something.deny",
@@ -567,7 +709,7 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 											Service:  "general",
 										},
 									},
-								},
+								}, terraformProviderSuccesses...),
 							},
 						},
 					},
@@ -594,6 +736,11 @@ func TestTerraformMisconfigurationScan(t *testing.T) {
 					BlobInfo: types.BlobInfo{
 						SchemaVersion: 2,
 						Misconfigurations: []types.Misconfiguration{
+							{
+								FileType:  "terraform",
+								FilePath:  ".",
+								Successes: terraformProviderSuccesses,
+							},
 							{
 								FileType: types.Terraform,
 								FilePath: "../parent/main.tf",
@@ -2074,6 +2221,11 @@ func TestMixedConfigurationScan(t *testing.T) {
 									},
 								},
 							},
+							{
+								FileType:  "terraform",
+								FilePath:  ".",
+								Successes: terraformProviderSuccesses,
+							},
 							{
 								FileType: "terraform",
 								FilePath: "main.tf",
@@ -0,0 +1,99 @@
+package remotepkg
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/wire"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/downloader"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/local"
+	"github.com/aquasecurity/trivy/pkg/fanal/walker"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var (
+	ArtifactSet = wire.NewSet(
+		walker.NewFS,
+		wire.Bind(new(Walker), new(*walker.FS)),
+		NewArtifact,
+	)
+
+	_ Walker = (*walker.FS)(nil)
+)
+
+type Walker interface {
+	Walk(root string, opt walker.Option, fn walker.WalkFunc) error
+}
+
+// Artifact represents a package downloaded from the registry, such as npm and PyPI.
+type Artifact struct {
+	target Target
+	local  artifact.Artifact
+}
+
+// NewArtifact downloads the package specified as "<ecosystem>:<name>@<version>" into a temp dir
+// and returns the artifact to scan the contents.
+func NewArtifact(ctx context.Context, target string, c cache.ArtifactCache, w Walker, artifactOpt artifact.Option) (
+	artifact.Artifact, func(), error) {
+	return newArtifact(ctx, target, c, w, artifactOpt, defaultRegistries)
+}
+
+func newArtifact(ctx context.Context, target string, c cache.ArtifactCache, w Walker, artifactOpt artifact.Option,
+	regs registries) (artifact.Artifact, func(), error) {
+	cleanup := func() {}
+	t, err := ParseTarget(target)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("invalid package: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "trivy-package")
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	cleanup = func() { _ = os.RemoveAll(tmpDir) }
+
+	f := &fetcher{
+		client: &http.Client{
+			Transport: downloader.NewCustomTransport(downloader.Options{Insecure: artifactOpt.Insecure}),
+			Timeout:   time.Minute * 5,
+		},
+		registries: regs,
+	}
+
+	log.InfoContext(ctx, "Downloading the package...", log.String("package", t.String()))
+	if err = f.fetch(ctx, t, tmpDir); err != nil {
+		return nil, cleanup, xerrors.Errorf("package download error: %w", err)
+	}
+
+	art, err := local.NewArtifact(tmpDir, c, w, artifactOpt)
+	if err != nil {
+		return nil, cleanup, xerrors.Errorf("fs artifact: %w", err)
+	}
+
+	return Artifact{
+		target: t,
+		local:  art,
+	}, cleanup, nil
+}
+
+func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
+	ref, err := a.local.Inspect(ctx)
+	if err != nil {
+		return artifact.Reference{}, xerrors.Errorf("package error: %w", err)
+	}
+
+	ref.Name = a.target.String()
+	ref.Type = artifact.TypePackage
+
+	return ref, nil
+}
+
+func (Artifact) Clean(_ artifact.Reference) error {
+	return nil
+}
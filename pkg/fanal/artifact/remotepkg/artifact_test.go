@@ -0,0 +1,317 @@
+package remotepkg
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/walker"
+
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/nodejs/pkg"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/python/packaging"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/ruby/gem"
+	_ "github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/rust/crate"
+)
+
+type file struct {
+	name    string
+	content []byte
+}
+
+func tarball(t *testing.T, compress bool, files ...file) []byte {
+	var buf bytes.Buffer
+	var tw *tar.Writer
+	var gw *gzip.Writer
+	if compress {
+		gw = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(gw)
+	} else {
+		tw = tar.NewWriter(&buf)
+	}
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: f.name,
+			Mode: 0o644,
+			Size: int64(len(f.content)),
+		}))
+		_, err := tw.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	if gw != nil {
+		require.NoError(t, gw.Close())
+	}
+	return buf.Bytes()
+}
+
+func zipball(t *testing.T, files ...file) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		require.NoError(t, err)
+		_, err = w.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, b []byte) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, err := gw.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func newRegistry(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	var ts *httptest.Server
+
+	// npm
+	mux.HandleFunc("/npm/@scope%2Fdemo/1.0.0", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"dist": map[string]string{"tarball": ts.URL + "/npm/@scope/demo/-/demo-1.0.0.tgz"},
+		})
+	})
+	mux.HandleFunc("/npm/@scope/demo/-/demo-1.0.0.tgz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tarball(t, true,
+			file{"package/package.json", []byte(`{"name": "@scope/demo", "version": "1.0.0", "license": "MIT"}`)},
+			file{"package/index.js", []byte(`module.exports = {};`)},
+		))
+	})
+
+	// PyPI
+	mux.HandleFunc("/pypi/pypi/demo/1.0.0/json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"urls": []map[string]string{
+				{
+					"packagetype": "sdist",
+					"filename":    "demo-1.0.0.tar.gz",
+					"url":         ts.URL + "/pypi/files/demo-1.0.0.tar.gz",
+				},
+				{
+					"packagetype": "bdist_wheel",
+					"filename":    "demo-1.0.0-py3-none-any.whl",
+					"url":         ts.URL + "/pypi/files/demo-1.0.0-py3-none-any.whl",
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/pypi/files/demo-1.0.0-py3-none-any.whl", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(zipball(t,
+			file{"demo/__init__.py", []byte("")},
+			file{"demo-1.0.0.dist-info/METADATA", []byte("Metadata-Version: 2.1\nName: demo\nVersion: 1.0.0\nLicense: BSD-3-Clause\n")},
+		))
+	})
+	mux.HandleFunc("/pypi/pypi/sdist-only/2.0.0/json", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"urls": []map[string]string{
+				{
+					"packagetype": "sdist",
+					"filename":    "sdist-only-2.0.0.tar.gz",
+					"url":         ts.URL + "/pypi/files/sdist-only-2.0.0.tar.gz",
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/pypi/files/sdist-only-2.0.0.tar.gz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tarball(t, true,
+			file{"sdist-only-2.0.0/setup.py", []byte("from setuptools import setup\nsetup()\n")},
+			file{"sdist-only-2.0.0/sdist_only.egg-info/PKG-INFO", []byte("Metadata-Version: 2.1\nName: sdist-only\nVersion: 2.0.0\nLicense: MIT\n")},
+		))
+	})
+
+	// RubyGems
+	mux.HandleFunc("/rubygems/downloads/demo-1.0.0.gem", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tarball(t, false,
+			file{"metadata.gz", gzipped(t, []byte("name: demo\nversion:\n  version: 1.0.0\nlicenses:\n- MIT\n"))},
+			file{"data.tar.gz", tarball(t, true, file{"lib/demo.rb", []byte("module Demo; end\n")})},
+		))
+	})
+
+	// crates.io
+	mux.HandleFunc("/crates/crates/demo/demo-1.0.0.crate", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(tarball(t, true,
+			file{"demo-1.0.0/Cargo.toml", []byte("[package]\nname = \"demo\"\nversion = \"1.0.0\"\nlicense = \"Apache-2.0\"\n")},
+			file{"demo-1.0.0/src/lib.rs", []byte("")},
+		))
+	})
+
+	ts = httptest.NewServer(mux)
+	return ts
+}
+
+func TestArtifact_Inspect(t *testing.T) {
+	ts := newRegistry(t)
+	defer ts.Close()
+
+	regs := registries{
+		npm:      ts.URL + "/npm",
+		pypi:     ts.URL + "/pypi",
+		rubygems: ts.URL + "/rubygems",
+		crates:   ts.URL + "/crates",
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		wantName string
+		wantApps []types.Application
+		wantErr  string
+	}{
+		{
+			name:     "npm scoped package",
+			target:   "npm:@scope/demo@1.0.0",
+			wantName: "npm:@scope/demo@1.0.0",
+			wantApps: []types.Application{
+				{
+					Type:     types.NodePkg,
+					FilePath: "node_modules/@scope/demo/package.json",
+					Packages: types.Packages{
+						{
+							ID:       "@scope/demo@1.0.0",
+							Name:     "@scope/demo",
+							Version:  "1.0.0",
+							Licenses: []string{"MIT"},
+							FilePath: "node_modules/@scope/demo/package.json",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "PyPI wheel",
+			target:   "pypi:demo@1.0.0",
+			wantName: "pypi:demo@1.0.0",
+			wantApps: []types.Application{
+				{
+					Type:     types.PythonPkg,
+					FilePath: "site-packages/demo-1.0.0.dist-info/METADATA",
+					Packages: types.Packages{
+						{
+							Name:     "demo",
+							Version:  "1.0.0",
+							Licenses: []string{"BSD-3-Clause"},
+							FilePath: "site-packages/demo-1.0.0.dist-info/METADATA",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "PyPI source distribution",
+			target:   "pypi:sdist-only@2.0.0",
+			wantName: "pypi:sdist-only@2.0.0",
+			wantApps: []types.Application{
+				{
+					Type:     types.PythonPkg,
+					FilePath: "sdist-only-2.0.0/sdist_only.egg-info/PKG-INFO",
+					Packages: types.Packages{
+						{
+							Name:     "sdist-only",
+							Version:  "2.0.0",
+							Licenses: []string{"MIT"},
+							FilePath: "sdist-only-2.0.0/sdist_only.egg-info/PKG-INFO",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "gem",
+			target:   "gem:demo@1.0.0",
+			wantName: "gem:demo@1.0.0",
+			wantApps: []types.Application{
+				{
+					Type:     types.GemSpec,
+					FilePath: "cache/demo-1.0.0.gem",
+					Packages: types.Packages{
+						{
+							Name:     "demo",
+							Version:  "1.0.0",
+							Licenses: []string{"MIT"},
+							FilePath: "cache/demo-1.0.0.gem",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "crate",
+			target:   "crate:demo@1.0.0",
+			wantName: "crate:demo@1.0.0",
+			wantApps: []types.Application{
+				{
+					Type:     types.Cargo,
+					FilePath: "cache/demo-1.0.0.crate",
+					Packages: types.Packages{
+						{
+							Name:     "demo",
+							Version:  "1.0.0",
+							Licenses: []string{"Apache-2.0"},
+							FilePath: "cache/demo-1.0.0.crate",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "not found",
+			target:  "npm:missing@1.0.0",
+			wantErr: "package not found",
+		},
+		{
+			name:    "invalid target",
+			target:  "npm:lodash",
+			wantErr: "must specify the package version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cache.NewMemoryCache()
+			art, cleanup, err := newArtifact(context.Background(), tt.target, c, walker.NewFS(), artifact.Option{}, regs)
+			defer cleanup()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			ref, err := art.Inspect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, ref.Name)
+			assert.Equal(t, artifact.TypePackage, ref.Type)
+
+			require.Len(t, ref.BlobIDs, 1)
+			blob, err := c.GetBlob(ref.BlobIDs[0])
+			require.NoError(t, err)
+
+			// Digests and locations depend on the analyzers
+			apps := lo.Map(blob.Applications, func(app types.Application, _ int) types.Application {
+				for i := range app.Packages {
+					app.Packages[i].Digest = ""
+					app.Packages[i].Locations = nil
+				}
+				return app
+			})
+			assert.Equal(t, tt.wantApps, apps)
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package remotepkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	getter "github.com/hashicorp/go-getter"
+	"golang.org/x/xerrors"
+)
+
+// registries holds the base URLs of the package registries.
+type registries struct {
+	npm      string
+	pypi     string
+	rubygems string
+	crates   string
+}
+
+var defaultRegistries = registries{
+	npm:      "https://registry.npmjs.org",
+	pypi:     "https://pypi.org",
+	rubygems: "https://rubygems.org",
+	crates:   "https://static.crates.io",
+}
+
+// fetcher downloads packages and lays them out in a directory as package managers install them,
+// so that the analyzers for installed packages identify the package itself.
+type fetcher struct {
+	client     *http.Client
+	registries registries
+}
+
+// fetch downloads the package into dir.
+// The layout is as follows:
+//   - npm:   node_modules/<name>/...
+//   - pypi:  site-packages/<name>-<version>.dist-info/... for wheels, <name>-<version>/... for source distributions
+//   - gem:   cache/<name>-<version>.gem and gems/<name>-<version>/...
+//   - crate: cache/<name>-<version>.crate and src/<name>-<version>/...
+func (f *fetcher) fetch(ctx context.Context, target Target, dir string) error {
+	var err error
+	switch target.Ecosystem {
+	case EcosystemNpm:
+		err = f.fetchNpm(ctx, target, dir)
+	case EcosystemPyPI:
+		err = f.fetchPyPI(ctx, target, dir)
+	case EcosystemGem:
+		err = f.fetchGem(ctx, target, dir)
+	case EcosystemCrate:
+		err = f.fetchCrate(ctx, target, dir)
+	default:
+		return xerrors.Errorf("unsupported ecosystem: %s", target.Ecosystem)
+	}
+	if err != nil {
+		return err
+	}
+	return verifyExtracted(dir)
+}
+
+// cf. https://github.com/npm/registry/blob/main/docs/REGISTRY-API.md#getpackageversion
+func (f *fetcher) fetchNpm(ctx context.Context, target Target, dir string) error {
+	var manifest struct {
+		Dist struct {
+			Tarball string `json:"tarball"`
+		} `json:"dist"`
+	}
+	// Scoped packages are accessed as "@scope%2Fname"
+	u := fmt.Sprintf("%s/%s/%s", f.registries.npm, strings.ReplaceAll(target.Name, "/", "%2F"), url.PathEscape(target.Version))
+	if err := f.getJSON(ctx, u, &manifest); err != nil {
+		return xerrors.Errorf("npm registry error: %w", err)
+	} else if manifest.Dist.Tarball == "" {
+		return xerrors.Errorf("no tarball found for %s", target)
+	}
+
+	archive, err := f.download(ctx, manifest.Dist.Tarball, dir)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive)
+
+	dst, err := joinUnder(dir, "node_modules", filepath.FromSlash(target.Name))
+	if err != nil {
+		return err
+	}
+	return extractSingleRoot(&getter.TarGzipDecompressor{}, archive, dst)
+}
+
+// cf. https://warehouse.pypa.io/api-reference/json.html#get--pypi--project_name---version--json
+func (f *fetcher) fetchPyPI(ctx context.Context, target Target, dir string) error {
+	var release struct {
+		URLs []struct {
+			PackageType string `json:"packagetype"`
+			Filename    string `json:"filename"`
+			URL         string `json:"url"`
+		} `json:"urls"`
+	}
+	u := fmt.Sprintf("%s/pypi/%s/%s/json", f.registries.pypi, url.PathEscape(target.Name), url.PathEscape(target.Version))
+	if err := f.getJSON(ctx, u, &release); err != nil {
+		return xerrors.Errorf("PyPI error: %w", err)
+	}
+
+	// Prefer wheels as pip does. Wheels contain the metadata in "*.dist-info/METADATA".
+	var wheelURL, sdistURL, sdistFilename string
+	for _, file := range release.URLs {
+		switch file.PackageType {
+		case "bdist_wheel":
+			if wheelURL == "" {
+				wheelURL = file.URL
+			}
+		case "sdist":
+			sdistURL, sdistFilename = file.URL, file.Filename
+		}
+	}
+
+	switch {
+	case wheelURL != "":
+		archive, err := f.download(ctx, wheelURL, dir)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(archive)
+		return decompress(&getter.ZipDecompressor{}, archive, filepath.Join(dir, "site-packages"))
+	case sdistURL != "":
+		archive, err := f.download(ctx, sdistURL, dir)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(archive)
+
+		var d getter.Decompressor = &getter.TarGzipDecompressor{}
+		if strings.HasSuffix(sdistFilename, ".zip") {
+			d = &getter.ZipDecompressor{}
+		}
+		dst, err := joinUnder(dir, target.Name+"-"+target.Version)
+		if err != nil {
+			return err
+		}
+		return extractSingleRoot(d, archive, dst)
+	}
+	return xerrors.Errorf("no distribution found for %s", target)
+}
+
+// cf. https://guides.rubygems.org/rubygems-basics/#installing-gems
+func (f *fetcher) fetchGem(ctx context.Context, target Target, dir string) error {
+	fileName := target.Name + "-" + target.Version + ".gem"
+	u := fmt.Sprintf("%s/downloads/%s", f.registries.rubygems, url.PathEscape(fileName))
+	archive, err := f.download(ctx, u, filepath.Join(dir, "cache"))
+	if err != nil {
+		return xerrors.Errorf("RubyGems error: %w", err)
+	}
+
+	// .gem files are tar archives containing "metadata.gz" and "data.tar.gz"
+	tmpDir, err := os.MkdirTemp(dir, "gem-")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = decompress(&getter.TarDecompressor{}, archive, tmpDir); err != nil {
+		return err
+	}
+	dst, err := joinUnder(dir, "gems", strings.TrimSuffix(fileName, ".gem"))
+	if err != nil {
+		return err
+	}
+	return decompress(&getter.TarGzipDecompressor{}, filepath.Join(tmpDir, "data.tar.gz"), dst)
+}
+
+// cf. https://doc.rust-lang.org/cargo/reference/registry-index.html#index-configuration
+func (f *fetcher) fetchCrate(ctx context.Context, target Target, dir string) error {
+	fileName := target.Name + "-" + target.Version + ".crate"
+	u := fmt.Sprintf("%s/crates/%s/%s", f.registries.crates, url.PathEscape(target.Name), url.PathEscape(fileName))
+	archive, err := f.download(ctx, u, filepath.Join(dir, "cache"))
+	if err != nil {
+		return xerrors.Errorf("crates.io error: %w", err)
+	}
+	dst, err := joinUnder(dir, "src", strings.TrimSuffix(fileName, ".crate"))
+	if err != nil {
+		return err
+	}
+	return extractSingleRoot(&getter.TarGzipDecompressor{}, archive, dst)
+}
+
+func (f *fetcher) getJSON(ctx context.Context, u string, v any) error {
+	resp, err := f.get(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return xerrors.Errorf("json decode error: %w", err)
+	}
+	return nil
+}
+
+// download saves the file at the URL into dir and returns the file path.
+func (f *fetcher) download(ctx context.Context, u, dir string) (string, error) {
+	resp, err := f.get(ctx, u)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return "", xerrors.Errorf("mkdir error: %w", err)
+	}
+
+	fileName := path.Base(resp.Request.URL.Path)
+	if fileName == "/" || fileName == "." {
+		return "", xerrors.Errorf("invalid download URL: %s", u)
+	}
+	filePath := filepath.Join(dir, fileName)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return "", xerrors.Errorf("file create error: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		return "", xerrors.Errorf("download error: %w", err)
+	}
+	return filePath, nil
+}
+
+func (f *fetcher) get(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create a request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, xerrors.Errorf("HTTP error: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, xerrors.Errorf("package not found: %s", u)
+		}
+		return nil, xerrors.Errorf("unexpected status code %d: %s", resp.StatusCode, u)
+	}
+	return resp, nil
+}
+
+func decompress(d getter.Decompressor, src, dst string) error {
+	if err := d.Decompress(dst, src, true, 0o022); err != nil {
+		return xerrors.Errorf("unable to extract %s: %w", filepath.Base(src), err)
+	}
+	return nil
+}
+
+// extractSingleRoot extracts the archive into dst, stripping the top-level directory if the archive has only one,
+// e.g. "package/" in npm tarballs and "<name>-<version>/" in crates and source distributions.
+func extractSingleRoot(d getter.Decompressor, src, dst string) error {
+	tmpDir, err := os.MkdirTemp(filepath.Dir(src), "extract-")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = decompress(d, src, tmpDir); err != nil {
+		return err
+	}
+
+	root := tmpDir
+	if entries, err := os.ReadDir(tmpDir); err != nil {
+		return xerrors.Errorf("read dir error: %w", err)
+	} else if len(entries) == 1 && entries[0].IsDir() {
+		root = filepath.Join(tmpDir, entries[0].Name())
+	}
+
+	if err = os.MkdirAll(filepath.Dir(dst), 0o700); err != nil {
+		return xerrors.Errorf("mkdir error: %w", err)
+	}
+	if err = os.Rename(root, dst); err != nil {
+		return xerrors.Errorf("rename error: %w", err)
+	}
+	return nil
+}
+
+// joinUnder joins the elements to dir and ensures that the result doesn't escape dir.
+// The package names are validated in ParseTarget, but the check is repeated here as they come from user input.
+func joinUnder(dir string, elem ...string) (string, error) {
+	p := filepath.Join(append([]string{dir}, elem...)...)
+	if !isUnder(dir, p) {
+		return "", xerrors.Errorf("%s is outside %s", p, dir)
+	}
+	return p, nil
+}
+
+// verifyExtracted ensures that all the extracted files, including the targets of symlinks, are under dir.
+func verifyExtracted(dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if !isUnder(dir, p) {
+			return xerrors.Errorf("%s is outside %s", p, dir)
+		} else if d.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+
+		link, err := os.Readlink(p)
+		if err != nil {
+			return xerrors.Errorf("readlink error: %w", err)
+		}
+		if !filepath.IsAbs(link) {
+			link = filepath.Join(filepath.Dir(p), link)
+		}
+		if !isUnder(dir, link) {
+			return xerrors.Errorf("symlink %s points outside %s: %s", p, dir, link)
+		}
+		return nil
+	})
+}
+
+func isUnder(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
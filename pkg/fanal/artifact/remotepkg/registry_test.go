@@ -0,0 +1,59 @@
+package remotepkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinUnder(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := joinUnder(dir, "node_modules", "@babel", "core")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "node_modules", "@babel", "core"), got)
+
+	_, err = joinUnder(dir, "node_modules", filepath.Join("..", "..", "x"))
+	require.ErrorContains(t, err, "is outside")
+}
+
+func TestVerifyExtracted(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		wantErr string
+	}{
+		{
+			name: "symlink inside",
+			link: filepath.Join("..", "index.js"),
+		},
+		{
+			name:    "relative symlink outside",
+			link:    filepath.Join("..", "..", "..", "..", "etc", "passwd"),
+			wantErr: "points outside",
+		},
+		{
+			name:    "absolute symlink outside",
+			link:    filepath.Join(os.TempDir(), "passwd"),
+			wantErr: "points outside",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			pkgDir := filepath.Join(dir, "node_modules", "foo", "lib")
+			require.NoError(t, os.MkdirAll(pkgDir, 0o700))
+			require.NoError(t, os.Symlink(tt.link, filepath.Join(pkgDir, "link")))
+
+			err := verifyExtracted(dir)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package remotepkg
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+)
+
+// Ecosystem represents a package registry ecosystem
+type Ecosystem string
+
+const (
+	EcosystemNpm   Ecosystem = "npm"
+	EcosystemPyPI  Ecosystem = "pypi"
+	EcosystemGem   Ecosystem = "gem"
+	EcosystemCrate Ecosystem = "crate"
+)
+
+var ecosystems = []Ecosystem{
+	EcosystemNpm,
+	EcosystemPyPI,
+	EcosystemGem,
+	EcosystemCrate,
+}
+
+var (
+	// namePatterns restricts the package names to the characters allowed by each ecosystem,
+	// so that the names can't escape the directory where the package is extracted.
+	namePatterns = map[Ecosystem]*regexp.Regexp{
+		// cf. https://github.com/npm/validate-npm-package-name
+		EcosystemNpm: regexp.MustCompile(`^(@[A-Za-z0-9~-][A-Za-z0-9._~-]*/)?[A-Za-z0-9~-][A-Za-z0-9._~-]*$`),
+		// cf. https://packaging.python.org/en/latest/specifications/name-normalization/
+		EcosystemPyPI: regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$`),
+		// cf. https://guides.rubygems.org/name-your-gem/
+		EcosystemGem: regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]*$`),
+		// cf. https://doc.rust-lang.org/cargo/reference/manifest.html#the-name-field
+		EcosystemCrate: regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`),
+	}
+
+	// versionPattern covers SemVer, PEP 440 and RubyGems versions
+	versionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.+!_~-]*$`)
+)
+
+// Target represents a package in a registry, such as "npm:lodash@4.17.21".
+type Target struct {
+	Ecosystem Ecosystem
+	Name      string
+	Version   string
+}
+
+// ParseTarget parses "<ecosystem>:<name>@<version>".
+// The name may contain "@" for npm scoped packages, e.g. "npm:@babel/core@7.24.0".
+func ParseTarget(s string) (Target, error) {
+	eco, pkg, ok := strings.Cut(s, ":")
+	if !ok {
+		return Target{}, xerrors.Errorf("%q must be in the format <ecosystem>:<name>@<version>", s)
+	}
+
+	ecosystem := Ecosystem(strings.ToLower(eco))
+	if !slices.Contains(ecosystems, ecosystem) {
+		supported := lo.Map(ecosystems, func(e Ecosystem, _ int) string { return string(e) })
+		return Target{}, xerrors.Errorf("unsupported ecosystem %q (supported: %s)", eco, strings.Join(supported, ", "))
+	}
+
+	i := strings.LastIndex(pkg, "@")
+	if i <= 0 || i == len(pkg)-1 {
+		return Target{}, xerrors.Errorf("%q must specify the package version, e.g. %s:%s@<version>", s, eco, strings.TrimSuffix(pkg, "@"))
+	}
+
+	name, version := pkg[:i], pkg[i+1:]
+	if !namePatterns[ecosystem].MatchString(name) {
+		return Target{}, xerrors.Errorf("invalid %s package name: %q", ecosystem, name)
+	} else if !versionPattern.MatchString(version) {
+		return Target{}, xerrors.Errorf("invalid %s package version: %q", ecosystem, version)
+	}
+
+	return Target{
+		Ecosystem: ecosystem,
+		Name:      name,
+		Version:   version,
+	}, nil
+}
+
+func (t Target) String() string {
+	return fmt.Sprintf("%s:%s@%s", t.Ecosystem, t.Name, t.Version)
+}
@@ -0,0 +1,120 @@
+package remotepkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Target
+		wantErr string
+	}{
+		{
+			name:  "npm",
+			input: "npm:lodash@4.17.21",
+			want: Target{
+				Ecosystem: EcosystemNpm,
+				Name:      "lodash",
+				Version:   "4.17.21",
+			},
+		},
+		{
+			name:  "npm scoped package",
+			input: "npm:@babel/core@7.24.0",
+			want: Target{
+				Ecosystem: EcosystemNpm,
+				Name:      "@babel/core",
+				Version:   "7.24.0",
+			},
+		},
+		{
+			name:  "upper case ecosystem",
+			input: "PyPI:requests@2.31.0",
+			want: Target{
+				Ecosystem: EcosystemPyPI,
+				Name:      "requests",
+				Version:   "2.31.0",
+			},
+		},
+		{
+			name:  "crate",
+			input: "crate:serde_json@1.0.117",
+			want: Target{
+				Ecosystem: EcosystemCrate,
+				Name:      "serde_json",
+				Version:   "1.0.117",
+			},
+		},
+		{
+			name:    "npm path traversal",
+			input:   "npm:../../x@1.0.0",
+			wantErr: `invalid npm package name: "../../x"`,
+		},
+		{
+			name:    "npm scope traversal",
+			input:   "npm:@scope/../x@1.0.0",
+			wantErr: `invalid npm package name: "@scope/../x"`,
+		},
+		{
+			name:    "npm absolute path",
+			input:   "npm:/etc/passwd@1.0.0",
+			wantErr: `invalid npm package name: "/etc/passwd"`,
+		},
+		{
+			name:    "pypi separator",
+			input:   `pypi:foo\bar@1.0.0`,
+			wantErr: `invalid pypi package name: "foo\\bar"`,
+		},
+		{
+			name:    "gem parent directory",
+			input:   "gem:..@1.0.0",
+			wantErr: `invalid gem package name: ".."`,
+		},
+		{
+			name:    "crate separator",
+			input:   "crate:foo/bar@1.0.0",
+			wantErr: `invalid crate package name: "foo/bar"`,
+		},
+		{
+			name:    "version traversal",
+			input:   "crate:serde@../../x",
+			wantErr: `invalid crate package version: "../../x"`,
+		},
+		{
+			name:    "no ecosystem",
+			input:   "lodash@4.17.21",
+			wantErr: "must be in the format <ecosystem>:<name>@<version>",
+		},
+		{
+			name:    "unsupported ecosystem",
+			input:   "maven:org.example:foo@1.0.0",
+			wantErr: `unsupported ecosystem "maven" (supported: npm, pypi, gem, crate)`,
+		},
+		{
+			name:    "no version",
+			input:   "npm:@babel/core",
+			wantErr: "must specify the package version, e.g. npm:@babel/core@<version>",
+		},
+		{
+			name:    "empty version",
+			input:   "gem:rails@",
+			wantErr: "must specify the package version, e.g. gem:rails@<version>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTarget(tt.input)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
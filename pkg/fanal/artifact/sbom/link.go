@@ -0,0 +1,200 @@
+package sbom
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/sbom"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// linkResolver resolves the external BOMs and VEX documents referenced by an SBOM.
+// External BOMs are located by file paths relative to the referencing BOM,
+// or by BOM-Links to the CycloneDX BOMs in the directory of the scanned SBOM, i.e. the bundle.
+// Remote documents are not fetched.
+type linkResolver struct {
+	bundleDir string
+
+	// bundle maps the BOM-Links of the CycloneDX BOMs in the bundle directory to their paths
+	bundle map[string]string
+	// visited are the paths of the resolved BOMs
+	visited map[string]struct{}
+}
+
+func newLinkResolver(filePath string) *linkResolver {
+	return &linkResolver{
+		bundleDir: filepath.Dir(filePath),
+		visited:   map[string]struct{}{filepath.Clean(filePath): {}},
+	}
+}
+
+// resolve merges the packages of the BOMs linked from the BOM into the root SBOM recursively,
+// and resolves the VEX documents of each BOM.
+// The BOM-Refs of the merged packages are replaced with BOM-Links,
+// so that the packages can be told apart from the packages of other BOMs.
+func (r *linkResolver) resolve(ctx context.Context, root *types.SBOM, bom types.SBOM, filePath string) error {
+	r.resolveVEX(ctx, bom, filePath)
+
+	for _, link := range bom.BOM.BOMLinks {
+		linkedPath, ok := r.locate(link, filePath)
+		if !ok {
+			log.WarnContext(ctx, "Unable to resolve the external BOM", log.String("link", link))
+			continue
+		} else if _, ok = r.visited[linkedPath]; ok {
+			continue
+		}
+		r.visited[linkedPath] = struct{}{}
+
+		linked, err := decodeFile(ctx, linkedPath)
+		if err != nil {
+			return xerrors.Errorf("failed to decode the external BOM %q: %w", linkedPath, err)
+		}
+		log.DebugContext(ctx, "External BOM resolved", log.String("link", link), log.FilePath(linkedPath))
+
+		linkPackages(linked)
+		root.Packages = append(root.Packages, linked.Packages...)
+		root.Applications = append(root.Applications, linked.Applications...)
+		root.BOM.Linked = append(root.BOM.Linked, linked.BOM)
+
+		if err = r.resolve(ctx, root, linked, linkedPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveVEX resolves the VEX documents referenced by or embedded in the BOM
+func (*linkResolver) resolveVEX(ctx context.Context, bom types.SBOM, filePath string) {
+	if bom.BOM.EmbeddedVEX {
+		bom.BOM.VEXFiles = append(bom.BOM.VEXFiles, filePath)
+	}
+	for _, link := range bom.BOM.VEXLinks {
+		if vexPath, ok := localPath(link, filePath); ok {
+			bom.BOM.VEXFiles = append(bom.BOM.VEXFiles, vexPath)
+		} else {
+			log.WarnContext(ctx, "Unable to resolve the VEX document", log.String("link", link))
+		}
+	}
+}
+
+// locate returns the path of the BOM referenced by the link
+func (r *linkResolver) locate(link, filePath string) (string, bool) {
+	if !cdx.IsBOMLink(link) {
+		return localPath(link, filePath)
+	}
+
+	if r.bundle == nil {
+		r.bundle = make(map[string]string)
+		entries, err := os.ReadDir(r.bundleDir)
+		if err != nil {
+			return "", false
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			p := filepath.Join(r.bundleDir, entry.Name())
+			if bomLink, ok := cycloneDXLink(p); ok {
+				r.bundle[bomLink] = p
+			}
+		}
+	}
+
+	// Links to elements in the BOM, e.g. "urn:cdx:.../1#component", refer to the BOM itself
+	bomLink, _, _ := strings.Cut(link, "#")
+	p, ok := r.bundle[bomLink]
+	return p, ok
+}
+
+// localPath returns the path of the document referenced by a relative path or a file URL
+func localPath(link, filePath string) (string, bool) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", false
+	}
+	switch u.Scheme {
+	case "":
+	case "file":
+		link = u.Path
+	default:
+		// e.g. HTTP URLs and BOM-Links
+		return "", false
+	}
+	if !filepath.IsAbs(link) {
+		link = filepath.Join(filepath.Dir(filePath), filepath.FromSlash(link))
+	}
+	return filepath.Clean(link), true
+}
+
+// cycloneDXLink returns the BOM-Link of the CycloneDX JSON BOM, e.g. "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1"
+func cycloneDXLink(filePath string) (string, bool) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	if ok, err := sbom.IsCycloneDXJSON(f); err != nil || !ok {
+		return "", false
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return "", false
+	}
+	bom := cdx.NewBOM()
+	if err = cdx.NewBOMDecoder(f, cdx.BOMFileFormatJSON).Decode(bom); err != nil {
+		return "", false
+	}
+	link, err := cdx.NewBOMLink(bom.SerialNumber, bom.Version, nil)
+	if err != nil {
+		return "", false
+	}
+	return link.String(), true
+}
+
+// linkPackages replaces the BOM-Refs of the packages with BOM-Links to the packages in the BOM
+func linkPackages(bom types.SBOM) {
+	bomLink, err := cdx.NewBOMLink(bom.BOM.SerialNumber, bom.BOM.Version, nil)
+	if err != nil {
+		return
+	}
+	link := func(id *ftypes.PkgIdentifier) {
+		if id.BOMRef == "" {
+			return
+		}
+		// The BOM-Ref is not escaped, so that it matches the "affects" of VEX documents as they are
+		id.BOMRef = bomLink.String() + "#" + id.BOMRef
+	}
+	for i := range bom.Packages {
+		for j := range bom.Packages[i].Packages {
+			link(&bom.Packages[i].Packages[j].Identifier)
+		}
+	}
+	for i := range bom.Applications {
+		for j := range bom.Applications[i].Packages {
+			link(&bom.Applications[i].Packages[j].Identifier)
+		}
+	}
+}
+
+func decodeFile(ctx context.Context, filePath string) (types.SBOM, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return types.SBOM{}, xerrors.Errorf("failed to open sbom file error: %w", err)
+	}
+	defer f.Close()
+
+	format, err := sbom.DetectFormat(f)
+	if err != nil {
+		return types.SBOM{}, xerrors.Errorf("failed to detect SBOM format: %w", err)
+	}
+	return sbom.Decode(ctx, f, format)
+}
@@ -0,0 +1,44 @@
+package sbom
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestLinkResolver_Resolve(t *testing.T) {
+	filePath := filepath.Join("testdata", "linked", "app.cdx.json")
+
+	ctx := context.Background()
+	bom, err := decodeFile(ctx, filePath)
+	require.NoError(t, err)
+
+	err = newLinkResolver(filePath).resolve(ctx, &bom, bom, filePath)
+	require.NoError(t, err)
+
+	var bomRefs []string
+	for _, app := range bom.Applications {
+		for _, pkg := range app.Packages {
+			bomRefs = append(bomRefs, pkg.Identifier.BOMRef)
+		}
+	}
+	assert.ElementsMatch(t, []string{
+		"pkg:maven/org.example/app-lib@1.0.0",
+		"urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#pkg:maven/org.springframework.boot/spring-boot@2.6.0",
+	}, bomRefs)
+	assert.True(t, lo.ContainsBy(bom.Applications, func(app ftypes.Application) bool {
+		return app.Type == ftypes.Jar
+	}))
+
+	require.Len(t, bom.BOM.Linked, 1)
+	linked := bom.BOM.Linked[0]
+	assert.Equal(t, "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79", linked.SerialNumber)
+	assert.Equal(t, []string{filepath.Join("testdata", "linked", "supplier.cdx.json")}, linked.VEXFiles)
+	assert.Empty(t, bom.BOM.VEXFiles)
+}
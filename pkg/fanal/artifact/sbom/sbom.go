@@ -57,6 +57,11 @@ func (a Artifact) Inspect(ctx context.Context) (artifact.Reference, error) {
 		return artifact.Reference{}, xerrors.Errorf("SBOM decode error: %w", err)
 	}
 
+	// Merge the external BOMs linked from the SBOM, e.g. SBOMs of components supplied by vendors
+	if err = newLinkResolver(a.filePath).resolve(ctx, &bom, bom, a.filePath); err != nil {
+		return artifact.Reference{}, xerrors.Errorf("external BOM error: %w", err)
+	}
+
 	blobInfo := types.BlobInfo{
 		SchemaVersion: types.BlobJSONSchemaVersion,
 		OS:            lo.FromPtr(bom.Metadata.OS),
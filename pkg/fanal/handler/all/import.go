@@ -1,6 +1,7 @@
 package all
 
 import (
+	_ "github.com/aquasecurity/trivy/pkg/fanal/handler/buildpacks"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/handler/sysfile"
 	_ "github.com/aquasecurity/trivy/pkg/fanal/handler/unpackaged"
 )
@@ -0,0 +1,99 @@
+package buildpacks
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/handler"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func init() {
+	handler.RegisterPostHandlerInit(types.BuildpacksPostHandler, newBuildpacksPostHandler)
+}
+
+const (
+	version = 1
+
+	// e.g. layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.cdx.json
+	sbomDir = "layers/sbom/"
+)
+
+// SBOM formats generated by Cloud Native Buildpacks in order of preference.
+// Syft JSON is not supported.
+// ref: https://github.com/buildpacks/spec/blob/main/buildpack.md#bill-of-materials
+var sbomFiles = []string{
+	"sbom.cdx.json",
+	"sbom.spdx.json",
+}
+
+type buildpacksPostHandler struct{}
+
+func newBuildpacksPostHandler(artifact.Option) (handler.PostHandler, error) {
+	return buildpacksPostHandler{}, nil
+}
+
+// Handle removes duplicate packages of Cloud Native Buildpacks images.
+// Buildpacks write the same SBOM in several formats into the layer directory,
+// so only the SBOM in the preferred format is taken for each layer.
+func (h buildpacksPostHandler) Handle(_ context.Context, _ *analyzer.AnalysisResult, blob *types.BlobInfo) error {
+	// SBOM file names keyed by layer directory
+	sboms := make(map[string][]string)
+	for _, app := range blob.Applications {
+		if isBuildpacksSBOM(app.FilePath) {
+			dir, file := path.Split(app.FilePath)
+			sboms[dir] = append(sboms[dir], file)
+		}
+	}
+	for _, pkgInfo := range blob.PackageInfos {
+		if isBuildpacksSBOM(pkgInfo.FilePath) {
+			dir, file := path.Split(pkgInfo.FilePath)
+			sboms[dir] = append(sboms[dir], file)
+		}
+	}
+	if len(sboms) == 0 {
+		return nil
+	}
+
+	duplicate := func(filePath string) bool {
+		if !isBuildpacksSBOM(filePath) {
+			return false
+		}
+		dir, file := path.Split(filePath)
+		for _, f := range sbomFiles {
+			if slices.Contains(sboms[dir], f) {
+				return f != file
+			}
+		}
+		return false
+	}
+
+	blob.Applications = slices.DeleteFunc(blob.Applications, func(app types.Application) bool {
+		return duplicate(app.FilePath)
+	})
+	blob.PackageInfos = slices.DeleteFunc(blob.PackageInfos, func(pkgInfo types.PackageInfo) bool {
+		return duplicate(pkgInfo.FilePath)
+	})
+
+	return nil
+}
+
+func isBuildpacksSBOM(filePath string) bool {
+	return strings.HasPrefix(filePath, sbomDir) && slices.Contains(sbomFiles, path.Base(filePath))
+}
+
+func (h buildpacksPostHandler) Version() int {
+	return version
+}
+
+func (h buildpacksPostHandler) Type() types.HandlerType {
+	return types.BuildpacksPostHandler
+}
+
+func (h buildpacksPostHandler) Priority() int {
+	return types.BuildpacksPostHandlerPriority
+}
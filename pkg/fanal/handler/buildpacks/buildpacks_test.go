@@ -0,0 +1,89 @@
+package buildpacks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func Test_buildpacksPostHandler_Handle(t *testing.T) {
+	goApp := func(filePath string) types.Application {
+		return types.Application{
+			Type:     types.GoBinary,
+			FilePath: filePath,
+			Packages: types.Packages{
+				{
+					ID:      "github.com/gorilla/mux@v1.8.0",
+					Name:    "github.com/gorilla/mux",
+					Version: "v1.8.0",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		blob *types.BlobInfo
+		want *types.BlobInfo
+	}{
+		{
+			name: "CycloneDX and SPDX in the same layer",
+			blob: &types.BlobInfo{
+				Applications: []types.Application{
+					goApp("layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.cdx.json"),
+					goApp("layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.spdx.json"),
+					goApp("workspace/app"),
+				},
+			},
+			want: &types.BlobInfo{
+				Applications: []types.Application{
+					goApp("layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.cdx.json"),
+					goApp("workspace/app"),
+				},
+			},
+		},
+		{
+			name: "SPDX only",
+			blob: &types.BlobInfo{
+				Applications: []types.Application{
+					goApp("layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.spdx.json"),
+					goApp("layers/sbom/launch/paketo-buildpacks_go-dist/go/sbom.cdx.json"),
+				},
+			},
+			want: &types.BlobInfo{
+				Applications: []types.Application{
+					goApp("layers/sbom/launch/paketo-buildpacks_go-build/targets/sbom.spdx.json"),
+					goApp("layers/sbom/launch/paketo-buildpacks_go-dist/go/sbom.cdx.json"),
+				},
+			},
+		},
+		{
+			name: "not buildpacks SBOM",
+			blob: &types.BlobInfo{
+				Applications: []types.Application{
+					goApp("app/sbom.cdx.json"),
+					goApp("app/sbom.spdx.json"),
+				},
+			},
+			want: &types.BlobInfo{
+				Applications: []types.Application{
+					goApp("app/sbom.cdx.json"),
+					goApp("app/sbom.spdx.json"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := buildpacksPostHandler{}
+			err := h.Handle(context.Background(), &analyzer.AnalysisResult{}, tt.blob)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.blob)
+		})
+	}
+}
@@ -41,6 +41,9 @@ var (
 
 		// Go binaries
 		types.GoBinary,
+
+		// C libraries in binaries
+		types.CBinary,
 	}
 )
 
@@ -0,0 +1,246 @@
+package secret
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"slices"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+const (
+	EntropyRuleID   = "high-entropy-string"
+	CategoryEntropy = types.SecretRuleCategory("Entropy")
+
+	defaultEntropySeverity  = "MEDIUM"
+	defaultEntropyMinLength = 20
+	defaultEntropyMaxLength = 256
+
+	base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/=-_"
+	hexChars    = "0123456789abcdefABCDEF"
+)
+
+// Built-in charsets and their default thresholds.
+// Random strings have about 4 bits of entropy per character in hex and 6 bits in base64,
+// but the entropy of a short string is bounded by log2 of its length.
+var builtinCharsets = map[string]EntropyCharset{
+	"base64": {
+		Name:      "base64",
+		Chars:     base64Chars,
+		Threshold: 4.5,
+	},
+	"hex": {
+		Name:      "hex",
+		Chars:     hexChars,
+		Threshold: 3.0,
+	},
+}
+
+var (
+	uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+	// e.g. "sha512-" of Subresource Integrity, "sha256:" of OCI digests and "h1:" of go.sum
+	hashPrefixRegexp = regexp.MustCompile(`(?i)(md5|sha\d*|h1)[-:]$`)
+	// "-" is a base64 character, so the prefix can be a part of the string
+	hashPrefixedRegexp = regexp.MustCompile(`(?i)^(md5|sha\d*)-`)
+
+	// Hex strings of these lengths on a line mentioning a hash are digests of MD5, SHA-1, SHA-256, SHA-384 and SHA-512.
+	hashLengths  = []int{32, 40, 64, 96, 128}
+	hashKeywords = []string{"hash", "digest", "checksum", "sum", "sha", "md5", "integrity", "commit", "revision", "etag"}
+)
+
+// EntropyConfig enables the detection of high-entropy strings, which complements the rules
+// to find secrets in unknown or custom formats.
+type EntropyConfig struct {
+	Enabled   bool             `yaml:"enabled"`
+	Severity  string           `yaml:"severity"`
+	MinLength int              `yaml:"min-length"`
+	MaxLength int              `yaml:"max-length"`
+	Charsets  []EntropyCharset `yaml:"charsets"`
+}
+
+// EntropyCharset is a set of characters a secret consists of.
+// "base64" and "hex" are built in. Custom charsets require "chars".
+type EntropyCharset struct {
+	Name      string  `yaml:"name"`
+	Chars     string  `yaml:"chars"`
+	Threshold float64 `yaml:"threshold"`
+}
+
+type charset struct {
+	chars     [256]bool
+	threshold float64
+}
+
+type entropyDetector struct {
+	rule      Rule
+	minLength int
+	maxLength int
+	charsets  []charset
+}
+
+// validate fills in the defaults and checks the config
+func (c *EntropyConfig) validate() error {
+	if c.MinLength == 0 {
+		c.MinLength = defaultEntropyMinLength
+	}
+	if c.MaxLength == 0 {
+		c.MaxLength = defaultEntropyMaxLength
+	}
+	if c.MinLength > c.MaxLength {
+		return xerrors.Errorf("min-length (%d) must not be greater than max-length (%d)", c.MinLength, c.MaxLength)
+	}
+
+	if len(c.Charsets) == 0 {
+		c.Charsets = []EntropyCharset{
+			builtinCharsets["base64"],
+			builtinCharsets["hex"],
+		}
+	}
+	for i, cs := range c.Charsets {
+		builtin, ok := builtinCharsets[cs.Name]
+		if cs.Chars == "" {
+			if !ok {
+				return xerrors.Errorf("charset %q requires chars", cs.Name)
+			}
+			cs.Chars = builtin.Chars
+		}
+		if cs.Threshold == 0 {
+			if !ok {
+				return xerrors.Errorf("charset %q requires threshold", cs.Name)
+			}
+			cs.Threshold = builtin.Threshold
+		}
+		c.Charsets[i] = cs
+	}
+	return nil
+}
+
+func newEntropyDetector(c EntropyConfig) *entropyDetector {
+	d := &entropyDetector{
+		rule: Rule{
+			ID:       EntropyRuleID,
+			Category: CategoryEntropy,
+			Title:    "High entropy string",
+			Severity: c.Severity,
+		},
+		minLength: c.MinLength,
+		maxLength: c.MaxLength,
+	}
+	if d.rule.Severity == "" {
+		d.rule.Severity = defaultEntropySeverity
+	}
+	for _, cs := range c.Charsets {
+		var chars [256]bool
+		for i := 0; i < len(cs.Chars); i++ {
+			chars[cs.Chars[i]] = true
+		}
+		d.charsets = append(d.charsets, charset{
+			chars:     chars,
+			threshold: cs.Threshold,
+		})
+	}
+	return d
+}
+
+// FindLocations returns the locations of strings consisting of a charset with entropy above its threshold.
+// A location overlapping with the one found with a preceding charset is skipped.
+func (d *entropyDetector) FindLocations(content []byte) []Location {
+	var locs []Location
+	for _, cs := range d.charsets {
+		for start := 0; start < len(content); start++ {
+			if !cs.chars[content[start]] {
+				continue
+			}
+			end := start
+			for end < len(content) && cs.chars[content[end]] {
+				end++
+			}
+
+			loc := Location{
+				Start: start,
+				End:   end,
+			}
+			start = end
+
+			if n := loc.End - loc.Start; n < d.minLength || n > d.maxLength {
+				continue
+			} else if overlaps(locs, loc) || suppressed(content, loc) {
+				continue
+			} else if shannonEntropy(content[loc.Start:loc.End]) < cs.threshold {
+				continue
+			}
+			locs = append(locs, loc)
+		}
+	}
+	return locs
+}
+
+// suppressed returns true if the string is a UUID or a hash judging from its context.
+func suppressed(content []byte, loc Location) bool {
+	s := content[loc.Start:loc.End]
+	if uuidRegexp.Match(s) {
+		return true
+	}
+
+	start := lineStart(content, loc.Start)
+	if hashPrefixRegexp.Match(content[start:loc.Start]) || hashPrefixedRegexp.Match(s) {
+		return true
+	}
+
+	if !isHex(s) || !slices.Contains(hashLengths, len(s)) {
+		return false
+	}
+	lower := bytes.ToLower(content[start:lineEnd(content, loc.End)])
+	for _, kw := range hashKeywords {
+		if bytes.Contains(lower, []byte(kw)) {
+			return true
+		}
+	}
+	return false
+}
+
+// shannonEntropy returns the entropy of the string in bits per character
+func shannonEntropy(s []byte) float64 {
+	var counts [256]int
+	for _, b := range s {
+		counts[b]++
+	}
+
+	var entropy float64
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / float64(len(s))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func overlaps(locs []Location, loc Location) bool {
+	for _, l := range locs {
+		if l.Start < loc.End && loc.Start < l.End {
+			return true
+		}
+	}
+	return false
+}
+
+func isHex(s []byte) bool {
+	return len(bytes.Trim(s, hexChars)) == 0
+}
+
+func lineStart(content []byte, pos int) int {
+	return bytes.LastIndexByte(content[:pos], '\n') + 1
+}
+
+func lineEnd(content []byte, pos int) int {
+	if i := bytes.IndexByte(content[pos:], '\n'); i >= 0 {
+		return pos + i
+	}
+	return len(content)
+}
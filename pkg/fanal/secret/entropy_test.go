@@ -0,0 +1,181 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntropyDetector_FindLocations(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  EntropyConfig
+		content string
+		want    []string
+	}{
+		{
+			name:    "base64",
+			content: `token: "Zx9Qm2Lr8Tv4Kp1Wn7Bc3Hd6Fg0Js5YeAuRiOo"`,
+			want:    []string{"Zx9Qm2Lr8Tv4Kp1Wn7Bc3Hd6Fg0Js5YeAuRiOo"},
+		},
+		{
+			name:    "hex",
+			content: "key=8f3a9c1e7b2d4f6a0c5e9b3d7f1a2c4e",
+			want:    []string{"8f3a9c1e7b2d4f6a0c5e9b3d7f1a2c4e"},
+		},
+		{
+			name:    "low entropy",
+			content: "name: the_quick_brown_fox_jumps_over_the_lazy_dog",
+		},
+		{
+			name:    "hash with keyword",
+			content: "sha1sum: 2c1b9ff8e9a1c8d5a4e3b2f1a0c9d8e7f6a5b4c3",
+		},
+		{
+			name:    "hash with prefix",
+			content: "image@sha256:5c9f4c9ed3bc1b6b1bfd8e5de94da2fa3e5b8b4c6f46fd7ed8e3e34a0c1b0f6a",
+		},
+		{
+			name:    "subresource integrity",
+			content: `"integrity": "sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="`,
+		},
+		{
+			name:    "go.sum",
+			content: "golang.org/x/xerrors v0.0.0-20231012003039-104605ab7028 h1:+cNy6SZtPcJQH3LJVLOSmiC7MMxXNOb3PU/VUEz+EhU=",
+		},
+		{
+			name: "uuid",
+			config: EntropyConfig{
+				Charsets: []EntropyCharset{
+					{
+						Name:      "hex-with-dashes",
+						Chars:     hexChars + "-",
+						Threshold: 3.0,
+					},
+				},
+			},
+			content: "id: 3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		},
+		{
+			name: "custom charset",
+			config: EntropyConfig{
+				MinLength: 10,
+				Charsets: []EntropyCharset{
+					{
+						Name:      "digits",
+						Chars:     "0123456789",
+						Threshold: 3.0,
+					},
+				},
+			},
+			content: "pin: 8302741956, count: 1000000000",
+			want:    []string{"8302741956"},
+		},
+		{
+			name: "too long",
+			config: EntropyConfig{
+				MaxLength: 30,
+			},
+			content: `token: "Zx9Qm2Lr8Tv4Kp1Wn7Bc3Hd6Fg0Js5YeAuRiOo"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.config.validate())
+			d := newEntropyDetector(tt.config)
+
+			var got []string
+			for _, loc := range d.FindLocations([]byte(tt.content)) {
+				got = append(got, tt.content[loc.Start:loc.End])
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEntropyConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  EntropyConfig
+		want    EntropyConfig
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			want: EntropyConfig{
+				MinLength: defaultEntropyMinLength,
+				MaxLength: defaultEntropyMaxLength,
+				Charsets: []EntropyCharset{
+					builtinCharsets["base64"],
+					builtinCharsets["hex"],
+				},
+			},
+		},
+		{
+			name: "built-in charset with threshold",
+			config: EntropyConfig{
+				Charsets: []EntropyCharset{
+					{
+						Name:      "hex",
+						Threshold: 3.5,
+					},
+				},
+			},
+			want: EntropyConfig{
+				MinLength: defaultEntropyMinLength,
+				MaxLength: defaultEntropyMaxLength,
+				Charsets: []EntropyCharset{
+					{
+						Name:      "hex",
+						Chars:     hexChars,
+						Threshold: 3.5,
+					},
+				},
+			},
+		},
+		{
+			name: "custom charset without chars",
+			config: EntropyConfig{
+				Charsets: []EntropyCharset{
+					{
+						Name:      "alnum",
+						Threshold: 4.0,
+					},
+				},
+			},
+			wantErr: `charset "alnum" requires chars`,
+		},
+		{
+			name: "custom charset without threshold",
+			config: EntropyConfig{
+				Charsets: []EntropyCharset{
+					{
+						Name:  "alnum",
+						Chars: "abc",
+					},
+				},
+			},
+			wantErr: `charset "alnum" requires threshold`,
+		},
+		{
+			name: "invalid lengths",
+			config: EntropyConfig{
+				MinLength: 40,
+				MaxLength: 30,
+			},
+			wantErr: "min-length (40) must not be greater than max-length (30)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.validate()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.config)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package secret
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// fingerprint identifies the secret across scans regardless of its location in the file.
+// The secret is hashed first so that the fingerprint can be shared without revealing it.
+func fingerprint(ruleID, filePath string, secret []byte) string {
+	secretHash := sha256.Sum256(secret)
+	// Files in container images have a leading slash
+	filePath = strings.TrimPrefix(filePath, "/")
+	h := sha256.Sum256([]byte(ruleID + ":" + filePath + ":" + hex.EncodeToString(secretHash[:])))
+	return "sha256:" + hex.EncodeToString(h[:])
+}
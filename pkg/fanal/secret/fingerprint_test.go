@@ -0,0 +1,32 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_Scan_Fingerprint(t *testing.T) {
+	scan := func(s Scanner, filePath, content string) string {
+		got := s.Scan(ScanArgs{
+			FilePath: filePath,
+			Content:  []byte(content),
+		})
+		require.Len(t, got.Findings, 1)
+		return got.Findings[0].Fingerprint
+	}
+
+	s := NewScanner(&Config{}, WithFingerprint())
+	want := scan(s, "app/.env", "GITHUB_TOKEN="+testGitHubToken+"\n")
+	assert.Equal(t, fingerprint("github-pat", "app/.env", []byte(testGitHubToken)), want)
+
+	// The fingerprint doesn't depend on the line and the leading slash of image files
+	assert.Equal(t, want, scan(s, "/app/.env", "# comment\n\nexport GITHUB_TOKEN="+testGitHubToken+"\n"))
+
+	// Another file
+	assert.NotEqual(t, want, scan(s, "app/.env.local", "GITHUB_TOKEN="+testGitHubToken+"\n"))
+
+	// Disabled
+	assert.Empty(t, scan(NewScanner(&Config{}), "app/.env", "GITHUB_TOKEN="+testGitHubToken+"\n"))
+}
@@ -0,0 +1,68 @@
+package secret
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Redaction defines how matched secrets appear in findings
+type Redaction string
+
+const (
+	// RedactionFull replaces the whole secret with "*"
+	RedactionFull Redaction = "full"
+	// RedactionPartial keeps the first and last characters of the secret
+	RedactionPartial Redaction = "partial"
+	// RedactionHash replaces the secret with its SHA-256 hash so that findings can be correlated
+	RedactionHash Redaction = "hash"
+)
+
+var Redactions = []Redaction{
+	RedactionFull,
+	RedactionPartial,
+	RedactionHash,
+}
+
+const (
+	// partialRevealLength is the number of characters kept at each end of the secret with the partial redaction
+	partialRevealLength = 4
+	// partialMinLength is the minimum length of secrets redacted partially.
+	// Shorter secrets are fully redacted so that most of the secret stays hidden.
+	partialMinLength = 4 * partialRevealLength
+)
+
+// WithRedaction sets how the matched secrets are redacted. The full redaction is used by default.
+func WithRedaction(r Redaction) ScannerOption {
+	return func(s *Scanner) {
+		s.redaction = r
+	}
+}
+
+// censor redacts the secret at the location of the censored content.
+// The length of the content is kept so that the other locations remain valid.
+func (r Redaction) censor(loc Location, original, censored []byte) []byte {
+	secret := original[loc.Start:loc.End]
+	switch {
+	case r == RedactionPartial && len(secret) >= partialMinLength:
+		hidden := Location{
+			Start: loc.Start + partialRevealLength,
+			End:   loc.End - partialRevealLength,
+		}
+		return censorLocation(hidden, censored)
+	case r == RedactionHash:
+		sum := sha256.Sum256(secret)
+		replacement := []byte(hex.EncodeToString(sum[:]))
+		if len(replacement) < len(secret) {
+			replacement = append(replacement, bytes.Repeat([]byte("*"), len(secret)-len(replacement))...)
+		}
+		// Keep line breaks so that the line numbers of the findings don't change
+		for i, b := range secret {
+			if b != '\n' && b != '\r' {
+				censored[loc.Start+i] = replacement[i]
+			}
+		}
+		return censored
+	}
+	return censorLocation(loc, censored)
+}
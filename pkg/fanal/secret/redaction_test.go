@@ -0,0 +1,98 @@
+package secret
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_Scan_Redaction(t *testing.T) {
+	sum := sha256.Sum256([]byte(testGitHubToken))
+	hash := hex.EncodeToString(sum[:])[:len(testGitHubToken)]
+
+	tests := []struct {
+		name      string
+		redaction Redaction
+		content   string
+		want      string
+	}{
+		{
+			name:      "default",
+			redaction: "",
+			content:   "GITHUB_TOKEN=" + testGitHubToken,
+			want:      "GITHUB_TOKEN=" + strings.Repeat("*", len(testGitHubToken)),
+		},
+		{
+			name:      "full",
+			redaction: RedactionFull,
+			content:   "GITHUB_TOKEN=" + testGitHubToken,
+			want:      "GITHUB_TOKEN=" + strings.Repeat("*", len(testGitHubToken)),
+		},
+		{
+			name:      "partial",
+			redaction: RedactionPartial,
+			content:   "GITHUB_TOKEN=" + testGitHubToken,
+			want:      "GITHUB_TOKEN=" + testGitHubToken[:4] + strings.Repeat("*", len(testGitHubToken)-8) + testGitHubToken[len(testGitHubToken)-4:],
+		},
+		{
+			name:      "hash",
+			redaction: RedactionHash,
+			content:   "GITHUB_TOKEN=" + testGitHubToken,
+			want:      "GITHUB_TOKEN=" + hash,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(&Config{}, WithRedaction(tt.redaction))
+			got := s.Scan(ScanArgs{
+				FilePath: "app/.env",
+				Content:  []byte(tt.content),
+			})
+			require.Len(t, got.Findings, 1)
+			assert.Equal(t, tt.want, got.Findings[0].Match)
+			assert.Equal(t, tt.want, got.Findings[0].Code.Lines[0].Content)
+		})
+	}
+}
+
+func TestRedaction_censor(t *testing.T) {
+	tests := []struct {
+		name      string
+		redaction Redaction
+		content   string
+		loc       Location
+		want      string
+	}{
+		{
+			name:      "partial with a short secret",
+			redaction: RedactionPartial,
+			content:   "password=0123456789",
+			loc:       Location{Start: 9, End: 19},
+			want:      "password=**********",
+		},
+		{
+			name:      "hash with a secret longer than the hash",
+			redaction: RedactionHash,
+			content:   strings.Repeat("a", 70),
+			loc:       Location{Start: 0, End: 70},
+			want:      "6bd5e5034855a11241f0dee8fc72850ffd9955b28347a86428b5fa19119f6ad0******",
+		},
+		{
+			name:      "hash keeps line breaks",
+			redaction: RedactionHash,
+			content:   "ab\ncd",
+			loc:       Location{Start: 0, End: 5},
+			want:      "41\n72",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.redaction.censor(tt.loc, []byte(tt.content), []byte(tt.content))
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"regexp"
 	"slices"
@@ -22,10 +23,30 @@ import (
 var lineSep = []byte{'\n'}
 
 type Scanner struct {
-	logger *log.Logger
+	logger      *log.Logger
+	validator   *validator
+	fingerprint bool
+	redaction   Redaction
 	*Global
 }
 
+type ScannerOption func(*Scanner)
+
+// WithValidation verifies whether the detected secrets are active with low-impact API calls.
+// The default client is used if the client is nil.
+func WithValidation(client *http.Client) ScannerOption {
+	return func(s *Scanner) {
+		s.validator = newValidator(client)
+	}
+}
+
+// WithFingerprint sets fingerprints to findings, which are used to compare findings with a baseline.
+func WithFingerprint() ScannerOption {
+	return func(s *Scanner) {
+		s.fingerprint = true
+	}
+}
+
 type Config struct {
 	// Enable only specified built-in rules. If only one ID is specified, all other rules are disabled.
 	// All the built-in rules are enabled if this field is not specified. It doesn't affect custom rules.
@@ -40,12 +61,17 @@ type Config struct {
 	CustomRules      []Rule       `yaml:"rules"`
 	CustomAllowRules AllowRules   `yaml:"allow-rules"`
 	ExcludeBlock     ExcludeBlock `yaml:"exclude-block"`
+
+	// Detect high-entropy strings in addition to the rules. It is disabled by default.
+	Entropy EntropyConfig `yaml:"entropy"`
 }
 
 type Global struct {
 	Rules        []Rule
 	AllowRules   AllowRules
 	ExcludeBlock ExcludeBlock
+
+	entropy *entropyDetector
 }
 
 // Allow checks if the match is allowed
@@ -303,6 +329,15 @@ func ParseConfig(configPath string) (*Config, error) {
 		config.CustomRules[i].Severity = convertSeverity(logger, config.CustomRules[i].Severity)
 	}
 
+	if config.Entropy.Enabled {
+		if err = config.Entropy.validate(); err != nil {
+			return nil, xerrors.Errorf("entropy config error: %w", err)
+		}
+		if config.Entropy.Severity != "" {
+			config.Entropy.Severity = convertSeverity(logger, config.Entropy.Severity)
+		}
+	}
+
 	return &config, nil
 }
 
@@ -317,7 +352,15 @@ func convertSeverity(logger *log.Logger, severity string) string {
 	}
 }
 
-func NewScanner(config *Config) Scanner {
+func NewScanner(config *Config, opts ...ScannerOption) Scanner {
+	s := newScanner(config)
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+func newScanner(config *Config) Scanner {
 	logger := log.WithPrefix("secret")
 
 	// Use the default rules
@@ -353,12 +396,18 @@ func NewScanner(config *Config) Scanner {
 		return !slices.Contains(config.DisableAllowRuleIDs, v.ID)
 	})
 
+	var entropy *entropyDetector
+	if config.Entropy.Enabled {
+		entropy = newEntropyDetector(config.Entropy)
+	}
+
 	return Scanner{
 		logger: logger,
 		Global: &Global{
 			Rules:        rules,
 			AllowRules:   allowRules,
 			ExcludeBlock: config.ExcludeBlock,
+			entropy:      entropy,
 		},
 	}
 }
@@ -389,6 +438,18 @@ func (s *Scanner) Scan(args ScanArgs) types.Secret {
 	var copyCensored sync.Once
 	var matched []Match
 
+	addMatch := func(rule Rule, loc Location) {
+		matched = append(matched, Match{
+			Rule:     rule,
+			Location: loc,
+		})
+		copyCensored.Do(func() {
+			censored = make([]byte, len(args.Content))
+			copy(censored, args.Content)
+		})
+		censored = s.redaction.censor(loc, args.Content, censored)
+	}
+
 	var findings []types.SecretFinding
 	globalExcludedBlocks := newBlocks(args.Content, s.ExcludeBlock.Regexes)
 	for _, rule := range s.Rules {
@@ -423,20 +484,27 @@ func (s *Scanner) Scan(args ScanArgs) types.Secret {
 			if globalExcludedBlocks.Match(loc) || localExcludedBlocks.Match(loc) {
 				continue
 			}
+			addMatch(rule, loc)
+		}
+	}
 
-			matched = append(matched, Match{
-				Rule:     rule,
-				Location: loc,
-			})
-			copyCensored.Do(func() {
-				censored = make([]byte, len(args.Content))
-				copy(censored, args.Content)
-			})
-			censored = censorLocation(loc, censored)
+	// High-entropy strings are detected only when no rule matches them.
+	// Binary files are skipped as they are full of random bytes.
+	if s.entropy != nil && !args.Binary {
+		matchedLocs := lo.Map(matched, func(m Match, _ int) Location { return m.Location })
+		for _, loc := range s.entropy.FindLocations(args.Content) {
+			if globalExcludedBlocks.Match(loc) || overlaps(matchedLocs, loc) ||
+				s.Allow(string(args.Content[loc.Start:loc.End])) {
+				continue
+			}
+			addMatch(s.entropy.rule, loc)
 		}
 	}
 	for _, match := range matched {
 		finding := toFinding(match.Rule, match.Location, censored)
+		if s.fingerprint {
+			finding.Fingerprint = fingerprint(match.Rule.ID, args.FilePath, args.Content[match.Location.Start:match.Location.End])
+		}
 		// Rewrite unreadable fields for binary files
 		if args.Binary {
 			finding.Match = fmt.Sprintf("Binary file %q matches a rule %q", args.FilePath, match.Rule.Title)
@@ -445,6 +513,11 @@ func (s *Scanner) Scan(args ScanArgs) types.Secret {
 		findings = append(findings, finding)
 	}
 
+	// Secrets in binary files can't be extracted precisely
+	if s.validator != nil && !args.Binary {
+		s.validator.validate(matched, args.Content, findings)
+	}
+
 	if len(findings) == 0 {
 		return types.Secret{}
 	}
@@ -955,6 +955,69 @@ func TestSecretScanner(t *testing.T) {
 		},
 	}
 
+	wantFindingEntropyBase64 := types.SecretFinding{
+		RuleID:    "high-entropy-string",
+		Category:  secret.CategoryEntropy,
+		Title:     "High entropy string",
+		Severity:  "HIGH",
+		StartLine: 1,
+		EndLine:   1,
+		Match:     `api_token = "**************************************"`,
+		Code: types.Code{
+			Lines: []types.Line{
+				{
+					Number:      1,
+					Content:     `api_token = "**************************************"`,
+					Highlighted: `api_token = "**************************************"`,
+					IsCause:     true,
+					FirstCause:  true,
+					LastCause:   true,
+				},
+				{
+					Number:      2,
+					Content:     `request_id = "3f2504e0-4f89-11d3-9a0c-0305e82c3301"`,
+					Highlighted: `request_id = "3f2504e0-4f89-11d3-9a0c-0305e82c3301"`,
+				},
+			},
+		},
+	}
+	wantFindingEntropyHex := types.SecretFinding{
+		RuleID:    "high-entropy-string",
+		Category:  secret.CategoryEntropy,
+		Title:     "High entropy string",
+		Severity:  "HIGH",
+		StartLine: 6,
+		EndLine:   6,
+		Match:     "signing_key: ********************************",
+		Code: types.Code{
+			Lines: []types.Line{
+				{
+					Number:      4,
+					Content:     `integrity = "sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="`,
+					Highlighted: `integrity = "sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="`,
+				},
+				{
+					Number:      5,
+					Content:     `name = "the_quick_brown_fox_jumps_over_the_lazy_dog"`,
+					Highlighted: `name = "the_quick_brown_fox_jumps_over_the_lazy_dog"`,
+				},
+				{
+					Number:      6,
+					Content:     "signing_key: ********************************",
+					Highlighted: "signing_key: ********************************",
+					IsCause:     true,
+					FirstCause:  true,
+					LastCause:   true,
+				},
+				{
+					Number:      7,
+					Content:     "",
+					Highlighted: "",
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name          string
 		configPath    string
@@ -1318,6 +1381,24 @@ func TestSecretScanner(t *testing.T) {
 				Findings: []types.SecretFinding{wantMultiLine},
 			},
 		},
+		{
+			name:          "find high entropy strings",
+			configPath:    filepath.Join("testdata", "config-entropy.yaml"),
+			inputFilePath: filepath.Join("testdata", "entropy.txt"),
+			want: types.Secret{
+				FilePath: filepath.Join("testdata", "entropy.txt"),
+				Findings: []types.SecretFinding{
+					wantFindingEntropyBase64,
+					wantFindingEntropyHex,
+				},
+			},
+		},
+		{
+			name:          "high entropy strings are not detected by default",
+			configPath:    filepath.Join("testdata", "config.yaml"),
+			inputFilePath: filepath.Join("testdata", "entropy.txt"),
+			want:          types.Secret{},
+		},
 		{
 			name:          "long obfuscated js code with secrets",
 			configPath:    filepath.Join("testdata", "skip-test.yaml"),
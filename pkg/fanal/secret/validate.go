@@ -0,0 +1,188 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const validationTimeout = 10 * time.Second
+
+// validateFunc verifies the secret with a low-impact API call.
+// "pairs" are the secrets in the same file verified together, e.g. the access key IDs for the AWS secret access key.
+type validateFunc func(ctx context.Context, client *http.Client, secret string, pairs []string) types.SecretStatus
+
+type secretValidator struct {
+	validate   validateFunc
+	pairRuleID string
+}
+
+var validators = map[string]secretValidator{
+	"aws-access-key-id": {
+		validate:   validateAWSAccessKeyID,
+		pairRuleID: "aws-secret-access-key",
+	},
+	"aws-secret-access-key": {
+		validate:   validateAWSSecretAccessKey,
+		pairRuleID: "aws-access-key-id",
+	},
+	"github-pat":              {validate: validateGitHubToken},
+	"github-oauth":            {validate: validateGitHubToken},
+	"github-app-token":        {validate: validateGitHubToken},
+	"github-fine-grained-pat": {validate: validateGitHubToken},
+	"slack-web-hook":          {validate: validateSlackWebhook},
+}
+
+// validator verifies whether detected secrets are active.
+// The results are kept as the same secret is often found in several files.
+type validator struct {
+	logger  *log.Logger
+	client  *http.Client
+	results sync.Map // rule ID, secret and pairs => types.SecretStatus
+}
+
+func newValidator(client *http.Client) *validator {
+	if client == nil {
+		client = &http.Client{Timeout: validationTimeout}
+	}
+	return &validator{
+		logger: log.WithPrefix("secret"),
+		client: client,
+	}
+}
+
+// validate sets the statuses of the findings supported by validators.
+func (v *validator) validate(matched []Match, content []byte, findings []types.SecretFinding) {
+	secrets := make(map[string][]string)
+	for _, m := range matched {
+		secrets[m.Rule.ID] = append(secrets[m.Rule.ID], string(content[m.Location.Start:m.Location.End]))
+	}
+
+	for i, m := range matched {
+		sv, ok := validators[m.Rule.ID]
+		if !ok {
+			continue
+		}
+		secret := string(content[m.Location.Start:m.Location.End])
+		pairs := secrets[sv.pairRuleID]
+
+		key := strings.Join(append([]string{m.Rule.ID, secret}, pairs...), ":")
+		if status, ok := v.results.Load(key); ok {
+			findings[i].Status = status.(types.SecretStatus)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), validationTimeout)
+		status := sv.validate(ctx, v.client, secret, pairs)
+		cancel()
+
+		v.logger.Debug("Secret validated", log.String("rule_id", m.Rule.ID), log.String("status", string(status)))
+		v.results.Store(key, status)
+		findings[i].Status = status
+	}
+}
+
+// validateGitHubToken gets the authenticated user.
+// Installation tokens of GitHub Apps are not allowed to get the user, but they are still active.
+// cf. https://docs.github.com/en/rest/users/users#get-the-authenticated-user
+func validateGitHubToken(ctx context.Context, client *http.Client, token string, _ []string) types.SecretStatus {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", http.NoBody)
+	if err != nil {
+		return types.SecretStatusUnknown
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	return statusFromResponse(client.Do(req))
+}
+
+// validateSlackWebhook posts an empty message, which is rejected without being sent.
+// Active webhooks respond with "invalid_payload" or "no_text", while the others respond with 403 or 404.
+// cf. https://api.slack.com/messaging/webhooks#handling_errors
+func validateSlackWebhook(ctx context.Context, client *http.Client, webhook string, _ []string) types.SecretStatus {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, strings.NewReader("{}"))
+	if err != nil {
+		return types.SecretStatusUnknown
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return types.SecretStatusUnknown
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		return types.SecretStatusActive
+	case http.StatusForbidden, http.StatusNotFound, http.StatusGone:
+		return types.SecretStatusInactive
+	}
+	return types.SecretStatusUnknown
+}
+
+// validateAWSAccessKeyID calls GetCallerIdentity with each secret access key in the same file.
+// GetCallerIdentity requires no permissions.
+func validateAWSAccessKeyID(ctx context.Context, client *http.Client, keyID string, secretKeys []string) types.SecretStatus {
+	return validateAWSKeyPairs(ctx, client, []string{keyID}, secretKeys)
+}
+
+func validateAWSSecretAccessKey(ctx context.Context, client *http.Client, secretKey string, keyIDs []string) types.SecretStatus {
+	return validateAWSKeyPairs(ctx, client, keyIDs, []string{secretKey})
+}
+
+func validateAWSKeyPairs(ctx context.Context, client *http.Client, keyIDs, secretKeys []string) types.SecretStatus {
+	// The secret access key can't be verified alone
+	if len(keyIDs) == 0 || len(secretKeys) == 0 {
+		return types.SecretStatusUnknown
+	}
+
+	status := types.SecretStatusUnknown
+	for _, keyID := range keyIDs {
+		for _, secretKey := range secretKeys {
+			s := sts.New(sts.Options{
+				Region:      "us-east-1",
+				HTTPClient:  client,
+				Credentials: credentials.NewStaticCredentialsProvider(keyID, secretKey, ""),
+			})
+			_, err := s.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}, func(o *sts.Options) {
+				o.RetryMaxAttempts = 1
+			})
+			if err == nil {
+				return types.SecretStatusActive
+			}
+
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) {
+				switch apiErr.ErrorCode() {
+				case "InvalidClientTokenId", "SignatureDoesNotMatch", "ExpiredToken":
+					status = types.SecretStatusInactive
+				}
+			}
+		}
+	}
+	return status
+}
+
+func statusFromResponse(resp *http.Response, err error) types.SecretStatus {
+	if err != nil {
+		return types.SecretStatusUnknown
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusForbidden:
+		return types.SecretStatusActive
+	case http.StatusUnauthorized:
+		return types.SecretStatusInactive
+	}
+	return types.SecretStatusUnknown
+}
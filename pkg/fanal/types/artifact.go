@@ -116,6 +116,9 @@ type ArtifactInfo struct {
 
 	// HistoryPackages are packages extracted from RUN instructions
 	HistoryPackages Packages `json:",omitempty"`
+
+	// Applications holds applications listed in container image config, such as the Cloud Native Buildpacks BOM label
+	Applications []Application `json:",omitempty"`
 }
 
 // BlobInfo is stored in cache
@@ -146,6 +149,10 @@ type BlobInfo struct {
 	// CustomResources hold analysis results from custom analyzers.
 	// It is for extensibility and not used in OSS.
 	CustomResources []CustomResource `json:",omitempty"`
+
+	// Checkpoint is the last analyzed file when the blob holds the partial result of an interrupted filesystem scan.
+	// It is empty for complete results.
+	Checkpoint string `json:",omitempty"`
 }
 
 // ArtifactDetail represents the analysis result.
@@ -81,6 +81,7 @@ const (
 	GoModule       LangType = "gomod"
 	JavaScript     LangType = "javascript"
 	RustBinary     LangType = "rustbinary"
+	CBinary        LangType = "cbinary"
 	Conan          LangType = "conan"
 	Cocoapods      LangType = "cocoapods"
 	Swift          LangType = "swift"
@@ -89,6 +90,8 @@ const (
 	Bitnami        LangType = "bitnami"
 	Julia          LangType = "julia"
 
+	TerraformProvider LangType = "terraform-provider" // Providers in .terraform.lock.hcl
+
 	K8sUpstream LangType = "kubernetes"
 	EKS         LangType = "eks" // Amazon Elastic Kubernetes Service
 	GKE         LangType = "gke" // Google Kubernetes Engine
@@ -142,6 +145,17 @@ const (
 	Helm                  ConfigType = "helm"
 	Cloud                 ConfigType = "cloud"
 	AzureARM              ConfigType = "azure-arm"
+	Bicep                 ConfigType = "bicep"
+	Pulumi                ConfigType = "pulumi"
+	Ansible               ConfigType = "ansible"
+	GitHubActions         ConfigType = "github-actions"
+	GitLabCI              ConfigType = "gitlab-ci"
+	AzurePipelines        ConfigType = "azure-pipelines"
+	CUE                   ConfigType = "cue"
+	Jsonnet               ConfigType = "jsonnet"
+	Pickle                ConfigType = "pickle"
+	HuggingFace           ConfigType = "huggingface"
+	InstallScript         ConfigType = "install-script"
 )
 
 // Language-specific file names
@@ -149,8 +163,10 @@ const (
 	NuGetPkgsLock   = "packages.lock.json"
 	NuGetPkgsConfig = "packages.config"
 
-	GoMod = "go.mod"
-	GoSum = "go.sum"
+	GoMod     = "go.mod"
+	GoSum     = "go.sum"
+	GoWork    = "go.work"
+	GoWorkSum = "go.work.sum"
 
 	MavenPom = "pom.xml"
 	SbtLock  = "build.sbt.lock"
@@ -170,6 +186,7 @@ const (
 	PoetryLock      = "poetry.lock"
 	UvLock          = "uv.lock"
 
+	Gemfile     = "Gemfile"
 	GemfileLock = "Gemfile.lock"
 
 	CargoLock = "Cargo.lock"
@@ -189,4 +206,6 @@ const (
 
 	JuliaProject  = "Project.toml"
 	JuliaManifest = "Manifest.toml"
+
+	TerraformLock = ".terraform.lock.hcl"
 )
@@ -5,9 +5,11 @@ type HandlerType string
 const (
 	SystemFileFilteringPostHandler HandlerType = "system-file-filter"
 	UnpackagedPostHandler          HandlerType = "unpackaged"
+	BuildpacksPostHandler          HandlerType = "buildpacks"
 
 	// SystemFileFilteringPostHandlerPriority should be higher than other handlers.
 	// Otherwise, other handlers need to process unnecessary files.
 	SystemFileFilteringPostHandlerPriority = 100
 	UnpackagedPostHandlerPriority          = 50
+	BuildpacksPostHandlerPriority          = 70
 )
@@ -75,6 +75,19 @@ type ImageSource string
 // ImageSources is a slice of image sources
 type ImageSources []ImageSource
 
+// SignatureOptions represents the expected signer of OCI artifacts.
+// The public key is used if specified, otherwise the keyless signature is verified against the identity.
+type SignatureOptions struct {
+	// Key is the path to the PEM-encoded public key
+	Key string
+
+	// Identity is a regular expression matching the subject of the signing certificate
+	Identity string
+
+	// OIDCIssuer is the OIDC issuer of the signing certificate
+	OIDCIssuer string
+}
+
 type RegistryOptions struct {
 	// Auth for registries
 	Credentials []Credential
@@ -88,6 +101,18 @@ type RegistryOptions struct {
 	// SSL/TLS
 	Insecure bool
 
+	// CACert holds PEM-encoded CA certificates trusted in addition to the system ones,
+	// e.g. for registries with certificates issued by an internal CA
+	CACert []byte
+
+	// BlobDir is a directory to persist blobs being downloaded so that interrupted downloads can be resumed.
+	// Resuming is disabled if empty.
+	BlobDir string
+
+	// ArtifactSignature holds the options to verify the cosign signatures of OCI artifacts
+	// such as the vulnerability DB. Signatures are not verified if nil.
+	ArtifactSignature *SignatureOptions
+
 	// For internal use. Needed for mTLS authentication.
 	ClientCert []byte
 	ClientKey  []byte
@@ -22,12 +22,22 @@ const (
 	CategoryUnknown      LicenseCategory = "unknown"
 )
 
+// LicenseViolation represents how a license breaks the license policy
+type LicenseViolation string
+
+const (
+	ViolationNotAllowed LicenseViolation = "not-allowed" // not in the allow list
+	ViolationRestricted LicenseViolation = "restricted"
+	ViolationForbidden  LicenseViolation = "forbidden"
+)
+
 type LicenseFile struct {
-	Type     LicenseType
-	FilePath string
-	PkgName  string
-	Findings LicenseFindings
-	Layer    Layer `json:",omitempty"`
+	Type       LicenseType
+	FilePath   string
+	PkgName    string
+	Findings   LicenseFindings
+	Copyrights []string `json:",omitempty"` // copyright statements found in the file
+	Layer      Layer    `json:",omitempty"`
 }
 
 type LicenseFindings []LicenseFinding
@@ -165,8 +165,11 @@ type ExternalRef struct {
 type RefType string
 
 const (
-	RefVCS   RefType = "vcs"
-	RefOther RefType = "other"
+	RefVCS          RefType = "vcs"
+	RefIssueTracker RefType = "issue-tracker"
+	RefDistribution RefType = "distribution"
+	RefWebsite      RefType = "website"
+	RefOther        RefType = "other"
 )
 
 // BuildInfo represents information under /root/buildinfo in RHEL
@@ -191,7 +194,7 @@ type Package struct {
 	SrcEpoch           int           `json:",omitempty"`
 	Licenses           []string      `json:",omitempty"`
 	Maintainer         string        `json:",omitempty"`
-	ExternalReferences []ExternalRef `json:"-" hash:"ignore"`
+	ExternalReferences []ExternalRef `json:",omitempty" hash:"ignore"`
 
 	Modularitylabel string     `json:",omitempty"` // only for Red Hat based distributions
 	BuildInfo       *BuildInfo `json:",omitempty"` // only for Red Hat
@@ -216,6 +219,11 @@ type Package struct {
 
 	// Files installed by the package
 	InstalledFiles []string `json:",omitempty"`
+
+	// Unresolved is true when the version is not resolved, e.g. dependencies in Gemfile without Gemfile.lock.
+	// Version is empty in that case, and VersionConstraint holds the declared requirement.
+	Unresolved        bool   `json:",omitempty"`
+	VersionConstraint string `json:",omitempty"`
 }
 
 func (pkg *Package) Empty() bool {
@@ -2,6 +2,15 @@ package types
 
 type SecretRuleCategory string
 
+// SecretStatus represents whether the secret is active, verified with the issuer
+type SecretStatus string
+
+const (
+	SecretStatusActive   SecretStatus = "Active"
+	SecretStatusInactive SecretStatus = "Inactive"
+	SecretStatusUnknown  SecretStatus = "Unknown"
+)
+
 type Secret struct {
 	FilePath string
 	Findings []SecretFinding
@@ -16,5 +25,10 @@ type SecretFinding struct {
 	EndLine   int
 	Code      Code
 	Match     string
-	Layer     Layer `json:",omitempty"`
+	Status    SecretStatus `json:",omitempty"`
+
+	// Fingerprint identifies the secret regardless of the line for comparison with a baseline
+	Fingerprint string `json:",omitempty"`
+
+	Layer Layer `json:",omitempty"`
 }
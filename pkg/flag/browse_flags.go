@@ -0,0 +1,52 @@
+package flag
+
+var (
+	BrowseExportPathFlag = Flag[string]{
+		Name:       "export-path",
+		ConfigName: "browse.export-path",
+		Default:    "trivy-export.json",
+		Usage:      "path to export the selected findings as a JSON report",
+	}
+)
+
+// BrowseFlagGroup composes flags for the interactive report browser
+type BrowseFlagGroup struct {
+	ExportPath *Flag[string]
+}
+
+type BrowseOptions struct {
+	BrowseReportPath string
+	ExportPath       string
+}
+
+func NewBrowseFlagGroup() *BrowseFlagGroup {
+	return &BrowseFlagGroup{
+		ExportPath: BrowseExportPathFlag.Clone(),
+	}
+}
+
+func (f *BrowseFlagGroup) Name() string {
+	return "Browse"
+}
+
+func (f *BrowseFlagGroup) Flags() []Flagger {
+	return []Flagger{
+		f.ExportPath,
+	}
+}
+
+func (f *BrowseFlagGroup) ToOptions(args []string) (BrowseOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return BrowseOptions{}, err
+	}
+
+	var reportPath string
+	if len(args) == 1 {
+		reportPath = args[0]
+	}
+
+	return BrowseOptions{
+		BrowseReportPath: reportPath,
+		ExportPath:       f.ExportPath.Value(),
+	}, nil
+}
@@ -2,6 +2,9 @@ package flag
 
 import (
 	"time"
+
+	"github.com/docker/go-units"
+	"golang.org/x/xerrors"
 )
 
 // e.g. config yaml:
@@ -9,10 +12,16 @@ import (
 //	cache:
 //	  clear: true
 //	  backend: "redis://localhost:6379"
+//	  ttl: 168h
+//	  max-size: 10GB
 //	redis:
 //	  ca: ca-cert.pem
 //	  cert: cert.pem
 //	  key: key.pem
+//	s3:
+//	  sse: aws:kms
+//	  sse-kms-key-id: alias/trivy
+//	  concurrency: 10
 var (
 	// Deprecated
 	ClearCacheFlag = Flag[bool]{
@@ -25,12 +34,17 @@ var (
 		Name:       "cache-backend",
 		ConfigName: "cache.backend",
 		Default:    "fs",
-		Usage:      "[EXPERIMENTAL] cache backend (e.g. redis://localhost:6379)",
+		Usage:      "[EXPERIMENTAL] cache backend (e.g. redis://localhost:6379, s3://bucket/prefix)",
 	}
 	CacheTTLFlag = Flag[time.Duration]{
 		Name:       "cache-ttl",
 		ConfigName: "cache.ttl",
-		Usage:      "cache TTL when using redis as cache backend",
+		Usage:      "cache TTL when using redis, postgres or fs as cache backend",
+	}
+	CacheMaxSizeFlag = Flag[string]{
+		Name:       "cache-max-size",
+		ConfigName: "cache.max-size",
+		Usage:      "maximum size of the scan cache when using fs as cache backend, removing the least recently used entries beyond it (e.g. 10GB)",
 	}
 	RedisTLSFlag = Flag[bool]{
 		Name:       "redis-tls",
@@ -52,6 +66,23 @@ var (
 		ConfigName: "cache.redis.key",
 		Usage:      "redis key file location, if using redis as cache backend",
 	}
+	S3SSEFlag = Flag[string]{
+		Name:       "s3-sse",
+		ConfigName: "cache.s3.sse",
+		Values:     []string{"AES256", "aws:kms", "aws:kms:dsse"},
+		Usage:      "server-side encryption of the cached objects, if using S3 as cache backend",
+	}
+	S3SSEKMSKeyIDFlag = Flag[string]{
+		Name:       "s3-sse-kms-key-id",
+		ConfigName: "cache.s3.sse-kms-key-id",
+		Usage:      "KMS key ID for the aws:kms server-side encryption, if using S3 as cache backend",
+	}
+	S3ConcurrencyFlag = Flag[int]{
+		Name:       "s3-concurrency",
+		ConfigName: "cache.s3.concurrency",
+		Default:    10,
+		Usage:      "number of concurrent requests, if using S3 as cache backend",
+	}
 )
 
 // CacheFlagGroup composes common printer flag structs used for commands requiring cache logic.
@@ -59,11 +90,16 @@ type CacheFlagGroup struct {
 	ClearCache   *Flag[bool]
 	CacheBackend *Flag[string]
 	CacheTTL     *Flag[time.Duration]
+	CacheMaxSize *Flag[string]
 
 	RedisTLS    *Flag[bool]
 	RedisCACert *Flag[string]
 	RedisCert   *Flag[string]
 	RedisKey    *Flag[string]
+
+	S3SSE         *Flag[string]
+	S3SSEKMSKeyID *Flag[string]
+	S3Concurrency *Flag[int]
 }
 
 type CacheOptions struct {
@@ -71,10 +107,15 @@ type CacheOptions struct {
 
 	CacheBackend string
 	CacheTTL     time.Duration
+	CacheMaxSize int64
 	RedisTLS     bool
 	RedisCACert  string
 	RedisCert    string
 	RedisKey     string
+
+	S3SSE         string
+	S3SSEKMSKeyID string
+	S3Concurrency int
 }
 
 // NewCacheFlagGroup returns a default CacheFlagGroup
@@ -83,10 +124,15 @@ func NewCacheFlagGroup() *CacheFlagGroup {
 		ClearCache:   ClearCacheFlag.Clone(),
 		CacheBackend: CacheBackendFlag.Clone(),
 		CacheTTL:     CacheTTLFlag.Clone(),
+		CacheMaxSize: CacheMaxSizeFlag.Clone(),
 		RedisTLS:     RedisTLSFlag.Clone(),
 		RedisCACert:  RedisCACertFlag.Clone(),
 		RedisCert:    RedisCertFlag.Clone(),
 		RedisKey:     RedisKeyFlag.Clone(),
+
+		S3SSE:         S3SSEFlag.Clone(),
+		S3SSEKMSKeyID: S3SSEKMSKeyIDFlag.Clone(),
+		S3Concurrency: S3ConcurrencyFlag.Clone(),
 	}
 }
 
@@ -99,10 +145,14 @@ func (fg *CacheFlagGroup) Flags() []Flagger {
 		fg.ClearCache,
 		fg.CacheBackend,
 		fg.CacheTTL,
+		fg.CacheMaxSize,
 		fg.RedisTLS,
 		fg.RedisCACert,
 		fg.RedisCert,
 		fg.RedisKey,
+		fg.S3SSE,
+		fg.S3SSEKMSKeyID,
+		fg.S3Concurrency,
 	}
 }
 
@@ -111,12 +161,26 @@ func (fg *CacheFlagGroup) ToOptions() (CacheOptions, error) {
 		return CacheOptions{}, err
 	}
 
+	var maxSize int64
+	if value := fg.CacheMaxSize.Value(); value != "" {
+		parsedSize, err := units.FromHumanSize(value)
+		if err != nil {
+			return CacheOptions{}, xerrors.Errorf("invalid max cache size %q: %w", value, err)
+		}
+		maxSize = parsedSize
+	}
+
 	return CacheOptions{
 		CacheBackend: fg.CacheBackend.Value(),
 		CacheTTL:     fg.CacheTTL.Value(),
+		CacheMaxSize: maxSize,
 		RedisTLS:     fg.RedisTLS.Value(),
 		RedisCACert:  fg.RedisCACert.Value(),
 		RedisCert:    fg.RedisCert.Value(),
 		RedisKey:     fg.RedisKey.Value(),
+
+		S3SSE:         fg.S3SSE.Value(),
+		S3SSEKMSKeyID: fg.S3SSEKMSKeyID.Value(),
+		S3Concurrency: fg.S3Concurrency.Value(),
 	}, nil
 }
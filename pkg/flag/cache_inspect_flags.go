@@ -0,0 +1,50 @@
+package flag
+
+var (
+	CacheInspectDeleteFlag = Flag[bool]{
+		Name:       "delete",
+		ConfigName: "cache.inspect.delete",
+		Usage:      "delete the matching entries so that they are analyzed again on the next scan",
+	}
+)
+
+// CacheInspectFlagGroup composes flags for inspecting the scan cache
+type CacheInspectFlagGroup struct {
+	Delete *Flag[bool]
+}
+
+type CacheInspectOptions struct {
+	CacheInspectDigest string
+	CacheInspectDelete bool
+}
+
+func NewCacheInspectFlagGroup() *CacheInspectFlagGroup {
+	return &CacheInspectFlagGroup{
+		Delete: CacheInspectDeleteFlag.Clone(),
+	}
+}
+
+func (f *CacheInspectFlagGroup) Name() string {
+	return "Cache Inspect"
+}
+
+func (f *CacheInspectFlagGroup) Flags() []Flagger {
+	return []Flagger{
+		f.Delete,
+	}
+}
+
+func (f *CacheInspectFlagGroup) ToOptions(args []string) (CacheInspectOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return CacheInspectOptions{}, err
+	}
+
+	var digest string
+	if len(args) == 1 {
+		digest = args[0]
+	}
+	return CacheInspectOptions{
+		CacheInspectDigest: digest,
+		CacheInspectDelete: f.Delete.Value(),
+	}, nil
+}
@@ -0,0 +1,69 @@
+package flag
+
+import "time"
+
+var (
+	CheckTestDataFlag = Flag[[]string]{
+		Name:       "data",
+		ConfigName: "check.test.data",
+		Usage:      "specify paths from which data for the Rego checks will be recursively loaded",
+	}
+	CheckTestRunFlag = Flag[string]{
+		Name:       "run",
+		ConfigName: "check.test.run",
+		Usage:      "run only the tests matching the regular expression",
+	}
+	CheckTestTimeoutFlag = Flag[time.Duration]{
+		Name:       "test-timeout",
+		ConfigName: "check.test.timeout",
+		Default:    5 * time.Second,
+		Usage:      "time limit of each test",
+	}
+)
+
+// CheckTestFlagGroup composes flags for testing custom Rego checks
+type CheckTestFlagGroup struct {
+	Data    *Flag[[]string]
+	Run     *Flag[string]
+	Timeout *Flag[time.Duration]
+}
+
+type CheckTestOptions struct {
+	CheckTestPaths     []string
+	CheckTestDataPaths []string
+	CheckTestRun       string
+	CheckTestTimeout   time.Duration
+}
+
+func NewCheckTestFlagGroup() *CheckTestFlagGroup {
+	return &CheckTestFlagGroup{
+		Data:    CheckTestDataFlag.Clone(),
+		Run:     CheckTestRunFlag.Clone(),
+		Timeout: CheckTestTimeoutFlag.Clone(),
+	}
+}
+
+func (f *CheckTestFlagGroup) Name() string {
+	return "Check Test"
+}
+
+func (f *CheckTestFlagGroup) Flags() []Flagger {
+	return []Flagger{
+		f.Data,
+		f.Run,
+		f.Timeout,
+	}
+}
+
+func (f *CheckTestFlagGroup) ToOptions(args []string) (CheckTestOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return CheckTestOptions{}, err
+	}
+
+	return CheckTestOptions{
+		CheckTestPaths:     args,
+		CheckTestDataPaths: f.Data.Value(),
+		CheckTestRun:       f.Run.Value(),
+		CheckTestTimeout:   f.Timeout.Value(),
+	}, nil
+}
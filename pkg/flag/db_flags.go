@@ -2,6 +2,8 @@ package flag
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"golang.org/x/xerrors"
@@ -62,6 +64,43 @@ var (
 		Default:    []string{javadb.DefaultGCRRepository, javadb.DefaultGHCRRepository},
 		Usage:      "OCI repository(ies) to retrieve trivy-java-db in order of priority",
 	}
+	DBURLFlag = Flag[string]{
+		Name:       "db-url",
+		ConfigName: "db.url",
+		Usage:      "HTTP(S) URL of a gzipped tarball to retrieve trivy-db from, instead of the OCI repositories",
+	}
+	DBDeltaFlag = Flag[bool]{
+		Name:       "db-delta",
+		ConfigName: "db.delta",
+		Usage:      "[EXPERIMENTAL] download only the changes since the local trivy-db if the DB repository publishes them (not available for the default repositories)",
+	}
+	JavaDBURLFlag = Flag[string]{
+		Name:       "java-db-url",
+		ConfigName: "db.java-url",
+		Usage:      "HTTP(S) URL of a gzipped tarball to retrieve trivy-java-db from, instead of the OCI repositories",
+	}
+	VerifyDBSignatureFlag = Flag[bool]{
+		Name:       "verify-db-signature",
+		ConfigName: "db.verify-signature",
+		Usage:      "verify the cosign signatures of trivy-db, trivy-java-db and the checks bundle before use",
+	}
+	DBSignatureKeyFlag = Flag[string]{
+		Name:       "db-signature-key",
+		ConfigName: "db.signature-key",
+		Usage:      "path to the public key to verify the signatures with, instead of the keyless signing identity",
+	}
+	DBSignatureIdentityFlag = Flag[string]{
+		Name:       "db-signature-identity",
+		ConfigName: "db.signature-identity",
+		Default:    `^https://github\.com/aquasecurity/`,
+		Usage:      "regular expression matching the identity of the keyless signatures",
+	}
+	DBSignatureOIDCIssuerFlag = Flag[string]{
+		Name:       "db-signature-oidc-issuer",
+		ConfigName: "db.signature-oidc-issuer",
+		Default:    "https://token.actions.githubusercontent.com",
+		Usage:      "OIDC issuer of the keyless signatures",
+	}
 	LightFlag = Flag[bool]{
 		Name:       "light",
 		ConfigName: "db.light",
@@ -80,6 +119,13 @@ type DBFlagGroup struct {
 	NoProgress         *Flag[bool]
 	DBRepositories     *Flag[[]string]
 	JavaDBRepositories *Flag[[]string]
+	DBURL              *Flag[string]
+	DBDelta            *Flag[bool]
+	JavaDBURL          *Flag[string]
+	VerifySignature    *Flag[bool]
+	SignatureKey       *Flag[string]
+	SignatureIdentity  *Flag[string]
+	SignatureIssuer    *Flag[string]
 	Light              *Flag[bool] // deprecated
 }
 
@@ -92,6 +138,15 @@ type DBOptions struct {
 	NoProgress         bool
 	DBRepositories     []name.Reference
 	JavaDBRepositories []name.Reference
+	DBURL              string
+	DBDelta            bool
+	JavaDBURL          string
+
+	// Signature verification of the databases and the checks bundle
+	VerifyDBSignature     bool
+	DBSignatureKey        string
+	DBSignatureIdentity   string
+	DBSignatureOIDCIssuer string
 }
 
 // NewDBFlagGroup returns a default DBFlagGroup
@@ -106,6 +161,13 @@ func NewDBFlagGroup() *DBFlagGroup {
 		NoProgress:         NoProgressFlag.Clone(),
 		DBRepositories:     DBRepositoryFlag.Clone(),
 		JavaDBRepositories: JavaDBRepositoryFlag.Clone(),
+		DBURL:              DBURLFlag.Clone(),
+		DBDelta:            DBDeltaFlag.Clone(),
+		JavaDBURL:          JavaDBURLFlag.Clone(),
+		VerifySignature:    VerifyDBSignatureFlag.Clone(),
+		SignatureKey:       DBSignatureKeyFlag.Clone(),
+		SignatureIdentity:  DBSignatureIdentityFlag.Clone(),
+		SignatureIssuer:    DBSignatureOIDCIssuerFlag.Clone(),
 	}
 }
 
@@ -123,6 +185,13 @@ func (f *DBFlagGroup) Flags() []Flagger {
 		f.NoProgress,
 		f.DBRepositories,
 		f.JavaDBRepositories,
+		f.DBURL,
+		f.DBDelta,
+		f.JavaDBURL,
+		f.VerifySignature,
+		f.SignatureKey,
+		f.SignatureIdentity,
+		f.SignatureIssuer,
 		f.Light,
 	}
 }
@@ -164,6 +233,28 @@ func (f *DBFlagGroup) ToOptions() (DBOptions, error) {
 		javaDBRepositories = append(javaDBRepositories, ref)
 	}
 
+	dbURL, javaDBURL := f.DBURL.Value(), f.JavaDBURL.Value()
+	for _, u := range []struct{ flag, value string }{
+		{"--db-url", dbURL},
+		{"--java-db-url", javaDBURL},
+	} {
+		if u.value == "" {
+			continue
+		}
+		if err := validateHTTPURL(u.value); err != nil {
+			return DBOptions{}, xerrors.Errorf("invalid %s: %w", u.flag, err)
+		}
+		// Signatures are attached to the OCI artifacts
+		if f.VerifySignature.Value() {
+			return DBOptions{}, xerrors.Errorf("--verify-db-signature and %s options can not be specified both", u.flag)
+		}
+	}
+
+	signatureIdentity := f.SignatureIdentity.Value()
+	if _, err := regexp.Compile(signatureIdentity); err != nil {
+		return DBOptions{}, xerrors.Errorf("invalid --db-signature-identity: %w", err)
+	}
+
 	return DBOptions{
 		Reset:              f.Reset.Value(),
 		DownloadDBOnly:     downloadDBOnly,
@@ -173,9 +264,29 @@ func (f *DBFlagGroup) ToOptions() (DBOptions, error) {
 		NoProgress:         f.NoProgress.Value(),
 		DBRepositories:     dbRepositories,
 		JavaDBRepositories: javaDBRepositories,
+		DBURL:              dbURL,
+		DBDelta:            f.DBDelta.Value(),
+		JavaDBURL:          javaDBURL,
+
+		VerifyDBSignature:     f.VerifySignature.Value(),
+		DBSignatureKey:        f.SignatureKey.Value(),
+		DBSignatureIdentity:   signatureIdentity,
+		DBSignatureOIDCIssuer: f.SignatureIssuer.Value(),
 	}, nil
 }
 
+func validateHTTPURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return xerrors.Errorf("unsupported scheme %q, must be http or https", u.Scheme)
+	} else if u.Host == "" {
+		return xerrors.New("host is empty")
+	}
+	return nil
+}
+
 func parseRepository(repo string, dbSchemaVersion int) (name.Reference, error) {
 	dbRepository, err := name.ParseReference(repo, name.WithDefaultTag(""))
 	if err != nil {
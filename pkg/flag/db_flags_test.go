@@ -14,11 +14,14 @@ import (
 
 func TestDBFlagGroup_ToOptions(t *testing.T) {
 	type fields struct {
-		SkipDBUpdate     bool
-		DownloadDBOnly   bool
-		Light            bool
-		DBRepository     []string
-		JavaDBRepository []string
+		SkipDBUpdate      bool
+		DownloadDBOnly    bool
+		Light             bool
+		DBRepository      []string
+		JavaDBRepository  []string
+		SignatureIdentity string
+		DBURL             string
+		VerifySignature   bool
 	}
 	tests := []struct {
 		name     string
@@ -63,6 +66,37 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 			},
 			wantErr: "invalid DB repository",
 		},
+		{
+			name: "invalid signature identity",
+			fields: fields{
+				SignatureIdentity: "[",
+			},
+			wantErr: "invalid --db-signature-identity",
+		},
+		{
+			name: "db url",
+			fields: fields{
+				DBURL: "https://example.com/trivy-db.tar.gz",
+			},
+			want: flag.DBOptions{
+				DBURL: "https://example.com/trivy-db.tar.gz",
+			},
+		},
+		{
+			name: "invalid db url",
+			fields: fields{
+				DBURL: "ftp://example.com/trivy-db.tar.gz",
+			},
+			wantErr: `invalid --db-url: unsupported scheme "ftp"`,
+		},
+		{
+			name: "db url with signature verification",
+			fields: fields{
+				DBURL:           "https://example.com/trivy-db.tar.gz",
+				VerifySignature: true,
+			},
+			wantErr: "--verify-db-signature and --db-url options can not be specified both",
+		},
 		{
 			name: "multiple repos",
 			fields: fields{
@@ -93,6 +127,9 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 			viper.Set(flag.DownloadDBOnlyFlag.ConfigName, tt.fields.DownloadDBOnly)
 			viper.Set(flag.DBRepositoryFlag.ConfigName, tt.fields.DBRepository)
 			viper.Set(flag.JavaDBRepositoryFlag.ConfigName, tt.fields.JavaDBRepository)
+			viper.Set(flag.DBSignatureIdentityFlag.ConfigName, tt.fields.SignatureIdentity)
+			viper.Set(flag.DBURLFlag.ConfigName, tt.fields.DBURL)
+			viper.Set(flag.VerifyDBSignatureFlag.ConfigName, tt.fields.VerifySignature)
 
 			// Assert options
 			f := &flag.DBFlagGroup{
@@ -100,6 +137,9 @@ func TestDBFlagGroup_ToOptions(t *testing.T) {
 				SkipDBUpdate:       flag.SkipDBUpdateFlag.Clone(),
 				DBRepositories:     flag.DBRepositoryFlag.Clone(),
 				JavaDBRepositories: flag.JavaDBRepositoryFlag.Clone(),
+				SignatureIdentity:  flag.DBSignatureIdentityFlag.Clone(),
+				DBURL:              flag.DBURLFlag.Clone(),
+				VerifySignature:    flag.VerifyDBSignatureFlag.Clone(),
 			}
 			got, err := f.ToOptions()
 			if tt.wantErr != "" {
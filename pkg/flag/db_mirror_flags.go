@@ -0,0 +1,100 @@
+package flag
+
+import (
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+var (
+	DBMirrorDirFlag = Flag[string]{
+		Name:       "mirror-dir",
+		ConfigName: "db.mirror.dir",
+		Usage:      "directory storing the mirrored databases and checks bundle as an OCI image layout (default: \"$CACHE_DIR/mirror\")",
+	}
+	DBMirrorListenFlag = Flag[string]{
+		Name:       "listen",
+		ConfigName: "db.mirror.listen",
+		Default:    "localhost:4955",
+		Usage:      "listen address of the mirror",
+	}
+	DBMirrorSyncIntervalFlag = Flag[time.Duration]{
+		Name:       "sync-interval",
+		ConfigName: "db.mirror.sync-interval",
+		Default:    6 * time.Hour,
+		Usage:      "interval of syncing with the upstream repositories (0 to disable syncing, e.g. in air-gapped environments)",
+	}
+	DBMirrorTLSCertFlag = Flag[string]{
+		Name:       "tls-cert",
+		ConfigName: "db.mirror.tls-cert",
+		Usage:      "certificate file to serve the mirror over HTTPS",
+	}
+	DBMirrorTLSKeyFlag = Flag[string]{
+		Name:       "tls-key",
+		ConfigName: "db.mirror.tls-key",
+		Usage:      "private key file to serve the mirror over HTTPS",
+	}
+)
+
+// DBMirrorFlagGroup composes flags for serving a mirror of the databases
+type DBMirrorFlagGroup struct {
+	Dir          *Flag[string]
+	Listen       *Flag[string]
+	SyncInterval *Flag[time.Duration]
+	TLSCert      *Flag[string]
+	TLSKey       *Flag[string]
+}
+
+type DBMirrorOptions struct {
+	DBMirrorDir          string
+	DBMirrorListen       string
+	DBMirrorSyncInterval time.Duration
+	DBMirrorTLSCert      string
+	DBMirrorTLSKey       string
+}
+
+func NewDBMirrorFlagGroup() *DBMirrorFlagGroup {
+	return &DBMirrorFlagGroup{
+		Dir:          DBMirrorDirFlag.Clone(),
+		Listen:       DBMirrorListenFlag.Clone(),
+		SyncInterval: DBMirrorSyncIntervalFlag.Clone(),
+		TLSCert:      DBMirrorTLSCertFlag.Clone(),
+		TLSKey:       DBMirrorTLSKeyFlag.Clone(),
+	}
+}
+
+func (f *DBMirrorFlagGroup) Name() string {
+	return "DB Mirror"
+}
+
+func (f *DBMirrorFlagGroup) Flags() []Flagger {
+	return []Flagger{
+		f.Dir,
+		f.Listen,
+		f.SyncInterval,
+		f.TLSCert,
+		f.TLSKey,
+	}
+}
+
+func (f *DBMirrorFlagGroup) ToOptions() (DBMirrorOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return DBMirrorOptions{}, err
+	}
+
+	tlsCert, tlsKey := f.TLSCert.Value(), f.TLSKey.Value()
+	if (tlsCert == "") != (tlsKey == "") {
+		return DBMirrorOptions{}, xerrors.New("--tls-cert and --tls-key must be specified together")
+	}
+	if f.SyncInterval.Value() < 0 {
+		return DBMirrorOptions{}, xerrors.New("--sync-interval must not be negative")
+	}
+
+	return DBMirrorOptions{
+		DBMirrorDir:          f.Dir.Value(),
+		DBMirrorListen:       f.Listen.Value(),
+		DBMirrorSyncInterval: f.SyncInterval.Value(),
+		DBMirrorTLSCert:      tlsCert,
+		DBMirrorTLSKey:       tlsKey,
+	}, nil
+}
@@ -19,6 +19,12 @@ var (
 		Usage:      "config path",
 		Persistent: true,
 	}
+	BaseConfigFlag = Flag[string]{
+		Name:       "base-config",
+		ConfigName: "base-config",
+		Usage:      "URL of the base config the config file overrides (https:// or oci://)",
+		Persistent: true,
+	}
 	ShowVersionFlag = Flag[bool]{
 		Name:       "version",
 		ConfigName: "version",
@@ -71,6 +77,7 @@ var (
 // GlobalFlagGroup composes global flags
 type GlobalFlagGroup struct {
 	ConfigFile            *Flag[string]
+	BaseConfig            *Flag[string]
 	ShowVersion           *Flag[bool] // spf13/cobra can't override the logic of version printing like VersionPrinter in urfave/cli. -v needs to be defined ourselves.
 	Quiet                 *Flag[bool]
 	Debug                 *Flag[bool]
@@ -83,6 +90,7 @@ type GlobalFlagGroup struct {
 // GlobalOptions defines flags and other configuration parameters for all the subcommands
 type GlobalOptions struct {
 	ConfigFile            string
+	BaseConfig            string
 	ShowVersion           bool
 	Quiet                 bool
 	Debug                 bool
@@ -95,6 +103,7 @@ type GlobalOptions struct {
 func NewGlobalFlagGroup() *GlobalFlagGroup {
 	return &GlobalFlagGroup{
 		ConfigFile:            ConfigFileFlag.Clone(),
+		BaseConfig:            BaseConfigFlag.Clone(),
 		ShowVersion:           ShowVersionFlag.Clone(),
 		Quiet:                 QuietFlag.Clone(),
 		Debug:                 DebugFlag.Clone(),
@@ -112,6 +121,7 @@ func (f *GlobalFlagGroup) Name() string {
 func (f *GlobalFlagGroup) Flags() []Flagger {
 	return []Flagger{
 		f.ConfigFile,
+		f.BaseConfig,
 		f.ShowVersion,
 		f.Quiet,
 		f.Debug,
@@ -149,6 +159,7 @@ func (f *GlobalFlagGroup) ToOptions() (GlobalOptions, error) {
 
 	return GlobalOptions{
 		ConfigFile:            f.ConfigFile.Value(),
+		BaseConfig:            f.BaseConfig.Value(),
 		ShowVersion:           f.ShowVersion.Value(),
 		Quiet:                 f.Quiet.Value(),
 		Debug:                 f.Debug.Value(),
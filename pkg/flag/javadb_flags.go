@@ -0,0 +1,49 @@
+package flag
+
+var (
+	JavaDBGroupIDsFlag = Flag[[]string]{
+		Name:       "group-ids",
+		ConfigName: "java-db.export.group-ids",
+		Usage:      "export only indexes of artifacts with the group IDs and their sub-groups (e.g. 'org.apache' matches 'org.apache.commons')",
+	}
+)
+
+// JavaDBFlagGroup composes flags for exporting and importing the Java DB
+type JavaDBFlagGroup struct {
+	GroupIDs *Flag[[]string]
+}
+
+type JavaDBOptions struct {
+	JavaDBArchivePath string
+	JavaDBGroupIDs    []string
+}
+
+func NewJavaDBFlagGroup() *JavaDBFlagGroup {
+	return &JavaDBFlagGroup{
+		GroupIDs: JavaDBGroupIDsFlag.Clone(),
+	}
+}
+
+func (f *JavaDBFlagGroup) Name() string {
+	return "Java DB"
+}
+
+func (f *JavaDBFlagGroup) Flags() []Flagger {
+	return []Flagger{f.GroupIDs}
+}
+
+func (f *JavaDBFlagGroup) ToOptions(args []string) (JavaDBOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return JavaDBOptions{}, err
+	}
+
+	var archivePath string
+	if len(args) == 1 {
+		archivePath = args[0]
+	}
+
+	return JavaDBOptions{
+		JavaDBArchivePath: archivePath,
+		JavaDBGroupIDs:    f.GroupIDs.Value(),
+	}, nil
+}
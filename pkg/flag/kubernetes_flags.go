@@ -91,6 +91,11 @@ var (
 		Default:    10,
 		Usage:      "specify the maximum burst for throttle",
 	}
+	DriftFlag = Flag[bool]{
+		Name:       "drift",
+		ConfigName: "kubernetes.drift",
+		Usage:      "[EXPERIMENTAL] report only compliance controls whose status changed since the last scan with '--compliance'",
+	}
 )
 
 type K8sFlagGroup struct {
@@ -109,6 +114,7 @@ type K8sFlagGroup struct {
 	IncludeNamespaces      *Flag[[]string]
 	QPS                    *Flag[float64]
 	Burst                  *Flag[int]
+	Drift                  *Flag[bool]
 }
 
 type K8sOptions struct {
@@ -127,6 +133,7 @@ type K8sOptions struct {
 	QPS                    float32
 	SkipImages             bool
 	Burst                  int
+	Drift                  bool
 }
 
 func NewK8sFlagGroup() *K8sFlagGroup {
@@ -146,6 +153,7 @@ func NewK8sFlagGroup() *K8sFlagGroup {
 		QPS:                    QPS.Clone(),
 		SkipImages:             SkipImages.Clone(),
 		Burst:                  Burst.Clone(),
+		Drift:                  DriftFlag.Clone(),
 	}
 }
 
@@ -170,6 +178,7 @@ func (f *K8sFlagGroup) Flags() []Flagger {
 		f.QPS,
 		f.SkipImages,
 		f.Burst,
+		f.Drift,
 	}
 }
 
@@ -215,6 +224,7 @@ func (f *K8sFlagGroup) ToOptions() (K8sOptions, error) {
 		ExcludeNamespaces:      f.ExcludeNamespaces.Value(),
 		IncludeNamespaces:      f.IncludeNamespaces.Value(),
 		Burst:                  f.Burst.Value(),
+		Drift:                  f.Drift.Value(),
 	}, nil
 }
 
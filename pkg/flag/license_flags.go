@@ -1,8 +1,11 @@
 package flag
 
 import (
+	"github.com/aquasecurity/trivy/pkg/fanal/analyzer/language/golang/license"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
 	"github.com/aquasecurity/trivy/pkg/licensing/expression"
+	xstrings "github.com/aquasecurity/trivy/pkg/x/strings"
 )
 
 var (
@@ -59,6 +62,33 @@ var (
 		Default:    expression.UnencumberedLicenses,
 		Usage:      "unencumbered licenses",
 	}
+
+	// LicensePolicyAllowed is an option only in a config file
+	LicensePolicyAllowed = Flag[[]string]{
+		ConfigName: "license.policy.allowed",
+		Usage:      "licenses allowed by the license policy. Licenses not in the list violate the policy if set",
+	}
+	// LicensePolicyRestricted is an option only in a config file
+	LicensePolicyRestricted = Flag[[]string]{
+		ConfigName: "license.policy.restricted",
+		Usage:      "licenses restricted by the license policy",
+	}
+	// LicensePolicyForbidden is an option only in a config file
+	LicensePolicyForbidden = Flag[[]string]{
+		ConfigName: "license.policy.forbidden",
+		Usage:      "licenses forbidden by the license policy",
+	}
+	ProjectLicense = Flag[string]{
+		Name:       "project-license",
+		ConfigName: "license.project",
+		Usage:      "[EXPERIMENTAL] license of the project to warn about licenses of dependencies incompatible with it, such as Apache-2.0",
+	}
+	LicenseGoBinarySources = Flag[[]string]{
+		Name:       "license-go-binary-sources",
+		ConfigName: "license.goBinarySources",
+		Values:     xstrings.ToStringSlice(license.Sources),
+		Usage:      "[EXPERIMENTAL] sources to resolve licenses of modules embedded in Go binaries, tried in order. The proxy is configured by GOPROXY and GOPRIVATE",
+	}
 )
 
 type LicenseFlagGroup struct {
@@ -73,6 +103,14 @@ type LicenseFlagGroup struct {
 	LicenseNotice       *Flag[[]string] // mapped to LOW
 	LicensePermissive   *Flag[[]string] // mapped to LOW
 	LicenseUnencumbered *Flag[[]string] // mapped to LOW
+
+	// License Policy
+	LicensePolicyAllowed    *Flag[[]string]
+	LicensePolicyRestricted *Flag[[]string] // mapped to HIGH
+	LicensePolicyForbidden  *Flag[[]string] // mapped to CRITICAL
+
+	ProjectLicense         *Flag[string]
+	LicenseGoBinarySources *Flag[[]string]
 }
 
 type LicenseOptions struct {
@@ -81,19 +119,27 @@ type LicenseOptions struct {
 	LicenseConfidenceLevel float64
 	LicenseRiskThreshold   int
 	LicenseCategories      map[types.LicenseCategory][]string
+	LicensePolicy          licensing.Policy
+	ProjectLicense         licensing.Compatibility
+	LicenseGoBinarySources []string
 }
 
 func NewLicenseFlagGroup() *LicenseFlagGroup {
 	return &LicenseFlagGroup{
-		LicenseFull:            LicenseFull.Clone(),
-		IgnoredLicenses:        IgnoredLicenses.Clone(),
-		LicenseConfidenceLevel: LicenseConfidenceLevel.Clone(),
-		LicenseForbidden:       LicenseForbidden.Clone(),
-		LicenseRestricted:      LicenseRestricted.Clone(),
-		LicenseReciprocal:      LicenseReciprocal.Clone(),
-		LicenseNotice:          LicenseNotice.Clone(),
-		LicensePermissive:      LicensePermissive.Clone(),
-		LicenseUnencumbered:    LicenseUnencumbered.Clone(),
+		LicenseFull:             LicenseFull.Clone(),
+		IgnoredLicenses:         IgnoredLicenses.Clone(),
+		LicenseConfidenceLevel:  LicenseConfidenceLevel.Clone(),
+		LicenseForbidden:        LicenseForbidden.Clone(),
+		LicenseRestricted:       LicenseRestricted.Clone(),
+		LicenseReciprocal:       LicenseReciprocal.Clone(),
+		LicenseNotice:           LicenseNotice.Clone(),
+		LicensePermissive:       LicensePermissive.Clone(),
+		LicenseUnencumbered:     LicenseUnencumbered.Clone(),
+		LicensePolicyAllowed:    LicensePolicyAllowed.Clone(),
+		LicensePolicyRestricted: LicensePolicyRestricted.Clone(),
+		LicensePolicyForbidden:  LicensePolicyForbidden.Clone(),
+		ProjectLicense:          ProjectLicense.Clone(),
+		LicenseGoBinarySources:  LicenseGoBinarySources.Clone(),
 	}
 }
 
@@ -111,7 +157,12 @@ func (f *LicenseFlagGroup) Flags() []Flagger {
 		f.LicenseNotice,
 		f.LicensePermissive,
 		f.LicenseUnencumbered,
+		f.LicensePolicyAllowed,
+		f.LicensePolicyRestricted,
+		f.LicensePolicyForbidden,
 		f.LicenseConfidenceLevel,
+		f.ProjectLicense,
+		f.LicenseGoBinarySources,
 	}
 }
 
@@ -133,5 +184,9 @@ func (f *LicenseFlagGroup) ToOptions() (LicenseOptions, error) {
 		IgnoredLicenses:        f.IgnoredLicenses.Value(),
 		LicenseConfidenceLevel: f.LicenseConfidenceLevel.Value(),
 		LicenseCategories:      licenseCategories,
+		LicensePolicy: licensing.NewPolicy(f.LicensePolicyAllowed.Value(), f.LicensePolicyRestricted.Value(),
+			f.LicensePolicyForbidden.Value()),
+		ProjectLicense:         licensing.NewCompatibility(f.ProjectLicense.Value()),
+		LicenseGoBinarySources: f.LicenseGoBinarySources.Value(),
 	}, nil
 }
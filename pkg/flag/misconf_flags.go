@@ -2,11 +2,17 @@ package flag
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/samber/lo"
+	"golang.org/x/xerrors"
 
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
 	"github.com/aquasecurity/trivy/pkg/policy"
+	"github.com/aquasecurity/trivy/pkg/result"
 	xstrings "github.com/aquasecurity/trivy/pkg/x/strings"
 )
 
@@ -39,7 +45,7 @@ var (
 	HelmValuesFileFlag = Flag[[]string]{
 		Name:       "helm-values",
 		ConfigName: "misconfiguration.helm.values",
-		Usage:      "specify paths to override the Helm values.yaml files",
+		Usage:      "specify paths to override the Helm values.yaml files (can specify multiple, later files take precedence)",
 	}
 	HelmSetFlag = Flag[[]string]{
 		Name:       "helm-set",
@@ -66,6 +72,11 @@ var (
 		ConfigName: "misconfiguration.helm.kube-version",
 		Usage:      "Kubernetes version used for Capabilities.KubeVersion. This flag is the same as the kube-version flag of the helm template command.",
 	}
+	HelmDependencyUpdateFlag = Flag[bool]{
+		Name:       "helm-dependency-update",
+		ConfigName: "misconfiguration.helm.dependency-update",
+		Usage:      "fetch chart dependencies not vendored in the charts directory from their repositories before rendering",
+	}
 	TfVarsFlag = Flag[[]string]{
 		Name:       "tf-vars",
 		ConfigName: "misconfiguration.terraform.vars",
@@ -77,16 +88,82 @@ var (
 		Default:    []string{},
 		Usage:      "specify paths to override the CloudFormation parameters files",
 	}
+	CfS3TemplatesFlag = Flag[bool]{
+		Name:       "cf-s3-templates",
+		ConfigName: "misconfiguration.cloudformation.s3-templates",
+		Usage:      "fetch the templates of nested stacks from S3 with the default AWS credentials",
+	}
 	TerraformExcludeDownloaded = Flag[bool]{
 		Name:       "tf-exclude-downloaded-modules",
 		ConfigName: "misconfiguration.terraform.exclude-downloaded-modules",
 		Usage:      "exclude misconfigurations for downloaded terraform modules",
 	}
-	ChecksBundleRepositoryFlag = Flag[string]{
+	TerraformPlanChangesOnly = Flag[bool]{
+		Name:       "tf-plan-changes-only",
+		ConfigName: "misconfiguration.terraform.plan-changes-only",
+		Usage:      "only report misconfigurations of resources created or updated by the terraform plan",
+	}
+	TerraformModuleCacheTTL = Flag[time.Duration]{
+		Name:       "tf-module-cache-ttl",
+		ConfigName: "misconfiguration.terraform.module-cache-ttl",
+		Default:    24 * time.Hour,
+		Usage:      "how long downloaded terraform modules are cached before downloading them again (0 to never expire)",
+	}
+	K8sSchemaDirFlag = Flag[string]{
+		Name:       "k8s-schema-dir",
+		ConfigName: "misconfiguration.kubernetes.schema-dir",
+		Usage:      "specify a directory with Kubernetes JSON schemas to validate manifests against (layout of https://github.com/yannh/kubernetes-json-schema)",
+	}
+	K8sSchemaVersionFlag = Flag[string]{
+		Name:       "k8s-schema-version",
+		ConfigName: "misconfiguration.kubernetes.schema-version",
+		Usage:      "Kubernetes version of the schemas used to validate manifests (example: 1.29.0)",
+	}
+	K8sUpgradeVersionFlag = Flag[string]{
+		Name:       "k8s-upgrade-version",
+		ConfigName: "misconfiguration.kubernetes.upgrade-version",
+		Usage:      "report apiVersions of manifests deprecated or removed in the Kubernetes version (example: 1.29.0)",
+	}
+	K8sCRDSchemasFlag = Flag[[]string]{
+		Name:       "k8s-crd-schemas",
+		ConfigName: "misconfiguration.kubernetes.crd-schemas",
+		Usage:      "specify CRD manifests, or directories of CRD manifests or JSON schemas (layout of https://github.com/datreeio/CRDs-catalog), to validate custom resources against",
+	}
+	K8sResolveGitOpsFlag = Flag[bool]{
+		Name:       "k8s-resolve-gitops",
+		ConfigName: "misconfiguration.kubernetes.resolve-gitops",
+		Usage:      "render the charts, Kustomizations and manifests in the repository referred to by Argo CD Applications and Flux Kustomizations and HelmReleases",
+	}
+	JsonnetImportPathsFlag = Flag[[]string]{
+		Name:       "jsonnet-jpath",
+		ConfigName: "misconfiguration.jsonnet.jpath",
+		Usage:      "specify library paths for Jsonnet imports, relative to the scan target (e.g. lib,vendor)",
+	}
+	JsonnetExtVarsFlag = Flag[[]string]{
+		Name:       "jsonnet-ext-str",
+		ConfigName: "misconfiguration.jsonnet.ext-str",
+		Usage:      "specify Jsonnet external variables as strings (can specify multiple or separate values with commas: key1=val1,key2=val2; the value of a key without a value is read from the environment)",
+	}
+	JsonnetExtCodeFlag = Flag[[]string]{
+		Name:       "jsonnet-ext-code",
+		ConfigName: "misconfiguration.jsonnet.ext-code",
+		Usage:      "specify Jsonnet external variables as code (can specify multiple: key1=code1)",
+	}
+	MisconfigMaxFileSizeFlag = Flag[string]{
+		Name:       "misconfig-max-file-size",
+		ConfigName: "misconfiguration.max-file-size",
+		Usage:      "maximum size of the JSON, YAML, TOML and Terraform files parsed for misconfigurations, specified in a human-readable format (e.g., '512kB', '5MB'); larger files are skipped with a warning",
+	}
+	MisconfigParseTimeoutFlag = Flag[time.Duration]{
+		Name:       "misconfig-parse-timeout",
+		ConfigName: "misconfiguration.parse-timeout",
+		Usage:      "maximum time to parse a JSON, YAML, TOML or Terraform file for misconfigurations (0 for no limit); files taking longer are skipped with a warning",
+	}
+	ChecksBundleRepositoryFlag = Flag[[]string]{
 		Name:       "checks-bundle-repository",
 		ConfigName: "misconfiguration.checks-bundle-repository",
-		Default:    fmt.Sprintf("%s:%d", policy.BundleRepository, policy.BundleVersion),
-		Usage:      "OCI registry URL to retrieve checks bundle from",
+		Default:    []string{fmt.Sprintf("%s:%d", policy.BundleRepository, policy.BundleVersion)},
+		Usage:      "OCI repository(ies) to retrieve checks bundle from in order of priority, e.g. mirrors for air-gapped environments. Pin the bundle with '@sha256:<digest>'",
 		Aliases: []Alias{
 			{
 				Name:       "policy-bundle-repository",
@@ -99,10 +176,14 @@ var (
 		Name:       "misconfig-scanners",
 		ConfigName: "misconfiguration.scanners",
 		Default: xstrings.ToStringSlice(
-			lo.Without(analyzer.TypeConfigFiles, analyzer.TypeYAML, analyzer.TypeJSON),
+			lo.Without(analyzer.TypeConfigFiles, analyzer.TypeYAML, analyzer.TypeJSON, analyzer.TypeCUE),
 		),
 		Usage: "comma-separated list of misconfig scanners to use for misconfiguration scanning",
 	}
+	CheckOverridesFlag = Flag[result.CheckOverrides]{
+		ConfigName: "misconfiguration.check-overrides",
+		Usage:      "map of check IDs or AVD IDs to the severity, tags or disabled state overriding those of the checks",
+	}
 	ConfigFileSchemasFlag = Flag[[]string]{
 		Name:       "config-file-schemas",
 		ConfigName: "misconfiguration.config-file-schemas",
@@ -114,7 +195,7 @@ var (
 type MisconfFlagGroup struct {
 	IncludeNonFailures     *Flag[bool]
 	ResetChecksBundle      *Flag[bool]
-	ChecksBundleRepository *Flag[string]
+	ChecksBundleRepository *Flag[[]string]
 
 	// Values Files
 	HelmValues                 *Flag[[]string]
@@ -123,30 +204,60 @@ type MisconfFlagGroup struct {
 	HelmStringValues           *Flag[[]string]
 	HelmAPIVersions            *Flag[[]string]
 	HelmKubeVersion            *Flag[string]
+	HelmDependencyUpdate       *Flag[bool]
 	TerraformTFVars            *Flag[[]string]
 	CloudformationParamVars    *Flag[[]string]
+	CloudformationS3Templates  *Flag[bool]
 	TerraformExcludeDownloaded *Flag[bool]
+	TerraformPlanChangesOnly   *Flag[bool]
+	TerraformModuleCacheTTL    *Flag[time.Duration]
+	K8sSchemaDir               *Flag[string]
+	K8sSchemaVersion           *Flag[string]
+	K8sUpgradeVersion          *Flag[string]
+	K8sCRDSchemas              *Flag[[]string]
+	K8sResolveGitOps           *Flag[bool]
+	JsonnetImportPaths         *Flag[[]string]
+	JsonnetExtVars             *Flag[[]string]
+	JsonnetExtCode             *Flag[[]string]
+	MaxFileSize                *Flag[string]
+	ParseTimeout               *Flag[time.Duration]
 	MisconfigScanners          *Flag[[]string]
 	ConfigFileSchemas          *Flag[[]string]
+	CheckOverrides             *Flag[result.CheckOverrides]
 }
 
 type MisconfOptions struct {
-	IncludeNonFailures     bool
-	ResetChecksBundle      bool
-	ChecksBundleRepository string
+	IncludeNonFailures       bool
+	ResetChecksBundle        bool
+	ChecksBundleRepositories []string
 
 	// Values Files
-	HelmValues              []string
-	HelmValueFiles          []string
-	HelmFileValues          []string
-	HelmStringValues        []string
-	HelmAPIVersions         []string
-	HelmKubeVersion         string
-	TerraformTFVars         []string
-	CloudFormationParamVars []string
-	TfExcludeDownloaded     bool
-	MisconfigScanners       []analyzer.Type
-	ConfigFileSchemas       []string
+	HelmValues                []string
+	HelmValueFiles            []string
+	HelmFileValues            []string
+	HelmStringValues          []string
+	HelmAPIVersions           []string
+	HelmKubeVersion           string
+	HelmDependencyUpdate      bool
+	TerraformTFVars           []string
+	CloudFormationParamVars   []string
+	CloudFormationS3Templates bool
+	TfExcludeDownloaded       bool
+	TfPlanChangesOnly         bool
+	TfModuleCacheTTL          time.Duration
+	K8sSchemaDir              string
+	K8sSchemaVersion          string
+	K8sUpgradeVersion         string
+	K8sCRDSchemas             []string
+	K8sResolveGitOps          bool
+	JsonnetImportPaths        []string
+	JsonnetExtVars            []string
+	JsonnetExtCode            []string
+	MaxFileSize               int64
+	ParseTimeout              time.Duration
+	MisconfigScanners         []analyzer.Type
+	ConfigFileSchemas         []string
+	CheckOverrides            result.CheckOverrides
 }
 
 func NewMisconfFlagGroup() *MisconfFlagGroup {
@@ -161,11 +272,26 @@ func NewMisconfFlagGroup() *MisconfFlagGroup {
 		HelmValueFiles:             HelmValuesFileFlag.Clone(),
 		HelmAPIVersions:            HelmAPIVersionsFlag.Clone(),
 		HelmKubeVersion:            HelmKubeVersionFlag.Clone(),
+		HelmDependencyUpdate:       HelmDependencyUpdateFlag.Clone(),
 		TerraformTFVars:            TfVarsFlag.Clone(),
 		CloudformationParamVars:    CfParamsFlag.Clone(),
+		CloudformationS3Templates:  CfS3TemplatesFlag.Clone(),
 		TerraformExcludeDownloaded: TerraformExcludeDownloaded.Clone(),
+		TerraformPlanChangesOnly:   TerraformPlanChangesOnly.Clone(),
+		TerraformModuleCacheTTL:    TerraformModuleCacheTTL.Clone(),
+		K8sSchemaDir:               K8sSchemaDirFlag.Clone(),
+		K8sSchemaVersion:           K8sSchemaVersionFlag.Clone(),
+		K8sUpgradeVersion:          K8sUpgradeVersionFlag.Clone(),
+		K8sCRDSchemas:              K8sCRDSchemasFlag.Clone(),
+		K8sResolveGitOps:           K8sResolveGitOpsFlag.Clone(),
+		JsonnetImportPaths:         JsonnetImportPathsFlag.Clone(),
+		JsonnetExtVars:             JsonnetExtVarsFlag.Clone(),
+		JsonnetExtCode:             JsonnetExtCodeFlag.Clone(),
+		MaxFileSize:                MisconfigMaxFileSizeFlag.Clone(),
+		ParseTimeout:               MisconfigParseTimeoutFlag.Clone(),
 		MisconfigScanners:          MisconfigScannersFlag.Clone(),
 		ConfigFileSchemas:          ConfigFileSchemasFlag.Clone(),
+		CheckOverrides:             CheckOverridesFlag.Clone(),
 	}
 }
 
@@ -184,11 +310,26 @@ func (f *MisconfFlagGroup) Flags() []Flagger {
 		f.HelmStringValues,
 		f.HelmAPIVersions,
 		f.HelmKubeVersion,
+		f.HelmDependencyUpdate,
 		f.TerraformTFVars,
 		f.TerraformExcludeDownloaded,
+		f.TerraformPlanChangesOnly,
+		f.TerraformModuleCacheTTL,
 		f.CloudformationParamVars,
+		f.CloudformationS3Templates,
+		f.K8sSchemaDir,
+		f.K8sSchemaVersion,
+		f.K8sUpgradeVersion,
+		f.K8sCRDSchemas,
+		f.K8sResolveGitOps,
+		f.JsonnetImportPaths,
+		f.JsonnetExtVars,
+		f.JsonnetExtCode,
+		f.MaxFileSize,
+		f.ParseTimeout,
 		f.MisconfigScanners,
 		f.ConfigFileSchemas,
+		f.CheckOverrides,
 	}
 }
 
@@ -197,20 +338,54 @@ func (f *MisconfFlagGroup) ToOptions() (MisconfOptions, error) {
 		return MisconfOptions{}, err
 	}
 
+	var maxFileSize int64
+	if value := f.MaxFileSize.Value(); value != "" {
+		size, err := units.FromHumanSize(value)
+		if err != nil {
+			return MisconfOptions{}, xerrors.Errorf("invalid max file size %q: %w", value, err)
+		}
+		maxFileSize = size
+	}
+
+	checkOverrides := f.CheckOverrides.Value()
+	for id, override := range checkOverrides {
+		if override.Severity == "" {
+			continue
+		}
+		if _, err := dbTypes.NewSeverity(strings.ToUpper(override.Severity)); err != nil {
+			return MisconfOptions{}, xerrors.Errorf("invalid severity of the check override %q: %w", id, err)
+		}
+	}
+
 	return MisconfOptions{
-		IncludeNonFailures:      f.IncludeNonFailures.Value(),
-		ResetChecksBundle:       f.ResetChecksBundle.Value(),
-		ChecksBundleRepository:  f.ChecksBundleRepository.Value(),
-		HelmValues:              f.HelmValues.Value(),
-		HelmValueFiles:          f.HelmValueFiles.Value(),
-		HelmFileValues:          f.HelmFileValues.Value(),
-		HelmStringValues:        f.HelmStringValues.Value(),
-		HelmAPIVersions:         f.HelmAPIVersions.Value(),
-		HelmKubeVersion:         f.HelmKubeVersion.Value(),
-		TerraformTFVars:         f.TerraformTFVars.Value(),
-		CloudFormationParamVars: f.CloudformationParamVars.Value(),
-		TfExcludeDownloaded:     f.TerraformExcludeDownloaded.Value(),
-		MisconfigScanners:       xstrings.ToTSlice[analyzer.Type](f.MisconfigScanners.Value()),
-		ConfigFileSchemas:       f.ConfigFileSchemas.Value(),
+		IncludeNonFailures:        f.IncludeNonFailures.Value(),
+		ResetChecksBundle:         f.ResetChecksBundle.Value(),
+		ChecksBundleRepositories:  f.ChecksBundleRepository.Value(),
+		HelmValues:                f.HelmValues.Value(),
+		HelmValueFiles:            f.HelmValueFiles.Value(),
+		HelmFileValues:            f.HelmFileValues.Value(),
+		HelmStringValues:          f.HelmStringValues.Value(),
+		HelmAPIVersions:           f.HelmAPIVersions.Value(),
+		HelmKubeVersion:           f.HelmKubeVersion.Value(),
+		HelmDependencyUpdate:      f.HelmDependencyUpdate.Value(),
+		TerraformTFVars:           f.TerraformTFVars.Value(),
+		CloudFormationParamVars:   f.CloudformationParamVars.Value(),
+		CloudFormationS3Templates: f.CloudformationS3Templates.Value(),
+		TfExcludeDownloaded:       f.TerraformExcludeDownloaded.Value(),
+		TfPlanChangesOnly:         f.TerraformPlanChangesOnly.Value(),
+		TfModuleCacheTTL:          f.TerraformModuleCacheTTL.Value(),
+		K8sSchemaDir:              f.K8sSchemaDir.Value(),
+		K8sSchemaVersion:          f.K8sSchemaVersion.Value(),
+		K8sUpgradeVersion:         f.K8sUpgradeVersion.Value(),
+		K8sCRDSchemas:             f.K8sCRDSchemas.Value(),
+		K8sResolveGitOps:          f.K8sResolveGitOps.Value(),
+		JsonnetImportPaths:        f.JsonnetImportPaths.Value(),
+		JsonnetExtVars:            f.JsonnetExtVars.Value(),
+		JsonnetExtCode:            f.JsonnetExtCode.Value(),
+		MaxFileSize:               maxFileSize,
+		ParseTimeout:              f.ParseTimeout.Value(),
+		MisconfigScanners:         xstrings.ToTSlice[analyzer.Type](f.MisconfigScanners.Value()),
+		ConfigFileSchemas:         f.ConfigFileSchemas.Value(),
+		CheckOverrides:            checkOverrides,
 	}, nil
 }
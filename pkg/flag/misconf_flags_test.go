@@ -0,0 +1,79 @@
+package flag_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/result"
+)
+
+func TestMisconfFlagGroup_ToOptions_CheckOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    result.CheckOverrides
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			config: `
+misconfiguration:
+  check-overrides:
+    AVD-AWS-0001:
+      severity: LOW
+      tags:
+        - team-a
+        - pci
+    DS002:
+      disabled: true
+`,
+			want: result.CheckOverrides{
+				"avd-aws-0001": {
+					Severity: "LOW",
+					Tags:     []string{"team-a", "pci"},
+				},
+				"ds002": {
+					Disabled: true,
+				},
+			},
+		},
+		{
+			name:   "no overrides",
+			config: `misconfiguration: {}`,
+		},
+		{
+			name: "invalid severity",
+			config: `
+misconfiguration:
+  check-overrides:
+    AVD-AWS-0001:
+      severity: SEVERE
+`,
+			wantErr: `invalid severity of the check override "avd-aws-0001"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			viper.SetConfigType("yaml")
+			require.NoError(t, viper.ReadConfig(strings.NewReader(tt.config)))
+
+			f := flag.NewMisconfFlagGroup()
+			require.NoError(t, f.CheckOverrides.Bind(nil))
+
+			got, err := f.ToOptions()
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.CheckOverrides)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package flag
+
+import (
+	"time"
+)
+
+// e.g. config yaml:
+//
+//	monitor:
+//	  interval: 24h
+//	  state-file: /path/to/state.json
+var (
+	MonitorIntervalFlag = Flag[time.Duration]{
+		Name:       "interval",
+		ConfigName: "monitor.interval",
+		Usage:      "interval between evaluations. If zero, SBOMs are evaluated only once",
+	}
+	MonitorStateFileFlag = Flag[string]{
+		Name:       "state-file",
+		ConfigName: "monitor.state-file",
+		Usage:      "path to the file storing findings of the previous evaluation (default: \"$CACHE_DIR/monitor/<hash of SBOM_DIR>.json\")",
+	}
+)
+
+type MonitorFlagGroup struct {
+	Interval  *Flag[time.Duration]
+	StateFile *Flag[string]
+}
+
+type MonitorOptions struct {
+	MonitorInterval  time.Duration
+	MonitorStateFile string
+}
+
+func NewMonitorFlagGroup() *MonitorFlagGroup {
+	return &MonitorFlagGroup{
+		Interval:  MonitorIntervalFlag.Clone(),
+		StateFile: MonitorStateFileFlag.Clone(),
+	}
+}
+
+func (f *MonitorFlagGroup) Name() string {
+	return "Monitor"
+}
+
+func (f *MonitorFlagGroup) Flags() []Flagger {
+	return []Flagger{
+		f.Interval,
+		f.StateFile,
+	}
+}
+
+func (f *MonitorFlagGroup) ToOptions() (MonitorOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return MonitorOptions{}, err
+	}
+
+	return MonitorOptions{
+		MonitorInterval:  f.Interval.Value(),
+		MonitorStateFile: f.StateFile.Value(),
+	}, nil
+}
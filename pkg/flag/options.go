@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
 	"github.com/samber/lo"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -23,6 +24,7 @@ import (
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/plugin"
+	"github.com/aquasecurity/trivy/pkg/remote"
 	"github.com/aquasecurity/trivy/pkg/result"
 	"github.com/aquasecurity/trivy/pkg/rpc/client"
 	"github.com/aquasecurity/trivy/pkg/types"
@@ -30,7 +32,7 @@ import (
 )
 
 type FlagType interface {
-	int | string | []string | bool | time.Duration | float64 | map[string][]string
+	int | string | []string | bool | time.Duration | float64 | map[string][]string | result.CheckOverrides
 }
 
 type Flag[T FlagType] struct {
@@ -172,6 +174,12 @@ func (f *Flag[T]) cast(val any) any {
 			val = strings.Split(s, ",")
 		}
 		return cast.ToStringSlice(val)
+	case result.CheckOverrides:
+		var overrides result.CheckOverrides
+		if err := mapstructure.Decode(val, &overrides); err != nil {
+			return val
+		}
+		return overrides
 	}
 	return val
 }
@@ -335,39 +343,53 @@ type Flagger interface {
 }
 
 type Flags struct {
-	GlobalFlagGroup        *GlobalFlagGroup
-	AWSFlagGroup           *AWSFlagGroup
-	CacheFlagGroup         *CacheFlagGroup
-	CleanFlagGroup         *CleanFlagGroup
-	DBFlagGroup            *DBFlagGroup
-	ImageFlagGroup         *ImageFlagGroup
-	K8sFlagGroup           *K8sFlagGroup
-	LicenseFlagGroup       *LicenseFlagGroup
-	MisconfFlagGroup       *MisconfFlagGroup
-	ModuleFlagGroup        *ModuleFlagGroup
-	PackageFlagGroup       *PackageFlagGroup
-	RemoteFlagGroup        *RemoteFlagGroup
-	RegistryFlagGroup      *RegistryFlagGroup
-	RegoFlagGroup          *RegoFlagGroup
-	RepoFlagGroup          *RepoFlagGroup
-	ReportFlagGroup        *ReportFlagGroup
-	ScanFlagGroup          *ScanFlagGroup
-	SecretFlagGroup        *SecretFlagGroup
-	VulnerabilityFlagGroup *VulnerabilityFlagGroup
+	GlobalFlagGroup         *GlobalFlagGroup
+	AWSFlagGroup            *AWSFlagGroup
+	BrowseFlagGroup         *BrowseFlagGroup
+	CacheFlagGroup          *CacheFlagGroup
+	CacheInspectFlagGroup   *CacheInspectFlagGroup
+	CheckTestFlagGroup      *CheckTestFlagGroup
+	CleanFlagGroup          *CleanFlagGroup
+	DBFlagGroup             *DBFlagGroup
+	DBMirrorFlagGroup       *DBMirrorFlagGroup
+	ImageFlagGroup          *ImageFlagGroup
+	JavaDBFlagGroup         *JavaDBFlagGroup
+	K8sFlagGroup            *K8sFlagGroup
+	LicenseFlagGroup        *LicenseFlagGroup
+	MisconfFlagGroup        *MisconfFlagGroup
+	ModuleFlagGroup         *ModuleFlagGroup
+	MonitorFlagGroup        *MonitorFlagGroup
+	PackageFlagGroup        *PackageFlagGroup
+	RemoteFlagGroup         *RemoteFlagGroup
+	RegistryFlagGroup       *RegistryFlagGroup
+	RegoFlagGroup           *RegoFlagGroup
+	RepoFlagGroup           *RepoFlagGroup
+	ReportFlagGroup         *ReportFlagGroup
+	ScanFlagGroup           *ScanFlagGroup
+	SecretFlagGroup         *SecretFlagGroup
+	SecretRuleTestFlagGroup *SecretRuleTestFlagGroup
+	VerifyReportFlagGroup   *VerifyReportFlagGroup
+	VulnerabilityFlagGroup  *VulnerabilityFlagGroup
 }
 
 // Options holds all the runtime configuration
 type Options struct {
 	GlobalOptions
 	AWSOptions
+	BrowseOptions
 	CacheOptions
+	CacheInspectOptions
+	CheckTestOptions
 	CleanOptions
 	DBOptions
+	DBMirrorOptions
 	ImageOptions
+	JavaDBOptions
 	K8sOptions
 	LicenseOptions
 	MisconfOptions
 	ModuleOptions
+	MonitorOptions
 	PackageOptions
 	RegistryOptions
 	RegoOptions
@@ -376,6 +398,8 @@ type Options struct {
 	ReportOptions
 	ScanOptions
 	SecretOptions
+	SecretRuleTestOptions
+	VerifyReportOptions
 	VulnerabilityOptions
 
 	// Trivy's version, not populated via CLI flags
@@ -401,6 +425,11 @@ func (o *Options) Align(f *Flags) error {
 		return xerrors.Errorf("'--pkg-relationships' cannot be used with '--dependency-tree', '--vex' or SBOM formats")
 	}
 
+	// The progress of the scan is lost on exit with the memory cache
+	if o.Resume && o.CacheBackend == string(cache.TypeMemory) {
+		return xerrors.New("'--resume' requires a persistent cache backend, such as '--cache-backend fs'")
+	}
+
 	if o.Compliance.Spec.ID != "" {
 		if viper.IsSet(ScannersFlag.ConfigName) {
 			log.Info(`The option to change scanners is disabled for scanning with the "--compliance" flag. Default scanners used.`)
@@ -437,7 +466,7 @@ func (o *Options) enableSBOM() {
 	}
 
 	// Enable the SBOM scanner when a list of packages is necessary.
-	if o.ListAllPkgs || slices.Contains(types.SupportedSBOMFormats, o.Format) {
+	if o.ListAllPkgs || slices.Contains(types.SupportedSBOMFormats, o.Format) || o.Format == types.FormatGraph {
 		o.Scanners.Enable(types.SBOMScanner)
 	}
 
@@ -449,6 +478,14 @@ func (o *Options) enableSBOM() {
 		}
 		o.Scanners.Enable(types.SBOMScanner)
 	}
+
+	// The attribution file lists only packages, so security scanning is disabled by default.
+	if o.Format == types.FormatAttribution {
+		if !viper.IsSet(ScannersFlag.ConfigName) {
+			o.Scanners = nil
+		}
+		o.Scanners.Enable(types.SBOMScanner)
+	}
 }
 
 // ScanOpts returns options for scanning
@@ -463,19 +500,35 @@ func (o *Options) ScanOpts() types.ScanOptions {
 		FilePatterns:        o.FilePatterns,
 		IncludeDevDeps:      o.IncludeDevDeps,
 		Distro:              o.Distro,
+		CheckPkgNames:       o.CheckPkgNames,
+		InternalNamespaces:  o.InternalNamespaces,
 	}
 }
 
 // RegistryOpts returns options for OCI registries
 func (o *Options) RegistryOpts() ftypes.RegistryOptions {
-	return ftypes.RegistryOptions{
+	var blobDir string
+	if o.CacheDir != "" {
+		blobDir = remote.BlobDir(o.CacheDir)
+	}
+	opts := ftypes.RegistryOptions{
 		Credentials:     o.Credentials,
 		RegistryToken:   o.RegistryToken,
 		Insecure:        o.Insecure,
+		CACert:          o.RegistryCACert,
+		BlobDir:         blobDir,
 		Platform:        o.Platform,
 		AWSRegion:       o.AWSOptions.Region,
 		RegistryMirrors: o.RegistryMirrors,
 	}
+	if o.VerifyDBSignature {
+		opts.ArtifactSignature = &ftypes.SignatureOptions{
+			Key:        o.DBSignatureKey,
+			Identity:   o.DBSignatureIdentity,
+			OIDCIssuer: o.DBSignatureOIDCIssuer,
+		}
+	}
+	return opts
 }
 
 // FilterOpts returns options for filtering
@@ -486,22 +539,39 @@ func (o *Options) FilterOpts() result.FilterOptions {
 		IncludeNonFailures: o.IncludeNonFailures,
 		IgnoreFile:         o.IgnoreFile,
 		PolicyFile:         o.IgnorePolicy,
+		Baseline:           o.Baseline,
 		IgnoreLicenses:     o.IgnoredLicenses,
+		LicensePolicy:      o.LicensePolicy,
+		ProjectLicense:     o.ProjectLicense,
 		CacheDir:           o.CacheDir,
 		VEXSources:         o.VEXSources,
+		CheckOverrides:     o.CheckOverrides,
+		SecretBaseline: result.SecretBaselineOptions{
+			Path:     o.SecretBaseline,
+			Generate: o.GenerateSecretBaseline,
+		},
+		Scoring: result.ScoringOptions{
+			PolicyFile:       o.ScoringPolicy,
+			AssetCriticality: o.AssetCriticality,
+			MinScore:         o.MinRiskScore,
+		},
 	}
 }
 
 // CacheOpts returns options for scan cache
 func (o *Options) CacheOpts() cache.Options {
 	return cache.Options{
-		Backend:     o.CacheBackend,
-		CacheDir:    o.CacheDir,
-		RedisCACert: o.RedisCACert,
-		RedisCert:   o.RedisCert,
-		RedisKey:    o.RedisKey,
-		RedisTLS:    o.RedisTLS,
-		TTL:         o.CacheTTL,
+		Backend:       o.CacheBackend,
+		CacheDir:      o.CacheDir,
+		RedisCACert:   o.RedisCACert,
+		RedisCert:     o.RedisCert,
+		RedisKey:      o.RedisKey,
+		RedisTLS:      o.RedisTLS,
+		S3SSE:         o.S3SSE,
+		S3SSEKMSKeyID: o.S3SSEKMSKeyID,
+		S3Concurrency: o.S3Concurrency,
+		TTL:           o.CacheTTL,
+		MaxSize:       o.CacheMaxSize,
 	}
 }
 
@@ -592,6 +662,12 @@ func (f *Flags) groups() []FlagGroup {
 	if f.DBFlagGroup != nil {
 		groups = append(groups, f.DBFlagGroup)
 	}
+	if f.DBMirrorFlagGroup != nil {
+		groups = append(groups, f.DBMirrorFlagGroup)
+	}
+	if f.JavaDBFlagGroup != nil {
+		groups = append(groups, f.JavaDBFlagGroup)
+	}
 	if f.RegistryFlagGroup != nil {
 		groups = append(groups, f.RegistryFlagGroup)
 	}
@@ -607,9 +683,21 @@ func (f *Flags) groups() []FlagGroup {
 	if f.ModuleFlagGroup != nil {
 		groups = append(groups, f.ModuleFlagGroup)
 	}
+	if f.MonitorFlagGroup != nil {
+		groups = append(groups, f.MonitorFlagGroup)
+	}
 	if f.SecretFlagGroup != nil {
 		groups = append(groups, f.SecretFlagGroup)
 	}
+	if f.SecretRuleTestFlagGroup != nil {
+		groups = append(groups, f.SecretRuleTestFlagGroup)
+	}
+	if f.CheckTestFlagGroup != nil {
+		groups = append(groups, f.CheckTestFlagGroup)
+	}
+	if f.CacheInspectFlagGroup != nil {
+		groups = append(groups, f.CacheInspectFlagGroup)
+	}
 	if f.LicenseFlagGroup != nil {
 		groups = append(groups, f.LicenseFlagGroup)
 	}
@@ -619,6 +707,9 @@ func (f *Flags) groups() []FlagGroup {
 	if f.AWSFlagGroup != nil {
 		groups = append(groups, f.AWSFlagGroup)
 	}
+	if f.BrowseFlagGroup != nil {
+		groups = append(groups, f.BrowseFlagGroup)
+	}
 	if f.K8sFlagGroup != nil {
 		groups = append(groups, f.K8sFlagGroup)
 	}
@@ -631,6 +722,9 @@ func (f *Flags) groups() []FlagGroup {
 	if f.RepoFlagGroup != nil {
 		groups = append(groups, f.RepoFlagGroup)
 	}
+	if f.VerifyReportFlagGroup != nil {
+		groups = append(groups, f.VerifyReportFlagGroup)
+	}
 	return groups
 }
 
@@ -708,6 +802,13 @@ func (f *Flags) ToOptions(args []string) (Options, error) {
 		}
 	}
 
+	if f.BrowseFlagGroup != nil {
+		opts.BrowseOptions, err = f.BrowseFlagGroup.ToOptions(args)
+		if err != nil {
+			return Options{}, xerrors.Errorf("browse flag error: %w", err)
+		}
+	}
+
 	if f.CacheFlagGroup != nil {
 		opts.CacheOptions, err = f.CacheFlagGroup.ToOptions()
 		if err != nil {
@@ -729,6 +830,13 @@ func (f *Flags) ToOptions(args []string) (Options, error) {
 		}
 	}
 
+	if f.DBMirrorFlagGroup != nil {
+		opts.DBMirrorOptions, err = f.DBMirrorFlagGroup.ToOptions()
+		if err != nil {
+			return Options{}, xerrors.Errorf("db mirror flag error: %w", err)
+		}
+	}
+
 	if f.ImageFlagGroup != nil {
 		opts.ImageOptions, err = f.ImageFlagGroup.ToOptions()
 		if err != nil {
@@ -736,6 +844,13 @@ func (f *Flags) ToOptions(args []string) (Options, error) {
 		}
 	}
 
+	if f.JavaDBFlagGroup != nil {
+		opts.JavaDBOptions, err = f.JavaDBFlagGroup.ToOptions(args)
+		if err != nil {
+			return Options{}, xerrors.Errorf("java db flag error: %w", err)
+		}
+	}
+
 	if f.K8sFlagGroup != nil {
 		opts.K8sOptions, err = f.K8sFlagGroup.ToOptions()
 		if err != nil {
@@ -764,6 +879,13 @@ func (f *Flags) ToOptions(args []string) (Options, error) {
 		}
 	}
 
+	if f.MonitorFlagGroup != nil {
+		opts.MonitorOptions, err = f.MonitorFlagGroup.ToOptions()
+		if err != nil {
+			return Options{}, xerrors.Errorf("monitor flag error: %w", err)
+		}
+	}
+
 	if f.PackageFlagGroup != nil {
 		opts.PackageOptions, err = f.PackageFlagGroup.ToOptions()
 		if err != nil {
@@ -820,6 +942,34 @@ func (f *Flags) ToOptions(args []string) (Options, error) {
 		}
 	}
 
+	if f.SecretRuleTestFlagGroup != nil {
+		opts.SecretRuleTestOptions, err = f.SecretRuleTestFlagGroup.ToOptions(args)
+		if err != nil {
+			return Options{}, xerrors.Errorf("secret rule test flag error: %w", err)
+		}
+	}
+
+	if f.CheckTestFlagGroup != nil {
+		opts.CheckTestOptions, err = f.CheckTestFlagGroup.ToOptions(args)
+		if err != nil {
+			return Options{}, xerrors.Errorf("check test flag error: %w", err)
+		}
+	}
+
+	if f.CacheInspectFlagGroup != nil {
+		opts.CacheInspectOptions, err = f.CacheInspectFlagGroup.ToOptions(args)
+		if err != nil {
+			return Options{}, xerrors.Errorf("cache inspect flag error: %w", err)
+		}
+	}
+
+	if f.VerifyReportFlagGroup != nil {
+		opts.VerifyReportOptions, err = f.VerifyReportFlagGroup.ToOptions(args)
+		if err != nil {
+			return Options{}, xerrors.Errorf("verify report flag error: %w", err)
+		}
+	}
+
 	if f.VulnerabilityFlagGroup != nil {
 		opts.VulnerabilityOptions, err = f.VulnerabilityFlagGroup.ToOptions()
 		if err != nil {
@@ -879,15 +1029,21 @@ func (a flagAliases) NormalizeFunc() func(*pflag.FlagSet, string) pflag.Normaliz
 func HiddenFlags() []string {
 	var allFlagGroups = []FlagGroup{
 		NewGlobalFlagGroup(),
+		NewBrowseFlagGroup(),
 		NewCacheFlagGroup(),
+		NewCacheInspectFlagGroup(),
+		NewCheckTestFlagGroup(),
 		NewCleanFlagGroup(),
 		NewClientFlags(),
 		NewDBFlagGroup(),
+		NewDBMirrorFlagGroup(),
 		NewImageFlagGroup(),
+		NewJavaDBFlagGroup(),
 		NewK8sFlagGroup(),
 		NewLicenseFlagGroup(),
 		NewMisconfFlagGroup(),
 		NewModuleFlagGroup(),
+		NewMonitorFlagGroup(),
 		NewPackageFlagGroup(),
 		NewRegistryFlagGroup(),
 		NewRegoFlagGroup(),
@@ -895,7 +1051,9 @@ func HiddenFlags() []string {
 		NewRepoFlagGroup(),
 		NewScanFlagGroup(),
 		NewSecretFlagGroup(),
+		NewSecretRuleTestFlagGroup(),
 		NewServerFlags(),
+		NewVerifyReportFlagGroup(),
 		NewVulnerabilityFlagGroup(),
 	}
 
@@ -2,6 +2,7 @@ package flag
 
 import (
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/types"
 	xstrings "github.com/aquasecurity/trivy/pkg/x/strings"
 )
@@ -33,27 +34,43 @@ var (
 		Values:     xstrings.ToStringSlice(ftypes.Relationships),
 		Usage:      "list of package relationships",
 	}
+	CheckPkgNamesFlag = Flag[bool]{
+		Name:       "check-pkg-names",
+		ConfigName: "pkg.check-names",
+		Usage:      "[EXPERIMENTAL] report dependencies whose names look like typosquats of popular packages or match internal namespaces",
+	}
+	InternalNamespacesFlag = Flag[[]string]{
+		Name:       "internal-namespaces",
+		ConfigName: "pkg.internal-namespaces",
+		Usage:      "prefixes of internal package names to detect dependency confusion with '--check-pkg-names' (e.g. '@acme/', 'acme-')",
+	}
 )
 
 // PackageFlagGroup composes common package flag structs.
 // These flags affect both SBOM and vulnerability scanning.
 type PackageFlagGroup struct {
-	IncludeDevDeps   *Flag[bool]
-	PkgTypes         *Flag[[]string]
-	PkgRelationships *Flag[[]string]
+	IncludeDevDeps     *Flag[bool]
+	PkgTypes           *Flag[[]string]
+	PkgRelationships   *Flag[[]string]
+	CheckPkgNames      *Flag[bool]
+	InternalNamespaces *Flag[[]string]
 }
 
 type PackageOptions struct {
-	IncludeDevDeps   bool
-	PkgTypes         []string
-	PkgRelationships []ftypes.Relationship
+	IncludeDevDeps     bool
+	PkgTypes           []string
+	PkgRelationships   []ftypes.Relationship
+	CheckPkgNames      bool
+	InternalNamespaces []string
 }
 
 func NewPackageFlagGroup() *PackageFlagGroup {
 	return &PackageFlagGroup{
-		IncludeDevDeps:   IncludeDevDepsFlag.Clone(),
-		PkgTypes:         PkgTypesFlag.Clone(),
-		PkgRelationships: PkgRelationshipsFlag.Clone(),
+		IncludeDevDeps:     IncludeDevDepsFlag.Clone(),
+		PkgTypes:           PkgTypesFlag.Clone(),
+		PkgRelationships:   PkgRelationshipsFlag.Clone(),
+		CheckPkgNames:      CheckPkgNamesFlag.Clone(),
+		InternalNamespaces: InternalNamespacesFlag.Clone(),
 	}
 }
 
@@ -66,6 +83,8 @@ func (f *PackageFlagGroup) Flags() []Flagger {
 		f.IncludeDevDeps,
 		f.PkgTypes,
 		f.PkgRelationships,
+		f.CheckPkgNames,
+		f.InternalNamespaces,
 	}
 }
 
@@ -83,9 +102,15 @@ func (f *PackageFlagGroup) ToOptions() (PackageOptions, error) {
 		relationships = append(relationships, relationship)
 	}
 
+	if len(f.InternalNamespaces.Value()) > 0 && !f.CheckPkgNames.Value() {
+		log.Warn("'--internal-namespaces' is ignored without '--check-pkg-names'")
+	}
+
 	return PackageOptions{
-		IncludeDevDeps:   f.IncludeDevDeps.Value(),
-		PkgTypes:         f.PkgTypes.Value(),
-		PkgRelationships: relationships,
+		IncludeDevDeps:     f.IncludeDevDeps.Value(),
+		PkgTypes:           f.PkgTypes.Value(),
+		PkgRelationships:   relationships,
+		CheckPkgNames:      f.CheckPkgNames.Value(),
+		InternalNamespaces: f.InternalNamespaces.Value(),
 	}, nil
 }
@@ -31,6 +31,11 @@ var (
 		ConfigName: "registry.token",
 		Usage:      "registry token",
 	}
+	RegistryCACertFlag = Flag[string]{
+		Name:       "registry-ca-cert",
+		ConfigName: "registry.ca-cert",
+		Usage:      "path to a PEM file of CA certificates trusted for registries in addition to the system ones",
+	}
 	RegistryMirrorsFlag = Flag[map[string][]string]{
 		ConfigName: "registry.mirrors",
 		Usage:      "map of hosts and registries for them.",
@@ -42,12 +47,14 @@ type RegistryFlagGroup struct {
 	Password        *Flag[[]string]
 	PasswordStdin   *Flag[bool]
 	RegistryToken   *Flag[string]
+	RegistryCACert  *Flag[string]
 	RegistryMirrors *Flag[map[string][]string]
 }
 
 type RegistryOptions struct {
 	Credentials     []types.Credential
 	RegistryToken   string
+	RegistryCACert  []byte
 	RegistryMirrors map[string][]string
 }
 
@@ -57,6 +64,7 @@ func NewRegistryFlagGroup() *RegistryFlagGroup {
 		Password:        PasswordFlag.Clone(),
 		PasswordStdin:   PasswordStdinFlag.Clone(),
 		RegistryToken:   RegistryTokenFlag.Clone(),
+		RegistryCACert:  RegistryCACertFlag.Clone(),
 		RegistryMirrors: RegistryMirrorsFlag.Clone(),
 	}
 }
@@ -71,6 +79,7 @@ func (f *RegistryFlagGroup) Flags() []Flagger {
 		f.Password,
 		f.PasswordStdin,
 		f.RegistryToken,
+		f.RegistryCACert,
 		f.RegistryMirrors,
 	}
 }
@@ -104,9 +113,19 @@ func (f *RegistryFlagGroup) ToOptions() (RegistryOptions, error) {
 		})
 	}
 
+	var caCert []byte
+	if path := f.RegistryCACert.Value(); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return RegistryOptions{}, xerrors.Errorf("failed to read the registry CA certificate: %w", err)
+		}
+		caCert = b
+	}
+
 	return RegistryOptions{
 		Credentials:     credentials,
 		RegistryToken:   f.RegistryToken.Value(),
+		RegistryCACert:  caCert,
 		RegistryMirrors: f.RegistryMirrors.Value(),
 	}, nil
 }
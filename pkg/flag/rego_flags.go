@@ -29,10 +29,15 @@ var (
 		ConfigName: "rego.trace",
 		Usage:      "enable more verbose trace output for custom queries",
 	}
+	ProfileChecksFlag = Flag[bool]{
+		Name:       "profile-checks",
+		ConfigName: "rego.profile-checks",
+		Usage:      "record the evaluation time of each check and print a summary sorted by time after the scan",
+	}
 	ConfigCheckFlag = Flag[[]string]{
 		Name:       "config-check",
 		ConfigName: "rego.check",
-		Usage:      "specify the paths to the Rego check files or to the directories containing them, applying config files",
+		Usage:      "specify the paths to the Rego check files, to the directories containing them or to OPA bundles (.tar.gz), applying config files",
 		Aliases: []Alias{
 			{Name: "policy", Deprecated: true},
 			{Name: "config-policy", Deprecated: true},
@@ -41,11 +46,21 @@ var (
 	ConfigDataFlag = Flag[[]string]{
 		Name:       "config-data",
 		ConfigName: "rego.data",
-		Usage:      "specify paths from which data for the Rego checks will be recursively loaded",
+		Usage:      "specify paths from which data for the Rego checks will be recursively loaded, or HTTPS URLs and OCI references (oci://) to download data from",
 		Aliases: []Alias{
 			{Name: "data"},
 		},
 	}
+	RepoChecksKeyFlag = Flag[string]{
+		Name:       "repo-checks-key",
+		ConfigName: "rego.repo-checks-key",
+		Usage:      "[EXPERIMENTAL] load custom checks and data from '.trivy/checks' in the scanned directory, verifying their signatures with the public key",
+	}
+	CheckBundleKeyFlag = Flag[string]{
+		Name:       "check-bundle-key",
+		ConfigName: "rego.check-bundle-key",
+		Usage:      "specify the path to the public key verifying the signatures of the OPA bundles passed to --config-check",
+	}
 	CheckNamespaceFlag = Flag[[]string]{
 		Name:       "check-namespaces",
 		ConfigName: "rego.namespaces",
@@ -62,18 +77,24 @@ type RegoFlagGroup struct {
 	IncludeDeprecatedChecks *Flag[bool]
 	SkipCheckUpdate         *Flag[bool]
 	Trace                   *Flag[bool]
+	ProfileChecks           *Flag[bool]
 	CheckPaths              *Flag[[]string]
 	DataPaths               *Flag[[]string]
 	CheckNamespaces         *Flag[[]string]
+	RepoChecksKey           *Flag[string]
+	CheckBundleKey          *Flag[string]
 }
 
 type RegoOptions struct {
 	IncludeDeprecatedChecks bool
 	SkipCheckUpdate         bool
 	Trace                   bool
+	ProfileChecks           bool
 	CheckPaths              []string
 	DataPaths               []string
 	CheckNamespaces         []string
+	RepoChecksKey           string
+	CheckBundleKey          string
 }
 
 func NewRegoFlagGroup() *RegoFlagGroup {
@@ -81,9 +102,12 @@ func NewRegoFlagGroup() *RegoFlagGroup {
 		IncludeDeprecatedChecks: IncludeDeprecatedChecksFlag.Clone(),
 		SkipCheckUpdate:         SkipCheckUpdateFlag.Clone(),
 		Trace:                   TraceFlag.Clone(),
+		ProfileChecks:           ProfileChecksFlag.Clone(),
 		CheckPaths:              ConfigCheckFlag.Clone(),
 		DataPaths:               ConfigDataFlag.Clone(),
 		CheckNamespaces:         CheckNamespaceFlag.Clone(),
+		RepoChecksKey:           RepoChecksKeyFlag.Clone(),
+		CheckBundleKey:          CheckBundleKeyFlag.Clone(),
 	}
 }
 
@@ -96,9 +120,12 @@ func (f *RegoFlagGroup) Flags() []Flagger {
 		f.IncludeDeprecatedChecks,
 		f.SkipCheckUpdate,
 		f.Trace,
+		f.ProfileChecks,
 		f.CheckPaths,
 		f.DataPaths,
 		f.CheckNamespaces,
+		f.RepoChecksKey,
+		f.CheckBundleKey,
 	}
 }
 
@@ -111,8 +138,11 @@ func (f *RegoFlagGroup) ToOptions() (RegoOptions, error) {
 		IncludeDeprecatedChecks: f.IncludeDeprecatedChecks.Value(),
 		SkipCheckUpdate:         f.SkipCheckUpdate.Value(),
 		Trace:                   f.Trace.Value(),
+		ProfileChecks:           f.ProfileChecks.Value(),
 		CheckPaths:              f.CheckPaths.Value(),
 		DataPaths:               f.DataPaths.Value(),
 		CheckNamespaces:         f.CheckNamespaces.Value(),
+		RepoChecksKey:           f.RepoChecksKey.Value(),
+		CheckBundleKey:          f.CheckBundleKey.Value(),
 	}, nil
 }
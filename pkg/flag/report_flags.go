@@ -1,7 +1,9 @@
 package flag
 
 import (
+	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/mattn/go-shellwords"
@@ -70,11 +72,37 @@ var (
 		ConfigName: "ignore-policy",
 		Usage:      "specify the Rego file path to evaluate each vulnerability",
 	}
+	BaselineFlag = Flag[string]{
+		Name:       "baseline",
+		ConfigName: "baseline",
+		Usage:      "specify a previous report in the JSON or SARIF format; findings in the report are suppressed",
+	}
+	ScoringPolicyFlag = Flag[string]{
+		Name:       "scoring-policy",
+		ConfigName: "scoring.policy",
+		Usage:      "[EXPERIMENTAL] specify the Rego file path to calculate a custom risk score for each finding",
+	}
+	AssetCriticalityFlag = Flag[string]{
+		Name:       "asset-criticality",
+		ConfigName: "scoring.asset-criticality",
+		Usage:      "[EXPERIMENTAL] criticality of the scanned asset passed to the scoring policy as 'data.asset.criticality'",
+	}
+	MinRiskScoreFlag = Flag[float64]{
+		Name:       "min-risk-score",
+		ConfigName: "scoring.min-risk-score",
+		Usage:      "[EXPERIMENTAL] hide findings with a risk score lower than the specified value",
+	}
 	ExitCodeFlag = Flag[int]{
 		Name:       "exit-code",
 		ConfigName: "exit-code",
 		Usage:      "specify exit code when any security issues are found",
 	}
+	ExitCodeMapFlag = Flag[[]string]{
+		Name:       "exit-code-map",
+		ConfigName: "exit-code-map",
+		Usage: fmt.Sprintf("[EXPERIMENTAL] exit codes per scan outcome (%s), e.g. 'findings=1,partial=3,error=2'",
+			strings.Join(xstrings.ToStringSlice(types.ExitStatuses), ",")),
+	}
 	ExitOnEOLFlag = Flag[int]{
 		Name:       "exit-on-eol",
 		ConfigName: "exit-on-eol",
@@ -109,25 +137,103 @@ var (
 		ConfigName: "scan.show-suppressed",
 		Usage:      "[EXPERIMENTAL] show suppressed vulnerabilities",
 	}
+	EarlyResultsFlag = Flag[bool]{
+		Name:       "early-results",
+		ConfigName: "early-results",
+		Usage:      "[EXPERIMENTAL] output vulnerabilities in OS packages from an additional OS-only scan running alongside the full scan (table and json formats only)",
+	}
+	DedupFindingsFlag = Flag[bool]{
+		Name:       "dedup-findings",
+		ConfigName: "dedup-findings",
+		Usage:      "[EXPERIMENTAL] output identical vulnerabilities of multiple targets once with the list of affected targets (json format only)",
+	}
+	GraphFormatFlag = Flag[string]{
+		Name:       "graph-format",
+		ConfigName: "graph-format",
+		Default:    "dot",
+		Values: []string{
+			"dot",
+			"graphml",
+			"cyclonedx",
+		},
+		Usage: "graph format of the dependency graph with \"--format graph\"",
+	}
+	CSVColumnsFlag = Flag[[]string]{
+		Name:       "csv-columns",
+		ConfigName: "csv-columns",
+		Default: []string{
+			"Target",
+			"Type",
+			"FindingType",
+			"ID",
+			"Pkg",
+			"InstalledVersion",
+			"FixedVersion",
+			"Severity",
+			"Status",
+			"Title",
+			"PrimaryURL",
+		},
+		Values: []string{
+			"Target",
+			"Class",
+			"Type",
+			"FindingType",
+			"ID",
+			"Pkg",
+			"PkgPath",
+			"InstalledVersion",
+			"FixedVersion",
+			"Severity",
+			"Status",
+			"Title",
+			"Description",
+			"PrimaryURL",
+			"StartLine",
+			"EndLine",
+		},
+		Usage: "columns of the CSV report with \"--format csv\"",
+	}
+	GitHubSubmitFlag = Flag[bool]{
+		Name:       "github-submit",
+		ConfigName: "github-submit",
+		Usage:      "[EXPERIMENTAL] submit the GitHub dependency snapshot to the repository with GITHUB_TOKEN (\"--format github\" only)",
+	}
+	SigningKeyFlag = Flag[string]{
+		Name:       "signing-key",
+		ConfigName: "signing-key",
+		Usage:      "[EXPERIMENTAL] path to a private key (PEM or cosign.key) to sign the report; the signature is written to '<output>.sig'",
+	}
 )
 
 // ReportFlagGroup composes common printer flag structs
 // used for commands requiring reporting logic.
 type ReportFlagGroup struct {
-	Format          *Flag[string]
-	ReportFormat    *Flag[string]
-	Template        *Flag[string]
-	DependencyTree  *Flag[bool]
-	ListAllPkgs     *Flag[bool]
-	IgnoreFile      *Flag[string]
-	IgnorePolicy    *Flag[string]
-	ExitCode        *Flag[int]
-	ExitOnEOL       *Flag[int]
-	Output          *Flag[string]
-	OutputPluginArg *Flag[string]
-	Severity        *Flag[[]string]
-	Compliance      *Flag[string]
-	ShowSuppressed  *Flag[bool]
+	Format           *Flag[string]
+	ReportFormat     *Flag[string]
+	Template         *Flag[string]
+	DependencyTree   *Flag[bool]
+	ListAllPkgs      *Flag[bool]
+	IgnoreFile       *Flag[string]
+	IgnorePolicy     *Flag[string]
+	Baseline         *Flag[string]
+	ScoringPolicy    *Flag[string]
+	AssetCriticality *Flag[string]
+	MinRiskScore     *Flag[float64]
+	ExitCode         *Flag[int]
+	ExitCodeMap      *Flag[[]string]
+	ExitOnEOL        *Flag[int]
+	Output           *Flag[string]
+	OutputPluginArg  *Flag[string]
+	Severity         *Flag[[]string]
+	Compliance       *Flag[string]
+	ShowSuppressed   *Flag[bool]
+	EarlyResults     *Flag[bool]
+	DedupFindings    *Flag[bool]
+	GraphFormat      *Flag[string]
+	CSVColumns       *Flag[[]string]
+	GitHubSubmit     *Flag[bool]
+	SigningKey       *Flag[string]
 }
 
 type ReportOptions struct {
@@ -138,31 +244,52 @@ type ReportOptions struct {
 	ListAllPkgs      bool
 	IgnoreFile       string
 	ExitCode         int
+	ExitCodeMap      map[types.ExitStatus]int
 	ExitOnEOL        int
 	IgnorePolicy     string
+	Baseline         string
+	ScoringPolicy    string
+	AssetCriticality string
+	MinRiskScore     float64
 	Output           string
 	OutputPluginArgs []string
 	Severities       []dbTypes.Severity
 	Compliance       spec.ComplianceSpec
 	ShowSuppressed   bool
+	EarlyResults     bool
+	DedupFindings    bool
+	GraphFormat      string
+	CSVColumns       []string
+	GitHubSubmit     bool
+	SigningKey       string
 }
 
 func NewReportFlagGroup() *ReportFlagGroup {
 	return &ReportFlagGroup{
-		Format:          FormatFlag.Clone(),
-		ReportFormat:    ReportFormatFlag.Clone(),
-		Template:        TemplateFlag.Clone(),
-		DependencyTree:  DependencyTreeFlag.Clone(),
-		ListAllPkgs:     ListAllPkgsFlag.Clone(),
-		IgnoreFile:      IgnoreFileFlag.Clone(),
-		IgnorePolicy:    IgnorePolicyFlag.Clone(),
-		ExitCode:        ExitCodeFlag.Clone(),
-		ExitOnEOL:       ExitOnEOLFlag.Clone(),
-		Output:          OutputFlag.Clone(),
-		OutputPluginArg: OutputPluginArgFlag.Clone(),
-		Severity:        SeverityFlag.Clone(),
-		Compliance:      ComplianceFlag.Clone(),
-		ShowSuppressed:  ShowSuppressedFlag.Clone(),
+		Format:           FormatFlag.Clone(),
+		ReportFormat:     ReportFormatFlag.Clone(),
+		Template:         TemplateFlag.Clone(),
+		DependencyTree:   DependencyTreeFlag.Clone(),
+		ListAllPkgs:      ListAllPkgsFlag.Clone(),
+		IgnoreFile:       IgnoreFileFlag.Clone(),
+		IgnorePolicy:     IgnorePolicyFlag.Clone(),
+		Baseline:         BaselineFlag.Clone(),
+		ScoringPolicy:    ScoringPolicyFlag.Clone(),
+		AssetCriticality: AssetCriticalityFlag.Clone(),
+		MinRiskScore:     MinRiskScoreFlag.Clone(),
+		ExitCode:         ExitCodeFlag.Clone(),
+		ExitCodeMap:      ExitCodeMapFlag.Clone(),
+		ExitOnEOL:        ExitOnEOLFlag.Clone(),
+		Output:           OutputFlag.Clone(),
+		OutputPluginArg:  OutputPluginArgFlag.Clone(),
+		Severity:         SeverityFlag.Clone(),
+		Compliance:       ComplianceFlag.Clone(),
+		ShowSuppressed:   ShowSuppressedFlag.Clone(),
+		DedupFindings:    DedupFindingsFlag.Clone(),
+		GraphFormat:      GraphFormatFlag.Clone(),
+		CSVColumns:       CSVColumnsFlag.Clone(),
+		GitHubSubmit:     GitHubSubmitFlag.Clone(),
+		SigningKey:       SigningKeyFlag.Clone(),
 	}
 }
 
@@ -179,13 +306,24 @@ func (f *ReportFlagGroup) Flags() []Flagger {
 		f.ListAllPkgs,
 		f.IgnoreFile,
 		f.IgnorePolicy,
+		f.Baseline,
+		f.ScoringPolicy,
+		f.AssetCriticality,
+		f.MinRiskScore,
 		f.ExitCode,
+		f.ExitCodeMap,
 		f.ExitOnEOL,
 		f.Output,
 		f.OutputPluginArg,
 		f.Severity,
 		f.Compliance,
 		f.ShowSuppressed,
+		f.EarlyResults,
+		f.DedupFindings,
+		f.GraphFormat,
+		f.CSVColumns,
+		f.GitHubSubmit,
+		f.SigningKey,
 	}
 }
 
@@ -227,10 +365,58 @@ func (f *ReportFlagGroup) ToOptions() (ReportOptions, error) {
 		}
 	}
 
+	// "--early-results" option is available only with "--format table" and "--format json".
+	earlyResults := f.EarlyResults.Value()
+	if earlyResults && format != types.FormatTable && format != types.FormatJSON {
+		log.Warnf(`"--early-results" is ignored because '--format %s' is specified. Use "--early-results" with "--format table" or "--format json".`, format)
+		earlyResults = false
+	}
+
+	// "--dedup-findings" option is available only with "--format json".
+	dedupFindings := f.DedupFindings.Value()
+	if dedupFindings && format != types.FormatJSON {
+		log.Warnf(`"--dedup-findings" is ignored because '--format %s' is specified. Use "--dedup-findings" with "--format json".`, format)
+		dedupFindings = false
+	}
+
+	// "--graph-format" option is available only with "--format graph".
+	graphFormat := f.GraphFormat.Value()
+	if graphFormat != "" && graphFormat != GraphFormatFlag.Default && format != types.FormatGraph {
+		log.Warnf(`"--graph-format" is ignored because '--format %s' is specified. Use "--graph-format" with "--format graph".`, format)
+	}
+
+	// "--csv-columns" option is available only with "--format csv".
+	csvColumns := f.CSVColumns.Value()
+	if len(csvColumns) > 0 && !slices.Equal(csvColumns, CSVColumnsFlag.Default) && format != types.FormatCSV {
+		log.Warnf(`"--csv-columns" is ignored because '--format %s' is specified. Use "--csv-columns" with "--format csv".`, format)
+	}
+
+	// "--github-submit" option is available only with "--format github".
+	githubSubmit := f.GitHubSubmit.Value()
+	if githubSubmit && format != types.FormatGitHub {
+		log.Warnf(`"--github-submit" is ignored because '--format %s' is specified. Use "--github-submit" with "--format github".`, format)
+		githubSubmit = false
+	}
+
 	cs, err := loadComplianceTypes(f.Compliance.Value())
 	if err != nil {
 		return ReportOptions{}, xerrors.Errorf("unable to load compliance spec: %w", err)
 	}
+	if earlyResults && cs.Spec.ID != "" {
+		log.Warn(`"--early-results" is ignored for compliance reports.`)
+		earlyResults = false
+	}
+
+	// The signature is written next to the report, so the report must be written to a file.
+	output := f.Output.Value()
+	signingKey := f.SigningKey.Value()
+	if signingKey != "" {
+		if output == "" || strings.HasPrefix(output, "plugin=") {
+			return ReportOptions{}, xerrors.New(`"--signing-key" requires "--output" to write the report to a file`)
+		} else if earlyResults {
+			return ReportOptions{}, xerrors.New(`"--signing-key" can't be used with "--early-results"`)
+		}
+	}
 
 	var outputPluginArgs []string
 	if arg := f.OutputPluginArg.Value(); arg != "" {
@@ -240,9 +426,17 @@ func (f *ReportFlagGroup) ToOptions() (ReportOptions, error) {
 		}
 	}
 
+	exitCodeMap, err := parseExitCodeMap(f.ExitCodeMap.Value())
+	if err != nil {
+		return ReportOptions{}, xerrors.Errorf("invalid exit code map: %w", err)
+	}
+
 	if viper.IsSet(f.IgnoreFile.ConfigName) && !fsutils.FileExists(f.IgnoreFile.Value()) {
 		return ReportOptions{}, xerrors.Errorf("ignore file not found: %s", f.IgnoreFile.Value())
 	}
+	if baseline := f.Baseline.Value(); baseline != "" && !fsutils.FileExists(baseline) {
+		return ReportOptions{}, xerrors.Errorf("baseline not found: %s", baseline)
+	}
 
 	return ReportOptions{
 		Format:           format,
@@ -252,16 +446,49 @@ func (f *ReportFlagGroup) ToOptions() (ReportOptions, error) {
 		ListAllPkgs:      listAllPkgs,
 		IgnoreFile:       f.IgnoreFile.Value(),
 		ExitCode:         f.ExitCode.Value(),
+		ExitCodeMap:      exitCodeMap,
 		ExitOnEOL:        f.ExitOnEOL.Value(),
 		IgnorePolicy:     f.IgnorePolicy.Value(),
-		Output:           f.Output.Value(),
+		Baseline:         f.Baseline.Value(),
+		ScoringPolicy:    f.ScoringPolicy.Value(),
+		AssetCriticality: f.AssetCriticality.Value(),
+		MinRiskScore:     f.MinRiskScore.Value(),
+		Output:           output,
 		OutputPluginArgs: outputPluginArgs,
 		Severities:       toSeverity(f.Severity.Value()),
 		Compliance:       cs,
 		ShowSuppressed:   f.ShowSuppressed.Value(),
+		EarlyResults:     earlyResults,
+		DedupFindings:    dedupFindings,
+		GraphFormat:      graphFormat,
+		CSVColumns:       csvColumns,
+		GitHubSubmit:     githubSubmit,
+		SigningKey:       signingKey,
 	}, nil
 }
 
+// parseExitCodeMap parses values such as "findings=1" and "error=2"
+func parseExitCodeMap(values []string) (map[types.ExitStatus]int, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	exitCodes := make(map[types.ExitStatus]int)
+	for _, value := range values {
+		status, code, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, xerrors.Errorf("%q must be in the format 'OUTCOME=CODE'", value)
+		} else if !slices.Contains(types.ExitStatuses, types.ExitStatus(status)) {
+			return nil, xerrors.Errorf("unknown outcome %q in %q", status, value)
+		}
+		n, err := strconv.Atoi(code)
+		if err != nil || n < 0 || n > 255 {
+			return nil, xerrors.Errorf("exit code must be between 0 and 255 in %q", value)
+		}
+		exitCodes[types.ExitStatus(status)] = n
+	}
+	return exitCodes, nil
+}
+
 func loadComplianceTypes(compliance string) (spec.ComplianceSpec, error) {
 	if compliance != "" && !slices.Contains(types.SupportedCompliances, compliance) && !strings.HasPrefix(compliance, "@") {
 		return spec.ComplianceSpec{}, xerrors.Errorf("unknown compliance : %v", compliance)
@@ -24,6 +24,7 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		ignoreUnfixed    bool
 		ignoreFile       string
 		exitCode         int
+		exitCodeMap      []string
 		exitOnEOSL       bool
 		ignorePolicy     string
 		output           string
@@ -32,6 +33,8 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		compliance       string
 		debug            bool
 		pkgTypes         string
+		earlyResults     bool
+		signingKey       string
 	}
 	tests := []struct {
 		name     string
@@ -44,6 +47,23 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 			fields: fields{},
 			want:   flag.ReportOptions{},
 		},
+		{
+			name: "happy path with an exit code map",
+			fields: fields{
+				exitCodeMap: []string{
+					"findings=1",
+					"partial=3",
+					"error=2",
+				},
+			},
+			want: flag.ReportOptions{
+				ExitCodeMap: map[types.ExitStatus]int{
+					types.ExitStatusFindings: 1,
+					types.ExitStatusPartial:  3,
+					types.ExitStatusError:    2,
+				},
+			},
+		},
 		{
 			name: "happy path with an cyclonedx",
 			fields: fields{
@@ -115,6 +135,43 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				ListAllPkgs: true,
 			},
 		},
+		{
+			name: "happy path with early results",
+			fields: fields{
+				format:       "json",
+				earlyResults: true,
+			},
+			want: flag.ReportOptions{
+				Format:       "json",
+				EarlyResults: true,
+			},
+		},
+		{
+			name: "invalid option combination: --early-results with --format sarif",
+			fields: fields{
+				format:       "sarif",
+				earlyResults: true,
+			},
+			wantLogs: []string{
+				`"--early-results" is ignored because '--format sarif' is specified. Use "--early-results" with "--format table" or "--format json".`,
+			},
+			want: flag.ReportOptions{
+				Format: "sarif",
+			},
+		},
+		{
+			name: "happy path with signing key",
+			fields: fields{
+				format:     "json",
+				output:     "result.json",
+				signingKey: "cosign.key",
+			},
+			want: flag.ReportOptions{
+				Format:     "json",
+				Output:     "result.json",
+				SigningKey: "cosign.key",
+			},
+		},
 		{
 			name: "happy path with output plugin args",
 			fields: fields{
@@ -179,11 +236,14 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 			setValue(flag.IgnoreUnfixedFlag.ConfigName, tt.fields.ignoreUnfixed)
 			setValue(flag.IgnorePolicyFlag.ConfigName, tt.fields.ignorePolicy)
 			setValue(flag.ExitCodeFlag.ConfigName, tt.fields.exitCode)
+			setSliceValue(flag.ExitCodeMapFlag.ConfigName, tt.fields.exitCodeMap)
 			setValue(flag.ExitOnEOLFlag.ConfigName, tt.fields.exitOnEOSL)
 			setValue(flag.OutputFlag.ConfigName, tt.fields.output)
 			setValue(flag.OutputPluginArgFlag.ConfigName, tt.fields.outputPluginArgs)
 			setValue(flag.SeverityFlag.ConfigName, tt.fields.severities)
 			setValue(flag.ComplianceFlag.ConfigName, tt.fields.compliance)
+			setValue(flag.EarlyResultsFlag.ConfigName, tt.fields.earlyResults)
+			setValue(flag.SigningKeyFlag.ConfigName, tt.fields.signingKey)
 
 			// Assert options
 			f := &flag.ReportFlagGroup{
@@ -194,11 +254,14 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 				IgnoreFile:      flag.IgnoreFileFlag.Clone(),
 				IgnorePolicy:    flag.IgnorePolicyFlag.Clone(),
 				ExitCode:        flag.ExitCodeFlag.Clone(),
+				ExitCodeMap:     flag.ExitCodeMapFlag.Clone(),
 				ExitOnEOL:       flag.ExitOnEOLFlag.Clone(),
 				Output:          flag.OutputFlag.Clone(),
 				OutputPluginArg: flag.OutputPluginArgFlag.Clone(),
 				Severity:        flag.SeverityFlag.Clone(),
 				Compliance:      flag.ComplianceFlag.Clone(),
+				EarlyResults:    flag.EarlyResultsFlag.Clone(),
+				SigningKey:      flag.SigningKeyFlag.Clone(),
 			}
 
 			got, err := f.ToOptions()
@@ -221,4 +284,29 @@ func TestReportFlagGroup_ToOptions(t *testing.T) {
 		_, err := f.ToOptions()
 		assert.ErrorContains(t, err, "ignore file not found: doesntexist")
 	})
+
+	t.Run("Error on signing key without output file", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+
+		setValue(flag.SigningKeyFlag.ConfigName, "cosign.key")
+		f := &flag.ReportFlagGroup{
+			Output:     flag.OutputFlag.Clone(),
+			SigningKey: flag.SigningKeyFlag.Clone(),
+		}
+
+		_, err := f.ToOptions()
+		assert.ErrorContains(t, err, `"--signing-key" requires "--output" to write the report to a file`)
+	})
+
+	t.Run("Error on invalid exit code map", func(t *testing.T) {
+		t.Cleanup(viper.Reset)
+
+		setSliceValue(flag.ExitCodeMapFlag.ConfigName, []string{"failure=1"})
+		f := &flag.ReportFlagGroup{
+			ExitCodeMap: flag.ExitCodeMapFlag.Clone(),
+		}
+
+		_, err := f.ToOptions()
+		assert.ErrorContains(t, err, `unknown outcome "failure"`)
+	})
 }
@@ -118,6 +118,11 @@ var (
 		ConfigName: "scan.distro",
 		Usage:      "[EXPERIMENTAL] specify a distribution, <family>/<version>",
 	}
+	ResumeFlag = Flag[bool]{
+		Name:       "resume",
+		ConfigName: "scan.resume",
+		Usage:      "[EXPERIMENTAL] save the progress of the scan periodically and resume it from the last checkpoint after interruption. It requires a persistent cache backend",
+	}
 )
 
 type ScanFlagGroup struct {
@@ -132,6 +137,7 @@ type ScanFlagGroup struct {
 	RekorURL          *Flag[string]
 	DetectionPriority *Flag[string]
 	DistroFlag        *Flag[string]
+	Resume            *Flag[bool] // only for filesystem scanning
 }
 
 type ScanOptions struct {
@@ -146,6 +152,7 @@ type ScanOptions struct {
 	RekorURL          string
 	DetectionPriority ftypes.DetectionPriority
 	Distro            ftypes.OS
+	Resume            bool
 }
 
 func NewScanFlagGroup() *ScanFlagGroup {
@@ -181,6 +188,7 @@ func (f *ScanFlagGroup) Flags() []Flagger {
 		f.RekorURL,
 		f.DetectionPriority,
 		f.DistroFlag,
+		f.Resume,
 	}
 }
 
@@ -224,5 +232,6 @@ func (f *ScanFlagGroup) ToOptions(args []string) (ScanOptions, error) {
 		RekorURL:          f.RekorURL.Value(),
 		DetectionPriority: ftypes.DetectionPriority(f.DetectionPriority.Value()),
 		Distro:            distro,
+		Resume:            f.Resume.Value(),
 	}, nil
 }
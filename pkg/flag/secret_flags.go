@@ -1,5 +1,18 @@
 package flag
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/docker/go-units"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/secret"
+	"github.com/aquasecurity/trivy/pkg/types"
+	xstrings "github.com/aquasecurity/trivy/pkg/x/strings"
+)
+
 var (
 	SecretConfigFlag = Flag[string]{
 		Name:       "secret-config",
@@ -7,19 +20,73 @@ var (
 		Default:    "trivy-secret.yaml",
 		Usage:      "specify a path to config file for secret scanning",
 	}
+	ValidateSecretsFlag = Flag[bool]{
+		Name:       "validate-secrets",
+		ConfigName: "secret.validate",
+		Usage:      "[EXPERIMENTAL] verify whether detected secrets are active with low-impact API calls to the issuers",
+	}
+	SecretBaselineFlag = Flag[string]{
+		Name:       "secret-baseline",
+		ConfigName: "secret.baseline",
+		Usage:      "specify a path to the baseline file; secrets in the baseline are suppressed",
+	}
+	GenerateSecretBaselineFlag = Flag[bool]{
+		Name:       "generate-secret-baseline",
+		ConfigName: "secret.generate-baseline",
+		Usage:      "write the detected secrets to the file specified with '--secret-baseline' instead of suppressing them",
+	}
+	SecretArchiveDepthFlag = Flag[int]{
+		Name:       "secret-archive-depth",
+		ConfigName: "secret.archive.depth",
+		Default:    0,
+		Usage:      "[EXPERIMENTAL] depth of nested archives (zip, jar, war, ear, tar and tar.gz) to scan for secrets; 0 disables scanning inside archives",
+	}
+	SecretArchiveMaxSizeFlag = Flag[string]{
+		Name:       "secret-archive-max-size",
+		ConfigName: "secret.archive.max-size",
+		Default:    "100MB",
+		Usage:      "[EXPERIMENTAL] maximum size of an archive and of the files extracted from it for secret scanning, specified in a human-readable format (e.g., '44kB', '17MB')",
+	}
+	SecretRedactionFlag = Flag[[]string]{
+		Name:       "secret-redaction",
+		ConfigName: "secret.redaction",
+		Default:    []string{string(secret.RedactionFull)},
+		Usage: fmt.Sprintf("how matched secrets appear in reports (%s), optionally per output format (e.g. 'partial,sarif=hash')",
+			strings.Join(xstrings.ToStringSlice(secret.Redactions), ",")),
+	}
 )
 
 type SecretFlagGroup struct {
-	SecretConfig *Flag[string]
+	SecretConfig     *Flag[string]
+	ValidateSecrets  *Flag[bool]
+	Baseline         *Flag[string]
+	GenerateBaseline *Flag[bool]
+	ArchiveDepth     *Flag[int]
+	ArchiveMaxSize   *Flag[string]
+	Redaction        *Flag[[]string]
 }
 
 type SecretOptions struct {
-	SecretConfigPath string
+	SecretConfigPath       string
+	ValidateSecrets        bool
+	SecretBaseline         string
+	GenerateSecretBaseline bool
+	ArchiveDepth           int
+	ArchiveMaxSize         int64
+
+	// SecretRedactions maps output formats to the redaction. The empty format is for the other formats.
+	SecretRedactions map[types.Format]secret.Redaction
 }
 
 func NewSecretFlagGroup() *SecretFlagGroup {
 	return &SecretFlagGroup{
-		SecretConfig: SecretConfigFlag.Clone(),
+		SecretConfig:     SecretConfigFlag.Clone(),
+		ValidateSecrets:  ValidateSecretsFlag.Clone(),
+		Baseline:         SecretBaselineFlag.Clone(),
+		GenerateBaseline: GenerateSecretBaselineFlag.Clone(),
+		ArchiveDepth:     SecretArchiveDepthFlag.Clone(),
+		ArchiveMaxSize:   SecretArchiveMaxSizeFlag.Clone(),
+		Redaction:        SecretRedactionFlag.Clone(),
 	}
 }
 
@@ -28,7 +95,15 @@ func (f *SecretFlagGroup) Name() string {
 }
 
 func (f *SecretFlagGroup) Flags() []Flagger {
-	return []Flagger{f.SecretConfig}
+	return []Flagger{
+		f.SecretConfig,
+		f.ValidateSecrets,
+		f.Baseline,
+		f.GenerateBaseline,
+		f.ArchiveDepth,
+		f.ArchiveMaxSize,
+		f.Redaction,
+	}
 }
 
 func (f *SecretFlagGroup) ToOptions() (SecretOptions, error) {
@@ -36,7 +111,64 @@ func (f *SecretFlagGroup) ToOptions() (SecretOptions, error) {
 		return SecretOptions{}, err
 	}
 
+	if f.GenerateBaseline.Value() && f.Baseline.Value() == "" {
+		return SecretOptions{}, xerrors.New("'--generate-secret-baseline' requires '--secret-baseline' to specify the output path")
+	}
+
+	var maxSize int64
+	if value := f.ArchiveMaxSize.Value(); value != "" {
+		parsedSize, err := units.FromHumanSize(value)
+		if err != nil {
+			return SecretOptions{}, xerrors.Errorf("invalid max archive size %q: %w", value, err)
+		}
+		maxSize = parsedSize
+	}
+
+	redactions, err := parseSecretRedactions(f.Redaction.Value())
+	if err != nil {
+		return SecretOptions{}, xerrors.Errorf("invalid secret redaction: %w", err)
+	}
+
 	return SecretOptions{
-		SecretConfigPath: f.SecretConfig.Value(),
+		SecretConfigPath:       f.SecretConfig.Value(),
+		ValidateSecrets:        f.ValidateSecrets.Value(),
+		SecretBaseline:         f.Baseline.Value(),
+		GenerateSecretBaseline: f.GenerateBaseline.Value(),
+		ArchiveDepth:           f.ArchiveDepth.Value(),
+		ArchiveMaxSize:         maxSize,
+		SecretRedactions:       redactions,
 	}, nil
 }
+
+// SecretRedaction returns the redaction for the output format.
+// It returns an empty string for the full redaction, which is the default of the scanner.
+func (o SecretOptions) SecretRedaction(format types.Format) string {
+	r, ok := o.SecretRedactions[format]
+	if !ok {
+		r = o.SecretRedactions[""]
+	}
+	if r == secret.RedactionFull {
+		return ""
+	}
+	return string(r)
+}
+
+// parseSecretRedactions parses values such as "partial" and "sarif=hash"
+func parseSecretRedactions(values []string) (map[types.Format]secret.Redaction, error) {
+	redactions := make(map[types.Format]secret.Redaction)
+	for _, value := range values {
+		var format types.Format
+		mode := value
+		if f, m, ok := strings.Cut(value, "="); ok {
+			format, mode = types.Format(f), m
+			if !slices.Contains(types.SupportedFormats, format) {
+				return nil, xerrors.Errorf("unknown format %q in %q", format, value)
+			}
+		}
+		if !slices.Contains(secret.Redactions, secret.Redaction(mode)) {
+			return nil, xerrors.Errorf("unknown redaction %q in %q", mode, value)
+		}
+		redactions[format] = secret.Redaction(mode)
+	}
+	return redactions, nil
+}
@@ -0,0 +1,75 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestSecretFlagGroup_ToOptions_Redaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		redactions []string
+		format     types.Format
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "default",
+			redactions: []string{"full"},
+			format:     types.FormatTable,
+			want:       "",
+		},
+		{
+			name:       "all formats",
+			redactions: []string{"partial"},
+			format:     types.FormatJSON,
+			want:       "partial",
+		},
+		{
+			name: "per format",
+			redactions: []string{
+				"partial",
+				"sarif=hash",
+			},
+			format: types.FormatSarif,
+			want:   "hash",
+		},
+		{
+			name:       "other format",
+			redactions: []string{"sarif=hash"},
+			format:     types.FormatTable,
+			want:       "",
+		},
+		{
+			name:       "unknown redaction",
+			redactions: []string{"none"},
+			wantErr:    `unknown redaction "none"`,
+		},
+		{
+			name:       "unknown format",
+			redactions: []string{"xml=hash"},
+			wantErr:    `unknown format "xml"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+			viper.Set(flag.SecretRedactionFlag.ConfigName, tt.redactions)
+
+			f := flag.NewSecretFlagGroup()
+			got, err := f.ToOptions()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.SecretRedaction(tt.format))
+		})
+	}
+}
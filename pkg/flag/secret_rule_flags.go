@@ -0,0 +1,49 @@
+package flag
+
+var (
+	SecretRuleCasesFlag = Flag[string]{
+		Name:       "cases",
+		ConfigName: "secret.test.cases",
+		Usage:      "specify a path to the directory containing positive and negative samples for each rule",
+	}
+)
+
+// SecretRuleTestFlagGroup composes flags for testing custom secret rules
+type SecretRuleTestFlagGroup struct {
+	Cases *Flag[string]
+}
+
+type SecretRuleTestOptions struct {
+	SecretRulesPath string
+	SecretCasesDir  string
+}
+
+func NewSecretRuleTestFlagGroup() *SecretRuleTestFlagGroup {
+	return &SecretRuleTestFlagGroup{
+		Cases: SecretRuleCasesFlag.Clone(),
+	}
+}
+
+func (f *SecretRuleTestFlagGroup) Name() string {
+	return "Secret Rule Test"
+}
+
+func (f *SecretRuleTestFlagGroup) Flags() []Flagger {
+	return []Flagger{f.Cases}
+}
+
+func (f *SecretRuleTestFlagGroup) ToOptions(args []string) (SecretRuleTestOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return SecretRuleTestOptions{}, err
+	}
+
+	var rulesPath string
+	if len(args) == 1 {
+		rulesPath = args[0]
+	}
+
+	return SecretRuleTestOptions{
+		SecretRulesPath: rulesPath,
+		SecretCasesDir:  f.Cases.Value(),
+	}, nil
+}
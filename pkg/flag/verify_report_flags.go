@@ -0,0 +1,66 @@
+package flag
+
+var (
+	VerifyReportKeyFlag = Flag[string]{
+		Name:       "key",
+		ConfigName: "verify-report.key",
+		Usage:      "path to the public key (PEM) to verify the signature",
+	}
+	VerifyReportSignatureFlag = Flag[string]{
+		Name:       "signature",
+		ConfigName: "verify-report.signature",
+		Usage:      "path to the signature (default: '<report>.sig')",
+	}
+)
+
+// VerifyReportFlagGroup composes flags for verifying signed reports
+type VerifyReportFlagGroup struct {
+	Key       *Flag[string]
+	Signature *Flag[string]
+}
+
+type VerifyReportOptions struct {
+	ReportPath      string
+	VerifyKey       string
+	ReportSignature string
+}
+
+func NewVerifyReportFlagGroup() *VerifyReportFlagGroup {
+	return &VerifyReportFlagGroup{
+		Key:       VerifyReportKeyFlag.Clone(),
+		Signature: VerifyReportSignatureFlag.Clone(),
+	}
+}
+
+func (f *VerifyReportFlagGroup) Name() string {
+	return "Verify Report"
+}
+
+func (f *VerifyReportFlagGroup) Flags() []Flagger {
+	return []Flagger{
+		f.Key,
+		f.Signature,
+	}
+}
+
+func (f *VerifyReportFlagGroup) ToOptions(args []string) (VerifyReportOptions, error) {
+	if err := parseFlags(f); err != nil {
+		return VerifyReportOptions{}, err
+	}
+
+	var reportPath string
+	if len(args) == 1 {
+		reportPath = args[0]
+	}
+
+	signature := f.Signature.Value()
+	if signature == "" && reportPath != "" {
+		signature = reportPath + ".sig"
+	}
+
+	return VerifyReportOptions{
+		ReportPath:      reportPath,
+		VerifyKey:       f.Key.Value(),
+		ReportSignature: signature,
+	}, nil
+}
@@ -23,7 +23,7 @@ var (
 	VEXFlag = Flag[[]string]{
 		Name:       "vex",
 		ConfigName: "vulnerability.vex",
-		Usage:      `[EXPERIMENTAL] VEX sources ("repo", "oci" or file path)`,
+		Usage:      `[EXPERIMENTAL] VEX sources ("repo", "oci", "sbom-ref" or file path)`,
 	}
 	SkipVEXRepoUpdateFlag = Flag[bool]{
 		Name:       "skip-vex-repo-update",
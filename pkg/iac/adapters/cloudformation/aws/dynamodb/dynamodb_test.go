@@ -23,6 +23,14 @@ Resources:
     Properties:
       SSESpecification:
         SSEEnabled: true
+  table:
+    Type: AWS::DynamoDB::Table
+    Properties:
+      SSESpecification:
+        SSEEnabled: true
+        KMSMasterKeyId: key
+      PointInTimeRecoverySpecification:
+        PointInTimeRecoveryEnabled: true
 `,
 			expected: dynamodb.DynamoDB{
 				DAXClusters: []dynamodb.DAXCluster{
@@ -32,6 +40,15 @@ Resources:
 						},
 					},
 				},
+				Tables: []dynamodb.Table{
+					{
+						ServerSideEncryption: dynamodb.ServerSideEncryption{
+							Enabled:  types.BoolTest(true),
+							KMSKeyID: types.StringTest("key"),
+						},
+						PointInTimeRecovery: types.BoolTest(true),
+					},
+				},
 			},
 		},
 		{
@@ -40,9 +57,12 @@ Resources:
 Resources:
   daxCluster:
     Type: AWS::DAX::Cluster
+  table:
+    Type: AWS::DynamoDB::Table
   `,
 			expected: dynamodb.DynamoDB{
 				DAXClusters: []dynamodb.DAXCluster{{}},
+				Tables:      []dynamodb.Table{{}},
 			},
 		},
 	}
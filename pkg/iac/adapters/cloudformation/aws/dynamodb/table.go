@@ -0,0 +1,36 @@
+package dynamodb
+
+import (
+	"github.com/aquasecurity/trivy/pkg/iac/providers/aws/dynamodb"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/cloudformation/parser"
+	iacTypes "github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+func getTables(file parser.FileContext) (tables []dynamodb.Table) {
+
+	tableResources := file.GetResourcesByType("AWS::DynamoDB::Table")
+
+	for _, r := range tableResources {
+		table := dynamodb.Table{
+			Metadata: r.Metadata(),
+			ServerSideEncryption: dynamodb.ServerSideEncryption{
+				Metadata: r.Metadata(),
+				Enabled:  iacTypes.BoolDefault(false, r.Metadata()),
+				KMSKeyID: iacTypes.StringDefault("", r.Metadata()),
+			},
+			PointInTimeRecovery: r.GetBoolProperty("PointInTimeRecoverySpecification.PointInTimeRecoveryEnabled"),
+		}
+
+		if sseProp := r.GetProperty("SSESpecification"); sseProp.IsNotNil() {
+			table.ServerSideEncryption = dynamodb.ServerSideEncryption{
+				Metadata: sseProp.Metadata(),
+				Enabled:  sseProp.GetBoolProperty("SSEEnabled"),
+				KMSKeyID: sseProp.GetStringProperty("KMSMasterKeyId"),
+			}
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables
+}
@@ -10,7 +10,11 @@ import (
 	"github.com/xeipuuv/gojsonschema"
 	"gopkg.in/yaml.v3"
 
+	ansibleparser "github.com/aquasecurity/trivy/pkg/iac/scanners/ansible/parser"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure/arm/parser/armjson"
+	azurepipelinesparser "github.com/aquasecurity/trivy/pkg/iac/scanners/azurepipelines/parser"
+	githubactionsparser "github.com/aquasecurity/trivy/pkg/iac/scanners/githubactions/parser"
+	gitlabciparser "github.com/aquasecurity/trivy/pkg/iac/scanners/gitlabci/parser"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/snapshot"
 	"github.com/aquasecurity/trivy/pkg/iac/types"
 	"github.com/aquasecurity/trivy/pkg/log"
@@ -31,6 +35,14 @@ const (
 	FileTypeJSON                  FileType = "json"
 	FileTypeHelm                  FileType = "helm"
 	FileTypeAzureARM              FileType = "azure-arm"
+	FileTypeBicep                 FileType = "bicep"
+	FileTypePulumi                FileType = "pulumi"
+	FileTypeAnsible               FileType = "ansible"
+	FileTypeGitHubActions         FileType = "github-actions"
+	FileTypeGitLabCI              FileType = "gitlab-ci"
+	FileTypeAzurePipelines        FileType = "azure-pipelines"
+	FileTypeCUE                   FileType = "cue"
+	FileTypeJsonnet               FileType = "jsonnet"
 )
 
 var matchers = make(map[FileType]func(name string, r io.ReadSeeker) bool)
@@ -134,6 +146,10 @@ func init() {
 		return sniff.Resources != nil
 	}
 
+	matchers[FileTypeBicep] = func(name string, _ io.ReadSeeker) bool {
+		return IsBicepFile(name)
+	}
+
 	matchers[FileTypeAzureARM] = func(name string, r io.ReadSeeker) bool {
 
 		if resetReader(r) == nil {
@@ -158,6 +174,91 @@ func init() {
 		return len(sniff.Parameters) > 0 || len(sniff.Resources) > 0
 	}
 
+	matchers[FileTypeAnsible] = func(name string, r io.ReadSeeker) bool {
+		// Files of roles and inventories are recognized by their location
+		if ansibleparser.IsRoleFile(name) || ansibleparser.IsVarsFile(name) || ansibleparser.IsInventoryFile(name) {
+			return true
+		}
+		if !isYAML(name) || resetReader(r) == nil {
+			return false
+		}
+		return ansibleparser.IsPlaybook(r)
+	}
+
+	matchers[FileTypeGitHubActions] = func(name string, r io.ReadSeeker) bool {
+		if !isYAML(name) || resetReader(r) == nil {
+			return false
+		}
+		return githubactionsparser.IsWorkflow(r)
+	}
+
+	matchers[FileTypeGitLabCI] = func(name string, r io.ReadSeeker) bool {
+		// Files included by ".gitlab-ci.yml" are recognized by their content
+		if gitlabciparser.IsPipelineFile(name) {
+			return true
+		}
+		if !isYAML(name) || resetReader(r) == nil {
+			return false
+		}
+		return gitlabciparser.IsPipelineConfig(r)
+	}
+
+	matchers[FileTypeAzurePipelines] = func(name string, r io.ReadSeeker) bool {
+		// Templates are recognized by their content
+		if azurepipelinesparser.IsPipelineFile(name) {
+			return true
+		}
+		if !isYAML(name) || resetReader(r) == nil {
+			return false
+		}
+		return azurepipelinesparser.IsPipelineConfig(r)
+	}
+
+	matchers[FileTypeCUE] = func(name string, _ io.ReadSeeker) bool {
+		return strings.EqualFold(filepath.Ext(name), ".cue")
+	}
+
+	matchers[FileTypeJsonnet] = func(name string, _ io.ReadSeeker) bool {
+		ext := strings.ToLower(filepath.Ext(name))
+		return ext == ".jsonnet" || ext == ".libsonnet"
+	}
+
+	matchers[FileTypePulumi] = func(name string, r io.ReadSeeker) bool {
+		if !IsType(name, r, FileTypeYAML) && !IsType(name, r, FileTypeJSON) {
+			return false
+		}
+		if resetReader(r) == nil {
+			return false
+		}
+
+		// Pulumi YAML programs and the output of `pulumi preview --json`
+		sniff := struct {
+			Resources map[string]struct {
+				Type string `yaml:"type"`
+			} `yaml:"resources"`
+			Steps []struct {
+				URN string `yaml:"urn"`
+			} `yaml:"steps"`
+		}{}
+		// JSON is valid YAML
+		if err := yaml.NewDecoder(r).Decode(&sniff); err != nil {
+			return false
+		}
+
+		for _, res := range sniff.Resources {
+			// type tokens are "<package>:<module>:<type>"
+			if strings.Count(res.Type, ":") == 2 {
+				return true
+			}
+		}
+		for _, step := range sniff.Steps {
+			if strings.HasPrefix(step.URN, "urn:pulumi:") {
+				return true
+			}
+		}
+		return false
+	}
+
 	matchers[FileTypeDockerfile] = func(name string, _ io.ReadSeeker) bool {
 		requiredFiles := []string{"Dockerfile", "Containerfile"}
 		for _, requiredFile := range requiredFiles {
@@ -258,6 +359,11 @@ func IsTerraformFile(path string) bool {
 		return true
 	}
 
+	// Terragrunt configurations
+	if base := filepath.Base(path); base == "terragrunt.hcl" || base == "root.hcl" {
+		return true
+	}
+
 	for _, ext := range []string{".tf", ".tf.json", ".tfvars"} {
 		if strings.HasSuffix(path, ext) {
 			return true
@@ -267,6 +373,12 @@ func IsTerraformFile(path string) bool {
 	return false
 }
 
+// IsBicepFile checks if the file is a Bicep file or a Bicep parameters file
+func IsBicepFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".bicep" || ext == ".bicepparam"
+}
+
 func IsType(name string, r io.ReadSeeker, t FileType) bool {
 	r = ensureSeeker(r)
 	f, ok := matchers[t]
@@ -420,6 +420,190 @@ rules:
 				FileTypeJSON,
 			},
 		},
+		{
+			name: "Bicep, no reader",
+			path: "main.bicep",
+			expected: []FileType{
+				FileTypeBicep,
+			},
+		},
+		{
+			name: "Bicep parameters, no reader",
+			path: "main.bicepparam",
+			expected: []FileType{
+				FileTypeBicep,
+			},
+		},
+		{
+			name: "Pulumi YAML program",
+			path: "Pulumi.yaml",
+			r: strings.NewReader(`
+name: app
+runtime: yaml
+resources:
+  logs:
+    type: aws:s3:Bucket
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeHelm,
+				FileTypePulumi,
+			},
+		},
+		{
+			name: "Pulumi preview output",
+			path: "preview.json",
+			r: strings.NewReader(`
+{
+  "steps": [
+    {
+      "op": "create",
+      "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs"
+    }
+  ]
+}
+`),
+			expected: []FileType{
+				FileTypeJSON,
+				FileTypePulumi,
+			},
+		},
+		{
+			name: "Ansible playbook",
+			path: "site.yml",
+			r: strings.NewReader(`
+- hosts: webservers
+  become: true
+  tasks:
+    - name: install nginx
+      apt: name=nginx
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeAnsible,
+			},
+		},
+		{
+			name: "Ansible role tasks",
+			path: "roles/nginx/tasks/main.yml",
+			r: strings.NewReader(`
+- name: install nginx
+  apt: name=nginx
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeAnsible,
+			},
+		},
+		{
+			name: "Ansible inventory, no reader",
+			path: "inventories/production/hosts",
+			expected: []FileType{
+				FileTypeAnsible,
+			},
+		},
+		{
+			name: "GitHub Actions workflow",
+			path: ".github/workflows/ci.yml",
+			r: strings.NewReader(`
+on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeGitHubActions,
+			},
+		},
+		{
+			name: "CUE file",
+			path: "config/app.cue",
+			r: strings.NewReader(`
+package config
+
+replicas: 3
+`),
+			expected: []FileType{
+				FileTypeCUE,
+			},
+		},
+		{
+			name: "Jsonnet library",
+			path: "lib/k.libsonnet",
+			r: strings.NewReader(`
+{
+  deployment(name):: { apiVersion: 'apps/v1', kind: 'Deployment', metadata: { name: name } },
+}
+`),
+			expected: []FileType{
+				FileTypeJsonnet,
+			},
+		},
+		{
+			name: "GitLab CI pipeline",
+			path: ".gitlab-ci.yml",
+			r: strings.NewReader(`
+include:
+  - local: ci/build.yml
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeGitLabCI,
+			},
+		},
+		{
+			name: "GitLab CI included file",
+			path: "ci/build.yml",
+			r: strings.NewReader(`
+build:
+  image: golang:1.23
+  script:
+    - go build ./...
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeGitLabCI,
+			},
+		},
+		{
+			name: "Azure Pipelines pipeline",
+			path: "azure-pipelines.yml",
+			r: strings.NewReader(`
+extends:
+  template: pipeline.yml@templates
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeAzurePipelines,
+			},
+		},
+		{
+			name: "Azure Pipelines template",
+			path: "templates/steps.yml",
+			r: strings.NewReader(`
+steps:
+  - task: GoTool@0
+  - script: go build ./...
+`),
+			expected: []FileType{
+				FileTypeYAML,
+				FileTypeAzurePipelines,
+			},
+		},
+		{
+			name: "YAML list without plays",
+			path: "list.yml",
+			r: strings.NewReader(`
+- name: item
+  hosts: 1
+`),
+			expected: []FileType{
+				FileTypeYAML,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,129 @@
+package rego
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"maps"
+	"slices"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/samber/lo"
+)
+
+const (
+	// moduleCacheSize is several times the number of the embedded checks and libraries,
+	// so that custom checks don't evict them
+	moduleCacheSize = 4096
+	// compilerCacheSize bounds the compilers kept for the sets of checks, which hold the compiled rules of all the checks
+	compilerCacheSize = 16
+)
+
+// The scanners of different sources, e.g. JSON, YAML, Terraform and Kubernetes, load the same checks.
+// Parsed modules and compilers are cached within the process, so that the checks are parsed and compiled once.
+// The caches are bounded, as long-running processes may load different checks over time.
+var (
+	// parsedModules maps the digests of the module sources to the parsed modules
+	parsedModules = lo.Must(lru.New[string, *ast.Module](moduleCacheSize))
+	// moduleDigests maps the parsed modules to the digests of their sources
+	moduleDigests = lo.Must(lru.New[*ast.Module, string](moduleCacheSize))
+
+	compilers = &compilerCache{
+		compilers: lo.Must(lru.New[string, *ast.Compiler](compilerCacheSize)),
+	}
+)
+
+// parseModule parses the Rego module, or returns the module parsed from the same source.
+// The returned module is shared and must not be modified.
+func parseModule(name string, data []byte) (*ast.Module, error) {
+	h := sha256.New()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write(data)
+	digest := hex.EncodeToString(h.Sum(nil))
+
+	if module, ok := parsedModules.Get(digest); ok {
+		return module, nil
+	}
+
+	module, err := ast.ParseModuleWithOpts(name, string(data), ast.ParserOptions{
+		ProcessAnnotation: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if actual, ok, _ := parsedModules.PeekOrAdd(digest, module); ok {
+		module = actual
+	}
+	moduleDigests.Add(module, digest)
+	return module, nil
+}
+
+type compilerCache struct {
+	compilers *lru.Cache[string, *ast.Compiler]
+}
+
+// compile compiles the modules with the schemas, or returns the compiler which compiled the same modules and schemas.
+// Compilers are shared between scanners and only used for evaluation.
+// Compilers which failed are not cached, as the modules with errors are pruned and compiled again.
+func (c *compilerCache) compile(modules map[string]*ast.Module, schemaSet *ast.SchemaSet) *ast.Compiler {
+	key, ok := compilerKey(modules, schemaSet)
+	if ok {
+		if compiler, found := c.compilers.Get(key); found {
+			return compiler
+		}
+	}
+
+	compiler := ast.NewCompiler().
+		WithUseTypeCheckAnnotations(true).
+		WithCapabilities(ast.CapabilitiesForThisVersion()).
+		WithSchemas(schemaSet)
+	compiler.Compile(modules)
+
+	if ok && !compiler.Failed() {
+		c.compilers.Add(key, compiler)
+	}
+	return compiler
+}
+
+// compilerKey returns the digest of the modules and the schemas referred to by them.
+// Modules not loaded by parseModule, or evicted from the cache, cannot be identified, and false is returned.
+func compilerKey(modules map[string]*ast.Module, schemaSet *ast.SchemaSet) (string, bool) {
+	h := sha256.New()
+
+	schemaRefs := []string{"schema.input"}
+	for _, name := range slices.Sorted(maps.Keys(modules)) {
+		module := modules[name]
+		digest, ok := moduleDigests.Get(module)
+		if !ok {
+			return "", false
+		}
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(digest))
+		h.Write([]byte{0})
+
+		for _, annotation := range module.Annotations {
+			for _, ss := range annotation.Schemas {
+				if ss.Schema != nil {
+					schemaRefs = append(schemaRefs, ss.Schema.String())
+				}
+			}
+		}
+	}
+
+	for _, ref := range lo.Uniq(slices.Sorted(slices.Values(schemaRefs))) {
+		schema := schemaSet.Get(ast.MustParseRef(ref))
+		b, err := json.Marshal(schema)
+		if err != nil {
+			return "", false
+		}
+		h.Write([]byte(ref))
+		h.Write([]byte{0})
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
@@ -0,0 +1,62 @@
+package rego
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+func TestParseModule(t *testing.T) {
+	src := []byte(`package user.test
+
+deny := true
+`)
+
+	m1, err := parseModule("test.rego", src)
+	require.NoError(t, err)
+	m2, err := parseModule("test.rego", src)
+	require.NoError(t, err)
+	assert.Same(t, m1, m2)
+
+	m3, err := parseModule("other.rego", src)
+	require.NoError(t, err)
+	assert.NotSame(t, m1, m3)
+
+	_, err = parseModule("invalid.rego", []byte("package"))
+	require.Error(t, err)
+
+	// The least recently used modules are evicted
+	for i := range moduleCacheSize {
+		_, err = parseModule(fmt.Sprintf("evict%d.rego", i), src)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, moduleCacheSize, parsedModules.Len())
+
+	m4, err := parseModule("test.rego", src)
+	require.NoError(t, err)
+	assert.NotSame(t, m1, m4)
+
+	// The modules evicted from the cache cannot be identified and their compilers are not cached
+	_, ok := compilerKey(map[string]*ast.Module{"test.rego": m1}, ast.NewSchemaSet())
+	assert.False(t, ok)
+	_, ok = compilerKey(map[string]*ast.Module{"test.rego": m4}, ast.NewSchemaSet())
+	assert.True(t, ok)
+}
+
+func TestCompilerCache(t *testing.T) {
+	newScanner := func(source types.Source) *Scanner {
+		s := NewScanner(source, WithEmbeddedPolicies(true), WithEmbeddedLibraries(true))
+		require.NoError(t, s.LoadPolicies(nil))
+		return s
+	}
+
+	// The checks are compiled once and shared by the scanners of different sources
+	dockerfile := newScanner(types.SourceDockerfile)
+	assert.Same(t, dockerfile.compiler, newScanner(types.SourceDockerfile).compiler)
+	assert.Same(t, dockerfile.compiler, newScanner(types.SourceKubernetes).compiler)
+}
@@ -104,9 +104,7 @@ func LoadPoliciesFromDirs(target fs.FS, paths ...string) (map[string]*ast.Module
 			if err != nil {
 				return err
 			}
-			module, err := ast.ParseModuleWithOpts(path, string(data), ast.ParserOptions{
-				ProcessAnnotation: true,
-			})
+			module, err := parseModule(path, data)
 			if err != nil {
 				return fmt.Errorf("failed to parse Rego module: %w", err)
 			}
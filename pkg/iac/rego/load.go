@@ -43,9 +43,7 @@ func (s *Scanner) loadPoliciesFromReaders(readers []io.Reader) (map[string]*ast.
 		if err != nil {
 			return nil, err
 		}
-		module, err := ast.ParseModuleWithOpts(moduleName, string(data), ast.ParserOptions{
-			ProcessAnnotation: true,
-		})
+		module, err := parseModule(moduleName, data)
 		if err != nil {
 			return nil, err
 		}
@@ -241,12 +239,7 @@ func (s *Scanner) compilePolicies(srcFS fs.FS, paths []string) error {
 		s.inputSchema = nil // discard auto detected input schema in favor of check defined schema
 	}
 
-	compiler := ast.NewCompiler().
-		WithUseTypeCheckAnnotations(true).
-		WithCapabilities(ast.CapabilitiesForThisVersion()).
-		WithSchemas(schemaSet)
-
-	compiler.Compile(s.policies)
+	compiler := compilers.compile(s.policies, schemaSet)
 	if compiler.Failed() {
 		s.fallbackChecks(compiler)
 		if err := s.prunePoliciesWithError(compiler); err != nil {
@@ -262,8 +255,8 @@ func (s *Scanner) compilePolicies(srcFS fs.FS, paths []string) error {
 	if s.inputSchema != nil {
 		schemaSet := ast.NewSchemaSet()
 		schemaSet.Put(ast.MustParseRef("schema.input"), s.inputSchema)
-		compiler.WithSchemas(schemaSet)
-		compiler.Compile(s.policies)
+		// compilers are shared, so the filtered modules are compiled by another compiler
+		compiler = compilers.compile(s.policies, schemaSet)
 		if compiler.Failed() {
 			if err := s.prunePoliciesWithError(compiler); err != nil {
 				return err
@@ -273,6 +266,11 @@ func (s *Scanner) compilePolicies(srcFS fs.FS, paths []string) error {
 	}
 	s.compiler = compiler
 	s.retriever = retriever
+
+	// queries prepared for the previous compiler are no longer valid
+	s.queriesMu.Lock()
+	s.preparedQueries = nil
+	s.queriesMu.Unlock()
 	return nil
 }
 
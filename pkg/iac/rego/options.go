@@ -49,6 +49,15 @@ func WithPerResultTracing(enabled bool) options.ScannerOption {
 	}
 }
 
+// WithProfiler records the time spent evaluating each check in the profiler
+func WithProfiler(p *Profiler) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if ss, ok := s.(*Scanner); ok {
+			ss.profiler = p
+		}
+	}
+}
+
 func WithPolicyDirs(paths ...string) options.ScannerOption {
 	return func(s options.ConfigurableScanner) {
 		if ss, ok := s.(*Scanner); ok {
@@ -0,0 +1,82 @@
+package rego
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// CheckProfile is the time spent evaluating a check
+type CheckProfile struct {
+	Namespace string
+	ID        string
+	// Inputs is the number of inputs the check is evaluated against
+	Inputs   int
+	Duration time.Duration
+}
+
+// Profiler records the time spent evaluating each check, across all the scanners it is passed to,
+// so that the checks responsible for slow scans can be found.
+type Profiler struct {
+	mu     sync.Mutex
+	checks map[string]*CheckProfile
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		checks: make(map[string]*CheckProfile),
+	}
+}
+
+func (p *Profiler) record(namespace, id string, inputs int, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	profile, ok := p.checks[namespace]
+	if !ok {
+		profile = &CheckProfile{
+			Namespace: namespace,
+			ID:        id,
+		}
+		p.checks[namespace] = profile
+	}
+	profile.Inputs += inputs
+	profile.Duration += d
+}
+
+// Profiles returns the profiles of the evaluated checks, the slowest first
+func (p *Profiler) Profiles() []CheckProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	profiles := make([]CheckProfile, 0, len(p.checks))
+	for _, profile := range p.checks {
+		profiles = append(profiles, *profile)
+	}
+	slices.SortFunc(profiles, func(a, b CheckProfile) int {
+		return cmp.Or(
+			cmp.Compare(b.Duration, a.Duration),
+			cmp.Compare(a.Namespace, b.Namespace),
+		)
+	})
+	return profiles
+}
+
+// Write writes a table of the profiles of the evaluated checks, the slowest first
+func (p *Profiler) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tNAMESPACE\tINPUTS\tTOTAL\tAVERAGE")
+	for _, profile := range p.Profiles() {
+		var avg time.Duration
+		if profile.Inputs > 0 {
+			avg = profile.Duration / time.Duration(profile.Inputs)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", profile.ID, profile.Namespace, profile.Inputs,
+			profile.Duration.Round(time.Microsecond), avg.Round(time.Microsecond))
+	}
+	return tw.Flush()
+}
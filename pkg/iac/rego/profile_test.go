@@ -0,0 +1,78 @@
+package rego_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+func TestProfiler(t *testing.T) {
+	srcFS := CreateFS(t, map[string]string{
+		"policies/evil.rego": `# METADATA
+# custom:
+#   avd_id: AVD-TEST-0001
+package defsec.evil
+
+deny {
+    input.evil
+}
+`,
+		"policies/bad.rego": `# METADATA
+# custom:
+#   id: TEST002
+package defsec.bad
+
+deny {
+    input.bad
+}
+`,
+	})
+
+	profiler := rego.NewProfiler()
+	scanner := rego.NewScanner(
+		types.SourceJSON,
+		rego.WithPolicyDirs("policies"),
+		rego.WithProfiler(profiler),
+	)
+	require.NoError(t, scanner.LoadPolicies(srcFS))
+
+	inputs := []rego.Input{
+		{Path: "a.json", Contents: map[string]any{"evil": true}},
+		{Path: "b.json", Contents: map[string]any{"bad": true}},
+	}
+	_, err := scanner.ScanInput(context.TODO(), inputs...)
+	require.NoError(t, err)
+	_, err = scanner.ScanInput(context.TODO(), inputs[0])
+	require.NoError(t, err)
+
+	profiles := profiler.Profiles()
+	require.Len(t, profiles, 2)
+	for i, profile := range profiles {
+		assert.Equal(t, 3, profile.Inputs)
+		assert.Positive(t, profile.Duration)
+		if i > 0 {
+			assert.GreaterOrEqual(t, profiles[i-1].Duration, profile.Duration)
+		}
+	}
+
+	ids := map[string]string{
+		profiles[0].Namespace: profiles[0].ID,
+		profiles[1].Namespace: profiles[1].ID,
+	}
+	assert.Equal(t, map[string]string{
+		"defsec.evil": "AVD-TEST-0001",
+		"defsec.bad":  "TEST002",
+	}, ids)
+
+	var buf bytes.Buffer
+	require.NoError(t, profiler.Write(&buf))
+	assert.Contains(t, buf.String(), "CHECK")
+	assert.Contains(t, buf.String(), "AVD-TEST-0001")
+	assert.Contains(t, buf.String(), "defsec.bad")
+}
@@ -2,12 +2,15 @@ package rego
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
@@ -67,6 +70,13 @@ type Scanner struct {
 	customSchemas  map[string][]byte
 
 	disabledCheckIDs set.Set[string]
+
+	profiler *Profiler
+
+	// preparedQueries caches the rule queries prepared for the compiler, the store and the input schema of the scanner,
+	// so that the queries are parsed, compiled and planned once rather than for each input.
+	queriesMu       sync.Mutex
+	preparedQueries map[string]rego.PreparedEvalQuery
 }
 
 func (s *Scanner) trace(heading string, input any) {
@@ -121,6 +131,21 @@ func NewScanner(source types.Source, opts ...options.ScannerOption) *Scanner {
 func (s *Scanner) runQuery(ctx context.Context, query string, input ast.Value, disableTracing bool) (rego.ResultSet, []string, error) {
 
 	trace := (s.traceWriter != nil || s.tracePerResult) && !disableTracing
+	if !trace {
+		pq, err := s.preparedQuery(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+		var evalOptions []rego.EvalOption
+		if input != nil {
+			evalOptions = append(evalOptions, rego.EvalParsedInput(input))
+		}
+		resultSet, err := pq.Eval(ctx, evalOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resultSet, nil, nil
+	}
 
 	regoOptions := []func(*rego.Rego){
 		rego.Query(query),
@@ -162,6 +187,38 @@ func (s *Scanner) runQuery(ctx context.Context, query string, input ast.Value, d
 	return resultSet, traces, nil
 }
 
+// preparedQuery returns the query prepared for evaluation, preparing it on first use
+func (s *Scanner) preparedQuery(ctx context.Context, query string) (rego.PreparedEvalQuery, error) {
+	s.queriesMu.Lock()
+	defer s.queriesMu.Unlock()
+
+	if pq, ok := s.preparedQueries[query]; ok {
+		return pq, nil
+	}
+
+	regoOptions := []func(*rego.Rego){
+		rego.Query(query),
+		rego.Compiler(s.compiler),
+		rego.Store(s.store),
+		rego.Runtime(s.runtimeValues),
+	}
+	if s.inputSchema != nil {
+		schemaSet := ast.NewSchemaSet()
+		schemaSet.Put(ast.MustParseRef("schema.input"), s.inputSchema)
+		regoOptions = append(regoOptions, rego.Schemas(schemaSet))
+	}
+
+	pq, err := rego.New(regoOptions...).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+	if s.preparedQueries == nil {
+		s.preparedQueries = make(map[string]rego.PreparedEvalQuery)
+	}
+	s.preparedQueries[query] = pq
+	return pq, nil
+}
+
 type Input struct {
 	Path     string `json:"path"`
 	FS       fs.FS  `json:"-"`
@@ -196,6 +253,7 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 			continue
 		}
 
+		start := time.Now()
 		staticMeta, err := s.retriever.RetrieveMetadata(ctx, module, GetInputsContents(inputs)...)
 		if err != nil {
 			s.logger.Error(
@@ -245,6 +303,9 @@ func (s *Scanner) ScanInput(ctx context.Context, inputs ...Input) (scan.Results,
 			}
 		}
 
+		if s.profiler != nil {
+			s.profiler.record(namespace, cmp.Or(staticMeta.AVDID, staticMeta.ID), len(inputs), time.Since(start))
+		}
 	}
 
 	return results, nil
@@ -1040,3 +1040,98 @@ deny {
 		})
 	}
 }
+
+func Test_RegoScanning_PreparedQueries(t *testing.T) {
+	srcFS := CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny {
+    input.evil
+}
+`,
+	})
+
+	scanner := rego.NewScanner(
+		types.SourceJSON,
+		rego.WithPolicyDirs("policies"),
+	)
+	require.NoError(t, scanner.LoadPolicies(srcFS))
+
+	// the prepared query is reused across inputs
+	for _, evil := range []bool{true, false, true} {
+		results, err := scanner.ScanInput(context.TODO(), rego.Input{
+			Path:     "/evil.lol",
+			Contents: map[string]any{"evil": evil},
+			FS:       srcFS,
+		})
+		require.NoError(t, err)
+		if evil {
+			assert.Len(t, results.GetFailed(), 1)
+		} else {
+			assert.Empty(t, results.GetFailed())
+		}
+	}
+
+	// queries prepared for the previous checks are discarded on reload
+	srcFS = CreateFS(t, map[string]string{
+		"policies/test.rego": `
+package defsec.test
+
+deny {
+    input.good
+}
+`,
+	})
+	require.NoError(t, scanner.LoadPolicies(srcFS))
+
+	results, err := scanner.ScanInput(context.TODO(), rego.Input{
+		Path:     "/evil.lol",
+		Contents: map[string]any{"evil": true},
+		FS:       srcFS,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results.GetFailed())
+}
+
+func BenchmarkScanner_ScanInput(b *testing.B) {
+	srcFS := fstest.MapFS{
+		"policies/test.rego": &fstest.MapFile{Data: []byte(`
+package defsec.test
+
+deny[msg] {
+    container := input.spec.containers[_]
+    container.securityContext.privileged
+    msg := sprintf("Container %q is privileged", [container.name])
+}
+`)},
+	}
+
+	scanner := rego.NewScanner(
+		types.SourceJSON,
+		rego.WithPolicyDirs("policies"),
+	)
+	require.NoError(b, scanner.LoadPolicies(srcFS))
+
+	input := rego.Input{
+		Path: "/pod.json",
+		Contents: map[string]any{
+			"spec": map[string]any{
+				"containers": []any{
+					map[string]any{
+						"name":            "app",
+						"securityContext": map[string]any{"privileged": true},
+					},
+				},
+			},
+		},
+		FS: srcFS,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := scanner.ScanInput(context.TODO(), input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -5,12 +5,17 @@ import (
 )
 
 var SchemaMap = map[types.Source]Schema{
-	types.SourceDefsec:     Cloud,
-	types.SourceCloud:      Cloud,
-	types.SourceKubernetes: Kubernetes,
-	types.SourceRbac:       Kubernetes,
-	types.SourceDockerfile: Dockerfile,
-	types.SourceTOML:       Anything,
-	types.SourceYAML:       Anything,
-	types.SourceJSON:       Anything,
+	types.SourceDefsec:         Cloud,
+	types.SourceCloud:          Cloud,
+	types.SourceKubernetes:     Kubernetes,
+	types.SourceRbac:           Kubernetes,
+	types.SourceDockerfile:     Dockerfile,
+	types.SourceTOML:           Anything,
+	types.SourceYAML:           Anything,
+	types.SourceJSON:           Anything,
+	types.SourceAnsible:        Anything,
+	types.SourceGitHubActions:  Anything,
+	types.SourceGitLabCI:       Anything,
+	types.SourceAzurePipelines: Anything,
+	types.SourceCUE:            Anything,
 }
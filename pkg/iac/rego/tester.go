@@ -0,0 +1,135 @@
+package rego
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/tester"
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/set"
+)
+
+// TestOptions holds options for running the unit tests of checks
+type TestOptions struct {
+	// DataFS and DataPaths are the data documents passed to the checks
+	DataFS    fs.FS
+	DataPaths []string
+
+	// Run is a regular expression selecting the tests to run, e.g. "test_deny_.*"
+	Run string
+
+	// Timeout is the time limit of each test
+	Timeout time.Duration
+}
+
+// RunTests runs the unit tests of the checks in the paths of fsys, i.e. the rules prefixed with "test_" in "*_test.rego" files.
+// Checks are compiled as they are when scanning, with the embedded libraries, Trivy's builtins and the input schemas
+// declared in their metadata, so that the tests behave as the checks do in scans.
+func RunTests(ctx context.Context, fsys fs.FS, paths []string, opts TestOptions) ([]*tester.Result, error) {
+	modules, err := loadTestModules(fsys, paths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Rego modules: %w", err)
+	}
+	if !lo.ContainsBy(lo.Keys(modules), isTestFile) {
+		return nil, nil
+	}
+
+	libs, err := LoadEmbeddedLibraries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded rego libraries: %w", err)
+	}
+	for name, lib := range libs {
+		modules["embedded/"+name] = lib
+	}
+
+	schemaSet, _, err := BuildSchemaSetFromPolicies(modules, paths, fsys, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := set.New[string]()
+	for _, module := range modules {
+		namespaces.Append(getModuleNamespace(module))
+	}
+	dataFS := lo.Ternary(opts.DataFS != nil, opts.DataFS, fsys)
+	store, err := initStore(dataFS, opts.DataPaths, namespaces.Items())
+	if err != nil {
+		return nil, fmt.Errorf("unable to load data: %w", err)
+	}
+
+	txn, err := store.NewTransaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a transaction: %w", err)
+	}
+	defer store.Abort(ctx, txn)
+
+	compiler := ast.NewCompiler().
+		WithUseTypeCheckAnnotations(true).
+		WithCapabilities(ast.CapabilitiesForThisVersion()).
+		WithSchemas(schemaSet).
+		WithEnablePrintStatements(true)
+
+	runner := tester.NewRunner().
+		SetCompiler(compiler).
+		SetStore(store).
+		SetModules(modules).
+		SetRuntime(addRuntimeValues()).
+		CapturePrintOutput(true).
+		Filter(opts.Run)
+	if opts.Timeout > 0 {
+		runner = runner.SetTimeout(opts.Timeout)
+	}
+
+	ch, err := runner.RunTests(ctx, txn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run tests: %w", err)
+	}
+
+	var results []*tester.Result
+	for result := range ch {
+		if isTestFile(result.Location.File) {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// loadTestModules loads the checks and their tests, unlike LoadPoliciesFromDirs which skips tests
+func loadTestModules(fsys fs.FS, paths []string) (map[string]*ast.Module, error) {
+	modules := make(map[string]*ast.Module)
+	for _, path := range paths {
+		if err := fs.WalkDir(fsys, sanitisePath(path), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			} else if d.IsDir() || filepath.Ext(d.Name()) != bundle.RegoExt || IsDotFile(d.Name()) {
+				return nil
+			}
+			data, err := fs.ReadFile(fsys, filepath.ToSlash(path))
+			if err != nil {
+				return err
+			}
+			module, err := ast.ParseModuleWithOpts(path, string(data), ast.ParserOptions{
+				ProcessAnnotation: true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to parse Rego module: %w", err)
+			}
+			modules[path] = module
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return modules, nil
+}
+
+func isTestFile(name string) bool {
+	return strings.HasSuffix(name, "_test"+bundle.RegoExt)
+}
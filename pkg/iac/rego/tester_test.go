@@ -0,0 +1,96 @@
+package rego_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+func TestRunTests(t *testing.T) {
+	srcFS := CreateFS(t, map[string]string{
+		"checks/privileged.rego": `# METADATA
+# title: Privileged container
+# schemas:
+# - input: schema["kubernetes"]
+# custom:
+#   id: USER-0001
+#   input:
+#     selector:
+#     - type: kubernetes
+package user.kubernetes.privileged
+
+import data.lib.kubernetes
+
+deny[res] {
+	container := kubernetes.containers[_]
+	container.securityContext.privileged
+	res := result.new(sprintf("Container %q is privileged", [container.name]), container)
+}
+`,
+		"checks/privileged_test.rego": `package user.kubernetes.privileged
+
+test_privileged {
+	r := deny with input as {"kind": "Pod", "spec": {"containers": [{"name": "app", "securityContext": {"privileged": true}}]}}
+	count(r) == 1
+}
+
+test_not_privileged {
+	r := deny with input as {"kind": "Pod", "spec": {"containers": [{"name": "app"}]}}
+	count(r) == 1
+}
+
+test_allowed_registry {
+	data.registries.allowed[_] == "registry.example.com"
+}
+`,
+		"data/registries.yaml": `registries:
+  allowed:
+    - registry.example.com
+`,
+	})
+
+	tests := []struct {
+		name string
+		opts rego.TestOptions
+		want map[string]bool
+	}{
+		{
+			name: "all tests",
+			opts: rego.TestOptions{
+				DataPaths: []string{"data"},
+			},
+			want: map[string]bool{
+				"test_privileged":       true,
+				"test_not_privileged":   false,
+				"test_allowed_registry": true,
+			},
+		},
+		{
+			name: "filtered",
+			opts: rego.TestOptions{
+				Run: "test_privileged",
+			},
+			want: map[string]bool{
+				"test_privileged": true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := rego.RunTests(context.Background(), srcFS, []string{"checks"}, tt.opts)
+			require.NoError(t, err)
+
+			got := make(map[string]bool)
+			for _, r := range results {
+				require.NoError(t, r.Error)
+				assert.Equal(t, "data.user.kubernetes.privileged", r.Package)
+				got[r.Name] = r.Pass()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -272,6 +272,25 @@ func (r *Results) Ignore(ignoreRules ignore.Rules, ignores map[string]ignore.Ign
 	}
 }
 
+// IgnoreByComments ignores the failed results with "trivy:ignore" comments in the files they are found in
+func (r *Results) IgnoreByComments(fsys fs.FS) error {
+	seen := make(map[string]struct{})
+	for _, result := range r.GetFailed() {
+		filename := result.Metadata().Range().GetFilename()
+		if _, ok := seen[filename]; ok {
+			continue
+		}
+		seen[filename] = struct{}{}
+
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return err
+		}
+		r.Ignore(ignore.Parse(string(content), filename, ""), nil)
+	}
+	return nil
+}
+
 func (r *Results) SetRule(rule Rule) {
 	for i := range *r {
 		(*r)[i].rule = rule
@@ -2,8 +2,10 @@ package scan_test
 
 import (
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
 	"github.com/aquasecurity/trivy/pkg/iac/types"
@@ -55,3 +57,27 @@ func Test_Occurrences(t *testing.T) {
 		})
 	}
 }
+
+func Test_IgnoreByComments(t *testing.T) {
+	fsys := fstest.MapFS{
+		"pipeline.yml": {
+			Data: []byte(`# trivy:ignore:AVD-TEST-0001
+image: alpine
+script: echo
+`),
+		},
+	}
+
+	var results scan.Results
+	for _, line := range []int{2, 3} {
+		results.Add("test", types.NewMetadata(types.NewRange("pipeline.yml", line, line, "", nil), "test"))
+	}
+	results.SetRule(scan.Rule{AVDID: "AVD-TEST-0001"})
+
+	require.NoError(t, results.IgnoreByComments(fsys))
+	assert.Equal(t, scan.StatusIgnored, results[0].Status())
+	assert.Equal(t, scan.StatusFailed, results[1].Status())
+
+	results.Add("test", types.NewMetadata(types.NewRange("missing.yml", 1, 1, "", nil), "test"))
+	require.Error(t, results.IgnoreByComments(fsys))
+}
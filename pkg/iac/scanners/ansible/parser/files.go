@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// roleDirs are the directories of a role containing YAML files
+var roleDirs = []string{
+	"tasks",
+	"handlers",
+	"defaults",
+	"vars",
+	"meta",
+}
+
+// inventoryDirs are the directories conventionally containing inventories
+var inventoryDirs = []string{
+	"inventory",
+	"inventories",
+}
+
+// playKeys are the keys of a play that run tasks
+var playKeys = []string{
+	"tasks",
+	"pre_tasks",
+	"post_tasks",
+	"handlers",
+	"roles",
+}
+
+// importPlaybookKeys are the keys of a playbook entry importing another playbook
+var importPlaybookKeys = []string{
+	"import_playbook",
+	"ansible.builtin.import_playbook",
+}
+
+// IsRoleFile checks if the file is a YAML file of a role, e.g. "roles/nginx/tasks/main.yml"
+func IsRoleFile(filePath string) bool {
+	if !isYAML(filePath) {
+		return false
+	}
+	_, dir, ok := roleOf(filePath)
+	return ok && slices.Contains(roleDirs, dir)
+}
+
+// IsVarsFile checks if the file defines variables of an inventory group or host,
+// e.g. "group_vars/all.yml" or "host_vars/web1/main.yml"
+func IsVarsFile(filePath string) bool {
+	_, _, ok := varsOf(filePath)
+	return ok
+}
+
+// IsInventoryFile checks if the file is an INI or YAML inventory,
+// e.g. "inventory", "hosts.ini" or "inventories/production/hosts"
+func IsInventoryFile(filePath string) bool {
+	filePath = path.Clean(strings.ReplaceAll(filePath, "\\", "/"))
+	ext := path.Ext(filePath)
+	if !slices.Contains([]string{"", ".ini", ".yml", ".yaml"}, ext) || IsVarsFile(filePath) {
+		return false
+	}
+
+	switch strings.TrimSuffix(path.Base(filePath), ext) {
+	case "inventory":
+		return true
+	case "hosts":
+		// "/etc/hosts" is not an inventory
+		return path.Base(path.Dir(filePath)) != "etc"
+	}
+
+	dirs := strings.Split(path.Dir(filePath), "/")
+	return slices.ContainsFunc(dirs, func(dir string) bool {
+		return slices.Contains(inventoryDirs, dir)
+	})
+}
+
+// IsPlaybook checks if the YAML document is a playbook, i.e. a list of plays or playbook imports
+func IsPlaybook(r io.Reader) bool {
+	var entries []map[string]any
+	if err := yaml.NewDecoder(r).Decode(&entries); err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if isPlay(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+func isPlay(entry map[string]any) bool {
+	for _, key := range importPlaybookKeys {
+		if _, ok := entry[key]; ok {
+			return true
+		}
+	}
+	if _, ok := entry["hosts"]; !ok {
+		return false
+	}
+	for _, key := range playKeys {
+		if _, ok := entry[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// roleOf returns the role directory, e.g. "roles/nginx", and the directory within the role, e.g. "tasks"
+func roleOf(filePath string) (string, string, bool) {
+	parts := strings.Split(path.Clean(strings.ReplaceAll(filePath, "\\", "/")), "/")
+	// roles/<role>/<dir>/<file>
+	for i := len(parts) - 4; i >= 0; i-- {
+		if parts[i] == "roles" {
+			return path.Join(parts[:i+2]...), parts[i+2], true
+		}
+	}
+	return "", "", false
+}
+
+// varsOf returns the kind of the variables ("group_vars" or "host_vars") and the name of the group or host
+func varsOf(filePath string) (string, string, bool) {
+	parts := strings.Split(path.Clean(strings.ReplaceAll(filePath, "\\", "/")), "/")
+	for i := len(parts) - 2; i >= 0 && i >= len(parts)-3; i-- {
+		if parts[i] != "group_vars" && parts[i] != "host_vars" {
+			continue
+		}
+		name := parts[i+1]
+		if i == len(parts)-2 {
+			// group_vars/<group>.yml
+			ext := path.Ext(name)
+			if ext != "" && !isYAML(name) {
+				return "", "", false
+			}
+			name = strings.TrimSuffix(name, ext)
+		} else if !isYAML(parts[i+2]) {
+			// group_vars/<group>/<file>.yml
+			return "", "", false
+		}
+		return parts[i], name, true
+	}
+	return "", "", false
+}
+
+func isYAML(name string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	return ext == ".yml" || ext == ".yaml"
+}
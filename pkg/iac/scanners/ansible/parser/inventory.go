@@ -0,0 +1,278 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// ungrouped is the group of hosts defined outside of groups
+const ungrouped = "ungrouped"
+
+// inventoryBuilder collects the groups and hosts of an inventory in the order they are defined
+type inventoryBuilder struct {
+	inventory *Inventory
+	groups    map[string]*Group
+	hosts     map[string]*Host
+}
+
+func newInventoryBuilder(inventory *Inventory) *inventoryBuilder {
+	b := &inventoryBuilder{
+		inventory: inventory,
+		groups:    make(map[string]*Group),
+		hosts:     make(map[string]*Host),
+	}
+	for _, group := range inventory.Groups {
+		b.groups[group.Name] = group
+	}
+	for _, host := range inventory.Hosts {
+		b.hosts[host.Name] = host
+	}
+	return b
+}
+
+func (b *inventoryBuilder) group(name string, metadata Metadata) *Group {
+	if group, ok := b.groups[name]; ok {
+		return group
+	}
+	group := &Group{
+		Metadata: metadata,
+		Name:     name,
+		Vars:     make(map[string]any),
+	}
+	b.groups[name] = group
+	b.inventory.Groups = append(b.inventory.Groups, group)
+	return group
+}
+
+func (b *inventoryBuilder) host(name string, metadata Metadata) *Host {
+	if host, ok := b.hosts[name]; ok {
+		return host
+	}
+	host := &Host{
+		Metadata: metadata,
+		Name:     name,
+		Vars:     make(map[string]any),
+	}
+	b.hosts[name] = host
+	b.inventory.Hosts = append(b.inventory.Hosts, host)
+	return host
+}
+
+func (b *inventoryBuilder) addHost(group *Group, host *Host) {
+	if !slices.Contains(group.Hosts, host.Name) {
+		group.Hosts = append(group.Hosts, host.Name)
+	}
+	if !slices.Contains(host.Groups, group.Name) {
+		host.Groups = append(host.Groups, group.Name)
+	}
+}
+
+func (b *inventoryBuilder) addChild(group *Group, child string) {
+	if !slices.Contains(group.Children, child) {
+		group.Children = append(group.Children, child)
+	}
+}
+
+func (p *Parser) parseInventory(filePath string) (*Inventory, error) {
+	content, err := fs.ReadFile(p.fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{
+		Path: filePath,
+	}
+	b := newInventoryBuilder(inventory)
+
+	var node yaml.Node
+	switch {
+	case isYAML(filePath):
+		if err := yaml.Unmarshal(content, &node); err != nil {
+			return nil, err
+		}
+	case path.Ext(filePath) == "" && yaml.Unmarshal(content, &node) == nil && isYAMLInventory(&node):
+		// Inventories without an extension may be written in YAML
+	default:
+		b.parseINI(filePath, content)
+		return inventory, nil
+	}
+
+	for _, entry := range yamlnode.Entries(&node) {
+		b.parseYAMLGroup(filePath, entry.Key, entry.KeyNode, entry.Value)
+	}
+	return inventory, nil
+}
+
+// isYAMLInventory checks if the document is a mapping of groups
+func isYAMLInventory(node *yaml.Node) bool {
+	groups := yamlnode.Entries(node)
+	if len(groups) == 0 {
+		return false
+	}
+	for _, group := range groups {
+		node := yamlnode.Resolve(group.Value)
+		if node != nil && node.Kind != yaml.MappingNode && node.Tag != "!!null" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseYAMLGroup parses a group of a YAML inventory, e.g.
+//
+//	webservers:
+//	  hosts:
+//	    web1:
+//	      ansible_host: 192.0.2.1
+//	  vars:
+//	    http_port: 80
+//	  children:
+//	    ...
+func (b *inventoryBuilder) parseYAMLGroup(filePath, name string, keyNode, node *yaml.Node) *Group {
+	group := b.group(name, Metadata{
+		FilePath:  filePath,
+		StartLine: keyNode.Line,
+		EndLine:   max(keyNode.Line, yamlnode.EndLine(node)),
+	})
+
+	for _, entry := range yamlnode.Entries(yamlnode.Lookup(node, "hosts")) {
+		host := b.host(entry.Key, Metadata{
+			FilePath:  filePath,
+			StartLine: entry.KeyNode.Line,
+			EndLine:   max(entry.KeyNode.Line, yamlnode.EndLine(entry.Value)),
+		})
+		if vars, ok := value(entry.Value).(map[string]any); ok {
+			maps.Copy(host.Vars, vars)
+		}
+		b.addHost(group, host)
+	}
+	if vars, ok := value(yamlnode.Lookup(node, "vars")).(map[string]any); ok {
+		maps.Copy(group.Vars, vars)
+	}
+	for _, entry := range yamlnode.Entries(yamlnode.Lookup(node, "children")) {
+		child := b.parseYAMLGroup(filePath, entry.Key, entry.KeyNode, entry.Value)
+		b.addChild(group, child.Name)
+	}
+	return group
+}
+
+// parseINI parses an INI inventory, e.g.
+//
+//	[webservers]
+//	web1 ansible_host=192.0.2.1
+//
+//	[webservers:vars]
+//	http_port=80
+//
+//	[production:children]
+//	webservers
+func (b *inventoryBuilder) parseINI(filePath string, content []byte) {
+	lineMetadata := func(line int) Metadata {
+		return Metadata{
+			FilePath:  filePath,
+			StartLine: line,
+			EndLine:   line,
+		}
+	}
+
+	section, kind := ungrouped, "hosts"
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section, kind, _ = strings.Cut(text[1:len(text)-1], ":")
+			if kind == "" {
+				kind = "hosts"
+			}
+			group := b.group(section, lineMetadata(line))
+			if group.Metadata.EndLine < line {
+				group.Metadata.EndLine = line
+			}
+			continue
+		}
+
+		group := b.group(section, lineMetadata(line))
+		group.Metadata.EndLine = max(group.Metadata.EndLine, line)
+		switch kind {
+		case "vars":
+			k, v, ok := strings.Cut(text, "=")
+			if ok {
+				group.Vars[strings.TrimSpace(k)] = unquote(strings.TrimSpace(v))
+			}
+		case "children":
+			b.group(text, lineMetadata(line))
+			b.addChild(group, text)
+		default:
+			tokens := splitArgs(text)
+			host := b.host(tokens[0], lineMetadata(line))
+			for _, token := range tokens[1:] {
+				if k, v, ok := strings.Cut(token, "="); ok {
+					host.Vars[k] = unquote(v)
+				}
+			}
+			b.addHost(group, host)
+		}
+	}
+}
+
+// applyVarsFiles adds the variables defined in "group_vars" and "host_vars" directories to the inventories
+// next to these directories. Inventories are created for the directories without inventories,
+// e.g. when group_vars are next to playbooks.
+func (p *Parser) applyVarsFiles(inventories []*Inventory, varsFiles []string) []*Inventory {
+	for _, filePath := range varsFiles {
+		kind, name, _ := varsOf(filePath)
+		dir := path.Dir(filePath)
+		for path.Base(dir) != kind {
+			dir = path.Dir(dir)
+		}
+		dir = path.Dir(dir)
+
+		node, err := p.parseYAML(filePath)
+		if err != nil {
+			p.logger.Debug("Failed to parse variables", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		vars, _ := value(node).(map[string]any)
+		metadata := newMetadata(filePath, yamlnode.Resolve(node))
+
+		var found bool
+		for _, inventory := range inventories {
+			if path.Dir(inventory.Path) != dir && inventory.Path != dir {
+				continue
+			}
+			found = true
+			applyVars(inventory, kind, name, vars, metadata)
+		}
+		if !found {
+			inventory := &Inventory{
+				Path: dir,
+			}
+			applyVars(inventory, kind, name, vars, metadata)
+			inventories = append(inventories, inventory)
+		}
+	}
+	return inventories
+}
+
+func applyVars(inventory *Inventory, kind, name string, vars map[string]any, metadata Metadata) {
+	b := newInventoryBuilder(inventory)
+	if kind == "host_vars" {
+		maps.Copy(b.host(name, metadata).Vars, vars)
+	} else {
+		maps.Copy(b.group(name, metadata).Vars, vars)
+	}
+}
@@ -0,0 +1,430 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// maxIncludeDepth limits nested includes of tasks and roles, e.g. roles including themselves
+const maxIncludeDepth = 10
+
+// Parser parses the playbooks, roles and inventories in a directory.
+// Roles are resolved by their name from the "roles" directories found in the directory.
+type Parser struct {
+	fsys   fs.FS
+	logger *log.Logger
+
+	files map[string]*yaml.Node
+	roles map[string]*Role
+	// used are the task files whose tasks have already been listed
+	used map[string]struct{}
+}
+
+func New(fsys fs.FS) *Parser {
+	return &Parser{
+		fsys:   fsys,
+		logger: log.WithPrefix("ansible parser"),
+		files:  make(map[string]*yaml.Node),
+		roles:  make(map[string]*Role),
+		used:   make(map[string]struct{}),
+	}
+}
+
+// taskContext is the context tasks are defined in
+type taskContext struct {
+	filePath string
+	role     *Role
+	handler  bool
+	privs    privileges
+	depth    int
+}
+
+func (p *Parser) ParseFS(ctx context.Context, dir string) (*Project, error) {
+	var playbooks, inventories, varsFiles []string
+	roleFiles := make(map[string][]string)
+
+	if err := fs.WalkDir(p.fsys, dir, func(filePath string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		switch {
+		case IsRoleFile(filePath):
+			roleDir, _, _ := roleOf(filePath)
+			roleFiles[roleDir] = append(roleFiles[roleDir], filePath)
+		case IsVarsFile(filePath):
+			varsFiles = append(varsFiles, filePath)
+		case IsInventoryFile(filePath):
+			inventories = append(inventories, filePath)
+		case isYAML(filePath):
+			if p.isPlaybook(filePath) {
+				playbooks = append(playbooks, filePath)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	project := &Project{}
+
+	roleDirs := sortedKeys(roleFiles)
+	for _, roleDir := range roleDirs {
+		role := p.newRole(roleDir)
+		if _, exists := p.roles[role.Name]; !exists {
+			p.roles[role.Name] = role
+		}
+		project.Roles = append(project.Roles, role)
+	}
+
+	for _, filePath := range playbooks {
+		playbook, tasks, err := p.parsePlaybook(filePath)
+		if err != nil {
+			p.logger.Error("Failed to parse playbook", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		project.Playbooks = append(project.Playbooks, playbook)
+		project.Tasks = append(project.Tasks, tasks...)
+	}
+
+	// The tasks of roles and task files which are not used by the playbooks
+	for _, roleDir := range roleDirs {
+		role := p.roleByDir(project.Roles, roleDir)
+		files := roleFiles[roleDir]
+		sort.SliceStable(files, func(i, j int) bool {
+			return isMainFile(files[i]) && !isMainFile(files[j])
+		})
+		for _, filePath := range files {
+			if _, dir, _ := roleOf(filePath); dir != "tasks" && dir != "handlers" {
+				continue
+			}
+			if _, ok := p.used[filePath]; ok {
+				continue
+			}
+			project.Tasks = append(project.Tasks, p.tasksFromFile(filePath, taskContext{
+				role:    role,
+				handler: strings.HasPrefix(filePath, path.Join(role.Path, "handlers")+"/"),
+			})...)
+		}
+	}
+
+	for _, filePath := range inventories {
+		inventory, err := p.parseInventory(filePath)
+		if err != nil {
+			p.logger.Error("Failed to parse inventory", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		project.Inventories = append(project.Inventories, inventory)
+	}
+	project.Inventories = p.applyVarsFiles(project.Inventories, varsFiles)
+
+	return project, nil
+}
+
+func (p *Parser) roleByDir(roles []*Role, roleDir string) *Role {
+	for _, role := range roles {
+		if role.Path == roleDir {
+			return role
+		}
+	}
+	return nil
+}
+
+func (p *Parser) isPlaybook(filePath string) bool {
+	f, err := p.fsys.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	return IsPlaybook(f)
+}
+
+// parseYAML parses the YAML file once
+func (p *Parser) parseYAML(filePath string) (*yaml.Node, error) {
+	if node, ok := p.files[filePath]; ok {
+		return node, nil
+	}
+
+	b, err := fs.ReadFile(p.fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+	p.files[filePath] = &node
+	return &node, nil
+}
+
+// newRole creates a role with its variables. The tasks are added when they are parsed.
+func (p *Parser) newRole(roleDir string) *Role {
+	role := &Role{
+		Name: path.Base(roleDir),
+		Path: roleDir,
+	}
+	if filePath, ok := p.mainFile(roleDir, "defaults"); ok {
+		role.Defaults = p.varsFromFile(filePath)
+	}
+	if filePath, ok := p.mainFile(roleDir, "vars"); ok {
+		role.Vars = p.varsFromFile(filePath)
+	}
+	return role
+}
+
+// mainFile returns the main file of a directory in a role, e.g. "roles/nginx/tasks/main.yml"
+func (p *Parser) mainFile(roleDir, dir string) (string, bool) {
+	return p.taskFile(roleDir, dir, "main")
+}
+
+func (p *Parser) taskFile(roleDir, dir, name string) (string, bool) {
+	for _, ext := range []string{".yml", ".yaml"} {
+		filePath := path.Join(roleDir, dir, name+ext)
+		if _, err := fs.Stat(p.fsys, filePath); err == nil {
+			return filePath, true
+		}
+	}
+	return "", false
+}
+
+func (p *Parser) varsFromFile(filePath string) map[string]any {
+	node, err := p.parseYAML(filePath)
+	if err != nil {
+		p.logger.Debug("Failed to parse variables", log.FilePath(filePath), log.Err(err))
+		return nil
+	}
+	vars, _ := value(node).(map[string]any)
+	return vars
+}
+
+func (p *Parser) parsePlaybook(filePath string) (*Playbook, []*Task, error) {
+	node, err := p.parseYAML(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	playbook := &Playbook{
+		Path: filePath,
+	}
+	var tasks []*Task
+	for _, entry := range yamlnode.Items(node) {
+		if imported := p.importedPlaybook(entry); imported != "" {
+			playbook.Imports = append(playbook.Imports, imported)
+			continue
+		}
+		play := p.parsePlay(filePath, entry)
+		playbook.Plays = append(playbook.Plays, play)
+		tasks = append(tasks, play.Tasks...)
+	}
+	return playbook, tasks, nil
+}
+
+func (p *Parser) importedPlaybook(node *yaml.Node) string {
+	for _, key := range importPlaybookKeys {
+		if imported := yamlnode.Lookup(node, key); imported != nil {
+			return yamlnode.String(imported)
+		}
+	}
+	return ""
+}
+
+func (p *Parser) parsePlay(filePath string, node *yaml.Node) *Play {
+	privs := privileges{}.inherit(node)
+	play := &Play{
+		Metadata:   newMetadata(filePath, yamlnode.Resolve(node)),
+		Become:     privs.become,
+		BecomeUser: privs.becomeUser,
+		Keywords:   make(map[string]any),
+	}
+
+	for _, entry := range yamlnode.Entries(node) {
+		switch entry.Key {
+		case "name":
+			play.Name = yamlnode.String(entry.Value)
+		case "hosts":
+			play.Hosts = value(entry.Value)
+		case "become", "become_user", "tasks", "pre_tasks", "post_tasks", "handlers":
+		case "roles":
+			for _, ref := range yamlnode.Items(entry.Value) {
+				play.Roles = append(play.Roles, roleName(ref))
+			}
+		default:
+			play.Keywords[entry.Key] = value(entry.Value)
+		}
+	}
+
+	tctx := taskContext{
+		filePath: filePath,
+		privs:    privs,
+	}
+	// Ansible runs pre_tasks, roles, tasks and post_tasks in this order
+	play.Tasks = append(play.Tasks, p.tasks(yamlnode.Lookup(node, "pre_tasks"), tctx)...)
+	for _, ref := range yamlnode.Items(yamlnode.Lookup(node, "roles")) {
+		play.Tasks = append(play.Tasks, p.roleTasks(roleName(ref), "", tctx.withPrivs(ref))...)
+	}
+	play.Tasks = append(play.Tasks, p.tasks(yamlnode.Lookup(node, "tasks"), tctx)...)
+	play.Tasks = append(play.Tasks, p.tasks(yamlnode.Lookup(node, "post_tasks"), tctx)...)
+
+	tctx.handler = true
+	play.Tasks = append(play.Tasks, p.tasks(yamlnode.Lookup(node, "handlers"), tctx)...)
+	return play
+}
+
+// roleName returns the name of a role reference, e.g. "nginx", "{role: nginx}" or "{name: nginx}"
+func roleName(node *yaml.Node) string {
+	name := yamlnode.String(node)
+	if name == "" {
+		name = yamlnode.String(yamlnode.Lookup(node, "role"))
+	}
+	if name == "" {
+		name = yamlnode.String(yamlnode.Lookup(node, "name"))
+	}
+	// roles given by their path, e.g. "../roles/nginx"
+	if !strings.Contains(name, "{{") {
+		name = path.Base(name)
+	}
+	return name
+}
+
+func (c taskContext) withPrivs(node *yaml.Node) taskContext {
+	c.privs = c.privs.inherit(node)
+	return c
+}
+
+// roleTasks returns the tasks and handlers of the role which have not been listed yet
+func (p *Parser) roleTasks(name, tasksFrom string, tctx taskContext) []*Task {
+	role, ok := p.roles[name]
+	if !ok {
+		// roles of collections, e.g. "namespace.collection.nginx"
+		role, ok = p.roles[name[strings.LastIndex(name, ".")+1:]]
+	}
+	if !ok {
+		p.logger.Debug("Role not found", log.String("role", name))
+		return nil
+	}
+	if tasksFrom == "" {
+		tasksFrom = "main"
+	}
+
+	tctx.role = role
+	tctx.depth++
+
+	var tasks []*Task
+	if filePath, ok := p.taskFile(role.Path, "tasks", tasksFrom); ok {
+		tasks = append(tasks, p.tasksFromFile(filePath, tctx)...)
+	}
+	if filePath, ok := p.mainFile(role.Path, "handlers"); ok {
+		tctx.handler = true
+		tasks = append(tasks, p.tasksFromFile(filePath, tctx)...)
+	}
+	return tasks
+}
+
+// tasksFromFile returns the tasks of a file, unless they have already been listed
+func (p *Parser) tasksFromFile(filePath string, tctx taskContext) []*Task {
+	if _, ok := p.used[filePath]; ok || tctx.depth > maxIncludeDepth {
+		return nil
+	}
+	p.used[filePath] = struct{}{}
+
+	node, err := p.parseYAML(filePath)
+	if err != nil {
+		p.logger.Error("Failed to parse tasks", log.FilePath(filePath), log.Err(err))
+		return nil
+	}
+	tctx.filePath = filePath
+	return p.tasks(node, tctx)
+}
+
+// tasks returns the tasks of a list, including the tasks of blocks and included files and roles
+func (p *Parser) tasks(node *yaml.Node, tctx taskContext) []*Task {
+	var tasks []*Task
+	for _, item := range yamlnode.Items(node) {
+		if isBlock(item) {
+			blockCtx := tctx.withPrivs(item)
+			for _, key := range []string{"block", "rescue", "always"} {
+				tasks = append(tasks, p.tasks(yamlnode.Lookup(item, key), blockCtx)...)
+			}
+			continue
+		}
+
+		task := newTask(tctx.filePath, item, tctx.privs)
+		task.Handler = tctx.handler
+		if tctx.role != nil {
+			task.Role = tctx.role.Name
+			if task.Handler {
+				tctx.role.Handlers = append(tctx.role.Handlers, task)
+			} else {
+				tctx.role.Tasks = append(tctx.role.Tasks, task)
+			}
+		}
+		tasks = append(tasks, task)
+		tasks = append(tasks, p.includedTasks(task, tctx)...)
+	}
+	return tasks
+}
+
+// includedTasks returns the tasks of the files and roles included by the task
+func (p *Parser) includedTasks(task *Task, tctx taskContext) []*Task {
+	tctx.privs = privileges{
+		become:     task.Become,
+		becomeUser: task.BecomeUser,
+	}
+
+	switch task.Module {
+	case "include_role", "import_role":
+		name, _ := task.Args["name"].(string)
+		tasksFrom, _ := task.Args["tasks_from"].(string)
+		if name == "" || strings.Contains(name+tasksFrom, "{{") {
+			return nil
+		}
+		return p.roleTasks(path.Base(name), strings.TrimSuffix(tasksFrom, path.Ext(tasksFrom)), tctx)
+	case "include_tasks", "import_tasks", "include":
+		file, _ := task.Args["_raw_params"].(string)
+		if file == "" {
+			file, _ = task.Args["file"].(string)
+		}
+		if file == "" || strings.Contains(file, "{{") || path.IsAbs(file) {
+			return nil
+		}
+		filePath := path.Join(path.Dir(tctx.filePath), file)
+		if tctx.role != nil && !strings.HasPrefix(filePath, tctx.role.Path+"/") {
+			// the tasks of roles are relative to the "tasks" directory
+			filePath = path.Join(tctx.role.Path, "tasks", file)
+		}
+		tctx.depth++
+		return p.tasksFromFile(filePath, tctx)
+	}
+	return nil
+}
+
+func isMainFile(filePath string) bool {
+	return strings.TrimSuffix(path.Base(filePath), path.Ext(filePath)) == "main"
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
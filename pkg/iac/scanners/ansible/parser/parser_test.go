@@ -0,0 +1,404 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+)
+
+type testTask struct {
+	FilePath   string
+	StartLine  int
+	EndLine    int
+	Module     string
+	Args       map[string]any
+	Become     bool
+	BecomeUser string
+	Role       string
+	Handler    bool
+}
+
+func toTestTasks(tasks []*Task) []testTask {
+	var res []testTask
+	for _, task := range tasks {
+		res = append(res, testTask{
+			FilePath:   task.Metadata.FilePath,
+			StartLine:  task.Metadata.StartLine,
+			EndLine:    task.Metadata.EndLine,
+			Module:     task.Module,
+			Args:       task.Args,
+			Become:     task.Become,
+			BecomeUser: task.BecomeUser,
+			Role:       task.Role,
+			Handler:    task.Handler,
+		})
+	}
+	return res
+}
+
+func TestParser_ParseFS(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []testTask
+	}{
+		{
+			name: "play with blocks",
+			files: map[string]string{
+				"site.yml": `- name: web
+  hosts: webservers
+  become: true
+  tasks:
+    - name: print the port
+      shell: echo {{ port }} chdir=/tmp
+    - block:
+        - ansible.builtin.copy:
+            src: a.conf
+            dest: /etc/a.conf
+            mode: 0644
+        - copy: src=b.conf dest="/etc/b.conf" mode=0666
+      become_user: app
+    - action: command whoami
+      become: false
+  handlers:
+    - name: restart
+      service: name=nginx state=restarted
+`,
+			},
+			want: []testTask{
+				{
+					FilePath:  "site.yml",
+					StartLine: 5,
+					EndLine:   6,
+					Module:    "shell",
+					Args: map[string]any{
+						"_raw_params": "echo {{ port }}",
+						"chdir":       "/tmp",
+					},
+					Become: true,
+				},
+				{
+					FilePath:  "site.yml",
+					StartLine: 8,
+					EndLine:   11,
+					Module:    "copy",
+					Args: map[string]any{
+						"src":  "a.conf",
+						"dest": "/etc/a.conf",
+						"mode": "0644",
+					},
+					Become:     true,
+					BecomeUser: "app",
+				},
+				{
+					FilePath:  "site.yml",
+					StartLine: 12,
+					EndLine:   12,
+					Module:    "copy",
+					Args: map[string]any{
+						"src":  "b.conf",
+						"dest": "/etc/b.conf",
+						"mode": "0666",
+					},
+					Become:     true,
+					BecomeUser: "app",
+				},
+				{
+					FilePath:  "site.yml",
+					StartLine: 14,
+					EndLine:   15,
+					Module:    "command",
+					Args: map[string]any{
+						"_raw_params": "whoami",
+					},
+				},
+				{
+					FilePath:  "site.yml",
+					StartLine: 17,
+					EndLine:   18,
+					Module:    "service",
+					Args: map[string]any{
+						"name":  "nginx",
+						"state": "restarted",
+					},
+					Become:  true,
+					Handler: true,
+				},
+			},
+		},
+		{
+			name: "roles and includes",
+			files: map[string]string{
+				"site.yml": `- hosts: all
+  become: yes
+  roles:
+    - role: nginx
+      become_user: www-data
+  tasks:
+    - include_role:
+        name: acme.web.common
+`,
+				"roles/nginx/tasks/main.yml": `- apt: name=nginx
+- include_tasks: config.yml
+`,
+				"roles/nginx/tasks/config.yml": `- template:
+    src: nginx.conf.j2
+    dest: /etc/nginx/nginx.conf
+`,
+				"roles/nginx/handlers/main.yml": `- service: name=nginx state=restarted
+`,
+				"roles/common/tasks/main.yml": `- ansible.legacy.command: uptime
+`,
+				"roles/unused/tasks/main.yml": `- file:
+    path: /tmp/unused
+    state: absent
+`,
+			},
+			want: []testTask{
+				{
+					FilePath:   "roles/nginx/tasks/main.yml",
+					StartLine:  1,
+					EndLine:    1,
+					Module:     "apt",
+					Args:       map[string]any{"name": "nginx"},
+					Become:     true,
+					BecomeUser: "www-data",
+					Role:       "nginx",
+				},
+				{
+					FilePath:   "roles/nginx/tasks/main.yml",
+					StartLine:  2,
+					EndLine:    2,
+					Module:     "include_tasks",
+					Args:       map[string]any{"_raw_params": "config.yml"},
+					Become:     true,
+					BecomeUser: "www-data",
+					Role:       "nginx",
+				},
+				{
+					FilePath:  "roles/nginx/tasks/config.yml",
+					StartLine: 1,
+					EndLine:   3,
+					Module:    "template",
+					Args: map[string]any{
+						"src":  "nginx.conf.j2",
+						"dest": "/etc/nginx/nginx.conf",
+					},
+					Become:     true,
+					BecomeUser: "www-data",
+					Role:       "nginx",
+				},
+				{
+					FilePath:   "roles/nginx/handlers/main.yml",
+					StartLine:  1,
+					EndLine:    1,
+					Module:     "service",
+					Args:       map[string]any{"name": "nginx", "state": "restarted"},
+					Become:     true,
+					BecomeUser: "www-data",
+					Role:       "nginx",
+					Handler:    true,
+				},
+				{
+					FilePath:  "site.yml",
+					StartLine: 7,
+					EndLine:   8,
+					Module:    "include_role",
+					Args:      map[string]any{"name": "acme.web.common"},
+					Become:    true,
+				},
+				{
+					FilePath:  "roles/common/tasks/main.yml",
+					StartLine: 1,
+					EndLine:   1,
+					Module:    "command",
+					Args:      map[string]any{"_raw_params": "uptime"},
+					Become:    true,
+					Role:      "common",
+				},
+				{
+					FilePath:  "roles/unused/tasks/main.yml",
+					StartLine: 1,
+					EndLine:   3,
+					Module:    "file",
+					Args: map[string]any{
+						"path":  "/tmp/unused",
+						"state": "absent",
+					},
+					Role: "unused",
+				},
+			},
+		},
+		{
+			name: "invalid file",
+			files: map[string]string{
+				"roles/broken/tasks/main.yml": `- name: broken
+  shell: [
+`,
+				"roles/valid/tasks/main.yml": `- ping:
+`,
+			},
+			want: []testTask{
+				{
+					FilePath:  "roles/valid/tasks/main.yml",
+					StartLine: 1,
+					EndLine:   1,
+					Module:    "ping",
+					Args:      map[string]any{},
+					Role:      "valid",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := testutil.CreateFS(t, tt.files)
+			project, err := New(fsys).ParseFS(context.TODO(), ".")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, toTestTasks(project.Tasks))
+		})
+	}
+}
+
+func TestParser_Inventories(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"inventory": `web1 ansible_host=192.0.2.1
+
+[webservers]
+web2 ansible_user=root ansible_password="secret"
+
+[webservers:vars]
+http_port=80
+
+[production:children]
+webservers
+`,
+		"group_vars/webservers.yml": `ntp_server: time.example.com
+`,
+		"inventories/staging/hosts.yml": `all:
+  children:
+    db:
+      hosts:
+        db1:
+          ansible_host: 192.0.2.3
+      vars:
+        backup: true
+`,
+		"inventories/staging/host_vars/db1/main.yml": `ansible_port: 2222
+`,
+	})
+
+	project, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+
+	want := []*Inventory{
+		{
+			Path: "inventories/staging/hosts.yml",
+			Groups: []*Group{
+				{
+					Metadata: Metadata{FilePath: "inventories/staging/hosts.yml", StartLine: 1, EndLine: 8},
+					Name:     "all",
+					Children: []string{"db"},
+					Vars:     map[string]any{},
+				},
+				{
+					Metadata: Metadata{FilePath: "inventories/staging/hosts.yml", StartLine: 3, EndLine: 8},
+					Name:     "db",
+					Hosts:    []string{"db1"},
+					Vars:     map[string]any{"backup": true},
+				},
+			},
+			Hosts: []*Host{
+				{
+					Metadata: Metadata{FilePath: "inventories/staging/hosts.yml", StartLine: 5, EndLine: 6},
+					Name:     "db1",
+					Groups:   []string{"db"},
+					Vars: map[string]any{
+						"ansible_host": "192.0.2.3",
+						"ansible_port": 2222,
+					},
+				},
+			},
+		},
+		{
+			Path: "inventory",
+			Groups: []*Group{
+				{
+					Metadata: Metadata{FilePath: "inventory", StartLine: 1, EndLine: 1},
+					Name:     "ungrouped",
+					Hosts:    []string{"web1"},
+					Vars:     map[string]any{},
+				},
+				{
+					Metadata: Metadata{FilePath: "inventory", StartLine: 3, EndLine: 7},
+					Name:     "webservers",
+					Hosts:    []string{"web2"},
+					Vars: map[string]any{
+						"http_port":  "80",
+						"ntp_server": "time.example.com",
+					},
+				},
+				{
+					Metadata: Metadata{FilePath: "inventory", StartLine: 9, EndLine: 10},
+					Name:     "production",
+					Children: []string{"webservers"},
+					Vars:     map[string]any{},
+				},
+			},
+			Hosts: []*Host{
+				{
+					Metadata: Metadata{FilePath: "inventory", StartLine: 1, EndLine: 1},
+					Name:     "web1",
+					Groups:   []string{"ungrouped"},
+					Vars:     map[string]any{"ansible_host": "192.0.2.1"},
+				},
+				{
+					Metadata: Metadata{FilePath: "inventory", StartLine: 4, EndLine: 4},
+					Name:     "web2",
+					Groups:   []string{"webservers"},
+					Vars: map[string]any{
+						"ansible_user":     "root",
+						"ansible_password": "secret",
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, project.Inventories)
+}
+
+func TestFiles(t *testing.T) {
+	tests := []struct {
+		path      string
+		role      bool
+		vars      bool
+		inventory bool
+	}{
+		{path: "roles/nginx/tasks/main.yml", role: true},
+		{path: "playbooks/roles/nginx/handlers/main.yaml", role: true},
+		{path: "roles/nginx/templates/nginx.conf.j2"},
+		{path: "roles/nginx/files/config.yml"},
+		{path: "group_vars/all.yml", vars: true},
+		{path: "group_vars/all", vars: true},
+		{path: "inventories/prod/host_vars/web1/main.yml", vars: true},
+		{path: "group_vars/all.json"},
+		{path: "inventory", inventory: true},
+		{path: "hosts.ini", inventory: true},
+		{path: "inventories/prod/hosts", inventory: true},
+		{path: "inventories/prod/web.yml", inventory: true},
+		{path: "etc/hosts"},
+		{path: "site.yml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.role, IsRoleFile(tt.path), "role")
+			assert.Equal(t, tt.vars, IsVarsFile(tt.path), "vars")
+			assert.Equal(t, tt.inventory, IsInventoryFile(tt.path), "inventory")
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package parser
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+// Project is the Ansible content found in a directory
+type Project struct {
+	Playbooks   []*Playbook
+	Roles       []*Role
+	Inventories []*Inventory
+	// Tasks are the tasks of all playbooks and roles. The tasks of a file are only listed once,
+	// with the privileges of the first play running them.
+	Tasks []*Task
+}
+
+// Metadata is the location of an element in a file
+type Metadata struct {
+	FilePath  string
+	StartLine int
+	EndLine   int
+}
+
+func newMetadata(filePath string, node *yaml.Node) Metadata {
+	return Metadata{
+		FilePath:  filePath,
+		StartLine: node.Line,
+		EndLine:   yamlnode.EndLine(node),
+	}
+}
+
+func (m Metadata) ToRego() map[string]any {
+	return map[string]any{
+		"filepath":  m.FilePath,
+		"startline": m.StartLine,
+		"endline":   m.EndLine,
+	}
+}
+
+type Playbook struct {
+	Path  string
+	Plays []*Play
+	// Imports are the playbooks imported with import_playbook
+	Imports []string
+}
+
+type Play struct {
+	Metadata   Metadata
+	Name       string
+	Hosts      any
+	Become     bool
+	BecomeUser string
+	Roles      []string
+	// Tasks are the tasks run by the play in order, including the tasks of its roles and handlers
+	Tasks []*Task
+	// Keywords are the other keywords of the play, e.g. "vars" or "remote_user"
+	Keywords map[string]any
+}
+
+type Role struct {
+	Name     string
+	Path     string
+	Defaults map[string]any
+	Vars     map[string]any
+	Tasks    []*Task
+	Handlers []*Task
+}
+
+type Task struct {
+	Metadata Metadata
+	Name     string
+	// Action is the module as written, e.g. "ansible.builtin.shell"
+	Action string
+	// Module is the module without the "ansible.builtin" or "ansible.legacy" collection, e.g. "shell"
+	Module string
+	// Args are the arguments of the module. Free-form arguments are stored as "_raw_params".
+	Args map[string]any
+	// Become and BecomeUser are the privileges the task is run with, including the inherited ones
+	Become     bool
+	BecomeUser string
+	Role       string
+	Handler    bool
+	// Keywords are the other keywords of the task, e.g. "when" or "loop"
+	Keywords map[string]any
+}
+
+type Inventory struct {
+	Path   string
+	Groups []*Group
+	Hosts  []*Host
+}
+
+type Group struct {
+	Metadata Metadata
+	Name     string
+	Hosts    []string
+	Children []string
+	Vars     map[string]any
+}
+
+type Host struct {
+	Metadata Metadata
+	Name     string
+	Groups   []string
+	Vars     map[string]any
+}
+
+func (p *Project) ToRego() any {
+	return map[string]any{
+		"playbooks":   toRegoSlice(p.Playbooks),
+		"roles":       toRegoSlice(p.Roles),
+		"inventories": toRegoSlice(p.Inventories),
+		"tasks":       toRegoSlice(p.Tasks),
+	}
+}
+
+func (p *Playbook) ToRego() any {
+	return map[string]any{
+		"path":    p.Path,
+		"plays":   toRegoSlice(p.Plays),
+		"imports": toAnySlice(p.Imports),
+	}
+}
+
+func (p *Play) ToRego() any {
+	m := make(map[string]any)
+	for k, v := range p.Keywords {
+		m[k] = v
+	}
+	m["name"] = p.Name
+	m["hosts"] = p.Hosts
+	m["become"] = p.Become
+	m["become_user"] = p.BecomeUser
+	m["roles"] = toAnySlice(p.Roles)
+	m["tasks"] = toRegoSlice(p.Tasks)
+	m["__defsec_metadata"] = p.Metadata.ToRego()
+	return m
+}
+
+func (r *Role) ToRego() any {
+	return map[string]any{
+		"name":     r.Name,
+		"path":     r.Path,
+		"defaults": toMap(r.Defaults),
+		"vars":     toMap(r.Vars),
+		"tasks":    toRegoSlice(r.Tasks),
+		"handlers": toRegoSlice(r.Handlers),
+	}
+}
+
+func (t *Task) ToRego() any {
+	m := make(map[string]any)
+	for k, v := range t.Keywords {
+		m[k] = v
+	}
+	m["name"] = t.Name
+	m["action"] = t.Action
+	m["module"] = t.Module
+	m["args"] = toMap(t.Args)
+	m["become"] = t.Become
+	m["become_user"] = t.BecomeUser
+	m["role"] = t.Role
+	m["handler"] = t.Handler
+	m["__defsec_metadata"] = t.Metadata.ToRego()
+	return m
+}
+
+func (i *Inventory) ToRego() any {
+	return map[string]any{
+		"path":   i.Path,
+		"groups": toRegoSlice(i.Groups),
+		"hosts":  toRegoSlice(i.Hosts),
+	}
+}
+
+func (g *Group) ToRego() any {
+	return map[string]any{
+		"name":              g.Name,
+		"hosts":             toAnySlice(g.Hosts),
+		"children":          toAnySlice(g.Children),
+		"vars":              toMap(g.Vars),
+		"__defsec_metadata": g.Metadata.ToRego(),
+	}
+}
+
+func (h *Host) ToRego() any {
+	return map[string]any{
+		"name":              h.Name,
+		"groups":            toAnySlice(h.Groups),
+		"vars":              toMap(h.Vars),
+		"__defsec_metadata": h.Metadata.ToRego(),
+	}
+}
+
+func toRegoSlice[T interface{ ToRego() any }](elems []T) []any {
+	result := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		result = append(result, elem.ToRego())
+	}
+	return result
+}
+
+func toAnySlice[T any](elems []T) []any {
+	result := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		result = append(result, elem)
+	}
+	return result
+}
+
+func toMap(m map[string]any) map[string]any {
+	if m == nil {
+		return make(map[string]any)
+	}
+	return m
+}
@@ -0,0 +1,258 @@
+package parser
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+// taskKeywords are the keywords of tasks and blocks which are not modules.
+// See https://docs.ansible.com/ansible/latest/reference_appendices/playbooks_keywords.html#task
+var taskKeywords = []string{
+	"any_errors_fatal",
+	"args",
+	"async",
+	"become",
+	"become_exe",
+	"become_flags",
+	"become_method",
+	"become_user",
+	"changed_when",
+	"check_mode",
+	"collections",
+	"connection",
+	"debugger",
+	"delay",
+	"delegate_facts",
+	"delegate_to",
+	"diff",
+	"environment",
+	"failed_when",
+	"ignore_errors",
+	"ignore_unreachable",
+	"listen",
+	"loop",
+	"loop_control",
+	"module_defaults",
+	"name",
+	"no_log",
+	"notify",
+	"poll",
+	"port",
+	"register",
+	"remote_user",
+	"retries",
+	"run_once",
+	"tags",
+	"throttle",
+	"timeout",
+	"until",
+	"vars",
+	"when",
+}
+
+// rawParamsModules are the modules taking a free-form command or file instead of key=value arguments
+var rawParamsModules = []string{
+	"command",
+	"shell",
+	"raw",
+	"script",
+	"win_command",
+	"win_shell",
+	"include_tasks",
+	"import_tasks",
+	"include_vars",
+	"meta",
+}
+
+// commandOptions are the options of the command and shell modules which can be given in the free form
+var commandOptions = []string{
+	"chdir",
+	"creates",
+	"executable",
+	"removes",
+	"stdin",
+	"stdin_add_newline",
+	"strip_empty_ends",
+}
+
+// builtinCollections are the collections omitted from module names
+var builtinCollections = []string{
+	"ansible.builtin.",
+	"ansible.legacy.",
+}
+
+// privileges are the privileges tasks are run with
+type privileges struct {
+	become     bool
+	becomeUser string
+}
+
+// inherit returns the privileges overridden by the keywords of a play, block, role or task
+func (p privileges) inherit(node *yaml.Node) privileges {
+	if become, ok := boolValue(yamlnode.Lookup(node, "become")); ok {
+		p.become = become
+	}
+	if user := yamlnode.String(yamlnode.Lookup(node, "become_user")); user != "" {
+		p.becomeUser = user
+	}
+	return p
+}
+
+func isBlock(node *yaml.Node) bool {
+	return yamlnode.Lookup(node, "block") != nil
+}
+
+// newTask creates a task from its definition
+func newTask(filePath string, node *yaml.Node, privs privileges) *Task {
+	privs = privs.inherit(node)
+	task := &Task{
+		Metadata:   newMetadata(filePath, yamlnode.Resolve(node)),
+		Become:     privs.become,
+		BecomeUser: privs.becomeUser,
+		Args:       make(map[string]any),
+		Keywords:   make(map[string]any),
+	}
+
+	var args map[string]any
+	for _, entry := range yamlnode.Entries(node) {
+		switch {
+		case entry.Key == "name":
+			task.Name = yamlnode.String(entry.Value)
+		case entry.Key == "become" || entry.Key == "become_user":
+			// already resolved
+		case entry.Key == "args":
+			if m, ok := value(entry.Value).(map[string]any); ok {
+				args = m
+			}
+		case entry.Key == "action" || entry.Key == "local_action":
+			task.Action, task.Args = parseAction(entry.Value)
+			if entry.Key == "local_action" {
+				task.Keywords["delegate_to"] = "localhost"
+			}
+		case slices.Contains(taskKeywords, entry.Key) || strings.HasPrefix(entry.Key, "with_"):
+			task.Keywords[entry.Key] = value(entry.Value)
+		case task.Action == "":
+			task.Action = entry.Key
+			task.Args = parseArgs(moduleName(entry.Key), entry.Value)
+		default:
+			// unknown keywords
+			task.Keywords[entry.Key] = value(entry.Value)
+		}
+	}
+
+	task.Module = moduleName(task.Action)
+	maps.Copy(task.Args, args)
+	return task
+}
+
+// parseAction parses the "action" keyword, e.g. "action: shell echo hello" or "action: {module: shell, ...}"
+func parseAction(node *yaml.Node) (string, map[string]any) {
+	node = yamlnode.Resolve(node)
+	if node != nil && node.Kind == yaml.MappingNode {
+		args, _ := value(node).(map[string]any)
+		module, _ := args["module"].(string)
+		delete(args, "module")
+		return module, args
+	}
+
+	module, params, _ := strings.Cut(strings.TrimSpace(yamlnode.String(node)), " ")
+	return module, parseFreeForm(moduleName(module), params)
+}
+
+// parseArgs parses the arguments of a module given as a mapping or in the free form
+func parseArgs(module string, node *yaml.Node) map[string]any {
+	node = yamlnode.Resolve(node)
+	switch {
+	case node == nil:
+		return make(map[string]any)
+	case node.Kind == yaml.MappingNode:
+		return value(node).(map[string]any)
+	case node.Kind == yaml.ScalarNode && node.Tag != "!!null":
+		return parseFreeForm(module, node.Value)
+	}
+	return make(map[string]any)
+}
+
+// parseFreeForm parses free-form arguments, e.g. "src=a.conf dest=/etc/a.conf" or "echo hello chdir=/tmp"
+func parseFreeForm(module, params string) map[string]any {
+	args := make(map[string]any)
+	var raw []string
+	for _, token := range splitArgs(params) {
+		k, v, ok := strings.Cut(token, "=")
+		switch {
+		case !ok || strings.ContainsAny(k, " \"'{"):
+			raw = append(raw, token)
+		case slices.Contains(rawParamsModules, module) &&
+			!(slices.Contains(commandOptions, k) && (module == "command" || module == "shell")):
+			raw = append(raw, token)
+		default:
+			args[k] = unquote(v)
+		}
+	}
+	if len(raw) > 0 {
+		args["_raw_params"] = strings.Join(raw, " ")
+	}
+	return args
+}
+
+// splitArgs splits arguments by spaces outside of quotes and Jinja2 expressions
+func splitArgs(s string) []string {
+	var (
+		tokens  []string
+		current strings.Builder
+		quote   rune
+		depth   int
+	)
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote && (i == 0 || runes[i-1] != '\\') {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '{' && i+1 < len(runes) && (runes[i+1] == '{' || runes[i+1] == '%'):
+			depth++
+		case (r == '}' || r == '%') && i+1 < len(runes) && runes[i+1] == '}' && depth > 0:
+			depth--
+			current.WriteRune(r)
+			i++
+			r = runes[i]
+		case (r == ' ' || r == '\t' || r == '\n') && depth == 0:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// moduleName returns the name of the module without the builtin collection, e.g. "shell" for "ansible.builtin.shell"
+func moduleName(action string) string {
+	for _, prefix := range builtinCollections {
+		if name, ok := strings.CutPrefix(action, prefix); ok {
+			return name
+		}
+	}
+	return action
+}
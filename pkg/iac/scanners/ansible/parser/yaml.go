@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+// value converts the node into a value for Rego checks.
+// Integers written in the octal notation, such as file modes, are kept as written, e.g. "0644".
+func value(node *yaml.Node) any {
+	return yamlnode.ValueWith(node, func(node *yaml.Node) (any, bool) {
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!int" && isOctal(node.Value) {
+			return node.Value, true
+		}
+		return nil, false
+	})
+}
+
+func isOctal(s string) bool {
+	return len(s) > 1 && (s[0] == '0' && s[1] != 'x' && s[1] != 'X' || strings.HasPrefix(s, "0o"))
+}
+
+// boolValue returns the value of a boolean node, including the "yes" and "no" values used by Ansible.
+// The second return value is false if the value is not set or cannot be determined, e.g. it's templated.
+func boolValue(node *yaml.Node) (bool, bool) {
+	node = yamlnode.Resolve(node)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return false, false
+	}
+	switch strings.ToLower(node.Value) {
+	case "true", "yes", "on", "y", "1":
+		return true, true
+	case "false", "no", "off", "n", "0":
+		return false, true
+	}
+	return false, false
+}
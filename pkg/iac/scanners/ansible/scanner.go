@@ -0,0 +1,83 @@
+package ansible
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/ansible/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var _ scanners.FSScanner = (*Scanner)(nil)
+var _ options.ConfigurableScanner = (*Scanner)(nil)
+
+// Scanner scans Ansible playbooks, roles and inventories.
+// The content of the scanned directory is passed to checks as a single input.
+type Scanner struct {
+	mu             sync.Mutex
+	scannerOptions []options.ScannerOption
+	logger         *log.Logger
+	regoScanner    *rego.Scanner
+}
+
+func New(opts ...options.ScannerOption) *Scanner {
+	scanner := &Scanner{
+		scannerOptions: opts,
+		logger:         log.WithPrefix("ansible scanner"),
+	}
+	for _, opt := range opts {
+		opt(scanner)
+	}
+	return scanner
+}
+
+func (s *Scanner) Name() string {
+	return "Ansible"
+}
+
+func (s *Scanner) initRegoScanner(srcFS fs.FS) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.regoScanner != nil {
+		return nil
+	}
+	regoScanner := rego.NewScanner(types.SourceAnsible, s.scannerOptions...)
+	if err := regoScanner.LoadPolicies(srcFS); err != nil {
+		return err
+	}
+	s.regoScanner = regoScanner
+	return nil
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, fsys fs.FS, dir string) (scan.Results, error) {
+	project, err := parser.New(fsys).ParseFS(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(project.Playbooks) == 0 && len(project.Roles) == 0 && len(project.Inventories) == 0 {
+		return nil, nil
+	}
+
+	if err := s.initRegoScanner(fsys); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Scanning project", log.Int("playbooks", len(project.Playbooks)),
+		log.Int("roles", len(project.Roles)), log.Int("inventories", len(project.Inventories)))
+	results, err := s.regoScanner.ScanInput(ctx, rego.Input{
+		Path:     dir,
+		FS:       fsys,
+		Contents: project.ToRego(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rego scan error: %w", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,157 @@
+package ansible
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+const unquotedVariableCheck = `# METADATA
+# title: Shell commands should quote variables
+# custom:
+#   avd_id: AVD-TEST-0001
+#   severity: HIGH
+#   short_code: quote-shell-variables
+#   input:
+#     selector:
+#     - type: ansible
+package user.ansible.test001
+
+deny[res] {
+	task := input.tasks[_]
+	task.module == "shell"
+	regex.match("{{[^}|]+}}", task.args._raw_params)
+	res := result.new("The shell command uses an unquoted variable", task)
+}
+`
+
+const becomeUserCheck = `# METADATA
+# title: Become should specify the user
+# custom:
+#   avd_id: AVD-TEST-0002
+#   severity: MEDIUM
+#   short_code: specify-become-user
+#   input:
+#     selector:
+#     - type: ansible
+package user.ansible.test002
+
+deny[res] {
+	task := input.tasks[_]
+	task.become
+	task.become_user == ""
+	res := result.new("The task becomes root implicitly", task)
+}
+`
+
+const worldReadableCheck = `# METADATA
+# title: Copied files should not be world-readable
+# custom:
+#   avd_id: AVD-TEST-0003
+#   severity: LOW
+#   short_code: no-world-readable-files
+#   input:
+#     selector:
+#     - type: ansible
+package user.ansible.test003
+
+deny[res] {
+	task := input.tasks[_]
+	task.module == "copy"
+	regex.match("^0?[0-7]{2}[4-7]$", task.args.mode)
+	res := result.new("The copied file is world-readable", task)
+}
+`
+
+func TestScanner_ScanFS(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"rules/test001.rego": unquotedVariableCheck,
+		"rules/test002.rego": becomeUserCheck,
+		"rules/test003.rego": worldReadableCheck,
+		"code/site.yml": `- hosts: webservers
+  become: true
+  become_user: app
+  roles:
+    - web
+  tasks:
+    - name: greet
+      shell: echo {{ greeting }}
+    - name: greet safely
+      shell: echo {{ greeting | quote }}
+`,
+		"code/roles/web/tasks/main.yml": `- copy:
+    src: app.conf
+    dest: /etc/app.conf
+    mode: 0644
+- copy:
+    src: secret.conf
+    dest: /etc/secret.conf
+    mode: "0600"
+`,
+		"code/roles/db/tasks/main.yml": `- apt: name=postgresql
+  become: true
+`,
+	})
+
+	scanner := New(
+		rego.WithPolicyFilesystem(fsys),
+		rego.WithPolicyDirs("rules"),
+		rego.WithPolicyNamespaces("user"),
+		rego.WithEmbeddedPolicies(false),
+		rego.WithEmbeddedLibraries(true),
+	)
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	type failure struct {
+		AVDID     string
+		FilePath  string
+		StartLine int
+		EndLine   int
+	}
+	var got []failure
+	for _, result := range results.GetFailed() {
+		got = append(got, failure{
+			AVDID:     result.Rule().AVDID,
+			FilePath:  result.Range().GetFilename(),
+			StartLine: result.Range().GetStartLine(),
+			EndLine:   result.Range().GetEndLine(),
+		})
+	}
+	want := []failure{
+		{
+			AVDID:     "AVD-TEST-0001",
+			FilePath:  "code/site.yml",
+			StartLine: 7,
+			EndLine:   8,
+		},
+		{
+			AVDID:     "AVD-TEST-0002",
+			FilePath:  "code/roles/db/tasks/main.yml",
+			StartLine: 1,
+			EndLine:   2,
+		},
+		{
+			AVDID:     "AVD-TEST-0003",
+			FilePath:  "code/roles/web/tasks/main.yml",
+			StartLine: 1,
+			EndLine:   4,
+		},
+	}
+	assert.ElementsMatch(t, want, got)
+}
+
+func TestScanner_ScanFS_NoAnsibleFiles(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/values.yaml": `replicas: 1
+`,
+	})
+	results, err := New(rego.WithEmbeddedPolicies(false)).ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
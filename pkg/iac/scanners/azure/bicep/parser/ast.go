@@ -0,0 +1,134 @@
+package parser
+
+// file is a parsed Bicep or Bicep parameters file
+type file struct {
+	targetScope expr
+	// using is the Bicep file of the parameters file
+	using     string
+	params    []*paramDecl
+	vars      []*varDecl
+	resources []*resourceDecl
+	modules   []*moduleDecl
+	outputs   []*varDecl
+}
+
+type paramDecl struct {
+	name       string
+	value      expr // default value, or the value in parameters files
+	decorators []*callExpr
+	startLine  int
+	endLine    int
+}
+
+type varDecl struct {
+	name      string
+	value     expr
+	startLine int
+	endLine   int
+}
+
+type resourceDecl struct {
+	symbol string
+	// typ is the type with the API version, e.g. "Microsoft.Storage/storageAccounts@2023-01-01"
+	typ      string
+	existing bool
+	body     expr
+	// children are the resources declared in the body
+	children  []*resourceDecl
+	startLine int
+	endLine   int
+}
+
+type moduleDecl struct {
+	symbol    string
+	path      string
+	body      expr
+	startLine int
+	endLine   int
+}
+
+type expr interface {
+	lines() (int, int)
+}
+
+type rng struct {
+	start, end int
+}
+
+func (r rng) lines() (int, int) {
+	return r.start, r.end
+}
+
+type (
+	literalExpr struct {
+		rng
+		value any // string, int64, bool or nil
+	}
+	// templateExpr is an interpolated string, e.g. 'st${name}'
+	templateExpr struct {
+		rng
+		parts []any // string or expr
+	}
+	identExpr struct {
+		rng
+		name string
+	}
+	memberExpr struct {
+		rng
+		x    expr
+		name string
+	}
+	indexExpr struct {
+		rng
+		x     expr
+		index expr
+	}
+	callExpr struct {
+		rng
+		name string
+		args []expr
+	}
+	objectExpr struct {
+		rng
+		keys   []string
+		values []expr
+		// resources declared in the object, i.e. child resources
+		resources []*resourceDecl
+	}
+	arrayExpr struct {
+		rng
+		items []expr
+	}
+	// forExpr is a loop, e.g. [for (item, i) in items: item.name]
+	forExpr struct {
+		rng
+		item  string
+		index string
+		src   expr
+		body  expr
+	}
+	// ifExpr is the condition of resources and modules, e.g. if (deploy) { ... }
+	ifExpr struct {
+		rng
+		cond expr
+		body expr
+	}
+	unaryExpr struct {
+		rng
+		op string
+		x  expr
+	}
+	binaryExpr struct {
+		rng
+		op   string
+		x, y expr
+	}
+	ternaryExpr struct {
+		rng
+		cond, x, y expr
+	}
+	// unknownExpr is an expression which is not evaluated, e.g. lambdas
+	unknownExpr struct {
+		rng
+	}
+)
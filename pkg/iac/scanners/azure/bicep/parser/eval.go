@@ -0,0 +1,391 @@
+package parser
+
+import (
+	"path"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// maxModuleDepth limits the nesting of modules, e.g. in case of cyclic references
+const maxModuleDepth = 10
+
+// unknown is the value of expressions evaluated at deployment, e.g. resourceGroup().location
+type unknown struct{}
+
+// symbol is a lazily evaluated declaration
+type symbol struct {
+	eval       func() azure.Value
+	value      *azure.Value
+	evaluating bool
+}
+
+// evaluator evaluates a Bicep file into the deployment, as the Bicep compiler does into an ARM template
+type evaluator struct {
+	parser *Parser
+	path   string
+	file   *file
+	depth  int
+
+	symbols map[string]*symbol
+	// scopes are the variables of loops, from the outermost
+	scopes []map[string]azure.Value
+
+	resources []azure.Resource
+}
+
+func newEvaluator(p *Parser, filePath string, f *file, params map[string]azure.Value, depth int) *evaluator {
+	e := &evaluator{
+		parser:  p,
+		path:    filePath,
+		file:    f,
+		depth:   depth,
+		symbols: make(map[string]*symbol),
+	}
+
+	for _, param := range f.params {
+		e.symbols[param.name] = &symbol{eval: func() azure.Value {
+			if val, ok := params[param.name]; ok {
+				return val
+			} else if param.value != nil {
+				return e.eval(param.value)
+			}
+			return e.unknown(param.startLine, param.endLine)
+		}}
+	}
+	for _, v := range f.vars {
+		e.symbols[v.name] = &symbol{eval: func() azure.Value {
+			return e.eval(v.value)
+		}}
+	}
+	for _, r := range f.resources {
+		e.addResourceSymbols(r, "", "", "")
+	}
+	for _, m := range f.modules {
+		e.symbols[m.symbol] = &symbol{eval: func() azure.Value {
+			return e.evalModule(m)
+		}}
+	}
+	return e
+}
+
+func (e *evaluator) metadata(start, end int) types.Metadata {
+	return types.NewMetadata(types.NewRange(e.path, start, end, "", e.parser.fsys), "")
+}
+
+func (e *evaluator) unknown(start, end int) azure.Value {
+	return azure.NewValue(unknown{}, e.metadata(start, end))
+}
+
+func (e *evaluator) lookup(name string) (azure.Value, bool) {
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		if val, ok := e.scopes[i][name]; ok {
+			return val, true
+		}
+	}
+
+	s, ok := e.symbols[name]
+	if !ok {
+		return azure.Value{}, false
+	}
+	if s.value == nil {
+		if s.evaluating {
+			// cyclic reference
+			return azure.Value{}, false
+		}
+		s.evaluating = true
+		// Symbols are evaluated outside of loops
+		scopes := e.scopes
+		e.scopes = nil
+		val := s.eval()
+		e.scopes = scopes
+		s.value = &val
+		s.evaluating = false
+	}
+	return *s.value, true
+}
+
+// withScope evaluates fn with the variables of the loop
+func (e *evaluator) withScope(vars map[string]azure.Value, fn func()) {
+	e.scopes = append(e.scopes, vars)
+	fn()
+	e.scopes = e.scopes[:len(e.scopes)-1]
+}
+
+// deployment evaluates the file, including the modules
+func (e *evaluator) deployment() azure.Deployment {
+	deployment := azure.Deployment{
+		Metadata:    e.metadata(0, 0),
+		TargetScope: azure.ScopeResourceGroup,
+	}
+	if e.file.targetScope != nil {
+		if scope := e.eval(e.file.targetScope).AsString(); scope != "" {
+			deployment.TargetScope = azure.Scope(scope)
+		}
+	}
+
+	for _, param := range e.file.params {
+		val, _ := e.lookup(param.name)
+		var def azure.Value
+		if param.value != nil {
+			def = e.eval(param.value)
+		}
+		deployment.Parameters = append(deployment.Parameters, azure.Parameter{
+			Variable: azure.Variable{
+				Name:  param.name,
+				Value: val,
+			},
+			Default:    def,
+			Decorators: e.decorators(param.decorators),
+		})
+	}
+	for _, v := range e.file.vars {
+		val, _ := e.lookup(v.name)
+		deployment.Variables = append(deployment.Variables, azure.Variable{
+			Name:  v.name,
+			Value: val,
+		})
+	}
+
+	for _, r := range e.file.resources {
+		e.evalResource(r, "", "", azure.Value{})
+	}
+	// Resources of modules are added when the modules are evaluated
+	for _, m := range e.file.modules {
+		e.lookup(m.symbol)
+	}
+	deployment.Resources = e.resources
+
+	for _, output := range e.file.outputs {
+		deployment.Outputs = append(deployment.Outputs, azure.Output{
+			Name:  output.name,
+			Value: e.eval(output.value),
+		})
+	}
+	return deployment
+}
+
+func (e *evaluator) decorators(calls []*callExpr) []azure.Decorator {
+	var decorators []azure.Decorator
+	for _, call := range calls {
+		decorator := azure.Decorator{Name: call.name}
+		for _, arg := range call.args {
+			decorator.Args = append(decorator.Args, e.eval(arg))
+		}
+		decorators = append(decorators, decorator)
+	}
+	return decorators
+}
+
+// resourceType returns the full type and the API version of the resource.
+// Nested resources have types relative to the parent, e.g. "blobServices".
+func resourceType(r *resourceDecl, parentType, parentVersion string) (string, string) {
+	typ, version, found := strings.Cut(r.typ, "@")
+	if !found {
+		version = parentVersion
+	}
+	if parentType != "" && !strings.Contains(typ, ".") {
+		typ = parentType + "/" + typ
+	}
+	return typ, version
+}
+
+// addResourceSymbols adds the symbols of the resource and the nested resources,
+// which are evaluated to the objects with the declared properties, e.g. sa.properties.minimumTlsVersion.
+// The nested resources are referenced by the names qualified by the parent, e.g. sa::blob,
+// or by the names in the body of the parent.
+func (e *evaluator) addResourceSymbols(r *resourceDecl, parentType, parentVersion, parentSymbol string) {
+	typ, version := resourceType(r, parentType, parentVersion)
+	symbolName := r.symbol
+	if parentSymbol != "" {
+		symbolName = parentSymbol + "::" + r.symbol
+	}
+	s := &symbol{eval: func() azure.Value {
+		var parentName azure.Value
+		if parentSymbol != "" {
+			parent, _ := e.lookup(parentSymbol)
+			parentName = parent.GetMapValue("name")
+		}
+
+		var instances []azure.Value
+		isLoop := e.instances(r.body, func(body azure.Value) {
+			props := body.AsMap()
+			obj := make(map[string]azure.Value, len(props)+3)
+			for k, v := range props {
+				obj[k] = v
+			}
+			obj["name"] = qualifiedName(body, parentName)
+			obj["id"] = e.unknown(r.startLine, r.endLine)
+			obj["type"] = azure.NewValue(typ, e.metadata(r.startLine, r.startLine))
+			obj["apiVersion"] = azure.NewValue(version, e.metadata(r.startLine, r.startLine))
+			instances = append(instances, azure.NewValue(obj, e.metadata(r.startLine, r.endLine)))
+		})
+		switch {
+		case isLoop:
+			return azure.NewValue(instances, e.metadata(r.startLine, r.endLine))
+		case len(instances) == 0:
+			return e.unknown(r.startLine, r.endLine)
+		}
+		return instances[0]
+	}}
+	e.symbols[symbolName] = s
+	if _, exists := e.symbols[r.symbol]; !exists {
+		e.symbols[r.symbol] = s
+	}
+	for _, child := range r.children {
+		e.addResourceSymbols(child, typ, version, symbolName)
+	}
+}
+
+// qualifiedName returns the name of the resource qualified by the parent name, as in ARM templates,
+// e.g. "st/default" for the blob service of the storage account.
+// The parent is the resource declaring the nested resource, or the one of the parent property.
+func qualifiedName(body, parentName azure.Value) azure.Value {
+	name, ok := body.AsMap()["name"]
+	if !ok {
+		return azure.NewValue(nil, body.Metadata)
+	}
+	if parent, ok := body.AsMap()["parent"]; ok {
+		parentName = parent.GetMapValue("name")
+	}
+	if name.Kind == azure.KindString && parentName.Kind == azure.KindString {
+		return azure.NewValue(parentName.AsString()+"/"+name.AsString(), name.Metadata)
+	}
+	return name
+}
+
+// evalResource adds the instances of the resource and the nested resources to the deployment.
+// Existing resources are not part of the deployment.
+func (e *evaluator) evalResource(r *resourceDecl, parentType, parentVersion string, parentName azure.Value) {
+	typ, version := resourceType(r, parentType, parentVersion)
+	e.instances(r.body, func(body azure.Value) {
+		metadata := e.metadata(r.startLine, r.endLine)
+		prop := func(name string) azure.Value {
+			if val, ok := body.AsMap()[name]; ok {
+				return val
+			}
+			return azure.NewValue(nil, metadata)
+		}
+
+		name := qualifiedName(body, parentName)
+		if !r.existing {
+			e.resources = append(e.resources, azure.Resource{
+				Metadata:   metadata,
+				APIVersion: azure.NewValue(version, e.metadata(r.startLine, r.startLine)),
+				Type:       azure.NewValue(typ, e.metadata(r.startLine, r.startLine)),
+				Kind:       prop("kind"),
+				Name:       name,
+				Location:   prop("location"),
+				Tags:       prop("tags"),
+				Sku:        prop("sku"),
+				Properties: prop("properties"),
+			})
+		}
+
+		for _, child := range r.children {
+			e.evalResource(child, typ, version, name)
+		}
+	})
+}
+
+// instances calls fn with the evaluated bodies of the instances of resources and modules.
+// Conditional instances are skipped if the condition is false.
+// Loops over values not known until deployment have a single instance.
+func (e *evaluator) instances(body expr, fn func(body azure.Value)) bool {
+	switch b := body.(type) {
+	case *ifExpr:
+		if cond := e.eval(b.cond); cond.Kind == azure.KindBoolean && !cond.AsBool() {
+			return false
+		}
+		return e.instances(b.body, fn)
+	case *forExpr:
+		e.loop(b, func() {
+			e.instances(b.body, fn)
+		})
+		return true
+	}
+	fn(e.eval(body))
+	return false
+}
+
+// loop calls fn for each item with the loop variables
+func (e *evaluator) loop(loop *forExpr, fn func()) {
+	src := e.eval(loop.src)
+	var items []azure.Value
+	switch src.Kind {
+	case azure.KindArray:
+		items = src.AsList()
+	case azure.KindNumber:
+		// "for i in range(0, n)" is evaluated as an array, so numbers are not iterated
+	default:
+		start, end := loop.src.lines()
+		items = []azure.Value{e.unknown(start, end)}
+	}
+
+	for i, item := range items {
+		vars := map[string]azure.Value{loop.item: item}
+		if loop.index != "" {
+			vars[loop.index] = azure.NewValue(int64(i), item.Metadata)
+		}
+		e.withScope(vars, fn)
+	}
+}
+
+// evalModule evaluates the module and adds the resources to the deployment.
+// The module is evaluated to an object with the outputs, e.g. mod.outputs.id
+func (e *evaluator) evalModule(m *moduleDecl) azure.Value {
+	metadata := e.metadata(m.startLine, m.endLine)
+	modulePath, ok := e.modulePath(m.path)
+	if !ok {
+		e.parser.logger.Debug("Skipping module from registry", log.String("source", m.path))
+		return e.unknown(m.startLine, m.endLine)
+	}
+	if e.depth >= maxModuleDepth {
+		return e.unknown(m.startLine, m.endLine)
+	}
+	f, err := e.parser.parseFile(modulePath)
+	if err != nil {
+		e.parser.logger.Error("Failed to parse module", log.FilePath(modulePath), log.Err(err))
+		return e.unknown(m.startLine, m.endLine)
+	}
+
+	var instances []azure.Value
+	isLoop := e.instances(m.body, func(body azure.Value) {
+		params := body.AsMap()["params"].AsMap()
+		module := newEvaluator(e.parser, modulePath, f, params, e.depth+1)
+		deployment := module.deployment()
+		e.resources = append(e.resources, deployment.Resources...)
+
+		outputs := make(map[string]azure.Value)
+		for _, output := range deployment.Outputs {
+			outputs[output.Name] = output.Value
+		}
+		instances = append(instances, azure.NewValue(map[string]any{
+			"name":    body.AsMap()["name"],
+			"outputs": azure.NewValue(outputs, metadata),
+		}, metadata))
+	})
+
+	switch {
+	case isLoop:
+		return azure.NewValue(instances, metadata)
+	case len(instances) == 0:
+		return e.unknown(m.startLine, m.endLine)
+	}
+	return instances[0]
+}
+
+// modulePath returns the path of local modules. Modules in registries are not supported.
+func (e *evaluator) modulePath(source string) (string, bool) {
+	if strings.Contains(source, ":") {
+		// e.g. "br:mcr.microsoft.com/bicep/storage/storage-account:1.0.1" and "ts:..."
+		return "", false
+	}
+	if strings.HasPrefix(source, "br/") {
+		// registry aliases, e.g. "br/public:..."
+		return "", false
+	}
+	return path.Join(path.Dir(e.path), source), true
+}
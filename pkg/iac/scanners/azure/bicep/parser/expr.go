@@ -0,0 +1,348 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure/functions"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+// pureFunctions are the functions which are evaluated with the implementations of the ARM template functions.
+// The other functions, e.g. resourceGroup() and uniqueString(), are evaluated at deployment.
+var pureFunctions = []string{
+	"array", "base64", "base64ToJson", "bool", "coalesce", "concat", "contains", "createArray", "createObject",
+	"dataUri", "dataUriToString", "empty", "endsWith", "first", "format", "indexOf", "int", "intersection",
+	"join", "last", "lastIndexOf", "length", "max", "min", "padLeft", "range", "replace", "skip", "split",
+	"startsWith", "string", "substring", "take", "toLower", "toUpper", "trim", "union", "uri",
+}
+
+func (e *evaluator) eval(x expr) azure.Value {
+	start, end := x.lines()
+	metadata := e.metadata(start, end)
+
+	switch x := x.(type) {
+	case *literalExpr:
+		return azure.NewValue(x.value, metadata)
+	case *templateExpr:
+		var sb strings.Builder
+		for _, part := range x.parts {
+			switch part := part.(type) {
+			case string:
+				sb.WriteString(part)
+			case expr:
+				val := e.eval(part)
+				switch val.Kind {
+				case azure.KindString, azure.KindNumber, azure.KindBoolean:
+					sb.WriteString(fmt.Sprint(val.Raw()))
+				default:
+					return e.unknown(start, end)
+				}
+			}
+		}
+		return azure.NewValue(sb.String(), metadata)
+	case *identExpr:
+		val, ok := e.lookup(x.name)
+		if !ok {
+			return e.unknown(start, end)
+		}
+		// The value is reported at the reference, e.g. the property of the resource set to the parameter
+		val.Metadata = metadata
+		return val
+	case *memberExpr:
+		obj := e.eval(x.x)
+		switch obj.Kind {
+		case azure.KindObject:
+			if val, ok := obj.AsMap()[x.name]; ok {
+				return val
+			}
+			return azure.NewValue(nil, metadata)
+		case azure.KindNull:
+			return azure.NewValue(nil, metadata)
+		}
+		return e.unknown(start, end)
+	case *indexExpr:
+		return e.evalIndex(e.eval(x.x), e.eval(x.index), metadata)
+	case *callExpr:
+		return e.evalCall(x)
+	case *objectExpr:
+		obj := make(map[string]azure.Value, len(x.keys))
+		for i, key := range x.keys {
+			obj[key] = e.eval(x.values[i])
+		}
+		return azure.NewValue(obj, metadata)
+	case *arrayExpr:
+		items := make([]azure.Value, 0, len(x.items))
+		for _, item := range x.items {
+			items = append(items, e.eval(item))
+		}
+		return azure.NewValue(items, metadata)
+	case *forExpr:
+		if src := e.eval(x.src); src.Kind != azure.KindArray {
+			return e.unknown(start, end)
+		}
+		items := make([]azure.Value, 0)
+		e.loop(x, func() {
+			items = append(items, e.eval(x.body))
+		})
+		return azure.NewValue(items, metadata)
+	case *ifExpr:
+		cond := e.eval(x.cond)
+		switch {
+		case cond.Kind != azure.KindBoolean:
+			return e.unknown(start, end)
+		case cond.AsBool():
+			return e.eval(x.body)
+		}
+		return azure.NewValue(nil, metadata)
+	case *unaryExpr:
+		val := e.eval(x.x)
+		switch {
+		case x.op == "!" && val.Kind == azure.KindBoolean:
+			return azure.NewValue(!val.AsBool(), metadata)
+		case x.op == "-" && val.Kind == azure.KindNumber:
+			return azure.NewValue(-int64(val.AsInt()), metadata)
+		}
+		return e.unknown(start, end)
+	case *binaryExpr:
+		return e.evalBinary(x)
+	case *ternaryExpr:
+		cond := e.eval(x.cond)
+		if cond.Kind != azure.KindBoolean {
+			return e.unknown(start, end)
+		} else if cond.AsBool() {
+			return e.eval(x.x)
+		}
+		return e.eval(x.y)
+	}
+	return e.unknown(start, end)
+}
+
+func (e *evaluator) evalIndex(x, index azure.Value, metadata types.Metadata) azure.Value {
+	switch {
+	case x.Kind == azure.KindArray && index.Kind == azure.KindNumber:
+		items := x.AsList()
+		if i := index.AsInt(); i >= 0 && i < len(items) {
+			return items[i]
+		}
+		return azure.NewValue(nil, metadata)
+	case x.Kind == azure.KindObject && index.Kind == azure.KindString:
+		if val, ok := x.AsMap()[index.AsString()]; ok {
+			return val
+		}
+		return azure.NewValue(nil, metadata)
+	}
+	return azure.NewValue(unknown{}, metadata)
+}
+
+func (e *evaluator) evalCall(call *callExpr) azure.Value {
+	start, end := call.lines()
+	metadata := e.metadata(start, end)
+
+	args := make([]any, 0, len(call.args))
+	for _, arg := range call.args {
+		val := e.eval(arg)
+		if call.name == "any" {
+			// any() only changes the type
+			return val
+		}
+		raw, ok := toRaw(val)
+		if !ok {
+			return e.unknown(start, end)
+		}
+		args = append(args, raw)
+	}
+
+	if !slices.Contains(pureFunctions, call.name) {
+		return e.unknown(start, end)
+	}
+	result, ok := callFunction(call.name, args)
+	if !ok {
+		return e.unknown(start, end)
+	}
+	return fromRaw(result, metadata)
+}
+
+// callFunction calls the implementation of the ARM template function,
+// which may panic for the arguments of unexpected types
+func callFunction(name string, args []any) (result any, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, ok = nil, false
+		}
+	}()
+	return functions.Evaluate(nil, name, args...), true
+}
+
+func (e *evaluator) evalBinary(x *binaryExpr) azure.Value {
+	start, end := x.lines()
+	metadata := e.metadata(start, end)
+	unknownValue := e.unknown(start, end)
+
+	l := e.eval(x.x)
+	switch x.op {
+	case "??":
+		if l.Kind == azure.KindNull {
+			return e.eval(x.y)
+		}
+		return l
+	case "&&", "||":
+		if l.Kind != azure.KindBoolean {
+			return unknownValue
+		}
+		// short-circuit evaluation
+		if l.AsBool() == (x.op == "||") {
+			return azure.NewValue(l.AsBool(), metadata)
+		}
+		if r := e.eval(x.y); r.Kind == azure.KindBoolean {
+			return azure.NewValue(r.AsBool(), metadata)
+		}
+		return unknownValue
+	}
+
+	r := e.eval(x.y)
+	if l.Kind == azure.KindUnresolvable || r.Kind == azure.KindUnresolvable {
+		return unknownValue
+	}
+
+	switch x.op {
+	case "==", "!=":
+		return azure.NewValue(equal(l, r, false) == (x.op == "=="), metadata)
+	case "=~", "!~":
+		return azure.NewValue(equal(l, r, true) == (x.op == "=~"), metadata)
+	}
+
+	switch {
+	case l.Kind == azure.KindNumber && r.Kind == azure.KindNumber:
+		a, b := int64(l.AsInt()), int64(r.AsInt())
+		switch x.op {
+		case "<":
+			return azure.NewValue(a < b, metadata)
+		case ">":
+			return azure.NewValue(a > b, metadata)
+		case "<=":
+			return azure.NewValue(a <= b, metadata)
+		case ">=":
+			return azure.NewValue(a >= b, metadata)
+		case "+":
+			return azure.NewValue(a+b, metadata)
+		case "-":
+			return azure.NewValue(a-b, metadata)
+		case "*":
+			return azure.NewValue(a*b, metadata)
+		case "/":
+			if b != 0 {
+				return azure.NewValue(a/b, metadata)
+			}
+		case "%":
+			if b != 0 {
+				return azure.NewValue(a%b, metadata)
+			}
+		}
+	case l.Kind == azure.KindString && r.Kind == azure.KindString:
+		a, b := l.AsString(), r.AsString()
+		switch x.op {
+		case "<":
+			return azure.NewValue(a < b, metadata)
+		case ">":
+			return azure.NewValue(a > b, metadata)
+		case "<=":
+			return azure.NewValue(a <= b, metadata)
+		case ">=":
+			return azure.NewValue(a >= b, metadata)
+		}
+	}
+	return unknownValue
+}
+
+// equal compares the values, strings are compared case-insensitively if ignoreCase is true
+func equal(a, b azure.Value, ignoreCase bool) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	switch a.Kind {
+	case azure.KindString:
+		if ignoreCase {
+			return strings.EqualFold(a.AsString(), b.AsString())
+		}
+		return a.AsString() == b.AsString()
+	case azure.KindArray:
+		return slices.EqualFunc(a.AsList(), b.AsList(), func(x, y azure.Value) bool {
+			return equal(x, y, ignoreCase)
+		})
+	case azure.KindObject:
+		am, bm := a.AsMap(), b.AsMap()
+		if len(am) != len(bm) {
+			return false
+		}
+		for k, v := range am {
+			if w, ok := bm[k]; !ok || !equal(v, w, ignoreCase) {
+				return false
+			}
+		}
+		return true
+	}
+	return a.Raw() == b.Raw()
+}
+
+// toRaw converts the value to the arguments of the ARM template functions.
+// It returns false if the value isn't known until deployment.
+func toRaw(v azure.Value) (any, bool) {
+	switch v.Kind {
+	case azure.KindArray:
+		items := make([]any, 0, len(v.AsList()))
+		for _, item := range v.AsList() {
+			raw, ok := toRaw(item)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, raw)
+		}
+		return items, true
+	case azure.KindObject:
+		obj := make(map[string]any, len(v.AsMap()))
+		for k, val := range v.AsMap() {
+			raw, ok := toRaw(val)
+			if !ok {
+				return nil, false
+			}
+			obj[k] = raw
+		}
+		return obj, true
+	case azure.KindNumber:
+		return v.AsInt(), true
+	case azure.KindString, azure.KindBoolean, azure.KindNull:
+		return v.Raw(), true
+	}
+	return nil, false
+}
+
+// fromRaw converts the result of the ARM template functions to the value
+func fromRaw(raw any, metadata types.Metadata) azure.Value {
+	switch raw := raw.(type) {
+	case []any:
+		items := make([]azure.Value, 0, len(raw))
+		for _, item := range raw {
+			items = append(items, fromRaw(item, metadata))
+		}
+		return azure.NewValue(items, metadata)
+	case map[string]any:
+		obj := make(map[string]azure.Value, len(raw))
+		for k, val := range raw {
+			obj[k] = fromRaw(val, metadata)
+		}
+		return azure.NewValue(obj, metadata)
+	case int:
+		return azure.NewValue(int64(raw), metadata)
+	case float64:
+		// e.g. the numbers of parsed JSON
+		if raw == float64(int64(raw)) {
+			return azure.NewValue(int64(raw), metadata)
+		}
+	case string, bool, nil, int64:
+		return azure.NewValue(raw, metadata)
+	}
+	return azure.NewValue(unknown{}, metadata)
+}
@@ -0,0 +1,243 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNewLine
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	// text is the identifier, the number or the punctuation
+	text string
+	// parts are the literals (string) and the interpolated expressions ([]token) of strings
+	parts []any
+	line  int
+}
+
+func (t token) is(kind tokenKind, text string) bool {
+	return t.kind == kind && t.text == text
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of file"
+	case tokenNewLine:
+		return "new line"
+	case tokenString:
+		return "string"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// punctuations sorted by length, so that the longest one is matched first
+var punctuations = []string{
+	"??", ".?", "==", "!=", "=~", "!~", "<=", ">=", "&&", "||", "=>", "::",
+	"{", "}", "[", "]", "(", ")", ",", ":", ".", "?", "!", "=", "<", ">", "+", "-", "*", "/", "%", "@", "|",
+}
+
+type lexer struct {
+	src  []rune
+	pos  int
+	line int
+}
+
+func lex(src string) ([]token, error) {
+	l := &lexer{
+		src:  []rune(src),
+		line: 1,
+	}
+	tokens, err := l.lex(false)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", l.line, err)
+	}
+	return tokens, nil
+}
+
+// lex returns the tokens until the end of the source, or the closing brace of the interpolation
+func (l *lexer) lex(interpolation bool) ([]token, error) {
+	var tokens []token
+	depth := 0
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			tokens = append(tokens, token{kind: tokenNewLine, line: l.line})
+			l.line++
+			l.pos++
+		case unicode.IsSpace(c):
+			l.pos++
+		case l.hasPrefix("//"):
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case l.hasPrefix("/*"):
+			l.pos += 2
+			end := l.index("*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			l.line += strings.Count(string(l.src[l.pos:l.pos+end]), "\n")
+			l.pos += end + 2
+		case l.hasPrefix("'''"):
+			tok, err := l.multilineString()
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+		case c == '\'':
+			tok, err := l.string()
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+		case unicode.IsDigit(c):
+			start := l.pos
+			for l.pos < len(l.src) && (unicode.IsDigit(l.src[l.pos]) || l.src[l.pos] == '.' &&
+				l.pos+1 < len(l.src) && unicode.IsDigit(l.src[l.pos+1])) {
+				l.pos++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(l.src[start:l.pos]), line: l.line})
+		case c == '_' || unicode.IsLetter(c):
+			start := l.pos
+			for l.pos < len(l.src) && (l.src[l.pos] == '_' || unicode.IsLetter(l.src[l.pos]) || unicode.IsDigit(l.src[l.pos])) {
+				l.pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(l.src[start:l.pos]), line: l.line})
+		default:
+			punct, ok := l.punctuation()
+			if !ok {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+			if interpolation {
+				switch punct {
+				case "{":
+					depth++
+				case "}":
+					if depth == 0 {
+						l.pos++
+						return tokens, nil
+					}
+					depth--
+				}
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: punct, line: l.line})
+			l.pos += len(punct)
+		}
+	}
+	if interpolation {
+		return nil, fmt.Errorf("unterminated interpolation")
+	}
+	return append(tokens, token{kind: tokenEOF, line: l.line}), nil
+}
+
+func (l *lexer) hasPrefix(s string) bool {
+	return strings.HasPrefix(string(l.src[l.pos:min(l.pos+len(s), len(l.src))]), s)
+}
+
+// index returns the offset of s from the current position, or -1 if it's not found
+func (l *lexer) index(s string) int {
+	r := []rune(s)
+	for i := l.pos; i+len(r) <= len(l.src); i++ {
+		if string(l.src[i:i+len(r)]) == s {
+			return i - l.pos
+		}
+	}
+	return -1
+}
+
+func (l *lexer) punctuation() (string, bool) {
+	for _, p := range punctuations {
+		if l.hasPrefix(p) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// string lexes a single-quoted string, which may contain escapes and interpolations, e.g. 'st${name}\'s'
+func (l *lexer) string() (token, error) {
+	tok := token{kind: tokenString, line: l.line}
+	var sb strings.Builder
+	l.pos++ // opening quote
+	for {
+		if l.pos >= len(l.src) || l.src[l.pos] == '\n' {
+			return token{}, fmt.Errorf("unterminated string")
+		}
+		c := l.src[l.pos]
+		switch {
+		case c == '\'':
+			l.pos++
+			tok.parts = append(tok.parts, sb.String())
+			return tok, nil
+		case c == '\\' && l.pos+1 < len(l.src):
+			l.pos++
+			switch e := l.src[l.pos]; e {
+			case 'n':
+				sb.WriteRune('\n')
+			case 'r':
+				sb.WriteRune('\r')
+			case 't':
+				sb.WriteRune('\t')
+			case 'u':
+				// \u{1F600}
+				end := l.index("}")
+				if end < 0 || l.src[l.pos+1] != '{' {
+					return token{}, fmt.Errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseInt(string(l.src[l.pos+2:l.pos+end]), 16, 32)
+				if err != nil {
+					return token{}, fmt.Errorf("invalid unicode escape: %w", err)
+				}
+				sb.WriteRune(rune(code))
+				l.pos += end
+			default:
+				// \' \\ \$
+				sb.WriteRune(e)
+			}
+			l.pos++
+		case c == '$' && l.hasPrefix("${"):
+			l.pos += 2
+			tok.parts = append(tok.parts, sb.String())
+			sb.Reset()
+			tokens, err := l.lex(true)
+			if err != nil {
+				return token{}, err
+			}
+			tok.parts = append(tok.parts, append(tokens, token{kind: tokenEOF, line: l.line}))
+		default:
+			sb.WriteRune(c)
+			l.pos++
+		}
+	}
+}
+
+// multilineString lexes a string enclosed by triple quotes, which doesn't support escapes and interpolations
+func (l *lexer) multilineString() (token, error) {
+	tok := token{kind: tokenString, line: l.line}
+	l.pos += 3
+	end := l.index("'''")
+	if end < 0 {
+		return token{}, fmt.Errorf("unterminated multi-line string")
+	}
+	s := string(l.src[l.pos : l.pos+end])
+	l.pos += end + 3
+	l.line += strings.Count(s, "\n")
+	// The first new line is ignored
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "\r"), "\n")
+	tok.parts = []any{s}
+	return tok, nil
+}
@@ -0,0 +1,706 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type syntaxParser struct {
+	tokens []token
+	pos    int
+}
+
+// parse parses the Bicep source. Statements which don't affect the deployment,
+// such as imports, user-defined types and functions, are skipped.
+func parse(src string) (*file, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &syntaxParser{tokens: tokens}
+	f, err := p.parseFile()
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", p.peek().line, err)
+	}
+	return f, nil
+}
+
+func (p *syntaxParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *syntaxParser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *syntaxParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// prevLine returns the line of the last consumed token
+func (p *syntaxParser) prevLine() int {
+	if p.pos == 0 {
+		return p.peek().line
+	}
+	return p.tokens[p.pos-1].line
+}
+
+func (p *syntaxParser) skipNewLines() {
+	for p.peek().kind == tokenNewLine {
+		p.next()
+	}
+}
+
+func (p *syntaxParser) expect(kind tokenKind, text string) (token, error) {
+	t := p.next()
+	if t.kind != kind || text != "" && t.text != text {
+		return t, fmt.Errorf("unexpected %s", t)
+	}
+	return t, nil
+}
+
+func (p *syntaxParser) expectIdent() (string, error) {
+	t, err := p.expect(tokenIdent, "")
+	return t.text, err
+}
+
+func (p *syntaxParser) expectString() (string, error) {
+	t := p.next()
+	if t.kind != tokenString || len(t.parts) != 1 {
+		return "", fmt.Errorf("expected string literal, got %s", t)
+	}
+	return t.parts[0].(string), nil
+}
+
+// skipStatement skips the tokens until the end of the statement
+func (p *syntaxParser) skipStatement() {
+	depth := 0
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return
+		case t.kind == tokenNewLine && depth <= 0:
+			return
+		case t.kind == tokenPunct && strings.Contains("{[(", t.text):
+			depth++
+		case t.kind == tokenPunct && strings.Contains("}])", t.text):
+			depth--
+		}
+		p.next()
+	}
+}
+
+func (p *syntaxParser) parseFile() (*file, error) {
+	f := &file{}
+	for {
+		p.skipNewLines()
+
+		decorators, err := p.parseDecorators()
+		if err != nil {
+			return nil, err
+		}
+
+		t := p.peek()
+		if t.kind == tokenEOF {
+			return f, nil
+		} else if t.kind != tokenIdent {
+			return nil, fmt.Errorf("unexpected %s", t)
+		}
+		startLine := t.line
+
+		switch t.text {
+		case "targetScope":
+			p.next()
+			if _, err := p.expect(tokenPunct, "="); err != nil {
+				return nil, err
+			}
+			if f.targetScope, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+		case "using":
+			p.next()
+			// "using none" is used for parameters files without Bicep files
+			if p.peek().kind == tokenString {
+				if f.using, err = p.expectString(); err != nil {
+					return nil, err
+				}
+			} else {
+				p.skipStatement()
+			}
+		case "param":
+			p.next()
+			param := &paramDecl{decorators: decorators, startLine: startLine}
+			if param.name, err = p.expectIdent(); err != nil {
+				return nil, err
+			}
+			// The parameters files don't have types
+			if !p.peek().is(tokenPunct, "=") {
+				p.skipType()
+			}
+			if p.peek().is(tokenPunct, "=") {
+				p.next()
+				if param.value, err = p.parseExpr(); err != nil {
+					return nil, err
+				}
+			}
+			param.endLine = p.prevLine()
+			f.params = append(f.params, param)
+		case "var", "output":
+			p.next()
+			v := &varDecl{startLine: startLine}
+			if v.name, err = p.expectIdent(); err != nil {
+				return nil, err
+			}
+			if t.text == "output" {
+				p.skipType()
+			}
+			if _, err := p.expect(tokenPunct, "="); err != nil {
+				return nil, err
+			}
+			if v.value, err = p.parseExpr(); err != nil {
+				return nil, err
+			}
+			v.endLine = p.prevLine()
+			if t.text == "var" {
+				f.vars = append(f.vars, v)
+			} else {
+				f.outputs = append(f.outputs, v)
+			}
+		case "resource":
+			if p.peekAt(1).kind != tokenIdent {
+				p.skipStatement()
+				continue
+			}
+			r, err := p.parseResource()
+			if err != nil {
+				return nil, err
+			}
+			f.resources = append(f.resources, r)
+		case "module":
+			p.next()
+			m := &moduleDecl{startLine: startLine}
+			if m.symbol, err = p.expectIdent(); err != nil {
+				return nil, err
+			}
+			if m.path, err = p.expectString(); err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenPunct, "="); err != nil {
+				return nil, err
+			}
+			if m.body, err = p.parseBody(); err != nil {
+				return nil, err
+			}
+			m.endLine = p.prevLine()
+			f.modules = append(f.modules, m)
+		default:
+			// metadata, import, type, func, extension, etc.
+			p.skipStatement()
+		}
+	}
+}
+
+func (p *syntaxParser) parseDecorators() ([]*callExpr, error) {
+	var decorators []*callExpr
+	for p.peek().is(tokenPunct, "@") {
+		p.next()
+		x, err := p.parsePostfix()
+		if err != nil {
+			return nil, err
+		}
+		if call, ok := x.(*callExpr); ok {
+			decorators = append(decorators, call)
+		}
+		p.skipNewLines()
+	}
+	return decorators, nil
+}
+
+// skipType skips the type of parameters and outputs, e.g. "string[]" and "'a' | 'b'"
+func (p *syntaxParser) skipType() {
+	depth := 0
+	for {
+		t := p.peek()
+		switch {
+		case t.kind == tokenEOF:
+			return
+		case depth == 0 && (t.kind == tokenNewLine || t.is(tokenPunct, "=")):
+			return
+		case t.kind == tokenPunct && strings.Contains("{[(", t.text):
+			depth++
+		case t.kind == tokenPunct && strings.Contains("}])", t.text):
+			depth--
+		}
+		p.next()
+	}
+}
+
+// parseResource parses a resource declaration, e.g. resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {...}
+func (p *syntaxParser) parseResource() (*resourceDecl, error) {
+	r := &resourceDecl{startLine: p.next().line}
+	var err error
+	if r.symbol, err = p.expectIdent(); err != nil {
+		return nil, err
+	}
+	if r.typ, err = p.expectString(); err != nil {
+		return nil, err
+	}
+	if p.peek().is(tokenIdent, "existing") {
+		p.next()
+		r.existing = true
+	}
+	if _, err := p.expect(tokenPunct, "="); err != nil {
+		return nil, err
+	}
+	if r.body, err = p.parseBody(); err != nil {
+		return nil, err
+	}
+	r.children = bodyResources(r.body)
+	r.endLine = p.prevLine()
+	return r, nil
+}
+
+// bodyResources returns the resources declared in the resource body
+func bodyResources(body expr) []*resourceDecl {
+	switch b := body.(type) {
+	case *objectExpr:
+		return b.resources
+	case *ifExpr:
+		return bodyResources(b.body)
+	case *forExpr:
+		return bodyResources(b.body)
+	}
+	return nil
+}
+
+// parseBody parses the body of resources and modules, which may be conditional or a loop
+func (p *syntaxParser) parseBody() (expr, error) {
+	if p.peek().is(tokenIdent, "if") {
+		return p.parseIf()
+	}
+	return p.parseExpr()
+}
+
+func (p *syntaxParser) parseIf() (expr, error) {
+	start := p.next().line
+	if _, err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+	cond, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenPunct, ")"); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	return &ifExpr{rng: rng{start, p.prevLine()}, cond: cond, body: body}, nil
+}
+
+func (p *syntaxParser) parseExpr() (expr, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.peek().is(tokenPunct, "?") {
+		return cond, nil
+	}
+	p.next()
+	p.skipNewLines()
+	x, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipNewLines()
+	if _, err := p.expect(tokenPunct, ":"); err != nil {
+		return nil, err
+	}
+	p.skipNewLines()
+	y, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	start, _ := cond.lines()
+	return &ternaryExpr{rng: rng{start, p.prevLine()}, cond: cond, x: x, y: y}, nil
+}
+
+// binaryOps are the binary operators by precedence, from the lowest
+var binaryOps = [][]string{
+	{"??"},
+	{"||"},
+	{"&&"},
+	{"==", "!=", "=~", "!~"},
+	{"<", ">", "<=", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *syntaxParser) parseBinary(level int) (expr, error) {
+	if level == len(binaryOps) {
+		return p.parseUnary()
+	}
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenPunct || !slices.Contains(binaryOps[level], t.text) {
+			return x, nil
+		}
+		p.next()
+		p.skipNewLines()
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		start, _ := x.lines()
+		x = &binaryExpr{rng: rng{start, p.prevLine()}, op: t.text, x: x, y: y}
+	}
+}
+
+func (p *syntaxParser) parseUnary() (expr, error) {
+	t := p.peek()
+	if t.is(tokenPunct, "!") || t.is(tokenPunct, "-") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{rng: rng{t.line, p.prevLine()}, op: t.text, x: x}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *syntaxParser) parsePostfix() (expr, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		start, _ := x.lines()
+		switch {
+		case t.is(tokenPunct, ".") || t.is(tokenPunct, ".?") || t.is(tokenPunct, "::"):
+			p.next()
+			name, err := p.expectIdent()
+			if err != nil {
+				return nil, err
+			}
+			// nested resources, e.g. sa::blob
+			if ident, ok := x.(*identExpr); ok && t.text == "::" {
+				x = &identExpr{rng: rng{start, p.prevLine()}, name: ident.name + "::" + name}
+				continue
+			}
+			x = &memberExpr{rng: rng{start, p.prevLine()}, x: x, name: name}
+		case t.is(tokenPunct, "["):
+			p.next()
+			// safe dereference, e.g. items[?0]
+			if p.peek().is(tokenPunct, "?") {
+				p.next()
+			}
+			index, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenPunct, "]"); err != nil {
+				return nil, err
+			}
+			x = &indexExpr{rng: rng{start, p.prevLine()}, x: x, index: index}
+		case t.is(tokenPunct, "("):
+			// functions, including the ones with namespaces, e.g. az.resourceGroup(),
+			// and resource functions, e.g. sa.listKeys()
+			var name string
+			switch fn := x.(type) {
+			case *identExpr:
+				name = fn.name
+			case *memberExpr:
+				name = fn.name
+				if ns, ok := fn.x.(*identExpr); !ok || ns.name != "az" && ns.name != "sys" {
+					// resource functions are evaluated at deployment
+					name = ""
+				}
+			}
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			x = &callExpr{rng: rng{start, p.prevLine()}, name: name, args: args}
+		case t.is(tokenPunct, "!") && p.isNonNullAssertion():
+			p.next()
+		default:
+			return x, nil
+		}
+	}
+}
+
+// isNonNullAssertion checks if "!" is the postfix operator asserting the value isn't null, e.g. "a.b!.c"
+func (p *syntaxParser) isNonNullAssertion() bool {
+	t := p.peekAt(1)
+	return t.kind == tokenNewLine || t.kind == tokenEOF ||
+		t.kind == tokenPunct && slices.Contains([]string{".", ".?", ")", "]", "}", ",", "["}, t.text)
+}
+
+func (p *syntaxParser) parseArgs() ([]expr, error) {
+	if _, err := p.expect(tokenPunct, "("); err != nil {
+		return nil, err
+	}
+	var args []expr
+	for {
+		p.skipNewLines()
+		if p.peek().is(tokenPunct, ")") {
+			p.next()
+			return args, nil
+		}
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		p.skipNewLines()
+		if p.peek().is(tokenPunct, ",") {
+			p.next()
+		}
+	}
+}
+
+func (p *syntaxParser) parsePrimary() (expr, error) {
+	t := p.peek()
+	r := rng{t.line, t.line}
+	switch t.kind {
+	case tokenNumber:
+		p.next()
+		// Bicep supports integers only
+		i, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &literalExpr{rng: r, value: i}, nil
+	case tokenString:
+		p.next()
+		return p.parseTemplate(t)
+	case tokenIdent:
+		// lambda, e.g. x => x.name
+		if p.peekAt(1).is(tokenPunct, "=>") {
+			return p.parseLambda()
+		}
+		p.next()
+		switch t.text {
+		case "true", "false":
+			return &literalExpr{rng: r, value: t.text == "true"}, nil
+		case "null":
+			return &literalExpr{rng: r}, nil
+		}
+		return &identExpr{rng: r, name: t.text}, nil
+	case tokenPunct:
+		switch t.text {
+		case "(":
+			if p.isLambda() {
+				return p.parseLambda()
+			}
+			p.next()
+			p.skipNewLines()
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			p.skipNewLines()
+			if _, err := p.expect(tokenPunct, ")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "{":
+			return p.parseObject()
+		case "[":
+			if p.peekAt(1).is(tokenIdent, "for") || p.peekAt(1).kind == tokenNewLine && p.peekAt(2).is(tokenIdent, "for") {
+				return p.parseFor()
+			}
+			return p.parseArray()
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+// isLambda checks if the parenthesis starts the parameters of a lambda, e.g. (x, i) => ...
+func (p *syntaxParser) isLambda() bool {
+	for i := 1; ; i++ {
+		t := p.peekAt(i)
+		switch {
+		case t.kind == tokenIdent || t.is(tokenPunct, ","):
+		case t.is(tokenPunct, ")"):
+			return p.peekAt(i+1).is(tokenPunct, "=>")
+		default:
+			return false
+		}
+	}
+}
+
+// parseLambda parses lambdas, which are not evaluated
+func (p *syntaxParser) parseLambda() (expr, error) {
+	start := p.peek().line
+	for !p.peek().is(tokenPunct, "=>") {
+		p.next()
+	}
+	p.next()
+	p.skipNewLines()
+	if _, err := p.parseExpr(); err != nil {
+		return nil, err
+	}
+	return &unknownExpr{rng: rng{start, p.prevLine()}}, nil
+}
+
+func (p *syntaxParser) parseTemplate(t token) (expr, error) {
+	r := rng{t.line, t.line}
+	if len(t.parts) == 1 {
+		return &literalExpr{rng: r, value: t.parts[0]}, nil
+	}
+	tmpl := &templateExpr{rng: r}
+	for _, part := range t.parts {
+		switch v := part.(type) {
+		case string:
+			tmpl.parts = append(tmpl.parts, v)
+		case []token:
+			sub := &syntaxParser{tokens: v}
+			x, err := sub.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			tmpl.parts = append(tmpl.parts, x)
+		}
+	}
+	return tmpl, nil
+}
+
+func (p *syntaxParser) parseObject() (expr, error) {
+	obj := &objectExpr{rng: rng{start: p.next().line}}
+	for {
+		p.skipNewLines()
+		t := p.peek()
+		switch {
+		case t.is(tokenPunct, "}"):
+			p.next()
+			obj.end = t.line
+			return obj, nil
+		case t.is(tokenPunct, "@"):
+			// decorators of nested resources
+			if _, err := p.parseDecorators(); err != nil {
+				return nil, err
+			}
+			continue
+		case t.is(tokenIdent, "resource") && p.peekAt(1).kind == tokenIdent:
+			r, err := p.parseResource()
+			if err != nil {
+				return nil, err
+			}
+			obj.resources = append(obj.resources, r)
+			continue
+		}
+
+		var key string
+		switch t.kind {
+		case tokenIdent:
+			key = t.text
+		case tokenString:
+			if len(t.parts) != 1 {
+				return nil, fmt.Errorf("interpolated keys are not supported")
+			}
+			key = t.parts[0].(string)
+		default:
+			return nil, fmt.Errorf("unexpected %s", t)
+		}
+		p.next()
+		if _, err := p.expect(tokenPunct, ":"); err != nil {
+			return nil, err
+		}
+		p.skipNewLines()
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		obj.keys = append(obj.keys, key)
+		obj.values = append(obj.values, value)
+
+		if p.peek().is(tokenPunct, ",") {
+			p.next()
+		}
+	}
+}
+
+func (p *syntaxParser) parseArray() (expr, error) {
+	arr := &arrayExpr{rng: rng{start: p.next().line}}
+	for {
+		p.skipNewLines()
+		if t := p.peek(); t.is(tokenPunct, "]") {
+			p.next()
+			arr.end = t.line
+			return arr, nil
+		}
+		item, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		arr.items = append(arr.items, item)
+		if p.peek().is(tokenPunct, ",") {
+			p.next()
+		}
+	}
+}
+
+// parseFor parses loops, e.g. [for (item, i) in items: {...}]
+func (p *syntaxParser) parseFor() (expr, error) {
+	loop := &forExpr{rng: rng{start: p.next().line}}
+	p.skipNewLines()
+	p.next() // for
+
+	var err error
+	if p.peek().is(tokenPunct, "(") {
+		p.next()
+		if loop.item, err = p.expectIdent(); err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunct, ","); err != nil {
+			return nil, err
+		}
+		if loop.index, err = p.expectIdent(); err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunct, ")"); err != nil {
+			return nil, err
+		}
+	} else if loop.item, err = p.expectIdent(); err != nil {
+		return nil, err
+	}
+
+	if _, err := p.expect(tokenIdent, "in"); err != nil {
+		return nil, err
+	}
+	if loop.src, err = p.parseExpr(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenPunct, ":"); err != nil {
+		return nil, err
+	}
+	p.skipNewLines()
+	if loop.body, err = p.parseBody(); err != nil {
+		return nil, err
+	}
+	p.skipNewLines()
+	if _, err := p.expect(tokenPunct, "]"); err != nil {
+		return nil, err
+	}
+	loop.end = p.prevLine()
+	return loop, nil
+}
@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	bicepExt      = ".bicep"
+	bicepParamExt = ".bicepparam"
+)
+
+// Parser evaluates Bicep files into deployments, as if they were compiled into ARM templates
+type Parser struct {
+	fsys   fs.FS
+	logger *log.Logger
+	files  map[string]*file
+}
+
+func New(fsys fs.FS) *Parser {
+	return &Parser{
+		fsys:   fsys,
+		logger: log.WithPrefix("bicep parser"),
+		files:  make(map[string]*file),
+	}
+}
+
+// ParseFS returns the deployments of the Bicep files in the directory.
+// The parameters files are evaluated with the Bicep files they use,
+// and the Bicep files used by the other files, e.g. as modules, aren't evaluated on their own.
+func (p *Parser) ParseFS(ctx context.Context, dir string) ([]azure.Deployment, error) {
+	var paths []string
+	if err := fs.WalkDir(p.fsys, dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if isBicepFile(filePath) {
+			paths = append(paths, filePath)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]struct{})
+	for _, filePath := range paths {
+		f, err := p.parseFile(filePath)
+		if err != nil {
+			p.logger.Error("Failed to parse Bicep file", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		if f.using != "" {
+			used[path.Join(path.Dir(filePath), f.using)] = struct{}{}
+		}
+		for _, m := range f.modules {
+			used[path.Join(path.Dir(filePath), m.path)] = struct{}{}
+		}
+	}
+
+	var deployments []azure.Deployment
+	for _, filePath := range paths {
+		f, ok := p.files[filePath]
+		if !ok {
+			continue
+		}
+		if path.Ext(filePath) == bicepParamExt {
+			if deployment, ok := p.evalParamsFile(filePath, f); ok {
+				deployments = append(deployments, deployment)
+			}
+			continue
+		}
+		if _, ok := used[filePath]; ok {
+			continue
+		}
+		deployments = append(deployments, newEvaluator(p, filePath, f, nil, 0).deployment())
+	}
+	return deployments, nil
+}
+
+// evalParamsFile evaluates the Bicep file used by the parameters file with the parameters
+func (p *Parser) evalParamsFile(filePath string, f *file) (azure.Deployment, bool) {
+	if f.using == "" {
+		return azure.Deployment{}, false
+	}
+	params := make(map[string]azure.Value)
+	e := newEvaluator(p, filePath, f, nil, 0)
+	for _, param := range f.params {
+		if val, ok := e.lookup(param.name); ok {
+			params[param.name] = val
+		}
+	}
+
+	templatePath := path.Join(path.Dir(filePath), f.using)
+	template, err := p.parseFile(templatePath)
+	if err != nil {
+		p.logger.Error("Failed to parse Bicep file", log.FilePath(templatePath), log.Err(err))
+		return azure.Deployment{}, false
+	}
+	return newEvaluator(p, templatePath, template, params, 0).deployment(), true
+}
+
+// parseFile parses the file once, so that the modules used many times are parsed once
+func (p *Parser) parseFile(filePath string) (*file, error) {
+	if f, ok := p.files[filePath]; ok {
+		return f, nil
+	}
+	data, err := fs.ReadFile(p.fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	p.files[filePath] = f
+	return f, nil
+}
+
+func isBicepFile(filePath string) bool {
+	return slices.Contains([]string{bicepExt, bicepParamExt}, path.Ext(filePath))
+}
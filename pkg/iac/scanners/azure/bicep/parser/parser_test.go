@@ -0,0 +1,389 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure"
+)
+
+type testResource struct {
+	Type       string
+	APIVersion string
+	Name       any
+	Location   any
+	Properties any
+}
+
+func toTestResources(resources []azure.Resource) []testResource {
+	var res []testResource
+	for _, r := range resources {
+		res = append(res, testResource{
+			Type:       r.Type.AsString(),
+			APIVersion: r.APIVersion.AsString(),
+			Name:       toTestValue(r.Name),
+			Location:   toTestValue(r.Location),
+			Properties: toTestValue(r.Properties),
+		})
+	}
+	return res
+}
+
+func toTestValue(v azure.Value) any {
+	switch v.Kind {
+	case azure.KindObject:
+		obj := make(map[string]any)
+		for k, val := range v.AsMap() {
+			obj[k] = toTestValue(val)
+		}
+		return obj
+	case azure.KindArray:
+		var items []any
+		for _, item := range v.AsList() {
+			items = append(items, toTestValue(item))
+		}
+		return items
+	case azure.KindUnresolvable:
+		return "<unknown>"
+	case azure.KindNumber:
+		return v.AsInt()
+	}
+	return v.Raw()
+}
+
+func TestParser_ParseFS(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected map[string][]testResource
+	}{
+		{
+			name: "parameters, variables and interpolation",
+			files: map[string]string{
+				"main.bicep": `
+@minLength(3)
+param prefix string = 'app'
+param location string = resourceGroup().location
+param minTls string = 'TLS1_0'
+
+var name = '${prefix}st${length(prefix)}'
+
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: toLower(name)
+  location: location
+  kind: 'StorageV2'
+  properties: {
+    minimumTlsVersion: minTls
+    supportsHttpsTrafficOnly: !false
+    allowBlobPublicAccess: prefix == 'app' ? true : false
+  }
+}
+
+output id string = sa.id
+`,
+			},
+			expected: map[string][]testResource{
+				"main.bicep": {
+					{
+						Type:       "Microsoft.Storage/storageAccounts",
+						APIVersion: "2023-01-01",
+						Name:       "appst3",
+						Location:   "<unknown>",
+						Properties: map[string]any{
+							"minimumTlsVersion":        "TLS1_0",
+							"supportsHttpsTrafficOnly": true,
+							"allowBlobPublicAccess":    true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "conditions and loops",
+			files: map[string]string{
+				"main.bicep": `
+param deploy bool = false
+param names array = [
+  'a'
+  'b'
+]
+
+resource skipped 'Microsoft.Storage/storageAccounts@2023-01-01' = if (deploy) {
+  name: 'skipped'
+}
+
+resource accounts 'Microsoft.Storage/storageAccounts@2023-01-01' = [for (name, i) in names: {
+  name: '${name}${i}'
+  properties: {
+    minimumTlsVersion: 'TLS1_2'
+  }
+}]
+
+resource unknownLoop 'Microsoft.KeyVault/vaults@2022-07-01' = [for v in subscription().vaults: {
+  name: v.name
+  properties: {
+    enableSoftDelete: true
+  }
+}]
+`,
+			},
+			expected: map[string][]testResource{
+				"main.bicep": {
+					{
+						Type:       "Microsoft.Storage/storageAccounts",
+						APIVersion: "2023-01-01",
+						Name:       "a0",
+						Properties: map[string]any{"minimumTlsVersion": "TLS1_2"},
+					},
+					{
+						Type:       "Microsoft.Storage/storageAccounts",
+						APIVersion: "2023-01-01",
+						Name:       "b1",
+						Properties: map[string]any{"minimumTlsVersion": "TLS1_2"},
+					},
+					{
+						Type:       "Microsoft.KeyVault/vaults",
+						APIVersion: "2022-07-01",
+						Name:       "<unknown>",
+						Properties: map[string]any{"enableSoftDelete": true},
+					},
+				},
+			},
+		},
+		{
+			name: "child resources",
+			files: map[string]string{
+				"main.bicep": `
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' existing = {
+  name: 'st'
+
+  resource blob 'blobServices' = {
+    name: 'default'
+    properties: {
+      isVersioningEnabled: true
+    }
+  }
+}
+
+resource container 'Microsoft.Storage/storageAccounts/blobServices/containers@2023-01-01' = {
+  parent: sa::blob
+  name: 'logs'
+  properties: {
+    publicAccess: 'None'
+  }
+}
+`,
+			},
+			expected: map[string][]testResource{
+				"main.bicep": {
+					{
+						Type:       "Microsoft.Storage/storageAccounts/blobServices",
+						APIVersion: "2023-01-01",
+						Name:       "st/default",
+						Properties: map[string]any{"isVersioningEnabled": true},
+					},
+					{
+						Type:       "Microsoft.Storage/storageAccounts/blobServices/containers",
+						APIVersion: "2023-01-01",
+						Name:       "st/default/logs",
+						Properties: map[string]any{"publicAccess": "None"},
+					},
+				},
+			},
+		},
+		{
+			name: "modules",
+			files: map[string]string{
+				"main.bicep": `
+module storage 'modules/storage.bicep' = {
+  name: 'storage'
+  params: {
+    name: 'st'
+    tls: 'TLS1_1'
+  }
+}
+
+module remote 'br/public:avm/res/storage/storage-account:0.9.0' = {
+  name: 'remote'
+}
+
+resource vault 'Microsoft.KeyVault/vaults@2022-07-01' = {
+  name: storage.outputs.vaultName
+}
+`,
+				"modules/storage.bicep": `
+param name string
+param tls string = 'TLS1_2'
+
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: name
+  properties: {
+    minimumTlsVersion: tls
+  }
+}
+
+output vaultName string = '${name}-kv'
+`,
+			},
+			expected: map[string][]testResource{
+				"main.bicep": {
+					{
+						Type:       "Microsoft.Storage/storageAccounts",
+						APIVersion: "2023-01-01",
+						Name:       "st",
+						Properties: map[string]any{"minimumTlsVersion": "TLS1_1"},
+					},
+					{
+						Type:       "Microsoft.KeyVault/vaults",
+						APIVersion: "2022-07-01",
+						Name:       "st-kv",
+					},
+				},
+			},
+		},
+		{
+			name: "parameters file",
+			files: map[string]string{
+				"main.bicep": `
+param tls string = 'TLS1_2'
+
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: 'st'
+  properties: {
+    minimumTlsVersion: tls
+  }
+}
+`,
+				"main.bicepparam": `
+using 'main.bicep'
+
+var suffix = '0'
+
+param tls = 'TLS1_${suffix}'
+`,
+			},
+			expected: map[string][]testResource{
+				"main.bicep": {
+					{
+						Type:       "Microsoft.Storage/storageAccounts",
+						APIVersion: "2023-01-01",
+						Name:       "st",
+						Properties: map[string]any{"minimumTlsVersion": "TLS1_0"},
+					},
+				},
+			},
+		},
+		{
+			name: "syntax",
+			files: map[string]string{
+				"main.bicep": `
+import { tags } from 'shared.bicep'
+
+metadata description = 'Storage'
+
+/*
+  user-defined types and functions aren't evaluated
+*/
+type config = {
+  tls: 'TLS1_2' | 'TLS1_3'
+  @description('rules')
+  rules: string[]?
+}
+
+func prefixed(name string) string => 'st${name}'
+
+@description('''
+  Settings
+''')
+param settings object = {
+  'network-acls': {
+    defaultAction: 'Deny' // comment
+  }
+}
+
+var rules = filter(settings.rules ?? [], r => r.enabled)
+
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: 'st-\'${settings.?name ?? 'default'}\''
+  properties: {
+    networkAcls: settings['network-acls']!
+    ipRules: rules
+    description: '''
+multi-line'''
+    encryption: {
+      services: settings.?encryption.?services
+    }
+  }
+}
+`,
+			},
+			expected: map[string][]testResource{
+				"main.bicep": {
+					{
+						Type:       "Microsoft.Storage/storageAccounts",
+						APIVersion: "2023-01-01",
+						Name:       "st-'default'",
+						Properties: map[string]any{
+							"networkAcls": map[string]any{"defaultAction": "Deny"},
+							"ipRules":     "<unknown>",
+							"description": "multi-line",
+							"encryption":  map[string]any{"services": nil},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid file",
+			files: map[string]string{
+				"main.bicep": `resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {`,
+			},
+			expected: map[string][]testResource{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := testutil.CreateFS(t, tt.files)
+			deployments, err := New(fsys).ParseFS(context.TODO(), ".")
+			require.NoError(t, err)
+
+			got := make(map[string][]testResource)
+			for _, deployment := range deployments {
+				got[deployment.Metadata.Range().GetFilename()] = toTestResources(deployment.Resources)
+			}
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParser_Metadata(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"main.bicep": `param minTls string = 'TLS1_0'
+
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: 'st'
+  properties: {
+    minimumTlsVersion: minTls
+  }
+}
+`,
+	})
+	deployments, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+	require.Len(t, deployments[0].Resources, 1)
+
+	sa := deployments[0].Resources[0]
+	assert.Equal(t, "main.bicep", sa.Metadata.Range().GetFilename())
+	assert.Equal(t, 3, sa.Metadata.Range().GetStartLine())
+	assert.Equal(t, 8, sa.Metadata.Range().GetEndLine())
+
+	tls := sa.Properties.GetMapValue("minimumTlsVersion")
+	assert.Equal(t, "TLS1_0", tls.AsString())
+	assert.Equal(t, 6, tls.Metadata.Range().GetStartLine())
+}
@@ -0,0 +1,126 @@
+package bicep
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+const minimumTLSCheck = `# METADATA
+# title: Storage accounts should use TLS 1.2
+# custom:
+#   avd_id: AVD-TEST-0123
+#   severity: HIGH
+#   short_code: use-secure-tls
+#   input:
+#     selector:
+#     - type: cloud
+#       subtypes:
+#         - service: storage
+#           provider: azure
+package user.bicep.test123
+
+deny[res] {
+	account := input.azure.storage.accounts[_]
+	account.minimumtlsversion.value != "TLS1_2"
+	res := result.new("The storage account uses an old TLS version", account.minimumtlsversion)
+}
+`
+
+func TestScanner_ScanFS(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantFiles []string
+		wantLines [][2]int
+	}{
+		{
+			name: "Bicep file",
+			files: map[string]string{
+				"main.bicep": `param tls string = 'TLS1_0'
+
+resource secure 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: 'secure'
+  properties: {
+    minimumTlsVersion: 'TLS1_2'
+  }
+}
+
+resource insecure 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: 'insecure'
+  properties: {
+    minimumTlsVersion: tls
+  }
+}
+`,
+			},
+			wantFiles: []string{"code/main.bicep"},
+			wantLines: [][2]int{{13, 13}},
+		},
+		{
+			name: "module with parameters file",
+			files: map[string]string{
+				"main.bicep": `param tls string
+
+module storage 'modules/storage.bicep' = {
+  name: 'storage'
+  params: {
+    tls: tls
+  }
+}
+`,
+				"main.bicepparam": `using 'main.bicep'
+
+param tls = 'TLS1_1'
+`,
+				"modules/storage.bicep": `param tls string
+
+resource sa 'Microsoft.Storage/storageAccounts@2023-01-01' = {
+  name: 'st'
+  properties: {
+    minimumTlsVersion: tls
+  }
+}
+`,
+			},
+			wantFiles: []string{"code/modules/storage.bicep"},
+			wantLines: [][2]int{{6, 6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{
+				"rules/test.rego": minimumTLSCheck,
+			}
+			for name, content := range tt.files {
+				files["code/"+name] = content
+			}
+			fsys := testutil.CreateFS(t, files)
+
+			scanner := New(
+				rego.WithPolicyFilesystem(fsys),
+				rego.WithPolicyDirs("rules"),
+				rego.WithPolicyNamespaces("user"),
+				rego.WithEmbeddedPolicies(false),
+				rego.WithEmbeddedLibraries(true),
+			)
+			results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+			require.NoError(t, err)
+
+			var gotFiles []string
+			var gotLines [][2]int
+			for _, failure := range results.GetFailed() {
+				assert.Equal(t, "AVD-TEST-0123", failure.Rule().AVDID)
+				gotFiles = append(gotFiles, failure.Range().GetFilename())
+				gotLines = append(gotLines, [2]int{failure.Range().GetStartLine(), failure.Range().GetEndLine()})
+			}
+			assert.Equal(t, tt.wantFiles, gotFiles)
+			assert.Equal(t, tt.wantLines, gotLines)
+		})
+	}
+}
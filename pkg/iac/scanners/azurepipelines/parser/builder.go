@@ -0,0 +1,369 @@
+package parser
+
+import (
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	// maxTemplateDepth is the maximum number of nested templates allowed by Azure Pipelines
+	maxTemplateDepth = 20
+
+	// defaultStageName and defaultJobName are the names of the stage and the job of pipelines without them
+	defaultStageName = "__default"
+	defaultJobName   = "Job"
+)
+
+// deploymentHooks are the lifecycle hooks of the deployment strategies in the order they run
+var deploymentHooks = []string{"preDeploy", "deploy", "routeTraffic", "postRouteTraffic"}
+
+// fileContext is the file elements are defined in, and the templates including it
+type fileContext struct {
+	filePath string
+	chain    []string
+}
+
+// listItem is an item of a list of stages, jobs, steps or variables with the file it is defined in
+type listItem struct {
+	fc   fileContext
+	node *yaml.Node
+}
+
+// builder builds a pipeline from its file and the templates it uses
+type builder struct {
+	parser    *Parser
+	repoDir   string
+	resources Resources
+	templates []*Template
+}
+
+func (b *builder) pipeline(filePath string, root *yaml.Node) *Pipeline {
+	fc := fileContext{filePath: filePath, chain: []string{filePath}}
+	b.resources = b.parseResources(fc, yamlnode.Lookup(root, "resources"))
+
+	pipeline := &Pipeline{
+		Metadata:  yamlnode.NewMetadata(filePath, root),
+		Trigger:   yamlnode.Value(yamlnode.Lookup(root, "trigger")),
+		PR:        yamlnode.Value(yamlnode.Lookup(root, "pr")),
+		Variables: b.variables(fc, yamlnode.Lookup(root, "variables")),
+		Pool:      parsePool(fc, yamlnode.Lookup(root, "pool")),
+		Resources: b.resources,
+	}
+
+	// The stages, jobs or steps of pipelines extending a template are defined by the template
+	body, bodyFC := root, fc
+	if extends := yamlnode.Lookup(root, "extends"); extends != nil {
+		if tmpl, tmplFC, ok := b.loadTemplate(fc, yamlnode.Lookup(extends, "template")); ok {
+			body, bodyFC = tmpl, tmplFC
+			maps.Copy(pipeline.Variables, b.variables(tmplFC, yamlnode.Lookup(tmpl, "variables")))
+			if pool := parsePool(tmplFC, yamlnode.Lookup(tmpl, "pool")); pool != nil {
+				pipeline.Pool = pool
+			}
+		}
+	}
+
+	pipeline.Stages = b.stages(bodyFC, body, pipeline.Pool)
+	pipeline.Templates = b.templates
+	return pipeline
+}
+
+// stages returns the stages of the pipeline, or a default stage with the jobs of pipelines without stages
+func (b *builder) stages(fc fileContext, node *yaml.Node, pool *Pool) []*Stage {
+	if stages := yamlnode.Lookup(node, "stages"); stages != nil {
+		var result []*Stage
+		for _, item := range b.expand(fc, stages, "stages") {
+			result = append(result, b.stage(item.fc, item.node, pool))
+		}
+		return result
+	}
+	return []*Stage{{
+		Metadata:  yamlnode.NewMetadata(fc.filePath, node),
+		Name:      defaultStageName,
+		Variables: map[string]any{},
+		Jobs:      b.jobs(fc, node, pool),
+	}}
+}
+
+func (b *builder) stage(fc fileContext, node *yaml.Node, pool *Pool) *Stage {
+	if p := parsePool(fc, yamlnode.Lookup(node, "pool")); p != nil {
+		pool = p
+	}
+	return &Stage{
+		Metadata:  yamlnode.NewMetadata(fc.filePath, node),
+		Name:      yamlnode.String(yamlnode.Lookup(node, "stage")),
+		Condition: yamlnode.String(yamlnode.Lookup(node, "condition")),
+		Variables: b.variables(fc, yamlnode.Lookup(node, "variables")),
+		Jobs:      b.jobs(fc, node, pool),
+	}
+}
+
+// jobs returns the jobs of the stage, or a default job with the steps of stages without jobs
+func (b *builder) jobs(fc fileContext, node *yaml.Node, pool *Pool) []*Job {
+	if jobs := yamlnode.Lookup(node, "jobs"); jobs != nil {
+		var result []*Job
+		for _, item := range b.expand(fc, jobs, "jobs") {
+			result = append(result, b.job(item.fc, item.node, pool))
+		}
+		return result
+	}
+	if steps := yamlnode.Lookup(node, "steps"); steps != nil {
+		return []*Job{{
+			Metadata:  yamlnode.NewMetadata(fc.filePath, node),
+			Name:      defaultJobName,
+			Pool:      pool,
+			Container: b.container(fc, yamlnode.Lookup(node, "container")),
+			Variables: map[string]any{},
+			Steps:     b.steps(fc, steps),
+		}}
+	}
+	return nil
+}
+
+func (b *builder) job(fc fileContext, node *yaml.Node, pool *Pool) *Job {
+	if p := parsePool(fc, yamlnode.Lookup(node, "pool")); p != nil {
+		pool = p
+	}
+	job := &Job{
+		Metadata:    yamlnode.NewMetadata(fc.filePath, node),
+		Name:        yamlnode.String(yamlnode.Lookup(node, "job")),
+		Environment: environmentName(yamlnode.Lookup(node, "environment")),
+		Pool:        pool,
+		Container:   b.container(fc, yamlnode.Lookup(node, "container")),
+		Condition:   yamlnode.String(yamlnode.Lookup(node, "condition")),
+		Variables:   b.variables(fc, yamlnode.Lookup(node, "variables")),
+	}
+	for _, entry := range yamlnode.Entries(yamlnode.Lookup(node, "services")) {
+		if service := b.container(fc, entry.Value); service != nil {
+			job.Services = append(job.Services, service)
+		}
+	}
+
+	if deployment := yamlnode.String(yamlnode.Lookup(node, "deployment")); deployment != "" {
+		job.Name = deployment
+		job.Deployment = true
+		for _, strategy := range yamlnode.Entries(yamlnode.Lookup(node, "strategy")) {
+			for _, hook := range deploymentHooks {
+				job.Steps = append(job.Steps, b.steps(fc, yamlnode.Lookup(yamlnode.Lookup(strategy.Value, hook), "steps"))...)
+			}
+			for _, hook := range yamlnode.Entries(yamlnode.Lookup(strategy.Value, "on")) {
+				job.Steps = append(job.Steps, b.steps(fc, yamlnode.Lookup(hook.Value, "steps"))...)
+			}
+		}
+		return job
+	}
+
+	job.Steps = b.steps(fc, yamlnode.Lookup(node, "steps"))
+	return job
+}
+
+func (b *builder) steps(fc fileContext, node *yaml.Node) []*Step {
+	var steps []*Step
+	for _, item := range b.expand(fc, node, "steps") {
+		steps = append(steps, parseStep(item.fc, item.node))
+	}
+	return steps
+}
+
+func parseStep(fc fileContext, node *yaml.Node) *Step {
+	step := &Step{
+		Metadata:    yamlnode.NewMetadata(fc.filePath, node),
+		DisplayName: yamlnode.String(yamlnode.Lookup(node, "displayName")),
+		Condition:   yamlnode.String(yamlnode.Lookup(node, "condition")),
+		Inputs:      yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "inputs"))),
+		Env:         yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "env"))),
+	}
+	for _, entry := range yamlnode.Entries(node) {
+		if isStepKeyword(entry.Key) {
+			step.Type = entry.Key
+			break
+		}
+	}
+
+	v := yamlnode.String(yamlnode.Lookup(node, step.Type))
+	switch step.Type {
+	case "task":
+		step.Task, step.Version, _ = strings.Cut(v, "@")
+	case "script", "bash", "pwsh", "powershell":
+		step.Script = v
+	case "checkout":
+		step.Checkout = v
+		step.PersistCredentials = yamlnode.Value(yamlnode.Lookup(node, "persistCredentials")) == true
+	}
+	return step
+}
+
+// variables returns the variables, which are either a mapping or a list of variables, groups and templates.
+// Variable groups are not resolved.
+func (b *builder) variables(fc fileContext, node *yaml.Node) map[string]any {
+	node = yamlnode.Resolve(node)
+	if node == nil {
+		return map[string]any{}
+	}
+	if node.Kind == yaml.MappingNode {
+		return yamlnode.ToMap(yamlnode.Value(node))
+	}
+
+	vars := make(map[string]any)
+	for _, item := range b.expand(fc, node, "variables") {
+		if name := yamlnode.String(yamlnode.Lookup(item.node, "name")); name != "" {
+			vars[name] = yamlnode.Value(yamlnode.Lookup(item.node, "value"))
+		}
+	}
+	return vars
+}
+
+// expand returns the items of a list of stages, jobs, steps or variables with the items of the templates
+// and of the conditional insertions in the list. Template expressions are not evaluated, so the items of
+// all the branches of conditions are returned.
+func (b *builder) expand(fc fileContext, node *yaml.Node, key string) []listItem {
+	var result []listItem
+	for _, item := range yamlnode.Items(node) {
+		item = yamlnode.Resolve(item)
+		itemEntries := yamlnode.Entries(item)
+
+		switch {
+		case len(itemEntries) > 0 && isExpression(itemEntries[0].Key):
+			// e.g. "- ${{ if eq(parameters.debug, true) }}:" followed by a list of items
+			for _, entry := range itemEntries {
+				result = append(result, b.expand(fc, entry.Value, key)...)
+			}
+		case yamlnode.Lookup(item, "template") != nil:
+			if tmpl, tmplFC, ok := b.loadTemplate(fc, yamlnode.Lookup(item, "template")); ok {
+				result = append(result, b.expand(tmplFC, yamlnode.Lookup(tmpl, key), key)...)
+			}
+		default:
+			result = append(result, listItem{fc: fc, node: item})
+		}
+	}
+	return result
+}
+
+// loadTemplate returns the content of the template in the repository. The path of the template is relative
+// to the file using it, or to the root of the repository if it starts with "/".
+func (b *builder) loadTemplate(fc fileContext, node *yaml.Node) (*yaml.Node, fileContext, bool) {
+	ref := yamlnode.String(node)
+	if ref == "" {
+		return nil, fileContext{}, false
+	}
+
+	location, repo, _ := strings.Cut(ref, "@")
+	tmpl := &Template{
+		Metadata:   yamlnode.NewMetadata(fc.filePath, node),
+		Path:       location,
+		Repository: repo,
+	}
+	b.templates = append(b.templates, tmpl)
+
+	if (repo != "" && repo != "self") || strings.Contains(location, "${{") {
+		return nil, fileContext{}, false
+	}
+
+	filePath := path.Join(path.Dir(fc.filePath), location)
+	if strings.HasPrefix(location, "/") {
+		filePath = path.Join(b.repoDir, location)
+	}
+	logger := b.parser.logger.With(log.FilePath(fc.filePath), log.String("template", filePath))
+
+	if slices.Contains(fc.chain, filePath) || len(fc.chain) > maxTemplateDepth {
+		logger.Warn("Templates are too deep or recursive")
+		return nil, fileContext{}, false
+	}
+	root, err := b.parser.parseFile(filePath)
+	if err != nil {
+		logger.Debug("Unable to load template", log.Err(err))
+		return nil, fileContext{}, false
+	}
+	logger.Debug("Template resolved")
+
+	b.parser.used[filePath] = struct{}{}
+	tmpl.Resolved = true
+	return root, fileContext{filePath: filePath, chain: append(fc.chain, filePath)}, true
+}
+
+func (b *builder) parseResources(fc fileContext, node *yaml.Node) Resources {
+	var resources Resources
+	for _, item := range yamlnode.Items(yamlnode.Lookup(node, "repositories")) {
+		resources.Repositories = append(resources.Repositories, &Repository{
+			Metadata: yamlnode.NewMetadata(fc.filePath, item),
+			Alias:    yamlnode.String(yamlnode.Lookup(item, "repository")),
+			Type:     yamlnode.String(yamlnode.Lookup(item, "type")),
+			Name:     yamlnode.String(yamlnode.Lookup(item, "name")),
+			Ref:      yamlnode.String(yamlnode.Lookup(item, "ref")),
+			Endpoint: yamlnode.String(yamlnode.Lookup(item, "endpoint")),
+		})
+	}
+	for _, item := range yamlnode.Items(yamlnode.Lookup(node, "containers")) {
+		resources.Containers = append(resources.Containers, parseContainer(fc, item))
+	}
+	return resources
+}
+
+// container returns the container of a job, which is either an alias of a container resource, an image
+// or a container definition
+func (b *builder) container(fc fileContext, node *yaml.Node) *Container {
+	node = yamlnode.Resolve(node)
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		for _, c := range b.resources.Containers {
+			if c.Alias == node.Value {
+				return c
+			}
+		}
+		return &Container{
+			Metadata: yamlnode.NewMetadata(fc.filePath, node),
+			Image:    node.Value,
+		}
+	}
+	return parseContainer(fc, node)
+}
+
+func parseContainer(fc fileContext, node *yaml.Node) *Container {
+	return &Container{
+		Metadata: yamlnode.NewMetadata(fc.filePath, node),
+		Alias:    yamlnode.String(yamlnode.Lookup(node, "container")),
+		Image:    yamlnode.String(yamlnode.Lookup(node, "image")),
+		Options:  yamlnode.String(yamlnode.Lookup(node, "options")),
+		Endpoint: yamlnode.String(yamlnode.Lookup(node, "endpoint")),
+		Env:      yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "env"))),
+	}
+}
+
+// parsePool returns the pool, which is either the name of a pool or a pool definition, or nil if not set
+func parsePool(fc fileContext, node *yaml.Node) *Pool {
+	node = yamlnode.Resolve(node)
+	if node == nil {
+		return nil
+	}
+	pool := &Pool{Metadata: yamlnode.NewMetadata(fc.filePath, node)}
+	if node.Kind == yaml.ScalarNode {
+		pool.Name = node.Value
+		return pool
+	}
+	pool.Name = yamlnode.String(yamlnode.Lookup(node, "name"))
+	pool.VMImage = yamlnode.String(yamlnode.Lookup(node, "vmImage"))
+	pool.Demands = yamlnode.Value(yamlnode.Lookup(node, "demands"))
+	return pool
+}
+
+// environmentName returns the name of the environment, which is either a name or an environment definition
+func environmentName(node *yaml.Node) string {
+	node = yamlnode.Resolve(node)
+	if node != nil && node.Kind == yaml.MappingNode {
+		return yamlnode.String(yamlnode.Lookup(node, "name"))
+	}
+	return yamlnode.String(node)
+}
+
+// isExpression checks if the value is a template expression, e.g. "${{ parameters.template }}"
+func isExpression(s string) bool {
+	return strings.HasPrefix(strings.TrimSpace(s), "${{")
+}
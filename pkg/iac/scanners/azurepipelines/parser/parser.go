@@ -0,0 +1,192 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var (
+	// stepKeywords are the keywords identifying the types of steps
+	stepKeywords = []string{
+		"task", "script", "bash", "pwsh", "powershell", "checkout", "download",
+		"downloadBuild", "getPackage", "publish", "reviewApp",
+	}
+
+	// listItemKeywords are the keywords identifying the items of the lists of stages, jobs and steps
+	listItemKeywords = map[string][]string{
+		"stages": {"stage", "template"},
+		"jobs":   {"job", "deployment", "template"},
+		"steps":  append([]string{"template"}, stepKeywords...),
+	}
+)
+
+// IsPipelineFile checks if the file is the default pipeline of a repository, i.e. "azure-pipelines.yml"
+func IsPipelineFile(filePath string) bool {
+	name := path.Base(filepath.ToSlash(filePath))
+	return name == "azure-pipelines.yml" || name == "azure-pipelines.yaml"
+}
+
+// IsPipelineConfig checks if the YAML document is a pipeline or a template of stages, jobs or steps,
+// i.e. it has a list of stages, jobs or steps, or it extends a template
+func IsPipelineConfig(r io.Reader) bool {
+	var config map[string]any
+	if err := yaml.NewDecoder(r).Decode(&config); err != nil {
+		return false
+	}
+
+	if extends, ok := config["extends"].(map[string]any); ok {
+		if _, ok := extends["template"]; ok {
+			return true
+		}
+	}
+	for key, keywords := range listItemKeywords {
+		list, ok := config[key].([]any)
+		if !ok {
+			continue
+		}
+		for _, item := range list {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			for _, keyword := range keywords {
+				if _, ok := m[keyword]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Parser parses the pipelines in a directory, which is the root of the repository.
+// Templates in the repository are resolved, templates in other repositories are not fetched.
+type Parser struct {
+	fsys   fs.FS
+	logger *log.Logger
+
+	// used are the files used as templates by other files
+	used map[string]struct{}
+}
+
+func New(fsys fs.FS) *Parser {
+	return &Parser{
+		fsys:   fsys,
+		logger: log.WithPrefix("azure-pipelines parser"),
+		used:   make(map[string]struct{}),
+	}
+}
+
+// ParseFS parses the "azure-pipelines.yml" files and the other pipelines in the directory.
+// Files used as templates by other files are not parsed on their own.
+func (p *Parser) ParseFS(ctx context.Context, dir string) ([]*Pipeline, error) {
+	var roots, others []string
+	if err := fs.WalkDir(p.fsys, dir, func(filePath string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch {
+		case IsPipelineFile(filePath):
+			roots = append(roots, filePath)
+		case path.Ext(filePath) == ".yml" || path.Ext(filePath) == ".yaml":
+			others = append(others, filePath)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var pipelines []*Pipeline
+	for _, filePath := range roots {
+		pipeline, err := p.parsePipeline(filePath, dir)
+		if err != nil {
+			p.logger.Error("Failed to parse pipeline", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+
+	var standalone []*Pipeline
+	for _, filePath := range others {
+		if !p.isPipelineConfig(filePath) {
+			continue
+		}
+		pipeline, err := p.parsePipeline(filePath, dir)
+		if err != nil {
+			p.logger.Debug("Failed to parse pipeline", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		standalone = append(standalone, pipeline)
+	}
+	for _, pipeline := range standalone {
+		if _, ok := p.used[pipeline.Metadata.FilePath]; !ok {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+	return pipelines, nil
+}
+
+func (p *Parser) isPipelineConfig(filePath string) bool {
+	f, err := p.fsys.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	return IsPipelineConfig(f)
+}
+
+func (p *Parser) parsePipeline(filePath, repoDir string) (*Pipeline, error) {
+	root, err := p.parseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	b := &builder{
+		parser:  p,
+		repoDir: repoDir,
+	}
+	return b.pipeline(filePath, root), nil
+}
+
+func (p *Parser) parseFile(filePath string) (*yaml.Node, error) {
+	f, err := p.fsys.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var doc yaml.Node
+	if err := yaml.NewDecoder(f).Decode(&doc); errors.Is(err, io.EOF) {
+		return nil, errors.New("empty file")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to decode pipeline: %w", err)
+	}
+	root := yamlnode.Resolve(&doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, errors.New("pipeline must be a mapping")
+	}
+	return root, nil
+}
+
+// isStepKeyword checks if the key identifies the type of a step
+func isStepKeyword(key string) bool {
+	return slices.Contains(stepKeywords, key)
+}
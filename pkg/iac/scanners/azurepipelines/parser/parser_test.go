@@ -0,0 +1,270 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+func TestIsPipelineConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name: "steps",
+			content: `trigger: [main]
+steps:
+  - script: make
+`,
+			want: true,
+		},
+		{
+			name: "jobs template",
+			content: `parameters:
+  - name: image
+jobs:
+  - deployment: Deploy
+    environment: production
+`,
+			want: true,
+		},
+		{
+			name: "stages",
+			content: `stages:
+  - stage: Build
+`,
+			want: true,
+		},
+		{
+			name: "extends",
+			content: `extends:
+  template: pipeline.yml@templates
+`,
+			want: true,
+		},
+		{
+			name: "gitlab ci",
+			content: `stages:
+  - build
+build:
+  script: make
+`,
+			want: false,
+		},
+		{
+			name: "github actions",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPipelineConfig(strings.NewReader(tt.content)))
+		})
+	}
+}
+
+func TestParser_ParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"azure-pipelines.yml": &fstest.MapFile{Data: []byte(`trigger: [main]
+pr: [main]
+resources:
+  repositories:
+    - repository: templates
+      type: github
+      name: org/templates
+  containers:
+    - container: builder
+      image: golang:1.23
+      options: --privileged
+variables:
+  - name: configuration
+    value: release
+  - template: pipelines/vars.yml
+  - group: secrets
+pool:
+  vmImage: ubuntu-latest
+stages:
+  - stage: Build
+    jobs:
+      - job: Build
+        container: builder
+        steps:
+          - checkout: self
+            persistCredentials: true
+          - template: pipelines/steps/build.yml
+          - template: steps/lint.yml@templates
+  - template: pipelines/stages/deploy.yml
+    parameters:
+      environment: production
+`)},
+		"pipelines/vars.yml": &fstest.MapFile{Data: []byte(`variables:
+  - name: goVersion
+    value: "1.23"
+`)},
+		"pipelines/steps/build.yml": &fstest.MapFile{Data: []byte(`parameters:
+  - name: race
+    default: false
+steps:
+  - task: GoTool@0
+    inputs:
+      version: $(goVersion)
+  - ${{ if eq(parameters.race, true) }}:
+    - bash: go test -race ./...
+  - ${{ else }}:
+    - bash: go test ./...
+`)},
+		"pipelines/stages/deploy.yml": &fstest.MapFile{Data: []byte(`stages:
+  - stage: Deploy
+    pool: self-hosted
+    jobs:
+      - deployment: Deploy
+        environment:
+          name: ${{ parameters.environment }}
+        strategy:
+          runOnce:
+            deploy:
+              steps:
+                - template: /pipelines/steps/deploy.yml
+            on:
+              failure:
+                steps:
+                  - script: ./rollback.sh
+`)},
+		"pipelines/steps/deploy.yml": &fstest.MapFile{Data: []byte(`steps:
+  - task: AzureCLI@2
+    inputs:
+      inlineScript: ./deploy.sh
+`)},
+		"tools/release.yml": &fstest.MapFile{Data: []byte(`steps:
+  - script: ./release.sh
+    env:
+      TOKEN: $(System.AccessToken)
+`)},
+	}
+
+	pipelines, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+	require.Len(t, pipelines, 2)
+
+	pipeline := pipelines[0]
+	assert.Equal(t, "azure-pipelines.yml", pipeline.Metadata.FilePath)
+	assert.Equal(t, []any{"main"}, pipeline.PR)
+	assert.Equal(t, map[string]any{"configuration": "release", "goVersion": "1.23"}, pipeline.Variables)
+	assert.Equal(t, "ubuntu-latest", pipeline.Pool.VMImage)
+	require.Len(t, pipeline.Resources.Repositories, 1)
+	assert.Equal(t, "org/templates", pipeline.Resources.Repositories[0].Name)
+
+	var templates []Template
+	for _, tmpl := range pipeline.Templates {
+		templates = append(templates, *tmpl)
+	}
+	assert.Equal(t, []Template{
+		{
+			Metadata: yamlnode.Metadata{FilePath: "azure-pipelines.yml", StartLine: 15, EndLine: 15},
+			Path:     "pipelines/vars.yml",
+			Resolved: true,
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: "azure-pipelines.yml", StartLine: 29, EndLine: 29},
+			Path:     "pipelines/stages/deploy.yml",
+			Resolved: true,
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: "azure-pipelines.yml", StartLine: 27, EndLine: 27},
+			Path:     "pipelines/steps/build.yml",
+			Resolved: true,
+		},
+		{
+			Metadata:   yamlnode.Metadata{FilePath: "azure-pipelines.yml", StartLine: 28, EndLine: 28},
+			Path:       "steps/lint.yml",
+			Repository: "templates",
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: "pipelines/stages/deploy.yml", StartLine: 12, EndLine: 12},
+			Path:     "/pipelines/steps/deploy.yml",
+			Resolved: true,
+		},
+	}, templates)
+
+	require.Len(t, pipeline.Stages, 2)
+
+	build := pipeline.Stages[0]
+	assert.Equal(t, "Build", build.Name)
+	require.Len(t, build.Jobs, 1)
+	job := build.Jobs[0]
+	assert.Equal(t, "Build", job.Name)
+	assert.Equal(t, "ubuntu-latest", job.Pool.VMImage)
+	require.NotNil(t, job.Container)
+	assert.Equal(t, "--privileged", job.Container.Options)
+	assert.Equal(t, yamlnode.Metadata{FilePath: "azure-pipelines.yml", StartLine: 9, EndLine: 11}, job.Container.Metadata)
+
+	var steps []string
+	for _, step := range job.Steps {
+		steps = append(steps, step.Type+":"+step.Task+step.Script+step.Checkout)
+	}
+	assert.Equal(t, []string{"checkout:self", "task:GoTool", "bash:go test -race ./...", "bash:go test ./..."}, steps)
+	assert.True(t, job.Steps[0].PersistCredentials)
+	assert.Equal(t, "0", job.Steps[1].Version)
+	assert.Equal(t, yamlnode.Metadata{FilePath: "pipelines/steps/build.yml", StartLine: 5, EndLine: 7}, job.Steps[1].Metadata)
+
+	deploy := pipeline.Stages[1]
+	assert.Equal(t, "Deploy", deploy.Name)
+	require.Len(t, deploy.Jobs, 1)
+	job = deploy.Jobs[0]
+	assert.Equal(t, "Deploy", job.Name)
+	assert.True(t, job.Deployment)
+	assert.Equal(t, "${{ parameters.environment }}", job.Environment)
+	assert.Equal(t, "self-hosted", job.Pool.Name)
+	require.Len(t, job.Steps, 2)
+	assert.Equal(t, "AzureCLI", job.Steps[0].Task)
+	assert.Equal(t, map[string]any{"inlineScript": "./deploy.sh"}, job.Steps[0].Inputs)
+	assert.Equal(t, "./rollback.sh", job.Steps[1].Script)
+
+	// Pipelines which are not used as templates are parsed on their own
+	release := pipelines[1]
+	assert.Equal(t, "tools/release.yml", release.Metadata.FilePath)
+	require.Len(t, release.Stages, 1)
+	assert.Equal(t, "__default", release.Stages[0].Name)
+	require.Len(t, release.Stages[0].Jobs, 1)
+	assert.Equal(t, "Job", release.Stages[0].Jobs[0].Name)
+	assert.Equal(t, map[string]any{"TOKEN": "$(System.AccessToken)"}, release.Stages[0].Jobs[0].Steps[0].Env)
+}
+
+func TestParser_ParseFS_RecursiveTemplates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"azure-pipelines.yml": &fstest.MapFile{Data: []byte(`extends:
+  template: template.yml
+`)},
+		"template.yml": &fstest.MapFile{Data: []byte(`steps:
+  - script: make
+  - template: template.yml
+`)},
+	}
+
+	pipelines, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+
+	pipeline := pipelines[0]
+	require.Len(t, pipeline.Templates, 2)
+	assert.True(t, pipeline.Templates[0].Resolved)
+	assert.False(t, pipeline.Templates[1].Resolved)
+
+	require.Len(t, pipeline.Stages, 1)
+	require.Len(t, pipeline.Stages[0].Jobs, 1)
+	require.Len(t, pipeline.Stages[0].Jobs[0].Steps, 1)
+	assert.Equal(t, "make", pipeline.Stages[0].Jobs[0].Steps[0].Script)
+}
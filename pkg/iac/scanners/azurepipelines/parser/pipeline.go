@@ -0,0 +1,213 @@
+package parser
+
+import "github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+
+// Pipeline is a pipeline with the content of the templates it uses
+type Pipeline struct {
+	Metadata yamlnode.Metadata
+	// Trigger and PR are the CI and pull request triggers as they are set, or nil if they are not set
+	Trigger   any
+	PR        any
+	Variables map[string]any
+	Pool      *Pool
+	Resources Resources
+	// Templates are all the templates used by the pipeline, including the ones which are not resolved
+	Templates []*Template
+	// Stages are the stages of the pipeline. Pipelines without stages have a single stage named "__default".
+	Stages []*Stage
+}
+
+type Resources struct {
+	Repositories []*Repository
+	Containers   []*Container
+}
+
+type Repository struct {
+	Metadata yamlnode.Metadata
+	Alias    string
+	Type     string
+	Name     string
+	Ref      string
+	Endpoint string
+}
+
+// Template is a template used by the pipeline
+type Template struct {
+	Metadata yamlnode.Metadata
+	Path     string
+	// Repository is the alias of the repository of the template, or empty for templates in the same repository
+	Repository string
+	// Resolved is true if the content of the template is in the scanned pipeline
+	Resolved bool
+}
+
+type Stage struct {
+	Metadata  yamlnode.Metadata
+	Name      string
+	Condition string
+	Variables map[string]any
+	Jobs      []*Job
+}
+
+// Job is a job or a deployment job. Jobs of stages without jobs are named "Job".
+type Job struct {
+	Metadata   yamlnode.Metadata
+	Name       string
+	Deployment bool
+	// Environment is the name of the environment of deployment jobs
+	Environment string
+	// Pool is the pool of the job, which may be inherited from the stage or the pipeline
+	Pool      *Pool
+	Container *Container
+	Services  []*Container
+	Condition string
+	Variables map[string]any
+	// Steps are the steps of the job, including the steps of all the lifecycle hooks of deployment jobs
+	Steps []*Step
+}
+
+// Pool is an agent pool. Pools with a VM image are Microsoft-hosted.
+type Pool struct {
+	Metadata yamlnode.Metadata
+	Name     string
+	VMImage  string
+	Demands  any
+}
+
+type Container struct {
+	Metadata yamlnode.Metadata
+	Alias    string
+	Image    string
+	Options  string
+	Endpoint string
+	Env      map[string]any
+}
+
+type Step struct {
+	Metadata yamlnode.Metadata
+	// Type is the keyword of the step, e.g. "task", "script" or "checkout"
+	Type        string
+	DisplayName string
+	Condition   string
+	// Task and Version are the name and the version of the task of "task" steps
+	Task    string
+	Version string
+	Inputs  map[string]any
+	// Script is the script of "script", "bash", "pwsh" and "powershell" steps
+	Script string
+	// Checkout is the repository of "checkout" steps
+	Checkout           string
+	PersistCredentials bool
+	Env                map[string]any
+}
+
+func (p *Pipeline) ToRego() any {
+	return map[string]any{
+		"trigger":   p.Trigger,
+		"pr":        p.PR,
+		"variables": p.Variables,
+		"pool":      p.Pool.ToRego(),
+		"resources": map[string]any{
+			"repositories": toRegoSlice(p.Resources.Repositories),
+			"containers":   toRegoSlice(p.Resources.Containers),
+		},
+		"templates":         toRegoSlice(p.Templates),
+		"stages":            toRegoSlice(p.Stages),
+		"__defsec_metadata": p.Metadata.ToRego(),
+	}
+}
+
+func (r *Repository) ToRego() any {
+	return map[string]any{
+		"repository":        r.Alias,
+		"type":              r.Type,
+		"name":              r.Name,
+		"ref":               r.Ref,
+		"endpoint":          r.Endpoint,
+		"__defsec_metadata": r.Metadata.ToRego(),
+	}
+}
+
+func (t *Template) ToRego() any {
+	return map[string]any{
+		"path":              t.Path,
+		"repository":        t.Repository,
+		"resolved":          t.Resolved,
+		"__defsec_metadata": t.Metadata.ToRego(),
+	}
+}
+
+func (s *Stage) ToRego() any {
+	return map[string]any{
+		"stage":             s.Name,
+		"condition":         s.Condition,
+		"variables":         s.Variables,
+		"jobs":              toRegoSlice(s.Jobs),
+		"__defsec_metadata": s.Metadata.ToRego(),
+	}
+}
+
+func (j *Job) ToRego() any {
+	return map[string]any{
+		"job":               j.Name,
+		"deployment":        j.Deployment,
+		"environment":       j.Environment,
+		"pool":              j.Pool.ToRego(),
+		"container":         j.Container.ToRego(),
+		"services":          toRegoSlice(j.Services),
+		"condition":         j.Condition,
+		"variables":         j.Variables,
+		"steps":             toRegoSlice(j.Steps),
+		"__defsec_metadata": j.Metadata.ToRego(),
+	}
+}
+
+func (p *Pool) ToRego() any {
+	if p == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":              p.Name,
+		"vmImage":           p.VMImage,
+		"demands":           p.Demands,
+		"__defsec_metadata": p.Metadata.ToRego(),
+	}
+}
+
+func (c *Container) ToRego() any {
+	if c == nil {
+		return nil
+	}
+	return map[string]any{
+		"container":         c.Alias,
+		"image":             c.Image,
+		"options":           c.Options,
+		"endpoint":          c.Endpoint,
+		"env":               c.Env,
+		"__defsec_metadata": c.Metadata.ToRego(),
+	}
+}
+
+func (s *Step) ToRego() any {
+	return map[string]any{
+		"type":               s.Type,
+		"displayName":        s.DisplayName,
+		"condition":          s.Condition,
+		"task":               s.Task,
+		"version":            s.Version,
+		"inputs":             s.Inputs,
+		"script":             s.Script,
+		"checkout":           s.Checkout,
+		"persistCredentials": s.PersistCredentials,
+		"env":                s.Env,
+		"__defsec_metadata":  s.Metadata.ToRego(),
+	}
+}
+
+func toRegoSlice[T interface{ ToRego() any }](elems []T) []any {
+	result := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		result = append(result, elem.ToRego())
+	}
+	return result
+}
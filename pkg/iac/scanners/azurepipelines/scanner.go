@@ -0,0 +1,29 @@
+package azurepipelines
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azurepipelines/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+// NewScanner returns a scanner of Azure Pipelines.
+// Each pipeline, with the content of the templates it uses, is passed to checks as an input.
+func NewScanner(opts ...options.ScannerOption) *generic.GenericScanner {
+	return generic.NewFSScanner("Azure Pipelines", types.SourceAzurePipelines, parse, opts...)
+}
+
+func parse(ctx context.Context, fsys fs.FS, dir string) (map[string]any, error) {
+	pipelines, err := parser.New(fsys).ParseFS(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	return lo.SliceToMap(pipelines, func(pipeline *parser.Pipeline) (string, any) {
+		return pipeline.Metadata.FilePath, pipeline
+	}), nil
+}
@@ -0,0 +1,51 @@
+package azurepipelines_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azurepipelines"
+)
+
+func TestScanner_ScanFS(t *testing.T) {
+	// The check embedded in the Azure Pipelines analyzer
+	check, err := os.Open("../../../fanal/analyzer/config/azurepipelines/checks/unpinned_tasks.rego")
+	require.NoError(t, err)
+	defer check.Close()
+
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/azure-pipelines.yml": `jobs:
+  - job: Build
+    steps:
+      - task: Go@0
+      - task: publisher.extension.Deploy@1
+      # trivy:ignore:AZP001
+      - task: publisher.extension.Publish@1
+`,
+	})
+
+	scanner := azurepipelines.NewScanner(
+		rego.WithEmbeddedPolicies(false),
+		rego.WithEmbeddedLibraries(true),
+		rego.WithPolicyReader(check),
+		rego.WithPolicyNamespaces("builtin"),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "AVD-AZP-0001", failed[0].Rule().AVDID)
+	assert.Equal(t, `Task "publisher.extension.Deploy@1" used in job "Build" is not pinned to a version`, failed[0].Description())
+	assert.Equal(t, "code/azure-pipelines.yml", failed[0].Metadata().Range().GetFilename())
+	assert.Equal(t, 5, failed[0].Metadata().Range().GetStartLine())
+
+	assert.Len(t, results.GetIgnored(), 1)
+}
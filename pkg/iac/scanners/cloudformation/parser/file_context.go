@@ -21,11 +21,12 @@ type FileContext struct {
 	lines        []string
 	SourceFormat SourceFormat
 	Ignores      ignore.Rules
-	Parameters   map[string]*Parameter `json:"Parameters" yaml:"Parameters"`
-	Resources    map[string]*Resource  `json:"Resources" yaml:"Resources"`
-	Globals      map[string]*Resource  `json:"Globals" yaml:"Globals"`
-	Mappings     map[string]any        `json:"Mappings,omitempty" yaml:"Mappings"`
-	Conditions   map[string]Property   `json:"Conditions,omitempty" yaml:"Conditions"`
+	Parameters   map[string]*Parameter           `json:"Parameters" yaml:"Parameters"`
+	Resources    map[string]*Resource            `json:"Resources" yaml:"Resources"`
+	Globals      map[string]map[string]*Property `json:"Globals" yaml:"Globals"`
+	Transform    *Property                       `json:"Transform" yaml:"Transform"`
+	Mappings     map[string]any                  `json:"Mappings,omitempty" yaml:"Mappings"`
+	Conditions   map[string]Property             `json:"Conditions,omitempty" yaml:"Conditions"`
 }
 
 func (t *FileContext) GetResourceByLogicalID(name string) *Resource {
@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/liamg/memoryfs"
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	stackResourceType = "AWS::CloudFormation::Stack"
+	moduleTypeSuffix  = "::MODULE"
+
+	// moduleConfigFileName is the configuration of the module projects created by the CloudFormation CLI
+	moduleConfigFileName = ".rpdk-config"
+	// moduleFragmentsDir is the directory of the module projects containing the template of the module
+	moduleFragmentsDir = "fragments"
+
+	maxNestingDepth = 10
+)
+
+// IsModuleConfig checks if the file is the configuration of a CloudFormation module project, i.e. ".rpdk-config"
+func IsModuleConfig(filePath string) bool {
+	return path.Base(filepath.ToSlash(filePath)) == moduleConfigFileName
+}
+
+// loadModule returns the type name of the module, e.g. "My::S3::Bucket::MODULE", and the path of its template
+func loadModule(fsys fs.FS, configPath string) (string, string, error) {
+	b, err := fs.ReadFile(fsys, configPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	var config struct {
+		TypeName string `json:"typeName"`
+	}
+	if err := json.Unmarshal(b, &config); err != nil {
+		return "", "", fmt.Errorf("invalid module config: %w", err)
+	} else if !strings.HasSuffix(config.TypeName, moduleTypeSuffix) {
+		return "", "", fmt.Errorf("%q is not a module", config.TypeName)
+	}
+
+	fragmentsDir := path.Join(path.Dir(configPath), moduleFragmentsDir)
+	entries, err := fs.ReadDir(fsys, fragmentsDir)
+	if err != nil {
+		return "", "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch path.Ext(entry.Name()) {
+		case ".json", ".yaml", ".yml":
+			return config.TypeName, path.Join(fragmentsDir, entry.Name()), nil
+		}
+	}
+	return "", "", errors.New("module template not found")
+}
+
+// nestedTemplate is the template of a nested stack or a module
+type nestedTemplate struct {
+	fsys     fs.FS
+	filePath string
+	// local is true if the template is in the scanned file system
+	local bool
+	// params are the parameters passed by the parent template
+	params Parameters
+}
+
+// resolveNestedTemplates parses the templates of the nested stacks and the modules with the parameters
+// passed by the parent templates, so that their resources are scanned as they are deployed.
+// Templates used as nested templates are not scanned on their own.
+func (p *Parser) resolveNestedTemplates(ctx context.Context, fsys fs.FS, contexts FileContexts, modules map[string]string) FileContexts {
+	nested := make(map[string]struct{})
+	var resolved FileContexts
+
+	var resolve func(fsys fs.FS, fctx *FileContext, chain []string)
+	resolve = func(fsys fs.FS, fctx *FileContext, chain []string) {
+		for _, id := range slices.Sorted(maps.Keys(fctx.Resources)) {
+			tmpl, err := p.nestedTemplate(ctx, fsys, fctx, fctx.Resources[id], modules)
+			if err != nil {
+				p.logger.Debug("Unable to resolve nested template", log.FilePath(fctx.filepath),
+					log.String("resource", id), log.Err(err))
+				continue
+			} else if tmpl == nil {
+				continue
+			}
+
+			if slices.Contains(chain, tmpl.filePath) || len(chain) >= maxNestingDepth {
+				p.logger.Warn("Nested templates are too deep or recursive", log.FilePath(tmpl.filePath))
+				continue
+			}
+			if tmpl.local {
+				nested[tmpl.filePath] = struct{}{}
+			}
+
+			child, err := p.parseFile(ctx, tmpl.fsys, tmpl.filePath, tmpl.params)
+			if err != nil {
+				p.logger.Error("Error parsing nested template", log.FilePath(tmpl.filePath), log.Err(err))
+				continue
+			}
+			p.logger.Debug("Nested template resolved", log.FilePath(fctx.filepath),
+				log.String("resource", id), log.String("template", tmpl.filePath))
+
+			resolved = append(resolved, child)
+			resolve(tmpl.fsys, child, append(chain, tmpl.filePath))
+		}
+	}
+
+	for _, fctx := range contexts {
+		resolve(fsys, fctx, []string{fctx.filepath})
+	}
+
+	contexts = lo.Filter(contexts, func(fctx *FileContext, _ int) bool {
+		_, ok := nested[fctx.filepath]
+		return !ok
+	})
+	return append(contexts, resolved...)
+}
+
+// nestedTemplate returns the template of the nested stack or the module, or nil if the resource is neither of them
+func (p *Parser) nestedTemplate(ctx context.Context, fsys fs.FS, parent *FileContext, r *Resource,
+	modules map[string]string) (*nestedTemplate, error) {
+	switch {
+	case r.Type() == stackResourceType:
+		templateURL := r.GetStringProperty("TemplateURL")
+		if !templateURL.GetMetadata().IsResolvable() || templateURL.Value() == "" {
+			return nil, errors.New("TemplateURL is not resolvable")
+		}
+		params := parameterValues(r.GetProperty("Parameters").AsMap())
+
+		u, err := url.Parse(templateURL.Value())
+		if err != nil {
+			return nil, err
+		} else if u.Scheme == "" {
+			// The template is packaged with "aws cloudformation package" later
+			filePath := path.Join(path.Dir(parent.filepath), u.Path)
+			if _, err := fs.Stat(fsys, filePath); err != nil {
+				return nil, err
+			}
+			return &nestedTemplate{fsys: fsys, filePath: filePath, local: true, params: params}, nil
+		}
+
+		bucket, key, _, ok := s3Location(u)
+		if !ok {
+			return nil, fmt.Errorf("unsupported TemplateURL %q", templateURL.Value())
+		} else if p.fetchTemplate == nil {
+			return nil, errors.New("fetching templates from S3 is not enabled")
+		}
+		b, err := p.fetchTemplate(ctx, templateURL.Value())
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch template: %w", err)
+		}
+
+		filePath := path.Join(bucket, key)
+		memFS := memoryfs.New()
+		if err := memFS.MkdirAll(path.Dir(filePath), fs.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := memFS.WriteFile(filePath, b, fs.ModePerm); err != nil {
+			return nil, err
+		}
+		return &nestedTemplate{fsys: memFS, filePath: filePath, params: params}, nil
+	case strings.HasSuffix(r.Type(), moduleTypeSuffix):
+		filePath, ok := modules[r.Type()]
+		if !ok {
+			return nil, fmt.Errorf("module %q not found", r.Type())
+		}
+		// The properties of a module are the parameters of its template
+		return &nestedTemplate{fsys: fsys, filePath: filePath, local: true, params: parameterValues(r.properties())}, nil
+	}
+	return nil, nil
+}
+
+// parameterValues converts the resolvable properties to parameter values
+func parameterValues(props map[string]*Property) Parameters {
+	params := make(Parameters)
+	for name, prop := range props {
+		if v, ok := parameterValue(prop); ok {
+			params[name] = v
+		}
+	}
+	return params
+}
+
+func parameterValue(prop *Property) (string, bool) {
+	if prop.IsNil() {
+		return "", false
+	}
+	resolved, ok := prop.resolveValue()
+	if !ok || resolved.IsNil() {
+		return "", false
+	}
+
+	switch {
+	case resolved.IsString():
+		return resolved.AsString(), true
+	case resolved.IsInt():
+		return strconv.Itoa(resolved.AsInt()), true
+	case resolved.IsBool():
+		return strconv.FormatBool(resolved.AsBool()), true
+	case resolved.IsList():
+		// List parameters, e.g. CommaDelimitedList, are passed as comma-separated strings
+		var values []string
+		for _, item := range resolved.AsList() {
+			v, ok := parameterValue(item)
+			if !ok {
+				return "", false
+			}
+			values = append(values, v)
+		}
+		return strings.Join(values, ","), true
+	}
+	return "", false
+}
@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+)
+
+func TestParser_NestedStacks(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"main.yaml": `AWSTemplateFormatVersion: 2010-09-09
+Parameters:
+  Name:
+    Type: String
+    Default: root-bucket
+Resources:
+  Storage:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: ./stacks/storage.yaml
+      Parameters:
+        BucketName: !Ref Name
+        Versioning: Enabled
+`,
+		"stacks/storage.yaml": `AWSTemplateFormatVersion: 2010-09-09
+Parameters:
+  BucketName:
+    Type: String
+  Versioning:
+    Type: String
+    Default: Suspended
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Ref BucketName
+      VersioningConfiguration:
+        Status: !Ref Versioning
+`,
+	})
+
+	files, err := New().ParseFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "main.yaml", files[0].filepath)
+	assert.Equal(t, "stacks/storage.yaml", files[1].filepath)
+
+	bucket := files[1].GetResourceByLogicalID("Bucket")
+	require.NotNil(t, bucket)
+	assert.Equal(t, "root-bucket", bucket.GetStringProperty("BucketName").Value())
+	assert.Equal(t, "Enabled", bucket.GetStringProperty("VersioningConfiguration.Status").Value())
+}
+
+func TestParser_RecursiveNestedStacks(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"main.yaml": `Resources:
+  Self:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: main.yaml
+`,
+	})
+
+	files, err := New().ParseFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "main.yaml", files[0].filepath)
+}
+
+func TestParser_S3NestedStacks(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"main.yaml": `Resources:
+  Storage:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: https://templates.s3.eu-west-1.amazonaws.com/storage.yaml
+      Parameters:
+        BucketName: remote-bucket
+`,
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		files, err := New().ParseFS(context.TODO(), fsys, ".")
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		p := New(WithS3Templates(true))
+		p.fetchTemplate = func(_ context.Context, templateURL string) ([]byte, error) {
+			assert.Equal(t, "https://templates.s3.eu-west-1.amazonaws.com/storage.yaml", templateURL)
+			return []byte(`Parameters:
+  BucketName:
+    Type: String
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    Properties:
+      BucketName: !Ref BucketName
+`), nil
+		}
+
+		files, err := p.ParseFS(context.TODO(), fsys, ".")
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+
+		assert.Equal(t, "templates/storage.yaml", files[1].filepath)
+		bucket := files[1].GetResourceByLogicalID("Bucket")
+		require.NotNil(t, bucket)
+		assert.Equal(t, "remote-bucket", bucket.GetStringProperty("BucketName").Value())
+	})
+}
+
+func TestParser_Modules(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"main.yaml": `Resources:
+  Logs:
+    Type: My::S3::Bucket::MODULE
+    Properties:
+      BucketName: logs
+`,
+		"modules/bucket/.rpdk-config": `{"typeName": "My::S3::Bucket::MODULE", "language": "module"}`,
+		"modules/bucket/fragments/template.json": `{
+  "Parameters": {
+    "BucketName": {"Type": "String"}
+  },
+  "Resources": {
+    "Bucket": {
+      "Type": "AWS::S3::Bucket",
+      "Properties": {
+        "BucketName": {"Ref": "BucketName"}
+      }
+    }
+  }
+}`,
+	})
+
+	files, err := New().ParseFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "main.yaml", files[0].filepath)
+	assert.Equal(t, "modules/bucket/fragments/template.json", files[1].filepath)
+
+	bucket := files[1].GetResourceByLogicalID("Bucket")
+	require.NotNil(t, bucket)
+	assert.Equal(t, "logs", bucket.GetStringProperty("BucketName").Value())
+}
+
+func TestS3Location(t *testing.T) {
+	tests := []struct {
+		url    string
+		bucket string
+		key    string
+		region string
+		ok     bool
+	}{
+		{
+			url:    "s3://templates/stacks/storage.yaml",
+			bucket: "templates",
+			key:    "stacks/storage.yaml",
+			ok:     true,
+		},
+		{
+			url:    "https://templates.s3.amazonaws.com/storage.yaml",
+			bucket: "templates",
+			key:    "storage.yaml",
+			ok:     true,
+		},
+		{
+			url:    "https://templates.s3.eu-west-1.amazonaws.com/storage.yaml",
+			bucket: "templates",
+			key:    "storage.yaml",
+			region: "eu-west-1",
+			ok:     true,
+		},
+		{
+			url:    "https://templates.s3-eu-west-1.amazonaws.com/storage.yaml",
+			bucket: "templates",
+			key:    "storage.yaml",
+			region: "eu-west-1",
+			ok:     true,
+		},
+		{
+			url:    "https://s3.eu-west-1.amazonaws.com/templates/storage.yaml",
+			bucket: "templates",
+			key:    "storage.yaml",
+			region: "eu-west-1",
+			ok:     true,
+		},
+		{
+			url:    "https://s3.amazonaws.com/templates/storage.yaml",
+			bucket: "templates",
+			key:    "storage.yaml",
+			ok:     true,
+		},
+		{
+			url: "https://s3.amazonaws.com/templates",
+		},
+		{
+			url: "https://example.com/storage.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			require.NoError(t, err)
+
+			bucket, key, region, ok := s3Location(u)
+			assert.Equal(t, tt.ok, ok)
+			if !tt.ok {
+				return
+			}
+			assert.Equal(t, tt.bucket, bucket)
+			assert.Equal(t, tt.key, key)
+			assert.Equal(t, tt.region, region)
+		})
+	}
+}
@@ -24,6 +24,8 @@ type Parser struct {
 	parameters          map[string]any
 	overridedParameters Parameters
 	configsFS           fs.FS
+	// fetchTemplate fetches the templates of nested stacks stored in S3, if allowed
+	fetchTemplate func(ctx context.Context, templateURL string) ([]byte, error)
 }
 
 type Option func(*Parser)
@@ -46,6 +48,17 @@ func WithConfigsFS(fsys fs.FS) Option {
 	}
 }
 
+// WithS3Templates allows fetching the templates of nested stacks from S3 with the default AWS credentials
+func WithS3Templates(allowed bool) Option {
+	return func(p *Parser) {
+		if allowed {
+			p.fetchTemplate = fetchS3Template
+		} else {
+			p.fetchTemplate = nil
+		}
+	}
+}
+
 func New(opts ...Option) *Parser {
 	p := &Parser{
 		logger: log.WithPrefix("cloudformation parser"),
@@ -58,6 +71,7 @@ func New(opts ...Option) *Parser {
 
 func (p *Parser) ParseFS(ctx context.Context, fsys fs.FS, dir string) (FileContexts, error) {
 	var contexts FileContexts
+	modules := make(map[string]string)
 	if err := fs.WalkDir(fsys, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
 		select {
 		case <-ctx.Done():
@@ -71,6 +85,16 @@ func (p *Parser) ParseFS(ctx context.Context, fsys fs.FS, dir string) (FileConte
 			return nil
 		}
 
+		if IsModuleConfig(path) {
+			typeName, fragmentPath, err := loadModule(fsys, path)
+			if err != nil {
+				p.logger.Debug("Unable to load module", log.FilePath(path), log.Err(err))
+				return nil
+			}
+			modules[typeName] = fragmentPath
+			return nil
+		}
+
 		c, err := p.ParseFile(ctx, fsys, path)
 		if err != nil {
 			p.logger.Error("Error parsing file", log.FilePath(path), log.Err(err))
@@ -81,10 +105,16 @@ func (p *Parser) ParseFS(ctx context.Context, fsys fs.FS, dir string) (FileConte
 	}); err != nil {
 		return nil, err
 	}
-	return contexts, nil
+
+	return p.resolveNestedTemplates(ctx, fsys, contexts, modules), nil
 }
 
 func (p *Parser) ParseFile(ctx context.Context, fsys fs.FS, filePath string) (fctx *FileContext, err error) {
+	return p.parseFile(ctx, fsys, filePath, nil)
+}
+
+// parseFile parses the template, overriding the parameters with the parameters passed by the parent template if any
+func (p *Parser) parseFile(ctx context.Context, fsys fs.FS, filePath string, params Parameters) (fctx *FileContext, err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			err = fmt.Errorf("panic during parse: %s", e)
@@ -144,6 +174,7 @@ func (p *Parser) ParseFile(ctx context.Context, fsys fs.FS, filePath string) (fc
 	fctx.stripNullProperties()
 
 	fctx.overrideParameters(p.overridedParameters)
+	fctx.overrideParameters(params)
 
 	if params := fctx.missingParameterValues(); len(params) > 0 {
 		p.logger.Warn("Missing parameter values", log.FilePath(filePath), log.String("parameters", strings.Join(params, ", ")))
@@ -164,6 +195,11 @@ func (p *Parser) ParseFile(ctx context.Context, fsys fs.FS, filePath string) (fc
 		r.configureResource(name, fsys, filePath, fctx)
 	}
 
+	fctx.configureGlobals(fsys, filePath)
+	if fctx.usesServerlessTransform() {
+		fctx.transformServerless()
+	}
+
 	return fctx, nil
 }
 
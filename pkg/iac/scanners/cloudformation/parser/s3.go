@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const defaultS3Region = "us-east-1"
+
+// s3Location returns the bucket, the key and the region of the S3 object referred to by the URL.
+// The following formats are supported:
+//   - s3://bucket/key
+//   - https://bucket.s3.region.amazonaws.com/key (virtual-hosted-style)
+//   - https://s3.region.amazonaws.com/bucket/key (path-style)
+func s3Location(u *url.URL) (bucket, key, region string, ok bool) {
+	if u.Scheme == "s3" {
+		return u.Host, strings.TrimPrefix(u.Path, "/"), "", u.Host != "" && u.Path != ""
+	} else if u.Scheme != "https" && u.Scheme != "http" {
+		return "", "", "", false
+	}
+
+	host, found := strings.CutSuffix(u.Hostname(), ".amazonaws.com")
+	if !found {
+		return "", "", "", false
+	}
+	p := strings.TrimPrefix(u.Path, "/")
+
+	// Path-style
+	if host == "s3" || strings.HasPrefix(host, "s3.") || strings.HasPrefix(host, "s3-") {
+		bucket, key, _ = strings.Cut(p, "/")
+		return bucket, key, s3Region(host), bucket != "" && key != ""
+	}
+
+	// Virtual-hosted-style
+	for _, sep := range []string{".s3.", ".s3-"} {
+		if b, rest, ok := strings.Cut(host+".", sep); ok {
+			return b, p, s3Region("s3" + sep[3:] + strings.TrimSuffix(rest, ".")), b != "" && p != ""
+		}
+	}
+	return "", "", "", false
+}
+
+// s3Region returns the region in the S3 endpoint, e.g. "s3.eu-west-1" and "s3-eu-west-1"
+func s3Region(endpoint string) string {
+	region := strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(endpoint, "s3"), "."), "-")
+	region = strings.TrimPrefix(region, "dualstack.")
+	if region == "" || region == "external-1" {
+		return ""
+	}
+	return region
+}
+
+// fetchS3Template downloads the template from S3 with the default AWS credentials
+func fetchS3Template(ctx context.Context, templateURL string) ([]byte, error) {
+	u, err := url.Parse(templateURL)
+	if err != nil {
+		return nil, err
+	}
+	bucket, key, region, ok := s3Location(u)
+	if !ok {
+		return nil, errors.New("not an S3 URL")
+	}
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("aws config load error: %w", err)
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+	}
+
+	out, err := s3.NewFromConfig(cfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
@@ -0,0 +1,260 @@
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/cloudformation/cftypes"
+)
+
+const (
+	// serverlessTransform is the transform declared by AWS SAM templates
+	serverlessTransform = "AWS::Serverless-2016-10-31"
+
+	serverlessFunctionType    = "AWS::Serverless::Function"
+	serverlessApiType         = "AWS::Serverless::Api"
+	serverlessSimpleTableType = "AWS::Serverless::SimpleTable"
+)
+
+var (
+	// functionProperties are the properties of AWS::Serverless::Function passed to AWS::Lambda::Function as they are
+	functionProperties = []string{
+		"Architectures", "CodeSigningConfigArn", "Description", "Environment", "EphemeralStorage",
+		"FileSystemConfigs", "FunctionName", "Handler", "ImageConfig", "KmsKeyArn", "Layers", "LoggingConfig",
+		"MemorySize", "PackageType", "ReservedConcurrentExecutions", "Runtime", "SnapStart", "Timeout", "VpcConfig",
+	}
+	// restApiProperties are the properties of AWS::Serverless::Api passed to AWS::ApiGateway::RestApi as they are
+	restApiProperties = []string{
+		"ApiKeySourceType", "BinaryMediaTypes", "Description", "DisableExecuteApiEndpoint",
+		"MinimumCompressionSize", "Mode", "Name",
+	}
+	// stageProperties are the properties of AWS::Serverless::Api passed to AWS::ApiGateway::Stage as they are
+	stageProperties = []string{
+		"AccessLogSetting", "CacheClusterEnabled", "CacheClusterSize", "CanarySetting",
+		"MethodSettings", "StageName", "TracingEnabled", "Variables",
+	}
+	// tableProperties are the properties of AWS::Serverless::SimpleTable passed to AWS::DynamoDB::Table as they are
+	tableProperties = []string{
+		"SSESpecification", "TableName",
+	}
+)
+
+// usesServerlessTransform checks if the template is an AWS SAM template
+func (t *FileContext) usesServerlessTransform() bool {
+	switch {
+	case t.Transform.IsString():
+		return t.Transform.AsString() == serverlessTransform
+	case !t.Transform.IsList():
+		return false
+	}
+	return lo.ContainsBy(t.Transform.AsList(), func(p *Property) bool {
+		return p.IsString() && p.AsString() == serverlessTransform
+	})
+}
+
+// configureGlobals sets the file and the context to the properties in the Globals section of SAM templates
+func (t *FileContext) configureGlobals(target fs.FS, filepath string) {
+	for section, props := range t.Globals {
+		for name, p := range props {
+			if p == nil {
+				continue
+			}
+			p.setName(name)
+			p.setFileAndParentRange(target, filepath, p.rng)
+			p.SetLogicalResource("Globals." + section)
+			p.setContext(t)
+		}
+	}
+}
+
+// transformServerless expands the AWS::Serverless::Function, AWS::Serverless::Api and AWS::Serverless::SimpleTable
+// resources into the CloudFormation resources created by the SAM transform, so that they are scanned as deployed.
+// The logical IDs of the expanded resources follow the SAM transform, e.g. "MyFunctionRole" for the role of "MyFunction".
+// The expanded resources refer to the source ranges of the SAM resources.
+func (t *FileContext) transformServerless() {
+	for _, id := range slices.Sorted(maps.Keys(t.Resources)) {
+		r := t.Resources[id]
+
+		var expanded []*Resource
+		switch r.Type() {
+		case serverlessFunctionType:
+			expanded = r.expandFunction(t.withGlobals("Function", r))
+		case serverlessApiType:
+			expanded = r.expandApi(t.withGlobals("Api", r))
+		case serverlessSimpleTableType:
+			expanded = r.expandSimpleTable(t.withGlobals("SimpleTable", r))
+		default:
+			continue
+		}
+
+		delete(t.Resources, id)
+		for _, e := range expanded {
+			if _, exists := t.Resources[e.id]; exists {
+				// The resource defined in the template takes precedence
+				continue
+			}
+			t.Resources[e.id] = e
+		}
+	}
+}
+
+// withGlobals returns the properties of the resource merged with the properties in the Globals section.
+// The properties of the resource take precedence.
+func (t *FileContext) withGlobals(section string, r *Resource) map[string]*Property {
+	props := maps.Clone(t.Globals[section])
+	if props == nil {
+		props = make(map[string]*Property)
+	}
+	maps.Copy(props, r.properties())
+	return lo.OmitBy(props, func(_ string, p *Property) bool {
+		return p.IsNil()
+	})
+}
+
+func (r *Resource) expandFunction(props map[string]*Property) []*Resource {
+	fnProps := lo.PickByKeys(props, functionProperties)
+
+	if tracing, ok := props["Tracing"]; ok {
+		fnProps["TracingConfig"] = tracing.deriveResolved(cftypes.Map, map[string]*Property{
+			"Mode": tracing,
+		})
+	}
+	if dlq, ok := props["DeadLetterQueue"]; ok && dlq.IsMap() {
+		fnProps["DeadLetterConfig"] = dlq.deriveResolved(cftypes.Map, map[string]*Property{
+			"TargetArn": dlq.GetProperty("TargetArn"),
+		})
+	}
+
+	if role, ok := props["Role"]; ok {
+		fnProps["Role"] = role
+		return []*Resource{r.derive(r.id, "AWS::Lambda::Function", fnProps)}
+	}
+
+	// SAM creates the execution role of the function unless the role is specified
+	roleID := r.id + "Role"
+	fnProps["Role"] = r.newProperty("Role", cftypes.Map, map[string]*Property{
+		"Fn::GetAtt": r.newProperty("Fn::GetAtt", cftypes.List, []*Property{
+			r.newProperty("", cftypes.String, roleID),
+			r.newProperty("", cftypes.String, "Arn"),
+		}),
+	})
+
+	roleProps := lo.PickByKeys(props, []string{"PermissionsBoundary"})
+	if policies, ok := props["Policies"]; ok {
+		managed, inline := r.functionPolicies(roleID, policies)
+		if len(managed) > 0 {
+			roleProps["ManagedPolicyArns"] = policies.deriveResolved(cftypes.List, managed)
+		}
+		if len(inline) > 0 {
+			roleProps["Policies"] = policies.deriveResolved(cftypes.List, inline)
+		}
+	}
+
+	return []*Resource{
+		r.derive(r.id, "AWS::Lambda::Function", fnProps),
+		r.derive(roleID, "AWS::IAM::Role", roleProps),
+	}
+}
+
+// functionPolicies returns the managed policies and the inline policies of the Policies property of the function.
+// Policies can be the name of a managed policy, a policy document or a list of them.
+// SAM policy templates are not expanded.
+func (r *Resource) functionPolicies(roleID string, policies *Property) (managed, inline []*Property) {
+	items := []*Property{policies}
+	if policies.IsList() {
+		items = policies.AsList()
+	}
+
+	for _, item := range items {
+		switch {
+		case item.IsString():
+			managed = append(managed, item)
+		case item.IsMap() && item.GetProperty("Statement").IsNotNil():
+			inline = append(inline, item.deriveResolved(cftypes.Map, map[string]*Property{
+				"PolicyName":     r.newProperty("PolicyName", cftypes.String, fmt.Sprintf("%sPolicy%d", roleID, len(inline))),
+				"PolicyDocument": item,
+			}))
+		}
+	}
+	return managed, inline
+}
+
+func (r *Resource) expandApi(props map[string]*Property) []*Resource {
+	expanded := []*Resource{
+		r.derive(r.id, "AWS::ApiGateway::RestApi", lo.PickByKeys(props, restApiProperties)),
+	}
+
+	stageProps := lo.PickByKeys(props, stageProperties)
+	stageProps["RestApiId"] = r.newRef("RestApiId", r.id)
+
+	var stageName string
+	if name, ok := props["StageName"]; ok && name.IsString() {
+		stageName = name.AsString()
+	}
+	expanded = append(expanded, r.derive(r.id+logicalIDPart(stageName)+"Stage", "AWS::ApiGateway::Stage", stageProps))
+
+	if domain, ok := props["Domain"]; ok && domain.IsMap() {
+		domainProps := lo.PickByKeys(domain.AsMap(), []string{
+			"CertificateArn", "DomainName", "SecurityPolicy",
+		})
+		expanded = append(expanded, r.derive(r.id+"DomainName", "AWS::ApiGateway::DomainName", domainProps))
+	}
+	return expanded
+}
+
+func (r *Resource) expandSimpleTable(props map[string]*Property) []*Resource {
+	return []*Resource{
+		r.derive(r.id, "AWS::DynamoDB::Table", lo.PickByKeys(props, tableProperties)),
+	}
+}
+
+// derive returns a resource created from the resource by the SAM transform
+func (r *Resource) derive(id, resourceType string, props map[string]*Property) *Resource {
+	return &Resource{
+		ctx:     r.ctx,
+		rng:     r.rng,
+		id:      id,
+		comment: r.comment,
+		Inner: ResourceInner{
+			Type:       resourceType,
+			Properties: props,
+		},
+	}
+}
+
+// newProperty returns a property which is not defined in the template, but created by the SAM transform
+func (r *Resource) newProperty(name string, propType cftypes.CfType, value any) *Property {
+	return &Property{
+		ctx:         r.ctx,
+		name:        name,
+		rng:         r.rng,
+		parentRange: r.rng,
+		logicalId:   r.id,
+		Inner: PropertyInner{
+			Type:  propType,
+			Value: value,
+		},
+	}
+}
+
+func (r *Resource) newRef(name, logicalID string) *Property {
+	return r.newProperty(name, cftypes.Map, map[string]*Property{
+		"Ref": r.newProperty("Ref", cftypes.String, logicalID),
+	})
+}
+
+// logicalIDPart removes the characters not allowed in logical IDs
+func logicalIDPart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r)) {
+			return -1
+		}
+		return r
+	}, s)
+}
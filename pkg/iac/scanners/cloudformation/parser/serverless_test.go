@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+)
+
+func TestParser_ServerlessTransform(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Globals:
+  Function:
+    Runtime: python3.12
+    Tracing: Active
+Resources:
+  MyFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: my-function
+      Handler: app.handler
+      Policies:
+        - AWSLambdaExecute
+        - Version: '2012-10-17'
+          Statement:
+            - Effect: Allow
+              Action: s3:GetObject
+              Resource: '*'
+  MyApi:
+    Type: AWS::Serverless::Api
+    Properties:
+      Name: my-api
+      StageName: prod
+      TracingEnabled: true
+      Domain:
+        DomainName: example.com
+        SecurityPolicy: TLS_1_2
+  MyTable:
+    Type: AWS::Serverless::SimpleTable
+    Properties:
+      TableName: my-table
+      SSESpecification:
+        SSEEnabled: true
+`,
+	})
+
+	fctx, err := New().ParseFile(context.TODO(), fsys, "template.yaml")
+	require.NoError(t, err)
+
+	types := make(map[string]string)
+	for id, r := range fctx.Resources {
+		types[id] = r.Type()
+	}
+	assert.Equal(t, map[string]string{
+		"MyFunction":      "AWS::Lambda::Function",
+		"MyFunctionRole":  "AWS::IAM::Role",
+		"MyApi":           "AWS::ApiGateway::RestApi",
+		"MyApiprodStage":  "AWS::ApiGateway::Stage",
+		"MyApiDomainName": "AWS::ApiGateway::DomainName",
+		"MyTable":         "AWS::DynamoDB::Table",
+	}, types)
+
+	function := fctx.GetResourceByLogicalID("MyFunction")
+	assert.Equal(t, "my-function", function.GetStringProperty("FunctionName").Value())
+	assert.Equal(t, "python3.12", function.GetStringProperty("Runtime").Value())
+	assert.Equal(t, "Active", function.GetStringProperty("TracingConfig.Mode").Value())
+	assert.Equal(t, 8, function.Range().GetStartLine())
+
+	role := fctx.GetResourceByLogicalID("MyFunctionRole")
+	managed := role.GetProperty("ManagedPolicyArns").AsList()
+	require.Len(t, managed, 1)
+	assert.Equal(t, "AWSLambdaExecute", managed[0].AsString())
+	policies := role.GetProperty("Policies").AsList()
+	require.Len(t, policies, 1)
+	assert.Equal(t, "MyFunctionRolePolicy0", policies[0].GetStringProperty("PolicyName").Value())
+	assert.Equal(t, "Allow", policies[0].GetProperty("PolicyDocument").AsMap()["Statement"].AsList()[0].GetStringProperty("Effect").Value())
+
+	stage := fctx.GetResourceByLogicalID("MyApiprodStage")
+	assert.Equal(t, "MyApi", stage.GetStringProperty("RestApiId").Value())
+	assert.True(t, stage.GetBoolProperty("TracingEnabled").IsTrue())
+
+	domain := fctx.GetResourceByLogicalID("MyApiDomainName")
+	assert.Equal(t, "TLS_1_2", domain.GetStringProperty("SecurityPolicy").Value())
+
+	table := fctx.GetResourceByLogicalID("MyTable")
+	assert.True(t, table.GetBoolProperty("SSESpecification.SSEEnabled").IsTrue())
+}
+
+func TestParser_ServerlessTransform_JSON(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"template.json": `{
+  "Transform": ["AWS::Serverless-2016-10-31"],
+  "Globals": {
+    "Function": {
+      "Tracing": "PassThrough"
+    }
+  },
+  "Resources": {
+    "MyFunction": {
+      "Type": "AWS::Serverless::Function",
+      "Properties": {
+        "Role": "arn:aws:iam::123456789012:role/my-role",
+        "Tracing": "Active"
+      }
+    }
+  }
+}`,
+	})
+
+	fctx, err := New().ParseFile(context.TODO(), fsys, "template.json")
+	require.NoError(t, err)
+	require.Len(t, fctx.Resources, 1)
+
+	function := fctx.GetResourceByLogicalID("MyFunction")
+	assert.Equal(t, "AWS::Lambda::Function", function.Type())
+	assert.Equal(t, "Active", function.GetStringProperty("TracingConfig.Mode").Value())
+	assert.Equal(t, "arn:aws:iam::123456789012:role/my-role", function.GetStringProperty("Role").Value())
+}
+
+func TestParser_WithoutServerlessTransform(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"template.yaml": `Resources:
+  MyFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      Tracing: Active
+`,
+	})
+
+	fctx, err := New().ParseFile(context.TODO(), fsys, "template.yaml")
+	require.NoError(t, err)
+	require.Len(t, fctx.Resources, 1)
+	assert.Equal(t, "AWS::Serverless::Function", fctx.GetResourceByLogicalID("MyFunction").Type())
+}
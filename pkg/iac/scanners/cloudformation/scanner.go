@@ -41,6 +41,15 @@ func WithConfigsFS(fsys fs.FS) options.ScannerOption {
 	}
 }
 
+// WithS3Templates allows fetching the templates of nested stacks from S3
+func WithS3Templates(allowed bool) options.ScannerOption {
+	return func(cs options.ConfigurableScanner) {
+		if s, ok := cs.(*Scanner); ok {
+			s.addParserOption(parser.WithS3Templates(allowed))
+		}
+	}
+}
+
 var _ scanners.FSScanner = (*Scanner)(nil)
 var _ options.ConfigurableScanner = (*Scanner)(nil)
 
@@ -0,0 +1,238 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/build"
+	"cuelang.org/go/cue/cuecontext"
+	cueparser "cuelang.org/go/cue/parser"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const metadataKey = "__defsec_metadata"
+
+// Package is a CUE package, i.e. the files in a directory with the same package clause,
+// evaluated into a single value
+type Package struct {
+	Name string
+	Dir  string
+	// Files are the files of the package, sorted
+	Files []string
+	Value cue.Value
+}
+
+// ToRego returns the concrete value of the package.
+// Fields which are not concrete, such as constraints like "int" or ">0", and definitions are omitted.
+func (p *Package) ToRego() any {
+	return toRego(p.Value)
+}
+
+// Parser evaluates the CUE packages in a file system.
+// Packages may import the standard library only, as modules are not loaded.
+type Parser struct {
+	fsys   fs.FS
+	logger *log.Logger
+	ctx    *cue.Context
+}
+
+func New(fsys fs.FS) *Parser {
+	return &Parser{
+		fsys:   fsys,
+		logger: log.WithPrefix("cue parser"),
+		ctx:    cuecontext.New(),
+	}
+}
+
+// ParseFS evaluates the packages in the directory and its subdirectories.
+// Packages which cannot be evaluated, e.g. because of conflicting values, are skipped.
+func (p *Parser) ParseFS(ctx context.Context, dir string) ([]*Package, error) {
+	dirs := make(map[string][]string)
+	if err := fs.WalkDir(p.fsys, filepath.ToSlash(dir), func(filePath string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// Dependencies of modules
+			if entry.Name() == "cue.mod" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if path.Ext(filePath) == ".cue" {
+			dirs[path.Dir(filePath)] = append(dirs[path.Dir(filePath)], filePath)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	dirNames := make([]string, 0, len(dirs))
+	for d := range dirs {
+		dirNames = append(dirNames, d)
+	}
+	sort.Strings(dirNames)
+
+	var packages []*Package
+	for _, d := range dirNames {
+		pkgs, err := p.parseDir(d, dirs[d])
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, pkgs...)
+	}
+	return packages, nil
+}
+
+// parseDir evaluates the packages of the files in the directory
+func (p *Parser) parseDir(dir string, filePaths []string) ([]*Package, error) {
+	sources := make(map[string][]byte)
+	files := make(map[string][]string)
+	for _, filePath := range filePaths {
+		src, err := fs.ReadFile(p.fsys, filePath)
+		if err != nil {
+			return nil, err
+		}
+		f, err := cueparser.ParseFile(filePath, src, cueparser.PackageClauseOnly)
+		if err != nil {
+			p.logger.Error("Failed to parse file", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		sources[filePath] = src
+		files[f.PackageName()] = append(files[f.PackageName()], filePath)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var packages []*Package
+	for _, name := range names {
+		pkgFiles := files[name]
+		slices.Sort(pkgFiles)
+		value, err := p.build(dir, pkgFiles, sources)
+		if err != nil {
+			p.logger.Error("Failed to evaluate package", log.FilePath(dir), log.String("package", name), log.Err(err))
+			continue
+		}
+		packages = append(packages, &Package{
+			Name:  name,
+			Dir:   dir,
+			Files: pkgFiles,
+			Value: value,
+		})
+	}
+	return packages, nil
+}
+
+func (p *Parser) build(dir string, filePaths []string, sources map[string][]byte) (cue.Value, error) {
+	inst := build.NewContext().NewInstance(dir, nil)
+	for _, filePath := range filePaths {
+		if err := inst.AddFile(filePath, sources[filePath]); err != nil {
+			return cue.Value{}, err
+		}
+	}
+	if inst.Err != nil {
+		return cue.Value{}, inst.Err
+	}
+
+	value := p.ctx.BuildInstance(inst)
+	if err := value.Err(); err != nil {
+		return cue.Value{}, err
+	}
+	if err := value.Validate(); err != nil {
+		return cue.Value{}, fmt.Errorf("invalid value: %w", err)
+	}
+	return value, nil
+}
+
+func toRego(v cue.Value) any {
+	switch v = concrete(v); v.Kind() {
+	case cue.StructKind:
+		m := map[string]any{
+			metadataKey: metadata(v),
+		}
+		it, err := v.Fields()
+		if err != nil {
+			return m
+		}
+		for it.Next() {
+			child := concrete(it.Value())
+			if child.Kind() == cue.BottomKind {
+				continue
+			}
+			m[it.Selector().Unquoted()] = toRego(child)
+		}
+		return m
+	case cue.ListKind:
+		list := make([]any, 0)
+		it, err := v.List()
+		if err != nil {
+			return list
+		}
+		for it.Next() {
+			if item := concrete(it.Value()); item.Kind() != cue.BottomKind {
+				list = append(list, toRego(item))
+			}
+		}
+		return list
+	case cue.IntKind:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case cue.FloatKind:
+		f, _ := v.Float64()
+		return f
+	case cue.StringKind:
+		s, _ := v.String()
+		return s
+	case cue.BytesKind:
+		b, _ := v.Bytes()
+		return string(b)
+	case cue.BoolKind:
+		b, _ := v.Bool()
+		return b
+	}
+	return nil
+}
+
+// concrete returns the default of the value if it has one, e.g. false for "bool | *false"
+func concrete(v cue.Value) cue.Value {
+	if d, ok := v.Default(); ok {
+		return d
+	}
+	return v
+}
+
+// metadata returns the location of the value, used as the location of the results of checks
+func metadata(v cue.Value) map[string]any {
+	m := make(map[string]any)
+	startPos := v.Pos()
+	if !startPos.IsValid() {
+		return m
+	}
+	endLine := startPos.Line()
+	if src := v.Source(); src != nil && src.End().IsValid() {
+		endLine = src.End().Line()
+	}
+	m["filepath"] = startPos.Filename()
+	m["startline"] = startPos.Line()
+	m["endline"] = endLine
+	return m
+}
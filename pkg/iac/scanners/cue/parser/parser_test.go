@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"service/schema.cue": &fstest.MapFile{Data: []byte(`package service
+
+import "strings"
+
+#Service: {
+	name:     string
+	replicas: int & >0 | *1
+	public:   bool | *false
+	port?:    int
+	tags?: [...string]
+}
+
+service: #Service & {
+	name: strings.ToLower("API")
+}
+`)},
+		"service/prod.cue": &fstest.MapFile{Data: []byte(`package service
+
+service: {
+	replicas: 3
+	tags: ["prod", "api"]
+}
+`)},
+		"service/other.cue": &fstest.MapFile{Data: []byte(`package other
+
+limit: int
+name:  "other"
+`)},
+		"conflict/a.cue": &fstest.MapFile{Data: []byte(`replicas: 1
+`)},
+		"conflict/b.cue": &fstest.MapFile{Data: []byte(`replicas: 2
+`)},
+		"cue.mod/pkg/example.com/lib/lib.cue": &fstest.MapFile{Data: []byte(`package lib
+`)},
+	}
+
+	packages, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+
+	assert.Equal(t, "other", packages[0].Name)
+	assert.Equal(t, map[string]any{
+		"__defsec_metadata": map[string]any{
+			"filepath":  "service/other.cue",
+			"startline": 1,
+			"endline":   4,
+		},
+		"name": "other",
+	}, packages[0].ToRego())
+
+	pkg := packages[1]
+	assert.Equal(t, "service", pkg.Name)
+	assert.Equal(t, []string{"service/prod.cue", "service/schema.cue"}, pkg.Files)
+
+	value, ok := pkg.ToRego().(map[string]any)
+	require.True(t, ok)
+	service, ok := value["service"].(map[string]any)
+	require.True(t, ok)
+	delete(service, "__defsec_metadata")
+	assert.Equal(t, map[string]any{
+		"name":     "api",
+		"replicas": 3,
+		"public":   false,
+		"tags":     []any{"prod", "api"},
+	}, service)
+	assert.NotContains(t, value, "#Service")
+}
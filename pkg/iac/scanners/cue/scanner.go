@@ -0,0 +1,29 @@
+package cue
+
+import (
+	"context"
+	"io/fs"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/cue/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+// NewScanner returns a scanner of CUE configurations.
+// Each package, evaluated into a concrete value, is passed to checks as an input.
+func NewScanner(opts ...options.ScannerOption) *generic.GenericScanner {
+	return generic.NewFSScanner("CUE", types.SourceCUE, parse, opts...)
+}
+
+func parse(ctx context.Context, fsys fs.FS, dir string) (map[string]any, error) {
+	packages, err := parser.New(fsys).ParseFS(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	return lo.SliceToMap(packages, func(pkg *parser.Package) (string, any) {
+		return pkg.Files[0], pkg
+	}), nil
+}
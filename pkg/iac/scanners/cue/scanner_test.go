@@ -0,0 +1,109 @@
+package cue
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+const replicasCheck = `# METADATA
+# title: Deployments should have more than one replica
+# custom:
+#   id: USR-CUE-0001
+#   avd_id: USR-CUE-0001
+#   severity: MEDIUM
+#   short_code: replicas
+#   input:
+#     selector:
+#     - type: cue
+package user.cue.replicas
+
+import rego.v1
+
+deny contains res if {
+	some name, deployment in input.deployments
+	deployment.replicas < 2
+	res := result.new(sprintf("Deployment %q has a single replica", [name]), deployment)
+}
+`
+
+func TestScanner_ScanFS(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		failed    int
+		ignored   int
+		startLine int
+	}{
+		{
+			name: "default value",
+			files: map[string]string{
+				"code/schema.cue": `package apps
+
+#Deployment: {
+	image:    string
+	replicas: int | *1
+}
+
+deployments: [string]: #Deployment
+`,
+				"code/apps.cue": `package apps
+
+deployments: web: {
+	image: "nginx"
+}
+deployments: api: {
+	image:    "api"
+	replicas: 3
+}
+`,
+			},
+			failed:    1,
+			startLine: 3,
+		},
+		{
+			name: "ignored",
+			files: map[string]string{
+				"code/apps.cue": `package apps
+
+// trivy:ignore:USR-CUE-0001
+deployments: web: {
+	image:    "nginx"
+	replicas: 1
+}
+`,
+			},
+			ignored: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := testutil.CreateFS(t, tt.files)
+
+			scanner := NewScanner(
+				rego.WithEmbeddedPolicies(false),
+				rego.WithEmbeddedLibraries(true),
+				rego.WithPolicyReader(strings.NewReader(replicasCheck)),
+				rego.WithPolicyNamespaces("user"),
+			)
+
+			results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+			require.NoError(t, err)
+
+			failed := results.GetFailed()
+			assert.Len(t, failed, tt.failed)
+			assert.Len(t, results.GetIgnored(), tt.ignored)
+			if tt.failed > 0 {
+				assert.Equal(t, "code/apps.cue", failed[0].Metadata().Range().GetFilename())
+				assert.Equal(t, tt.startLine, failed[0].Metadata().Range().GetStartLine())
+			}
+		})
+	}
+}
@@ -1,21 +1,18 @@
 package generic
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/fs"
 	"path/filepath"
-	"strings"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
-	"github.com/samber/lo"
-	"gopkg.in/yaml.v3"
 
-	"github.com/aquasecurity/trivy/pkg/iac/ignore"
 	"github.com/aquasecurity/trivy/pkg/iac/rego"
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
@@ -35,6 +32,8 @@ func NewTomlScanner(opts ...options.ScannerOption) *GenericScanner {
 	return NewScanner("TOML", types.SourceTOML, ParseFunc(parseTOML), opts...)
 }
 
+var _ options.ConfigurableParseLimits = (*GenericScanner)(nil)
+
 type configParser interface {
 	Parse(ctx context.Context, r io.Reader, path string) (any, error)
 }
@@ -47,8 +46,10 @@ type GenericScanner struct {
 	logger      *log.Logger
 	options     []options.ScannerOption
 	regoScanner *rego.Scanner
+	limits      options.ParseLimits
 
-	parser configParser
+	parser   configParser
+	fsParser FSParseFunc
 }
 
 type ParseFunc func(ctx context.Context, r io.Reader, path string) (any, error)
@@ -57,6 +58,17 @@ func (f ParseFunc) Parse(ctx context.Context, r io.Reader, path string) (any, er
 	return f(ctx, r, path)
 }
 
+// FSParseFunc parses configurations spanning multiple files in the directory, such as pipelines including other files.
+// The parsed configurations are keyed by their paths.
+type FSParseFunc func(ctx context.Context, fsys fs.FS, dir string) (map[string]any, error)
+
+// NewFSScanner returns a scanner of configurations parsed from the whole directory rather than file by file
+func NewFSScanner(name string, source types.Source, parse FSParseFunc, opts ...options.ScannerOption) *GenericScanner {
+	s := NewScanner(name, source, nil, opts...)
+	s.fsParser = parse
+	return s
+}
+
 func NewScanner(name string, source types.Source, parser configParser, opts ...options.ScannerOption) *GenericScanner {
 	s := &GenericScanner{
 		name:    name,
@@ -77,8 +89,22 @@ func (s *GenericScanner) Name() string {
 	return s.name
 }
 
+func (s *GenericScanner) SetMaxFileSize(size int64) {
+	s.limits.MaxFileSize = size
+}
+
+func (s *GenericScanner) SetParseTimeout(timeout time.Duration) {
+	s.limits.Timeout = timeout
+}
+
 func (s *GenericScanner) ScanFS(ctx context.Context, fsys fs.FS, dir string) (scan.Results, error) {
-	fileset, err := s.parseFS(ctx, fsys, dir)
+	var fileset map[string]any
+	var err error
+	if s.fsParser != nil {
+		fileset, err = s.fsParser(ctx, fsys, dir)
+	} else {
+		fileset, err = s.parseFS(ctx, fsys, dir)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +159,13 @@ func (s *GenericScanner) ScanFS(ctx context.Context, fsys fs.FS, dir string) (sc
 }
 
 func (s *GenericScanner) supportsIgnoreRules() bool {
-	return s.source == types.SourceDockerfile
+	return slices.Contains([]types.Source{
+		types.SourceDockerfile,
+		types.SourceGitHubActions,
+		types.SourceGitLabCI,
+		types.SourceAzurePipelines,
+		types.SourceCUE,
+	}, s.source)
 }
 
 func (s *GenericScanner) parseFS(ctx context.Context, fsys fs.FS, path string) (map[string]any, error) {
@@ -151,14 +183,28 @@ func (s *GenericScanner) parseFS(ctx context.Context, fsys fs.FS, path string) (
 			return nil
 		}
 
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if err := s.limits.CheckSize(info.Size()); err != nil {
+			s.logger.Warn("Skipping file", log.FilePath(path), log.Err(err))
+			return nil
+		}
+
 		f, err := fsys.Open(filepath.ToSlash(path))
 		if err != nil {
 			return err
 		}
 		defer f.Close()
 
-		df, err := s.parser.Parse(ctx, f, path)
-		if err != nil {
+		df, err := options.Parse(ctx, s.limits, func(ctx context.Context) (any, error) {
+			return s.parser.Parse(ctx, f, path)
+		})
+		if options.IsLimitError(err) {
+			s.logger.Warn("Skipping file", log.FilePath(path), log.Err(err))
+			return nil
+		} else if err != nil {
 			s.logger.Error("Failed to parse file", log.FilePath(path), log.Err(err))
 			return nil
 		}
@@ -189,20 +235,7 @@ func (s *GenericScanner) applyIgnoreRules(fsys fs.FS, results scan.Results) erro
 		return nil
 	}
 
-	uniqueFiles := lo.Uniq(lo.Map(results.GetFailed(), func(res scan.Result, _ int) string {
-		return res.Metadata().Range().GetFilename()
-	}))
-
-	for _, filename := range uniqueFiles {
-		content, err := fs.ReadFile(fsys, filename)
-		if err != nil {
-			return err
-		}
-
-		ignoreRules := ignore.Parse(string(content), filename, "")
-		results.Ignore(ignoreRules, nil)
-	}
-	return nil
+	return results.IgnoreByComments(fsys)
 }
 
 func parseJson(ctx context.Context, r io.Reader, _ string) (any, error) {
@@ -213,31 +246,6 @@ func parseJson(ctx context.Context, r io.Reader, _ string) (any, error) {
 	return target, nil
 }
 
-func parseYaml(ctx context.Context, r io.Reader, _ string) (any, error) {
-	contents, err := io.ReadAll(r)
-	if err != nil {
-		return nil, err
-	}
-
-	var results []any
-
-	marker := "\n---\n"
-	altMarker := "\r\n---\r\n"
-	if bytes.Contains(contents, []byte(altMarker)) {
-		marker = altMarker
-	}
-
-	for _, partial := range strings.Split(string(contents), marker) {
-		var target any
-		if err := yaml.Unmarshal([]byte(partial), &target); err != nil {
-			return nil, err
-		}
-		results = append(results, target)
-	}
-
-	return results, nil
-}
-
 func parseTOML(ctx context.Context, r io.Reader, _ string) (any, error) {
 	var target any
 	if _, err := toml.NewDecoder(r).Decode(&target); err != nil {
@@ -2,6 +2,7 @@ package generic_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/iac/rego"
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
 )
 
 func TestJsonScanner(t *testing.T) {
@@ -214,3 +216,100 @@ deny[res] {
 		results.GetFailed()[0].Rule(),
 	)
 }
+
+func TestYamlScanner_Locations(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"/code/services.yaml": `defaults: &defaults
+  privileged: true
+services:
+  web:
+    <<: *defaults
+    image: nginx
+---
+services:
+  worker:
+    image: worker
+    privileged: true
+  api:
+    image: api
+`,
+		"/rules/rule.rego": `# METADATA
+# title: Privileged services
+# custom:
+#   id: USR-YAML-0001
+#   avd_id: USR-YAML-0001
+#   severity: HIGH
+#   short_code: privileged
+#   input:
+#     selector:
+#     - type: yaml
+package user.yaml.privileged
+
+import rego.v1
+
+deny contains res if {
+	some name, service in input.services
+	service.privileged
+	res := result.new(sprintf("Service %q is privileged", [name]), service)
+}
+`,
+	})
+
+	scanner := generic.NewYamlScanner(
+		rego.WithPolicyDirs("rules"),
+		rego.WithPolicyNamespaces("user"),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 2)
+
+	lines := make([][2]int, 0, len(failed))
+	for _, res := range failed {
+		rng := res.Range()
+		assert.Equal(t, "code/services.yaml", rng.GetFilename())
+		lines = append(lines, [2]int{rng.GetStartLine(), rng.GetEndLine()})
+	}
+	assert.ElementsMatch(t, [][2]int{{5, 6}, {10, 11}}, lines)
+}
+
+func TestYamlScanner_MaxFileSize(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"/code/small.yaml": "privileged: true\n",
+		"/code/large.yaml": "privileged: true\ndescription: " + strings.Repeat("x", 100) + "\n",
+		"/rules/rule.rego": `# METADATA
+# title: Privileged
+# custom:
+#   id: USR-YAML-0001
+#   avd_id: USR-YAML-0001
+#   severity: HIGH
+#   short_code: privileged
+#   input:
+#     selector:
+#     - type: yaml
+package user.yaml.privileged
+
+import rego.v1
+
+deny contains res if {
+	input.privileged
+	res := result.new("Privileged", input)
+}
+`,
+	})
+
+	scanner := generic.NewYamlScanner(
+		rego.WithPolicyDirs("rules"),
+		rego.WithPolicyNamespaces("user"),
+		options.ScannerWithMaxFileSize(64),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "code/small.yaml", failed[0].Range().GetFilename())
+}
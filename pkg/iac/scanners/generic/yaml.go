@@ -0,0 +1,177 @@
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const mergeTag = "!!merge"
+
+// parseYaml parses the documents of the file into values with fully expanded aliases and merge keys.
+// Objects have the location of their node in the file, so that the results of checks refer to the document
+// and the lines they are found in. The values of aliases have the location of the alias.
+func parseYaml(_ context.Context, r io.Reader, path string) (any, error) {
+	decoder := yaml.NewDecoder(r)
+
+	var docs []any
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Decoding the document detects recursive aliases and excessive aliasing, before they are expanded
+		var v any
+		if err := doc.Decode(&v); err != nil {
+			return nil, err
+		}
+
+		val, err := yamlConverter{path: path}.convert(&doc, nil)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, val)
+	}
+	return docs, nil
+}
+
+type lineRange struct {
+	start, end int
+}
+
+type yamlConverter struct {
+	path string
+}
+
+// convert converts the node into a value. The location of the objects is site if set,
+// which is the location of the alias whose value is being converted.
+func (c yamlConverter) convert(node *yaml.Node, site *lineRange) (any, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return c.convert(node.Content[0], site)
+	case yaml.AliasNode:
+		if site == nil {
+			site = &lineRange{start: node.Line, end: node.Line}
+		}
+		return c.convert(node.Alias, site)
+	case yaml.MappingNode:
+		return c.convertMapping(node, site)
+	case yaml.SequenceNode:
+		list := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			val, err := c.convert(item, site)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, val)
+		}
+		return list, nil
+	case yaml.ScalarNode:
+		var val any
+		if err := node.Decode(&val); err != nil {
+			return nil, err
+		}
+		if t, ok := val.(time.Time); ok {
+			return t.Format(time.RFC3339), nil
+		}
+		return val, nil
+	}
+	return nil, fmt.Errorf("unsupported node kind %d at line %d", node.Kind, node.Line)
+}
+
+// convertMapping converts the mapping with the values of its merge keys.
+// Keys set explicitly override the merged ones, and earlier mappings in a merge sequence override later ones.
+func (c yamlConverter) convertMapping(node *yaml.Node, site *lineRange) (any, error) {
+	lines := lineRange{start: node.Line, end: endLine(node)}
+	if site != nil {
+		lines = *site
+	}
+	m := map[string]any{
+		"__defsec_metadata": map[string]any{
+			"filepath":  c.path,
+			"startline": lines.start,
+			"endline":   lines.end,
+		},
+	}
+
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := resolveAlias(node.Content[i]); key.Tag == mergeTag {
+			merged = append(merged, node.Content[i+1])
+		}
+	}
+	for i := len(merged) - 1; i >= 0; i-- {
+		if err := c.merge(m, merged[i], site); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := resolveAlias(node.Content[i])
+		if key.Tag == mergeTag {
+			continue
+		}
+		val, err := c.convert(node.Content[i+1], site)
+		if err != nil {
+			return nil, err
+		}
+		m[key.Value] = val
+	}
+	return m, nil
+}
+
+// merge sets the keys of the mapping, or of the sequence of mappings, of a merge key
+func (c yamlConverter) merge(m map[string]any, node *yaml.Node, site *lineRange) error {
+	if resolved := resolveAlias(node); resolved.Kind == yaml.SequenceNode {
+		for i := len(resolved.Content) - 1; i >= 0; i-- {
+			if err := c.merge(m, resolved.Content[i], site); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	val, err := c.convert(node, site)
+	if err != nil {
+		return err
+	}
+	src, ok := val.(map[string]any)
+	if !ok {
+		return fmt.Errorf("map merge requires a map or a sequence of maps as the value at line %d", node.Line)
+	}
+	for key, v := range src {
+		if key != "__defsec_metadata" {
+			m[key] = v
+		}
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// endLine returns the last line of the node, aliases take a single line
+func endLine(node *yaml.Node) int {
+	end := node.Line
+	if node.Kind == yaml.AliasNode {
+		return end
+	}
+	for _, child := range node.Content {
+		end = max(end, endLine(child))
+	}
+	return end
+}
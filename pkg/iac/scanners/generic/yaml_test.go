@@ -0,0 +1,148 @@
+package generic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func metadata(start, end int) map[string]any {
+	return map[string]any{
+		"filepath":  "test.yaml",
+		"startline": start,
+		"endline":   end,
+	}
+}
+
+func TestParseYaml(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected []any
+		wantErr  string
+	}{
+		{
+			name: "documents",
+			src: `first: 1
+---
+# comment
+second:
+  nested: true
+--- # comment after the marker
+third: [a, b]
+...
+`,
+			expected: []any{
+				map[string]any{
+					"__defsec_metadata": metadata(1, 1),
+					"first":             1,
+				},
+				map[string]any{
+					"__defsec_metadata": metadata(4, 5),
+					"second": map[string]any{
+						"__defsec_metadata": metadata(5, 5),
+						"nested":            true,
+					},
+				},
+				map[string]any{
+					"__defsec_metadata": metadata(7, 7),
+					"third":             []any{"a", "b"},
+				},
+			},
+		},
+		{
+			name: "aliases",
+			src: `defaults: &defaults
+  image: alpine
+  env:
+    DEBUG: "false"
+jobs:
+  build: *defaults
+  tags: &tags [a, b]
+  more: *tags
+`,
+			expected: []any{
+				map[string]any{
+					"__defsec_metadata": metadata(1, 8),
+					"defaults": map[string]any{
+						"__defsec_metadata": metadata(1, 4),
+						"image":             "alpine",
+						"env": map[string]any{
+							"__defsec_metadata": metadata(4, 4),
+							"DEBUG":             "false",
+						},
+					},
+					"jobs": map[string]any{
+						"__defsec_metadata": metadata(6, 8),
+						"build": map[string]any{
+							"__defsec_metadata": metadata(6, 6),
+							"image":             "alpine",
+							"env": map[string]any{
+								"__defsec_metadata": metadata(6, 6),
+								"DEBUG":             "false",
+							},
+						},
+						"tags": []any{"a", "b"},
+						"more": []any{"a", "b"},
+					},
+				},
+			},
+		},
+		{
+			name: "merge keys",
+			src: `base: &base
+  image: alpine
+  retries: 1
+extra: &extra
+  retries: 2
+  timeout: 10
+job:
+  <<: [*extra, *base]
+  image: golang
+`,
+			expected: []any{
+				map[string]any{
+					"__defsec_metadata": metadata(1, 9),
+					"base": map[string]any{
+						"__defsec_metadata": metadata(1, 3),
+						"image":             "alpine",
+						"retries":           1,
+					},
+					"extra": map[string]any{
+						"__defsec_metadata": metadata(4, 6),
+						"retries":           2,
+						"timeout":           10,
+					},
+					"job": map[string]any{
+						"__defsec_metadata": metadata(8, 9),
+						"image":             "golang",
+						"retries":           2,
+						"timeout":           10,
+					},
+				},
+			},
+		},
+		{
+			name: "recursive alias",
+			src: `a: &a
+  b: *a
+`,
+			wantErr: "contains itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYaml(context.TODO(), strings.NewReader(tt.src), "test.yaml")
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
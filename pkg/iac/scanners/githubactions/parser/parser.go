@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+// IsWorkflow checks if the YAML document is a GitHub Actions workflow, i.e. it has the "on" and "jobs" keys
+func IsWorkflow(r io.Reader) bool {
+	var workflow map[string]any
+	if err := yaml.NewDecoder(r).Decode(&workflow); err != nil {
+		return false
+	}
+	_, hasOn := workflow["on"]
+	jobs, hasJobs := workflow["jobs"].(map[string]any)
+	return hasOn && hasJobs && len(jobs) > 0
+}
+
+// Parse parses the GitHub Actions workflow
+func Parse(_ context.Context, r io.Reader, filePath string) (any, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow: %w", err)
+	}
+	root := yamlnode.Resolve(&doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, errors.New("workflow must be a mapping")
+	}
+	return parseWorkflow(filePath, root), nil
+}
+
+func parseWorkflow(filePath string, node *yaml.Node) *Workflow {
+	workflow := &Workflow{
+		Metadata:    yamlnode.NewMetadata(filePath, node),
+		Name:        yamlnode.String(yamlnode.Lookup(node, "name")),
+		On:          events(yamlnode.Lookup(node, "on")),
+		Permissions: parsePermissions(filePath, yamlnode.Lookup(node, "permissions")),
+		Env:         yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "env"))),
+	}
+	for _, entry := range yamlnode.Entries(yamlnode.Lookup(node, "jobs")) {
+		workflow.Jobs = append(workflow.Jobs, parseJob(filePath, entry))
+	}
+	return workflow
+}
+
+// events normalizes the triggers of the workflow into a map of the events and their configurations,
+// e.g. "on: push" and "on: [push]" into {"push": nil}
+func events(node *yaml.Node) map[string]any {
+	node = yamlnode.Resolve(node)
+	if node == nil {
+		return map[string]any{}
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return map[string]any{node.Value: nil}
+	case yaml.SequenceNode:
+		m := make(map[string]any)
+		for _, item := range yamlnode.Items(node) {
+			if event := yamlnode.String(item); event != "" {
+				m[event] = nil
+			}
+		}
+		return m
+	}
+	return yamlnode.ToMap(yamlnode.Value(node))
+}
+
+func parseJob(filePath string, entry yamlnode.Entry) *Job {
+	node := yamlnode.Resolve(entry.Value)
+	job := &Job{
+		Metadata:    yamlnode.NewMetadata(filePath, entry.KeyNode, node),
+		ID:          entry.Key,
+		Name:        yamlnode.String(yamlnode.Lookup(node, "name")),
+		If:          yamlnode.String(yamlnode.Lookup(node, "if")),
+		RunsOn:      yamlnode.Value(yamlnode.Lookup(node, "runs-on")),
+		Permissions: parsePermissions(filePath, yamlnode.Lookup(node, "permissions")),
+		Uses:        yamlnode.String(yamlnode.Lookup(node, "uses")),
+		With:        yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "with"))),
+		Env:         yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "env"))),
+	}
+	for _, item := range yamlnode.Items(yamlnode.Lookup(node, "steps")) {
+		job.Steps = append(job.Steps, parseStep(filePath, item))
+	}
+	return job
+}
+
+func parseStep(filePath string, node *yaml.Node) *Step {
+	node = yamlnode.Resolve(node)
+	return &Step{
+		Metadata: yamlnode.NewMetadata(filePath, node),
+		ID:       yamlnode.String(yamlnode.Lookup(node, "id")),
+		Name:     yamlnode.String(yamlnode.Lookup(node, "name")),
+		If:       yamlnode.String(yamlnode.Lookup(node, "if")),
+		Uses:     yamlnode.String(yamlnode.Lookup(node, "uses")),
+		Run:      yamlnode.String(yamlnode.Lookup(node, "run")),
+		Shell:    yamlnode.String(yamlnode.Lookup(node, "shell")),
+		With:     yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "with"))),
+		Env:      yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(node, "env"))),
+	}
+}
+
+// parsePermissions returns the permissions of the GITHUB_TOKEN, or nil if they are not set
+func parsePermissions(filePath string, node *yaml.Node) *Permissions {
+	node = yamlnode.Resolve(node)
+	if node == nil {
+		return nil
+	}
+
+	permissions := &Permissions{
+		Metadata: yamlnode.NewMetadata(filePath, node),
+		Scopes:   make(map[string]string),
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		permissions.All = strings.ToLower(node.Value)
+	case yaml.MappingNode:
+		for _, entry := range yamlnode.Entries(node) {
+			permissions.Scopes[entry.Key] = strings.ToLower(yamlnode.String(entry.Value))
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+func TestIsWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name: "workflow",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+`,
+			want: true,
+		},
+		{
+			name: "composite action",
+			content: `name: Setup
+runs:
+  using: composite
+  steps: []
+`,
+			want: false,
+		},
+		{
+			name:    "invalid",
+			content: `on: [`,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsWorkflow(strings.NewReader(tt.content)))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	src := `name: CI
+on: [push, pull_request]
+permissions:
+  contents: Read
+jobs:
+  build:
+    runs-on: [self-hosted, linux]
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - run: |
+          make
+          make test
+  release:
+    permissions: write-all
+    uses: org/repo/.github/workflows/release.yml@main
+    with:
+      version: 1
+`
+	got, err := Parse(context.TODO(), strings.NewReader(src), ".github/workflows/ci.yml")
+	require.NoError(t, err)
+
+	want := &Workflow{
+		Metadata: yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 1, EndLine: 20},
+		Name:     "CI",
+		On: map[string]any{
+			"push":         nil,
+			"pull_request": nil,
+		},
+		Permissions: &Permissions{
+			Metadata: yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 4, EndLine: 4},
+			Scopes:   map[string]string{"contents": "read"},
+		},
+		Env: map[string]any{},
+		Jobs: []*Job{
+			{
+				Metadata:    yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 6, EndLine: 15},
+				ID:          "build",
+				RunsOn:      []any{"self-hosted", "linux"},
+				Permissions: nil,
+				With:        map[string]any{},
+				Env:         map[string]any{},
+				Steps: []*Step{
+					{
+						Metadata: yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 9, EndLine: 12},
+						Name:     "Checkout",
+						Uses:     "actions/checkout@v4",
+						With:     map[string]any{"fetch-depth": 0},
+						Env:      map[string]any{},
+					},
+					{
+						Metadata: yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 13, EndLine: 15},
+						Run:      "make\nmake test\n",
+						With:     map[string]any{},
+						Env:      map[string]any{},
+					},
+				},
+			},
+			{
+				Metadata: yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 16, EndLine: 20},
+				ID:       "release",
+				Permissions: &Permissions{
+					Metadata: yamlnode.Metadata{FilePath: ".github/workflows/ci.yml", StartLine: 17, EndLine: 17},
+					All:      "write-all",
+					Scopes:   map[string]string{},
+				},
+				Uses: "org/repo/.github/workflows/release.yml@main",
+				With: map[string]any{"version": 1},
+				Env:  map[string]any{},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+}
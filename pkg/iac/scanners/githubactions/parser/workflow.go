@@ -0,0 +1,113 @@
+package parser
+
+import "github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+
+type Workflow struct {
+	Metadata yamlnode.Metadata
+	Name     string
+	// On are the events triggering the workflow and their configurations
+	On map[string]any
+	// Permissions are the default permissions of the GITHUB_TOKEN for the jobs, or nil if not set
+	Permissions *Permissions
+	Env         map[string]any
+	Jobs        []*Job
+}
+
+type Job struct {
+	Metadata    yamlnode.Metadata
+	ID          string
+	Name        string
+	If          string
+	RunsOn      any
+	Permissions *Permissions
+	// Uses is the reusable workflow called by the job
+	Uses  string
+	With  map[string]any
+	Env   map[string]any
+	Steps []*Step
+}
+
+type Step struct {
+	Metadata yamlnode.Metadata
+	ID       string
+	Name     string
+	If       string
+	// Uses is the action run by the step
+	Uses  string
+	Run   string
+	Shell string
+	With  map[string]any
+	Env   map[string]any
+}
+
+// Permissions are the permissions granted to the GITHUB_TOKEN
+type Permissions struct {
+	Metadata yamlnode.Metadata
+	// All is "read-all" or "write-all" if the permissions are set for all the scopes at once
+	All string
+	// Scopes are the access levels of the scopes, e.g. {"contents": "read"}
+	Scopes map[string]string
+}
+
+func (w *Workflow) ToRego() any {
+	return map[string]any{
+		"name":              w.Name,
+		"on":                w.On,
+		"permissions":       w.Permissions.ToRego(),
+		"env":               w.Env,
+		"jobs":              toRegoSlice(w.Jobs),
+		"__defsec_metadata": w.Metadata.ToRego(),
+	}
+}
+
+func (j *Job) ToRego() any {
+	return map[string]any{
+		"id":                j.ID,
+		"name":              j.Name,
+		"if":                j.If,
+		"runs-on":           j.RunsOn,
+		"permissions":       j.Permissions.ToRego(),
+		"uses":              j.Uses,
+		"with":              j.With,
+		"env":               j.Env,
+		"steps":             toRegoSlice(j.Steps),
+		"__defsec_metadata": j.Metadata.ToRego(),
+	}
+}
+
+func (s *Step) ToRego() any {
+	return map[string]any{
+		"id":                s.ID,
+		"name":              s.Name,
+		"if":                s.If,
+		"uses":              s.Uses,
+		"run":               s.Run,
+		"shell":             s.Shell,
+		"with":              s.With,
+		"env":               s.Env,
+		"__defsec_metadata": s.Metadata.ToRego(),
+	}
+}
+
+func (p *Permissions) ToRego() any {
+	if p == nil {
+		return nil
+	}
+	scopes := make(map[string]any, len(p.Scopes))
+	for scope, access := range p.Scopes {
+		scopes[scope] = access
+	}
+	return map[string]any{
+		"all":               p.All,
+		"scopes":            scopes,
+		"__defsec_metadata": p.Metadata.ToRego(),
+	}
+}
+
+func toRegoSlice[T interface{ ToRego() any }](elems []T) []any {
+	result := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		result = append(result, elem.ToRego())
+	}
+	return result
+}
@@ -0,0 +1,14 @@
+package githubactions
+
+import (
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/githubactions/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+// NewScanner returns a scanner of GitHub Actions workflows.
+// Each workflow is passed to checks as an input.
+func NewScanner(opts ...options.ScannerOption) *generic.GenericScanner {
+	return generic.NewScanner("GitHub Actions", types.SourceGitHubActions, generic.ParseFunc(parser.Parse), opts...)
+}
@@ -0,0 +1,53 @@
+package githubactions_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/githubactions"
+)
+
+func TestScanner_ScanFS(t *testing.T) {
+	// The check embedded in the GitHub Actions analyzer
+	check, err := os.Open("../../../fanal/analyzer/config/githubactions/checks/unpinned_actions.rego")
+	require.NoError(t, err)
+	defer check.Close()
+
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/.github/workflows/ci.yml": `on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: golangci/golangci-lint-action@v6
+      # trivy:ignore:GHA001
+      - uses: goreleaser/goreleaser-action@v6
+`,
+	})
+
+	scanner := githubactions.NewScanner(
+		rego.WithEmbeddedPolicies(false),
+		rego.WithEmbeddedLibraries(true),
+		rego.WithPolicyReader(check),
+		rego.WithPolicyNamespaces("builtin"),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "AVD-GHA-0001", failed[0].Rule().AVDID)
+	assert.Equal(t, `Action "golangci/golangci-lint-action@v6" used in job "test" is not pinned to an immutable reference`, failed[0].Description())
+	assert.Equal(t, "code/.github/workflows/ci.yml", failed[0].Metadata().Range().GetFilename())
+	assert.Equal(t, 7, failed[0].Metadata().Range().GetStartLine())
+
+	assert.Len(t, results.GetIgnored(), 1)
+}
@@ -0,0 +1,284 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+const (
+	// defaultStage is the stage of the jobs which do not set one
+	defaultStage = "test"
+
+	// maxExtendsDepth is the maximum number of nested "extends" allowed by GitLab
+	maxExtendsDepth = 11
+	// maxReferenceDepth is the maximum number of nested "!reference" tags allowed by GitLab
+	maxReferenceDepth = 10
+)
+
+var (
+	// reservedKeywords are the global keywords which are not jobs
+	reservedKeywords = []string{
+		"after_script", "before_script", "cache", "default", "image", "include",
+		"services", "spec", "stages", "types", "variables", "workflow",
+	}
+	// defaultKeywords are the keywords of jobs which can be set in the "default" section
+	defaultKeywords = []string{
+		"after_script", "artifacts", "before_script", "cache", "hooks", "id_tokens",
+		"image", "interruptible", "retry", "services", "tags", "timeout",
+	}
+	// legacyGlobalKeywords are the global keywords deprecated in favor of the "default" section
+	legacyGlobalKeywords = []string{"after_script", "before_script", "cache", "image", "services"}
+)
+
+// jobConfig is the configuration of a job, or of a hidden job, as defined in the files of the pipeline
+type jobConfig struct {
+	metadata yamlnode.Metadata
+	value    map[string]any
+}
+
+// config is the configuration of a file merged with the files it includes
+type config struct {
+	globals map[string]any
+	jobs    map[string]*jobConfig
+	// order is the order the jobs are defined in
+	order    []string
+	includes []*Include
+}
+
+func newConfig() *config {
+	return &config{
+		globals: make(map[string]any),
+		jobs:    make(map[string]*jobConfig),
+	}
+}
+
+// merge merges the other configuration into the configuration.
+// The other configuration takes precedence, as the configuration of a file takes precedence over the files it includes.
+func (c *config) merge(other *config) {
+	c.globals = deepMerge(c.globals, other.globals)
+	for _, name := range other.order {
+		job := other.jobs[name]
+		if existing, ok := c.jobs[name]; ok {
+			c.jobs[name] = &jobConfig{
+				metadata: job.metadata,
+				value:    deepMerge(existing.value, job.value),
+			}
+			continue
+		}
+		c.jobs[name] = job
+		c.order = append(c.order, name)
+	}
+	c.includes = append(c.includes, other.includes...)
+}
+
+// deepMerge returns the maps merged recursively. The values of src take precedence and lists are not merged.
+func deepMerge(dst, src map[string]any) map[string]any {
+	result := maps.Clone(dst)
+	if result == nil {
+		result = make(map[string]any)
+	}
+	for k, v := range src {
+		if s, ok := v.(map[string]any); ok {
+			if d, ok := result[k].(map[string]any); ok {
+				result[k] = deepMerge(d, s)
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// extended returns the configuration of the job merged with the configurations of the jobs it extends
+func (c *config) extended(name string, chain []string) map[string]any {
+	job, ok := c.jobs[name]
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]any)
+	for _, parent := range stringList(job.value["extends"]) {
+		if slices.Contains(chain, parent) || len(chain) >= maxExtendsDepth {
+			continue
+		}
+		result = deepMerge(result, c.extended(parent, append(chain, parent)))
+	}
+	return deepMerge(result, job.value)
+}
+
+// defaults returns the keywords set for all the jobs
+func (c *config) defaults() map[string]any {
+	defaults := maps.Clone(yamlnode.ToMap(c.globals["default"]))
+	for _, key := range legacyGlobalKeywords {
+		if v, ok := c.globals[key]; ok {
+			if _, exists := defaults[key]; !exists {
+				defaults[key] = v
+			}
+		}
+	}
+	return defaults
+}
+
+// resolveReferences replaces the "!reference" tags with the configuration they refer to
+func (c *config) resolveReferences(v any, depth int) any {
+	switch v := v.(type) {
+	case reference:
+		if len(v) == 0 || depth >= maxReferenceDepth {
+			return nil
+		}
+		var target any = c.extended(v[0], []string{v[0]})
+		for _, key := range v[1:] {
+			target = yamlnode.ToMap(target)[key]
+		}
+		return c.resolveReferences(target, depth+1)
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, elem := range v {
+			m[key] = c.resolveReferences(elem, depth)
+		}
+		return m
+	case []any:
+		s := make([]any, 0, len(v))
+		for _, elem := range v {
+			s = append(s, c.resolveReferences(elem, depth))
+		}
+		return s
+	}
+	return v
+}
+
+// job returns the job with its extended configuration and the default keywords it inherits
+func (c *config) job(name string, defaults map[string]any) *Job {
+	jc := c.jobs[name]
+	v := c.extended(name, []string{name})
+
+	inherited := inheritedDefaults(v, defaults)
+	for _, key := range defaultKeywords {
+		if _, ok := v[key]; ok {
+			continue
+		}
+		if d, ok := inherited[key]; ok {
+			v[key] = d
+		}
+	}
+	v = yamlnode.ToMap(c.resolveReferences(v, 0))
+
+	job := &Job{
+		Metadata:     jc.metadata,
+		Name:         name,
+		Stage:        stringValue(v["stage"]),
+		Image:        parseImage(jc.metadata, v["image"]),
+		Script:       scriptLines(v["script"]),
+		BeforeScript: scriptLines(v["before_script"]),
+		AfterScript:  scriptLines(v["after_script"]),
+		Variables:    yamlnode.ToMap(v["variables"]),
+		Rules:        toList(v["rules"]),
+		Only:         v["only"],
+		Except:       v["except"],
+		When:         stringValue(v["when"]),
+		Environment:  environmentName(v["environment"]),
+		Extends:      stringList(v["extends"]),
+		Tags:         stringList(v["tags"]),
+	}
+	if job.Stage == "" {
+		job.Stage = defaultStage
+	}
+	for _, service := range toList(v["services"]) {
+		if image := parseImage(jc.metadata, service); image != nil {
+			job.Services = append(job.Services, image)
+		}
+	}
+	return job
+}
+
+// inheritedDefaults returns the default keywords inherited by the job, as configured by "inherit:default"
+func inheritedDefaults(job, defaults map[string]any) map[string]any {
+	switch inherit := yamlnode.ToMap(job["inherit"])["default"].(type) {
+	case bool:
+		if !inherit {
+			return nil
+		}
+	case []any:
+		return lo.PickByKeys(defaults, stringList(inherit))
+	}
+	return defaults
+}
+
+// parseImage parses the image of a job or a service, which is either a name or a mapping with the name
+func parseImage(metadata yamlnode.Metadata, v any) *Image {
+	switch v := v.(type) {
+	case string:
+		return &Image{Metadata: metadata, Name: v}
+	case map[string]any:
+		return &Image{
+			Metadata:   metadata,
+			Name:       stringValue(v["name"]),
+			Alias:      stringValue(v["alias"]),
+			Entrypoint: stringList(v["entrypoint"]),
+			Command:    stringList(v["command"]),
+		}
+	}
+	return nil
+}
+
+// environmentName returns the name of the environment, which is either a name or a mapping with the name
+func environmentName(v any) string {
+	if m, ok := v.(map[string]any); ok {
+		return stringValue(m["name"])
+	}
+	return stringValue(v)
+}
+
+// scriptLines returns the lines of a script. Nested lists, e.g. of referenced scripts, are flattened.
+func scriptLines(v any) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		var lines []string
+		for _, elem := range v {
+			lines = append(lines, scriptLines(elem)...)
+		}
+		return lines
+	}
+	return []string{stringValue(v)}
+}
+
+// stringList returns the strings of a value which is either a string or a list of strings
+func stringList(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var result []string
+		for _, elem := range v {
+			if s, ok := elem.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(v)
+}
+
+func toList(v any) []any {
+	if l, ok := v.([]any); ok {
+		return l
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// maxIncludeDepth limits nested includes, e.g. files including themselves. GitLab allows 150 nested includes.
+const maxIncludeDepth = 150
+
+// IsPipelineFile checks if the file is the pipeline configuration of a project, i.e. ".gitlab-ci.yml"
+func IsPipelineFile(filePath string) bool {
+	name := path.Base(filepath.ToSlash(filePath))
+	return name == ".gitlab-ci.yml" || name == ".gitlab-ci.yaml"
+}
+
+// IsPipelineConfig checks if the YAML document is a pipeline configuration, such as the files included by
+// ".gitlab-ci.yml". The configuration must define the stages or a job with a script.
+func IsPipelineConfig(r io.Reader) bool {
+	decoder := yaml.NewDecoder(r)
+	for {
+		var config map[string]any
+		if err := decoder.Decode(&config); err != nil {
+			return false
+		}
+
+		if stages, ok := config["stages"].([]any); ok && len(stages) > 0 && len(stringList(stages)) == len(stages) {
+			return true
+		}
+		for name, v := range config {
+			if slices.Contains(reservedKeywords, name) {
+				continue
+			}
+			if job, ok := v.(map[string]any); ok {
+				if _, ok := job["script"]; ok {
+					return true
+				}
+			}
+		}
+	}
+}
+
+// Parser parses the pipeline configurations in a directory.
+// Local includes are resolved from the directory of ".gitlab-ci.yml", which is the root of the project.
+// Remote, project, template and component includes are not fetched.
+type Parser struct {
+	fsys   fs.FS
+	logger *log.Logger
+
+	// included are the files included by other files
+	included map[string]struct{}
+}
+
+func New(fsys fs.FS) *Parser {
+	return &Parser{
+		fsys:     fsys,
+		logger:   log.WithPrefix("gitlab-ci parser"),
+		included: make(map[string]struct{}),
+	}
+}
+
+// ParseFS parses the pipelines of the ".gitlab-ci.yml" files in the directory.
+// Other pipeline configurations are parsed on their own unless they are included by other files.
+func (p *Parser) ParseFS(ctx context.Context, dir string) ([]*Pipeline, error) {
+	var roots, others []string
+	if err := fs.WalkDir(p.fsys, dir, func(filePath string, d fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch {
+		case IsPipelineFile(filePath):
+			roots = append(roots, filePath)
+		case path.Ext(filePath) == ".yml" || path.Ext(filePath) == ".yaml":
+			others = append(others, filePath)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var pipelines []*Pipeline
+	for _, filePath := range roots {
+		pipeline, err := p.parsePipeline(filePath, path.Dir(filePath))
+		if err != nil {
+			p.logger.Error("Failed to parse pipeline", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+
+	var standalone []*Pipeline
+	for _, filePath := range others {
+		if !p.isPipelineConfig(filePath) {
+			continue
+		}
+		pipeline, err := p.parsePipeline(filePath, dir)
+		if err != nil {
+			p.logger.Debug("Failed to parse pipeline configuration", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		standalone = append(standalone, pipeline)
+	}
+	for _, pipeline := range standalone {
+		if _, ok := p.included[pipeline.Metadata.FilePath]; !ok {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+	return pipelines, nil
+}
+
+func (p *Parser) isPipelineConfig(filePath string) bool {
+	f, err := p.fsys.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	return IsPipelineConfig(f)
+}
+
+// parsePipeline parses the pipeline configuration with the files it includes
+func (p *Parser) parsePipeline(filePath, projectDir string) (*Pipeline, error) {
+	node, err := p.parseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	cfg := p.load(filePath, node, projectDir, []string{filePath})
+
+	pipeline := &Pipeline{
+		Metadata:  yamlnode.NewMetadata(filePath, node),
+		Stages:    stringList(cfg.globals["stages"]),
+		Variables: yamlnode.ToMap(cfg.globals["variables"]),
+		Workflow:  yamlnode.ToMap(cfg.globals["workflow"]),
+		Includes:  cfg.includes,
+	}
+
+	defaults := cfg.defaults()
+	for _, name := range cfg.order {
+		// Hidden jobs are templates for other jobs
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		pipeline.Jobs = append(pipeline.Jobs, cfg.job(name, defaults))
+	}
+	return pipeline, nil
+}
+
+// parseFile returns the configuration of the file. The header of the files with inputs is skipped.
+func (p *Parser) parseFile(filePath string) (*yaml.Node, error) {
+	f, err := p.fsys.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var root *yaml.Node
+	decoder := yaml.NewDecoder(f)
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode configuration: %w", err)
+		}
+		root = yamlnode.Resolve(&doc)
+	}
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, errors.New("configuration must be a mapping")
+	}
+	return root, nil
+}
+
+// load returns the configuration of the file merged with the files it includes
+func (p *Parser) load(filePath string, node *yaml.Node, projectDir string, chain []string) *config {
+	cfg := newConfig()
+	for _, include := range parseIncludes(filePath, node) {
+		cfg.includes = append(cfg.includes, include)
+		if include.Type != "local" {
+			continue
+		}
+
+		for _, includePath := range p.localFiles(projectDir, include.Location) {
+			if slices.Contains(chain, includePath) || len(chain) >= maxIncludeDepth {
+				p.logger.Warn("Includes are too deep or recursive", log.FilePath(includePath))
+				continue
+			}
+			child, err := p.parseFile(includePath)
+			if err != nil {
+				p.logger.Error("Failed to parse included file", log.FilePath(includePath), log.Err(err))
+				continue
+			}
+			p.logger.Debug("Include resolved", log.FilePath(filePath), log.String("include", includePath))
+
+			p.included[includePath] = struct{}{}
+			include.Resolved = true
+			cfg.merge(p.load(includePath, child, projectDir, append(chain, includePath)))
+		}
+	}
+
+	own := newConfig()
+	for _, entry := range yamlnode.Entries(node) {
+		switch {
+		case entry.Key == "include":
+			continue
+		case slices.Contains(reservedKeywords, entry.Key):
+			own.globals[entry.Key] = value(entry.Value)
+		default:
+			v, ok := value(entry.Value).(map[string]any)
+			if !ok {
+				continue
+			}
+			if _, exists := own.jobs[entry.Key]; !exists {
+				own.order = append(own.order, entry.Key)
+			}
+			own.jobs[entry.Key] = &jobConfig{
+				metadata: yamlnode.NewMetadata(filePath, entry.KeyNode, entry.Value),
+				value:    v,
+			}
+		}
+	}
+	cfg.merge(own)
+	return cfg
+}
+
+// localFiles returns the files matching the path of a local include, which is relative to the root of the project
+// and may contain wildcards
+func (p *Parser) localFiles(projectDir, location string) []string {
+	pattern := path.Join(projectDir, strings.TrimPrefix(location, "/"))
+	if !strings.Contains(pattern, "*") {
+		if _, err := fs.Stat(p.fsys, pattern); err != nil {
+			p.logger.Debug("Included file not found", log.FilePath(pattern))
+			return nil
+		}
+		return []string{pattern}
+	}
+
+	matches, err := doublestar.Glob(p.fsys, pattern, doublestar.WithFilesOnly())
+	if err != nil {
+		p.logger.Debug("Invalid include pattern", log.String("pattern", pattern), log.Err(err))
+		return nil
+	}
+	slices.Sort(matches)
+	return slices.DeleteFunc(matches, func(match string) bool {
+		return path.Ext(match) != ".yml" && path.Ext(match) != ".yaml"
+	})
+}
+
+// parseIncludes returns the includes of the configuration, which are either a single include or a list of them
+func parseIncludes(filePath string, node *yaml.Node) []*Include {
+	var includeNode *yaml.Node
+	for _, entry := range yamlnode.Entries(node) {
+		if entry.Key == "include" {
+			includeNode = yamlnode.Resolve(entry.Value)
+		}
+	}
+	if includeNode == nil {
+		return nil
+	}
+
+	nodes := []*yaml.Node{includeNode}
+	if includeNode.Kind == yaml.SequenceNode {
+		nodes = includeNode.Content
+	}
+
+	var includes []*Include
+	for _, n := range nodes {
+		includes = append(includes, parseInclude(yamlnode.NewMetadata(filePath, n), value(n))...)
+	}
+	return includes
+}
+
+func parseInclude(metadata yamlnode.Metadata, v any) []*Include {
+	if location, ok := v.(string); ok {
+		include := &Include{Metadata: metadata, Type: "local", Location: location}
+		if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+			include.Type = "remote"
+		}
+		return []*Include{include}
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	switch {
+	case m["local"] != nil:
+		return []*Include{{Metadata: metadata, Type: "local", Location: stringValue(m["local"])}}
+	case m["remote"] != nil:
+		return []*Include{{Metadata: metadata, Type: "remote", Location: stringValue(m["remote"])}}
+	case m["template"] != nil:
+		return []*Include{{Metadata: metadata, Type: "template", Location: stringValue(m["template"])}}
+	case m["component"] != nil:
+		location, version, _ := strings.Cut(stringValue(m["component"]), "@")
+		return []*Include{{Metadata: metadata, Type: "component", Location: location, Ref: version}}
+	case m["project"] != nil:
+		var includes []*Include
+		for _, file := range stringList(m["file"]) {
+			includes = append(includes, &Include{
+				Metadata: metadata,
+				Type:     "project",
+				Location: file,
+				Project:  stringValue(m["project"]),
+				Ref:      stringValue(m["ref"]),
+			})
+		}
+		return includes
+	}
+	return nil
+}
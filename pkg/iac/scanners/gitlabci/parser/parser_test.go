@@ -0,0 +1,279 @@
+package parser
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+func TestIsPipelineFile(t *testing.T) {
+	assert.True(t, IsPipelineFile(".gitlab-ci.yml"))
+	assert.True(t, IsPipelineFile("project/.gitlab-ci.yaml"))
+	assert.False(t, IsPipelineFile("ci/build.yml"))
+	assert.False(t, IsPipelineFile("gitlab-ci.yml"))
+}
+
+func TestIsPipelineConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name: "job",
+			content: `build:
+  script: make
+`,
+			want: true,
+		},
+		{
+			name: "hidden job",
+			content: `.deploy:
+  script:
+    - ./deploy.sh
+`,
+			want: true,
+		},
+		{
+			name: "stages",
+			content: `stages:
+  - build
+  - test
+`,
+			want: true,
+		},
+		{
+			name: "inputs",
+			content: `spec:
+  inputs:
+    stage:
+---
+scan:
+  stage: $[[ inputs.stage ]]
+  script: ./scan.sh
+`,
+			want: true,
+		},
+		{
+			name: "azure pipelines",
+			content: `stages:
+  - stage: Build
+    jobs:
+      - job: Build
+`,
+			want: false,
+		},
+		{
+			name: "github actions",
+			content: `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+			want: false,
+		},
+		{
+			name: "docker compose",
+			content: `services:
+  web:
+    image: nginx
+`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPipelineConfig(strings.NewReader(tt.content)))
+		})
+	}
+}
+
+func TestParser_ParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitlab-ci.yml": &fstest.MapFile{Data: []byte(`include:
+  - local: /ci/build.yml
+  - 'ci/jobs/*.yml'
+  - remote: https://example.com/ci.yml
+  - project: group/templates
+    ref: v1.0.0
+    file:
+      - /deploy.yml
+  - component: gitlab.com/components/sast/sast@2.0.0
+default:
+  image: alpine:3.20
+  before_script:
+    - echo default
+variables:
+  GIT_DEPTH: "10"
+build:
+  variables:
+    GOFLAGS: -mod=vendor
+`)},
+		"ci/build.yml": &fstest.MapFile{Data: []byte(`include: ci/templates.yml
+stages:
+  - build
+  - deploy
+build:
+  stage: build
+  image: golang:1.23
+  script:
+    - go build ./...
+`)},
+		"ci/templates.yml": &fstest.MapFile{Data: []byte(`include: ci/build.yml
+.deploy:
+  stage: deploy
+  services:
+    - docker:dind
+    - name: postgres:16
+      alias: db
+  environment:
+    name: production
+  script:
+    - ./deploy.sh
+.setup:
+  script:
+    - ./setup.sh
+`)},
+		"ci/jobs/deploy.yml": &fstest.MapFile{Data: []byte(`deploy:
+  extends: .deploy
+  inherit:
+    default: false
+  rules:
+    - if: $CI_COMMIT_BRANCH == "main"
+  script:
+    - !reference [.setup, script]
+    - ./deploy.sh
+`)},
+		"ci/jobs/README.md": &fstest.MapFile{Data: []byte(`# Jobs`)},
+		"templates/scan.yml": &fstest.MapFile{Data: []byte(`scan:
+  image:
+    name: scanner:latest
+    entrypoint: [""]
+  script: ./scan.sh
+`)},
+	}
+
+	pipelines, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+	require.Len(t, pipelines, 2)
+
+	pipeline := pipelines[0]
+	assert.Equal(t, yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 1, EndLine: 18}, pipeline.Metadata)
+	assert.Equal(t, []string{"build", "deploy"}, pipeline.Stages)
+	assert.Equal(t, map[string]any{"GIT_DEPTH": "10"}, pipeline.Variables)
+
+	var includes []Include
+	for _, include := range pipeline.Includes {
+		includes = append(includes, *include)
+	}
+	assert.Equal(t, []Include{
+		{
+			Metadata: yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 2, EndLine: 2},
+			Type:     "local",
+			Location: "/ci/build.yml",
+			Resolved: true,
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: "ci/build.yml", StartLine: 1, EndLine: 1},
+			Type:     "local",
+			Location: "ci/templates.yml",
+			Resolved: true,
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: "ci/templates.yml", StartLine: 1, EndLine: 1},
+			Type:     "local",
+			Location: "ci/build.yml",
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 3, EndLine: 3},
+			Type:     "local",
+			Location: "ci/jobs/*.yml",
+			Resolved: true,
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 4, EndLine: 4},
+			Type:     "remote",
+			Location: "https://example.com/ci.yml",
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 5, EndLine: 8},
+			Type:     "project",
+			Location: "/deploy.yml",
+			Project:  "group/templates",
+			Ref:      "v1.0.0",
+		},
+		{
+			Metadata: yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 9, EndLine: 9},
+			Type:     "component",
+			Location: "gitlab.com/components/sast/sast",
+			Ref:      "2.0.0",
+		},
+	}, includes)
+
+	require.Len(t, pipeline.Jobs, 2)
+
+	build := pipeline.Jobs[0]
+	assert.Equal(t, "build", build.Name)
+	// The job is defined in the included file and extended by the including file
+	assert.Equal(t, yamlnode.Metadata{FilePath: ".gitlab-ci.yml", StartLine: 16, EndLine: 18}, build.Metadata)
+	assert.Equal(t, "build", build.Stage)
+	assert.Equal(t, "golang:1.23", build.Image.Name)
+	assert.Equal(t, []string{"go build ./..."}, build.Script)
+	assert.Equal(t, []string{"echo default"}, build.BeforeScript)
+	assert.Equal(t, map[string]any{"GOFLAGS": "-mod=vendor"}, build.Variables)
+
+	deploy := pipeline.Jobs[1]
+	assert.Equal(t, "deploy", deploy.Name)
+	assert.Equal(t, yamlnode.Metadata{FilePath: "ci/jobs/deploy.yml", StartLine: 1, EndLine: 9}, deploy.Metadata)
+	assert.Equal(t, "deploy", deploy.Stage)
+	assert.Equal(t, "production", deploy.Environment)
+	assert.Equal(t, []string{".deploy"}, deploy.Extends)
+	assert.Nil(t, deploy.Image)
+	assert.Empty(t, deploy.BeforeScript)
+	assert.Equal(t, []string{"./setup.sh", "./deploy.sh"}, deploy.Script)
+	assert.Len(t, deploy.Rules, 1)
+	require.Len(t, deploy.Services, 2)
+	assert.Equal(t, "docker:dind", deploy.Services[0].Name)
+	assert.Equal(t, "postgres:16", deploy.Services[1].Name)
+	assert.Equal(t, "db", deploy.Services[1].Alias)
+
+	// Files which are not included are scanned on their own
+	scan := pipelines[1]
+	assert.Equal(t, "templates/scan.yml", scan.Metadata.FilePath)
+	require.Len(t, scan.Jobs, 1)
+	assert.Equal(t, "test", scan.Jobs[0].Stage)
+	assert.Equal(t, "scanner:latest", scan.Jobs[0].Image.Name)
+	assert.Equal(t, []string{""}, scan.Jobs[0].Image.Entrypoint)
+	assert.Equal(t, []string{"./scan.sh"}, scan.Jobs[0].Script)
+}
+
+func TestParser_ParseFS_RecursiveExtends(t *testing.T) {
+	fsys := fstest.MapFS{
+		".gitlab-ci.yml": &fstest.MapFile{Data: []byte(`.a:
+  extends: .b
+  image: a
+.b:
+  extends: .a
+  script: b
+job:
+  extends: .a
+`)},
+	}
+
+	pipelines, err := New(fsys).ParseFS(context.TODO(), ".")
+	require.NoError(t, err)
+	require.Len(t, pipelines, 1)
+	require.Len(t, pipelines[0].Jobs, 1)
+
+	job := pipelines[0].Jobs[0]
+	assert.Equal(t, "a", job.Image.Name)
+	assert.Equal(t, []string{"b"}, job.Script)
+}
@@ -0,0 +1,131 @@
+package parser
+
+import "github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+
+// Pipeline is the configuration of a pipeline merged with the files it includes
+type Pipeline struct {
+	Metadata  yamlnode.Metadata
+	Stages    []string
+	Variables map[string]any
+	Workflow  map[string]any
+	// Includes are all the includes of the pipeline, including the ones which are not resolved
+	Includes []*Include
+	// Jobs are the jobs of the pipeline, excluding hidden jobs, with their extended and default configuration
+	Jobs []*Job
+}
+
+// Include is a file included by the pipeline
+type Include struct {
+	Metadata yamlnode.Metadata
+	// Type is "local", "remote", "project", "template" or "component"
+	Type string
+	// Location is the path, the URL, the name of the template or the address of the component
+	Location string
+	// Project is the project of "project" includes
+	Project string
+	// Ref is the ref of "project" includes or the version of "component" includes
+	Ref string
+	// Resolved is true if the file is included in the scanned pipeline
+	Resolved bool
+}
+
+type Job struct {
+	Metadata     yamlnode.Metadata
+	Name         string
+	Stage        string
+	Image        *Image
+	Services     []*Image
+	Script       []string
+	BeforeScript []string
+	AfterScript  []string
+	Variables    map[string]any
+	Rules        []any
+	Only         any
+	Except       any
+	When         string
+	// Environment is the name of the environment the job deploys to
+	Environment string
+	Extends     []string
+	Tags        []string
+}
+
+// Image is the image of a job or a service
+type Image struct {
+	Metadata   yamlnode.Metadata
+	Name       string
+	Alias      string
+	Entrypoint []string
+	Command    []string
+}
+
+func (p *Pipeline) ToRego() any {
+	return map[string]any{
+		"stages":            toAnySlice(p.Stages),
+		"variables":         p.Variables,
+		"workflow":          p.Workflow,
+		"includes":          toRegoSlice(p.Includes),
+		"jobs":              toRegoSlice(p.Jobs),
+		"__defsec_metadata": p.Metadata.ToRego(),
+	}
+}
+
+func (i *Include) ToRego() any {
+	return map[string]any{
+		"type":              i.Type,
+		"location":          i.Location,
+		"project":           i.Project,
+		"ref":               i.Ref,
+		"resolved":          i.Resolved,
+		"__defsec_metadata": i.Metadata.ToRego(),
+	}
+}
+
+func (j *Job) ToRego() any {
+	return map[string]any{
+		"name":              j.Name,
+		"stage":             j.Stage,
+		"image":             j.Image.ToRego(),
+		"services":          toRegoSlice(j.Services),
+		"script":            toAnySlice(j.Script),
+		"before_script":     toAnySlice(j.BeforeScript),
+		"after_script":      toAnySlice(j.AfterScript),
+		"variables":         j.Variables,
+		"rules":             j.Rules,
+		"only":              j.Only,
+		"except":            j.Except,
+		"when":              j.When,
+		"environment":       j.Environment,
+		"extends":           toAnySlice(j.Extends),
+		"tags":              toAnySlice(j.Tags),
+		"__defsec_metadata": j.Metadata.ToRego(),
+	}
+}
+
+func (i *Image) ToRego() any {
+	if i == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":              i.Name,
+		"alias":             i.Alias,
+		"entrypoint":        toAnySlice(i.Entrypoint),
+		"command":           toAnySlice(i.Command),
+		"__defsec_metadata": i.Metadata.ToRego(),
+	}
+}
+
+func toRegoSlice[T interface{ ToRego() any }](elems []T) []any {
+	result := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		result = append(result, elem.ToRego())
+	}
+	return result
+}
+
+func toAnySlice(elems []string) []any {
+	result := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		result = append(result, elem)
+	}
+	return result
+}
@@ -0,0 +1,27 @@
+package parser
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+// referenceTag is the custom tag reusing the configuration of other jobs, e.g. "!reference [.setup, script]"
+const referenceTag = "!reference"
+
+// reference is the value of a "!reference" tag, i.e. the path of the reused configuration
+type reference []string
+
+// value converts the node into a value of the configuration, keeping "!reference" tags as references
+func value(node *yaml.Node) any {
+	return yamlnode.ValueWith(node, func(node *yaml.Node) (any, bool) {
+		if node.Kind != yaml.SequenceNode || node.Tag != referenceTag {
+			return nil, false
+		}
+		var ref reference
+		for _, item := range node.Content {
+			ref = append(ref, item.Value)
+		}
+		return ref, true
+	})
+}
@@ -0,0 +1,57 @@
+package gitlabci_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/gitlabci"
+)
+
+func TestScanner_ScanFS(t *testing.T) {
+	// The check embedded in the GitLab CI analyzer
+	check, err := os.Open("../../../fanal/analyzer/config/gitlabci/checks/unpinned_images.rego")
+	require.NoError(t, err)
+	defer check.Close()
+
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/.gitlab-ci.yml": `include:
+  - local: ci/build.yml
+
+# trivy:ignore:GLC003
+test:
+  image: golang
+  script: go test ./...
+`,
+		"code/ci/build.yml": `build:
+  image: golang:latest
+  script: go build ./...
+`,
+	})
+
+	scanner := gitlabci.NewScanner(
+		rego.WithEmbeddedPolicies(false),
+		rego.WithEmbeddedLibraries(true),
+		rego.WithPolicyReader(check),
+		rego.WithPolicyNamespaces("builtin"),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	// The job in the included file is checked as a part of the pipeline
+	failed := results.GetFailed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "AVD-GLC-0003", failed[0].Rule().AVDID)
+	assert.Equal(t, `Image "golang:latest" of job "build" is not pinned to a version`, failed[0].Description())
+	assert.Equal(t, "code/ci/build.yml", failed[0].Metadata().Range().GetFilename())
+	assert.Equal(t, 1, failed[0].Metadata().Range().GetStartLine())
+	assert.Equal(t, 3, failed[0].Metadata().Range().GetEndLine())
+
+	assert.Len(t, results.GetIgnored(), 1)
+}
@@ -52,3 +52,11 @@ func ScannerWithKubeVersion(values string) options.ScannerOption {
 		}
 	}
 }
+
+func ScannerWithDependencyUpdate(value bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if helmScanner, ok := s.(*Scanner); ok {
+			helmScanner.addParserOptions(parser.OptionWithDependencyUpdate(value))
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// fetchDependencies fetches the dependencies declared in Chart.yaml which are not vendored in the charts directory
+// from their repositories, i.e. chart repositories and OCI registries, and adds them to the chart.
+func (p *Parser) fetchDependencies(c *chart.Chart) error {
+	for _, dep := range c.Metadata.Dependencies {
+		if hasDependency(c, dep.Name) {
+			continue
+		}
+
+		p.logger.Debug("Fetching chart dependency", log.String("name", dep.Name),
+			log.String("version", dep.Version), log.String("repository", dep.Repository))
+
+		var (
+			data []byte
+			err  error
+		)
+		switch {
+		case registry.IsOCI(dep.Repository):
+			data, err = p.pullOCIChart(dep)
+		case strings.HasPrefix(dep.Repository, "http://"), strings.HasPrefix(dep.Repository, "https://"):
+			data, err = fetchRepoChart(dep)
+		default:
+			// Local charts and repositories referred to by their names in the Helm configuration are not supported
+			return fmt.Errorf("unable to fetch dependency %q: unsupported repository %q", dep.Name, dep.Repository)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to fetch dependency %q: %w", dep.Name, err)
+		}
+
+		subchart, err := loader.LoadArchive(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("unable to load dependency %q: %w", dep.Name, err)
+		}
+		c.AddDependency(subchart)
+	}
+	return nil
+}
+
+func hasDependency(c *chart.Chart, name string) bool {
+	for _, d := range c.Dependencies() {
+		if d.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchRepoChart downloads the chart matching the version constraint from the index of the chart repository
+func fetchRepoChart(dep *chart.Dependency) ([]byte, error) {
+	g, err := getter.NewHTTPGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	repoURL := strings.TrimSuffix(dep.Repository, "/")
+	b, err := g.Get(repoURL + "/index.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("unable to download repository index: %w", err)
+	}
+
+	var index repo.IndexFile
+	if err := yaml.Unmarshal(b.Bytes(), &index); err != nil {
+		return nil, fmt.Errorf("invalid repository index: %w", err)
+	}
+	index.SortEntries()
+
+	cv, err := index.Get(dep.Name, dep.Version)
+	if err != nil {
+		return nil, fmt.Errorf("version %q not found in %s: %w", dep.Version, repoURL, err)
+	} else if len(cv.URLs) == 0 {
+		return nil, fmt.Errorf("version %q has no downloadable URLs", cv.Version)
+	}
+
+	chartURL, err := repo.ResolveReferenceURL(repoURL, cv.URLs[0])
+	if err != nil {
+		return nil, err
+	}
+	b, err = g.Get(chartURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download chart: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// pullOCIChart pulls the chart with the highest tag matching the version constraint from the OCI registry
+func (p *Parser) pullOCIChart(dep *chart.Dependency) ([]byte, error) {
+	if p.registryClient == nil {
+		client, err := registry.NewClient(registry.ClientOptEnableCache(true))
+		if err != nil {
+			return nil, err
+		}
+		p.registryClient = client
+	}
+
+	ref := strings.TrimSuffix(strings.TrimPrefix(dep.Repository, registry.OCIScheme+"://"), "/") + "/" + dep.Name
+	tags, err := p.registryClient.Tags(ref)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := registry.GetTagMatchingVersionOrConstraint(tags, dep.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.registryClient.Pull(ref+":"+tag, registry.PullOptWithChart(true))
+	if err != nil {
+		return nil, err
+	}
+	return res.Chart.Data, nil
+}
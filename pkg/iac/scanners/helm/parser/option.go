@@ -7,6 +7,7 @@ type ConfigurableHelmParser interface {
 	SetStringValues(...string)
 	SetAPIVersions(...string)
 	SetKubeVersion(string)
+	SetDependencyUpdate(bool)
 }
 
 type Option func(p *Parser)
@@ -46,3 +47,30 @@ func OptionWithKubeVersion(value string) Option {
 		p.kubeVersion = value
 	}
 }
+
+func OptionWithDependencyUpdate(value bool) Option {
+	return func(p *Parser) {
+		p.dependencyUpdate = value
+	}
+}
+
+// OptionWithValuesObject sets values merged over the values files and under the values set on the command line
+func OptionWithValuesObject(values map[string]any) Option {
+	return func(p *Parser) {
+		p.valuesObject = values
+	}
+}
+
+// OptionWithReleaseName sets the name of the release, which defaults to the name of the chart
+func OptionWithReleaseName(name string) Option {
+	return func(p *Parser) {
+		p.releaseName = name
+	}
+}
+
+// OptionWithNamespace sets the namespace of the release
+func OptionWithNamespace(namespace string) Option {
+	return func(p *Parser) {
+		p.helmClient.Namespace = namespace
+	}
+}
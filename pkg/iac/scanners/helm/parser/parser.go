@@ -18,6 +18,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
 	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/releaseutil"
 
@@ -38,8 +39,13 @@ type Parser struct {
 	values       []string
 	fileValues   []string
 	stringValues []string
+	valuesObject map[string]any
 	apiVersions  []string
 	kubeVersion  string
+	releaseName  string
+
+	dependencyUpdate bool
+	registryClient   *registry.Client
 }
 
 type ChartFile struct {
@@ -53,6 +59,8 @@ func New(src string, opts ...Option) (*Parser, error) {
 	client.DryRun = true     // don't do anything
 	client.Replace = true    // skip name check
 	client.ClientOnly = true // don't try to talk to a cluster
+	// values are validated against the schema before rendering, see validateValues
+	client.SkipSchemaValidation = true
 
 	p := &Parser{
 		helmClient:  client,
@@ -210,12 +218,24 @@ func (p *Parser) getRelease(chrt *chart.Chart) (*release.Release, error) {
 		Values:       p.values,
 		FileValues:   p.fileValues,
 		StringValues: p.stringValues,
+		ValuesObject: p.valuesObject,
 	}
 
 	vals, err := opts.MergeValues()
 	if err != nil {
 		return nil, err
 	}
+
+	// Values violating the schema would make "helm install" fail,
+	// but the chart is still rendered to scan the manifests for misconfigurations.
+	if err := validateValues(chrt, vals); err != nil {
+		p.logger.Warn("Values do not match the values schema of the chart",
+			log.String("chart", chrt.Name()), log.Err(err))
+	}
+
+	if p.releaseName != "" {
+		p.helmClient.ReleaseName = p.releaseName
+	}
 	r, err := p.helmClient.RunWithContext(context.Background(), chrt, vals)
 	if err != nil {
 		return nil, err
@@ -227,6 +247,20 @@ func (p *Parser) getRelease(chrt *chart.Chart) (*release.Release, error) {
 	return r, nil
 }
 
+// validateValues validates the values merged with the default values of the chart and its dependencies
+// against the values.schema.json files
+func validateValues(chrt *chart.Chart, vals map[string]any) error {
+	// Disabled dependencies are not validated, as "helm install" does
+	if err := chartutil.ProcessDependenciesWithMerge(chrt, vals); err != nil {
+		return err
+	}
+	coalesced, err := chartutil.CoalesceValues(chrt, vals)
+	if err != nil {
+		return err
+	}
+	return chartutil.ValidateAgainstSchema(chrt, coalesced)
+}
+
 func (p *Parser) loadChart() (*chart.Chart, error) {
 
 	var files []*loader.BufferedFile
@@ -252,7 +286,12 @@ func (p *Parser) loadChart() (*chart.Chart, error) {
 
 	if req := c.Metadata.Dependencies; req != nil {
 		if err := action.CheckDependencies(c, req); err != nil {
-			return nil, err
+			if !p.dependencyUpdate {
+				return nil, err
+			}
+			if err := p.fetchDependencies(c); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -79,3 +79,93 @@ func TestParseFS(t *testing.T) {
 		assert.Subset(t, p.filepaths, expectedFiles)
 	})
 }
+
+func TestRenderedChartFiles_ValuesSchema(t *testing.T) {
+	valuesFiles := []string{
+		filepath.Join("testdata", "values-files", "first.yaml"),
+		// violates the schema
+		filepath.Join("testdata", "values-files", "second.yaml"),
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "default values",
+			want: "runAsNonRoot: true",
+		},
+		{
+			name: "values files",
+			opts: []Option{OptionWithValuesFile(valuesFiles...)},
+			want: "runAsNonRoot: false",
+		},
+		{
+			name: "values override values files",
+			opts: []Option{
+				OptionWithValuesFile(valuesFiles...),
+				OptionWithValues("securityContext.runAsNonRoot=true"),
+			},
+			want: "runAsNonRoot: true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(".", tt.opts...)
+			require.NoError(t, err)
+			require.NoError(t, p.ParseFS(context.TODO(), os.DirFS(filepath.Join("testdata", "values-schema")), "."))
+
+			files, err := p.RenderedChartFiles()
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+			assert.Contains(t, files[0].ManifestContent, tt.want)
+		})
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		vals    map[string]any
+		wantErr string
+	}{
+		{
+			name: "default values",
+		},
+		{
+			name: "valid values",
+			vals: map[string]any{"replicaCount": 3},
+		},
+		{
+			name:    "below minimum",
+			vals:    map[string]any{"replicaCount": 0},
+			wantErr: "replicaCount",
+		},
+		{
+			name: "wrong type",
+			vals: map[string]any{
+				"securityContext": map[string]any{"runAsNonRoot": "yes"},
+			},
+			wantErr: "runAsNonRoot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(".")
+			require.NoError(t, err)
+			require.NoError(t, p.ParseFS(context.TODO(), os.DirFS(filepath.Join("testdata", "values-schema")), "."))
+			c, err := p.loadChart()
+			require.NoError(t, err)
+
+			err = validateValues(c, tt.vals)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -17,6 +17,7 @@ type ValueOptions struct {
 	StringValues []string
 	Values       []string
 	FileValues   []string
+	ValuesObject map[string]any
 }
 
 // MergeValues merges values from files specified via -f/--values and directly
@@ -40,6 +41,10 @@ func (opts *ValueOptions) MergeValues() (map[string]any, error) {
 		base = mergeMaps(base, currentMap)
 	}
 
+	if opts.ValuesObject != nil {
+		base = mergeMaps(base, opts.ValuesObject)
+	}
+
 	// User specified a value via --set
 	for _, value := range opts.Values {
 		if err := strvals.ParseInto(value, base); err != nil {
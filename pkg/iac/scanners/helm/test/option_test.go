@@ -2,13 +2,20 @@ package test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
 
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/helm/parser"
 )
@@ -224,3 +231,71 @@ func Test_helm_parser_with_options_with_kube_versions(t *testing.T) {
 		})
 	}
 }
+
+func Test_helm_parser_with_options_with_dependency_update(t *testing.T) {
+	// Serve the nginx chart of "with-subchart" from a chart repository
+	subchart, err := loader.LoadDir(filepath.Join("testdata", "with-subchart", "charts", "nginx"))
+	require.NoError(t, err)
+	archive, err := chartutil.Save(subchart, t.TempDir())
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	index := repo.NewIndexFile()
+	require.NoError(t, index.MustAdd(subchart.Metadata, filepath.Base(archive), ts.URL, ""))
+	indexData, err := yaml.Marshal(index)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(indexData)
+	})
+	mux.HandleFunc("/"+filepath.Base(archive), func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, archive)
+	})
+
+	fsys := fstest.MapFS{
+		"Chart.yaml": &fstest.MapFile{Data: []byte(`apiVersion: v2
+name: test
+version: 0.1.0
+dependencies:
+- name: nginx
+  version: ~0.1.0
+  repository: ` + ts.URL + `
+`)},
+	}
+
+	tests := []struct {
+		testName         string
+		dependencyUpdate bool
+		expectedError    string
+	}{
+		{
+			testName:         "dependency update enabled",
+			dependencyUpdate: true,
+		},
+		{
+			testName:      "dependency update disabled",
+			expectedError: "found in Chart.yaml, but missing in charts/ directory: nginx",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			helmParser, err := parser.New("test", parser.OptionWithDependencyUpdate(test.dependencyUpdate))
+			require.NoError(t, err)
+			require.NoError(t, helmParser.ParseFS(context.TODO(), fsys, "."))
+
+			manifests, err := helmParser.RenderedChartFiles()
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, manifests, 1)
+			assert.Equal(t, "charts/nginx/templates/pod.yaml", manifests[0].TemplateFilePath)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// Package yamlnode provides helpers to read YAML nodes, so that parsers keep the positions of the values
+// for the metadata of misconfigurations.
+package yamlnode
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Resolve returns the content of documents and the target of aliases
+func Resolve(node *yaml.Node) *yaml.Node {
+	for node != nil {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) > 0:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// Entry is a key and its value in a YAML mapping
+type Entry struct {
+	Key     string
+	KeyNode *yaml.Node
+	Value   *yaml.Node
+}
+
+// Entries returns the entries of a mapping in order, including the entries of merge keys
+func Entries(node *yaml.Node) []Entry {
+	node = Resolve(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var result []Entry
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Tag == "!!merge" {
+			merged := Resolve(value)
+			if merged != nil && merged.Kind == yaml.SequenceNode {
+				for _, m := range merged.Content {
+					result = append(result, Entries(m)...)
+				}
+			} else {
+				result = append(result, Entries(merged)...)
+			}
+			continue
+		}
+		result = append(result, Entry{
+			Key:     key.Value,
+			KeyNode: key,
+			Value:   value,
+		})
+	}
+	return result
+}
+
+// Lookup returns the value of the key in a mapping
+func Lookup(node *yaml.Node, key string) *yaml.Node {
+	for _, entry := range Entries(node) {
+		if entry.Key == key {
+			return entry.Value
+		}
+	}
+	return nil
+}
+
+// Items returns the items of a sequence
+func Items(node *yaml.Node) []*yaml.Node {
+	node = Resolve(node)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+// ValueFunc converts a node with a syntax specific to the configuration, e.g. a custom tag.
+// It returns false to convert the node as usual.
+type ValueFunc func(node *yaml.Node) (any, bool)
+
+// Value converts the node into a value for Rego checks
+func Value(node *yaml.Node) any {
+	return ValueWith(node, nil)
+}
+
+// ValueWith converts the node into a value for Rego checks, calling fn first for the node and its descendants
+func ValueWith(node *yaml.Node, fn ValueFunc) any {
+	node = Resolve(node)
+	if node == nil {
+		return nil
+	}
+	if fn != nil {
+		if v, ok := fn(node); ok {
+			return v
+		}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		m := make(map[string]any)
+		for _, entry := range Entries(node) {
+			m[entry.Key] = ValueWith(entry.Value, fn)
+		}
+		return m
+	case yaml.SequenceNode:
+		s := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			s = append(s, ValueWith(item, fn))
+		}
+		return s
+	case yaml.ScalarNode:
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return node.Value
+		}
+		return v
+	}
+	return nil
+}
+
+// ToMap returns the value if it's a map, otherwise an empty map
+func ToMap(v any) map[string]any {
+	if m, ok := v.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{}
+}
+
+// String returns the value of a scalar node as a string
+func String(node *yaml.Node) string {
+	node = Resolve(node)
+	if node == nil || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		return ""
+	}
+	return node.Value
+}
+
+// EndLine returns the last line of the node
+func EndLine(node *yaml.Node) int {
+	if node == nil {
+		return 0
+	}
+	if node.Kind == yaml.AliasNode {
+		return node.Line
+	}
+	line := node.Line
+	if node.Kind == yaml.ScalarNode {
+		switch node.Style {
+		case yaml.LiteralStyle, yaml.FoldedStyle:
+			line += strings.Count(strings.TrimRight(node.Value, "\n"), "\n") + 1
+		default:
+			line += strings.Count(node.Value, "\n")
+		}
+	}
+	for _, child := range node.Content {
+		if l := EndLine(child); l > line {
+			line = l
+		}
+	}
+	return line
+}
+
+// Metadata is the location of an element in a YAML file
+type Metadata struct {
+	FilePath  string
+	StartLine int
+	EndLine   int
+}
+
+// NewMetadata returns the location from the first node to the end of the last node
+func NewMetadata(filePath string, nodes ...*yaml.Node) Metadata {
+	return Metadata{
+		FilePath:  filePath,
+		StartLine: nodes[0].Line,
+		EndLine:   EndLine(nodes[len(nodes)-1]),
+	}
+}
+
+// ToRego returns the location in the format of the metadata of Rego inputs
+func (m Metadata) ToRego() map[string]any {
+	return map[string]any{
+		"filepath":  m.FilePath,
+		"startline": m.StartLine,
+		"endline":   m.EndLine,
+	}
+}
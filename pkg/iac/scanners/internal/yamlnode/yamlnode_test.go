@@ -0,0 +1,72 @@
+package yamlnode_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/internal/yamlnode"
+)
+
+const src = `base: &base
+  image: alpine
+extra: &extra
+  tags: [a, b]
+job:
+  <<: [*base, *extra]
+  script: |
+    echo 1
+    echo 2
+  empty: ~
+  ref: !custom [x, y]
+`
+
+func parse(t *testing.T) *yaml.Node {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(src), &node))
+	return &node
+}
+
+func TestEntries(t *testing.T) {
+	job := yamlnode.Lookup(parse(t), "job")
+
+	var keys []string
+	for _, entry := range yamlnode.Entries(job) {
+		keys = append(keys, entry.Key)
+	}
+	assert.Equal(t, []string{"image", "tags", "script", "empty", "ref"}, keys)
+
+	assert.Equal(t, "alpine", yamlnode.String(yamlnode.Lookup(job, "image")))
+	assert.Empty(t, yamlnode.String(yamlnode.Lookup(job, "empty")))
+	assert.Len(t, yamlnode.Items(yamlnode.Lookup(job, "tags")), 2)
+	assert.Nil(t, yamlnode.Lookup(job, "missing"))
+}
+
+func TestValueWith(t *testing.T) {
+	job := yamlnode.Lookup(parse(t), "job")
+
+	got := yamlnode.ValueWith(job, func(node *yaml.Node) (any, bool) {
+		if node.Tag == "!custom" {
+			return "custom", true
+		}
+		return nil, false
+	})
+	assert.Equal(t, map[string]any{
+		"image":  "alpine",
+		"tags":   []any{"a", "b"},
+		"script": "echo 1\necho 2\n",
+		"empty":  nil,
+		"ref":    "custom",
+	}, got)
+
+	assert.Equal(t, []any{"x", "y"}, yamlnode.ToMap(yamlnode.Value(job))["ref"])
+	assert.Empty(t, yamlnode.ToMap(yamlnode.Value(yamlnode.Lookup(job, "image"))))
+}
+
+func TestEndLine(t *testing.T) {
+	root := parse(t)
+	assert.Equal(t, 11, yamlnode.EndLine(yamlnode.Lookup(root, "job")))
+	assert.Equal(t, 9, yamlnode.EndLine(yamlnode.Lookup(yamlnode.Lookup(root, "job"), "script")))
+}
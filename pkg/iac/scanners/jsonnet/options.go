@@ -0,0 +1,30 @@
+package jsonnet
+
+import (
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/jsonnet/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+)
+
+func ScannerWithImportPaths(paths ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if jsonnetScanner, ok := s.(*Scanner); ok {
+			jsonnetScanner.addParserOptions(parser.OptionWithImportPaths(paths...))
+		}
+	}
+}
+
+func ScannerWithExtVars(vars ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if jsonnetScanner, ok := s.(*Scanner); ok {
+			jsonnetScanner.addParserOptions(parser.OptionWithExtVars(vars...))
+		}
+	}
+}
+
+func ScannerWithExtCode(vars ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if jsonnetScanner, ok := s.(*Scanner); ok {
+			jsonnetScanner.addParserOptions(parser.OptionWithExtCode(vars...))
+		}
+	}
+}
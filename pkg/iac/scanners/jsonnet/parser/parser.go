@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/go-jsonnet"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// File is a Jsonnet file evaluated into JSON
+type File struct {
+	Path  string
+	Value any
+}
+
+type Option func(p *Parser)
+
+// OptionWithImportPaths sets the library paths searched for imports, relative to the root of the file system,
+// e.g. "lib" and "vendor" in Tanka projects. Imports are first searched relative to the file importing them.
+func OptionWithImportPaths(paths ...string) Option {
+	return func(p *Parser) {
+		p.importPaths = append(p.importPaths, paths...)
+	}
+}
+
+// OptionWithExtVars sets the external variables, as "key=value" strings.
+// The value of variables without "=value" is read from the environment variable with the same name.
+func OptionWithExtVars(vars ...string) Option {
+	return func(p *Parser) {
+		p.extVars = append(p.extVars, vars...)
+	}
+}
+
+// OptionWithExtCode sets the external variables whose values are Jsonnet code, as "key=code" strings.
+func OptionWithExtCode(vars ...string) Option {
+	return func(p *Parser) {
+		p.extCode = append(p.extCode, vars...)
+	}
+}
+
+// Parser evaluates the Jsonnet files in a file system
+type Parser struct {
+	logger      *log.Logger
+	importPaths []string
+	extVars     []string
+	extCode     []string
+}
+
+func New(opts ...Option) *Parser {
+	p := &Parser{
+		logger: log.WithPrefix("jsonnet parser"),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// ParseFS evaluates the .jsonnet files in the directory and its subdirectories.
+// .libsonnet files and the files imported by other files are libraries, and are not evaluated on their own.
+// Files which cannot be evaluated, e.g. because of missing imports, are skipped.
+func (p *Parser) ParseFS(ctx context.Context, fsys fs.FS, dir string) ([]*File, error) {
+	var filePaths []string
+	if err := fs.WalkDir(fsys, filepath.ToSlash(dir), func(filePath string, entry fs.DirEntry, err error) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && path.Ext(filePath) == ".jsonnet" {
+			filePaths = append(filePaths, filePath)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	imp := newImporter(fsys, p.importPaths)
+	vars, err := p.vars()
+	if err != nil {
+		return nil, err
+	}
+	codeVars := p.codeVars()
+
+	var files []*File
+	imported := make(map[string]bool)
+	for _, filePath := range filePaths {
+		vm := jsonnet.MakeVM()
+		vm.Importer(imp)
+		for key, val := range vars {
+			vm.ExtVar(key, val)
+		}
+		for key, code := range codeVars {
+			vm.ExtCode(key, code)
+		}
+
+		imp.imported = make(map[string]bool)
+		output, err := vm.EvaluateFile(filePath)
+		if err != nil {
+			p.logger.Error("Failed to evaluate file", log.FilePath(filePath), log.Err(err))
+			continue
+		}
+		for importedPath := range imp.imported {
+			if importedPath != filePath {
+				imported[importedPath] = true
+			}
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(output), &value); err != nil {
+			return nil, fmt.Errorf("failed to decode output of %q: %w", filePath, err)
+		}
+		files = append(files, &File{
+			Path:  filePath,
+			Value: value,
+		})
+	}
+
+	return slices.DeleteFunc(files, func(f *File) bool {
+		return imported[f.Path]
+	}), nil
+}
+
+func (p *Parser) vars() (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, v := range p.extVars {
+		key, val, ok := strings.Cut(v, "=")
+		if !ok {
+			if val, ok = os.LookupEnv(key); !ok {
+				return nil, fmt.Errorf("environment variable %q of the external variable is not set", key)
+			}
+		}
+		vars[key] = val
+	}
+	return vars, nil
+}
+
+func (p *Parser) codeVars() map[string]string {
+	vars := make(map[string]string)
+	for _, v := range p.extCode {
+		if key, code, ok := strings.Cut(v, "="); ok {
+			vars[key] = code
+		}
+	}
+	return vars
+}
+
+// importer imports files from the file system, relative to the importing file or to the import paths
+type importer struct {
+	fsys        fs.FS
+	importPaths []string
+	cache       map[string]jsonnet.Contents
+	// imported are the files imported during the evaluation of a file
+	imported map[string]bool
+}
+
+func newImporter(fsys fs.FS, importPaths []string) *importer {
+	return &importer{
+		fsys:        fsys,
+		importPaths: importPaths,
+		cache:       make(map[string]jsonnet.Contents),
+		imported:    make(map[string]bool),
+	}
+}
+
+func (i *importer) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	candidates := []string{path.Join(path.Dir(importedFrom), importedPath)}
+	for _, dir := range i.importPaths {
+		candidates = append(candidates, path.Join(filepath.ToSlash(dir), importedPath))
+	}
+
+	for _, candidate := range candidates {
+		if contents, ok := i.cache[candidate]; ok {
+			i.imported[candidate] = true
+			return contents, candidate, nil
+		}
+		b, err := fs.ReadFile(i.fsys, candidate)
+		if err != nil {
+			continue
+		}
+		contents := jsonnet.MakeContentsRaw(b)
+		i.cache[candidate] = contents
+		i.imported[candidate] = true
+		return contents, candidate, nil
+	}
+	return jsonnet.Contents{}, "", fmt.Errorf("couldn't open import %q: no match locally or in the import paths", importedPath)
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"vendor/k.libsonnet": {Data: []byte(`{
+  deployment(name, replicas=1):: {
+    apiVersion: 'apps/v1',
+    kind: 'Deployment',
+    metadata: { name: name },
+    spec: { replicas: replicas },
+  },
+}
+`)},
+		"environments/default/main.jsonnet": {Data: []byte(`local k = import 'k.libsonnet';
+local spec = import 'spec.json';
+{
+  app: k.deployment('app', replicas=std.parseInt(std.extVar('replicas'))),
+  namespace: spec.namespace,
+  env: std.extVar('env'),
+  debug: std.extVar('debug'),
+}
+`)},
+		"environments/default/spec.json":           {Data: []byte(`{"namespace": "default"}`)},
+		"environments/default/common.jsonnet":      {Data: []byte(`{ namespace: 'common' }`)},
+		"environments/default/uses_common.jsonnet": {Data: []byte(`(import 'common.jsonnet') + { name: 'test' }`)},
+		"environments/broken/main.jsonnet":         {Data: []byte(`import 'missing.libsonnet'`)},
+	}
+
+	p := New(
+		OptionWithImportPaths("vendor"),
+		OptionWithExtVars("replicas=3", "env=prod"),
+		OptionWithExtCode("debug=true"),
+	)
+
+	files, err := p.ParseFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+
+	expected := []*File{
+		{
+			Path: "environments/default/main.jsonnet",
+			Value: map[string]any{
+				"app": map[string]any{
+					"apiVersion": "apps/v1",
+					"kind":       "Deployment",
+					"metadata":   map[string]any{"name": "app"},
+					"spec":       map[string]any{"replicas": float64(3)},
+				},
+				"namespace": "default",
+				"env":       "prod",
+				"debug":     true,
+			},
+		},
+		{
+			Path: "environments/default/uses_common.jsonnet",
+			Value: map[string]any{
+				"namespace": "common",
+				"name":      "test",
+			},
+		},
+	}
+	assert.Equal(t, expected, files)
+}
+
+func TestParser_ExtVarFromEnv(t *testing.T) {
+	fsys := fstest.MapFS{
+		"main.jsonnet": {Data: []byte(`{ env: std.extVar('TEST_JSONNET_ENV') }`)},
+	}
+
+	t.Setenv("TEST_JSONNET_ENV", "prod")
+	files, err := New(OptionWithExtVars("TEST_JSONNET_ENV")).ParseFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, map[string]any{"env": "prod"}, files[0].Value)
+
+	_, err = New(OptionWithExtVars("TEST_JSONNET_UNSET")).ParseFS(context.TODO(), fsys, ".")
+	require.Error(t, err)
+}
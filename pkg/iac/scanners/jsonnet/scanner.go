@@ -0,0 +1,185 @@
+package jsonnet
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/liamg/memoryfs"
+	kyaml "sigs.k8s.io/yaml"
+
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/jsonnet/parser"
+	kparser "github.com/aquasecurity/trivy/pkg/iac/scanners/kubernetes/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var _ scanners.FSScanner = (*Scanner)(nil)
+var _ options.ConfigurableScanner = (*Scanner)(nil)
+
+// Scanner evaluates Jsonnet files and scans the Kubernetes objects they produce,
+// e.g. the environments of Tanka projects.
+type Scanner struct {
+	mu            sync.Mutex
+	logger        *log.Logger
+	options       []options.ScannerOption
+	parserOptions []parser.Option
+	regoScanner   *rego.Scanner
+}
+
+// New creates a new Scanner
+func New(opts ...options.ScannerOption) *Scanner {
+	s := &Scanner{
+		options: opts,
+		logger:  log.WithPrefix("jsonnet scanner"),
+	}
+
+	for _, option := range opts {
+		option(s)
+	}
+	return s
+}
+
+func (s *Scanner) addParserOptions(opts ...parser.Option) {
+	s.parserOptions = append(s.parserOptions, opts...)
+}
+
+func (s *Scanner) Name() string {
+	return "Jsonnet"
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, path string) (scan.Results, error) {
+	files, err := parser.New(s.parserOptions...).ParseFS(ctx, target, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	if err := s.initRegoScanner(target); err != nil {
+		return nil, fmt.Errorf("failed to init rego scanner: %w", err)
+	}
+
+	var results scan.Results
+	for _, file := range files {
+		fileResults, err := s.scanFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, fileResults...)
+	}
+	return results, nil
+}
+
+// scanFile scans the Kubernetes objects of the output of the file, rendered as a YAML stream
+// which the results refer to, as the objects have no location in the Jsonnet sources
+func (s *Scanner) scanFile(ctx context.Context, file *parser.File) (scan.Results, error) {
+	objects := kubernetesObjects(file.Value)
+	if len(objects) == 0 {
+		s.logger.Debug("No Kubernetes objects found", log.FilePath(file.Path))
+		return nil, nil
+	}
+
+	docs := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		doc, err := kyaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %q: %w", file.Path, err)
+		}
+		docs = append(docs, string(doc))
+	}
+	content := strings.Join(docs, "---\n")
+
+	manifests, err := kparser.Parse(ctx, strings.NewReader(content), file.Path)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal yaml: %w", err)
+	}
+
+	renderedFS := memoryfs.New()
+	if err := renderedFS.MkdirAll(filepath.Dir(file.Path), fs.ModePerm); err != nil {
+		return nil, err
+	}
+	if err := renderedFS.WriteLazyFile(file.Path, func() (io.Reader, error) {
+		return strings.NewReader(content), nil
+	}, fs.ModePerm); err != nil {
+		return nil, err
+	}
+
+	var results scan.Results
+	for _, manifest := range manifests {
+		manifestResults, err := s.regoScanner.ScanInput(ctx, rego.Input{
+			Path:     file.Path,
+			Contents: manifest,
+			FS:       renderedFS,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning error: %w", err)
+		}
+		manifestResults.SetSourceAndFilesystem("", renderedFS, false)
+		results = append(results, manifestResults...)
+	}
+	return results, nil
+}
+
+// kubernetesObjects returns the Kubernetes objects in the output of a Jsonnet file, as Tanka does:
+// objects with an apiVersion and a kind, found at any depth of objects and arrays, with the items of Lists.
+// Objects are sorted by their keys for the output to be deterministic.
+func kubernetesObjects(val any) []map[string]any {
+	switch v := val.(type) {
+	case map[string]any:
+		if isKubernetesObject(v) {
+			if items, ok := v["items"].([]any); ok && strings.HasSuffix(v["kind"].(string), "List") {
+				return kubernetesObjects(items)
+			}
+			return []map[string]any{v}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var objects []map[string]any
+		for _, key := range keys {
+			objects = append(objects, kubernetesObjects(v[key])...)
+		}
+		return objects
+	case []any:
+		var objects []map[string]any
+		for _, item := range v {
+			objects = append(objects, kubernetesObjects(item)...)
+		}
+		return objects
+	}
+	return nil
+}
+
+func isKubernetesObject(v map[string]any) bool {
+	apiVersion, _ := v["apiVersion"].(string)
+	kind, _ := v["kind"].(string)
+	return apiVersion != "" && kind != ""
+}
+
+func (s *Scanner) initRegoScanner(srcFS fs.FS) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.regoScanner != nil {
+		return nil
+	}
+	regoScanner := rego.NewScanner(types.SourceKubernetes, s.options...)
+	if err := regoScanner.LoadPolicies(srcFS); err != nil {
+		return err
+	}
+	s.regoScanner = regoScanner
+	return nil
+}
@@ -0,0 +1,105 @@
+package jsonnet
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+const privilegedCheck = `# METADATA
+# title: Privileged containers
+# custom:
+#   id: USR-KSV-0001
+#   avd_id: USR-KSV-0001
+#   severity: HIGH
+#   short_code: privileged
+#   input:
+#     selector:
+#     - type: kubernetes
+package user.kubernetes.privileged
+
+import rego.v1
+
+deny contains res if {
+	some container in input.spec.template.spec.containers
+	container.securityContext.privileged
+	res := result.new(sprintf("Container %q of %s %q is privileged", [container.name, input.kind, input.metadata.name]), container)
+}
+`
+
+func TestScanner_ScanFS(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"lib/deployment.libsonnet": `{
+  new(name, privileged=false):: {
+    apiVersion: 'apps/v1',
+    kind: 'Deployment',
+    metadata: { name: name },
+    spec: {
+      template: {
+        spec: {
+          containers: [{
+            name: name,
+            image: 'nginx',
+            securityContext: { privileged: privileged },
+          }],
+        },
+      },
+    },
+  },
+}
+`,
+		"environments/default/main.jsonnet": `local deployment = import 'deployment.libsonnet';
+{
+  web: {
+    deployment: deployment.new('web'),
+  },
+  agent: {
+    deployment: deployment.new('agent', privileged=std.extVar('privileged') == 'true'),
+  },
+  list: {
+    apiVersion: 'v1',
+    kind: 'List',
+    items: [deployment.new('worker', privileged=true)],
+  },
+  // not a Kubernetes object
+  config: { replicas: 3 },
+}
+`,
+	})
+
+	scanner := New(
+		rego.WithEmbeddedPolicies(false),
+		rego.WithEmbeddedLibraries(true),
+		rego.WithPolicyReader(strings.NewReader(privilegedCheck)),
+		rego.WithPolicyNamespaces("user"),
+		ScannerWithImportPaths("lib"),
+		ScannerWithExtVars("privileged=true"),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 2)
+	assert.Len(t, results.GetPassed(), 1)
+
+	messages := make([]string, 0, len(failed))
+	for _, res := range failed {
+		assert.Equal(t, "environments/default/main.jsonnet", res.Metadata().Range().GetFilename())
+		messages = append(messages, res.Description())
+	}
+	assert.ElementsMatch(t, []string{
+		`Container "agent" of Deployment "agent" is privileged`,
+		`Container "worker" of Deployment "worker" is privileged`,
+	}, messages)
+
+	code, err := failed[0].GetCode()
+	require.NoError(t, err)
+	assert.NotEmpty(t, code.Lines)
+}
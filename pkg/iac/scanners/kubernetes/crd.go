@@ -0,0 +1,344 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// crdSchema is the schema of a version of a custom resource
+type crdSchema struct {
+	// raw is the OpenAPI v3 schema of the version, used to type the values of custom resources
+	raw    map[string]any
+	schema *gojsonschema.Schema
+}
+
+// crdSchemas are the schemas of custom resources by apiVersion and kind, see crdKey
+type crdSchemas map[string]*crdSchema
+
+func crdKey(apiVersion, kind string) string {
+	return apiVersion + "/" + strings.ToLower(kind)
+}
+
+// loadCRDSchemas loads the schemas of custom resources from the paths, which are either
+//   - files with CustomResourceDefinitions or Lists of them, e.g. exported with "kubectl get crds -o yaml"
+//   - directories of such files
+//   - directories of JSON schemas, laid out like https://github.com/datreeio/CRDs-catalog,
+//     i.e. "{group}/{kind}_{version}.json"
+func loadCRDSchemas(paths []string) (crdSchemas, error) {
+	schemas := make(crdSchemas)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CRD schemas: %w", err)
+		}
+		if !info.IsDir() {
+			if err := schemas.loadFile("", path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch filepath.Ext(filePath) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					return schemas.loadFile(path, filePath)
+				}
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+// loadFile loads the CRDs or the JSON schema in the file. root is the directory given by the user,
+// the group of JSON schemas is the name of the directory they are in relative to it.
+func (s crdSchemas) loadFile(root, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	for {
+		var doc map[string]any
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode %q: %w", filePath, err)
+		}
+
+		switch kind, _ := doc["kind"].(string); {
+		case kind == "CustomResourceDefinition":
+			if err := s.addCRD(doc); err != nil {
+				return fmt.Errorf("invalid CRD in %q: %w", filePath, err)
+			}
+		case strings.HasSuffix(kind, "List"):
+			items, _ := doc["items"].([]any)
+			for _, item := range items {
+				if crd, ok := item.(map[string]any); ok && crd["kind"] == "CustomResourceDefinition" {
+					if err := s.addCRD(crd); err != nil {
+						return fmt.Errorf("invalid CRD in %q: %w", filePath, err)
+					}
+				}
+			}
+		case root != "" && filepath.Ext(filePath) == ".json":
+			if err := s.addJSONSchema(root, filePath, doc); err != nil {
+				return fmt.Errorf("invalid schema %q: %w", filePath, err)
+			}
+		}
+	}
+}
+
+// addCRD adds the schemas of the versions of the CRD, both apiextensions.k8s.io/v1 and v1beta1 CRDs are supported
+func (s crdSchemas) addCRD(crd map[string]any) error {
+	spec, _ := crd["spec"].(map[string]any)
+	group, _ := spec["group"].(string)
+	names, _ := spec["names"].(map[string]any)
+	kind, _ := names["kind"].(string)
+	if group == "" || kind == "" {
+		return errors.New("group and kind are required")
+	}
+
+	// v1beta1 CRDs may have a single schema for all the versions
+	validation, _ := spec["validation"].(map[string]any)
+	common, _ := validation["openAPIV3Schema"].(map[string]any)
+
+	versions, _ := spec["versions"].([]any)
+	if ver, ok := spec["version"].(string); ok && len(versions) == 0 {
+		versions = []any{map[string]any{"name": ver}}
+	}
+	for _, v := range versions {
+		version, _ := v.(map[string]any)
+		name, _ := version["name"].(string)
+		schema, _ := version["schema"].(map[string]any)
+		raw, ok := schema["openAPIV3Schema"].(map[string]any)
+		if !ok {
+			raw = common
+		}
+		if name == "" || raw == nil {
+			continue
+		}
+
+		compiled, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(toJSONSchema(raw, true)))
+		if err != nil {
+			return fmt.Errorf("version %q: %w", name, err)
+		}
+		s[crdKey(group+"/"+name, kind)] = &crdSchema{
+			raw:    raw,
+			schema: compiled,
+		}
+	}
+	return nil
+}
+
+// addJSONSchema adds the JSON schema named "{kind}_{version}.json" in the directory of the group
+func (s crdSchemas) addJSONSchema(root, filePath string, schema map[string]any) error {
+	rel, err := filepath.Rel(root, filepath.Dir(filePath))
+	if err != nil || rel == "." {
+		return nil
+	}
+	group := filepath.Base(rel)
+	name := strings.TrimSuffix(filepath.Base(filePath), ".json")
+	i := strings.LastIndex(name, "_")
+	if i <= 0 {
+		return nil
+	}
+	kind, version := name[:i], name[i+1:]
+
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(schema))
+	if err != nil {
+		return err
+	}
+	s[crdKey(group+"/"+version, kind)] = &crdSchema{
+		raw:    schema,
+		schema: compiled,
+	}
+	return nil
+}
+
+func (s crdSchemas) lookup(apiVersion, kind string) *crdSchema {
+	return s[crdKey(apiVersion, kind)]
+}
+
+// toJSONSchema converts the OpenAPI v3 schema of a CRD to a JSON schema.
+// Unknown fields are not allowed as the API server prunes them, unless the schema preserves them.
+func toJSONSchema(raw map[string]any, resource bool) map[string]any {
+	schema := make(map[string]any, len(raw))
+	for key, val := range raw {
+		switch key {
+		case "properties", "patternProperties", "definitions":
+			props, _ := val.(map[string]any)
+			converted := make(map[string]any, len(props))
+			for name, prop := range props {
+				if m, ok := prop.(map[string]any); ok {
+					converted[name] = toJSONSchema(m, false)
+				}
+			}
+			schema[key] = converted
+		case "items", "additionalProperties", "not":
+			if m, ok := val.(map[string]any); ok {
+				schema[key] = toJSONSchema(m, false)
+			} else {
+				schema[key] = val
+			}
+		case "allOf", "anyOf", "oneOf":
+			list, _ := val.([]any)
+			converted := make([]any, 0, len(list))
+			for _, item := range list {
+				if m, ok := item.(map[string]any); ok {
+					converted = append(converted, toJSONSchema(m, false))
+				}
+			}
+			schema[key] = converted
+		default:
+			schema[key] = val
+		}
+	}
+
+	if typ, ok := schema["type"].(string); ok && raw["nullable"] == true {
+		schema["type"] = []any{typ, "null"}
+	}
+	if raw["x-kubernetes-int-or-string"] == true {
+		delete(schema, "type")
+		schema["anyOf"] = []any{
+			map[string]any{"type": "integer"},
+			map[string]any{"type": "string"},
+		}
+	}
+
+	props, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return schema
+	}
+	if resource || raw["x-kubernetes-embedded-resource"] == true {
+		for _, name := range []string{"apiVersion", "kind"} {
+			if _, ok := props[name]; !ok {
+				props[name] = map[string]any{"type": "string"}
+			}
+		}
+		// The schema of the metadata can only restrict the name and the generateName
+		if metadata, ok := props["metadata"].(map[string]any); ok {
+			delete(metadata, "additionalProperties")
+		} else {
+			props["metadata"] = map[string]any{"type": "object"}
+		}
+	}
+	if _, ok := schema["additionalProperties"]; !ok && raw["x-kubernetes-preserve-unknown-fields"] != true {
+		schema["additionalProperties"] = false
+	}
+	return schema
+}
+
+// applyTypes converts the values of the custom resource to the types of its schema and sets the defaults
+// of the missing fields, as the API server does, so that checks get the values the resource is stored with.
+func (s crdSchemas) applyTypes(manifest any) {
+	doc, ok := manifest.(map[string]any)
+	if !ok {
+		return
+	}
+	apiVersion, _ := doc["apiVersion"].(string)
+	kind, _ := doc["kind"].(string)
+	if schema := s.lookup(apiVersion, kind); schema != nil {
+		typed(doc, schema.raw)
+	}
+}
+
+// typed returns the value converted to the type of the schema. Objects and arrays are converted in place.
+func typed(val any, schema map[string]any) any {
+	if schema == nil || schema["x-kubernetes-int-or-string"] == true {
+		return val
+	}
+
+	switch v := val.(type) {
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		for name, prop := range props {
+			propSchema, _ := prop.(map[string]any)
+			if child, ok := v[name]; ok {
+				v[name] = typed(child, propSchema)
+			} else if def, ok := propSchema["default"]; ok {
+				v[name] = typed(clone(def), propSchema)
+			}
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]any); ok {
+			for name, child := range v {
+				if _, ok := props[name]; !ok && name != metadataKey {
+					v[name] = typed(child, additional)
+				}
+			}
+		}
+		return v
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range v {
+			v[i] = typed(item, items)
+		}
+		return v
+	}
+
+	switch schemaType(schema) {
+	case "string":
+		switch v := val.(type) {
+		case int:
+			return strconv.Itoa(v)
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(v)
+		}
+	case "integer":
+		if f, ok := val.(float64); ok && f == float64(int(f)) {
+			return int(f)
+		}
+	}
+	return val
+}
+
+// schemaType returns the type of the schema, JSON schemas may have types like ["string", "null"]
+func schemaType(schema map[string]any) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func clone(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, child := range v {
+			m[key] = clone(child)
+		}
+		return m
+	case []any:
+		s := make([]any, 0, len(v))
+		for _, child := range v {
+			s = append(s, clone(child))
+		}
+		return s
+	default:
+		return val
+	}
+}
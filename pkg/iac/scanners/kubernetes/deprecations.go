@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/aquasecurity/go-version/pkg/version"
+
+	"github.com/aquasecurity/trivy/pkg/iac/providers"
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/severity"
+)
+
+var removedAPIRule = scan.Rule{
+	AVDID:       "AVD-K8S-0002",
+	ShortCode:   "no-removed-api-versions",
+	Summary:     "Manifests should not use apiVersions removed in the Kubernetes version",
+	Explanation: "Resources with removed apiVersions are rejected by the API server after the upgrade.",
+	Resolution:  "Migrate the manifest to the replacement apiVersion before upgrading.",
+	Provider:    providers.KubernetesProvider,
+	Service:     "general",
+	Links:       []string{"https://kubernetes.io/docs/reference/using-api/deprecation-guide/"},
+	Severity:    severity.High,
+}
+
+var deprecatedAPIRule = scan.Rule{
+	AVDID:       "AVD-K8S-0003",
+	ShortCode:   "no-deprecated-api-versions",
+	Summary:     "Manifests should not use apiVersions deprecated in the Kubernetes version",
+	Explanation: "Deprecated apiVersions are removed in later Kubernetes versions.",
+	Resolution:  "Migrate the manifest to the replacement apiVersion.",
+	Provider:    providers.KubernetesProvider,
+	Service:     "general",
+	Links:       []string{"https://kubernetes.io/docs/reference/using-api/deprecation-guide/"},
+	Severity:    severity.Low,
+}
+
+type deprecatedAPI struct {
+	apiVersion   string
+	kinds        []string
+	deprecatedIn string
+	removedIn    string
+	// empty if the API has no replacement
+	replacement string
+}
+
+// deprecatedAPIs are the apiVersions deprecated or removed in Kubernetes.
+// cf. https://kubernetes.io/docs/reference/using-api/deprecation-guide/
+var deprecatedAPIs = []deprecatedAPI{
+	{"extensions/v1beta1", []string{"Deployment", "DaemonSet", "ReplicaSet"}, "1.9", "1.16", "apps/v1"},
+	{"extensions/v1beta1", []string{"NetworkPolicy"}, "1.9", "1.16", "networking.k8s.io/v1"},
+	{"extensions/v1beta1", []string{"PodSecurityPolicy"}, "1.10", "1.16", "policy/v1beta1"},
+	{"extensions/v1beta1", []string{"Ingress"}, "1.14", "1.22", "networking.k8s.io/v1"},
+	{"apps/v1beta1", []string{"Deployment", "StatefulSet"}, "1.9", "1.16", "apps/v1"},
+	{"apps/v1beta2", []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet"}, "1.9", "1.16", "apps/v1"},
+	{"networking.k8s.io/v1beta1", []string{"Ingress", "IngressClass"}, "1.19", "1.22", "networking.k8s.io/v1"},
+	{"admissionregistration.k8s.io/v1beta1", []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}, "1.16", "1.22", "admissionregistration.k8s.io/v1"},
+	{"apiextensions.k8s.io/v1beta1", []string{"CustomResourceDefinition"}, "1.16", "1.22", "apiextensions.k8s.io/v1"},
+	{"apiregistration.k8s.io/v1beta1", []string{"APIService"}, "1.19", "1.22", "apiregistration.k8s.io/v1"},
+	{"authentication.k8s.io/v1beta1", []string{"TokenReview"}, "1.19", "1.22", "authentication.k8s.io/v1"},
+	{"authorization.k8s.io/v1beta1", []string{"SubjectAccessReview", "LocalSubjectAccessReview", "SelfSubjectAccessReview"}, "1.19", "1.22", "authorization.k8s.io/v1"},
+	{"certificates.k8s.io/v1beta1", []string{"CertificateSigningRequest"}, "1.19", "1.22", "certificates.k8s.io/v1"},
+	{"coordination.k8s.io/v1beta1", []string{"Lease"}, "1.19", "1.22", "coordination.k8s.io/v1"},
+	{"rbac.authorization.k8s.io/v1beta1", []string{"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}, "1.17", "1.22", "rbac.authorization.k8s.io/v1"},
+	{"scheduling.k8s.io/v1beta1", []string{"PriorityClass"}, "1.14", "1.22", "scheduling.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", []string{"CSIDriver", "CSINode", "StorageClass", "VolumeAttachment"}, "1.19", "1.22", "storage.k8s.io/v1"},
+	{"batch/v1beta1", []string{"CronJob"}, "1.21", "1.25", "batch/v1"},
+	{"discovery.k8s.io/v1beta1", []string{"EndpointSlice"}, "1.21", "1.25", "discovery.k8s.io/v1"},
+	{"events.k8s.io/v1beta1", []string{"Event"}, "1.19", "1.25", "events.k8s.io/v1"},
+	{"autoscaling/v2beta1", []string{"HorizontalPodAutoscaler"}, "1.22", "1.25", "autoscaling/v2"},
+	{"policy/v1beta1", []string{"PodDisruptionBudget"}, "1.21", "1.25", "policy/v1"},
+	{"policy/v1beta1", []string{"PodSecurityPolicy"}, "1.21", "1.25", ""},
+	{"node.k8s.io/v1beta1", []string{"RuntimeClass"}, "1.20", "1.25", "node.k8s.io/v1"},
+	{"autoscaling/v2beta2", []string{"HorizontalPodAutoscaler"}, "1.23", "1.26", "autoscaling/v2"},
+	{"flowcontrol.apiserver.k8s.io/v1beta1", []string{"FlowSchema", "PriorityLevelConfiguration"}, "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1"},
+	{"storage.k8s.io/v1beta1", []string{"CSIStorageCapacity"}, "1.24", "1.27", "storage.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta2", []string{"FlowSchema", "PriorityLevelConfiguration"}, "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1"},
+	{"flowcontrol.apiserver.k8s.io/v1beta3", []string{"FlowSchema", "PriorityLevelConfiguration"}, "1.29", "1.32", "flowcontrol.apiserver.k8s.io/v1"},
+}
+
+// checkAPIVersion reports the apiVersion of the resource if it is deprecated or removed in the upgrade version
+func (v *validator) checkAPIVersion(doc map[string]any, apiVersion, kind string) scan.Results {
+	api, ok := findDeprecatedAPI(apiVersion, kind)
+	if !ok {
+		return nil
+	}
+
+	var (
+		rule  scan.Rule
+		msg   string
+		since string
+	)
+	switch {
+	case v.isSince(api.removedIn):
+		rule, msg, since = removedAPIRule, "removed", api.removedIn
+	case v.isSince(api.deprecatedIn):
+		rule, msg, since = deprecatedAPIRule, "deprecated", api.deprecatedIn
+	default:
+		return nil
+	}
+
+	msg = fmt.Sprintf("%s %q uses %s which is %s since Kubernetes %s", kind, resourceName(doc), apiVersion, msg, since)
+	if api.replacement != "" {
+		msg += fmt.Sprintf(", use %s instead", api.replacement)
+	}
+
+	var results scan.Results
+	results.Add(msg, nodeMetadata(doc, ""))
+	results.SetRule(rule)
+	return results
+}
+
+// isSince checks if the upgrade version is the Kubernetes version or later
+func (v *validator) isSince(kubernetesVersion string) bool {
+	ver, err := version.Parse(kubernetesVersion)
+	return err == nil && v.upgradeVersion.GreaterThanOrEqual(ver)
+}
+
+func findDeprecatedAPI(apiVersion, kind string) (deprecatedAPI, bool) {
+	for _, api := range deprecatedAPIs {
+		if api.apiVersion == apiVersion && slices.Contains(api.kinds, kind) {
+			return api, true
+		}
+	}
+	return deprecatedAPI{}, false
+}
@@ -0,0 +1,502 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/liamg/memoryfs"
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	kyaml "sigs.k8s.io/yaml"
+
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	helmparser "github.com/aquasecurity/trivy/pkg/iac/scanners/helm/parser"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// maxGitOpsDepth limits the resolution of sources referring to other sources, e.g. Argo CD apps of apps
+const maxGitOpsDepth = 10
+
+// gitOpsSource is a directory of the repository referred to by an Argo CD Application,
+// a Flux Kustomization or a Flux HelmRelease
+type gitOpsSource struct {
+	// object is the kind and the name of the object referring to the source, e.g. "Application/guestbook"
+	object string
+	// dir is the directory of the manifests, the Kustomization or the chart, relative to the root of the repository
+	dir string
+	// recurse reads the manifests in the subdirectories of directories without Kustomization
+	recurse bool
+	helm    *helmSource
+}
+
+// helmSource are the settings of the release of a chart
+type helmSource struct {
+	releaseName string
+	namespace   string
+	// valueFiles are relative to the root of the repository
+	valueFiles []string
+	values     map[string]any
+	// parameters are set as "--set" values, e.g. "image.tag=v1"
+	parameters []string
+}
+
+// gitOps resolves the sources referred to by GitOps objects in a repository
+type gitOps struct {
+	logger *log.Logger
+	srcFS  fs.FS
+
+	// files are all the files of the repository, loaded for kustomize
+	files map[string][]byte
+	kfs   filesys.FileSystem
+
+	// consumed are the files the sources consist of, which are not scanned on their own
+	consumed map[string]struct{}
+	visited  map[string]struct{}
+}
+
+// renderGitOpsSources renders the sources referred to by Argo CD Applications, Flux Kustomizations and Flux HelmReleases,
+// and appends the rendered manifests to the files of the objects referring to them,
+// so that the effective workloads are scanned instead of the pointers to them.
+// srcFS is the file system of the repository, fsys is the file system to be scanned,
+// which only has manifests if the Kustomizations have been rendered.
+// Sources which cannot be rendered, e.g. in other repositories, are skipped.
+func (s *Scanner) renderGitOpsSources(ctx context.Context, srcFS, fsys fs.FS, filtered bool, dir string) (fs.FS, error) {
+	dir = filepath.ToSlash(dir)
+
+	contents := make(map[string][]byte)
+	var filePaths []string
+	if err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+		if !filtered && (IsKustomization(filePath) ||
+			!detection.IsType(filePath, bytes.NewReader(b), detection.FileTypeKubernetes)) {
+			return nil
+		}
+		contents[filePath] = b
+		filePaths = append(filePaths, filePath)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	g := &gitOps{
+		logger:   s.logger,
+		srcFS:    srcFS,
+		consumed: make(map[string]struct{}),
+		visited:  make(map[string]struct{}),
+	}
+	rendered := make(map[string][]byte)
+	for _, filePath := range filePaths {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		if !isInDir(filePath, dir) {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := g.resolve(ctx, contents[filePath], &buf, 0); err != nil {
+			return nil, err
+		}
+		if buf.Len() > 0 {
+			rendered[filePath] = buf.Bytes()
+		}
+	}
+
+	resolvedFS := memoryfs.New()
+	for _, filePath := range filePaths {
+		b, ok := rendered[filePath]
+		if _, consumed := g.consumed[filePath]; consumed && !ok {
+			continue
+		}
+		if ok {
+			s.logger.Debug("GitOps sources rendered", log.FilePath(filePath))
+			b = slices.Concat(contents[filePath], []byte("\n---\n"), b)
+		} else {
+			b = contents[filePath]
+		}
+		if err := resolvedFS.MkdirAll(path.Dir(filePath), fs.ModePerm); err != nil {
+			return nil, err
+		}
+		if err := resolvedFS.WriteFile(filePath, b, fs.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+	return resolvedFS, nil
+}
+
+// resolve writes the manifests of the sources referred to by the objects in the manifest
+func (g *gitOps) resolve(ctx context.Context, manifest []byte, w io.Writer, depth int) error {
+	if depth >= maxGitOpsDepth {
+		return nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest))
+	for {
+		var doc map[string]any
+		if err := decoder.Decode(&doc); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			// Invalid manifests are reported when scanning them
+			return nil
+		}
+
+		for _, src := range gitOpsSources(doc) {
+			key := src.object + "@" + src.dir
+			if _, ok := g.visited[key]; ok {
+				continue
+			}
+			g.visited[key] = struct{}{}
+
+			b, err := g.render(ctx, src)
+			if err != nil {
+				g.logger.Debug("Unable to render GitOps source", log.String("object", src.object),
+					log.FilePath(src.dir), log.Err(err))
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s\n---\n", bytes.TrimSpace(b)); err != nil {
+				return err
+			}
+			if err := g.resolve(ctx, b, w, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// render renders the source as a chart, a Kustomization or a directory of manifests
+func (g *gitOps) render(ctx context.Context, src gitOpsSource) ([]byte, error) {
+	info, err := fs.Stat(g.srcFS, src.dir)
+	if err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("%q is not a directory", src.dir)
+	}
+
+	switch {
+	case src.helm != nil || exists(g.srcFS, path.Join(src.dir, "Chart.yaml")):
+		return g.renderChart(ctx, src)
+	case slices.ContainsFunc(konfig.RecognizedKustomizationFileNames(), func(name string) bool {
+		return exists(g.srcFS, path.Join(src.dir, name))
+	}):
+		return g.renderKustomization(src)
+	default:
+		return g.readManifests(src)
+	}
+}
+
+func (g *gitOps) renderChart(ctx context.Context, src gitOpsSource) ([]byte, error) {
+	helm := src.helm
+	if helm == nil {
+		helm = &helmSource{}
+	}
+
+	values := make(map[string]any)
+	for _, valueFile := range helm.valueFiles {
+		b, err := fs.ReadFile(g.srcFS, valueFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read values file: %w", err)
+		}
+		var vals map[string]any
+		if err := kyaml.Unmarshal(b, &vals); err != nil {
+			return nil, fmt.Errorf("invalid values file %q: %w", valueFile, err)
+		}
+		mergeValues(values, vals)
+	}
+	mergeValues(values, helm.values)
+
+	opts := []helmparser.Option{
+		helmparser.OptionWithValuesObject(values),
+		helmparser.OptionWithValues(helm.parameters...),
+		helmparser.OptionWithNamespace(helm.namespace),
+	}
+	if helm.releaseName != "" {
+		opts = append(opts, helmparser.OptionWithReleaseName(helm.releaseName))
+	}
+	p, err := helmparser.New(src.dir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.ParseFS(ctx, g.srcFS, src.dir); err != nil {
+		return nil, err
+	}
+	chartFiles, err := p.RenderedChartFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, file := range chartFiles {
+		buf.WriteString(strings.TrimSpace(file.ManifestContent))
+		buf.WriteString("\n---\n")
+	}
+	if err := fs.WalkDir(g.srcFS, src.dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			g.consumed[filePath] = struct{}{}
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (g *gitOps) renderKustomization(src gitOpsSource) ([]byte, error) {
+	if g.kfs == nil {
+		g.files = make(map[string][]byte)
+		if err := fs.WalkDir(g.srcFS, ".", func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			b, err := fs.ReadFile(g.srcFS, filePath)
+			if err != nil {
+				return err
+			}
+			g.files[filePath] = b
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		kfs, err := kustomizeFS(g.files)
+		if err != nil {
+			return nil, err
+		}
+		g.kfs = kfs
+	}
+
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if _, ok := g.files[path.Join(src.dir, name)]; !ok {
+			continue
+		}
+		k, err := renderKustomization(g.kfs, path.Join(src.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(g.consumed, k.read)
+		return k.manifest, nil
+	}
+	return nil, errors.New("kustomization not found")
+}
+
+// readManifests reads the manifests in the directory as Argo CD and Flux do for directories without Kustomization
+func (g *gitOps) readManifests(src gitOpsSource) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := fs.WalkDir(g.srcFS, src.dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() {
+			if filePath != src.dir && !src.recurse {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		b, err := fs.ReadFile(g.srcFS, filePath)
+		if err != nil {
+			return err
+		}
+		if !detection.IsType(filePath, bytes.NewReader(b), detection.FileTypeKubernetes) {
+			return nil
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(b)), "{") {
+			if b, err = kyaml.JSONToYAML(b); err != nil {
+				return nil
+			}
+		}
+		g.consumed[filePath] = struct{}{}
+		buf.Write(bytes.TrimSpace(b))
+		buf.WriteString("\n---\n")
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// gitOpsSources returns the sources in the repository referred to by the object
+func gitOpsSources(doc map[string]any) []gitOpsSource {
+	apiVersion, _ := doc["apiVersion"].(string)
+	kind, _ := doc["kind"].(string)
+	metadata, _ := doc["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	namespace, _ := metadata["namespace"].(string)
+	spec, _ := doc["spec"].(map[string]any)
+	object := kind + "/" + name
+
+	switch {
+	case strings.HasPrefix(apiVersion, "argoproj.io/") && kind == "Application":
+		destination, _ := spec["destination"].(map[string]any)
+		destNamespace, _ := destination["namespace"].(string)
+
+		sources, _ := spec["sources"].([]any)
+		if source, ok := spec["source"]; ok {
+			sources = append(sources, source)
+		}
+		var result []gitOpsSource
+		for _, item := range sources {
+			source, _ := item.(map[string]any)
+			src, ok := argoSource(source, object, name, destNamespace)
+			if ok {
+				result = append(result, src)
+			}
+		}
+		return result
+	case strings.HasPrefix(apiVersion, "kustomize.toolkit.fluxcd.io/") && kind == "Kustomization":
+		if !isGitRepositoryRef(spec["sourceRef"]) {
+			return nil
+		}
+		p, _ := spec["path"].(string)
+		dir, ok := repoPath(".", p)
+		if !ok {
+			return nil
+		}
+		return []gitOpsSource{{object: object, dir: dir, recurse: true}}
+	case strings.HasPrefix(apiVersion, "helm.toolkit.fluxcd.io/") && kind == "HelmRelease":
+		chart, _ := spec["chart"].(map[string]any)
+		chartSpec, _ := chart["spec"].(map[string]any)
+		if !isGitRepositoryRef(chartSpec["sourceRef"]) {
+			return nil
+		}
+		chartPath, _ := chartSpec["chart"].(string)
+		dir, ok := repoPath(".", chartPath)
+		if !ok {
+			return nil
+		}
+
+		helm := &helmSource{releaseName: name, namespace: namespace}
+		if releaseName, ok := spec["releaseName"].(string); ok && releaseName != "" {
+			helm.releaseName = releaseName
+		}
+		if targetNamespace, ok := spec["targetNamespace"].(string); ok && targetNamespace != "" {
+			helm.namespace = targetNamespace
+		}
+		valuesFiles, _ := chartSpec["valuesFiles"].([]any)
+		for _, item := range valuesFiles {
+			if valuesFile, ok := item.(string); ok {
+				if p, ok := repoPath(".", valuesFile); ok {
+					helm.valueFiles = append(helm.valueFiles, p)
+				}
+			}
+		}
+		helm.values, _ = spec["values"].(map[string]any)
+		return []gitOpsSource{{object: object, dir: dir, helm: helm}}
+	}
+	return nil
+}
+
+// argoSource returns the source of an Argo CD Application, sources of charts in Helm repositories are skipped
+func argoSource(source map[string]any, object, appName, namespace string) (gitOpsSource, bool) {
+	if _, ok := source["chart"]; ok {
+		return gitOpsSource{}, false
+	}
+	p, ok := source["path"].(string)
+	if !ok {
+		return gitOpsSource{}, false
+	}
+	dir, ok := repoPath(".", p)
+	if !ok {
+		return gitOpsSource{}, false
+	}
+	src := gitOpsSource{object: object, dir: dir}
+	directory, _ := source["directory"].(map[string]any)
+	src.recurse, _ = directory["recurse"].(bool)
+
+	helm, ok := source["helm"].(map[string]any)
+	if !ok {
+		return src, true
+	}
+	src.helm = &helmSource{releaseName: appName, namespace: namespace}
+	if releaseName, ok := helm["releaseName"].(string); ok && releaseName != "" {
+		src.helm.releaseName = releaseName
+	}
+	valueFiles, _ := helm["valueFiles"].([]any)
+	for _, item := range valueFiles {
+		valueFile, _ := item.(string)
+		// Value files of other sources, e.g. "$values/prod.yaml", are not resolved
+		if strings.HasPrefix(valueFile, "$") {
+			continue
+		}
+		if p, ok := repoPath(dir, valueFile); ok {
+			src.helm.valueFiles = append(src.helm.valueFiles, p)
+		}
+	}
+	values := make(map[string]any)
+	if s, ok := helm["values"].(string); ok {
+		var vals map[string]any
+		if err := kyaml.Unmarshal([]byte(s), &vals); err == nil {
+			mergeValues(values, vals)
+		}
+	}
+	if valuesObject, ok := helm["valuesObject"].(map[string]any); ok {
+		mergeValues(values, valuesObject)
+	}
+	src.helm.values = values
+	parameters, _ := helm["parameters"].([]any)
+	for _, item := range parameters {
+		param, _ := item.(map[string]any)
+		name, _ := param["name"].(string)
+		value, _ := param["value"].(string)
+		if name != "" {
+			src.helm.parameters = append(src.helm.parameters, name+"="+value)
+		}
+	}
+	return src, true
+}
+
+// isGitRepositoryRef checks if the Flux source reference is a Git repository, which is assumed to be the scanned one
+func isGitRepositoryRef(ref any) bool {
+	m, _ := ref.(map[string]any)
+	kind, _ := m["kind"].(string)
+	return kind == "GitRepository"
+}
+
+// repoPath returns the path relative to the directory in the repository, paths outside the repository are invalid
+func repoPath(dir, p string) (string, bool) {
+	if strings.HasPrefix(p, "/") {
+		dir = "."
+	}
+	p = path.Clean(path.Join(dir, strings.TrimPrefix(p, "/")))
+	if p == ".." || strings.HasPrefix(p, "../") {
+		return "", false
+	}
+	return p, true
+}
+
+// mergeValues merges the values into dst, values of nested maps are merged as Helm does
+func mergeValues(dst, src map[string]any) {
+	for key, val := range src {
+		if m, ok := val.(map[string]any); ok {
+			if d, ok := dst[key].(map[string]any); ok {
+				mergeValues(d, m)
+				continue
+			}
+			d := make(map[string]any)
+			mergeValues(d, m)
+			dst[key] = d
+			continue
+		}
+		dst[key] = val
+	}
+}
+
+func exists(fsys fs.FS, filePath string) bool {
+	_, err := fs.Stat(fsys, filePath)
+	return err == nil
+}
@@ -0,0 +1,232 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/liamg/memoryfs"
+	"github.com/samber/lo"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	ktypes "sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// IsKustomization checks if the file is a Kustomization file, e.g. "kustomization.yaml"
+func IsKustomization(filePath string) bool {
+	return slices.Contains(konfig.RecognizedKustomizationFileNames(), path.Base(filepath.ToSlash(filePath)))
+}
+
+// kustomization is a Kustomization rendered by kustomize
+type kustomization struct {
+	filePath string
+	manifest []byte
+	// read are the files read to render the Kustomization, including the Kustomization file itself
+	read map[string]struct{}
+}
+
+// renderKustomizations renders the Kustomizations in the directory and returns the file system to be scanned,
+// and whether it is a new file system with the rendered and the other manifests only.
+// The effective manifests of an overlay are scanned as its Kustomization file,
+// instead of the bases, resources and patches the overlay consists of.
+// Kustomizations which cannot be rendered are skipped, so that their files are scanned as they are.
+func (s *Scanner) renderKustomizations(ctx context.Context, fsys fs.FS, dir string) (fs.FS, bool, error) {
+	dir = filepath.ToSlash(dir)
+
+	// Bases may be outside the directory, so all the files are loaded.
+	files := make(map[string][]byte)
+	var filePaths, kustomizationPaths []string
+	if err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+		files[filePath] = b
+		filePaths = append(filePaths, filePath)
+		if IsKustomization(filePath) && isInDir(filePath, dir) {
+			kustomizationPaths = append(kustomizationPaths, filePath)
+		}
+		return nil
+	}); err != nil {
+		return nil, false, err
+	}
+
+	if len(kustomizationPaths) == 0 {
+		return fsys, false, nil
+	}
+
+	memFS, err := kustomizeFS(files)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var rendered []kustomization
+	for _, kustomizationPath := range kustomizationPaths {
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		default:
+		}
+		k, err := renderKustomization(memFS, kustomizationPath)
+		if err != nil {
+			s.logger.Debug("Unable to render Kustomization", log.FilePath(kustomizationPath), log.Err(err))
+			continue
+		}
+		rendered = append(rendered, k)
+	}
+
+	// Only overlays not referred to by other overlays are scanned, and the files they consist of are not.
+	overlays := lo.Filter(rendered, func(k kustomization, _ int) bool {
+		return !lo.ContainsBy(rendered, func(other kustomization) bool {
+			_, ok := other.read[k.filePath]
+			return other.filePath != k.filePath && ok
+		})
+	})
+	consumed := make(map[string]struct{})
+	for _, overlay := range overlays {
+		maps.Copy(consumed, overlay.read)
+	}
+
+	renderedFS := memoryfs.New()
+	writeFile := func(filePath string, b []byte) error {
+		if err := renderedFS.MkdirAll(path.Dir(filePath), fs.ModePerm); err != nil {
+			return err
+		}
+		return renderedFS.WriteFile(filePath, b, fs.ModePerm)
+	}
+	for _, filePath := range filePaths {
+		if _, ok := consumed[filePath]; ok || IsKustomization(filePath) {
+			continue
+		}
+		// Files which are not manifests, such as JSON patches and generator sources, are only used for rendering
+		if !detection.IsType(filePath, bytes.NewReader(files[filePath]), detection.FileTypeKubernetes) {
+			continue
+		}
+		if err := writeFile(filePath, files[filePath]); err != nil {
+			return nil, false, err
+		}
+	}
+	for _, overlay := range overlays {
+		s.logger.Debug("Kustomization rendered", log.FilePath(overlay.filePath))
+		if err := writeFile(overlay.filePath, overlay.manifest); err != nil {
+			return nil, false, err
+		}
+	}
+	return renderedFS, true, nil
+}
+
+// kustomizeFS returns an in-memory file system for kustomize with the files at the root
+func kustomizeFS(files map[string][]byte) (filesys.FileSystem, error) {
+	memFS := filesys.MakeFsInMemory()
+	for filePath, b := range files {
+		if err := memFS.MkdirAll(path.Join("/", path.Dir(filePath))); err != nil {
+			return nil, err
+		}
+		if err := memFS.WriteFile(path.Join("/", filePath), b); err != nil {
+			return nil, err
+		}
+	}
+	return memFS, nil
+}
+
+// renderKustomization renders the Kustomization as "kustomize build" does
+func renderKustomization(fSys filesys.FileSystem, kustomizationPath string) (kustomization, error) {
+	kustomizationDir := path.Dir(kustomizationPath)
+	// kustomize fetches remote resources, which is not allowed while scanning
+	if err := checkLocalResources(fSys, kustomizationDir, make(map[string]struct{})); err != nil {
+		return kustomization{}, err
+	}
+
+	recorder := &readRecorder{
+		FileSystem: fSys,
+		read:       make(map[string]struct{}),
+	}
+	k := krusty.MakeKustomizer(krusty.MakeDefaultOptions())
+	resMap, err := k.Run(recorder, path.Join("/", kustomizationDir))
+	if err != nil {
+		return kustomization{}, err
+	}
+	manifest, err := resMap.AsYaml()
+	if err != nil {
+		return kustomization{}, err
+	}
+	return kustomization{
+		filePath: kustomizationPath,
+		manifest: manifest,
+		read:     recorder.read,
+	}, nil
+}
+
+// checkLocalResources checks that the resources, bases and components of the Kustomization
+// and the Kustomizations it refers to are in the file system
+func checkLocalResources(fSys filesys.FileSystem, dir string, visited map[string]struct{}) error {
+	if _, ok := visited[dir]; ok {
+		return nil
+	}
+	visited[dir] = struct{}{}
+
+	k, err := readKustomization(fSys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range slices.Concat(k.Resources, k.Bases, k.Components, k.Crds) {
+		refPath := path.Join("/", dir, ref)
+		if strings.Contains(ref, "://") || !fSys.Exists(refPath) {
+			return fmt.Errorf("resource %q not found locally", ref)
+		}
+		if fSys.IsDir(refPath) {
+			if err := checkLocalResources(fSys, path.Join(dir, ref), visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readKustomization(fSys filesys.FileSystem, dir string) (*ktypes.Kustomization, error) {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		b, err := fSys.ReadFile(path.Join("/", dir, fileName))
+		if err != nil {
+			continue
+		}
+		var k ktypes.Kustomization
+		if err := yaml.Unmarshal(b, &k); err != nil {
+			return nil, fmt.Errorf("invalid kustomization: %w", err)
+		}
+		return &k, nil
+	}
+	return nil, errors.New("kustomization not found")
+}
+
+// readRecorder records the files read by kustomize
+type readRecorder struct {
+	filesys.FileSystem
+	read map[string]struct{}
+}
+
+func (r *readRecorder) ReadFile(filePath string) ([]byte, error) {
+	r.read[strings.TrimPrefix(path.Clean(filePath), "/")] = struct{}{}
+	return r.FileSystem.ReadFile(filePath)
+}
+
+func isInDir(filePath, dir string) bool {
+	return dir == "." || dir == "" || filePath == dir || strings.HasPrefix(filePath, strings.TrimSuffix(dir, "/")+"/")
+}
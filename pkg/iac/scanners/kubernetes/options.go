@@ -0,0 +1,54 @@
+package kubernetes
+
+import (
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+)
+
+// ScannerWithSchemaDir validates manifests against the JSON schemas in the directory,
+// laid out like https://github.com/yannh/kubernetes-json-schema
+func ScannerWithSchemaDir(dir string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if k8sScanner, ok := s.(*Scanner); ok {
+			k8sScanner.schemaDir = dir
+		}
+	}
+}
+
+// ScannerWithSchemaVersion sets the Kubernetes version of the schemas, e.g. "1.29.0"
+func ScannerWithSchemaVersion(version string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if k8sScanner, ok := s.(*Scanner); ok {
+			k8sScanner.schemaVersion = version
+		}
+	}
+}
+
+// ScannerWithUpgradeVersion reports apiVersions deprecated or removed in the Kubernetes version, e.g. "1.29.0"
+func ScannerWithUpgradeVersion(version string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if k8sScanner, ok := s.(*Scanner); ok {
+			k8sScanner.upgradeVersion = version
+		}
+	}
+}
+
+// ScannerWithCRDSchemas validates custom resources against the schemas of their CRDs, and types their values
+// for checks. Paths are CRD manifests, directories of them, or directories of JSON schemas
+// laid out like https://github.com/datreeio/CRDs-catalog
+func ScannerWithCRDSchemas(paths ...string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if k8sScanner, ok := s.(*Scanner); ok {
+			k8sScanner.crdPaths = append(k8sScanner.crdPaths, paths...)
+		}
+	}
+}
+
+// ScannerWithGitOpsResolution renders the charts, Kustomizations and manifests in the repository
+// referred to by Argo CD Applications, Flux Kustomizations and Flux HelmReleases
+func ScannerWithGitOpsResolution(enabled bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if k8sScanner, ok := s.(*Scanner); ok {
+			k8sScanner.resolveGitOps = enabled
+		}
+	}
+}
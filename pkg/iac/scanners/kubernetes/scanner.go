@@ -3,17 +3,129 @@ package kubernetes
 import (
 	"context"
 	"io"
+	"io/fs"
+	"path/filepath"
+	"sync"
 
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/kubernetes/parser"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
 	"github.com/aquasecurity/trivy/pkg/iac/types"
+	"github.com/aquasecurity/trivy/pkg/log"
 )
 
-func NewScanner(opts ...options.ScannerOption) *generic.GenericScanner {
-	return generic.NewScanner("Kubernetes", types.SourceKubernetes, generic.ParseFunc(parse), opts...)
+var _ scanners.FSScanner = (*Scanner)(nil)
+var _ options.ConfigurableScanner = (*Scanner)(nil)
+
+// Scanner scans Kubernetes manifests with Rego checks.
+// Kustomize overlays are rendered before scanning, as are the sources of GitOps objects when configured.
+// Manifests are also validated against the Kubernetes schemas and checked for deprecated apiVersions when configured.
+// Custom resources are validated against the schemas of their CRDs, which also type their values for checks.
+type Scanner struct {
+	*generic.GenericScanner
+	logger *log.Logger
+
+	schemaDir      string
+	schemaVersion  string
+	upgradeVersion string
+	resolveGitOps  bool
+
+	crdPaths []string
+	crdOnce  sync.Once
+	crds     crdSchemas
+	crdErr   error
+}
+
+func NewScanner(opts ...options.ScannerOption) *Scanner {
+	s := &Scanner{
+		logger: log.WithPrefix("kubernetes scanner"),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.GenericScanner = generic.NewScanner("Kubernetes", types.SourceKubernetes, generic.ParseFunc(s.parse), opts...)
+	return s
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, fsys fs.FS, dir string) (scan.Results, error) {
+	renderedFS, rendered, err := s.renderKustomizations(ctx, fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	if s.resolveGitOps {
+		if renderedFS, err = s.renderGitOpsSources(ctx, fsys, renderedFS, rendered, dir); err != nil {
+			return nil, err
+		}
+	}
+	fsys = renderedFS
+
+	crds, err := s.loadCRDs()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.GenericScanner.ScanFS(ctx, fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	if s.schemaDir == "" && s.upgradeVersion == "" && len(crds) == 0 {
+		return results, nil
+	}
+
+	validator, err := newValidator(s.schemaDir, s.schemaVersion, s.upgradeVersion, crds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fs.WalkDir(fsys, filepath.ToSlash(dir), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() {
+			return nil
+		}
+
+		f, err := fsys.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		manifests, err := parser.Parse(ctx, f, path)
+		if err != nil {
+			s.logger.Debug("Failed to parse file", log.FilePath(path), log.Err(err))
+			return nil
+		}
+		for _, manifest := range manifests {
+			results = append(results, validator.validate(manifest)...)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	results.SetSourceAndFilesystem("", fsys, false)
+	return results, nil
+}
+
+// loadCRDs loads the CRD schemas once, as they do not change between scans
+func (s *Scanner) loadCRDs() (crdSchemas, error) {
+	s.crdOnce.Do(func() {
+		if len(s.crdPaths) > 0 {
+			s.crds, s.crdErr = loadCRDSchemas(s.crdPaths)
+		}
+	})
+	return s.crds, s.crdErr
 }
 
-func parse(ctx context.Context, r io.Reader, path string) (any, error) {
-	return parser.Parse(ctx, r, path)
+func (s *Scanner) parse(ctx context.Context, r io.Reader, path string) (any, error) {
+	manifests, err := parser.Parse(ctx, r, path)
+	if err != nil {
+		return nil, err
+	}
+	for _, manifest := range manifests {
+		s.crds.applyTypes(manifest)
+	}
+	return manifests, nil
 }
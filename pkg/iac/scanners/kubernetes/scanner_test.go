@@ -3,6 +3,8 @@ package kubernetes_test
 import (
 	"context"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"testing/fstest"
@@ -305,3 +307,434 @@ func buildFS(files map[string]string) fs.FS {
 		return &fstest.MapFile{Data: []byte(val)}
 	}))
 }
+
+func Test_ScanFS_SchemaValidation(t *testing.T) {
+	schemaDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(schemaDir, "v1.29.0-standalone-strict"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(schemaDir, "v1.29.0-standalone-strict", "deployment-apps-v1.json"), []byte(`{
+  "type": "object",
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "spec": {
+      "type": "object",
+      "properties": {
+        "replicas": {"type": "integer"}
+      },
+      "additionalProperties": false
+    }
+  },
+  "additionalProperties": false
+}`), 0o600))
+
+	file := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unknown-schema
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: three
+  paused: true
+`
+	fsys := buildFS(map[string]string{
+		"code/deployment.yaml": file,
+	})
+
+	scanner := kubernetes.NewScanner(
+		rego.WithEmbeddedPolicies(false),
+		kubernetes.ScannerWithSchemaDir(schemaDir),
+		kubernetes.ScannerWithSchemaVersion("1.29.0"),
+	)
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 2)
+
+	var messages []string
+	for _, failure := range failed {
+		assert.Equal(t, "AVD-K8S-0001", failure.Rule().AVDID)
+		assert.Equal(t, 11, failure.Range().GetStartLine())
+		assert.Equal(t, 12, failure.Range().GetEndLine())
+		messages = append(messages, failure.Description())
+	}
+	assert.ElementsMatch(t, []string{
+		`Deployment "web" is invalid: spec: Additional property paused is not allowed`,
+		`Deployment "web" is invalid: spec.replicas: Invalid type. Expected: integer, given: string`,
+	}, messages)
+	assertLines(t, file, failed)
+}
+
+func Test_ScanFS_DeprecatedAPIs(t *testing.T) {
+	fsys := buildFS(map[string]string{
+		"code/manifests.yaml": `apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+---
+apiVersion: flowcontrol.apiserver.k8s.io/v1beta3
+kind: FlowSchema
+metadata:
+  name: system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`,
+	})
+
+	scanner := kubernetes.NewScanner(
+		rego.WithEmbeddedPolicies(false),
+		kubernetes.ScannerWithUpgradeVersion("1.29.2"),
+	)
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 2)
+
+	assert.Equal(t, "AVD-K8S-0002", failed[0].Rule().AVDID)
+	assert.Equal(t, `CronJob "backup" uses batch/v1beta1 which is removed since Kubernetes 1.25, use batch/v1 instead`,
+		failed[0].Description())
+	assert.Equal(t, 1, failed[0].Range().GetStartLine())
+	assert.Equal(t, 4, failed[0].Range().GetEndLine())
+
+	assert.Equal(t, "AVD-K8S-0003", failed[1].Rule().AVDID)
+	assert.Equal(t, `FlowSchema "system" uses flowcontrol.apiserver.k8s.io/v1beta3 which is deprecated since Kubernetes 1.29, use flowcontrol.apiserver.k8s.io/v1 instead`,
+		failed[1].Description())
+	assert.Equal(t, 6, failed[1].Range().GetStartLine())
+	assert.Equal(t, 9, failed[1].Range().GetEndLine())
+}
+
+func Test_ScanFS_Kustomize(t *testing.T) {
+	fsys := buildFS(map[string]string{
+		"code/base/kustomization.yaml": `resources:
+- deployment.yaml
+`,
+		"code/base/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        securityContext:
+          privileged: false
+`,
+		"code/overlays/prod/kustomization.yaml": `namePrefix: prod-
+resources:
+- ../../base
+patches:
+- path: privileged.yaml
+  target:
+    kind: Deployment
+configMapGenerator:
+- name: web
+  envs:
+  - web.env
+`,
+		"code/overlays/prod/privileged.yaml": `- op: replace
+  path: /spec/template/spec/containers/0/securityContext/privileged
+  value: true
+`,
+		"code/overlays/prod/web.env": "LOG_LEVEL=debug\n",
+		"code/pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+    securityContext:
+      privileged: true
+`,
+		"checks/rule.rego": `# METADATA
+# title: test check
+# custom:
+#   id: KSV017
+#   avd_id: AVD-KSV-0017
+#   severity: HIGH
+#   input:
+#     selector:
+#     - type: kubernetes
+package builtin.kubernetes.KSV017
+
+import data.lib.kubernetes
+
+deny[res] {
+	container := kubernetes.containers[_]
+	container.securityContext.privileged == true
+	res := result.new(sprintf("%s %q is privileged", [kubernetes.kind, kubernetes.name]), container)
+}
+`,
+	})
+
+	scanner := kubernetes.NewScanner(
+		rego.WithPolicyFilesystem(fsys),
+		rego.WithPolicyDirs("checks"),
+		rego.WithEmbeddedLibraries(true),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	require.Len(t, failed, 2)
+
+	messages := lo.Map(failed, func(r scan.Result, _ int) string {
+		return r.Range().GetFilename() + ": " + r.Description()
+	})
+	assert.ElementsMatch(t, []string{
+		`code/overlays/prod/kustomization.yaml: Deployment "prod-web" is privileged`,
+		`code/pod.yaml: Pod "debug" is privileged`,
+	}, messages)
+
+	passed := results.GetPassed()
+	assert.Empty(t, lo.Filter(passed, func(r scan.Result, _ int) bool {
+		return strings.HasPrefix(r.Range().GetFilename(), "code/base/")
+	}))
+}
+
+func Test_ScanFS_CRDSchemas(t *testing.T) {
+	crdDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(crdDir, "crds.yaml"), []byte(`apiVersion: v1
+kind: List
+items:
+- apiVersion: apiextensions.k8s.io/v1
+  kind: CustomResourceDefinition
+  metadata:
+    name: databases.example.com
+  spec:
+    group: example.com
+    names:
+      kind: Database
+    versions:
+    - name: v1
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                engine:
+                  type: string
+                version:
+                  type: string
+                replicas:
+                  type: integer
+                public:
+                  type: boolean
+                  default: false
+                options:
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(crdDir, "catalog", "cache.example.com"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(crdDir, "catalog", "cache.example.com", "redis_v1alpha1.json"), []byte(`{
+  "type": "object",
+  "properties": {
+    "apiVersion": {"type": "string"},
+    "kind": {"type": "string"},
+    "metadata": {"type": "object"},
+    "spec": {
+      "type": "object",
+      "properties": {
+        "size": {"type": "integer"}
+      },
+      "additionalProperties": false
+    }
+  }
+}`), 0o600))
+
+	file := `apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: orders
+spec:
+  engine: postgres
+  version: 16
+  replicas: two
+  backup: true
+  options:
+    max_connections: 100
+---
+apiVersion: cache.example.com/v1alpha1
+kind: Redis
+metadata:
+  name: sessions
+spec:
+  size: 3
+  persistence: false
+`
+	fsys := buildFS(map[string]string{
+		"code/resources.yaml": file,
+		"checks/rule.rego": `# METADATA
+# title: test check
+# custom:
+#   id: USR001
+#   avd_id: USR001
+#   severity: LOW
+#   input:
+#     selector:
+#     - type: kubernetes
+package user.kubernetes.database
+
+import rego.v1
+
+deny contains res if {
+	input.kind == "Database"
+	input.spec.version == "16"
+	input.spec.public == false
+	res := result.new("typed", input.spec)
+}
+`,
+	})
+
+	scanner := kubernetes.NewScanner(
+		rego.WithPolicyFilesystem(fsys),
+		rego.WithPolicyDirs("checks"),
+		rego.WithPolicyNamespaces("user"),
+		rego.WithEmbeddedPolicies(false),
+		kubernetes.ScannerWithCRDSchemas(filepath.Join(crdDir, "crds.yaml"), filepath.Join(crdDir, "catalog")),
+	)
+	results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	messages := lo.Map(failed, func(r scan.Result, _ int) string {
+		return r.Rule().AVDID + ": " + r.Description()
+	})
+	assert.ElementsMatch(t, []string{
+		"USR001: typed",
+		`AVD-K8S-0001: Database "orders" is invalid: spec: Additional property backup is not allowed`,
+		`AVD-K8S-0001: Database "orders" is invalid: spec.version: Invalid type. Expected: string, given: integer`,
+		`AVD-K8S-0001: Database "orders" is invalid: spec.replicas: Invalid type. Expected: integer, given: string`,
+		`AVD-K8S-0001: Redis "sessions" is invalid: spec: Additional property persistence is not allowed`,
+	}, messages)
+	assertLines(t, file, failed)
+}
+
+func Test_ScanFS_GitOps(t *testing.T) {
+	fsys := buildFS(map[string]string{
+		"argocd/web.yaml": `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: web
+spec:
+  source:
+    repoURL: https://github.com/example/gitops.git
+    path: charts/web
+    helm:
+      valueFiles:
+      - values-prod.yaml
+      valuesObject:
+        replicas: 3
+  destination:
+    namespace: prod
+`,
+		"charts/web/Chart.yaml": `apiVersion: v2
+name: web
+version: 0.1.0
+`,
+		"charts/web/values.yaml": `privileged: false
+replicas: 1
+`,
+		"charts/web/values-prod.yaml": `privileged: true
+`,
+		"charts/web/templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  namespace: {{ .Release.Namespace }}
+spec:
+  replicas: {{ .Values.replicas }}
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx
+        securityContext:
+          privileged: {{ .Values.privileged }}
+`,
+		"clusters/prod/apps.yaml": `apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: apps
+spec:
+  path: ./apps/prod
+  sourceRef:
+    kind: GitRepository
+    name: flux-system
+---
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: remote
+spec:
+  path: ./apps
+  sourceRef:
+    kind: OCIRepository
+    name: remote
+`,
+		"apps/prod/debug/pod.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+    securityContext:
+      privileged: true
+`,
+		"checks/rule.rego": `# METADATA
+# title: test check
+# custom:
+#   id: KSV017
+#   avd_id: AVD-KSV-0017
+#   severity: HIGH
+#   input:
+#     selector:
+#     - type: kubernetes
+package builtin.kubernetes.KSV017
+
+import data.lib.kubernetes
+
+deny[res] {
+	container := kubernetes.containers[_]
+	container.securityContext.privileged == true
+	res := result.new(sprintf("%s %q in %q is privileged", [kubernetes.kind, kubernetes.name, kubernetes.namespace]), container)
+}
+`,
+	})
+
+	scanner := kubernetes.NewScanner(
+		rego.WithPolicyFilesystem(fsys),
+		rego.WithPolicyDirs("checks"),
+		rego.WithEmbeddedLibraries(true),
+		kubernetes.ScannerWithGitOpsResolution(true),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+
+	failed := results.GetFailed()
+	messages := lo.Map(failed, func(r scan.Result, _ int) string {
+		return r.Range().GetFilename() + ": " + r.Description()
+	})
+	assert.ElementsMatch(t, []string{
+		`argocd/web.yaml: Deployment "web" in "prod" is privileged`,
+		`clusters/prod/apps.yaml: Pod "debug" in "default" is privileged`,
+	}, messages)
+}
@@ -0,0 +1,231 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/go-version/pkg/version"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/aquasecurity/trivy/pkg/iac/providers"
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/severity"
+	iacTypes "github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+const metadataKey = "__defsec_metadata"
+
+var schemaViolationRule = scan.Rule{
+	AVDID:     "AVD-K8S-0001",
+	ShortCode: "valid-manifest-schema",
+	Summary:   "Manifests should be valid for the Kubernetes version",
+	Explanation: "Manifests not matching the Kubernetes schema, e.g. with unknown fields or values of wrong types, " +
+		"are rejected by the API server or silently lose the invalid fields.",
+	Resolution: "Fix the manifest according to the API reference of the Kubernetes version.",
+	Provider:   providers.KubernetesProvider,
+	Service:    "general",
+	Links:      []string{"https://kubernetes.io/docs/reference/kubernetes-api/"},
+	Severity:   severity.Medium,
+}
+
+// validator validates manifests against the Kubernetes schemas and checks their apiVersions
+type validator struct {
+	schemaDirs     []string
+	crds           crdSchemas
+	upgradeVersion *version.Version
+
+	// schemas are cached by file name, nil if the schema is not found
+	schemas map[string]*gojsonschema.Schema
+}
+
+func newValidator(schemaDir, schemaVersion, upgradeVersion string, crds crdSchemas) (*validator, error) {
+	v := &validator{
+		schemaDirs: schemaDirs(schemaDir, schemaVersion),
+		crds:       crds,
+		schemas:    make(map[string]*gojsonschema.Schema),
+	}
+	if upgradeVersion != "" {
+		ver, err := version.Parse(strings.TrimPrefix(upgradeVersion, "v"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid upgrade version %q: %w", upgradeVersion, err)
+		}
+		v.upgradeVersion = &ver
+	}
+	return v, nil
+}
+
+// schemaDirs returns the directories the schemas are looked up in, e.g.
+// "v1.29.0-standalone-strict", "v1.29.0-standalone" and "v1.29.0" for the version 1.29.0
+func schemaDirs(dir, schemaVersion string) []string {
+	if dir == "" {
+		return nil
+	}
+	if schemaVersion == "" {
+		return []string{dir}
+	}
+	if schemaVersion != "master" && !strings.HasPrefix(schemaVersion, "v") {
+		schemaVersion = "v" + schemaVersion
+	}
+	return []string{
+		filepath.Join(dir, schemaVersion+"-standalone-strict"),
+		filepath.Join(dir, schemaVersion+"-standalone"),
+		filepath.Join(dir, schemaVersion),
+	}
+}
+
+func (v *validator) validate(manifest any) scan.Results {
+	doc, ok := manifest.(map[string]any)
+	if !ok {
+		return nil
+	}
+	apiVersion, _ := doc["apiVersion"].(string)
+	kind, _ := doc["kind"].(string)
+	if apiVersion == "" || kind == "" {
+		return nil
+	}
+
+	var results scan.Results
+	if v.upgradeVersion != nil {
+		results = append(results, v.checkAPIVersion(doc, apiVersion, kind)...)
+	}
+	if len(v.schemaDirs) > 0 || len(v.crds) > 0 {
+		results = append(results, v.validateSchema(doc, apiVersion, kind)...)
+	}
+	return results
+}
+
+func (v *validator) validateSchema(doc map[string]any, apiVersion, kind string) scan.Results {
+	schema, err := v.schema(schemaFileName(apiVersion, kind))
+	if err != nil {
+		return nil
+	}
+	if schema == nil {
+		// Custom resources are validated against the schemas of their CRDs
+		crd := v.crds.lookup(apiVersion, kind)
+		if crd == nil {
+			return nil
+		}
+		schema = crd.schema
+	}
+
+	res, err := schema.Validate(gojsonschema.NewGoLoader(stripMetadata(doc)))
+	if err != nil {
+		return nil
+	}
+
+	var results scan.Results
+	for _, resErr := range res.Errors() {
+		var r scan.Results
+		r.Add(fmt.Sprintf("%s %q is invalid: %s", kind, resourceName(doc), resErr.String()),
+			nodeMetadata(doc, resErr.Field()))
+		r.SetRule(schemaViolationRule)
+		results = append(results, r...)
+	}
+	return results
+}
+
+// schema returns the schema in the first schema directory containing the file
+func (v *validator) schema(fileName string) (*gojsonschema.Schema, error) {
+	if schema, ok := v.schemas[fileName]; ok {
+		return schema, nil
+	}
+
+	var schema *gojsonschema.Schema
+	for _, dir := range v.schemaDirs {
+		b, err := os.ReadFile(filepath.Join(dir, fileName))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if schema, err = gojsonschema.NewSchema(gojsonschema.NewBytesLoader(b)); err != nil {
+			return nil, fmt.Errorf("invalid schema %q: %w", fileName, err)
+		}
+		break
+	}
+	v.schemas[fileName] = schema
+	return schema, nil
+}
+
+// schemaFileName returns the name of the schema file for the resource,
+// e.g. "deployment-apps-v1.json" for apps/v1 Deployment and "pod-v1.json" for v1 Pod
+func schemaFileName(apiVersion, kind string) string {
+	kind = strings.ToLower(kind)
+	group, ver, ok := strings.Cut(apiVersion, "/")
+	if !ok {
+		return fmt.Sprintf("%s-%s.json", kind, apiVersion)
+	}
+	group, _, _ = strings.Cut(group, ".")
+	return fmt.Sprintf("%s-%s-%s.json", kind, group, ver)
+}
+
+func resourceName(doc map[string]any) string {
+	metadata, _ := doc["metadata"].(map[string]any)
+	name, _ := metadata["name"].(string)
+	return name
+}
+
+// stripMetadata returns the manifest without the metadata added by the parser
+func stripMetadata(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, child := range v {
+			if key != metadataKey {
+				m[key] = stripMetadata(child)
+			}
+		}
+		return m
+	case []any:
+		s := make([]any, 0, len(v))
+		for _, child := range v {
+			s = append(s, stripMetadata(child))
+		}
+		return s
+	default:
+		return v
+	}
+}
+
+// nodeMetadata returns the metadata of the innermost object containing the field, e.g. "spec.template.spec"
+func nodeMetadata(doc map[string]any, field string) iacTypes.Metadata {
+	// Only the document has the offset of the document in the file
+	raw, _ := doc[metadataKey].(map[string]any)
+	offset, _ := raw["offset"].(int)
+
+	metadata := toMetadata(doc, offset)
+	var node any = doc
+	for _, part := range strings.Split(field, ".") {
+		switch n := node.(type) {
+		case map[string]any:
+			node = n[part]
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(n) {
+				return metadata
+			}
+			node = n[i]
+		default:
+			return metadata
+		}
+		if m, ok := node.(map[string]any); ok {
+			metadata = toMetadata(m, offset)
+		}
+	}
+	return metadata
+}
+
+func toMetadata(m map[string]any, offset int) iacTypes.Metadata {
+	raw, _ := m[metadataKey].(map[string]any)
+	filePath, _ := raw["filepath"].(string)
+	startLine, _ := raw["startline"].(int)
+	endLine, _ := raw["endline"].(int)
+	return iacTypes.NewMetadata(
+		iacTypes.NewRange(filePath, startLine+offset, endLine+offset, "", nil), "",
+	)
+}
@@ -0,0 +1,91 @@
+package options
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrFileTooLarge = errors.New("file exceeds the maximum size")
+	ErrParseTimeout = errors.New("file parsing timed out")
+)
+
+// ParseLimits limit the files parsed by scanners, so that a single pathological file can't hang a scan.
+// Files exceeding the limits are skipped with a warning. Zero values mean no limit.
+type ParseLimits struct {
+	MaxFileSize int64
+	Timeout     time.Duration
+}
+
+// ConfigurableParseLimits is implemented by the scanners whose parsers support limits
+type ConfigurableParseLimits interface {
+	ConfigurableScanner
+	SetMaxFileSize(size int64)
+	SetParseTimeout(timeout time.Duration)
+}
+
+// ScannerWithMaxFileSize sets the maximum size of the files parsed, in bytes
+func ScannerWithMaxFileSize(size int64) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ls, ok := s.(ConfigurableParseLimits); ok {
+			ls.SetMaxFileSize(size)
+		}
+	}
+}
+
+// ScannerWithParseTimeout sets the maximum time to parse a file
+func ScannerWithParseTimeout(timeout time.Duration) ScannerOption {
+	return func(s ConfigurableScanner) {
+		if ls, ok := s.(ConfigurableParseLimits); ok {
+			ls.SetParseTimeout(timeout)
+		}
+	}
+}
+
+// CheckSize returns ErrFileTooLarge if the size exceeds the maximum size
+func (l ParseLimits) CheckSize(size int64) error {
+	if l.MaxFileSize > 0 && size > l.MaxFileSize {
+		return fmt.Errorf("%w: %d bytes, limit is %d bytes", ErrFileTooLarge, size, l.MaxFileSize)
+	}
+	return nil
+}
+
+// Parse calls parse, and returns ErrParseTimeout if it does not return within the timeout.
+// The context passed to parse is canceled on timeout, parsers ignoring it keep running in the background
+// but their result is discarded.
+func Parse[T any](ctx context.Context, l ParseLimits, parse func(ctx context.Context) (T, error)) (T, error) {
+	if l.Timeout <= 0 {
+		return parse(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.Timeout)
+	defer cancel()
+
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		val, err := parse(ctx)
+		done <- result{val: val, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.val, res.err
+	case <-ctx.Done():
+		var zero T
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return zero, fmt.Errorf("%w after %s", ErrParseTimeout, l.Timeout)
+		}
+		return zero, ctx.Err()
+	}
+}
+
+// IsLimitError returns true if the error is caused by a file exceeding the limits
+func IsLimitError(err error) bool {
+	return errors.Is(err, ErrFileTooLarge) || errors.Is(err, ErrParseTimeout)
+}
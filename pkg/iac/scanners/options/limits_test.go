@@ -0,0 +1,62 @@
+package options
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimits_CheckSize(t *testing.T) {
+	assert.NoError(t, ParseLimits{}.CheckSize(1<<30))
+	assert.NoError(t, ParseLimits{MaxFileSize: 10}.CheckSize(10))
+
+	err := ParseLimits{MaxFileSize: 10}.CheckSize(11)
+	require.ErrorIs(t, err, ErrFileTooLarge)
+	assert.True(t, IsLimitError(err))
+}
+
+func TestParse(t *testing.T) {
+	t.Run("no timeout", func(t *testing.T) {
+		val, err := Parse(context.TODO(), ParseLimits{}, func(context.Context) (int, error) {
+			return 1, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, val)
+	})
+
+	t.Run("within the timeout", func(t *testing.T) {
+		val, err := Parse(context.TODO(), ParseLimits{Timeout: time.Minute}, func(context.Context) (int, error) {
+			return 1, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, val)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		_, err := Parse(context.TODO(), ParseLimits{Timeout: 10 * time.Millisecond}, func(context.Context) (int, error) {
+			// a parser ignoring the context
+			<-release
+			return 1, nil
+		})
+		require.ErrorIs(t, err, ErrParseTimeout)
+		assert.True(t, IsLimitError(err))
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.TODO())
+		cancel()
+
+		_, err := Parse(ctx, ParseLimits{Timeout: time.Minute}, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.False(t, IsLimitError(err))
+	})
+}
@@ -0,0 +1,285 @@
+package parser
+
+import (
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/liamg/memoryfs"
+	"github.com/samber/lo"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// providers maps the Pulumi providers to the Terraform providers they are bridged from
+var providers = map[string]string{
+	"aws":   "aws",
+	"azure": "azurerm",
+	"gcp":   "google",
+}
+
+// modulesWithoutPrefix are the modules whose resource types don't have the module name in Terraform,
+// e.g. "aws:ec2/instance:Instance" is "aws_instance"
+var modulesWithoutPrefix = map[string][]string{
+	"aws":   {"ec2"},
+	"azure": {"core", "containerservice"},
+	"gcp":   {"organizations"},
+}
+
+// moduleNames maps the Pulumi modules named differently from the Terraform resource types
+var moduleNames = map[string]string{
+	"apigateway": "api_gateway",
+	"monitoring": "monitor",
+	"projects":   "project",
+}
+
+// versionSuffix is the suffix of resources replacing deprecated ones, e.g. "BucketV2"
+var versionSuffix = regexp.MustCompile(`V\d+$`)
+
+// resourceTypes maps the Pulumi types which don't follow the naming convention to the Terraform types
+var resourceTypes = map[string]string{
+	"aws:alb:LoadBalancer":        "aws_lb",
+	"aws:alb:Listener":            "aws_lb_listener",
+	"aws:alb:TargetGroup":         "aws_lb_target_group",
+	"aws:lb:LoadBalancer":         "aws_lb",
+	"aws:cloudtrail:Trail":        "aws_cloudtrail",
+	"aws:rds:Instance":            "aws_db_instance",
+	"aws:rds:SecurityGroup":       "aws_db_security_group",
+	"aws:rds:SubnetGroup":         "aws_db_subnet_group",
+	"aws:rds:ParameterGroup":      "aws_db_parameter_group",
+	"aws:ec2:VpcEndpoint":         "aws_vpc_endpoint",
+	"aws:cloudfront:Distribution": "aws_cloudfront_distribution",
+}
+
+// mapAttributes are the attributes whose object values are maps rather than nested blocks
+var mapAttributes = []string{
+	"tags",
+	"tags_all",
+	"labels",
+	"annotations",
+	"metadata",
+	"variables",
+	"parameters",
+}
+
+// ResourceLocation is the location of the resource block in the generated "main.tf"
+type ResourceLocation struct {
+	Resource  Resource
+	StartLine int
+	EndLine   int
+}
+
+// ToFSWithLocations converts the resources of providers bridged from Terraform into HCL,
+// so that the Terraform checks can be run against them.
+// The locations of the resource blocks in "main.tf" are returned to map findings back to the Pulumi resources.
+func ToFSWithLocations(resources []Resource) (*memoryfs.FS, []ResourceLocation, error) {
+	// Pulumi names are unique per type, so they are used as the names of the Terraform resources
+	addresses := make(map[string][]string)
+	for _, r := range resources {
+		if typ := TerraformType(r.Type); typ != "" {
+			addresses[r.Name] = []string{typ, resourceName(r.Name)}
+		}
+	}
+
+	var blocks []string
+	var locations []ResourceLocation
+	line := 1
+	for _, r := range resources {
+		typ := TerraformType(r.Type)
+		if typ == "" {
+			continue
+		}
+		address := []string{typ, resourceName(r.Name)}
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body().AppendNewBlock("resource", address).Body()
+		writeBody(body, r.Properties, addresses)
+
+		hcl := string(hclwrite.Format(f.Bytes()))
+		blocks = append(blocks, hcl)
+
+		endLine := line + strings.Count(strings.TrimSuffix(hcl, "\n"), "\n")
+		locations = append(locations, ResourceLocation{
+			Resource:  r,
+			StartLine: line,
+			EndLine:   endLine,
+		})
+		// blocks are separated by an empty line
+		line = endLine + 2
+	}
+
+	rootFS := memoryfs.New()
+	if err := rootFS.WriteFile("main.tf", []byte(strings.Join(blocks, "\n")), os.ModePerm); err != nil {
+		return nil, nil, err
+	}
+	return rootFS, locations, nil
+}
+
+// TerraformType returns the Terraform resource type of the Pulumi type token, e.g.
+// "aws:s3/bucket:Bucket" and "aws:s3:Bucket" are "aws_s3_bucket".
+// An empty string is returned if the provider is not bridged from Terraform.
+func TerraformType(token string) string {
+	parts := strings.Split(token, ":")
+	if len(parts) != 3 {
+		return ""
+	}
+	provider, module, typ := parts[0], parts[1], parts[2]
+	tfProvider, ok := providers[provider]
+	if !ok {
+		return ""
+	}
+	// Drop the file name from the full form, e.g. "s3/bucket"
+	module, _, _ = strings.Cut(module, "/")
+	typ = versionSuffix.ReplaceAllString(typ, "")
+
+	if tfType, ok := resourceTypes[strings.Join([]string{provider, module, typ}, ":")]; ok {
+		return tfType
+	}
+
+	name := toSnakeCase(typ)
+	if !slices.Contains(modulesWithoutPrefix[provider], module) {
+		prefix := lo.ValueOr(moduleNames, module, module)
+		// The type may already contain the module, e.g. "azure:keyvault/keyVault:KeyVault" is "azurerm_key_vault"
+		if !strings.HasPrefix(strings.ReplaceAll(name, "_", ""), strings.ReplaceAll(prefix, "_", "")) {
+			name = prefix + "_" + name
+		}
+	}
+	return tfProvider + "_" + name
+}
+
+// writeBody writes the properties as attributes and nested blocks
+func writeBody(body *hclwrite.Body, props map[string]any, addresses map[string][]string) {
+	for _, key := range slices.Sorted(maps.Keys(props)) {
+		name := toSnakeCase(key)
+		if !hclsyntax.ValidIdentifier(name) {
+			continue
+		}
+
+		switch val := props[key].(type) {
+		case map[string]any:
+			if slices.Contains(mapAttributes, name) {
+				if tokens := tokensFor(val, addresses); tokens != nil {
+					body.SetAttributeRaw(name, tokens)
+				}
+				continue
+			}
+			writeBody(body.AppendNewBlock(name, nil).Body(), val, addresses)
+		case []any:
+			objects := lo.FilterMap(val, func(v any, _ int) (map[string]any, bool) {
+				m, ok := v.(map[string]any)
+				return m, ok
+			})
+			if len(objects) == 0 {
+				if tokens := tokensFor(val, addresses); tokens != nil {
+					body.SetAttributeRaw(name, tokens)
+				}
+				continue
+			}
+			// Lists of objects are repeated blocks with singular names, e.g. "rules" is "rule"
+			for _, obj := range objects {
+				writeBody(body.AppendNewBlock(singular(name), nil).Body(), obj, addresses)
+			}
+		default:
+			if tokens := tokensFor(val, addresses); tokens != nil {
+				body.SetAttributeRaw(name, tokens)
+			}
+		}
+	}
+}
+
+// tokensFor returns the tokens of the value, or nil if the value can't be represented
+func tokensFor(val any, addresses map[string][]string) hclwrite.Tokens {
+	switch v := val.(type) {
+	case Reference:
+		address, ok := addresses[v.Resource]
+		if !ok {
+			return nil
+		}
+		traversal := hcl.Traversal{
+			hcl.TraverseRoot{Name: address[0]},
+			hcl.TraverseAttr{Name: address[1]},
+		}
+		// A reference to the resource itself is its ID
+		path := lo.Ternary(len(v.Path) == 0, []string{"id"}, v.Path)
+		for _, p := range path {
+			traversal = append(traversal, hcl.TraverseAttr{Name: toSnakeCase(p)})
+		}
+		return hclwrite.TokensForTraversal(traversal)
+	case map[string]any:
+		var items []hclwrite.ObjectAttrTokens
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if tokens := tokensFor(v[k], addresses); tokens != nil {
+				items = append(items, hclwrite.ObjectAttrTokens{
+					Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+					Value: tokens,
+				})
+			}
+		}
+		return hclwrite.TokensForObject(items)
+	case []any:
+		var items []hclwrite.Tokens
+		for _, elem := range v {
+			if tokens := tokensFor(elem, addresses); tokens != nil {
+				items = append(items, tokens)
+			}
+		}
+		return hclwrite.TokensForTuple(items)
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	}
+	return nil
+}
+
+// resourceName converts the Pulumi name to a valid Terraform identifier
+func resourceName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || unicode.IsDigit(rune(name[0])) || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
+
+// toSnakeCase converts the camel case names of Pulumi to the snake case names of Terraform,
+// e.g. "serverSideEncryptionConfiguration" and "IAMMember" are "server_side_encryption_configuration" and "iam_member"
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])) {
+				sb.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// singular returns the singular form of the block name, e.g. "policies" is "policy"
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "ss"), !strings.HasSuffix(name, "s"):
+		return name
+	}
+	return strings.TrimSuffix(name, "s")
+}
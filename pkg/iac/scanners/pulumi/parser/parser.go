@@ -0,0 +1,413 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	// unknownValue is the value of outputs not known until the update, e.g. IDs of created resources
+	unknownValue = "04da6b54-80e4-46f7-96ec-b56ff0331ba9"
+	// secretSig is the key identifying secret values in the preview output
+	secretSig = "4dabf18193072939515e22adb298388d"
+)
+
+// Resource is a resource declared in a Pulumi YAML program or planned in the output of `pulumi preview --json`
+type Resource struct {
+	// Name is the logical name of the resource
+	Name string
+	// Type is the Pulumi type token, e.g. "aws:s3/bucket:Bucket"
+	Type string
+	// Properties are the inputs of the resource
+	Properties map[string]any
+	StartLine  int
+	EndLine    int
+}
+
+// Reference is a reference to a property of another resource of the program, e.g. "${bucket.arn}"
+type Reference struct {
+	Resource string
+	Path     []string
+}
+
+type Parser struct {
+	logger *log.Logger
+}
+
+func New() *Parser {
+	return &Parser{
+		logger: log.WithPrefix("pulumi parser"),
+	}
+}
+
+// Parse parses a Pulumi YAML program or the output of `pulumi preview --json`.
+// Nil is returned for other documents.
+func (p *Parser) Parse(r io.Reader) ([]Resource, error) {
+	// JSON is valid YAML, so the preview output is decoded into nodes as well to get the locations
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to decode: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	root := doc.Content[0]
+
+	if steps := mappingValue(root, "steps"); steps != nil {
+		return p.parsePreview(steps)
+	} else if resources := mappingValue(root, "resources"); resources != nil {
+		return p.parseProgram(root, resources)
+	}
+	return nil, nil
+}
+
+// parsePreview parses the steps of the preview output, e.g.
+//
+//	{"steps": [{"op": "create", "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs", "newState": {"type": "aws:s3/bucket:Bucket", "inputs": {...}}}]}
+func (p *Parser) parsePreview(steps *yaml.Node) ([]Resource, error) {
+	if steps.Kind != yaml.SequenceNode {
+		return nil, nil
+	}
+
+	var resources []Resource
+	for _, step := range steps.Content {
+		var s struct {
+			Op       string `yaml:"op"`
+			URN      string `yaml:"urn"`
+			NewState struct {
+				Type   string         `yaml:"type"`
+				Inputs map[string]any `yaml:"inputs"`
+			} `yaml:"newState"`
+		}
+		if err := step.Decode(&s); err != nil {
+			return nil, fmt.Errorf("failed to decode step: %w", err)
+		}
+		// Deleted resources have no new state
+		if !strings.HasPrefix(s.URN, "urn:pulumi:") || s.Op == "delete" || s.NewState.Type == "" {
+			continue
+		}
+
+		// urn:pulumi:<stack>::<project>::<qualified type>::<name>
+		name := s.URN[strings.LastIndex(s.URN, "::")+2:]
+		props, _ := previewValue(s.NewState.Inputs).(map[string]any)
+		resources = append(resources, Resource{
+			Name:       name,
+			Type:       s.NewState.Type,
+			Properties: props,
+			StartLine:  step.Line,
+			EndLine:    endLine(step),
+		})
+	}
+	return resources, nil
+}
+
+// previewValue removes unknown values and unwraps secrets of the preview output
+func previewValue(v any) any {
+	switch t := v.(type) {
+	case string:
+		if t == unknownValue {
+			return nil
+		}
+	case map[string]any:
+		if _, ok := t[secretSig]; ok {
+			return previewValue(t["value"])
+		}
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			if val = previewValue(val); val != nil {
+				m[k] = val
+			}
+		}
+		return m
+	case []any:
+		var s []any
+		for _, val := range t {
+			if val = previewValue(val); val != nil {
+				s = append(s, val)
+			}
+		}
+		return s
+	}
+	return v
+}
+
+// parseProgram parses the resources of a Pulumi YAML program, e.g.
+//
+//	resources:
+//	  logs:
+//	    type: aws:s3:Bucket
+//	    properties:
+//	      acl: private
+func (p *Parser) parseProgram(root, resourcesNode *yaml.Node) ([]Resource, error) {
+	if resourcesNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	e := &evaluator{
+		values:    make(map[string]any),
+		resources: make(map[string]bool),
+	}
+	for i := 0; i+1 < len(resourcesNode.Content); i += 2 {
+		e.resources[resourcesNode.Content[i].Value] = true
+	}
+
+	// "configuration" is the legacy name of "config"
+	for _, key := range []string{"configuration", "config"} {
+		if config := mappingValue(root, key); config != nil {
+			e.loadConfig(config)
+		}
+	}
+	if variables := mappingValue(root, "variables"); variables != nil && variables.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(variables.Content); i += 2 {
+			if val := e.eval(variables.Content[i+1]); val != nil {
+				e.values[variables.Content[i].Value] = val
+			}
+		}
+	}
+
+	var resources []Resource
+	for i := 0; i+1 < len(resourcesNode.Content); i += 2 {
+		name, node := resourcesNode.Content[i].Value, resourcesNode.Content[i+1]
+		if node.Kind != yaml.MappingNode {
+			continue
+		}
+		typeNode := mappingValue(node, "type")
+		// Resources with "get" are not managed by the program
+		if typeNode == nil || mappingValue(node, "get") != nil {
+			continue
+		}
+
+		props := make(map[string]any)
+		if propsNode := mappingValue(node, "properties"); propsNode != nil {
+			if val, ok := e.eval(propsNode).(map[string]any); ok {
+				props = val
+			}
+		}
+		resources = append(resources, Resource{
+			Name:       name,
+			Type:       typeNode.Value,
+			Properties: props,
+			StartLine:  resourcesNode.Content[i].Line,
+			EndLine:    endLine(node),
+		})
+	}
+	return resources, nil
+}
+
+// evaluator resolves the expressions of a Pulumi YAML program.
+// Values which are not known statically are nil.
+type evaluator struct {
+	// values of the config and variables
+	values map[string]any
+	// resources declared in the program
+	resources map[string]bool
+}
+
+func (e *evaluator) loadConfig(config *yaml.Node) {
+	if config.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(config.Content); i += 2 {
+		name, node := config.Content[i].Value, config.Content[i+1]
+		if node.Kind == yaml.MappingNode {
+			// e.g. bucketName: {type: string, default: logs}
+			node = mappingValue(node, "default")
+		}
+		if node == nil {
+			continue
+		}
+		if val := e.eval(node); val != nil {
+			e.values[name] = val
+		}
+	}
+}
+
+// eval returns the value of the node, which is nil if it's not known
+func (e *evaluator) eval(node *yaml.Node) any {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return e.eval(node.Alias)
+	case yaml.ScalarNode:
+		var val any
+		if err := node.Decode(&val); err != nil {
+			return nil
+		}
+		if s, ok := val.(string); ok {
+			return e.interpolate(s)
+		}
+		return val
+	case yaml.SequenceNode:
+		s := make([]any, 0, len(node.Content))
+		for _, n := range node.Content {
+			if val := e.eval(n); val != nil {
+				s = append(s, val)
+			}
+		}
+		return s
+	case yaml.MappingNode:
+		if len(node.Content) == 2 && strings.HasPrefix(node.Content[0].Value, "fn::") {
+			return e.evalFunction(node.Content[0].Value, node.Content[1])
+		}
+		m := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if val := e.eval(node.Content[i+1]); val != nil {
+				m[node.Content[i].Value] = val
+			}
+		}
+		return m
+	}
+	return nil
+}
+
+// evalFunction evaluates built-in functions. Invokes and functions reading files or assets are not known.
+func (e *evaluator) evalFunction(name string, arg *yaml.Node) any {
+	switch name {
+	case "fn::secret":
+		return e.eval(arg)
+	case "fn::toJSON":
+		val := e.eval(arg)
+		if val == nil || hasReference(val) {
+			return nil
+		}
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil
+		}
+		return string(b)
+	case "fn::join":
+		// fn::join: [",", ["a", "b"]]
+		args, ok := e.eval(arg).([]any)
+		if !ok || len(args) != 2 {
+			return nil
+		}
+		sep, ok := args[0].(string)
+		items, ok2 := args[1].([]any)
+		if !ok || !ok2 {
+			return nil
+		}
+		var parts []string
+		for _, item := range items {
+			s, ok := item.(string)
+			if !ok {
+				return nil
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, sep)
+	}
+	return nil
+}
+
+// interpolate resolves "${...}" expressions in the string.
+// A string consisting of a single reference to a resource is returned as Reference,
+// while strings with other references are not known.
+func (e *evaluator) interpolate(s string) any {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var sb strings.Builder
+	for s != "" {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			sb.WriteString(s)
+			break
+		}
+		// "$${" is an escaped "${"
+		if start > 0 && s[start-1] == '$' {
+			sb.WriteString(s[:start-1] + "${")
+			s = s[start+2:]
+			continue
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			sb.WriteString(s)
+			break
+		}
+		expr := s[start+2 : start+end]
+		sb.WriteString(s[:start])
+		s = s[start+end+1:]
+
+		parts := strings.Split(expr, ".")
+		if e.resources[parts[0]] {
+			if start != 0 || s != "" || sb.Len() != 0 {
+				return nil
+			}
+			return Reference{
+				Resource: parts[0],
+				Path:     parts[1:],
+			}
+		}
+
+		val, ok := e.values[expr]
+		if !ok {
+			return nil
+		}
+		switch v := val.(type) {
+		case string:
+			sb.WriteString(v)
+		case bool, int, float64:
+			// A variable interpolated alone keeps its type
+			if sb.Len() == 0 && s == "" {
+				return v
+			}
+			sb.WriteString(fmt.Sprint(v))
+		default:
+			if sb.Len() == 0 && s == "" {
+				return v
+			}
+			return nil
+		}
+	}
+	return sb.String()
+}
+
+func hasReference(v any) bool {
+	switch t := v.(type) {
+	case Reference:
+		return true
+	case map[string]any:
+		for _, val := range t {
+			if hasReference(val) {
+				return true
+			}
+		}
+	case []any:
+		for _, val := range t {
+			if hasReference(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// endLine returns the last line of the node
+func endLine(node *yaml.Node) int {
+	line := node.Line
+	for _, n := range node.Content {
+		line = max(line, endLine(n))
+	}
+	return line
+}
@@ -0,0 +1,239 @@
+package parser
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Resource
+	}{
+		{
+			name: "YAML program",
+			input: `name: app
+runtime: yaml
+config:
+  acl:
+    type: string
+    default: private
+variables:
+  prefix: logs
+resources:
+  logs:
+    type: aws:s3:Bucket
+    properties:
+      bucket: ${prefix}-bucket
+      acl: ${acl}
+      tags:
+        Env: ${pulumi.stack}
+  logsPolicy:
+    type: aws:s3/bucketPolicy:BucketPolicy
+    properties:
+      bucket: ${logs.id}
+      policy:
+        fn::toJSON:
+          Version: "2012-10-17"
+  existing:
+    type: aws:s3:Bucket
+    get:
+      id: existing-bucket
+`,
+			want: []Resource{
+				{
+					Name: "logs",
+					Type: "aws:s3:Bucket",
+					Properties: map[string]any{
+						"bucket": "logs-bucket",
+						"acl":    "private",
+						"tags":   map[string]any{},
+					},
+					StartLine: 10,
+					EndLine:   16,
+				},
+				{
+					Name: "logsPolicy",
+					Type: "aws:s3/bucketPolicy:BucketPolicy",
+					Properties: map[string]any{
+						"bucket": Reference{Resource: "logs", Path: []string{"id"}},
+						"policy": `{"Version":"2012-10-17"}`,
+					},
+					StartLine: 17,
+					EndLine:   23,
+				},
+			},
+		},
+		{
+			name: "preview output",
+			input: `{
+  "steps": [
+    {
+      "op": "create",
+      "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+      "newState": {
+        "type": "aws:s3/bucket:Bucket",
+        "inputs": {
+          "acl": "public-read",
+          "arn": "04da6b54-80e4-46f7-96ec-b56ff0331ba9",
+          "bucketPrefix": {
+            "4dabf18193072939515e22adb298388d": "1b47061264138c4ac30d75fd1eb44270",
+            "value": "logs-"
+          }
+        }
+      }
+    },
+    {
+      "op": "delete",
+      "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::old",
+      "oldState": {
+        "type": "aws:s3/bucket:Bucket"
+      }
+    }
+  ]
+}`,
+			want: []Resource{
+				{
+					Name: "logs",
+					Type: "aws:s3/bucket:Bucket",
+					Properties: map[string]any{
+						"acl":          "public-read",
+						"bucketPrefix": "logs-",
+					},
+					StartLine: 3,
+					EndLine:   13,
+				},
+			},
+		},
+		{
+			name:  "other document",
+			input: `foo: bar`,
+		},
+		{
+			name: "empty document",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().Parse(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestToFSWithLocations(t *testing.T) {
+	resources := []Resource{
+		{
+			Name: "logs",
+			Type: "aws:s3/bucketV2:BucketV2",
+			Properties: map[string]any{
+				"bucket": "logs",
+				"serverSideEncryptionConfiguration": map[string]any{
+					"rule": map[string]any{
+						"applyServerSideEncryptionByDefault": map[string]any{
+							"sseAlgorithm": "aws:kms",
+						},
+					},
+				},
+				"lifecycleRules": []any{
+					map[string]any{"enabled": true},
+				},
+				"tags": map[string]any{"Name": "logs"},
+			},
+			StartLine: 1,
+			EndLine:   10,
+		},
+		{
+			Name: "web-sg",
+			Type: "aws:ec2:SecurityGroup",
+			Properties: map[string]any{
+				"ingress": []any{
+					map[string]any{
+						"cidrBlocks": []any{"0.0.0.0/0"},
+						"fromPort":   443,
+					},
+				},
+				"description": Reference{Resource: "logs", Path: []string{"bucketDomainName"}},
+			},
+			StartLine: 11,
+			EndLine:   20,
+		},
+		{
+			Name:      "vpc",
+			Type:      "awsx:ec2:Vpc",
+			StartLine: 21,
+			EndLine:   25,
+		},
+	}
+
+	fsys, locations, err := ToFSWithLocations(resources)
+	require.NoError(t, err)
+
+	f, err := fsys.Open("main.tf")
+	require.NoError(t, err)
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+
+	want := `resource "aws_s3_bucket" "logs" {
+  bucket = "logs"
+  lifecycle_rule {
+    enabled = true
+  }
+  server_side_encryption_configuration {
+    rule {
+      apply_server_side_encryption_by_default {
+        sse_algorithm = "aws:kms"
+      }
+    }
+  }
+  tags = {
+    "Name" = "logs"
+  }
+}
+
+resource "aws_security_group" "web-sg" {
+  description = aws_s3_bucket.logs.bucket_domain_name
+  ingress {
+    cidr_blocks = ["0.0.0.0/0"]
+    from_port   = 443
+  }
+}
+`
+	assert.Equal(t, want, string(b))
+	assert.Equal(t, []ResourceLocation{
+		{Resource: resources[0], StartLine: 1, EndLine: 16},
+		{Resource: resources[1], StartLine: 18, EndLine: 24},
+	}, locations)
+}
+
+func TestTerraformType(t *testing.T) {
+	tests := []struct {
+		token string
+		want  string
+	}{
+		{token: "aws:s3/bucket:Bucket", want: "aws_s3_bucket"},
+		{token: "aws:s3:BucketV2", want: "aws_s3_bucket"},
+		{token: "aws:s3/bucketPublicAccessBlock:BucketPublicAccessBlock", want: "aws_s3_bucket_public_access_block"},
+		{token: "aws:ec2/instance:Instance", want: "aws_instance"},
+		{token: "aws:rds/instance:Instance", want: "aws_db_instance"},
+		{token: "aws:apigateway/restApi:RestApi", want: "aws_api_gateway_rest_api"},
+		{token: "azure:storage/account:Account", want: "azurerm_storage_account"},
+		{token: "azure:keyvault/keyVault:KeyVault", want: "azurerm_key_vault"},
+		{token: "azure:core/resourceGroup:ResourceGroup", want: "azurerm_resource_group"},
+		{token: "gcp:storage/bucket:Bucket", want: "google_storage_bucket"},
+		{token: "gcp:projects/iAMMember:IAMMember", want: "google_project_iam_member"},
+		{token: "azure-native:storage:StorageAccount", want: ""},
+		{token: "pulumi:pulumi:Stack", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			assert.Equal(t, tt.want, TerraformType(tt.token))
+		})
+	}
+}
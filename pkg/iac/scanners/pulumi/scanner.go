@@ -0,0 +1,113 @@
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/pulumi/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraform"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+var _ scanners.FSScanner = (*Scanner)(nil)
+var _ options.ConfigurableScanner = (*Scanner)(nil)
+
+var extensions = []string{".yaml", ".yml", ".json"}
+
+// Scanner scans Pulumi YAML programs and the output of `pulumi preview --json`.
+// Resources of the providers bridged from Terraform are converted into Terraform resources,
+// so that the Terraform checks can be run against them.
+type Scanner struct {
+	logger    *log.Logger
+	parser    *parser.Parser
+	tfScanner *terraform.Scanner
+}
+
+func New(opts ...options.ScannerOption) *Scanner {
+	scanner := &Scanner{
+		logger:    log.WithPrefix("pulumi scanner"),
+		parser:    parser.New(),
+		tfScanner: terraform.New(opts...),
+	}
+	for _, opt := range opts {
+		opt(scanner)
+	}
+	return scanner
+}
+
+func (s *Scanner) Name() string {
+	return "Pulumi"
+}
+
+func (s *Scanner) ScanFS(ctx context.Context, fsys fs.FS, dir string) (scan.Results, error) {
+	var results scan.Results
+
+	walkFn := func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !slices.Contains(extensions, filepath.Ext(path)) {
+			return nil
+		}
+
+		res, err := s.ScanFile(ctx, fsys, path)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", path, err)
+		}
+		results = append(results, res...)
+		return nil
+	}
+
+	if err := fs.WalkDir(fsys, dir, walkFn); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *Scanner) ScanFile(ctx context.Context, fsys fs.FS, path string) (scan.Results, error) {
+	s.logger.Debug("Scanning file", log.FilePath(path))
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	resources, err := s.parser.Parse(f)
+	if err != nil {
+		return nil, err
+	}
+
+	tfFS, locations, err := parser.ToFSWithLocations(resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resources to Terraform: %w", err)
+	} else if len(locations) == 0 {
+		s.logger.Debug("No supported resources found", log.FilePath(path))
+		return nil, nil
+	}
+
+	results, err := s.tfScanner.ScanFS(ctx, tfFS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	// Report the findings at the Pulumi resources
+	for i, result := range results {
+		line := result.Range().GetStartLine()
+		for _, loc := range locations {
+			if line < loc.StartLine || line > loc.EndLine {
+				continue
+			}
+			rng := types.NewRange(path, loc.Resource.StartLine, loc.Resource.EndLine, "", fsys)
+			results[i].OverrideMetadata(types.NewMetadata(rng, loc.Resource.Name))
+			break
+		}
+	}
+	return results, nil
+}
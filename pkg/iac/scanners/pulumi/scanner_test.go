@@ -0,0 +1,123 @@
+package pulumi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+	"github.com/aquasecurity/trivy/pkg/iac/rego"
+)
+
+const publicBucketCheck = `# METADATA
+# title: Buckets should not be public
+# custom:
+#   avd_id: AVD-TEST-0123
+#   severity: HIGH
+#   short_code: no-public-buckets
+#   input:
+#     selector:
+#     - type: cloud
+#       subtypes:
+#         - service: s3
+#           provider: aws
+package user.pulumi.test123
+
+deny[res] {
+	bucket := input.aws.s3.buckets[_]
+	bucket.acl.value == "public-read"
+	res := result.new("The bucket is public", bucket.acl)
+}
+`
+
+func TestScanner_ScanFS(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		content   string
+		wantLines [][2]int
+	}{
+		{
+			name: "YAML program",
+			file: "Pulumi.yaml",
+			content: `name: app
+runtime: yaml
+config:
+  acl:
+    default: public-read
+resources:
+  private:
+    type: aws:s3:Bucket
+    properties:
+      acl: private
+  public:
+    type: aws:s3/bucket:Bucket
+    properties:
+      acl: ${acl}
+      tags:
+        Name: public
+`,
+			wantLines: [][2]int{{11, 16}},
+		},
+		{
+			name: "preview output",
+			file: "preview.json",
+			content: `{
+  "steps": [
+    {
+      "op": "create",
+      "urn": "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev",
+      "newState": {"type": "pulumi:pulumi:Stack"}
+    },
+    {
+      "op": "update",
+      "urn": "urn:pulumi:dev::app::aws:s3/bucketV2:BucketV2::public",
+      "newState": {
+        "type": "aws:s3/bucketV2:BucketV2",
+        "inputs": {"acl": "public-read"}
+      }
+    }
+  ]
+}`,
+			wantLines: [][2]int{{8, 13}},
+		},
+		{
+			name: "no supported resources",
+			file: "Pulumi.yaml",
+			content: `name: app
+runtime: yaml
+resources:
+  vpc:
+    type: awsx:ec2:Vpc
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := testutil.CreateFS(t, map[string]string{
+				"code/" + tt.file: tt.content,
+				"rules/test.rego": publicBucketCheck,
+			})
+
+			scanner := New(
+				rego.WithPolicyFilesystem(fsys),
+				rego.WithPolicyDirs("rules"),
+				rego.WithPolicyNamespaces("user"),
+				rego.WithEmbeddedPolicies(false),
+				rego.WithEmbeddedLibraries(true),
+			)
+			results, err := scanner.ScanFS(context.TODO(), fsys, "code")
+			require.NoError(t, err)
+
+			var gotLines [][2]int
+			for _, failure := range results.GetFailed() {
+				assert.Equal(t, "AVD-TEST-0123", failure.Rule().AVDID)
+				assert.Equal(t, "code/"+tt.file, failure.Range().GetFilename())
+				gotLines = append(gotLines, [2]int{failure.Range().GetStartLine(), failure.Range().GetEndLine()})
+			}
+			assert.Equal(t, tt.wantLines, gotLines)
+		})
+	}
+}
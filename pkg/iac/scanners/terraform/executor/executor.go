@@ -22,6 +22,7 @@ type Executor struct {
 	logger         *log.Logger
 	resultsFilters []func(scan.Results) scan.Results
 	regoScanner    *rego.Scanner
+	inputValues    map[string]any
 }
 
 // New creates a new Executor
@@ -41,8 +42,17 @@ func (e *Executor) Execute(ctx context.Context, modules terraform.Modules, baseP
 	infra := adapter.Adapt(modules)
 	e.logger.Debug("Adapted module(s) into state data.", log.Int("count", len(modules)))
 
+	contents := infra.ToRego()
+	if m, ok := contents.(map[string]any); ok {
+		// Expose the configuration of Terraform itself, such as the required providers, as "input.terraform"
+		m[terraformInputKey] = terraformInput(modules)
+		for k, v := range e.inputValues {
+			m[k] = v
+		}
+	}
+
 	results, err := e.regoScanner.ScanInput(ctx, rego.Input{
-		Contents: infra.ToRego(),
+		Contents: contents,
 		Path:     basePath,
 	})
 	if err != nil {
@@ -78,6 +88,31 @@ func (e *Executor) Execute(ctx context.Context, modules terraform.Modules, baseP
 	return results, nil
 }
 
+// terraformInputKey is the key of the Terraform configuration in the input of checks
+const terraformInputKey = "terraform"
+
+// terraformInput returns the required providers of all modules, e.g.
+//
+//	{"required_providers": [{"name": "aws", "source": "hashicorp/aws", "version": "~> 5.0"}]}
+//
+// The providers have the metadata, so checks can report findings at the required_providers block.
+func terraformInput(modules terraform.Modules) map[string]any {
+	providers := make([]any, 0)
+	for _, module := range modules {
+		for _, provider := range module.RequiredProviders() {
+			providers = append(providers, map[string]any{
+				"name":              provider.Name,
+				"source":            provider.Source,
+				"version":           provider.Version,
+				"__defsec_metadata": provider.Metadata.ToRego(),
+			})
+		}
+	}
+	return map[string]any{
+		"required_providers": providers,
+	}
+}
+
 func (e *Executor) filterResults(results scan.Results) scan.Results {
 	if len(e.resultsFilters) > 0 && len(results) > 0 {
 		before := len(results.GetIgnored())
@@ -24,3 +24,11 @@ func OptionWithRegoScanner(s *rego.Scanner) Option {
 		e.regoScanner = s
 	}
 }
+
+// OptionWithInputValues adds the values to the input of checks in addition to the adapted state,
+// e.g. the resource changes of a Terraform plan.
+func OptionWithInputValues(values map[string]any) Option {
+	return func(e *Executor) {
+		e.inputValues = values
+	}
+}
@@ -3,6 +3,7 @@ package terraform
 import (
 	"io/fs"
 	"strings"
+	"time"
 
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
@@ -77,6 +78,22 @@ func ScannerWithSkipCachedModules(b bool) options.ScannerOption {
 	}
 }
 
+func ScannerWithModuleCacheDir(dir string) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if tf, ok := s.(ConfigurableTerraformScanner); ok {
+			tf.AddParserOptions(parser.OptionWithModuleCacheDir(dir))
+		}
+	}
+}
+
+func ScannerWithModuleCacheTTL(ttl time.Duration) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if tf, ok := s.(ConfigurableTerraformScanner); ok {
+			tf.AddParserOptions(parser.OptionWithModuleCacheTTL(ttl))
+		}
+	}
+}
+
 func ScannerWithConfigsFileSystem(fsys fs.FS) options.ScannerOption {
 	return func(s options.ConfigurableScanner) {
 		if tf, ok := s.(ConfigurableTerraformScanner); ok {
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"reflect"
 	"slices"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/typeexpr"
@@ -38,6 +39,8 @@ type evaluator struct {
 	parentParser      *Parser
 	allowDownloads    bool
 	skipCachedModules bool
+	moduleCacheDir    string
+	moduleCacheTTL    time.Duration
 }
 
 func newEvaluator(
@@ -55,6 +58,8 @@ func newEvaluator(
 	logger *log.Logger,
 	allowDownloads bool,
 	skipCachedModules bool,
+	moduleCacheDir string,
+	moduleCacheTTL time.Duration,
 ) *evaluator {
 
 	// create a context to store variables and make functions available
@@ -87,6 +92,8 @@ func newEvaluator(
 		logger:            logger,
 		allowDownloads:    allowDownloads,
 		skipCachedModules: skipCachedModules,
+		moduleCacheDir:    moduleCacheDir,
+		moduleCacheTTL:    moduleCacheTTL,
 	}
 }
 
@@ -146,6 +146,8 @@ func (e *evaluator) loadExternalModule(ctx context.Context, b *terraform.Block,
 		Logger:          log.WithPrefix("module resolver"),
 		AllowDownloads:  e.allowDownloads,
 		SkipCache:       e.skipCachedModules,
+		CacheDir:        e.moduleCacheDir,
+		CacheTTL:        e.moduleCacheTTL,
 	}
 
 	filesystem, prefix, downloadPath, err := resolveModule(ctx, e.filesystem, opt)
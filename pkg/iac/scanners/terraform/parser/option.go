@@ -2,6 +2,7 @@ package parser
 
 import (
 	"io/fs"
+	"time"
 
 	"github.com/zclconf/go-cty/cty"
 )
@@ -44,12 +45,41 @@ func OptionWithSkipCachedModules(b bool) Option {
 	}
 }
 
+// OptionWithModuleCacheDir sets the directory where downloaded modules are cached
+func OptionWithModuleCacheDir(dir string) Option {
+	return func(p *Parser) {
+		p.moduleCacheDir = dir
+	}
+}
+
+// OptionWithModuleCacheTTL sets how long cached modules are used before they are downloaded again.
+// Zero means cached modules never expire.
+func OptionWithModuleCacheTTL(ttl time.Duration) Option {
+	return func(p *Parser) {
+		p.moduleCacheTTL = ttl
+	}
+}
+
 func OptionWithConfigsFS(fsys fs.FS) Option {
 	return func(p *Parser) {
 		p.configsFS = fsys
 	}
 }
 
+// OptionWithMaxFileSize sets the maximum size of the files parsed, larger files are skipped
+func OptionWithMaxFileSize(size int64) Option {
+	return func(p *Parser) {
+		p.limits.MaxFileSize = size
+	}
+}
+
+// OptionWithParseTimeout sets the maximum time to parse a file, files taking longer are skipped
+func OptionWithParseTimeout(timeout time.Duration) Option {
+	return func(p *Parser) {
+		p.limits.Timeout = timeout
+	}
+}
+
 func OptionWithSkipFiles(files []string) Option {
 	return func(p *Parser) {
 		p.skipPaths = files
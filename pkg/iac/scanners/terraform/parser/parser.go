@@ -12,13 +12,16 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/aquasecurity/trivy/pkg/fanal/utils"
 	"github.com/aquasecurity/trivy/pkg/iac/ignore"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
 	"github.com/aquasecurity/trivy/pkg/iac/terraform"
 	tfcontext "github.com/aquasecurity/trivy/pkg/iac/terraform/context"
 	"github.com/aquasecurity/trivy/pkg/log"
@@ -40,24 +43,26 @@ type Parser struct {
 	files             []sourceFile
 	tfvarsPaths       []string
 	tfvars            map[string]cty.Value
+	terragruntInputs  map[string]cty.Value
 	stopOnHCLError    bool
 	workspaceName     string
-	underlying        *hclparse.Parser
 	children          []*Parser
 	options           []Option
 	logger            *log.Logger
 	allowDownloads    bool
 	skipCachedModules bool
+	moduleCacheDir    string
+	moduleCacheTTL    time.Duration
 	fsMap             map[string]fs.FS
 	configsFS         fs.FS
 	skipPaths         []string
+	limits            options.ParseLimits
 }
 
 // New creates a new Parser
 func New(moduleFS fs.FS, moduleSource string, opts ...Option) *Parser {
 	p := &Parser{
 		workspaceName:  "default",
-		underlying:     hclparse.NewParser(),
 		options:        opts,
 		moduleName:     "root",
 		allowDownloads: true,
@@ -90,7 +95,7 @@ func (p *Parser) newModuleParser(moduleFS fs.FS, moduleSource, modulePath, modul
 	return mp
 }
 
-func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
+func (p *Parser) ParseFile(ctx context.Context, fullPath string) error {
 
 	isJSON := strings.HasSuffix(fullPath, ".tf.json")
 	isHCL := strings.HasSuffix(fullPath, ".tf")
@@ -105,6 +110,14 @@ func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
 	}
 	defer func() { _ = f.Close() }()
 
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := p.limits.CheckSize(info.Size()); err != nil {
+		return err
+	}
+
 	data, err := io.ReadAll(f)
 	if err != nil {
 		return err
@@ -116,24 +129,48 @@ func (p *Parser) ParseFile(_ context.Context, fullPath string) error {
 		p.modulePath = dir
 	}
 
-	var file *hcl.File
-	var diag hcl.Diagnostics
-
-	if isHCL {
-		file, diag = p.underlying.ParseHCL(data, fullPath)
-	} else {
-		file, diag = p.underlying.ParseJSON(data, fullPath)
+	file, err := options.Parse(ctx, p.limits, func(context.Context) (*hcl.File, error) {
+		return parseFile(fullPath, data)
+	})
+	if err != nil {
+		return err
 	}
-	if diag != nil && diag.HasErrors() {
-		return diag
+	p.appendFile(fullPath, file)
+	return nil
+}
+
+// addFile parses the content of the file, which might not exist in the filesystem, e.g. files generated by Terragrunt
+func (p *Parser) addFile(fullPath string, data []byte) error {
+	file, err := parseFile(fullPath, data)
+	if err != nil {
+		return err
 	}
+	p.appendFile(fullPath, file)
+	return nil
+}
+
+func (p *Parser) appendFile(fullPath string, file *hcl.File) {
 	p.files = append(p.files, sourceFile{
 		file: file,
 		path: fullPath,
 	})
-
 	p.logger.Debug("Added file", log.FilePath(fullPath))
-	return nil
+}
+
+// parseFile parses the HCL or JSON file. It has no side effects, so that it can be abandoned on timeout.
+func parseFile(fullPath string, data []byte) (*hcl.File, error) {
+	var file *hcl.File
+	var diag hcl.Diagnostics
+
+	if strings.HasSuffix(fullPath, ".tf.json") {
+		file, diag = hcljson.Parse(data, fullPath)
+	} else {
+		file, diag = hclsyntax.ParseConfig(data, fullPath, hcl.InitialPos)
+	}
+	if diag.HasErrors() {
+		return nil, diag
+	}
+	return file, nil
 }
 
 // ParseFS parses a root module, where it exists at the root of the provided filesystem
@@ -147,6 +184,14 @@ func (p *Parser) ParseFS(ctx context.Context, dir string) error {
 		p.modulePath = dir
 	}
 
+	// Terragrunt units are supported only in root modules
+	if p.moduleBlock == nil && isTerragruntUnit(p.moduleFS, dir) {
+		return p.parseTerragrunt(ctx, dir)
+	}
+	return p.parseDir(ctx, dir)
+}
+
+func (p *Parser) parseDir(ctx context.Context, dir string) error {
 	slashed := filepath.ToSlash(dir)
 	p.logger.Debug("Parsing FS", log.FilePath(slashed))
 	fileInfos, err := fs.ReadDir(p.moduleFS, slashed)
@@ -173,6 +218,11 @@ func (p *Parser) ParseFS(ctx context.Context, dir string) error {
 			continue
 		}
 
+		if options.IsLimitError(err) {
+			p.logger.Warn("Skipping file", log.FilePath(path), log.Err(err))
+			continue
+		}
+
 		if p.stopOnHCLError {
 			return err
 		}
@@ -265,6 +315,16 @@ func (p *Parser) Load(ctx context.Context) (*evaluator, error) {
 		}
 		p.logger.Debug("Added input variables from tfvars", log.Int("count", len(inputVars)))
 
+		// Terragrunt passes inputs as environment variables, which have lower precedence than tfvars files
+		for name, val := range p.terragruntInputs {
+			if _, ok := inputVars[name]; !ok {
+				inputVars[name] = val
+			}
+		}
+		if len(p.terragruntInputs) > 0 {
+			p.logger.Debug("Added input variables from Terragrunt", log.Int("count", len(p.terragruntInputs)))
+		}
+
 		if missingVars := missingVariableValues(blocks, inputVars); len(missingVars) > 0 {
 			p.logger.Warn(
 				"Variable values was not found in the environment or variable files. Evaluating may not work correctly.",
@@ -306,6 +366,8 @@ func (p *Parser) Load(ctx context.Context) (*evaluator, error) {
 		log.WithPrefix("terraform evaluator"),
 		p.allowDownloads,
 		p.skipCachedModules,
+		p.moduleCacheDir,
+		p.moduleCacheTTL,
 	), nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 	"testing/fstest"
 
@@ -2187,3 +2188,84 @@ resource "aws_s3_bucket" "example" {
 	val := modules.GetResourcesByType("aws_s3_bucket")[0].GetAttribute("bucket").GetRawValue()
 	assert.Nil(t, val)
 }
+
+func TestRequiredProviders(t *testing.T) {
+	modules := parse(t, map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    google = {
+      source = "registry.terraform.io/HashiCorp/google"
+    }
+    random = "3.6.0"
+  }
+}
+
+module "network" {
+  source = "./modules/network"
+}
+`,
+		"modules/network/main.tf": `
+terraform {
+  required_providers {
+    cloudflare = {
+      source  = "cloudflare/cloudflare"
+      version = "= 4.20.0"
+    }
+  }
+}
+`,
+	})
+	require.Len(t, modules, 2)
+
+	type provider struct {
+		Name, Source, Version string
+		StartLine             int
+	}
+	var got []provider
+	for _, module := range modules {
+		for _, p := range module.RequiredProviders() {
+			got = append(got, provider{p.Name, p.Source, p.Version, p.Metadata.Range().GetStartLine()})
+		}
+	}
+
+	expected := []provider{
+		{"aws", "hashicorp/aws", "~> 5.0", 4},
+		{"google", "hashicorp/google", "", 8},
+		{"random", "hashicorp/random", "3.6.0", 11},
+		{"cloudflare", "cloudflare/cloudflare", "= 4.20.0", 4},
+	}
+	assert.ElementsMatch(t, expected, got)
+}
+
+func TestMaxFileSize(t *testing.T) {
+	var buf bytes.Buffer
+	slog.SetDefault(slog.New(log.NewHandler(&buf, nil)))
+
+	fsys := fstest.MapFS{
+		"main.tf": &fstest.MapFile{
+			Data: []byte(`resource "aws_s3_bucket" "small" {}`),
+		},
+		"large.tf": &fstest.MapFile{
+			Data: []byte(`resource "aws_s3_bucket" "large" {
+  bucket = "` + strings.Repeat("x", 100) + `"
+}`),
+		},
+	}
+
+	parser := New(fsys, "", OptionStopOnHCLError(true), OptionWithMaxFileSize(64))
+	require.NoError(t, parser.ParseFS(context.TODO(), "."))
+
+	modules, _, err := parser.EvaluateAll(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+
+	blocks := modules[0].GetResourcesByType("aws_s3_bucket")
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "small", blocks[0].NameLabel())
+	assert.Contains(t, buf.String(), "file exceeds the maximum size")
+}
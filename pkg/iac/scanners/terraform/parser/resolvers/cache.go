@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/aquasecurity/trivy/pkg/log"
 )
@@ -58,6 +59,11 @@ func (r *cacheResolver) Resolve(_ context.Context, _ fs.FS, opt Options) (filesy
 
 	opt.Logger.Debug("Trying to resolve module via cache", log.String("key", key))
 	if info, err := fs.Stat(cacheFS, filepath.ToSlash(key)); err == nil && info.IsDir() {
+		// Expired modules are downloaded again, but they are still used when downloads are disabled
+		if opt.AllowDownloads && opt.CacheTTL > 0 && time.Since(info.ModTime()) > opt.CacheTTL {
+			opt.Logger.Debug("Cached module has expired", log.String("key", key))
+			return nil, "", "", false, nil
+		}
 		opt.Logger.Debug("Module resolved from cache", log.String("key", key))
 		cacheDir, err := locateCacheDir(opt.CacheDir)
 		if err != nil {
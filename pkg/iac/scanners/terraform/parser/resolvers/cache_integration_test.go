@@ -8,9 +8,12 @@ import (
 	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -170,3 +173,40 @@ func TestResolveModuleFromCacheWithDifferentSubdir(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "# S3 bucket notification", string(b))
 }
+
+func TestResolveModuleFromCacheWithTTL(t *testing.T) {
+	repo := "terraform-aws-s3-bucket"
+	gs := gittest.NewServer(t, repo, "testdata/terraform-aws-s3-bucket")
+	defer gs.Close()
+
+	opts := testOptions(t, buildGitSource(gs.URL+"/"+repo+".git"))
+	opts.CacheTTL = time.Hour
+
+	_, _, _, _, err := resolvers.Remote.Resolve(context.Background(), nil, opts)
+	require.NoError(t, err)
+
+	_, _, _, applies, err := resolvers.Cache.Resolve(context.Background(), nil, opts)
+	require.NoError(t, err)
+	assert.True(t, applies)
+
+	// Make the cached module stale
+	entries, err := os.ReadDir(opts.CacheDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	modTime := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(opts.CacheDir, entries[0].Name()), modTime, modTime))
+
+	_, _, _, applies, err = resolvers.Cache.Resolve(context.Background(), nil, opts)
+	require.NoError(t, err)
+	assert.False(t, applies)
+
+	// The stale module is used when downloads are disabled
+	opts.AllowDownloads = false
+	fsys, _, dir, applies, err := resolvers.Cache.Resolve(context.Background(), nil, opts)
+	require.NoError(t, err)
+	assert.True(t, applies)
+
+	b, err := fs.ReadFile(fsys, path.Join(dir, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# AWS S3 bucket Terraform module", string(b))
+}
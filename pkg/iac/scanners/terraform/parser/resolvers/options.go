@@ -3,6 +3,7 @@ package resolvers
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/aquasecurity/trivy/pkg/log"
 )
@@ -14,6 +15,7 @@ type Options struct {
 	SkipCache                                                                      bool
 	RelativePath                                                                   string
 	CacheDir                                                                       string
+	CacheTTL                                                                       time.Duration
 	Client                                                                         *http.Client
 }
 
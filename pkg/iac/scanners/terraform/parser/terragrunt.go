@@ -0,0 +1,459 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	terragruntFile = "terragrunt.hcl"
+
+	// terragruntModuleName is the name of the module block generated for the Terraform source of a Terragrunt unit
+	terragruntModuleName = "terragrunt"
+
+	// maxTerragruntDepth limits nested includes and read_terragrunt_config calls
+	maxTerragruntDepth = 10
+)
+
+// module arguments that cannot be passed as inputs
+var moduleMetaArguments = []string{"source", "version", "count", "for_each", "providers", "depends_on"}
+
+// terragruntConfig represents a Terragrunt configuration merged with the included configurations
+type terragruntConfig struct {
+	source    string
+	locals    map[string]cty.Value
+	inputs    map[string]cty.Value
+	generates map[string]terragruntGenerate
+}
+
+// terragruntGenerate represents a "generate" block writing a file into the Terraform module
+type terragruntGenerate struct {
+	path     string
+	contents string
+}
+
+func (c *terragruntConfig) merge(included *terragruntConfig) {
+	if c.source == "" {
+		c.source = included.source
+	}
+	for name, val := range included.inputs {
+		if _, ok := c.inputs[name]; !ok {
+			c.inputs[name] = val
+		}
+	}
+	for name, gen := range included.generates {
+		if _, ok := c.generates[name]; !ok {
+			c.generates[name] = gen
+		}
+	}
+}
+
+func (c *terragruntConfig) toValue() cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"locals": cty.ObjectVal(c.locals),
+		"inputs": cty.ObjectVal(c.inputs),
+	})
+}
+
+// terragruntLoader evaluates Terragrunt configurations of a unit.
+// Paths returned by the Terragrunt functions start with "/" and are relative to the root of the filesystem.
+type terragruntLoader struct {
+	fsys    fs.FS
+	unitDir string
+	logger  *log.Logger
+
+	// includeDir is the directory of the configuration included by the unit
+	includeDir string
+}
+
+func isTerragruntUnit(fsys fs.FS, dir string) bool {
+	_, err := fs.Stat(fsys, path.Join(dir, terragruntFile))
+	return err == nil
+}
+
+// parseTerragrunt parses the Terragrunt unit in the directory.
+// Terragrunt passes inputs to the Terraform module specified in the "terraform" block, so the module is loaded
+// via a generated module block with the inputs as arguments. The files of "generate" blocks are added to the unit.
+// If the unit has no source, the Terraform files in the directory are used with the inputs as variable values.
+func (p *Parser) parseTerragrunt(ctx context.Context, dir string) error {
+	loader := &terragruntLoader{
+		fsys:    p.moduleFS,
+		unitDir: dir,
+		logger:  log.WithPrefix("terragrunt"),
+	}
+
+	configPath := path.Join(dir, terragruntFile)
+	p.logger.Debug("Parsing Terragrunt configuration", log.FilePath(configPath))
+	cfg, err := loader.load(configPath, 0)
+	if err != nil {
+		if p.stopOnHCLError {
+			return fmt.Errorf("failed to load terragrunt configuration: %w", err)
+		}
+		p.logger.Error("Failed to load Terragrunt configuration", log.FilePath(configPath), log.Err(err))
+		return p.parseDir(ctx, dir)
+	}
+
+	if cfg.source == "" {
+		if err := p.parseDir(ctx, dir); err != nil {
+			return err
+		}
+		p.terragruntInputs = cfg.inputs
+	} else {
+		data := terragruntModule(loader.moduleSource(cfg.source), cfg.inputs)
+		if err := p.addFile(configPath, data); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.generates)) {
+		gen := cfg.generates[name]
+		filePath := path.Join(dir, gen.path)
+		if !strings.HasSuffix(filePath, ".tf") && !strings.HasSuffix(filePath, ".tf.json") {
+			continue
+		}
+		p.logger.Debug("Adding file generated by Terragrunt", log.String("name", name), log.FilePath(filePath))
+		if err := p.addFile(filePath, []byte(gen.contents)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// terragruntModule generates a module block calling the Terraform source with the inputs.
+// Inputs which are not known, e.g. outputs of dependencies without mocks, are skipped.
+func terragruntModule(source string, inputs map[string]cty.Value) []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body().AppendNewBlock("module", []string{terragruntModuleName}).Body()
+
+	source, version := registrySource(source)
+	body.SetAttributeValue("source", cty.StringVal(source))
+	if version != "" {
+		body.SetAttributeValue("version", cty.StringVal(version))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(inputs)) {
+		val := inputs[name]
+		if !hclsyntax.ValidIdentifier(name) || slices.Contains(moduleMetaArguments, name) || !val.IsWhollyKnown() {
+			continue
+		}
+		body.SetAttributeValue(name, val)
+	}
+	return f.Bytes()
+}
+
+// registrySource converts the Terragrunt registry source "tfr://host/namespace/name/provider?version=x"
+// to the Terraform module source and version
+func registrySource(source string) (string, string) {
+	if !strings.HasPrefix(source, "tfr://") {
+		return source, ""
+	}
+	u, err := url.Parse(source)
+	if err != nil {
+		return source, ""
+	}
+	return path.Join(u.Host, strings.TrimPrefix(u.Path, "/")), u.Query().Get("version")
+}
+
+// moduleSource converts local sources to paths relative to the unit, as Terraform requires
+func (l *terragruntLoader) moduleSource(source string) string {
+	if !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".") {
+		return source
+	}
+	// e.g. "../modules//vpc"
+	source = strings.ReplaceAll(source, "//", "/")
+	if strings.HasPrefix(source, "/") {
+		rel, err := filepath.Rel(l.unitDir, strings.TrimPrefix(source, "/"))
+		if err != nil {
+			return source
+		}
+		source = filepath.ToSlash(rel)
+	}
+	if !strings.HasPrefix(source, ".") {
+		source = "./" + source
+	}
+	return source
+}
+
+// load evaluates the configuration file
+func (l *terragruntLoader) load(filePath string, depth int) (*terragruntConfig, error) {
+	if depth > maxTerragruntDepth {
+		return nil, fmt.Errorf("too many nested configurations: %s", filePath)
+	}
+
+	data, err := fs.ReadFile(l.fsys, filePath)
+	if err != nil {
+		return nil, err
+	}
+	file, diags := hclsyntax.ParseConfig(data, filePath, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, errors.New("unexpected body type")
+	}
+
+	dir := path.Dir(filePath)
+	evalCtx := &hcl.EvalContext{
+		Variables: make(map[string]cty.Value),
+		Functions: l.functions(dir, depth),
+	}
+
+	cfg := &terragruntConfig{
+		locals:    make(map[string]cty.Value),
+		inputs:    make(map[string]cty.Value),
+		generates: make(map[string]terragruntGenerate),
+	}
+
+	// Includes can be referenced in locals, so they are loaded first
+	var included []*terragruntConfig
+	includes := make(map[string]cty.Value)
+	for _, block := range blocksOfType(body, "include") {
+		includePath := l.evalString(evalCtx, block.Body.Attributes["path"])
+		if includePath == "" {
+			continue
+		}
+		includePath = l.resolvePath(dir, includePath)
+		if dir == l.unitDir && l.includeDir == "" {
+			l.includeDir = path.Dir(includePath)
+		}
+		includedCfg, err := l.load(includePath, depth+1)
+		if err != nil {
+			l.logger.Debug("Failed to load the included configuration", log.FilePath(includePath), log.Err(err))
+			continue
+		}
+		if strategy := l.evalString(evalCtx, block.Body.Attributes["merge_strategy"]); strategy != "no_merge" {
+			included = append(included, includedCfg)
+		}
+		if len(block.Labels) > 0 {
+			includes[block.Labels[0]] = includedCfg.toValue()
+		}
+	}
+	evalCtx.Variables["include"] = cty.ObjectVal(includes)
+
+	// Locals can refer to each other, so they are evaluated until all the references are resolved
+	attrs := make(hclsyntax.Attributes)
+	for _, block := range blocksOfType(body, "locals") {
+		maps.Copy(attrs, block.Body.Attributes)
+	}
+	for range len(attrs) + 1 {
+		for name, attr := range attrs {
+			cfg.locals[name] = l.eval(evalCtx, attr)
+		}
+		evalCtx.Variables["local"] = cty.ObjectVal(cfg.locals)
+	}
+
+	dependencies := make(map[string]cty.Value)
+	for _, block := range blocksOfType(body, "dependency") {
+		if len(block.Labels) == 0 {
+			continue
+		}
+		// Outputs of dependencies are unknown without applying them, so only mocks are available
+		outputs := cty.DynamicVal
+		if attr, ok := block.Body.Attributes["mock_outputs"]; ok {
+			outputs = l.eval(evalCtx, attr)
+		}
+		dependencies[block.Labels[0]] = cty.ObjectVal(map[string]cty.Value{
+			"outputs": outputs,
+		})
+	}
+	evalCtx.Variables["dependency"] = cty.ObjectVal(dependencies)
+
+	for _, block := range blocksOfType(body, "terraform") {
+		if attr, ok := block.Body.Attributes["source"]; ok {
+			cfg.source = l.evalString(evalCtx, attr)
+		}
+	}
+
+	for _, block := range blocksOfType(body, "generate") {
+		if len(block.Labels) == 0 {
+			continue
+		}
+		if disable := l.eval(evalCtx, block.Body.Attributes["disable"]); disable.Type() == cty.Bool &&
+			disable.IsKnown() && disable.True() {
+			continue
+		}
+		cfg.generates[block.Labels[0]] = terragruntGenerate{
+			path:     l.evalString(evalCtx, block.Body.Attributes["path"]),
+			contents: l.evalString(evalCtx, block.Body.Attributes["contents"]),
+		}
+	}
+
+	if attr, ok := body.Attributes["inputs"]; ok {
+		if inputs := l.eval(evalCtx, attr); inputs.IsKnown() && !inputs.IsNull() && inputs.CanIterateElements() {
+			maps.Copy(cfg.inputs, inputs.AsValueMap())
+		}
+	}
+
+	for _, includedCfg := range included {
+		cfg.merge(includedCfg)
+	}
+	return cfg, nil
+}
+
+func blocksOfType(body *hclsyntax.Body, blockType string) hclsyntax.Blocks {
+	var blocks hclsyntax.Blocks
+	for _, block := range body.Blocks {
+		if block.Type == blockType {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// eval returns a dynamic value if the attribute cannot be evaluated,
+// e.g. references to unknown values or unsupported functions
+func (l *terragruntLoader) eval(ctx *hcl.EvalContext, attr *hclsyntax.Attribute) cty.Value {
+	if attr == nil {
+		return cty.NilVal
+	}
+	val, diags := attr.Expr.Value(ctx)
+	if diags.HasErrors() {
+		l.logger.Debug("Failed to evaluate the attribute", log.String("name", attr.Name), log.Err(diags))
+		return cty.DynamicVal
+	}
+	return val
+}
+
+func (l *terragruntLoader) evalString(ctx *hcl.EvalContext, attr *hclsyntax.Attribute) string {
+	val := l.eval(ctx, attr)
+	if val == cty.NilVal || val.IsNull() || !val.IsKnown() || val.Type() != cty.String {
+		return ""
+	}
+	return val.AsString()
+}
+
+// resolvePath returns the path relative to the root of the filesystem
+func (l *terragruntLoader) resolvePath(dir, filePath string) string {
+	if strings.HasPrefix(filePath, "/") {
+		return path.Clean(strings.TrimPrefix(filePath, "/"))
+	}
+	return path.Join(dir, filePath)
+}
+
+func (l *terragruntLoader) functions(dir string, depth int) map[string]function.Function {
+	funcs := Functions(l.fsys, dir)
+	funcs["find_in_parent_folders"] = l.findInParentFoldersFunc(dir)
+	// The functions refer to the included configuration in both the unit and the included configuration
+	parentDir := func() string {
+		if dir == l.unitDir && l.includeDir != "" {
+			return l.includeDir
+		}
+		return dir
+	}
+	funcs["get_terragrunt_dir"] = stringFunc(func() string { return "/" + l.unitDir })
+	funcs["get_original_terragrunt_dir"] = stringFunc(func() string { return "/" + l.unitDir })
+	funcs["get_parent_terragrunt_dir"] = stringFunc(func() string { return "/" + parentDir() })
+	funcs["path_relative_to_include"] = stringFunc(func() string { return relPath(parentDir(), l.unitDir) })
+	funcs["path_relative_from_include"] = stringFunc(func() string { return relPath(l.unitDir, parentDir()) })
+	funcs["get_env"] = getEnvFunc
+	funcs["read_terragrunt_config"] = l.readTerragruntConfigFunc(dir, depth)
+	return funcs
+}
+
+func relPath(base, target string) string {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return "."
+	}
+	return filepath.ToSlash(rel)
+}
+
+func stringFunc(fn func() string) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{
+			Name: "args",
+			Type: cty.DynamicPseudoType,
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(_ []cty.Value, _ cty.Type) (cty.Value, error) {
+			return cty.StringVal(fn()), nil
+		},
+	})
+}
+
+// getEnvFunc returns the default value as the scan should not depend on the environment
+var getEnvFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "name",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "default",
+		Type: cty.String,
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		if len(args) > 1 {
+			return args[1], nil
+		}
+		return cty.UnknownVal(cty.String), nil
+	},
+})
+
+// findInParentFoldersFunc searches for the file in the parent directories of the configuration.
+// The default file name is "terragrunt.hcl".
+func (l *terragruntLoader) findInParentFoldersFunc(dir string) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{
+			Name: "args",
+			Type: cty.String,
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			name := terragruntFile
+			if len(args) > 0 {
+				name = args[0].AsString()
+			}
+			for current := dir; current != "." && current != "/"; {
+				current = path.Dir(current)
+				filePath := path.Join(current, name)
+				if _, err := fs.Stat(l.fsys, filePath); err == nil {
+					return cty.StringVal("/" + filePath), nil
+				}
+			}
+			if len(args) > 1 {
+				return args[1], nil
+			}
+			return cty.NilVal, fmt.Errorf("%s not found in the parent folders of %s", name, dir)
+		},
+	})
+}
+
+// readTerragruntConfigFunc returns the locals and inputs of another configuration
+func (l *terragruntLoader) readTerragruntConfigFunc(dir string, depth int) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "path",
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+			cfg, err := l.load(l.resolvePath(dir, args[0].AsString()), depth+1)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			return cfg.toValue(), nil
+		},
+	})
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/internal/testutil"
+)
+
+func TestParseTerragrunt(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"live/root.hcl": `
+locals {
+  env = "prod"
+}
+
+inputs = {
+  name = "${local.env}-bucket"
+  acl  = "public-read"
+}
+
+generate "provider" {
+  path     = "provider.tf"
+  contents = <<EOF
+provider "aws" {
+  region = "us-east-1"
+}
+EOF
+}
+`,
+		"live/prod/bucket/terragrunt.hcl": `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+locals {
+  suffix = "${include.root.locals.env}-${path_relative_to_include()}"
+}
+
+dependency "kms" {
+  config_path = "../kms"
+  mock_outputs = {
+    key_arn = "arn:aws:kms:us-east-1:123456789012:key/mock"
+  }
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+terraform {
+  source = "${get_terragrunt_dir()}/../../../modules//bucket"
+}
+
+inputs = {
+  acl     = "private"
+  tag     = local.suffix
+  kms_key = dependency.kms.outputs.key_arn
+  vpc_id  = dependency.vpc.outputs.vpc_id
+}
+`,
+		"modules/bucket/main.tf": `
+variable "name" {}
+variable "acl" {}
+variable "tag" {}
+variable "kms_key" {}
+variable "vpc_id" {
+  default = "default-vpc"
+}
+
+resource "aws_s3_bucket" "this" {
+  bucket  = var.name
+  acl     = var.acl
+  tags    = { Name = var.tag }
+  kms_key = var.kms_key
+  vpc_id  = var.vpc_id
+}
+`,
+	})
+
+	parser := New(fsys, "", OptionStopOnHCLError(true))
+	require.NoError(t, parser.ParseFS(context.TODO(), "live/prod/bucket"))
+
+	modules, _, err := parser.EvaluateAll(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	providers := modules.GetBlocks().OfType("provider")
+	require.Len(t, providers, 1)
+	assert.Equal(t, "us-east-1", providers[0].GetAttribute("region").Value().AsString())
+
+	buckets := modules.GetResourcesByType("aws_s3_bucket")
+	require.Len(t, buckets, 1)
+	bucket := buckets[0]
+
+	assert.Equal(t, "prod-bucket", bucket.GetAttribute("bucket").Value().AsString())
+	assert.Equal(t, "private", bucket.GetAttribute("acl").Value().AsString())
+	assert.Equal(t, "prod-prod/bucket", bucket.GetAttribute("tags").MapValue("Name").AsString())
+	assert.Equal(t, "arn:aws:kms:us-east-1:123456789012:key/mock", bucket.GetAttribute("kms_key").Value().AsString())
+	assert.Equal(t, "default-vpc", bucket.GetAttribute("vpc_id").Value().AsString())
+}
+
+func TestParseTerragrunt_LocalFiles(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"terragrunt.hcl": `
+inputs = {
+  name = "from-terragrunt"
+  acl  = "from-terragrunt"
+}
+`,
+		"main.tf": `
+variable "name" {}
+variable "acl" {}
+
+resource "aws_s3_bucket" "this" {
+  bucket = var.name
+  acl    = var.acl
+}
+`,
+		"main.tfvars": `acl = "from-tfvars"`,
+	})
+
+	parser := New(fsys, "", OptionStopOnHCLError(true), OptionWithTFVarsPaths("main.tfvars"))
+	require.NoError(t, parser.ParseFS(context.TODO(), "."))
+
+	modules, _, err := parser.EvaluateAll(context.TODO())
+	require.NoError(t, err)
+
+	buckets := modules.GetResourcesByType("aws_s3_bucket")
+	require.Len(t, buckets, 1)
+	assert.Equal(t, "from-terragrunt", buckets[0].GetAttribute("bucket").Value().AsString())
+	assert.Equal(t, "from-tfvars", buckets[0].GetAttribute("acl").Value().AsString())
+}
+
+func TestTerragruntModule(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "local",
+			source: "/modules//vpc",
+			want: `module "terragrunt" {
+  source = "../../modules/vpc"
+}
+`,
+		},
+		{
+			name:   "registry",
+			source: "tfr:///terraform-aws-modules/vpc/aws?version=5.0.0",
+			want: `module "terragrunt" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+`,
+		},
+		{
+			name:   "git",
+			source: "git::https://github.com/org/modules.git//vpc?ref=v1.0.0",
+			want: `module "terragrunt" {
+  source = "git::https://github.com/org/modules.git//vpc?ref=v1.0.0"
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &terragruntLoader{unitDir: "live/vpc"}
+			got := terragruntModule(l.moduleSource(tt.source), nil)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/samber/lo"
 
 	"github.com/aquasecurity/trivy/pkg/iac/rego"
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
@@ -22,9 +25,16 @@ import (
 	"github.com/aquasecurity/trivy/pkg/set"
 )
 
+const (
+	terragruntFile = "terragrunt.hcl"
+	// terragruntCacheDir contains copies of modules downloaded by Terragrunt
+	terragruntCacheDir = ".terragrunt-cache"
+)
+
 var _ scanners.FSScanner = (*Scanner)(nil)
 var _ options.ConfigurableScanner = (*Scanner)(nil)
 var _ ConfigurableTerraformScanner = (*Scanner)(nil)
+var _ options.ConfigurableParseLimits = (*Scanner)(nil)
 
 type Scanner struct {
 	mu           sync.Mutex
@@ -50,6 +60,14 @@ func (s *Scanner) AddParserOptions(opts ...parser.Option) {
 	s.parserOpt = append(s.parserOpt, opts...)
 }
 
+func (s *Scanner) SetMaxFileSize(size int64) {
+	s.AddParserOptions(parser.OptionWithMaxFileSize(size))
+}
+
+func (s *Scanner) SetParseTimeout(timeout time.Duration) {
+	s.AddParserOptions(parser.OptionWithParseTimeout(timeout))
+}
+
 func (s *Scanner) AddExecutorOptions(opts ...executor.Option) {
 	s.executorOpt = append(s.executorOpt, opts...)
 }
@@ -93,6 +111,9 @@ func (s *Scanner) ScanFS(ctx context.Context, target fs.FS, dir string) (scan.Re
 
 	// find directories which directly contain tf files
 	modulePaths := s.findModules(target, dir, dir)
+	// Terragrunt units can be nested in each other
+	modulePaths = append(modulePaths, s.findTerragruntUnits(target, dir)...)
+	modulePaths = lo.Uniq(modulePaths)
 	sort.Strings(modulePaths)
 
 	if len(modulePaths) == 0 {
@@ -216,7 +237,7 @@ func (s *Scanner) findModules(target fs.FS, scanDir string, dirs ...string) []st
 			continue
 		}
 		for _, file := range files {
-			if file.IsDir() {
+			if file.IsDir() && file.Name() != terragruntCacheDir {
 				others = append(others, path.Join(dir, file.Name()))
 			}
 		}
@@ -229,6 +250,27 @@ func (s *Scanner) findModules(target fs.FS, scanDir string, dirs ...string) []st
 	return s.removeNestedDirs(roots)
 }
 
+// findTerragruntUnits returns directories containing terragrunt.hcl
+func (s *Scanner) findTerragruntUnits(target fs.FS, dir string) []string {
+	var units []string
+	err := fs.WalkDir(target, filepath.ToSlash(dir), func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir() && (d.Name() == terragruntCacheDir || d.Name() == ".terraform"):
+			return fs.SkipDir
+		case !d.IsDir() && d.Name() == terragruntFile:
+			units = append(units, path.Dir(filePath))
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("Failed to find Terragrunt units", log.FilePath(dir), log.Err(err))
+	}
+	return units
+}
+
 func (s *Scanner) isRootModule(target fs.FS, dir string) bool {
 	files, err := fs.ReadDir(target, filepath.ToSlash(dir))
 	if err != nil {
@@ -995,3 +995,117 @@ deny contains res if {
 
 	assert.Len(t, results.GetFailed(), 1)
 }
+
+func TestScanTerragruntUnits(t *testing.T) {
+	fsys := testutil.CreateFS(t, map[string]string{
+		"live/root.hcl": `
+inputs = {
+  bucket_name = ""
+}
+`,
+		"live/dev/s3/terragrunt.hcl": `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "../../../modules//s3"
+}
+`,
+		"live/prod/s3/terragrunt.hcl": `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "../../../modules//s3"
+}
+
+inputs = {
+  bucket_name = "prod-bucket"
+}
+`,
+		"live/.terragrunt-cache/abc/s3/main.tf": `
+resource "aws_s3_bucket" "cached" {
+}
+`,
+		"modules/s3/main.tf": `
+variable "bucket_name" {
+  type = string
+}
+
+resource "aws_s3_bucket" "main" {
+  bucket = var.bucket_name
+}
+`,
+		"rules/bucket_name.rego": emptyBucketCheck,
+	})
+
+	scanner := New(
+		rego.WithPolicyNamespaces("user"),
+		rego.WithPolicyFilesystem(fsys),
+		rego.WithPolicyDirs("rules"),
+		rego.WithEmbeddedPolicies(false),
+		rego.WithEmbeddedLibraries(false),
+		ScannerWithAllDirectories(true),
+	)
+
+	results, err := scanner.ScanFS(context.TODO(), fsys, ".")
+	require.NoError(t, err)
+
+	assert.Len(t, results.GetPassed(), 1)
+	require.Len(t, results.GetFailed(), 1)
+	assert.Equal(t, "modules/s3/main.tf", results.GetFailed()[0].Range().GetFilename())
+}
+
+func TestRequiredProvidersInput(t *testing.T) {
+	check := `# METADATA
+# custom:
+#   avd_id: USER-TEST-0125
+#   input:
+#     selector:
+#     - type: cloud
+package user.providers
+
+import rego.v1
+
+deny contains res if {
+	some provider in input.terraform.required_providers
+	provider.source == "hashicorp/aws"
+	not startswith(provider.version, "~> 5")
+	res := result.new(sprintf("Provider %s has the version constraint %q", [provider.name, provider.version]), provider)
+}
+`
+
+	fsys := testutil.CreateFS(t, map[string]string{
+		"code/main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "4.67.0"
+    }
+    random = {
+      source  = "hashicorp/random"
+      version = "3.6.0"
+    }
+  }
+}
+`,
+		"rules/providers.rego": check,
+	})
+
+	results, err := scanFS(fsys, "code",
+		rego.WithPolicyFilesystem(fsys),
+		rego.WithPolicyDirs("rules"),
+		rego.WithPolicyNamespaces("user"),
+	)
+	require.NoError(t, err)
+
+	require.Len(t, results.GetFailed(), 1)
+	failure := results.GetFailed()[0]
+	assert.Equal(t, `Provider aws has the version constraint "4.67.0"`, failure.Description())
+	assert.Equal(t, "code/main.tf", failure.Range().GetFilename())
+	assert.Equal(t, 4, failure.Range().GetStartLine())
+	assert.Equal(t, 7, failure.Range().GetEndLine())
+}
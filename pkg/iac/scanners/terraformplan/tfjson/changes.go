@@ -0,0 +1,90 @@
+package tfjson
+
+import (
+	"slices"
+
+	"github.com/aquasecurity/trivy/pkg/iac/scan"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/tfjson/parser"
+)
+
+// planInputKey is the key of the resource changes in the input of checks
+const planInputKey = "terraform_plan"
+
+// ScannerWithChangesOnly reports only misconfigurations of resources created or updated by the plan,
+// so that pre-existing infrastructure is not flagged again at plan time.
+func ScannerWithChangesOnly(changesOnly bool) options.ScannerOption {
+	return func(s options.ConfigurableScanner) {
+		if ss, ok := s.(*Scanner); ok {
+			ss.changesOnly = changesOnly
+		}
+	}
+}
+
+// planInput returns the resource changes with their before and after values, e.g.
+//
+//	{"resource_changes": [{"address": "aws_s3_bucket.example", "actions": ["update"], "before": {...}, "after": {...}}]}
+//
+// Changes of resources rendered in the HCL have the metadata, so checks can report findings at the resource block.
+func planInput(planFile *parser.PlanFile, locations []parser.ResourceLocation, fsKey string) map[string]any {
+	changes := make([]any, 0, len(planFile.ResourceChanges))
+	for _, rc := range planFile.ResourceChanges {
+		change := map[string]any{
+			"address": rc.Address,
+			"mode":    rc.Mode,
+			"type":    rc.Type,
+			"name":    rc.Name,
+			"actions": rc.Actions,
+			"before":  rc.Before,
+			"after":   rc.After,
+		}
+		if loc, ok := findLocation(locations, rc.Address); ok {
+			change["__defsec_metadata"] = map[string]any{
+				"filepath":     "main.tf",
+				"startline":    loc.StartLine,
+				"endline":      loc.EndLine,
+				"sourceprefix": "",
+				"managed":      true,
+				"explicit":     false,
+				"unresolvable": false,
+				"fskey":        fsKey,
+				"resource":     rc.Address,
+			}
+		}
+		changes = append(changes, change)
+	}
+	return map[string]any{
+		"resource_changes": changes,
+	}
+}
+
+// ignoreUnchanged marks failures of resources not created or updated by the plan as ignored
+func ignoreUnchanged(results scan.Results, planFile *parser.PlanFile, locations []parser.ResourceLocation) {
+	changed := make(map[string]bool)
+	for _, rc := range planFile.ResourceChanges {
+		// A replaced resource has both "delete" and "create"
+		changed[rc.Address] = slices.Contains(rc.Actions, "create") || slices.Contains(rc.Actions, "update")
+	}
+
+	for i, result := range results {
+		if result.Status() != scan.StatusFailed {
+			continue
+		}
+		line := result.Range().GetStartLine()
+		for _, loc := range locations {
+			if line >= loc.StartLine && line <= loc.EndLine && !changed[loc.Address] {
+				results[i].OverrideStatus(scan.StatusIgnored)
+				break
+			}
+		}
+	}
+}
+
+func findLocation(locations []parser.ResourceLocation, address string) (parser.ResourceLocation, bool) {
+	for _, loc := range locations {
+		if loc.Address == address {
+			return loc, true
+		}
+	}
+	return parser.ResourceLocation{}, false
+}
@@ -51,30 +51,54 @@ func (p *Parser) Parse(reader io.Reader) (*PlanFile, error) {
 }
 
 func (p *PlanFile) ToFS() (*memoryfs.FS, error) {
+	rootFS, _, err := p.ToFSWithLocations()
+	return rootFS, err
+}
+
+// ToFSWithLocations converts the plan into HCL and also returns the locations of the resource blocks in "main.tf",
+// so that findings can be associated with the resource changes.
+func (p *PlanFile) ToFSWithLocations() (*memoryfs.FS, []ResourceLocation, error) {
 
 	rootFS := memoryfs.New()
 
 	var fileResources []string
+	var locations []ResourceLocation
 
 	resources, err := getResources(p.PlannedValues.RootModule, p.ResourceChanges, p.Configuration)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	line := 1
 	for _, r := range resources {
-		fileResources = append(fileResources, r.ToHCL())
+		hcl := r.block.ToHCL()
+		fileResources = append(fileResources, hcl)
+
+		endLine := line + strings.Count(hcl, "\n")
+		locations = append(locations, ResourceLocation{
+			Address:   r.address,
+			StartLine: line,
+			EndLine:   endLine,
+		})
+		// blocks are separated by an empty line
+		line = endLine + 2
 	}
 
 	fileContent := strings.Join(fileResources, "\n\n")
 	if err := rootFS.WriteFile("main.tf", []byte(fileContent), os.ModePerm); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return rootFS, nil
+	return rootFS, locations, nil
+
+}
 
+type planResource struct {
+	address string
+	block   terraform.PlanBlock
 }
 
-func getResources(module Module, resourceChanges []ResourceChange, configuration Configuration) ([]terraform.PlanBlock, error) {
-	var resources []terraform.PlanBlock
+func getResources(module Module, resourceChanges []ResourceChange, configuration Configuration) ([]planResource, error) {
+	var resources []planResource
 	for _, r := range module.Resources {
 		resourceName := r.Name
 		if strings.HasPrefix(r.Address, "module.") {
@@ -117,7 +141,10 @@ func getResources(module Module, resourceChanges []ResourceChange, configuration
 				}
 			}
 		}
-		resources = append(resources, *res)
+		resources = append(resources, planResource{
+			address: r.Address,
+			block:   *res,
+		})
 	}
 
 	for _, m := range module.ChildModules {
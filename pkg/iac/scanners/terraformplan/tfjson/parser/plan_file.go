@@ -21,8 +21,9 @@ type ConfigurationResource struct {
 }
 
 type Change struct {
-	Before map[string]any `json:"before"`
-	After  map[string]any `json:"after"`
+	Actions []string       `json:"actions"`
+	Before  map[string]any `json:"before"`
+	After   map[string]any `json:"after"`
 }
 
 type Module struct {
@@ -58,6 +59,13 @@ type Configuration struct {
 	RootModule ConfigurationModule `json:"root_module"`
 }
 
+// ResourceLocation is the location of the resource block in the HCL converted from the plan
+type ResourceLocation struct {
+	Address   string
+	StartLine int
+	EndLine   int
+}
+
 type PlanFile struct {
 	FormatVersion    string           `json:"format_version"`
 	TerraformVersion string           `json:"terraform_version"`
@@ -9,15 +9,18 @@ import (
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraform"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraform/executor"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/tfjson/parser"
+	"github.com/aquasecurity/trivy/pkg/iac/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 )
 
 type Scanner struct {
-	parser    *parser.Parser
-	logger    *log.Logger
-	options   []options.ScannerOption
-	tfScanner *terraform.Scanner
+	parser      *parser.Parser
+	logger      *log.Logger
+	options     []options.ScannerOption
+	tfScanner   *terraform.Scanner
+	changesOnly bool
 }
 
 func (s *Scanner) Name() string {
@@ -60,6 +63,9 @@ func New(opts ...options.ScannerOption) *Scanner {
 		parser:    parser.New(),
 		tfScanner: terraform.New(opts...),
 	}
+	for _, opt := range opts {
+		opt(scanner)
+	}
 
 	return scanner
 }
@@ -82,10 +88,23 @@ func (s *Scanner) Scan(reader io.Reader) (scan.Results, error) {
 		return nil, err
 	}
 
-	planFS, err := planFile.ToFS()
+	planFS, locations, err := planFile.ToFSWithLocations()
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert plan to FS: %w", err)
 	}
 
-	return s.tfScanner.ScanFS(context.TODO(), planFS, ".")
+	// Expose the resource changes to checks as "input.terraform_plan"
+	s.tfScanner.AddExecutorOptions(executor.OptionWithInputValues(map[string]any{
+		planInputKey: planInput(planFile, locations, types.CreateFSKey(planFS)),
+	}))
+
+	results, err := s.tfScanner.ScanFS(context.TODO(), planFS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if s.changesOnly {
+		ignoreUnchanged(results, planFile, locations)
+	}
+	return results, nil
 }
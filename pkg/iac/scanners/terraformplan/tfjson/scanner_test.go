@@ -126,3 +126,110 @@ deny[cause] {
 		})
 	}
 }
+
+const changesPlan = `{
+  "format_version": "1.2",
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {"address": "aws_s3_bucket.existing", "mode": "managed", "type": "aws_s3_bucket", "name": "existing"},
+        {"address": "aws_s3_bucket.changed", "mode": "managed", "type": "aws_s3_bucket", "name": "changed"}
+      ]
+    }
+  },
+  "resource_changes": [
+    {
+      "address": "aws_s3_bucket.existing", "mode": "managed", "type": "aws_s3_bucket", "name": "existing",
+      "change": {
+        "actions": ["no-op"],
+        "before": {"bucket": "tfsec-plan-testing", "acl": "private"},
+        "after": {"bucket": "tfsec-plan-testing", "acl": "private"}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.changed", "mode": "managed", "type": "aws_s3_bucket", "name": "changed",
+      "change": {
+        "actions": ["update"],
+        "before": {"bucket": "tfsec-plan-testing", "acl": "private"},
+        "after": {"bucket": "tfsec-plan-testing", "acl": "public-read"}
+      }
+    }
+  ],
+  "configuration": {"root_module": {}}
+}`
+
+const aclChangeCheck = `# METADATA
+# title: Buckets should not become public
+# custom:
+#   avd_id: AVD-TEST-0124
+#   severity: HIGH
+#   short_code: no-public-change
+package user.plan.ABC002
+
+deny[res] {
+	change := input.terraform_plan.resource_changes[_]
+	change.type == "aws_s3_bucket"
+	change.before.acl == "private"
+	change.after.acl == "public-read"
+	res := result.new("The bucket becomes public", change)
+}
+`
+
+func Test_TerraformScanner_PlanChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		check       string
+		changesOnly bool
+		wantIDs     []string
+		wantLines   []int
+	}{
+		{
+			name:      "all resources",
+			check:     defaultCheck,
+			wantIDs:   []string{"AVD-TEST-0123", "AVD-TEST-0123"},
+			wantLines: []int{3, 8},
+		},
+		{
+			name:        "changed resources only",
+			check:       defaultCheck,
+			changesOnly: true,
+			wantIDs:     []string{"AVD-TEST-0123"},
+			wantLines:   []int{8},
+		},
+		{
+			name:      "before and after values",
+			check:     aclChangeCheck,
+			wantIDs:   []string{"AVD-TEST-0124"},
+			wantLines: []int{6}, // the block of aws_s3_bucket.changed
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := testutil.CreateFS(t, map[string]string{
+				"/code/main.tfplan.json": changesPlan,
+				"/rules/test.rego":       tt.check,
+			})
+
+			scanner := New(
+				rego.WithPolicyDirs("rules"),
+				rego.WithPolicyNamespaces("user"),
+				rego.WithPolicyFilesystem(fs),
+				ScannerWithChangesOnly(tt.changesOnly),
+			)
+
+			results, err := scanner.ScanFS(context.TODO(), fs, "code")
+			require.NoError(t, err)
+
+			var gotIDs []string
+			var gotLines []int
+			for _, failure := range results.GetFailed() {
+				gotIDs = append(gotIDs, failure.Rule().AVDID)
+				gotLines = append(gotLines, failure.Range().GetStartLine())
+				assert.Equal(t, "main.tf", failure.Range().GetLocalFilename())
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+			assert.ElementsMatch(t, tt.wantLines, gotLines)
+		})
+	}
+}
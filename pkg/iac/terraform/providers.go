@@ -0,0 +1,85 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+
+	iacTypes "github.com/aquasecurity/trivy/pkg/iac/types"
+)
+
+// defaultRegistryHost is omitted from the source addresses of providers
+const defaultRegistryHost = "registry.terraform.io/"
+
+// RequiredProvider is a provider declared in the required_providers block, e.g.
+//
+//	terraform {
+//	  required_providers {
+//	    aws = {
+//	      source  = "hashicorp/aws"
+//	      version = "~> 5.0"
+//	    }
+//	  }
+//	}
+type RequiredProvider struct {
+	Metadata iacTypes.Metadata
+	// Name is the local name of the provider, e.g. "aws"
+	Name string
+	// Source is the source address in lowercase without the default registry host, e.g. "hashicorp/aws".
+	// Providers without the source belong to the "hashicorp" namespace.
+	Source string
+	// Version is the version constraint, e.g. "~> 5.0"
+	Version string
+}
+
+// RequiredProviders returns the providers declared in the terraform blocks of the module
+func (c *Module) RequiredProviders() []RequiredProvider {
+	var providers []RequiredProvider
+	for _, block := range c.blocks.OfType("terraform") {
+		for _, requiredProviders := range block.GetBlocks("required_providers") {
+			for _, attr := range requiredProviders.GetAttributes() {
+				providers = append(providers, newRequiredProvider(attr))
+			}
+		}
+	}
+	return providers
+}
+
+func newRequiredProvider(attr *Attribute) RequiredProvider {
+	provider := RequiredProvider{
+		Metadata: attr.GetMetadata(),
+		Name:     attr.Name(),
+	}
+
+	val := attr.Value()
+	switch {
+	case val.Type() == cty.String:
+		// the legacy syntax only has the version constraint, e.g. aws = "~> 3.0"
+		provider.Version = val.AsString()
+	case val.Type().IsObjectType() || val.Type().IsMapType():
+		provider.Source = stringElement(val, "source")
+		provider.Version = stringElement(val, "version")
+	}
+
+	if provider.Source == "" {
+		provider.Source = "hashicorp/" + provider.Name
+	}
+	provider.Source = strings.TrimPrefix(strings.ToLower(provider.Source), defaultRegistryHost)
+	return provider
+}
+
+func stringElement(val cty.Value, key string) string {
+	var elem cty.Value
+	switch {
+	case val.Type().IsObjectType() && val.Type().HasAttribute(key):
+		elem = val.GetAttr(key)
+	case val.Type().IsMapType() && val.HasIndex(cty.StringVal(key)).True():
+		elem = val.Index(cty.StringVal(key))
+	default:
+		return ""
+	}
+	if !elem.IsKnown() || elem.IsNull() || elem.Type() != cty.String {
+		return ""
+	}
+	return elem.AsString()
+}
@@ -3,12 +3,17 @@ package types
 type Source string
 
 const (
-	SourceDockerfile Source = "dockerfile"
-	SourceKubernetes Source = "kubernetes"
-	SourceRbac       Source = "rbac"   // deprecated - please use "kubernetes" instead
-	SourceDefsec     Source = "defsec" // deprecated - please use "cloud" instead
-	SourceCloud      Source = "cloud"
-	SourceYAML       Source = "yaml"
-	SourceJSON       Source = "json"
-	SourceTOML       Source = "toml"
+	SourceDockerfile     Source = "dockerfile"
+	SourceKubernetes     Source = "kubernetes"
+	SourceRbac           Source = "rbac"   // deprecated - please use "kubernetes" instead
+	SourceDefsec         Source = "defsec" // deprecated - please use "cloud" instead
+	SourceCloud          Source = "cloud"
+	SourceYAML           Source = "yaml"
+	SourceJSON           Source = "json"
+	SourceTOML           Source = "toml"
+	SourceAnsible        Source = "ansible"
+	SourceGitHubActions  Source = "github-actions"
+	SourceGitLabCI       Source = "gitlab-ci"
+	SourceAzurePipelines Source = "azure-pipelines"
+	SourceCUE            Source = "cue"
 )
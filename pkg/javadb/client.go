@@ -16,6 +16,7 @@ import (
 	"github.com/aquasecurity/trivy-java-db/pkg/db"
 	"github.com/aquasecurity/trivy-java-db/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/dependency/parser/java/jar"
+	"github.com/aquasecurity/trivy/pkg/downloader"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/oci"
@@ -38,6 +39,7 @@ var updater *Updater
 
 type Updater struct {
 	repos          []name.Reference
+	url            string
 	dbDir          string
 	skip           bool
 	quiet          bool
@@ -62,7 +64,11 @@ func (u *Updater) Update() error {
 	if (meta.Version != SchemaVersion || !u.isNewDB(ctx, meta)) && !u.skip {
 		// Download DB
 		// TODO: support remote options
-		if err := u.downloadDB(ctx); err != nil {
+		if u.url != "" {
+			if err := u.downloadURL(ctx); err != nil {
+				return xerrors.Errorf("HTTP download error: %w", err)
+			}
+		} else if err := u.downloadDB(ctx); err != nil {
 			return xerrors.Errorf("OCI artifact error: %w", err)
 		}
 
@@ -113,9 +119,54 @@ func (u *Updater) downloadDB(ctx context.Context) error {
 	return nil
 }
 
-func Init(cacheDir string, javaDBRepositories []name.Reference, skip, quiet bool, registryOption ftypes.RegistryOptions) {
+func (u *Updater) downloadURL(ctx context.Context) error {
+	log.InfoContext(ctx, "Downloading Java DB...", log.String("url", u.url))
+
+	// The ETag is sent only if the local DB was downloaded from the same URL and is still valid
+	var etag string
+	if meta := downloader.ReadArchiveMetadata(u.dbDir); meta.URL == u.url {
+		metac := db.NewMetadata(u.dbDir)
+		if m, err := metac.Get(); err == nil && m.Version == SchemaVersion {
+			etag = meta.ETag
+		}
+	}
+
+	newETag, err := downloader.DownloadArchive(ctx, u.url, u.dbDir, downloader.Options{
+		Insecure: u.registryOption.Insecure,
+		ETag:     etag,
+	}, func(dir string) error {
+		metac := db.NewMetadata(dir)
+		meta, err := metac.Get()
+		if err != nil {
+			return xerrors.Errorf("metadata error: %w", err)
+		} else if meta.Version != SchemaVersion {
+			return xerrors.Errorf("schema version mismatch (expected: %d, actual: %d)", SchemaVersion, meta.Version)
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, downloader.ErrSkipDownload):
+		log.InfoContext(ctx, "Java DB is not modified on the server")
+		newETag = etag
+	case err != nil:
+		return xerrors.Errorf("failed to download Java DB: %w", err)
+	}
+
+	if err = downloader.WriteArchiveMetadata(u.dbDir, downloader.ArchiveMetadata{
+		URL:  u.url,
+		ETag: newETag,
+	}); err != nil {
+		return xerrors.Errorf("unable to write the download metadata: %w", err)
+	}
+	return nil
+}
+
+// Init initializes the Java DB updater.
+// If javaDBURL is not empty, the Java DB is downloaded from the URL over HTTP(S) instead of javaDBRepositories.
+func Init(cacheDir string, javaDBRepositories []name.Reference, javaDBURL string, skip, quiet bool, registryOption ftypes.RegistryOptions) {
 	updater = &Updater{
 		repos:          javaDBRepositories,
+		url:            javaDBURL,
 		dbDir:          dbDir(cacheDir),
 		skip:           skip,
 		quiet:          quiet,
@@ -0,0 +1,249 @@
+package javadb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy-java-db/pkg/db"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/utils/archive"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+const (
+	dbFileName       = "trivy-java.db"
+	metadataFileName = "metadata.json"
+
+	// checksumFileName has SHA-256 digests of the other files
+	checksumFileName = "SHA256SUMS"
+)
+
+// ExportOption holds the options for exporting the Java DB.
+type ExportOption struct {
+	// GroupIDs limits the indexes to the artifacts with these group IDs and their sub-groups,
+	// e.g. "org.apache" matches "org.apache" and "org.apache.commons".
+	// All the indexes are exported if empty.
+	GroupIDs []string
+}
+
+// Verify checks the integrity of the Java DB in the cache directory.
+func Verify(ctx context.Context, cacheDir string) (db.Metadata, error) {
+	return verify(ctx, dbDir(cacheDir))
+}
+
+func verify(ctx context.Context, dir string) (db.Metadata, error) {
+	metac := db.NewMetadata(dir)
+	meta, err := metac.Get()
+	if err != nil {
+		return db.Metadata{}, xerrors.Errorf("Java DB metadata error: %w", err)
+	} else if meta.Version != SchemaVersion {
+		return db.Metadata{}, xerrors.Errorf("Java DB schema version mismatch (expected: %d, actual: %d)", SchemaVersion, meta.Version)
+	}
+
+	// Downloaded DBs don't have the checksum file. They are verified with the digests of OCI artifacts.
+	if _, err = os.Stat(filepath.Join(dir, checksumFileName)); err == nil {
+		if err = verifyChecksums(dir); err != nil {
+			return db.Metadata{}, xerrors.Errorf("checksum error: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return db.Metadata{}, xerrors.Errorf("stat error: %w", err)
+	}
+
+	dbPath := filepath.Join(dir, dbFileName)
+	if _, err = os.Stat(dbPath); err != nil {
+		return db.Metadata{}, xerrors.Errorf("Java DB file error: %w", err)
+	}
+	if err = checkDB(ctx, dbPath); err != nil {
+		return db.Metadata{}, xerrors.Errorf("Java DB file error: %w", err)
+	}
+	return meta, nil
+}
+
+// checkDB runs the SQLite integrity check and makes sure that the tables exist.
+func checkDB(ctx context.Context, dbPath string) error {
+	client, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro", dbPath))
+	if err != nil {
+		return xerrors.Errorf("open error: %w", err)
+	}
+	defer client.Close()
+
+	var result string
+	if err = client.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+		return xerrors.Errorf("integrity check error: %w", err)
+	} else if result != "ok" {
+		return xerrors.Errorf("integrity check failed: %s", result)
+	}
+
+	var count int
+	if err = client.QueryRowContext(ctx, "SELECT COUNT(*) FROM indices").Scan(&count); err != nil {
+		return xerrors.Errorf("unable to count indexes: %w", err)
+	}
+	log.DebugContext(ctx, "Java DB indexes", log.Int("count", count))
+	return nil
+}
+
+// Export writes the Java DB in the cache directory to a gzipped tarball,
+// which can be imported with Import in air-gapped environments.
+func Export(ctx context.Context, cacheDir, archivePath string, opt ExportOption) error {
+	ctx = log.WithContextPrefix(ctx, log.PrefixJavaDB)
+	dir := dbDir(cacheDir)
+	if _, err := verify(ctx, dir); err != nil {
+		return xerrors.Errorf("unable to verify Java DB: %w", err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "trivy-java-db-*")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dbPath := filepath.Join(dir, dbFileName)
+	if len(opt.GroupIDs) > 0 {
+		// Filter out indexes from a copy, not to modify the cached DB
+		dbPath = filepath.Join(tmpDir, dbFileName)
+		if _, err = fsutils.CopyFile(filepath.Join(dir, dbFileName), dbPath); err != nil {
+			return xerrors.Errorf("copy error: %w", err)
+		}
+		if err = filterGroups(ctx, dbPath, opt.GroupIDs); err != nil {
+			return xerrors.Errorf("unable to filter indexes: %w", err)
+		}
+	}
+
+	files := map[string]string{
+		dbFileName:       dbPath,
+		metadataFileName: filepath.Join(dir, metadataFileName),
+	}
+	checksums, err := archive.Checksums(files)
+	if err != nil {
+		return xerrors.Errorf("checksum error: %w", err)
+	}
+	checksumPath := filepath.Join(tmpDir, checksumFileName)
+	if err = os.WriteFile(checksumPath, checksums, 0o644); err != nil {
+		return xerrors.Errorf("unable to write checksums: %w", err)
+	}
+	files[checksumFileName] = checksumPath
+
+	if err = archive.Write(archivePath, files); err != nil {
+		return xerrors.Errorf("archive error: %w", err)
+	}
+	log.InfoContext(ctx, "Java DB exported", log.FilePath(archivePath))
+	return nil
+}
+
+// filterGroups deletes the indexes of artifacts not matching the group IDs.
+func filterGroups(ctx context.Context, dbPath string, groupIDs []string) error {
+	client, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return xerrors.Errorf("open error: %w", err)
+	}
+	defer client.Close()
+
+	// e.g. "org.apache" => group_id = 'org.apache' OR group_id LIKE 'org.apache.%'
+	var conds []string
+	var args []any
+	for _, groupID := range groupIDs {
+		conds = append(conds, `group_id = ? OR group_id LIKE ? ESCAPE '\'`)
+		args = append(args, groupID, escapeLike(groupID)+".%")
+	}
+	notMatched := fmt.Sprintf("NOT (%s)", strings.Join(conds, " OR "))
+
+	res, err := client.ExecContext(ctx, fmt.Sprintf(
+		"DELETE FROM indices WHERE artifact_id IN (SELECT id FROM artifacts WHERE %s)", notMatched), args...)
+	if err != nil {
+		return xerrors.Errorf("unable to delete indexes: %w", err)
+	}
+	if _, err = client.ExecContext(ctx, "DELETE FROM artifacts WHERE "+notMatched, args...); err != nil {
+		return xerrors.Errorf("unable to delete artifacts: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil {
+		log.InfoContext(ctx, "Indexes not matching the group IDs were removed", log.Int64("count", n))
+	}
+
+	// Shrink the file
+	if _, err = client.ExecContext(ctx, "VACUUM"); err != nil {
+		return xerrors.Errorf("vacuum error: %w", err)
+	}
+	return nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// Import installs the Java DB exported by Export into the cache directory.
+// The existing Java DB is replaced only when the archive is verified.
+func Import(ctx context.Context, cacheDir, archivePath string) error {
+	ctx = log.WithContextPrefix(ctx, log.PrefixJavaDB)
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return xerrors.Errorf("failed to create the cache dir: %w", err)
+	}
+
+	// Extract into the cache directory so that the DB can be moved atomically
+	tmpDir, err := os.MkdirTemp(cacheDir, "java-db-import-*")
+	if err != nil {
+		return xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Only the files written by Export are extracted
+	if err = archive.Extract(archivePath, tmpDir, validName); err != nil {
+		return xerrors.Errorf("unable to extract %s: %w", archivePath, err)
+	}
+	if _, err = os.Stat(filepath.Join(tmpDir, checksumFileName)); err != nil {
+		return xerrors.Errorf("%s not found in the archive: %w", checksumFileName, err)
+	}
+
+	meta, err := verify(ctx, tmpDir)
+	if err != nil {
+		return xerrors.Errorf("unable to verify Java DB: %w", err)
+	}
+
+	// Update DownloadedAt in the same way as downloaded DBs
+	meta.DownloadedAt = time.Now().UTC()
+	metac := db.NewMetadata(tmpDir)
+	if err = metac.Update(meta); err != nil {
+		return xerrors.Errorf("Java DB metadata update error: %w", err)
+	}
+	// The checksum is no longer valid for the updated metadata
+	if err = os.Remove(filepath.Join(tmpDir, checksumFileName)); err != nil {
+		return xerrors.Errorf("unable to remove the checksum file: %w", err)
+	}
+
+	dir := dbDir(cacheDir)
+	if err = os.RemoveAll(dir); err != nil {
+		return xerrors.Errorf("unable to remove the old Java DB: %w", err)
+	}
+	if err = os.Rename(tmpDir, dir); err != nil {
+		return xerrors.Errorf("unable to install Java DB: %w", err)
+	}
+
+	log.InfoContext(ctx, "Java DB imported", log.String("updated_at", meta.UpdatedAt.String()))
+	return nil
+}
+
+// validName reports whether the file is one of the files written by Export
+func validName(name string) bool {
+	return slices.Contains([]string{dbFileName, metadataFileName, checksumFileName}, name)
+}
+
+func verifyChecksums(dir string) error {
+	verified, err := archive.VerifyChecksums(dir, checksumFileName, validName)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{dbFileName, metadataFileName} {
+		if !slices.Contains(verified, name) {
+			return xerrors.Errorf("digest of %s not found", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package javadb_test
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	jdb "github.com/aquasecurity/trivy-java-db/pkg/db"
+	jtypes "github.com/aquasecurity/trivy-java-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/javadb"
+)
+
+var (
+	commonsIndex = jtypes.Index{
+		GroupID:     "org.apache.commons",
+		ArtifactID:  "commons-lang3",
+		Version:     "3.14.0",
+		SHA1:        mustDecode("1ed471194b02f2c6cb734a0cd6f6f107c673afae"),
+		ArchiveType: jtypes.JarType,
+	}
+	jacksonIndex = jtypes.Index{
+		GroupID:     "com.fasterxml.jackson.core",
+		ArtifactID:  "jackson-databind",
+		Version:     "2.17.0",
+		SHA1:        mustDecode("7173e9e1d4bc6d7ca03bc4eeedcd548b8b580b34"),
+		ArchiveType: jtypes.JarType,
+	}
+	// Not a sub-group of "org.apache"
+	apacheLikeIndex = jtypes.Index{
+		GroupID:     "org.apachex",
+		ArtifactID:  "fake",
+		Version:     "1.0.0",
+		SHA1:        mustDecode("0000000000000000000000000000000000000001"),
+		ArchiveType: jtypes.JarType,
+	}
+)
+
+func TestExportImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		groupIDs []string
+		want     []jtypes.Index
+		wantGone []jtypes.Index
+	}{
+		{
+			name: "all indexes",
+			want: []jtypes.Index{
+				commonsIndex,
+				jacksonIndex,
+				apacheLikeIndex,
+			},
+		},
+		{
+			name:     "partial indexes",
+			groupIDs: []string{"org.apache"},
+			want: []jtypes.Index{
+				commonsIndex,
+			},
+			wantGone: []jtypes.Index{
+				jacksonIndex,
+				apacheLikeIndex,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			srcCacheDir := t.TempDir()
+			initDB(t, srcCacheDir, []jtypes.Index{
+				commonsIndex,
+				jacksonIndex,
+				apacheLikeIndex,
+			})
+
+			archivePath := filepath.Join(t.TempDir(), "java-db.tar.gz")
+			err := javadb.Export(ctx, srcCacheDir, archivePath, javadb.ExportOption{GroupIDs: tt.groupIDs})
+			require.NoError(t, err)
+
+			dstCacheDir := t.TempDir()
+			err = javadb.Import(ctx, dstCacheDir, archivePath)
+			require.NoError(t, err)
+
+			meta, err := javadb.Verify(ctx, dstCacheDir)
+			require.NoError(t, err)
+			assert.Equal(t, jdb.SchemaVersion, meta.Version)
+			assert.False(t, meta.DownloadedAt.IsZero())
+
+			dbc, err := jdb.New(filepath.Join(dstCacheDir, "java-db"))
+			require.NoError(t, err)
+			defer dbc.Close()
+
+			for _, want := range tt.want {
+				got, err := dbc.SelectIndexBySha1(hex.EncodeToString(want.SHA1))
+				require.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+			for _, gone := range tt.wantGone {
+				got, err := dbc.SelectIndexBySha1(hex.EncodeToString(gone.SHA1))
+				require.NoError(t, err)
+				assert.Empty(t, got.ArtifactID)
+			}
+
+			// The source DB is not modified
+			_, err = javadb.Verify(ctx, srcCacheDir)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name    string
+		archive string
+		wantErr string
+	}{
+		{
+			name:    "not an archive",
+			archive: "testdata/invalid.tar.gz",
+			wantErr: "gzip error",
+		},
+		{
+			name:    "tampered metadata",
+			archive: "testdata/tampered.tar.gz",
+			wantErr: "digest mismatch for metadata.json",
+		},
+		{
+			name:    "no checksum",
+			archive: "testdata/no-checksum.tar.gz",
+			wantErr: "SHA256SUMS not found in the archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheDir := t.TempDir()
+			err := javadb.Import(context.Background(), cacheDir, tt.archive)
+			require.ErrorContains(t, err, tt.wantErr)
+
+			// The cached Java DB is kept as is
+			_, err = os.Stat(filepath.Join(cacheDir, "java-db"))
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func initDB(t *testing.T, cacheDir string, indexes []jtypes.Index) {
+	dbDir := filepath.Join(cacheDir, "java-db")
+	dbc, err := jdb.New(dbDir)
+	require.NoError(t, err)
+	require.NoError(t, dbc.Init())
+	require.NoError(t, dbc.InsertIndexes(indexes))
+	require.NoError(t, dbc.Close())
+
+	metac := jdb.NewMetadata(dbDir)
+	require.NoError(t, metac.Update(jdb.Metadata{
+		Version:    jdb.SchemaVersion,
+		NextUpdate: time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+	}))
+}
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
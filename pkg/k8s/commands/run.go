@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
 
 	"github.com/spf13/viper"
 	"golang.org/x/xerrors"
 
 	k8sArtifacts "github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
 	"github.com/aquasecurity/trivy-kubernetes/pkg/k8s"
+	"github.com/aquasecurity/trivy/pkg/clock"
 	cmd "github.com/aquasecurity/trivy/pkg/commands/artifact"
 	"github.com/aquasecurity/trivy/pkg/commands/operation"
 	cr "github.com/aquasecurity/trivy/pkg/compliance/report"
@@ -23,7 +27,7 @@ import (
 )
 
 // Run runs a k8s scan
-func Run(ctx context.Context, args []string, opts flag.Options) error {
+func Run(ctx context.Context, args []string, opts flag.Options) (err error) {
 	clusterOptions := []k8s.ClusterOption{
 		k8s.WithKubeConfig(opts.K8sOptions.KubeConfig),
 		k8s.WithBurst(opts.K8sOptions.Burst),
@@ -32,6 +36,10 @@ func Run(ctx context.Context, args []string, opts flag.Options) error {
 	if len(args) > 0 {
 		clusterOptions = append(clusterOptions, k8s.WithContext(args[0]))
 	}
+	defer func() {
+		err = operation.ExitOnError(opts, err)
+	}()
+
 	cluster, err := k8s.GetCluster(clusterOptions...)
 	if err != nil {
 		return xerrors.Errorf("failed getting k8s cluster: %w", err)
@@ -107,6 +115,36 @@ func (r *runner) run(ctx context.Context, artifacts []*k8sArtifacts.Artifact) er
 		if err != nil {
 			return xerrors.Errorf("compliance report build error: %w", err)
 		}
+		return r.writeCompliance(ctx, complianceReport, output)
+	}
+
+	if err := k8sRep.Write(ctx, rpt, report.Option{
+		Format:        r.flagOpts.Format,
+		Report:        r.flagOpts.ReportFormat,
+		Output:        output,
+		Severities:    r.flagOpts.Severities,
+		Scanners:      r.flagOpts.ScanOptions.Scanners,
+		APIVersion:    r.flagOpts.AppVersion,
+		DedupFindings: r.flagOpts.DedupFindings,
+	}); err != nil {
+		return xerrors.Errorf("unable to write results: %w", err)
+	}
+
+	return operation.Exit(r.flagOpts, rpt.Failed(), rpt.Partial(), types.Metadata{})
+}
+
+// writeCompliance writes the compliance report, or its drift from the previous scan with "--drift".
+// The status of the controls is saved to the cache directory for the next scan in any case.
+func (r *runner) writeCompliance(ctx context.Context, complianceReport *cr.ComplianceReport, output io.Writer) error {
+	snapshotPath := complianceSnapshotPath(r.flagOpts.CacheDir, r.cluster, complianceReport.ID)
+	defer func() {
+		snapshot := cr.NewSnapshot(complianceReport, r.cluster, clock.Now(ctx))
+		if err := cr.SaveSnapshot(snapshotPath, snapshot); err != nil {
+			log.WarnContext(ctx, "Unable to save the compliance result for drift detection", log.Err(err))
+		}
+	}()
+
+	if !r.flagOpts.Drift {
 		return cr.Write(ctx, complianceReport, cr.Option{
 			Format: r.flagOpts.Format,
 			Report: r.flagOpts.ReportFormat,
@@ -114,18 +152,30 @@ func (r *runner) run(ctx context.Context, artifacts []*k8sArtifacts.Artifact) er
 		})
 	}
 
-	if err := k8sRep.Write(ctx, rpt, report.Option{
-		Format:     r.flagOpts.Format,
-		Report:     r.flagOpts.ReportFormat,
-		Output:     output,
-		Severities: r.flagOpts.Severities,
-		Scanners:   r.flagOpts.ScanOptions.Scanners,
-		APIVersion: r.flagOpts.AppVersion,
+	previous, err := cr.LoadSnapshot(snapshotPath)
+	if err != nil {
+		return xerrors.Errorf("compliance snapshot error: %w", err)
+	}
+	drift := cr.BuildDriftReport(previous, complianceReport, r.cluster)
+	if err = cr.WriteDrift(drift, cr.Option{
+		Format: r.flagOpts.Format,
+		Output: output,
 	}); err != nil {
-		return xerrors.Errorf("unable to write results: %w", err)
+		return xerrors.Errorf("unable to write the drift report: %w", err)
 	}
 
-	return operation.Exit(r.flagOpts, rpt.Failed(), types.Metadata{})
+	// Controls that started failing are regarded as findings
+	return operation.Exit(r.flagOpts, drift.Regressed(), false, types.Metadata{})
+}
+
+// unsafePathChars are characters not allowed in file names, e.g. cluster names may be ARNs of EKS clusters
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// complianceSnapshotPath returns the path of the compliance result saved for the cluster,
+// e.g. ~/.cache/trivy/compliance/k8s/my-cluster/k8s-cis-1.23.json
+func complianceSnapshotPath(cacheDir, cluster, specID string) string {
+	return filepath.Join(cacheDir, "compliance", "k8s", unsafePathChars.ReplaceAllString(cluster, "_"),
+		unsafePathChars.ReplaceAllString(specID, "_")+".json")
 }
 
 // Full-cluster scanning with '--format table' without explicit '--report all' is not allowed so that it won't mess up user's terminal.
@@ -144,7 +194,12 @@ func (r *runner) run(ctx context.Context, artifacts []*k8sArtifacts.Artifact) er
 //
 // e.g. $ trivy k8s pod myapp
 func validateReportArguments(opts flag.Options) error {
-	if opts.ReportFormat == "all" &&
+	if opts.Drift && opts.Compliance.Spec.ID == "" {
+		return xerrors.New("'--drift' requires '--compliance'")
+	}
+
+	// The drift report shows only the changed controls
+	if !opts.Drift && opts.ReportFormat == "all" &&
 		!viper.IsSet("report") &&
 		opts.Format == "table" {
 
@@ -11,6 +11,9 @@ import (
 type JSONWriter struct {
 	Output io.Writer
 	Report string
+
+	// DedupFindings outputs identical vulnerabilities of multiple resources only once
+	DedupFindings bool
 }
 
 // Write writes the results in JSON format
@@ -18,6 +21,10 @@ func (jw JSONWriter) Write(report Report) error {
 	var output []byte
 	var err error
 
+	if jw.DedupFindings {
+		report = report.deduplicate()
+	}
+
 	switch jw.Report {
 	case AllReport:
 		output, err = json.MarshalIndent(report, "", "  ")
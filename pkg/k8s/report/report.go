@@ -34,6 +34,7 @@ type Option struct {
 	ColumnHeading []string
 	Scanners      types.Scanners
 	APIVersion    string
+	DedupFindings bool
 }
 
 // Report represents a kubernetes scan report
@@ -43,6 +44,8 @@ type Report struct {
 	Resources     []Resource `json:",omitempty"`
 	BOM           *core.BOM  `json:"-"`
 	name          string
+
+	DeduplicatedVulnerabilities []types.DeduplicatedVulnerability `json:",omitempty"`
 }
 
 // ConsolidatedReport represents a kubernetes scan report with consolidated findings
@@ -50,6 +53,8 @@ type ConsolidatedReport struct {
 	SchemaVersion int `json:",omitempty"`
 	ClusterName   string
 	Findings      []Resource `json:",omitempty"`
+
+	DeduplicatedVulnerabilities []types.DeduplicatedVulnerability `json:",omitempty"`
 }
 
 // Resource represents a kubernetes resource report
@@ -69,6 +74,15 @@ func (r Resource) fullname() string {
 	return strings.ToLower(fmt.Sprintf("%s/%s/%s", r.Namespace, r.Kind, r.Name))
 }
 
+// target returns the name of the result target prefixed with the resource, e.g. "default/Deployment/app: alpine:3.20 (alpine 3.20.3)"
+func (r Resource) target(result types.Result) string {
+	name := fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	if r.Namespace != "" {
+		name = r.Namespace + "/" + name
+	}
+	return fmt.Sprintf("%s: %s", name, result.Target)
+}
+
 // Failed returns whether the k8s report includes any vulnerabilities or misconfigurations
 func (r Report) Failed() bool {
 	for _, v := range r.Resources {
@@ -79,10 +93,36 @@ func (r Report) Failed() bool {
 	return false
 }
 
+// Partial returns true if some resources failed to be scanned
+func (r Report) Partial() bool {
+	return lo.ContainsBy(r.Resources, func(resource Resource) bool {
+		return resource.Error != ""
+	})
+}
+
+// deduplicate moves identical vulnerabilities of all the resources to DeduplicatedVulnerabilities.
+// The resources are copied so that the original report is not modified.
+func (r Report) deduplicate() Report {
+	d := types.NewVulnerabilityDeduplicator()
+	resources := make([]Resource, 0, len(r.Resources))
+	for _, resource := range r.Resources {
+		resource.Results = slices.Clone(resource.Results)
+		for i, result := range resource.Results {
+			d.Add(resource.target(result), result.Vulnerabilities)
+			resource.Results[i].Vulnerabilities = nil
+		}
+		resources = append(resources, resource)
+	}
+	r.Resources = resources
+	r.DeduplicatedVulnerabilities = d.Vulnerabilities()
+	return r
+}
+
 func (r Report) consolidate() ConsolidatedReport {
 	consolidated := ConsolidatedReport{
-		SchemaVersion: r.SchemaVersion,
-		ClusterName:   r.ClusterName,
+		SchemaVersion:               r.SchemaVersion,
+		ClusterName:                 r.ClusterName,
+		DeduplicatedVulnerabilities: r.DeduplicatedVulnerabilities,
 	}
 
 	index := make(map[string]Resource)
@@ -603,6 +603,64 @@ func TestReport_consolidate(t *testing.T) {
 	}
 }
 
+func TestReport_deduplicate(t *testing.T) {
+	vuln := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2022-1111",
+		PkgName:          "musl",
+		InstalledVersion: "1.2.2-r0",
+	}
+	other := vuln
+	other.VulnerabilityID = "CVE-2022-2222"
+
+	report := Report{
+		ClusterName: "test",
+		Resources: []Resource{
+			{
+				Namespace: "default",
+				Kind:      "Deployment",
+				Name:      "orion",
+				Results: types.Results{
+					{
+						Target:          "alpine:3.14 (alpine 3.14.2)",
+						Vulnerabilities: []types.DetectedVulnerability{vuln, other},
+					},
+				},
+			},
+			{
+				Kind: "NodeComponents",
+				Name: "node-1",
+				Results: types.Results{
+					{
+						Target:          "alpine:3.14 (alpine 3.14.2)",
+						Vulnerabilities: []types.DetectedVulnerability{vuln},
+					},
+				},
+			},
+		},
+	}
+
+	got := report.deduplicate()
+	assert.Equal(t, []types.DeduplicatedVulnerability{
+		{
+			DetectedVulnerability: vuln,
+			Targets: []string{
+				"default/Deployment/orion: alpine:3.14 (alpine 3.14.2)",
+				"NodeComponents/node-1: alpine:3.14 (alpine 3.14.2)",
+			},
+		},
+		{
+			DetectedVulnerability: other,
+			Targets:               []string{"default/Deployment/orion: alpine:3.14 (alpine 3.14.2)"},
+		},
+	}, got.DeduplicatedVulnerabilities)
+
+	for _, resource := range got.Resources {
+		assert.Empty(t, resource.Results[0].Vulnerabilities)
+	}
+	// The original report must not be modified
+	assert.Len(t, report.Resources[0].Results[0].Vulnerabilities, 2)
+}
+
 func TestResource_fullname(t *testing.T) {
 	tests := []struct {
 		expected string
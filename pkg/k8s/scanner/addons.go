@@ -0,0 +1,184 @@
+package scanner
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/aquasecurity/trivy-kubernetes/pkg/artifacts"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/k8s"
+	"github.com/aquasecurity/trivy/pkg/k8s/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// clusterAddon is a cluster component whose vulnerabilities are published as advisories of its upstream project.
+// Such vulnerabilities are not detected by scanning the contents of its images,
+// so they are matched by the version in the image tag.
+type clusterAddon struct {
+	// pkgType selects the advisories, i.e. the Kubernetes advisories or the Go advisories
+	pkgType ftypes.LangType
+	// name is the package name in the advisories
+	name string
+	// repositories are the image repositories without the registry, matched by their last path components
+	repositories []string
+}
+
+var clusterAddons = []clusterAddon{
+	{
+		pkgType:      ftypes.K8sUpstream,
+		name:         "k8s.io/apiserver",
+		repositories: []string{"kube-apiserver"},
+	},
+	{
+		pkgType:      ftypes.K8sUpstream,
+		name:         "k8s.io/controller-manager",
+		repositories: []string{"kube-controller-manager"},
+	},
+	{
+		pkgType:      ftypes.K8sUpstream,
+		name:         "k8s.io/kube-scheduler",
+		repositories: []string{"kube-scheduler"},
+	},
+	{
+		pkgType:      ftypes.K8sUpstream,
+		name:         "k8s.io/kube-proxy",
+		repositories: []string{"kube-proxy"},
+	},
+	{
+		pkgType:      ftypes.GoBinary,
+		name:         "github.com/coredns/coredns",
+		repositories: []string{"coredns"},
+	},
+	{
+		pkgType: ftypes.GoBinary,
+		name:    "k8s.io/ingress-nginx",
+		repositories: []string{
+			"ingress-nginx/controller",
+			"ingress-nginx/controller-chroot",
+		},
+	},
+	{
+		pkgType: ftypes.GoBinary,
+		name:    "github.com/cert-manager/cert-manager",
+		repositories: []string{
+			"cert-manager-controller",
+			"cert-manager-cainjector",
+			"cert-manager-webhook",
+			"cert-manager-acmesolver",
+		},
+	},
+}
+
+// semverPrefix matches the version in image tags, e.g. "v1.11.1" in "v1.11.1-eksbuild.4"
+var semverPrefix = regexp.MustCompile(`^v?(\d+\.\d+\.\d+)`)
+
+// scanAddonVulns matches the control plane and add-on components running in the cluster against their advisories.
+// The control plane components found in the KBOM are skipped, as they are scanned by scanK8sVulns.
+func (s *Scanner) scanAddonVulns(ctx context.Context, artifactsData, k8sCoreArtifacts []*artifacts.Artifact) ([]report.Resource, error) {
+	var scanned []string
+	for _, artifact := range k8sCoreArtifacts {
+		if artifact.Kind == controlPlaneComponents {
+			scanned = append(scanned, artifact.Name)
+		}
+	}
+	nodeName := s.findNodeName(k8sCoreArtifacts)
+
+	k8sScanner := k8s.NewKubernetesScanner()
+	scanOptions := types.ScanOptions{
+		Scanners: s.opts.Scanners,
+		PkgTypes: s.opts.PkgTypes,
+	}
+
+	var resources []report.Resource
+	for _, artifact := range artifactsData {
+		apps := addonApplications(artifact.Images, scanned, nodeName)
+		if len(apps) == 0 {
+			continue
+		}
+
+		results, _, err := k8sScanner.Scan(ctx, types.ScanTarget{
+			Applications: apps,
+		}, scanOptions)
+		if err != nil {
+			return nil, err
+		}
+		if results != nil {
+			resource, err := s.filter(ctx, types.Report{
+				Results:      results,
+				ArtifactName: artifact.Name,
+			}, artifact)
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// addonApplications returns the control plane and add-on components of the images,
+// e.g. "k8s.io/ingress-nginx" v1.9.4 for "registry.k8s.io/ingress-nginx/controller:v1.9.4".
+// The images are used as the file paths, so findings are reported per image.
+func addonApplications(images, scanned []string, nodeName string) []ftypes.Application {
+	var apps []ftypes.Application
+	for _, image := range images {
+		repo, tag := imageRepositoryTag(image)
+		m := semverPrefix.FindStringSubmatch(tag)
+		if m == nil {
+			continue
+		}
+		addon, ok := findClusterAddon(repo)
+		if !ok || slices.Contains(scanned, addon.name) {
+			continue
+		}
+
+		pkgType, ver := addon.pkgType, "v"+m[1]
+		if pkgType == ftypes.K8sUpstream {
+			// Identify k8s distribution by the full tag, e.g. "v1.28.3-eks-4f4795d"
+			ver = unifiedVersion(tag)
+			namespace := k8sNamespace(ver, nodeName)
+			if namespace == "" {
+				continue
+			}
+			pkgType = ftypes.LangType(namespace)
+		}
+
+		apps = append(apps, ftypes.Application{
+			Type:     pkgType,
+			FilePath: image,
+			Packages: []ftypes.Package{
+				{
+					Name:    addon.name,
+					Version: ver,
+				},
+			},
+		})
+	}
+	return apps
+}
+
+func findClusterAddon(repo string) (clusterAddon, bool) {
+	for _, addon := range clusterAddons {
+		for _, r := range addon.repositories {
+			if repo == r || strings.HasSuffix(repo, "/"+r) {
+				return addon, true
+			}
+		}
+	}
+	return clusterAddon{}, false
+}
+
+// imageRepositoryTag returns the repository without the registry and the tag of the image,
+// e.g. "ingress-nginx/controller" and "v1.9.4" for "registry.k8s.io/ingress-nginx/controller:v1.9.4@sha256:..."
+func imageRepositoryTag(image string) (string, string) {
+	image, _, _ = strings.Cut(image, "@")
+	tag, err := name.NewTag(image)
+	if err != nil {
+		return "", ""
+	}
+	return tag.RepositoryStr(), tag.TagStr()
+}
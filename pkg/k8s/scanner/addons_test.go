@@ -0,0 +1,95 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestAddonApplications(t *testing.T) {
+	tests := []struct {
+		name     string
+		images   []string
+		scanned  []string
+		nodeName string
+		want     []ftypes.Application
+	}{
+		{
+			name: "add-ons",
+			images: []string{
+				"registry.k8s.io/ingress-nginx/controller:v1.9.4@sha256:5b161f051d017e55d358435f295f5e9a297e66158f136321d9b04520ec6c48a3",
+				"602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/coredns:v1.11.1-eksbuild.4",
+				"quay.io/jetstack/cert-manager-controller:v1.13.2",
+				"nginx:1.25.3",
+			},
+			want: []ftypes.Application{
+				{
+					Type:     ftypes.GoBinary,
+					FilePath: "registry.k8s.io/ingress-nginx/controller:v1.9.4@sha256:5b161f051d017e55d358435f295f5e9a297e66158f136321d9b04520ec6c48a3",
+					Packages: []ftypes.Package{
+						{
+							Name:    "k8s.io/ingress-nginx",
+							Version: "v1.9.4",
+						},
+					},
+				},
+				{
+					Type:     ftypes.GoBinary,
+					FilePath: "602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/coredns:v1.11.1-eksbuild.4",
+					Packages: []ftypes.Package{
+						{
+							Name:    "github.com/coredns/coredns",
+							Version: "v1.11.1",
+						},
+					},
+				},
+				{
+					Type:     ftypes.GoBinary,
+					FilePath: "quay.io/jetstack/cert-manager-controller:v1.13.2",
+					Packages: []ftypes.Package{
+						{
+							Name:    "github.com/cert-manager/cert-manager",
+							Version: "v1.13.2",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "control plane",
+			images: []string{
+				"registry.k8s.io/kube-apiserver:v1.28.2",
+				"registry.k8s.io/kube-scheduler:v1.28.2",
+			},
+			scanned:  []string{"k8s.io/kube-scheduler"},
+			nodeName: "kind-control-plane",
+			want: []ftypes.Application{
+				{
+					Type:     ftypes.K8sUpstream,
+					FilePath: "registry.k8s.io/kube-apiserver:v1.28.2",
+					Packages: []ftypes.Package{
+						{
+							Name:    "k8s.io/apiserver",
+							Version: "v1.28.2",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "no version",
+			images: []string{
+				"coredns/coredns",
+				"registry.k8s.io/ingress-nginx/controller@sha256:5b161f051d017e55d358435f295f5e9a297e66158f136321d9b04520ec6c48a3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addonApplications(tt.images, tt.scanned, tt.nodeName)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -133,6 +133,12 @@ func (s *Scanner) Scan(ctx context.Context, artifactsData []*artifacts.Artifact)
 			return report.Report{}, err
 		}
 		resources = append(resources, k8sResource...)
+
+		addonResources, err := s.scanAddonVulns(ctx, resourceArtifacts, k8sCoreArtifacts)
+		if err != nil {
+			return report.Report{}, err
+		}
+		resources = append(resources, addonResources...)
 	}
 	return report.Report{
 		SchemaVersion: 0,
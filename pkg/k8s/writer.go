@@ -18,8 +18,9 @@ func Write(ctx context.Context, k8sreport report.Report, option report.Option) e
 	switch option.Format {
 	case types.FormatJSON:
 		jwriter := report.JSONWriter{
-			Output: option.Output,
-			Report: option.Report,
+			Output:        option.Output,
+			Report:        option.Report,
+			DedupFindings: option.DedupFindings,
 		}
 		return jwriter.Write(k8sreport)
 	case types.FormatTable:
@@ -3,6 +3,8 @@ package licensing
 import (
 	"fmt"
 	"io"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"sync"
 
@@ -19,8 +21,16 @@ var (
 	cf             *classifier.Classifier
 	classifierOnce sync.Once
 	m              sync.Mutex
+
+	// e.g. LICENSE, LICENCE.txt, LICENSE-MIT, COPYING.md
+	licenseFileRegexp = regexp.MustCompile(`^(?i)((UN)?LICEN(S|C)E|COPYING)([-._].*)?$`)
 )
 
+// IsLicenseFile returns true if the file name looks like a license file bundled with a package
+func IsLicenseFile(fileName string) bool {
+	return licenseFileRegexp.MatchString(fileName)
+}
+
 func initGoogleClassifier() error {
 	// Initialize the default classifier once.
 	// This loading is expensive and should be called only when the license classification is needed.
@@ -78,10 +88,25 @@ func Classify(filePath string, r io.Reader, confidenceLevel float64) (*types.Lic
 			Link:       licenseLink,
 		})
 	}
+
+	// Fall back to the text similarity for license files without known license headers,
+	// e.g. vendored license files with modified wording.
+	if len(findings) == 0 && IsLicenseFile(filepath.Base(filePath)) {
+		if name, score := loadCorpus().matchText(content); score > confidenceLevel {
+			matchType = types.LicenseTypeFile
+			findings = append(findings, types.LicenseFinding{
+				Name:       name,
+				Confidence: score,
+				Link:       fmt.Sprintf("https://spdx.org/licenses/%s.html", name),
+			})
+		}
+	}
+
 	sort.Sort(findings)
 	return &types.LicenseFile{
-		Type:     matchType,
-		FilePath: filePath,
-		Findings: findings,
+		Type:       matchType,
+		FilePath:   filePath,
+		Findings:   findings,
+		Copyrights: Copyrights(string(content)),
 	}, nil
 }
@@ -63,6 +63,22 @@ func TestClassifier_FullClassify(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:     "License file with reordered clauses",
+			filePath: "testdata/LICENSE_reordered",
+			want: &types.LicenseFile{
+				Type:     types.LicenseTypeFile,
+				FilePath: "testdata/LICENSE_reordered",
+				Findings: []types.LicenseFinding{
+					{
+						Name:       "BSD-3-Clause",
+						Confidence: 0.9635416666666666,
+						Link:       "https://spdx.org/licenses/BSD-3-Clause.html",
+					},
+				},
+				Copyrights: []string{"Copyright (c) 2021, Example Authors"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -76,3 +92,44 @@ func TestClassifier_FullClassify(t *testing.T) {
 		})
 	}
 }
+
+func TestIsLicenseFile(t *testing.T) {
+	tests := []struct {
+		fileName string
+		want     bool
+	}{
+		{
+			fileName: "LICENSE",
+			want:     true,
+		},
+		{
+			fileName: "LICENCE.txt",
+			want:     true,
+		},
+		{
+			fileName: "LICENSE-MIT",
+			want:     true,
+		},
+		{
+			fileName: "copying.md",
+			want:     true,
+		},
+		{
+			fileName: "UNLICENSE",
+			want:     true,
+		},
+		{
+			fileName: "README.md",
+			want:     false,
+		},
+		{
+			fileName: "licensed.go",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			assert.Equal(t, tt.want, licensing.IsLicenseFile(tt.fileName))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package licensing
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/licensing/expression"
+)
+
+var (
+	gplProjects = []string{
+		"gpl-2.0-only",
+		"gpl-2.0-or-later",
+		"gpl-3.0-only",
+		"gpl-3.0-or-later",
+		"agpl-3.0-only",
+		"agpl-3.0-or-later",
+	}
+
+	// copyleftLicenses maps strong copyleft licenses to the project licenses under which the combined work
+	// can be distributed.
+	copyleftLicenses = map[string][]string{
+		"gpl-2.0-only":      gplProjects[:2],
+		"gpl-2.0-or-later":  gplProjects,
+		"gpl-3.0-only":      gplProjects[2:],
+		"gpl-3.0-or-later":  gplProjects[2:],
+		"agpl-3.0-only":     gplProjects[2:],
+		"agpl-3.0-or-later": gplProjects[2:],
+	}
+
+	// incompatibleLicenses maps licenses to the project licenses they are known to be incompatible with,
+	// such as Apache-2.0 with GPL-2.0-only due to the patent termination clause.
+	incompatibleLicenses = map[string][]string{
+		"apache-2.0":        {"gpl-2.0-only"},
+		"lgpl-3.0-only":     {"gpl-2.0-only"},
+		"lgpl-3.0-or-later": {"gpl-2.0-only"},
+		"mpl-1.1":           gplProjects,
+		"epl-1.0":           gplProjects,
+		"epl-2.0":           gplProjects,
+		"cddl-1.0":          gplProjects,
+		"cddl-1.1":          gplProjects,
+	}
+
+	// linkingExceptions allow linking the copyleft license into works under any license
+	linkingExceptions = []string{
+		"classpath-exception-2.0",
+		"gcc-exception-3.1",
+		"llvm-exception",
+	}
+)
+
+// Compatibility evaluates whether licenses of dependencies can be combined into the project under the given license,
+// e.g. GPL-3.0-only components linked into an Apache-2.0 project.
+// It is a simplified model based on well-known incompatibilities and not legal advice.
+// The zero value is disabled.
+type Compatibility struct {
+	projectLicense string
+	project        string // normalized key of the project license
+}
+
+func NewCompatibility(projectLicense string) Compatibility {
+	if projectLicense == "" {
+		return Compatibility{}
+	}
+	return Compatibility{
+		projectLicense: projectLicense,
+		project:        policyKey(expression.SimpleExpr{License: projectLicense}),
+	}
+}
+
+// Enabled returns true if the project license is configured
+func (c Compatibility) Enabled() bool {
+	return c.project != ""
+}
+
+// Evaluate returns why the license is incompatible with the project license.
+// It returns an empty string if the license is compatible or unknown.
+func (c Compatibility) Evaluate(license string) string {
+	if !c.Enabled() || strings.HasPrefix(license, LicenseTextPrefix) {
+		return ""
+	}
+	expr, err := expression.Parse(license)
+	if err != nil {
+		expr = expression.SimpleExpr{License: license}
+	}
+	if c.compatible(expr) {
+		return ""
+	}
+	return fmt.Sprintf("%s is incompatible with the project license %s", license, c.projectLicense)
+}
+
+// compatible evaluates the license expression.
+// The licensee may choose any license of "OR", while all licenses of "AND" must be compatible.
+func (c Compatibility) compatible(expr expression.Expression) bool {
+	if e, ok := expr.(expression.CompoundExpr); ok {
+		switch e.Conjunction() {
+		case expression.TokenOR:
+			return c.compatible(e.Left()) || c.compatible(e.Right())
+		case expression.TokenAnd:
+			return c.compatible(e.Left()) && c.compatible(e.Right())
+		case expression.TokenWith:
+			if slices.Contains(linkingExceptions, strings.ToLower(e.Right().String())) {
+				return true
+			}
+			return c.compatible(e.Left())
+		}
+	}
+
+	key := policyKey(expr)
+	if key == c.project {
+		return true
+	}
+	if projects, ok := copyleftLicenses[key]; ok {
+		return slices.Contains(projects, c.project)
+	}
+	return !slices.Contains(incompatibleLicenses[key], c.project)
+}
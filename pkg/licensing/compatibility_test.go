@@ -0,0 +1,87 @@
+package licensing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+func TestCompatibility_Evaluate(t *testing.T) {
+	tests := []struct {
+		name           string
+		projectLicense string
+		license        string
+		want           string
+	}{
+		{
+			name:           "copyleft into permissive project",
+			projectLicense: "Apache-2.0",
+			license:        "GPL-3.0-only",
+			want:           "GPL-3.0-only is incompatible with the project license Apache-2.0",
+		},
+		{
+			name:           "permissive into permissive project",
+			projectLicense: "Apache-2.0",
+			license:        "MIT",
+		},
+		{
+			name:           "same license",
+			projectLicense: "GPL-2.0",
+			license:        "GPL-2.0-only",
+		},
+		{
+			name:           "or later",
+			projectLicense: "GPL-3.0-only",
+			license:        "GPL-2.0+",
+		},
+		{
+			name:           "GPL-2.0-only into GPL-3.0 project",
+			projectLicense: "GPL-3.0-only",
+			license:        "GPL-2.0-only",
+			want:           "GPL-2.0-only is incompatible with the project license GPL-3.0-only",
+		},
+		{
+			name:           "known incompatibility",
+			projectLicense: "GPL-2.0-only",
+			license:        "Apache-2.0",
+			want:           "Apache-2.0 is incompatible with the project license GPL-2.0-only",
+		},
+		{
+			name:           "dual license",
+			projectLicense: "Apache-2.0",
+			license:        "GPL-2.0-only OR MIT",
+		},
+		{
+			name:           "all licenses apply",
+			projectLicense: "Apache-2.0",
+			license:        "MIT AND GPL-2.0-only",
+			want:           "MIT AND GPL-2.0-only is incompatible with the project license Apache-2.0",
+		},
+		{
+			name:           "linking exception",
+			projectLicense: "Apache-2.0",
+			license:        "GPL-2.0-only WITH Classpath-exception-2.0",
+		},
+		{
+			name:    "no project license",
+			license: "GPL-3.0-only",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := licensing.NewCompatibility(tt.projectLicense)
+			assert.Equal(t, tt.want, c.Evaluate(tt.license))
+		})
+	}
+}
+
+func TestJoinLicenses(t *testing.T) {
+	got := licensing.JoinLicenses([]string{
+		"MIT",
+		"Apache-2.0 OR BSD-3-Clause",
+		"GPL-2.0-only WITH Classpath-exception-2.0",
+	})
+	assert.Equal(t, "MIT AND (Apache-2.0 OR BSD-3-Clause) AND GPL-2.0-only WITH Classpath-exception-2.0", got)
+}
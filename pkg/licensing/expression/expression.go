@@ -13,7 +13,8 @@ var (
 
 type NormalizeFunc func(license Expression) Expression
 
-func parse(license string) (Expression, error) {
+// Parse parses the license expression, e.g. "MIT OR (GPL-2.0-only WITH Classpath-exception-2.0)"
+func Parse(license string) (Expression, error) {
 	l := NewLexer(strings.NewReader(license))
 	if yyParse(l) != 0 {
 		return nil, xerrors.Errorf("license parse error: %w", l.Err())
@@ -25,7 +26,7 @@ func parse(license string) (Expression, error) {
 }
 
 func Normalize(license string, funcs ...NormalizeFunc) (string, error) {
-	expr, err := parse(license)
+	expr, err := Parse(license)
 	if err != nil {
 		return "", xerrors.Errorf("license (%s) parse error: %w", license, err)
 	}
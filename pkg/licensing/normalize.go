@@ -744,6 +744,21 @@ func SplitLicenses(str string) []string {
 	return licenses
 }
 
+// JoinLicenses returns the expression of the licenses declared by a package, e.g. "MIT AND (Apache-2.0 OR BSD-3-Clause)".
+// Multiple licenses of a package are regarded as all applying to the package.
+func JoinLicenses(licenses []string) string {
+	exprs := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		if e, err := expr.Parse(license); err == nil {
+			if c, ok := e.(expr.CompoundExpr); ok && c.Conjunction() != expr.TokenWith {
+				license = "(" + license + ")"
+			}
+		}
+		exprs = append(exprs, license)
+	}
+	return strings.Join(exprs, " AND ")
+}
+
 // Split license string considering spaces as separator
 // e.g. MPL 2.0 GPL2+ => {"MPL2.0", "GPL2+"}
 func LaxSplitLicenses(str string) []string {
@@ -0,0 +1,197 @@
+package licensing
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing/expression"
+)
+
+// Obligation represents a condition that must be met when distributing software under a license
+type Obligation string
+
+const (
+	ObligationAttribution          Obligation = "attribution"
+	ObligationIncludeLicense       Obligation = "include-license"
+	ObligationIncludeNotice        Obligation = "include-notice"
+	ObligationStateChanges         Obligation = "state-changes"
+	ObligationSourceDisclosure     Obligation = "source-disclosure"
+	ObligationNetworkDisclosure    Obligation = "network-source-disclosure"
+	ObligationSameLicense          Obligation = "same-license"
+	ObligationPatentGrant          Obligation = "patent-grant"
+	ObligationPatentRetaliation    Obligation = "patent-retaliation"
+	ObligationNonCommercialUseOnly Obligation = "non-commercial-use-only"
+	ObligationNoDerivatives        Obligation = "no-derivatives"
+)
+
+// Description returns a human-readable explanation of the obligation
+func (o Obligation) Description() string {
+	switch o {
+	case ObligationAttribution:
+		return "Retain copyright notices and give credit to the authors"
+	case ObligationIncludeLicense:
+		return "Include a copy of the license text with the distribution"
+	case ObligationIncludeNotice:
+		return "Include the contents of the NOTICE file, if any, with the distribution"
+	case ObligationStateChanges:
+		return "Mark modified files with a notice describing the changes"
+	case ObligationSourceDisclosure:
+		return "Make the source code available when distributing the software"
+	case ObligationNetworkDisclosure:
+		return "Make the source code available to users interacting with the software over a network"
+	case ObligationSameLicense:
+		return "Release modifications or derivative works under the same license"
+	case ObligationPatentGrant:
+		return "Contributors grant a patent license for their contributions"
+	case ObligationPatentRetaliation:
+		return "Patent rights terminate if you initiate patent litigation over the software"
+	case ObligationNonCommercialUseOnly:
+		return "The software may not be used for commercial purposes"
+	case ObligationNoDerivatives:
+		return "Modified versions of the work may not be distributed"
+	}
+	return string(o)
+}
+
+var (
+	permissiveObligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+	}
+	apacheObligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationIncludeNotice,
+		ObligationStateChanges,
+		ObligationPatentGrant,
+		ObligationPatentRetaliation,
+	}
+	gpl2Obligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationStateChanges,
+		ObligationSourceDisclosure,
+		ObligationSameLicense,
+	}
+	gpl3Obligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationStateChanges,
+		ObligationSourceDisclosure,
+		ObligationSameLicense,
+		ObligationPatentGrant,
+		ObligationPatentRetaliation,
+	}
+	agplObligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationStateChanges,
+		ObligationSourceDisclosure,
+		ObligationNetworkDisclosure,
+		ObligationSameLicense,
+		ObligationPatentGrant,
+		ObligationPatentRetaliation,
+	}
+	weakCopyleftObligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationSourceDisclosure,
+		ObligationSameLicense,
+		ObligationPatentGrant,
+		ObligationPatentRetaliation,
+	}
+	weakCopyleftNoPatentObligations = []Obligation{
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationSourceDisclosure,
+		ObligationSameLicense,
+	}
+	nonCommercialObligations = []Obligation{
+		ObligationAttribution,
+		ObligationNonCommercialUseOnly,
+	}
+)
+
+// licenseObligations is a built-in knowledge base of the obligations triggered by well-known licenses.
+// Licenses not listed here fall back to the obligations of their category.
+var licenseObligations = map[string][]Obligation{
+	expression.Apache10: permissiveObligations,
+	expression.Apache11: {
+		ObligationAttribution,
+		ObligationIncludeLicense,
+		ObligationIncludeNotice,
+	},
+	expression.Apache20:        apacheObligations,
+	expression.MIT:             permissiveObligations,
+	expression.ISC:             permissiveObligations,
+	expression.BSD2Clause:      permissiveObligations,
+	expression.BSD3Clause:      permissiveObligations,
+	expression.BSD4Clause:      permissiveObligations,
+	expression.Zlib:            {ObligationStateChanges},
+	expression.BSL10:           {ObligationIncludeLicense},
+	expression.PostgreSQL:      permissiveObligations,
+	expression.Python20:        permissiveObligations,
+	expression.Unlicense:       nil,
+	expression.CC010:           nil,
+	expression.ZeroBSD:         nil,
+	expression.WTFPL:           nil,
+	expression.GPL10:           gpl2Obligations,
+	expression.GPL20:           gpl2Obligations,
+	expression.GPL30:           gpl3Obligations,
+	expression.AGPL10:          agplObligations,
+	expression.AGPL30:          agplObligations,
+	expression.LGPL20:          weakCopyleftNoPatentObligations,
+	expression.LGPL21:          weakCopyleftNoPatentObligations,
+	expression.LGPL30:          weakCopyleftObligations,
+	expression.MPL10:           weakCopyleftNoPatentObligations,
+	expression.MPL11:           weakCopyleftNoPatentObligations,
+	expression.MPL20:           weakCopyleftObligations,
+	expression.EPL10:           weakCopyleftObligations,
+	expression.EPL20:           weakCopyleftObligations,
+	expression.CDDL10:          weakCopyleftObligations,
+	expression.CDDL11:          weakCopyleftObligations,
+	expression.CCBYNC40:        nonCommercialObligations,
+	expression.CCBYNCND40:      {ObligationAttribution, ObligationNonCommercialUseOnly, ObligationNoDerivatives},
+	expression.CCBYNCSA40:      {ObligationAttribution, ObligationNonCommercialUseOnly, ObligationSameLicense},
+	expression.CCBY40:          {ObligationAttribution, ObligationStateChanges},
+	expression.CCBYSA40:        {ObligationAttribution, ObligationStateChanges, ObligationSameLicense},
+	expression.Artistic20:      {ObligationAttribution, ObligationIncludeLicense, ObligationStateChanges},
+	expression.Facebook2Clause: permissiveObligations,
+	expression.Facebook3Clause: permissiveObligations,
+}
+
+// categoryObligations holds the obligations assumed for licenses missing from the knowledge base
+var categoryObligations = map[types.LicenseCategory][]Obligation{
+	types.CategoryForbidden:    gpl3Obligations,
+	types.CategoryRestricted:   gpl2Obligations,
+	types.CategoryReciprocal:   weakCopyleftObligations,
+	types.CategoryNotice:       permissiveObligations,
+	types.CategoryPermissive:   permissiveObligations,
+	types.CategoryUnencumbered: nil,
+}
+
+// Obligations returns the obligations triggered by the given license.
+// The category is used as a fallback when the license is not in the knowledge base.
+func Obligations(licenseName string, category types.LicenseCategory) []Obligation {
+	name := baseLicenseName(licenseName)
+	if obligations, ok := licenseObligations[name]; ok {
+		return obligations
+	}
+	return categoryObligations[category]
+}
+
+// baseLicenseName normalizes the license name and trims the version range suffixes, such as "-only" and "-or-later",
+// since they change neither the obligations nor the license text.
+func baseLicenseName(licenseName string) string {
+	var name string
+	switch normalized := NormalizeLicense(expression.SimpleExpr{License: licenseName}).(type) {
+	case expression.SimpleExpr:
+		name = normalized.License
+	default:
+		name = normalized.String()
+	}
+	for _, suffix := range []string{"-only", "-or-later", "+"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+package licensing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+func TestObligations(t *testing.T) {
+	tests := []struct {
+		name        string
+		licenseName string
+		category    types.LicenseCategory
+		want        []licensing.Obligation
+	}{
+		{
+			name:        "known license",
+			licenseName: "Apache-2.0",
+			category:    types.CategoryNotice,
+			want: []licensing.Obligation{
+				licensing.ObligationAttribution,
+				licensing.ObligationIncludeLicense,
+				licensing.ObligationIncludeNotice,
+				licensing.ObligationStateChanges,
+				licensing.ObligationPatentGrant,
+				licensing.ObligationPatentRetaliation,
+			},
+		},
+		{
+			name:        "license with version range",
+			licenseName: "GPL-2.0-only",
+			category:    types.CategoryRestricted,
+			want: []licensing.Obligation{
+				licensing.ObligationAttribution,
+				licensing.ObligationIncludeLicense,
+				licensing.ObligationStateChanges,
+				licensing.ObligationSourceDisclosure,
+				licensing.ObligationSameLicense,
+			},
+		},
+		{
+			name:        "category fallback",
+			licenseName: "BSD-3-Clause-Clear",
+			category:    types.CategoryNotice,
+			want: []licensing.Obligation{
+				licensing.ObligationAttribution,
+				licensing.ObligationIncludeLicense,
+			},
+		},
+		{
+			name:        "unencumbered",
+			licenseName: "CC0-1.0",
+			category:    types.CategoryUnencumbered,
+			want:        nil,
+		},
+		{
+			name:        "unknown license",
+			licenseName: "Custom-License",
+			category:    types.CategoryUnknown,
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := licensing.Obligations(tt.licenseName, tt.category)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
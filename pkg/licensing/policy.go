@@ -0,0 +1,134 @@
+package licensing
+
+import (
+	"strings"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing/expression"
+)
+
+// verdict is the result of evaluating a license against the policy.
+// Verdicts are ordered from the most permissive to the strictest.
+type verdict int
+
+const (
+	verdictCompliant verdict = iota
+	verdictNotAllowed
+	verdictRestricted
+	verdictForbidden
+)
+
+// Policy evaluates SPDX license expressions against allow and deny lists.
+// The zero value is a disabled policy.
+type Policy struct {
+	allowed    map[string]struct{}
+	restricted map[string]struct{}
+	forbidden  map[string]struct{}
+}
+
+// NewPolicy returns a license policy.
+// Each entry is a license identifier such as "MIT" or a license with an exception such as
+// "GPL-2.0-only WITH Classpath-exception-2.0". When the allowed list is not empty,
+// licenses not in the list violate the policy.
+func NewPolicy(allowed, restricted, forbidden []string) Policy {
+	return Policy{
+		allowed:    policyKeys(allowed),
+		restricted: policyKeys(restricted),
+		forbidden:  policyKeys(forbidden),
+	}
+}
+
+// Enabled returns true if any list of the policy is configured
+func (p Policy) Enabled() bool {
+	return len(p.allowed) > 0 || len(p.restricted) > 0 || len(p.forbidden) > 0
+}
+
+// Evaluate evaluates the license expression and returns the violation with its severity.
+// The violation is empty if the license complies with the policy.
+//
+// The licensee may choose any license of "OR", so the most permissive one is taken,
+// while all licenses of "AND" must comply with the policy.
+func (p Policy) Evaluate(license string) (types.LicenseViolation, string) {
+	expr, err := expression.Parse(license)
+	if err != nil {
+		// e.g. license texts
+		expr = expression.SimpleExpr{License: license}
+	}
+
+	switch p.evaluate(expr) {
+	case verdictForbidden:
+		return types.ViolationForbidden, dbTypes.SeverityCritical.String()
+	case verdictRestricted:
+		return types.ViolationRestricted, dbTypes.SeverityHigh.String()
+	case verdictNotAllowed:
+		return types.ViolationNotAllowed, dbTypes.SeverityMedium.String()
+	}
+	return "", ""
+}
+
+func (p Policy) evaluate(expr expression.Expression) verdict {
+	if e, ok := expr.(expression.CompoundExpr); ok {
+		switch e.Conjunction() {
+		case expression.TokenOR:
+			return min(p.evaluate(e.Left()), p.evaluate(e.Right()))
+		case expression.TokenAnd:
+			return max(p.evaluate(e.Left()), p.evaluate(e.Right()))
+		case expression.TokenWith:
+			// The exception may be listed explicitly, e.g. "GPL-2.0-only WITH Classpath-exception-2.0".
+			// Otherwise, the license is evaluated without the exception.
+			if v, ok := p.lookup(policyKey(e)); ok {
+				return v
+			}
+			return p.evaluate(e.Left())
+		}
+	}
+
+	if v, ok := p.lookup(policyKey(expr)); ok {
+		return v
+	}
+	if len(p.allowed) > 0 {
+		return verdictNotAllowed
+	}
+	return verdictCompliant
+}
+
+// lookup returns the verdict of the listed license. The strictest list wins.
+func (p Policy) lookup(key string) (verdict, bool) {
+	if _, ok := p.forbidden[key]; ok {
+		return verdictForbidden, true
+	} else if _, ok = p.restricted[key]; ok {
+		return verdictRestricted, true
+	} else if _, ok = p.allowed[key]; ok {
+		return verdictCompliant, true
+	}
+	return verdictCompliant, false
+}
+
+func policyKeys(licenses []string) map[string]struct{} {
+	keys := make(map[string]struct{}, len(licenses))
+	for _, license := range licenses {
+		expr, err := expression.Parse(license)
+		if err != nil {
+			keys[strings.ToLower(strings.TrimSpace(license))] = struct{}{}
+			continue
+		}
+		keys[policyKey(expr)] = struct{}{}
+	}
+	return keys
+}
+
+// policyKey normalizes the license so that e.g. "GPL-2.0" and "GPL-2.0-only" match.
+// License identifiers are case-insensitive in SPDX.
+func policyKey(expr expression.Expression) string {
+	switch e := NormalizeLicense(expr).(type) {
+	case expression.SimpleExpr:
+		return strings.ToLower(e.String())
+	case expression.CompoundExpr:
+		if e.Conjunction() == expression.TokenWith {
+			return policyKey(e.Left()) + " with " + strings.ToLower(e.Right().String())
+		}
+		return strings.ToLower(e.String())
+	}
+	return strings.ToLower(expr.String())
+}
@@ -0,0 +1,104 @@
+package licensing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+func TestPolicy_Evaluate(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       []string
+		restricted    []string
+		forbidden     []string
+		license       string
+		wantViolation types.LicenseViolation
+		wantSeverity  string
+	}{
+		{
+			name:          "forbidden",
+			forbidden:     []string{"AGPL-3.0-only"},
+			license:       "AGPL-3.0",
+			wantViolation: types.ViolationForbidden,
+			wantSeverity:  "CRITICAL",
+		},
+		{
+			name:          "restricted, case insensitive",
+			restricted:    []string{"mpl-2.0"},
+			license:       "MPL-2.0",
+			wantViolation: types.ViolationRestricted,
+			wantSeverity:  "HIGH",
+		},
+		{
+			name:          "not in the allow list",
+			allowed:       []string{"MIT", "Apache-2.0"},
+			license:       "BSD-3-Clause",
+			wantViolation: types.ViolationNotAllowed,
+			wantSeverity:  "MEDIUM",
+		},
+		{
+			name:      "not listed without allow list",
+			forbidden: []string{"AGPL-3.0-only"},
+			license:   "BSD-3-Clause",
+		},
+		{
+			name:    "allowed",
+			allowed: []string{"MIT", "Apache-2.0"},
+			license: "Apache License 2.0",
+		},
+		{
+			name:      "OR takes the most permissive license",
+			allowed:   []string{"MIT"},
+			forbidden: []string{"GPL-3.0-only"},
+			license:   "GPL-3.0-only OR MIT",
+		},
+		{
+			name:          "AND takes the strictest license",
+			allowed:       []string{"MIT", "Apache-2.0"},
+			restricted:    []string{"LGPL-2.1-only"},
+			license:       "MIT AND (Apache-2.0 OR LGPL-2.1-only) AND LGPL-2.1-only",
+			wantViolation: types.ViolationRestricted,
+			wantSeverity:  "HIGH",
+		},
+		{
+			name:      "exception listed explicitly",
+			allowed:   []string{"GPL-2.0-only WITH Classpath-exception-2.0"},
+			forbidden: []string{"GPL-2.0-only"},
+			license:   "GPL-2.0-only WITH Classpath-exception-2.0",
+		},
+		{
+			name:          "exception falls back to the license",
+			forbidden:     []string{"GPL-2.0-only"},
+			license:       "GPL-2.0-only WITH LLVM-exception",
+			wantViolation: types.ViolationForbidden,
+			wantSeverity:  "CRITICAL",
+		},
+		{
+			name:          "both lists",
+			allowed:       []string{"MIT"},
+			forbidden:     []string{"MIT"},
+			license:       "MIT",
+			wantViolation: types.ViolationForbidden,
+			wantSeverity:  "CRITICAL",
+		},
+		{
+			name:          "license text",
+			allowed:       []string{"MIT"},
+			license:       "text://(c) 2024 Example, Inc.",
+			wantViolation: types.ViolationNotAllowed,
+			wantSeverity:  "MEDIUM",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := licensing.NewPolicy(tt.allowed, tt.restricted, tt.forbidden)
+			violation, severity := p.Evaluate(tt.license)
+			assert.Equal(t, tt.wantViolation, violation)
+			assert.Equal(t, tt.wantSeverity, severity)
+		})
+	}
+}
@@ -22,6 +22,32 @@ func NewScanner(categories map[types.LicenseCategory][]string) Scanner {
 }
 
 func (s *Scanner) Scan(licenseName string) (types.LicenseCategory, string) {
+	category := s.lookup(licenseName)
+	if category == types.CategoryUnknown {
+		// Evaluate the license expression as a whole, e.g. "MIT OR GPL-2.0-only" declared by dual-licensed packages
+		if expr, err := expression.Parse(licenseName); err == nil {
+			category = s.evaluate(expr)
+		}
+	}
+	return category, categoryToSeverity(category).String()
+}
+
+// evaluate returns the category of the license expression.
+// The licensee may choose any license of "OR", so the most permissive one is taken,
+// while all licenses of "AND" apply, so the strictest one is taken.
+func (s *Scanner) evaluate(expr expression.Expression) types.LicenseCategory {
+	if e, ok := expr.(expression.CompoundExpr); ok {
+		switch e.Conjunction() {
+		case expression.TokenOR:
+			return morePermissive(s.evaluate(e.Left()), s.evaluate(e.Right()))
+		case expression.TokenAnd:
+			return stricter(s.evaluate(e.Left()), s.evaluate(e.Right()))
+		}
+	}
+	return s.lookup(expr.String())
+}
+
+func (s *Scanner) lookup(licenseName string) types.LicenseCategory {
 	normalized := NormalizeLicense(expression.SimpleExpr{License: licenseName})
 	var normalizedName string
 	switch normalized := normalized.(type) {
@@ -33,10 +59,36 @@ func (s *Scanner) Scan(licenseName string) (types.LicenseCategory, string) {
 
 	for category, names := range s.categories {
 		if slices.Contains(names, normalizedName) {
-			return category, categoryToSeverity(category).String()
+			return category
 		}
 	}
-	return types.CategoryUnknown, dbTypes.SeverityUnknown.String()
+	return types.CategoryUnknown
+}
+
+// categoryStrictness orders the categories from the most permissive to the strictest.
+// Unknown licenses are regarded as stricter than reciprocal ones so that they are not hidden by "AND".
+var categoryStrictness = []types.LicenseCategory{
+	types.CategoryUnencumbered,
+	types.CategoryPermissive,
+	types.CategoryNotice,
+	types.CategoryReciprocal,
+	types.CategoryUnknown,
+	types.CategoryRestricted,
+	types.CategoryForbidden,
+}
+
+func morePermissive(a, b types.LicenseCategory) types.LicenseCategory {
+	if slices.Index(categoryStrictness, a) <= slices.Index(categoryStrictness, b) {
+		return a
+	}
+	return b
+}
+
+func stricter(a, b types.LicenseCategory) types.LicenseCategory {
+	if slices.Index(categoryStrictness, a) >= slices.Index(categoryStrictness, b) {
+		return a
+	}
+	return b
 }
 
 func categoryToSeverity(category types.LicenseCategory) dbTypes.Severity {
@@ -57,6 +57,45 @@ func TestScanner_Scan(t *testing.T) {
 			wantCategory: types.CategoryRestricted,
 			wantSeverity: "HIGH",
 		},
+		{
+			name: "dual license",
+			categories: map[types.LicenseCategory][]string{
+				types.CategoryRestricted: {
+					expression.GPL30,
+				},
+				types.CategoryPermissive: {
+					expression.MIT,
+				},
+			},
+			licenseName:  "GPL-3.0 OR MIT",
+			wantCategory: types.CategoryPermissive,
+			wantSeverity: "LOW",
+		},
+		{
+			name: "all licenses apply",
+			categories: map[types.LicenseCategory][]string{
+				types.CategoryRestricted: {
+					expression.GPL30,
+				},
+				types.CategoryPermissive: {
+					expression.MIT,
+				},
+			},
+			licenseName:  "(GPL-3.0 OR MIT) AND GPL-3.0",
+			wantCategory: types.CategoryRestricted,
+			wantSeverity: "HIGH",
+		},
+		{
+			name: "all licenses apply with unknown license",
+			categories: map[types.LicenseCategory][]string{
+				types.CategoryPermissive: {
+					expression.MIT,
+				},
+			},
+			licenseName:  "MIT AND LicenseRef-Custom",
+			wantCategory: types.CategoryUnknown,
+			wantSeverity: "UNKNOWN",
+		},
 		{
 			name:         "unknown",
 			categories:   make(map[types.LicenseCategory][]string),
@@ -0,0 +1,146 @@
+package licensing
+
+import (
+	"hash/fnv"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/licenseclassifier/v2/assets"
+
+	"github.com/aquasecurity/trivy/pkg/licensing/expression"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	// corpusDir is the directory of full license texts in the classifier assets
+	corpusDir = "License"
+
+	// shingleSize is the number of consecutive words hashed together
+	shingleSize = 2
+)
+
+var (
+	// corpusVariants are the file names of the license texts in the classifier assets
+	corpusVariants = []string{
+		"license.txt",
+		"pristine.txt",
+		"a.txt",
+	}
+
+	corpus     *licenseCorpus
+	corpusOnce sync.Once
+)
+
+type corpusEntry struct {
+	name     string
+	shingles map[uint64]struct{}
+}
+
+// licenseCorpus holds the SPDX license texts for the text similarity matching
+type licenseCorpus struct {
+	// exact maps the hash of the normalized text to the license name
+	exact   map[uint64]string
+	entries []corpusEntry
+}
+
+func loadCorpus() *licenseCorpus {
+	// Loading the corpus is expensive and is done only when needed.
+	corpusOnce.Do(func() {
+		log.Debug("Loading the license corpus for the text similarity matching...")
+		corpus = &licenseCorpus{
+			exact: make(map[uint64]string),
+		}
+		// Only licenses that Trivy can classify are loaded
+		names := slices.Concat(expression.ForbiddenLicenses, expression.RestrictedLicenses,
+			expression.ReciprocalLicenses, expression.NoticeLicenses, expression.PermissiveLicenses,
+			expression.UnencumberedLicenses)
+		for _, name := range names {
+			for _, variant := range corpusVariants {
+				// e.g. License/MIT/pristine.txt
+				b, err := assets.ReadLicenseFile(path.Join(corpusDir, name, variant))
+				if err != nil {
+					continue
+				}
+				words := normalizeWords(string(b))
+				corpus.exact[hashWords(words)] = name
+				corpus.entries = append(corpus.entries, corpusEntry{
+					name:     name,
+					shingles: shingles(words),
+				})
+			}
+		}
+	})
+	return corpus
+}
+
+// matchText returns the license whose text is the most similar to the content.
+// The similarity is the Dice coefficient of the word shingles, between 0.0 and 1.0.
+func (c *licenseCorpus) matchText(content []byte) (string, float64) {
+	words := normalizeWords(string(content))
+	if len(words) == 0 {
+		return "", 0
+	}
+	if name, ok := c.exact[hashWords(words)]; ok {
+		return name, 1.0
+	}
+
+	target := shingles(words)
+	var bestName string
+	var bestScore float64
+	for _, e := range c.entries {
+		if score := dice(target, e.shingles); score > bestScore {
+			bestName, bestScore = e.name, score
+		}
+	}
+	return bestName, bestScore
+}
+
+// normalizeWords lowercases the text and splits it into words, dropping punctuation and copyright notices
+// so that the formatting and the copyright holders of vendored license files don't affect the matching.
+func normalizeWords(text string) []string {
+	var words []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if strings.HasPrefix(line, "copyright") || strings.HasPrefix(line, "(c)") {
+			continue
+		}
+		words = append(words, strings.FieldsFunc(line, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})...)
+	}
+	return words
+}
+
+func hashWords(words []string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strings.Join(words, " ")))
+	return h.Sum64()
+}
+
+func shingles(words []string) map[uint64]struct{} {
+	set := make(map[uint64]struct{})
+	for i := 0; i+shingleSize <= len(words); i++ {
+		set[hashWords(words[i:i+shingleSize])] = struct{}{}
+	}
+	return set
+}
+
+// dice returns the Dice coefficient of the two sets
+func dice(a, b map[uint64]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var common int
+	for s := range a {
+		if _, ok := b[s]; ok {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
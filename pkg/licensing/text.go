@@ -0,0 +1,36 @@
+package licensing
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/licenseclassifier/v2/assets"
+)
+
+// copyrightRegexp matches copyright statements, e.g. "Copyright (c) 2024 Foo", "Copyright 2024 Foo" and "© 2024 Foo",
+// but not the sentences of license texts mentioning copyrights, e.g. "copyright notice that is included in the work".
+var copyrightRegexp = regexp.MustCompile(`(?i)^\s*(copyright\b:?\s*(\(c\)|©|\d{4})|(\(c\)|©)\s*\d{4})`)
+
+// LicenseText returns the canonical text of the given license bundled with the license classifier.
+// It returns an empty string if the license text is not available.
+func LicenseText(licenseName string) string {
+	dir := path.Join("License", baseLicenseName(licenseName))
+	for _, file := range []string{"pristine.txt", "license.txt"} {
+		if b, err := assets.ReadLicenseFile(path.Join(dir, file)); err == nil {
+			return string(b)
+		}
+	}
+	return ""
+}
+
+// Copyrights extracts copyright statements from the license text
+func Copyrights(text string) []string {
+	var copyrights []string
+	for _, line := range strings.Split(text, "\n") {
+		if copyrightRegexp.MatchString(line) {
+			copyrights = append(copyrights, strings.TrimSpace(line))
+		}
+	}
+	return copyrights
+}
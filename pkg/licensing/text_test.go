@@ -0,0 +1,61 @@
+package licensing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/trivy/pkg/licensing"
+)
+
+func TestLicenseText(t *testing.T) {
+	tests := []struct {
+		name        string
+		licenseName string
+		wantPrefix  string
+	}{
+		{
+			name:        "pristine text",
+			licenseName: "MIT",
+			wantPrefix:  "Permission is hereby granted, free of charge",
+		},
+		{
+			name:        "license with version range",
+			licenseName: "GPL-3.0-or-later",
+			wantPrefix:  "GNU GENERAL PUBLIC LICENSE",
+		},
+		{
+			name:        "unknown license",
+			licenseName: "Custom-License",
+			wantPrefix:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := licensing.LicenseText(tt.licenseName)
+			if tt.wantPrefix == "" {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Contains(t, got, tt.wantPrefix)
+		})
+	}
+}
+
+func TestCopyrights(t *testing.T) {
+	text := `Copyright (c) 2015 Foo
+  (c) 2016 Bar
+© 2017 Baz
+Copyright: 2018 Qux
+
+Permission is hereby granted, provided that the above copyright notice is retained.
+Copyright [yyyy] [name of copyright owner]
+(c) You must retain all copyright notices.`
+	want := []string{
+		"Copyright (c) 2015 Foo",
+		"(c) 2016 Bar",
+		"© 2017 Baz",
+		"Copyright: 2018 Qux",
+	}
+	assert.Equal(t, want, licensing.Copyrights(text))
+}
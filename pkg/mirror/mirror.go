@@ -0,0 +1,245 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+	"github.com/hashicorp/go-multierror"
+	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/oci"
+	"github.com/aquasecurity/trivy/pkg/remote"
+)
+
+// Repository names in the mirror
+const (
+	DBRepository     = "aquasecurity/trivy-db"
+	JavaDBRepository = "aquasecurity/trivy-java-db"
+	ChecksRepository = "aquasecurity/trivy-checks"
+)
+
+// Source is an artifact to be mirrored
+type Source struct {
+	// Repository is the name of the repository in the mirror, e.g. "aquasecurity/trivy-db"
+	Repository string
+	// Upstreams are the repositories to pull the artifact from, in order of priority.
+	// The artifact is served with the tag of the upstream, e.g. "aquasecurity/trivy-db:2".
+	Upstreams []name.Reference
+}
+
+// Mirror stores artifacts in an OCI image layout and serves them as a read-only registry
+type Mirror struct {
+	// mu guards index.json and the blobs against concurrent syncing and serving
+	mu   sync.RWMutex
+	path layout.Path
+}
+
+// New opens the OCI image layout in dir, creating it if it doesn't exist
+func New(dir string) (*Mirror, error) {
+	p, err := layout.FromPath(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		if p, err = layout.Write(dir, empty.Index); err != nil {
+			return nil, xerrors.Errorf("unable to create an OCI image layout: %w", err)
+		}
+	} else if err != nil {
+		return nil, xerrors.Errorf("unable to open the OCI image layout: %w", err)
+	}
+	return &Mirror{path: p}, nil
+}
+
+// Sync pulls the latest artifacts from the upstream repositories.
+// Artifacts which cannot be pulled are left as they are.
+func (m *Mirror) Sync(ctx context.Context, sources []Source, opt types.RegistryOptions) error {
+	var errs error
+	for _, src := range sources {
+		if err := m.sync(ctx, src, opt); err != nil {
+			errs = multierror.Append(errs, xerrors.Errorf("failed to sync %s: %w", src.Repository, err))
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.removeUnreferencedBlobs(); err != nil {
+		errs = multierror.Append(errs, xerrors.Errorf("failed to remove unreferenced blobs: %w", err))
+	}
+	return errs
+}
+
+func (m *Mirror) sync(ctx context.Context, src Source, opt types.RegistryOptions) error {
+	var errs error
+	for _, upstream := range src.Upstreams {
+		err := m.syncUpstream(ctx, src.Repository, upstream, opt)
+		if err == nil {
+			return nil
+		}
+		log.WarnContext(ctx, "Failed to sync the artifact", log.String("repo", upstream.String()), log.Err(err))
+		errs = multierror.Append(errs, err)
+	}
+	return errs
+}
+
+func (m *Mirror) syncUpstream(ctx context.Context, repository string, upstream name.Reference, opt types.RegistryOptions) error {
+	img, err := remote.Image(ctx, upstream, opt)
+	if err != nil {
+		return xerrors.Errorf("OCI repository error: %w", err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return xerrors.Errorf("digest error: %w", err)
+	}
+
+	refName := referenceName(repository, upstream)
+	oldDigest := m.current(refName)
+	if oldDigest == digest {
+		log.DebugContext(ctx, "The artifact is up to date", log.String("repo", upstream.String()),
+			log.String("digest", digest.String()))
+		return nil
+	}
+
+	// Write the blobs first, without blocking the requests
+	log.InfoContext(ctx, "Syncing the artifact...", log.String("repo", upstream.String()))
+	if err = m.path.WriteImage(img); err != nil {
+		return xerrors.Errorf("unable to write the artifact: %w", err)
+	}
+
+	// The signature is served before the artifact so that clients can always verify it
+	if err = m.syncSignature(ctx, repository, upstream, digest, opt); err != nil {
+		return xerrors.Errorf("signature error: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err = m.path.ReplaceImage(img, match.Name(refName), layout.WithAnnotations(map[string]string{
+		imagespec.AnnotationRefName: refName,
+	}))
+	if err != nil {
+		return xerrors.Errorf("unable to update the index: %w", err)
+	}
+	log.InfoContext(ctx, "Artifact synced", log.String("repo", upstream.String()), log.String("digest", digest.String()))
+
+	// The signature of the replaced artifact is no longer needed
+	if oldDigest != (v1.Hash{}) {
+		if err = m.path.RemoveDescriptors(match.Name(repository + ":" + oci.SignatureTag(oldDigest))); err != nil {
+			return xerrors.Errorf("unable to remove the old signature: %w", err)
+		}
+	}
+	return nil
+}
+
+// syncSignature mirrors the cosign signature of the artifact so that it can be verified with '--verify-db-signature'.
+// Unsigned artifacts are mirrored as they are.
+func (m *Mirror) syncSignature(ctx context.Context, repository string, upstream name.Reference, digest v1.Hash, opt types.RegistryOptions) error {
+	tag := oci.SignatureTag(digest)
+	sigImg, err := remote.Image(ctx, upstream.Context().Tag(tag), opt)
+	if err != nil {
+		log.DebugContext(ctx, "No signature found", log.String("repo", upstream.String()), log.Err(err))
+		return nil
+	}
+	if err = m.path.WriteImage(sigImg); err != nil {
+		return xerrors.Errorf("unable to write the signature: %w", err)
+	}
+
+	refName := repository + ":" + tag
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	err = m.path.ReplaceImage(sigImg, match.Name(refName), layout.WithAnnotations(map[string]string{
+		imagespec.AnnotationRefName: refName,
+	}))
+	if err != nil {
+		return xerrors.Errorf("unable to update the index: %w", err)
+	}
+	return nil
+}
+
+// current returns the digest of the artifact with the reference name in the mirror
+func (m *Mirror) current(refName string) v1.Hash {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	index, err := m.indexManifest()
+	if err != nil {
+		return v1.Hash{}
+	}
+	for _, desc := range index.Manifests {
+		if desc.Annotations[imagespec.AnnotationRefName] == refName {
+			return desc.Digest
+		}
+	}
+	return v1.Hash{}
+}
+
+func (m *Mirror) indexManifest() (*v1.IndexManifest, error) {
+	index, err := m.path.ImageIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.IndexManifest()
+}
+
+// removeUnreferencedBlobs removes the blobs of the artifacts replaced by newer ones
+func (m *Mirror) removeUnreferencedBlobs() error {
+	index, err := m.indexManifest()
+	if err != nil {
+		return err
+	}
+
+	referenced := make(map[v1.Hash]struct{})
+	for _, desc := range index.Manifests {
+		referenced[desc.Digest] = struct{}{}
+		b, err := m.path.Bytes(desc.Digest)
+		if err != nil {
+			return xerrors.Errorf("unable to read the manifest %s: %w", desc.Digest, err)
+		}
+		manifest, err := v1.ParseManifest(bytes.NewReader(b))
+		if err != nil {
+			return xerrors.Errorf("unable to parse the manifest %s: %w", desc.Digest, err)
+		}
+		referenced[manifest.Config.Digest] = struct{}{}
+		for _, layer := range manifest.Layers {
+			referenced[layer.Digest] = struct{}{}
+		}
+	}
+
+	blobsDir := filepath.Join(string(m.path), "blobs")
+	return filepath.WalkDir(blobsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(blobsDir, path)
+		if err != nil {
+			return err
+		}
+		h, err := v1.NewHash(strings.Replace(filepath.ToSlash(rel), "/", ":", 1))
+		if err != nil {
+			// Not a blob, e.g. a temporary file of an interrupted sync
+			return os.Remove(path)
+		}
+		if _, ok := referenced[h]; ok {
+			return nil
+		}
+		return m.path.RemoveBlob(h)
+	})
+}
+
+// referenceName returns the name of the artifact in the mirror, e.g. "aquasecurity/trivy-db:2"
+func referenceName(repository string, upstream name.Reference) string {
+	if d, ok := upstream.(name.Digest); ok {
+		return repository + "@" + d.DigestStr()
+	}
+	return repository + ":" + upstream.Identifier()
+}
@@ -0,0 +1,205 @@
+package mirror_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/mirror"
+	"github.com/aquasecurity/trivy/pkg/oci"
+)
+
+func TestMirror(t *testing.T) {
+	upstream := httptest.NewServer(registry.New())
+	defer upstream.Close()
+	upstreamHost := strings.TrimPrefix(upstream.URL, "http://")
+
+	dbRef, err := name.NewTag(upstreamHost + "/aquasec/trivy-db:2")
+	require.NoError(t, err)
+	dbImg := pushRandomImage(t, dbRef)
+	dbSig := pushRandomImage(t, signatureTag(t, dbRef, dbImg))
+
+	missingRef, err := name.NewTag(upstreamHost + "/aquasec/missing:1")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	m, err := mirror.New(dir)
+	require.NoError(t, err)
+
+	sources := []mirror.Source{
+		{
+			Repository: mirror.DBRepository,
+			// The first repository is missing and the artifact is pulled from the second one
+			Upstreams: []name.Reference{
+				missingRef,
+				dbRef,
+			},
+		},
+	}
+	ctx := context.Background()
+	require.NoError(t, m.Sync(ctx, sources, types.RegistryOptions{}))
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+	mirrorHost := strings.TrimPrefix(server.URL, "http://")
+
+	// Pull the artifact from the mirror
+	got := pullImage(t, mirrorHost+"/aquasecurity/trivy-db:2")
+	assertSameImage(t, dbImg, got)
+
+	// Pull by digest
+	digest, err := dbImg.Digest()
+	require.NoError(t, err)
+	got = pullImage(t, mirrorHost+"/aquasecurity/trivy-db@"+digest.String())
+	assertSameImage(t, dbImg, got)
+
+	// The signature is mirrored as well
+	sigRef := "/aquasecurity/trivy-db:" + oci.SignatureTag(digest)
+	got = pullImage(t, mirrorHost+sigRef)
+	assertSameImage(t, dbSig, got)
+
+	// Update the upstream and sync again
+	newDBImg := pushRandomImage(t, dbRef)
+	require.NoError(t, m.Sync(ctx, sources, types.RegistryOptions{}))
+	got = pullImage(t, mirrorHost+"/aquasecurity/trivy-db:2")
+	assertSameImage(t, newDBImg, got)
+
+	// The signature of the old artifact is removed
+	_, err = ggcrremote.Image(lo.Must(name.ParseReference(mirrorHost + sigRef)))
+	require.ErrorContains(t, err, "MANIFEST_UNKNOWN")
+
+	// The blobs of the old artifact are removed
+	layers, err := dbImg.Layers()
+	require.NoError(t, err)
+	for _, layer := range layers {
+		d, err := layer.Digest()
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "blobs", d.Algorithm, d.Hex))
+	}
+
+	// The mirror can be reopened
+	_, err = mirror.New(dir)
+	require.NoError(t, err)
+}
+
+func TestMirror_Handler(t *testing.T) {
+	m, err := mirror.New(t.TempDir())
+	require.NoError(t, err)
+
+	server := httptest.NewServer(m.Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "version check",
+			method:     http.MethodGet,
+			path:       "/v2/",
+			wantStatus: http.StatusOK,
+			wantBody:   "{}",
+		},
+		{
+			name:       "unknown manifest",
+			method:     http.MethodGet,
+			path:       "/v2/aquasecurity/trivy-db/manifests/2",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "MANIFEST_UNKNOWN",
+		},
+		{
+			name:       "unknown repository",
+			method:     http.MethodGet,
+			path:       "/v2/aquasecurity/trivy-db/blobs/sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "NAME_UNKNOWN",
+		},
+		{
+			name:       "invalid digest",
+			method:     http.MethodGet,
+			path:       "/v2/aquasecurity/trivy-db/blobs/sha256:..",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "DIGEST_INVALID",
+		},
+		{
+			name:       "push",
+			method:     http.MethodPut,
+			path:       "/v2/aquasecurity/trivy-db/manifests/2",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "UNSUPPORTED",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, http.NoBody)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), tt.wantBody)
+		})
+	}
+}
+
+func pushRandomImage(t *testing.T, ref name.Reference) v1.Image {
+	img, err := random.Image(1024, 2)
+	require.NoError(t, err)
+	require.NoError(t, ggcrremote.Write(ref, img))
+	return img
+}
+
+func signatureTag(t *testing.T, ref name.Reference, img v1.Image) name.Reference {
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return ref.Context().Tag(oci.SignatureTag(digest))
+}
+
+func pullImage(t *testing.T, ref string) v1.Image {
+	r, err := name.ParseReference(ref)
+	require.NoError(t, err)
+	img, err := ggcrremote.Image(r)
+	require.NoError(t, err)
+	return img
+}
+
+func assertSameImage(t *testing.T, want, got v1.Image) {
+	wantDigest, err := want.Digest()
+	require.NoError(t, err)
+	gotDigest, err := got.Digest()
+	require.NoError(t, err)
+	assert.Equal(t, wantDigest, gotDigest)
+
+	// Download all the blobs
+	layers, err := got.Layers()
+	require.NoError(t, err)
+	for _, layer := range layers {
+		rc, err := layer.Compressed()
+		require.NoError(t, err)
+		_, err = io.Copy(io.Discard, rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+	}
+	_, err = got.RawConfigFile()
+	require.NoError(t, err)
+}
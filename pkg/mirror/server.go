@@ -0,0 +1,166 @@
+package mirror
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	imagespec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+// Error codes of the OCI distribution spec
+const (
+	errCodeBlobUnknown     = "BLOB_UNKNOWN"
+	errCodeManifestUnknown = "MANIFEST_UNKNOWN"
+	errCodeNameUnknown     = "NAME_UNKNOWN"
+	errCodeDigestInvalid   = "DIGEST_INVALID"
+	errCodeUnsupported     = "UNSUPPORTED"
+)
+
+// Handler returns the handler serving the artifacts with the pull endpoints of the OCI distribution spec
+func (m *Mirror) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeError(w, http.StatusMethodNotAllowed, errCodeUnsupported, "the mirror is read-only")
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/v2/")
+		switch {
+		case r.URL.Path == "/v2/" || r.URL.Path == "/v2":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("{}"))
+		case !strings.HasPrefix(r.URL.Path, "/v2/"):
+			http.NotFound(w, r)
+		case strings.Contains(path, "/manifests/"):
+			i := strings.LastIndex(path, "/manifests/")
+			m.serveManifest(w, r, path[:i], path[i+len("/manifests/"):])
+		case strings.Contains(path, "/blobs/"):
+			i := strings.LastIndex(path, "/blobs/")
+			m.serveBlob(w, r, path[:i], path[i+len("/blobs/"):])
+		default:
+			writeError(w, http.StatusNotFound, errCodeUnsupported, "unsupported endpoint")
+		}
+	})
+}
+
+// serveManifest serves the manifest referenced by the tag or the digest
+func (m *Mirror) serveManifest(w http.ResponseWriter, r *http.Request, repository, reference string) {
+	desc, found := m.findManifest(repository, reference)
+	if !found {
+		writeError(w, http.StatusNotFound, errCodeManifestUnknown, "manifest unknown")
+		return
+	}
+
+	m.mu.RLock()
+	b, err := m.path.Bytes(desc.Digest)
+	m.mu.RUnlock()
+	if err != nil {
+		log.Error("Unable to read the manifest", log.String("digest", desc.Digest.String()), log.Err(err))
+		writeError(w, http.StatusNotFound, errCodeManifestUnknown, "manifest unknown")
+		return
+	}
+
+	w.Header().Set("Content-Type", string(desc.MediaType))
+	w.Header().Set("Docker-Content-Digest", desc.Digest.String())
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(b)
+}
+
+func (m *Mirror) findManifest(repository, reference string) (v1.Descriptor, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	index, err := m.indexManifest()
+	if err != nil {
+		return v1.Descriptor{}, false
+	}
+
+	for _, desc := range index.Manifests {
+		refName := desc.Annotations[imagespec.AnnotationRefName]
+		if refName == repository+":"+reference {
+			return desc, true
+		}
+		// Artifacts can also be pulled by digest
+		if desc.Digest.String() == reference && inRepository(refName, repository) {
+			return desc, true
+		}
+	}
+	return v1.Descriptor{}, false
+}
+
+// serveBlob serves the blob of the artifacts in the repository, supporting range requests
+func (m *Mirror) serveBlob(w http.ResponseWriter, r *http.Request, repository, digest string) {
+	h, err := v1.NewHash(digest)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errCodeDigestInvalid, "invalid digest")
+		return
+	}
+	if !m.hasRepository(repository) {
+		writeError(w, http.StatusNotFound, errCodeNameUnknown, "repository unknown")
+		return
+	}
+
+	// Once opened, the blob can be streamed even if syncing removes it
+	m.mu.RLock()
+	f, err := os.Open(filepath.Join(string(m.path), "blobs", h.Algorithm, h.Hex))
+	m.mu.RUnlock()
+	if err != nil {
+		writeError(w, http.StatusNotFound, errCodeBlobUnknown, "blob unknown")
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Docker-Content-Digest", h.String())
+	http.ServeContent(w, r, "", time.Time{}, f)
+}
+
+func (m *Mirror) hasRepository(repository string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	index, err := m.indexManifest()
+	if err != nil {
+		return false
+	}
+	for _, desc := range index.Manifests {
+		if inRepository(desc.Annotations[imagespec.AnnotationRefName], repository) {
+			return true
+		}
+	}
+	return false
+}
+
+// inRepository reports whether the reference name, e.g. "aquasecurity/trivy-db:2", is in the repository
+func inRepository(refName, repository string) bool {
+	return strings.HasPrefix(refName, repository+":") || strings.HasPrefix(refName, repository+"@")
+}
+
+type registryError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string][]registryError{
+		"errors": {
+			{
+				Code:    code,
+				Message: message,
+			},
+		},
+	})
+}
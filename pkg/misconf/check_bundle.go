@@ -0,0 +1,138 @@
+package misconf
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/mapfs"
+)
+
+// checkBundleKeyID is the ID of the key verifying check bundles, overriding the key IDs of the signatures
+const checkBundleKeyID = "trivy"
+
+// IsCheckBundle returns true if the path is an OPA bundle tarball, e.g. built by `opa build`
+func IsCheckBundle(path string) bool {
+	return strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz")
+}
+
+// LoadCheckBundle reads the OPA bundle tarball at the path.
+// If a public key is given, the bundle must be signed with the matching private key, e.g. by `opa build --signing-key`.
+// Without a key, signatures are not verified.
+func LoadCheckBundle(path, keyPath string) (*bundle.Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	reader := bundle.NewCustomReader(bundle.NewTarballLoader(f)).WithSkipBundleVerification(keyPath == "")
+	if keyPath != "" {
+		verification, err := bundleVerificationConfig(keyPath)
+		if err != nil {
+			return nil, err
+		}
+		reader = reader.WithBundleVerificationConfig(verification)
+	}
+
+	b, err := reader.Read()
+	if err != nil {
+		return nil, xerrors.Errorf("bundle read error: %w", err)
+	}
+
+	if len(b.WasmModules) > 0 {
+		log.Warn("Wasm modules of check bundles are not supported and will be ignored",
+			log.Prefix(log.PrefixMisconfiguration), log.FilePath(path), log.Int("count", len(b.WasmModules)))
+	}
+	log.Info("Loaded check bundle", log.Prefix(log.PrefixMisconfiguration), log.FilePath(path),
+		log.String("revision", b.Manifest.Revision), log.Int("modules", len(b.Modules)))
+	return &b, nil
+}
+
+// bundleVerificationConfig returns the configuration verifying bundles with the public key.
+// The signing algorithm is derived from the key: RS256 for RSA keys, and ES256, ES384 or ES512 for ECDSA keys.
+func bundleVerificationConfig(keyPath string) (*bundle.VerificationConfig, error) {
+	b, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read the public key: %w", err)
+	}
+	pub, err := cryptoutils.UnmarshalPEMToPublicKey(b)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to parse the public key: %w", err)
+	}
+
+	var alg string
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		alg = "RS256"
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			alg = "ES256"
+		case elliptic.P384():
+			alg = "ES384"
+		case elliptic.P521():
+			alg = "ES512"
+		default:
+			return nil, xerrors.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+	default:
+		return nil, xerrors.Errorf("unsupported public key type %T", pub)
+	}
+
+	keys := map[string]*bundle.KeyConfig{
+		checkBundleKeyID: {
+			Key:       string(b),
+			Algorithm: alg,
+		},
+	}
+	return bundle.NewVerificationConfig(keys, checkBundleKeyID, "", nil), nil
+}
+
+// checkBundleReaders returns readers of the Rego modules of the bundles
+func checkBundleReaders(bundles []*bundle.Bundle) []io.Reader {
+	var readers []io.Reader
+	for _, b := range bundles {
+		for _, m := range b.Modules {
+			readers = append(readers, bytes.NewReader(m.Raw))
+		}
+	}
+	return readers
+}
+
+// writeCheckBundleData writes the data documents of the bundles in the data file system,
+// and returns the directories they are written in
+func writeCheckBundleData(fsys *mapfs.FS, bundles []*bundle.Bundle) ([]string, error) {
+	var dirs []string
+	for i, b := range bundles {
+		if len(b.Data) == 0 {
+			continue
+		}
+		data, err := json.Marshal(b.Data)
+		if err != nil {
+			return nil, xerrors.Errorf("bundle data marshal error: %w", err)
+		}
+
+		dir := filepath.Join("bundles", fmt.Sprint(i))
+		if err := fsys.MkdirAll(dir, 0o700); err != nil {
+			return nil, xerrors.Errorf("mapfs mkdir error: %w", err)
+		}
+		if err := fsys.WriteVirtualFile(filepath.Join(dir, "data.json"), data, 0o600); err != nil {
+			return nil, xerrors.Errorf("mapfs write error: %w", err)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
@@ -0,0 +1,176 @@
+package misconf
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/iac/detection"
+	"github.com/aquasecurity/trivy/pkg/mapfs"
+)
+
+const bundleCheck = `# METADATA
+# title: Denied service
+# custom:
+#   id: USER-0001
+#   avd_id: USER-0001
+#   severity: HIGH
+#   input:
+#     selector:
+#     - type: json
+package user.bundle.services
+
+deny[res] {
+	input.service == data.denied.services[_]
+	res := result.new(sprintf("Service %q is denied", [input.service]), {})
+}
+`
+
+type bundleKey struct {
+	private string
+	public  string
+}
+
+func generateBundleKey(t *testing.T) bundleKey {
+	t.Helper()
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privPEM, err := cryptoutils.MarshalPrivateKeyToPEM(privKey)
+	require.NoError(t, err)
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(privKey.Public())
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	key := bundleKey{
+		private: filepath.Join(dir, "bundle.key"),
+		public:  filepath.Join(dir, "bundle.pub"),
+	}
+	require.NoError(t, os.WriteFile(key.private, privPEM, 0o600))
+	require.NoError(t, os.WriteFile(key.public, pubPEM, 0o600))
+	return key
+}
+
+// writeCheckBundle writes a bundle tarball as built by `opa build`, signed with the private key if set
+func writeCheckBundle(t *testing.T, signingKey string) string {
+	t.Helper()
+	b := bundle.Bundle{
+		Manifest: bundle.Manifest{
+			Revision: "v1.2.0",
+		},
+		Data: map[string]any{
+			"denied": map[string]any{
+				"services": []any{"telnet"},
+			},
+		},
+		Modules: []bundle.ModuleFile{
+			{
+				URL:  "/checks/services.rego",
+				Path: "/checks/services.rego",
+				Raw:  []byte(bundleCheck),
+			},
+		},
+	}
+	b.Manifest.Init()
+
+	if signingKey != "" {
+		require.NoError(t, b.GenerateSignature(bundle.NewSigningConfig(signingKey, "RS256", ""), "", false))
+	}
+
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, bundle.NewWriter(f).DisableFormat(true).Write(b))
+	return path
+}
+
+func TestLoadCheckBundle(t *testing.T) {
+	key := generateBundleKey(t)
+	otherKey := generateBundleKey(t)
+
+	tests := []struct {
+		name    string
+		signed  bool
+		keyPath string
+		wantErr string
+	}{
+		{
+			name: "unsigned bundle",
+		},
+		{
+			name:    "signed bundle",
+			signed:  true,
+			keyPath: key.public,
+		},
+		{
+			name:   "signed bundle without key",
+			signed: true,
+		},
+		{
+			name:    "unsigned bundle with key",
+			keyPath: key.public,
+			wantErr: "bundle missing .signatures.json file",
+		},
+		{
+			name:    "signed with another key",
+			signed:  true,
+			keyPath: otherKey.public,
+			wantErr: "crypto/rsa: verification error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var signingKey string
+			if tt.signed {
+				signingKey = key.private
+			}
+			path := writeCheckBundle(t, signingKey)
+
+			got, err := LoadCheckBundle(path, tt.keyPath)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "v1.2.0", got.Manifest.Revision)
+			require.Len(t, got.Modules, 1)
+			assert.Equal(t, bundleCheck, string(got.Modules[0].Raw))
+			assert.Equal(t, map[string]any{
+				"denied": map[string]any{
+					"services": []any{"telnet"},
+				},
+			}, got.Data)
+		})
+	}
+}
+
+func TestScanner_Scan_CheckBundle(t *testing.T) {
+	key := generateBundleKey(t)
+	bundlePath := writeCheckBundle(t, key.private)
+
+	fsys := mapfs.New()
+	require.NoError(t, fsys.WriteVirtualFile("service.json", []byte(`{"service": "telnet"}`), 0o600))
+
+	s, err := NewScanner(detection.FileTypeJSON, ScannerOption{
+		PolicyPaths:             []string{bundlePath},
+		CheckBundleKey:          key.public,
+		Namespaces:              []string{"user"},
+		DisableEmbeddedPolicies: true,
+	})
+	require.NoError(t, err)
+
+	misconfs, err := s.Scan(context.Background(), fsys)
+	require.NoError(t, err)
+	require.Len(t, misconfs, 1)
+	require.Len(t, misconfs[0].Failures, 1)
+	assert.Equal(t, "USER-0001", misconfs[0].Failures[0].ID)
+	assert.Equal(t, `Service "telnet" is denied`, misconfs[0].Failures[0].Message)
+}
@@ -0,0 +1,171 @@
+package misconf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/downloader"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/oci"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+const (
+	remoteDataCacheDir       = "misconfig-data"
+	remoteDataDir            = "data"
+	remoteDataMetadataFile   = "metadata.json"
+	remoteDataUpdateInterval = time.Hour
+)
+
+type remoteDataMetadata struct {
+	Source    string
+	ETag      string `json:",omitempty"`
+	UpdatedAt time.Time
+}
+
+// IsRemoteData returns true if the data path is an HTTPS URL or an OCI reference prefixed with "oci://"
+func IsRemoteData(path string) bool {
+	return strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "oci://")
+}
+
+// ResolveDataPaths downloads the remote data documents into the cache dir,
+// and returns the data paths with the remote ones replaced by the directories they are downloaded into.
+// Downloaded documents are used for an hour without checking for updates,
+// and they are also used when the download fails or in offline mode so that scans keep working.
+func ResolveDataPaths(ctx context.Context, dataPaths []string, cacheDir string, offline bool,
+	registryOpts ftypes.RegistryOptions) ([]string, error) {
+	resolved := make([]string, 0, len(dataPaths))
+	for _, p := range dataPaths {
+		if !IsRemoteData(p) {
+			resolved = append(resolved, p)
+			continue
+		}
+		dir, err := fetchRemoteData(ctx, p, cacheDir, offline, registryOpts)
+		if err != nil {
+			return nil, xerrors.Errorf("remote data %q error: %w", p, err)
+		}
+		resolved = append(resolved, dir)
+	}
+	return resolved, nil
+}
+
+func fetchRemoteData(ctx context.Context, src, cacheDir string, offline bool, registryOpts ftypes.RegistryOptions) (string, error) {
+	hash := sha256.Sum256([]byte(src))
+	dir := filepath.Join(cacheDir, remoteDataCacheDir, hex.EncodeToString(hash[:]))
+	dataDir := filepath.Join(dir, remoteDataDir)
+	logger := log.WithPrefix(log.PrefixMisconfiguration).With(log.String("source", src))
+
+	var m remoteDataMetadata
+	if fsutils.DirExists(dataDir) {
+		var err error
+		if m, err = readRemoteDataMetadata(dir); err != nil {
+			logger.Debug("Failed to read the remote data metadata", log.Err(err))
+		} else if offline {
+			logger.Debug("Using the cached remote data in offline mode", log.Time("updated_at", m.UpdatedAt))
+			return dataDir, nil
+		} else if clock.Now(ctx).Before(m.UpdatedAt.Add(remoteDataUpdateInterval)) {
+			logger.Debug("Using the cached remote data", log.Time("updated_at", m.UpdatedAt))
+			return dataDir, nil
+		}
+	} else if offline {
+		return "", xerrors.New("the data is not cached and cannot be downloaded in offline mode")
+	}
+
+	logger.Debug("Downloading the remote data...", log.String("etag", m.ETag))
+	etag, err := downloadRemoteData(ctx, src, dir, m.ETag, registryOpts)
+	switch {
+	case errors.Is(err, downloader.ErrSkipDownload):
+		logger.Debug("No updates in the remote data")
+		etag = m.ETag // Keep the old ETag
+	case err != nil:
+		if !fsutils.DirExists(dataDir) {
+			return "", xerrors.Errorf("failed to download the data: %w", err)
+		}
+		logger.Warn("Failed to download the remote data, using the cached one", log.Err(err))
+		return dataDir, nil
+	}
+
+	if err = writeRemoteDataMetadata(dir, remoteDataMetadata{
+		Source:    src,
+		ETag:      etag,
+		UpdatedAt: clock.Now(ctx),
+	}); err != nil {
+		return "", xerrors.Errorf("failed to write the remote data metadata: %w", err)
+	}
+	return dataDir, nil
+}
+
+// downloadRemoteData downloads the data into a temporary directory first
+// so that the cached data remains when the download fails.
+// Archives, such as tar.gz files, are extracted.
+func downloadRemoteData(ctx context.Context, src, dir, etag string, registryOpts ftypes.RegistryOptions) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", xerrors.Errorf("failed to mkdir: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(dir, "download-")
+	if err != nil {
+		return "", xerrors.Errorf("failed to create a temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tmpDataDir := filepath.Join(tmpDir, remoteDataDir)
+
+	var newETag string
+	switch {
+	case strings.HasPrefix(src, "oci://"):
+		art := oci.NewArtifact(strings.TrimPrefix(src, "oci://"), registryOpts)
+		if err = art.Download(ctx, tmpDataDir, oci.DownloadOption{Quiet: true}); err != nil {
+			return "", xerrors.Errorf("OCI download error: %w", err)
+		}
+	default:
+		newETag, err = downloader.Download(ctx, src, tmpDataDir, tmpDir, downloader.Options{
+			Insecure: registryOpts.Insecure,
+			ETag:     etag,
+		})
+		if err != nil {
+			return "", xerrors.Errorf("download error: %w", err)
+		}
+	}
+
+	dataDir := filepath.Join(dir, remoteDataDir)
+	if err = os.RemoveAll(dataDir); err != nil {
+		return "", xerrors.Errorf("failed to remove the old data: %w", err)
+	}
+	if err = os.Rename(tmpDataDir, dataDir); err != nil {
+		return "", xerrors.Errorf("failed to rename the data dir: %w", err)
+	}
+	return newETag, nil
+}
+
+func readRemoteDataMetadata(dir string) (remoteDataMetadata, error) {
+	b, err := os.ReadFile(filepath.Join(dir, remoteDataMetadataFile))
+	if err != nil {
+		return remoteDataMetadata{}, xerrors.Errorf("unable to read the metadata: %w", err)
+	}
+	var m remoteDataMetadata
+	if err = json.Unmarshal(b, &m); err != nil {
+		return remoteDataMetadata{}, xerrors.Errorf("unable to decode the metadata: %w", err)
+	}
+	return m, nil
+}
+
+func writeRemoteDataMetadata(dir string, m remoteDataMetadata) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return xerrors.Errorf("unable to encode the metadata: %w", err)
+	}
+	if err = os.WriteFile(filepath.Join(dir, remoteDataMetadataFile), b, 0600); err != nil {
+		return xerrors.Errorf("unable to write the metadata: %w", err)
+	}
+	return nil
+}
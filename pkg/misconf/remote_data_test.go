@@ -0,0 +1,91 @@
+package misconf
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/clock"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+func TestResolveDataPaths(t *testing.T) {
+	const (
+		etag = `"v1"`
+		data = `{"allowed": {"cidrs": ["10.0.0.0/8"]}}`
+	)
+
+	var requests int
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			return
+		}
+		requests++
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(data))
+	}))
+	defer ts.Close()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cacheDir := t.TempDir()
+	registryOpts := ftypes.RegistryOptions{Insecure: true}
+	src := ts.URL + "/cidrs.json"
+	resolve := func(t *testing.T, ctx context.Context, src string, offline bool) string {
+		t.Helper()
+		got, err := ResolveDataPaths(ctx, []string{"local/data", src}, cacheDir, offline, registryOpts)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, "local/data", got[0])
+		return got[1]
+	}
+
+	t.Run("download", func(t *testing.T) {
+		dir := resolve(t, clock.With(context.Background(), now), src, false)
+		b, err := os.ReadFile(filepath.Join(dir, "cidrs.json"))
+		require.NoError(t, err)
+		assert.JSONEq(t, data, string(b))
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		resolve(t, clock.With(context.Background(), now.Add(time.Minute)), src, false)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("not modified", func(t *testing.T) {
+		dir := resolve(t, clock.With(context.Background(), now.Add(2*time.Hour)), src, false)
+		assert.FileExists(t, filepath.Join(dir, "cidrs.json"))
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		dir := resolve(t, clock.With(context.Background(), now.Add(24*time.Hour)), src, true)
+		assert.FileExists(t, filepath.Join(dir, "cidrs.json"))
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("fallback to the cache", func(t *testing.T) {
+		ts.Close()
+		dir := resolve(t, clock.With(context.Background(), now.Add(24*time.Hour)), src, false)
+		assert.FileExists(t, filepath.Join(dir, "cidrs.json"))
+	})
+
+	t.Run("not cached", func(t *testing.T) {
+		_, err := ResolveDataPaths(context.Background(), []string{ts.URL + "/other.json"}, cacheDir, false, registryOpts)
+		require.ErrorContains(t, err, "failed to download the data")
+
+		_, err = ResolveDataPaths(context.Background(), []string{"oci://localhost/data:1"}, cacheDir, true, registryOpts)
+		require.ErrorContains(t, err, "offline mode")
+	})
+}
@@ -0,0 +1,71 @@
+package misconf
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/signing"
+	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
+)
+
+// RepoChecksDir is the directory in scanned repositories containing custom checks and data
+var RepoChecksDir = filepath.Join(".trivy", "checks")
+
+// extensions of checks and data files loaded from RepoChecksDir
+var repoCheckExts = []string{".rego", ".json", ".yaml", ".yml"}
+
+// LoadRepoChecks returns the directory of custom checks and data shipped in the scanned directory.
+// Every check and data file must be signed with the key, e.g. by `cosign sign-blob --key cosign.key`,
+// and the signature is stored next to the file as "<file>.sig".
+// An empty path is returned if the directory doesn't contain checks.
+func LoadRepoChecks(ctx context.Context, target, keyPath string) (string, error) {
+	dir := filepath.Join(target, RepoChecksDir)
+	if !fsutils.DirExists(dir) {
+		log.DebugContext(ctx, "No repository checks found", log.FilePath(dir))
+		return "", nil
+	}
+
+	var found bool
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() || !slices.Contains(repoCheckExts, filepath.Ext(path)) {
+			return nil
+		}
+
+		if err = verifyRepoCheck(path, keyPath); err != nil {
+			return xerrors.Errorf("%s: %w", path, err)
+		}
+		found = true
+		return nil
+	})
+	if err != nil {
+		return "", xerrors.Errorf("repository checks verification error: %w", err)
+	} else if !found {
+		return "", nil
+	}
+
+	log.InfoContext(ctx, "Loading repository checks", log.FilePath(dir))
+	return dir, nil
+}
+
+func verifyRepoCheck(path, keyPath string) error {
+	sig, err := os.ReadFile(path + ".sig")
+	if err != nil {
+		return xerrors.Errorf("unable to read the signature: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	return signing.Verify(keyPath, f, sig)
+}
@@ -0,0 +1,113 @@
+package misconf
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/signing"
+)
+
+func TestLoadRepoChecks(t *testing.T) {
+	keyDir := t.TempDir()
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privPEM, err := cryptoutils.MarshalPrivateKeyToPEM(privKey)
+	require.NoError(t, err)
+	pubPEM, err := cryptoutils.MarshalPublicKeyToPEM(privKey.Public())
+	require.NoError(t, err)
+
+	signingKey := filepath.Join(keyDir, "ec.key")
+	verifyKey := filepath.Join(keyDir, "ec.pub")
+	require.NoError(t, os.WriteFile(signingKey, privPEM, 0o600))
+	require.NoError(t, os.WriteFile(verifyKey, pubPEM, 0o600))
+
+	const check = "package user.test\n"
+
+	tests := []struct {
+		name    string
+		files   map[string]string // file path => content
+		signed  []string          // files signed with the key
+		tamper  bool              // modify check.rego after signing
+		want    bool
+		wantErr string
+	}{
+		{
+			name: "signed checks and data",
+			files: map[string]string{
+				"check.rego":        check,
+				"data/exclude.yaml": "exclude: []",
+				"README.md":         "not loaded",
+			},
+			signed: []string{"check.rego", "data/exclude.yaml"},
+			want:   true,
+		},
+		{
+			name: "missing signature",
+			files: map[string]string{
+				"check.rego": check,
+			},
+			wantErr: "unable to read the signature",
+		},
+		{
+			name: "tampered check",
+			files: map[string]string{
+				"check.rego": check,
+			},
+			signed:  []string{"check.rego"},
+			tamper:  true,
+			wantErr: "invalid signature",
+		},
+		{
+			name: "no checks",
+			files: map[string]string{
+				"README.md": "not loaded",
+			},
+		},
+		{
+			name: "no directory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := t.TempDir()
+			dir := filepath.Join(target, RepoChecksDir)
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			}
+			for _, name := range tt.signed {
+				path := filepath.Join(dir, name)
+				sig, err := signing.Sign(signingKey, strings.NewReader(tt.files[name]))
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(path+".sig", sig, 0o600))
+			}
+			if tt.tamper {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "check.rego"), []byte(check+"deny := true\n"), 0o600))
+			}
+
+			got, err := LoadRepoChecks(context.Background(), target, verifyKey)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.want {
+				assert.Equal(t, dir, got)
+			} else {
+				assert.Empty(t, got)
+			}
+		})
+	}
+}
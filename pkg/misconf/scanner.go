@@ -1,6 +1,7 @@
 package misconf
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,9 +9,12 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/open-policy-agent/opa/bundle"
 	"github.com/samber/lo"
 	"github.com/xeipuuv/gojsonschema"
 	"golang.org/x/xerrors"
@@ -20,14 +24,22 @@ import (
 	"github.com/aquasecurity/trivy/pkg/iac/rego"
 	"github.com/aquasecurity/trivy/pkg/iac/scan"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/ansible"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure/arm"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azure/bicep"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/azurepipelines"
 	cfscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/cloudformation"
 	cfparser "github.com/aquasecurity/trivy/pkg/iac/scanners/cloudformation/parser"
+	cuescanner "github.com/aquasecurity/trivy/pkg/iac/scanners/cue"
 	dfscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/dockerfile"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/generic"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/githubactions"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/gitlabci"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/helm"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/jsonnet"
 	k8sscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/kubernetes"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/options"
+	"github.com/aquasecurity/trivy/pkg/iac/scanners/pulumi"
 	"github.com/aquasecurity/trivy/pkg/iac/scanners/terraform"
 	tfprawscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/snapshot"
 	tfpjsonscanner "github.com/aquasecurity/trivy/pkg/iac/scanners/terraformplan/tfjson"
@@ -39,13 +51,21 @@ import (
 
 var enablediacTypes = map[detection.FileType]types.ConfigType{
 	detection.FileTypeAzureARM:              types.AzureARM,
+	detection.FileTypeAzurePipelines:        types.AzurePipelines,
+	detection.FileTypeBicep:                 types.Bicep,
 	detection.FileTypeCloudFormation:        types.CloudFormation,
 	detection.FileTypeTerraform:             types.Terraform,
 	detection.FileTypeDockerfile:            types.Dockerfile,
 	detection.FileTypeKubernetes:            types.Kubernetes,
 	detection.FileTypeHelm:                  types.Helm,
+	detection.FileTypeJsonnet:               types.Jsonnet,
+	detection.FileTypePulumi:                types.Pulumi,
+	detection.FileTypeAnsible:               types.Ansible,
+	detection.FileTypeGitHubActions:         types.GitHubActions,
+	detection.FileTypeGitLabCI:              types.GitLabCI,
 	detection.FileTypeTerraformPlanJSON:     types.TerraformPlanJSON,
 	detection.FileTypeTerraformPlanSnapshot: types.TerraformPlanSnapshot,
+	detection.FileTypeCUE:                   types.CUE,
 	detection.FileTypeJSON:                  types.JSON,
 	detection.FileTypeYAML:                  types.YAML,
 }
@@ -64,24 +84,50 @@ type ScannerOption struct {
 	DisableEmbeddedPolicies  bool
 	DisableEmbeddedLibraries bool
 	IncludeDeprecatedChecks  bool
-
-	HelmValues              []string
-	HelmValueFiles          []string
-	HelmFileValues          []string
-	HelmStringValues        []string
-	HelmAPIVersions         []string
-	HelmKubeVersion         string
-	TerraformTFVars         []string
-	CloudFormationParamVars []string
-	TfExcludeDownloaded     bool
-	K8sVersion              string
+	// CheckBundleKey is the public key verifying the signatures of the OPA bundles in PolicyPaths
+	CheckBundleKey string
+
+	HelmValues                []string
+	HelmValueFiles            []string
+	HelmFileValues            []string
+	HelmStringValues          []string
+	HelmAPIVersions           []string
+	HelmKubeVersion           string
+	HelmDependencyUpdate      bool
+	TerraformTFVars           []string
+	CloudFormationParamVars   []string
+	CloudFormationS3Templates bool
+	TfExcludeDownloaded       bool
+	TfPlanChangesOnly         bool
+	TfModuleCacheDir          string
+	TfModuleCacheTTL          time.Duration
+	K8sVersion                string
+	K8sSchemaDir              string
+	K8sSchemaVersion          string
+	K8sUpgradeVersion         string
+	K8sCRDSchemas             []string
+	K8sResolveGitOps          bool
+	JsonnetImportPaths        []string
+	JsonnetExtVars            []string
+	JsonnetExtCode            []string
+	MaxFileSize               int64
+	ParseTimeout              time.Duration
 
 	FilePatterns      []string
 	ConfigFileSchemas []*ConfigFileSchema
 
+	// Offline disables downloading remote modules, cached modules are still used
+	Offline bool
+
 	DisabledChecks []DisabledCheck
 	SkipFiles      []string
 	SkipDirs       []string
+
+	// BuiltinChecks holds Rego checks bundled with an analyzer, such as the image label checks
+	BuiltinChecks fs.FS
+
+	// CheckProfiler records the evaluation time of checks if set
+	CheckProfiler *rego.Profiler
 }
 
 func (o *ScannerOption) Sort() {
@@ -95,6 +141,7 @@ type Scanner struct {
 	scanner           scanners.FSScanner
 	hasFilePattern    bool
 	configFileSchemas []*ConfigFileSchema
+	k8sResolveGitOps  bool
 }
 
 func NewScanner(t detection.FileType, opt ScannerOption) (*Scanner, error) {
@@ -105,16 +152,32 @@ func NewScanner(t detection.FileType, opt ScannerOption) (*Scanner, error) {
 
 	var scanner scanners.FSScanner
 	switch t {
+	case detection.FileTypeAnsible:
+		scanner = ansible.New(opts...)
 	case detection.FileTypeAzureARM:
 		scanner = arm.New(opts...)
+	case detection.FileTypeAzurePipelines:
+		scanner = azurepipelines.NewScanner(opts...)
+	case detection.FileTypeBicep:
+		scanner = bicep.New(opts...)
 	case detection.FileTypeCloudFormation:
 		scanner = cfscanner.New(opts...)
+	case detection.FileTypeCUE:
+		scanner = cuescanner.NewScanner(opts...)
 	case detection.FileTypeDockerfile:
 		scanner = dfscanner.NewScanner(opts...)
+	case detection.FileTypeGitHubActions:
+		scanner = githubactions.NewScanner(opts...)
+	case detection.FileTypeGitLabCI:
+		scanner = gitlabci.NewScanner(opts...)
 	case detection.FileTypeHelm:
 		scanner = helm.New(opts...)
+	case detection.FileTypeJsonnet:
+		scanner = jsonnet.New(opts...)
 	case detection.FileTypeKubernetes:
 		scanner = k8sscanner.NewScanner(opts...)
+	case detection.FileTypePulumi:
+		scanner = pulumi.New(opts...)
 	case detection.FileTypeTerraform:
 		scanner = terraform.New(opts...)
 	case detection.FileTypeTerraformPlanJSON:
@@ -134,6 +197,7 @@ func NewScanner(t detection.FileType, opt ScannerOption) (*Scanner, error) {
 		scanner:           scanner,
 		hasFilePattern:    hasFilePattern(t, opt.FilePatterns),
 		configFileSchemas: opt.ConfigFileSchemas,
+		k8sResolveGitOps:  opt.K8sResolveGitOps,
 	}, nil
 }
 
@@ -178,6 +242,18 @@ func (s *Scanner) filterFS(fsys fs.FS) (fs.FS, error) {
 		return fsys, nil
 	}
 
+	// Kustomizations may consist of files other than manifests, such as JSON patches and generator sources,
+	// and so may the charts referred to by GitOps objects,
+	// so that the Kubernetes scanner rendering them filters files by itself.
+	if s.fileType == detection.FileTypeKubernetes && (hasKustomization(mfs) || s.k8sResolveGitOps) {
+		return fsys, nil
+	}
+
+	// Jsonnet files may import JSON files, and the scanner only evaluates .jsonnet files
+	if s.fileType == detection.FileTypeJsonnet {
+		return fsys, nil
+	}
+
 	schemas := lo.SliceToMap(s.configFileSchemas, func(schema *ConfigFileSchema) (string, *gojsonschema.Schema) {
 		return schema.path, schema.schema
 	})
@@ -190,6 +266,11 @@ func (s *Scanner) filterFS(fsys fs.FS) (fs.FS, error) {
 		}
 		defer file.Close()
 
+		// The configurations of module projects map the module types to their templates
+		if s.fileType == detection.FileTypeCloudFormation && cfparser.IsModuleConfig(path) {
+			return false, nil
+		}
+
 		rs, ok := file.(io.ReadSeeker)
 		if !ok {
 			return false, xerrors.Errorf("type assertion error: %w", err)
@@ -216,6 +297,21 @@ func (s *Scanner) filterFS(fsys fs.FS) (fs.FS, error) {
 	return newfs, nil
 }
 
+func hasKustomization(fsys fs.FS) bool {
+	var found bool
+	_ = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && k8sscanner.IsKustomization(path) {
+			found = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return found
+}
+
 func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerOption, error) {
 	disabledCheckIDs := lo.Map(opt.DisabledChecks, func(check DisabledCheck, _ int) string {
 		log.Info("Check disabled", log.Prefix(log.PrefixMisconfiguration), log.String("ID", check.ID),
@@ -230,7 +326,10 @@ func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerO
 		rego.WithDisabledCheckIDs(disabledCheckIDs...),
 	}
 
-	policyFS, policyPaths, err := CreatePolicyFS(opt.PolicyPaths)
+	bundlePaths, checkPaths := lo.FilterReject(opt.PolicyPaths, func(p string, _ int) bool {
+		return IsCheckBundle(p)
+	})
+	policyFS, policyPaths, err := CreatePolicyFS(checkPaths)
 	if err != nil {
 		return nil, err
 	}
@@ -238,7 +337,32 @@ func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerO
 		opts = append(opts, rego.WithPolicyFilesystem(policyFS))
 	}
 
-	dataFS, dataPaths, err := CreateDataFS(opt.DataPaths, opt.K8sVersion)
+	var checkBundles []*bundle.Bundle
+	for _, p := range bundlePaths {
+		b, err := LoadCheckBundle(p, opt.CheckBundleKey)
+		if err != nil {
+			return nil, xerrors.Errorf("check bundle %q error: %w", p, err)
+		}
+		checkBundles = append(checkBundles, b)
+	}
+	readers := checkBundleReaders(checkBundles)
+
+	if opt.BuiltinChecks != nil {
+		builtinReaders, err := checkReaders(opt.BuiltinChecks)
+		if err != nil {
+			return nil, xerrors.Errorf("builtin checks error: %w", err)
+		}
+		readers = append(readers, builtinReaders...)
+	}
+	if len(readers) > 0 {
+		opts = append(opts, rego.WithPolicyReader(readers...))
+	}
+
+	dataFS, err := createDataFS(opt.DataPaths, opt.K8sVersion)
+	if err != nil {
+		return nil, err
+	}
+	bundleDataDirs, err := writeCheckBundleData(dataFS, checkBundles)
 	if err != nil {
 		return nil, err
 	}
@@ -248,7 +372,7 @@ func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerO
 	})
 
 	opts = append(opts,
-		rego.WithDataDirs(dataPaths...),
+		rego.WithDataDirs("."),
 		rego.WithDataFilesystem(dataFS),
 		rego.WithCustomSchemas(schemas),
 	)
@@ -257,25 +381,43 @@ func scannerOptions(t detection.FileType, opt ScannerOption) ([]options.ScannerO
 		opts = append(opts, rego.WithPerResultTracing(true))
 	}
 
+	if opt.CheckProfiler != nil {
+		opts = append(opts, rego.WithProfiler(opt.CheckProfiler))
+	}
+
 	if len(policyPaths) > 0 {
 		opts = append(opts, rego.WithPolicyDirs(policyPaths...))
 	}
 
 	if len(opt.DataPaths) > 0 {
-		opts = append(opts, rego.WithDataDirs(opt.DataPaths...))
+		opts = append(opts, rego.WithDataDirs(slices.Concat(opt.DataPaths, bundleDataDirs)...))
 	}
 
 	if len(opt.Namespaces) > 0 {
 		opts = append(opts, rego.WithPolicyNamespaces(opt.Namespaces...))
 	}
 
+	if opt.MaxFileSize > 0 {
+		opts = append(opts, options.ScannerWithMaxFileSize(opt.MaxFileSize))
+	}
+
+	if opt.ParseTimeout > 0 {
+		opts = append(opts, options.ScannerWithParseTimeout(opt.ParseTimeout))
+	}
+
 	switch t {
 	case detection.FileTypeHelm:
 		return addHelmOpts(opts, opt), nil
 	case detection.FileTypeTerraform, detection.FileTypeTerraformPlanSnapshot:
 		return addTFOpts(opts, opt)
+	case detection.FileTypeTerraformPlanJSON:
+		return append(opts, tfpjsonscanner.ScannerWithChangesOnly(opt.TfPlanChangesOnly)), nil
 	case detection.FileTypeCloudFormation:
 		return addCFOpts(opts, opt)
+	case detection.FileTypeKubernetes:
+		return addK8sOpts(opts, opt), nil
+	case detection.FileTypeJsonnet:
+		return addJsonnetOpts(opts, opt), nil
 	default:
 		return opts, nil
 	}
@@ -306,6 +448,9 @@ func addTFOpts(opts []options.ScannerOption, scannerOption ScannerOption) ([]opt
 	opts = append(opts,
 		terraform.ScannerWithAllDirectories(true),
 		terraform.ScannerWithSkipDownloaded(scannerOption.TfExcludeDownloaded),
+		terraform.ScannerWithDownloadsAllowed(!scannerOption.Offline),
+		terraform.ScannerWithModuleCacheDir(scannerOption.TfModuleCacheDir),
+		terraform.ScannerWithModuleCacheTTL(scannerOption.TfModuleCacheTTL),
 		terraform.ScannerWithSkipFiles(scannerOption.SkipFiles),
 		terraform.ScannerWithSkipDirs(scannerOption.SkipDirs),
 	)
@@ -325,7 +470,7 @@ func addCFOpts(opts []options.ScannerOption, scannerOption ScannerOption) ([]opt
 			cfscanner.WithConfigsFS(configFS),
 		)
 	}
-	return opts, nil
+	return append(opts, cfscanner.WithS3Templates(scannerOption.CloudFormationS3Templates && !scannerOption.Offline)), nil
 }
 
 func addHelmOpts(opts []options.ScannerOption, scannerOption ScannerOption) []options.ScannerOption {
@@ -353,6 +498,49 @@ func addHelmOpts(opts []options.ScannerOption, scannerOption ScannerOption) []op
 		opts = append(opts, helm.ScannerWithKubeVersion(scannerOption.HelmKubeVersion))
 	}
 
+	if scannerOption.HelmDependencyUpdate && !scannerOption.Offline {
+		opts = append(opts, helm.ScannerWithDependencyUpdate(true))
+	}
+
+	return opts
+}
+
+func addK8sOpts(opts []options.ScannerOption, scannerOption ScannerOption) []options.ScannerOption {
+	if scannerOption.K8sSchemaDir != "" {
+		opts = append(opts,
+			k8sscanner.ScannerWithSchemaDir(scannerOption.K8sSchemaDir),
+			k8sscanner.ScannerWithSchemaVersion(scannerOption.K8sSchemaVersion),
+		)
+	}
+
+	if scannerOption.K8sUpgradeVersion != "" {
+		opts = append(opts, k8sscanner.ScannerWithUpgradeVersion(scannerOption.K8sUpgradeVersion))
+	}
+
+	if scannerOption.K8sResolveGitOps {
+		opts = append(opts, k8sscanner.ScannerWithGitOpsResolution(true))
+	}
+
+	if len(scannerOption.K8sCRDSchemas) > 0 {
+		opts = append(opts, k8sscanner.ScannerWithCRDSchemas(scannerOption.K8sCRDSchemas...))
+	}
+
+	return opts
+}
+
+func addJsonnetOpts(opts []options.ScannerOption, scannerOption ScannerOption) []options.ScannerOption {
+	if len(scannerOption.JsonnetImportPaths) > 0 {
+		opts = append(opts, jsonnet.ScannerWithImportPaths(scannerOption.JsonnetImportPaths...))
+	}
+
+	if len(scannerOption.JsonnetExtVars) > 0 {
+		opts = append(opts, jsonnet.ScannerWithExtVars(scannerOption.JsonnetExtVars...))
+	}
+
+	if len(scannerOption.JsonnetExtCode) > 0 {
+		opts = append(opts, jsonnet.ScannerWithExtCode(scannerOption.JsonnetExtCode...))
+	}
+
 	return opts
 }
 
@@ -369,6 +557,28 @@ func createConfigFS(paths []string) (fs.FS, error) {
 	return mfs, nil
 }
 
+// checkReaders returns readers of the Rego files in the filesystem
+func checkReaders(fsys fs.FS) ([]io.Reader, error) {
+	var readers []io.Reader
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if d.IsDir() || filepath.Ext(path) != ".rego" {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		readers = append(readers, bytes.NewReader(b))
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("walk error: %w", err)
+	}
+	return readers, nil
+}
+
 func CreatePolicyFS(policyPaths []string) (fs.FS, []string, error) {
 	if len(policyPaths) == 0 {
 		return nil, nil, nil
@@ -408,30 +618,38 @@ func CreatePolicyFS(policyPaths []string) (fs.FS, []string, error) {
 }
 
 func CreateDataFS(dataPaths []string, opts ...string) (fs.FS, []string, error) {
+	fsys, err := createDataFS(dataPaths, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// dataPaths are no longer needed as fs.FS contains only needed files now.
+	dataPaths = []string{"."}
+
+	return fsys, dataPaths, nil
+}
+
+func createDataFS(dataPaths []string, opts ...string) (*mapfs.FS, error) {
 	fsys := mapfs.New()
 
 	// Check if k8sVersion is provided
 	if len(opts) > 0 {
 		k8sVersion := opts[0]
 		if err := fsys.MkdirAll("system", 0700); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		data := []byte(fmt.Sprintf(`{"k8s": {"version": %q}}`, k8sVersion))
 		if err := fsys.WriteVirtualFile("system/k8s-version.json", data, 0600); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
 	for _, path := range dataPaths {
 		if err := fsys.CopyFilesUnder(path); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
-
-	// dataPaths are no longer needed as fs.FS contains only needed files now.
-	dataPaths = []string{"."}
-
-	return fsys, dataPaths, nil
+	return fsys, nil
 }
 
 // ResultsToMisconf is exported for trivy-plugin-aqua purposes only
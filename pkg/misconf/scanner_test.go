@@ -132,6 +132,50 @@ func TestScanner_Scan(t *testing.T) {
 			wantFileType:     types.Dockerfile,
 			misconfsExpected: 1,
 		},
+		{
+			name:     "happy path. Kustomize overlay",
+			fileType: detection.FileTypeKubernetes,
+			files: []file{
+				{
+					path: "base/kustomization.yaml",
+					content: []byte(`resources:
+- pod.yaml
+`),
+				},
+				{
+					path: "base/pod.yaml",
+					content: []byte(`apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+  - name: web
+    image: nginx
+`),
+				},
+				{
+					path: "overlay/kustomization.yaml",
+					content: []byte(`resources:
+- ../base
+patches:
+- path: patch.yaml
+  target:
+    kind: Pod
+`),
+				},
+				{
+					path: "overlay/patch.yaml",
+					content: []byte(`- op: add
+  path: /spec/hostNetwork
+  value: true
+`),
+				},
+			},
+			wantFilePath:     "overlay/kustomization.yaml",
+			wantFileType:     types.Kubernetes,
+			misconfsExpected: 1,
+		},
 		{
 			name:     "happy path. terraform plan file",
 			fileType: detection.FileTypeTerraformPlanJSON,
@@ -151,6 +195,7 @@ func TestScanner_Scan(t *testing.T) {
 			// Create a virtual filesystem for testing
 			fsys := mapfs.New()
 			for _, f := range tt.files {
+				require.NoError(t, fsys.MkdirAll(filepath.Dir(f.path), 0o755))
 				err := fsys.WriteVirtualFile(f.path, f.content, 0666)
 				require.NoError(t, err)
 			}
@@ -24,3 +24,10 @@ type PostScanner interface {
 	PostScanSpec() serialize.PostScanSpec
 	PostScan(serialize.Results) (serialize.Results, error)
 }
+
+// Artifact registers a new artifact type such as a proprietary appliance bundle.
+// Extract unpacks the artifact and returns the files, which Trivy scans as a filesystem.
+type Artifact interface {
+	ArtifactType() string
+	Extract(filePath string) ([]serialize.File, error)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/samber/lo"
@@ -18,6 +19,8 @@ import (
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/fanal/analyzer"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/custom"
 	"github.com/aquasecurity/trivy/pkg/log"
 	tapi "github.com/aquasecurity/trivy/pkg/module/api"
 	"github.com/aquasecurity/trivy/pkg/module/serialize"
@@ -183,6 +186,9 @@ func (m *Manager) Deregister() {
 	for _, mod := range m.modules {
 		analyzer.DeregisterAnalyzer(analyzer.Type(mod.Name()))
 		post.DeregisterPostScanner(mod.Name())
+		if mod.artifactType != "" {
+			custom.DeregisterArtifact(mod.artifactType)
+		}
 	}
 }
 
@@ -270,10 +276,12 @@ type wasmModule struct {
 	isAnalyzer    bool
 	isPostScanner bool
 	postScanSpec  serialize.PostScanSpec
+	artifactType  artifact.Type // empty if the module doesn't register an artifact type
 
 	// Exported functions
 	analyze  api.Function
 	postScan api.Function
+	extract  api.Function
 	malloc   api.Function // TinyGo specific
 	free     api.Function // TinyGo specific
 }
@@ -369,6 +377,16 @@ func newWASMPlugin(ctx context.Context, ccache wazero.CompilationCache, code []b
 		return nil, xerrors.New("post_scan() must be exported")
 	}
 
+	// artifact_type() and extract() are optional for backward compatibility
+	artifactType, err := moduleArtifactType(ctx, mod)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get an artifact type: %w", err)
+	}
+	extractFunc := mod.ExportedFunction("extract")
+	if artifactType != "" && extractFunc == nil {
+		return nil, xerrors.New("extract() must be exported")
+	}
+
 	var requiredFiles []*regexp.Regexp
 	if isAnalyzer {
 		// Get required files
@@ -397,9 +415,11 @@ func newWASMPlugin(ctx context.Context, ccache wazero.CompilationCache, code []b
 		isAnalyzer:    isAnalyzer,
 		isPostScanner: isPostScanner,
 		postScanSpec:  postScanSpec,
+		artifactType:  artifactType,
 
 		analyze:  analyzeFunc,
 		postScan: postScanFunc,
+		extract:  extractFunc,
 		malloc:   malloc,
 		free:     free,
 	}, nil
@@ -416,6 +436,12 @@ func (m *wasmModule) Register() {
 		logger.Debug("Registering custom post scanner")
 		post.RegisterPostScanner(m)
 	}
+	if m.artifactType != "" {
+		logger.Debug("Registering custom artifact type", log.String("type", string(m.artifactType)))
+		if err := custom.RegisterArtifact(m.artifactType, custom.NewExtractor(m.artifactType, m.Extract)); err != nil {
+			logger.Warn("Failed to register the artifact type", log.Err(err))
+		}
+	}
 }
 
 func (m *wasmModule) Close(ctx context.Context) error {
@@ -477,6 +503,66 @@ func (m *wasmModule) Analyze(ctx context.Context, input analyzer.AnalysisInput)
 	return &result, nil
 }
 
+// Extract unpacks the artifact of the registered type into the directory
+func (m *wasmModule) Extract(ctx context.Context, target, dir string) error {
+	f, err := os.Open(target)
+	if err != nil {
+		return xerrors.Errorf("file open error: %w", err)
+	}
+	defer f.Close()
+
+	filePath := "/" + filepath.Base(target)
+	log.Debug("Module extracting...", log.String("module", m.name), log.FilePath(target))
+
+	// Wasm module instances are not Goroutine safe
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if err = m.memFS.initialize(filePath, f); err != nil {
+		return err
+	}
+
+	inputPtr, inputSize, err := stringToPtrSize(ctx, filePath, m.mod, m.malloc)
+	if err != nil {
+		return xerrors.Errorf("failed to write string to memory: %w", err)
+	}
+	defer m.free.Call(ctx, inputPtr) // nolint: errcheck
+
+	extractRes, err := m.extract.Call(ctx, inputPtr, inputSize)
+	if err != nil {
+		return xerrors.Errorf("extract error: %w", err)
+	} else if len(extractRes) != 1 {
+		return xerrors.New("invalid signature: extract")
+	} else if extractRes[0] == 0 {
+		return xerrors.New("the module failed to extract the artifact")
+	}
+
+	var files []serialize.File
+	if err = unmarshal(m.mod.Memory(), extractRes[0], &files); err != nil {
+		return xerrors.Errorf("invalid return value: %w", err)
+	}
+	return writeFiles(dir, files)
+}
+
+// writeFiles writes the files returned by the module into the directory.
+// The paths must be local so that the module cannot write files outside the directory.
+func writeFiles(dir string, files []serialize.File) error {
+	for _, file := range files {
+		p := filepath.FromSlash(strings.TrimPrefix(file.Path, "/"))
+		if !filepath.IsLocal(p) {
+			return xerrors.Errorf("invalid file path: %s", file.Path)
+		}
+		p = filepath.Join(dir, p)
+		if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+			return xerrors.Errorf("mkdir error: %w", err)
+		}
+		if err := os.WriteFile(p, file.Content, 0o600); err != nil {
+			return xerrors.Errorf("file write error: %w", err)
+		}
+	}
+	return nil
+}
+
 // PostScan performs post scanning
 // e.g. Remove a vulnerability, change severity, etc.
 func (m *wasmModule) PostScan(ctx context.Context, results types.Results) (types.Results, error) {
@@ -703,6 +789,28 @@ func moduleRequiredFiles(ctx context.Context, mod api.Module) ([]*regexp.Regexp,
 	return requiredFiles, nil
 }
 
+func moduleArtifactType(ctx context.Context, mod api.Module) (artifact.Type, error) {
+	artifactTypeFunc := mod.ExportedFunction("artifact_type")
+	if artifactTypeFunc == nil {
+		return "", nil
+	}
+
+	artifactTypeRes, err := artifactTypeFunc.Call(ctx)
+	if err != nil {
+		return "", xerrors.Errorf("wasm function artifact_type() invocation error: %w", err)
+	} else if len(artifactTypeRes) != 1 {
+		return "", xerrors.New("invalid signature: artifact_type")
+	} else if artifactTypeRes[0] == 0 {
+		return "", nil
+	}
+
+	artifactType, err := ptrSizeToString(mod.Memory(), artifactTypeRes[0])
+	if err != nil {
+		return "", xerrors.Errorf("invalid return value: %w", err)
+	}
+	return artifact.Type(artifactType), nil
+}
+
 func moduleIsAnalyzer(ctx context.Context, mod api.Module) (bool, error) {
 	return isType(ctx, mod, "is_analyzer")
 }
@@ -25,6 +25,12 @@ type CustomResource struct {
 	Data     any
 }
 
+// File is a file unpacked from an artifact of the type registered by the module
+type File struct {
+	Path    string
+	Content []byte
+}
+
 type PostScanAction string
 
 type PostScanSpec struct {
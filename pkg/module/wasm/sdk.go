@@ -128,6 +128,27 @@ func _post_scan(ptr, size uint32) uint64 {
 	return marshal(results)
 }
 
+//export artifact_type
+func _artifactType() uint64 {
+	a, ok := module.(api.Artifact)
+	if !ok || a.ArtifactType() == "" {
+		return 0
+	}
+	ptr, size := stringToPtr(a.ArtifactType())
+	return (uint64(ptr) << uint64(32)) | uint64(size)
+}
+
+//export extract
+func _extract(ptr, size uint32) uint64 {
+	filePath := ptrToString(ptr, size)
+	files, err := module.(api.Artifact).Extract(filePath)
+	if err != nil {
+		Error(fmt.Sprintf("extract error: %s", err))
+		return 0
+	}
+	return marshal(files)
+}
+
 func marshal(v any) uint64 {
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -0,0 +1,266 @@
+package namecheck
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+type ecosystem string
+
+const (
+	ecosystemNpm      ecosystem = "npm"
+	ecosystemPyPI     ecosystem = "pypi"
+	ecosystemRubyGems ecosystem = "rubygems"
+	ecosystemCrates   ecosystem = "crates"
+
+	// Short names are skipped, as most of them are one edit away from popular ones
+	minTyposquatLength = 5
+)
+
+var (
+	//go:embed popular/*.txt
+	popularFS embed.FS
+
+	ecosystems = map[ftypes.LangType]ecosystem{
+		ftypes.Npm:        ecosystemNpm,
+		ftypes.Yarn:       ecosystemNpm,
+		ftypes.Pnpm:       ecosystemNpm,
+		ftypes.NodePkg:    ecosystemNpm,
+		ftypes.Pip:        ecosystemPyPI,
+		ftypes.Pipenv:     ecosystemPyPI,
+		ftypes.Poetry:     ecosystemPyPI,
+		ftypes.Uv:         ecosystemPyPI,
+		ftypes.PythonPkg:  ecosystemPyPI,
+		ftypes.Bundler:    ecosystemRubyGems,
+		ftypes.GemSpec:    ecosystemRubyGems,
+		ftypes.Cargo:      ecosystemCrates,
+		ftypes.RustBinary: ecosystemCrates,
+	}
+
+	// Hosts of public registries, used to detect internal packages resolved from them
+	publicRegistries = map[ecosystem][]string{
+		ecosystemNpm:      {"registry.npmjs.org", "registry.yarnpkg.com"},
+		ecosystemPyPI:     {"pypi.org", "files.pythonhosted.org"},
+		ecosystemRubyGems: {"rubygems.org"},
+		ecosystemCrates:   {"crates.io", "static.crates.io", "index.crates.io"},
+	}
+
+	// cf. https://peps.python.org/pep-0503/#normalized-names
+	pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+)
+
+var (
+	typosquatCheck = ftypes.PolicyMetadata{
+		ID:                 "SC005",
+		Type:               "Package Name Check",
+		Title:              "Possible typosquatting",
+		Description:        "The package name is very similar to a popular package. Attackers publish malicious packages with misspelled names of popular packages to be installed by mistake.",
+		Severity:           "HIGH",
+		RecommendedActions: "Make sure the package is the intended one, and replace it with the popular package otherwise.",
+		References: []string{
+			"https://owasp.org/www-project-top-10-ci-cd-security-risks/CICD-SEC-03-Dependency-Chain-Abuse",
+		},
+	}
+	dependencyConfusionCheck = ftypes.PolicyMetadata{
+		ID:                 "SC006",
+		Type:               "Package Name Check",
+		Title:              "Possible dependency confusion",
+		Description:        "The package name matches an internal namespace. Attackers publish packages with the names of internal packages to public registries, so that package managers install them instead of the internal ones.",
+		Severity:           "MEDIUM",
+		RecommendedActions: "Install internal packages only from the internal registry, e.g. with scoped registries, and reserve the names in the public registry.",
+		References: []string{
+			"https://owasp.org/www-project-top-10-ci-cd-security-risks/CICD-SEC-03-Dependency-Chain-Abuse",
+			"https://medium.com/@alex.birsan/dependency-confusion-4a5d60fec610",
+		},
+	}
+)
+
+// Checker detects likely typosquats and dependency confusion candidates from package names.
+type Checker struct {
+	popular            map[ecosystem][]string
+	internalNamespaces []string
+}
+
+// NewChecker returns a checker with the built-in lists of popular packages.
+// internalNamespaces are prefixes of internal package names, such as "@acme/" and "acme-".
+func NewChecker(internalNamespaces []string) Checker {
+	popular := make(map[ecosystem][]string)
+	for _, eco := range lo.Uniq(lo.Values(ecosystems)) {
+		names, err := loadPopular(eco)
+		if err != nil {
+			log.Debug("Unable to load popular packages", log.String("ecosystem", string(eco)), log.Err(err))
+			continue
+		}
+		popular[eco] = lo.Map(names, func(name string, _ int) string {
+			return normalize(eco, name)
+		})
+	}
+	return Checker{
+		popular: popular,
+		internalNamespaces: lo.FilterMap(internalNamespaces, func(ns string, _ int) (string, bool) {
+			ns = strings.TrimSpace(ns)
+			return ns, ns != ""
+		}),
+	}
+}
+
+func loadPopular(eco ecosystem) ([]string, error) {
+	f, err := popularFS.Open(path.Join("popular", string(eco)+".txt"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, scanner.Err()
+}
+
+// Check returns the findings for packages in the application.
+func (c Checker) Check(app ftypes.Application) []ftypes.MisconfResult {
+	eco, ok := ecosystems[app.Type]
+	if !ok {
+		return nil
+	}
+
+	var results []ftypes.MisconfResult
+	for _, pkg := range app.Packages {
+		name := normalize(eco, pkg.Name)
+		if target := c.typosquatTarget(eco, name); target != "" {
+			msg := fmt.Sprintf("Package '%s' is similar to the popular package '%s'", pkg.Name, target)
+			results = append(results, newResult(typosquatCheck, pkg, msg))
+		}
+		if ns := c.internalNamespace(eco, name); ns != "" {
+			results = append(results, c.dependencyConfusion(eco, pkg, ns)...)
+		}
+	}
+	return results
+}
+
+// typosquatTarget returns the popular package similar to the name.
+func (c Checker) typosquatTarget(eco ecosystem, name string) string {
+	popular := c.popular[eco]
+	if slices.Contains(popular, name) || c.internalNamespace(eco, name) != "" {
+		return ""
+	}
+	for _, p := range popular {
+		// e.g. "crossenv" for "cross-env"
+		// PyPI treats names with different separators as the same package.
+		if eco != ecosystemPyPI && stripSeparators(name) == stripSeparators(p) {
+			return p
+		}
+		if len(name) < minTyposquatLength || len(p) < minTyposquatLength || abs(len(name)-len(p)) > 1 {
+			continue
+		}
+		if editDistance(name, p) == 1 {
+			return p
+		}
+	}
+	return ""
+}
+
+func (c Checker) internalNamespace(eco ecosystem, name string) string {
+	for _, ns := range c.internalNamespaces {
+		if strings.HasPrefix(name, normalize(eco, ns)) {
+			return ns
+		}
+	}
+	return ""
+}
+
+func (c Checker) dependencyConfusion(eco ecosystem, pkg ftypes.Package, ns string) []ftypes.MisconfResult {
+	urls := lo.FilterMap(pkg.ExternalReferences, func(ref ftypes.ExternalRef, _ int) (*url.URL, bool) {
+		u, err := url.Parse(ref.URL)
+		return u, err == nil && u.Host != ""
+	})
+
+	// The source is unknown, e.g. in requirements.txt
+	if len(urls) == 0 {
+		msg := fmt.Sprintf("Package '%s' matches the internal namespace '%s', but the registry is unknown", pkg.Name, ns)
+		return []ftypes.MisconfResult{newResult(dependencyConfusionCheck, pkg, msg)}
+	}
+
+	for _, u := range urls {
+		if slices.Contains(publicRegistries[eco], u.Hostname()) {
+			metadata := dependencyConfusionCheck
+			metadata.Severity = "CRITICAL"
+			msg := fmt.Sprintf("Package '%s' matches the internal namespace '%s', but it is resolved from the public registry '%s'", pkg.Name, ns, u.Hostname())
+			return []ftypes.MisconfResult{newResult(metadata, pkg, msg)}
+		}
+	}
+	return nil
+}
+
+func newResult(metadata ftypes.PolicyMetadata, pkg ftypes.Package, msg string) ftypes.MisconfResult {
+	res := ftypes.MisconfResult{
+		Namespace:      "supplychain.pkgname",
+		Message:        msg,
+		PolicyMetadata: metadata,
+		CauseMetadata: ftypes.CauseMetadata{
+			Resource: pkg.Name,
+		},
+	}
+	if len(pkg.Locations) > 0 {
+		res.CauseMetadata.StartLine = pkg.Locations[0].StartLine
+		res.CauseMetadata.EndLine = pkg.Locations[0].EndLine
+	}
+	return res
+}
+
+func normalize(eco ecosystem, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if eco == ecosystemPyPI {
+		name = pythonNameSeparators.ReplaceAllString(name, "-")
+	}
+	return name
+}
+
+func stripSeparators(name string) string {
+	return strings.NewReplacer("-", "", "_", "", ".", "").Replace(name)
+}
+
+// editDistance returns the optimal string alignment distance,
+// i.e. the number of insertions, deletions, substitutions and transpositions of adjacent characters.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := lo.Ternary(s[i-1] == t[j-1], 0, 1)
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+func abs(n int) int {
+	return lo.Ternary(n < 0, -n, n)
+}
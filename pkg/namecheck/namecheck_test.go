@@ -0,0 +1,178 @@
+package namecheck_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/namecheck"
+)
+
+func TestChecker_Check(t *testing.T) {
+	type finding struct {
+		ID        string
+		Severity  string
+		Message   string
+		StartLine int
+	}
+	tests := []struct {
+		name               string
+		internalNamespaces []string
+		app                ftypes.Application
+		want               []finding
+	}{
+		{
+			name: "npm typosquats",
+			app: ftypes.Application{
+				Type:     ftypes.Npm,
+				FilePath: "package-lock.json",
+				Packages: ftypes.Packages{
+					{
+						Name:      "crossenv",
+						Version:   "1.0.0",
+						Locations: []ftypes.Location{{StartLine: 10, EndLine: 15}},
+					},
+					{
+						Name:    "lodahs",
+						Version: "1.0.0",
+					},
+					{
+						Name:    "expres",
+						Version: "1.0.0",
+					},
+					// popular packages
+					{
+						Name:    "lodash",
+						Version: "4.17.21",
+					},
+					{
+						Name:    "react-dom",
+						Version: "18.2.0",
+					},
+					// short names
+					{
+						Name:    "qss",
+						Version: "1.0.0",
+					},
+				},
+			},
+			want: []finding{
+				{
+					ID:        "SC005",
+					Severity:  "HIGH",
+					Message:   "Package 'crossenv' is similar to the popular package 'cross-env'",
+					StartLine: 10,
+				},
+				{
+					ID:       "SC005",
+					Severity: "HIGH",
+					Message:  "Package 'lodahs' is similar to the popular package 'lodash'",
+				},
+				{
+					ID:       "SC005",
+					Severity: "HIGH",
+					Message:  "Package 'expres' is similar to the popular package 'express'",
+				},
+			},
+		},
+		{
+			name: "PyPI normalized names",
+			app: ftypes.Application{
+				Type:     ftypes.Pip,
+				FilePath: "requirements.txt",
+				Packages: ftypes.Packages{
+					{
+						Name:    "python_dateutil",
+						Version: "2.8.2",
+					},
+					{
+						Name:    "reqeusts",
+						Version: "2.31.0",
+					},
+				},
+			},
+			want: []finding{
+				{
+					ID:       "SC005",
+					Severity: "HIGH",
+					Message:  "Package 'reqeusts' is similar to the popular package 'requests'",
+				},
+			},
+		},
+		{
+			name:               "dependency confusion",
+			internalNamespaces: []string{"@acme/", "Acme-"},
+			app: ftypes.Application{
+				Type:     ftypes.Npm,
+				FilePath: "package-lock.json",
+				Packages: ftypes.Packages{
+					{
+						Name:    "@acme/utils",
+						Version: "1.0.0",
+						ExternalReferences: []ftypes.ExternalRef{
+							{
+								Type: ftypes.RefOther,
+								URL:  "https://registry.npmjs.org/@acme/utils/-/utils-1.0.0.tgz",
+							},
+						},
+					},
+					{
+						Name:    "@acme/core",
+						Version: "1.0.0",
+						ExternalReferences: []ftypes.ExternalRef{
+							{
+								Type: ftypes.RefOther,
+								URL:  "https://npm.acme.example.com/@acme/core/-/core-1.0.0.tgz",
+							},
+						},
+					},
+					{
+						Name:    "acme-logger",
+						Version: "1.0.0",
+					},
+				},
+			},
+			want: []finding{
+				{
+					ID:       "SC006",
+					Severity: "CRITICAL",
+					Message:  "Package '@acme/utils' matches the internal namespace '@acme/', but it is resolved from the public registry 'registry.npmjs.org'",
+				},
+				{
+					ID:       "SC006",
+					Severity: "MEDIUM",
+					Message:  "Package 'acme-logger' matches the internal namespace 'Acme-', but the registry is unknown",
+				},
+			},
+		},
+		{
+			name: "unsupported ecosystem",
+			app: ftypes.Application{
+				Type:     ftypes.GoModule,
+				FilePath: "go.mod",
+				Packages: ftypes.Packages{
+					{
+						Name:    "github.com/sirupsen/logrus",
+						Version: "1.9.3",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := namecheck.NewChecker(tt.internalNamespaces)
+			var got []finding
+			for _, res := range c.Check(tt.app) {
+				got = append(got, finding{
+					ID:        res.ID,
+					Severity:  res.Severity,
+					Message:   res.Message,
+					StartLine: res.StartLine,
+				})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -74,6 +74,11 @@ func NewArtifact(repo string, registryOpt types.RegistryOptions, opts ...Option)
 	return art
 }
 
+// Repository returns the repository of the artifact
+func (a *Artifact) Repository() string {
+	return a.repository
+}
+
 func (a *Artifact) populate(ctx context.Context, opt types.RegistryOptions) error {
 	if a.image != nil {
 		return nil
@@ -82,6 +87,19 @@ func (a *Artifact) populate(ctx context.Context, opt types.RegistryOptions) erro
 	a.m.Lock()
 	defer a.m.Unlock()
 
+	ref, err := a.reference(opt)
+	if err != nil {
+		return err
+	}
+
+	a.image, err = remote.Image(ctx, ref, opt)
+	if err != nil {
+		return xerrors.Errorf("OCI repository error: %w", err)
+	}
+	return nil
+}
+
+func (a *Artifact) reference(opt types.RegistryOptions) (name.Reference, error) {
 	var nameOpts []name.Option
 	if opt.Insecure {
 		nameOpts = append(nameOpts, name.Insecure)
@@ -89,14 +107,21 @@ func (a *Artifact) populate(ctx context.Context, opt types.RegistryOptions) erro
 
 	ref, err := name.ParseReference(a.repository, nameOpts...)
 	if err != nil {
-		return xerrors.Errorf("repository name error (%s): %w", a.repository, err)
+		return nil, xerrors.Errorf("repository name error (%s): %w", a.repository, err)
 	}
+	return ref, nil
+}
 
-	a.image, err = remote.Image(ctx, ref, opt)
+func (a *Artifact) verifySignature(ctx context.Context) error {
+	ref, err := a.reference(a.RegistryOptions)
 	if err != nil {
-		return xerrors.Errorf("OCI repository error: %w", err)
+		return err
 	}
-	return nil
+	digest, err := a.image.Digest()
+	if err != nil {
+		return xerrors.Errorf("digest error: %w", err)
+	}
+	return verifySignature(ctx, ref.Context(), digest, a.RegistryOptions)
 }
 
 type DownloadOption struct {
@@ -110,6 +135,13 @@ func (a *Artifact) Download(ctx context.Context, dir string, opt DownloadOption)
 		return err
 	}
 
+	// Verify the signature before use so that tampered artifacts are never extracted
+	if a.ArtifactSignature != nil {
+		if err := a.verifySignature(ctx); err != nil {
+			return xerrors.Errorf("signature verification error: %w", err)
+		}
+	}
+
 	layers, err := a.image.Layers()
 	if err != nil {
 		return xerrors.Errorf("OCI layer error: %w", err)
@@ -0,0 +1,112 @@
+package oci
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"regexp"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/hashicorp/go-multierror"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/oci/signature"
+	"github.com/sigstore/sigstore/pkg/fulcioroots"
+	sigsignature "github.com/sigstore/sigstore/pkg/signature"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/remote"
+)
+
+// SignatureTag returns the tag where cosign stores the signature of the artifact with the digest,
+// e.g. "sha256-<hex>.sig"
+func SignatureTag(digest v1.Hash) string {
+	return fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex)
+}
+
+// verifySignature verifies the cosign signature of the artifact with the digest in the repository.
+// It fails if none of the signatures is valid.
+func verifySignature(ctx context.Context, repo name.Repository, digest v1.Hash, opt types.RegistryOptions) error {
+	checkOpts, err := newCheckOpts(ctx, opt.ArtifactSignature)
+	if err != nil {
+		return xerrors.Errorf("unable to initialize the signature verification: %w", err)
+	}
+
+	sigRef := repo.Tag(SignatureTag(digest))
+	sigImg, err := remote.Image(ctx, sigRef, opt)
+	if err != nil {
+		return xerrors.Errorf("unable to fetch the signature (%s): %w", sigRef, err)
+	}
+
+	manifest, err := sigImg.Manifest()
+	if err != nil {
+		return xerrors.Errorf("signature manifest error: %w", err)
+	}
+	layers, err := sigImg.Layers()
+	if err != nil {
+		return xerrors.Errorf("signature layer error: %w", err)
+	} else if len(layers) != len(manifest.Layers) {
+		return xerrors.New("invalid signature manifest")
+	}
+
+	var errs error
+	for i, layer := range layers {
+		sig := signature.New(layer, manifest.Layers[i])
+		if _, err = cosign.VerifyImageSignature(ctx, sig, digest, checkOpts); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		log.DebugContext(ctx, "Signature verified", log.String("repo", repo.String()), log.String("digest", digest.String()))
+		return nil
+	}
+	if errs == nil {
+		return xerrors.Errorf("no signature found (%s)", sigRef)
+	}
+	return xerrors.Errorf("no valid signature: %w", errs)
+}
+
+// newCheckOpts returns the options for cosign.
+// Keyless signatures are verified offline with the Rekor bundle attached to them.
+func newCheckOpts(ctx context.Context, opt *types.SignatureOptions) (*cosign.CheckOpts, error) {
+	checkOpts := &cosign.CheckOpts{
+		ClaimVerifier: cosign.SimpleClaimVerifier,
+	}
+
+	if opt.Key != "" {
+		verifier, err := sigsignature.LoadVerifierFromPEMFile(opt.Key, crypto.SHA256)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to load the public key: %w", err)
+		}
+		checkOpts.SigVerifier = verifier
+		checkOpts.IgnoreTlog = true
+		return checkOpts, nil
+	}
+
+	if _, err := regexp.Compile(opt.Identity); err != nil {
+		return nil, xerrors.Errorf("invalid identity: %w", err)
+	}
+	checkOpts.Identities = []cosign.Identity{
+		{
+			Issuer:        opt.OIDCIssuer,
+			SubjectRegExp: opt.Identity,
+		},
+	}
+	checkOpts.Offline = true
+
+	var err error
+	if checkOpts.RootCerts, err = fulcioroots.Get(); err != nil {
+		return nil, xerrors.Errorf("unable to get the Fulcio root certificates: %w", err)
+	}
+	if checkOpts.IntermediateCerts, err = fulcioroots.GetIntermediates(); err != nil {
+		return nil, xerrors.Errorf("unable to get the Fulcio intermediate certificates: %w", err)
+	}
+	if checkOpts.RekorPubKeys, err = cosign.GetRekorPubs(ctx); err != nil {
+		return nil, xerrors.Errorf("unable to get the Rekor public keys: %w", err)
+	}
+	if checkOpts.CTLogPubKeys, err = cosign.GetCTLogPubs(ctx); err != nil {
+		return nil, xerrors.Errorf("unable to get the CT log public keys: %w", err)
+	}
+	return checkOpts, nil
+}
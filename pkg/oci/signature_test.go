@@ -0,0 +1,157 @@
+package oci_test
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	cosignempty "github.com/sigstore/cosign/v2/pkg/oci/empty"
+	cosignmutate "github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	"github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/oci"
+)
+
+func TestArtifact_Download_Signature(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	layer, err := tarball.LayerFromFile("testdata/test.tar.gz")
+	require.NoError(t, err)
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+	digest, err := img.Digest()
+	require.NoError(t, err)
+
+	signer, keyPath := newTestKey(t)
+	_, otherKeyPath := newTestKey(t)
+
+	tests := []struct {
+		name      string
+		repo      string
+		signature func(t *testing.T, ref name.Reference)
+		keyPath   string
+		wantErr   string
+	}{
+		{
+			name: "valid signature",
+			repo: host + "/signed:1",
+			signature: func(t *testing.T, ref name.Reference) {
+				pushSignature(t, ref, digest, digest, signer)
+			},
+			keyPath: keyPath,
+		},
+		{
+			name: "another key",
+			repo: host + "/signed-with-another-key:1",
+			signature: func(t *testing.T, ref name.Reference) {
+				pushSignature(t, ref, digest, digest, signer)
+			},
+			keyPath: otherKeyPath,
+			wantErr: "no valid signature",
+		},
+		{
+			name: "signature of another artifact",
+			repo: host + "/signed-for-another-artifact:1",
+			signature: func(t *testing.T, ref name.Reference) {
+				// The signature of another digest is copied to the tag of the artifact
+				pushSignature(t, ref, digest, v1.Hash{
+					Algorithm: "sha256",
+					Hex:       "0000000000000000000000000000000000000000000000000000000000000000",
+				}, signer)
+			},
+			keyPath: keyPath,
+			wantErr: "no valid signature",
+		},
+		{
+			name:    "unsigned",
+			repo:    host + "/unsigned:1",
+			keyPath: keyPath,
+			wantErr: "unable to fetch the signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := name.ParseReference(tt.repo)
+			require.NoError(t, err)
+			require.NoError(t, remote.Write(ref, img))
+			if tt.signature != nil {
+				tt.signature(t, ref)
+			}
+
+			tempDir := t.TempDir()
+			artifact := oci.NewArtifact(tt.repo, ftypes.RegistryOptions{
+				ArtifactSignature: &ftypes.SignatureOptions{
+					Key: tt.keyPath,
+				},
+			})
+			err = artifact.Download(context.Background(), tempDir, oci.DownloadOption{
+				Filename: "test.tar.gz",
+				Quiet:    true,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				assert.NoFileExists(t, filepath.Join(tempDir, "test.txt"))
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join(tempDir, "test.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, "Hello, world", string(got))
+		})
+	}
+}
+
+func newTestKey(t *testing.T) (signature.Signer, string) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signer, err := signature.LoadECDSASignerVerifier(priv, crypto.SHA256)
+	require.NoError(t, err)
+
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(priv.Public())
+	require.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(keyPath, pub, 0o600))
+	return signer, keyPath
+}
+
+// pushSignature pushes the signature of the signed digest in the same way as `cosign sign --key`
+func pushSignature(t *testing.T, ref name.Reference, digest, signed v1.Hash, signer signature.Signer) {
+	p, err := payload.Cosign{
+		Image: ref.Context().Digest(signed.String()),
+	}.MarshalJSON()
+	require.NoError(t, err)
+	rawSig, err := signer.SignMessage(bytes.NewReader(p))
+	require.NoError(t, err)
+
+	sig, err := static.NewSignature(p, base64.StdEncoding.EncodeToString(rawSig))
+	require.NoError(t, err)
+	sigs, err := cosignmutate.AppendSignatures(cosignempty.Signatures(), false, sig)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref.Context().Tag(oci.SignatureTag(digest)), sigs))
+}
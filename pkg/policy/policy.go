@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/hashicorp/go-multierror"
 	"github.com/open-policy-agent/opa/bundle"
+	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 	"k8s.io/utils/clock"
 
@@ -25,14 +29,14 @@ const (
 )
 
 type options struct {
-	artifact *oci.Artifact
-	clock    clock.Clock
+	artifacts []*oci.Artifact
+	clock     clock.Clock
 }
 
-// WithOCIArtifact takes an OCI artifact
-func WithOCIArtifact(art *oci.Artifact) Option {
+// WithOCIArtifact takes OCI artifacts, in order of priority
+func WithOCIArtifact(arts ...*oci.Artifact) Option {
 	return func(opts *options) {
-		opts.artifact = art
+		opts.artifacts = arts
 	}
 }
 
@@ -49,9 +53,9 @@ type Option func(*options)
 // Client implements check operations
 type Client struct {
 	*options
-	policyDir       string
-	checkBundleRepo string
-	quiet           bool
+	policyDir        string
+	checkBundleRepos []string
+	quiet            bool
 }
 
 // Metadata holds default check metadata
@@ -67,8 +71,10 @@ func (m Metadata) String() string {
 `, m.Digest, m.DownloadedAt.UTC())
 }
 
-// NewClient is the factory method for check client
-func NewClient(cacheDir string, quiet bool, checkBundleRepo string, opts ...Option) (*Client, error) {
+// NewClient is the factory method for check client.
+// The check bundle is retrieved from the first reachable repository of checkBundleRepos, in order of priority,
+// e.g. a registry and its mirrors for air-gapped environments.
+func NewClient(cacheDir string, quiet bool, checkBundleRepos []string, opts ...Option) (*Client, error) {
 	o := &options{
 		clock: clock.RealClock{},
 	}
@@ -77,31 +83,52 @@ func NewClient(cacheDir string, quiet bool, checkBundleRepo string, opts ...Opti
 		opt(o)
 	}
 
-	if checkBundleRepo == "" {
-		checkBundleRepo = fmt.Sprintf("%s:%d", BundleRepository, BundleVersion)
+	checkBundleRepos = lo.Compact(checkBundleRepos)
+	if len(checkBundleRepos) == 0 {
+		checkBundleRepos = []string{fmt.Sprintf("%s:%d", BundleRepository, BundleVersion)}
 	}
 
 	return &Client{
-		options:         o,
-		policyDir:       filepath.Join(cacheDir, "policy"),
-		checkBundleRepo: checkBundleRepo,
-		quiet:           quiet,
+		options:          o,
+		policyDir:        filepath.Join(cacheDir, "policy"),
+		checkBundleRepos: checkBundleRepos,
+		quiet:            quiet,
 	}, nil
 }
 
-func (c *Client) populateOCIArtifact(ctx context.Context, registryOpts types.RegistryOptions) {
-	if c.artifact == nil {
-		log.DebugContext(ctx, "Loading check bundle", log.String("repository", c.checkBundleRepo))
-		c.artifact = oci.NewArtifact(c.checkBundleRepo, registryOpts)
+func (c *Client) populateOCIArtifacts(ctx context.Context, registryOpts types.RegistryOptions) {
+	if c.artifacts == nil {
+		log.DebugContext(ctx, "Loading check bundle", log.Any("repositories", c.checkBundleRepos))
+		c.artifacts = lo.Map(c.checkBundleRepos, func(repo string, _ int) *oci.Artifact {
+			return oci.NewArtifact(repo, registryOpts)
+		})
 	}
 }
 
-// DownloadBuiltinChecks download default policies from GitHub Pages
+// DownloadBuiltinChecks downloads the check bundle from the first repository it can be downloaded from.
+// Unlike the DB, any error falls back to the next repository, as mirrors are often the only reachable
+// repositories in air-gapped environments.
 func (c *Client) DownloadBuiltinChecks(ctx context.Context, registryOpts types.RegistryOptions) error {
-	c.populateOCIArtifact(ctx, registryOpts)
+	c.populateOCIArtifacts(ctx, registryOpts)
+
+	var errs error
+	for i, art := range c.artifacts {
+		err := c.download(ctx, art)
+		if err == nil {
+			return nil
+		}
+		if i < len(c.artifacts)-1 {
+			log.WarnContext(ctx, "Failed to download the check bundle, trying the next repository",
+				log.String("repository", art.Repository()), log.Err(err))
+		}
+		errs = multierror.Append(errs, err)
+	}
+	return xerrors.Errorf("failed to download the check bundle from any repository: %w", errs)
+}
 
+func (c *Client) download(ctx context.Context, art *oci.Artifact) error {
 	dst := c.contentDir()
-	if err := c.artifact.Download(ctx, dst, oci.DownloadOption{
+	if err := art.Download(ctx, dst, oci.DownloadOption{
 		MediaType: policyMediaType,
 		Quiet:     c.quiet,
 	},
@@ -109,11 +136,12 @@ func (c *Client) DownloadBuiltinChecks(ctx context.Context, registryOpts types.R
 		return xerrors.Errorf("download error: %w", err)
 	}
 
-	digest, err := c.artifact.Digest(ctx)
+	digest, err := art.Digest(ctx)
 	if err != nil {
 		return xerrors.Errorf("digest error: %w", err)
 	}
-	log.DebugContext(ctx, "Digest of the built-in checks", log.String("digest", digest))
+	log.DebugContext(ctx, "Digest of the built-in checks", log.String("repository", art.Repository()),
+		log.String("digest", digest))
 
 	// Update metadata.json with the new digest and the current date
 	if err = c.updateMetadata(digest, c.clock.Now()); err != nil {
@@ -157,15 +185,21 @@ func (c *Client) NeedsUpdate(ctx context.Context, registryOpts types.RegistryOpt
 		return true, nil
 	}
 
+	// Bundles pinned by digest never change, so the registries don't need to be reachable
+	// once the pinned bundle is downloaded.
+	if pinned := c.pinnedDigests(); len(pinned) > 0 {
+		return !slices.Contains(pinned, meta.Digest), nil
+	}
+
 	// No need to update if it's been within a day since the last update.
 	if c.clock.Now().Before(meta.DownloadedAt.Add(updateInterval)) {
 		return false, nil
 	}
 
-	c.populateOCIArtifact(ctx, registryOpts)
-	digest, err := c.artifact.Digest(ctx)
+	c.populateOCIArtifacts(ctx, registryOpts)
+	digest, err := c.digest(ctx)
 	if err != nil {
-		return false, xerrors.Errorf("digest error: %w", err)
+		return false, err
 	}
 
 	if meta.Digest != digest {
@@ -182,6 +216,32 @@ func (c *Client) NeedsUpdate(ctx context.Context, registryOpts types.RegistryOpt
 	return false, nil
 }
 
+// digest returns the digest of the check bundle in the first repository it can be retrieved from
+func (c *Client) digest(ctx context.Context) (string, error) {
+	var errs error
+	for _, art := range c.artifacts {
+		digest, err := art.Digest(ctx)
+		if err == nil {
+			return digest, nil
+		}
+		log.DebugContext(ctx, "Failed to get the digest of the check bundle",
+			log.String("repository", art.Repository()), log.Err(err))
+		errs = multierror.Append(errs, err)
+	}
+	return "", xerrors.Errorf("digest error: %w", errs)
+}
+
+// pinnedDigests returns the digests of the repositories pinned by digest, e.g. "registry.local/checks@sha256:..."
+func (c *Client) pinnedDigests() []string {
+	var digests []string
+	for _, repo := range c.checkBundleRepos {
+		if d, err := name.NewDigest(repo); err == nil {
+			digests = append(digests, d.DigestStr())
+		}
+	}
+	return digests
+}
+
 func (c *Client) contentDir() string {
 	return filepath.Join(c.policyDir, "content")
 }
@@ -117,7 +117,7 @@ func TestClient_LoadBuiltinPolicies(t *testing.T) {
 
 			// Mock OCI artifact
 			art := oci.NewArtifact("repo", ftypes.RegistryOptions{}, oci.WithImage(img))
-			c, err := policy.NewClient(tt.cacheDir, true, "", policy.WithOCIArtifact(art))
+			c, err := policy.NewClient(tt.cacheDir, true, nil, policy.WithOCIArtifact(art))
 			require.NoError(t, err)
 
 			got, err := c.LoadBuiltinChecks()
@@ -140,6 +140,7 @@ func TestClient_NeedsUpdate(t *testing.T) {
 	tests := []struct {
 		name          string
 		clock         clock.Clock
+		repos         []string
 		digestReturns digestReturns
 		metadata      any
 		want          bool
@@ -203,6 +204,29 @@ func TestClient_NeedsUpdate(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name:  "pinned digest",
+			clock: fake.NewFakeClock(time.Date(2021, 1, 2, 1, 0, 0, 0, time.UTC)),
+			repos: []string{"registry.local/checks@sha256:01e033e78bd8a59fa4f4577215e7da06c05e1152526094d8d79d2aa06e98cb9d"},
+			digestReturns: digestReturns{
+				err: errors.New("unreachable"),
+			},
+			metadata: policy.Metadata{
+				Digest:       `sha256:01e033e78bd8a59fa4f4577215e7da06c05e1152526094d8d79d2aa06e98cb9d`,
+				DownloadedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: false,
+		},
+		{
+			name:  "pinned digest changed",
+			clock: fake.NewFakeClock(time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC)),
+			repos: []string{"registry.local/checks@sha256:922e50f14ab484f11ae65540c3d2d76009020213f1027d4331d31141575e5414"},
+			metadata: policy.Metadata{
+				Digest:       `sha256:01e033e78bd8a59fa4f4577215e7da06c05e1152526094d8d79d2aa06e98cb9d`,
+				DownloadedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: true,
+		},
 		{
 			name:  "sad: non-existent metadata",
 			clock: fake.NewFakeClock(time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC)),
@@ -256,7 +280,7 @@ func TestClient_NeedsUpdate(t *testing.T) {
 			}
 
 			art := oci.NewArtifact("repo", ftypes.RegistryOptions{}, oci.WithImage(img))
-			c, err := policy.NewClient(tmpDir, true, "", policy.WithOCIArtifact(art), policy.WithClock(tt.clock))
+			c, err := policy.NewClient(tmpDir, true, tt.repos, policy.WithOCIArtifact(art), policy.WithClock(tt.clock))
 			require.NoError(t, err)
 
 			// Assert results
@@ -358,7 +382,7 @@ func TestClient_DownloadBuiltinPolicies(t *testing.T) {
 
 			// Mock OCI artifact
 			art := oci.NewArtifact("repo", ftypes.RegistryOptions{}, oci.WithImage(img))
-			c, err := policy.NewClient(tempDir, true, "", policy.WithClock(tt.clock), policy.WithOCIArtifact(art))
+			c, err := policy.NewClient(tempDir, true, nil, policy.WithClock(tt.clock), policy.WithOCIArtifact(art))
 			require.NoError(t, err)
 
 			err = c.DownloadBuiltinChecks(context.Background(), ftypes.RegistryOptions{})
@@ -383,12 +407,50 @@ func TestClient_DownloadBuiltinPolicies(t *testing.T) {
 	}
 }
 
+func TestClient_DownloadBuiltinPolicies_Fallback(t *testing.T) {
+	newImage := func(layer v1.Layer, hex string) v1.Image {
+		img := new(fakei.FakeImage)
+		img.DigestReturns(v1.Hash{Algorithm: "sha256", Hex: hex}, nil)
+		img.LayersReturns([]v1.Layer{layer}, nil)
+		img.ManifestReturns(&v1.Manifest{
+			Layers: []v1.Descriptor{
+				{
+					MediaType: "application/vnd.cncf.openpolicyagent.layer.v1.tar+gzip",
+					Size:      100,
+					Annotations: map[string]string{
+						"org.opencontainers.image.title": "bundle.tar.gz",
+					},
+				},
+			},
+		}, nil)
+		return img
+	}
+
+	tempDir := t.TempDir()
+	clock := fake.NewFakeClock(time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC))
+	unreachable := oci.NewArtifact("registry.example.com/checks", ftypes.RegistryOptions{},
+		oci.WithImage(newImage(newBrokenLayer(t), "922e50f14ab484f11ae65540c3d2d76009020213f1027d4331d31141575e5414")))
+	mirror := oci.NewArtifact("mirror.local/checks", ftypes.RegistryOptions{},
+		oci.WithImage(newImage(newFakeLayer(t), "01e033e78bd8a59fa4f4577215e7da06c05e1152526094d8d79d2aa06e98cb9d")))
+
+	c, err := policy.NewClient(tempDir, true, nil, policy.WithClock(clock), policy.WithOCIArtifact(unreachable, mirror))
+	require.NoError(t, err)
+	require.NoError(t, c.DownloadBuiltinChecks(context.Background(), ftypes.RegistryOptions{}))
+
+	got, err := c.GetMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &policy.Metadata{
+		Digest:       "sha256:01e033e78bd8a59fa4f4577215e7da06c05e1152526094d8d79d2aa06e98cb9d",
+		DownloadedAt: time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC),
+	}, got)
+}
+
 func TestClient_Clear(t *testing.T) {
 	cacheDir := t.TempDir()
 	err := os.MkdirAll(filepath.Join(cacheDir, "policy"), 0755)
 	require.NoError(t, err)
 
-	c, err := policy.NewClient(cacheDir, true, "")
+	c, err := policy.NewClient(cacheDir, true, nil)
 	require.NoError(t, err)
 	require.NoError(t, c.Clear())
 }
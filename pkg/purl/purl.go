@@ -36,6 +36,14 @@ const (
 	//    - pkg:k8s/eks/k8s.io%2Fkube-proxy@1.26.2-eksbuild.1
 	TypeK8s = "k8s"
 
+	// TypeTerraform is a custom type for Terraform providers in PURL.
+	//  - namespace: The registry host and the provider namespace.
+	//  - name: The provider type.
+	//
+	//  Examples:
+	//    - pkg:terraform/registry.terraform.io/hashicorp/aws@5.31.0
+	TypeTerraform = "terraform"
+
 	NamespaceEKS = "eks"
 	NamespaceAKS = "aks"
 	NamespaceGKE = "gke"
@@ -101,6 +109,8 @@ func New(t ftypes.TargetType, metadata types.Metadata, pkg ftypes.Package) (*Pac
 		namespace, name = parseSwift(name)
 	case packageurl.TypeCocoapods:
 		name, subpath = parseCocoapods(name)
+	case TypeTerraform:
+		namespace, name = parsePkgName(name)
 	case packageurl.TypeOCI:
 		purl, err := parseOCI(metadata)
 		if err != nil {
@@ -163,6 +173,8 @@ func (p *PackageURL) LangType() ftypes.LangType {
 		return ftypes.Pub
 	case packageurl.TypeBitnami:
 		return ftypes.Bitnami
+	case TypeTerraform:
+		return ftypes.TerraformProvider
 	case TypeK8s:
 		switch p.Namespace {
 		case NamespaceEKS:
@@ -467,7 +479,7 @@ func purlType(t ftypes.TargetType) string {
 		return packageurl.TypeSwift
 	case ftypes.Hex:
 		return packageurl.TypeHex
-	case ftypes.Conan:
+	case ftypes.Conan, ftypes.CBinary:
 		return packageurl.TypeConan
 	case ftypes.Pub:
 		return packageurl.TypePub
@@ -486,6 +498,8 @@ func purlType(t ftypes.TargetType) string {
 		return packageurl.TypeOCI
 	case ftypes.Julia:
 		return packageurl.TypeJulia
+	case ftypes.TerraformProvider:
+		return TypeTerraform
 	}
 	return string(t)
 }
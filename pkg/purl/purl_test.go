@@ -440,6 +440,21 @@ func TestNewPackageURL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "terraform provider",
+			typ:  ftypes.TerraformProvider,
+			pkg: ftypes.Package{
+				ID:      "registry.terraform.io/hashicorp/aws@5.31.0",
+				Name:    "registry.terraform.io/hashicorp/aws",
+				Version: "5.31.0",
+			},
+			want: &purl.PackageURL{
+				Type:      purl.TypeTerraform,
+				Namespace: "registry.terraform.io/hashicorp",
+				Name:      "aws",
+				Version:   "5.31.0",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -791,6 +806,16 @@ func TestPackageURL_LangType(t *testing.T) {
 			},
 			want: ftypes.EKS,
 		},
+		{
+			name: "terraform",
+			purl: packageurl.PackageURL{
+				Type:      purl.TypeTerraform,
+				Namespace: "registry.terraform.io/hashicorp",
+				Name:      "aws",
+				Version:   "5.31.0",
+			},
+			want: ftypes.TerraformProvider,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3,6 +3,7 @@ package remote
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net"
@@ -205,6 +206,17 @@ func httpTransport(option types.RegistryOptions) (http.RoundTripper, error) {
 	tr.DialContext = d.DialContext
 	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: option.Insecure}
 
+	if len(option.CACert) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(option.CACert) {
+			return nil, xerrors.New("no valid CA certificate found")
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+
 	if len(option.ClientCert) != 0 && len(option.ClientKey) != 0 {
 		cert, err := tls.X509KeyPair(option.ClientCert, option.ClientKey)
 		if err != nil {
@@ -213,7 +225,10 @@ func httpTransport(option types.RegistryOptions) (http.RoundTripper, error) {
 		tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
 	}
 
-	tripper := transport.NewUserAgent(tr, fmt.Sprintf("trivy/%s", app.Version()))
+	var tripper http.RoundTripper = transport.NewUserAgent(tr, fmt.Sprintf("trivy/%s", app.Version()))
+	if option.BlobDir != "" {
+		tripper = newResumableTransport(tripper, option.BlobDir)
+	}
 	return tripper, nil
 }
 
@@ -3,6 +3,7 @@ package remote
 import (
 	"context"
 	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -348,6 +349,60 @@ func setupAgentTrackingRegistry(t *testing.T) (*httptest.Server, *userAgentsTrac
 	return tr, tracker
 }
 
+func TestGet_CACert(t *testing.T) {
+	tr := setupPrivateRegistry(t)
+	defer tr.Close()
+
+	// Serve the registry over TLS with a self-signed certificate
+	tlsServer := httptest.NewTLSServer(tr.Config.Handler)
+	defer tlsServer.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: tlsServer.Certificate().Raw,
+	})
+
+	n, err := name.ParseReference(fmt.Sprintf("%s/library/alpine:3.10", tlsServer.Listener.Addr().String()))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		caCert  []byte
+		wantErr string
+	}{
+		{
+			name:   "trusted CA certificate",
+			caCert: caCert,
+		},
+		{
+			name:    "untrusted certificate",
+			wantErr: "certificate signed by unknown authority",
+		},
+		{
+			name:    "invalid CA certificate",
+			caCert:  []byte("foo"),
+			wantErr: "no valid CA certificate found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Get(context.Background(), n, types.RegistryOptions{
+				Credentials: []types.Credential{
+					{
+						Username: "test",
+						Password: "testpass",
+					},
+				},
+				CACert: tt.caCert,
+			})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestUserAgents(t *testing.T) {
 	tr, tracker := setupAgentTrackingRegistry(t)
 	defer tr.Close()
@@ -0,0 +1,345 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const (
+	blobDirName = "blobs"
+
+	// maxRedirects is the same as the default of net/http.Client
+	maxRedirects = 10
+)
+
+// e.g. /v2/library/alpine/blobs/sha256:4abcf20661432fb2d719aaf90656f55c287f8ca915dc1c92ec14ff61e67fbaf8
+var blobPathRegexp = regexp.MustCompile(`^/v2/.+/blobs/sha256:([a-f0-9]{64})$`)
+
+// BlobDir returns the directory where partially downloaded blobs are stored.
+func BlobDir(cacheDir string) string {
+	return filepath.Join(cacheDir, blobDirName)
+}
+
+// resumableTransport persists blobs while downloading them so that interrupted downloads can be resumed.
+// When the connection is lost in the middle of the download, it requests the rest of the blob with the Range header.
+// Partially downloaded blobs are kept in dir, keyed by digest, and resumed on the next run as well.
+type resumableTransport struct {
+	inner      http.RoundTripper
+	dir        string
+	maxRetries int
+	backoff    time.Duration
+
+	// Blobs being downloaded in this process
+	inFlight sync.Map
+}
+
+func newResumableTransport(inner http.RoundTripper, dir string) *resumableTransport {
+	return &resumableTransport{
+		inner:      inner,
+		dir:        dir,
+		maxRetries: 3,
+		backoff:    time.Second,
+	}
+}
+
+func (t *resumableTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	matches := blobPathRegexp.FindStringSubmatch(req.URL.Path)
+	// Leave requests other than simple blob downloads as is
+	if req.Method != http.MethodGet || matches == nil || req.Header.Get("Range") != "" {
+		return t.inner.RoundTrip(req)
+	}
+	digest := "sha256:" + matches[1]
+
+	// The same blob might be requested concurrently, e.g. duplicated layers.
+	if _, loaded := t.inFlight.LoadOrStore(digest, struct{}{}); loaded {
+		return t.inner.RoundTrip(req)
+	}
+
+	logger := log.WithPrefix("remote").With(log.String("digest", digest))
+	f, offset, err := t.openPartial(matches[1])
+	if err != nil {
+		t.inFlight.Delete(digest)
+		logger.Debug("Unable to persist the blob, resuming interrupted downloads is disabled", log.Err(err))
+		return t.inner.RoundTrip(req)
+	}
+
+	resp, err := t.fetch(req, offset)
+	if err != nil {
+		t.release(digest, f)
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		logger.Info("Resuming the interrupted download", log.Int64("offset", offset))
+	case http.StatusOK:
+		// The registry doesn't support the Range header or there is nothing to resume.
+		if offset != 0 {
+			logger.Debug("Range requests are not supported, downloading the blob from the beginning")
+			offset = 0
+		}
+		if _, err = f.Seek(0, io.SeekStart); err == nil {
+			err = f.Truncate(0)
+		}
+		if err != nil {
+			_ = resp.Body.Close()
+			t.release(digest, f)
+			return nil, xerrors.Errorf("unable to truncate the partial blob: %w", err)
+		}
+	default:
+		t.release(digest, f)
+		return resp, nil
+	}
+
+	size, err := blobSize(resp, offset)
+	if err != nil {
+		_ = resp.Body.Close()
+		t.release(digest, f)
+		return nil, err
+	}
+
+	body := &resumableBody{
+		transport: t,
+		req:       req,
+		logger:    logger,
+		digest:    digest,
+		file:      f,
+		cached:    io.NewSectionReader(f, 0, offset),
+		body:      resp.Body,
+		written:   offset,
+		hasher:    sha256.New(),
+	}
+
+	// Return the whole blob as if it was downloaded at once
+	resp.StatusCode = http.StatusOK
+	resp.Status = "200 OK"
+	resp.Header.Del("Content-Range")
+	resp.ContentLength = size
+	if size >= 0 {
+		resp.Header.Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// openPartial opens the partially downloaded blob and returns the number of bytes already downloaded.
+func (t *resumableTransport) openPartial(hex string) (*os.File, int64, error) {
+	if err := os.MkdirAll(t.dir, 0o700); err != nil {
+		return nil, 0, xerrors.Errorf("mkdir error: %w", err)
+	}
+	f, err := os.OpenFile(t.partialPath(hex), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, 0, xerrors.Errorf("open error: %w", err)
+	}
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, xerrors.Errorf("seek error: %w", err)
+	}
+	return f, offset, nil
+}
+
+func (t *resumableTransport) partialPath(hex string) string {
+	return filepath.Join(t.dir, "sha256-"+hex+".partial")
+}
+
+func (t *resumableTransport) release(digest string, f *os.File) {
+	_ = f.Close()
+	t.inFlight.Delete(digest)
+}
+
+// fetch sends the request for the blob from offset.
+// Redirects are followed here so that the Range header is sent to the blob storage as well.
+func (t *resumableTransport) fetch(orig *http.Request, offset int64) (*http.Response, error) {
+	req := orig.Clone(orig.Context())
+	for range maxRedirects {
+		if offset > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		}
+		resp, err := t.inner.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+
+		location := resp.Header.Get("Location")
+		if !isRedirect(resp.StatusCode) || location == "" {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+
+		u, err := req.URL.Parse(location)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid redirect location %q: %w", location, err)
+		}
+		next := req.Clone(req.Context())
+		next.URL = u
+		next.Host = ""
+		// Don't leak the credentials for the registry to another host, as net/http.Client does.
+		if u.Host != req.URL.Host {
+			next.Header.Del("Authorization")
+		}
+		req = next
+	}
+	return nil, xerrors.Errorf("stopped after %d redirects", maxRedirects)
+}
+
+func isRedirect(code int) bool {
+	switch code {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// blobSize returns the size of the whole blob, or -1 if unknown.
+func blobSize(resp *http.Response, offset int64) (int64, error) {
+	if resp.StatusCode != http.StatusPartialContent {
+		return resp.ContentLength, nil
+	}
+
+	// e.g. "bytes 100-199/200"
+	contentRange := resp.Header.Get("Content-Range")
+	r, total, ok := strings.Cut(strings.TrimPrefix(contentRange, "bytes "), "/")
+	start, _, _ := strings.Cut(r, "-")
+	if !ok || start != strconv.FormatInt(offset, 10) {
+		return 0, xerrors.Errorf("unexpected Content-Range: %q", contentRange)
+	}
+	if total == "*" {
+		return -1, nil
+	}
+	size, err := strconv.ParseInt(total, 10, 64)
+	if err != nil {
+		return 0, xerrors.Errorf("invalid Content-Range %q: %w", contentRange, err)
+	}
+	return size, nil
+}
+
+// resumableBody reads the persisted part of the blob first, then downloads the rest while persisting it.
+type resumableBody struct {
+	transport *resumableTransport
+	req       *http.Request
+	logger    *log.Logger
+	digest    string
+
+	file    *os.File
+	cached  io.Reader
+	body    io.ReadCloser
+	written int64
+	retries int
+	hasher  hash.Hash
+
+	closeOnce sync.Once
+}
+
+func (b *resumableBody) Read(p []byte) (int, error) {
+	if b.cached != nil {
+		n, err := b.cached.Read(p)
+		b.hasher.Write(p[:n])
+		if errors.Is(err, io.EOF) {
+			b.cached = nil
+			err = nil
+		}
+		return n, err
+	}
+
+	n, err := b.body.Read(p)
+	if n > 0 {
+		if _, werr := b.file.Write(p[:n]); werr != nil {
+			return 0, xerrors.Errorf("unable to persist the blob: %w", werr)
+		}
+		b.hasher.Write(p[:n])
+		b.written += int64(n)
+	}
+
+	switch {
+	case err == nil:
+		return n, nil
+	case errors.Is(err, io.EOF):
+		b.complete()
+		return n, err
+	case b.retries >= b.transport.maxRetries || b.req.Context().Err() != nil:
+		return n, err
+	}
+
+	// The connection was lost in the middle of the download
+	b.retries++
+	b.logger.Warn("Blob download interrupted, retrying...", log.Int("attempt", b.retries),
+		log.Int64("offset", b.written), log.Err(err))
+	if rerr := b.resume(); rerr != nil {
+		b.logger.Debug("Unable to resume the download", log.Err(rerr))
+		return n, err
+	}
+	return n, nil
+}
+
+// resume requests the rest of the blob.
+func (b *resumableBody) resume() error {
+	_ = b.body.Close()
+
+	select {
+	case <-b.req.Context().Done():
+		return b.req.Context().Err()
+	case <-time.After(b.transport.backoff * time.Duration(b.retries)):
+	}
+
+	resp, err := b.transport.fetch(b.req, b.written)
+	if err != nil {
+		return err
+	}
+	b.body = resp.Body
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if _, err = blobSize(resp, b.written); err != nil {
+			return err
+		}
+	case http.StatusOK:
+		// The Range header is ignored, so skip the bytes already read.
+		if _, err = io.CopyN(io.Discard, resp.Body, b.written); err != nil {
+			return xerrors.Errorf("unable to skip the downloaded bytes: %w", err)
+		}
+	default:
+		return xerrors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// complete removes the persisted blob once the whole blob is read.
+// The blob is no longer needed as the analysis results are cached.
+// If the digest doesn't match, the persisted blob is broken and must not be resumed either.
+func (b *resumableBody) complete() {
+	if got := fmt.Sprintf("sha256:%x", b.hasher.Sum(nil)); got != b.digest {
+		b.logger.Debug("Digest mismatch, discarding the downloaded blob", log.String("got", got))
+	}
+	b.close()
+	if err := os.Remove(b.file.Name()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		b.logger.Debug("Unable to remove the downloaded blob", log.Err(err))
+	}
+}
+
+func (b *resumableBody) close() {
+	b.closeOnce.Do(func() {
+		b.transport.release(b.digest, b.file)
+	})
+}
+
+func (b *resumableBody) Close() error {
+	b.close()
+	return b.body.Close()
+}
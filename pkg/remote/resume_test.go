@@ -0,0 +1,202 @@
+package remote
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type blobServer struct {
+	blob []byte
+
+	// Redirect blob requests to the storage like Docker Hub, etc.
+	redirect bool
+	// Ignore the Range header
+	noRange bool
+	// Close the connection after sending the number of bytes in the first request
+	interruptAt int
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (s *blobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.redirect && strings.HasPrefix(r.URL.Path, "/v2/") {
+		http.Redirect(w, r, "/storage/blob", http.StatusTemporaryRedirect)
+		return
+	}
+
+	s.mu.Lock()
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	first := len(s.ranges) == 1
+	s.mu.Unlock()
+
+	if first && s.interruptAt > 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(len(s.blob)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(s.blob[:s.interruptAt])
+		// Drop the connection in the middle of the body
+		w.(http.Flusher).Flush()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+		return
+	}
+
+	if s.noRange {
+		r.Header.Del("Range")
+	}
+	http.ServeContent(w, r, "blob", time.Time{}, bytes.NewReader(s.blob))
+}
+
+func TestResumableTransport(t *testing.T) {
+	blob := bytes.Repeat([]byte("trivy"), 10000)
+	digest := fmt.Sprintf("%x", sha256.Sum256(blob))
+
+	tests := []struct {
+		name       string
+		server     *blobServer
+		partial    []byte
+		path       string
+		wantRanges []string
+		wantBroken bool
+	}{
+		{
+			name:       "no partial blob",
+			server:     &blobServer{},
+			path:       "/v2/library/alpine/blobs/sha256:" + digest,
+			wantRanges: []string{""},
+		},
+		{
+			name:    "resume partial blob",
+			server:  &blobServer{},
+			partial: blob[:1000],
+			path:    "/v2/library/alpine/blobs/sha256:" + digest,
+			wantRanges: []string{
+				"bytes=1000-",
+			},
+		},
+		{
+			name:    "resume partial blob with redirect",
+			server:  &blobServer{redirect: true},
+			partial: blob[:1000],
+			path:    "/v2/library/alpine/blobs/sha256:" + digest,
+			wantRanges: []string{
+				"bytes=1000-",
+			},
+		},
+		{
+			name:    "range not supported",
+			server:  &blobServer{noRange: true},
+			partial: blob[:1000],
+			path:    "/v2/library/alpine/blobs/sha256:" + digest,
+			wantRanges: []string{
+				"bytes=1000-",
+			},
+		},
+		{
+			name:   "interrupted download",
+			server: &blobServer{interruptAt: 3000},
+			path:   "/v2/library/alpine/blobs/sha256:" + digest,
+			wantRanges: []string{
+				"",
+				"bytes=3000-",
+			},
+		},
+		{
+			name:    "broken partial blob",
+			server:  &blobServer{},
+			partial: []byte("broken"),
+			path:    "/v2/library/alpine/blobs/sha256:" + digest,
+			wantRanges: []string{
+				"bytes=6-",
+			},
+			wantBroken: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.server.blob = blob
+			ts := httptest.NewServer(tt.server)
+			defer ts.Close()
+
+			dir := t.TempDir()
+			partialPath := filepath.Join(dir, "sha256-"+digest+".partial")
+			if tt.partial != nil {
+				require.NoError(t, os.WriteFile(partialPath, tt.partial, 0o600))
+			}
+
+			tr := newResumableTransport(http.DefaultTransport, dir)
+			tr.backoff = 0
+			client := &http.Client{Transport: tr}
+
+			resp, err := client.Get(ts.URL + tt.path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, int64(len(blob)), resp.ContentLength)
+
+			got, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			if tt.wantBroken {
+				// The broken bytes are returned and the digest verification fails in go-containerregistry.
+				assert.NotEqual(t, blob, got)
+			} else {
+				assert.Equal(t, blob, got)
+			}
+			assert.Equal(t, tt.wantRanges, tt.server.ranges)
+
+			// The blob is removed once downloaded
+			assert.NoFileExists(t, partialPath)
+		})
+	}
+}
+
+func TestResumableTransport_Persist(t *testing.T) {
+	blob := bytes.Repeat([]byte("trivy"), 10000)
+	digest := fmt.Sprintf("%x", sha256.Sum256(blob))
+
+	// Always drop the connection in the middle of the body
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", fmt.Sprint(len(blob)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(blob[:2000])
+		w.(http.Flusher).Flush()
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	tr := newResumableTransport(http.DefaultTransport, dir)
+	tr.maxRetries = 0
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Get(ts.URL + "/v2/library/alpine/blobs/sha256:" + digest)
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	require.Error(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// The downloaded bytes are kept for the next run
+	got, err := os.ReadFile(filepath.Join(dir, "sha256-"+digest+".partial"))
+	require.NoError(t, err)
+	assert.Equal(t, blob[:2000], got)
+}
@@ -0,0 +1,185 @@
+package report
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	"github.com/aquasecurity/trivy/pkg/purl"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const attributionSeparator = "--------------------------------------------------------------------------------"
+
+// AttributionWriter implements result Writer.
+// It emits a THIRD-PARTY-NOTICES file listing the detected packages with their licenses.
+type AttributionWriter struct {
+	Output io.Writer
+}
+
+type attribution struct {
+	name       string
+	version    string
+	purl       string
+	dir        string // the directory of the package, where its license files are located
+	licenses   []string
+	copyrights []string
+}
+
+// Write writes the attribution file
+func (w AttributionWriter) Write(_ context.Context, report types.Report) error {
+	attributions, licenseTexts := aggregateAttributions(report.Results)
+
+	var sb strings.Builder
+	sb.WriteString("THIRD-PARTY SOFTWARE NOTICES AND INFORMATION\n\n")
+	if report.ArtifactName != "" {
+		fmt.Fprintf(&sb, "%s includes the following third-party software.\n\n", report.ArtifactName)
+	}
+
+	for _, a := range attributions {
+		sb.WriteString(attributionSeparator + "\n")
+		fmt.Fprintf(&sb, "Package: %s\n", a.name)
+		fmt.Fprintf(&sb, "Version: %s\n", a.version)
+		if a.purl != "" {
+			fmt.Fprintf(&sb, "PURL: %s\n", a.purl)
+		}
+		license := strings.Join(a.licenses, ", ")
+		if license == "" {
+			license = "UNKNOWN"
+		}
+		fmt.Fprintf(&sb, "License: %s\n", license)
+		for _, c := range a.copyrights {
+			fmt.Fprintf(&sb, "Copyright: %s\n", c)
+		}
+	}
+	if len(attributions) > 0 {
+		sb.WriteString(attributionSeparator + "\n")
+	}
+
+	if len(licenseTexts) > 0 {
+		sb.WriteString("\n")
+		writeHeading(&sb, "LICENSE TEXTS", "=")
+		for _, name := range slices.Sorted(maps.Keys(licenseTexts)) {
+			writeHeading(&sb, name, "-")
+			sb.WriteString(strings.TrimSpace(licenseTexts[name]) + "\n\n")
+		}
+	}
+
+	if _, err := io.WriteString(w.Output, sb.String()); err != nil {
+		return xerrors.Errorf("failed to write attributions: %w", err)
+	}
+	return nil
+}
+
+// aggregateAttributions returns the deduplicated packages and the texts of their licenses.
+// The copyright statements are collected from the license texts in the package metadata
+// and from the license files found in the directories of the packages.
+func aggregateAttributions(results types.Results) ([]attribution, map[string]string) {
+	attributions := make(map[string]*attribution)
+	licenseTexts := make(map[string]string)
+	for _, result := range results {
+		for _, pkg := range result.Packages {
+			key, purl := attributionKey(result.Type, pkg)
+			a, ok := attributions[key]
+			if !ok {
+				a = &attribution{
+					name:    pkg.Name,
+					version: pkg.Version,
+					purl:    purl,
+				}
+				attributions[key] = a
+			}
+			if a.dir == "" && pkg.FilePath != "" {
+				a.dir = path.Dir(filepath.ToSlash(pkg.FilePath))
+			}
+			for _, license := range pkg.Licenses {
+				// The license text is embedded when the package metadata doesn't have the license name
+				if text, ok := strings.CutPrefix(license, licensing.LicenseTextPrefix); ok {
+					name := licensing.CustomLicensePrefix + ": " + licensing.TrimLicenseText(text)
+					a.licenses = appendUnique(a.licenses, name)
+					a.copyrights = appendUnique(a.copyrights, licensing.Copyrights(text)...)
+					licenseTexts[name] = text
+					continue
+				}
+				a.licenses = appendUnique(a.licenses, license)
+				if _, ok := licenseTexts[license]; ok {
+					continue
+				}
+				if text := licensing.LicenseText(license); text != "" {
+					licenseTexts[license] = text
+				}
+			}
+		}
+	}
+
+	// License files, e.g. node_modules/lodash/LICENSE, belong to the package in the closest parent directory
+	for _, result := range results {
+		if result.Class != types.ClassLicenseFile {
+			continue
+		}
+		for _, license := range result.Licenses {
+			if len(license.Copyrights) == 0 {
+				continue
+			}
+			if a := ownerOf(attributions, filepath.ToSlash(license.FilePath)); a != nil {
+				a.copyrights = appendUnique(a.copyrights, license.Copyrights...)
+			}
+		}
+	}
+
+	sorted := slices.SortedFunc(maps.Values(attributions), func(a, b *attribution) int {
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.version, b.version), cmp.Compare(a.purl, b.purl))
+	})
+	return lo.FromSlicePtr(sorted), licenseTexts
+}
+
+// attributionKey returns the key to deduplicate the package and its PURL.
+// The name and the version are not enough as the same name may be used in different ecosystems.
+func attributionKey(t ftypes.TargetType, pkg ftypes.Package) (string, string) {
+	if pkg.Identifier.PURL != nil {
+		return pkg.Identifier.PURL.String(), pkg.Identifier.PURL.String()
+	}
+	if p, err := purl.New(t, types.Metadata{}, pkg); err == nil && p != nil {
+		return p.String(), p.String()
+	}
+	return string(t) + ":" + pkg.Name + "@" + pkg.Version, ""
+}
+
+// ownerOf returns the package in the closest parent directory of the file.
+// It returns nil if no package or several packages, e.g. JAR files in the same directory, are found there.
+func ownerOf(attributions map[string]*attribution, filePath string) *attribution {
+	for dir := path.Dir(filePath); ; dir = path.Dir(dir) {
+		var owners []*attribution
+		for _, a := range attributions {
+			if a.dir == dir {
+				owners = append(owners, a)
+			}
+		}
+		if len(owners) > 0 {
+			return lo.Ternary(len(owners) == 1, owners[0], nil)
+		}
+		if dir == "." || dir == "/" {
+			return nil
+		}
+	}
+}
+
+func appendUnique(s []string, values ...string) []string {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestAttributionWriter_Write(t *testing.T) {
+	r := types.Report{
+		ArtifactName: "app",
+		Results: types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Packages: []ftypes.Package{
+					{
+						Name:     "lodash",
+						Version:  "4.17.21",
+						Licenses: []string{"MIT"},
+					},
+					{
+						Name:     "custom",
+						Version:  "1.0.0",
+						Licenses: []string{"text://Copyright (c) 2024 Foo Corp.\nAll rights reserved."},
+					},
+					{
+						Name:    "no-license",
+						Version: "0.1.0",
+					},
+					{
+						Name:     "six",
+						Version:  "1.16.0",
+						FilePath: "node_modules/six/package.json",
+						Licenses: []string{"MIT"},
+					},
+				},
+			},
+			{
+				Target: "requirements.txt",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Pip,
+				Packages: []ftypes.Package{
+					{
+						// The same name and version as the npm package
+						Name:     "six",
+						Version:  "1.16.0",
+						Licenses: []string{"MIT"},
+					},
+				},
+			},
+			{
+				Target: "Loose File License(s)",
+				Class:  types.ClassLicenseFile,
+				Licenses: []types.DetectedLicense{
+					{
+						Name:       "MIT",
+						FilePath:   "node_modules/six/LICENSE",
+						Copyrights: []string{"Copyright (c) 2010-2024 Benjamin Peterson"},
+					},
+					{
+						// No package in the directory
+						Name:       "Apache-2.0",
+						FilePath:   "vendor/LICENSE",
+						Copyrights: []string{"Copyright 2024 Unknown"},
+					},
+				},
+			},
+			{
+				Target: "yarn.lock",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Yarn,
+				Packages: []ftypes.Package{
+					{
+						Name:     "lodash",
+						Version:  "4.17.21",
+						Licenses: []string{"MIT"},
+					},
+				},
+			},
+		},
+	}
+
+	output := bytes.NewBuffer(nil)
+	w := report.AttributionWriter{
+		Output: output,
+	}
+	err := w.Write(context.Background(), r)
+	require.NoError(t, err)
+
+	want, err := os.ReadFile("testdata/attribution.txt")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), output.String())
+}
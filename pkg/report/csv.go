@@ -0,0 +1,171 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// csvRow is a finding flattened into the CSV columns
+type csvRow map[string]string
+
+// CSVWriter implements result Writer.
+// It emits a row per finding with the given columns, which can be ingested by GRC tools.
+type CSVWriter struct {
+	Output  io.Writer
+	Columns []string
+}
+
+// DefaultCSVColumns is used when no columns are specified
+var DefaultCSVColumns = []string{
+	"Target",
+	"Type",
+	"FindingType",
+	"ID",
+	"Pkg",
+	"InstalledVersion",
+	"FixedVersion",
+	"Severity",
+	"Status",
+	"Title",
+	"PrimaryURL",
+}
+
+// SupportedCSVColumns lists the columns available in the CSV format
+var SupportedCSVColumns = []string{
+	"Target",
+	"Class",
+	"Type",
+	"FindingType",
+	"ID",
+	"Pkg",
+	"PkgPath",
+	"InstalledVersion",
+	"FixedVersion",
+	"Severity",
+	"Status",
+	"Title",
+	"Description",
+	"PrimaryURL",
+	"StartLine",
+	"EndLine",
+}
+
+// Write writes the findings in CSV format
+func (w CSVWriter) Write(_ context.Context, report types.Report) error {
+	columns := w.Columns
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+	for _, column := range columns {
+		if !slices.Contains(SupportedCSVColumns, column) {
+			return xerrors.Errorf("unknown CSV column: %s", column)
+		}
+	}
+
+	cw := csv.NewWriter(w.Output)
+	if err := cw.Write(columns); err != nil {
+		return xerrors.Errorf("failed to write the CSV header: %w", err)
+	}
+	for _, result := range report.Results {
+		for _, row := range csvRows(result) {
+			record := make([]string, len(columns))
+			for i, column := range columns {
+				record[i] = csvEscape(row[column])
+			}
+			if err := cw.Write(record); err != nil {
+				return xerrors.Errorf("failed to write a CSV row: %w", err)
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return xerrors.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
+
+// csvRows flattens the findings of the result
+func csvRows(result types.Result) []csvRow {
+	var rows []csvRow
+	newRow := func(findingType types.FindingType) csvRow {
+		return csvRow{
+			"Target":      result.Target,
+			"Class":       string(result.Class),
+			"Type":        string(result.Type),
+			"FindingType": string(findingType),
+		}
+	}
+
+	for _, vuln := range result.Vulnerabilities {
+		row := newRow(types.FindingTypeVulnerability)
+		row["ID"] = vuln.VulnerabilityID
+		row["Pkg"] = vuln.PkgName
+		row["PkgPath"] = vuln.PkgPath
+		row["InstalledVersion"] = vuln.InstalledVersion
+		row["FixedVersion"] = vuln.FixedVersion
+		row["Severity"] = vuln.Severity
+		row["Status"] = vuln.Status.String()
+		row["Title"] = vuln.Title
+		row["Description"] = vuln.Description
+		row["PrimaryURL"] = vuln.PrimaryURL
+		rows = append(rows, row)
+	}
+
+	for _, misconf := range result.Misconfigurations {
+		row := newRow(types.FindingTypeMisconfiguration)
+		row["ID"] = misconf.ID
+		row["Severity"] = misconf.Severity
+		row["Status"] = string(misconf.Status)
+		row["Title"] = misconf.Title
+		row["Description"] = misconf.Description
+		row["PrimaryURL"] = misconf.PrimaryURL
+		row["StartLine"] = csvLine(misconf.CauseMetadata.StartLine)
+		row["EndLine"] = csvLine(misconf.CauseMetadata.EndLine)
+		rows = append(rows, row)
+	}
+
+	for _, secret := range result.Secrets {
+		row := newRow(types.FindingTypeSecret)
+		row["ID"] = secret.RuleID
+		row["Severity"] = secret.Severity
+		row["Title"] = secret.Title
+		row["Description"] = secret.Match
+		row["StartLine"] = csvLine(secret.StartLine)
+		row["EndLine"] = csvLine(secret.EndLine)
+		rows = append(rows, row)
+	}
+
+	for _, license := range result.Licenses {
+		row := newRow(types.FindingTypeLicense)
+		row["ID"] = license.Name
+		row["Pkg"] = license.PkgName
+		row["PkgPath"] = license.FilePath
+		row["Severity"] = license.Severity
+		row["PrimaryURL"] = license.Link
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func csvLine(line int) string {
+	if line == 0 {
+		return ""
+	}
+	return strconv.Itoa(line)
+}
+
+// csvEscape prevents values such as "=HYPERLINK(...)" from being evaluated as formulas by spreadsheet applications
+func csvEscape(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
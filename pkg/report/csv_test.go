@@ -0,0 +1,157 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestCSVWriter_Write(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "test-image (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Type:   ftypes.Alpine,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2024-0001",
+					PkgName:          "openssl",
+					InstalledVersion: "3.3.0-r0",
+					FixedVersion:     "3.3.0-r1",
+					Status:           dbTypes.StatusFixed,
+					PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2024-0001",
+					Vulnerability: dbTypes.Vulnerability{
+						Title:    "openssl: buffer overflow, \"crash\"",
+						Severity: "HIGH",
+					},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Type:   ftypes.Dockerfile,
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Title:    "Image user should not be 'root'",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+					CauseMetadata: ftypes.CauseMetadata{
+						StartLine: 3,
+						EndLine:   3,
+					},
+				},
+			},
+		},
+		{
+			Target: "/app/config.env",
+			Class:  types.ClassSecret,
+			Secrets: []types.DetectedSecret{
+				{
+					RuleID:    "aws-access-key-id",
+					Severity:  "CRITICAL",
+					Title:     "AWS Access Key ID",
+					StartLine: 5,
+					EndLine:   5,
+				},
+			},
+		},
+		{
+			Target: "Java",
+			Class:  types.ClassLicense,
+			Licenses: []types.DetectedLicense{
+				{
+					Severity: "HIGH",
+					PkgName:  "org.example:lib",
+					Name:     "GPL-3.0",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		results types.Results
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "default columns",
+			results: results,
+			want: `Target,Type,FindingType,ID,Pkg,InstalledVersion,FixedVersion,Severity,Status,Title,PrimaryURL
+test-image (alpine 3.20.0),alpine,vulnerability,CVE-2024-0001,openssl,3.3.0-r0,3.3.0-r1,HIGH,fixed,"openssl: buffer overflow, ""crash""",https://avd.aquasec.com/nvd/cve-2024-0001
+Dockerfile,dockerfile,misconfiguration,DS002,,,,HIGH,FAIL,Image user should not be 'root',
+/app/config.env,,secret,aws-access-key-id,,,,CRITICAL,,AWS Access Key ID,
+Java,,license,GPL-3.0,org.example:lib,,,HIGH,,,
+`,
+		},
+		{
+			name: "custom columns",
+			columns: []string{
+				"ID",
+				"Severity",
+				"Target",
+				"StartLine",
+			},
+			results: results,
+			want: `ID,Severity,Target,StartLine
+CVE-2024-0001,HIGH,test-image (alpine 3.20.0),
+DS002,HIGH,Dockerfile,3
+aws-access-key-id,CRITICAL,/app/config.env,5
+GPL-3.0,HIGH,Java,
+`,
+		},
+		{
+			name: "formula",
+			columns: []string{
+				"ID",
+				"Title",
+			},
+			results: types.Results{
+				{
+					Target: "Dockerfile",
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{
+							ID:    "DS001",
+							Title: `=HYPERLINK("https://example.com")`,
+						},
+					},
+				},
+			},
+			want: `ID,Title
+DS001,"'=HYPERLINK(""https://example.com"")"
+`,
+		},
+		{
+			name:    "unknown column",
+			columns: []string{"Unknown"},
+			wantErr: "unknown CSV column: Unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			w := report.CSVWriter{
+				Output:  out,
+				Columns: tt.columns,
+			}
+			err := w.Write(context.Background(), types.Report{Results: tt.results})
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
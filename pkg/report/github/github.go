@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/samber/lo"
 	"golang.org/x/xerrors"
 
 	"github.com/aquasecurity/trivy/pkg/clock"
@@ -71,6 +72,9 @@ type DependencySnapshot struct {
 type Writer struct {
 	Output  io.Writer
 	Version string
+
+	// Submit the snapshot to the repository with the dependency submission API
+	Submit bool
 }
 
 func (w Writer) Write(ctx context.Context, report types.Report) error {
@@ -128,18 +132,31 @@ func (w Writer) Write(ctx context.Context, report types.Report) error {
 			}
 		}
 
+		// Dependencies must be referred by PURL
+		packageUrls := make([]string, len(result.Packages))
+		purls := make(map[string]string)
+		for i, pkg := range result.Packages {
+			packageUrl, err := buildPurl(result.Type, report.Metadata, pkg)
+			if err != nil {
+				return xerrors.Errorf("unable to build purl for %s: %w", pkg.Name, err)
+			}
+			packageUrls[i] = packageUrl
+			if pkg.ID != "" {
+				purls[pkg.ID] = packageUrl
+			}
+		}
+
 		resolved := make(map[string]Package)
 
-		for _, pkg := range result.Packages {
-			var err error
+		for i, pkg := range result.Packages {
 			githubPkg := Package{}
 			githubPkg.Scope = RuntimeScope
 			githubPkg.Relationship = getPkgRelationshipType(pkg)
-			githubPkg.Dependencies = pkg.DependsOn // TODO: replace with PURL
-			githubPkg.PackageUrl, err = buildPurl(result.Type, report.Metadata, pkg)
-			if err != nil {
-				return xerrors.Errorf("unable to build purl for %s: %w", pkg.Name, err)
-			}
+			githubPkg.Dependencies = lo.FilterMap(pkg.DependsOn, func(dep string, _ int) (string, bool) {
+				packageUrl, ok := purls[dep]
+				return packageUrl, ok && packageUrl != ""
+			})
+			githubPkg.PackageUrl = packageUrls[i]
 
 			if pkg.FilePath != "" {
 				githubPkg.Metadata = Metadata{"source_location": pkg.FilePath}
@@ -162,6 +179,12 @@ func (w Writer) Write(ctx context.Context, report types.Report) error {
 	if _, err = fmt.Fprint(w.Output, string(output)); err != nil {
 		return xerrors.Errorf("failed to write github dependency snapshots: %w", err)
 	}
+
+	if w.Submit {
+		if err = submit(ctx, snapshot); err != nil {
+			return xerrors.Errorf("failed to submit github dependency snapshots: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -234,6 +236,56 @@ func TestWriter_Write(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dependencies",
+			report: types.Report{
+				SchemaVersion: 2,
+				ArtifactName:  "app",
+				Results: types.Results{
+					{
+						Target: "package-lock.json",
+						Class:  "lang-pkgs",
+						Type:   "npm",
+						Packages: []ftypes.Package{
+							{
+								ID:           "express@4.17.1",
+								Name:         "express",
+								Version:      "4.17.1",
+								Relationship: ftypes.RelationshipDirect,
+								DependsOn:    []string{"qs@6.7.0"},
+							},
+							{
+								ID:           "qs@6.7.0",
+								Name:         "qs",
+								Version:      "6.7.0",
+								Relationship: ftypes.RelationshipIndirect,
+							},
+						},
+					},
+				},
+			},
+			want: map[string]github.Manifest{
+				"package-lock.json": {
+					Name: "npm",
+					File: &github.File{
+						SrcLocation: "package-lock.json",
+					},
+					Resolved: map[string]github.Package{
+						"express": {
+							PackageUrl:   "pkg:npm/express@4.17.1",
+							Relationship: "direct",
+							Dependencies: []string{"pkg:npm/qs@6.7.0"},
+							Scope:        "runtime",
+						},
+						"qs": {
+							PackageUrl:   "pkg:npm/qs@6.7.0",
+							Relationship: "indirect",
+							Scope:        "runtime",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -255,3 +307,103 @@ func TestWriter_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_Write_Submit(t *testing.T) {
+	report := types.Report{
+		SchemaVersion: 2,
+		ArtifactName:  "app",
+		Results: types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  "lang-pkgs",
+				Type:   "npm",
+				Packages: []ftypes.Package{
+					{
+						ID:      "qs@6.7.0",
+						Name:    "qs",
+						Version: "6.7.0",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		statusCode int
+		wantErr    string
+	}{
+		{
+			name: "happy path",
+			env: map[string]string{
+				"GITHUB_TOKEN":      "token",
+				"GITHUB_REPOSITORY": "aquasecurity/trivy",
+				"GITHUB_SHA":        "9fc25f6a1c3d3e6e1e4b1a9e0f5b7b1c2d3e4f5a",
+				"GITHUB_REF":        "refs/heads/main",
+			},
+			statusCode: http.StatusCreated,
+		},
+		{
+			name: "no token",
+			env: map[string]string{
+				"GITHUB_REPOSITORY": "aquasecurity/trivy",
+				"GITHUB_SHA":        "9fc25f6a1c3d3e6e1e4b1a9e0f5b7b1c2d3e4f5a",
+				"GITHUB_REF":        "refs/heads/main",
+			},
+			wantErr: "GITHUB_TOKEN must be set",
+		},
+		{
+			name: "no sha",
+			env: map[string]string{
+				"GITHUB_TOKEN":      "token",
+				"GITHUB_REPOSITORY": "aquasecurity/trivy",
+			},
+			wantErr: "GITHUB_SHA and GITHUB_REF must be set",
+		},
+		{
+			name: "forbidden",
+			env: map[string]string{
+				"GITHUB_TOKEN":      "token",
+				"GITHUB_REPOSITORY": "aquasecurity/trivy",
+				"GITHUB_SHA":        "9fc25f6a1c3d3e6e1e4b1a9e0f5b7b1c2d3e4f5a",
+				"GITHUB_REF":        "refs/heads/main",
+			},
+			statusCode: http.StatusForbidden,
+			wantErr:    "403",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got github.DependencySnapshot
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/repos/aquasecurity/trivy/dependency-graph/snapshots", r.URL.Path)
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"id": 1, "result": "SUCCESS", "message": "Dependency results for the repo have been successfully updated."}`))
+			}))
+			defer ts.Close()
+
+			for _, key := range []string{"GITHUB_TOKEN", "GITHUB_REPOSITORY", "GITHUB_SHA", "GITHUB_REF"} {
+				t.Setenv(key, tt.env[key])
+			}
+			t.Setenv("GITHUB_API_URL", ts.URL)
+
+			w := github.Writer{
+				Output: bytes.NewBuffer(nil),
+				Submit: true,
+			}
+			err := w.Write(context.Background(), report)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "refs/heads/main", got.Ref)
+			assert.Equal(t, "pkg:npm/qs@6.7.0", got.Manifests["package-lock.json"].Resolved["qs"].PackageUrl)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/v62/github"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+)
+
+const defaultAPIURL = "https://api.github.com"
+
+type submission struct {
+	ID      int64  `json:"id"`
+	Result  string `json:"result"`
+	Message string `json:"message"`
+}
+
+// submit submits the snapshot to the repository with the dependency submission API,
+// which populates the dependency graph and Dependabot alerts.
+// It takes the repository and the token from the environment variables set in GitHub Actions.
+// cf. https://docs.github.com/en/rest/dependency-graph/dependency-submission
+func submit(ctx context.Context, snapshot *DependencySnapshot) error {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return xerrors.New("GITHUB_TOKEN must be set to submit the snapshot")
+	}
+	owner, repo, ok := strings.Cut(os.Getenv("GITHUB_REPOSITORY"), "/")
+	if !ok {
+		return xerrors.New(`GITHUB_REPOSITORY must be set in the "owner/repo" format to submit the snapshot`)
+	}
+	if snapshot.Sha == "" || snapshot.Ref == "" {
+		return xerrors.New("GITHUB_SHA and GITHUB_REF must be set to submit the snapshot")
+	}
+
+	client := github.NewClient(nil).WithAuthToken(token)
+	// GITHUB_API_URL is set for GitHub Enterprise Server, e.g. "https://ghes.example.com/api/v3"
+	apiURL := os.Getenv("GITHUB_API_URL")
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(apiURL, "/") + "/")
+	if err != nil {
+		return xerrors.Errorf("invalid GITHUB_API_URL: %w", err)
+	}
+	client.BaseURL = baseURL
+
+	u := "repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo) + "/dependency-graph/snapshots"
+	req, err := client.NewRequest(http.MethodPost, u, snapshot)
+	if err != nil {
+		return xerrors.Errorf("failed to create a request: %w", err)
+	}
+
+	var res submission
+	if _, err = client.Do(ctx, req, &res); err != nil {
+		return xerrors.Errorf("dependency submission API error: %w", err)
+	}
+	log.Info("Dependency snapshot submitted", log.String("repository", owner+"/"+repo),
+		log.Int64("id", res.ID), log.String("result", res.Result))
+	return nil
+}
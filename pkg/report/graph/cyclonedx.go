@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"context"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// writeCycloneDX writes the dependency graph as a CycloneDX BOM.
+// Components are reduced to the identities referred by dependencies, and vulnerabilities annotate them.
+func (w Writer) writeCycloneDX(ctx context.Context, report types.Report) error {
+	bom, err := w.marshaler.MarshalReport(ctx, report)
+	if err != nil {
+		return xerrors.Errorf("CycloneDX marshal error: %w", err)
+	}
+
+	if bom.Metadata.Component != nil {
+		bom.Metadata.Component = identity(*bom.Metadata.Component)
+	}
+	if bom.Components != nil {
+		components := make([]cdx.Component, 0, len(*bom.Components))
+		for _, c := range *bom.Components {
+			components = append(components, *identity(c))
+		}
+		bom.Components = &components
+	}
+
+	encoder := cdx.NewBOMEncoder(w.output, cdx.BOMFileFormatJSON)
+	encoder.SetPretty(true)
+	encoder.SetEscapeHTML(false)
+	if err = encoder.Encode(bom); err != nil {
+		return xerrors.Errorf("failed to encode bom: %w", err)
+	}
+	return nil
+}
+
+func identity(c cdx.Component) *cdx.Component {
+	return &cdx.Component{
+		BOMRef:     c.BOMRef,
+		Type:       c.Type,
+		Group:      c.Group,
+		Name:       c.Name,
+		Version:    c.Version,
+		PackageURL: c.PackageURL,
+	}
+}
@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Fill colors of vulnerable packages by the highest severity
+var dotColors = map[string]string{
+	"CRITICAL": "#ff4d4d",
+	"HIGH":     "#ff9933",
+	"MEDIUM":   "#ffd633",
+	"LOW":      "#99ccff",
+	"UNKNOWN":  "#d9d9d9",
+}
+
+var dotShapes = map[nodeKind]string{
+	kindArtifact: "doubleoctagon",
+	kindTarget:   "folder",
+	kindPackage:  "box",
+}
+
+// writeDOT writes the graph in the DOT language of Graphviz.
+// cf. https://graphviz.org/doc/info/lang.html
+func writeDOT(w io.Writer, g graph) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "digraph %s {\n", dotQuote(g.name))
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	for _, n := range g.nodes {
+		label := dotEscape(n.label)
+		attrs := []string{fmt.Sprintf("shape=%s", dotShapes[n.kind])}
+		if len(n.vulnIDs) > 0 {
+			label += dotEscape(fmt.Sprintf("\nVulnerabilities: %d (%s)", len(n.vulnIDs), n.severity))
+			attrs = append(attrs,
+				"style=filled",
+				fmt.Sprintf("fillcolor=%s", dotQuote(dotColors[n.severity])),
+				fmt.Sprintf("tooltip=%s", dotQuote(strings.Join(n.vulnIDs, ", "))),
+			)
+		}
+		fmt.Fprintf(&sb, "  %s [label=\"%s\", %s];\n", n.id, label, strings.Join(attrs, ", "))
+	}
+
+	for _, e := range g.edges {
+		fmt.Fprintf(&sb, "  %s -> %s;\n", e.from, e.to)
+	}
+	sb.WriteString("}\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return xerrors.Errorf("failed to write the DOT graph: %w", err)
+	}
+	return nil
+}
+
+func dotQuote(s string) string {
+	return `"` + dotEscape(s) + `"`
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
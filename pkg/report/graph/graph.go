@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/sbom/cyclonedx"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+type Format string
+
+const (
+	FormatDOT       Format = "dot"
+	FormatGraphML   Format = "graphml"
+	FormatCycloneDX Format = "cyclonedx"
+)
+
+var SupportedFormats = []Format{
+	FormatDOT,
+	FormatGraphML,
+	FormatCycloneDX,
+}
+
+type nodeKind string
+
+const (
+	kindArtifact nodeKind = "artifact"
+	kindTarget   nodeKind = "target"
+	kindPackage  nodeKind = "package"
+)
+
+type node struct {
+	id       string
+	kind     nodeKind
+	label    string
+	name     string
+	version  string
+	purl     string
+	vulnIDs  []string
+	severity string // the highest severity of the vulnerabilities
+}
+
+type edge struct {
+	from string
+	to   string
+}
+
+// graph is the dependency graph of the artifact.
+// The artifact depends on the targets, e.g. lock files, and the targets depend on the top-level packages.
+type graph struct {
+	name  string
+	nodes []node
+	edges []edge
+}
+
+// Writer implements types.Writer
+// It emits the dependency graph with vulnerability annotations in a standard graph format.
+type Writer struct {
+	output    io.Writer
+	format    Format
+	marshaler cyclonedx.Marshaler
+}
+
+func NewWriter(output io.Writer, format Format, appVersion string) Writer {
+	if format == "" {
+		format = FormatDOT
+	}
+	return Writer{
+		output:    output,
+		format:    format,
+		marshaler: cyclonedx.NewMarshaler(appVersion),
+	}
+}
+
+// Write writes the dependency graph
+func (w Writer) Write(ctx context.Context, report types.Report) error {
+	switch w.format {
+	case FormatDOT:
+		return writeDOT(w.output, newGraph(report))
+	case FormatGraphML:
+		return writeGraphML(w.output, newGraph(report))
+	case FormatCycloneDX:
+		return w.writeCycloneDX(ctx, report)
+	}
+	return xerrors.Errorf("unknown graph format: %s", w.format)
+}
+
+func newGraph(report types.Report) graph {
+	g := graph{
+		name: report.ArtifactName,
+	}
+	root := g.addNode(node{
+		kind:  kindArtifact,
+		label: report.ArtifactName,
+		name:  report.ArtifactName,
+	})
+
+	for _, result := range report.Results {
+		if len(result.Packages) == 0 {
+			continue
+		}
+
+		label := result.Target
+		if result.Type != "" {
+			label = fmt.Sprintf("%s (%s)", result.Target, result.Type)
+		}
+		target := g.addNode(node{
+			kind:  kindTarget,
+			label: label,
+			name:  result.Target,
+		})
+		g.edges = append(g.edges, edge{
+			from: root,
+			to:   target,
+		})
+
+		vulns := make(map[string][]types.DetectedVulnerability)
+		for _, vuln := range result.Vulnerabilities {
+			key := vulnKey(vuln)
+			vulns[key] = append(vulns[key], vuln)
+		}
+
+		// Node IDs of packages in this target, keyed by package ID
+		ids := make(map[string]string)
+		dependents := make(map[string]struct{})
+		for _, pkg := range result.Packages {
+			ids[pkgID(pkg)] = g.addNode(newPackageNode(pkg, vulns[pkgKey(pkg)]))
+			for _, dep := range pkg.DependsOn {
+				dependents[dep] = struct{}{}
+			}
+		}
+
+		for _, pkg := range result.Packages {
+			id := pkgID(pkg)
+			// The target depends on the packages no other package depends on.
+			if _, ok := dependents[id]; !ok {
+				g.edges = append(g.edges, edge{
+					from: target,
+					to:   ids[id],
+				})
+			}
+			for _, dep := range pkg.DependsOn {
+				if to, ok := ids[dep]; ok {
+					g.edges = append(g.edges, edge{
+						from: ids[id],
+						to:   to,
+					})
+				}
+			}
+		}
+	}
+	return g
+}
+
+func (g *graph) addNode(n node) string {
+	n.id = fmt.Sprintf("n%d", len(g.nodes))
+	g.nodes = append(g.nodes, n)
+	return n.id
+}
+
+func newPackageNode(pkg ftypes.Package, vulns []types.DetectedVulnerability) node {
+	n := node{
+		kind:    kindPackage,
+		label:   pkg.Name,
+		name:    pkg.Name,
+		version: pkg.Version,
+	}
+	if pkg.Version != "" {
+		n.label = pkg.Name + "@" + pkg.Version
+	}
+	if pkg.Identifier.PURL != nil {
+		n.purl = pkg.Identifier.PURL.String()
+	}
+
+	var severity dbTypes.Severity
+	for _, vuln := range vulns {
+		if !slices.Contains(n.vulnIDs, vuln.VulnerabilityID) {
+			n.vulnIDs = append(n.vulnIDs, vuln.VulnerabilityID)
+		}
+		if s, err := dbTypes.NewSeverity(vuln.Severity); err == nil && s > severity {
+			severity = s
+		}
+	}
+	if len(n.vulnIDs) > 0 {
+		n.severity = severity.String()
+	}
+	return n
+}
+
+// pkgID returns the ID referred by DependsOn
+func pkgID(pkg ftypes.Package) string {
+	if pkg.ID != "" {
+		return pkg.ID
+	}
+	return pkg.Name + "@" + pkg.Version
+}
+
+// pkgKey returns the key to match the package with its vulnerabilities
+func pkgKey(pkg ftypes.Package) string {
+	if pkg.Identifier.UID != "" {
+		return pkg.Identifier.UID
+	}
+	return pkgID(pkg)
+}
+
+func vulnKey(vuln types.DetectedVulnerability) string {
+	if vuln.PkgIdentifier.UID != "" {
+		return vuln.PkgIdentifier.UID
+	} else if vuln.PkgID != "" {
+		return vuln.PkgID
+	}
+	return vuln.PkgName + "@" + vuln.InstalledVersion
+}
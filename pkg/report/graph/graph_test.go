@@ -0,0 +1,135 @@
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/package-url/packageurl-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/clock"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report/graph"
+	"github.com/aquasecurity/trivy/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/uuid"
+)
+
+func TestWriter_Write(t *testing.T) {
+	report := types.Report{
+		SchemaVersion: 2,
+		ArtifactName:  "app",
+		ArtifactType:  artifact.TypeFilesystem,
+		Results: types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.Npm,
+				Packages: []ftypes.Package{
+					{
+						ID:           "express@4.17.1",
+						Name:         "express",
+						Version:      "4.17.1",
+						Relationship: ftypes.RelationshipDirect,
+						Identifier: ftypes.PkgIdentifier{
+							UID: "express-uid",
+							PURL: &packageurl.PackageURL{
+								Type:    packageurl.TypeNPM,
+								Name:    "express",
+								Version: "4.17.1",
+							},
+						},
+						DependsOn: []string{"qs@6.7.0"},
+					},
+					{
+						ID:           "qs@6.7.0",
+						Name:         "qs",
+						Version:      "6.7.0",
+						Relationship: ftypes.RelationshipIndirect,
+						Identifier: ftypes.PkgIdentifier{
+							UID: "qs-uid",
+							PURL: &packageurl.PackageURL{
+								Type:    packageurl.TypeNPM,
+								Name:    "qs",
+								Version: "6.7.0",
+							},
+						},
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2022-24999",
+						PkgID:            "qs@6.7.0",
+						PkgName:          "qs",
+						InstalledVersion: "6.7.0",
+						FixedVersion:     "6.7.3",
+						PkgIdentifier: ftypes.PkgIdentifier{
+							UID: "qs-uid",
+							PURL: &packageurl.PackageURL{
+								Type:    packageurl.TypeNPM,
+								Name:    "qs",
+								Version: "6.7.0",
+							},
+						},
+						Vulnerability: dbTypes.Vulnerability{
+							Severity: dbTypes.SeverityHigh.String(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		format graph.Format
+		want   string
+	}{
+		{
+			name:   "dot",
+			format: graph.FormatDOT,
+			want:   "testdata/graph.dot",
+		},
+		{
+			name:   "default",
+			format: "",
+			want:   "testdata/graph.dot",
+		},
+		{
+			name:   "graphml",
+			format: graph.FormatGraphML,
+			want:   "testdata/graph.graphml",
+		},
+		{
+			name:   "cyclonedx",
+			format: graph.FormatCycloneDX,
+			want:   "testdata/graph.cdx.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := clock.With(context.Background(), time.Date(2021, 8, 25, 12, 20, 30, 5, time.UTC))
+			uuid.SetFakeUUID(t, "3ff14136-e09f-4df9-80ea-%012d")
+
+			output := bytes.NewBuffer(nil)
+			w := graph.NewWriter(output, tt.format, "dev")
+			err := w.Write(ctx, report)
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), output.String())
+		})
+	}
+}
+
+func TestWriter_Write_UnknownFormat(t *testing.T) {
+	w := graph.NewWriter(bytes.NewBuffer(nil), "gexf", "dev")
+	err := w.Write(context.Background(), types.Report{})
+	require.ErrorContains(t, err, "unknown graph format: gexf")
+}
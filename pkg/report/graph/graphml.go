@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+const graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// cf. http://graphml.graphdrawing.org/specification.html
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+var graphMLKeys = []graphMLKey{
+	{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+	{ID: "label", For: "node", AttrName: "label", AttrType: "string"},
+	{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+	{ID: "version", For: "node", AttrName: "version", AttrType: "string"},
+	{ID: "purl", For: "node", AttrName: "purl", AttrType: "string"},
+	{ID: "vulnerabilities", For: "node", AttrName: "vulnerabilities", AttrType: "string"},
+	{ID: "vulnerabilityCount", For: "node", AttrName: "vulnerabilityCount", AttrType: "int"},
+	{ID: "severity", For: "node", AttrName: "severity", AttrType: "string"},
+}
+
+// writeGraphML writes the graph in GraphML.
+// Vulnerable packages have the vulnerability IDs, the number of vulnerabilities and the highest severity.
+func writeGraphML(w io.Writer, g graph) error {
+	doc := graphMLDocument{
+		Xmlns: graphMLNamespace,
+		Keys:  graphMLKeys,
+		Graph: graphMLGraph{
+			ID:          "dependencies",
+			EdgeDefault: "directed",
+		},
+	}
+
+	for _, n := range g.nodes {
+		data := []graphMLData{
+			{Key: "kind", Value: string(n.kind)},
+			{Key: "label", Value: n.label},
+			{Key: "name", Value: n.name},
+		}
+		if n.version != "" {
+			data = append(data, graphMLData{Key: "version", Value: n.version})
+		}
+		if n.purl != "" {
+			data = append(data, graphMLData{Key: "purl", Value: n.purl})
+		}
+		if len(n.vulnIDs) > 0 {
+			data = append(data,
+				graphMLData{Key: "vulnerabilities", Value: strings.Join(n.vulnIDs, ",")},
+				graphMLData{Key: "vulnerabilityCount", Value: strconv.Itoa(len(n.vulnIDs))},
+				graphMLData{Key: "severity", Value: n.severity},
+			)
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID:   n.id,
+			Data: data,
+		})
+	}
+
+	for _, e := range g.edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: e.from,
+			Target: e.to,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return xerrors.Errorf("failed to write the GraphML header: %w", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return xerrors.Errorf("failed to encode GraphML: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return xerrors.Errorf("failed to write GraphML: %w", err)
+	}
+	return nil
+}
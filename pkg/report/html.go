@@ -0,0 +1,74 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"html/template"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+//go:embed html/report.html
+var htmlTemplate string
+
+var htmlTmpl = template.Must(template.New("html").Parse(htmlTemplate))
+
+// HTMLWriter implements result Writer.
+// It emits a self-contained HTML report with client-side filtering,
+// which embeds the JSON report so that it can be exported from the browser.
+type HTMLWriter struct {
+	Output  io.Writer
+	Version string
+}
+
+type htmlReport struct {
+	ArtifactName string
+	ArtifactType artifact.Type
+	CreatedAt    time.Time
+	Version      string
+	Severities   []string // In descending order
+	Results      types.Results
+	JSON         template.JS
+}
+
+// Write writes the results as an HTML page
+func (w HTMLWriter) Write(ctx context.Context, report types.Report) error {
+	// The same report as "--format json" is embedded
+	var buf bytes.Buffer
+	if err := (JSONWriter{
+		Output:  &buf,
+		Compact: true,
+	}).Write(ctx, report); err != nil {
+		return xerrors.Errorf("failed to write the JSON report: %w", err)
+	}
+
+	severities := slices.Clone(dbTypes.SeverityNames)
+	slices.Reverse(severities)
+
+	data := htmlReport{
+		ArtifactName: report.ArtifactName,
+		ArtifactType: report.ArtifactType,
+		CreatedAt:    report.CreatedAt,
+		Version:      w.Version,
+		Severities:   severities,
+		Results: lo.Filter(report.Results, func(r types.Result, _ int) bool {
+			return len(r.Vulnerabilities) > 0 || len(r.Misconfigurations) > 0 ||
+				len(r.Secrets) > 0 || len(r.Licenses) > 0
+		}),
+		// json.Marshal escapes "<", ">" and "&", so the JSON cannot close the script element
+		JSON: template.JS(bytes.TrimSpace(buf.Bytes())),
+	}
+	if err := htmlTmpl.Execute(w.Output, data); err != nil {
+		return xerrors.Errorf("failed to render the HTML report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestHTMLWriter_Write(t *testing.T) {
+	input := types.Report{
+		SchemaVersion: 2,
+		CreatedAt:     time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		ArtifactName:  "test-image",
+		ArtifactType:  artifact.TypeContainerImage,
+		Results: types.Results{
+			{
+				Target: "test-image (alpine 3.20.0)",
+				Class:  types.ClassOSPkg,
+				Type:   ftypes.Alpine,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "openssl",
+						InstalledVersion: "3.3.0-r0",
+						FixedVersion:     "3.3.0-r1",
+						PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2024-0001",
+						Vulnerability: dbTypes.Vulnerability{
+							Title:       "<script>alert(1)</script>",
+							Description: "</script><script>alert(2)</script>",
+							Severity:    "HIGH",
+						},
+					},
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Type:   ftypes.Dockerfile,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						Type:     "Dockerfile Security Check",
+						ID:       "DS002",
+						Title:    "Image user should not be 'root'",
+						Message:  "Last USER command in Dockerfile should not be 'root'",
+						Severity: "HIGH",
+						Status:   types.MisconfStatusFailure,
+						CauseMetadata: ftypes.CauseMetadata{
+							Code: ftypes.Code{
+								Lines: []ftypes.Line{
+									{
+										Number:  3,
+										Content: "USER root",
+										IsCause: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Target: "go.mod",
+				Class:  types.ClassLangPkg,
+				Type:   ftypes.GoModule,
+			},
+		},
+	}
+
+	out := bytes.NewBuffer(nil)
+	w := report.HTMLWriter{
+		Output:  out,
+		Version: "dev",
+	}
+	require.NoError(t, w.Write(context.Background(), input))
+	got := out.String()
+
+	// Findings
+	assert.Contains(t, got, `<tr class="finding" data-severity="HIGH" data-pkg="openssl">`)
+	assert.Contains(t, got, `<a href="https://avd.aquasec.com/nvd/cve-2024-0001" target="_blank" rel="noopener noreferrer">CVE-2024-0001</a>`)
+	assert.Contains(t, got, `<summary>&lt;script&gt;alert(1)&lt;/script&gt;</summary>`)
+	assert.Contains(t, got, `<span class="cause"><span class="number">3</span>USER root</span>`)
+	assert.NotContains(t, got, "<script>alert")
+
+	// Filters
+	assert.Contains(t, got, `<input type="checkbox" name="severity" value="CRITICAL" checked>`)
+	assert.Contains(t, got, `<option value="Dockerfile">Dockerfile</option>`)
+	// Targets without findings are omitted
+	assert.NotContains(t, got, `data-target="go.mod"`)
+
+	// The embedded JSON is the same as the JSON report
+	matches := regexp.MustCompile(`<script type="application/json" id="report-json">(.*)</script>`).FindStringSubmatch(got)
+	require.Len(t, matches, 2)
+	var embedded types.Report
+	require.NoError(t, json.Unmarshal([]byte(matches[1]), &embedded))
+	assert.Equal(t, "test-image", embedded.ArtifactName)
+	assert.Len(t, embedded.Results, 3)
+	assert.Equal(t, "</script><script>alert(2)</script>", embedded.Results[0].Vulnerabilities[0].Description)
+}
@@ -17,6 +17,12 @@ type JSONWriter struct {
 	Output         io.Writer
 	ListAllPkgs    bool
 	ShowSuppressed bool
+
+	// DedupFindings outputs identical vulnerabilities of multiple targets only once
+	DedupFindings bool
+
+	// Compact writes the report in a single line, so that reports are streamed as NDJSON
+	Compact bool
 }
 
 // Write writes the results in JSON format
@@ -33,11 +39,20 @@ func (jw JSONWriter) Write(_ context.Context, report types.Report) error {
 			report.Results[i].ModifiedFindings = nil
 		}
 	}
+	if jw.DedupFindings {
+		report.DeduplicateVulnerabilities()
+	}
 	report.Results = lo.Filter(report.Results, func(r types.Result, _ int) bool {
 		return r.Target != "" || !r.IsEmpty()
 	})
 
-	output, err := json.MarshalIndent(report, "", "  ")
+	var output []byte
+	var err error
+	if jw.Compact {
+		output, err = json.Marshal(report)
+	} else {
+		output, err = json.MarshalIndent(report, "", "  ")
+	}
 	if err != nil {
 		return xerrors.Errorf("failed to marshal json: %w", err)
 	}
@@ -98,3 +98,95 @@ func TestReportWriter_JSON(t *testing.T) {
 		})
 	}
 }
+
+func TestReportWriter_JSON_Compact(t *testing.T) {
+	output := bytes.NewBuffer(nil)
+	jw := report.JSONWriter{
+		Output:  output,
+		Compact: true,
+	}
+
+	reports := []types.Report{
+		{
+			SchemaVersion: 2,
+			ArtifactName:  "alpine:3.14",
+			Partial:       true,
+		},
+		{
+			SchemaVersion: 2,
+			ArtifactName:  "alpine:3.14",
+		},
+	}
+	for _, r := range reports {
+		require.NoError(t, jw.Write(context.Background(), r))
+	}
+
+	want := `{"SchemaVersion":2,"CreatedAt":"0001-01-01T00:00:00Z","ArtifactName":"alpine:3.14","Metadata":{"ImageConfig":{"architecture":"","created":"0001-01-01T00:00:00Z","os":"","rootfs":{"type":"","diff_ids":null},"config":{}}},"Partial":true}
+{"SchemaVersion":2,"CreatedAt":"0001-01-01T00:00:00Z","ArtifactName":"alpine:3.14","Metadata":{"ImageConfig":{"architecture":"","created":"0001-01-01T00:00:00Z","os":"","rootfs":{"type":"","diff_ids":null},"config":{}}}}
+`
+	assert.Equal(t, want, output.String())
+}
+
+func TestReportWriter_JSON_DedupFindings(t *testing.T) {
+	vuln := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2020-0001",
+		PkgName:          "foo",
+		InstalledVersion: "1.2.3",
+		FixedVersion:     "3.4.5",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: "HIGH",
+		},
+	}
+	withPath := vuln
+	withPath.PkgPath = "app/foo.jar"
+	other := vuln
+	other.VulnerabilityID = "CVE-2020-0002"
+
+	input := types.Report{
+		SchemaVersion: 2,
+		ArtifactName:  "alpine:3.14",
+		Results: types.Results{
+			{
+				Target:          "foo",
+				Vulnerabilities: []types.DetectedVulnerability{vuln, other},
+			},
+			{
+				Target:          "bar",
+				Vulnerabilities: []types.DetectedVulnerability{withPath},
+			},
+		},
+	}
+
+	output := bytes.NewBuffer(nil)
+	jw := report.JSONWriter{
+		Output:        output,
+		DedupFindings: true,
+	}
+	require.NoError(t, jw.Write(context.Background(), input))
+
+	var got types.Report
+	require.NoError(t, json.Unmarshal(output.Bytes(), &got))
+
+	want := types.Report{
+		SchemaVersion: 2,
+		ArtifactName:  "alpine:3.14",
+		Results: types.Results{
+			{Target: "foo"},
+			{Target: "bar"},
+		},
+		DeduplicatedVulnerabilities: []types.DeduplicatedVulnerability{
+			{
+				DetectedVulnerability: vuln,
+				Targets:               []string{"foo", "bar"},
+			},
+			{
+				DetectedVulnerability: other,
+				Targets:               []string{"foo"},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	// The original report must not be modified
+	assert.Len(t, input.Results[0].Vulnerabilities, 2)
+}
@@ -0,0 +1,190 @@
+package report
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// JUnitWriter implements result Writer.
+// It emits a JUnit XML report with a test suite per target and a test case per finding,
+// which can be ingested by CI systems such as Jenkins and GitLab as test reports.
+type JUnitWriter struct {
+	Output io.Writer
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// Write writes the results in the JUnit XML format
+func (w JUnitWriter) Write(_ context.Context, report types.Report) error {
+	suites := junitTestSuites{
+		Name: "trivy",
+	}
+	for _, result := range report.Results {
+		suite := junitSuite(result)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w.Output, xml.Header); err != nil {
+		return xerrors.Errorf("failed to write the XML header: %w", err)
+	}
+	enc := xml.NewEncoder(w.Output)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return xerrors.Errorf("failed to encode JUnit XML: %w", err)
+	}
+	if _, err := io.WriteString(w.Output, "\n"); err != nil {
+		return xerrors.Errorf("failed to write JUnit XML: %w", err)
+	}
+	return nil
+}
+
+// junitSuite converts the findings of the target into test cases
+func junitSuite(result types.Result) junitTestSuite {
+	suite := junitTestSuite{
+		Name: result.Target,
+	}
+	if result.Class != "" {
+		suite.Properties = append(suite.Properties, junitProperty{
+			Name:  "class",
+			Value: string(result.Class),
+		})
+	}
+	if result.Type != "" {
+		suite.Properties = append(suite.Properties, junitProperty{
+			Name:  "type",
+			Value: string(result.Type),
+		})
+	}
+
+	for _, vuln := range result.Vulnerabilities {
+		var fixedVersion string
+		if vuln.FixedVersion != "" {
+			fixedVersion = "Fixed version: " + vuln.FixedVersion
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: fmt.Sprintf("%s@%s", vuln.PkgName, vuln.InstalledVersion),
+			Name:      fmt.Sprintf("[%s] %s", vuln.Severity, vuln.VulnerabilityID),
+			Failure: &junitFailure{
+				Message:  cmp.Or(vuln.Title, vuln.VulnerabilityID),
+				Type:     vuln.Severity,
+				Contents: junitDetails(vuln.Description, fixedVersion, vuln.PrimaryURL),
+			},
+		})
+	}
+
+	for _, misconf := range result.Misconfigurations {
+		tc := junitTestCase{
+			ClassName: misconf.Type,
+			Name:      fmt.Sprintf("[%s] %s", misconf.Severity, misconf.ID),
+		}
+		switch misconf.Status {
+		case types.MisconfStatusFailure:
+			tc.Failure = &junitFailure{
+				Message:  cmp.Or(misconf.Message, misconf.Title),
+				Type:     misconf.Severity,
+				Contents: junitDetails(misconf.Title, misconf.Description, misconf.Resolution, misconf.PrimaryURL),
+			}
+		case types.MisconfStatusException:
+			tc.Skipped = &junitSkipped{
+				Message: "exception",
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	for _, secret := range result.Secrets {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: secret.RuleID,
+			Name:      fmt.Sprintf("[%s] %s", secret.Severity, secret.Title),
+			Failure: &junitFailure{
+				Message:  secret.Title,
+				Type:     secret.Severity,
+				Contents: junitDetails(fmt.Sprintf("%s:%d", result.Target, secret.StartLine), secret.Match),
+			},
+		})
+	}
+
+	for _, license := range result.Licenses {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			ClassName: cmp.Or(license.PkgName, license.FilePath),
+			Name:      fmt.Sprintf("[%s] %s", license.Severity, license.Name),
+			Failure: &junitFailure{
+				Message: fmt.Sprintf("%s license (%s)", license.Name, license.Category),
+				Type:    license.Severity,
+			},
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+	for _, tc := range suite.TestCases {
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+	return suite
+}
+
+// junitDetails joins the non-empty lines of the failure details
+func junitDetails(lines ...string) string {
+	var details []string
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			details = append(details, line)
+		}
+	}
+	return strings.Join(details, "\n")
+}
@@ -0,0 +1,174 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestJUnitWriter_Write(t *testing.T) {
+	tests := []struct {
+		name   string
+		report types.Report
+		want   string
+	}{
+		{
+			name: "happy path",
+			report: types.Report{
+				Results: types.Results{
+					{
+						Target: "test-image (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2024-0001",
+								PkgName:          "openssl",
+								InstalledVersion: "3.3.0-r0",
+								FixedVersion:     "3.3.0-r1",
+								PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2024-0001",
+								Vulnerability: dbTypes.Vulnerability{
+									Title:       "openssl: <overflow> & crash",
+									Description: "A buffer overflow.",
+									Severity:    "HIGH",
+								},
+							},
+						},
+					},
+					{
+						Target: "Dockerfile",
+						Class:  types.ClassConfig,
+						Type:   ftypes.Dockerfile,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								Type:       "Dockerfile Security Check",
+								ID:         "DS002",
+								Title:      "Image user should not be 'root'",
+								Message:    "Specify at least 1 USER command in Dockerfile with non-root user as argument",
+								Resolution: "Add 'USER <non root user name>' line to the Dockerfile",
+								Severity:   "HIGH",
+								Status:     types.MisconfStatusFailure,
+							},
+							{
+								Type:     "Dockerfile Security Check",
+								ID:       "DS001",
+								Severity: "MEDIUM",
+								Status:   types.MisconfStatusPassed,
+							},
+							{
+								Type:     "Dockerfile Security Check",
+								ID:       "DS005",
+								Severity: "LOW",
+								Status:   types.MisconfStatusException,
+							},
+						},
+					},
+					{
+						Target: "/app/config.env",
+						Class:  types.ClassSecret,
+						Secrets: []types.DetectedSecret{
+							{
+								RuleID:    "aws-access-key-id",
+								Severity:  "CRITICAL",
+								Title:     "AWS Access Key ID",
+								StartLine: 3,
+								Match:     "AWS_ACCESS_KEY_ID=********************",
+							},
+						},
+					},
+					{
+						Target: "Java",
+						Class:  types.ClassLicense,
+						Licenses: []types.DetectedLicense{
+							{
+								Severity: "HIGH",
+								Category: "restricted",
+								PkgName:  "org.example:lib",
+								Name:     "GPL-3.0",
+							},
+						},
+					},
+					{
+						Target: "go.mod",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.GoModule,
+					},
+				},
+			},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="trivy" tests="6" failures="4" errors="0" skipped="1">
+  <testsuite name="test-image (alpine 3.20.0)" tests="1" failures="1" errors="0" skipped="0">
+    <properties>
+      <property name="class" value="os-pkgs"></property>
+      <property name="type" value="alpine"></property>
+    </properties>
+    <testcase classname="openssl@3.3.0-r0" name="[HIGH] CVE-2024-0001">
+      <failure message="openssl: &lt;overflow&gt; &amp; crash" type="HIGH">A buffer overflow.&#xA;Fixed version: 3.3.0-r1&#xA;https://avd.aquasec.com/nvd/cve-2024-0001</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="Dockerfile" tests="3" failures="1" errors="0" skipped="1">
+    <properties>
+      <property name="class" value="config"></property>
+      <property name="type" value="dockerfile"></property>
+    </properties>
+    <testcase classname="Dockerfile Security Check" name="[HIGH] DS002">
+      <failure message="Specify at least 1 USER command in Dockerfile with non-root user as argument" type="HIGH">Image user should not be &#39;root&#39;&#xA;Add &#39;USER &lt;non root user name&gt;&#39; line to the Dockerfile</failure>
+    </testcase>
+    <testcase classname="Dockerfile Security Check" name="[MEDIUM] DS001"></testcase>
+    <testcase classname="Dockerfile Security Check" name="[LOW] DS005">
+      <skipped message="exception"></skipped>
+    </testcase>
+  </testsuite>
+  <testsuite name="/app/config.env" tests="1" failures="1" errors="0" skipped="0">
+    <properties>
+      <property name="class" value="secret"></property>
+    </properties>
+    <testcase classname="aws-access-key-id" name="[CRITICAL] AWS Access Key ID">
+      <failure message="AWS Access Key ID" type="CRITICAL">/app/config.env:3&#xA;AWS_ACCESS_KEY_ID=********************</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="Java" tests="1" failures="1" errors="0" skipped="0">
+    <properties>
+      <property name="class" value="license"></property>
+    </properties>
+    <testcase classname="org.example:lib" name="[HIGH] GPL-3.0">
+      <failure message="GPL-3.0 license (restricted)" type="HIGH"></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="go.mod" tests="0" failures="0" errors="0" skipped="0">
+    <properties>
+      <property name="class" value="lang-pkgs"></property>
+      <property name="type" value="gomod"></property>
+    </properties>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name:   "no results",
+			report: types.Report{},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="trivy" tests="0" failures="0" errors="0" skipped="0"></testsuites>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			w := report.JUnitWriter{
+				Output: out,
+			}
+			err := w.Write(context.Background(), tt.report)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
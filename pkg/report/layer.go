@@ -0,0 +1,177 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/fanal/image"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// LayerWriter implements result Writer.
+// It groups the findings by the image layer that introduced them,
+// so that it is clear whether the fix belongs in the Dockerfile or the base image.
+type LayerWriter struct {
+	Output io.Writer
+}
+
+type layerGroup struct {
+	number    int // 1-based index of the layer, 0 if unknown
+	diffID    string
+	createdBy string
+	base      bool
+	findings  []layerFinding
+}
+
+type layerFinding struct {
+	severity string
+	id       string
+	location string // package, resource or file
+	target   string
+}
+
+// Write writes the findings grouped by layer
+func (w LayerWriter) Write(_ context.Context, report types.Report) error {
+	groups := groupByLayer(report)
+
+	var sb strings.Builder
+	writeHeading(&sb, "Layers", "=")
+	if len(report.Metadata.DiffIDs) == 0 {
+		sb.WriteString("Layer information is available only for container images.\n\n")
+	}
+
+	var found bool
+	for _, g := range groups {
+		if len(g.findings) == 0 {
+			continue
+		}
+		found = true
+
+		title := "Unknown layer"
+		if g.number > 0 {
+			title = fmt.Sprintf("Layer %d: %s", g.number, g.diffID)
+		}
+		writeHeading(&sb, title, "-")
+		if g.number > 0 {
+			origin := "Dockerfile"
+			if g.base {
+				origin = "base image"
+			}
+			fmt.Fprintf(&sb, "Origin: %s\n", origin)
+			if g.createdBy != "" {
+				fmt.Fprintf(&sb, "Created by: %s\n", strings.TrimSpace(g.createdBy))
+			}
+		}
+		fmt.Fprintf(&sb, "Findings: %d (%s)\n\n", len(g.findings), severitySummary(g.findings))
+
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		for _, f := range g.findings {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", f.severity, f.id, f.location, f.target)
+		}
+		_ = tw.Flush()
+		sb.WriteString("\n")
+	}
+	if !found {
+		sb.WriteString("No findings detected.\n")
+	}
+
+	if _, err := io.WriteString(w.Output, sb.String()); err != nil {
+		return xerrors.Errorf("failed to write layers: %w", err)
+	}
+	return nil
+}
+
+// groupByLayer returns the layers of the image in order, followed by the findings without a known layer
+func groupByLayer(report types.Report) []*layerGroup {
+	var groups []*layerGroup
+	byDiffID := make(map[string]*layerGroup)
+	for i, diffID := range report.Metadata.DiffIDs {
+		g := &layerGroup{
+			number: i + 1,
+			diffID: diffID,
+		}
+		groups = append(groups, g)
+		byDiffID[diffID] = g
+	}
+
+	// Diff IDs don't include empty layers, so the history needs to be mapped
+	history := report.Metadata.ImageConfig.History
+	baseImageIndex := image.GuessBaseImageIndex(history)
+	var diffIDIndex int
+	for i, h := range history {
+		if h.EmptyLayer {
+			continue
+		}
+		if diffIDIndex >= len(groups) {
+			break
+		}
+		groups[diffIDIndex].createdBy = h.CreatedBy
+		groups[diffIDIndex].base = i <= baseImageIndex
+		diffIDIndex++
+	}
+
+	unknown := &layerGroup{}
+	add := func(layer ftypes.Layer, f layerFinding) {
+		g, ok := byDiffID[layer.DiffID]
+		if !ok {
+			g = unknown
+		}
+		g.findings = append(g.findings, f)
+	}
+
+	for _, result := range report.Results {
+		for _, vuln := range result.Vulnerabilities {
+			location := vuln.PkgName + "@" + vuln.InstalledVersion
+			if vuln.FixedVersion != "" {
+				location += fmt.Sprintf(" (fixed: %s)", vuln.FixedVersion)
+			}
+			add(vuln.Layer, layerFinding{
+				severity: vuln.Severity,
+				id:       vuln.VulnerabilityID,
+				location: location,
+				target:   result.Target,
+			})
+		}
+		for _, misconf := range result.Misconfigurations {
+			if misconf.Status != types.MisconfStatusFailure {
+				continue
+			}
+			add(misconf.Layer, layerFinding{
+				severity: misconf.Severity,
+				id:       misconf.ID,
+				location: misconf.CauseMetadata.Resource,
+				target:   result.Target,
+			})
+		}
+		for _, secret := range result.Secrets {
+			add(secret.Layer, layerFinding{
+				severity: secret.Severity,
+				id:       secret.RuleID,
+				location: fmt.Sprintf("line %d", secret.StartLine),
+				target:   result.Target,
+			})
+		}
+	}
+	return append(groups, unknown)
+}
+
+func severitySummary(findings []layerFinding) string {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.severity]++
+	}
+	var summaries []string
+	for _, s := range dbTypes.SeverityNames {
+		if counts[s] > 0 {
+			summaries = append(summaries, fmt.Sprintf("%s: %d", s, counts[s]))
+		}
+	}
+	return strings.Join(summaries, ", ")
+}
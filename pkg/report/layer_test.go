@@ -0,0 +1,144 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestLayerWriter_Write(t *testing.T) {
+	tests := []struct {
+		name   string
+		report types.Report
+		want   string
+	}{
+		{
+			name: "happy path",
+			report: types.Report{
+				ArtifactName: "test-image",
+				Metadata: types.Metadata{
+					DiffIDs: []string{
+						"sha256:base",
+						"sha256:app",
+					},
+					ImageConfig: v1.ConfigFile{
+						History: []v1.History{
+							{
+								CreatedBy: "/bin/sh -c #(nop) ADD file:abc in / ",
+							},
+							{
+								CreatedBy:  `/bin/sh -c #(nop)  CMD ["/bin/sh"]`,
+								EmptyLayer: true,
+							},
+							{
+								CreatedBy: "RUN /bin/sh -c apk add --no-cache curl # buildkit",
+							},
+						},
+					},
+				},
+				Results: types.Results{
+					{
+						Target: "test-image (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2024-0001",
+								PkgName:          "openssl",
+								InstalledVersion: "3.3.0-r0",
+								FixedVersion:     "3.3.0-r1",
+								Layer: ftypes.Layer{
+									DiffID: "sha256:base",
+								},
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: "CRITICAL",
+								},
+							},
+							{
+								VulnerabilityID:  "CVE-2024-0002",
+								PkgName:          "curl",
+								InstalledVersion: "8.8.0-r0",
+								Layer: ftypes.Layer{
+									DiffID: "sha256:app",
+								},
+								Vulnerability: dbTypes.Vulnerability{
+									Severity: "HIGH",
+								},
+							},
+						},
+					},
+					{
+						Target: "/app/config.env",
+						Class:  types.ClassSecret,
+						Secrets: []types.DetectedSecret{
+							{
+								RuleID:    "github-pat",
+								Severity:  "CRITICAL",
+								StartLine: 3,
+							},
+						},
+					},
+				},
+			},
+			want: `Layers
+======
+
+Layer 1: sha256:base
+--------------------
+
+Origin: base image
+Created by: /bin/sh -c #(nop) ADD file:abc in /
+Findings: 1 (CRITICAL: 1)
+
+  CRITICAL  CVE-2024-0001  openssl@3.3.0-r0 (fixed: 3.3.0-r1)  test-image (alpine 3.20.0)
+
+Layer 2: sha256:app
+-------------------
+
+Origin: Dockerfile
+Created by: RUN /bin/sh -c apk add --no-cache curl # buildkit
+Findings: 1 (HIGH: 1)
+
+  HIGH  CVE-2024-0002  curl@8.8.0-r0  test-image (alpine 3.20.0)
+
+Unknown layer
+-------------
+
+Findings: 1 (CRITICAL: 1)
+
+  CRITICAL  github-pat  line 3  /app/config.env
+
+`,
+		},
+		{
+			name: "not an image",
+			report: types.Report{
+				ArtifactName: ".",
+			},
+			want: `Layers
+======
+
+Layer information is available only for container images.
+
+No findings detected.
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := report.LayerWriter{Output: buf}
+			err := w.Write(context.Background(), tt.report)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
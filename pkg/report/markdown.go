@@ -0,0 +1,240 @@
+package report
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// DefaultMarkdownMaxSize is a little smaller than the maximum size of GitHub comments (65,536 characters)
+const DefaultMarkdownMaxSize = 60000
+
+// MarkdownWriter implements result Writer.
+// It emits a summary table and collapsible details per target to be posted as a pull request comment.
+// The details are truncated so that the output doesn't exceed MaxSize.
+type MarkdownWriter struct {
+	Output  io.Writer
+	MaxSize int
+}
+
+type markdownTarget struct {
+	name     string
+	typ      string
+	counts   map[string]int
+	sections []markdownSection
+}
+
+type markdownSection struct {
+	title  string
+	header []string
+	rows   [][]string
+}
+
+// Write writes the results in Markdown
+func (w MarkdownWriter) Write(_ context.Context, report types.Report) error {
+	maxSize := w.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMarkdownMaxSize
+	}
+
+	severities := slices.Clone(dbTypes.SeverityNames)
+	slices.Reverse(severities)
+
+	var targets []markdownTarget
+	for _, result := range report.Results {
+		if t := newMarkdownTarget(result); len(t.sections) > 0 {
+			targets = append(targets, t)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Trivy scan results")
+	if report.ArtifactName != "" {
+		fmt.Fprintf(&sb, " for `%s`", strings.ReplaceAll(report.ArtifactName, "`", "'"))
+	}
+	sb.WriteString("\n\n")
+
+	if len(targets) == 0 {
+		sb.WriteString("No issues found.\n")
+		return w.write(sb.String())
+	}
+
+	// Summary
+	writeMarkdownRow(&sb, append([]string{"Target", "Type"}, severities...))
+	writeMarkdownRow(&sb, slices.Repeat([]string{"---"}, len(severities)+2))
+	totals := make(map[string]int)
+	for _, t := range targets {
+		row := []string{t.name, t.typ}
+		for _, s := range severities {
+			row = append(row, fmt.Sprint(t.counts[s]))
+			totals[s] += t.counts[s]
+		}
+		writeMarkdownRow(&sb, row)
+	}
+	row := []string{"**Total**", ""}
+	for _, s := range severities {
+		row = append(row, fmt.Sprintf("**%d**", totals[s]))
+	}
+	writeMarkdownRow(&sb, row)
+
+	// Details, as long as they fit
+	const truncationNoticeSize = 200
+	budget := maxSize - sb.Len() - truncationNoticeSize
+	var omitted int
+	for _, t := range targets {
+		var details strings.Builder
+		fmt.Fprintf(&details, "\n<details><summary><b>%s</b>", html.EscapeString(t.name))
+		if t.typ != "" {
+			fmt.Fprintf(&details, " (%s)", html.EscapeString(t.typ))
+		}
+		details.WriteString("</summary>\n")
+		// The heading of the details must fit at least
+		if omitted > 0 || details.Len()+len("\n</details>\n") > budget {
+			omitted += t.total()
+			continue
+		}
+		for _, section := range t.sections {
+			var table strings.Builder
+			fmt.Fprintf(&table, "\n#### %s\n\n", section.title)
+			writeMarkdownRow(&table, section.header)
+			writeMarkdownRow(&table, slices.Repeat([]string{"---"}, len(section.header)))
+			if details.Len()+table.Len()+len("\n</details>\n") > budget {
+				omitted += len(section.rows)
+				continue
+			}
+			details.WriteString(table.String())
+			for i, r := range section.rows {
+				var line strings.Builder
+				writeMarkdownRow(&line, r)
+				if details.Len()+line.Len()+len("\n</details>\n") > budget {
+					omitted += len(section.rows) - i
+					break
+				}
+				details.WriteString(line.String())
+			}
+		}
+		details.WriteString("\n</details>\n")
+		budget -= details.Len()
+		sb.WriteString(details.String())
+	}
+
+	if omitted > 0 {
+		fmt.Fprintf(&sb, "\n> [!WARNING]\n> The details are truncated due to the size limit. %d findings are omitted.\n", omitted)
+	}
+	return w.write(sb.String())
+}
+
+func (w MarkdownWriter) write(s string) error {
+	if _, err := io.WriteString(w.Output, s); err != nil {
+		return xerrors.Errorf("failed to write markdown: %w", err)
+	}
+	return nil
+}
+
+func newMarkdownTarget(result types.Result) markdownTarget {
+	t := markdownTarget{
+		name:   result.Target,
+		typ:    string(result.Type),
+		counts: make(map[string]int),
+	}
+
+	if len(result.Vulnerabilities) > 0 {
+		section := markdownSection{
+			title:  "Vulnerabilities",
+			header: []string{"Severity", "ID", "Package", "Installed Version", "Fixed Version", "Title"},
+		}
+		for _, vuln := range result.Vulnerabilities {
+			t.counts[vuln.Severity]++
+			id := vuln.VulnerabilityID
+			if vuln.PrimaryURL != "" {
+				id = fmt.Sprintf("[%s](%s)", vuln.VulnerabilityID, vuln.PrimaryURL)
+			}
+			section.rows = append(section.rows, []string{vuln.Severity, id, vuln.PkgName, vuln.InstalledVersion,
+				vuln.FixedVersion, vuln.Title})
+		}
+		t.sections = append(t.sections, section)
+	}
+
+	misconfs := slices.DeleteFunc(slices.Clone(result.Misconfigurations), func(m types.DetectedMisconfiguration) bool {
+		return m.Status != types.MisconfStatusFailure
+	})
+	if len(misconfs) > 0 {
+		section := markdownSection{
+			title:  "Misconfigurations",
+			header: []string{"Severity", "ID", "Title", "Message", "Lines"},
+		}
+		for _, misconf := range misconfs {
+			t.counts[misconf.Severity]++
+			id := misconf.ID
+			if misconf.PrimaryURL != "" {
+				id = fmt.Sprintf("[%s](%s)", misconf.ID, misconf.PrimaryURL)
+			}
+			var lines string
+			if misconf.CauseMetadata.StartLine > 0 {
+				lines = fmt.Sprintf("%d-%d", misconf.CauseMetadata.StartLine, misconf.CauseMetadata.EndLine)
+			}
+			section.rows = append(section.rows, []string{misconf.Severity, id, misconf.Title, misconf.Message, lines})
+		}
+		t.sections = append(t.sections, section)
+	}
+
+	if len(result.Secrets) > 0 {
+		section := markdownSection{
+			title:  "Secrets",
+			header: []string{"Severity", "Rule ID", "Title", "Line"},
+		}
+		for _, secret := range result.Secrets {
+			t.counts[secret.Severity]++
+			section.rows = append(section.rows, []string{secret.Severity, secret.RuleID, secret.Title,
+				fmt.Sprint(secret.StartLine)})
+		}
+		t.sections = append(t.sections, section)
+	}
+
+	if len(result.Licenses) > 0 {
+		section := markdownSection{
+			title:  "Licenses",
+			header: []string{"Severity", "License", "Package", "Category"},
+		}
+		for _, license := range result.Licenses {
+			t.counts[license.Severity]++
+			section.rows = append(section.rows, []string{license.Severity, license.Name,
+				cmp.Or(license.PkgName, license.FilePath), string(license.Category)})
+		}
+		t.sections = append(t.sections, section)
+	}
+	return t
+}
+
+func (t markdownTarget) total() int {
+	var total int
+	for _, section := range t.sections {
+		total += len(section.rows)
+	}
+	return total
+}
+
+func writeMarkdownRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("|")
+	for _, cell := range cells {
+		sb.WriteString(" " + escapeMarkdownCell(cell) + " |")
+	}
+	sb.WriteString("\n")
+}
+
+// escapeMarkdownCell keeps the cell in a single table cell
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return html.EscapeString(s)
+}
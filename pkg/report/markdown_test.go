@@ -0,0 +1,170 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestMarkdownWriter_Write(t *testing.T) {
+	tests := []struct {
+		name   string
+		report types.Report
+		want   string
+	}{
+		{
+			name: "happy path",
+			report: types.Report{
+				ArtifactName: "test-image",
+				Results: types.Results{
+					{
+						Target: "test-image (alpine 3.20.0)",
+						Class:  types.ClassOSPkg,
+						Type:   ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{
+							{
+								VulnerabilityID:  "CVE-2024-0001",
+								PkgName:          "openssl",
+								InstalledVersion: "3.3.0-r0",
+								FixedVersion:     "3.3.0-r1",
+								PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2024-0001",
+								Vulnerability: dbTypes.Vulnerability{
+									Title:    "openssl: <overflow> | crash",
+									Severity: "HIGH",
+								},
+							},
+						},
+					},
+					{
+						Target: "Dockerfile",
+						Class:  types.ClassConfig,
+						Type:   ftypes.Dockerfile,
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								ID:       "DS002",
+								Title:    "Image user should not be root",
+								Message:  "Specify at least 1 USER command",
+								Severity: "CRITICAL",
+								Status:   types.MisconfStatusFailure,
+								CauseMetadata: ftypes.CauseMetadata{
+									StartLine: 1,
+									EndLine:   2,
+								},
+							},
+							{
+								ID:       "DS001",
+								Severity: "MEDIUM",
+								Status:   types.MisconfStatusPassed,
+							},
+						},
+					},
+					{
+						Target: "go.mod",
+						Class:  types.ClassLangPkg,
+						Type:   ftypes.GoModule,
+					},
+				},
+			},
+			want: "## Trivy scan results for `test-image`\n" + `
+| Target | Type | CRITICAL | HIGH | MEDIUM | LOW | UNKNOWN |
+| --- | --- | --- | --- | --- | --- | --- |
+| test-image (alpine 3.20.0) | alpine | 0 | 1 | 0 | 0 | 0 |
+| Dockerfile | dockerfile | 1 | 0 | 0 | 0 | 0 |
+| **Total** |  | **1** | **1** | **0** | **0** | **0** |
+
+<details><summary><b>test-image (alpine 3.20.0)</b> (alpine)</summary>
+
+#### Vulnerabilities
+
+| Severity | ID | Package | Installed Version | Fixed Version | Title |
+| --- | --- | --- | --- | --- | --- |
+| HIGH | [CVE-2024-0001](https://avd.aquasec.com/nvd/cve-2024-0001) | openssl | 3.3.0-r0 | 3.3.0-r1 | openssl: &lt;overflow&gt; \| crash |
+
+</details>
+
+<details><summary><b>Dockerfile</b> (dockerfile)</summary>
+
+#### Misconfigurations
+
+| Severity | ID | Title | Message | Lines |
+| --- | --- | --- | --- | --- |
+| CRITICAL | DS002 | Image user should not be root | Specify at least 1 USER command | 1-2 |
+
+</details>
+`,
+		},
+		{
+			name: "no findings",
+			report: types.Report{
+				ArtifactName: "test-image",
+				Results: types.Results{
+					{
+						Target: "go.mod",
+					},
+				},
+			},
+			want: "## Trivy scan results for `test-image`\n\nNo issues found.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			w := report.MarkdownWriter{
+				Output: out,
+			}
+			require.NoError(t, w.Write(context.Background(), tt.report))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestMarkdownWriter_Write_Truncate(t *testing.T) {
+	var vulns []types.DetectedVulnerability
+	for i := range 100 {
+		vulns = append(vulns, types.DetectedVulnerability{
+			VulnerabilityID:  fmt.Sprintf("CVE-2024-%04d", i),
+			PkgName:          "openssl",
+			InstalledVersion: "3.3.0-r0",
+			Vulnerability: dbTypes.Vulnerability{
+				Severity: "HIGH",
+			},
+		})
+	}
+	input := types.Report{
+		Results: types.Results{
+			{
+				Target:          "test-image (alpine 3.20.0)",
+				Vulnerabilities: vulns,
+			},
+			{
+				Target:          "test-image (debian 12)",
+				Vulnerabilities: vulns[:10],
+			},
+		},
+	}
+
+	out := bytes.NewBuffer(nil)
+	w := report.MarkdownWriter{
+		Output:  out,
+		MaxSize: 2000,
+	}
+	require.NoError(t, w.Write(context.Background(), input))
+	got := out.String()
+
+	assert.LessOrEqual(t, len(got), 2000)
+	// The summary is always written
+	assert.Contains(t, got, "| test-image (debian 12) |  | 0 | 10 | 0 | 0 | 0 |")
+	assert.Contains(t, got, "| CVE-2024-0000 |")
+	assert.NotContains(t, got, "| CVE-2024-0099 |")
+	assert.NotContains(t, got, "<b>test-image (debian 12)</b>")
+	assert.Contains(t, got, "> The details are truncated due to the size limit.")
+}
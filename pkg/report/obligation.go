@@ -0,0 +1,130 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// ObligationWriter implements result Writer.
+// It lists the obligations triggered by each detected license and aggregates the required NOTICE content.
+type ObligationWriter struct {
+	Output io.Writer
+}
+
+type licenseObligation struct {
+	name        string
+	category    ftypes.LicenseCategory
+	link        string
+	text        string
+	components  []string
+	obligations []licensing.Obligation
+}
+
+// requiresNotice returns true if the license requires the distribution to credit the authors
+func (l licenseObligation) requiresNotice() bool {
+	return slices.Contains(l.obligations, licensing.ObligationAttribution) ||
+		slices.Contains(l.obligations, licensing.ObligationIncludeNotice)
+}
+
+// Write writes the license obligations report
+func (w ObligationWriter) Write(_ context.Context, report types.Report) error {
+	licenses := aggregateLicenses(report.Results)
+
+	var sb strings.Builder
+	writeHeading(&sb, "License Obligations", "=")
+	if len(licenses) == 0 {
+		sb.WriteString("No licenses detected. Enable the license scanner with '--scanners license'.\n")
+	}
+	for _, l := range licenses {
+		writeHeading(&sb, fmt.Sprintf("%s (%s)", l.name, l.category), "-")
+		fmt.Fprintf(&sb, "Components: %s\n", strings.Join(l.components, ", "))
+		switch {
+		case len(l.obligations) > 0:
+			sb.WriteString("Obligations:\n")
+			for _, o := range l.obligations {
+				fmt.Fprintf(&sb, "  - %s: %s\n", o, o.Description())
+			}
+		case l.category == ftypes.CategoryUnknown:
+			sb.WriteString("Obligations: unknown license, review manually\n")
+		default:
+			sb.WriteString("Obligations: none\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	notices := lo.Filter(licenses, func(l licenseObligation, _ int) bool {
+		return l.requiresNotice()
+	})
+	if len(notices) > 0 {
+		writeHeading(&sb, "NOTICE", "=")
+		sb.WriteString("This product includes third-party software distributed under the following licenses.\n\n")
+		for _, l := range notices {
+			title := l.name
+			if l.link != "" {
+				title += " <" + l.link + ">"
+			}
+			sb.WriteString(title + "\n")
+			for _, c := range l.components {
+				fmt.Fprintf(&sb, "  - %s\n", c)
+			}
+			if l.text != "" {
+				fmt.Fprintf(&sb, "\n%s\n", strings.TrimSpace(l.text))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if _, err := io.WriteString(w.Output, sb.String()); err != nil {
+		return xerrors.Errorf("failed to write license obligations: %w", err)
+	}
+	return nil
+}
+
+// aggregateLicenses groups the detected licenses across all results by license name
+func aggregateLicenses(results types.Results) []licenseObligation {
+	licenses := make(map[string]*licenseObligation)
+	for _, result := range results {
+		for _, l := range result.Licenses {
+			lic, ok := licenses[l.Name]
+			if !ok {
+				lic = &licenseObligation{
+					name:        l.Name,
+					category:    l.Category,
+					link:        l.Link,
+					text:        l.Text,
+					obligations: licensing.Obligations(l.Name, l.Category),
+				}
+				licenses[l.Name] = lic
+			}
+			component := l.PkgName
+			if component == "" {
+				component = l.FilePath
+			}
+			lic.components = append(lic.components, component)
+		}
+	}
+
+	aggregated := lo.Map(lo.Values(licenses), func(l *licenseObligation, _ int) licenseObligation {
+		slices.Sort(l.components)
+		l.components = slices.Compact(l.components)
+		return *l
+	})
+	slices.SortFunc(aggregated, func(a, b licenseObligation) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return aggregated
+}
+
+func writeHeading(sb *strings.Builder, title, underline string) {
+	fmt.Fprintf(sb, "%s\n%s\n\n", title, strings.Repeat(underline, len(title)))
+}
@@ -0,0 +1,129 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestObligationWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		results types.Results
+		want    string
+	}{
+		{
+			name: "happy path",
+			results: types.Results{
+				{
+					Target: "OS Packages",
+					Class:  types.ClassLicense,
+					Licenses: []types.DetectedLicense{
+						{
+							Severity: "HIGH",
+							Category: ftypes.CategoryRestricted,
+							PkgName:  "bash",
+							Name:     "GPL-3.0-or-later",
+						},
+						{
+							Severity: "LOW",
+							Category: ftypes.CategoryNotice,
+							PkgName:  "musl",
+							Name:     "MIT",
+							Link:     "https://spdx.org/licenses/MIT.html",
+						},
+					},
+				},
+				{
+					Target: "Loose File License(s)",
+					Class:  types.ClassLicenseFile,
+					Licenses: []types.DetectedLicense{
+						{
+							Severity: "LOW",
+							Category: ftypes.CategoryNotice,
+							FilePath: "LICENSE",
+							Name:     "MIT",
+							Link:     "https://spdx.org/licenses/MIT.html",
+						},
+						{
+							Severity: "UNKNOWN",
+							Category: ftypes.CategoryUnknown,
+							FilePath: "vendor/LICENSE",
+							Name:     "Custom-License",
+						},
+					},
+				},
+			},
+			want: `License Obligations
+===================
+
+Custom-License (unknown)
+------------------------
+
+Components: vendor/LICENSE
+Obligations: unknown license, review manually
+
+GPL-3.0-or-later (restricted)
+-----------------------------
+
+Components: bash
+Obligations:
+  - attribution: Retain copyright notices and give credit to the authors
+  - include-license: Include a copy of the license text with the distribution
+  - state-changes: Mark modified files with a notice describing the changes
+  - source-disclosure: Make the source code available when distributing the software
+  - same-license: Release modifications or derivative works under the same license
+  - patent-grant: Contributors grant a patent license for their contributions
+  - patent-retaliation: Patent rights terminate if you initiate patent litigation over the software
+
+MIT (notice)
+------------
+
+Components: LICENSE, musl
+Obligations:
+  - attribution: Retain copyright notices and give credit to the authors
+  - include-license: Include a copy of the license text with the distribution
+
+NOTICE
+======
+
+This product includes third-party software distributed under the following licenses.
+
+GPL-3.0-or-later
+  - bash
+
+MIT <https://spdx.org/licenses/MIT.html>
+  - LICENSE
+  - musl
+
+`,
+		},
+		{
+			name:    "no licenses",
+			results: types.Results{},
+			want: `License Obligations
+===================
+
+No licenses detected. Enable the license scanner with '--scanners license'.
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := bytes.NewBuffer(nil)
+			w := report.ObligationWriter{
+				Output: output,
+			}
+			err := w.Write(context.Background(), types.Report{Results: tt.results})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, output.String())
+		})
+	}
+}
@@ -69,7 +69,10 @@ type sarifData struct {
 	locationMessage  string
 	message          string
 	cvssScore        string
+	riskScore        float64
 	locations        []location
+	tags             []string
+	fingerprint      string
 }
 
 type location struct {
@@ -91,11 +94,11 @@ func (sw *SarifWriter) addSarifRule(data *sarifData) {
 			Level: toSarifErrorLevel(data.severity),
 		}).
 		WithProperties(sarif.Properties{
-			"tags": []string{
+			"tags": append([]string{
 				data.title,
 				"security",
 				data.severity,
-			},
+			}, data.tags...),
 			"precision":         "very-high",
 			"security-severity": data.cvssScore,
 		})
@@ -112,6 +115,18 @@ func (sw *SarifWriter) addSarifResult(data *sarifData) {
 		WithMessage(sarif.NewTextMessage(data.message)).
 		WithLevel(toSarifErrorLevel(data.severity)).
 		WithLocations(toSarifLocations(data.locations, data.artifactLocation.String(), data.locationMessage))
+	if data.fingerprint != "" {
+		result.WithPartialFingerPrints(map[string]any{
+			types.SarifFingerprintKey: data.fingerprint,
+		})
+	}
+	if data.riskScore != 0 {
+		result.AttachPropertyBag(&sarif.PropertyBag{
+			Properties: sarif.Properties{
+				"risk-score": data.riskScore,
+			},
+		})
+	}
 	sw.run.AddResult(result)
 }
 
@@ -165,11 +180,13 @@ func (sw *SarifWriter) Write(ctx context.Context, report types.Report) error {
 				vulnerabilityId:  vuln.VulnerabilityID,
 				severity:         vuln.Severity,
 				cvssScore:        getCVSSScore(vuln),
+				riskScore:        vuln.RiskScore,
 				url:              toUri(vuln.PrimaryURL),
 				resourceClass:    res.Class,
 				artifactLocation: toUri(path),
 				locationMessage:  fmt.Sprintf("%v: %v@%v", path, vuln.PkgName, vuln.InstalledVersion),
 				locations:        sw.getLocations(vuln.PkgName, vuln.InstalledVersion, path, res.Packages),
+				fingerprint:      types.Fingerprint(res, vuln),
 				resultIndex:      getRuleIndex(vuln.VulnerabilityID, ruleIndexes),
 				shortDescription: html.EscapeString(vuln.Title),
 				fullDescription:  html.EscapeString(fullDescription),
@@ -188,6 +205,8 @@ func (sw *SarifWriter) Write(ctx context.Context, report types.Report) error {
 				vulnerabilityId:  misconf.ID,
 				severity:         misconf.Severity,
 				cvssScore:        severityToScore(misconf.Severity),
+				riskScore:        misconf.RiskScore,
+				tags:             misconf.Tags,
 				url:              toUri(misconf.PrimaryURL),
 				resourceClass:    res.Class,
 				artifactLocation: toUri(locationURI),
@@ -199,6 +218,7 @@ func (sw *SarifWriter) Write(ctx context.Context, report types.Report) error {
 					},
 				},
 				resultIndex:      getRuleIndex(misconf.ID, ruleIndexes),
+				fingerprint:      types.Fingerprint(res, misconf),
 				shortDescription: html.EscapeString(misconf.Title),
 				fullDescription:  html.EscapeString(misconf.Description),
 				helpText: fmt.Sprintf("Misconfiguration %v\nType: %s\nSeverity: %v\nCheck: %v\nMessage: %v\nLink: [%v](%v)\n%s",
@@ -226,6 +246,7 @@ func (sw *SarifWriter) Write(ctx context.Context, report types.Report) error {
 					},
 				},
 				resultIndex:      getRuleIndex(secret.RuleID, ruleIndexes),
+				fingerprint:      types.Fingerprint(res, secret),
 				shortDescription: html.EscapeString(secret.Title),
 				fullDescription:  html.EscapeString(secret.Match),
 				helpText: fmt.Sprintf("Secret %v\nSeverity: %v\nMatch: %s",
@@ -248,6 +269,7 @@ func (sw *SarifWriter) Write(ctx context.Context, report types.Report) error {
 				resourceClass:    res.Class,
 				artifactLocation: toUri(target),
 				resultIndex:      getRuleIndex(id, ruleIndexes),
+				fingerprint:      types.Fingerprint(res, license),
 				shortDescription: desc,
 				fullDescription:  desc,
 				helpText: fmt.Sprintf("License %s\nClassification: %s\nPkgName: %s\nPath: %s",
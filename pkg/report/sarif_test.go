@@ -135,6 +135,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr[uint](0),
 								Level:     lo.ToPtr("error"),
 								Message:   sarif.Message{Text: lo.ToPtr("Package: foo\nInstalled Version: 1.2.3\nVulnerability CVE-2020-0001\nSeverity: HIGH\nFixed Version: 3.4.5\nLink: [CVE-2020-0001](https://avd.aquasec.com/nvd/cve-2020-0001)")},
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:046fa7c80db66813ee6cb9c0affbfde9e89b1202e446900f37e8338ec6522a27",
+								},
 								Locations: []*sarif.Location{
 									{
 										Message: &sarif.Message{Text: lo.ToPtr("library/test 1: foo@1.2.3")},
@@ -284,6 +287,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr[uint](0),
 								Level:     lo.ToPtr("error"),
 								Message:   sarif.Message{Text: lo.ToPtr("Artifact: library/test 1\nType: \nVulnerability KSV001\nSeverity: HIGH\nMessage: Message\nLink: [KSV001](https://avd.aquasec.com/appshield/ksv001)")},
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:985ae02a09153a5bfa3b84871fb5b852ad8a923ae270d793056cfc6c8ea03c23",
+								},
 								Locations: []*sarif.Location{
 									{
 										Message: &sarif.Message{Text: lo.ToPtr("library/test 1")},
@@ -307,6 +313,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr[uint](1),
 								Level:     lo.ToPtr("error"),
 								Message:   sarif.Message{Text: lo.ToPtr("Artifact: library/test 1\nType: \nVulnerability KSV002\nSeverity: CRITICAL\nMessage: Message\nLink: [KSV002](https://avd.aquasec.com/appshield/ksv002)")},
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:0e668de90019995dbfc780c17b049f2b2d4aa3826c322385a35b6a9cde162c40",
+								},
 								Locations: []*sarif.Location{
 									{
 										Message: &sarif.Message{Text: lo.ToPtr("library/test 1")},
@@ -401,6 +410,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr[uint](0),
 								Level:     lo.ToPtr("error"),
 								Message:   sarif.Message{Text: lo.ToPtr("Artifact: library/test 1\nType: \nSecret AWS Secret Access Key\nSeverity: CRITICAL\nMatch: 'AWS_secret_KEY'=\"****************************************\"")},
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:94a05ef848caea7573503b84be8c777c494b01d1fcc20c1f72d6e7cd18ca4d14",
+								},
 								Locations: []*sarif.Location{
 									{
 										Message: &sarif.Message{Text: lo.ToPtr("library/test 1")},
@@ -490,6 +502,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr(uint(0)),
 								Level:     lo.ToPtr("error"),
 								Message:   sarif.Message{Text: lo.ToPtr("Artifact: OS Packages\nLicense GPL-3.0\nPkgName: restricted\n Classification: alpine-base\n Path: ")},
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:67efe1842eceb84b1b40d15db5f3f7f2018f0b35ac5c0a60146fc958e33dffdb",
+								},
 								Locations: []*sarif.Location{
 									{
 										Message: sarif.NewTextMessage(""),
@@ -672,6 +687,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr(uint(0)),
 								Level:     lo.ToPtr("error"),
 								Message:   *sarif.NewTextMessage("Artifact: github.com/terraform-google-modules/terraform-google-kubernetes-engine?ref=c4809044b52b91505bfba5ef9f25526aa0361788/modules/workload-identity/main.tf\nType: terraform\nVulnerability AVD-GCP-0007\nSeverity: HIGH\nMessage: Service account is granted a privileged role.\nLink: [AVD-GCP-0007](https://avd.aquasec.com/misconfig/avd-gcp-0007)"),
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:34326d4d14c41938b77fb8aaf36252cb1ec18615d68fe4fdba46cb4d3b6df3c6",
+								},
 								Locations: []*sarif.Location{
 									{
 										PhysicalLocation: sarif.NewPhysicalLocation().
@@ -698,6 +716,9 @@ func TestReportWriter_Sarif(t *testing.T) {
 								RuleIndex: lo.ToPtr(uint(0)),
 								Level:     lo.ToPtr("error"),
 								Message:   *sarif.NewTextMessage("Artifact: github.com/terraform-aws-modules/terraform-aws-s3-bucket/tree/v4.2.0/main.tf\nType: terraform\nVulnerability AVD-GCP-0007\nSeverity: HIGH\nMessage: Service account is granted a privileged role.\nLink: [AVD-GCP-0007](https://avd.aquasec.com/misconfig/avd-gcp-0007)"),
+								PartialFingerprints: map[string]any{
+									types.SarifFingerprintKey: "sha256:345ac5aaf43d95ae527773e7cbf5b5147e8be1183f2ad67b897187992370c5ea",
+								},
 								Locations: []*sarif.Location{
 									{
 										PhysicalLocation: sarif.NewPhysicalLocation().
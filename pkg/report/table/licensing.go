@@ -2,11 +2,14 @@ package table
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"sync"
 
 	"github.com/fatih/color"
+	"github.com/samber/lo"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
@@ -55,6 +58,7 @@ func (r pkgLicenseRenderer) Render() string {
 	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
 
 	r.tableWriter.Render()
+	renderIncompatibilities(r.w, r.result.Licenses, r.isTerminal)
 
 	return r.w.String()
 }
@@ -142,6 +146,7 @@ func (r fileLicenseRenderer) Render() string {
 	r.printf("Total: %d (%s)\n\n", total, strings.Join(summaries, ", "))
 
 	r.tableWriter.Render()
+	renderIncompatibilities(r.w, r.result.Licenses, r.isTerminal)
 
 	return r.w.String()
 }
@@ -206,6 +211,29 @@ func (r *fileLicenseRenderer) printf(format string, args ...any) {
 	_ = tml.Fprintf(r.w, format, args...)
 }
 
+// renderIncompatibilities warns about licenses incompatible with the project license
+func renderIncompatibilities(w io.Writer, licenses []types.DetectedLicense, isTerminal bool) {
+	var warnings []string
+	for _, l := range licenses {
+		if l.Incompatibility == "" {
+			continue
+		}
+		subject := lo.Ternary(l.PkgName != "", l.PkgName, l.FilePath)
+		warnings = append(warnings, fmt.Sprintf("WARNING: %s: %s", subject, l.Incompatibility))
+	}
+	if len(warnings) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	for _, warning := range warnings {
+		if isTerminal {
+			warning = color.New(color.FgYellow).Sprint(warning)
+		}
+		_, _ = fmt.Fprintln(w, warning)
+	}
+}
+
 func colorizeLicenseCategory(category ftypes.LicenseCategory) string {
 	switch category {
 	case ftypes.CategoryForbidden:
@@ -104,7 +104,11 @@ func (r *secretRenderer) renderSummary(secret types.DetectedSecret) {
 	}
 
 	// heading
-	r.printf("%s (%s)\r\n", secret.Category, secret.RuleID)
+	r.printf("%s (%s)", secret.Category, secret.RuleID)
+	if secret.Status != "" {
+		r.printf(" [%s]", secret.Status)
+	}
+	r.printf("\r\n")
 	r.printDoubleDivider()
 
 	// description
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -132,10 +133,21 @@ func (r *vulnerabilityRenderer) setHeaders(tw *table.Table) {
 		"Fixed Version",
 		"Title",
 	}
+	if r.scored() {
+		header = slices.Insert(header, 3, "Risk Score")
+	}
 	tw.SetHeaders(header...)
 }
 
+// scored returns true when a risk score is calculated by the scoring policy
+func (r *vulnerabilityRenderer) scored() bool {
+	return slices.ContainsFunc(r.result.Vulnerabilities, func(v types.DetectedVulnerability) bool {
+		return v.RiskScore != 0
+	})
+}
+
 func (r *vulnerabilityRenderer) setVulnerabilityRows(tw *table.Table, vulns []types.DetectedVulnerability) {
+	scored := r.scored()
 	for _, v := range vulns {
 		lib := v.PkgName
 		if v.PkgPath != "" {
@@ -188,6 +200,9 @@ func (r *vulnerabilityRenderer) setVulnerabilityRows(tw *table.Table, vulns []ty
 				strings.TrimSpace(title),
 			}
 		}
+		if scored {
+			row = slices.Insert(row, 3, strconv.FormatFloat(v.RiskScore, 'f', -1, 64))
+		}
 
 		tw.AddRow(row...)
 	}
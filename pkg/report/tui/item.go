@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// item is a finding shown as a row
+type item struct {
+	findingType types.FindingType
+	result      int // index of the result the finding belongs to
+	index       int // index of the finding in the result
+	target      string
+	severity    string
+	id          string
+	pkg         string // package, resource or file the finding is in
+	title       string
+	details     []string
+}
+
+func newItems(report types.Report) []item {
+	var items []item
+	for i, result := range report.Results {
+		for j, vuln := range result.Vulnerabilities {
+			items = append(items, item{
+				findingType: types.FindingTypeVulnerability,
+				result:      i,
+				index:       j,
+				target:      result.Target,
+				severity:    vuln.Severity,
+				id:          vuln.VulnerabilityID,
+				pkg:         vuln.PkgName + "@" + vuln.InstalledVersion,
+				title:       vuln.Title,
+				details:     vulnDetails(vuln),
+			})
+		}
+		for j, misconf := range result.Misconfigurations {
+			items = append(items, item{
+				findingType: types.FindingTypeMisconfiguration,
+				result:      i,
+				index:       j,
+				target:      result.Target,
+				severity:    misconf.Severity,
+				id:          misconf.ID,
+				pkg:         misconf.CauseMetadata.Resource,
+				title:       misconf.Title,
+				details:     misconfDetails(misconf),
+			})
+		}
+		for j, secret := range result.Secrets {
+			items = append(items, item{
+				findingType: types.FindingTypeSecret,
+				result:      i,
+				index:       j,
+				target:      result.Target,
+				severity:    secret.Severity,
+				id:          secret.RuleID,
+				pkg:         fmt.Sprintf("%s:%d", result.Target, secret.StartLine),
+				title:       secret.Title,
+				details:     secretDetails(secret),
+			})
+		}
+		for j, license := range result.Licenses {
+			items = append(items, item{
+				findingType: types.FindingTypeLicense,
+				result:      i,
+				index:       j,
+				target:      result.Target,
+				severity:    license.Severity,
+				id:          license.Name,
+				pkg:         license.PkgName + license.FilePath, // Either is filled
+				title:       string(license.Category),
+				details:     licenseDetails(license),
+			})
+		}
+	}
+	return items
+}
+
+// match returns true if the query is contained in the ID, the package or the title, ignoring case
+func (i item) match(query string) bool {
+	query = strings.ToLower(query)
+	for _, s := range []string{i.id, i.pkg, i.title} {
+		if strings.Contains(strings.ToLower(s), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func vulnDetails(vuln types.DetectedVulnerability) []string {
+	lines := []string{
+		"ID:                " + vuln.VulnerabilityID,
+		"Severity:          " + vuln.Severity,
+		"Package:           " + vuln.PkgName,
+		"Installed Version: " + vuln.InstalledVersion,
+		"Fixed Version:     " + vuln.FixedVersion,
+		"Status:            " + vuln.Status.String(),
+	}
+	if vuln.PkgPath != "" {
+		lines = append(lines, "Path:              "+vuln.PkgPath)
+	}
+	if vuln.PrimaryURL != "" {
+		lines = append(lines, "URL:               "+vuln.PrimaryURL)
+	}
+	lines = append(lines, "", vuln.Title, "")
+	return append(lines, strings.Split(vuln.Description, "\n")...)
+}
+
+func misconfDetails(misconf types.DetectedMisconfiguration) []string {
+	lines := []string{
+		"ID:         " + misconf.ID,
+		"Severity:   " + misconf.Severity,
+		"Status:     " + string(misconf.Status),
+		"Resource:   " + misconf.CauseMetadata.Resource,
+		"Resolution: " + misconf.Resolution,
+	}
+	if misconf.PrimaryURL != "" {
+		lines = append(lines, "URL:        "+misconf.PrimaryURL)
+	}
+	lines = append(lines, "", misconf.Title, misconf.Message)
+	return append(lines, codeLines(misconf.CauseMetadata.Code)...)
+}
+
+func secretDetails(secret types.DetectedSecret) []string {
+	lines := []string{
+		"Rule ID:  " + secret.RuleID,
+		"Category: " + string(secret.Category),
+		"Severity: " + secret.Severity,
+		fmt.Sprintf("Lines:    %d-%d", secret.StartLine, secret.EndLine),
+		"",
+		secret.Title,
+	}
+	return append(lines, codeLines(secret.Code)...)
+}
+
+func licenseDetails(license types.DetectedLicense) []string {
+	return []string{
+		"License:    " + license.Name,
+		"Category:   " + string(license.Category),
+		"Severity:   " + license.Severity,
+		"Package:    " + license.PkgName,
+		"File:       " + license.FilePath,
+		fmt.Sprintf("Confidence: %.2f", license.Confidence),
+	}
+}
+
+// codeLines returns the code context with line numbers, marking the causes
+func codeLines(code ftypes.Code) []string {
+	if len(code.Lines) == 0 {
+		return nil
+	}
+	lines := []string{""}
+	for _, l := range code.Lines {
+		if l.Truncated {
+			lines = append(lines, "      ...")
+			continue
+		}
+		mark := " "
+		if l.IsCause {
+			mark = ">"
+		}
+		lines = append(lines, fmt.Sprintf("%s %4d %s", mark, l.Number, l.Content))
+	}
+	return lines
+}
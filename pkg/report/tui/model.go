@@ -0,0 +1,262 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Keys returned by parseKeys in addition to printable characters
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEsc       = "esc"
+	keyBackspace = "backspace"
+	keyCtrlC     = "ctrl+c"
+)
+
+// severityKeys toggles the severities in descending order
+var severityKeys = map[string]dbTypes.Severity{
+	"1": dbTypes.SeverityCritical,
+	"2": dbTypes.SeverityHigh,
+	"3": dbTypes.SeverityMedium,
+	"4": dbTypes.SeverityLow,
+	"5": dbTypes.SeverityUnknown,
+}
+
+// model holds the state of the browser.
+// It doesn't depend on the terminal so that the key handling and the rendering can be tested.
+type model struct {
+	report     types.Report
+	items      []item
+	targets    []string
+	exportPath string
+
+	// Filters
+	severities map[string]bool // severities to show
+	target     int             // 0 means all targets, otherwise the index of targets + 1
+	query      string
+
+	// View state
+	filtered  []int        // indices of items shown
+	cursor    int          // index of filtered
+	offset    int          // index of filtered shown at the top
+	marked    map[int]bool // indices of items to export
+	detail    bool
+	scroll    int // line offset of the detail view
+	searching bool
+	message   string
+	height    int // rows available for the list
+	quit      bool
+}
+
+func newModel(r types.Report, exportPath string) *model {
+	m := &model{
+		report:     r,
+		items:      newItems(r),
+		exportPath: exportPath,
+		severities: make(map[string]bool),
+		marked:     make(map[int]bool),
+		height:     20,
+	}
+	for _, s := range dbTypes.SeverityNames {
+		m.severities[s] = true
+	}
+	for _, i := range m.items {
+		if !slices.Contains(m.targets, i.target) {
+			m.targets = append(m.targets, i.target)
+		}
+	}
+	m.applyFilters()
+	return m
+}
+
+// applyFilters updates the items shown, keeping the cursor within them
+func (m *model) applyFilters() {
+	m.filtered = m.filtered[:0]
+	for idx, i := range m.items {
+		if !m.severities[i.severity] {
+			continue
+		} else if m.target > 0 && i.target != m.targets[m.target-1] {
+			continue
+		} else if m.query != "" && !i.match(m.query) {
+			continue
+		}
+		m.filtered = append(m.filtered, idx)
+	}
+	m.cursor = max(0, min(m.cursor, len(m.filtered)-1))
+	m.offset = min(m.offset, m.cursor)
+}
+
+func (m *model) selected() (item, bool) {
+	if len(m.filtered) == 0 {
+		return item{}, false
+	}
+	return m.items[m.filtered[m.cursor]], true
+}
+
+// handleKey updates the state with the key
+func (m *model) handleKey(key string) {
+	m.message = ""
+	if key == keyCtrlC {
+		m.quit = true
+		return
+	}
+
+	if m.searching {
+		m.handleSearchKey(key)
+		return
+	}
+
+	if m.detail {
+		switch key {
+		case keyEnter, keyEsc, "q":
+			m.detail = false
+		case keyUp, "k":
+			m.scroll = max(0, m.scroll-1)
+		case keyDown, "j":
+			m.scroll++
+		}
+		return
+	}
+
+	switch key {
+	case "q":
+		m.quit = true
+	case keyUp, "k":
+		m.move(-1)
+	case keyDown, "j":
+		m.move(1)
+	case keyPageUp:
+		m.move(-m.height)
+	case keyPageDown:
+		m.move(m.height)
+	case keyHome, "g":
+		m.move(-len(m.filtered))
+	case keyEnd, "G":
+		m.move(len(m.filtered))
+	case keyEnter:
+		if _, ok := m.selected(); ok {
+			m.detail = true
+			m.scroll = 0
+		}
+	case "/":
+		m.searching = true
+	case keyEsc:
+		m.query = ""
+		m.applyFilters()
+	case "t":
+		m.target = (m.target + 1) % (len(m.targets) + 1)
+		m.applyFilters()
+	case " ":
+		if len(m.filtered) > 0 {
+			idx := m.filtered[m.cursor]
+			m.marked[idx] = !m.marked[idx]
+			m.move(1)
+		}
+	case "a":
+		// Mark all the items shown, or unmark them if all are marked
+		all := lo.EveryBy(m.filtered, func(idx int) bool { return m.marked[idx] })
+		for _, idx := range m.filtered {
+			m.marked[idx] = !all
+		}
+	case "e":
+		if err := m.export(); err != nil {
+			m.message = fmt.Sprintf("Export failed: %s", err)
+		}
+	default:
+		if s, ok := severityKeys[key]; ok {
+			m.severities[s.String()] = !m.severities[s.String()]
+			m.applyFilters()
+		}
+	}
+}
+
+func (m *model) handleSearchKey(key string) {
+	switch key {
+	case keyEnter:
+		m.searching = false
+	case keyEsc:
+		m.searching = false
+		m.query = ""
+	case keyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+		}
+	default:
+		if len([]rune(key)) != 1 {
+			return // Ignore other special keys
+		}
+		m.query += key
+	}
+	m.applyFilters()
+}
+
+func (m *model) move(n int) {
+	m.cursor = max(0, min(m.cursor+n, len(m.filtered)-1))
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+// export writes the marked findings, or all the findings shown if nothing is marked, as a JSON report
+func (m *model) export() error {
+	var indices []int
+	for idx := range m.items {
+		if m.marked[idx] {
+			indices = append(indices, idx)
+		}
+	}
+	if len(indices) == 0 {
+		indices = m.filtered
+	}
+
+	r := m.report
+	r.Results = make(types.Results, len(m.report.Results))
+	for i, result := range m.report.Results {
+		r.Results[i] = types.Result{
+			Target: result.Target,
+			Class:  result.Class,
+			Type:   result.Type,
+		}
+	}
+	for _, idx := range indices {
+		i := m.items[idx]
+		src, dst := m.report.Results[i.result], &r.Results[i.result]
+		switch i.findingType {
+		case types.FindingTypeVulnerability:
+			dst.Vulnerabilities = append(dst.Vulnerabilities, src.Vulnerabilities[i.index])
+		case types.FindingTypeMisconfiguration:
+			dst.Misconfigurations = append(dst.Misconfigurations, src.Misconfigurations[i.index])
+		case types.FindingTypeSecret:
+			dst.Secrets = append(dst.Secrets, src.Secrets[i.index])
+		case types.FindingTypeLicense:
+			dst.Licenses = append(dst.Licenses, src.Licenses[i.index])
+		}
+	}
+	r.Results = lo.Filter(r.Results, func(result types.Result, _ int) bool { return !result.IsEmpty() })
+
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal json: %w", err)
+	}
+	if err = os.WriteFile(m.exportPath, append(b, '\n'), 0o644); err != nil {
+		return xerrors.Errorf("failed to write %s: %w", m.exportPath, err)
+	}
+	m.message = fmt.Sprintf("Exported %d findings to %s", len(indices), m.exportPath)
+	return nil
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+var testReport = types.Report{
+	ArtifactName: "test-image",
+	Results: types.Results{
+		{
+			Target: "test-image (alpine 3.20.0)",
+			Class:  types.ClassOSPkg,
+			Type:   ftypes.Alpine,
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID:  "CVE-2024-0001",
+					PkgName:          "openssl",
+					InstalledVersion: "3.3.0-r0",
+					FixedVersion:     "3.3.0-r1",
+					Vulnerability: dbTypes.Vulnerability{
+						Title:    "openssl: buffer overflow",
+						Severity: "CRITICAL",
+					},
+				},
+				{
+					VulnerabilityID:  "CVE-2024-0002",
+					PkgName:          "busybox",
+					InstalledVersion: "1.36.1-r28",
+					Vulnerability: dbTypes.Vulnerability{
+						Title:    "busybox: use after free",
+						Severity: "LOW",
+					},
+				},
+			},
+		},
+		{
+			Target: "Dockerfile",
+			Class:  types.ClassConfig,
+			Type:   ftypes.Dockerfile,
+			Misconfigurations: []types.DetectedMisconfiguration{
+				{
+					ID:       "DS002",
+					Title:    "Image user should not be 'root'",
+					Severity: "HIGH",
+					Status:   types.MisconfStatusFailure,
+					CauseMetadata: ftypes.CauseMetadata{
+						Resource: "USER root",
+						Code: ftypes.Code{
+							Lines: []ftypes.Line{
+								{
+									Number:  3,
+									Content: "USER root",
+									IsCause: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestModel(t *testing.T) {
+	m := newModel(testReport, filepath.Join(t.TempDir(), "export.json"))
+	require.Len(t, m.items, 3)
+
+	ids := func() []string {
+		var ids []string
+		for _, idx := range m.filtered {
+			ids = append(ids, m.items[idx].id)
+		}
+		return ids
+	}
+	press := func(keys ...string) {
+		for _, key := range keys {
+			m.handleKey(key)
+		}
+	}
+
+	// Toggle LOW
+	press("4")
+	assert.Equal(t, []string{"CVE-2024-0001", "DS002"}, ids())
+	press("4")
+	assert.Equal(t, []string{"CVE-2024-0001", "CVE-2024-0002", "DS002"}, ids())
+
+	// Cycle targets
+	press("t")
+	assert.Equal(t, []string{"CVE-2024-0001", "CVE-2024-0002"}, ids())
+	press("t")
+	assert.Equal(t, []string{"DS002"}, ids())
+	press("t")
+	assert.Len(t, ids(), 3)
+
+	// Search by package
+	press("/", "b", "u", "s", keyEnter)
+	assert.Equal(t, []string{"CVE-2024-0002"}, ids())
+	press("a") // "a" marks all the findings shown after the search is applied
+	assert.Equal(t, "bus", m.query)
+	assert.Equal(t, 1, m.countMarked())
+	press(keyEsc)
+	assert.Len(t, ids(), 3)
+	press("a", "a") // Mark all, then unmark all
+	assert.Zero(t, m.countMarked())
+
+	// Move and show the details of the misconfiguration
+	press(keyDown, keyDown, keyDown)
+	assert.Equal(t, 2, m.cursor)
+	press(keyEnter)
+	assert.True(t, m.detail)
+	assert.Contains(t, m.view(80, 24, false), ">    3 USER root")
+	press(keyEsc)
+	assert.False(t, m.detail)
+
+	press("q")
+	assert.True(t, m.quit)
+}
+
+func TestModel_view(t *testing.T) {
+	m := newModel(testReport, "")
+	m.handleKey(" ")
+
+	want := []string{
+		"test-image | 3/3 findings | severity: CHMLU | target: all | marked: 1",
+		"   SEVERITY  ID                    PACKAGE/RESOURCE                TITLE",
+		" * CRITICAL  CVE-2024-0001         openssl@3.3.0-r0                openssl: buffer overflow",
+		">  LOW       CVE-2024-0002         busybox@1.36.1-r28              busybox: use after free",
+		"   HIGH      DS002                 USER root                       Image user should not be 'root'",
+		"",
+		"↑/↓: move | enter: details | 1-5: toggle severity | t: target | /: search | space: mark | a: mark a…",
+	}
+	assert.Equal(t, want, m.view(100, 7, false))
+}
+
+func TestModel_export(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	m := newModel(testReport, exportPath)
+
+	// Mark the misconfiguration
+	m.handleKey("G")
+	m.handleKey(" ")
+	m.handleKey("e")
+	assert.Equal(t, "Exported 1 findings to "+exportPath, m.message)
+
+	b, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	var got types.Report
+	require.NoError(t, json.Unmarshal(b, &got))
+	require.Len(t, got.Results, 1)
+	assert.Equal(t, "Dockerfile", got.Results[0].Target)
+	assert.Equal(t, testReport.Results[1].Misconfigurations, got.Results[0].Misconfigurations)
+
+	// Export the findings shown if nothing is marked
+	m.handleKey(" ")
+	m.handleKey("5")
+	m.handleKey("4")
+	m.handleKey("e")
+	assert.Equal(t, "Exported 2 findings to "+exportPath, m.message)
+}
+
+func Test_parseKeys(t *testing.T) {
+	got := parseKeys([]byte("a\x1b[A\x1b[B\r\x7f\x1b/é\x03"))
+	assert.Equal(t, []string{"a", keyUp, keyDown, keyEnter, keyBackspace, keyEsc, "/", "é", keyCtrlC}, got)
+}
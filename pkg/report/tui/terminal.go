@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	enterAltScreen = "\x1b[?1049h\x1b[?25l" // Also hides the cursor
+	exitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+// escapeKeys maps escape sequences of special keys sent by terminals
+var escapeKeys = map[string]string{
+	"\x1b[A":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+	"\x1b[H":  keyHome,
+	"\x1b[F":  keyEnd,
+	"\x1b[1~": keyHome,
+	"\x1b[4~": keyEnd,
+	"\x1bOA":  keyUp,
+	"\x1bOB":  keyDown,
+	"\x1bOH":  keyHome,
+	"\x1bOF":  keyEnd,
+}
+
+// Writer browses the report interactively instead of writing it
+type Writer struct {
+	Input      *os.File
+	Output     io.Writer
+	ExportPath string
+}
+
+func (w Writer) Write(_ context.Context, report types.Report) error {
+	return Run(report, w.ExportPath, w.Input, w.Output)
+}
+
+// Run browses the report interactively in the terminal until the user quits.
+// Findings are exported to exportPath as a JSON report.
+func Run(report types.Report, exportPath string, in *os.File, out io.Writer) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return xerrors.New("the interactive browser requires a terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return xerrors.Errorf("unable to enable the raw mode: %w", err)
+	}
+	defer term.Restore(fd, state)
+
+	w := bufio.NewWriter(out)
+	_, _ = w.WriteString(enterAltScreen)
+	defer func() {
+		_, _ = w.WriteString(exitAltScreen)
+		_ = w.Flush()
+	}()
+
+	m := newModel(report, exportPath)
+	buf := make([]byte, 256)
+	for !m.quit {
+		width, height, err := term.GetSize(fd)
+		if err != nil || width <= 0 || height <= 0 {
+			width, height = defaultWidth, defaultHeight
+		}
+
+		_, _ = w.WriteString(clearScreen)
+		// "\r\n" is required as the raw mode disables the output processing
+		_, _ = w.WriteString(strings.Join(m.view(width, height, true), "\r\n"))
+		if err = w.Flush(); err != nil {
+			return xerrors.Errorf("write error: %w", err)
+		}
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return xerrors.Errorf("read error: %w", err)
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			m.handleKey(key)
+		}
+	}
+	return nil
+}
+
+// parseKeys splits the input into keys
+func parseKeys(b []byte) []string {
+	var keys []string
+	s := string(b)
+	for len(s) > 0 {
+		if s[0] == 0x1b {
+			if key, seq, ok := matchEscape(s); ok {
+				keys = append(keys, key)
+				s = s[len(seq):]
+				continue
+			}
+			keys = append(keys, keyEsc)
+			s = s[1:]
+			continue
+		}
+
+		switch s[0] {
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 0x7f, 0x08:
+			keys = append(keys, keyBackspace)
+		case 0x03:
+			keys = append(keys, keyCtrlC)
+		default:
+			r, size := utf8.DecodeRuneInString(s)
+			if r >= 0x20 && r != utf8.RuneError {
+				keys = append(keys, string(r))
+			}
+			s = s[size:]
+			continue
+		}
+		s = s[1:]
+	}
+	return keys
+}
+
+func matchEscape(s string) (string, string, bool) {
+	for seq, key := range escapeKeys {
+		if strings.HasPrefix(s, seq) {
+			return key, seq, true
+		}
+	}
+	return "", "", false
+}
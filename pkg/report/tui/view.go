@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+)
+
+const (
+	// Rows other than the list: the header, the column names and the status bar
+	chromeRows = 3
+
+	ansiReset   = "\x1b[0m"
+	ansiReverse = "\x1b[7m"
+	ansiBold    = "\x1b[1m"
+)
+
+var severityColors = map[string]string{
+	dbTypes.SeverityCritical.String(): "\x1b[31m", // red
+	dbTypes.SeverityHigh.String():     "\x1b[91m", // bright red
+	dbTypes.SeverityMedium.String():   "\x1b[33m", // yellow
+	dbTypes.SeverityLow.String():      "\x1b[34m", // blue
+	dbTypes.SeverityUnknown.String():  "\x1b[36m", // cyan
+}
+
+// view renders the screen into lines of the width.
+// Colors are used only if color is true.
+func (m *model) view(width, height int, color bool) []string {
+	m.height = max(1, height-chromeRows)
+	m.move(0) // Keep the cursor visible after resizing
+
+	lines := []string{m.header()}
+	if m.detail {
+		lines = append(lines, m.detailView()...)
+	} else {
+		lines = append(lines, m.listView(width, color)...)
+	}
+
+	// Pad or cut the body so that the status bar stays at the bottom
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines[:height-1], m.statusBar())
+
+	for i, l := range lines {
+		if !strings.Contains(l, "\x1b") {
+			lines[i] = truncate(l, width)
+		}
+	}
+	return lines
+}
+
+func (m *model) header() string {
+	var sevs []string
+	for _, key := range []string{"1", "2", "3", "4", "5"} {
+		s := severityKeys[key].String()
+		if m.severities[s] {
+			sevs = append(sevs, s[:1])
+		} else {
+			sevs = append(sevs, "-")
+		}
+	}
+	target := "all"
+	if m.target > 0 {
+		target = m.targets[m.target-1]
+	}
+	h := fmt.Sprintf("%s | %d/%d findings | severity: %s | target: %s", m.report.ArtifactName,
+		len(m.filtered), len(m.items), strings.Join(sevs, ""), target)
+	if m.query != "" || m.searching {
+		h += " | search: " + m.query
+	}
+	if n := m.countMarked(); n > 0 {
+		h += fmt.Sprintf(" | marked: %d", n)
+	}
+	return h
+}
+
+func (m *model) listView(width int, color bool) []string {
+	lines := []string{fmt.Sprintf("   %-8s  %-20s  %-30s  %s", "SEVERITY", "ID", "PACKAGE/RESOURCE", "TITLE")}
+	if len(m.filtered) == 0 {
+		return append(lines, "  No findings match the filters")
+	}
+	end := min(m.offset+m.height, len(m.filtered))
+	for pos := m.offset; pos < end; pos++ {
+		idx := m.filtered[pos]
+		i := m.items[idx]
+		cursor, mark := " ", " "
+		if pos == m.cursor {
+			cursor = ">"
+		}
+		if m.marked[idx] {
+			mark = "*"
+		}
+		line := truncate(fmt.Sprintf("%s%s %-8s  %-20s  %-30s  %s", cursor, mark, i.severity, truncate(i.id, 20),
+			truncate(i.pkg, 30), i.title), width)
+		if color {
+			line = colorize(line, i.severity, pos == m.cursor)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (m *model) detailView() []string {
+	i, ok := m.selected()
+	if !ok {
+		return nil
+	}
+	lines := append([]string{fmt.Sprintf("[%s] %s", i.findingType, i.target), ""}, i.details...)
+	m.scroll = min(m.scroll, max(0, len(lines)-1))
+	return lines[m.scroll:]
+}
+
+func (m *model) statusBar() string {
+	switch {
+	case m.message != "":
+		return m.message
+	case m.searching:
+		return "Type to search by ID, package or title | enter: apply | esc: clear"
+	case m.detail:
+		return "↑/↓: scroll | enter/esc: back | ctrl+c: quit"
+	}
+	return "↑/↓: move | enter: details | 1-5: toggle severity | t: target | /: search | space: mark | a: mark all | e: export | q: quit"
+}
+
+func (m *model) countMarked() int {
+	var n int
+	for _, marked := range m.marked {
+		if marked {
+			n++
+		}
+	}
+	return n
+}
+
+// colorize colors the severity and highlights the line at the cursor
+func colorize(line, severity string, cursor bool) string {
+	if c, ok := severityColors[severity]; ok {
+		line = strings.Replace(line, severity, c+severity+ansiReset, 1)
+	}
+	if cursor {
+		// Keep the highlight after the severity color is reset
+		line = ansiReverse + ansiBold + strings.ReplaceAll(line, ansiReset, ansiReset+ansiReverse+ansiBold) + ansiReset
+	}
+	return line
+}
+
+// truncate cuts the string to fit in the width
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	} else if width <= 0 {
+		return ""
+	}
+	return string(r[:width-1]) + "…"
+}
@@ -1,8 +1,10 @@
 package report
 
 import (
+	"bytes"
 	"context"
 	"io"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -14,9 +16,12 @@ import (
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/report/cyclonedx"
 	"github.com/aquasecurity/trivy/pkg/report/github"
+	"github.com/aquasecurity/trivy/pkg/report/graph"
 	"github.com/aquasecurity/trivy/pkg/report/predicate"
 	"github.com/aquasecurity/trivy/pkg/report/spdx"
 	"github.com/aquasecurity/trivy/pkg/report/table"
+	"github.com/aquasecurity/trivy/pkg/report/tui"
+	"github.com/aquasecurity/trivy/pkg/signing"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
@@ -36,6 +41,17 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 		}
 	}()
 
+	// Keep the written report to sign it
+	if option.SigningKey != "" {
+		written := new(bytes.Buffer)
+		output = io.MultiWriter(output, written)
+		defer func() {
+			if err == nil {
+				err = writeSignature(option, written)
+			}
+		}()
+	}
+
 	// Compliance report
 	if option.Compliance.Spec.ID != "" {
 		return complianceWrite(ctx, report, option, output)
@@ -59,11 +75,14 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 			Output:         output,
 			ListAllPkgs:    option.ListAllPkgs,
 			ShowSuppressed: option.ShowSuppressed,
+			DedupFindings:  option.DedupFindings,
+			Compact:        option.EarlyResults,
 		}
 	case types.FormatGitHub:
 		writer = &github.Writer{
 			Output:  output,
 			Version: option.AppVersion,
+			Submit:  option.GitHubSubmit,
 		}
 	case types.FormatCycloneDX:
 		// TODO: support xml format option with cyclonedx writer
@@ -80,6 +99,15 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 			}
 			break
 		}
+		// The same goes for `html.tpl`, which is superseded by `--format html`.
+		if strings.HasPrefix(option.Template, "@") && strings.HasSuffix(option.Template, "html.tpl") {
+			log.Warn("Using `--template html.tpl` is deprecated. Please migrate to `--format html`.")
+			writer = &HTMLWriter{
+				Output:  output,
+				Version: option.AppVersion,
+			}
+			break
+		}
 		if writer, err = NewTemplateWriter(output, option.Template, option.AppVersion); err != nil {
 			return xerrors.Errorf("failed to initialize template writer: %w", err)
 		}
@@ -95,6 +123,44 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 		}
 	case types.FormatCosignVuln:
 		writer = predicate.NewVulnWriter(output, option.AppVersion)
+	case types.FormatAttribution:
+		writer = &AttributionWriter{
+			Output: output,
+		}
+	case types.FormatGraph:
+		writer = graph.NewWriter(output, graph.Format(option.GraphFormat), option.AppVersion)
+	case types.FormatTUI:
+		writer = &tui.Writer{
+			Input:      os.Stdin,
+			Output:     output,
+			ExportPath: flag.BrowseExportPathFlag.Default,
+		}
+	case types.FormatLayers:
+		writer = &LayerWriter{
+			Output: output,
+		}
+	case types.FormatHTML:
+		writer = &HTMLWriter{
+			Output:  output,
+			Version: option.AppVersion,
+		}
+	case types.FormatCSV:
+		writer = &CSVWriter{
+			Output:  output,
+			Columns: option.CSVColumns,
+		}
+	case types.FormatMarkdown:
+		writer = &MarkdownWriter{
+			Output: output,
+		}
+	case types.FormatJUnit:
+		writer = &JUnitWriter{
+			Output: output,
+		}
+	case types.FormatLicenseObligations:
+		writer = &ObligationWriter{
+			Output: output,
+		}
 	default:
 		return xerrors.Errorf("unknown format: %v", option.Format)
 	}
@@ -106,6 +172,21 @@ func Write(ctx context.Context, report types.Report, option flag.Options) (err e
 	return nil
 }
 
+// writeSignature signs the report and writes the signature to "<output>.sig".
+func writeSignature(opt flag.Options, report io.Reader) error {
+	sig, err := signing.Sign(opt.SigningKey, report)
+	if err != nil {
+		return xerrors.Errorf("failed to sign the report: %w", err)
+	}
+
+	sigPath := opt.Output + ".sig"
+	if err = os.WriteFile(sigPath, sig, 0o644); err != nil {
+		return xerrors.Errorf("failed to write the signature: %w", err)
+	}
+	log.Info("Report signed", log.FilePath(sigPath))
+	return nil
+}
+
 func complianceWrite(ctx context.Context, report types.Report, opt flag.Options, output io.Writer) error {
 	complianceReport, err := cr.BuildComplianceReport([]types.Results{report.Results}, opt.Compliance)
 	if err != nil {
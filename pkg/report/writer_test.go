@@ -1,10 +1,17 @@
 package report_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/aquasecurity/trivy/pkg/flag"
+	"github.com/aquasecurity/trivy/pkg/report"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
@@ -82,3 +89,55 @@ func TestResults_Failed(t *testing.T) {
 		})
 	}
 }
+
+func TestWrite_EarlyResults(t *testing.T) {
+	results := types.Results{
+		{
+			Target: "alpine:3.14 (alpine 3.14.2)",
+			Class:  types.ClassOSPkg,
+			Type:   "alpine",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{
+					VulnerabilityID: "CVE-2021-36159",
+					PkgName:         "apk-tools",
+				},
+			},
+		},
+	}
+
+	output := bytes.NewBuffer(nil)
+	opts := flag.Options{
+		ReportOptions: flag.ReportOptions{
+			Format:       types.FormatJSON,
+			EarlyResults: true,
+		},
+	}
+	opts.SetOutputWriter(output)
+
+	// The early results of OS packages are followed by the full results
+	reports := []types.Report{
+		{
+			SchemaVersion: 2,
+			ArtifactName:  "alpine:3.14",
+			Results:       results,
+			Partial:       true,
+		},
+		{
+			SchemaVersion: 2,
+			ArtifactName:  "alpine:3.14",
+			Results:       results,
+		},
+	}
+	for _, r := range reports {
+		require.NoError(t, report.Write(context.Background(), r, opts))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+	require.Len(t, lines, len(reports))
+	for i, line := range lines {
+		var got types.Report
+		require.NoError(t, json.Unmarshal([]byte(line), &got))
+		assert.Equal(t, reports[i].Partial, got.Partial)
+		assert.Equal(t, reports[i].Results, got.Results)
+	}
+}
@@ -0,0 +1,252 @@
+package result
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/owenrumney/go-sarif/v2/sarif"
+	"github.com/samber/lo"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/set"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// SecretBaselineOptions holds options for the secret baseline
+type SecretBaselineOptions struct {
+	// Path is the baseline file path
+	Path string
+
+	// Generate writes the detected secrets to the baseline file instead of suppressing them
+	Generate bool
+}
+
+// SecretBaseline holds the fingerprints of known secrets.
+// Secrets in the baseline are suppressed so that only newly introduced secrets are reported.
+type SecretBaseline struct {
+	Secrets []BaselineSecret
+}
+
+// BaselineSecret is a known secret.
+// RuleID and Target are informational and only Fingerprint is used for matching.
+type BaselineSecret struct {
+	Fingerprint string
+	RuleID      string
+	Target      string
+}
+
+// applySecretBaseline generates the baseline or suppresses the secrets in the baseline.
+func applySecretBaseline(report *types.Report, opts SecretBaselineOptions) error {
+	if opts.Path == "" {
+		return nil
+	} else if opts.Generate {
+		return writeSecretBaseline(*report, opts.Path)
+	}
+
+	baseline, err := parseSecretBaseline(opts.Path)
+	if err != nil {
+		return xerrors.Errorf("unable to parse the secret baseline: %w", err)
+	}
+	fingerprints := set.New[string]()
+	for _, s := range baseline.Secrets {
+		fingerprints.Append(s.Fingerprint)
+	}
+
+	for i := range report.Results {
+		result := &report.Results[i]
+		var filtered []types.DetectedSecret
+		for _, secret := range result.Secrets {
+			if secret.Fingerprint != "" && fingerprints.Contains(secret.Fingerprint) {
+				result.ModifiedFindings = append(result.ModifiedFindings,
+					types.NewModifiedFinding(secret, types.FindingStatusIgnored, "In the secret baseline", opts.Path))
+				continue
+			}
+			filtered = append(filtered, secret)
+		}
+		result.Secrets = filtered
+	}
+	return nil
+}
+
+func parseSecretBaseline(filePath string) (SecretBaseline, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return SecretBaseline{}, xerrors.Errorf("unable to read %s: %w", filePath, err)
+	}
+	var baseline SecretBaseline
+	if err = json.Unmarshal(b, &baseline); err != nil {
+		return SecretBaseline{}, xerrors.Errorf("json decode error: %w", err)
+	}
+	return baseline, nil
+}
+
+// writeSecretBaseline writes all the detected secrets regardless of other filters such as severities.
+func writeSecretBaseline(report types.Report, filePath string) error {
+	seen := set.New[string]()
+	baseline := SecretBaseline{
+		Secrets: []BaselineSecret{}, // Write an empty array rather than null
+	}
+	for _, result := range report.Results {
+		for _, secret := range result.Secrets {
+			if secret.Fingerprint == "" {
+				log.Warn("Secret without fingerprint is not added to the baseline",
+					log.String("target", result.Target), log.String("rule_id", secret.RuleID))
+				continue
+			} else if seen.Contains(secret.Fingerprint) {
+				continue
+			}
+			seen.Append(secret.Fingerprint)
+			baseline.Secrets = append(baseline.Secrets, BaselineSecret{
+				Fingerprint: secret.Fingerprint,
+				RuleID:      secret.RuleID,
+				Target:      result.Target,
+			})
+		}
+	}
+
+	// Sort secrets so that the baseline can be reviewed in diffs
+	slices.SortFunc(baseline.Secrets, func(a, b BaselineSecret) int {
+		return cmp.Or(
+			cmp.Compare(a.Target, b.Target),
+			cmp.Compare(a.RuleID, b.RuleID),
+			cmp.Compare(a.Fingerprint, b.Fingerprint),
+		)
+	})
+
+	b, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("json encode error: %w", err)
+	}
+	if err = os.WriteFile(filePath, append(b, '\n'), 0644); err != nil {
+		return xerrors.Errorf("unable to write the secret baseline: %w", err)
+	}
+	log.Info("Secret baseline written", log.FilePath(filePath), log.Int("secrets", len(baseline.Secrets)))
+	return nil
+}
+
+// applyBaseline suppresses the findings in the baseline, which is a previous report in the JSON or SARIF format.
+// Findings are matched by their fingerprints so that they are suppressed even if they move within the file.
+func applyBaseline(report *types.Report, filePath string) error {
+	if filePath == "" {
+		return nil
+	}
+
+	fingerprints, err := parseBaseline(filePath)
+	if err != nil {
+		return xerrors.Errorf("unable to parse the baseline: %w", err)
+	}
+
+	const statement = "In the baseline"
+	for i := range report.Results {
+		result := &report.Results[i]
+		result.Vulnerabilities = lo.Reject(result.Vulnerabilities, func(vuln types.DetectedVulnerability, _ int) bool {
+			if !fingerprints.Contains(types.Fingerprint(*result, vuln)) {
+				return false
+			}
+			result.ModifiedFindings = append(result.ModifiedFindings,
+				types.NewModifiedFinding(vuln, types.FindingStatusIgnored, statement, filePath))
+			return true
+		})
+
+		failures := countFailures(result.Misconfigurations)
+		result.Misconfigurations = lo.Reject(result.Misconfigurations, func(misconf types.DetectedMisconfiguration, _ int) bool {
+			if misconf.Status == types.MisconfStatusPassed || !fingerprints.Contains(types.Fingerprint(*result, misconf)) {
+				return false
+			}
+			result.ModifiedFindings = append(result.ModifiedFindings,
+				types.NewModifiedFinding(misconf, types.FindingStatusIgnored, statement, filePath))
+			return true
+		})
+		if result.MisconfSummary != nil {
+			result.MisconfSummary.Failures -= failures - countFailures(result.Misconfigurations)
+			if result.MisconfSummary.Empty() {
+				result.MisconfSummary = nil
+			}
+		}
+
+		result.Secrets = lo.Reject(result.Secrets, func(secret types.DetectedSecret, _ int) bool {
+			if !fingerprints.Contains(types.Fingerprint(*result, secret)) {
+				return false
+			}
+			result.ModifiedFindings = append(result.ModifiedFindings,
+				types.NewModifiedFinding(secret, types.FindingStatusIgnored, statement, filePath))
+			return true
+		})
+
+		result.Licenses = lo.Reject(result.Licenses, func(license types.DetectedLicense, _ int) bool {
+			if !fingerprints.Contains(types.Fingerprint(*result, license)) {
+				return false
+			}
+			result.ModifiedFindings = append(result.ModifiedFindings,
+				types.NewModifiedFinding(license, types.FindingStatusIgnored, statement, filePath))
+			return true
+		})
+	}
+	return nil
+}
+
+func countFailures(misconfs []types.DetectedMisconfiguration) int {
+	return lo.CountBy(misconfs, func(m types.DetectedMisconfiguration) bool {
+		return m.Status == types.MisconfStatusFailure
+	})
+}
+
+// parseBaseline returns the fingerprints of the findings in the baseline.
+// The format is detected by the "runs" field, which is present only in SARIF.
+func parseBaseline(filePath string) (set.Set[string], error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to read %s: %w", filePath, err)
+	}
+	var probe struct {
+		Runs json.RawMessage `json:"runs"`
+	}
+	if err = json.Unmarshal(b, &probe); err != nil {
+		return nil, xerrors.Errorf("json decode error: %w", err)
+	}
+
+	fingerprints := set.New[string]()
+	if probe.Runs != nil {
+		sarifReport, err := sarif.FromBytes(b)
+		if err != nil {
+			return nil, xerrors.Errorf("sarif decode error: %w", err)
+		}
+		for _, run := range sarifReport.Runs {
+			for _, result := range run.Results {
+				if fp, ok := result.PartialFingerprints[types.SarifFingerprintKey].(string); ok {
+					fingerprints.Append(fp)
+				}
+			}
+		}
+	} else {
+		var report types.Report
+		if err = json.Unmarshal(b, &report); err != nil {
+			return nil, xerrors.Errorf("json decode error: %w", err)
+		}
+		for _, result := range report.Results {
+			for _, vuln := range result.Vulnerabilities {
+				fingerprints.Append(types.Fingerprint(result, vuln))
+			}
+			for _, misconf := range result.Misconfigurations {
+				// Passed checks may fail later
+				if misconf.Status != types.MisconfStatusPassed {
+					fingerprints.Append(types.Fingerprint(result, misconf))
+				}
+			}
+			for _, secret := range result.Secrets {
+				fingerprints.Append(types.Fingerprint(result, secret))
+			}
+			for _, license := range result.Licenses {
+				fingerprints.Append(types.Fingerprint(result, license))
+			}
+		}
+	}
+
+	if fingerprints.Size() == 0 {
+		log.Warn("No findings in the baseline", log.FilePath(filePath))
+	}
+	return fingerprints, nil
+}
@@ -0,0 +1,303 @@
+package result_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/result"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestFilter_SecretBaseline(t *testing.T) {
+	known := types.DetectedSecret{
+		RuleID:      "aws-access-key-id",
+		Severity:    dbTypes.SeverityCritical.String(),
+		Fingerprint: "sha256:0123",
+	}
+	newSecret := types.DetectedSecret{
+		RuleID:      "github-pat",
+		Severity:    dbTypes.SeverityCritical.String(),
+		Fingerprint: "sha256:4567",
+	}
+	lowSecret := types.DetectedSecret{
+		RuleID:      "jwt-token",
+		Severity:    dbTypes.SeverityLow.String(),
+		Fingerprint: "sha256:89ab",
+	}
+	newReport := func() types.Report {
+		return types.Report{
+			Results: types.Results{
+				{
+					Target: "app/.env",
+					Class:  types.ClassSecret,
+					Secrets: []types.DetectedSecret{
+						known,
+						newSecret,
+						lowSecret,
+					},
+				},
+			},
+		}
+	}
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+
+	// Generate the baseline including secrets filtered out by severities
+	report := newReport()
+	err := result.Filter(context.Background(), report, result.FilterOptions{
+		Severities: []dbTypes.Severity{dbTypes.SeverityCritical},
+		SecretBaseline: result.SecretBaselineOptions{
+			Path:     baselinePath,
+			Generate: true,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []types.DetectedSecret{
+		known,
+		newSecret,
+	}, report.Results[0].Secrets)
+
+	got, err := os.ReadFile(baselinePath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "Secrets": [
+    {"Fingerprint": "sha256:0123", "RuleID": "aws-access-key-id", "Target": "app/.env"},
+    {"Fingerprint": "sha256:4567", "RuleID": "github-pat", "Target": "app/.env"},
+    {"Fingerprint": "sha256:89ab", "RuleID": "jwt-token", "Target": "app/.env"}
+  ]
+}`, string(got))
+
+	// Suppress the secrets in the baseline
+	err = os.WriteFile(baselinePath, []byte(`{"Secrets": [{"Fingerprint": "sha256:0123"}]}`), 0600)
+	require.NoError(t, err)
+
+	report = newReport()
+	err = result.Filter(context.Background(), report, result.FilterOptions{
+		Severities: []dbTypes.Severity{dbTypes.SeverityCritical},
+		SecretBaseline: result.SecretBaselineOptions{
+			Path: baselinePath,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []types.DetectedSecret{newSecret}, report.Results[0].Secrets)
+	assert.Equal(t, []types.ModifiedFinding{
+		{
+			Type:      types.FindingTypeSecret,
+			Status:    types.FindingStatusIgnored,
+			Statement: "In the secret baseline",
+			Source:    baselinePath,
+			Finding:   known,
+		},
+	}, report.Results[0].ModifiedFindings)
+
+	// Missing baseline
+	err = result.Filter(context.Background(), newReport(), result.FilterOptions{
+		SecretBaseline: result.SecretBaselineOptions{
+			Path: filepath.Join(t.TempDir(), "missing.json"),
+		},
+	})
+	require.ErrorContains(t, err, "unable to parse the secret baseline")
+}
+
+func TestFilter_Baseline(t *testing.T) {
+	// The findings in the baseline have different line numbers, installed versions and OS versions
+	baseline := types.Report{
+		Results: types.Results{
+			{
+				Target: "alpine:3.19 (alpine 3.19.1)",
+				Class:  types.ClassOSPkg,
+				Type:   ftypes.Alpine,
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2024-0001",
+						PkgName:          "openssl",
+						InstalledVersion: "3.1.0-r0",
+						Vulnerability: dbTypes.Vulnerability{
+							Severity: dbTypes.SeverityHigh.String(),
+						},
+					},
+				},
+			},
+			{
+				Target: "Dockerfile",
+				Class:  types.ClassConfig,
+				Type:   ftypes.Dockerfile,
+				Misconfigurations: []types.DetectedMisconfiguration{
+					{
+						ID:       "DS002",
+						Message:  "Specify at least 1 USER command",
+						Severity: dbTypes.SeverityHigh.String(),
+						Status:   types.MisconfStatusFailure,
+						CauseMetadata: ftypes.CauseMetadata{
+							StartLine: 1,
+							EndLine:   1,
+						},
+					},
+					{
+						ID:       "DS001",
+						Severity: dbTypes.SeverityMedium.String(),
+						Status:   types.MisconfStatusPassed,
+					},
+				},
+			},
+			{
+				Target: "app/.env",
+				Class:  types.ClassSecret,
+				Secrets: []types.DetectedSecret{
+					{
+						RuleID:      "aws-access-key-id",
+						Severity:    dbTypes.SeverityCritical.String(),
+						Match:       "AWS_ACCESS_KEY_ID=********************",
+						StartLine:   3,
+						EndLine:     3,
+						Fingerprint: "sha256:0f1e6d7c2e3a4b5c",
+					},
+				},
+			},
+		},
+	}
+
+	knownVuln := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0001",
+		PkgName:          "openssl",
+		InstalledVersion: "3.3.0-r0",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityHigh.String(),
+		},
+	}
+	newVuln := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2024-0002",
+		PkgName:          "openssl",
+		InstalledVersion: "3.3.0-r0",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityHigh.String(),
+		},
+	}
+	knownMisconf := types.DetectedMisconfiguration{
+		ID:       "DS002",
+		Message:  "Specify at least 1 USER command",
+		Severity: dbTypes.SeverityHigh.String(),
+		Status:   types.MisconfStatusFailure,
+		CauseMetadata: ftypes.CauseMetadata{
+			StartLine: 5,
+			EndLine:   5,
+		},
+	}
+	// Passed in the baseline
+	newMisconf := types.DetectedMisconfiguration{
+		ID:       "DS001",
+		Severity: dbTypes.SeverityMedium.String(),
+		Status:   types.MisconfStatusFailure,
+	}
+	knownSecret := types.DetectedSecret{
+		RuleID:      "aws-access-key-id",
+		Severity:    dbTypes.SeverityCritical.String(),
+		Match:       "AWS_ACCESS_KEY_ID=********************",
+		StartLine:   10,
+		EndLine:     10,
+		Fingerprint: "sha256:0f1e6d7c2e3a4b5c",
+	}
+	// Another secret with the same redacted line
+	rotatedSecret := types.DetectedSecret{
+		RuleID:      "aws-access-key-id",
+		Severity:    dbTypes.SeverityCritical.String(),
+		Match:       "AWS_ACCESS_KEY_ID=********************",
+		StartLine:   12,
+		EndLine:     12,
+		Fingerprint: "sha256:9a8b7c6d5e4f3a2b",
+	}
+	newSecret := types.DetectedSecret{
+		RuleID:    "github-pat",
+		Severity:  dbTypes.SeverityCritical.String(),
+		Match:     "GITHUB_TOKEN=****************************************",
+		StartLine: 11,
+		EndLine:   11,
+	}
+
+	tests := []struct {
+		name  string
+		write func(t *testing.T, baseline types.Report) []byte
+	}{
+		{
+			name: "json",
+			write: func(t *testing.T, baseline types.Report) []byte {
+				b, err := json.Marshal(baseline)
+				require.NoError(t, err)
+				return b
+			},
+		},
+		{
+			name: "sarif",
+			write: func(t *testing.T, baseline types.Report) []byte {
+				// Passed checks are not written without "--include-non-failures"
+				baseline.Results = slices.Clone(baseline.Results)
+				baseline.Results[1].Misconfigurations = baseline.Results[1].Misconfigurations[:1]
+
+				buf := bytes.NewBuffer(nil)
+				w := report.SarifWriter{Output: buf}
+				require.NoError(t, w.Write(context.Background(), baseline))
+				return buf.Bytes()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baselinePath := filepath.Join(t.TempDir(), "baseline")
+			require.NoError(t, os.WriteFile(baselinePath, tt.write(t, baseline), 0600))
+
+			got := types.Report{
+				Results: types.Results{
+					{
+						Target:          "alpine:3.20 (alpine 3.20.0)",
+						Class:           types.ClassOSPkg,
+						Type:            ftypes.Alpine,
+						Vulnerabilities: []types.DetectedVulnerability{knownVuln, newVuln},
+					},
+					{
+						Target:            "Dockerfile",
+						Class:             types.ClassConfig,
+						Type:              ftypes.Dockerfile,
+						Misconfigurations: []types.DetectedMisconfiguration{knownMisconf, newMisconf},
+					},
+					{
+						Target:  "app/.env",
+						Class:   types.ClassSecret,
+						Secrets: []types.DetectedSecret{knownSecret, newSecret, rotatedSecret},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), got, result.FilterOptions{
+				Severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityMedium,
+				},
+				Baseline: baselinePath,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, []types.DetectedVulnerability{newVuln}, got.Results[0].Vulnerabilities)
+			assert.Equal(t, []types.DetectedMisconfiguration{newMisconf}, got.Results[1].Misconfigurations)
+			assert.Equal(t, &types.MisconfSummary{Failures: 1}, got.Results[1].MisconfSummary)
+			assert.Equal(t, []types.DetectedSecret{newSecret, rotatedSecret}, got.Results[2].Secrets)
+
+			for i, want := range []any{knownVuln, knownMisconf, knownSecret} {
+				require.Len(t, got.Results[i].ModifiedFindings, 1)
+				assert.Equal(t, types.FindingStatusIgnored, got.Results[i].ModifiedFindings[0].Status)
+				assert.Equal(t, "In the baseline", got.Results[i].ModifiedFindings[0].Statement)
+				assert.Equal(t, baselinePath, got.Results[i].ModifiedFindings[0].Source)
+				assert.Equal(t, want, got.Results[i].ModifiedFindings[0].Finding)
+			}
+		})
+	}
+}
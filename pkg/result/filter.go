@@ -13,6 +13,7 @@ import (
 	"golang.org/x/xerrors"
 
 	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
 	"github.com/aquasecurity/trivy/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/vex"
 )
@@ -29,8 +30,14 @@ type FilterOptions struct {
 	IgnoreFile         string
 	PolicyFile         string
 	IgnoreLicenses     []string
+	LicensePolicy      licensing.Policy
+	ProjectLicense     licensing.Compatibility
 	CacheDir           string
 	VEXSources         []vex.Source
+	SecretBaseline     SecretBaselineOptions
+	Baseline           string
+	Scoring            ScoringOptions
+	CheckOverrides     CheckOverrides
 }
 
 // Filter filters out the report
@@ -40,6 +47,16 @@ func Filter(ctx context.Context, report types.Report, opts FilterOptions) error
 		return xerrors.Errorf("%s error: %w", opts.IgnoreFile, err)
 	}
 
+	// The baseline is generated before other filters so that it contains all the secrets.
+	if err = applySecretBaseline(&report, opts.SecretBaseline); err != nil {
+		return xerrors.Errorf("secret baseline error: %w", err)
+	}
+
+	// The scoring policy is compiled once rather than for each result
+	if opts.Scoring, err = opts.Scoring.prepare(ctx); err != nil {
+		return xerrors.Errorf("scoring policy error: %w", err)
+	}
+
 	for i := range report.Results {
 		if err = FilterResult(ctx, &report.Results[i], ignoreConf, opts); err != nil {
 			return xerrors.Errorf("unable to filter vulnerabilities: %w", err)
@@ -54,6 +71,11 @@ func Filter(ctx context.Context, report types.Report, opts FilterOptions) error
 		return xerrors.Errorf("VEX error: %w", err)
 	}
 
+	// Suppress the findings in the baseline after other filters so that the summary of misconfigurations is adjusted.
+	if err = applyBaseline(&report, opts.Baseline); err != nil {
+		return xerrors.Errorf("baseline error: %w", err)
+	}
+
 	return nil
 }
 
@@ -65,9 +87,10 @@ func FilterResult(ctx context.Context, result *types.Result, ignoreConf IgnoreCo
 	})
 
 	filterVulnerabilities(result, severities, opt.IgnoreStatuses, ignoreConf)
+	applyCheckOverrides(result, opt.CheckOverrides)
 	filterMisconfigurations(result, severities, opt.IncludeNonFailures, ignoreConf)
 	filterSecrets(result, severities, ignoreConf)
-	filterLicenses(result, severities, opt.IgnoreLicenses, ignoreConf)
+	filterLicenses(result, severities, opt.IgnoreLicenses, opt.LicensePolicy, opt.ProjectLicense, ignoreConf)
 
 	if opt.PolicyFile != "" {
 		if err := applyPolicy(ctx, result, opt.PolicyFile); err != nil {
@@ -76,6 +99,12 @@ func FilterResult(ctx context.Context, result *types.Result, ignoreConf IgnoreCo
 	}
 	sort.Sort(types.BySeverity(result.Vulnerabilities))
 
+	if opt.Scoring.PolicyFile != "" {
+		if err := applyScoringPolicy(ctx, result, opt.Scoring); err != nil {
+			return xerrors.Errorf("failed to apply the scoring policy: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -168,7 +197,8 @@ func filterSecrets(result *types.Result, severities []string, ignoreConfig Ignor
 	result.Secrets = filtered
 }
 
-func filterLicenses(result *types.Result, severities, ignoreLicenseNames []string, ignoreConfig IgnoreConfig) {
+func filterLicenses(result *types.Result, severities, ignoreLicenseNames []string, policy licensing.Policy,
+	compatibility licensing.Compatibility, ignoreConfig IgnoreConfig) {
 	// Merge ignore license names into ignored findings
 	var ignoreLicenses IgnoreConfig
 	for _, licenseName := range ignoreLicenseNames {
@@ -177,8 +207,23 @@ func filterLicenses(result *types.Result, severities, ignoreLicenseNames []strin
 		})
 	}
 
+	expressions := packageLicenseExpressions(result.Licenses)
+
 	var filtered []types.DetectedLicense
 	for _, l := range result.Licenses {
+		l.Expression = expressions[packageLicenseKey(l)]
+		l.Incompatibility = compatibility.Evaluate(l.Name)
+
+		// Only policy violations are reported when the license policy is configured
+		if policy.Enabled() {
+			violation, severity := policy.Evaluate(l.Name)
+			if violation == "" {
+				continue
+			}
+			l.Violation = violation
+			l.Severity = severity
+		}
+
 		// Filter by severity
 		if !slices.Contains(severities, l.Severity) {
 			continue
@@ -203,6 +248,30 @@ func filterLicenses(result *types.Result, severities, ignoreLicenseNames []strin
 	result.Licenses = filtered
 }
 
+// packageLicenseExpressions returns the full license expressions of packages declaring multiple licenses
+func packageLicenseExpressions(licenses []types.DetectedLicense) map[string]string {
+	pkgLicenses := make(map[string][]string)
+	for _, l := range licenses {
+		if l.PkgName == "" {
+			continue
+		}
+		key := packageLicenseKey(l)
+		pkgLicenses[key] = append(pkgLicenses[key], l.Name)
+	}
+
+	expressions := make(map[string]string)
+	for key, names := range pkgLicenses {
+		if len(names) > 1 {
+			expressions[key] = licensing.JoinLicenses(names)
+		}
+	}
+	return expressions
+}
+
+func packageLicenseKey(l types.DetectedLicense) string {
+	return l.PkgName + "@" + l.FilePath
+}
+
 func summarize(status types.MisconfStatus, summary *types.MisconfSummary) {
 	switch status {
 	case types.MisconfStatusFailure:
@@ -13,6 +13,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+	"github.com/aquasecurity/trivy/pkg/licensing"
 	"github.com/aquasecurity/trivy/pkg/result"
 	"github.com/aquasecurity/trivy/pkg/types"
 	"github.com/aquasecurity/trivy/pkg/vex"
@@ -194,6 +195,9 @@ func TestFilter(t *testing.T) {
 		ignoreFile     string
 		policyFile     string
 		vexPath        string
+		licensePolicy  licensing.Policy
+		projectLicense licensing.Compatibility
+		checkOverrides result.CheckOverrides
 	}
 	tests := []struct {
 		name string
@@ -246,6 +250,60 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "check overrides",
+			args: args{
+				report: types.Report{
+					Results: []types.Result{
+						{
+							Misconfigurations: []types.DetectedMisconfiguration{
+								misconf1, // filtered
+								misconf2, // disabled
+								misconf3,
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+				},
+				checkOverrides: result.CheckOverrides{
+					"avd-id100": {
+						Severity: "low",
+					},
+					"id200": {
+						Disabled: true,
+					},
+					"AVD-ID300": {
+						Severity: "critical",
+						Tags:     []string{"team-a"},
+					},
+				},
+			},
+			want: types.Report{
+				Results: []types.Result{
+					{
+						MisconfSummary: &types.MisconfSummary{
+							Successes: 0,
+							Failures:  1,
+						},
+						Misconfigurations: []types.DetectedMisconfiguration{
+							{
+								Type:     "Kubernetes Security Check",
+								ID:       "ID300",
+								AVDID:    "AVD-ID300",
+								Title:    "Bad Job",
+								Message:  "something bad",
+								Severity: dbTypes.SeverityCritical.String(),
+								Tags:     []string{"team-a"},
+								Status:   types.MisconfStatusFailure,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "filter by VEX",
 			args: args{
@@ -709,6 +767,126 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "license policy",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						{
+							Licenses: []types.DetectedLicense{
+								license1,
+								{
+									Name:     "MIT",
+									Severity: dbTypes.SeverityLow.String(),
+									PkgName:  "foo",
+									Category: "notice",
+								},
+								{
+									Name:     "Apache-2.0 OR LGPL-2.1-only",
+									Severity: dbTypes.SeverityUnknown.String(),
+									PkgName:  "bar",
+									Category: "unknown",
+								},
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityCritical,
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityMedium,
+				},
+				licensePolicy: licensing.NewPolicy([]string{"MIT"}, []string{"LGPL-2.1-only"}, []string{"GPL-3.0-only"}),
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Licenses: []types.DetectedLicense{
+							{
+								Name:       "GPL-3.0",
+								Severity:   dbTypes.SeverityCritical.String(),
+								FilePath:   "usr/share/gcc/python/libstdcxx/v6/__init__.py",
+								Category:   "restricted",
+								Confidence: 1,
+								Violation:  ftypes.ViolationForbidden,
+							},
+							{
+								Name:      "Apache-2.0 OR LGPL-2.1-only",
+								Severity:  dbTypes.SeverityMedium.String(),
+								PkgName:   "bar",
+								Category:  "unknown",
+								Violation: ftypes.ViolationNotAllowed,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "license expressions and compatibility",
+			args: args{
+				report: types.Report{
+					Results: types.Results{
+						{
+							Licenses: []types.DetectedLicense{
+								{
+									Name:     "MIT",
+									Severity: dbTypes.SeverityLow.String(),
+									PkgName:  "foo",
+									Category: "notice",
+								},
+								{
+									Name:     "GPL-3.0-only",
+									Severity: dbTypes.SeverityHigh.String(),
+									PkgName:  "foo",
+									Category: "restricted",
+								},
+								{
+									Name:     "GPL-3.0-only OR MIT",
+									Severity: dbTypes.SeverityLow.String(),
+									PkgName:  "bar",
+									Category: "notice",
+								},
+							},
+						},
+					},
+				},
+				severities: []dbTypes.Severity{
+					dbTypes.SeverityHigh,
+					dbTypes.SeverityLow,
+				},
+				projectLicense: licensing.NewCompatibility("Apache-2.0"),
+			},
+			want: types.Report{
+				Results: types.Results{
+					{
+						Licenses: []types.DetectedLicense{
+							{
+								Name:       "MIT",
+								Severity:   dbTypes.SeverityLow.String(),
+								PkgName:    "foo",
+								Category:   "notice",
+								Expression: "MIT AND GPL-3.0-only",
+							},
+							{
+								Name:            "GPL-3.0-only",
+								Severity:        dbTypes.SeverityHigh.String(),
+								PkgName:         "foo",
+								Category:        "restricted",
+								Expression:      "MIT AND GPL-3.0-only",
+								Incompatibility: "GPL-3.0-only is incompatible with the project license Apache-2.0",
+							},
+							{
+								Name:     "GPL-3.0-only OR MIT",
+								Severity: dbTypes.SeverityLow.String(),
+								PkgName:  "bar",
+								Category: "notice",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "happy path with duplicates, one with empty fixed version",
 			args: args{
@@ -1018,6 +1196,9 @@ func TestFilter(t *testing.T) {
 				IgnoreStatuses: tt.args.ignoreStatuses,
 				IgnoreFile:     tt.args.ignoreFile,
 				PolicyFile:     tt.args.policyFile,
+				LicensePolicy:  tt.args.licensePolicy,
+				ProjectLicense: tt.args.projectLicense,
+				CheckOverrides: tt.args.checkOverrides,
 			})
 			require.NoError(t, err)
 			assert.Equal(t, tt.want, tt.args.report)
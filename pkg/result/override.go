@@ -0,0 +1,64 @@
+package result
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// CheckOverride overrides the metadata of a misconfiguration check, such as a built-in check,
+// without modifying the check itself.
+type CheckOverride struct {
+	// Severity replaces the severity of the check if set
+	Severity string `mapstructure:"severity"`
+
+	// Tags are added to the misconfigurations detected by the check
+	Tags []string `mapstructure:"tags"`
+
+	// Disabled drops the misconfigurations detected by the check, whether they fail or not
+	Disabled bool `mapstructure:"disabled"`
+}
+
+// CheckOverrides maps check IDs or AVD IDs to their overrides.
+// IDs are case-insensitive as keys of the config file are lowercased.
+type CheckOverrides map[string]CheckOverride
+
+func (o CheckOverrides) find(misconf types.DetectedMisconfiguration) (CheckOverride, bool) {
+	for id, override := range o {
+		if strings.EqualFold(id, misconf.AVDID) || strings.EqualFold(id, misconf.ID) {
+			return override, true
+		}
+	}
+	return CheckOverride{}, false
+}
+
+// applyCheckOverrides applies the overrides to the misconfigurations.
+// It runs before the misconfigurations are filtered so that the overridden severity is used for filtering.
+func applyCheckOverrides(result *types.Result, overrides CheckOverrides) {
+	if len(overrides) == 0 || len(result.Misconfigurations) == 0 {
+		return
+	}
+
+	result.Misconfigurations = lo.FilterMap(result.Misconfigurations,
+		func(misconf types.DetectedMisconfiguration, _ int) (types.DetectedMisconfiguration, bool) {
+			override, ok := overrides.find(misconf)
+			if !ok {
+				return misconf, true
+			} else if override.Disabled {
+				return misconf, false
+			}
+
+			if override.Severity != "" {
+				misconf.Severity = strings.ToUpper(override.Severity)
+			}
+			for _, tag := range override.Tags {
+				if !slices.Contains(misconf.Tags, tag) {
+					misconf.Tags = append(misconf.Tags, tag)
+				}
+			}
+			return misconf, true
+		})
+}
@@ -0,0 +1,127 @@
+package result
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/open-policy-agent/opa/storage/inmem"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// ScoringOptions holds options for calculating custom risk scores
+type ScoringOptions struct {
+	// PolicyFile is the Rego file path defining "data.trivy.score"
+	PolicyFile string
+
+	// AssetCriticality is passed to the policy as "data.asset.criticality"
+	AssetCriticality string
+
+	// MinScore filters out findings scored lower than this value
+	MinScore float64
+
+	// query is the scoring policy prepared once for all the results
+	query *rego.PreparedEvalQuery
+}
+
+// prepare reads and compiles the scoring policy unless it is already prepared
+func (o ScoringOptions) prepare(ctx context.Context) (ScoringOptions, error) {
+	if o.PolicyFile == "" || o.query != nil {
+		return o, nil
+	}
+
+	policy, err := os.ReadFile(o.PolicyFile)
+	if err != nil {
+		return o, xerrors.Errorf("unable to read the scoring policy file: %w", err)
+	}
+
+	store := inmem.NewFromObject(map[string]any{
+		"asset": map[string]any{
+			"criticality": o.AssetCriticality,
+		},
+	})
+
+	query, err := rego.New(
+		rego.Query("data.trivy.score"),
+		rego.Module("lib.rego", module),
+		rego.Module("trivy.rego", string(policy)),
+		rego.Store(store),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return o, xerrors.Errorf("unable to prepare for eval: %w", err)
+	}
+	o.query = &query
+	return o, nil
+}
+
+// applyScoringPolicy evaluates the scoring policy for each vulnerability and misconfiguration,
+// and stores the calculated score into RiskScore.
+func applyScoringPolicy(ctx context.Context, result *types.Result, opts ScoringOptions) error {
+	// The policy is prepared by the caller, except when the result is filtered alone
+	opts, err := opts.prepare(ctx)
+	if err != nil {
+		return err
+	}
+	query := *opts.query
+
+	// Vulnerabilities
+	var scoredVulns []types.DetectedVulnerability
+	for _, vuln := range result.Vulnerabilities {
+		if vuln.RiskScore, err = evaluateScore(ctx, query, vuln); err != nil {
+			return err
+		}
+		if vuln.RiskScore < opts.MinScore {
+			continue
+		}
+		scoredVulns = append(scoredVulns, vuln)
+	}
+	// Sort in descending order of score. Vulnerabilities with the same score keep the severity order.
+	slices.SortStableFunc(scoredVulns, func(a, b types.DetectedVulnerability) int {
+		return cmp.Compare(b.RiskScore, a.RiskScore)
+	})
+	result.Vulnerabilities = scoredVulns
+
+	// Misconfigurations
+	var scoredMisconfs []types.DetectedMisconfiguration
+	for _, misconf := range result.Misconfigurations {
+		if misconf.RiskScore, err = evaluateScore(ctx, query, misconf); err != nil {
+			return err
+		}
+		if misconf.Status == types.MisconfStatusFailure && misconf.RiskScore < opts.MinScore {
+			result.MisconfSummary.Failures--
+			continue
+		}
+		scoredMisconfs = append(scoredMisconfs, misconf)
+	}
+	slices.SortStableFunc(scoredMisconfs, func(a, b types.DetectedMisconfiguration) int {
+		return cmp.Compare(b.RiskScore, a.RiskScore)
+	})
+	result.Misconfigurations = scoredMisconfs
+
+	return nil
+}
+
+func evaluateScore(ctx context.Context, query rego.PreparedEvalQuery, input any) (float64, error) {
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return 0, xerrors.Errorf("unable to evaluate the scoring policy: %w", err)
+	} else if len(results) == 0 {
+		// Handle undefined result.
+		return 0, nil
+	}
+	score, ok := results[0].Expressions[0].Value.(json.Number)
+	if !ok {
+		// Handle unexpected result type.
+		return 0, xerrors.New("the scoring policy must return a number")
+	}
+	f, err := score.Float64()
+	if err != nil {
+		return 0, xerrors.Errorf("invalid score: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,145 @@
+package result_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/result"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestFilter_Scoring(t *testing.T) {
+	vuln1 := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2019-0001",
+		PkgName:          "foo",
+		InstalledVersion: "1.2.3",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityCritical.String(),
+			CVSS: dbTypes.VendorCVSS{
+				"nvd": {
+					V3Score: 9.8,
+				},
+			},
+		},
+	}
+	vuln2 := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2019-0002",
+		PkgName:          "foo",
+		InstalledVersion: "1.2.3",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityLow.String(),
+			CVSS: dbTypes.VendorCVSS{
+				"nvd": {
+					V3Score: 3.1,
+				},
+			},
+		},
+	}
+	vuln3 := types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2019-0003",
+		PkgName:          "foo",
+		InstalledVersion: "1.2.3",
+		Vulnerability: dbTypes.Vulnerability{
+			Severity: dbTypes.SeverityMedium.String(),
+			CVSS: dbTypes.VendorCVSS{
+				"nvd": {
+					V3Score: 4.5,
+				},
+			},
+		},
+	}
+	misconf := types.DetectedMisconfiguration{
+		ID:       "AVD-TEST-0001",
+		AVDID:    "AVD-TEST-0001",
+		Severity: dbTypes.SeverityHigh.String(),
+		Status:   types.MisconfStatusFailure,
+	}
+
+	tests := []struct {
+		name    string
+		opts    result.ScoringOptions
+		want    []types.DetectedVulnerability
+		wantMis []types.DetectedMisconfiguration
+	}{
+		{
+			name: "sorted by score",
+			opts: result.ScoringOptions{
+				PolicyFile: "testdata/score.rego",
+			},
+			want: []types.DetectedVulnerability{
+				withScore(vuln2, 100),
+				withScore(vuln1, 9.8),
+				withScore(vuln3, 4.5),
+			},
+			wantMis: []types.DetectedMisconfiguration{
+				withMisconfScore(misconf, 5),
+			},
+		},
+		{
+			name: "asset criticality",
+			opts: result.ScoringOptions{
+				PolicyFile:       "testdata/score.rego",
+				AssetCriticality: "high",
+			},
+			want: []types.DetectedVulnerability{
+				withScore(vuln2, 100),
+				withScore(vuln1, 19.6),
+				withScore(vuln3, 9),
+			},
+			wantMis: []types.DetectedMisconfiguration{
+				withMisconfScore(misconf, 10),
+			},
+		},
+		{
+			name: "min score",
+			opts: result.ScoringOptions{
+				PolicyFile: "testdata/score.rego",
+				MinScore:   9,
+			},
+			want: []types.DetectedVulnerability{
+				withScore(vuln2, 100),
+				withScore(vuln1, 9.8),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := types.Report{
+				Results: types.Results{
+					{
+						Target: "foo",
+						Vulnerabilities: []types.DetectedVulnerability{
+							vuln1,
+							vuln2,
+							vuln3,
+						},
+						Misconfigurations: []types.DetectedMisconfiguration{
+							misconf,
+						},
+					},
+				},
+			}
+			err := result.Filter(context.Background(), report, result.FilterOptions{
+				Severities: []dbTypes.Severity{dbTypes.SeverityLow, dbTypes.SeverityMedium, dbTypes.SeverityHigh, dbTypes.SeverityCritical},
+				Scoring:    tt.opts,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.Results[0].Vulnerabilities)
+			assert.Equal(t, tt.wantMis, report.Results[0].Misconfigurations)
+		})
+	}
+}
+
+func withScore(vuln types.DetectedVulnerability, score float64) types.DetectedVulnerability {
+	vuln.RiskScore = score
+	return vuln
+}
+
+func withMisconfScore(misconf types.DetectedMisconfiguration, score float64) types.DetectedMisconfiguration {
+	misconf.RiskScore = score
+	return misconf
+}
@@ -0,0 +1,24 @@
+package trivy
+
+import rego.v1
+
+# Known exploited vulnerabilities are always prioritized
+kev := {"CVE-2019-0002"}
+
+multiplier := 2 if data.asset.criticality == "high"
+
+default multiplier := 1
+
+score := 100 if {
+	input.VulnerabilityID in kev
+}
+
+score := s * multiplier if {
+	not input.VulnerabilityID in kev
+	s := input.CVSS.nvd.V3Score
+}
+
+score := 5 * multiplier if {
+	input.AVDID
+	input.Severity == "HIGH"
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/metadata"
 	"github.com/aquasecurity/trivy/pkg/cache"
 	"github.com/aquasecurity/trivy/pkg/db"
+	"github.com/aquasecurity/trivy/pkg/downloader"
 	"github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/utils/fsutils"
@@ -37,13 +38,16 @@ type Server struct {
 	tokenHeader    string
 	pathPrefix     string
 	dbRepositories []name.Reference
+	dbURL          string
+	dbDelta        bool
 
 	// For OCI registries
 	types.RegistryOptions
 }
 
 // NewServer returns an instance of Server
-func NewServer(appVersion, addr, cacheDir, token, tokenHeader, pathPrefix string, dbRepositories []name.Reference, opt types.RegistryOptions) Server {
+func NewServer(appVersion, addr, cacheDir, token, tokenHeader, pathPrefix string, dbRepositories []name.Reference, dbURL string,
+	dbDelta bool, opt types.RegistryOptions) Server {
 	return Server{
 		appVersion:      appVersion,
 		addr:            addr,
@@ -53,6 +57,8 @@ func NewServer(appVersion, addr, cacheDir, token, tokenHeader, pathPrefix string
 		tokenHeader:     tokenHeader,
 		pathPrefix:      pathPrefix,
 		dbRepositories:  dbRepositories,
+		dbURL:           dbURL,
+		dbDelta:         dbDelta,
 		RegistryOptions: opt,
 	}
 }
@@ -63,7 +69,8 @@ func (s Server) ListenAndServe(ctx context.Context, serverCache cache.Cache, ski
 	dbUpdateWg := &sync.WaitGroup{}
 
 	go func() {
-		worker := newDBWorker(db.NewClient(s.dbDir, true, db.WithDBRepository(s.dbRepositories)))
+		worker := newDBWorker(db.NewClient(s.dbDir, true, db.WithDBRepository(s.dbRepositories), db.WithURL(s.dbURL),
+			db.WithDelta(s.dbDelta)))
 		for {
 			time.Sleep(updateInterval)
 			if err := worker.update(ctx, s.appVersion, s.dbDir, skipDBUpdate, dbUpdateWg, requestWg, s.RegistryOptions); err != nil {
@@ -193,6 +200,13 @@ func (w dbWorker) hotUpdate(ctx context.Context, dbDir string, dbUpdateWg, reque
 		return xerrors.Errorf("failed to copy the metadata file: %w", err)
 	}
 
+	// Copy download.json, which exists only if the DB is downloaded over HTTP(S)
+	if fsutils.FileExists(downloader.ArchiveMetadataPath(tmpDir)) {
+		if _, err = fsutils.CopyFile(downloader.ArchiveMetadataPath(tmpDir), downloader.ArchiveMetadataPath(dbDir)); err != nil {
+			return xerrors.Errorf("failed to copy the download metadata file: %w", err)
+		}
+	}
+
 	log.Info("Reopening DB...")
 	if err = db.Init(dbDir); err != nil {
 		return xerrors.Errorf("failed to open DB: %w", err)
@@ -182,7 +182,7 @@ func TestServer_newServeMux(t *testing.T) {
 			require.NoError(t, err)
 			defer func() { _ = c.Close() }()
 
-			s := NewServer("", "", "", tt.args.token, tt.args.tokenHeader, "", nil, ftypes.RegistryOptions{})
+			s := NewServer("", "", "", tt.args.token, tt.args.tokenHeader, "", nil, "", false, ftypes.RegistryOptions{})
 			ts := httptest.NewServer(s.NewServeMux(context.Background(), c, dbUpdateWg, requestWg))
 			defer ts.Close()
 
@@ -213,7 +213,7 @@ func Test_VersionEndpoint(t *testing.T) {
 	require.NoError(t, err)
 	defer func() { _ = c.Close() }()
 
-	s := NewServer("", "", "testdata/testcache", "", "", "", nil, ftypes.RegistryOptions{})
+	s := NewServer("", "", "testdata/testcache", "", "", "", nil, "", false, ftypes.RegistryOptions{})
 	ts := httptest.NewServer(s.NewServeMux(context.Background(), c, dbUpdateWg, requestWg))
 	defer ts.Close()
 
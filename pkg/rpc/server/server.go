@@ -2,13 +2,17 @@ package server
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/google/wire"
 	"github.com/samber/lo"
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/clock"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/rpc"
@@ -91,14 +95,47 @@ func (s *ScanServer) ToOptions(in *rpcScanner.ScanOptions) types.ScanOptions {
 	}
 }
 
+const (
+	// blobUploadTTL is how long incomplete chunked uploads are kept,
+	// e.g. when the client is interrupted in the middle of an upload.
+	blobUploadTTL = 10 * time.Minute
+
+	// maxBlobChunkSize is the maximum size of a chunk, matching the chunk size of clients
+	maxBlobChunkSize = 4 << 20 // 4MiB
+
+	// maxBlobSize is the maximum size of blob info assembled from chunks
+	maxBlobSize = 256 << 20 // 256MiB
+
+	// maxBlobChunks is the maximum number of chunks of an upload so that it doesn't exceed maxBlobSize
+	maxBlobChunks = maxBlobSize / maxBlobChunkSize
+
+	// maxBlobUploads is the maximum number of incomplete uploads kept at the same time
+	maxBlobUploads = 16
+)
+
 // CacheServer implements the cache
 type CacheServer struct {
 	cache cache.Cache
+
+	mu      sync.Mutex
+	uploads map[string]*blobUpload
+}
+
+// blobUpload holds chunks of a blob until all of them are received
+type blobUpload struct {
+	diffID    string
+	total     int32
+	chunks    map[int32][]byte
+	size      int // the total size of the received chunks
+	updatedAt time.Time
 }
 
 // NewCacheServer is the factory method for cacheServer
 func NewCacheServer(c cache.Cache) *CacheServer {
-	return &CacheServer{cache: c}
+	return &CacheServer{
+		cache:   c,
+		uploads: make(map[string]*blobUpload),
+	}
 }
 
 // PutArtifact puts the artifacts in cache
@@ -125,6 +162,80 @@ func (s *CacheServer) PutBlob(_ context.Context, in *rpcCache.PutBlobRequest) (*
 	return &emptypb.Empty{}, nil
 }
 
+// PutBlobChunk receives a part of a serialized PutBlobRequest that is too large to be sent at once.
+// The blob is stored in cache when all the chunks are received.
+func (s *CacheServer) PutBlobChunk(ctx context.Context, in *rpcCache.PutBlobChunkRequest) (*emptypb.Empty, error) {
+	data, err := s.addChunk(ctx, in)
+	if err != nil {
+		return nil, teeError(xerrors.Errorf("invalid blob chunk: %w", err))
+	} else if data == nil {
+		// Waiting for the remaining chunks
+		return &emptypb.Empty{}, nil
+	}
+
+	var req rpcCache.PutBlobRequest
+	if err = proto.Unmarshal(data, &req); err != nil {
+		return nil, teeError(xerrors.Errorf("unable to unmarshal blob chunks: %w", err))
+	} else if req.DiffId != in.DiffId {
+		return nil, teeError(xerrors.Errorf("diff ID mismatch: %s, %s", req.DiffId, in.DiffId))
+	}
+	return s.PutBlob(ctx, &req)
+}
+
+// addChunk stores the chunk and returns the whole data once all the chunks of the upload are received
+func (s *CacheServer) addChunk(ctx context.Context, in *rpcCache.PutBlobChunkRequest) ([]byte, error) {
+	if in.UploadId == "" {
+		return nil, xerrors.New("empty upload ID")
+	} else if in.Total <= 0 || in.Index < 0 || in.Index >= in.Total {
+		return nil, xerrors.Errorf("chunk index out of range: %d/%d", in.Index, in.Total)
+	} else if in.Total > maxBlobChunks {
+		return nil, xerrors.Errorf("too many chunks: %d (max: %d)", in.Total, maxBlobChunks)
+	} else if len(in.Data) > maxBlobChunkSize {
+		return nil, xerrors.Errorf("chunk too large: %d bytes (max: %d)", len(in.Data), maxBlobChunkSize)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock.Now(ctx)
+	for id, upload := range s.uploads {
+		if now.Sub(upload.updatedAt) > blobUploadTTL {
+			delete(s.uploads, id)
+		}
+	}
+
+	upload, ok := s.uploads[in.UploadId]
+	if !ok {
+		if len(s.uploads) >= maxBlobUploads {
+			return nil, xerrors.Errorf("too many uploads in progress (max: %d)", maxBlobUploads)
+		}
+		upload = &blobUpload{
+			diffID: in.DiffId,
+			total:  in.Total,
+			chunks: make(map[int32][]byte),
+		}
+		s.uploads[in.UploadId] = upload
+	} else if upload.diffID != in.DiffId || upload.total != in.Total {
+		return nil, xerrors.Errorf("chunk doesn't match upload %s", in.UploadId)
+	}
+
+	// The chunk might be sent again when the client retries
+	upload.size += len(in.Data) - len(upload.chunks[in.Index])
+	upload.chunks[in.Index] = in.Data
+	upload.updatedAt = now
+
+	if int32(len(upload.chunks)) < upload.total {
+		return nil, nil
+	}
+	delete(s.uploads, in.UploadId)
+
+	data := make([]byte, 0, upload.size)
+	for i := range upload.total {
+		data = append(data, upload.chunks[i]...)
+	}
+	return data, nil
+}
+
 // MissingBlobs returns missing blobs from cache
 func (s *CacheServer) MissingBlobs(_ context.Context, in *rpcCache.MissingBlobsRequest) (*rpcCache.MissingBlobsResponse, error) {
 	missingArtifact, blobIDs, err := s.cache.MissingBlobs(in.ArtifactId, in.BlobIds)
@@ -3,12 +3,15 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/xerrors"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/utils"
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
 	"github.com/aquasecurity/trivy/pkg/cache"
+	"github.com/aquasecurity/trivy/pkg/clock"
 	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/scanner"
 	"github.com/aquasecurity/trivy/pkg/types"
@@ -520,6 +524,150 @@ func TestCacheServer_PutBlob(t *testing.T) {
 	}
 }
 
+func TestCacheServer_PutBlobChunk(t *testing.T) {
+	diffID := "sha256:b2a1a2d80bf0c747a4f6b0ca6af5eef23f043fcdb1ed4f3a3e750aef2dc68079"
+	data, err := proto.Marshal(&rpcCache.PutBlobRequest{
+		DiffId: diffID,
+		BlobInfo: &rpcCache.BlobInfo{
+			SchemaVersion: 2,
+			DiffId:        diffID,
+			OpaqueDirs:    []string{"etc/"},
+		},
+	})
+	require.NoError(t, err)
+	half := len(data) / 2
+
+	newChunk := func(uploadID string, index int32, b []byte) *rpcCache.PutBlobChunkRequest {
+		return &rpcCache.PutBlobChunkRequest{
+			DiffId:   diffID,
+			UploadId: uploadID,
+			Index:    index,
+			Total:    2,
+			Data:     b,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		chunks   []*rpcCache.PutBlobChunkRequest
+		interval time.Duration
+		putBlob  bool
+		wantErr  string
+	}{
+		{
+			name: "happy path",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 0, data[:half]),
+				newChunk("upload1", 1, data[half:]),
+			},
+			putBlob: true,
+		},
+		{
+			name: "happy path: out of order",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 1, data[half:]),
+				newChunk("upload1", 0, data[:half]),
+			},
+			putBlob: true,
+		},
+		{
+			name: "incomplete upload",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 0, data[:half]),
+				newChunk("upload2", 1, data[half:]),
+			},
+		},
+		{
+			name: "expired upload",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 0, data[:half]),
+				newChunk("upload1", 1, data[half:]),
+			},
+			interval: blobUploadTTL + time.Minute,
+		},
+		{
+			name: "sad path: index out of range",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 2, data),
+			},
+			wantErr: "chunk index out of range: 2/2",
+		},
+		{
+			name: "sad path: empty upload ID",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("", 0, data),
+			},
+			wantErr: "empty upload ID",
+		},
+		{
+			name: "sad path: too many chunks",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				{
+					DiffId:   diffID,
+					UploadId: "upload1",
+					Total:    maxBlobChunks + 1,
+					Data:     data,
+				},
+			},
+			wantErr: "too many chunks: 65 (max: 64)",
+		},
+		{
+			name: "sad path: chunk too large",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 0, make([]byte, maxBlobChunkSize+1)),
+			},
+			wantErr: "chunk too large",
+		},
+		{
+			name: "sad path: too many uploads",
+			chunks: lo.Times(maxBlobUploads+1, func(i int) *rpcCache.PutBlobChunkRequest {
+				return newChunk(fmt.Sprintf("upload%d", i), 0, data[:half])
+			}),
+			wantErr: "too many uploads in progress (max: 16)",
+		},
+		{
+			name: "sad path: broken data",
+			chunks: []*rpcCache.PutBlobChunkRequest{
+				newChunk("upload1", 0, data[:half]),
+				newChunk("upload1", 1, []byte("broken")),
+			},
+			wantErr: "unable to unmarshal blob chunks",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCache := new(mockCache)
+			if tt.putBlob {
+				mockCache.ApplyPutBlobExpectation(cache.ArtifactCachePutBlobExpectation{
+					Args: cache.ArtifactCachePutBlobArgs{
+						BlobID: diffID,
+						BlobInfo: ftypes.BlobInfo{
+							SchemaVersion: 2,
+							DiffID:        diffID,
+							OpaqueDirs:    []string{"etc/"},
+						},
+					},
+				})
+			}
+
+			s := NewCacheServer(mockCache)
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			for _, chunk := range tt.chunks {
+				ctx := clock.With(context.Background(), now)
+				_, err = s.PutBlobChunk(ctx, chunk)
+				now = now.Add(tt.interval)
+			}
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			mockCache.MockArtifactCache.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCacheServer_MissingBlobs(t *testing.T) {
 	type args struct {
 		ctx context.Context
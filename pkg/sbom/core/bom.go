@@ -33,16 +33,18 @@ const (
 	PropertyLabelsPrefix = "Labels"
 
 	// Package properties
-	PropertyPkgID           = "PkgID"
-	PropertyPkgType         = "PkgType"
-	PropertySrcName         = "SrcName"
-	PropertySrcVersion      = "SrcVersion"
-	PropertySrcRelease      = "SrcRelease"
-	PropertySrcEpoch        = "SrcEpoch"
-	PropertyModularitylabel = "Modularitylabel"
-	PropertyFilePath        = "FilePath"
-	PropertyLayerDigest     = "LayerDigest"
-	PropertyLayerDiffID     = "LayerDiffID"
+	PropertyPkgID             = "PkgID"
+	PropertyPkgType           = "PkgType"
+	PropertySrcName           = "SrcName"
+	PropertySrcVersion        = "SrcVersion"
+	PropertySrcRelease        = "SrcRelease"
+	PropertySrcEpoch          = "SrcEpoch"
+	PropertyModularitylabel   = "Modularitylabel"
+	PropertyFilePath          = "FilePath"
+	PropertyLayerDigest       = "LayerDigest"
+	PropertyLayerDiffID       = "LayerDiffID"
+	PropertyUnresolved        = "Unresolved"
+	PropertyVersionConstraint = "VersionConstraint"
 
 	// Relationships
 	RelationshipDescribes RelationshipType = "describes"
@@ -58,6 +60,28 @@ type BOM struct {
 	SerialNumber string
 	Version      int
 
+	// BOMLinks are the locations of the external BOMs referenced by the BOM, e.g. SBOMs of components supplied by vendors.
+	// CycloneDX: "bom" external references
+	// SPDX: N/A
+	BOMLinks []string
+
+	// VEXLinks are the locations of the VEX documents referenced by the BOM.
+	// CycloneDX: "exploitability-statement" external references
+	// SPDX: N/A
+	VEXLinks []string
+
+	// EmbeddedVEX is true if the BOM contains VEX statements.
+	// CycloneDX: vulnerabilities
+	// SPDX: N/A
+	EmbeddedVEX bool
+
+	// VEXFiles are the paths of the VEX documents of the BOM, resolved from VEXLinks and EmbeddedVEX.
+	VEXFiles []string
+
+	// Linked are the external BOMs resolved from BOMLinks recursively.
+	// Their packages are scanned as part of the BOM.
+	Linked []*BOM
+
 	rootID        uuid.UUID
 	components    map[uuid.UUID]*Component
 	relationships map[uuid.UUID][]Relationship
@@ -142,6 +166,11 @@ type Component struct {
 	// SPDX: package.supplier
 	Supplier string
 
+	// ExternalReferences is a list of URLs related to the component, such as the source repository
+	// CycloneDX: component.externalReferences
+	// SPDX: N/A
+	ExternalReferences []ftypes.ExternalRef
+
 	// Files is a list of files that are part of the component.
 	// CycloneDX: component.properties
 	// SPDX: files
@@ -18,6 +18,7 @@ import (
 	"github.com/aquasecurity/trivy-db/pkg/vulnsrc/vulnerability"
 	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/digest"
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
 	"github.com/aquasecurity/trivy/pkg/log"
 	"github.com/aquasecurity/trivy/pkg/sbom/core"
 	sbomio "github.com/aquasecurity/trivy/pkg/sbom/io"
@@ -34,6 +35,15 @@ const (
 	timeLayout = "2006-01-02T15:04:05+00:00"
 )
 
+// externalReferenceTypes maps the reference types of packages to the CycloneDX ones
+var externalReferenceTypes = map[ftypes.RefType]cdx.ExternalReferenceType{
+	ftypes.RefVCS:          cdx.ERTypeVCS,
+	ftypes.RefIssueTracker: cdx.ERTypeIssueTracker,
+	ftypes.RefDistribution: cdx.ERTypeDistribution,
+	ftypes.RefWebsite:      cdx.ERTypeWebsite,
+	ftypes.RefOther:        cdx.ERTypeOther,
+}
+
 type Marshaler struct {
 	appVersion   string // Trivy version
 	bom          *core.BOM
@@ -119,6 +129,8 @@ func (m *Marshaler) MarshalComponent(component *core.Component) (*cdx.Component,
 		Hashes:     m.Hashes(component.Files),
 		Licenses:   m.Licenses(component.Licenses),
 		Properties: m.Properties(component.Properties),
+
+		ExternalReferences: m.ExternalReferences(component.ExternalReferences),
 	}
 	m.componentIDs[component.ID()] = cdxComponent.BOMRef
 
@@ -295,6 +307,23 @@ func (*Marshaler) Licenses(licenses []string) *cdx.Licenses {
 	return lo.ToPtr(cdx.Licenses(choices))
 }
 
+func (*Marshaler) ExternalReferences(refs []ftypes.ExternalRef) *[]cdx.ExternalReference {
+	if len(refs) == 0 {
+		return nil
+	}
+	cdxRefs := lo.Map(refs, func(ref ftypes.ExternalRef, _ int) cdx.ExternalReference {
+		refType, ok := externalReferenceTypes[ref.Type]
+		if !ok {
+			refType = cdx.ERTypeOther
+		}
+		return cdx.ExternalReference{
+			URL:  ref.URL,
+			Type: refType,
+		}
+	})
+	return &cdxRefs
+}
+
 func (*Marshaler) Properties(properties []core.Property) *[]cdx.Property {
 	cdxProps := make([]cdx.Property, 0, len(properties))
 	for _, property := range properties {
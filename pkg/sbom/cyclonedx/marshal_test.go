@@ -1289,6 +1289,16 @@ func TestMarshaler_MarshalReport(t *testing.T) {
 										Version:   "7.23.4",
 									},
 								},
+								ExternalReferences: []ftypes.ExternalRef{
+									{
+										Type: ftypes.RefVCS,
+										URL:  "https://github.com/babel/babel.git",
+									},
+									{
+										Type: ftypes.RefWebsite,
+										URL:  "https://babel.dev/docs/en/next/babel-helper-string-parser",
+									},
+								},
 							},
 						},
 					},
@@ -1404,6 +1414,16 @@ func TestMarshaler_MarshalReport(t *testing.T) {
 								Value: "yarn",
 							},
 						},
+						ExternalReferences: &[]cdx.ExternalReference{
+							{
+								URL:  "https://github.com/babel/babel.git",
+								Type: cdx.ERTypeVCS,
+							},
+							{
+								URL:  "https://babel.dev/docs/en/next/babel-helper-string-parser",
+								Type: cdx.ERTypeWebsite,
+							},
+						},
 					},
 				},
 				Vulnerabilities: &[]cdx.Vulnerability{},
@@ -58,6 +58,8 @@ func (b *BOM) UnmarshalJSON(data []byte) error {
 	b.BOM.SerialNumber = cdxBOM.SerialNumber
 	b.BOM.Version = cdxBOM.Version
 
+	b.parseLinks(cdxBOM)
+
 	return nil
 }
 
@@ -90,12 +92,43 @@ func (b *BOM) parseBOM(bom *cdx.BOM) error {
 	return nil
 }
 
+// parseLinks stores the references to external BOMs and VEX documents
+func (b *BOM) parseLinks(bom *cdx.BOM) {
+	var components []cdx.Component
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		components = append(components, *bom.Metadata.Component)
+	}
+	components = append(components, lo.FromPtr(bom.Components)...)
+
+	for _, c := range components {
+		for _, ref := range lo.FromPtr(c.ExternalReferences) {
+			switch {
+			case ref.URL == "":
+				continue
+			case ref.Type == cdx.ERTypeBOM:
+				b.BOM.BOMLinks = append(b.BOM.BOMLinks, ref.URL)
+			case ref.Type == cdx.ERTypeExploitabilityStatement:
+				b.BOM.VEXLinks = append(b.BOM.VEXLinks, ref.URL)
+			}
+		}
+	}
+	b.BOM.BOMLinks = lo.Uniq(b.BOM.BOMLinks)
+	b.BOM.VEXLinks = lo.Uniq(b.BOM.VEXLinks)
+	b.BOM.EmbeddedVEX = len(lo.FromPtr(bom.Vulnerabilities)) > 0
+}
+
 func (b *BOM) parseMetadataComponent(bom *cdx.BOM) (*core.Component, error) {
 	if bom.Metadata == nil || bom.Metadata.Component == nil {
 		return nil, nil
 	}
 	root, err := b.parseComponent(*bom.Metadata.Component)
-	if err != nil {
+	if errors.Is(err, ErrUnsupportedType) {
+		// e.g. SBOMs generated by Syft for directories have the "file" metadata component.
+		// The components can still be scanned without the root component.
+		log.Debug("Skipping the metadata component with the unsupported type",
+			log.String("bom-ref", bom.Metadata.Component.BOMRef), log.String("type", string(bom.Metadata.Component.Type)))
+		return nil, nil
+	} else if err != nil {
 		return nil, xerrors.Errorf("failed to parse metadata component: %w", err)
 	}
 	root.Root = true
@@ -152,9 +185,10 @@ func (b *BOM) parseComponent(c cdx.Component) (*core.Component, error) {
 				Digests: b.unmarshalHashes(c.Hashes),
 			},
 		},
-		PkgIdentifier: identifier,
-		Supplier:      b.unmarshalSupplier(c.Supplier),
-		Properties:    b.unmarshalProperties(c.Properties),
+		PkgIdentifier:      identifier,
+		Supplier:           b.unmarshalSupplier(c.Supplier),
+		ExternalReferences: b.unmarshalExternalReferences(c.ExternalReferences),
+		Properties:         b.unmarshalProperties(c.Properties),
 	}
 
 	return component, nil
@@ -235,6 +269,21 @@ func (b *BOM) unmarshalSupplier(supplier *cdx.OrganizationalEntity) string {
 	return supplier.Name
 }
 
+func (b *BOM) unmarshalExternalReferences(refs *[]cdx.ExternalReference) []ftypes.ExternalRef {
+	if refs == nil {
+		return nil
+	}
+	refTypes := lo.Invert(externalReferenceTypes)
+	return lo.FilterMap(*refs, func(ref cdx.ExternalReference, _ int) (ftypes.ExternalRef, bool) {
+		// Unsupported types, e.g. documentation, are skipped
+		refType, ok := refTypes[ref.Type]
+		return ftypes.ExternalRef{
+			Type: refType,
+			URL:  ref.URL,
+		}, ok && ref.URL != ""
+	})
+}
+
 func (b *BOM) unmarshalProperties(properties *[]cdx.Property) []core.Property {
 	var props []core.Property
 	for _, p := range lo.FromPtr(properties) {
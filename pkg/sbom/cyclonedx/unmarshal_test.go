@@ -490,6 +490,12 @@ func TestUnmarshaler_Unmarshal(t *testing.T) {
 									BOMRef: "pkg:composer/pear/log@1.13.1",
 								},
 								Licenses: []string{"MIT"},
+								ExternalReferences: []ftypes.ExternalRef{
+									{
+										Type: ftypes.RefVCS,
+										URL:  "https://github.com/pear/Log",
+									},
+								},
 							},
 							{
 								ID:      "pear/pear_exception@v1.0.0",
@@ -224,11 +224,16 @@ func (m *Decoder) decodePackage(ctx context.Context, c *core.Component) (*ftypes
 			pkg.Layer.Digest = prop.Value
 		case core.PropertyLayerDiffID:
 			pkg.Layer.DiffID = prop.Value
+		case core.PropertyUnresolved:
+			pkg.Unresolved = prop.Value == "true"
+		case core.PropertyVersionConstraint:
+			pkg.VersionConstraint = prop.Value
 		}
 	}
 
 	pkg.Identifier.BOMRef = c.PkgIdentifier.BOMRef
 	pkg.Licenses = c.Licenses
+	pkg.ExternalReferences = c.ExternalReferences
 
 	for _, f := range c.Files {
 		if f.Path != "" && pkg.FilePath == "" {
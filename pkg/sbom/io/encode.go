@@ -101,6 +101,8 @@ func (e *Encoder) rootComponent(r types.Report) (*core.Component, error) {
 		root.Type = core.TypeFilesystem
 	case artifact.TypeRepository:
 		root.Type = core.TypeRepository
+	case artifact.TypePackage:
+		root.Type = core.TypeLibrary
 	case artifact.TypeCycloneDX, artifact.TypeSPDX:
 		// When we scan SBOM file
 		// If SBOM file doesn't contain root component - use filesystem
@@ -354,6 +356,14 @@ func (*Encoder) component(result types.Result, pkg ftypes.Package) *core.Compone
 			Name:  core.PropertyLayerDiffID,
 			Value: pkg.Layer.DiffID,
 		},
+		{
+			Name:  core.PropertyUnresolved,
+			Value: lo.Ternary(pkg.Unresolved, "true", ""),
+		},
+		{
+			Name:  core.PropertyVersionConstraint,
+			Value: pkg.VersionConstraint,
+		},
 	}
 
 	var files []core.File
@@ -383,10 +393,11 @@ func (*Encoder) component(result types.Result, pkg ftypes.Package) *core.Compone
 			PURL:   pkg.Identifier.PURL,
 			BOMRef: pkg.Identifier.BOMRef,
 		},
-		Supplier:   pkg.Maintainer,
-		Licenses:   pkg.Licenses,
-		Files:      files,
-		Properties: filterProperties(properties),
+		Supplier:           pkg.Maintainer,
+		Licenses:           pkg.Licenses,
+		ExternalReferences: pkg.ExternalReferences,
+		Files:              files,
+		Properties:         filterProperties(properties),
 	}
 }
 
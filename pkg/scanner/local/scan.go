@@ -21,6 +21,7 @@ import (
 	"github.com/aquasecurity/trivy/pkg/iac/rego"
 	"github.com/aquasecurity/trivy/pkg/licensing"
 	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/namecheck"
 	"github.com/aquasecurity/trivy/pkg/scanner/langpkg"
 	"github.com/aquasecurity/trivy/pkg/scanner/ospkg"
 	"github.com/aquasecurity/trivy/pkg/scanner/post"
@@ -135,6 +136,9 @@ func (s Scanner) ScanTarget(ctx context.Context, target types.ScanTarget, option
 	// Scan licenses
 	results = append(results, s.scanLicenses(target, options)...)
 
+	// Check package names for typosquatting and dependency confusion
+	results = append(results, s.checkPkgNames(target, options)...)
+
 	// For WASM plugins and custom analyzers
 	if len(target.CustomResources) != 0 {
 		results = append(results, types.Result{
@@ -253,6 +257,51 @@ func (s Scanner) secretsToResults(secrets []ftypes.Secret, options types.ScanOpt
 	return results
 }
 
+func (s Scanner) checkPkgNames(target types.ScanTarget, options types.ScanOptions) types.Results {
+	if !options.CheckPkgNames {
+		return nil
+	}
+
+	var apps []ftypes.Application
+	for _, app := range target.Applications {
+		if app.FilePath != "" {
+			apps = append(apps, app)
+			continue
+		}
+		// Packages of aggregated applications, such as "node-pkg", are reported per file
+		for filePath, pkgs := range lo.GroupBy(app.Packages, func(pkg ftypes.Package) string { return pkg.FilePath }) {
+			apps = append(apps, ftypes.Application{
+				Type:     app.Type,
+				FilePath: filePath,
+				Packages: pkgs,
+			})
+		}
+	}
+
+	checker := namecheck.NewChecker(options.InternalNamespaces)
+	var results types.Results
+	for _, app := range apps {
+		var detected []types.DetectedMisconfiguration
+		for _, res := range checker.Check(app) {
+			detected = append(detected, toDetectedMisconfiguration(res, dbTypes.SeverityHigh, types.MisconfStatusFailure, ftypes.Layer{}))
+		}
+		if len(detected) == 0 {
+			continue
+		}
+		results = append(results, types.Result{
+			Target:            app.FilePath,
+			Class:             types.ClassConfig,
+			Type:              app.Type,
+			Misconfigurations: detected,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Target < results[j].Target
+	})
+	return results
+}
+
 func (s Scanner) scanLicenses(target types.ScanTarget, options types.ScanOptions) types.Results {
 	if !options.Scanners.Enabled(types.LicenseScanner) {
 		return nil
@@ -311,6 +360,7 @@ func (s Scanner) scanLicenses(target types.ScanTarget, options types.ScanOptions
 				Name:       finding.Name,
 				Confidence: finding.Confidence,
 				Link:       finding.Link,
+				Copyrights: license.Copyrights,
 			})
 
 		}
@@ -400,6 +450,7 @@ func toDetectedLicense(scanner licensing.Scanner, license, pkgName, filePath str
 		FilePath:   filePath,
 		Name:       license,
 		Text:       licenseText,
+		Copyrights: licensing.Copyrights(licenseText),
 		Confidence: 1.0,
 	}
 }
@@ -391,6 +391,7 @@ func TestScanner_Scan(t *testing.T) {
 							FilePath:   "opt/conda/lib/python3.11/site-packages/menuinst-2.0.2.dist-info/METADATA",
 							Name:       "CUSTOM License: (c) 2016 Continuum...",
 							Text:       "(c) 2016 Continuum Analytics, Inc. / http://continuum.io All Rights Reserved",
+							Copyrights: []string{"(c) 2016 Continuum Analytics, Inc. / http://continuum.io All Rights Reserved"},
 							Confidence: 1,
 						},
 					},
@@ -669,6 +670,97 @@ func TestScanner_Scan(t *testing.T) {
 				Eosl:   true,
 			},
 		},
+		{
+			name: "happy path with package name checks",
+			args: args{
+				target:   "/app",
+				layerIDs: []string{"sha256:5216338b40a7b96416b8b9858974bbe4acc3096ee60acbc4dfb1ee02aecceb10"},
+				options: types.ScanOptions{
+					PkgTypes:           []string{types.PkgTypeLibrary},
+					PkgRelationships:   ftypes.Relationships,
+					CheckPkgNames:      true,
+					InternalNamespaces: []string{"@acme/"},
+				},
+			},
+			applyLayersExpectation: ApplierApplyLayersExpectation{
+				Args: ApplierApplyLayersArgs{
+					BlobIDs: []string{"sha256:5216338b40a7b96416b8b9858974bbe4acc3096ee60acbc4dfb1ee02aecceb10"},
+				},
+				Returns: ApplierApplyLayersReturns{
+					Detail: ftypes.ArtifactDetail{
+						Applications: []ftypes.Application{
+							{
+								Type:     ftypes.Npm,
+								FilePath: "package-lock.json",
+								Packages: []ftypes.Package{
+									{
+										Name:      "crossenv",
+										Version:   "1.0.0",
+										Locations: []ftypes.Location{{StartLine: 10, EndLine: 15}},
+									},
+									{
+										Name:    "@acme/utils",
+										Version: "1.0.0",
+									},
+									{
+										Name:    "cross-env",
+										Version: "7.0.3",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantResults: types.Results{
+				{
+					Target: "package-lock.json",
+					Class:  types.ClassConfig,
+					Type:   ftypes.Npm,
+					Misconfigurations: []types.DetectedMisconfiguration{
+						{
+							Type:        "Package Name Check",
+							ID:          "SC005",
+							Title:       "Possible typosquatting",
+							Description: "The package name is very similar to a popular package. Attackers publish malicious packages with misspelled names of popular packages to be installed by mistake.",
+							Message:     "Package 'crossenv' is similar to the popular package 'cross-env'",
+							Namespace:   "supplychain.pkgname",
+							Resolution:  "Make sure the package is the intended one, and replace it with the popular package otherwise.",
+							Severity:    "HIGH",
+							PrimaryURL:  "https://owasp.org/www-project-top-10-ci-cd-security-risks/CICD-SEC-03-Dependency-Chain-Abuse",
+							References: []string{
+								"https://owasp.org/www-project-top-10-ci-cd-security-risks/CICD-SEC-03-Dependency-Chain-Abuse",
+							},
+							Status: types.MisconfStatusFailure,
+							CauseMetadata: ftypes.CauseMetadata{
+								Resource:  "crossenv",
+								StartLine: 10,
+								EndLine:   15,
+							},
+						},
+						{
+							Type:        "Package Name Check",
+							ID:          "SC006",
+							Title:       "Possible dependency confusion",
+							Description: "The package name matches an internal namespace. Attackers publish packages with the names of internal packages to public registries, so that package managers install them instead of the internal ones.",
+							Message:     "Package '@acme/utils' matches the internal namespace '@acme/', but the registry is unknown",
+							Namespace:   "supplychain.pkgname",
+							Resolution:  "Install internal packages only from the internal registry, e.g. with scoped registries, and reserve the names in the public registry.",
+							Severity:    "MEDIUM",
+							PrimaryURL:  "https://owasp.org/www-project-top-10-ci-cd-security-risks/CICD-SEC-03-Dependency-Chain-Abuse",
+							References: []string{
+								"https://owasp.org/www-project-top-10-ci-cd-security-risks/CICD-SEC-03-Dependency-Chain-Abuse",
+								"https://medium.com/@alex.birsan/dependency-confusion-4a5d60fec610",
+							},
+							Status: types.MisconfStatusFailure,
+							CauseMetadata: ftypes.CauseMetadata{
+								Resource: "@acme/utils",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "happy path with unsupported os",
 			args: args{
@@ -9,8 +9,10 @@ import (
 	"github.com/aquasecurity/trivy/pkg/cache"
 	"github.com/aquasecurity/trivy/pkg/clock"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/custom"
 	aimage "github.com/aquasecurity/trivy/pkg/fanal/artifact/image"
 	flocal "github.com/aquasecurity/trivy/pkg/fanal/artifact/local"
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact/remotepkg"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact/repo"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact/sbom"
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact/vm"
@@ -65,6 +67,18 @@ var StandaloneRepositorySet = wire.NewSet(
 	StandaloneSuperSet,
 )
 
+// StandalonePackageSet binds package dependencies
+var StandalonePackageSet = wire.NewSet(
+	remotepkg.ArtifactSet,
+	StandaloneSuperSet,
+)
+
+// StandaloneCustomSet binds dependencies of artifact types registered by embedding applications or modules
+var StandaloneCustomSet = wire.NewSet(
+	custom.ArtifactSet,
+	StandaloneSuperSet,
+)
+
 // StandaloneSBOMSet binds sbom dependencies
 var StandaloneSBOMSet = wire.NewSet(
 	sbom.NewArtifact,
@@ -105,6 +119,18 @@ var RemoteRepositorySet = wire.NewSet(
 	RemoteSuperSet,
 )
 
+// RemotePackageSet binds package dependencies for client/server mode
+var RemotePackageSet = wire.NewSet(
+	remotepkg.ArtifactSet,
+	RemoteSuperSet,
+)
+
+// RemoteCustomSet binds dependencies of registered artifact types for client/server mode
+var RemoteCustomSet = wire.NewSet(
+	custom.ArtifactSet,
+	RemoteSuperSet,
+)
+
 // RemoteSBOMSet binds sbom dependencies for client/server mode
 var RemoteSBOMSet = wire.NewSet(
 	sbom.NewArtifact,
@@ -0,0 +1,55 @@
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/base64"
+	"io"
+	"os"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"golang.org/x/xerrors"
+)
+
+// passwordEnv is the same environment variable as cosign,
+// so that keys generated by `cosign generate-key-pair` can be used as they are.
+const passwordEnv = "COSIGN_PASSWORD"
+
+// Sign signs the message with the private key and returns the base64-encoded signature.
+// The signature is compatible with `cosign sign-blob --key` and can be verified by `cosign verify-blob`.
+func Sign(keyPath string, message io.Reader) ([]byte, error) {
+	signer, err := signature.LoadSignerFromPEMFile(keyPath, crypto.SHA256, passFunc)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to load the signing key: %w", err)
+	}
+
+	sig, err := signer.SignMessage(message)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to sign: %w", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig)), nil
+}
+
+// Verify verifies the base64-encoded signature of the message with the public key.
+func Verify(keyPath string, message io.Reader, sig []byte) error {
+	verifier, err := signature.LoadVerifierFromPEMFile(keyPath, crypto.SHA256)
+	if err != nil {
+		return xerrors.Errorf("unable to load the public key: %w", err)
+	}
+
+	rawSig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sig)))
+	if err != nil {
+		return xerrors.Errorf("unable to decode the signature: %w", err)
+	}
+
+	if err = verifier.VerifySignature(bytes.NewReader(rawSig), message); err != nil {
+		return xerrors.Errorf("invalid signature: %w", err)
+	}
+	return nil
+}
+
+// passFunc returns the password of encrypted keys, such as cosign.key
+var passFunc cryptoutils.PassFunc = func(bool) ([]byte, error) {
+	return []byte(os.Getenv(passwordEnv)), nil
+}
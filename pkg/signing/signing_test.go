@@ -0,0 +1,73 @@
+package signing_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/signing"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	const report = `{"SchemaVersion": 2}`
+
+	tests := []struct {
+		name       string
+		signingKey string
+		password   string
+		verifyKey  string
+		message    string
+		wantErr    string
+	}{
+		{
+			name:       "PEM key",
+			signingKey: "testdata/ec.key",
+			verifyKey:  "testdata/ec.pub",
+			message:    report,
+		},
+		{
+			name:       "cosign key",
+			signingKey: "testdata/cosign.key",
+			password:   "test",
+			verifyKey:  "testdata/cosign.pub",
+			message:    report,
+		},
+		{
+			name:       "tampered report",
+			signingKey: "testdata/ec.key",
+			verifyKey:  "testdata/ec.pub",
+			message:    `{"SchemaVersion": 2, "Results": []}`,
+			wantErr:    "invalid signature",
+		},
+		{
+			name:       "different key",
+			signingKey: "testdata/ec.key",
+			verifyKey:  "testdata/cosign.pub",
+			message:    report,
+			wantErr:    "invalid signature",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COSIGN_PASSWORD", tt.password)
+
+			sig, err := signing.Sign(tt.signingKey, strings.NewReader(report))
+			require.NoError(t, err)
+
+			err = signing.Verify(tt.verifyKey, strings.NewReader(tt.message), sig)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSign_WrongPassword(t *testing.T) {
+	t.Setenv("COSIGN_PASSWORD", "wrong")
+	_, err := signing.Sign("testdata/cosign.key", strings.NewReader("{}"))
+	assert.ErrorContains(t, err, "unable to load the signing key")
+}
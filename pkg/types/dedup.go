@@ -0,0 +1,76 @@
+package types
+
+import (
+	"slices"
+	"strings"
+
+	ftypes "github.com/aquasecurity/trivy/pkg/fanal/types"
+)
+
+// DeduplicatedVulnerability represents a vulnerability found in the same package version of multiple targets
+type DeduplicatedVulnerability struct {
+	DetectedVulnerability
+	Targets []string
+}
+
+// VulnerabilityDeduplicator merges identical vulnerabilities of multiple targets,
+// e.g. the same vulnerability in the same OS package of many images.
+type VulnerabilityDeduplicator struct {
+	index map[string]int
+	vulns []DeduplicatedVulnerability
+}
+
+func NewVulnerabilityDeduplicator() *VulnerabilityDeduplicator {
+	return &VulnerabilityDeduplicator{
+		index: make(map[string]int),
+	}
+}
+
+// Add adds the vulnerabilities found in the target
+func (d *VulnerabilityDeduplicator) Add(target string, vulns []DetectedVulnerability) {
+	for _, vuln := range vulns {
+		key := strings.Join([]string{
+			vuln.VulnerabilityID,
+			vuln.PkgName,
+			vuln.InstalledVersion,
+			vuln.FixedVersion,
+			vuln.Status.String(),
+		}, "|")
+
+		if i, ok := d.index[key]; ok {
+			if !slices.Contains(d.vulns[i].Targets, target) {
+				d.vulns[i].Targets = append(d.vulns[i].Targets, target)
+			}
+			continue
+		}
+
+		// Drop the fields specific to the target
+		vuln.PkgPath = ""
+		vuln.Layer = ftypes.Layer{}
+		vuln.PkgIdentifier.UID = ""
+		vuln.PkgIdentifier.BOMRef = ""
+
+		d.index[key] = len(d.vulns)
+		d.vulns = append(d.vulns, DeduplicatedVulnerability{
+			DetectedVulnerability: vuln,
+			Targets:               []string{target},
+		})
+	}
+}
+
+// Vulnerabilities returns the deduplicated vulnerabilities in the order they were found
+func (d *VulnerabilityDeduplicator) Vulnerabilities() []DeduplicatedVulnerability {
+	return d.vulns
+}
+
+// DeduplicateVulnerabilities moves the vulnerabilities of the results to DeduplicatedVulnerabilities.
+// The results are copied so that the original report is not modified.
+func (r *Report) DeduplicateVulnerabilities() {
+	d := NewVulnerabilityDeduplicator()
+	r.Results = slices.Clone(r.Results)
+	for i := range r.Results {
+		d.Add(r.Results[i].Target, r.Results[i].Vulnerabilities)
+		r.Results[i].Vulnerabilities = nil
+	}
+	r.DeduplicatedVulnerabilities = d.Vulnerabilities()
+}
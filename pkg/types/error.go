@@ -4,14 +4,41 @@ import (
 	"fmt"
 )
 
+// ExitStatus classifies the outcome of a scan to choose the exit code
+type ExitStatus string
+
+const (
+	ExitStatusClean    ExitStatus = "clean"    // The scan completed without findings
+	ExitStatusFindings ExitStatus = "findings" // The scan completed with findings
+	ExitStatusPartial  ExitStatus = "partial"  // Some targets failed to be scanned
+	ExitStatusError    ExitStatus = "error"    // The scan failed, e.g. due to network errors
+)
+
+var ExitStatuses = []ExitStatus{
+	ExitStatusClean,
+	ExitStatusFindings,
+	ExitStatusPartial,
+	ExitStatusError,
+}
+
 type ExitError struct {
 	Code int
+
+	// Err is the error that caused the exit, if any
+	Err error
 }
 
 func (e *ExitError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("exit status %d: %s", e.Code, e.Err)
+	}
 	return fmt.Sprintf("exit status %d", e.Code)
 }
 
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
 // UserError represents an error with a user-friendly message.
 type UserError struct {
 	Message string
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SarifFingerprintKey is the key of the fingerprint in partialFingerprints of SARIF results.
+// The version suffix must be changed when the computation of the fingerprint changes.
+const SarifFingerprintKey = "trivyFingerprint/v1"
+
+// Fingerprint returns an identifier of the finding which is stable across scans.
+// It is computed from the rule ID, the package or file path and the logical location such as the resource name,
+// but not from line numbers and installed versions so that the finding can be tracked across commits.
+func Fingerprint(result Result, f finding) string {
+	// Files in container images have a leading slash
+	target := strings.TrimPrefix(result.Target, "/")
+
+	var parts []string
+	switch v := f.(type) {
+	case DetectedVulnerability:
+		if result.Class == ClassOSPkg {
+			// The target of OS packages contains the image name and the OS version, which are likely to change.
+			target = string(result.Type)
+		}
+		parts = []string{v.VulnerabilityID, target, v.PkgName, strings.TrimPrefix(v.PkgPath, "/")}
+	case DetectedMisconfiguration:
+		parts = []string{v.ID, target, v.CauseMetadata.Resource, v.Message}
+	case DetectedSecret:
+		// The fingerprint of the secret scanner is computed from the raw secret,
+		// so it is shared with the secret baseline and distinguishes secrets with the same redacted line.
+		if v.Fingerprint != "" {
+			return v.Fingerprint
+		}
+		// The match is redacted
+		parts = []string{v.RuleID, target, v.Match}
+	case DetectedLicense:
+		parts = []string{v.Name, target, v.PkgName, strings.TrimPrefix(v.FilePath, "/")}
+	default:
+		return ""
+	}
+
+	// Package names and paths may contain colons
+	h := sha256.Sum256([]byte(string(f.findingType()) + "\x00" + strings.Join(parts, "\x00")))
+	return "sha256:" + hex.EncodeToString(h[:])
+}
@@ -25,12 +25,27 @@ type DetectedLicense struct {
 	// Text holds a long license text if Trivy detects a license name as a license text
 	Text string
 
+	// Copyrights holds the copyright statements found in the license file or text
+	Copyrights []string `json:",omitempty"`
+
 	// Confidence is level of the match. The confidence level is between 0.0 and 1.0, with 1.0 indicating an
 	// exact match and 0.0 indicating a complete mismatch
 	Confidence float64
 
 	// Link is a SPDX link of the license
 	Link string
+
+	// Violation holds how the license breaks the license policy such as "forbidden".
+	// It is empty if no license policy is configured.
+	Violation types.LicenseViolation `json:",omitempty"`
+
+	// Expression holds the full license expression of the package when it declares multiple licenses,
+	// such as "MIT AND (Apache-2.0 OR BSD-3-Clause)".
+	Expression string `json:",omitempty"`
+
+	// Incompatibility describes why the license is incompatible with the project license.
+	// It is empty unless the project license is configured.
+	Incompatibility string `json:",omitempty"`
 }
 
 func (DetectedLicense) findingType() FindingType { return FindingTypeLicense }
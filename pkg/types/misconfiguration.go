@@ -16,10 +16,14 @@ type DetectedMisconfiguration struct {
 	Severity      string               `json:",omitempty"`
 	PrimaryURL    string               `json:",omitempty"`
 	References    []string             `json:",omitempty"`
+	Tags          []string             `json:",omitempty"`
 	Status        MisconfStatus        `json:",omitempty"`
 	Layer         ftypes.Layer         `json:",omitempty"`
 	CauseMetadata ftypes.CauseMetadata `json:",omitempty"`
 
+	// RiskScore is calculated by the scoring policy specified by users
+	RiskScore float64 `json:",omitempty"`
+
 	// For debugging
 	Traces []string `json:",omitempty"`
 }
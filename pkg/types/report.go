@@ -19,6 +19,12 @@ type Report struct {
 	Metadata      Metadata      `json:",omitempty"`
 	Results       Results       `json:",omitempty"`
 
+	// DeduplicatedVulnerabilities is filled in place of the vulnerabilities in Results when --dedup-findings is enabled
+	DeduplicatedVulnerabilities []DeduplicatedVulnerability `json:",omitempty"`
+
+	// Partial is true for early results of OS packages output before all the analyzers complete
+	Partial bool `json:",omitempty"`
+
 	// parsed SBOM
 	BOM *core.BOM `json:"-"` // Just for internal usage, not exported in JSON
 }
@@ -72,6 +78,16 @@ const (
 	FormatSPDXJSON   Format = "spdx-json"
 	FormatGitHub     Format = "github"
 	FormatCosignVuln Format = "cosign-vuln"
+
+	FormatLicenseObligations Format = "license-obligations"
+	FormatAttribution        Format = "attribution"
+	FormatGraph              Format = "graph"
+	FormatTUI                Format = "tui"
+	FormatLayers             Format = "layers"
+	FormatJUnit              Format = "junit"
+	FormatHTML               Format = "html"
+	FormatCSV                Format = "csv"
+	FormatMarkdown           Format = "markdown"
 )
 
 var (
@@ -85,6 +101,15 @@ var (
 		FormatSPDXJSON,
 		FormatGitHub,
 		FormatCosignVuln,
+		FormatLicenseObligations,
+		FormatAttribution,
+		FormatGraph,
+		FormatTUI,
+		FormatLayers,
+		FormatJUnit,
+		FormatHTML,
+		FormatCSV,
+		FormatMarkdown,
 	}
 	SupportedSBOMFormats = []Format{
 		FormatCycloneDX,
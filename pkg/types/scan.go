@@ -122,4 +122,6 @@ type ScanOptions struct {
 	FilePatterns        []string
 	IncludeDevDeps      bool
 	Distro              types.OS // Forced OS
+	CheckPkgNames       bool
+	InternalNamespaces  []string
 }
@@ -20,6 +20,9 @@ type DetectedVulnerability struct {
 	SeveritySource   types.SourceID       `json:",omitempty"`
 	PrimaryURL       string               `json:",omitempty"`
 
+	// RiskScore is calculated by the scoring policy specified by users
+	RiskScore float64 `json:",omitempty"`
+
 	// DataSource holds where the advisory comes from
 	DataSource *types.DataSource `json:",omitempty"`
 
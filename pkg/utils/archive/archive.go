@@ -0,0 +1,192 @@
+// Package archive writes and reads gzipped tarballs of files with their SHA-256 digests,
+// e.g. databases exported for air-gapped environments.
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// ValidFunc reports whether the file can be in the archive
+type ValidFunc func(name string) bool
+
+// Checksums returns the SHA-256 digests of the files in the format of "sha256sum",
+// so that archives can also be verified with "sha256sum -c".
+// The keys of files are the names in the archive and the values are the paths to the files.
+func Checksums(files map[string]string) ([]byte, error) {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		digest, err := sha256File(files[name])
+		if err != nil {
+			return nil, xerrors.Errorf("unable to calculate the digest of %s: %w", name, err)
+		}
+		fmt.Fprintf(&b, "%s  %s\n", digest, name)
+	}
+	return []byte(b.String()), nil
+}
+
+// VerifyChecksums verifies the digests listed in the checksum file in dir and returns the verified names.
+// It fails if a name is not safe or not valid, so that files outside dir are never read.
+func VerifyChecksums(dir, checksumFileName string, valid ValidFunc) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, checksumFileName))
+	if err != nil {
+		return nil, xerrors.Errorf("%s not found: %w", checksumFileName, err)
+	}
+	defer f.Close()
+
+	var verified []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		want, name, ok := strings.Cut(scanner.Text(), "  ")
+		if !ok || !safeName(name) || !valid(name) {
+			return nil, xerrors.Errorf("invalid line in %s: %q", checksumFileName, scanner.Text())
+		}
+		got, err := sha256File(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, xerrors.Errorf("unable to calculate the digest of %s: %w", name, err)
+		} else if got != want {
+			return nil, xerrors.Errorf("digest mismatch for %s (expected: %s, actual: %s)", name, want, got)
+		}
+		verified = append(verified, name)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, xerrors.Errorf("scan error: %w", err)
+	}
+	return verified, nil
+}
+
+// Write writes the files into a gzipped tarball.
+// The keys of files are the names in the archive and the values are the paths to the files.
+func Write(archivePath string, files map[string]string) (err error) {
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return xerrors.Errorf("create error: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if err = addFile(tw, name, files[name]); err != nil {
+			return xerrors.Errorf("unable to add %s: %w", name, err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		return xerrors.Errorf("tar close error: %w", err)
+	}
+	if err = gw.Close(); err != nil {
+		return xerrors.Errorf("gzip close error: %w", err)
+	}
+	return nil
+}
+
+// Extract extracts the gzipped tarball into dir.
+// It fails on anything but regular files with safe and valid names, so that it never writes outside dir.
+func Extract(archivePath, dir string, valid ValidFunc) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return xerrors.Errorf("open error: %w", err)
+	}
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return xerrors.Errorf("gzip error: %w", err)
+	}
+	defer gr.Close()
+
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return xerrors.Errorf("tar error: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg || !safeName(hdr.Name) || !valid(hdr.Name) {
+			return xerrors.Errorf("unexpected file in the archive: %s", hdr.Name)
+		}
+		filePath := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if err = os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+			return xerrors.Errorf("failed to mkdir: %w", err)
+		}
+		if err = writeFile(filePath, tr); err != nil {
+			return xerrors.Errorf("unable to extract %s: %w", hdr.Name, err)
+		}
+	}
+}
+
+// safeName reports whether the name is a clean relative path that doesn't point outside the directory
+func safeName(name string) bool {
+	return name != "" && name == path.Clean(name) && !path.IsAbs(name) &&
+		name != ".." && !strings.HasPrefix(name, "../")
+}
+
+func sha256File(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func addFile(tw *tar.Writer, name, filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func writeFile(filePath string, r io.Reader) error {
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,131 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/utils/archive"
+)
+
+func anyName(string) bool { return true }
+
+func TestWriteExtract(t *testing.T) {
+	srcDir := t.TempDir()
+	files := make(map[string]string)
+	for name, content := range map[string]string{
+		"db/trivy.db":   "trivy-db",
+		"metadata.json": `{"Version":2}`,
+	} {
+		filePath := filepath.Join(srcDir, filepath.Base(name))
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+		files[name] = filePath
+	}
+
+	checksums, err := archive.Checksums(files)
+	require.NoError(t, err)
+	checksumPath := filepath.Join(srcDir, "SHA256SUMS")
+	require.NoError(t, os.WriteFile(checksumPath, checksums, 0o644))
+	files["SHA256SUMS"] = checksumPath
+
+	archivePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+	require.NoError(t, archive.Write(archivePath, files))
+
+	dir := t.TempDir()
+	require.NoError(t, archive.Extract(archivePath, dir, anyName))
+
+	got, err := os.ReadFile(filepath.Join(dir, "db", "trivy.db"))
+	require.NoError(t, err)
+	assert.Equal(t, "trivy-db", string(got))
+
+	verified, err := archive.VerifyChecksums(dir, "SHA256SUMS", anyName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db/trivy.db", "metadata.json"}, verified)
+
+	// Tampered file
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metadata.json"), []byte("{}"), 0o644))
+	_, err = archive.VerifyChecksums(dir, "SHA256SUMS", anyName)
+	require.ErrorContains(t, err, "digest mismatch for metadata.json")
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		hdr     tar.Header
+		valid   archive.ValidFunc
+		wantErr string
+	}{
+		{
+			name: "happy path",
+			hdr: tar.Header{
+				Name:     "db/trivy.db",
+				Typeflag: tar.TypeReg,
+			},
+			valid: anyName,
+		},
+		{
+			name: "path traversal",
+			hdr: tar.Header{
+				Name:     "db/../../evil",
+				Typeflag: tar.TypeReg,
+			},
+			valid:   anyName,
+			wantErr: "unexpected file in the archive: db/../../evil",
+		},
+		{
+			name: "absolute path",
+			hdr: tar.Header{
+				Name:     "/etc/passwd",
+				Typeflag: tar.TypeReg,
+			},
+			valid:   anyName,
+			wantErr: "unexpected file in the archive: /etc/passwd",
+		},
+		{
+			name: "symlink",
+			hdr: tar.Header{
+				Name:     "db/trivy.db",
+				Typeflag: tar.TypeSymlink,
+				Linkname: "/etc/passwd",
+			},
+			valid:   anyName,
+			wantErr: "unexpected file in the archive: db/trivy.db",
+		},
+		{
+			name: "invalid name",
+			hdr: tar.Header{
+				Name:     "fanal/fanal.db",
+				Typeflag: tar.TypeReg,
+			},
+			valid: func(name string) bool {
+				return name == "db/trivy.db"
+			},
+			wantErr: "unexpected file in the archive: fanal/fanal.db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "archive.tar.gz")
+			f, err := os.Create(archivePath)
+			require.NoError(t, err)
+			gw := gzip.NewWriter(f)
+			tw := tar.NewWriter(gw)
+			require.NoError(t, tw.WriteHeader(&tt.hdr))
+			require.NoError(t, tw.Close())
+			require.NoError(t, gw.Close())
+			require.NoError(t, f.Close())
+
+			err = archive.Extract(archivePath, t.TempDir(), tt.valid)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
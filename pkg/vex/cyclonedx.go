@@ -65,7 +65,8 @@ func (v *CycloneDX) NotAffected(vuln types.DetectedVulnerability, product, _ *co
 				log.Int("version", link.Version()))
 			continue
 		}
-		if product.PkgIdentifier.Match(link.Reference()) {
+		// Packages of external BOMs are identified by BOM-Links
+		if product.PkgIdentifier.Match(link.Reference()) || product.PkgIdentifier.Match(affect) {
 			return types.NewModifiedFinding(vuln, stmt.Status, stmt.Justification, "CycloneDX VEX"), true
 		}
 	}
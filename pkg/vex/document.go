@@ -13,11 +13,18 @@ import (
 
 	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
 	"github.com/aquasecurity/trivy/pkg/sbom"
+	"github.com/aquasecurity/trivy/pkg/sbom/core"
 	"github.com/aquasecurity/trivy/pkg/sbom/cyclonedx"
 	"github.com/aquasecurity/trivy/pkg/types"
 )
 
 func NewDocument(filePath string, report *types.Report) (VEX, error) {
+	return newDocument(filePath, report.ArtifactType, report.BOM)
+}
+
+// newDocument loads the VEX document for the SBOM.
+// CycloneDX VEX refers to the components of the SBOM with BOM-Links.
+func newDocument(filePath string, artifactType artifact.Type, bom *core.BOM) (VEX, error) {
 	if filePath == "" {
 		return nil, xerrors.New("VEX file path is empty")
 	}
@@ -32,7 +39,7 @@ func NewDocument(filePath string, report *types.Report) (VEX, error) {
 	if ok, err := sbom.IsCycloneDXJSON(f); err != nil {
 		errs = multierror.Append(errs, err)
 	} else if ok {
-		return decodeCycloneDXJSON(f, report)
+		return decodeCycloneDXJSON(f, artifactType, bom)
 	}
 
 	// Try OpenVEX
@@ -52,7 +59,7 @@ func NewDocument(filePath string, report *types.Report) (VEX, error) {
 	return nil, xerrors.Errorf("unable to load VEX: %w", errs)
 }
 
-func decodeCycloneDXJSON(r io.ReadSeeker, report *types.Report) (*CycloneDX, error) {
+func decodeCycloneDXJSON(r io.ReadSeeker, artifactType artifact.Type, bom *core.BOM) (*CycloneDX, error) {
 	if _, err := r.Seek(0, io.SeekStart); err != nil {
 		return nil, xerrors.Errorf("seek error: %w", err)
 	}
@@ -60,10 +67,10 @@ func decodeCycloneDXJSON(r io.ReadSeeker, report *types.Report) (*CycloneDX, err
 	if err != nil {
 		return nil, xerrors.Errorf("json decode error: %w", err)
 	}
-	if report.ArtifactType != artifact.TypeCycloneDX {
+	if artifactType != artifact.TypeCycloneDX {
 		return nil, xerrors.New("CycloneDX VEX can be used with CycloneDX SBOM")
 	}
-	return newCycloneDX(report.BOM, vex), nil
+	return newCycloneDX(bom, vex), nil
 }
 
 func decodeOpenVEX(r io.ReadSeeker, source string) (*OpenVEX, error) {
@@ -0,0 +1,75 @@
+package vex
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"golang.org/x/xerrors"
+
+	"github.com/aquasecurity/trivy/pkg/fanal/artifact"
+	"github.com/aquasecurity/trivy/pkg/log"
+	"github.com/aquasecurity/trivy/pkg/sbom/core"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// SBOMReferenceSet is a set of the VEX documents of the scanned SBOM and the external BOMs linked from it,
+// e.g. VEX published by the vendors supplying components.
+// VEX documents of an external BOM are applied only to the packages of the BOM.
+type SBOMReferenceSet struct {
+	vexes []scopedVEX
+}
+
+// scopedVEX is a VEX document applied only to the packages identified by BOM-Links with the prefix
+type scopedVEX struct {
+	VEX
+	prefix string
+}
+
+func NewSBOMReferenceSet(report *types.Report) (VEX, error) {
+	if report.ArtifactType != artifact.TypeCycloneDX || report.BOM == nil {
+		return nil, xerrors.New("'--vex sbom-ref' can be used only when scanning CycloneDX SBOMs")
+	}
+
+	var vexes []scopedVEX
+	for i, bom := range append([]*core.BOM{report.BOM}, report.BOM.Linked...) {
+		// The VEX of the scanned SBOM is applied to all the packages
+		var prefix string
+		if i > 0 {
+			link, err := cdx.NewBOMLink(bom.SerialNumber, bom.Version, nil)
+			if err != nil {
+				log.Warn("VEX of the external BOM without serial number is ignored", log.Err(err))
+				continue
+			}
+			prefix = link.String() + "#"
+		}
+
+		for _, filePath := range bom.VEXFiles {
+			v, err := newDocument(filePath, report.ArtifactType, bom)
+			if err != nil {
+				return nil, xerrors.Errorf("unable to load VEX %q: %w", filePath, err)
+			}
+			vexes = append(vexes, scopedVEX{
+				VEX:    v,
+				prefix: prefix,
+			})
+		}
+	}
+
+	if len(vexes) == 0 {
+		log.Info("No VEX documents found in the SBOM")
+		return nil, nil
+	}
+	return &SBOMReferenceSet{vexes: vexes}, nil
+}
+
+func (s *SBOMReferenceSet) NotAffected(vuln types.DetectedVulnerability, product, subComponent *core.Component) (types.ModifiedFinding, bool) {
+	for _, v := range s.vexes {
+		if !strings.HasPrefix(product.PkgIdentifier.BOMRef, v.prefix) {
+			continue
+		}
+		if m, notAffected := v.NotAffected(vuln, product, subComponent); notAffected {
+			return m, true
+		}
+	}
+	return types.ModifiedFinding{}, false
+}
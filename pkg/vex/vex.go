@@ -18,6 +18,7 @@ const (
 	TypeFile       SourceType = "file"
 	TypeRepository SourceType = "repo"
 	TypeOCI        SourceType = "oci"
+	TypeSBOMRef    SourceType = "sbom-ref"
 )
 
 // VEX represents Vulnerability Exploitability eXchange. It abstracts multiple VEX formats.
@@ -49,6 +50,8 @@ func NewSource(src string) Source {
 		return Source{Type: TypeRepository}
 	case "oci":
 		return Source{Type: TypeOCI}
+	case "sbom-ref":
+		return Source{Type: TypeSBOMRef}
 	default:
 		return Source{
 			Type:     TypeFile,
@@ -111,6 +114,13 @@ func New(ctx context.Context, report *types.Report, opts Options) (*Client, erro
 			} else if v == nil {
 				continue
 			}
+		case TypeSBOMRef:
+			v, err = NewSBOMReferenceSet(report)
+			if err != nil {
+				return nil, xerrors.Errorf("VEX SBOM reference error: %w", err)
+			} else if v == nil {
+				continue
+			}
 		default:
 			log.Warn("Unsupported VEX source", log.String("type", string(src.Type)))
 			continue
@@ -43,6 +43,16 @@ var (
 			},
 		},
 	}
+	linkedSpringPackage = ftypes.Package{
+		ID:      "org.springframework.boot:spring-boot:2.6.0",
+		Name:    "org.springframework.boot:spring-boot",
+		Version: "2.6.0",
+		Identifier: ftypes.PkgIdentifier{
+			UID:    "07",
+			BOMRef: "urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1#pkg:maven/org.springframework.boot/spring-boot@2.6.0",
+			PURL:   springPackage.Identifier.PURL,
+		},
+	}
 	bashPackage = ftypes.Package{
 		ID:      "bash@5.3",
 		Name:    "bash",
@@ -123,6 +133,12 @@ var (
 		InstalledVersion: springPackage.Version,
 		PkgIdentifier:    springPackage.Identifier,
 	}
+	linkedVuln1 = types.DetectedVulnerability{
+		VulnerabilityID:  "CVE-2021-44228",
+		PkgName:          linkedSpringPackage.Name,
+		InstalledVersion: linkedSpringPackage.Version,
+		PkgIdentifier:    linkedSpringPackage.Identifier,
+	}
 	vuln2 = types.DetectedVulnerability{
 		VulnerabilityID:  "CVE-2021-0001",
 		PkgName:          springPackage.Name,
@@ -401,6 +417,65 @@ func TestFilter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "CycloneDX SBOM with CycloneDX VEX of the linked BOM",
+			args: args{
+				// - urn:uuid:c986ba94-e37d-49c8-9e30-96daccd0415b
+				//     - pkg:maven/org.springframework.boot/spring-boot@2.6.0
+				//     - urn:cdx:3e671687-395b-41f5-a30f-a58921a69b79/1 (linked)
+				//         - pkg:maven/org.springframework.boot/spring-boot@2.6.0
+				report: &types.Report{
+					ArtifactType: artifact.TypeCycloneDX,
+					BOM:          linkedBOM(),
+					Results: []types.Result{
+						springResult(types.Result{
+							Vulnerabilities: []types.DetectedVulnerability{vuln1}, // Will not be filtered because of the scope
+						}),
+						linkedSpringResult(types.Result{
+							Vulnerabilities: []types.DetectedVulnerability{linkedVuln1}, // filtered by VEX of the linked BOM
+						}),
+					},
+				},
+				opts: vex.Options{
+					Sources: []vex.Source{
+						{
+							Type: vex.TypeSBOMRef,
+						},
+					},
+				},
+			},
+			want: &types.Report{
+				ArtifactType: artifact.TypeCycloneDX,
+				BOM:          linkedBOM(),
+				Results: []types.Result{
+					springResult(types.Result{
+						Vulnerabilities: []types.DetectedVulnerability{vuln1},
+					}),
+					linkedSpringResult(types.Result{
+						Vulnerabilities:  []types.DetectedVulnerability{},
+						ModifiedFindings: []types.ModifiedFinding{modifiedFinding(linkedVuln1, codeNotReachable, "CycloneDX VEX")},
+					}),
+				},
+			},
+		},
+		{
+			name: "sbom-ref with image",
+			args: args{
+				report: imageReport([]types.Result{
+					springResult(types.Result{
+						Vulnerabilities: []types.DetectedVulnerability{vuln1},
+					}),
+				}),
+				opts: vex.Options{
+					Sources: []vex.Source{
+						{
+							Type: vex.TypeSBOMRef,
+						},
+					},
+				},
+			},
+			wantErr: "'--vex sbom-ref' can be used only when scanning CycloneDX SBOMs",
+		},
 		{
 			name: "CSAF, not affected",
 			args: args{
@@ -634,6 +709,29 @@ func springResult(result types.Result) types.Result {
 	return result
 }
 
+// linkedSpringResult wraps the result with the spring package of the linked BOM
+func linkedSpringResult(result types.Result) types.Result {
+	result.Type = ftypes.Jar
+	result.Class = types.ClassLangPkg
+	result.Packages = []ftypes.Package{linkedSpringPackage}
+	return result
+}
+
+// linkedBOM returns the BOM linking the BOM with the CycloneDX VEX
+func linkedBOM() *core.BOM {
+	return &core.BOM{
+		SerialNumber: "urn:uuid:c986ba94-e37d-49c8-9e30-96daccd0415b",
+		Version:      1,
+		Linked: []*core.BOM{
+			{
+				SerialNumber: "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+				Version:      1,
+				VEXFiles:     []string{"testdata/cyclonedx.json"},
+			},
+		},
+	}
+}
+
 // bashResult wraps the result with the bash package
 func bashResult(result types.Result) types.Result {
 	result.Type = ftypes.Debian
@@ -42,6 +42,8 @@ type Cache interface {
 	MissingBlobs(context.Context, *MissingBlobsRequest) (*MissingBlobsResponse, error)
 
 	DeleteBlobs(context.Context, *DeleteBlobsRequest) (*google_protobuf2.Empty, error)
+
+	PutBlobChunk(context.Context, *PutBlobChunkRequest) (*google_protobuf2.Empty, error)
 }
 
 // =====================
@@ -50,7 +52,7 @@ type Cache interface {
 
 type cacheProtobufClient struct {
 	client      HTTPClient
-	urls        [4]string
+	urls        [5]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -78,11 +80,12 @@ func NewCacheProtobufClient(baseURL string, client HTTPClient, opts ...twirp.Cli
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "trivy.cache.v1", "Cache")
-	urls := [4]string{
+	urls := [5]string{
 		serviceURL + "PutArtifact",
 		serviceURL + "PutBlob",
 		serviceURL + "MissingBlobs",
 		serviceURL + "DeleteBlobs",
+		serviceURL + "PutBlobChunk",
 	}
 
 	return &cacheProtobufClient{
@@ -277,13 +280,59 @@ func (c *cacheProtobufClient) callDeleteBlobs(ctx context.Context, in *DeleteBlo
 	return out, nil
 }
 
+func (c *cacheProtobufClient) PutBlobChunk(ctx context.Context, in *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.cache.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Cache")
+	ctx = ctxsetters.WithMethodName(ctx, "PutBlobChunk")
+	caller := c.callPutBlobChunk
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutBlobChunkRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutBlobChunkRequest) when calling interceptor")
+					}
+					return c.callPutBlobChunk(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf2.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf2.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *cacheProtobufClient) callPutBlobChunk(ctx context.Context, in *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+	out := new(google_protobuf2.Empty)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // =================
 // Cache JSON Client
 // =================
 
 type cacheJSONClient struct {
 	client      HTTPClient
-	urls        [4]string
+	urls        [5]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -311,11 +360,12 @@ func NewCacheJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientO
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "trivy.cache.v1", "Cache")
-	urls := [4]string{
+	urls := [5]string{
 		serviceURL + "PutArtifact",
 		serviceURL + "PutBlob",
 		serviceURL + "MissingBlobs",
 		serviceURL + "DeleteBlobs",
+		serviceURL + "PutBlobChunk",
 	}
 
 	return &cacheJSONClient{
@@ -510,6 +560,52 @@ func (c *cacheJSONClient) callDeleteBlobs(ctx context.Context, in *DeleteBlobsRe
 	return out, nil
 }
 
+func (c *cacheJSONClient) PutBlobChunk(ctx context.Context, in *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "trivy.cache.v1")
+	ctx = ctxsetters.WithServiceName(ctx, "Cache")
+	ctx = ctxsetters.WithMethodName(ctx, "PutBlobChunk")
+	caller := c.callPutBlobChunk
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutBlobChunkRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutBlobChunkRequest) when calling interceptor")
+					}
+					return c.callPutBlobChunk(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf2.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf2.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *cacheJSONClient) callPutBlobChunk(ctx context.Context, in *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+	out := new(google_protobuf2.Empty)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 // ====================
 // Cache Server Handler
 // ====================
@@ -619,6 +715,9 @@ func (s *cacheServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	case "DeleteBlobs":
 		s.serveDeleteBlobs(ctx, resp, req)
 		return
+	case "PutBlobChunk":
+		s.servePutBlobChunk(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
@@ -1346,6 +1445,186 @@ func (s *cacheServer) serveDeleteBlobsProtobuf(ctx context.Context, resp http.Re
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *cacheServer) servePutBlobChunk(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePutBlobChunkJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePutBlobChunkProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *cacheServer) servePutBlobChunkJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PutBlobChunk")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(PutBlobChunkRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.Cache.PutBlobChunk
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutBlobChunkRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutBlobChunkRequest) when calling interceptor")
+					}
+					return s.Cache.PutBlobChunk(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf2.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf2.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf2.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf2.Empty and nil error while calling PutBlobChunk. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *cacheServer) servePutBlobChunkProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PutBlobChunk")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(PutBlobChunkRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.Cache.PutBlobChunk
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PutBlobChunkRequest) (*google_protobuf2.Empty, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PutBlobChunkRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PutBlobChunkRequest) when calling interceptor")
+					}
+					return s.Cache.PutBlobChunk(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*google_protobuf2.Empty)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*google_protobuf2.Empty) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *google_protobuf2.Empty
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *google_protobuf2.Empty and nil error while calling PutBlobChunk. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *cacheServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1933,57 +2212,62 @@ func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error)
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 830 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdf, 0x8f, 0xdb, 0x44,
-	0x10, 0x56, 0x92, 0xbb, 0x4b, 0x32, 0xf9, 0x71, 0xc7, 0x52, 0x5a, 0x37, 0x54, 0x6d, 0x64, 0x40,
-	0x0a, 0x0f, 0xd8, 0xe2, 0xa0, 0x12, 0x12, 0x02, 0x71, 0xbd, 0x02, 0x8a, 0xd4, 0x8a, 0x63, 0x8b,
-	0x90, 0xe0, 0x25, 0x38, 0xeb, 0x75, 0xb2, 0x3a, 0xdb, 0xeb, 0xdb, 0x59, 0x07, 0xf2, 0xcc, 0x0b,
-	0xff, 0x13, 0xff, 0x1c, 0xda, 0xb5, 0x9d, 0xc4, 0x49, 0x7a, 0xa2, 0x2f, 0x91, 0x77, 0xe6, 0x9b,
-	0x6f, 0x67, 0xbe, 0xf9, 0xec, 0xc0, 0x23, 0x95, 0x31, 0x9f, 0x05, 0x6c, 0xc9, 0x7d, 0xe4, 0x6a,
-	0x25, 0x18, 0xf7, 0x32, 0x25, 0xb5, 0x24, 0x43, 0xad, 0xc4, 0x6a, 0xed, 0xd9, 0x94, 0xb7, 0xfa,
-	0x7c, 0xf4, 0x6c, 0x21, 0xe5, 0x22, 0xe6, 0xbe, 0xcd, 0xce, 0xf3, 0xc8, 0xd7, 0x22, 0xe1, 0xa8,
-	0x83, 0x24, 0x2b, 0x0a, 0x46, 0x8e, 0x65, 0x92, 0x49, 0x22, 0xd3, 0x3a, 0xd5, 0xe8, 0xc3, 0xfd,
-	0x52, 0x9e, 0x64, 0x7a, 0x5d, 0x24, 0xdd, 0x7f, 0x9a, 0xd0, 0xbf, 0x52, 0x5a, 0x44, 0x01, 0xd3,
-	0xd3, 0x34, 0x92, 0xe4, 0x13, 0x18, 0x22, 0x5b, 0xf2, 0x24, 0x98, 0xad, 0xb8, 0x42, 0x21, 0x53,
-	0xa7, 0x31, 0x6e, 0x4c, 0x4e, 0xe9, 0xa0, 0x88, 0xfe, 0x5a, 0x04, 0x89, 0x0b, 0xfd, 0x40, 0xb1,
-	0xa5, 0xd0, 0x9c, 0xe9, 0x5c, 0x71, 0xa7, 0x39, 0x6e, 0x4c, 0xba, 0xb4, 0x16, 0x23, 0x5f, 0x42,
-	0x9b, 0x29, 0x1e, 0x68, 0x1e, 0x3a, 0xad, 0x71, 0x63, 0xd2, 0xbb, 0x1c, 0x79, 0x45, 0x2b, 0x5e,
-	0xd5, 0x8a, 0xf7, 0x4b, 0x35, 0x05, 0xad, 0xa0, 0xa6, 0x81, 0x50, 0xb2, 0x5b, 0xae, 0x36, 0x0d,
-	0x9c, 0x58, 0xee, 0x41, 0x11, 0xad, 0x1a, 0x18, 0x42, 0x53, 0xa2, 0x73, 0x6a, 0x53, 0x4d, 0x89,
-	0xe4, 0x3b, 0xb8, 0x58, 0x0a, 0xd4, 0x52, 0xad, 0x67, 0x59, 0xc0, 0x6e, 0x83, 0x05, 0x47, 0xe7,
-	0x6c, 0xdc, 0x9a, 0xf4, 0x2e, 0x3f, 0xf0, 0x4a, 0x2d, 0xad, 0x38, 0xde, 0x4d, 0x91, 0xa5, 0xe7,
-	0x25, 0xbc, 0x3c, 0xa3, 0xfb, 0x17, 0x90, 0x9b, 0x5c, 0x57, 0x62, 0x50, 0x7e, 0x97, 0x73, 0xd4,
-	0xe4, 0x19, 0xf4, 0x82, 0x32, 0x34, 0x13, 0xa1, 0x15, 0xa3, 0x4b, 0xa1, 0x0a, 0x4d, 0x43, 0x72,
-	0x05, 0x83, 0x2d, 0x20, 0x8d, 0xa4, 0x95, 0xa2, 0x77, 0xf9, 0xc4, 0xab, 0x6f, 0xd0, 0xdb, 0x55,
-	0xd9, 0x08, 0xb5, 0x3d, 0xb9, 0x7f, 0x9f, 0x42, 0xe7, 0x45, 0x2c, 0xe7, 0xef, 0xb2, 0x80, 0xb1,
-	0x9d, 0xbf, 0xb8, 0xeb, 0xa2, 0x3e, 0xe1, 0x4f, 0x6f, 0xac, 0x22, 0x5f, 0x01, 0x28, 0x9e, 0x49,
-	0x14, 0x66, 0x4a, 0xa7, 0x67, 0x91, 0x4e, 0x1d, 0x49, 0x37, 0x79, 0xba, 0x83, 0x25, 0xdf, 0xc2,
-	0xa0, 0xd4, 0xd0, 0x4e, 0x84, 0x4e, 0xcb, 0x0a, 0xf9, 0xf8, 0xa8, 0x90, 0xc5, 0x3c, 0xd9, 0xf6,
-	0x80, 0xe4, 0x1b, 0xe8, 0x07, 0x59, 0x16, 0x0b, 0x16, 0x68, 0x21, 0x53, 0x74, 0x4e, 0x8e, 0x95,
-	0x5f, 0x6d, 0x11, 0xb4, 0x06, 0x27, 0xaf, 0xe0, 0xbd, 0x44, 0x20, 0x93, 0x69, 0x24, 0x16, 0xb9,
-	0x2a, 0x39, 0xba, 0x96, 0xe3, 0x69, 0x9d, 0xe3, 0xf5, 0x1e, 0x8c, 0x1e, 0x16, 0x9a, 0x05, 0xca,
-	0x2c, 0xb8, 0xcb, 0xf9, 0x2c, 0x14, 0xca, 0x38, 0xa6, 0x65, 0x16, 0x58, 0x84, 0x5e, 0x0a, 0x85,
-	0x46, 0xf0, 0x3f, 0x8d, 0x69, 0x65, 0xae, 0x67, 0x91, 0x88, 0x4b, 0xdf, 0x74, 0xe9, 0xa0, 0x8a,
-	0xfe, 0x60, 0x82, 0xe4, 0x21, 0x9c, 0x85, 0x62, 0xc1, 0x51, 0x3b, 0x6d, 0xeb, 0x81, 0xf2, 0x44,
-	0x1e, 0x41, 0x3b, 0x14, 0x51, 0x64, 0xcc, 0xd1, 0xa9, 0x12, 0x51, 0x34, 0x0d, 0xc9, 0x8f, 0x70,
-	0xc1, 0x72, 0xd4, 0x32, 0x99, 0x29, 0x8e, 0x32, 0x57, 0x8c, 0xa3, 0x03, 0x76, 0x8a, 0x27, 0xf5,
-	0x29, 0xae, 0x2d, 0x8a, 0x96, 0x20, 0x7a, 0xce, 0x6a, 0x67, 0x24, 0x1e, 0xb4, 0x91, 0x33, 0xc5,
-	0x35, 0x3a, 0x7d, 0x5b, 0xff, 0xa0, 0x5e, 0xff, 0xc6, 0x26, 0x69, 0x05, 0x22, 0xcf, 0xa1, 0x13,
-	0x0b, 0xc6, 0x53, 0xe4, 0xe8, 0x0c, 0x8e, 0x49, 0xff, 0xaa, 0xc8, 0x9a, 0xb9, 0xe8, 0x06, 0xea,
-	0xfe, 0x01, 0xc3, 0x9b, 0x5c, 0x1b, 0x1f, 0x56, 0xde, 0xdf, 0x19, 0xad, 0x51, 0x1b, 0xed, 0x39,
-	0x74, 0xe7, 0xb1, 0x9c, 0x17, 0x7e, 0x6f, 0xd5, 0x9d, 0x55, 0xf9, 0xbd, 0x32, 0x34, 0xed, 0xcc,
-	0xcb, 0x27, 0xf7, 0x1a, 0x7a, 0x37, 0xb9, 0xa6, 0x1c, 0x33, 0x99, 0x22, 0x2f, 0x2d, 0xdc, 0xb8,
-	0xc7, 0xc2, 0x04, 0x4e, 0xb8, 0xc4, 0xd8, 0xda, 0xbc, 0x43, 0xed, 0xb3, 0xfb, 0x33, 0xbc, 0xff,
-	0x5a, 0x20, 0x8a, 0x74, 0x61, 0x6e, 0xc0, 0xff, 0xfd, 0x9e, 0x3e, 0x86, 0x4e, 0xd1, 0x73, 0x68,
-	0x5e, 0x1b, 0xb3, 0xe0, 0xb6, 0x6d, 0x2c, 0x44, 0xf7, 0x16, 0x1e, 0xd4, 0x29, 0xcb, 0x06, 0x3f,
-	0x85, 0x8b, 0xa4, 0x88, 0xcf, 0x2a, 0x22, 0x4b, 0xdc, 0xa1, 0xe7, 0x65, 0xbc, 0x7a, 0xa9, 0xc9,
-	0x64, 0x0b, 0xdd, 0xbb, 0x65, 0x98, 0x6c, 0xa9, 0xcd, 0x65, 0x3e, 0x90, 0x97, 0x3c, 0xe6, 0x9a,
-	0xd7, 0xda, 0xdf, 0xed, 0xae, 0x51, 0xeb, 0xee, 0xf2, 0xdf, 0x26, 0x9c, 0x5e, 0x1b, 0x55, 0xc9,
-	0xd4, 0xea, 0xb7, 0xb9, 0xd3, 0xdd, 0x97, 0xfc, 0xf0, 0xf3, 0x35, 0x7a, 0x78, 0xf0, 0xc9, 0xfd,
-	0xde, 0x7c, 0xfd, 0xc9, 0x15, 0xb4, 0xcb, 0x65, 0x93, 0xa7, 0x47, 0x68, 0x76, 0x5c, 0xf0, 0x56,
-	0x8a, 0xdf, 0xa0, 0xbf, 0xab, 0x1a, 0xf9, 0x68, 0x9f, 0xe7, 0xc8, 0x9a, 0x46, 0x1f, 0xdf, 0x0f,
-	0x2a, 0x85, 0x9f, 0x42, 0x6f, 0x47, 0xa3, 0xc3, 0x41, 0x0f, 0x05, 0x7c, 0x5b, 0x97, 0x2f, 0xfc,
-	0xdf, 0x3f, 0x5b, 0x08, 0xbd, 0xcc, 0xe7, 0xc6, 0x5a, 0x7e, 0x70, 0x97, 0x07, 0xc8, 0x59, 0xae,
-	0x84, 0x5e, 0xfb, 0x96, 0xd4, 0xdf, 0xfc, 0x03, 0x7f, 0x6d, 0x7f, 0xe7, 0x67, 0x96, 0xe0, 0x8b,
-	0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x82, 0x05, 0x5f, 0x23, 0x9b, 0x07, 0x00, 0x00,
+	// 902 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdf, 0x6f, 0x23, 0x35,
+	0x10, 0x56, 0x9a, 0xa6, 0x49, 0x26, 0x3f, 0x5a, 0x7c, 0xe5, 0x6e, 0x2f, 0x87, 0xee, 0xa2, 0x00,
+	0x52, 0x78, 0x60, 0x57, 0x14, 0x4e, 0x42, 0x42, 0x20, 0x7a, 0x3d, 0x40, 0x11, 0x77, 0xa2, 0xf8,
+	0x10, 0x12, 0xbc, 0x04, 0xc7, 0xeb, 0x4d, 0xac, 0xee, 0xae, 0xb7, 0xb6, 0xb7, 0x5c, 0x9f, 0x79,
+	0x39, 0x89, 0x7f, 0x1a, 0xd9, 0xde, 0x4d, 0x76, 0x93, 0xb4, 0xc0, 0x4b, 0xb5, 0x9e, 0x19, 0x7f,
+	0x9e, 0xf9, 0xbe, 0xcf, 0x4e, 0xe1, 0x91, 0xcc, 0x68, 0x40, 0x09, 0x5d, 0xb1, 0x40, 0x31, 0x79,
+	0xc3, 0x29, 0xf3, 0x33, 0x29, 0xb4, 0x40, 0x43, 0x2d, 0xf9, 0xcd, 0xad, 0x6f, 0x53, 0xfe, 0xcd,
+	0x67, 0xa3, 0x67, 0x4b, 0x21, 0x96, 0x31, 0x0b, 0x6c, 0x76, 0x91, 0x47, 0x81, 0xe6, 0x09, 0x53,
+	0x9a, 0x24, 0x99, 0xdb, 0x30, 0xf2, 0x2c, 0x92, 0x48, 0x12, 0x91, 0xd6, 0xa1, 0x46, 0x4f, 0xb6,
+	0xb7, 0xb2, 0x24, 0xd3, 0xb7, 0x2e, 0x39, 0x79, 0x77, 0x00, 0xfd, 0x73, 0xa9, 0x79, 0x44, 0xa8,
+	0x9e, 0xa5, 0x91, 0x40, 0x1f, 0xc3, 0x50, 0xd1, 0x15, 0x4b, 0xc8, 0xfc, 0x86, 0x49, 0xc5, 0x45,
+	0xea, 0x35, 0xc6, 0x8d, 0x69, 0x0b, 0x0f, 0x5c, 0xf4, 0x57, 0x17, 0x44, 0x13, 0xe8, 0x13, 0x49,
+	0x57, 0x5c, 0x33, 0xaa, 0x73, 0xc9, 0xbc, 0x83, 0x71, 0x63, 0xda, 0xc5, 0xb5, 0x18, 0xfa, 0x02,
+	0xda, 0x54, 0x32, 0xa2, 0x59, 0xe8, 0x35, 0xc7, 0x8d, 0x69, 0xef, 0x6c, 0xe4, 0xbb, 0x56, 0xfc,
+	0xb2, 0x15, 0xff, 0x97, 0x72, 0x0a, 0x5c, 0x96, 0x9a, 0x06, 0x42, 0x41, 0xaf, 0x98, 0x5c, 0x37,
+	0x70, 0x68, 0xb1, 0x07, 0x2e, 0x5a, 0x36, 0x30, 0x84, 0x03, 0xa1, 0xbc, 0x96, 0x4d, 0x1d, 0x08,
+	0x85, 0xbe, 0x85, 0x93, 0x15, 0x57, 0x5a, 0xc8, 0xdb, 0x79, 0x46, 0xe8, 0x15, 0x59, 0x32, 0xe5,
+	0x1d, 0x8d, 0x9b, 0xd3, 0xde, 0xd9, 0xfb, 0x7e, 0xc1, 0xa5, 0x25, 0xc7, 0xbf, 0x74, 0x59, 0x7c,
+	0x5c, 0x94, 0x17, 0x6b, 0x35, 0x79, 0x0b, 0xe8, 0x32, 0xd7, 0x25, 0x19, 0x98, 0x5d, 0xe7, 0x4c,
+	0x69, 0xf4, 0x0c, 0x7a, 0xa4, 0x08, 0xcd, 0x79, 0x68, 0xc9, 0xe8, 0x62, 0x28, 0x43, 0xb3, 0x10,
+	0x9d, 0xc3, 0x60, 0x53, 0x90, 0x46, 0xc2, 0x52, 0xd1, 0x3b, 0xfb, 0xc0, 0xaf, 0x2b, 0xe8, 0x57,
+	0x59, 0x36, 0x44, 0x6d, 0x56, 0x93, 0xbf, 0x5a, 0xd0, 0x79, 0x11, 0x8b, 0xc5, 0xff, 0x11, 0x60,
+	0x6c, 0xe7, 0x77, 0x67, 0x9d, 0xd4, 0x27, 0xfc, 0xe9, 0x8d, 0x65, 0xe4, 0x4b, 0x00, 0xc9, 0x32,
+	0xa1, 0xb8, 0x99, 0xd2, 0xeb, 0xd9, 0x4a, 0xaf, 0x5e, 0x89, 0xd7, 0x79, 0x5c, 0xa9, 0x45, 0xdf,
+	0xc0, 0xa0, 0xe0, 0xd0, 0x4e, 0xa4, 0xbc, 0xa6, 0x25, 0xf2, 0xf1, 0x5e, 0x22, 0xdd, 0x3c, 0xd9,
+	0x66, 0xa1, 0xd0, 0xd7, 0xd0, 0x27, 0x59, 0x16, 0x73, 0x4a, 0x34, 0x17, 0xa9, 0xf2, 0x0e, 0xf7,
+	0x6d, 0x3f, 0xdf, 0x54, 0xe0, 0x5a, 0x39, 0x7a, 0x05, 0xef, 0x25, 0x5c, 0x51, 0x91, 0x46, 0x7c,
+	0x99, 0xcb, 0x02, 0xa3, 0x6b, 0x31, 0x9e, 0xd6, 0x31, 0x5e, 0x6f, 0x95, 0xe1, 0xdd, 0x8d, 0x46,
+	0x40, 0x91, 0x91, 0xeb, 0x9c, 0xcd, 0x43, 0x2e, 0x8d, 0x63, 0x9a, 0x46, 0x40, 0x17, 0x7a, 0xc9,
+	0xa5, 0x32, 0x84, 0xff, 0x69, 0x4c, 0x2b, 0x72, 0x3d, 0x8f, 0x78, 0x5c, 0xf8, 0xa6, 0x8b, 0x07,
+	0x65, 0xf4, 0x7b, 0x13, 0x44, 0x0f, 0xe1, 0x28, 0xe4, 0x4b, 0xa6, 0xb4, 0xd7, 0xb6, 0x1e, 0x28,
+	0x56, 0xe8, 0x11, 0xb4, 0x43, 0x1e, 0x45, 0xc6, 0x1c, 0x9d, 0x32, 0x11, 0x45, 0xb3, 0x10, 0xfd,
+	0x00, 0x27, 0x34, 0x57, 0x5a, 0x24, 0x73, 0xc9, 0x94, 0xc8, 0x25, 0x65, 0xca, 0x83, 0x71, 0xb3,
+	0xea, 0x0d, 0x37, 0xc5, 0x85, 0xad, 0xc2, 0x45, 0x11, 0x3e, 0xa6, 0xb5, 0xb5, 0x42, 0x3e, 0xb4,
+	0x15, 0xa3, 0x92, 0x69, 0xe5, 0xf5, 0xed, 0xfe, 0xd3, 0xfa, 0xfe, 0x37, 0x36, 0x89, 0xcb, 0x22,
+	0xf4, 0x1c, 0x3a, 0x31, 0xa7, 0x2c, 0x55, 0x4c, 0x79, 0x83, 0x7d, 0xd4, 0xbf, 0x72, 0x59, 0x33,
+	0x17, 0x5e, 0x97, 0x4e, 0xfe, 0x80, 0xe1, 0x65, 0xae, 0x8d, 0x0f, 0x4b, 0xef, 0x57, 0x46, 0x6b,
+	0xd4, 0x46, 0x7b, 0x0e, 0xdd, 0x45, 0x2c, 0x16, 0xce, 0xef, 0xcd, 0xba, 0xb3, 0x4a, 0xbf, 0x97,
+	0x86, 0xc6, 0x9d, 0x45, 0xf1, 0x35, 0xb9, 0x80, 0xde, 0x65, 0xae, 0x31, 0x53, 0x99, 0x48, 0x15,
+	0x2b, 0x2c, 0xdc, 0xb8, 0xc7, 0xc2, 0x08, 0x0e, 0x99, 0x50, 0xb1, 0xb5, 0x79, 0x07, 0xdb, 0xef,
+	0xc9, 0xcf, 0xf0, 0xe0, 0x35, 0x57, 0x8a, 0xa7, 0x4b, 0x73, 0x82, 0xfa, 0xcf, 0xf7, 0xf4, 0x31,
+	0x74, 0x5c, 0xcf, 0xa1, 0xb9, 0x36, 0x46, 0xe0, 0xb6, 0x6d, 0x2c, 0x54, 0x93, 0x2b, 0x38, 0xad,
+	0x43, 0x16, 0x0d, 0x7e, 0x02, 0x27, 0x89, 0x8b, 0xcf, 0x4b, 0x20, 0x0b, 0xdc, 0xc1, 0xc7, 0x45,
+	0xbc, 0xbc, 0xd4, 0x68, 0xba, 0x29, 0xdd, 0x3a, 0x65, 0x98, 0x6c, 0xa0, 0xcd, 0x61, 0x01, 0xa0,
+	0x97, 0x2c, 0x66, 0x9a, 0xd5, 0xda, 0xaf, 0x76, 0xd7, 0xa8, 0x77, 0xf7, 0x77, 0x03, 0x1e, 0x14,
+	0xc2, 0x5c, 0xac, 0xf2, 0xf4, 0xea, 0x5f, 0xd5, 0x79, 0x02, 0xdd, 0x3c, 0x8b, 0x05, 0x09, 0x4d,
+	0xca, 0x3d, 0xcc, 0x1d, 0x17, 0x98, 0x85, 0xe8, 0x14, 0x5a, 0x3c, 0x0d, 0xd9, 0x5b, 0x2b, 0x5b,
+	0x0b, 0xbb, 0x85, 0x89, 0x6a, 0xa1, 0x49, 0x6c, 0xdf, 0xda, 0x16, 0x76, 0x0b, 0x43, 0x7f, 0x48,
+	0x34, 0xb1, 0xaf, 0x6c, 0x1f, 0xdb, 0xef, 0xb3, 0x77, 0x4d, 0x68, 0x5d, 0x18, 0x8d, 0xd1, 0xcc,
+	0xaa, 0xb9, 0x66, 0x60, 0xb2, 0x6d, 0x80, 0xdd, 0xc7, 0x74, 0xf4, 0x70, 0xe7, 0x07, 0xe0, 0x3b,
+	0xf3, 0x5b, 0x84, 0xce, 0xa1, 0x5d, 0x4c, 0x88, 0x9e, 0xee, 0x81, 0xa9, 0x78, 0xf2, 0x4e, 0x88,
+	0xdf, 0xa0, 0x5f, 0xd5, 0x10, 0x7d, 0xb8, 0x8d, 0xb3, 0xc7, 0x34, 0xa3, 0x8f, 0xee, 0x2f, 0x2a,
+	0x6c, 0x30, 0x83, 0x5e, 0x45, 0xb1, 0xdd, 0x41, 0x77, 0xe5, 0xbc, 0xb3, 0xcb, 0x1f, 0xa1, 0x5f,
+	0x95, 0x72, 0xb7, 0xcb, 0x3d, 0x42, 0xdf, 0x05, 0xf6, 0x22, 0xf8, 0xfd, 0xd3, 0x25, 0xd7, 0xab,
+	0x7c, 0x61, 0x6e, 0x4d, 0x40, 0xae, 0x73, 0xa2, 0x18, 0xcd, 0x25, 0xd7, 0xb7, 0x81, 0x45, 0x0d,
+	0xd6, 0xff, 0x5c, 0x7c, 0x65, 0xff, 0x2e, 0x8e, 0x2c, 0xc0, 0xe7, 0xff, 0x0c, 0x00, 0xbb, 0x56,
+	0x8d, 0xd2, 0x76, 0x08, 0x00, 0x00,
 }